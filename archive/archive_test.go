@@ -0,0 +1,324 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/types"
+	"github.com/transparency-dev/merkle/compact"
+	"github.com/transparency-dev/merkle/rfc6962"
+	inmemory "github.com/transparency-dev/merkle/testonly"
+	"google.golang.org/protobuf/proto"
+)
+
+func testLeaf(i int) *trillian.LogLeaf {
+	value := []byte(fmt.Sprintf("leaf %d", i))
+	id := sha256.Sum256(value)
+	return &trillian.LogLeaf{
+		LeafIndex:        int64(i),
+		LeafValue:        value,
+		ExtraData:        []byte(fmt.Sprintf("extra %d", i)),
+		LeafIdentityHash: id[:],
+		MerkleLeafHash:   rfc6962.DefaultHasher.HashLeaf(value),
+	}
+}
+
+func logRoot(t *testing.T, tree *inmemory.Tree, size uint64) *trillian.SignedLogRoot {
+	t.Helper()
+	root, err := (&types.LogRootV1{TreeSize: size, RootHash: tree.HashAt(size), TimestampNanos: size}).MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary(): %v", err)
+	}
+	return &trillian.SignedLogRoot{LogRoot: root}
+}
+
+// writeBundle writes a bundle of numLeaves leaves with roots at rootSizes,
+// and returns its directory.
+func writeBundle(t *testing.T, numLeaves int, chunkSize uint64, rootSizes ...uint64) string {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "bundle")
+	w, err := NewWriter(dir, 42, chunkSize)
+	if err != nil {
+		t.Fatalf("NewWriter(): %v", err)
+	}
+	tree := inmemory.New(rfc6962.DefaultHasher)
+	for i := 0; i < numLeaves; i++ {
+		leaf := testLeaf(i)
+		tree.AppendData(leaf.LeafValue)
+		if err := w.AppendLeaves(leaf); err != nil {
+			t.Fatalf("AppendLeaves(%d): %v", i, err)
+		}
+	}
+	for _, size := range rootSizes {
+		if err := w.AddRoot(logRoot(t, tree, size)); err != nil {
+			t.Fatalf("AddRoot(%d): %v", size, err)
+		}
+	}
+	m, err := w.Close()
+	if err != nil {
+		t.Fatalf("Close(): %v", err)
+	}
+	if got, want := m.RootHash, hex.EncodeToString(tree.Hash()); got != want {
+		t.Errorf("Manifest.RootHash=%s, want %s", got, want)
+	}
+	return dir
+}
+
+func TestWriteAndVerify(t *testing.T) {
+	for _, test := range []struct {
+		desc      string
+		numLeaves int
+		chunkSize uint64
+		rootSizes []uint64
+	}{
+		{desc: "empty", numLeaves: 0, chunkSize: 4, rootSizes: []uint64{0}},
+		{desc: "one-leaf", numLeaves: 1, chunkSize: 4, rootSizes: []uint64{1}},
+		{desc: "full-chunks", numLeaves: 12, chunkSize: 4, rootSizes: []uint64{4, 12}},
+		{desc: "partial-chunk", numLeaves: 13, chunkSize: 5, rootSizes: []uint64{0, 3, 7, 13}},
+		{desc: "no-roots", numLeaves: 9, chunkSize: 100},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			dir := writeBundle(t, test.numLeaves, test.chunkSize, test.rootSizes...)
+			m, err := Verify(dir)
+			if err != nil {
+				t.Fatalf("Verify(): %v", err)
+			}
+			if got, want := m.TreeSize, uint64(test.numLeaves); got != want {
+				t.Errorf("TreeSize=%d, want %d", got, want)
+			}
+
+			r, err := NewReader(dir)
+			if err != nil {
+				t.Fatalf("NewReader(): %v", err)
+			}
+			var got []*trillian.LogLeaf
+			for _, c := range r.Manifest().ChunksByKind(LeafChunk) {
+				leaves, err := r.Leaves(c)
+				if err != nil {
+					t.Fatalf("Leaves(%s): %v", c.Name, err)
+				}
+				got = append(got, leaves...)
+			}
+			if len(got) != test.numLeaves {
+				t.Fatalf("read %d leaves, want %d", len(got), test.numLeaves)
+			}
+			for i, l := range got {
+				if want := testLeaf(i); !proto.Equal(l, want) {
+					t.Errorf("leaf %d: %v, want %v", i, l, want)
+				}
+			}
+		})
+	}
+}
+
+func TestWriterErrors(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "bundle")
+	if _, err := NewWriter(dir, 1, 0); err == nil {
+		t.Error("NewWriter() with zero chunk size succeeded")
+	}
+	w, err := NewWriter(dir, 1, 4)
+	if err != nil {
+		t.Fatalf("NewWriter(): %v", err)
+	}
+	if _, err := NewWriter(dir, 1, 4); err == nil {
+		t.Error("NewWriter() on existing directory succeeded")
+	}
+	if err := w.AppendLeaves(testLeaf(1)); err == nil {
+		t.Error("AppendLeaves() with gap succeeded")
+	}
+	bad := testLeaf(0)
+	bad.MerkleLeafHash = []byte("bad")
+	if err := w.AppendLeaves(bad); err == nil {
+		t.Error("AppendLeaves() with bad MerkleLeafHash succeeded")
+	}
+	if err := w.AppendLeaves(testLeaf(0)); err != nil {
+		t.Fatalf("AppendLeaves(): %v", err)
+	}
+	tree := inmemory.New(rfc6962.DefaultHasher)
+	tree.AppendData(testLeaf(0).LeafValue, testLeaf(1).LeafValue)
+	if err := w.AddRoot(logRoot(t, tree, 2)); err != nil {
+		t.Fatalf("AddRoot(): %v", err)
+	}
+	if _, err := w.Close(); err == nil || !strings.Contains(err.Error(), "beyond") {
+		t.Errorf("Close() with root beyond the leaves: %v, want error", err)
+	}
+}
+
+func TestVerifyDetectsTampering(t *testing.T) {
+	rewriteManifest := func(t *testing.T, dir string, f func(*Manifest)) {
+		t.Helper()
+		path := filepath.Join(dir, ManifestName)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var m Manifest
+		if err := json.Unmarshal(data, &m); err != nil {
+			t.Fatal(err)
+		}
+		f(&m)
+		if data, err = json.Marshal(&m); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// rewriteChunk replaces the contents of a chunk, and updates its size and
+	// hash in the manifest, so that only the tree hashes can catch it.
+	rewriteChunk := func(t *testing.T, dir, name string, f func([]byte) []byte) {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data = f(data)
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		rewriteManifest(t, dir, func(m *Manifest) {
+			for i, c := range m.Chunks {
+				if c.Name == name {
+					sum := sha256.Sum256(data)
+					m.Chunks[i].Size, m.Chunks[i].SHA256 = int64(len(data)), hex.EncodeToString(sum[:])
+				}
+			}
+		})
+	}
+	flipLastByte := func(data []byte) []byte {
+		data[len(data)-1] ^= 1
+		return data
+	}
+
+	for _, test := range []struct {
+		desc    string
+		tamper  func(t *testing.T, dir string)
+		wantErr string
+	}{
+		{
+			desc: "chunk-hash",
+			tamper: func(t *testing.T, dir string) {
+				path := filepath.Join(dir, "leaves-00000000000000000004")
+				data, err := os.ReadFile(path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, flipLastByte(data), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantErr: "SHA-256",
+		},
+		{
+			desc: "missing-chunk",
+			tamper: func(t *testing.T, dir string) {
+				if err := os.Remove(filepath.Join(dir, "leaves-00000000000000000004")); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantErr: "no such file",
+		},
+		{
+			desc: "leaf-value",
+			tamper: func(t *testing.T, dir string) {
+				rewriteChunk(t, dir, "leaves-00000000000000000004", func(data []byte) []byte {
+					// The first field is the value of leaf 4, "leaf 4".
+					data[9] = '5'
+					return data
+				})
+			},
+			wantErr: "don't match subtree chunk",
+		},
+		{
+			desc: "subtree-and-leaf",
+			tamper: func(t *testing.T, dir string) {
+				rewriteChunk(t, dir, "leaves-00000000000000000004", func(data []byte) []byte {
+					data[9] = '5'
+					return data
+				})
+				// Recompute the subtree chunk for the tampered leaves.
+				rng := (&compact.RangeFactory{Hash: rfc6962.DefaultHasher.HashChildren}).NewEmptyRange(4)
+				for _, v := range []string{"leaf 5", "leaf 5", "leaf 6", "leaf 7"} {
+					if err := rng.Append(rfc6962.DefaultHasher.HashLeaf([]byte(v)), nil); err != nil {
+						t.Fatal(err)
+					}
+				}
+				rewriteChunk(t, dir, "subtrees-00000000000000000004", func([]byte) []byte {
+					return appendRecord(nil, rng.Hashes()...)
+				})
+			},
+			wantErr: "root of size 6",
+		},
+		{
+			desc: "dropped-chunk",
+			tamper: func(t *testing.T, dir string) {
+				rewriteManifest(t, dir, func(m *Manifest) {
+					var chunks []Chunk
+					for _, c := range m.Chunks {
+						if c.First != 4 {
+							chunks = append(chunks, c)
+						}
+					}
+					m.Chunks = chunks
+				})
+			},
+			wantErr: "starts at leaf 8, want 4",
+		},
+		{
+			desc: "root-hash",
+			tamper: func(t *testing.T, dir string) {
+				rewriteManifest(t, dir, func(m *Manifest) { m.RootHash = strings.Repeat("00", 32) })
+			},
+			wantErr: "manifest says",
+		},
+		{
+			desc: "archived-root",
+			tamper: func(t *testing.T, dir string) {
+				rewriteChunk(t, dir, "roots", func(data []byte) []byte {
+					// The root hash of the first root follows its length,
+					// version, tree size and hash length.
+					data[4+2+8+1] ^= 1
+					return data
+				})
+			},
+			wantErr: "root of size 6",
+		},
+		{
+			desc: "format-version",
+			tamper: func(t *testing.T, dir string) {
+				rewriteManifest(t, dir, func(m *Manifest) { m.FormatVersion = 2 })
+			},
+			wantErr: "unsupported format version",
+		},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			dir := writeBundle(t, 10, 4, 6, 10)
+			test.tamper(t, dir)
+			if _, err := Verify(dir); err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("Verify(): %v, want error containing %q", err, test.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package archive defines a bundle format for the long-term archival of
+// frozen logs, and provides a writer, a reader and an offline verifier for
+// it.
+//
+// A bundle is a directory holding a manifest and a set of chunk files:
+//   - leaf chunks hold the values of consecutive leaves of the log;
+//   - subtree chunks hold the hashes of the compact range covering the leaves
+//     of the leaf chunk with the same First and Count, so that every leaf
+//     chunk can be checked on its own;
+//   - the root chunk holds the log roots archived with the log.
+//
+// The manifest is a JSON file which records the tree size and root hash of
+// the archived log, and the size and SHA-256 hash of every chunk. Chunk files
+// are sequences of records, and every record is a sequence of fields, each
+// encoded as a 4-byte big-endian length followed by that many bytes. The
+// format deliberately only depends on JSON, SHA-256 and the hashing strategy
+// of the tree, so that it can be read without Trillian.
+package archive
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	// FormatVersion is the version of the bundle format written by this
+	// package.
+	FormatVersion = 1
+	// ManifestName is the name of the manifest file within a bundle.
+	ManifestName = "MANIFEST.json"
+)
+
+// ChunkKind identifies the contents of a chunk.
+type ChunkKind string
+
+const (
+	// LeafChunk holds one record per leaf, with the fields LeafValue,
+	// ExtraData and LeafIdentityHash.
+	LeafChunk ChunkKind = "leaves"
+	// SubtreeChunk holds one record per compact range node hash.
+	SubtreeChunk ChunkKind = "subtrees"
+	// RootChunk holds one record per log root, with the TLS-encoded root as
+	// found in trillian.SignedLogRoot.LogRoot.
+	RootChunk ChunkKind = "roots"
+)
+
+// Chunk describes a chunk file of a bundle.
+type Chunk struct {
+	// Name is the file name of the chunk, relative to the bundle directory.
+	Name string    `json:"name"`
+	Kind ChunkKind `json:"kind"`
+	// First is the index of the first leaf covered by a leaf or subtree
+	// chunk, and zero for the root chunk.
+	First uint64 `json:"first"`
+	// Count is the number of leaves covered by a leaf or subtree chunk, or
+	// the number of roots in the root chunk.
+	Count uint64 `json:"count"`
+	// Size is the size of the chunk file in bytes.
+	Size int64 `json:"size"`
+	// SHA256 is the hex-encoded SHA-256 hash of the chunk file.
+	SHA256 string `json:"sha256"`
+}
+
+// Manifest describes a bundle.
+type Manifest struct {
+	FormatVersion int   `json:"format_version"`
+	LogID         int64 `json:"log_id"`
+	// HashStrategy is the name of the trillian.HashStrategy of the tree.
+	HashStrategy string `json:"hash_strategy"`
+	// TreeSize is the number of archived leaves.
+	TreeSize uint64 `json:"tree_size"`
+	// RootHash is the hex-encoded root hash of the archived leaves.
+	RootHash string  `json:"root_hash"`
+	Chunks   []Chunk `json:"chunks"`
+}
+
+// ChunksByKind returns the chunks of the given kind, in manifest order.
+func (m *Manifest) ChunksByKind(kind ChunkKind) []Chunk {
+	var ret []Chunk
+	for _, c := range m.Chunks {
+		if c.Kind == kind {
+			ret = append(ret, c)
+		}
+	}
+	return ret
+}
+
+func parseManifest(data []byte) (*Manifest, error) {
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %v", err)
+	}
+	if m.FormatVersion != FormatVersion {
+		return nil, fmt.Errorf("unsupported format version %d, want %d", m.FormatVersion, FormatVersion)
+	}
+	return &m, nil
+}
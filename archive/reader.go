@@ -0,0 +1,152 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/types"
+	"github.com/transparency-dev/merkle"
+	"github.com/transparency-dev/merkle/rfc6962"
+)
+
+// Reader reads the chunks of a bundle. Every chunk is checked against the
+// size and hash recorded in the manifest before it's decoded.
+type Reader struct {
+	fsys   fs.FS
+	m      *Manifest
+	hasher merkle.LogHasher
+}
+
+// NewReader reads the manifest of the bundle in dir.
+func NewReader(dir string) (*Reader, error) {
+	return NewReaderFS(os.DirFS(dir))
+}
+
+// NewReaderFS reads the manifest of the bundle at the root of fsys, which
+// allows reading bundles from object storage or other file systems.
+func NewReaderFS(fsys fs.FS) (*Reader, error) {
+	data, err := fs.ReadFile(fsys, ManifestName)
+	if err != nil {
+		return nil, err
+	}
+	m, err := parseManifest(data)
+	if err != nil {
+		return nil, err
+	}
+	if m.HashStrategy != trillian.HashStrategy_RFC6962_SHA256.String() {
+		return nil, fmt.Errorf("unsupported hash strategy %q", m.HashStrategy)
+	}
+	return &Reader{fsys: fsys, m: m, hasher: rfc6962.DefaultHasher}, nil
+}
+
+// Manifest returns the manifest of the bundle.
+func (r *Reader) Manifest() *Manifest {
+	return r.m
+}
+
+// ReadChunk returns the contents of the chunk, or an error if they don't
+// match its size and hash.
+func (r *Reader) ReadChunk(c Chunk) ([]byte, error) {
+	if !fs.ValidPath(c.Name) || path.Base(c.Name) != c.Name {
+		return nil, fmt.Errorf("chunk name %q is not a plain file name", c.Name)
+	}
+	data, err := fs.ReadFile(r.fsys, c.Name)
+	if err != nil {
+		return nil, err
+	}
+	if got, want := int64(len(data)), c.Size; got != want {
+		return nil, fmt.Errorf("chunk %s has %d bytes, manifest says %d", c.Name, got, want)
+	}
+	sum := sha256.Sum256(data)
+	if got, want := hex.EncodeToString(sum[:]), c.SHA256; got != want {
+		return nil, fmt.Errorf("chunk %s has SHA-256 %s, manifest says %s", c.Name, got, want)
+	}
+	return data, nil
+}
+
+// Leaves returns the leaves of a leaf chunk, with their LeafIndex and
+// MerkleLeafHash filled in.
+func (r *Reader) Leaves(c Chunk) ([]*trillian.LogLeaf, error) {
+	recs, err := r.records(c, LeafChunk, 3)
+	if err != nil {
+		return nil, err
+	}
+	leaves := make([]*trillian.LogLeaf, 0, len(recs))
+	for i, rec := range recs {
+		leaves = append(leaves, &trillian.LogLeaf{
+			LeafIndex:        int64(c.First) + int64(i),
+			LeafValue:        rec[0],
+			ExtraData:        rec[1],
+			LeafIdentityHash: rec[2],
+			MerkleLeafHash:   r.hasher.HashLeaf(rec[0]),
+		})
+	}
+	return leaves, nil
+}
+
+// Subtrees returns the compact range hashes of a subtree chunk.
+func (r *Reader) Subtrees(c Chunk) ([][]byte, error) {
+	recs, err := r.records(c, SubtreeChunk, 1)
+	if err != nil {
+		return nil, err
+	}
+	hashes := make([][]byte, 0, len(recs))
+	for _, rec := range recs {
+		hashes = append(hashes, rec[0])
+	}
+	return hashes, nil
+}
+
+// Roots returns the log roots of a root chunk.
+func (r *Reader) Roots(c Chunk) ([]*types.LogRootV1, error) {
+	recs, err := r.records(c, RootChunk, 1)
+	if err != nil {
+		return nil, err
+	}
+	roots := make([]*types.LogRootV1, 0, len(recs))
+	for i, rec := range recs {
+		var root types.LogRootV1
+		if err := root.UnmarshalBinary(rec[0]); err != nil {
+			return nil, fmt.Errorf("chunk %s: root %d: %v", c.Name, i, err)
+		}
+		roots = append(roots, &root)
+	}
+	return roots, nil
+}
+
+func (r *Reader) records(c Chunk, kind ChunkKind, fields int) ([][][]byte, error) {
+	if c.Kind != kind {
+		return nil, fmt.Errorf("chunk %s has kind %q, want %q", c.Name, c.Kind, kind)
+	}
+	data, err := r.ReadChunk(c)
+	if err != nil {
+		return nil, err
+	}
+	recs, err := parseRecords(data, fields)
+	if err != nil {
+		return nil, fmt.Errorf("chunk %s: %v", c.Name, err)
+	}
+	if kind != SubtreeChunk && uint64(len(recs)) != c.Count {
+		return nil, fmt.Errorf("chunk %s has %d records, manifest says %d", c.Name, len(recs), c.Count)
+	}
+	return recs, nil
+}
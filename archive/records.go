@@ -0,0 +1,61 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// appendRecord appends a record made of the given fields to buf.
+func appendRecord(buf []byte, fields ...[]byte) []byte {
+	var size [4]byte
+	for _, f := range fields {
+		binary.BigEndian.PutUint32(size[:], uint32(len(f)))
+		buf = append(append(buf, size[:]...), f...)
+	}
+	return buf
+}
+
+// parseRecords splits data into records of the given number of fields.
+func parseRecords(data []byte, fields int) ([][][]byte, error) {
+	var ret [][][]byte
+	for len(data) > 0 {
+		rec := make([][]byte, fields)
+		for i := range rec {
+			if len(data) < 4 {
+				return nil, errors.New("truncated field length")
+			}
+			n := binary.BigEndian.Uint32(data)
+			data = data[4:]
+			if uint64(n) > uint64(len(data)) {
+				return nil, fmt.Errorf("field of %d bytes exceeds the %d remaining bytes", n, len(data))
+			}
+			rec[i], data = data[:n:n], data[n:]
+		}
+		ret = append(ret, rec)
+	}
+	return ret, nil
+}
+
+// checkFieldSize checks that a field fits the 4-byte length prefix.
+func checkFieldSize(field []byte) error {
+	if uint64(len(field)) > math.MaxUint32 {
+		return fmt.Errorf("field of %d bytes is too large", len(field))
+	}
+	return nil
+}
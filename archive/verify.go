@@ -0,0 +1,161 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	"github.com/transparency-dev/merkle"
+	"github.com/transparency-dev/merkle/compact"
+)
+
+// Verify checks the bundle in dir without access to the log:
+//   - every chunk matches the size and hash in the manifest;
+//   - the leaf chunks cover all leaves from index zero to the tree size;
+//   - the leaves of every chunk hash to the nodes of its subtree chunk;
+//   - the archived roots match the root hashes of the leaves at their sizes;
+//   - the leaves hash to the root hash in the manifest.
+//
+// It returns the verified manifest.
+func Verify(dir string) (*Manifest, error) {
+	r, err := NewReader(dir)
+	if err != nil {
+		return nil, err
+	}
+	return VerifyReader(r)
+}
+
+// VerifyReader is like Verify, for a bundle read by r.
+func VerifyReader(r *Reader) (*Manifest, error) {
+	m := r.Manifest()
+
+	subtrees := make(map[uint64]Chunk)
+	for _, c := range m.ChunksByKind(SubtreeChunk) {
+		subtrees[c.First] = c
+	}
+	var roots []rootAt
+	for _, c := range m.ChunksByKind(RootChunk) {
+		rs, err := r.Roots(c)
+		if err != nil {
+			return nil, err
+		}
+		for _, root := range rs {
+			roots = append(roots, rootAt{size: root.TreeSize, hash: root.RootHash})
+		}
+	}
+	sort.Slice(roots, func(i, j int) bool { return roots[i].size < roots[j].size })
+
+	factory := &compact.RangeFactory{Hash: r.hasher.HashChildren}
+	tree := factory.NewEmptyRange(0)
+	// checkRoots checks the roots at the current size of the tree.
+	checkRoots := func() error {
+		for len(roots) > 0 && roots[0].size == tree.End() {
+			hash, err := rootHash(r.hasher, tree)
+			if err != nil {
+				return err
+			}
+			if !bytes.Equal(hash, roots[0].hash) {
+				return fmt.Errorf("root of size %d has hash %x, leaves hash to %x", roots[0].size, roots[0].hash, hash)
+			}
+			roots = roots[1:]
+		}
+		return nil
+	}
+
+	leafChunks := m.ChunksByKind(LeafChunk)
+	sort.Slice(leafChunks, func(i, j int) bool { return leafChunks[i].First < leafChunks[j].First })
+	for _, c := range leafChunks {
+		if c.First != tree.End() {
+			return nil, fmt.Errorf("chunk %s starts at leaf %d, want %d", c.Name, c.First, tree.End())
+		}
+		leaves, err := r.Leaves(c)
+		if err != nil {
+			return nil, err
+		}
+		sc, ok := subtrees[c.First]
+		if !ok || sc.Count != c.Count {
+			return nil, fmt.Errorf("no subtree chunk for the %d leaves of chunk %s", c.Count, c.Name)
+		}
+		want, err := r.Subtrees(sc)
+		if err != nil {
+			return nil, err
+		}
+
+		rng := factory.NewEmptyRange(c.First)
+		for _, l := range leaves {
+			if err := rng.Append(l.MerkleLeafHash, nil); err != nil {
+				return nil, err
+			}
+		}
+		if !equalHashes(rng.Hashes(), want) {
+			return nil, fmt.Errorf("leaves of chunk %s don't match subtree chunk %s", c.Name, sc.Name)
+		}
+		for _, l := range leaves {
+			if err := checkRoots(); err != nil {
+				return nil, err
+			}
+			if err := tree.Append(l.MerkleLeafHash, nil); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := checkRoots(); err != nil {
+		return nil, err
+	}
+	if len(roots) > 0 {
+		return nil, fmt.Errorf("root of size %d is beyond the %d archived leaves", roots[0].size, tree.End())
+	}
+
+	if got, want := tree.End(), m.TreeSize; got != want {
+		return nil, fmt.Errorf("bundle has %d leaves, manifest says %d", got, want)
+	}
+	hash, err := rootHash(r.hasher, tree)
+	if err != nil {
+		return nil, err
+	}
+	if got, want := hex.EncodeToString(hash), m.RootHash; got != want {
+		return nil, fmt.Errorf("leaves hash to %s, manifest says %s", got, want)
+	}
+	return m, nil
+}
+
+// rootHash returns the root hash of the tree covered by rng, which must start
+// at index zero.
+func rootHash(hasher merkle.LogHasher, rng *compact.Range) ([]byte, error) {
+	if rng.End() == 0 {
+		return hasher.EmptyRoot(), nil
+	}
+	return rng.GetRootHash(nil)
+}
+
+type rootAt struct {
+	size uint64
+	hash []byte
+}
+
+func equalHashes(a, b [][]byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !bytes.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,209 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/types"
+	"github.com/transparency-dev/merkle"
+	"github.com/transparency-dev/merkle/compact"
+	"github.com/transparency-dev/merkle/rfc6962"
+)
+
+// Writer writes a bundle. Leaves must be appended in order, starting from
+// index zero; they're written out in chunks of ChunkSize leaves. The bundle
+// is only complete once Close has written the manifest.
+type Writer struct {
+	dir       string
+	chunkSize uint64
+	hasher    merkle.LogHasher
+	factory   *compact.RangeFactory
+
+	m      Manifest
+	tree   *compact.Range
+	leaves []*trillian.LogLeaf
+	roots  [][]byte
+	closed bool
+}
+
+// NewWriter creates the directory dir, which must not exist, and returns a
+// Writer for a bundle of the given log in it.
+func NewWriter(dir string, logID int64, chunkSize uint64) (*Writer, error) {
+	if chunkSize == 0 {
+		return nil, errors.New("chunk size must be positive")
+	}
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		return nil, err
+	}
+	factory := &compact.RangeFactory{Hash: rfc6962.DefaultHasher.HashChildren}
+	return &Writer{
+		dir:       dir,
+		chunkSize: chunkSize,
+		hasher:    rfc6962.DefaultHasher,
+		factory:   factory,
+		m: Manifest{
+			FormatVersion: FormatVersion,
+			LogID:         logID,
+			HashStrategy:  trillian.HashStrategy_RFC6962_SHA256.String(),
+		},
+		tree: factory.NewEmptyRange(0),
+	}, nil
+}
+
+// AppendLeaves adds leaves to the bundle. Their LeafIndex must follow on from
+// the previously appended leaves, and their MerkleLeafHash, if set, must
+// match their LeafValue.
+func (w *Writer) AppendLeaves(leaves ...*trillian.LogLeaf) error {
+	if w.closed {
+		return errors.New("writer is closed")
+	}
+	for _, l := range leaves {
+		if want := w.tree.End() + uint64(len(w.leaves)); l.LeafIndex < 0 || uint64(l.LeafIndex) != want {
+			return fmt.Errorf("leaf has LeafIndex %d, want %d", l.LeafIndex, want)
+		}
+		for _, f := range [][]byte{l.LeafValue, l.ExtraData, l.LeafIdentityHash} {
+			if err := checkFieldSize(f); err != nil {
+				return fmt.Errorf("leaf %d: %v", l.LeafIndex, err)
+			}
+		}
+		if l.MerkleLeafHash != nil {
+			if want := w.hasher.HashLeaf(l.LeafValue); !bytes.Equal(l.MerkleLeafHash, want) {
+				return fmt.Errorf("leaf %d has MerkleLeafHash %x, want %x", l.LeafIndex, l.MerkleLeafHash, want)
+			}
+		}
+		w.leaves = append(w.leaves, l)
+		if uint64(len(w.leaves)) == w.chunkSize {
+			if err := w.flushLeaves(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// AddRoot adds a log root to the bundle. Roots are checked against the
+// archived leaves by Verify, so Close rejects roots of trees larger than the
+// bundle.
+func (w *Writer) AddRoot(root *trillian.SignedLogRoot) error {
+	if w.closed {
+		return errors.New("writer is closed")
+	}
+	var r types.LogRootV1
+	if err := r.UnmarshalBinary(root.GetLogRoot()); err != nil {
+		return fmt.Errorf("failed to parse log root: %v", err)
+	}
+	w.roots = append(w.roots, root.LogRoot)
+	return nil
+}
+
+// Close writes the pending leaves, the roots and the manifest, and returns
+// the manifest of the complete bundle.
+func (w *Writer) Close() (*Manifest, error) {
+	if w.closed {
+		return nil, errors.New("writer is closed")
+	}
+	w.closed = true
+	if err := w.flushLeaves(); err != nil {
+		return nil, err
+	}
+
+	var roots []byte
+	for _, root := range w.roots {
+		var r types.LogRootV1
+		if err := r.UnmarshalBinary(root); err != nil {
+			return nil, err
+		}
+		if r.TreeSize > w.tree.End() {
+			return nil, fmt.Errorf("root of size %d is beyond the %d archived leaves", r.TreeSize, w.tree.End())
+		}
+		roots = appendRecord(roots, root)
+	}
+	if err := w.writeChunk("roots", RootChunk, 0, uint64(len(w.roots)), roots); err != nil {
+		return nil, err
+	}
+
+	root, err := rootHash(w.hasher, w.tree)
+	if err != nil {
+		return nil, err
+	}
+	w.m.TreeSize = w.tree.End()
+	w.m.RootHash = hex.EncodeToString(root)
+	data, err := json.MarshalIndent(&w.m, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(w.dir, ManifestName), append(data, '\n'), 0o644); err != nil {
+		return nil, err
+	}
+	m := w.m
+	return &m, nil
+}
+
+// flushLeaves writes the pending leaves to a leaf chunk, and the hashes of
+// their compact range to a subtree chunk.
+func (w *Writer) flushLeaves() error {
+	if len(w.leaves) == 0 {
+		return nil
+	}
+	first, count := w.tree.End(), uint64(len(w.leaves))
+	rng := w.factory.NewEmptyRange(first)
+	var leaves []byte
+	for _, l := range w.leaves {
+		if err := rng.Append(w.hasher.HashLeaf(l.LeafValue), nil); err != nil {
+			return err
+		}
+		leaves = appendRecord(leaves, l.LeafValue, l.ExtraData, l.LeafIdentityHash)
+	}
+	var subtrees []byte
+	for _, h := range rng.Hashes() {
+		subtrees = appendRecord(subtrees, h)
+	}
+	if err := w.writeChunk(fmt.Sprintf("leaves-%020d", first), LeafChunk, first, count, leaves); err != nil {
+		return err
+	}
+	if err := w.writeChunk(fmt.Sprintf("subtrees-%020d", first), SubtreeChunk, first, count, subtrees); err != nil {
+		return err
+	}
+	if err := w.tree.AppendRange(rng, nil); err != nil {
+		return err
+	}
+	w.leaves = w.leaves[:0]
+	return nil
+}
+
+func (w *Writer) writeChunk(name string, kind ChunkKind, first, count uint64, data []byte) error {
+	if err := os.WriteFile(filepath.Join(w.dir, name), data, 0o644); err != nil {
+		return err
+	}
+	sum := sha256.Sum256(data)
+	w.m.Chunks = append(w.m.Chunks, Chunk{
+		Name:   name,
+		Kind:   kind,
+		First:  first,
+		Count:  count,
+		Size:   int64(len(data)),
+		SHA256: hex.EncodeToString(sum[:]),
+	})
+	return nil
+}
@@ -16,7 +16,10 @@ package client
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/binary"
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/golang/glog"
@@ -122,3 +125,15 @@ func InitLog(ctx context.Context, tree *trillian.Tree, logClient trillian.Trilli
 		return err
 	}, codes.FailedPrecondition)
 }
+
+// TreeIDFromName derives a tree ID from name, for the tree_id of a
+// CreateTreeRequest. It lets infrastructure-as-code create trees with the same
+// IDs in all environments. Like the IDs assigned by the storage, the returned
+// ID is positive.
+func TreeIDFromName(name string) int64 {
+	h := sha256.Sum256([]byte(name))
+	if id := int64(binary.BigEndian.Uint64(h[:8]) & math.MaxInt64); id != 0 {
+		return id
+	}
+	return 1
+}
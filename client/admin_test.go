@@ -0,0 +1,35 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import "testing"
+
+func TestTreeIDFromName(t *testing.T) {
+	// The IDs must never change, as they identify trees across environments.
+	for _, tc := range []struct {
+		name string
+		want int64
+	}{
+		{name: "llamas-log", want: 3884660163113697004},
+		{name: "", want: 7183457195969485844},
+	} {
+		if got := TreeIDFromName(tc.name); got != tc.want {
+			t.Errorf("TreeIDFromName(%q) = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+	if a, b := TreeIDFromName("llamas-log"), TreeIDFromName("llamas-log-staging"); a == b {
+		t.Errorf("TreeIDFromName() returned %v for different names", a)
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+
+	"github.com/google/trillian/types"
+	"golang.org/x/mod/sumdb/note"
+)
+
+// VerifyCheckpoint checks that the checkpoint returned by the log server is
+// signed by v, and that it is for the log with the given origin, e.g.
+// "trillian/1234". It returns the contents of the checkpoint.
+func VerifyCheckpoint(b []byte, origin string, v note.Verifier) (*types.Checkpoint, error) {
+	n, err := note.Open(b, note.VerifierList(v))
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify checkpoint: %v", err)
+	}
+	var cp types.Checkpoint
+	if err := cp.UnmarshalText([]byte(n.Text)); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint: %v", err)
+	}
+	if cp.Origin != origin {
+		return nil, fmt.Errorf("checkpoint origin %q, want %q", cp.Origin, origin)
+	}
+	return &cp, nil
+}
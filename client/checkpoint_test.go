@@ -0,0 +1,83 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"crypto/rand"
+	"reflect"
+	"testing"
+
+	"github.com/google/trillian/types"
+	"golang.org/x/mod/sumdb/note"
+)
+
+func TestVerifyCheckpoint(t *testing.T) {
+	skey, vkey, err := note.GenerateKey(rand.Reader, "log")
+	if err != nil {
+		t.Fatalf("GenerateKey(): %v", err)
+	}
+	signer, err := note.NewSigner(skey)
+	if err != nil {
+		t.Fatalf("NewSigner(): %v", err)
+	}
+	verifier, err := note.NewVerifier(vkey)
+	if err != nil {
+		t.Fatalf("NewVerifier(): %v", err)
+	}
+	otherKey, _, err := note.GenerateKey(rand.Reader, "log")
+	if err != nil {
+		t.Fatalf("GenerateKey(): %v", err)
+	}
+	otherSigner, err := note.NewSigner(otherKey)
+	if err != nil {
+		t.Fatalf("NewSigner(): %v", err)
+	}
+
+	want := &types.Checkpoint{Origin: "trillian/1", Size: 10, Hash: []byte("0123456789abcdef0123456789abcdef")}
+	sign := func(s note.Signer, text string) []byte {
+		b, err := note.Sign(&note.Note{Text: text}, s)
+		if err != nil {
+			t.Fatalf("Sign(): %v", err)
+		}
+		return b
+	}
+	body, err := want.MarshalText()
+	if err != nil {
+		t.Fatalf("MarshalText(): %v", err)
+	}
+
+	for _, tc := range []struct {
+		desc    string
+		cp      []byte
+		origin  string
+		wantErr bool
+	}{
+		{desc: "ok", cp: sign(signer, string(body)), origin: "trillian/1"},
+		{desc: "wrongOrigin", cp: sign(signer, string(body)), origin: "trillian/2", wantErr: true},
+		{desc: "wrongKey", cp: sign(otherSigner, string(body)), origin: "trillian/1", wantErr: true},
+		{desc: "malformed", cp: sign(signer, "trillian/1\n"), origin: "trillian/1", wantErr: true},
+		{desc: "unsigned", cp: body, origin: "trillian/1", wantErr: true},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := VerifyCheckpoint(tc.cp, tc.origin, verifier)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("VerifyCheckpoint(): %v, wantErr %v", err, tc.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(got, want) {
+				t.Errorf("VerifyCheckpoint(): %+v, want %+v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+const modulePath = "github.com/google/trillian"
+
+// forbiddenDeps are the import path prefixes which client-only consumers of
+// the API and client packages must not pull in, so that these packages can
+// be split into their own modules without the server and storage
+// dependencies. The legacy golang/protobuf runtime is only allowed
+// indirectly, through gRPC.
+var forbiddenDeps = []string{
+	modulePath + "/cmd",
+	modulePath + "/log",
+	modulePath + "/quota",
+	modulePath + "/server",
+	modulePath + "/storage",
+	"cloud.google.com/go/spanner",
+	"github.com/golang/protobuf",
+	"github.com/go-sql-driver/mysql",
+	"go.etcd.io/etcd",
+}
+
+// TestClientDependencies checks that the API and client packages, and all the
+// packages of this module they import, don't depend on server or storage
+// code or drivers. Test files are not considered.
+func TestClientDependencies(t *testing.T) {
+	root, err := filepath.Abs("..")
+	if err != nil {
+		t.Fatalf("Abs(): %v", err)
+	}
+	seen := make(map[string]bool)
+	// importedBy records one importer of each package, to explain violations.
+	importedBy := make(map[string]string)
+	queue := []string{modulePath, modulePath + "/client", modulePath + "/types"}
+	for len(queue) > 0 {
+		pkg := queue[0]
+		queue = queue[1:]
+		if seen[pkg] {
+			continue
+		}
+		seen[pkg] = true
+
+		for _, dep := range forbiddenDeps {
+			if pkg == dep || strings.HasPrefix(pkg, dep+"/") {
+				t.Errorf("%s is imported by %s", pkg, importedBy[pkg])
+			}
+		}
+		if pkg != modulePath && !strings.HasPrefix(pkg, modulePath+"/") {
+			continue
+		}
+		imports, err := packageImports(filepath.Join(root, strings.TrimPrefix(pkg, modulePath)))
+		if err != nil {
+			t.Fatalf("%s: %v", pkg, err)
+		}
+		for _, imp := range imports {
+			if _, ok := importedBy[imp]; !ok {
+				importedBy[imp] = pkg
+			}
+			queue = append(queue, imp)
+		}
+	}
+}
+
+// packageImports returns the imports of the non-test Go files in dir.
+func packageImports(dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	var imports []string
+	for _, name := range files {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		src, err := os.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		f, err := parser.ParseFile(token.NewFileSet(), name, src, parser.ImportsOnly)
+		if err != nil {
+			return nil, err
+		}
+		for _, imp := range f.Imports {
+			path, err := strconv.Unquote(imp.Path.Value)
+			if err != nil {
+				return nil, err
+			}
+			imports = append(imports, path)
+		}
+	}
+	return imports, nil
+}
@@ -0,0 +1,45 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"crypto"
+	"errors"
+	"fmt"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/types"
+)
+
+// VerifyJSONLogRoot checks the signature of the canonical JSON log root of a
+// GetLatestSignedLogRoot response with the public key the log server signs
+// inclusion promises with, and returns the root. The signature covers the root
+// prefixed with types.JSONLogRootSignatureContext.
+func VerifyJSONLogRoot(pub crypto.PublicKey, resp *trillian.GetLatestSignedLogRootResponse) (*types.LogRootV1, error) {
+	msg := resp.GetJsonLogRoot()
+	if len(msg) == 0 {
+		return nil, errors.New("no JSON log root")
+	}
+	if ok, err := verifySignature(pub, types.JSONLogRootSignedMessage(msg), resp.GetJsonLogRootSignature()); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, errors.New("invalid JSON log root signature")
+	}
+	var root types.LogRootV1
+	if err := root.UnmarshalCanonicalJSON(msg); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON log root: %v", err)
+	}
+	return &root, nil
+}
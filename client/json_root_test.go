@@ -0,0 +1,58 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"reflect"
+	"testing"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/types"
+)
+
+func TestVerifyJSONLogRoot(t *testing.T) {
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey(): %v", err)
+	}
+	want := &types.LogRootV1{TreeSize: 3, RootHash: []byte("hash"), TimestampNanos: 10, Metadata: []byte{}}
+	root, err := want.MarshalCanonicalJSON()
+	if err != nil {
+		t.Fatalf("MarshalCanonicalJSON(): %v", err)
+	}
+	resp := &trillian.GetLatestSignedLogRootResponse{JsonLogRoot: root, JsonLogRootSignature: ed25519.Sign(key, types.JSONLogRootSignedMessage(root))}
+	got, err := VerifyJSONLogRoot(pub, resp)
+	if err != nil {
+		t.Fatalf("VerifyJSONLogRoot(): %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("VerifyJSONLogRoot(): %+v, want %+v", got, want)
+	}
+
+	if _, err := VerifyJSONLogRoot(pub, &trillian.GetLatestSignedLogRootResponse{JsonLogRoot: root, JsonLogRootSignature: ed25519.Sign(key, root)}); err == nil {
+		t.Error("VerifyJSONLogRoot() of signature without context: no error")
+	}
+	if _, err := VerifyJSONLogRoot(pub, &trillian.GetLatestSignedLogRootResponse{}); err == nil {
+		t.Error("VerifyJSONLogRoot() without JSON root: no error")
+	}
+	modified := append([]byte{}, root...)
+	modified[len(modified)-2] = '2'
+	resp.JsonLogRoot = modified
+	if _, err := VerifyJSONLogRoot(pub, resp); err == nil {
+		t.Error("VerifyJSONLogRoot() of modified root: no error")
+	}
+}
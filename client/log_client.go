@@ -19,6 +19,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"sort"
 	"sync"
 	"time"
@@ -99,6 +100,47 @@ func (c *LogClient) ListByIndex(ctx context.Context, start, count int64) ([]*tri
 	return resp.Leaves, nil
 }
 
+// StreamByIndex calls f with the requested leaves by index, in order, as they
+// are streamed by the log. Unlike ListByIndex, it doesn't hold all the leaves
+// in memory, so it is suitable for exporting large ranges. It stops at the
+// first error returned by f, and returns it.
+func (c *LogClient) StreamByIndex(ctx context.Context, start, count int64, f func(*trillian.LogLeaf) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := c.client.StreamLeavesByRange(ctx,
+		&trillian.GetLeavesByRangeRequest{
+			LogId:      c.LogID,
+			StartIndex: start,
+			Count:      count,
+		})
+	if err != nil {
+		return err
+	}
+	next := start
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		// Verify that we get back the requested leaves.
+		for _, l := range resp.Leaves {
+			if l.LeafIndex != next {
+				return fmt.Errorf("Leaves[%d].LeafIndex=%d, want %d", next-start, l.LeafIndex, next)
+			}
+			if err := f(l); err != nil {
+				return err
+			}
+			next++
+		}
+	}
+	if got := next - start; got < count {
+		return fmt.Errorf("len(Leaves)=%d, want %d", got, count)
+	}
+	return nil
+}
+
 // WaitForRootUpdate repeatedly fetches the latest root until there is an
 // update, which it then applies, or until ctx times out.
 func (c *LogClient) WaitForRootUpdate(ctx context.Context) (*types.LogRootV1, error) {
@@ -268,6 +310,16 @@ func (c *LogClient) WaitForInclusion(ctx context.Context, data []byte) error {
 }
 
 func (c *LogClient) getAndVerifyInclusionProof(ctx context.Context, leafHash []byte, sth *types.LogRootV1) (bool, error) {
+	proofs, err := c.getAndVerifyInclusionProofs(ctx, leafHash, sth)
+	if err != nil {
+		return false, err
+	}
+	return len(proofs) > 0, nil
+}
+
+// getAndVerifyInclusionProofs returns the verified inclusion proofs of the
+// leaf with leafHash in the tree described by sth, or none if it isn't there.
+func (c *LogClient) getAndVerifyInclusionProofs(ctx context.Context, leafHash []byte, sth *types.LogRootV1) ([]*trillian.Proof, error) {
 	resp, err := c.client.GetInclusionProofByHash(ctx,
 		&trillian.GetInclusionProofByHashRequest{
 			LogId:    c.LogID,
@@ -275,17 +327,14 @@ func (c *LogClient) getAndVerifyInclusionProof(ctx context.Context, leafHash []b
 			TreeSize: int64(sth.TreeSize),
 		})
 	if err != nil {
-		return false, err
-	}
-	if len(resp.Proof) < 1 {
-		return false, nil
+		return nil, err
 	}
 	for _, proof := range resp.Proof {
 		if err := c.VerifyInclusionByHash(sth, leafHash, proof); err != nil {
-			return false, fmt.Errorf("VerifyInclusionByHash(): %v", err)
+			return nil, fmt.Errorf("VerifyInclusionByHash(): %v", err)
 		}
 	}
-	return true, nil
+	return resp.Proof, nil
 }
 
 // AddSequencedLeaves adds any number of pre-sequenced leaves to the log.
@@ -101,6 +101,37 @@ func TestListByIndex(t *testing.T) {
 	}
 }
 
+func TestStreamByIndex(t *testing.T) {
+	ctx := context.Background()
+	env, client := clientEnvForTest(ctx, t, stestonly.PreorderedLogTree)
+	defer env.Close()
+
+	leafData := [][]byte{
+		[]byte("A"),
+		[]byte("B"),
+		[]byte("C"),
+	}
+	if err := addSequencedLeaves(ctx, env, client, leafData); err != nil {
+		t.Fatalf("Failed to add leaves: %v", err)
+	}
+
+	var got [][]byte
+	if err := client.StreamByIndex(ctx, 1, 2, func(l *trillian.LogLeaf) error {
+		got = append(got, l.LeafValue)
+		return nil
+	}); err != nil {
+		t.Fatalf("StreamByIndex(): %v", err)
+	}
+	if want := leafData[1:]; !bytes.Equal(bytes.Join(got, nil), bytes.Join(want, nil)) || len(got) != len(want) {
+		t.Errorf("StreamByIndex() = %q, want %q", got, want)
+	}
+
+	// Ranges beyond the tree size are incomplete.
+	if err := client.StreamByIndex(ctx, 2, 5, func(*trillian.LogLeaf) error { return nil }); err == nil {
+		t.Error("StreamByIndex() beyond the tree size succeeded, want error")
+	}
+}
+
 func TestWaitForInclusion(t *testing.T) {
 	ctx := context.Background()
 	tree := proto.Clone(stestonly.LogTree).(*trillian.Tree)
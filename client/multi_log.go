@@ -0,0 +1,272 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MultiLogMember is one of the logs of a multi-log ecosystem.
+type MultiLogMember struct {
+	// Name identifies the log in results and errors.
+	Name string
+	// Client talks to the log, and holds the root trusted for it.
+	Client *LogClient
+	// CheckRoot, if set, is called on every root of the log before it is used
+	// to verify proofs. Trillian doesn't sign its roots, so this is where
+	// personalities check the root against the log's public key, e.g. by
+	// verifying the signature of the checkpoint it was published in. It is
+	// required by VerifyEvidence, as the roots of evidence don't come from the
+	// log.
+	CheckRoot func(*types.LogRootV1) error
+	// Timeout, if positive, bounds the time spent on the log by each call of
+	// MultiLogVerifier.VerifyInclusion or MultiLogClient.AddLeaf, so that a
+	// slow log doesn't hold up the result once the others are done.
+	Timeout time.Duration
+}
+
+// LogInclusion is the evidence that a leaf is included in one log: a root of
+// the log and an inclusion proof of the leaf in the tree of that root.
+type LogInclusion struct {
+	Name  string
+	LogID int64
+	Root  *types.LogRootV1
+	Proof *trillian.Proof
+}
+
+// MultiLogResult is the outcome of checking a leaf against all the logs of a
+// MultiLogVerifier.
+type MultiLogResult struct {
+	// Inclusions holds the verified evidence from the logs which include the
+	// leaf, in the order the logs were configured.
+	Inclusions []*LogInclusion
+	// Failures holds the reason why each of the other logs didn't count
+	// towards the quorum, by log name.
+	Failures map[string]error
+}
+
+// MultiLogVerifier verifies that leaves are included in at least a quorum of
+// K out of N logs, for ecosystems which require redundancy across
+// independently operated logs.
+type MultiLogVerifier struct {
+	quorum  int
+	members []*MultiLogMember
+	byID    map[int64]*MultiLogMember
+}
+
+// NewMultiLogVerifier returns a verifier requiring inclusion in quorum of the
+// given logs, which must have distinct names and tree IDs.
+func NewMultiLogVerifier(quorum int, members ...*MultiLogMember) (*MultiLogVerifier, error) {
+	if quorum < 1 || quorum > len(members) {
+		return nil, fmt.Errorf("quorum %d out of range [1, %d]", quorum, len(members))
+	}
+	names := make(map[string]bool)
+	byID := make(map[int64]*MultiLogMember)
+	for _, m := range members {
+		if m.Client == nil {
+			return nil, fmt.Errorf("log %q has no client", m.Name)
+		}
+		if names[m.Name] {
+			return nil, fmt.Errorf("duplicate log name %q", m.Name)
+		}
+		names[m.Name] = true
+		if _, ok := byID[m.Client.LogID]; ok {
+			return nil, fmt.Errorf("duplicate log ID %d", m.Client.LogID)
+		}
+		byID[m.Client.LogID] = m
+	}
+	return &MultiLogVerifier{quorum: quorum, members: members, byID: byID}, nil
+}
+
+// VerifyInclusion updates the trusted root of every log, and fetches and
+// verifies an inclusion proof of data in each of them concurrently. The
+// result is returned even if the leaf is included in fewer logs than the
+// quorum, in which case the error explains why.
+func (v *MultiLogVerifier) VerifyInclusion(ctx context.Context, data []byte) (*MultiLogResult, error) {
+	return v.fanOut(ctx, data, (*MultiLogMember).fetchInclusion)
+}
+
+// fanOut calls fn for every log concurrently, each with the log's timeout,
+// and collects the evidence it returns.
+func (v *MultiLogVerifier) fanOut(ctx context.Context, data []byte, fn func(*MultiLogMember, context.Context, []byte) (*LogInclusion, error)) (*MultiLogResult, error) {
+	inclusions := make([]*LogInclusion, len(v.members))
+	errs := make([]error, len(v.members))
+	var wg sync.WaitGroup
+	for i, m := range v.members {
+		wg.Add(1)
+		go func(i int, m *MultiLogMember) {
+			defer wg.Done()
+			ctx := ctx
+			if m.Timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, m.Timeout)
+				defer cancel()
+			}
+			inclusions[i], errs[i] = fn(m, ctx, data)
+		}(i, m)
+	}
+	wg.Wait()
+
+	res := &MultiLogResult{Failures: make(map[string]error)}
+	for i, m := range v.members {
+		if errs[i] != nil {
+			res.Failures[m.Name] = errs[i]
+			continue
+		}
+		res.Inclusions = append(res.Inclusions, inclusions[i])
+	}
+	return res, v.checkQuorum(len(res.Inclusions), res.Failures)
+}
+
+// VerifyEvidence checks inclusion evidence collected earlier, e.g. by
+// VerifyInclusion on the submitter's side and shipped alongside the artifact,
+// without contacting the logs. Evidence from unknown logs is ignored, and only
+// one piece of evidence counts per log.
+//
+// The roots in the evidence are only as trustworthy as the CheckRoot
+// functions of the logs make them, so evidence from logs without a CheckRoot
+// function fails.
+func (v *MultiLogVerifier) VerifyEvidence(leafHash []byte, evidence []*LogInclusion) error {
+	verified := make(map[int64]bool)
+	failures := make(map[string]error)
+	for _, e := range evidence {
+		m, ok := v.byID[e.LogID]
+		if !ok || verified[e.LogID] {
+			continue
+		}
+		if m.CheckRoot == nil {
+			failures[m.Name] = errors.New("no CheckRoot to trust the root of the evidence")
+			continue
+		}
+		if err := m.verifyInclusion(leafHash, e.Root, e.Proof); err != nil {
+			failures[m.Name] = err
+			continue
+		}
+		verified[e.LogID] = true
+		delete(failures, m.Name)
+	}
+	for _, m := range v.members {
+		if _, ok := failures[m.Name]; !ok && !verified[m.Client.LogID] {
+			failures[m.Name] = errors.New("no evidence")
+		}
+	}
+	return v.checkQuorum(len(verified), failures)
+}
+
+// MultiLogClient submits leaves to several logs concurrently, for submitters
+// which need their entries in at least a quorum of K out of N logs. As it
+// embeds a MultiLogVerifier, it can also check the evidence it collects.
+type MultiLogClient struct {
+	*MultiLogVerifier
+}
+
+// NewMultiLogClient returns a client submitting to the given logs, which must
+// have distinct names and tree IDs, and requiring inclusion in quorum of them.
+func NewMultiLogClient(quorum int, members ...*MultiLogMember) (*MultiLogClient, error) {
+	v, err := NewMultiLogVerifier(quorum, members...)
+	if err != nil {
+		return nil, err
+	}
+	return &MultiLogClient{MultiLogVerifier: v}, nil
+}
+
+// AddLeaf queues data in every log concurrently, and waits until each of them
+// includes it or the log's timeout expires. The verified inclusion in each log
+// is returned as a receipt, which VerifyEvidence can check later. The result
+// is returned even if fewer logs than the quorum include the leaf, in which
+// case the error explains why.
+func (c *MultiLogClient) AddLeaf(ctx context.Context, data []byte) (*MultiLogResult, error) {
+	return c.fanOut(ctx, data, (*MultiLogMember).addLeaf)
+}
+
+func (v *MultiLogVerifier) checkQuorum(included int, failures map[string]error) error {
+	if included >= v.quorum {
+		return nil
+	}
+	names := make([]string, 0, len(failures))
+	for name := range failures {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	reasons := make([]string, 0, len(names))
+	for _, name := range names {
+		reasons = append(reasons, fmt.Sprintf("%s: %v", name, failures[name]))
+	}
+	return status.Errorf(codes.NotFound, "leaf included in %d of %d logs, want %d: %s",
+		included, len(v.members), v.quorum, strings.Join(reasons, "; "))
+}
+
+// fetchInclusion returns the verified evidence of data being included in the
+// log, at its latest root.
+func (m *MultiLogMember) fetchInclusion(ctx context.Context, data []byte) (*LogInclusion, error) {
+	if _, err := m.Client.UpdateRoot(ctx); err != nil {
+		return nil, fmt.Errorf("UpdateRoot(): %v", err)
+	}
+	root := m.Client.GetRoot()
+	if err := m.checkRoot(root); err != nil {
+		return nil, err
+	}
+	if root.TreeSize == 0 {
+		return nil, errors.New("log is empty")
+	}
+	leafHash := prepareLeaf(m.Client.hasher, data).MerkleLeafHash
+	proofs, err := m.Client.getAndVerifyInclusionProofs(ctx, leafHash, root)
+	if status.Code(err) == codes.NotFound || err == nil && len(proofs) == 0 {
+		return nil, fmt.Errorf("leaf not found in tree of size %d", root.TreeSize)
+	} else if err != nil {
+		return nil, err
+	}
+	return &LogInclusion{Name: m.Name, LogID: m.Client.LogID, Root: root, Proof: proofs[0]}, nil
+}
+
+// addLeaf adds data to the log, and returns the verified evidence of its
+// inclusion.
+func (m *MultiLogMember) addLeaf(ctx context.Context, data []byte) (*LogInclusion, error) {
+	if err := m.Client.AddLeaf(ctx, data); err != nil {
+		return nil, err
+	}
+	return m.fetchInclusion(ctx, data)
+}
+
+func (m *MultiLogMember) verifyInclusion(leafHash []byte, root *types.LogRootV1, proof *trillian.Proof) error {
+	if root == nil || proof == nil {
+		return errors.New("incomplete evidence")
+	}
+	if err := m.checkRoot(root); err != nil {
+		return err
+	}
+	return m.Client.VerifyInclusionByHash(root, leafHash, proof)
+}
+
+func (m *MultiLogMember) checkRoot(root *types.LogRootV1) error {
+	if m.CheckRoot == nil {
+		return nil
+	}
+	if err := m.CheckRoot(root); err != nil {
+		return fmt.Errorf("CheckRoot(): %v", err)
+	}
+	return nil
+}
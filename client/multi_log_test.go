@@ -0,0 +1,300 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/types"
+	"github.com/transparency-dev/merkle/rfc6962"
+	inmemory "github.com/transparency-dev/merkle/testonly"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (f *fakeLog) GetInclusionProofByHash(ctx context.Context, in *trillian.GetInclusionProofByHashRequest, opts ...grpc.CallOption) (*trillian.GetInclusionProofByHashResponse, error) {
+	for i := uint64(0); i < uint64(in.TreeSize) && i < f.tree.Size(); i++ {
+		if !bytes.Equal(f.tree.LeafHash(i), in.LeafHash) {
+			continue
+		}
+		hashes, err := f.tree.InclusionProof(i, uint64(in.TreeSize))
+		if err != nil {
+			return nil, err
+		}
+		return &trillian.GetInclusionProofByHashResponse{
+			Proof: []*trillian.Proof{{LeafIndex: int64(i), Hashes: hashes}},
+		}, nil
+	}
+	return nil, status.Error(codes.NotFound, "leaf not found")
+}
+
+func multiLogForTest(t *testing.T, quorum int, logs ...*fakeLog) *MultiLogVerifier {
+	t.Helper()
+	var members []*MultiLogMember
+	for i, l := range logs {
+		l := l
+		members = append(members, &MultiLogMember{
+			Name:   string(rune('a' + i)),
+			Client: New(int64(i+1), l, NewLogVerifier(rfc6962.DefaultHasher), types.LogRootV1{}),
+			// Only roots of the log's own tree are trusted, as if they had
+			// been signed by it.
+			CheckRoot: func(root *types.LogRootV1) error {
+				if root.TreeSize > l.tree.Size() || !bytes.Equal(root.RootHash, l.tree.HashAt(root.TreeSize)) {
+					return errors.New("root not signed by the log")
+				}
+				return nil
+			},
+		})
+	}
+	v, err := NewMultiLogVerifier(quorum, members...)
+	if err != nil {
+		t.Fatalf("NewMultiLogVerifier(): %v", err)
+	}
+	return v
+}
+
+func TestNewMultiLogVerifier(t *testing.T) {
+	verifier := NewLogVerifier(rfc6962.DefaultHasher)
+	a := &MultiLogMember{Name: "a", Client: New(1, newFakeLog(), verifier, types.LogRootV1{})}
+	b := &MultiLogMember{Name: "b", Client: New(2, newFakeLog(), verifier, types.LogRootV1{})}
+	for _, tc := range []struct {
+		desc    string
+		quorum  int
+		members []*MultiLogMember
+		wantErr bool
+	}{
+		{desc: "ok", quorum: 2, members: []*MultiLogMember{a, b}},
+		{desc: "zero-quorum", quorum: 0, members: []*MultiLogMember{a, b}, wantErr: true},
+		{desc: "quorum-too-large", quorum: 3, members: []*MultiLogMember{a, b}, wantErr: true},
+		{desc: "no-client", quorum: 1, members: []*MultiLogMember{a, {Name: "c"}}, wantErr: true},
+		{desc: "duplicate-name", quorum: 1, members: []*MultiLogMember{a, {Name: "a", Client: b.Client}}, wantErr: true},
+		{desc: "duplicate-id", quorum: 1, members: []*MultiLogMember{a, {Name: "c", Client: a.Client}}, wantErr: true},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := NewMultiLogVerifier(tc.quorum, tc.members...)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("NewMultiLogVerifier(): %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestMultiLogVerifyInclusion(t *testing.T) {
+	ctx := context.Background()
+	logs := []*fakeLog{newFakeLog(), newFakeLog(), newFakeLog()}
+	// "leaf 2" is in the first two logs only, "leaf 0" in all of them.
+	logs[0].add(5)
+	logs[1].add(3)
+	logs[2].add(1)
+
+	v := multiLogForTest(t, 2, logs...)
+	res, err := v.VerifyInclusion(ctx, []byte("leaf 2"))
+	if err != nil {
+		t.Fatalf("VerifyInclusion(): %v", err)
+	}
+	if got, want := len(res.Inclusions), 2; got != want {
+		t.Fatalf("got %d inclusions, want %d", got, want)
+	}
+	for i, inc := range res.Inclusions {
+		if got, want := inc.Root.TreeSize, logs[i].tree.Size(); got != want {
+			t.Errorf("inclusion in %s at size %d, want %d", inc.Name, got, want)
+		}
+	}
+	if _, ok := res.Failures["c"]; !ok || len(res.Failures) != 1 {
+		t.Errorf("Failures=%v, want only c", res.Failures)
+	}
+
+	// The evidence can be checked later without the logs.
+	leafHash := rfc6962.DefaultHasher.HashLeaf([]byte("leaf 2"))
+	if err := v.VerifyEvidence(leafHash, res.Inclusions); err != nil {
+		t.Errorf("VerifyEvidence(): %v", err)
+	}
+	// Duplicate evidence from one log doesn't count twice.
+	if err := v.VerifyEvidence(leafHash, res.Inclusions[:1]); err == nil {
+		t.Error("VerifyEvidence() with one log succeeded, want error")
+	}
+	if err := v.VerifyEvidence(leafHash, []*LogInclusion{res.Inclusions[0], res.Inclusions[0]}); err == nil {
+		t.Error("VerifyEvidence() with duplicate evidence succeeded, want error")
+	}
+	// Evidence for another leaf fails.
+	if err := v.VerifyEvidence(rfc6962.DefaultHasher.HashLeaf([]byte("leaf 1")), res.Inclusions); err == nil {
+		t.Error("VerifyEvidence() for another leaf succeeded, want error")
+	}
+
+	// Below the quorum.
+	strict := multiLogForTest(t, 3, logs...)
+	res, err = strict.VerifyInclusion(ctx, []byte("leaf 2"))
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("VerifyInclusion() with quorum 3: %v, want NotFound", err)
+	}
+	if got, want := len(res.Inclusions), 2; got != want {
+		t.Errorf("got %d inclusions, want %d", got, want)
+	}
+	if _, err := strict.VerifyInclusion(ctx, []byte("leaf 0")); err != nil {
+		t.Errorf("VerifyInclusion() of leaf in all logs: %v", err)
+	}
+}
+
+func TestMultiLogCheckRoot(t *testing.T) {
+	ctx := context.Background()
+	logs := []*fakeLog{newFakeLog(), newFakeLog()}
+	logs[0].add(2)
+	logs[1].add(2)
+
+	v := multiLogForTest(t, 2, logs...)
+	res, err := v.VerifyInclusion(ctx, []byte("leaf 1"))
+	if err != nil {
+		t.Fatalf("VerifyInclusion(): %v", err)
+	}
+
+	// A log whose key doesn't sign the root doesn't count.
+	v.members[1].CheckRoot = func(*types.LogRootV1) error { return errors.New("bad signature") }
+	if _, err := v.VerifyInclusion(ctx, []byte("leaf 1")); err == nil {
+		t.Error("VerifyInclusion() with rejected root succeeded, want error")
+	}
+	leafHash := rfc6962.DefaultHasher.HashLeaf([]byte("leaf 1"))
+	if err := v.VerifyEvidence(leafHash, res.Inclusions); err == nil {
+		t.Error("VerifyEvidence() with rejected root succeeded, want error")
+	}
+}
+
+func TestMultiLogVerifyFabricatedEvidence(t *testing.T) {
+	logs := []*fakeLog{newFakeLog(), newFakeLog()}
+	logs[0].add(2)
+	logs[1].add(2)
+	v := multiLogForTest(t, 2, logs...)
+
+	// The evidence is self-consistent, but its tree was never in the logs.
+	fake := inmemory.New(rfc6962.DefaultHasher)
+	fake.AppendData([]byte("forged"))
+	fake.AppendData([]byte("leaf 1"))
+	hashes, err := fake.InclusionProof(1, 2)
+	if err != nil {
+		t.Fatalf("InclusionProof(): %v", err)
+	}
+	var evidence []*LogInclusion
+	for _, m := range v.members {
+		evidence = append(evidence, &LogInclusion{
+			Name:  m.Name,
+			LogID: m.Client.LogID,
+			Root:  &types.LogRootV1{TreeSize: 2, RootHash: fake.Hash()},
+			Proof: &trillian.Proof{LeafIndex: 1, Hashes: hashes},
+		})
+	}
+	leafHash := rfc6962.DefaultHasher.HashLeaf([]byte("leaf 1"))
+	if err := v.VerifyEvidence(leafHash, evidence); err == nil {
+		t.Error("VerifyEvidence() with fabricated roots succeeded, want error")
+	}
+
+	// Without CheckRoot, no evidence is trusted.
+	for _, m := range v.members {
+		m.CheckRoot = nil
+	}
+	if err := v.VerifyEvidence(leafHash, evidence); err == nil {
+		t.Error("VerifyEvidence() without CheckRoot succeeded, want error")
+	}
+}
+
+// queueingLog is a fakeLog which integrates queued leaves straight away, or
+// never answers if it is stalled.
+type queueingLog struct {
+	*fakeLog
+	stalled bool
+	err     error
+}
+
+func (q *queueingLog) QueueLeaf(ctx context.Context, in *trillian.QueueLeafRequest, opts ...grpc.CallOption) (*trillian.QueueLeafResponse, error) {
+	if q.stalled {
+		<-ctx.Done()
+		return nil, status.Error(codes.DeadlineExceeded, ctx.Err().Error())
+	}
+	if q.err != nil {
+		return nil, q.err
+	}
+	q.data = append(q.data, in.Leaf.LeafValue)
+	q.tree.AppendData(in.Leaf.LeafValue)
+	return &trillian.QueueLeafResponse{}, nil
+}
+
+func TestMultiLogAddLeaf(t *testing.T) {
+	ctx := context.Background()
+	for _, tc := range []struct {
+		quorum  int
+		wantErr bool
+	}{
+		{quorum: 2},
+		{quorum: 3, wantErr: true},
+	} {
+		t.Run(fmt.Sprintf("quorum-%d", tc.quorum), func(t *testing.T) {
+			logs := []*queueingLog{
+				{fakeLog: newFakeLog()},
+				{fakeLog: newFakeLog()},
+				{fakeLog: newFakeLog(), stalled: true},
+				{fakeLog: newFakeLog(), err: status.Error(codes.ResourceExhausted, "quota")},
+			}
+			var members []*MultiLogMember
+			for i, l := range logs {
+				l := l
+				l.add(i)
+				members = append(members, &MultiLogMember{
+					Name:   string(rune('a' + i)),
+					Client: New(int64(i+1), l, NewLogVerifier(rfc6962.DefaultHasher), types.LogRootV1{}),
+					CheckRoot: func(root *types.LogRootV1) error {
+						if !bytes.Equal(root.RootHash, l.tree.HashAt(root.TreeSize)) {
+							return errors.New("root not signed by the log")
+						}
+						return nil
+					},
+					Timeout: 100 * time.Millisecond,
+				})
+			}
+			c, err := NewMultiLogClient(tc.quorum, members...)
+			if err != nil {
+				t.Fatalf("NewMultiLogClient(): %v", err)
+			}
+
+			data := []byte("submission")
+			res, err := c.AddLeaf(ctx, data)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("AddLeaf(): %v, wantErr %v", err, tc.wantErr)
+			}
+			var names []string
+			for _, inc := range res.Inclusions {
+				names = append(names, inc.Name)
+			}
+			if got, want := strings.Join(names, ","), "a,b"; got != want {
+				t.Errorf("AddLeaf() included in %s, want %s: %v", got, want, res.Failures)
+			}
+			for _, name := range []string{"c", "d"} {
+				if _, ok := res.Failures[name]; !ok {
+					t.Errorf("AddLeaf(): no failure for %s in %v", name, res.Failures)
+				}
+			}
+			// The receipts are evidence for the verifier.
+			leafHash := rfc6962.DefaultHasher.HashLeaf(data)
+			if err := c.VerifyEvidence(leafHash, res.Inclusions); (err != nil) != tc.wantErr {
+				t.Errorf("VerifyEvidence(): %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// ErrPromisePending is returned by CheckInclusionPromise if the promised
+	// leaf isn't included yet, but its deadline hasn't passed.
+	ErrPromisePending = errors.New("inclusion promise pending")
+	// ErrPromiseBroken is returned by CheckInclusionPromise if the promised
+	// leaf wasn't integrated by its deadline.
+	ErrPromiseBroken = errors.New("inclusion promise broken")
+)
+
+// VerifyInclusionPromise checks the signature of promise with the public key
+// the log server signs inclusion promises with, and returns its contents.
+func VerifyInclusionPromise(pub crypto.PublicKey, promise *trillian.InclusionPromise) (*types.InclusionPromiseV1, error) {
+	msg := promise.GetPromise()
+	if ok, err := verifySignature(pub, msg, promise.GetSignature()); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, errors.New("invalid inclusion promise signature")
+	}
+	var p types.InclusionPromiseV1
+	if err := p.UnmarshalBinary(msg); err != nil {
+		return nil, fmt.Errorf("failed to parse inclusion promise: %v", err)
+	}
+	return &p, nil
+}
+
+// verifySignature checks sig over msg, as signed by the log server: ECDSA and
+// RSA (PKCS #1 v1.5) keys sign the SHA-256 digest of msg, and Ed25519 keys sign
+// msg itself.
+func verifySignature(pub crypto.PublicKey, msg, sig []byte) (bool, error) {
+	digest := sha256.Sum256(msg)
+	switch pub := pub.(type) {
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(pub, digest[:], sig), nil
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], sig) == nil, nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(pub, msg, sig), nil
+	default:
+		return false, fmt.Errorf("unsupported public key type %T", pub)
+	}
+}
+
+// CheckInclusionPromise audits whether the log kept a verified promise. It
+// updates the trusted root, and returns nil if the leaf is included in it and
+// was integrated by the deadline of the promise. It returns an error wrapping
+// ErrPromiseBroken if the leaf was integrated late, or isn't included in a
+// root from after the deadline, and ErrPromisePending if it isn't included in
+// a root from before the deadline.
+//
+// The deadline is compared with the timestamps of the log's own roots, so
+// that clock skew between the log and the auditor doesn't matter.
+func (c *LogClient) CheckInclusionPromise(ctx context.Context, promise *types.InclusionPromiseV1) error {
+	if got := int64(promise.LogID); got != c.LogID {
+		return fmt.Errorf("promise of log %d, want %d", got, c.LogID)
+	}
+	if _, err := c.UpdateRoot(ctx); err != nil {
+		return err
+	}
+	root := c.GetRoot()
+	deadline := promise.Deadline()
+
+	var proofs []*trillian.Proof
+	if root.TreeSize > 0 {
+		var err error
+		proofs, err = c.getAndVerifyInclusionProofs(ctx, promise.MerkleLeafHash, root)
+		if err != nil && status.Code(err) != codes.NotFound {
+			return err
+		}
+	}
+	if len(proofs) == 0 {
+		if rootTime := time.Unix(0, int64(root.TimestampNanos)); rootTime.After(deadline) {
+			return fmt.Errorf("%w: leaf %x not included in root of size %d from %v, deadline was %v",
+				ErrPromiseBroken, promise.MerkleLeafHash, root.TreeSize, rootTime, deadline)
+		}
+		return ErrPromisePending
+	}
+
+	leaves, err := c.ListByIndex(ctx, proofs[0].LeafIndex, 1)
+	if err != nil {
+		return err
+	}
+	leaf := leaves[0]
+	if !bytes.Equal(leaf.MerkleLeafHash, promise.MerkleLeafHash) {
+		return fmt.Errorf("leaf %d has hash %x, want %x", leaf.LeafIndex, leaf.MerkleLeafHash, promise.MerkleLeafHash)
+	}
+	if ts := leaf.IntegrateTimestamp; ts.IsValid() && ts.AsTime().After(deadline) {
+		return fmt.Errorf("%w: leaf %x integrated at %v, deadline was %v",
+			ErrPromiseBroken, promise.MerkleLeafHash, ts.AsTime(), deadline)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/types"
+	"github.com/transparency-dev/merkle/rfc6962"
+)
+
+func TestVerifyInclusionPromise(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey(): %v", err)
+	}
+	edPub, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey(): %v", err)
+	}
+	want := &types.InclusionPromiseV1{LogID: 1, MerkleLeafHash: []byte("hash"), QueueTimestampNanos: 10, MaxMergeDelayNanos: 20}
+	promise, err := want.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary(): %v", err)
+	}
+	digest := sha256.Sum256(promise)
+
+	for _, tc := range []struct {
+		desc    string
+		signer  crypto.Signer
+		msg     []byte
+		opts    crypto.SignerOpts
+		wantErr bool
+	}{
+		{desc: "ecdsa", signer: ecKey, msg: digest[:], opts: crypto.SHA256},
+		{desc: "ed25519", signer: edKey, msg: promise, opts: crypto.Hash(0)},
+		{desc: "ed25519-digest", signer: edKey, msg: digest[:], opts: crypto.Hash(0), wantErr: true},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			sig, err := tc.signer.Sign(rand.Reader, tc.msg, tc.opts)
+			if err != nil {
+				t.Fatalf("Sign(): %v", err)
+			}
+			got, err := VerifyInclusionPromise(tc.signer.Public(), &trillian.InclusionPromise{Promise: promise, Signature: sig})
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("VerifyInclusionPromise(): %v, wantErr %v", err, tc.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(got, want) {
+				t.Errorf("VerifyInclusionPromise(): %+v, want %+v", got, want)
+			}
+		})
+	}
+
+	// A signature by another key doesn't verify.
+	sig := ed25519.Sign(edKey, promise)
+	if _, err := VerifyInclusionPromise(&ecKey.PublicKey, &trillian.InclusionPromise{Promise: promise, Signature: sig}); err == nil {
+		t.Error("VerifyInclusionPromise() with wrong key: no error")
+	}
+	if _, err := VerifyInclusionPromise(edPub, &trillian.InclusionPromise{Promise: promise[1:], Signature: sig}); err == nil {
+		t.Error("VerifyInclusionPromise() of modified promise: no error")
+	}
+}
+
+func TestCheckInclusionPromise(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	for _, tc := range []struct {
+		desc       string
+		logID      uint64
+		leaf       []byte
+		queued     time.Time
+		integrated time.Time
+		wantErr    error
+	}{
+		{desc: "kept", logID: 1, leaf: []byte("leaf 2"), queued: now},
+		{desc: "kept-integrated", logID: 1, leaf: []byte("leaf 2"), queued: now, integrated: now.Add(time.Minute)},
+		{desc: "integrated-late", logID: 1, leaf: []byte("leaf 2"), queued: now, integrated: now.Add(2 * time.Hour), wantErr: ErrPromiseBroken},
+		{desc: "pending", logID: 1, leaf: []byte("new leaf"), queued: now, wantErr: ErrPromisePending},
+		{desc: "broken", logID: 1, leaf: []byte("new leaf"), queued: now.Add(-2 * time.Hour), wantErr: ErrPromiseBroken},
+		{desc: "wrong-log", logID: 2, leaf: []byte("leaf 2"), queued: now, wantErr: errors.New("any")},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			log := newFakeLog()
+			log.add(5)
+			log.integrated = tc.integrated
+			client := New(1, log, NewLogVerifier(rfc6962.DefaultHasher), types.LogRootV1{})
+
+			err := client.CheckInclusionPromise(ctx, &types.InclusionPromiseV1{
+				LogID:               tc.logID,
+				MerkleLeafHash:      rfc6962.DefaultHasher.HashLeaf(tc.leaf),
+				QueueTimestampNanos: uint64(tc.queued.UnixNano()),
+				MaxMergeDelayNanos:  uint64(time.Hour),
+			})
+			switch {
+			case tc.wantErr == nil && err != nil:
+				t.Errorf("CheckInclusionPromise(): %v", err)
+			case tc.wantErr != nil && err == nil:
+				t.Errorf("CheckInclusionPromise(): no error, want %v", tc.wantErr)
+			case tc.wantErr == ErrPromisePending || tc.wantErr == ErrPromiseBroken:
+				if !errors.Is(err, tc.wantErr) {
+					t.Errorf("CheckInclusionPromise(): %v, want %v", err, tc.wantErr)
+				}
+			}
+		})
+	}
+}
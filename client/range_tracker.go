@@ -0,0 +1,217 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/types"
+	"github.com/transparency-dev/merkle"
+	"github.com/transparency-dev/merkle/compact"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RangeTracker follows a log by fetching every leaf it integrates, and
+// maintains a compact range covering all of them. Each new root of the log is
+// verified against the root hash computed from the locally accumulated
+// hashes, so a monitor can check the log without storing the full tree or
+// relying on proofs served by the log.
+//
+// The state of a RangeTracker is the size and hashes of its compact range,
+// which can be persisted and passed to NewRangeTrackerFromState to resume.
+type RangeTracker struct {
+	LogID int64
+	// BatchSize is the maximum number of leaves fetched per request.
+	BatchSize int64
+
+	client  trillian.TrillianLogClient
+	hasher  merkle.LogHasher
+	factory *compact.RangeFactory
+
+	mu   sync.Mutex
+	rng  *compact.Range
+	root types.LogRootV1
+}
+
+// NewRangeTracker returns a RangeTracker which starts from the empty tree.
+func NewRangeTracker(logID int64, client trillian.TrillianLogClient, hasher merkle.LogHasher) *RangeTracker {
+	t, _ := NewRangeTrackerFromState(logID, client, hasher, 0, nil)
+	return t
+}
+
+// NewRangeTrackerFromState returns a RangeTracker which resumes from a
+// compact range of the given size, as returned by State.
+func NewRangeTrackerFromState(logID int64, client trillian.TrillianLogClient, hasher merkle.LogHasher, size uint64, hashes [][]byte) (*RangeTracker, error) {
+	factory := &compact.RangeFactory{Hash: hasher.HashChildren}
+	rng, err := factory.NewRange(0, size, hashes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid compact range for size %d: %v", size, err)
+	}
+	t := &RangeTracker{
+		LogID:     logID,
+		BatchSize: 1000,
+		client:    client,
+		hasher:    hasher,
+		factory:   factory,
+		rng:       rng,
+	}
+	if t.root.RootHash, err = t.rootHash(rng); err != nil {
+		return nil, err
+	}
+	t.root.TreeSize = size
+	return t, nil
+}
+
+// State returns the size and hashes of the current compact range.
+func (t *RangeTracker) State() (uint64, [][]byte) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.rng.End(), copyHashes(t.rng.Hashes())
+}
+
+// Root returns a copy of the latest verified root. Only its TreeSize and
+// RootHash are set before the first successful Update.
+func (t *RangeTracker) Root() *types.LogRootV1 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	ret := t.root
+	return &ret
+}
+
+// Update fetches the latest root of the log, and the leaves added since the
+// last update. It returns the new root if it matches the root hash computed
+// from the leaves, or an error if it doesn't, in which case the state of the
+// tracker is not changed. Returns nil if the log hasn't grown.
+func (t *RangeTracker) Update(ctx context.Context) (*types.LogRootV1, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	resp, err := t.client.GetLatestSignedLogRoot(ctx, &trillian.GetLatestSignedLogRootRequest{LogId: t.LogID})
+	if err != nil {
+		return nil, err
+	}
+	var root types.LogRootV1
+	if err := root.UnmarshalBinary(resp.GetSignedLogRoot().GetLogRoot()); err != nil {
+		return nil, err
+	}
+
+	size := t.rng.End()
+	switch {
+	case root.TreeSize < size:
+		return nil, fmt.Errorf("log %d shrank from size %d to %d", t.LogID, size, root.TreeSize)
+	case root.TreeSize == size:
+		if !bytes.Equal(root.RootHash, t.root.RootHash) {
+			return nil, fmt.Errorf("log %d has root hash %x at size %d, computed %x", t.LogID, root.RootHash, size, t.root.RootHash)
+		}
+		return nil, nil
+	}
+
+	rng, err := t.factory.NewRange(0, size, copyHashes(t.rng.Hashes()))
+	if err != nil {
+		return nil, err
+	}
+	for rng.End() < root.TreeSize {
+		count := int64(root.TreeSize - rng.End())
+		if count > t.BatchSize {
+			count = t.BatchSize
+		}
+		if err := t.appendLeaves(ctx, rng, count); err != nil {
+			return nil, err
+		}
+	}
+	hash, err := t.rootHash(rng)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(hash, root.RootHash) {
+		return nil, fmt.Errorf("log %d has root hash %x at size %d, computed %x", t.LogID, root.RootHash, root.TreeSize, hash)
+	}
+
+	t.rng, t.root = rng, root
+	ret := root
+	return &ret, nil
+}
+
+// Watch calls Update every interval until ctx is done or an update fails,
+// and calls f with every new verified root.
+func (t *RangeTracker) Watch(ctx context.Context, interval time.Duration, f func(*types.LogRootV1) error) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		root, err := t.Update(ctx)
+		if err != nil {
+			return err
+		}
+		if root != nil {
+			if err := f(root); err != nil {
+				return err
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return status.Errorf(codes.DeadlineExceeded, "%v", ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// appendLeaves fetches up to count leaves following the end of rng, checks
+// their hashes, and appends them to rng. Leaves whose data was pruned, i.e.
+// with an empty LeafValue and no ExtraData, are appended with the hash served
+// by the log, which is only checked by the root hash of the range.
+func (t *RangeTracker) appendLeaves(ctx context.Context, rng *compact.Range, count int64) error {
+	start := int64(rng.End())
+	resp, err := t.client.GetLeavesByRange(ctx, &trillian.GetLeavesByRangeRequest{
+		LogId:      t.LogID,
+		StartIndex: start,
+		Count:      count,
+	})
+	if err != nil {
+		return err
+	}
+	if len(resp.Leaves) == 0 {
+		return fmt.Errorf("log %d returned no leaves from index %d", t.LogID, start)
+	}
+	for i, l := range resp.Leaves {
+		if want := start + int64(i); l.LeafIndex != want {
+			return fmt.Errorf("Leaves[%d].LeafIndex=%d, want %d", i, l.LeafIndex, want)
+		}
+		pruned := len(l.LeafValue) == 0 && l.ExtraData == nil
+		if want := t.hasher.HashLeaf(l.LeafValue); !pruned && !bytes.Equal(l.MerkleLeafHash, want) {
+			return fmt.Errorf("leaf %d has MerkleLeafHash %x, want %x", l.LeafIndex, l.MerkleLeafHash, want)
+		}
+		if err := rng.Append(l.MerkleLeafHash, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *RangeTracker) rootHash(rng *compact.Range) ([]byte, error) {
+	if rng.End() == 0 {
+		return t.hasher.EmptyRoot(), nil
+	}
+	return rng.GetRootHash(nil)
+}
+
+func copyHashes(hashes [][]byte) [][]byte {
+	return append([][]byte(nil), hashes...)
+}
@@ -0,0 +1,219 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/types"
+	"github.com/transparency-dev/merkle/rfc6962"
+	inmemory "github.com/transparency-dev/merkle/testonly"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeLog serves the leaves and roots of an in-memory tree.
+type fakeLog struct {
+	trillian.TrillianLogClient
+	data    [][]byte
+	tree    *inmemory.Tree
+	badRoot bool
+	badLeaf int64
+	// pruned is the index of a leaf served without its data, unset if -1.
+	pruned   int64
+	requests int
+	// integrated is the integration time of all leaves, unset if zero.
+	integrated time.Time
+}
+
+func newFakeLog() *fakeLog {
+	return &fakeLog{tree: inmemory.New(rfc6962.DefaultHasher), badLeaf: -1, pruned: -1}
+}
+
+func (f *fakeLog) add(n int) {
+	for i := 0; i < n; i++ {
+		d := []byte(fmt.Sprintf("leaf %d", len(f.data)))
+		f.data = append(f.data, d)
+		f.tree.AppendData(d)
+	}
+}
+
+func (f *fakeLog) GetLatestSignedLogRoot(ctx context.Context, in *trillian.GetLatestSignedLogRootRequest, opts ...grpc.CallOption) (*trillian.GetLatestSignedLogRootResponse, error) {
+	root := &types.LogRootV1{TreeSize: f.tree.Size(), RootHash: f.tree.Hash(), TimestampNanos: uint64(time.Now().UnixNano())}
+	if f.badRoot {
+		root.RootHash = rfc6962.DefaultHasher.HashLeaf([]byte("bad"))
+	}
+	logRoot, err := root.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return &trillian.GetLatestSignedLogRootResponse{SignedLogRoot: &trillian.SignedLogRoot{LogRoot: logRoot}}, nil
+}
+
+func (f *fakeLog) GetLeavesByRange(ctx context.Context, in *trillian.GetLeavesByRangeRequest, opts ...grpc.CallOption) (*trillian.GetLeavesByRangeResponse, error) {
+	f.requests++
+	resp := &trillian.GetLeavesByRangeResponse{}
+	for i := in.StartIndex; i < in.StartIndex+in.Count && i < int64(len(f.data)); i++ {
+		leaf := prepareLeaf(rfc6962.DefaultHasher, f.data[i])
+		leaf.LeafIndex = i
+		if i == f.pruned {
+			leaf.LeafValue, leaf.ExtraData = nil, nil
+		}
+		switch {
+		case i == f.badLeaf && i == f.pruned:
+			leaf.MerkleLeafHash = rfc6962.DefaultHasher.HashLeaf([]byte("tampered"))
+		case i == f.badLeaf:
+			leaf.LeafValue = []byte("tampered")
+		}
+		if !f.integrated.IsZero() {
+			leaf.IntegrateTimestamp = timestamppb.New(f.integrated)
+		}
+		resp.Leaves = append(resp.Leaves, leaf)
+	}
+	return resp, nil
+}
+
+func TestRangeTrackerUpdate(t *testing.T) {
+	ctx := context.Background()
+	log := newFakeLog()
+	tracker := NewRangeTracker(1, log, rfc6962.DefaultHasher)
+	tracker.BatchSize = 3
+
+	if root, err := tracker.Update(ctx); err != nil || root != nil {
+		t.Fatalf("Update() on empty log: %v, %v, want nil, nil", root, err)
+	}
+	for _, add := range []int{1, 6, 0, 13} {
+		log.add(add)
+		root, err := tracker.Update(ctx)
+		if err != nil {
+			t.Fatalf("Update() at size %d: %v", log.tree.Size(), err)
+		}
+		if add == 0 {
+			if root != nil {
+				t.Errorf("Update() without new leaves returned %v, want nil", root)
+			}
+			continue
+		}
+		if got, want := root.TreeSize, log.tree.Size(); got != want {
+			t.Errorf("Update() returned size %d, want %d", got, want)
+		}
+		if got := tracker.Root(); !bytes.Equal(got.RootHash, log.tree.Hash()) {
+			t.Errorf("Root() = %x, want %x", got.RootHash, log.tree.Hash())
+		}
+	}
+	if got, want := log.requests, 1+2+5; got != want {
+		t.Errorf("GetLeavesByRange called %d times, want %d", got, want)
+	}
+
+	// A tracker resumed from the state carries on from the same size.
+	size, hashes := tracker.State()
+	resumed, err := NewRangeTrackerFromState(1, log, rfc6962.DefaultHasher, size, hashes)
+	if err != nil {
+		t.Fatalf("NewRangeTrackerFromState(): %v", err)
+	}
+	log.add(2)
+	log.requests = 0
+	if _, err := resumed.Update(ctx); err != nil {
+		t.Fatalf("Update() after resume: %v", err)
+	}
+	if got, want := log.requests, 1; got != want {
+		t.Errorf("GetLeavesByRange called %d times after resume, want %d", got, want)
+	}
+}
+
+func TestRangeTrackerPrunedLeaf(t *testing.T) {
+	ctx := context.Background()
+	log := newFakeLog()
+	log.add(7)
+	log.pruned = 3
+	tracker := NewRangeTracker(1, log, rfc6962.DefaultHasher)
+	root, err := tracker.Update(ctx)
+	if err != nil {
+		t.Fatalf("Update() with pruned leaf: %v", err)
+	}
+	if got, want := root.TreeSize, log.tree.Size(); got != want {
+		t.Errorf("Update() returned size %d, want %d", got, want)
+	}
+	if got := tracker.Root(); !bytes.Equal(got.RootHash, log.tree.Hash()) {
+		t.Errorf("Root() = %x, want %x", got.RootHash, log.tree.Hash())
+	}
+}
+
+func TestRangeTrackerUpdateErrors(t *testing.T) {
+	ctx := context.Background()
+	for _, test := range []struct {
+		desc    string
+		setup   func(*fakeLog)
+		wantErr string
+	}{
+		{desc: "bad-root", setup: func(f *fakeLog) { f.badRoot = true }, wantErr: "computed"},
+		{desc: "bad-leaf", setup: func(f *fakeLog) { f.badLeaf = 5 }, wantErr: "leaf 5 has MerkleLeafHash"},
+		// The hash of a pruned leaf is only checked by the root hash.
+		{desc: "bad-pruned-leaf", setup: func(f *fakeLog) { f.badLeaf, f.pruned = 5, 5 }, wantErr: "computed"},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			log := newFakeLog()
+			log.add(4)
+			tracker := NewRangeTracker(1, log, rfc6962.DefaultHasher)
+			if _, err := tracker.Update(ctx); err != nil {
+				t.Fatalf("Update(): %v", err)
+			}
+			before := tracker.Root()
+
+			log.add(4)
+			test.setup(log)
+			if _, err := tracker.Update(ctx); err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("Update(): %v, want error containing %q", err, test.wantErr)
+			}
+			if got := tracker.Root(); got.TreeSize != before.TreeSize || !bytes.Equal(got.RootHash, before.RootHash) {
+				t.Errorf("Root() after failed Update() = %v, want %v", got, before)
+			}
+		})
+	}
+
+	if _, err := NewRangeTrackerFromState(1, nil, rfc6962.DefaultHasher, 5, nil); err == nil {
+		t.Error("NewRangeTrackerFromState() with missing hashes returned nil error")
+	}
+}
+
+func TestRangeTrackerWatch(t *testing.T) {
+	log := newFakeLog()
+	log.add(3)
+	tracker := NewRangeTracker(1, log, rfc6962.DefaultHasher)
+
+	errStop := errors.New("stop")
+	var sizes []uint64
+	err := tracker.Watch(context.Background(), time.Millisecond, func(root *types.LogRootV1) error {
+		sizes = append(sizes, root.TreeSize)
+		if len(sizes) == 3 {
+			return errStop
+		}
+		log.add(2)
+		return nil
+	})
+	if err != errStop {
+		t.Errorf("Watch(): %v, want %v", err, errStop)
+	}
+	if got, want := fmt.Sprint(sizes), "[3 5 7]"; got != want {
+		t.Errorf("Watch() saw sizes %s, want %s", got, want)
+	}
+}
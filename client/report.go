@@ -0,0 +1,47 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+
+	"github.com/google/trillian"
+)
+
+// maxReportDetails is the maximum length of the details of a verification
+// failure report accepted by the log.
+const maxReportDetails = 1024
+
+// ReportVerificationFailure reports to the log that the response to the
+// request with the given ID failed verification with verr, so that the log's
+// operators are alerted. The client's trusted root is attached to the report.
+func (c *LogClient) ReportVerificationFailure(ctx context.Context, failure trillian.VerificationFailure, requestID string, verr error) error {
+	root, err := c.GetRoot().MarshalBinary()
+	if err != nil {
+		return err
+	}
+	details := verr.Error()
+	if len(details) > maxReportDetails {
+		details = details[:maxReportDetails]
+	}
+	_, err = c.client.ReportVerificationFailure(ctx, &trillian.ReportVerificationFailureRequest{
+		LogId:     c.LogID,
+		Failure:   failure,
+		RequestId: requestID,
+		LogRoot:   root,
+		Details:   details,
+	})
+	return err
+}
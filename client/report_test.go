@@ -0,0 +1,72 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/types"
+	"google.golang.org/grpc"
+)
+
+// reportRecorder is a log which records the verification failure reports it
+// receives.
+type reportRecorder struct {
+	trillian.TrillianLogClient
+	reports []*trillian.ReportVerificationFailureRequest
+}
+
+func (r *reportRecorder) ReportVerificationFailure(ctx context.Context, in *trillian.ReportVerificationFailureRequest, opts ...grpc.CallOption) (*trillian.ReportVerificationFailureResponse, error) {
+	r.reports = append(r.reports, in)
+	return &trillian.ReportVerificationFailureResponse{}, nil
+}
+
+func TestReportVerificationFailure(t *testing.T) {
+	ctx := context.Background()
+	log := &reportRecorder{}
+	trusted := types.LogRootV1{TreeSize: 5, RootHash: []byte("root"), TimestampNanos: 10}
+	c := New(42, log, nil, trusted)
+
+	for _, verr := range []error{
+		errors.New("bad proof"),
+		errors.New(strings.Repeat("x", 2*maxReportDetails)),
+	} {
+		if err := c.ReportVerificationFailure(ctx, trillian.VerificationFailure_INCLUSION_PROOF_MISMATCH, "req-1", verr); err != nil {
+			t.Fatalf("ReportVerificationFailure(): %v", err)
+		}
+	}
+	if got, want := len(log.reports), 2; got != want {
+		t.Fatalf("got %d reports, want %d", got, want)
+	}
+
+	r := log.reports[0]
+	if r.LogId != 42 || r.Failure != trillian.VerificationFailure_INCLUSION_PROOF_MISMATCH || r.RequestId != "req-1" || r.Details != "bad proof" {
+		t.Errorf("ReportVerificationFailure() sent %v", r)
+	}
+	var root types.LogRootV1
+	if err := root.UnmarshalBinary(r.LogRoot); err != nil {
+		t.Fatalf("UnmarshalBinary(LogRoot): %v", err)
+	}
+	if root.TreeSize != trusted.TreeSize || string(root.RootHash) != string(trusted.RootHash) {
+		t.Errorf("ReportVerificationFailure() sent root %+v, want %+v", root, trusted)
+	}
+	if got := len(log.reports[1].Details); got != maxReportDetails {
+		t.Errorf("ReportVerificationFailure() sent %d bytes of details, want %d", got, maxReportDetails)
+	}
+}
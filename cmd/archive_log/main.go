@@ -0,0 +1,122 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main contains the implementation and entry point for the
+// archive_log command, which writes the leaves and latest root of a log into
+// an archive bundle, and verifies the bundle.
+//
+// Example usage:
+// $ ./archive_log --log_server=host:port --log_id=123 --archive_dir=/archive/log-123
+//
+// The log should be frozen first, so that the archived root is its final one.
+// The bundle can be checked later, without access to the log, with
+// verify_archive.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian"
+	"github.com/google/trillian/archive"
+	"github.com/google/trillian/client/rpcflags"
+	"github.com/google/trillian/types"
+	"github.com/google/trillian/util"
+	"google.golang.org/grpc"
+)
+
+var (
+	logServerAddr = flag.String("log_server", "", "Address of the gRPC Trillian Log Server (host:port)")
+	logID         = flag.Int64("log_id", 0, "ID of the log to archive")
+	archiveDir    = flag.String("archive_dir", "", "Directory to write the bundle to; must not exist")
+	chunkSize     = flag.Uint64("chunk_size", 1<<16, "Number of leaves per leaf chunk of the bundle")
+	batchSize     = flag.Int64("batch_size", 1000, "Maximum number of leaves to request with each GetLeavesByRange call")
+)
+
+func main() {
+	flag.Parse()
+	defer glog.Flush()
+
+	if *logServerAddr == "" || *logID == 0 || *archiveDir == "" {
+		glog.Exit("--log_server, --log_id and --archive_dir must be set")
+	}
+	if *chunkSize == 0 || *batchSize <= 0 {
+		glog.Exit("--chunk_size and --batch_size must be positive")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go util.AwaitSignal(ctx, cancel)
+
+	dialOpts, err := rpcflags.NewClientDialOptionsFromFlags()
+	if err != nil {
+		glog.Exitf("Failed to determine dial options: %v", err)
+	}
+	conn, err := grpc.Dial(*logServerAddr, dialOpts...)
+	if err != nil {
+		glog.Exitf("Failed to dial %v: %v", *logServerAddr, err)
+	}
+	defer conn.Close()
+
+	m, err := archiveLog(ctx, trillian.NewTrillianLogClient(conn), *logID, *archiveDir, *chunkSize, *batchSize)
+	if err != nil {
+		glog.Exitf("Failed to archive log %d: %v", *logID, err)
+	}
+	fmt.Printf("Archived and verified %d leaves of log %d, root hash %s\n", m.TreeSize, m.LogID, m.RootHash)
+}
+
+// archiveLog writes the leaves of the log up to its latest root into a
+// bundle in dir, and verifies the bundle.
+func archiveLog(ctx context.Context, client trillian.TrillianLogClient, logID int64, dir string, chunkSize uint64, batchSize int64) (*archive.Manifest, error) {
+	rsp, err := client.GetLatestSignedLogRoot(ctx, &trillian.GetLatestSignedLogRootRequest{LogId: logID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest root: %v", err)
+	}
+	var root types.LogRootV1
+	if err := root.UnmarshalBinary(rsp.GetSignedLogRoot().GetLogRoot()); err != nil {
+		return nil, fmt.Errorf("failed to parse latest root: %v", err)
+	}
+
+	w, err := archive.NewWriter(dir, logID, chunkSize)
+	if err != nil {
+		return nil, err
+	}
+	for next := int64(0); next < int64(root.TreeSize); {
+		count := int64(root.TreeSize) - next
+		if count > batchSize {
+			count = batchSize
+		}
+		leaves, err := client.GetLeavesByRange(ctx, &trillian.GetLeavesByRangeRequest{LogId: logID, StartIndex: next, Count: count})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get leaves from index %d: %v", next, err)
+		}
+		if len(leaves.Leaves) == 0 {
+			return nil, fmt.Errorf("log returned no leaves from index %d", next)
+		}
+		if err := w.AppendLeaves(leaves.Leaves...); err != nil {
+			return nil, err
+		}
+		next += int64(len(leaves.Leaves))
+		glog.V(1).Infof("Archived %d of %d leaves", next, root.TreeSize)
+	}
+	if err := w.AddRoot(rsp.SignedLogRoot); err != nil {
+		return nil, err
+	}
+	if _, err := w.Close(); err != nil {
+		return nil, err
+	}
+	return archive.Verify(dir)
+}
@@ -0,0 +1,78 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/types"
+	"github.com/transparency-dev/merkle/rfc6962"
+	inmemory "github.com/transparency-dev/merkle/testonly"
+	"google.golang.org/grpc"
+)
+
+// fakeLog serves the leaves and root of an in-memory tree, returning at most
+// maxLeaves leaves per request.
+type fakeLog struct {
+	trillian.TrillianLogClient
+	data      [][]byte
+	tree      *inmemory.Tree
+	maxLeaves int64
+}
+
+func (f *fakeLog) GetLatestSignedLogRoot(ctx context.Context, in *trillian.GetLatestSignedLogRootRequest, opts ...grpc.CallOption) (*trillian.GetLatestSignedLogRootResponse, error) {
+	root, err := (&types.LogRootV1{TreeSize: f.tree.Size(), RootHash: f.tree.Hash()}).MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return &trillian.GetLatestSignedLogRootResponse{SignedLogRoot: &trillian.SignedLogRoot{LogRoot: root}}, nil
+}
+
+func (f *fakeLog) GetLeavesByRange(ctx context.Context, in *trillian.GetLeavesByRangeRequest, opts ...grpc.CallOption) (*trillian.GetLeavesByRangeResponse, error) {
+	rsp := &trillian.GetLeavesByRangeResponse{}
+	for i := in.StartIndex; i < in.StartIndex+in.Count && i < in.StartIndex+f.maxLeaves && i < int64(len(f.data)); i++ {
+		rsp.Leaves = append(rsp.Leaves, &trillian.LogLeaf{LeafIndex: i, LeafValue: f.data[i]})
+	}
+	return rsp, nil
+}
+
+func TestArchiveLog(t *testing.T) {
+	log := &fakeLog{tree: inmemory.New(rfc6962.DefaultHasher), maxLeaves: 3}
+	for i := 0; i < 20; i++ {
+		d := []byte(fmt.Sprintf("leaf %d", i))
+		log.data = append(log.data, d)
+		log.tree.AppendData(d)
+	}
+
+	dir := filepath.Join(t.TempDir(), "bundle")
+	m, err := archiveLog(context.Background(), log, 7, dir, 8, 5)
+	if err != nil {
+		t.Fatalf("archiveLog(): %v", err)
+	}
+	if got, want := m.TreeSize, uint64(20); got != want {
+		t.Errorf("TreeSize=%d, want %d", got, want)
+	}
+	if got, want := m.RootHash, hex.EncodeToString(log.tree.Hash()); got != want {
+		t.Errorf("RootHash=%s, want %s", got, want)
+	}
+	if _, err := archiveLog(context.Background(), log, 7, dir, 8, 5); err == nil {
+		t.Error("archiveLog() into existing directory succeeded")
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian"
+	"github.com/google/trillian/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// cloner copies the entries of a CT log into a PREORDERED_LOG Trillian tree.
+type cloner struct {
+	ct           *ctClient
+	admin        trillian.TrillianAdminClient
+	log          trillian.TrillianLogClient
+	treeID       int64
+	batchSize    uint64
+	pollInterval time.Duration
+}
+
+// clone copies all the entries covered by the latest STH of the CT log which
+// the tree doesn't have yet, waits for them to be integrated, and checks that
+// the resulting root hash matches the STH. Cloning resumes from the current
+// size of the tree, so it can be run repeatedly to follow the source log.
+func (c *cloner) clone(ctx context.Context) (*types.LogRootV1, error) {
+	if err := c.checkTree(ctx); err != nil {
+		return nil, err
+	}
+	sth, err := c.ct.getSTH(ctx)
+	if err != nil {
+		return nil, err
+	}
+	root, err := c.latestRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if root.TreeSize > sth.TreeSize {
+		return nil, fmt.Errorf("tree %d has size %d, beyond the STH size %d", c.treeID, root.TreeSize, sth.TreeSize)
+	}
+	glog.Infof("Cloning entries [%d, %d) into tree %d", root.TreeSize, sth.TreeSize, c.treeID)
+
+	for next := root.TreeSize; next < sth.TreeSize; {
+		end := next + c.batchSize
+		if end > sth.TreeSize {
+			end = sth.TreeSize
+		}
+		entries, err := c.ct.getEntries(ctx, next, end-1)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.addEntries(ctx, next, entries); err != nil {
+			return nil, err
+		}
+		next += uint64(len(entries))
+		glog.V(1).Infof("Added entries up to %d", next)
+	}
+
+	if root, err = c.waitForSize(ctx, sth.TreeSize); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(root.RootHash, sth.SHA256RootHash) {
+		return nil, fmt.Errorf("root hash mismatch at size %d: tree has %x, STH has %x", sth.TreeSize, root.RootHash, sth.SHA256RootHash)
+	}
+	return root, nil
+}
+
+// checkTree returns an error unless the tree is a PREORDERED_LOG which accepts
+// leaves identical to those stored at their index. Cloning resumes from the
+// size of the tree, so the leaves added by an interrupted run which weren't
+// integrated yet are added again, and storage reports them as conflicting
+// with themselves unless the tree's policy resolves that.
+func (c *cloner) checkTree(ctx context.Context) error {
+	tree, err := c.admin.GetTree(ctx, &trillian.GetTreeRequest{TreeId: c.treeID})
+	if err != nil {
+		return fmt.Errorf("GetTree(): %v", err)
+	}
+	if got, want := tree.TreeType, trillian.TreeType_PREORDERED_LOG; got != want {
+		return fmt.Errorf("tree %d has type %v, want %v", c.treeID, got, want)
+	}
+	if got, want := tree.SequencedLeafConflictPolicy, trillian.SequencedLeafConflictPolicy_CONFLICT_OVERWRITE_IF_IDENTICAL; got != want {
+		return fmt.Errorf("tree %d has sequenced_leaf_conflict_policy %v, want %v so that interrupted runs can be resumed", c.treeID, got, want)
+	}
+	return nil
+}
+
+func (c *cloner) addEntries(ctx context.Context, start uint64, entries []logEntry) error {
+	leaves := make([]*trillian.LogLeaf, 0, len(entries))
+	for i, e := range entries {
+		leaves = append(leaves, &trillian.LogLeaf{
+			LeafValue: e.LeafInput,
+			ExtraData: e.ExtraData,
+			LeafIndex: int64(start) + int64(i),
+		})
+	}
+	resp, err := c.log.AddSequencedLeaves(ctx, &trillian.AddSequencedLeavesRequest{LogId: c.treeID, Leaves: leaves})
+	if err != nil {
+		return fmt.Errorf("AddSequencedLeaves(): %v", err)
+	}
+	for _, res := range resp.GetResults() {
+		// Leaves left behind by an interrupted run are accepted by the tree's
+		// conflict policy if they're identical, see checkTree.
+		if s := status.FromProto(res.GetStatus()); s.Code() != codes.OK {
+			return fmt.Errorf("failed to add leaf %d: %v", res.GetLeaf().GetLeafIndex(), s.Err())
+		}
+	}
+	return nil
+}
+
+func (c *cloner) latestRoot(ctx context.Context) (*types.LogRootV1, error) {
+	resp, err := c.log.GetLatestSignedLogRoot(ctx, &trillian.GetLatestSignedLogRootRequest{LogId: c.treeID})
+	if err != nil {
+		return nil, fmt.Errorf("GetLatestSignedLogRoot(): %v", err)
+	}
+	var root types.LogRootV1
+	if err := root.UnmarshalBinary(resp.GetSignedLogRoot().GetLogRoot()); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal root: %v", err)
+	}
+	return &root, nil
+}
+
+// waitForSize polls the tree until its size reaches size.
+func (c *cloner) waitForSize(ctx context.Context, size uint64) (*types.LogRootV1, error) {
+	for {
+		root, err := c.latestRoot(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if root.TreeSize > size {
+			return nil, fmt.Errorf("tree %d grew to size %d, beyond the STH size %d", c.treeID, root.TreeSize, size)
+		}
+		if root.TreeSize == size {
+			return root, nil
+		}
+		glog.V(1).Infof("Waiting for tree %d to grow from %d to %d", c.treeID, root.TreeSize, size)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(c.pollInterval):
+		}
+	}
+}
@@ -0,0 +1,313 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/types"
+	"github.com/transparency-dev/merkle/compact"
+	"github.com/transparency-dev/merkle/rfc6962"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// signSTH sets the tree_head_signature of the STH, signed with the key.
+func signSTH(t *testing.T, sth *signedTreeHead, key crypto.Signer) {
+	t.Helper()
+	digest := sha256.Sum256(sth.signedData())
+	var sigAlg byte = signatureAlgorithmEC
+	if _, ok := key.(*rsa.PrivateKey); ok {
+		sigAlg = signatureAlgorithmRSA
+	}
+	sig, err := key.Sign(rand.Reader, digest[:], crypto.SHA256)
+	if err != nil {
+		t.Fatalf("Sign(): %v", err)
+	}
+	sth.TreeHeadSignature = []byte{hashAlgorithmSHA256, sigAlg, 0, 0}
+	binary.BigEndian.PutUint16(sth.TreeHeadSignature[2:], uint16(len(sig)))
+	sth.TreeHeadSignature = append(sth.TreeHeadSignature, sig...)
+}
+
+func newECDSAKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey(): %v", err)
+	}
+	return key
+}
+
+// fakeCTLog serves get-sth and get-entries for a log of the given entries,
+// returning at most maxEntries entries per get-entries call. Its STH is
+// signed with key.
+func fakeCTLog(t *testing.T, entries []logEntry, rootHash []byte, maxEntries int, key crypto.Signer) *httptest.Server {
+	t.Helper()
+	sth := signedTreeHead{TreeSize: uint64(len(entries)), Timestamp: 1000, SHA256RootHash: rootHash}
+	signSTH(t, &sth, key)
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var resp interface{}
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/ct/v1/get-sth"):
+			resp = sth
+		case strings.HasSuffix(r.URL.Path, "/ct/v1/get-entries"):
+			start, err1 := strconv.Atoi(r.URL.Query().Get("start"))
+			end, err2 := strconv.Atoi(r.URL.Query().Get("end"))
+			if err1 != nil || err2 != nil || start > end || end >= len(entries) {
+				http.Error(w, "bad range", http.StatusBadRequest)
+				return
+			}
+			if end-start+1 > maxEntries {
+				end = start + maxEntries - 1
+			}
+			resp = getEntriesResponse{Entries: entries[start : end+1]}
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			t.Errorf("Encode(): %v", err)
+		}
+	}))
+}
+
+// fakeAdmin is a TrillianAdminClient which returns a single tree.
+type fakeAdmin struct {
+	trillian.TrillianAdminClient
+	tree *trillian.Tree
+}
+
+func (f *fakeAdmin) GetTree(ctx context.Context, req *trillian.GetTreeRequest, opts ...grpc.CallOption) (*trillian.Tree, error) {
+	return f.tree, nil
+}
+
+// fakeLog is a TrillianLogClient for a PREORDERED_LOG. The first integrated
+// of its stored leaves are covered by the latest root, and each root request
+// integrates the rest, the way a signer would between requests.
+type fakeLog struct {
+	trillian.TrillianLogClient
+	policy     trillian.SequencedLeafConflictPolicy
+	leaves     [][]byte
+	integrated int
+}
+
+// AddSequencedLeaves reports leaves at stored indices the way storage does,
+// as FailedPrecondition, unless the conflict policy accepts identical ones.
+func (f *fakeLog) AddSequencedLeaves(ctx context.Context, req *trillian.AddSequencedLeavesRequest, opts ...grpc.CallOption) (*trillian.AddSequencedLeavesResponse, error) {
+	resp := &trillian.AddSequencedLeavesResponse{}
+	for _, leaf := range req.Leaves {
+		st := status.New(codes.OK, "OK")
+		switch idx := int(leaf.LeafIndex); {
+		case idx < len(f.leaves):
+			if f.policy != trillian.SequencedLeafConflictPolicy_CONFLICT_OVERWRITE_IF_IDENTICAL || !bytes.Equal(f.leaves[idx], leaf.LeafValue) {
+				st = status.New(codes.FailedPrecondition, "conflicting LeafIndex")
+			}
+		case idx == len(f.leaves):
+			f.leaves = append(f.leaves, leaf.LeafValue)
+		default:
+			return nil, status.Errorf(codes.FailedPrecondition, "gap before leaf %d", idx)
+		}
+		resp.Results = append(resp.Results, &trillian.QueuedLogLeaf{Leaf: leaf, Status: st.Proto()})
+	}
+	return resp, nil
+}
+
+func (f *fakeLog) GetLatestSignedLogRoot(ctx context.Context, req *trillian.GetLatestSignedLogRootRequest, opts ...grpc.CallOption) (*trillian.GetLatestSignedLogRootResponse, error) {
+	leaves := f.leaves[:f.integrated]
+	f.integrated = len(f.leaves)
+	logRoot, err := (&types.LogRootV1{TreeSize: uint64(len(leaves)), RootHash: rootHash(leaves)}).MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return &trillian.GetLatestSignedLogRootResponse{SignedLogRoot: &trillian.SignedLogRoot{LogRoot: logRoot}}, nil
+}
+
+func rootHash(leaves [][]byte) []byte {
+	if len(leaves) == 0 {
+		return rfc6962.DefaultHasher.EmptyRoot()
+	}
+	cr := (&compact.RangeFactory{Hash: rfc6962.DefaultHasher.HashChildren}).NewEmptyRange(0)
+	for _, l := range leaves {
+		if err := cr.Append(rfc6962.DefaultHasher.HashLeaf(l), nil); err != nil {
+			panic(err)
+		}
+	}
+	hash, err := cr.GetRootHash(nil)
+	if err != nil {
+		panic(err)
+	}
+	return hash
+}
+
+func TestClone(t *testing.T) {
+	var entries []logEntry
+	var inputs [][]byte
+	for i := 0; i < 21; i++ {
+		input := []byte(fmt.Sprintf("leaf input %d", i))
+		entries = append(entries, logEntry{LeafInput: input, ExtraData: []byte("chain")})
+		inputs = append(inputs, input)
+	}
+	conflicting := append([][]byte(nil), inputs[:10]...)
+	conflicting[8] = []byte("other leaf")
+	key := newECDSAKey(t)
+
+	for _, test := range []struct {
+		desc       string
+		rootHash   []byte
+		treeType   trillian.TreeType
+		failPolicy bool
+		stored     [][]byte
+		integrated int
+		batchSize  uint64
+		sthKey     crypto.Signer
+		wantErr    bool
+	}{
+		{desc: "from-scratch", rootHash: rootHash(inputs), batchSize: 5},
+		{desc: "resume", rootHash: rootHash(inputs), stored: inputs[:7], integrated: 7, batchSize: 100},
+		{desc: "resume-unintegrated", rootHash: rootHash(inputs), stored: inputs[:10], integrated: 7, batchSize: 2},
+		{desc: "up-to-date", rootHash: rootHash(inputs), stored: inputs, integrated: 21, batchSize: 5},
+		{desc: "root-mismatch", rootHash: rootHash(inputs[:20]), batchSize: 5, wantErr: true},
+		{desc: "conflicting-leaf", rootHash: rootHash(inputs), stored: conflicting, integrated: 7, batchSize: 5, wantErr: true},
+		{desc: "conflict-fail-policy", rootHash: rootHash(inputs), failPolicy: true, stored: inputs[:10], integrated: 7, batchSize: 5, wantErr: true},
+		{desc: "not-preordered", rootHash: rootHash(inputs), treeType: trillian.TreeType_LOG, batchSize: 5, wantErr: true},
+		{desc: "sth-wrong-key", rootHash: rootHash(inputs), batchSize: 5, sthKey: newECDSAKey(t), wantErr: true},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			sthKey := test.sthKey
+			if sthKey == nil {
+				sthKey = key
+			}
+			srv := fakeCTLog(t, entries, test.rootHash, 3, sthKey)
+			defer srv.Close()
+
+			treeType := test.treeType
+			if treeType == trillian.TreeType_UNKNOWN_TREE_TYPE {
+				treeType = trillian.TreeType_PREORDERED_LOG
+			}
+			policy := trillian.SequencedLeafConflictPolicy_CONFLICT_OVERWRITE_IF_IDENTICAL
+			if test.failPolicy {
+				policy = trillian.SequencedLeafConflictPolicy_CONFLICT_FAIL
+			}
+			log := &fakeLog{policy: policy, leaves: append([][]byte(nil), test.stored...), integrated: test.integrated}
+			c := &cloner{
+				ct:           newCTClient(srv.URL+"/", srv.Client(), key.Public()),
+				admin:        &fakeAdmin{tree: &trillian.Tree{TreeId: 1, TreeType: treeType, SequencedLeafConflictPolicy: policy}},
+				log:          log,
+				treeID:       1,
+				batchSize:    test.batchSize,
+				pollInterval: time.Millisecond,
+			}
+			root, err := c.clone(ctx)
+			if gotErr := err != nil; gotErr != test.wantErr {
+				t.Fatalf("clone()=_, %v, want err: %v", err, test.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got, want := root.TreeSize, uint64(len(entries)); got != want {
+				t.Errorf("clone() root size=%d, want %d", got, want)
+			}
+			if got, want := len(log.leaves), len(entries); got != want {
+				t.Errorf("tree has %d leaves, want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestSTHVerify(t *testing.T) {
+	ecKey := newECDSAKey(t)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey(): %v", err)
+	}
+	newSTH := func(key crypto.Signer) *signedTreeHead {
+		sth := &signedTreeHead{TreeSize: 10, Timestamp: 1000, SHA256RootHash: rootHash([][]byte{[]byte("leaf")})}
+		signSTH(t, sth, key)
+		return sth
+	}
+
+	for _, test := range []struct {
+		desc    string
+		sth     *signedTreeHead
+		modify  func(*signedTreeHead)
+		pub     crypto.PublicKey
+		wantErr bool
+	}{
+		{desc: "ecdsa", sth: newSTH(ecKey), pub: ecKey.Public()},
+		{desc: "rsa", sth: newSTH(rsaKey), pub: rsaKey.Public()},
+		{desc: "wrong-key", sth: newSTH(ecKey), pub: newECDSAKey(t).Public(), wantErr: true},
+		{desc: "wrong-key-type", sth: newSTH(ecKey), pub: rsaKey.Public(), wantErr: true},
+		{desc: "modified-size", sth: newSTH(ecKey), modify: func(sth *signedTreeHead) { sth.TreeSize++ }, pub: ecKey.Public(), wantErr: true},
+		{desc: "modified-root", sth: newSTH(rsaKey), modify: func(sth *signedTreeHead) { sth.SHA256RootHash[0] ^= 1 }, pub: rsaKey.Public(), wantErr: true},
+		{desc: "wrong-hash-algorithm", sth: newSTH(ecKey), modify: func(sth *signedTreeHead) { sth.TreeHeadSignature[0] = 2 }, pub: ecKey.Public(), wantErr: true},
+		{desc: "truncated", sth: newSTH(ecKey), modify: func(sth *signedTreeHead) { sth.TreeHeadSignature = sth.TreeHeadSignature[:10] }, pub: ecKey.Public(), wantErr: true},
+		{desc: "missing", sth: &signedTreeHead{TreeSize: 10}, pub: ecKey.Public(), wantErr: true},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			if test.modify != nil {
+				test.modify(test.sth)
+			}
+			if err := test.sth.verify(test.pub); (err != nil) != test.wantErr {
+				t.Errorf("verify()=%v, want err: %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestCTClient_Errors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/ct/v1/get-sth"):
+			fmt.Fprint(w, `{"tree_size": 10, "sha256_root_hash": "c2hvcnQ="}`)
+		case strings.HasSuffix(r.URL.Path, "/ct/v1/get-entries"):
+			fmt.Fprint(w, `{"entries": []}`)
+		default:
+			http.Error(w, "nope", http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+	ctx := context.Background()
+	c := newCTClient(srv.URL, srv.Client(), newECDSAKey(t).Public())
+
+	if _, err := c.getSTH(ctx); err == nil {
+		t.Error("getSTH() with short root hash returned nil error")
+	}
+	if _, err := c.getEntries(ctx, 0, 5); err == nil {
+		t.Error("getEntries() with no entries returned nil error")
+	}
+	if err := c.get(ctx, "get-roots", nil, &struct{}{}); err == nil {
+		t.Error("get() with HTTP error returned nil error")
+	}
+}
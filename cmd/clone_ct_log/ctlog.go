@@ -0,0 +1,195 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// The TLS encoded values of the RFC 6962 structures signed by a log.
+const (
+	hashAlgorithmSHA256   = 4
+	signatureAlgorithmRSA = 1
+	signatureAlgorithmEC  = 3
+	versionV1             = 0
+	signatureTypeTreeHash = 1
+)
+
+// signedTreeHead is the response of the RFC 6962 get-sth endpoint.
+type signedTreeHead struct {
+	TreeSize          uint64 `json:"tree_size"`
+	Timestamp         uint64 `json:"timestamp"`
+	SHA256RootHash    []byte `json:"sha256_root_hash"`
+	TreeHeadSignature []byte `json:"tree_head_signature"`
+}
+
+// logEntry is a single entry in the response of the RFC 6962 get-entries
+// endpoint. LeafInput is the MerkleTreeLeaf structure whose hash is committed
+// to by the log.
+type logEntry struct {
+	LeafInput []byte `json:"leaf_input"`
+	ExtraData []byte `json:"extra_data"`
+}
+
+// signedData returns the TLS encoded TreeHeadSignature struct of RFC 6962
+// section 3.5, which the tree_head_signature of the STH is over.
+func (sth *signedTreeHead) signedData() []byte {
+	data := make([]byte, 2+8+8, 2+8+8+len(sth.SHA256RootHash))
+	data[0], data[1] = versionV1, signatureTypeTreeHash
+	binary.BigEndian.PutUint64(data[2:], sth.Timestamp)
+	binary.BigEndian.PutUint64(data[10:], sth.TreeSize)
+	return append(data, sth.SHA256RootHash...)
+}
+
+// verify checks the tree_head_signature of the STH, a TLS encoded
+// DigitallySigned struct over the TreeHeadSignature of RFC 6962 section 3.5,
+// with the public key of the log.
+func (sth *signedTreeHead) verify(pub crypto.PublicKey) error {
+	sig := sth.TreeHeadSignature
+	if len(sig) < 4 {
+		return fmt.Errorf("tree_head_signature of %d bytes is too short", len(sig))
+	}
+	if sig[0] != hashAlgorithmSHA256 {
+		return fmt.Errorf("tree_head_signature has hash algorithm %d, want SHA-256 (%d)", sig[0], hashAlgorithmSHA256)
+	}
+	if got, want := int(binary.BigEndian.Uint16(sig[2:4])), len(sig)-4; got != want {
+		return fmt.Errorf("tree_head_signature has length %d, want %d", got, want)
+	}
+
+	digest := sha256.Sum256(sth.signedData())
+
+	switch pub := pub.(type) {
+	case *ecdsa.PublicKey:
+		if sig[1] != signatureAlgorithmEC || !ecdsa.VerifyASN1(pub, digest[:], sig[4:]) {
+			return errors.New("invalid ECDSA tree_head_signature")
+		}
+	case *rsa.PublicKey:
+		if sig[1] != signatureAlgorithmRSA || rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], sig[4:]) != nil {
+			return errors.New("invalid RSA tree_head_signature")
+		}
+	default:
+		return fmt.Errorf("unsupported public key type %T", pub)
+	}
+	return nil
+}
+
+// readPublicKey reads the PEM encoded public key of a CT log from a file, as
+// listed in the CT log lists.
+func readPublicKey(file string) (crypto.PublicKey, error) {
+	keyPEM, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(keyPEM)
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, fmt.Errorf("no PUBLIC KEY PEM block in %s", file)
+	}
+	return x509.ParsePKIXPublicKey(block.Bytes)
+}
+
+type getEntriesResponse struct {
+	Entries []logEntry `json:"entries"`
+}
+
+// ctClient talks to the read API of an RFC 6962 Certificate Transparency log.
+type ctClient struct {
+	baseURL string
+	hc      *http.Client
+	// pub is the public key of the log, which its STHs are verified with.
+	pub crypto.PublicKey
+}
+
+func newCTClient(baseURL string, hc *http.Client, pub crypto.PublicKey) *ctClient {
+	return &ctClient{baseURL: strings.TrimRight(baseURL, "/"), hc: hc, pub: pub}
+}
+
+// getSTH returns the latest signed tree head of the log, after verifying its
+// signature, as its root hash is what the clone is checked against.
+func (c *ctClient) getSTH(ctx context.Context) (*signedTreeHead, error) {
+	var sth signedTreeHead
+	if err := c.get(ctx, "get-sth", nil, &sth); err != nil {
+		return nil, err
+	}
+	if len(sth.SHA256RootHash) != 32 {
+		return nil, fmt.Errorf("get-sth returned root hash of %d bytes, want 32", len(sth.SHA256RootHash))
+	}
+	if err := sth.verify(c.pub); err != nil {
+		return nil, fmt.Errorf("get-sth returned STH which failed verification: %v", err)
+	}
+	return &sth, nil
+}
+
+// getEntries returns the entries in [start, end]. Logs may return fewer
+// entries than requested, but never zero for a valid range.
+func (c *ctClient) getEntries(ctx context.Context, start, end uint64) ([]logEntry, error) {
+	params := url.Values{
+		"start": {strconv.FormatUint(start, 10)},
+		"end":   {strconv.FormatUint(end, 10)},
+	}
+	var resp getEntriesResponse
+	if err := c.get(ctx, "get-entries", params, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Entries) == 0 {
+		return nil, fmt.Errorf("get-entries returned no entries for [%d, %d]", start, end)
+	}
+	if got, max := uint64(len(resp.Entries)), end-start+1; got > max {
+		return nil, fmt.Errorf("get-entries returned %d entries for [%d, %d]", got, start, end)
+	}
+	return resp.Entries, nil
+}
+
+func (c *ctClient) get(ctx context.Context, endpoint string, params url.Values, resp interface{}) error {
+	u := fmt.Sprintf("%s/ct/v1/%s", c.baseURL, endpoint)
+	if len(params) > 0 {
+		u += "?" + params.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	httpResp, err := c.hc.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %v", endpoint, err)
+	}
+	defer httpResp.Body.Close()
+	body, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return fmt.Errorf("%s: failed to read response: %v", endpoint, err)
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: got HTTP status %q: %s", endpoint, httpResp.Status, body)
+	}
+	if err := json.Unmarshal(body, resp); err != nil {
+		return fmt.Errorf("%s: failed to parse response: %v", endpoint, err)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main contains the implementation and entry point for the
+// clone_ct_log command, which copies the entries of an RFC 6962 Certificate
+// Transparency log into a Trillian PREORDERED_LOG tree, and checks that the
+// resulting root hash matches the source log's latest STH.
+//
+// Example usage:
+// $ TREE_ID=$(./createtree --admin_server=host:port --tree_type=PREORDERED_LOG --sequenced_leaf_conflict_policy=CONFLICT_OVERWRITE_IF_IDENTICAL)
+// $ ./clone_ct_log --log_server=host:port --tree_id=${TREE_ID} --ct_log_url=https://ct.googleapis.com/logs/argon2022 --log_public_key=argon2022.pem
+//
+// The tree must be sequenced by a running log signer. Cloning resumes from
+// the current size of the tree, so the command can be re-run to catch up with
+// the source log. The STH of the source log is verified with its public key
+// before its root hash is compared.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian"
+	"github.com/google/trillian/client/rpcflags"
+	"github.com/google/trillian/util"
+	"google.golang.org/grpc"
+)
+
+var (
+	logServerAddr = flag.String("log_server", "", "Address of the gRPC Trillian Log Server (host:port)")
+	treeID        = flag.Int64("tree_id", 0, "ID of the PREORDERED_LOG tree to clone into")
+	ctLogURL      = flag.String("ct_log_url", "", "Base URL of the CT log to clone, without the /ct/v1 suffix")
+	logPublicKey  = flag.String("log_public_key", "", "File containing the PEM encoded public key of the CT log, which its STH is verified with")
+	batchSize     = flag.Uint64("batch_size", 256, "Maximum number of entries to request with each get-entries call")
+	pollInterval  = flag.Duration("poll_interval", time.Second, "Interval between checks of the tree size while waiting for integration")
+	httpTimeout   = flag.Duration("http_timeout", 30*time.Second, "Timeout for requests to the CT log")
+)
+
+func main() {
+	flag.Parse()
+	defer glog.Flush()
+
+	if *logServerAddr == "" || *treeID == 0 || *ctLogURL == "" || *logPublicKey == "" {
+		glog.Exit("--log_server, --tree_id, --ct_log_url and --log_public_key must be set")
+	}
+	if *batchSize == 0 {
+		glog.Exit("--batch_size must be positive")
+	}
+	pub, err := readPublicKey(*logPublicKey)
+	if err != nil {
+		glog.Exitf("Failed to read --log_public_key: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go util.AwaitSignal(ctx, cancel)
+
+	dialOpts, err := rpcflags.NewClientDialOptionsFromFlags()
+	if err != nil {
+		glog.Exitf("Failed to determine dial options: %v", err)
+	}
+	conn, err := grpc.Dial(*logServerAddr, dialOpts...)
+	if err != nil {
+		glog.Exitf("Failed to dial %v: %v", *logServerAddr, err)
+	}
+	defer conn.Close()
+
+	c := &cloner{
+		ct:           newCTClient(*ctLogURL, &http.Client{Timeout: *httpTimeout}, pub),
+		admin:        trillian.NewTrillianAdminClient(conn),
+		log:          trillian.NewTrillianLogClient(conn),
+		treeID:       *treeID,
+		batchSize:    *batchSize,
+		pollInterval: *pollInterval,
+	}
+	root, err := c.clone(ctx)
+	if err != nil {
+		glog.Exitf("Failed to clone %s into tree %d: %v", *ctLogURL, *treeID, err)
+	}
+	fmt.Printf("Cloned %d entries, root hash %x matches the STH\n", root.TreeSize, root.RootHash)
+}
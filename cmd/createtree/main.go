@@ -31,6 +31,8 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/golang/glog"
@@ -39,6 +41,8 @@ import (
 	"github.com/google/trillian/client/rpcflags"
 	"github.com/google/trillian/cmd"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
 	"google.golang.org/protobuf/types/known/durationpb"
 )
 
@@ -46,11 +50,27 @@ var (
 	adminServerAddr = flag.String("admin_server", "", "Address of the gRPC Trillian Admin Server (host:port)")
 	rpcDeadline     = flag.Duration("rpc_deadline", time.Second*10, "Deadline for RPC requests")
 
+	treeID          = flag.Int64("tree_id", 0, "ID of the new tree; zero means a random ID assigned by the storage")
+	treeIDName      = flag.String("tree_id_name", "", "If set, the ID of the new tree is derived from this name, so that it's the same in all environments. Exclusive with --tree_id")
 	treeState       = flag.String("tree_state", trillian.TreeState_ACTIVE.String(), "State of the new tree")
 	treeType        = flag.String("tree_type", trillian.TreeType_LOG.String(), "Type of the new tree")
 	displayName     = flag.String("display_name", "", "Display name of the new tree")
 	description     = flag.String("description", "", "Description of the new tree")
 	maxRootDuration = flag.Duration("max_root_duration", time.Hour, "Interval after which a new signed root is produced despite no submissions; zero means never")
+	conflictPolicy  = flag.String("sequenced_leaf_conflict_policy", trillian.SequencedLeafConflictPolicy_CONFLICT_FAIL.String(), "How AddSequencedLeaves handles leaves conflicting with stored ones (PREORDERED_LOG only)")
+	duplicateWindow = flag.Int64("sequenced_leaf_duplicate_window", 0, "Number of indices below the tree size within which the conflict policy applies; zero means everywhere (PREORDERED_LOG only)")
+	readConsistency = flag.String("read_consistency", trillian.ReadConsistency_READ_OWN_WRITES.String(), "Which writes reads of the tree are guaranteed to reflect")
+	encryptHashes   = flag.Bool("encrypt_hashes", false, "Whether the storage encrypts the stored hashes of the tree; can't be changed later")
+	maxMergeDelay   = flag.Duration("max_merge_delay", 0, "If non-zero, QueueLeaf returns signed promises to integrate leaves within this delay (LOG only)")
+	quotaProfile    = flag.String("quota_profile", "", "Name of the quota profile from which the tree quotas are created, e.g. small, medium or large; empty means no tree quotas")
+
+	operatorEmail = flag.String("operator_contact_email", "", "Email address of the operator of the new tree, for published log lists")
+	operatorURL   = flag.String("operator_url", "", "URL of the operator's page about the new tree, e.g. its policy")
+	operatorOIDs  = flag.String("operator_policy_oids", "", "Comma-separated OIDs, e.g. 1.3.6.1.4.1.11129, of the policies the new tree is operated under")
+
+	leafSchemaFile     = flag.String("leaf_schema_descriptor_set", "", "File holding a FileDescriptorSet, as written by protoc --include_imports --descriptor_set_out, which defines the message type of leaf values; empty means leaf values aren't validated")
+	leafSchemaType     = flag.String("leaf_schema_message_type", "", "Full name of the message type of leaf values, defined in --leaf_schema_descriptor_set")
+	leafSchemaEncoding = flag.String("leaf_schema_encoding", trillian.LeafSchema_PROTO_BINARY.String(), "How leaf values encode the --leaf_schema_message_type message")
 
 	configFile = flag.String("config", "", "Config file containing flags, file contents can be overridden by command line flags")
 
@@ -96,18 +116,78 @@ func newRequest() (*trillian.CreateTreeRequest, error) {
 		return nil, fmt.Errorf("unknown TreeType: %v", *treeType)
 	}
 
+	cp, ok := trillian.SequencedLeafConflictPolicy_value[*conflictPolicy]
+	if !ok {
+		return nil, fmt.Errorf("unknown SequencedLeafConflictPolicy: %v", *conflictPolicy)
+	}
+
+	rc, ok := trillian.ReadConsistency_value[*readConsistency]
+	if !ok {
+		return nil, fmt.Errorf("unknown ReadConsistency: %v", *readConsistency)
+	}
+
 	ctr := &trillian.CreateTreeRequest{Tree: &trillian.Tree{
 		TreeState:       trillian.TreeState(ts),
 		TreeType:        trillian.TreeType(tt),
 		DisplayName:     *displayName,
 		Description:     *description,
 		MaxRootDuration: durationpb.New(*maxRootDuration),
+
+		SequencedLeafConflictPolicy:  trillian.SequencedLeafConflictPolicy(cp),
+		SequencedLeafDuplicateWindow: *duplicateWindow,
+		ReadConsistency:              trillian.ReadConsistency(rc),
+		EncryptHashes:                *encryptHashes,
+		QuotaProfile:                 *quotaProfile,
 	}}
+	if *maxMergeDelay != 0 {
+		ctr.Tree.MaxMergeDelay = durationpb.New(*maxMergeDelay)
+	}
+	switch {
+	case *treeIDName != "" && *treeID != 0:
+		return nil, errors.New("only one of --tree_id and --tree_id_name can be set")
+	case *treeIDName != "":
+		ctr.TreeId = client.TreeIDFromName(*treeIDName)
+	default:
+		ctr.TreeId = *treeID
+	}
+	if *operatorEmail != "" || *operatorURL != "" || *operatorOIDs != "" {
+		ctr.Tree.Operator = &trillian.TreeOperator{ContactEmail: *operatorEmail, Url: *operatorURL}
+		if *operatorOIDs != "" {
+			ctr.Tree.Operator.PolicyOids = strings.Split(*operatorOIDs, ",")
+		}
+	}
+	if *leafSchemaFile != "" {
+		schema, err := newLeafSchema()
+		if err != nil {
+			return nil, err
+		}
+		ctr.Tree.LeafSchema = schema
+	}
 	glog.Infof("Creating tree %+v", ctr.Tree)
 
 	return ctr, nil
 }
 
+func newLeafSchema() (*trillian.LeafSchema, error) {
+	enc, ok := trillian.LeafSchema_Encoding_value[*leafSchemaEncoding]
+	if !ok {
+		return nil, fmt.Errorf("unknown LeafSchema_Encoding: %v", *leafSchemaEncoding)
+	}
+	b, err := os.ReadFile(*leafSchemaFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read leaf schema: %v", err)
+	}
+	var fds descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(b, &fds); err != nil {
+		return nil, fmt.Errorf("failed to parse leaf schema descriptor set %q: %v", *leafSchemaFile, err)
+	}
+	return &trillian.LeafSchema{
+		FileDescriptors: &fds,
+		MessageType:     *leafSchemaType,
+		Encoding:        trillian.LeafSchema_Encoding(enc),
+	}, nil
+}
+
 func main() {
 	flag.Parse()
 	defer glog.Flush()
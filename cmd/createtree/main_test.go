@@ -17,11 +17,14 @@ package main
 import (
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/trillian"
+	"github.com/google/trillian/client"
 	"github.com/google/trillian/testonly"
 	"github.com/google/trillian/testonly/flagsaver"
 	"google.golang.org/grpc/codes"
@@ -52,6 +55,27 @@ func TestCreateTree(t *testing.T) {
 	nonDefaultTree.TreeType = trillian.TreeType_LOG
 	nonDefaultTree.DisplayName = "Llamas Log"
 	nonDefaultTree.Description = "For all your digital llama needs!"
+	nonDefaultTree.SequencedLeafConflictPolicy = trillian.SequencedLeafConflictPolicy_CONFLICT_SKIP
+	nonDefaultTree.SequencedLeafDuplicateWindow = 1000
+	nonDefaultTree.ReadConsistency = trillian.ReadConsistency_EVENTUAL
+	nonDefaultTree.EncryptHashes = true
+	nonDefaultTree.MaxMergeDelay = durationpb.New(24 * time.Hour)
+	nonDefaultTree.QuotaProfile = "small"
+	nonDefaultTree.Operator = &trillian.TreeOperator{
+		ContactEmail: "log-admin@example.com",
+		Url:          "https://example.com/logs",
+		PolicyOids:   []string{"1.2.3", "1.2.4"},
+	}
+	nonDefaultTree.LeafSchema = testonly.EntryLeafSchema(trillian.LeafSchema_PROTO_JSON)
+
+	schemaFile := filepath.Join(t.TempDir(), "schema.pb")
+	fds, err := proto.Marshal(nonDefaultTree.LeafSchema.FileDescriptors)
+	if err != nil {
+		t.Fatalf("Marshal(): %v", err)
+	}
+	if err := os.WriteFile(schemaFile, fds, 0o644); err != nil {
+		t.Fatalf("WriteFile(): %v", err)
+	}
 
 	runTest(t, []*testCase{
 		{
@@ -65,6 +89,18 @@ func TestCreateTree(t *testing.T) {
 				*treeType = nonDefaultTree.TreeType.String()
 				*displayName = nonDefaultTree.DisplayName
 				*description = nonDefaultTree.Description
+				*conflictPolicy = nonDefaultTree.SequencedLeafConflictPolicy.String()
+				*duplicateWindow = nonDefaultTree.SequencedLeafDuplicateWindow
+				*readConsistency = nonDefaultTree.ReadConsistency.String()
+				*encryptHashes = nonDefaultTree.EncryptHashes
+				*maxMergeDelay = nonDefaultTree.MaxMergeDelay.AsDuration()
+				*quotaProfile = nonDefaultTree.QuotaProfile
+				*operatorEmail = nonDefaultTree.Operator.ContactEmail
+				*operatorURL = nonDefaultTree.Operator.Url
+				*operatorOIDs = "1.2.3,1.2.4"
+				*leafSchemaFile = schemaFile
+				*leafSchemaType = nonDefaultTree.LeafSchema.MessageType
+				*leafSchemaEncoding = nonDefaultTree.LeafSchema.Encoding.String()
 			},
 			wantTree: nonDefaultTree,
 		},
@@ -87,6 +123,33 @@ func TestCreateTree(t *testing.T) {
 			validateErr: errors.New("unknown TreeType"),
 			wantErr:     true,
 		},
+		{
+			desc:        "invalidConflictPolicy",
+			setFlags:    func() { *conflictPolicy = "LLAMA!" },
+			validateErr: errors.New("unknown SequencedLeafConflictPolicy"),
+			wantErr:     true,
+		},
+		{
+			desc:        "invalidReadConsistency",
+			setFlags:    func() { *readConsistency = "LLAMA!" },
+			validateErr: errors.New("unknown ReadConsistency"),
+			wantErr:     true,
+		},
+		{
+			desc: "invalidLeafSchemaEncoding",
+			setFlags: func() {
+				*leafSchemaFile = schemaFile
+				*leafSchemaEncoding = "LLAMA!"
+			},
+			validateErr: errors.New("unknown LeafSchema_Encoding"),
+			wantErr:     true,
+		},
+		{
+			desc:        "missingLeafSchemaFile",
+			setFlags:    func() { *leafSchemaFile = filepath.Join(t.TempDir(), "missing.pb") },
+			validateErr: errors.New("failed to read leaf schema"),
+			wantErr:     true,
+		},
 		{
 			desc:      "createErr",
 			createErr: status.Errorf(codes.Unavailable, "create tree failed"),
@@ -105,6 +168,37 @@ func TestCreateTree(t *testing.T) {
 	})
 }
 
+func TestNewRequestTreeID(t *testing.T) {
+	for _, tc := range []struct {
+		desc       string
+		treeID     int64
+		treeIDName string
+		want       int64
+		wantErr    bool
+	}{
+		{desc: "random"},
+		{desc: "treeID", treeID: 12345, want: 12345},
+		{desc: "treeIDName", treeIDName: "llamas-log", want: client.TreeIDFromName("llamas-log")},
+		{desc: "both", treeID: 12345, treeIDName: "llamas-log", wantErr: true},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			defer flagsaver.Save().MustRestore()
+			*treeID = tc.treeID
+			*treeIDName = tc.treeIDName
+
+			req, err := newRequest()
+			if hasErr := err != nil; hasErr != tc.wantErr {
+				t.Fatalf("newRequest() = (_, %v), wantErr = %v", err, tc.wantErr)
+			} else if hasErr {
+				return
+			}
+			if got := req.TreeId; got != tc.want {
+				t.Errorf("newRequest() returned TreeId %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
 // runTest executes the createtree command against a fake TrillianAdminServer
 // for each of the provided tests, and checks that the tree in the request is
 // as expected, or an expected error occurs.
@@ -0,0 +1,343 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian"
+	"github.com/google/trillian/extension"
+	"github.com/google/trillian/log"
+	"github.com/google/trillian/quota"
+	"github.com/google/trillian/server"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/storage/memory"
+	"github.com/google/trillian/types"
+	"github.com/google/trillian/util/clock"
+	"github.com/transparency-dev/merkle/proof"
+	"github.com/transparency-dev/merkle/rfc6962"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const (
+	// maxRoots is the number of recent roots kept to prove consistency with.
+	maxRoots = 20
+	// maxLeaves is the number of recent leaves shown.
+	maxLeaves = 10
+)
+
+// demo is a log in memory storage which is written to and integrated by the
+// demo itself, and shown by its web UI.
+type demo struct {
+	log  *server.TrillianLogRPCServer
+	ls   storage.LogStorage
+	tree *trillian.Tree
+	ts   clock.TimeSource
+
+	mu      sync.Mutex
+	written int
+	// roots are the recent roots of the log, the latest last.
+	roots []*types.LogRootV1
+}
+
+// newDemo creates and initialises a log in empty memory storage.
+func newDemo(ctx context.Context, ts clock.TimeSource) (*demo, error) {
+	log.InitMetrics(nil)
+	ms := memory.NewTreeStorage()
+	registry := extension.Registry{
+		AdminStorage: memory.NewAdminStorage(ms),
+		LogStorage:   memory.NewLogStorage(ms, nil),
+		QuotaManager: quota.Noop(),
+	}
+	tree, err := storage.CreateTree(ctx, registry.AdminStorage, &trillian.Tree{
+		TreeState:       trillian.TreeState_ACTIVE,
+		TreeType:        trillian.TreeType_LOG,
+		DisplayName:     "Demo Log",
+		Description:     "Synthetic leaves written by the demo command",
+		MaxRootDuration: durationpb.New(0),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tree: %v", err)
+	}
+	d := &demo{
+		log:  server.NewTrillianLogRPCServer(registry, ts),
+		ls:   registry.LogStorage,
+		tree: tree,
+		ts:   ts,
+	}
+	if _, err := d.log.InitLog(ctx, &trillian.InitLogRequest{LogId: tree.TreeId}); err != nil {
+		return nil, fmt.Errorf("failed to initialise log: %v", err)
+	}
+	if err := d.updateRoot(ctx); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// run writes n leaves every writeInterval, and integrates up to batchSize of
+// them every sequencerInterval, until ctx is done.
+func (d *demo) run(ctx context.Context, writeInterval time.Duration, n int, sequencerInterval time.Duration, batchSize int) {
+	writes := time.NewTicker(writeInterval)
+	defer writes.Stop()
+	integrations := time.NewTicker(sequencerInterval)
+	defer integrations.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-writes.C:
+			if err := d.writeLeaves(ctx, n); err != nil {
+				glog.Warningf("Failed to write leaves: %v", err)
+			}
+		case <-integrations.C:
+			if err := d.integrate(ctx, batchSize); err != nil {
+				glog.Warningf("Failed to integrate leaves: %v", err)
+			}
+		}
+	}
+}
+
+// writeLeaves queues n synthetic leaves.
+func (d *demo) writeLeaves(ctx context.Context, n int) error {
+	for i := 0; i < n; i++ {
+		d.mu.Lock()
+		index := d.written
+		d.written++
+		d.mu.Unlock()
+		data := fmt.Sprintf("demo leaf %d written at %s", index, d.ts.Now().UTC().Format(time.RFC3339Nano))
+		if _, err := d.log.QueueLeaf(ctx, &trillian.QueueLeafRequest{
+			LogId: d.tree.TreeId,
+			Leaf:  &trillian.LogLeaf{LeafValue: []byte(data)},
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// integrate integrates up to batchSize queued leaves, and records the new
+// root of the log.
+func (d *demo) integrate(ctx context.Context, batchSize int) error {
+	if _, err := log.IntegrateBatch(ctx, d.tree, batchSize, 0, 0, d.ts, d.ls, quota.Noop()); err != nil {
+		return err
+	}
+	return d.updateRoot(ctx)
+}
+
+// updateRoot records the latest root of the log, if it has grown.
+func (d *demo) updateRoot(ctx context.Context) error {
+	resp, err := d.log.GetLatestSignedLogRoot(ctx, &trillian.GetLatestSignedLogRootRequest{LogId: d.tree.TreeId})
+	if err != nil {
+		return err
+	}
+	var root types.LogRootV1
+	if err := root.UnmarshalBinary(resp.GetSignedLogRoot().GetLogRoot()); err != nil {
+		return fmt.Errorf("failed to unmarshal root: %v", err)
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if n := len(d.roots); n > 0 && d.roots[n-1].TreeSize == root.TreeSize {
+		return nil
+	}
+	d.roots = append(d.roots, &root)
+	if len(d.roots) > maxRoots {
+		d.roots = d.roots[len(d.roots)-maxRoots:]
+	}
+	return nil
+}
+
+// page is the data shown by the web UI.
+type page struct {
+	TreeID      int64
+	Root        *types.LogRootV1
+	Roots       []*types.LogRootV1
+	Leaves      []*trillian.LogLeaf
+	Inclusion   *proofResult
+	Consistency *proofResult
+	Refresh     bool
+}
+
+// Proofs returns the requested proofs.
+func (p page) Proofs() []*proofResult {
+	var ret []*proofResult
+	for _, r := range []*proofResult{p.Inclusion, p.Consistency} {
+		if r != nil {
+			ret = append(ret, r)
+		}
+	}
+	return ret
+}
+
+// proofResult is a proof shown by the web UI, along with the outcome of its
+// verification.
+type proofResult struct {
+	Title  string
+	Hashes [][]byte
+	Error  string
+}
+
+// ServeHTTP serves the web UI. The leaf parameter requests the inclusion
+// proof of a leaf in the latest root, and the from parameter requests the
+// consistency proof of the root of that size with the latest root.
+func (d *demo) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	ctx := r.Context()
+	d.mu.Lock()
+	p := page{TreeID: d.tree.TreeId, Root: d.roots[len(d.roots)-1]}
+	for i := len(d.roots) - 1; i >= 0; i-- {
+		p.Roots = append(p.Roots, d.roots[i])
+	}
+	d.mu.Unlock()
+
+	if size := int64(p.Root.TreeSize); size > 0 {
+		start := size - maxLeaves
+		if start < 0 {
+			start = 0
+		}
+		resp, err := d.log.GetLeavesByRange(ctx, &trillian.GetLeavesByRangeRequest{LogId: d.tree.TreeId, StartIndex: start, Count: size - start})
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to read leaves: %v", err), http.StatusInternalServerError)
+			return
+		}
+		for i := len(resp.Leaves) - 1; i >= 0; i-- {
+			p.Leaves = append(p.Leaves, resp.Leaves[i])
+		}
+	}
+	if v := r.FormValue("leaf"); v != "" {
+		p.Inclusion = d.proveInclusion(ctx, v, p.Root)
+	}
+	if v := r.FormValue("from"); v != "" {
+		p.Consistency = d.proveConsistency(ctx, v, p.Roots)
+	}
+	p.Refresh = p.Inclusion == nil && p.Consistency == nil
+
+	if err := pageTmpl.Execute(w, p); err != nil {
+		glog.Warningf("Failed to render page: %v", err)
+	}
+}
+
+// proveInclusion fetches and verifies the inclusion proof of the leaf with the
+// given index in root.
+func (d *demo) proveInclusion(ctx context.Context, index string, root *types.LogRootV1) *proofResult {
+	res := &proofResult{Title: fmt.Sprintf("Inclusion of leaf %s in tree of size %d", index, root.TreeSize)}
+	i, err := strconv.ParseInt(index, 10, 64)
+	if err != nil || i < 0 || uint64(i) >= root.TreeSize {
+		res.Error = fmt.Sprintf("leaf index must be a number from 0 to %d", int64(root.TreeSize)-1)
+		return res
+	}
+	leaves, err := d.log.GetLeavesByRange(ctx, &trillian.GetLeavesByRangeRequest{LogId: d.tree.TreeId, StartIndex: i, Count: 1})
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	resp, err := d.log.GetInclusionProof(ctx, &trillian.GetInclusionProofRequest{LogId: d.tree.TreeId, LeafIndex: i, TreeSize: int64(root.TreeSize)})
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	res.Hashes = resp.GetProof().GetHashes()
+	leafHash := rfc6962.DefaultHasher.HashLeaf(leaves.Leaves[0].LeafValue)
+	if err := proof.VerifyInclusion(rfc6962.DefaultHasher, uint64(i), root.TreeSize, leafHash, res.Hashes, root.RootHash); err != nil {
+		res.Error = fmt.Sprintf("verification failed: %v", err)
+	}
+	return res
+}
+
+// proveConsistency fetches and verifies the consistency proof of the recent
+// root with the given size with the latest one, roots[0].
+func (d *demo) proveConsistency(ctx context.Context, size string, roots []*types.LogRootV1) *proofResult {
+	latest := roots[0]
+	res := &proofResult{Title: fmt.Sprintf("Consistency of tree of size %s with tree of size %d", size, latest.TreeSize)}
+	s, err := strconv.ParseUint(size, 10, 64)
+	if err != nil {
+		res.Error = "tree size must be a number"
+		return res
+	}
+	var old *types.LogRootV1
+	for _, root := range roots {
+		if root.TreeSize == s && s > 0 {
+			old = root
+		}
+	}
+	if old == nil {
+		res.Error = "tree size must be that of a recent non-empty root"
+		return res
+	}
+	resp, err := d.log.GetConsistencyProof(ctx, &trillian.GetConsistencyProofRequest{LogId: d.tree.TreeId, FirstTreeSize: int64(s), SecondTreeSize: int64(latest.TreeSize)})
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	res.Hashes = resp.GetProof().GetHashes()
+	if err := proof.VerifyConsistency(rfc6962.DefaultHasher, old.TreeSize, latest.TreeSize, res.Hashes, old.RootHash, latest.RootHash); err != nil {
+		res.Error = fmt.Sprintf("verification failed: %v", err)
+	}
+	return res
+}
+
+var pageTmpl = template.Must(template.New("page").Funcs(template.FuncMap{
+	"hex": hex.EncodeToString,
+	"time": func(nanos uint64) string {
+		return time.Unix(0, int64(nanos)).UTC().Format(time.RFC3339)
+	},
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<title>Trillian demo log {{.TreeID}}</title>
+{{if .Refresh}}<meta http-equiv="refresh" content="2">{{end}}
+<style>
+body { font-family: sans-serif; }
+th, td { padding: 2px 8px; text-align: left; }
+code { font-size: 90%; }
+.ok { color: green; }
+.error { color: red; }
+</style>
+</head>
+<body>
+<h1>Trillian demo log {{.TreeID}}</h1>
+<p>Tree size <b>{{.Root.TreeSize}}</b>, root hash <code>{{hex .Root.RootHash}}</code>, at {{time .Root.TimestampNanos}}.</p>
+<form>
+Prove inclusion of leaf <input name="leaf" size="8">
+<input type="submit" value="Prove">
+</form>
+{{range .Proofs}}
+<h2>{{.Title}}</h2>
+{{if .Error}}<p class="error">{{.Error}}</p>{{else}}<p class="ok">Proof verified.</p>{{end}}
+<ol start="0">{{range .Hashes}}<li><code>{{hex .}}</code></li>{{end}}</ol>
+{{end}}
+<h2>Recent roots</h2>
+<table>
+<tr><th>Tree size</th><th>Root hash</th><th>Timestamp</th><th></th></tr>
+{{range .Roots}}<tr><td>{{.TreeSize}}</td><td><code>{{hex .RootHash}}</code></td><td>{{time .TimestampNanos}}</td><td>{{if .TreeSize}}<a href="?from={{.TreeSize}}">prove consistency</a>{{end}}</td></tr>
+{{end}}</table>
+<h2>Recent leaves</h2>
+<table>
+<tr><th>Index</th><th>Value</th><th></th></tr>
+{{range .Leaves}}<tr><td>{{.LeafIndex}}</td><td>{{printf "%s" .LeafValue}}</td><td><a href="?leaf={{.LeafIndex}}">prove inclusion</a></td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
@@ -0,0 +1,152 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/trillian/util/clock"
+)
+
+// newTestDemo returns a demo log with integrated leaves, whose recent roots
+// have the given sizes after the empty one.
+func newTestDemo(ctx context.Context, t *testing.T, sizes ...int) *demo {
+	t.Helper()
+	d, err := newDemo(ctx, clock.System)
+	if err != nil {
+		t.Fatalf("newDemo(): %v", err)
+	}
+	prev := 0
+	for _, size := range sizes {
+		if err := d.writeLeaves(ctx, size-prev); err != nil {
+			t.Fatalf("writeLeaves(): %v", err)
+		}
+		if err := d.integrate(ctx, 100); err != nil {
+			t.Fatalf("integrate(): %v", err)
+		}
+		prev = size
+	}
+	return d
+}
+
+func TestDemoUI(t *testing.T) {
+	ctx := context.Background()
+	d := newTestDemo(ctx, t, 5, 8)
+
+	for _, tc := range []struct {
+		desc       string
+		url        string
+		wantStatus int
+		want       []string
+		notWant    []string
+	}{
+		{
+			desc:       "root",
+			url:        "/",
+			wantStatus: http.StatusOK,
+			want:       []string{"Tree size <b>8</b>", "demo leaf 7 written at", `<a href="?from=5">`, `http-equiv="refresh"`},
+			notWant:    []string{`<a href="?from=0">`},
+		},
+		{
+			desc:       "inclusion",
+			url:        "/?leaf=2",
+			wantStatus: http.StatusOK,
+			want:       []string{"Inclusion of leaf 2 in tree of size 8", "Proof verified."},
+			notWant:    []string{`http-equiv="refresh"`},
+		},
+		{
+			desc:       "inclusionBeyondTree",
+			url:        "/?leaf=8",
+			wantStatus: http.StatusOK,
+			want:       []string{"leaf index must be a number from 0 to 7"},
+			notWant:    []string{"Proof verified."},
+		},
+		{
+			desc:       "consistency",
+			url:        "/?from=5",
+			wantStatus: http.StatusOK,
+			want:       []string{"Consistency of tree of size 5 with tree of size 8", "Proof verified."},
+		},
+		{
+			desc:       "consistencyUnknownRoot",
+			url:        "/?from=3",
+			wantStatus: http.StatusOK,
+			want:       []string{"tree size must be that of a recent non-empty root"},
+			notWant:    []string{"Proof verified."},
+		},
+		{
+			desc:       "consistencyNotNumber",
+			url:        "/?from=x",
+			wantStatus: http.StatusOK,
+			want:       []string{"tree size must be a number"},
+		},
+		{
+			desc:       "notFound",
+			url:        "/favicon.ico",
+			wantStatus: http.StatusNotFound,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			d.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.url, nil))
+			resp := w.Result()
+			if got, want := resp.StatusCode, tc.wantStatus; got != want {
+				t.Fatalf("GET %s: status %d, want %d", tc.url, got, want)
+			}
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("ReadAll(): %v", err)
+			}
+			for _, want := range tc.want {
+				if !strings.Contains(string(body), want) {
+					t.Errorf("GET %s: body doesn't contain %q:\n%s", tc.url, want, body)
+				}
+			}
+			for _, notWant := range tc.notWant {
+				if strings.Contains(string(body), notWant) {
+					t.Errorf("GET %s: body contains %q:\n%s", tc.url, notWant, body)
+				}
+			}
+		})
+	}
+}
+
+func TestDemoRecentRoots(t *testing.T) {
+	ctx := context.Background()
+	sizes := make([]int, 0, maxRoots+5)
+	for i := 1; i <= cap(sizes); i++ {
+		sizes = append(sizes, i)
+	}
+	d := newTestDemo(ctx, t, sizes...)
+	if got, want := len(d.roots), maxRoots; got != want {
+		t.Fatalf("demo kept %d roots, want %d", got, want)
+	}
+	if got, want := d.roots[len(d.roots)-1].TreeSize, uint64(len(sizes)); got != want {
+		t.Errorf("latest root has size %d, want %d", got, want)
+	}
+
+	// Integrating nothing doesn't record another root.
+	if err := d.integrate(ctx, 100); err != nil {
+		t.Fatalf("integrate(): %v", err)
+	}
+	if got, want := d.roots[len(d.roots)-1].TreeSize, d.roots[len(d.roots)-2].TreeSize+1; got != want {
+		t.Errorf("latest root has size %d, want %d", got, want)
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main contains the implementation and entry point for the demo
+// command, which runs a log in memory storage, continuously writes synthetic
+// leaves to it, and serves a web UI showing its latest roots and proofs, to
+// evaluate Trillian without setting up a database or any other servers.
+//
+// Example usage:
+// $ ./demo --http_endpoint=localhost:8080 --write_interval=1s --leaves_per_write=3
+//
+// The UI refreshes itself, and proves the inclusion of any leaf in the latest
+// root, and the consistency of any recent root with the latest one. Proofs
+// are verified by the UI as a client of the log would. All data is lost when
+// the command exits.
+package main
+
+import (
+	"context"
+	"flag"
+	"net/http"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian/util"
+	"github.com/google/trillian/util/clock"
+)
+
+var (
+	httpEndpoint      = flag.String("http_endpoint", "localhost:8080", "Endpoint to serve the web UI on (host:port)")
+	writeInterval     = flag.Duration("write_interval", time.Second, "Time between writes of synthetic leaves")
+	leavesPerWrite    = flag.Int("leaves_per_write", 1, "Number of synthetic leaves queued per write")
+	sequencerInterval = flag.Duration("sequencer_interval", time.Second, "Time between integrations of the queued leaves")
+	batchSize         = flag.Int("batch_size", 100, "Maximum number of leaves integrated at a time")
+)
+
+func main() {
+	flag.Parse()
+	defer glog.Flush()
+
+	if *writeInterval <= 0 || *sequencerInterval <= 0 || *leavesPerWrite <= 0 || *batchSize <= 0 {
+		glog.Exit("--write_interval, --sequencer_interval, --leaves_per_write and --batch_size must be positive")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go util.AwaitSignal(ctx, cancel)
+
+	d, err := newDemo(ctx, clock.System)
+	if err != nil {
+		glog.Exitf("Failed to set up demo log: %v", err)
+	}
+	go d.run(ctx, *writeInterval, *leavesPerWrite, *sequencerInterval, *batchSize)
+
+	srv := &http.Server{Addr: *httpEndpoint, Handler: d}
+	go func() {
+		<-ctx.Done()
+		srv.Close()
+	}()
+	glog.Infof("Serving demo log %d at http://%s/", d.tree.TreeId, *httpEndpoint)
+	if err := srv.ListenAndServe(); err != http.ErrServerClosed {
+		glog.Exitf("HTTP server failed: %v", err)
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package masterelection holds the flags which select the master election of
+// the log signers, so that the log server, which takes part in it to re-sign
+// roots, is configured the same way.
+package masterelection
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/google/trillian/monitoring"
+	"github.com/google/trillian/util/election2"
+	consulelect "github.com/google/trillian/util/election2/consul"
+	etcdelect "github.com/google/trillian/util/election2/etcd"
+	k8select "github.com/google/trillian/util/election2/k8s"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+var (
+	backend           = flag.String("master_election", "etcd", "Master election system of the log signers. One of: etcd (with --etcd_servers), k8s (Leases of the Kubernetes cluster which the binary runs in, whose service account must be allowed to get, create and update Leases), consul. The log server only takes part in the election, to re-sign roots, if this is set explicitly, to the same value as for the signers")
+	lockDir           = flag.String("lock_file_path", "/test/multimaster", "etcd lock file directory path of --master_election=etcd")
+	k8sLeaseNamespace = flag.String("k8s_lease_namespace", "", "Namespace of the Leases used by --master_election=k8s. Empty means the namespace of the pod")
+	k8sLeasePrefix    = flag.String("k8s_lease_prefix", "trillian-log-", "Prefix of the names of the Leases used by --master_election=k8s, which are followed by the tree ID")
+	k8sLeaseDuration  = flag.Duration("k8s_lease_duration", 15*time.Second, "Duration of the Leases used by --master_election=k8s. A master which fails to renew its Lease for two thirds of it gives up mastership, and others take over once it has passed")
+	consulAddr        = flag.String("consul_addr", "http://127.0.0.1:8500", "Address of the Consul agent used by --master_election=consul")
+	consulToken       = flag.String("consul_token", os.Getenv("CONSUL_HTTP_TOKEN"), "ACL token of the requests to Consul for --master_election=consul, which need write access to the keys under --consul_key_prefix and to sessions. Defaults to $CONSUL_HTTP_TOKEN")
+	consulKeyPrefix   = flag.String("consul_key_prefix", "trillian/log_signer/master/", "Prefix of the Consul keys locked by --master_election=consul, which are followed by the tree ID")
+	consulSessionTTL  = flag.Duration("consul_session_ttl", 15*time.Second, "TTL of the Consul sessions used by --master_election=consul, between 10s and 24h. A master whose session Consul invalidates, as it wasn't renewed in time, loses mastership")
+)
+
+// Backend returns the election system selected with --master_election.
+func Backend() string {
+	return *backend
+}
+
+// Explicit returns whether --master_election was set on the command line,
+// rather than left at its default.
+func Explicit() bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "master_election" {
+			set = true
+		}
+	})
+	return set
+}
+
+// NewFactory returns the election factory of the system selected with
+// --master_election, whose elections are joined as instanceID. The etcd
+// system uses client, which is nil unless --etcd_servers is set.
+func NewFactory(instanceID string, client *clientv3.Client, mf monitoring.MetricFactory) (election2.Factory, error) {
+	switch *backend {
+	case "etcd":
+		if client == nil {
+			return nil, errors.New("--master_election=etcd needs --etcd_servers")
+		}
+		etcdelect.InitMetrics(mf)
+		return etcdelect.NewFactory(instanceID, client, *lockDir), nil
+	case "k8s":
+		if *k8sLeaseDuration < time.Second {
+			return nil, fmt.Errorf("--k8s_lease_duration must be at least 1s, got %v", *k8sLeaseDuration)
+		}
+		k8sClient, err := k8select.NewInClusterClient()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Kubernetes client: %v", err)
+		}
+		ns := *k8sLeaseNamespace
+		if ns == "" {
+			if ns, err = k8select.InClusterNamespace(); err != nil {
+				return nil, fmt.Errorf("failed to get namespace for --master_election=k8s: %v", err)
+			}
+		}
+		k8select.InitMetrics(mf)
+		return k8select.NewFactory(instanceID, k8sClient, ns, *k8sLeasePrefix, *k8sLeaseDuration), nil
+	case "consul":
+		if *consulSessionTTL < 10*time.Second || *consulSessionTTL > 24*time.Hour {
+			return nil, fmt.Errorf("--consul_session_ttl must be between 10s and 24h, got %v", *consulSessionTTL)
+		}
+		// Blocking queries watching the lock last up to half of the TTL.
+		consulClient := consulelect.NewClient(*consulAddr, &http.Client{Timeout: *consulSessionTTL}, *consulToken)
+		consulelect.InitMetrics(mf)
+		return consulelect.NewFactory(instanceID, consulClient, *consulKeyPrefix, *consulSessionTTL), nil
+	default:
+		return nil, fmt.Errorf("unknown --master_election %q", *backend)
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package masterelection
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/google/trillian/monitoring"
+	"github.com/google/trillian/testonly/flagsaver"
+	consulelect "github.com/google/trillian/util/election2/consul"
+)
+
+func TestNewFactory(t *testing.T) {
+	defer flagsaver.Save().MustRestore()
+	// Kubernetes elections fail outside of a cluster.
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+	if Explicit() {
+		t.Fatal("Explicit() = true before --master_election is set")
+	}
+
+	for _, tc := range []struct {
+		desc    string
+		flags   map[string]string
+		wantErr bool
+	}{
+		{desc: "etcdWithoutServers", flags: map[string]string{"master_election": "etcd"}, wantErr: true},
+		{desc: "consul", flags: map[string]string{"master_election": "consul"}},
+		{desc: "consulShortTTL", flags: map[string]string{"master_election": "consul", "consul_session_ttl": "1s"}, wantErr: true},
+		{desc: "k8sOutsideCluster", flags: map[string]string{"master_election": "k8s"}, wantErr: true},
+		{desc: "k8sShortLease", flags: map[string]string{"master_election": "k8s", "k8s_lease_duration": "100ms"}, wantErr: true},
+		{desc: "unknown", flags: map[string]string{"master_election": "zookeeper"}, wantErr: true},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			defer flagsaver.Save().MustRestore()
+			for name, value := range tc.flags {
+				if err := flag.Set(name, value); err != nil {
+					t.Fatalf("flag.Set(%q, %q): %v", name, value, err)
+				}
+			}
+			if !Explicit() {
+				t.Error("Explicit() = false with --master_election set")
+			}
+			f, err := NewFactory("instance", nil /* client */, monitoring.InertMetricFactory{})
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("NewFactory(): %v, want error: %v", err, tc.wantErr)
+			}
+			if err == nil {
+				if _, ok := f.(*consulelect.Factory); !ok {
+					t.Errorf("NewFactory() = %T, want *consul.Factory", f)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverutil
+
+import (
+	"errors"
+	"net/http"
+
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// ACMEConfig configures the automatic acquisition and renewal of the TLS
+// certificate of the HTTP endpoint from an ACME certificate authority, such
+// as Let's Encrypt.
+type ACMEConfig struct {
+	// Hosts are the host names which certificates are requested for. Requests
+	// for other names are refused, so that clients can't make the server
+	// request arbitrary certificates.
+	Hosts []string
+	// CacheDir is the directory which the account key and certificates are
+	// stored in, so that restarts don't request new ones and run into the
+	// rate limits of the certificate authority.
+	CacheDir string
+	// Email, if set, is the contact address of the account, which the
+	// certificate authority e.g. warns of expiring certificates.
+	Email string
+	// DirectoryURL is the URL of the directory of the certificate authority.
+	// Empty means the production Let's Encrypt directory.
+	DirectoryURL string
+	// ChallengeEndpoint, if set, is the endpoint (host:port) which serves
+	// HTTP-01 challenges, and redirects all other requests to HTTPS. It must
+	// be reachable on port 80 of the hosts. Otherwise only TLS-ALPN-01
+	// challenges are answered, which requires the HTTP endpoint to be
+	// reachable on port 443.
+	ChallengeEndpoint string
+}
+
+// newACMEManager returns the certificate manager of the configuration.
+func newACMEManager(cfg *ACMEConfig) (*autocert.Manager, error) {
+	if len(cfg.Hosts) == 0 {
+		return nil, errors.New("ACME requires at least one host")
+	}
+	if cfg.CacheDir == "" {
+		return nil, errors.New("ACME requires a cache directory")
+	}
+	m := &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		Cache:      autocert.DirCache(cfg.CacheDir),
+		HostPolicy: autocert.HostWhitelist(cfg.Hosts...),
+		Email:      cfg.Email,
+	}
+	if cfg.DirectoryURL != "" {
+		m.Client = &acme.Client{DirectoryURL: cfg.DirectoryURL}
+	}
+	return m, nil
+}
+
+// acmeChallengeServer returns the server of the HTTP-01 challenges of the
+// manager, or nil if the configuration has no endpoint for them.
+func acmeChallengeServer(cfg *ACMEConfig, m *autocert.Manager) *http.Server {
+	if cfg.ChallengeEndpoint == "" {
+		return nil
+	}
+	return &http.Server{Addr: cfg.ChallengeEndpoint, Handler: m.HTTPHandler(nil)}
+}
@@ -0,0 +1,83 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverutil
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewACMEManager(t *testing.T) {
+	for _, test := range []struct {
+		desc    string
+		cfg     ACMEConfig
+		wantErr bool
+	}{
+		{desc: "ok", cfg: ACMEConfig{Hosts: []string{"log.example.com"}, CacheDir: t.TempDir()}},
+		{desc: "noHosts", cfg: ACMEConfig{CacheDir: t.TempDir()}, wantErr: true},
+		{desc: "noCacheDir", cfg: ACMEConfig{Hosts: []string{"log.example.com"}}, wantErr: true},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			_, err := newACMEManager(&test.cfg)
+			if gotErr := err != nil; gotErr != test.wantErr {
+				t.Errorf("newACMEManager() = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestACMEManager(t *testing.T) {
+	cfg := &ACMEConfig{
+		Hosts:             []string{"log.example.com"},
+		CacheDir:          t.TempDir(),
+		DirectoryURL:      "https://acme.example.com/directory",
+		ChallengeEndpoint: ":80",
+	}
+	m, err := newACMEManager(cfg)
+	if err != nil {
+		t.Fatalf("newACMEManager(): %v", err)
+	}
+	if got, want := m.Client.DirectoryURL, cfg.DirectoryURL; got != want {
+		t.Errorf("directory URL %q, want %q", got, want)
+	}
+	ctx := context.Background()
+	if err := m.HostPolicy(ctx, "log.example.com"); err != nil {
+		t.Errorf("HostPolicy(log.example.com) = %v, want nil", err)
+	}
+	if err := m.HostPolicy(ctx, "other.example.com"); err == nil {
+		t.Error("HostPolicy(other.example.com) = nil, want error")
+	}
+
+	s := acmeChallengeServer(cfg, m)
+	if s == nil || s.Addr != cfg.ChallengeEndpoint {
+		t.Fatalf("acmeChallengeServer() = %v, want server on %q", s, cfg.ChallengeEndpoint)
+	}
+	// Requests other than challenges are redirected to HTTPS.
+	w := httptest.NewRecorder()
+	s.Handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "http://log.example.com/metrics", nil))
+	if got, want := w.Code, http.StatusFound; got != want {
+		t.Errorf("GET /metrics returned status %d, want %d", got, want)
+	}
+	if got, want := w.Header().Get("Location"), "https://log.example.com/metrics"; got != want {
+		t.Errorf("GET /metrics redirected to %q, want %q", got, want)
+	}
+
+	cfg.ChallengeEndpoint = ""
+	if s := acmeChallengeServer(cfg, m); s != nil {
+		t.Errorf("acmeChallengeServer() without endpoint = %v, want nil", s)
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverutil
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/google/trillian"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/utilities"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/runtime/protoiface"
+)
+
+// newGateway returns a gRPC-gateway mux which serves the TrillianLog and
+// TrillianAdmin APIs as REST/JSON, by calling the RPC server at endpoint.
+// Every method is reachable with a POST of its JSON request to
+// /<service>/<method>, e.g. /trillian.TrillianLog/QueueLeaf. The requests of
+// client-streaming methods, and the responses of server-streaming ones, are
+// newline-delimited. The mux stops using the connection to the RPC server
+// when ctx is done.
+//
+// The handlers are built from the service descriptors, rather than generated
+// by protoc-gen-grpc-gateway, so that the API package doesn't depend on the
+// gateway.
+func newGateway(ctx context.Context, endpoint string, useTLS bool) (*runtime.ServeMux, error) {
+	creds := insecure.NewCredentials()
+	if useTLS {
+		// The RPC server runs in this process, so there is no point in
+		// verifying its certificate, which needn't be valid for endpoint.
+		creds = credentials.NewTLS(&tls.Config{InsecureSkipVerify: true})
+	}
+	conn, err := grpc.DialContext(ctx, endpoint, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial RPC server: %v", err)
+	}
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+
+	mux := runtime.NewServeMux()
+	for _, fd := range []protoreflect.FileDescriptor{trillian.File_trillian_log_api_proto, trillian.File_trillian_admin_api_proto} {
+		services := fd.Services()
+		for i := 0; i < services.Len(); i++ {
+			methods := services.Get(i).Methods()
+			for j := 0; j < methods.Len(); j++ {
+				if err := handleMethod(mux, conn, methods.Get(j)); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return mux, nil
+}
+
+// handleMethod registers the handler of an RPC method on mux.
+func handleMethod(mux *runtime.ServeMux, conn *grpc.ClientConn, md protoreflect.MethodDescriptor) error {
+	service := string(md.Parent().FullName())
+	pattern, err := runtime.NewPattern(1,
+		[]int{int(utilities.OpLitPush), 0, int(utilities.OpLitPush), 1},
+		[]string{service, string(md.Name())}, "", runtime.AssumeColonVerbOpt(true))
+	if err != nil {
+		return fmt.Errorf("invalid gateway pattern for %s: %v", md.FullName(), err)
+	}
+	inputType, err := protoregistry.GlobalTypes.FindMessageByName(md.Input().FullName())
+	if err != nil {
+		return err
+	}
+	outputType, err := protoregistry.GlobalTypes.FindMessageByName(md.Output().FullName())
+	if err != nil {
+		return err
+	}
+	method := fmt.Sprintf("/%s/%s", service, md.Name())
+	desc := &grpc.StreamDesc{StreamName: string(md.Name()), ClientStreams: md.IsStreamingClient(), ServerStreams: md.IsStreamingServer()}
+	newMessage := func(mt protoreflect.MessageType) protoiface.MessageV1 {
+		return mt.New().Interface().(protoiface.MessageV1)
+	}
+
+	mux.Handle(http.MethodPost, pattern, func(w http.ResponseWriter, req *http.Request, _ map[string]string) {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		inbound, outbound := runtime.MarshalerForRequest(mux, req)
+		rctx, err := runtime.AnnotateContext(ctx, mux, req)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outbound, w, req, err)
+			return
+		}
+
+		stream, err := conn.NewStream(rctx, desc, method)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outbound, w, req, err)
+			return
+		}
+		// Unary and server-streaming methods take one request, which may be
+		// empty; client-streaming ones take any number of them.
+		dec := inbound.NewDecoder(req.Body)
+		for sent := 0; desc.ClientStreams || sent == 0; sent++ {
+			msg := newMessage(inputType)
+			if err := dec.Decode(msg); err == io.EOF {
+				if desc.ClientStreams || sent > 0 {
+					break
+				}
+			} else if err != nil {
+				runtime.HTTPError(ctx, mux, outbound, w, req, status.Errorf(codes.InvalidArgument, "%v", err))
+				return
+			}
+			if err := stream.SendMsg(msg); err != nil {
+				break // The error is returned by RecvMsg.
+			}
+		}
+		if err := stream.CloseSend(); err != nil {
+			runtime.HTTPError(ctx, mux, outbound, w, req, err)
+			return
+		}
+
+		var smd runtime.ServerMetadata
+		if smd.HeaderMD, err = stream.Header(); err != nil {
+			runtime.HTTPError(ctx, mux, outbound, w, req, err)
+			return
+		}
+		if desc.ServerStreams {
+			ctx = runtime.NewServerMetadataContext(ctx, smd)
+			runtime.ForwardResponseStream(ctx, mux, outbound, w, req, func() (protoiface.MessageV1, error) {
+				msg := newMessage(outputType)
+				return msg, stream.RecvMsg(msg)
+			})
+			return
+		}
+		msg := newMessage(outputType)
+		err = stream.RecvMsg(msg)
+		smd.TrailerMD = stream.Trailer()
+		ctx = runtime.NewServerMetadataContext(ctx, smd)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outbound, w, req, err)
+			return
+		}
+		runtime.ForwardResponseMessage(ctx, mux, outbound, w, req, msg)
+	})
+	return nil
+}
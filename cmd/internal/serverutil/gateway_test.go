@@ -0,0 +1,204 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverutil
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/trillian"
+	"github.com/google/trillian/testonly"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGateway(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	s, stop, err := testonly.NewMockServer(ctrl)
+	if err != nil {
+		t.Fatalf("NewMockServer(): %v", err)
+	}
+	defer stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	gw, err := newGateway(ctx, s.Addr, false /* useTLS */)
+	if err != nil {
+		t.Fatalf("newGateway(): %v", err)
+	}
+	hs := httptest.NewServer(gw)
+	defer hs.Close()
+
+	root := &trillian.SignedLogRoot{LogRoot: []byte("root")}
+	s.Log.EXPECT().GetLatestSignedLogRoot(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, req *trillian.GetLatestSignedLogRootRequest) (*trillian.GetLatestSignedLogRootResponse, error) {
+			if req.LogId != 42 || req.FirstTreeSize != 7 {
+				t.Errorf("GetLatestSignedLogRoot() got request %v", req)
+			}
+			return &trillian.GetLatestSignedLogRootResponse{SignedLogRoot: root}, nil
+		})
+	s.Admin.EXPECT().GetTree(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.NotFound, "no tree"))
+
+	for _, test := range []struct {
+		desc       string
+		path, body string
+		wantStatus int
+		wantResp   proto.Message
+		gotResp    proto.Message
+	}{
+		{
+			desc:       "log",
+			path:       "/trillian.TrillianLog/GetLatestSignedLogRoot",
+			body:       `{"log_id": "42", "first_tree_size": 7}`,
+			wantStatus: http.StatusOK,
+			wantResp:   &trillian.GetLatestSignedLogRootResponse{SignedLogRoot: root},
+			gotResp:    &trillian.GetLatestSignedLogRootResponse{},
+		},
+		{
+			desc:       "admin-error",
+			path:       "/trillian.TrillianAdmin/GetTree",
+			body:       `{"tree_id": "1"}`,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			desc:       "bad-request",
+			path:       "/trillian.TrillianLog/GetLatestSignedLogRoot",
+			body:       `{"log_id": `,
+			wantStatus: http.StatusBadRequest,
+		},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			resp, err := http.Post(hs.URL+test.path, "application/json", strings.NewReader(test.body))
+			if err != nil {
+				t.Fatalf("Post(): %v", err)
+			}
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("ReadAll(): %v", err)
+			}
+			if resp.StatusCode != test.wantStatus {
+				t.Fatalf("Post() returned status %d, want %d: %s", resp.StatusCode, test.wantStatus, body)
+			}
+			if test.wantResp == nil {
+				return
+			}
+			if err := protojson.Unmarshal(body, test.gotResp); err != nil {
+				t.Fatalf("Unmarshal(%s): %v", body, err)
+			}
+			if !proto.Equal(test.gotResp, test.wantResp) {
+				t.Errorf("Post() returned %v, want %v", test.gotResp, test.wantResp)
+			}
+		})
+	}
+}
+
+func TestGatewayStreams(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	s, stop, err := testonly.NewMockServer(ctrl)
+	if err != nil {
+		t.Fatalf("NewMockServer(): %v", err)
+	}
+	defer stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	gw, err := newGateway(ctx, s.Addr, false /* useTLS */)
+	if err != nil {
+		t.Fatalf("newGateway(): %v", err)
+	}
+	hs := httptest.NewServer(gw)
+	defer hs.Close()
+
+	post := func(path, body string) string {
+		t.Helper()
+		resp, err := http.Post(hs.URL+path, "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("Post(): %v", err)
+		}
+		defer resp.Body.Close()
+		b, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("ReadAll(): %v", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Post(%s) returned status %d: %s", path, resp.StatusCode, b)
+		}
+		return string(b)
+	}
+
+	// The requests of client-streaming methods are newline-delimited.
+	s.Log.EXPECT().QueueLeavesStream(gomock.Any()).DoAndReturn(func(stream trillian.TrillianLog_QueueLeavesStreamServer) error {
+		resp := &trillian.QueueLeavesStreamResponse{}
+		for {
+			req, err := stream.Recv()
+			if err == io.EOF {
+				return stream.SendAndClose(resp)
+			} else if err != nil {
+				return err
+			}
+			resp.QueuedLeaves = append(resp.QueuedLeaves, &trillian.QueuedLogLeaf{Leaf: req.Leaf})
+		}
+	})
+	body := post("/trillian.TrillianLog/QueueLeavesStream", "{\"log_id\": \"1\", \"leaf\": {\"leaf_value\": \"YQ==\"}}\n{\"log_id\": \"1\", \"leaf\": {\"leaf_value\": \"Yg==\"}}\n")
+	var queued trillian.QueueLeavesStreamResponse
+	if err := protojson.Unmarshal([]byte(body), &queued); err != nil {
+		t.Fatalf("Unmarshal(%s): %v", body, err)
+	}
+	if got, want := len(queued.QueuedLeaves), 2; got != want {
+		t.Errorf("QueueLeavesStream returned %d leaves, want %d", got, want)
+	}
+
+	// The responses of server-streaming methods are newline-delimited, and
+	// each is wrapped in a result.
+	s.Log.EXPECT().StreamLeavesByRange(gomock.Any(), gomock.Any()).DoAndReturn(func(req *trillian.GetLeavesByRangeRequest, stream trillian.TrillianLog_StreamLeavesByRangeServer) error {
+		for i := int64(0); i < req.Count; i++ {
+			if err := stream.Send(&trillian.GetLeavesByRangeResponse{Leaves: []*trillian.LogLeaf{{LeafIndex: req.StartIndex + i}}}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	body = post("/trillian.TrillianLog/StreamLeavesByRange", `{"log_id": "1", "start_index": "5", "count": "3"}`)
+	lines := strings.Split(strings.TrimSpace(body), "\n")
+	if got, want := len(lines), 3; got != want {
+		t.Fatalf("StreamLeavesByRange returned %d responses, want %d: %s", got, want, body)
+	}
+	for i, line := range lines {
+		var result struct {
+			Result json.RawMessage `json:"result"`
+		}
+		if err := json.Unmarshal([]byte(line), &result); err != nil {
+			t.Fatalf("Unmarshal(%s): %v", line, err)
+		}
+		var resp trillian.GetLeavesByRangeResponse
+		if err := protojson.Unmarshal(result.Result, &resp); err != nil {
+			t.Fatalf("Unmarshal(%s): %v", result.Result, err)
+		}
+		if got, want := resp.Leaves[0].LeafIndex, int64(5+i); got != want {
+			t.Errorf("response %d has leaf %d, want %d", i, got, want)
+		}
+	}
+}
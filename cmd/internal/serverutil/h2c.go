@@ -0,0 +1,197 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverutil
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"google.golang.org/grpc"
+)
+
+const (
+	grpcContentType        = "application/grpc"
+	grpcWebContentType     = "application/grpc-web"
+	grpcWebTextContentType = "application/grpc-web-text"
+
+	// grpcWebTrailerFlag marks the frame of a gRPC-Web response body which
+	// holds the trailers of the response.
+	grpcWebTrailerFlag = 0x80
+)
+
+// newH2CHandler returns the handler of an RPC endpoint serving cleartext
+// HTTP/2 (h2c), as well as HTTP/1.1. The requests are told apart by their
+// content type:
+//   - gRPC requests, which must use HTTP/2, are served by srv.
+//   - gRPC-Web requests are translated to gRPC requests served by srv.
+//   - Other requests are served by gw, or fail if it's nil.
+func newH2CHandler(srv *grpc.Server, gw http.Handler) http.Handler {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType := r.Header.Get("Content-Type")
+		switch {
+		case strings.HasPrefix(contentType, grpcWebContentType):
+			serveGRPCWeb(srv, w, r)
+		case r.ProtoMajor == 2 && strings.HasPrefix(contentType, grpcContentType):
+			srv.ServeHTTP(w, r)
+		case gw != nil:
+			gw.ServeHTTP(w, r)
+		default:
+			http.Error(w, fmt.Sprintf("unsupported content type %q", contentType), http.StatusUnsupportedMediaType)
+		}
+	})
+	return h2c.NewHandler(h, &http2.Server{})
+}
+
+// serveGRPCWeb serves a gRPC-Web request with srv. As browsers can't read
+// HTTP trailers, the trailers of the response are sent in the last frame of
+// its body. The bodies of application/grpc-web-text requests and responses
+// are base64-encoded.
+func serveGRPCWeb(srv http.Handler, w http.ResponseWriter, r *http.Request) {
+	contentType := r.Header.Get("Content-Type")
+	text := strings.HasPrefix(contentType, grpcWebTextContentType)
+
+	r = r.Clone(r.Context())
+	r.ProtoMajor, r.ProtoMinor, r.Proto = 2, 0, "HTTP/2.0"
+	r.ContentLength = -1
+	r.Header.Del("Content-Length")
+	if text {
+		r.Header.Set("Content-Type", grpcContentType+strings.TrimPrefix(contentType, grpcWebTextContentType))
+		r.Body = struct {
+			io.Reader
+			io.Closer
+		}{base64.NewDecoder(base64.StdEncoding, r.Body), r.Body}
+	} else {
+		r.Header.Set("Content-Type", grpcContentType+strings.TrimPrefix(contentType, grpcWebContentType))
+	}
+
+	gw := &grpcWebResponseWriter{w: w, contentType: contentType, header: make(http.Header)}
+	if text {
+		gw.enc = base64.NewEncoder(base64.StdEncoding, w)
+	}
+	srv.ServeHTTP(gw, r)
+	gw.finish()
+}
+
+// grpcWebResponseWriter turns the gRPC response written to it into a gRPC-Web
+// response written to w.
+type grpcWebResponseWriter struct {
+	w           http.ResponseWriter
+	contentType string
+	// enc encodes the body of application/grpc-web-text responses, and is
+	// nil for others.
+	enc io.WriteCloser
+
+	// header is the header written by the gRPC server, and sent is the part
+	// of it sent before the body. The rest is sent as trailers.
+	header http.Header
+	sent   http.Header
+}
+
+func (g *grpcWebResponseWriter) Header() http.Header {
+	return g.header
+}
+
+func (g *grpcWebResponseWriter) WriteHeader(code int) {
+	if g.sent != nil {
+		return
+	}
+	g.sent = g.header.Clone()
+	h := g.w.Header()
+	for k, vv := range g.header {
+		// The declared trailers are sent in the body.
+		if k == "Trailer" || strings.HasPrefix(k, http2.TrailerPrefix) {
+			continue
+		}
+		h[k] = vv
+	}
+	h.Set("Content-Type", g.contentType)
+	h.Del("Content-Length")
+	g.w.WriteHeader(code)
+}
+
+func (g *grpcWebResponseWriter) Write(p []byte) (int, error) {
+	g.WriteHeader(http.StatusOK)
+	if g.enc != nil {
+		return g.enc.Write(p)
+	}
+	return g.w.Write(p)
+}
+
+func (g *grpcWebResponseWriter) Flush() {
+	g.WriteHeader(http.StatusOK)
+	if g.enc != nil {
+		// Each flushed part of a text body is padded, so that it can be
+		// decoded as soon as it's received.
+		g.enc.Close()
+		g.enc = base64.NewEncoder(base64.StdEncoding, g.w)
+	}
+	if f, ok := g.w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// finish writes the trailer frame of the response.
+func (g *grpcWebResponseWriter) finish() {
+	g.WriteHeader(http.StatusOK)
+	var trailers []string
+	for k, vv := range g.header {
+		if k == "Trailer" {
+			continue
+		}
+		name := strings.TrimPrefix(k, http2.TrailerPrefix)
+		if name == k && equalValues(g.sent[k], vv) {
+			continue
+		}
+		for _, v := range vv {
+			trailers = append(trailers, fmt.Sprintf("%s: %s\r\n", strings.ToLower(name), v))
+		}
+	}
+	sort.Strings(trailers)
+
+	var block bytes.Buffer
+	for _, t := range trailers {
+		block.WriteString(t)
+	}
+	frame := make([]byte, 5, 5+block.Len())
+	frame[0] = grpcWebTrailerFlag
+	binary.BigEndian.PutUint32(frame[1:], uint32(block.Len()))
+	if _, err := g.Write(append(frame, block.Bytes()...)); err != nil {
+		return
+	}
+	if g.enc != nil {
+		g.enc.Close()
+	}
+}
+
+// equalValues returns whether a and b hold the same header values.
+func equalValues(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
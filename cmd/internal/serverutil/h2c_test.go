@@ -0,0 +1,205 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverutil
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// newH2CServer returns a test server of an h2c RPC endpoint serving the gRPC
+// health service.
+func newH2CServer(t *testing.T, gw http.Handler) *httptest.Server {
+	t.Helper()
+	srv := grpc.NewServer()
+	grpc_health_v1.RegisterHealthServer(srv, health.NewServer())
+	hs := httptest.NewServer(newH2CHandler(srv, gw))
+	t.Cleanup(hs.Close)
+	return hs
+}
+
+func TestH2CGRPC(t *testing.T) {
+	hs := newH2CServer(t, nil)
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, strings.TrimPrefix(hs.URL, "http://"), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("DialContext(): %v", err)
+	}
+	defer conn.Close()
+	resp, err := grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Check(): %v", err)
+	}
+	if got, want := resp.Status, grpc_health_v1.HealthCheckResponse_SERVING; got != want {
+		t.Errorf("Check(): status %v, want %v", got, want)
+	}
+}
+
+func TestH2CGRPCWeb(t *testing.T) {
+	hs := newH2CServer(t, nil)
+	for _, tc := range []struct {
+		desc        string
+		contentType string
+		service     string
+		wantStatus  string
+		wantMessage bool
+	}{
+		{desc: "binary", contentType: "application/grpc-web+proto", wantStatus: "0", wantMessage: true},
+		{desc: "binaryNoSubtype", contentType: "application/grpc-web", wantStatus: "0", wantMessage: true},
+		{desc: "text", contentType: "application/grpc-web-text", wantStatus: "0", wantMessage: true},
+		{desc: "error", contentType: "application/grpc-web+proto", service: "unknown", wantStatus: "5"},
+		{desc: "textError", contentType: "application/grpc-web-text+proto", service: "unknown", wantStatus: "5"},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			text := strings.HasPrefix(tc.contentType, grpcWebTextContentType)
+			req, err := proto.Marshal(&grpc_health_v1.HealthCheckRequest{Service: tc.service})
+			if err != nil {
+				t.Fatalf("Marshal(): %v", err)
+			}
+			body := frame(0, req)
+			if text {
+				body = []byte(base64.StdEncoding.EncodeToString(body))
+			}
+			resp, err := http.Post(hs.URL+"/grpc.health.v1.Health/Check", tc.contentType, bytes.NewReader(body))
+			if err != nil {
+				t.Fatalf("Post(): %v", err)
+			}
+			defer resp.Body.Close()
+			if got, want := resp.Header.Get("Content-Type"), tc.contentType; got != want {
+				t.Errorf("Content-Type: %q, want %q", got, want)
+			}
+			if got := resp.Header.Get("Trailer"); got != "" {
+				t.Errorf("Trailer: %q, want none", got)
+			}
+			got, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("ReadAll(): %v", err)
+			}
+			if text {
+				got = decodeText(t, got)
+			}
+
+			var msgs [][]byte
+			var trailers string
+			for len(got) > 0 {
+				if len(got) < 5 {
+					t.Fatalf("truncated frame header: %x", got)
+				}
+				size := binary.BigEndian.Uint32(got[1:5])
+				if uint32(len(got)-5) < size {
+					t.Fatalf("truncated frame: %x", got)
+				}
+				if got[0] == grpcWebTrailerFlag {
+					trailers = string(got[5 : 5+size])
+				} else {
+					msgs = append(msgs, got[5:5+size])
+				}
+				got = got[5+size:]
+			}
+			if want := "grpc-status: " + tc.wantStatus + "\r\n"; !strings.Contains(trailers, want) {
+				t.Errorf("trailers %q, want %q", trailers, want)
+			}
+			if !tc.wantMessage {
+				if len(msgs) != 0 {
+					t.Errorf("got %d messages, want none", len(msgs))
+				}
+				return
+			}
+			if len(msgs) != 1 {
+				t.Fatalf("got %d messages, want 1", len(msgs))
+			}
+			var check grpc_health_v1.HealthCheckResponse
+			if err := proto.Unmarshal(msgs[0], &check); err != nil {
+				t.Fatalf("Unmarshal(): %v", err)
+			}
+			if got, want := check.Status, grpc_health_v1.HealthCheckResponse_SERVING; got != want {
+				t.Errorf("status %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestH2COtherContentTypes(t *testing.T) {
+	gw := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("gateway"))
+	})
+	for _, tc := range []struct {
+		desc     string
+		gw       http.Handler
+		wantCode int
+		wantBody string
+	}{
+		{desc: "gateway", gw: gw, wantCode: http.StatusOK, wantBody: "gateway"},
+		{desc: "noGateway", wantCode: http.StatusUnsupportedMediaType},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			hs := newH2CServer(t, tc.gw)
+			resp, err := http.Post(hs.URL+"/trillian.TrillianLog/GetLatestSignedLogRoot", "application/json", strings.NewReader("{}"))
+			if err != nil {
+				t.Fatalf("Post(): %v", err)
+			}
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("ReadAll(): %v", err)
+			}
+			if resp.StatusCode != tc.wantCode {
+				t.Errorf("status code %d, want %d", resp.StatusCode, tc.wantCode)
+			}
+			if tc.wantBody != "" && string(body) != tc.wantBody {
+				t.Errorf("body %q, want %q", body, tc.wantBody)
+			}
+		})
+	}
+}
+
+// decodeText decodes the body of an application/grpc-web-text response, which
+// may be made of several padded base64 parts.
+func decodeText(t *testing.T, body []byte) []byte {
+	t.Helper()
+	var ret []byte
+	for ; len(body) >= 4; body = body[4:] {
+		b, err := base64.StdEncoding.DecodeString(string(body[:4]))
+		if err != nil {
+			t.Fatalf("DecodeString(): %v", err)
+		}
+		ret = append(ret, b...)
+	}
+	if len(body) > 0 {
+		t.Fatalf("truncated base64 body: %q", body)
+	}
+	return ret
+}
+
+// frame returns the gRPC frame of a message.
+func frame(flags byte, msg []byte) []byte {
+	f := make([]byte, 5, 5+len(msg))
+	f[0] = flags
+	binary.BigEndian.PutUint32(f[1:], uint32(len(msg)))
+	return append(f, msg...)
+}
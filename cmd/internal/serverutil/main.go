@@ -59,18 +59,57 @@ type Main struct {
 	// HTTP is optional, if empty it'll not be bound.
 	RPCEndpoint, HTTPEndpoint string
 
+	// HTTPGateway makes the HTTP endpoint also serve the TrillianLog and
+	// TrillianAdmin APIs as REST/JSON, by calling the RPC server.
+	HTTPGateway bool
+
 	// TLS Certificate and Key files for the server.
 	TLSCertFile, TLSKeyFile string
 
+	// H2C makes the RPC endpoint serve gRPC over cleartext HTTP/2 (h2c), for
+	// trusted load balancers which terminate TLS. It also serves gRPC-Web
+	// requests and, with HTTPGateway, the REST/JSON requests, told apart by
+	// their content type. Can't be used with TLSCertFile and TLSKeyFile.
+	H2C bool
+
+	// ACME, if set, makes the HTTP endpoint serve TLS with certificates
+	// acquired and renewed automatically, instead of TLSCertFile and
+	// TLSKeyFile, which still apply to the RPC endpoint.
+	ACME *ACMEConfig
+
 	DBClose func() error
+	// OnShutdown, if set, is called once the servers have stopped, before
+	// the storage is closed.
+	OnShutdown func()
 
 	Registry extension.Registry
 
 	StatsPrefix string
 	QuotaDryRun bool
 
+	// MaxCallerMetrics is the maximum number of distinct authenticated callers
+	// for which per-caller metrics are exported. Zero disables them.
+	MaxCallerMetrics int
+
+	// AnalyticsSampleRate is the fraction of requests sampled for request
+	// analytics. Zero disables them.
+	AnalyticsSampleRate float64
+
+	// ReadCircuitBreakerThreshold is the number of consecutive timed out
+	// reads of a tree after which its reads are shed for
+	// ReadCircuitBreakerOpenDuration. Zero disables the circuit breakers.
+	ReadCircuitBreakerThreshold    int
+	ReadCircuitBreakerOpenDuration time.Duration
+
+	// ConcurrencyLimits bounds the number of concurrent requests of each
+	// RPC class. Classes without a limit are unlimited.
+	ConcurrencyLimits map[interceptor.RPCClass]interceptor.ConcurrencyLimit
+
 	// RegisterServerFn is called to register RPC servers.
 	RegisterServerFn func(*grpc.Server, extension.Registry) error
+	// ExtraServices are the names of services registered with
+	// extension.RegisterService which are added to the RPC server.
+	ExtraServices []string
 
 	// IsHealthy will be called whenever "/healthz" is called on the mux.
 	// A nil return value from this function will result in a 200-OK response
@@ -84,10 +123,28 @@ type Main struct {
 	// bound by Main. nil means unrestricted.
 	AllowedTreeTypes []trillian.TreeType
 
+	// RunbookRPCsEnabled determines whether the Admin Server bound by Main
+	// serves the RPCs which modify tree contents during incident response.
+	RunbookRPCsEnabled bool
+
+	// ReadOnly makes the server reject all mutating requests, and disables
+	// tree garbage collection.
+	ReadOnly bool
+
+	// StrictValidation makes the server reject requests with unknown fields
+	// or enum values.
+	StrictValidation bool
+
 	TreeGCEnabled         bool
 	TreeDeleteThreshold   time.Duration
 	TreeDeleteMinInterval time.Duration
 
+	// TracingUnaryInterceptor and TracingStreamInterceptor, if set, are the
+	// outermost interceptors of the RPC server, so that the spans they start
+	// cover all of the handling of each RPC.
+	TracingUnaryInterceptor  grpc.UnaryServerInterceptor
+	TracingStreamInterceptor grpc.StreamServerInterceptor
+
 	// These will be added to the GRPC server options.
 	ExtraOptions []grpc.ServerOption
 }
@@ -124,57 +181,69 @@ func (m *Main) Run(ctx context.Context) error {
 	if err := m.RegisterServerFn(srv, m.Registry); err != nil {
 		return err
 	}
-	trillian.RegisterTrillianAdminServer(srv, admin.New(m.Registry, m.AllowedTreeTypes))
+	if err := extension.AddServices(srv, m.Registry, m.ExtraServices); err != nil {
+		return err
+	}
+	adminServer := admin.New(m.Registry, m.AllowedTreeTypes)
+	if m.RunbookRPCsEnabled {
+		adminServer.EnableRunbookRPCs()
+	}
+	trillian.RegisterTrillianAdminServer(srv, adminServer)
 	reflection.Register(srv)
 
+	if m.H2C && (m.TLSCertFile != "" || m.TLSKeyFile != "") {
+		return errors.New("h2c can't be used with TLS")
+	}
+
 	g, ctx := errgroup.WithContext(ctx)
 
+	var gw http.Handler
+	if m.HTTPGateway {
+		if gw, err = newGateway(ctx, m.RPCEndpoint, m.TLSCertFile != "" || m.TLSKeyFile != ""); err != nil {
+			return err
+		}
+	}
+
 	if endpoint := m.HTTPEndpoint; endpoint != "" {
 		http.Handle("/metrics", promhttp.Handler())
 		http.HandleFunc("/healthz", m.healthz)
+		if gw != nil {
+			http.Handle("/trillian.TrillianLog/", gw)
+			http.Handle("/trillian.TrillianAdmin/", gw)
+		}
 
 		s := &http.Server{
 			Addr: endpoint,
 		}
-
-		run := func() error {
-			glog.Infof("HTTP server starting on %v", endpoint)
-
-			var err error
-			// Let http.ListenAndServeTLS handle the error case when only one of the flags is set.
-			if m.TLSCertFile != "" || m.TLSKeyFile != "" {
-				err = s.ListenAndServeTLS(m.TLSCertFile, m.TLSKeyFile)
-			} else {
-				err = s.ListenAndServe()
-			}
-
+		if m.ACME != nil {
+			mgr, err := newACMEManager(m.ACME)
 			if err != nil {
-				if errors.Is(err, http.ErrServerClosed) {
-					return nil
-				}
-
-				err = fmt.Errorf("HTTP server stopped: %v", err)
+				return err
 			}
-
-			return err
-		}
-
-		shutdown := func() {
-			glog.Infof("Stopping HTTP server...")
-			glog.Flush()
-
-			// 15 second exit time limit
-			ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
-			defer cancel()
-
-			if err := s.Shutdown(ctx); err != nil {
-				glog.Errorf("Failed to http server shutdown: %v", err)
+			s.TLSConfig = mgr.TLSConfig()
+			if cs := acmeChallengeServer(m.ACME, mgr); cs != nil {
+				g.Go(func() error {
+					return runHTTPServer(ctx, cs, "ACME challenge", cs.ListenAndServe)
+				})
 			}
 		}
 
 		g.Go(func() error {
-			return srvRun(ctx, run, shutdown)
+			return runHTTPServer(ctx, s, "HTTP", func() error {
+				switch {
+				case s.TLSConfig != nil:
+					// The certificates are provided by the ACME manager.
+					return s.ListenAndServeTLS("", "")
+				// Let http.ListenAndServeTLS handle the error case when only one of the flags is set.
+				case m.TLSCertFile != "" || m.TLSKeyFile != "":
+					return s.ListenAndServeTLS(m.TLSCertFile, m.TLSKeyFile)
+				default:
+					return s.ListenAndServe()
+				}
+			})
 		})
+	} else if m.ACME != nil {
+		return errors.New("ACME requires an HTTP endpoint")
 	}
 
 	glog.Infof("RPC server starting on %v", m.RPCEndpoint)
@@ -183,7 +252,9 @@ func (m *Main) Run(ctx context.Context) error {
 		return err
 	}
 
-	if m.TreeGCEnabled {
+	if m.TreeGCEnabled && m.ReadOnly {
+		glog.Info("Deleted tree GC disabled in read-only mode")
+	} else if m.TreeGCEnabled {
 		g.Go(func() error {
 			glog.Info("Deleted tree GC started")
 			gc := admin.NewDeletedTreeGC(
@@ -196,27 +267,42 @@ func (m *Main) Run(ctx context.Context) error {
 		})
 	}
 
-	run := func() error {
-		if err := srv.Serve(lis); err != nil {
-			return fmt.Errorf("RPC server terminated: %v", err)
+	if m.H2C {
+		s := &http.Server{
+			Addr:    m.RPCEndpoint,
+			Handler: newH2CHandler(srv, gw),
 		}
+		g.Go(func() error {
+			return runHTTPServer(ctx, s, "RPC (h2c)", func() error {
+				return s.Serve(lis)
+			})
+		})
+	} else {
+		run := func() error {
+			if err := srv.Serve(lis); err != nil {
+				return fmt.Errorf("RPC server terminated: %v", err)
+			}
 
-		return nil
-	}
+			return nil
+		}
 
-	shutdown := func() {
-		glog.Infof("Stopping RPC server...")
-		glog.Flush()
+		shutdown := func() {
+			glog.Infof("Stopping RPC server...")
+			glog.Flush()
 
-		srv.GracefulStop()
-	}
+			srv.GracefulStop()
+		}
 
-	g.Go(func() error {
-		return srvRun(ctx, run, shutdown)
-	})
+		g.Go(func() error {
+			return srvRun(ctx, run, shutdown)
+		})
+	}
 
 	// wait for all jobs to exit gracefully
 	err = g.Wait()
+	if m.OnShutdown != nil {
+		m.OnShutdown()
+	}
 
 	// Give things a few seconds to tidy up
 	time.Sleep(time.Second * 5)
@@ -228,13 +314,24 @@ func (m *Main) Run(ctx context.Context) error {
 func (m *Main) newGRPCServer() (*grpc.Server, error) {
 	stats := monitoring.NewRPCStatsInterceptor(clock.System, m.StatsPrefix, m.Registry.MetricFactory)
 	ti := interceptor.New(m.Registry.AdminStorage, m.Registry.QuotaManager, m.QuotaDryRun, m.Registry.MetricFactory)
-
+	ti.EnableCallerMetrics(m.MaxCallerMetrics)
+	ti.EnableAnalytics(m.AnalyticsSampleRate)
+	ti.EnableReadCircuitBreakers(m.ReadCircuitBreakerThreshold, m.ReadCircuitBreakerOpenDuration)
+	ti.EnableConcurrencyLimits(m.ConcurrencyLimits)
+	ti.SetReadOnly(m.ReadOnly)
+	ti.SetStrictValidation(m.StrictValidation)
+
+	unary := []grpc.UnaryServerInterceptor{stats.Interceptor(), interceptor.ErrorWrapper, ti.UnaryInterceptor}
+	if m.TracingUnaryInterceptor != nil {
+		unary = append([]grpc.UnaryServerInterceptor{m.TracingUnaryInterceptor}, unary...)
+	}
+	stream := []grpc.StreamServerInterceptor{interceptor.StreamErrorWrapper, ti.StreamInterceptor}
+	if m.TracingStreamInterceptor != nil {
+		stream = append([]grpc.StreamServerInterceptor{m.TracingStreamInterceptor}, stream...)
+	}
 	serverOpts := []grpc.ServerOption{
-		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
-			stats.Interceptor(),
-			interceptor.ErrorWrapper,
-			ti.UnaryInterceptor,
-		)),
+		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(unary...)),
+		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(stream...)),
 	}
 	serverOpts = append(serverOpts, m.ExtraOptions...)
 
@@ -308,6 +405,36 @@ func listenKeepAliveRsp(ctx context.Context, keepAliveRspCh <-chan *clientv3.Lea
 	}
 }
 
+// runHTTPServer runs the server with listen until ctx is done, and then shuts
+// it down.
+func runHTTPServer(ctx context.Context, s *http.Server, name string, listen func() error) error {
+	run := func() error {
+		glog.Infof("%s server starting on %v", name, s.Addr)
+		if err := listen(); err != nil {
+			if errors.Is(err, http.ErrServerClosed) {
+				return nil
+			}
+			return fmt.Errorf("%s server stopped: %v", name, err)
+		}
+		return nil
+	}
+
+	shutdown := func() {
+		glog.Infof("Stopping %s server...", name)
+		glog.Flush()
+
+		// 15 second exit time limit
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+
+		if err := s.Shutdown(ctx); err != nil {
+			glog.Errorf("Failed to %s server shutdown: %v", name, err)
+		}
+	}
+
+	return srvRun(ctx, run, shutdown)
+}
+
 // srvRun run the server and call `shutdown` when the context has been cancelled
 func srvRun(ctx context.Context, run func() error, shutdown func()) error {
 	exit := make(chan struct{})
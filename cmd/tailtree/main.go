@@ -0,0 +1,227 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main contains the implementation and entry point for the tailtree
+// command, which prints the activity of a log as it happens: the batches its
+// signer integrates, the roots it signs, and the changes of the tree, e.g. of
+// its state or quota.
+//
+// Example usage:
+// $ ./tailtree --admin_server=host:port --signer_server=host:port --tree_id=123456789
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian"
+	"github.com/google/trillian/client/rpcflags"
+	"github.com/google/trillian/log/sequencerpb"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	adminServerAddr  = flag.String("admin_server", "", "Address of the gRPC Trillian Admin Server (host:port)")
+	signerServerAddr = flag.String("signer_server", "", "Address of the gRPC endpoint of the log signer which serves the batch reports (host:port). If empty, only the changes of the tree are printed")
+	treeID           = flag.Int64("tree_id", 0, "The ID of the log to tail")
+	pollInterval     = flag.Duration("poll_interval", 5*time.Second, "Interval at which the batch reports are polled")
+	maxReports       = flag.Int("max_reports", 100, "Maximum number of batch reports read per poll. Batches run between two polls beyond this number aren't printed")
+)
+
+func main() {
+	flag.Parse()
+	defer glog.Flush()
+
+	if *adminServerAddr == "" {
+		glog.Exit("Empty --admin_server, please provide the Admin server host:port")
+	}
+	if *treeID == 0 {
+		glog.Exit("Empty --tree_id, please provide the ID of the log to tail")
+	}
+	dialOpts, err := rpcflags.NewClientDialOptionsFromFlags()
+	if err != nil {
+		glog.Exitf("Failed to determine dial options: %v", err)
+	}
+	conn, err := grpc.Dial(*adminServerAddr, dialOpts...)
+	if err != nil {
+		glog.Exitf("Failed to dial %v: %v", *adminServerAddr, err)
+	}
+	defer conn.Close()
+	t := &tailer{
+		w:        os.Stdout,
+		admin:    trillian.NewTrillianAdminClient(conn),
+		treeID:   *treeID,
+		interval: *pollInterval,
+		max:      int32(*maxReports),
+	}
+	if *signerServerAddr != "" {
+		sconn, err := grpc.Dial(*signerServerAddr, dialOpts...)
+		if err != nil {
+			glog.Exitf("Failed to dial %v: %v", *signerServerAddr, err)
+		}
+		defer sconn.Close()
+		t.sequencer = sequencerpb.NewSequencerClient(sconn)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+	if err := t.run(ctx); err != nil && ctx.Err() == nil {
+		glog.Exitf("Tail failed: %v", err)
+	}
+}
+
+// tailer prints the activity of a log until its context is done.
+type tailer struct {
+	w         io.Writer
+	admin     trillian.TrillianAdminClient
+	sequencer sequencerpb.SequencerClient // nil if batches aren't printed.
+	treeID    int64
+	interval  time.Duration
+	max       int32
+}
+
+// run watches the tree and polls the batch reports of its signer, and returns
+// the first error of either.
+func (t *tailer) run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	errc := make(chan error, 2)
+	go func() { errc <- t.watchTree(ctx) }()
+	if t.sequencer != nil {
+		go func() { errc <- t.pollReports(ctx) }()
+	}
+	return <-errc
+}
+
+// watchTree prints the changes of the tree streamed by WatchTrees.
+func (t *tailer) watchTree(ctx context.Context) error {
+	stream, err := t.admin.WatchTrees(ctx, &trillian.WatchTreesRequest{ShowDeleted: true})
+	if err != nil {
+		return err
+	}
+	var last *trillian.Tree
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		tree := event.GetTree()
+		if tree.GetTreeId() != t.treeID {
+			continue
+		}
+		switch event.Type {
+		case trillian.TreeEvent_CREATED:
+			fmt.Fprintf(t.w, "%s tree %d: %s\n", timestamp(time.Now()), t.treeID, strings.Join(treeChanges(nil, tree), ", "))
+		case trillian.TreeEvent_UPDATED:
+			if changes := treeChanges(last, tree); len(changes) > 0 {
+				fmt.Fprintf(t.w, "%s tree %d: %s\n", timestamp(time.Now()), t.treeID, strings.Join(changes, ", "))
+			}
+		case trillian.TreeEvent_DELETED:
+			fmt.Fprintf(t.w, "%s tree %d: deleted\n", timestamp(time.Now()), t.treeID)
+			return nil
+		}
+		last = tree
+	}
+}
+
+// pollReports prints the batch reports of the log, oldest first, each one
+// once.
+func (t *tailer) pollReports(ctx context.Context) error {
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+	var since time.Time
+	for {
+		resp, err := t.sequencer.ListBatchReports(ctx, &sequencerpb.ListBatchReportsRequest{TreeId: t.treeID, MaxReports: t.max})
+		if err != nil {
+			return err
+		}
+		var reports []*sequencerpb.BatchReport
+		reports, since = newReports(resp.GetReports(), since)
+		for _, r := range reports {
+			fmt.Fprintln(t.w, formatReport(r))
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// newReports returns the reports, listed most recent first, which started
+// after since, oldest first, and the start time of the most recent one.
+func newReports(reports []*sequencerpb.BatchReport, since time.Time) ([]*sequencerpb.BatchReport, time.Time) {
+	var ret []*sequencerpb.BatchReport
+	for i := len(reports) - 1; i >= 0; i-- {
+		if start := reports[i].GetStartTime().AsTime(); start.After(since) {
+			ret = append(ret, reports[i])
+			since = start
+		}
+	}
+	return ret, since
+}
+
+// formatReport returns a line describing a sequencing pass, and the root it
+// signed, if any.
+func formatReport(r *sequencerpb.BatchReport) string {
+	line := fmt.Sprintf("%s batch: %d/%d leaves in %v", timestamp(r.GetStartTime().AsTime()), r.GetLeavesIntegrated(), r.GetBatchSize(), r.GetDuration().AsDuration())
+	if d := r.GetMaxMergeDelay(); d != nil {
+		line += fmt.Sprintf(", max merge delay %v", d.AsDuration())
+	}
+	if root := r.GetNewRoot(); root != nil {
+		line += fmt.Sprintf(", signed root size %d hash %x at %s", root.GetTreeSize(), root.GetRootHash(), timestamp(root.GetTimestamp().AsTime()))
+	}
+	if r.GetError() != "" {
+		line += ", failed: " + r.GetError()
+	}
+	return line
+}
+
+// treeChanges describes the changes of the tree which matter to operators,
+// i.e. of its state, integration pause and quota. All of them are described
+// if old is nil.
+func treeChanges(old, tree *trillian.Tree) []string {
+	var ret []string
+	if old == nil || old.TreeState != tree.TreeState {
+		ret = append(ret, "state "+tree.TreeState.String())
+	}
+	if old == nil || !proto.Equal(old.IntegrationPause, tree.IntegrationPause) {
+		if p := tree.IntegrationPause; p != nil {
+			ret = append(ret, fmt.Sprintf("integration paused: %q", p.Reason))
+		} else if old != nil {
+			ret = append(ret, "integration resumed")
+		}
+	}
+	if old == nil || !proto.Equal(old.Quota, tree.Quota) {
+		if q := tree.Quota; q != nil {
+			ret = append(ret, fmt.Sprintf("quota read %g/s burst %d, write %g/s burst %d", q.ReadTokensPerSecond, q.ReadBurst, q.WriteTokensPerSecond, q.WriteBurst))
+		} else if old != nil {
+			ret = append(ret, "quota removed")
+		}
+	}
+	return ret
+}
+
+// timestamp formats t for the output.
+func timestamp(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
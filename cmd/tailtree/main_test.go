@@ -0,0 +1,116 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/log/sequencerpb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestNewReports(t *testing.T) {
+	start := time.Unix(1500000000, 0)
+	report := func(sec int64) *sequencerpb.BatchReport {
+		return &sequencerpb.BatchReport{StartTime: timestamppb.New(start.Add(time.Duration(sec) * time.Second))}
+	}
+	// Reports are listed most recent first.
+	reports := []*sequencerpb.BatchReport{report(3), report(2), report(1)}
+
+	got, since := newReports(reports, time.Time{})
+	if want := []*sequencerpb.BatchReport{reports[2], reports[1], reports[0]}; !reflect.DeepEqual(got, want) {
+		t.Errorf("newReports(): %v, want %v", got, want)
+	}
+	if want := start.Add(3 * time.Second); !since.Equal(want) {
+		t.Errorf("newReports(): since %v, want %v", since, want)
+	}
+
+	got, since = newReports(append([]*sequencerpb.BatchReport{report(4)}, reports...), since)
+	if want := []*sequencerpb.BatchReport{report(4)}; !reflect.DeepEqual(got, want) {
+		t.Errorf("newReports() after first poll: %v, want %v", got, want)
+	}
+	if got, _ := newReports(reports, since); len(got) != 0 {
+		t.Errorf("newReports() of seen reports: %v, want none", got)
+	}
+}
+
+func TestFormatReport(t *testing.T) {
+	start := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, tc := range []struct {
+		desc   string
+		report *sequencerpb.BatchReport
+		want   string
+	}{
+		{
+			desc: "signed",
+			report: &sequencerpb.BatchReport{
+				StartTime:        timestamppb.New(start),
+				Duration:         durationpb.New(50 * time.Millisecond),
+				BatchSize:        100,
+				LeavesIntegrated: 10,
+				MaxMergeDelay:    durationpb.New(2 * time.Second),
+				NewRoot:          &sequencerpb.Root{TreeSize: 42, RootHash: []byte{0xab, 0xcd}, Timestamp: timestamppb.New(start)},
+			},
+			want: "2022-01-02T03:04:05Z batch: 10/100 leaves in 50ms, max merge delay 2s, signed root size 42 hash abcd at 2022-01-02T03:04:05Z",
+		},
+		{
+			desc: "failed",
+			report: &sequencerpb.BatchReport{
+				StartTime: timestamppb.New(start),
+				Duration:  durationpb.New(time.Second),
+				BatchSize: 100,
+				Error:     "storage unavailable",
+			},
+			want: "2022-01-02T03:04:05Z batch: 0/100 leaves in 1s, failed: storage unavailable",
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := formatReport(tc.report); got != tc.want {
+				t.Errorf("formatReport(): %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTreeChanges(t *testing.T) {
+	tree := &trillian.Tree{TreeId: 1, TreeState: trillian.TreeState_ACTIVE}
+	paused := &trillian.Tree{TreeId: 1, TreeState: trillian.TreeState_ACTIVE, IntegrationPause: &trillian.IntegrationPause{Reason: "maintenance"}}
+	quota := &trillian.Tree{TreeId: 1, TreeState: trillian.TreeState_ACTIVE, Quota: &trillian.TreeQuota{ReadTokensPerSecond: 10, WriteTokensPerSecond: 1.5, WriteBurst: 3}}
+	frozen := &trillian.Tree{TreeId: 1, TreeState: trillian.TreeState_FROZEN}
+
+	for _, tc := range []struct {
+		desc      string
+		old, tree *trillian.Tree
+		want      []string
+	}{
+		{desc: "created", tree: tree, want: []string{"state ACTIVE"}},
+		{desc: "unchanged", old: tree, tree: tree},
+		{desc: "paused", old: tree, tree: paused, want: []string{`integration paused: "maintenance"`}},
+		{desc: "resumed", old: paused, tree: tree, want: []string{"integration resumed"}},
+		{desc: "quota", old: tree, tree: quota, want: []string{"quota read 10/s burst 0, write 1.5/s burst 3"}},
+		{desc: "quotaRemoved", old: quota, tree: tree, want: []string{"quota removed"}},
+		{desc: "frozen", old: tree, tree: frozen, want: []string{"state FROZEN"}},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := treeChanges(tc.old, tc.tree); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("treeChanges(): %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main contains the implementation and entry point for the
+// trillian_log_exporter command, which continuously writes the tiles, entry
+// bundles and checkpoints of a log to object storage, from where they can be
+// served by a CDN.
+//
+// Example usage:
+// $ ./trillian_log_exporter --log_server=host:port --log_id=123 --bucket=gs://bucket/log-123
+//
+// The log server must be run with --checkpoint_key. The layout of the files
+// is described in the export package.
+package main
+
+import (
+	"context"
+	"flag"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian"
+	"github.com/google/trillian/client/rpcflags"
+	"github.com/google/trillian/export"
+	"github.com/google/trillian/util"
+	"google.golang.org/grpc"
+)
+
+var (
+	logServerAddr = flag.String("log_server", "", "Address of the gRPC Trillian Log Server (host:port)")
+	logID         = flag.Int64("log_id", 0, "ID of the log to export")
+	bucketURI     = flag.String("bucket", "", "Where to write the log to: a GCS bucket (gs://bucket/prefix), an S3 bucket (s3://bucket/prefix), or a local directory")
+	interval      = flag.Duration("interval", 10*time.Second, "Interval between exports of the latest root of the log")
+	batchSize     = flag.Int64("batch_size", 256, "Maximum number of leaves to request with each GetLeavesByRange call")
+)
+
+func main() {
+	flag.Parse()
+	defer glog.Flush()
+
+	if *logServerAddr == "" || *logID == 0 || *bucketURI == "" {
+		glog.Exit("--log_server, --log_id and --bucket must be set")
+	}
+	if *interval <= 0 || *batchSize <= 0 {
+		glog.Exit("--interval and --batch_size must be positive")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go util.AwaitSignal(ctx, cancel)
+
+	dialOpts, err := rpcflags.NewClientDialOptionsFromFlags()
+	if err != nil {
+		glog.Exitf("Failed to determine dial options: %v", err)
+	}
+	conn, err := grpc.Dial(*logServerAddr, dialOpts...)
+	if err != nil {
+		glog.Exitf("Failed to dial %v: %v", *logServerAddr, err)
+	}
+	defer conn.Close()
+
+	bucket, err := export.OpenBucket(ctx, *bucketURI)
+	if err != nil {
+		glog.Exitf("Failed to open bucket: %v", err)
+	}
+	e := export.New(trillian.NewTrillianLogClient(conn), *logID, bucket, *batchSize)
+	glog.Infof("Exporting log %d to %s every %v", *logID, *bucketURI, *interval)
+	if err := e.Run(ctx, *interval); err != context.Canceled {
+		glog.Exitf("Exporter stopped: %v", err)
+	}
+}
@@ -18,6 +18,8 @@ package main
 
 import (
 	"context"
+	"crypto"
+	"errors"
 	"flag"
 	"fmt"
 	_ "net/http/pprof" // Register pprof HTTP handlers.
@@ -29,51 +31,123 @@ import (
 	"github.com/golang/glog"
 	"github.com/google/trillian"
 	"github.com/google/trillian/cmd"
+	"github.com/google/trillian/cmd/internal/masterelection"
 	"github.com/google/trillian/cmd/internal/serverutil"
+	"github.com/google/trillian/crypto/keys/awskms"
+	"github.com/google/trillian/crypto/keys/pem"
+	"github.com/google/trillian/crypto/keys/pkcs11"
+	"github.com/google/trillian/crypto/keys/remote"
+	"github.com/google/trillian/crypto/keyspb"
 	"github.com/google/trillian/extension"
 	"github.com/google/trillian/monitoring"
-	"github.com/google/trillian/monitoring/opencensus"
+	"github.com/google/trillian/monitoring/opentelemetry"
 	"github.com/google/trillian/monitoring/prometheus"
 	"github.com/google/trillian/quota"
 	"github.com/google/trillian/quota/etcd"
 	"github.com/google/trillian/quota/etcd/quotaapi"
 	"github.com/google/trillian/quota/etcd/quotapb"
 	"github.com/google/trillian/server"
+	"github.com/google/trillian/server/interceptor"
 	"github.com/google/trillian/storage"
 	"github.com/google/trillian/util"
 	"github.com/google/trillian/util/clock"
+	"github.com/google/trillian/witness"
+	"github.com/google/trillian/witness/witnesspb"
 	clientv3 "go.etcd.io/etcd/client/v3"
+	"golang.org/x/crypto/acme"
+	"golang.org/x/mod/sumdb/note"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/prototext"
 
 	// Register supported storage providers.
+	_ "github.com/google/trillian/storage/archive"
+	_ "github.com/google/trillian/storage/bbolt"
 	_ "github.com/google/trillian/storage/cloudspanner"
+	_ "github.com/google/trillian/storage/crdb"
 	_ "github.com/google/trillian/storage/mysql"
+	_ "github.com/google/trillian/storage/postgres"
 
-	// Load MySQL quota provider
+	// The database/sql driver of the postgresql and crdb storage providers.
+	_ "github.com/lib/pq"
+
+	// Load MySQL and Redis quota providers
 	_ "github.com/google/trillian/quota/mysqlqm"
+	_ "github.com/google/trillian/quota/redis/redisqm"
 )
 
 var (
 	rpcEndpoint     = flag.String("rpc_endpoint", "localhost:8090", "Endpoint for RPC requests (host:port)")
 	httpEndpoint    = flag.String("http_endpoint", "localhost:8091", "Endpoint for HTTP metrics (host:port, empty means disabled)")
+	httpGateway     = flag.Bool("http_gateway", false, "If true, the TrillianLog and TrillianAdmin APIs are also served as REST/JSON on --http_endpoint, at /trillian.TrillianLog/<method> and /trillian.TrillianAdmin/<method>")
 	healthzTimeout  = flag.Duration("healthz_timeout", time.Second*5, "Timeout used during healthz checks")
 	tlsCertFile     = flag.String("tls_cert_file", "", "Path to the TLS server certificate. If unset, the server will use unsecured connections.")
 	tlsKeyFile      = flag.String("tls_key_file", "", "Path to the TLS server key. If unset, the server will use unsecured connections.")
+	rpcH2C          = flag.Bool("rpc_h2c", false, "If true, --rpc_endpoint serves gRPC over cleartext HTTP/2 (h2c), for trusted load balancers which terminate TLS, as well as gRPC-Web requests and, with --http_gateway, REST/JSON requests, told apart by their content type. Can't be used with --tls_cert_file and --tls_key_file")
+	acmeHosts       = flag.String("acme_hosts", "", "If set, comma-separated host names whose TLS certificates for --http_endpoint are acquired and renewed automatically from an ACME certificate authority, such as Let's Encrypt, instead of using --tls_cert_file and --tls_key_file. Requires --acme_cache_dir")
+	acmeCacheDir    = flag.String("acme_cache_dir", "", "Directory which the ACME account key and certificates of --acme_hosts are stored in")
+	acmeEmail       = flag.String("acme_email", "", "Contact email address of the ACME account of --acme_hosts")
+	acmeDirectory   = flag.String("acme_directory_url", acme.LetsEncryptURL, "Directory URL of the ACME certificate authority of --acme_hosts")
+	acmeChallenge   = flag.String("acme_http_challenge_endpoint", "", "If set, the endpoint (host:port) serving the HTTP-01 challenges of --acme_hosts, which must be reachable on their port 80, and redirecting other requests to HTTPS. Otherwise --http_endpoint must be reachable on port 443 for TLS-ALPN-01 challenges")
 	etcdService     = flag.String("etcd_service", "trillian-logserver", "Service name to announce ourselves under")
 	etcdHTTPService = flag.String("etcd_http_service", "trillian-logserver-http", "Service name to announce our HTTP endpoint under")
 
 	quotaSystem = flag.String("quota_system", "mysql", fmt.Sprintf("Quota system to use. One of: %v", quota.Providers()))
 	quotaDryRun = flag.Bool("quota_dry_run", false, "If true no requests are blocked due to lack of tokens")
 
+	maxCallerMetrics        = flag.Int("max_caller_metrics", 0, "Maximum number of distinct authenticated callers to export per-caller request, byte and quota metrics for; further callers are reported as \"other\". Zero disables per-caller metrics")
+	analyticsSampleRate     = flag.Float64("analytics_sample_rate", 0, "Fraction of requests, between 0 and 1, for which range widths, leaf sizes and proof sizes are exported as aggregated per-method histograms. Leaf contents, trees and callers are not recorded. Zero disables request analytics")
+	readBreakerThreshold    = flag.Int("read_circuit_breaker_threshold", 0, "Number of consecutive timed out read requests for a tree after which its reads fail fast with Unavailable for --read_circuit_breaker_open_duration. Zero disables the circuit breakers")
+	readBreakerOpenDuration = flag.Duration("read_circuit_breaker_open_duration", 10*time.Second, "Time for which the reads of a tree are shed once its circuit breaker opened, before a probe request is let through")
+
+	maxConcurrentWrites = flag.Int("max_concurrent_writes", 0, "Maximum number of write requests (QueueLeaf, AddSequencedLeaves, InitLog, UpdateLeafExtraData) handled at the same time. Zero means unlimited")
+	maxConcurrentProofs = flag.Int("max_concurrent_proofs", 0, "Maximum number of root and proof requests handled at the same time. Zero means unlimited")
+	maxConcurrentRanges = flag.Int("max_concurrent_range_reads", 0, "Maximum number of leaf range requests (GetLeavesByRange and other listings) handled at the same time. Zero means unlimited")
+	maxConcurrentAdmin  = flag.Int("max_concurrent_admin", 0, "Maximum number of admin and quota requests handled at the same time. Zero means unlimited")
+	maxQueuedRequests   = flag.Int("max_queued_requests", 0, "Maximum number of requests of each class waiting for one of the --max_concurrent_* limits; further requests fail with ResourceExhausted")
+
+	queueBatchSize        = flag.Int("queue_leaves_stream_batch_size", server.DefaultQueueBatchSize, "Maximum number of leaves of a QueueLeavesStream call which are queued together, in one storage transaction")
+	maxPendingMemoryAcks  = flag.Int("max_pending_memory_acks", server.DefaultMaxPendingMemoryAcks, "Maximum number of leaves acknowledged with ACK_AFTER_MEMORY which wait to be stored. Further such requests fail with ResourceExhausted")
+	memoryAckWriteTimeout = flag.Duration("memory_ack_write_timeout", server.DefaultMemoryAckWriteTimeout, "Timeout of the storage writes of leaves acknowledged with ACK_AFTER_MEMORY, after which they are lost")
+	storageUsageRefresh   = flag.Duration("storage_usage_refresh_interval", server.DefaultStorageUsageRefresh, "Interval at which the storage usage of logs with storage caps is read again, to check their caps")
+
+	auditSessionTTL  = flag.Duration("audit_session_ttl", server.DefaultAuditSessionTTL, "Time after which audit sessions expire if they aren't used")
+	maxAuditSessions = flag.Int("max_audit_sessions", server.DefaultMaxAuditSessions, "Maximum number of audit sessions held by the server. Further BeginAuditSession calls fail with ResourceExhausted")
+
+	promiseKey         = flag.String("inclusion_promise_key", "", "PEM file with the password-protected private key which signs the inclusion promises returned for trees with a max merge delay")
+	promiseKeyPassword = flag.String("inclusion_promise_key_password", "", "Password of --inclusion_promise_key")
+	promiseKMSKey      = flag.String("inclusion_promise_aws_kms_key", "", "Key ID, alias or ARN of the AWS KMS key which signs the inclusion promises, instead of --inclusion_promise_key")
+	promisePKCS11      = flag.String("inclusion_promise_pkcs11_config", "", "File with the keyspb.PKCS11Config, in protobuf text format, of the HSM key which signs the inclusion promises, instead of --inclusion_promise_key. Requires a binary built with the pkcs11 tag")
+	promiseRemote      = flag.String("inclusion_promise_remote_signer_config", "", "File with the keyspb.RemoteSignerConfig, in protobuf text format, of the external signing service which signs the inclusion promises, instead of --inclusion_promise_key")
+	pkcs11ModulePath   = flag.String("pkcs11_module_path", "", "Path to the PKCS#11 module of the HSM used with --inclusion_promise_pkcs11_config")
+
+	checkpointKey     = flag.String("checkpoint_key", "", "File with the signed note private key, as generated by golang.org/x/mod/sumdb/note.GenerateKey, which signs the checkpoints returned with the latest log roots")
+	checkpointRemote  = flag.String("checkpoint_remote_signer_config", "", "File with the keyspb.RemoteSignerConfig, in protobuf text format, of the external signing service whose Ed25519 key signs the checkpoints, instead of --checkpoint_key")
+	checkpointKeyName = flag.String("checkpoint_key_name", "", "Name of the key which signs the checkpoints, as it appears in their signatures. Required with --checkpoint_remote_signer_config")
+	checkpointOrigin  = flag.String("checkpoint_origin_prefix", "trillian/", "Prefix of the checkpoint origin lines, which is followed by the tree ID")
+	witnessConfig     = flag.String("witness_config", "", "File with the witnesspb.WitnessConfig, in protobuf text format, of the witnesses which cosign the checkpoints. Requires --checkpoint_key or --checkpoint_remote_signer_config")
+
+	consistencyCheck         = flag.Bool("startup_consistency_check", true, "If true, the stored roots, tree nodes and leaves of every log are checked for consistency in the background from startup, and logs which fail the check are not served. Logs are refused with UNAVAILABLE until they've been checked")
+	consistencyCheckInterval = flag.Duration("consistency_check_interval", time.Hour, "Interval at which the consistency check of --startup_consistency_check is repeated, so that repaired logs are served again and new logs are checked. Zero checks only on startup")
+
+	runbookRPCs = flag.Bool("enable_runbook_rpcs", false, "If true, the admin RPCs which re-sign log roots and quarantine or requeue leaves are served. Every call is audit logged. Re-signing needs --master_election and its flags set as for the signers, to take the mastership of the tree from them")
+
+	readOnly = flag.Bool("read_only", false, "If true, all RPCs which modify trees, leaves or quota configs fail with FailedPrecondition, while reads are served, and deleted trees aren't garbage collected. Services added with --extra_services aren't affected")
+
+	strictValidation = flag.Bool("strict_request_validation", false, "If true, requests which have fields or enum values unknown to this server, e.g. sent by clients built from newer or altered protos, fail with InvalidArgument instead of being served as if those weren't set")
+
+	extraServices = flag.String("extra_services", "", fmt.Sprintf("Comma-separated names of additional gRPC services to serve on the RPC endpoint, as registered by linked-in personalities. Any of: %v", extension.Services()))
+
 	storageSystem = flag.String("storage_system", "mysql", fmt.Sprintf("Storage system to use. One of: %v", storage.Providers()))
 
 	treeGCEnabled            = flag.Bool("tree_gc", true, "If true, tree garbage collection (hard-deletion) is periodically performed")
 	treeDeleteThreshold      = flag.Duration("tree_delete_threshold", serverutil.DefaultTreeDeleteThreshold, "Minimum period a tree has to remain deleted before being hard-deleted")
 	treeDeleteMinRunInterval = flag.Duration("tree_delete_min_run_interval", serverutil.DefaultTreeDeleteMinInterval, "Minimum interval between tree garbage collection sweeps. Actual runs happen randomly between [minInterval,2*minInterval).")
 
-	tracing          = flag.Bool("tracing", false, "If true opencensus Stackdriver tracing will be enabled. See https://opencensus.io/.")
-	tracingProjectID = flag.String("tracing_project_id", "", "project ID to pass to stackdriver. Can be empty for GCP, consult docs for other platforms.")
-	tracingPercent   = flag.Int("tracing_percent", 0, "Percent of requests to be traced. Zero is a special case to use the DefaultSampler")
+	tracing             = flag.Bool("tracing", false, "If true, OpenTelemetry traces of RPCs, sequencing batches and storage transactions are exported over OTLP/gRPC to --tracing_otlp_endpoint")
+	tracingOTLPEndpoint = flag.String("tracing_otlp_endpoint", "localhost:4317", "Endpoint (host:port) of the OTLP/gRPC receiver, e.g. an OpenTelemetry collector, which traces are exported to")
+	tracingOTLPInsecure = flag.Bool("tracing_otlp_insecure", false, "If true, traces are exported to --tracing_otlp_endpoint without TLS")
+	tracingProjectID    = flag.String("tracing_project_id", "", "Deprecated: ignored, as traces are exported over OTLP. Configure the project in the exporter of the OpenTelemetry collector instead")
+	tracingPercent      = flag.Int("tracing_percent", 0, "Percent of requests to be traced. Zero is a special case to use the default sampler, which traces 0.01% of requests. Requests whose caller traces them are always traced")
 
 	configFile = flag.String("config", "", "Config file containing flags, file contents can be overridden by command line flags")
 
@@ -96,17 +170,30 @@ func main() {
 	defer cancel()
 	go util.AwaitSignal(ctx, cancel)
 
-	var options []grpc.ServerOption
 	mf := prometheus.MetricFactory{}
-	monitoring.SetStartSpan(opencensus.StartSpan)
+	monitoring.SetStartSpan(opentelemetry.StartSpan)
 
+	var tracingUnary grpc.UnaryServerInterceptor
+	var tracingStream grpc.StreamServerInterceptor
 	if *tracing {
-		opts, err := opencensus.EnableRPCServerTracing(*tracingProjectID, *tracingPercent)
+		if *tracingProjectID != "" {
+			glog.Warning("--tracing_project_id is deprecated and ignored, traces are exported to --tracing_otlp_endpoint")
+		}
+		shutdown, err := opentelemetry.EnableTracing(ctx, opentelemetry.Options{
+			ServiceName: "trillian_log_server",
+			Endpoint:    *tracingOTLPEndpoint,
+			Insecure:    *tracingOTLPInsecure,
+			Percent:     *tracingPercent,
+		})
 		if err != nil {
-			glog.Exitf("Failed to initialize stackdriver / opencensus tracing: %v", err)
+			glog.Exitf("Failed to initialize OpenTelemetry tracing: %v", err)
 		}
-		// Enable the server request counter tracing etc.
-		options = append(options, opts...)
+		defer func() {
+			if err := shutdown(context.Background()); err != nil {
+				glog.Warningf("Failed to export remaining traces: %v", err)
+			}
+		}()
+		tracingUnary, tracingStream = opentelemetry.UnaryServerInterceptor(), opentelemetry.StreamServerInterceptor()
 	}
 
 	sp, err := storage.NewProvider(*storageSystem, mf)
@@ -146,6 +233,16 @@ func main() {
 		QuotaManager:  qm,
 		MetricFactory: mf,
 	}
+	if masterelection.Explicit() {
+		// Joins the election of the signers, for ResignLogRoot. It's only
+		// joined if set explicitly, as --etcd_servers may only be set for
+		// quotas, and the signers may use another election system.
+		hostname, _ := os.Hostname()
+		instanceID := fmt.Sprintf("%s.%d", hostname, os.Getpid())
+		if registry.ElectionFactory, err = masterelection.NewFactory(instanceID, client, mf); err != nil {
+			glog.Exitf("Failed to join the master election of the signers: %v", err)
+		}
+	}
 
 	// Enable CPU profile if requested.
 	if *cpuProfile != "" {
@@ -154,33 +251,92 @@ func main() {
 		defer pprof.StopCPUProfile()
 	}
 
+	// The log server is created with the RPC server, and waited for on
+	// shutdown.
+	var logServer *server.TrillianLogRPCServer
 	m := serverutil.Main{
 		RPCEndpoint:  *rpcEndpoint,
 		HTTPEndpoint: *httpEndpoint,
+		HTTPGateway:  *httpGateway,
 		TLSCertFile:  *tlsCertFile,
 		TLSKeyFile:   *tlsKeyFile,
+		H2C:          *rpcH2C,
+		ACME:         acmeConfig(),
 		StatsPrefix:  "log",
-		ExtraOptions: options,
 		QuotaDryRun:  *quotaDryRun,
 		DBClose:      sp.Close,
 		Registry:     registry,
+
+		TracingUnaryInterceptor:  tracingUnary,
+		TracingStreamInterceptor: tracingStream,
 		RegisterServerFn: func(s *grpc.Server, registry extension.Registry) error {
-			logServer := server.NewTrillianLogRPCServer(registry, clock.System)
+			logServer = server.NewTrillianLogRPCServer(registry, clock.System)
 			if err := logServer.IsHealthy(); err != nil {
 				return err
 			}
+			logServer.SetQueueBatchSize(*queueBatchSize)
+			logServer.SetMaxPendingMemoryAcks(*maxPendingMemoryAcks)
+			logServer.SetMemoryAckWriteTimeout(*memoryAckWriteTimeout)
+			logServer.SetStorageUsageRefresh(*storageUsageRefresh)
+			logServer.SetAuditSessionLimits(*auditSessionTTL, *maxAuditSessions)
+			signer, err := inclusionPromiseSigner(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to load inclusion promise key: %v", err)
+			}
+			if signer != nil {
+				logServer.EnableInclusionPromises(signer)
+			}
+			cpSigner, err := checkpointSigner(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to load checkpoint key: %v", err)
+			}
+			if cpSigner != nil {
+				logServer.EnableCheckpoints(cpSigner, *checkpointOrigin)
+			}
+			if *witnessConfig != "" {
+				if cpSigner == nil {
+					return errors.New("--witness_config requires --checkpoint_key or --checkpoint_remote_signer_config")
+				}
+				policy, err := witnessPolicy(*witnessConfig)
+				if err != nil {
+					return fmt.Errorf("failed to load witness config: %v", err)
+				}
+				logServer.EnableWitnessing(policy)
+			}
+			if *consistencyCheck {
+				logServer.StartConsistencyChecks(ctx, *consistencyCheckInterval)
+			}
 			trillian.RegisterTrillianLogServer(s, logServer)
 			if *quotaSystem == etcd.QuotaManagerName {
 				quotapb.RegisterQuotaServer(s, quotaapi.NewServer(client))
 			}
 			return nil
 		},
+		OnShutdown: func() {
+			if logServer != nil {
+				logServer.WaitForMemoryAcks()
+			}
+		},
 		IsHealthy: func(ctx context.Context) error {
 			as := sp.AdminStorage()
 			return as.CheckDatabaseAccessible(ctx)
 		},
-		HealthyDeadline:       *healthzTimeout,
+		HealthyDeadline:                *healthzTimeout,
+		MaxCallerMetrics:               *maxCallerMetrics,
+		AnalyticsSampleRate:            *analyticsSampleRate,
+		ReadCircuitBreakerThreshold:    *readBreakerThreshold,
+		ReadCircuitBreakerOpenDuration: *readBreakerOpenDuration,
+		ConcurrencyLimits: map[interceptor.RPCClass]interceptor.ConcurrencyLimit{
+			interceptor.WriteRPCs: {MaxInFlight: *maxConcurrentWrites, MaxQueued: *maxQueuedRequests},
+			interceptor.ProofRPCs: {MaxInFlight: *maxConcurrentProofs, MaxQueued: *maxQueuedRequests},
+			interceptor.RangeRPCs: {MaxInFlight: *maxConcurrentRanges, MaxQueued: *maxQueuedRequests},
+			interceptor.AdminRPCs: {MaxInFlight: *maxConcurrentAdmin, MaxQueued: *maxQueuedRequests},
+		},
 		AllowedTreeTypes:      []trillian.TreeType{trillian.TreeType_LOG, trillian.TreeType_PREORDERED_LOG},
+		RunbookRPCsEnabled:    *runbookRPCs,
+		ReadOnly:              *readOnly,
+		StrictValidation:      *strictValidation,
+		ExtraServices:         splitServices(*extraServices),
 		TreeGCEnabled:         *treeGCEnabled,
 		TreeDeleteThreshold:   *treeDeleteThreshold,
 		TreeDeleteMinInterval: *treeDeleteMinRunInterval,
@@ -196,6 +352,17 @@ func main() {
 	}
 }
 
+// splitServices returns the service names in a comma-separated list.
+func splitServices(list string) []string {
+	var names []string
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
 func mustCreate(fileName string) *os.File {
 	f, err := os.Create(fileName)
 	if err != nil {
@@ -203,3 +370,103 @@ func mustCreate(fileName string) *os.File {
 	}
 	return f
 }
+
+// inclusionPromiseSigner returns the signer of inclusion promises given by
+// the flags, or nil if none is given.
+func inclusionPromiseSigner(ctx context.Context) (crypto.Signer, error) {
+	set := 0
+	for _, f := range []string{*promiseKey, *promiseKMSKey, *promisePKCS11, *promiseRemote} {
+		if f != "" {
+			set++
+		}
+	}
+	if set > 1 {
+		return nil, errors.New("only one of --inclusion_promise_key, --inclusion_promise_aws_kms_key, --inclusion_promise_pkcs11_config and --inclusion_promise_remote_signer_config may be set")
+	}
+	switch {
+	case *promiseKey != "":
+		return pem.ReadPrivateKeyFile(*promiseKey, *promiseKeyPassword)
+	case *promiseKMSKey != "":
+		return awskms.FromConfig(ctx, &keyspb.AWSKMSConfig{KeyId: *promiseKMSKey})
+	case *promisePKCS11 != "":
+		b, err := os.ReadFile(*promisePKCS11)
+		if err != nil {
+			return nil, err
+		}
+		var config keyspb.PKCS11Config
+		if err := prototext.Unmarshal(b, &config); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %v", *promisePKCS11, err)
+		}
+		return pkcs11.FromConfig(*pkcs11ModulePath, &config)
+	case *promiseRemote != "":
+		return remoteSigner(ctx, *promiseRemote)
+	}
+	return nil, nil
+}
+
+// checkpointSigner returns the signer of checkpoints given by the flags, or nil
+// if none is given.
+func checkpointSigner(ctx context.Context) (note.Signer, error) {
+	switch {
+	case *checkpointKey != "" && *checkpointRemote != "":
+		return nil, errors.New("only one of --checkpoint_key and --checkpoint_remote_signer_config may be set")
+	case *checkpointKey != "":
+		b, err := os.ReadFile(*checkpointKey)
+		if err != nil {
+			return nil, err
+		}
+		return note.NewSigner(strings.TrimSpace(string(b)))
+	case *checkpointRemote != "":
+		if *checkpointKeyName == "" {
+			return nil, errors.New("--checkpoint_remote_signer_config requires --checkpoint_key_name")
+		}
+		signer, err := remoteSigner(ctx, *checkpointRemote)
+		if err != nil {
+			return nil, err
+		}
+		return remote.NewNoteSigner(*checkpointKeyName, signer)
+	}
+	return nil, nil
+}
+
+// remoteSigner returns the signer of the external signing service configured
+// in the file at path.
+func remoteSigner(ctx context.Context, path string) (*remote.Signer, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var config keyspb.RemoteSignerConfig
+	if err := prototext.Unmarshal(b, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", path, err)
+	}
+	return remote.FromConfig(ctx, &config)
+}
+
+// witnessPolicy reads the witness configuration from the file at path.
+func witnessPolicy(path string) (*witness.Policy, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var config witnesspb.WitnessConfig
+	if err := prototext.Unmarshal(b, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", path, err)
+	}
+	return witness.NewPolicy(&config)
+}
+
+// acmeConfig returns the ACME configuration of the flags, or nil if ACME is
+// disabled.
+func acmeConfig() *serverutil.ACMEConfig {
+	if *acmeHosts == "" {
+		return nil
+	}
+	return &serverutil.ACMEConfig{
+		Hosts:             strings.Split(*acmeHosts, ","),
+		CacheDir:          *acmeCacheDir,
+		Email:             *acmeEmail,
+		DirectoryURL:      *acmeDirectory,
+		ChallengeEndpoint: *acmeChallenge,
+	}
+}
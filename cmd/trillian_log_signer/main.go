@@ -19,19 +19,23 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"io"
 	_ "net/http/pprof" // Register pprof HTTP handlers.
 	"os"
 	"runtime/pprof"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/golang/glog"
 	"github.com/google/trillian/cmd"
+	"github.com/google/trillian/cmd/internal/masterelection"
 	"github.com/google/trillian/cmd/internal/serverutil"
 	"github.com/google/trillian/extension"
 	"github.com/google/trillian/log"
+	"github.com/google/trillian/log/sequencerpb"
 	"github.com/google/trillian/monitoring"
-	"github.com/google/trillian/monitoring/opencensus"
+	"github.com/google/trillian/monitoring/opentelemetry"
 	"github.com/google/trillian/monitoring/prometheus"
 	"github.com/google/trillian/quota"
 	"github.com/google/trillian/quota/etcd"
@@ -40,16 +44,23 @@ import (
 	"github.com/google/trillian/util/clock"
 	"github.com/google/trillian/util/election"
 	"github.com/google/trillian/util/election2"
-	etcdelect "github.com/google/trillian/util/election2/etcd"
+	"github.com/google/trillian/util/election2/electionpb"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"google.golang.org/grpc"
 
 	// Register supported storage providers.
+	_ "github.com/google/trillian/storage/bbolt"
 	_ "github.com/google/trillian/storage/cloudspanner"
+	_ "github.com/google/trillian/storage/crdb"
 	_ "github.com/google/trillian/storage/mysql"
+	_ "github.com/google/trillian/storage/postgres"
 
-	// Load MySQL quota provider
+	// The database/sql driver of the postgresql and crdb storage providers.
+	_ "github.com/lib/pq"
+
+	// Load MySQL and Redis quota providers
 	_ "github.com/google/trillian/quota/mysqlqm"
+	_ "github.com/google/trillian/quota/redis/redisqm"
 )
 
 var (
@@ -60,11 +71,34 @@ var (
 	sequencerIntervalFlag    = flag.Duration("sequencer_interval", 100*time.Millisecond, "Time between each sequencing pass through all logs")
 	batchSizeFlag            = flag.Int("batch_size", 1000, "Max number of leaves to process per batch")
 	numSeqFlag               = flag.Int("num_sequencers", 10, "Number of sequencer workers to run in parallel")
+	sequencerWorkers         = flag.Int("sequencer_workers", 0, "If set, the number of workers which each take the next due log as soon as they're free, in weighted round robin order, so that a slow pass over a large log doesn't hold up the others. Replaces the rounds over all logs of --num_sequencers, and --sequencer_interval becomes the time between the passes over each log")
+	sequencerTreeWeights     = flag.String("sequencer_tree_weights", "", "Comma-separated treeID=weight pairs giving the number of passes a log gets in each round of --sequencer_workers, for logs which don't get the default of 1")
 	sequencerGuardWindowFlag = flag.Duration("sequencer_guard_window", 0, "If set, the time elapsed before submitted leaves are eligible for sequencing")
 	forceMaster              = flag.Bool("force_master", false, "If true, assume master for all logs")
 	etcdHTTPService          = flag.String("etcd_http_service", "trillian-logsigner-http", "Service name to announce our HTTP endpoint under")
-	lockDir                  = flag.String("lock_file_path", "/test/multimaster", "etcd lock file directory path")
 	healthzTimeout           = flag.Duration("healthz_timeout", time.Second*5, "Timeout used during healthz checks")
+	poisonLeafThreshold      = flag.Int("poison_leaf_threshold", 0, "If set, the number of consecutive failed sequencing passes of a log after which the leaf causing them is quarantined. Zero disables automatic quarantine")
+	queueStatsInterval       = flag.Duration("queue_stats_interval", time.Minute, "Minimum time between exports of the queue age metrics of a log. Zero disables them")
+	retentionInterval        = flag.Duration("retention_interval", time.Hour, "Minimum time between the passes which prune the data of the leaves of a log older than its retention policy allows. Zero disables pruning")
+	retentionBatchSize       = flag.Int("retention_batch_size", 1000, "Maximum number of leaves pruned by a pass. A pass which prunes a full batch is followed by another one with the next sequencing pass")
+	maxGCPause               = flag.Duration("max_gc_pause", 0, "If set, the longest GC pause a sequencing pass may cause before the batch size is reduced. Zero disables the check")
+	maxHeapGrowth            = flag.Uint64("max_heap_growth_bytes", 0, "If set, the largest heap growth a sequencing pass may cause before the batch size is reduced. Zero disables the check")
+	shadowSampleRate         = flag.Float64("shadow_sample_rate", 0, "Fraction of sequencing passes which also run replaced implementations in shadow and record where they diverge, e.g. check cached tree nodes against storage")
+	batchTuning              = flag.Bool("batch_tuning", false, "If true, the batch size and the time between the sequencing passes of each log are tuned to the latency of its passes and its backlog, starting from --batch_size and --sequencer_interval")
+	minBatchSize             = flag.Int("min_batch_size", 10, "Smallest batch size of a log, with --batch_tuning")
+	maxBatchSize             = flag.Int("max_batch_size", 10000, "Largest batch size of a log, with --batch_tuning")
+	maxSequencerInterval     = flag.Duration("max_sequencer_interval", time.Second, "Longest time between the sequencing passes of an idle log, with --batch_tuning. The shortest is --sequencer_interval")
+	targetPassLatency        = flag.Duration("target_pass_latency", time.Second, "Duration of a sequencing pass above which the batch size of the log shrinks, with --batch_tuning. It only grows while passes take less than half of it")
+	targetCommitLatency      = flag.Duration("target_commit_latency", 0, "If positive, duration of the storage commit of a sequencing pass above which the batch size of the log shrinks, with --batch_tuning. It only grows while commits take less than half of it")
+	batchTuningHysteresis    = flag.Int("batch_tuning_hysteresis", 3, "Number of consecutive sequencing passes of a log which must call for a change of its batch size or interval before it's made, with --batch_tuning")
+	dedupFilterCapacity      = flag.Uint64("dedup_filter_capacity", 0, "If set, the number of leaves which the dedup filter of each log is sized for. The filters hold the identity hashes of the integrated leaves, and are persisted for the storage to skip duplicate checks of the leaves they don't hold. Zero disables them")
+	dedupFilterFPRate        = flag.Float64("dedup_filter_fp_rate", 0.01, "False positive rate of the dedup filters of --dedup_filter_capacity leaves")
+	dedupFilterPersist       = flag.Duration("dedup_filter_persist_interval", time.Minute, "Minimum time between stores of the dedup filter of a log, with --dedup_filter_capacity")
+	dedupFilterBackfill      = flag.Int("dedup_filter_backfill_batch", 10000, "Maximum number of leaves integrated before the dedup filter of a log was last stored which a sequencing pass adds to it, with --dedup_filter_capacity")
+	batchReportsPerLog       = flag.Int("batch_reports_per_log", 100, "Number of reports of recent sequencing passes kept for each log, and served by the Sequencer RPC service. Zero disables them")
+	batchReportLog           = flag.String("batch_report_log", "", "If set, the file which each sequencing pass report is appended to, as a line of JSON")
+	singleShotTreeID         = flag.Int64("single_shot_tree_id", 0, "If set, run exactly one sequencing pass for this tree, print what was integrated, and exit")
+	dryRun                   = flag.Bool("dry_run", false, "If true, the --single_shot_tree_id pass is rolled back instead of committed, and only shows what would have been integrated")
 
 	quotaSystem         = flag.String("quota_system", "mysql", fmt.Sprintf("Quota system to use. One of: %v", quota.Providers()))
 	quotaIncreaseFactor = flag.Float64("quota_increase_factor", log.QuotaIncreaseFactor,
@@ -77,6 +111,11 @@ var (
 	masterHoldInterval = flag.Duration("master_hold_interval", 60*time.Second, "Minimum interval to hold mastership for")
 	masterHoldJitter   = flag.Duration("master_hold_jitter", 120*time.Second, "Maximal random addition to --master_hold_interval")
 
+	tracing             = flag.Bool("tracing", false, "If true, OpenTelemetry traces of RPCs, sequencing batches and storage transactions are exported over OTLP/gRPC to --tracing_otlp_endpoint")
+	tracingOTLPEndpoint = flag.String("tracing_otlp_endpoint", "localhost:4317", "Endpoint (host:port) of the OTLP/gRPC receiver, e.g. an OpenTelemetry collector, which traces are exported to")
+	tracingOTLPInsecure = flag.Bool("tracing_otlp_insecure", false, "If true, traces are exported to --tracing_otlp_endpoint without TLS")
+	tracingPercent      = flag.Int("tracing_percent", 0, "Percent of sequencing batches and requests to be traced. Zero is a special case to use the default sampler, which traces 0.01% of them. Requests whose caller traces them are always traced")
+
 	configFile = flag.String("config", "", "Config file containing flags, file contents can be overridden by command line flags")
 
 	// Profiling related flags.
@@ -98,7 +137,7 @@ func main() {
 	glog.Info("**** Log Signer Starting ****")
 
 	mf := prometheus.MetricFactory{}
-	monitoring.SetStartSpan(opencensus.StartSpan)
+	monitoring.SetStartSpan(opentelemetry.StartSpan)
 
 	sp, err := storage.NewProvider(*storageSystem, mf)
 	if err != nil {
@@ -121,6 +160,26 @@ func main() {
 	defer cancel()
 	go util.AwaitSignal(ctx, cancel)
 
+	var tracingUnary grpc.UnaryServerInterceptor
+	var tracingStream grpc.StreamServerInterceptor
+	if *tracing {
+		shutdown, err := opentelemetry.EnableTracing(ctx, opentelemetry.Options{
+			ServiceName: "trillian_log_signer",
+			Endpoint:    *tracingOTLPEndpoint,
+			Insecure:    *tracingOTLPInsecure,
+			Percent:     *tracingPercent,
+		})
+		if err != nil {
+			glog.Exitf("Failed to initialize OpenTelemetry tracing: %v", err)
+		}
+		defer func() {
+			if err := shutdown(context.Background()); err != nil {
+				glog.Warningf("Failed to export remaining traces: %v", err)
+			}
+		}()
+		tracingUnary, tracingStream = opentelemetry.UnaryServerInterceptor(), opentelemetry.StreamServerInterceptor()
+	}
+
 	hostname, _ := os.Hostname()
 	instanceID := fmt.Sprintf("%s.%d", hostname, os.Getpid())
 	var electionFactory election2.Factory
@@ -128,10 +187,13 @@ func main() {
 	case *forceMaster:
 		glog.Warning("**** Acting as master for all logs ****")
 		electionFactory = election2.NoopFactory{}
-	case client != nil:
-		electionFactory = etcdelect.NewFactory(instanceID, client, *lockDir)
+	case masterelection.Backend() == "etcd" && client == nil && *singleShotTreeID != 0 && *dryRun:
+		// Dry runs never commit, so they can't conflict with an active master.
+		electionFactory = election2.NoopFactory{}
 	default:
-		glog.Exit("Either --force_master or --etcd_servers must be supplied")
+		if electionFactory, err = masterelection.NewFactory(instanceID, client, mf); err != nil {
+			glog.Exitf("Failed to set up master election (or set --force_master): %v", err)
+		}
 	}
 
 	qm, err := quota.NewManager(*quotaSystem)
@@ -147,6 +209,18 @@ func main() {
 		MetricFactory:   mf,
 	}
 
+	if *singleShotTreeID != 0 {
+		info := log.OperationInfo{
+			Registry:   registry,
+			BatchSize:  *batchSizeFlag,
+			TimeSource: clock.System,
+		}
+		if err := runSingleShot(ctx, *singleShotTreeID, *dryRun, info); err != nil {
+			glog.Exitf("Single-shot sequencing of tree %d failed: %v", *singleShotTreeID, err)
+		}
+		return
+	}
+
 	// Start HTTP server (optional)
 	if *httpEndpoint != "" {
 		// Announce our endpoint to etcd if so configured.
@@ -159,12 +233,61 @@ func main() {
 	// TODO(Martin2112): Should respect read only mode and the flags in tree control etc
 	log.QuotaIncreaseFactor = *quotaIncreaseFactor
 	sequencerManager := log.NewSequencerManager(registry, *sequencerGuardWindowFlag)
+	var reportLog io.Writer
+	if *batchReportLog != "" {
+		f, err := os.OpenFile(*batchReportLog, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+		if err != nil {
+			glog.Exitf("Failed to open batch report log: %v", err)
+		}
+		defer f.Close()
+		reportLog = f
+	}
+	batchReports := log.NewBatchReports(*batchReportsPerLog, reportLog)
+	var tuning *log.BatchTuning
+	if *batchTuning {
+		tuning = &log.BatchTuning{
+			MinBatchSize:        *minBatchSize,
+			MaxBatchSize:        *maxBatchSize,
+			MaxInterval:         *maxSequencerInterval,
+			TargetLatency:       *targetPassLatency,
+			TargetCommitLatency: *targetCommitLatency,
+			Hysteresis:          *batchTuningHysteresis,
+		}
+	}
+	var dedupFilter *log.DedupFilterConfig
+	if *dedupFilterCapacity > 0 {
+		if *dedupFilterFPRate <= 0 || *dedupFilterFPRate >= 1 {
+			glog.Exitf("Invalid --dedup_filter_fp_rate %v, want between 0 and 1", *dedupFilterFPRate)
+		}
+		dedupFilter = &log.DedupFilterConfig{
+			Capacity:          *dedupFilterCapacity,
+			FalsePositiveRate: *dedupFilterFPRate,
+			PersistInterval:   *dedupFilterPersist,
+			BackfillBatchSize: *dedupFilterBackfill,
+		}
+	}
+	weights, err := parseTreeWeights(*sequencerTreeWeights)
+	if err != nil {
+		glog.Exitf("Invalid --sequencer_tree_weights: %v", err)
+	}
 	info := log.OperationInfo{
-		Registry:    registry,
-		BatchSize:   *batchSizeFlag,
-		NumWorkers:  *numSeqFlag,
-		RunInterval: *sequencerIntervalFlag,
-		TimeSource:  clock.System,
+		Registry:            registry,
+		BatchSize:           *batchSizeFlag,
+		NumWorkers:          *numSeqFlag,
+		SequencerWorkers:    *sequencerWorkers,
+		TreeWeights:         weights,
+		RunInterval:         *sequencerIntervalFlag,
+		TimeSource:          clock.System,
+		PoisonLeafThreshold: *poisonLeafThreshold,
+		QueueStatsInterval:  *queueStatsInterval,
+		RetentionInterval:   *retentionInterval,
+		RetentionBatchSize:  *retentionBatchSize,
+		MaxGCPause:          *maxGCPause,
+		MaxHeapGrowth:       *maxHeapGrowth,
+		ShadowSampleRate:    *shadowSampleRate,
+		BatchReports:        batchReports,
+		BatchTuning:         tuning,
+		DedupFilter:         dedupFilter,
 		ElectionConfig: election.RunnerConfig{
 			PreElectionPause:   *preElectionPause,
 			MasterHoldInterval: *masterHoldInterval,
@@ -183,16 +306,23 @@ func main() {
 	}
 
 	m := serverutil.Main{
-		RPCEndpoint:      *rpcEndpoint,
-		HTTPEndpoint:     *httpEndpoint,
-		TLSCertFile:      *tlsCertFile,
-		TLSKeyFile:       *tlsKeyFile,
-		StatsPrefix:      "logsigner",
-		DBClose:          sp.Close,
-		Registry:         registry,
-		RegisterServerFn: func(s *grpc.Server, _ extension.Registry) error { return nil },
-		IsHealthy:        sp.AdminStorage().CheckDatabaseAccessible,
-		HealthyDeadline:  *healthzTimeout,
+		RPCEndpoint:  *rpcEndpoint,
+		HTTPEndpoint: *httpEndpoint,
+		TLSCertFile:  *tlsCertFile,
+		TLSKeyFile:   *tlsKeyFile,
+		StatsPrefix:  "logsigner",
+		DBClose:      sp.Close,
+		Registry:     registry,
+		RegisterServerFn: func(s *grpc.Server, _ extension.Registry) error {
+			sequencerpb.RegisterSequencerServer(s, batchReports)
+			electionpb.RegisterMastersServer(s, log.NewMasters(registry))
+			return nil
+		},
+		IsHealthy:       sp.AdminStorage().CheckDatabaseAccessible,
+		HealthyDeadline: *healthzTimeout,
+
+		TracingUnaryInterceptor:  tracingUnary,
+		TracingStreamInterceptor: tracingStream,
 	}
 
 	if err := m.Run(ctx); err != nil {
@@ -209,6 +339,83 @@ func main() {
 	time.Sleep(time.Second * 5)
 }
 
+// runSingleShot runs one sequencing pass for the given tree, and prints the
+// leaves that were (or, for a dry run, would have been) integrated. Unless
+// this is a dry run, mastership for the tree is acquired before sequencing.
+func runSingleShot(ctx context.Context, treeID int64, dryRun bool, info log.OperationInfo) error {
+	sm := log.NewSequencerManager(info.Registry, *sequencerGuardWindowFlag)
+	if dryRun {
+		res, err := sm.DryRunPass(ctx, treeID, &info)
+		if err != nil {
+			return err
+		}
+		printBatchResult(treeID, res, dryRun)
+		return nil
+	}
+
+	e, err := info.Registry.ElectionFactory.NewElection(ctx, strconv.FormatInt(treeID, 10))
+	if err != nil {
+		return fmt.Errorf("failed to create election: %v", err)
+	}
+	defer e.Close(context.Background())
+	glog.Infof("%d: awaiting mastership", treeID)
+	if err := e.Await(ctx); err != nil {
+		return fmt.Errorf("failed to acquire mastership: %v", err)
+	}
+	mctx, err := e.WithMastership(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get mastership context: %v", err)
+	}
+	res, err := sm.SinglePass(mctx, treeID, &info)
+	if err != nil {
+		return err
+	}
+	printBatchResult(treeID, res, dryRun)
+	return nil
+}
+
+func printBatchResult(treeID int64, res *log.BatchResult, dryRun bool) {
+	verb := "integrated"
+	if dryRun {
+		verb = "would integrate"
+	}
+	if res.OldRoot != nil {
+		fmt.Printf("tree %d: current root: size=%d hash=%x\n", treeID, res.OldRoot.TreeSize, res.OldRoot.RootHash)
+	}
+	fmt.Printf("tree %d: %s %d leaves\n", treeID, verb, len(res.Leaves))
+	for _, leaf := range res.Leaves {
+		fmt.Printf("  index=%d leaf_hash=%x identity_hash=%x queued=%v\n",
+			leaf.LeafIndex, leaf.MerkleLeafHash, leaf.LeafIdentityHash, leaf.GetQueueTimestamp().AsTime().Format(time.RFC3339Nano))
+	}
+	if res.NewRoot != nil {
+		fmt.Printf("tree %d: new root: size=%d hash=%x\n", treeID, res.NewRoot.TreeSize, res.NewRoot.RootHash)
+	}
+}
+
+// parseTreeWeights parses comma-separated treeID=weight pairs.
+func parseTreeWeights(s string) (map[int64]int, error) {
+	weights := make(map[int64]int)
+	if s == "" {
+		return weights, nil
+	}
+	for _, pair := range strings.Split(s, ",") {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("%q is not a treeID=weight pair", pair)
+		}
+		treeID, err := strconv.ParseInt(parts[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tree ID in %q: %v", pair, err)
+		}
+		weight, err := strconv.Atoi(parts[1])
+		if err != nil || weight < 1 {
+			return nil, fmt.Errorf("weight in %q must be a positive integer", pair)
+		}
+		weights[treeID] = weight
+	}
+	return weights, nil
+}
+
 func mustCreate(fileName string) *os.File {
 	f, err := os.Create(fileName)
 	if err != nil {
@@ -0,0 +1,151 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main contains the implementation and entry point for the
+// trillian_migrate command, which copies trees, with all of their leaves,
+// from one storage system to another, and verifies each copy against the
+// root of the source tree it was made from.
+//
+// Example usage:
+// $ ./trillian_migrate --source_storage_system=mysql --mysql_uri=... --target_storage_system=postgresql --postgres_uri=... --tree_ids=123,456
+//
+// All trees which aren't deleted are copied unless --tree_ids is set. Trees
+// keep their IDs and settings, and are frozen in the target storage until
+// their copy is verified. A source log is copied up to the size of its latest
+// root when its copy starts, so logs should be frozen, or their signers
+// stopped, for the copy to be complete. The target storage system must
+// support importing leaves, which the MySQL, PostgreSQL and CockroachDB
+// storage do. As the storage systems are configured with their own flags, the
+// source and target storage systems must differ.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian"
+	"github.com/google/trillian/monitoring"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/util"
+	"github.com/google/trillian/util/clock"
+
+	// Register supported storage providers.
+	_ "github.com/google/trillian/storage/bbolt"
+	_ "github.com/google/trillian/storage/cloudspanner"
+	_ "github.com/google/trillian/storage/crdb"
+	_ "github.com/google/trillian/storage/mysql"
+	_ "github.com/google/trillian/storage/postgres"
+
+	// The database/sql driver of the postgresql and crdb storage providers.
+	_ "github.com/lib/pq"
+)
+
+var (
+	sourceStorageSystem = flag.String("source_storage_system", "mysql", fmt.Sprintf("Storage system to copy trees from. One of: %v", storage.Providers()))
+	targetStorageSystem = flag.String("target_storage_system", "", fmt.Sprintf("Storage system to copy trees to. One of: %v", storage.Providers()))
+	treeIDs             = flag.String("tree_ids", "", "Comma-separated IDs of the trees to copy; all trees which aren't deleted if empty")
+	batchSize           = flag.Int64("batch_size", 1000, "Maximum number of leaves copied per transaction")
+)
+
+func main() {
+	flag.Parse()
+	defer glog.Flush()
+
+	if *targetStorageSystem == "" || *targetStorageSystem == *sourceStorageSystem {
+		glog.Exit("--target_storage_system must be set, and differ from --source_storage_system")
+	}
+	if *batchSize <= 0 {
+		glog.Exit("--batch_size must be positive")
+	}
+	ids, err := parseTreeIDs(*treeIDs)
+	if err != nil {
+		glog.Exitf("Invalid --tree_ids: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go util.AwaitSignal(ctx, cancel)
+
+	src, err := storage.NewProvider(*sourceStorageSystem, monitoring.InertMetricFactory{})
+	if err != nil {
+		glog.Exitf("Failed to get source storage provider: %v", err)
+	}
+	defer src.Close()
+	dst, err := storage.NewProvider(*targetStorageSystem, monitoring.InertMetricFactory{})
+	if err != nil {
+		glog.Exitf("Failed to get target storage provider: %v", err)
+	}
+	defer dst.Close()
+
+	trees, err := selectTrees(ctx, src.AdminStorage(), ids)
+	if err != nil {
+		glog.Exitf("Failed to read trees: %v", err)
+	}
+	failed := 0
+	for _, tree := range trees {
+		root, err := migrateTree(ctx, src, dst, tree, options{batchSize: *batchSize, timeSource: clock.System})
+		switch {
+		case err != nil:
+			glog.Errorf("%v: migration failed: %v", tree.TreeId, err)
+			failed++
+		case root == nil:
+			glog.Infof("%v: copied uninitialised tree", tree.TreeId)
+		default:
+			glog.Infof("%v: copied %d leaves, root hash %x", tree.TreeId, root.TreeSize, root.RootHash)
+		}
+	}
+	if failed > 0 {
+		glog.Errorf("Failed to migrate %d of %d trees", failed, len(trees))
+		glog.Flush()
+		os.Exit(1)
+	}
+}
+
+// parseTreeIDs parses a comma-separated list of tree IDs.
+func parseTreeIDs(s string) ([]int64, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var ids []int64
+	for _, f := range strings.Split(s, ",") {
+		id, err := strconv.ParseInt(strings.TrimSpace(f), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// selectTrees returns the trees with the given IDs, or all trees which aren't
+// deleted if there are none.
+func selectTrees(ctx context.Context, admin storage.AdminStorage, ids []int64) ([]*trillian.Tree, error) {
+	if len(ids) == 0 {
+		return storage.ListTrees(ctx, admin, false /* includeDeleted */)
+	}
+	trees := make([]*trillian.Tree, 0, len(ids))
+	for _, id := range ids {
+		tree, err := storage.GetTree(ctx, admin, id)
+		if err != nil {
+			return nil, fmt.Errorf("tree %d: %v", id, err)
+		}
+		trees = append(trees, tree)
+	}
+	return trees, nil
+}
@@ -0,0 +1,155 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/log"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/types"
+	"github.com/google/trillian/util/clock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// options configures a migration.
+type options struct {
+	// batchSize is the maximum number of leaves copied per transaction.
+	batchSize int64
+	// timeSource sets the timestamps of the roots stored in the target.
+	timeSource clock.TimeSource
+}
+
+// migrateTree copies tree, with its leaves up to the size of its latest root,
+// from src to dst, and verifies that the root stored in dst has the size and
+// root hash of the source root. The tree is frozen in dst until then, and
+// its state is restored afterwards. Returns the verified root, or nil if the
+// source log isn't initialised, in which case only the tree is copied.
+func migrateTree(ctx context.Context, src, dst storage.Provider, tree *trillian.Tree, opts options) (*types.LogRootV1, error) {
+	if tree.TreeType != trillian.TreeType_LOG && tree.TreeType != trillian.TreeType_PREORDERED_LOG {
+		return nil, status.Errorf(codes.InvalidArgument, "tree %d is a %v tree, want a log", tree.TreeId, tree.TreeType)
+	}
+	want, err := latestRoot(ctx, src.LogStorage(), tree)
+	if err != nil {
+		return nil, err
+	}
+
+	created := proto.Clone(tree).(*trillian.Tree)
+	created.TreeState = trillian.TreeState_ACTIVE
+	created.CreateTime = nil
+	created.UpdateTime = nil
+	created.IntegrationPause = nil
+	if _, err := storage.CreateTree(ctx, dst.AdminStorage(), created); err != nil {
+		return nil, err
+	}
+	if want != nil {
+		if created, err = storage.UpdateTree(ctx, dst.AdminStorage(), tree.TreeId, func(tree *trillian.Tree) {
+			tree.TreeState = trillian.TreeState_FROZEN
+		}); err != nil {
+			return nil, err
+		}
+		if err := copyLeaves(ctx, src.LogStorage(), dst.LogStorage(), created, want.TreeSize, opts); err != nil {
+			return nil, err
+		}
+		got, err := latestRoot(ctx, dst.LogStorage(), created)
+		if err != nil {
+			return nil, err
+		}
+		if got == nil || got.TreeSize != want.TreeSize || !bytes.Equal(got.RootHash, want.RootHash) {
+			return nil, status.Errorf(codes.DataLoss, "copy of log %d has root %+v, want size %d and root hash %x", tree.TreeId, got, want.TreeSize, want.RootHash)
+		}
+	}
+
+	if tree.TreeState != created.TreeState {
+		if _, err := storage.UpdateTree(ctx, dst.AdminStorage(), tree.TreeId, func(t *trillian.Tree) {
+			t.TreeState = tree.TreeState
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return want, nil
+}
+
+// copyLeaves copies the first size leaves of tree from src to dst, in
+// batches, each read in a snapshot of src and imported in a transaction of
+// dst. The log in dst is initialised even if there are no leaves.
+func copyLeaves(ctx context.Context, src, dst storage.LogStorage, tree *trillian.Tree, size uint64, opts options) error {
+	if size == 0 {
+		_, err := log.ImportLeaves(ctx, tree, nil, opts.timeSource, dst)
+		return err
+	}
+	for start := int64(0); start < int64(size); start += opts.batchSize {
+		count := opts.batchSize
+		if start+count > int64(size) {
+			count = int64(size) - start
+		}
+		var leaves []*trillian.LogLeaf
+		if err := snapshot(ctx, src, tree, func(tx storage.ReadOnlyLogTreeTX) error {
+			var err error
+			leaves, err = tx.GetLeavesByRange(ctx, start, count)
+			return err
+		}); err != nil {
+			return err
+		}
+		if got := int64(len(leaves)); got != count {
+			return status.Errorf(codes.DataLoss, "read %d leaves at index %d, want %d", got, start, count)
+		}
+		if _, err := log.ImportLeaves(ctx, tree, leaves, opts.timeSource, dst); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// latestRoot returns the latest root of tree, or nil if the log isn't
+// initialised.
+func latestRoot(ctx context.Context, ls storage.LogStorage, tree *trillian.Tree) (*types.LogRootV1, error) {
+	var slr *trillian.SignedLogRoot
+	err := snapshot(ctx, ls, tree, func(tx storage.ReadOnlyLogTreeTX) error {
+		var err error
+		slr, err = tx.LatestSignedLogRoot(ctx)
+		return err
+	})
+	if err == storage.ErrTreeNeedsInit || (err == nil && slr.GetLogRoot() == nil) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var root types.LogRootV1
+	if err := root.UnmarshalBinary(slr.LogRoot); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to unmarshal root of log %d: %v", tree.TreeId, err)
+	}
+	return &root, nil
+}
+
+// snapshot runs f in a read-only transaction of the tree.
+func snapshot(ctx context.Context, ls storage.LogStorage, tree *trillian.Tree, f func(tx storage.ReadOnlyLogTreeTX) error) error {
+	tx, err := ls.SnapshotForTree(ctx, tree)
+	if err != nil {
+		if tx != nil {
+			tx.Close()
+		}
+		return err
+	}
+	defer tx.Close()
+	if err := f(tx); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
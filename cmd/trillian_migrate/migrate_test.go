@@ -0,0 +1,269 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/log"
+	"github.com/google/trillian/monitoring"
+	"github.com/google/trillian/quota"
+	"github.com/google/trillian/storage"
+	stestonly "github.com/google/trillian/storage/testonly"
+	"github.com/google/trillian/util/clock"
+	"github.com/transparency-dev/merkle/rfc6962"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	// Register the memory storage provider.
+	_ "github.com/google/trillian/storage/memory"
+)
+
+// newProvider returns empty memory storage.
+func newProvider(t *testing.T) storage.Provider {
+	t.Helper()
+	p, err := storage.NewProvider("memory", monitoring.InertMetricFactory{})
+	if err != nil {
+		t.Fatalf("NewProvider(): %v", err)
+	}
+	return p
+}
+
+// newLog creates a log in p with the given number of integrated leaves, or an
+// uninitialised one if size is negative.
+func newLog(ctx context.Context, t *testing.T, p storage.Provider, size int, fakeTime clock.TimeSource) *trillian.Tree {
+	t.Helper()
+	tree, err := storage.CreateTree(ctx, p.AdminStorage(), proto.Clone(stestonly.LogTree).(*trillian.Tree))
+	if err != nil {
+		t.Fatalf("CreateTree(): %v", err)
+	}
+	if size < 0 {
+		return tree
+	}
+	// The first root predates the one stored when the leaves are integrated.
+	if _, err := log.ImportLeaves(ctx, tree, nil, clock.NewFake(time.Unix(1, 0)), p.LogStorage()); err != nil {
+		t.Fatalf("ImportLeaves(): %v", err)
+	}
+	if size == 0 {
+		return tree
+	}
+	leaves := make([]*trillian.LogLeaf, 0, size)
+	for i := 0; i < size; i++ {
+		data := []byte(fmt.Sprintf("leaf %d", i))
+		hash := sha256.Sum256(data)
+		leaves = append(leaves, &trillian.LogLeaf{
+			LeafValue:        data,
+			LeafIdentityHash: hash[:],
+			MerkleLeafHash:   rfc6962.DefaultHasher.HashLeaf(data),
+		})
+	}
+	if _, err := p.LogStorage().QueueLeaves(ctx, tree, leaves, fakeTime.Now()); err != nil {
+		t.Fatalf("QueueLeaves(): %v", err)
+	}
+	if _, err := log.IntegrateBatch(ctx, tree, size, 0, 0, fakeTime, p.LogStorage(), quota.Noop()); err != nil {
+		t.Fatalf("IntegrateBatch(): %v", err)
+	}
+	return tree
+}
+
+// readLeaves returns all leaves of tree up to the given size.
+func readLeaves(ctx context.Context, t *testing.T, p storage.Provider, tree *trillian.Tree, size uint64) []*trillian.LogLeaf {
+	t.Helper()
+	var leaves []*trillian.LogLeaf
+	if err := snapshot(ctx, p.LogStorage(), tree, func(tx storage.ReadOnlyLogTreeTX) error {
+		var err error
+		leaves, err = tx.GetLeavesByRange(ctx, 0, int64(size))
+		return err
+	}); err != nil {
+		t.Fatalf("GetLeavesByRange(): %v", err)
+	}
+	return leaves
+}
+
+// noImportProvider is a provider whose log storage doesn't support importing
+// leaves.
+type noImportProvider struct {
+	storage.Provider
+}
+
+func (p noImportProvider) LogStorage() storage.LogStorage {
+	return noImportStorage{p.Provider.LogStorage()}
+}
+
+type noImportStorage struct {
+	storage.LogStorage
+}
+
+func (s noImportStorage) ReadWriteTransaction(ctx context.Context, tree *trillian.Tree, f storage.LogTXFunc) error {
+	return s.LogStorage.ReadWriteTransaction(ctx, tree, func(ctx context.Context, tx storage.LogTreeTX) error {
+		return f(ctx, struct{ storage.LogTreeTX }{tx})
+	})
+}
+
+func TestMigrateTree(t *testing.T) {
+	ctx := context.Background()
+	log.InitMetrics(nil)
+	fakeTime := clock.NewFake(time.Unix(1500000000, 0))
+	opts := options{batchSize: 5, timeSource: fakeTime}
+
+	for _, tc := range []struct {
+		desc      string
+		size      int
+		state     trillian.TreeState
+		wantNoLog bool
+	}{
+		{desc: "ok", size: 23, state: trillian.TreeState_ACTIVE},
+		{desc: "singleBatch", size: 5, state: trillian.TreeState_ACTIVE},
+		{desc: "frozen", size: 12, state: trillian.TreeState_FROZEN},
+		{desc: "empty", size: 0, state: trillian.TreeState_ACTIVE},
+		{desc: "uninitialised", size: -1, state: trillian.TreeState_ACTIVE, wantNoLog: true},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			src, dst := newProvider(t), newProvider(t)
+			tree := newLog(ctx, t, src, tc.size, fakeTime)
+			if tc.state != tree.TreeState {
+				var err error
+				if tree, err = storage.UpdateTree(ctx, src.AdminStorage(), tree.TreeId, func(tree *trillian.Tree) {
+					tree.TreeState = tc.state
+				}); err != nil {
+					t.Fatalf("UpdateTree(): %v", err)
+				}
+			}
+			want, err := latestRoot(ctx, src.LogStorage(), tree)
+			if err != nil {
+				t.Fatalf("latestRoot(): %v", err)
+			}
+
+			root, err := migrateTree(ctx, src, dst, tree, opts)
+			if err != nil {
+				t.Fatalf("migrateTree(): %v", err)
+			}
+			copied, err := storage.GetTree(ctx, dst.AdminStorage(), tree.TreeId)
+			if err != nil {
+				t.Fatalf("GetTree(): %v", err)
+			}
+			if got, want := copied.TreeState, tc.state; got != want {
+				t.Errorf("copied tree in state %v, want %v", got, want)
+			}
+			if copied.DisplayName != tree.DisplayName || copied.MaxRootDuration.AsDuration() != tree.MaxRootDuration.AsDuration() {
+				t.Errorf("copied tree %v, want settings of %v", copied, tree)
+			}
+			got, err := latestRoot(ctx, dst.LogStorage(), copied)
+			if err != nil {
+				t.Fatalf("latestRoot(): %v", err)
+			}
+			if tc.wantNoLog {
+				if root != nil || got != nil {
+					t.Errorf("migrateTree(): root %+v, stored %+v, want no root", root, got)
+				}
+				return
+			}
+			if got.TreeSize != want.TreeSize || string(got.RootHash) != string(want.RootHash) || root.TreeSize != want.TreeSize {
+				t.Errorf("migrateTree(): root %+v, stored %+v, want %+v", root, got, want)
+			}
+			gotLeaves, wantLeaves := readLeaves(ctx, t, dst, copied, got.TreeSize), readLeaves(ctx, t, src, tree, want.TreeSize)
+			if len(gotLeaves) != len(wantLeaves) {
+				t.Fatalf("copied %d leaves, want %d", len(gotLeaves), len(wantLeaves))
+			}
+			for i := range gotLeaves {
+				if !proto.Equal(gotLeaves[i], wantLeaves[i]) {
+					t.Errorf("copied leaf %d = %v, want %v", i, gotLeaves[i], wantLeaves[i])
+				}
+			}
+
+			if _, err := migrateTree(ctx, src, dst, tree, opts); status.Code(err) != codes.AlreadyExists {
+				t.Errorf("migrateTree() of copied tree: %v, want code %v", err, codes.AlreadyExists)
+			}
+		})
+	}
+}
+
+func TestMigrateTreeErrors(t *testing.T) {
+	ctx := context.Background()
+	log.InitMetrics(nil)
+	fakeTime := clock.NewFake(time.Unix(1500000000, 0))
+	opts := options{batchSize: 4, timeSource: fakeTime}
+
+	t.Run("importUnsupported", func(t *testing.T) {
+		src := newProvider(t)
+		tree := newLog(ctx, t, src, 3, fakeTime)
+		_, err := migrateTree(ctx, src, noImportProvider{newProvider(t)}, tree, opts)
+		if got, want := status.Code(err), codes.Unimplemented; got != want {
+			t.Errorf("migrateTree(): %v, want code %v", err, want)
+		}
+	})
+
+	t.Run("rootHash", func(t *testing.T) {
+		src := newProvider(t)
+		tree := newLog(ctx, t, src, 7, fakeTime)
+		root, err := latestRoot(ctx, src.LogStorage(), tree)
+		if err != nil {
+			t.Fatalf("latestRoot(): %v", err)
+		}
+		root.RootHash = make([]byte, len(root.RootHash))
+		root.TimestampNanos++
+		logRoot, err := root.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary(): %v", err)
+		}
+		if err := src.LogStorage().ReadWriteTransaction(ctx, tree, func(ctx context.Context, tx storage.LogTreeTX) error {
+			return tx.StoreSignedLogRoot(ctx, &trillian.SignedLogRoot{LogRoot: logRoot})
+		}); err != nil {
+			t.Fatalf("StoreSignedLogRoot(): %v", err)
+		}
+		_, err = migrateTree(ctx, src, newProvider(t), tree, opts)
+		if got, want := status.Code(err), codes.DataLoss; got != want {
+			t.Errorf("migrateTree(): %v, want code %v", err, want)
+		}
+	})
+
+	t.Run("notLog", func(t *testing.T) {
+		tree := proto.Clone(stestonly.LogTree).(*trillian.Tree)
+		tree.TreeType = trillian.TreeType_UNKNOWN_TREE_TYPE
+		_, err := migrateTree(ctx, newProvider(t), newProvider(t), tree, opts)
+		if got, want := status.Code(err), codes.InvalidArgument; got != want {
+			t.Errorf("migrateTree(): %v, want code %v", err, want)
+		}
+	})
+}
+
+func TestParseTreeIDs(t *testing.T) {
+	for _, tc := range []struct {
+		in      string
+		want    []int64
+		wantErr bool
+	}{
+		{in: "", want: nil},
+		{in: "123", want: []int64{123}},
+		{in: "123, 456,789", want: []int64{123, 456, 789}},
+		{in: "123,abc", wantErr: true},
+		{in: "123,", wantErr: true},
+	} {
+		got, err := parseTreeIDs(tc.in)
+		if gotErr := err != nil; gotErr != tc.wantErr {
+			t.Errorf("parseTreeIDs(%q): %v, want error %v", tc.in, err, tc.wantErr)
+			continue
+		}
+		if fmt.Sprint(got) != fmt.Sprint(tc.want) {
+			t.Errorf("parseTreeIDs(%q) = %v, want %v", tc.in, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main contains the implementation and entry point for the
+// verify_archive command, which checks an archive bundle of a log offline.
+//
+// Example usage:
+// $ ./verify_archive --archive_dir=/archive/log-123
+//
+// See the archive package for the checks performed.
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian/archive"
+)
+
+var archiveDir = flag.String("archive_dir", "", "Directory of the bundle to verify")
+
+func main() {
+	flag.Parse()
+	defer glog.Flush()
+
+	if *archiveDir == "" {
+		glog.Exit("--archive_dir must be set")
+	}
+	m, err := archive.Verify(*archiveDir)
+	if err != nil {
+		glog.Exitf("Verification failed: %v", err)
+	}
+	fmt.Printf("OK: %d leaves of log %d in %d chunks, root hash %s\n", m.TreeSize, m.LogID, len(m.Chunks), m.RootHash)
+}
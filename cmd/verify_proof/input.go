@@ -0,0 +1,126 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/types"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// root is the part of a log root that proofs are verified against.
+type root struct {
+	size uint64
+	hash []byte
+}
+
+// parseRoot parses a log root in the given format, which is one of "proto"
+// (a binary SignedLogRoot), "json" (a SignedLogRoot in JSON), "checkpoint"
+// (a checkpoint note body) or "auto" to guess the format from the data.
+func parseRoot(data []byte, format string) (*root, error) {
+	if format == "auto" {
+		format = guessRootFormat(data)
+	}
+	switch format {
+	case "proto", "json":
+		var slr trillian.SignedLogRoot
+		var err error
+		if format == "proto" {
+			err = proto.Unmarshal(data, &slr)
+		} else {
+			err = protojson.Unmarshal(data, &slr)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse SignedLogRoot as %s: %v", format, err)
+		}
+		var lr types.LogRootV1
+		if err := lr.UnmarshalBinary(slr.LogRoot); err != nil {
+			return nil, fmt.Errorf("failed to parse log_root of SignedLogRoot: %v", err)
+		}
+		return &root{size: lr.TreeSize, hash: lr.RootHash}, nil
+	case "checkpoint":
+		return parseCheckpoint(data)
+	default:
+		return nil, fmt.Errorf("unknown root format %q", format)
+	}
+}
+
+func guessRootFormat(data []byte) string {
+	trimmed := bytes.TrimSpace(data)
+	if bytes.HasPrefix(trimmed, []byte("{")) {
+		return "json"
+	}
+	if lines := strings.Split(string(trimmed), "\n"); len(lines) >= 3 {
+		if _, err := strconv.ParseUint(lines[1], 10, 64); err == nil {
+			return "checkpoint"
+		}
+	}
+	return "proto"
+}
+
+// parseCheckpoint parses the body of a checkpoint, i.e. the origin, the tree
+// size in decimal and the base64 encoded root hash, one per line. Any further
+// lines, including the signatures of a signed note, are ignored; signatures
+// are not verified.
+func parseCheckpoint(data []byte) (*root, error) {
+	lines := strings.SplitN(string(data), "\n", 4)
+	if len(lines) < 3 {
+		return nil, fmt.Errorf("checkpoint has %d lines, want at least 3", len(lines))
+	}
+	size, err := strconv.ParseUint(lines[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid checkpoint tree size %q: %v", lines[1], err)
+	}
+	hash, err := base64.StdEncoding.DecodeString(lines[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid checkpoint root hash %q: %v", lines[2], err)
+	}
+	return &root{size: size, hash: hash}, nil
+}
+
+// parseProof parses a proof, which is either a trillian.Proof in JSON, or a
+// list of hex encoded hashes, one per line. The returned leaf index is -1 if
+// the proof doesn't carry one.
+func parseProof(data []byte) (int64, [][]byte, error) {
+	trimmed := bytes.TrimSpace(data)
+	if bytes.HasPrefix(trimmed, []byte("{")) {
+		var p trillian.Proof
+		if err := protojson.Unmarshal(trimmed, &p); err != nil {
+			return 0, nil, fmt.Errorf("failed to parse Proof as json: %v", err)
+		}
+		return p.LeafIndex, p.Hashes, nil
+	}
+	var hashes [][]byte
+	for i, line := range strings.Split(string(trimmed), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		h, err := hex.DecodeString(line)
+		if err != nil {
+			return 0, nil, fmt.Errorf("line %d of proof: invalid hex hash: %v", i+1, err)
+		}
+		hashes = append(hashes, h)
+	}
+	return -1, hashes, nil
+}
@@ -0,0 +1,126 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main contains the implementation and entry point for the
+// verify_proof command, which verifies inclusion and consistency proofs of
+// RFC 6962 logs offline, and explains where verification goes wrong.
+//
+// Example usage:
+// $ ./verify_proof --root=root.json --leaf_file=leaf.der --leaf_index=3 --proof=proof.json
+// $ ./verify_proof --old_root=checkpoint.old --root=checkpoint.new --proof=consistency.txt
+//
+// Roots can be binary or JSON encoded SignedLogRoot protos, or checkpoints.
+// Proofs can be JSON encoded Proof protos, or one hex encoded hash per line.
+// Signatures of roots are not verified.
+package main
+
+import (
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/golang/glog"
+)
+
+var (
+	rootFile   = flag.String("root", "", "File containing the log root to verify against")
+	oldRoot    = flag.String("old_root", "", "File containing an older log root; if set, a consistency proof between the two roots is verified")
+	rootFormat = flag.String("root_format", "auto", "Format of the root files: auto, proto, json or checkpoint")
+	leafFile   = flag.String("leaf_file", "", "File containing the leaf data, which is hashed as an RFC 6962 leaf")
+	leafHash   = flag.String("leaf_hash", "", "Hex encoded Merkle leaf hash, as an alternative to --leaf_file")
+	leafIndex  = flag.Int64("leaf_index", -1, "Index of the leaf; overrides the index carried by the proof")
+	proofFile  = flag.String("proof", "", "File containing the proof")
+)
+
+func main() {
+	flag.Parse()
+	defer glog.Flush()
+
+	if *rootFile == "" || *proofFile == "" {
+		glog.Exit("--root and --proof must be set")
+	}
+	if err := run(os.Stdout); err != nil {
+		glog.Exitf("Verification failed: %v", err)
+	}
+}
+
+func run(w io.Writer) error {
+	r, err := readRoot(*rootFile)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(*proofFile)
+	if err != nil {
+		return err
+	}
+	index, hashes, err := parseProof(data)
+	if err != nil {
+		return err
+	}
+
+	if *oldRoot != "" {
+		old, err := readRoot(*oldRoot)
+		if err != nil {
+			return err
+		}
+		return verifyConsistency(w, old.size, r.size, hashes, old.hash, r.hash)
+	}
+
+	leaf, err := readLeafHash()
+	if err != nil {
+		return err
+	}
+	if *leafIndex >= 0 {
+		index = *leafIndex
+	}
+	if index < 0 {
+		return fmt.Errorf("the proof doesn't carry a leaf index, set --leaf_index")
+	}
+	return verifyInclusion(w, uint64(index), r.size, leaf, hashes, r.hash)
+}
+
+func readRoot(path string) (*root, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	r, err := parseRoot(data, *rootFormat)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return r, nil
+}
+
+func readLeafHash() ([]byte, error) {
+	switch {
+	case *leafFile != "" && *leafHash != "":
+		return nil, fmt.Errorf("only one of --leaf_file and --leaf_hash can be set")
+	case *leafFile != "":
+		data, err := os.ReadFile(*leafFile)
+		if err != nil {
+			return nil, err
+		}
+		return hasher.HashLeaf(data), nil
+	case *leafHash != "":
+		h, err := hex.DecodeString(*leafHash)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --leaf_hash: %v", err)
+		}
+		return h, nil
+	default:
+		return nil, fmt.Errorf("one of --leaf_file and --leaf_hash must be set for inclusion proofs")
+	}
+}
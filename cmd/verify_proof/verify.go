@@ -0,0 +1,182 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/transparency-dev/merkle/proof"
+	"github.com/transparency-dev/merkle/rfc6962"
+)
+
+var hasher = rfc6962.DefaultHasher
+
+// nodeLabels describes the tree nodes that the hashes of a proof are
+// expected to represent, in proof order.
+func nodeLabels(nodes proof.Nodes, size uint64) []string {
+	ephem, begin, end := nodes.Ephem()
+	var labels []string
+	for i := 0; i < len(nodes.IDs); i++ {
+		id := nodes.IDs[i]
+		if i == begin && end-begin > 1 {
+			first, _ := ephem.Coverage()
+			labels = append(labels, fmt.Sprintf("ephemeral node covering leaves [%d, %d)", first, size))
+			i = end - 1
+			continue
+		}
+		first, last := id.Coverage()
+		labels = append(labels, fmt.Sprintf("node (level %d, index %d) covering leaves [%d, %d)", id.Level, id.Index, first, last))
+	}
+	return labels
+}
+
+func label(labels []string, i int) string {
+	if i < len(labels) {
+		return labels[i]
+	}
+	return "unexpected extra hash"
+}
+
+// verifyInclusion checks an inclusion proof, and writes a trace of the
+// verification to w which shows what every proof hash is expected to be, and
+// how the root is recomputed from them.
+func verifyInclusion(w io.Writer, index, size uint64, leafHash []byte, hashes [][]byte, root []byte) error {
+	fmt.Fprintf(w, "Inclusion of leaf %d in tree of size %d\n", index, size)
+	fmt.Fprintf(w, "  leaf hash: %x\n", leafHash)
+	fmt.Fprintf(w, "  root hash: %x\n", root)
+	if index >= size {
+		return fmt.Errorf("leaf index %d is beyond the tree size %d, the leaf isn't covered by this root", index, size)
+	}
+	if got, want := len(leafHash), hasher.Size(); got != want {
+		return fmt.Errorf("leaf hash has %d bytes, want %d", got, want)
+	}
+	nodes, err := proof.Inclusion(index, size)
+	if err != nil {
+		return err
+	}
+	labels := nodeLabels(nodes, size)
+	if got, want := len(hashes), len(labels); got != want {
+		fmt.Fprintf(w, "Proof has %d hashes, but an inclusion proof for leaf %d at size %d needs %d:\n", got, index, size, want)
+		for i, l := range labels {
+			fmt.Fprintf(w, "  proof[%d]: %s\n", i, l)
+		}
+		return fmt.Errorf("wrong proof size %d, want %d: the proof was probably built for a different leaf index or tree size", got, want)
+	}
+
+	// Recompute the root following RFC 9162 section 2.1.3.2.
+	fmt.Fprintln(w, "Recomputing root:")
+	fn, sn, r := index, size-1, leafHash
+	for i, p := range hashes {
+		if fn&1 == 1 || fn == sn {
+			r = hasher.HashChildren(p, r)
+			fmt.Fprintf(w, "  proof[%d] %x is the left sibling, %s -> %x\n", i, p, labels[i], r)
+			for fn&1 == 0 && fn != 0 {
+				fn, sn = fn>>1, sn>>1
+			}
+		} else {
+			r = hasher.HashChildren(r, p)
+			fmt.Fprintf(w, "  proof[%d] %x is the right sibling, %s -> %x\n", i, p, labels[i], r)
+		}
+		fn, sn = fn>>1, sn>>1
+	}
+
+	if err := proof.VerifyInclusion(hasher, index, size, leafHash, hashes, root); err != nil {
+		fmt.Fprintf(w, "Recomputed root %x doesn't match the expected root %x.\n", r, root)
+		fmt.Fprintln(w, "Either the leaf hash, one of the proof hashes, or the root is wrong. The leaf hash")
+		fmt.Fprintln(w, "must be the RFC 6962 Merkle leaf hash, i.e. SHA-256(0x00 || leaf data).")
+		return fmt.Errorf("inclusion proof doesn't verify: root mismatch")
+	}
+	fmt.Fprintln(w, "Inclusion proof verified.")
+	return nil
+}
+
+// verifyConsistency checks a consistency proof, and writes a trace of the
+// verification to w which shows how both roots are recomputed from the proof.
+func verifyConsistency(w io.Writer, size1, size2 uint64, hashes [][]byte, root1, root2 []byte) error {
+	fmt.Fprintf(w, "Consistency between tree sizes %d and %d\n", size1, size2)
+	fmt.Fprintf(w, "  old root hash: %x\n", root1)
+	fmt.Fprintf(w, "  new root hash: %x\n", root2)
+	if size1 > size2 {
+		return fmt.Errorf("old tree size %d is larger than the new tree size %d, swap the roots", size1, size2)
+	}
+	nodes, err := proof.Consistency(size1, size2)
+	if err != nil {
+		return err
+	}
+	labels := nodeLabels(nodes, size2)
+	if got, want := len(hashes), len(labels); got != want {
+		fmt.Fprintf(w, "Proof has %d hashes, but a consistency proof from size %d to %d needs %d:\n", got, size1, size2, want)
+		for i, l := range labels {
+			fmt.Fprintf(w, "  proof[%d]: %s\n", i, l)
+		}
+		return fmt.Errorf("wrong proof size %d, want %d: the proof was probably built for different tree sizes", got, want)
+	}
+	if size1 == size2 || size1 == 0 {
+		if err := proof.VerifyConsistency(hasher, size1, size2, hashes, root1, root2); err != nil {
+			return fmt.Errorf("roots of the same size differ: %v", err)
+		}
+		fmt.Fprintln(w, "Consistency proof verified.")
+		return nil
+	}
+
+	// Recompute both roots following RFC 9162 section 2.1.4.2.
+	fmt.Fprintln(w, "Recomputing roots:")
+	path := hashes
+	pathLabels := labels
+	if size1&(size1-1) == 0 {
+		// The old root is the seed if the old tree is perfect.
+		path = append([][]byte{root1}, hashes...)
+		pathLabels = append([]string{"old root"}, labels...)
+	}
+	fn, sn := size1-1, size2-1
+	for fn&1 == 1 {
+		fn, sn = fn>>1, sn>>1
+	}
+	fr, sr := path[0], path[0]
+	fmt.Fprintf(w, "  seed %x: %s\n", path[0], pathLabels[0])
+	for i, c := range path[1:] {
+		desc := pathLabels[i+1]
+		if fn&1 == 1 || fn == sn {
+			fr, sr = hasher.HashChildren(c, fr), hasher.HashChildren(c, sr)
+			fmt.Fprintf(w, "  %x is a left sibling, %s -> old %x, new %x\n", c, desc, fr, sr)
+			for fn&1 == 0 && fn != 0 {
+				fn, sn = fn>>1, sn>>1
+			}
+		} else {
+			sr = hasher.HashChildren(sr, c)
+			fmt.Fprintf(w, "  %x is a right sibling beyond the old tree, %s -> new %x\n", c, desc, sr)
+		}
+		fn, sn = fn>>1, sn>>1
+	}
+
+	if err := proof.VerifyConsistency(hasher, size1, size2, hashes, root1, root2); err != nil {
+		oldOK, newOK := bytes.Equal(fr, root1), bytes.Equal(sr, root2)
+		fmt.Fprintf(w, "Recomputed old root %x (match: %t), new root %x (match: %t).\n", fr, oldOK, sr, newOK)
+		switch {
+		case !oldOK && newOK:
+			fmt.Fprintln(w, "The proof matches the new root but not the old one: the old root is probably wrong.")
+		case oldOK && !newOK:
+			fmt.Fprintln(w, "The proof matches the old root but not the new one: either the new root or a proof")
+			fmt.Fprintln(w, "hash for leaves beyond the old tree is wrong.")
+		default:
+			fmt.Fprintln(w, "The proof matches neither root: the proof hashes are probably wrong.")
+		}
+		return fmt.Errorf("consistency proof doesn't verify: root mismatch")
+	}
+	fmt.Fprintln(w, "Consistency proof verified.")
+	return nil
+}
@@ -0,0 +1,221 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/types"
+	inmemory "github.com/transparency-dev/merkle/testonly"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+func newTree(size int) *inmemory.Tree {
+	tree := inmemory.New(hasher)
+	for i := 0; i < size; i++ {
+		tree.AppendData([]byte(fmt.Sprintf("leaf %d", i)))
+	}
+	return tree
+}
+
+func corrupt(h []byte) []byte {
+	c := append([]byte(nil), h...)
+	c[0] ^= 1
+	return c
+}
+
+func TestVerifyInclusion(t *testing.T) {
+	tree := newTree(7)
+	for index := uint64(0); index < 7; index++ {
+		hashes, err := tree.InclusionProof(index, 7)
+		if err != nil {
+			t.Fatalf("InclusionProof(%d): %v", index, err)
+		}
+		leaf := tree.LeafHash(index)
+		if err := verifyInclusion(io.Discard, index, 7, leaf, hashes, tree.Hash()); err != nil {
+			t.Errorf("verifyInclusion(%d): %v", index, err)
+		}
+	}
+
+	hashes, err := tree.InclusionProof(2, 7)
+	if err != nil {
+		t.Fatalf("InclusionProof(): %v", err)
+	}
+	for _, test := range []struct {
+		desc    string
+		index   uint64
+		leaf    []byte
+		hashes  [][]byte
+		wantErr string
+		wantOut string
+	}{
+		{desc: "wrong-leaf", index: 2, leaf: tree.LeafHash(3), hashes: hashes, wantErr: "root mismatch", wantOut: "SHA-256(0x00 || leaf data)"},
+		{desc: "wrong-hash", index: 2, leaf: tree.LeafHash(2), hashes: [][]byte{hashes[0], corrupt(hashes[1]), hashes[2]}, wantErr: "root mismatch"},
+		{desc: "short-proof", index: 2, leaf: tree.LeafHash(2), hashes: hashes[:2], wantErr: "wrong proof size 2, want 3", wantOut: "ephemeral node covering leaves [4, 7)"},
+		{desc: "index-beyond-size", index: 7, leaf: tree.LeafHash(2), hashes: hashes, wantErr: "beyond the tree size"},
+		{desc: "short-leaf-hash", index: 2, leaf: []byte{1, 2}, hashes: hashes, wantErr: "leaf hash has 2 bytes"},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			var out bytes.Buffer
+			err := verifyInclusion(&out, test.index, 7, test.leaf, test.hashes, tree.Hash())
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("verifyInclusion(): %v, want error containing %q", err, test.wantErr)
+			}
+			if !strings.Contains(out.String(), test.wantOut) {
+				t.Errorf("verifyInclusion() output:\n%s\nwant it to contain %q", out.String(), test.wantOut)
+			}
+		})
+	}
+}
+
+func TestVerifyConsistency(t *testing.T) {
+	tree := newTree(11)
+	for _, sizes := range [][2]uint64{{0, 11}, {1, 11}, {4, 11}, {5, 11}, {6, 8}, {11, 11}} {
+		size1, size2 := sizes[0], sizes[1]
+		hashes, err := tree.ConsistencyProof(size1, size2)
+		if err != nil {
+			t.Fatalf("ConsistencyProof(%d, %d): %v", size1, size2, err)
+		}
+		if err := verifyConsistency(io.Discard, size1, size2, hashes, tree.HashAt(size1), tree.HashAt(size2)); err != nil {
+			t.Errorf("verifyConsistency(%d, %d): %v", size1, size2, err)
+		}
+	}
+
+	hashes, err := tree.ConsistencyProof(5, 11)
+	if err != nil {
+		t.Fatalf("ConsistencyProof(): %v", err)
+	}
+	for _, test := range []struct {
+		desc    string
+		hashes  [][]byte
+		root1   []byte
+		root2   []byte
+		wantErr string
+		wantOut string
+	}{
+		{desc: "wrong-old-root", hashes: hashes, root1: corrupt(tree.HashAt(5)), root2: tree.Hash(), wantErr: "root mismatch", wantOut: "the old root is probably wrong"},
+		{desc: "wrong-new-root", hashes: hashes, root1: tree.HashAt(5), root2: corrupt(tree.Hash()), wantErr: "root mismatch", wantOut: "matches the old root but not the new one"},
+		{desc: "wrong-hash", hashes: append([][]byte{corrupt(hashes[0])}, hashes[1:]...), root1: tree.HashAt(5), root2: tree.Hash(), wantErr: "root mismatch", wantOut: "matches neither root"},
+		{desc: "long-proof", hashes: append(hashes, hashes[0]), root1: tree.HashAt(5), root2: tree.Hash(), wantErr: "wrong proof size"},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			var out bytes.Buffer
+			err := verifyConsistency(&out, 5, 11, test.hashes, test.root1, test.root2)
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("verifyConsistency(): %v, want error containing %q", err, test.wantErr)
+			}
+			if !strings.Contains(out.String(), test.wantOut) {
+				t.Errorf("verifyConsistency() output:\n%s\nwant it to contain %q", out.String(), test.wantOut)
+			}
+		})
+	}
+}
+
+func TestParseRoot(t *testing.T) {
+	want := &root{size: 11, hash: newTree(11).Hash()}
+	lr, err := (&types.LogRootV1{TreeSize: want.size, RootHash: want.hash}).MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary(): %v", err)
+	}
+	slr := &trillian.SignedLogRoot{LogRoot: lr}
+	binary, err := proto.Marshal(slr)
+	if err != nil {
+		t.Fatalf("proto.Marshal(): %v", err)
+	}
+	js, err := protojson.Marshal(slr)
+	if err != nil {
+		t.Fatalf("protojson.Marshal(): %v", err)
+	}
+	checkpoint := fmt.Sprintf("example.com/log\n11\n%s\n\n— example.com/log sig\n", base64.StdEncoding.EncodeToString(want.hash))
+
+	for _, test := range []struct {
+		desc    string
+		data    []byte
+		format  string
+		wantErr bool
+	}{
+		{desc: "proto", data: binary, format: "proto"},
+		{desc: "json", data: js, format: "json"},
+		{desc: "checkpoint", data: []byte(checkpoint), format: "checkpoint"},
+		{desc: "auto-proto", data: binary, format: "auto"},
+		{desc: "auto-json", data: js, format: "auto"},
+		{desc: "auto-checkpoint", data: []byte(checkpoint), format: "auto"},
+		{desc: "bad-checkpoint-size", data: []byte("origin\nbig\nAAAA\n"), format: "checkpoint", wantErr: true},
+		{desc: "bad-format", data: js, format: "xml", wantErr: true},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			got, err := parseRoot(test.data, test.format)
+			if gotErr := err != nil; gotErr != test.wantErr {
+				t.Fatalf("parseRoot(): %v, wantErr: %t", err, test.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.size != want.size || !bytes.Equal(got.hash, want.hash) {
+				t.Errorf("parseRoot() = {%d, %x}, want {%d, %x}", got.size, got.hash, want.size, want.hash)
+			}
+		})
+	}
+}
+
+func TestParseProof(t *testing.T) {
+	hashes, err := newTree(7).InclusionProof(2, 7)
+	if err != nil {
+		t.Fatalf("InclusionProof(): %v", err)
+	}
+	js, err := protojson.Marshal(&trillian.Proof{LeafIndex: 2, Hashes: hashes})
+	if err != nil {
+		t.Fatalf("protojson.Marshal(): %v", err)
+	}
+	text := fmt.Sprintf("%x\n%x\n\n%x\n", hashes[0], hashes[1], hashes[2])
+
+	for _, test := range []struct {
+		desc      string
+		data      []byte
+		wantIndex int64
+	}{
+		{desc: "json", data: js, wantIndex: 2},
+		{desc: "text", data: []byte(text), wantIndex: -1},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			index, got, err := parseProof(test.data)
+			if err != nil {
+				t.Fatalf("parseProof(): %v", err)
+			}
+			if index != test.wantIndex {
+				t.Errorf("parseProof() index = %d, want %d", index, test.wantIndex)
+			}
+			if len(got) != len(hashes) {
+				t.Fatalf("parseProof() returned %d hashes, want %d", len(got), len(hashes))
+			}
+			for i := range got {
+				if !bytes.Equal(got[i], hashes[i]) {
+					t.Errorf("parseProof() hash %d = %x, want %x", i, got[i], hashes[i])
+				}
+			}
+		})
+	}
+
+	if _, _, err := parseProof([]byte("not hex\n")); err == nil {
+		t.Error("parseProof(not hex) returned nil error")
+	}
+}
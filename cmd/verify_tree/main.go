@@ -0,0 +1,125 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main contains the implementation and entry point for the
+// verify_tree command, which re-hashes a whole log from its leaves, straight
+// from storage, and compares the result against the stored Merkle tree nodes
+// and the latest root of the log.
+//
+// Example usage:
+// $ ./verify_tree --storage_system=mysql --mysql_uri=... --log_id=123 --workers=8 --report=report.json
+//
+// The leaves are split into chunks of --chunk_size leaves, which --workers
+// goroutines verify in parallel, each reading at most --batch_size leaves at a
+// time, and fewer if needed to keep the leaves held by all workers within
+// --memory_limit_bytes. The report is written as JSON, and the command exits
+// with a non-zero status unless the tree is consistent. Pruned leaves, which
+// have no value, are verified with their stored leaf hashes.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian"
+	"github.com/google/trillian/monitoring"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/util"
+
+	// Register supported storage providers.
+	_ "github.com/google/trillian/storage/bbolt"
+	_ "github.com/google/trillian/storage/cloudspanner"
+	_ "github.com/google/trillian/storage/crdb"
+	_ "github.com/google/trillian/storage/mysql"
+	_ "github.com/google/trillian/storage/postgres"
+
+	// The database/sql driver of the postgresql and crdb storage providers.
+	_ "github.com/lib/pq"
+)
+
+var (
+	storageSystem = flag.String("storage_system", "mysql", fmt.Sprintf("Storage system to use. One of: %v", storage.Providers()))
+	logID         = flag.Int64("log_id", 0, "ID of the log to verify")
+	workers       = flag.Int("workers", 4, "Number of chunks of leaves verified in parallel")
+	chunkSize     = flag.Int64("chunk_size", 1<<16, "Number of leaves per chunk")
+	batchSize     = flag.Int64("batch_size", 1000, "Maximum number of leaves read from storage at a time")
+	memoryLimit   = flag.Int64("memory_limit_bytes", 256<<20, "Approximate number of bytes of leaves and nodes which all workers may hold at once")
+	maxMismatches = flag.Int("max_mismatches", 100, "Maximum number of mismatches listed in the report; all of them are counted")
+	reportFile    = flag.String("report", "", "File to write the JSON report to; stdout if empty")
+)
+
+func main() {
+	flag.Parse()
+	defer glog.Flush()
+
+	if *logID == 0 {
+		glog.Exit("--log_id must be set")
+	}
+	if *workers <= 0 || *chunkSize <= 0 || *batchSize <= 0 || *memoryLimit <= 0 {
+		glog.Exit("--workers, --chunk_size, --batch_size and --memory_limit_bytes must be positive")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go util.AwaitSignal(ctx, cancel)
+
+	sp, err := storage.NewProvider(*storageSystem, monitoring.InertMetricFactory{})
+	if err != nil {
+		glog.Exitf("Failed to get storage provider: %v", err)
+	}
+	defer sp.Close()
+
+	tree, err := storage.GetTree(ctx, sp.AdminStorage(), *logID)
+	if err != nil {
+		glog.Exitf("Failed to get tree %d: %v", *logID, err)
+	}
+	if tree.TreeType != trillian.TreeType_LOG && tree.TreeType != trillian.TreeType_PREORDERED_LOG {
+		glog.Exitf("Tree %d is a %v tree, want a log", *logID, tree.TreeType)
+	}
+
+	r, err := verifyTree(ctx, sp.LogStorage(), tree, options{
+		workers:       *workers,
+		chunkSize:     *chunkSize,
+		batchSize:     *batchSize,
+		memoryLimit:   *memoryLimit,
+		maxMismatches: *maxMismatches,
+	})
+	if err != nil {
+		glog.Errorf("Verification of log %d failed: %v", *logID, err)
+	}
+	if err := writeReport(r); err != nil {
+		glog.Exitf("Failed to write report: %v", err)
+	}
+	if !r.OK {
+		glog.Flush()
+		os.Exit(1)
+	}
+}
+
+func writeReport(r *report) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if *reportFile == "" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+	return os.WriteFile(*reportFile, data, 0o644)
+}
@@ -0,0 +1,384 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/types"
+	"github.com/transparency-dev/merkle/compact"
+	"github.com/transparency-dev/merkle/rfc6962"
+	"golang.org/x/sync/errgroup"
+)
+
+// leafOverhead is the estimated number of bytes held per leaf besides its
+// value and extra data, including the nodes computed from it and their
+// stored copies.
+const leafOverhead = 512
+
+// options configures a verification.
+type options struct {
+	// workers is the number of chunks verified in parallel.
+	workers int
+	// chunkSize is the number of leaves per chunk.
+	chunkSize int64
+	// batchSize is the maximum number of leaves read at a time.
+	batchSize int64
+	// memoryLimit is the number of bytes of leaves and nodes which all
+	// workers may hold at once. Workers read fewer than batchSize leaves at a
+	// time if the leaves seen so far suggest that a batch wouldn't fit.
+	memoryLimit int64
+	// maxMismatches is the maximum number of mismatches listed in the report.
+	maxMismatches int
+}
+
+// report is the machine-readable outcome of a verification. Hashes are hex
+// encoded.
+type report struct {
+	LogID            int64      `json:"log_id"`
+	TreeSize         uint64     `json:"tree_size"`
+	RootHash         string     `json:"root_hash"`
+	ComputedRootHash string     `json:"computed_root_hash,omitempty"`
+	Leaves           int64      `json:"leaves"`
+	PrunedLeaves     int64      `json:"pruned_leaves"`
+	NodesCompared    int64      `json:"nodes_compared"`
+	MismatchCount    int64      `json:"mismatch_count"`
+	Mismatches       []mismatch `json:"mismatches,omitempty"`
+	Error            string     `json:"error,omitempty"`
+	OK               bool       `json:"ok"`
+}
+
+// mismatch is a difference between a hash computed from the leaves of the
+// tree and the stored one. Kind is one of "leaf_hash" for the Merkle leaf
+// hash of a leaf, "node" for a tree node, "missing_node" for a tree node
+// which isn't stored, and "root_hash" for the latest root.
+type mismatch struct {
+	Kind     string `json:"kind"`
+	Level    uint   `json:"level"`
+	Index    uint64 `json:"index"`
+	Stored   string `json:"stored,omitempty"`
+	Computed string `json:"computed"`
+}
+
+// verifier re-hashes a tree from its leaves, in chunks which are verified in
+// parallel, and compares every node it computes against the stored one.
+type verifier struct {
+	// leaves and leafBytes count the leaves read so far and their size, from
+	// which the number of leaves to read at a time is estimated. The counters
+	// come first to be 64-bit aligned for atomic access.
+	leaves, leafBytes int64
+	pruned, compared  int64
+
+	ls   storage.LogStorage
+	tree *trillian.Tree
+	opts options
+	rf   *compact.RangeFactory
+
+	mu     sync.Mutex
+	report *report
+}
+
+// verifyTree verifies the leaves and stored nodes of tree against its latest
+// root. Mismatches are listed in the report, errors which prevent completing
+// the verification are returned along with the partial report.
+func verifyTree(ctx context.Context, ls storage.LogStorage, tree *trillian.Tree, opts options) (*report, error) {
+	v := &verifier{
+		ls:     ls,
+		tree:   tree,
+		opts:   opts,
+		rf:     &compact.RangeFactory{Hash: rfc6962.DefaultHasher.HashChildren},
+		report: &report{LogID: tree.TreeId},
+	}
+	err := v.verify(ctx)
+	v.report.Leaves = atomic.LoadInt64(&v.leaves)
+	v.report.PrunedLeaves = atomic.LoadInt64(&v.pruned)
+	v.report.NodesCompared = atomic.LoadInt64(&v.compared)
+	if err != nil {
+		v.report.Error = err.Error()
+	}
+	v.report.OK = err == nil && v.report.MismatchCount == 0
+	return v.report, err
+}
+
+func (v *verifier) verify(ctx context.Context) error {
+	root, err := v.latestRoot(ctx)
+	if err != nil {
+		return err
+	}
+	v.report.TreeSize = root.TreeSize
+	v.report.RootHash = fmt.Sprintf("%x", root.RootHash)
+	size := int64(root.TreeSize)
+	if size < 0 {
+		return fmt.Errorf("tree size %d is too large", root.TreeSize)
+	}
+
+	// Each chunk is reduced to a compact range, which are merged in order.
+	chunks := make([]*compact.Range, (size+v.opts.chunkSize-1)/v.opts.chunkSize)
+	next := make(chan int)
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		defer close(next)
+		for i := range chunks {
+			select {
+			case next <- i:
+			case <-gctx.Done():
+				return gctx.Err()
+			}
+		}
+		return nil
+	})
+	for w := 0; w < v.opts.workers; w++ {
+		g.Go(func() error {
+			for i := range next {
+				begin := int64(i) * v.opts.chunkSize
+				end := begin + v.opts.chunkSize
+				if end > size {
+					end = size
+				}
+				r, err := v.verifyChunk(gctx, begin, end)
+				if err != nil {
+					return err
+				}
+				chunks[i] = r
+				glog.V(1).Infof("%d: verified leaves [%d, %d)", v.tree.TreeId, begin, end)
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	// Merging the chunks computes the nodes above them.
+	r := v.rf.NewEmptyRange(0)
+	var nodes []compact.NodeID
+	hashes := make(map[compact.NodeID][]byte)
+	visit := func(id compact.NodeID, hash []byte) {
+		nodes = append(nodes, id)
+		hashes[id] = hash
+	}
+	for _, c := range chunks {
+		if err := r.AppendRange(c, visit); err != nil {
+			return err
+		}
+		if int64(len(nodes)) >= v.opts.batchSize {
+			if err := v.compareNodes(ctx, nodes, hashes); err != nil {
+				return err
+			}
+			nodes, hashes = nil, make(map[compact.NodeID][]byte)
+		}
+	}
+	if err := v.compareNodes(ctx, nodes, hashes); err != nil {
+		return err
+	}
+
+	computed, err := r.GetRootHash(nil)
+	if err != nil {
+		return err
+	}
+	if size == 0 {
+		computed = rfc6962.DefaultHasher.EmptyRoot()
+	}
+	v.report.ComputedRootHash = fmt.Sprintf("%x", computed)
+	if !bytes.Equal(computed, root.RootHash) {
+		v.addMismatch(mismatch{Kind: "root_hash", Stored: v.report.RootHash, Computed: v.report.ComputedRootHash})
+	}
+	return nil
+}
+
+// latestRoot returns the latest root of the tree.
+func (v *verifier) latestRoot(ctx context.Context) (*types.LogRootV1, error) {
+	tx, err := v.ls.SnapshotForTree(ctx, v.tree)
+	if tx != nil {
+		defer tx.Close()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open snapshot: %v", err)
+	}
+	slr, err := tx.LatestSignedLogRoot(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest root: %v", err)
+	}
+	var root types.LogRootV1
+	if err := root.UnmarshalBinary(slr.GetLogRoot()); err != nil {
+		return nil, fmt.Errorf("failed to parse latest root: %v", err)
+	}
+	return &root, tx.Commit(ctx)
+}
+
+// verifyChunk re-hashes the leaves [begin, end) of the tree, compares the
+// nodes of the chunk against the stored ones, and returns the compact range
+// of the chunk.
+func (v *verifier) verifyChunk(ctx context.Context, begin, end int64) (*compact.Range, error) {
+	r := v.rf.NewEmptyRange(uint64(begin))
+	for next := begin; next < end; {
+		count := v.readSize()
+		if count > end-next {
+			count = end - next
+		}
+		read, err := v.verifyBatch(ctx, r, next, count)
+		if err != nil {
+			return nil, err
+		}
+		next += read
+	}
+	return r, nil
+}
+
+// readSize returns the number of leaves to read at a time, so that the
+// leaves and nodes held by all workers stay within the memory limit.
+func (v *verifier) readSize() int64 {
+	leaves := atomic.LoadInt64(&v.leaves)
+	if leaves == 0 {
+		// Nothing is known about the size of the leaves yet.
+		return 1
+	}
+	perLeaf := atomic.LoadInt64(&v.leafBytes)/leaves + leafOverhead
+	count := v.opts.memoryLimit / int64(v.opts.workers) / perLeaf
+	switch {
+	case count < 1:
+		return 1
+	case count > v.opts.batchSize:
+		return v.opts.batchSize
+	}
+	return count
+}
+
+// verifyBatch appends up to count leaves from index start to r, and compares
+// the nodes computed from them against the stored ones. Returns the number of
+// leaves appended.
+func (v *verifier) verifyBatch(ctx context.Context, r *compact.Range, start, count int64) (int64, error) {
+	tx, err := v.ls.SnapshotForTree(ctx, v.tree)
+	if tx != nil {
+		defer tx.Close()
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to open snapshot: %v", err)
+	}
+	leaves, err := tx.GetLeavesByRange(ctx, start, count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read leaves from index %d: %v", start, err)
+	}
+	if len(leaves) == 0 {
+		return 0, fmt.Errorf("leaf %d is missing", start)
+	}
+
+	var nodes []compact.NodeID
+	hashes := make(map[compact.NodeID][]byte)
+	visit := func(id compact.NodeID, hash []byte) {
+		nodes = append(nodes, id)
+		hashes[id] = hash
+	}
+	var size int64
+	for i, leaf := range leaves {
+		if want := start + int64(i); leaf.LeafIndex != want {
+			return 0, fmt.Errorf("leaf %d is missing, got leaf %d", want, leaf.LeafIndex)
+		}
+		size += int64(len(leaf.LeafValue) + len(leaf.ExtraData))
+		if err := r.Append(v.leafHash(leaf), visit); err != nil {
+			return 0, err
+		}
+	}
+	atomic.AddInt64(&v.leafBytes, size)
+	atomic.AddInt64(&v.leaves, int64(len(leaves)))
+
+	if err := v.compareNodesTX(ctx, tx, nodes, hashes); err != nil {
+		return 0, err
+	}
+	return int64(len(leaves)), tx.Commit(ctx)
+}
+
+// leafHash returns the Merkle leaf hash of leaf computed from its value, and
+// reports a mismatch if it differs from the stored one. Pruned leaves, which
+// have no value, keep their stored hash.
+func (v *verifier) leafHash(leaf *trillian.LogLeaf) []byte {
+	hash := rfc6962.DefaultHasher.HashLeaf(leaf.LeafValue)
+	if bytes.Equal(hash, leaf.MerkleLeafHash) {
+		return hash
+	}
+	if len(leaf.LeafValue) == 0 && leaf.ExtraData == nil {
+		atomic.AddInt64(&v.pruned, 1)
+		return leaf.MerkleLeafHash
+	}
+	v.addMismatch(mismatch{
+		Kind:     "leaf_hash",
+		Index:    uint64(leaf.LeafIndex),
+		Stored:   fmt.Sprintf("%x", leaf.MerkleLeafHash),
+		Computed: fmt.Sprintf("%x", hash),
+	})
+	return hash
+}
+
+// compareNodes compares the given computed nodes against the stored ones, in
+// a new snapshot.
+func (v *verifier) compareNodes(ctx context.Context, ids []compact.NodeID, hashes map[compact.NodeID][]byte) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	tx, err := v.ls.SnapshotForTree(ctx, v.tree)
+	if tx != nil {
+		defer tx.Close()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open snapshot: %v", err)
+	}
+	if err := v.compareNodesTX(ctx, tx, ids, hashes); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (v *verifier) compareNodesTX(ctx context.Context, tx storage.ReadOnlyLogTreeTX, ids []compact.NodeID, hashes map[compact.NodeID][]byte) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	nodes, err := tx.GetMerkleNodes(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to read nodes: %v", err)
+	}
+	stored := make(map[compact.NodeID][]byte, len(nodes))
+	for _, n := range nodes {
+		stored[n.ID] = n.Hash
+	}
+	for _, id := range ids {
+		m := mismatch{Level: id.Level, Index: id.Index, Computed: fmt.Sprintf("%x", hashes[id])}
+		switch hash, ok := stored[id]; {
+		case !ok:
+			m.Kind = "missing_node"
+			v.addMismatch(m)
+		case !bytes.Equal(hash, hashes[id]):
+			m.Kind, m.Stored = "node", fmt.Sprintf("%x", hash)
+			v.addMismatch(m)
+		}
+	}
+	atomic.AddInt64(&v.compared, int64(len(ids)))
+	return nil
+}
+
+func (v *verifier) addMismatch(m mismatch) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.report.MismatchCount++
+	if len(v.report.Mismatches) < v.opts.maxMismatches {
+		v.report.Mismatches = append(v.report.Mismatches, m)
+	}
+}
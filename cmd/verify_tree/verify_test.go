@@ -0,0 +1,293 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/log"
+	"github.com/google/trillian/quota"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/storage/memory"
+	stestonly "github.com/google/trillian/storage/testonly"
+	"github.com/google/trillian/storage/tree"
+	"github.com/google/trillian/types"
+	"github.com/google/trillian/util/clock"
+	"github.com/transparency-dev/merkle/compact"
+	"github.com/transparency-dev/merkle/rfc6962"
+	"google.golang.org/protobuf/proto"
+)
+
+// newLog returns a memory log with the given number of integrated leaves.
+func newLog(ctx context.Context, t *testing.T, size int) (*trillian.Tree, storage.LogStorage) {
+	t.Helper()
+	log.InitMetrics(nil)
+	ts := memory.NewTreeStorage()
+	tree, err := storage.CreateTree(ctx, memory.NewAdminStorage(ts), proto.Clone(stestonly.LogTree).(*trillian.Tree))
+	if err != nil {
+		t.Fatalf("CreateTree(): %v", err)
+	}
+	ls := memory.NewLogStorage(ts, nil)
+	root, err := (&types.LogRootV1{RootHash: rfc6962.DefaultHasher.EmptyRoot(), TimestampNanos: 1}).MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary(): %v", err)
+	}
+	if err := ls.ReadWriteTransaction(ctx, tree, func(ctx context.Context, tx storage.LogTreeTX) error {
+		return tx.StoreSignedLogRoot(ctx, &trillian.SignedLogRoot{LogRoot: root})
+	}); err != nil {
+		t.Fatalf("StoreSignedLogRoot(): %v", err)
+	}
+	if size == 0 {
+		return tree, ls
+	}
+
+	leaves := make([]*trillian.LogLeaf, 0, size)
+	for i := 0; i < size; i++ {
+		data := []byte(fmt.Sprintf("leaf %d", i))
+		hash := sha256.Sum256(data)
+		leaves = append(leaves, &trillian.LogLeaf{
+			LeafValue:        data,
+			LeafIdentityHash: hash[:],
+			MerkleLeafHash:   rfc6962.DefaultHasher.HashLeaf(data),
+		})
+	}
+	fakeTime := clock.NewFake(time.Unix(1500000000, 0))
+	if _, err := ls.QueueLeaves(ctx, tree, leaves, fakeTime.Now()); err != nil {
+		t.Fatalf("QueueLeaves(): %v", err)
+	}
+	if _, err := log.IntegrateBatch(ctx, tree, size, 0, 0, fakeTime, ls, quota.Noop()); err != nil {
+		t.Fatalf("IntegrateBatch(): %v", err)
+	}
+	return tree, ls
+}
+
+// perfectNodes returns the number of nodes of perfect subtrees in a tree of
+// the given size.
+func perfectNodes(size int64) int64 {
+	var n int64
+	for ; size > 0; size >>= 1 {
+		n += size
+	}
+	return n
+}
+
+// corruptStorage alters what snapshots read from the underlying storage. It
+// also runs one snapshot at a time, as the memory storage doesn't support
+// concurrent ones.
+type corruptStorage struct {
+	storage.LogStorage
+	leaf func(*trillian.LogLeaf)
+	node func(*tree.Node)
+	root func(*types.LogRootV1)
+
+	mu sync.Mutex
+}
+
+func (s *corruptStorage) SnapshotForTree(ctx context.Context, tree *trillian.Tree) (storage.ReadOnlyLogTreeTX, error) {
+	s.mu.Lock()
+	tx, err := s.LogStorage.SnapshotForTree(ctx, tree)
+	if err != nil {
+		if tx != nil {
+			tx.Close()
+		}
+		s.mu.Unlock()
+		return nil, err
+	}
+	return &corruptTX{ReadOnlyLogTreeTX: tx, s: s}, nil
+}
+
+type corruptTX struct {
+	storage.ReadOnlyLogTreeTX
+	s    *corruptStorage
+	done sync.Once
+}
+
+func (t *corruptTX) Commit(ctx context.Context) error {
+	defer t.done.Do(t.s.mu.Unlock)
+	return t.ReadOnlyLogTreeTX.Commit(ctx)
+}
+
+func (t *corruptTX) Close() error {
+	defer t.done.Do(t.s.mu.Unlock)
+	return t.ReadOnlyLogTreeTX.Close()
+}
+
+func (t *corruptTX) GetLeavesByRange(ctx context.Context, start, count int64) ([]*trillian.LogLeaf, error) {
+	leaves, err := t.ReadOnlyLogTreeTX.GetLeavesByRange(ctx, start, count)
+	if t.s.leaf != nil {
+		for i, leaf := range leaves {
+			leaves[i] = proto.Clone(leaf).(*trillian.LogLeaf)
+			t.s.leaf(leaves[i])
+		}
+	}
+	return leaves, err
+}
+
+func (t *corruptTX) GetMerkleNodes(ctx context.Context, ids []compact.NodeID) ([]tree.Node, error) {
+	nodes, err := t.ReadOnlyLogTreeTX.GetMerkleNodes(ctx, ids)
+	if t.s.node != nil {
+		for i := range nodes {
+			t.s.node(&nodes[i])
+		}
+	}
+	return nodes, err
+}
+
+func (t *corruptTX) LatestSignedLogRoot(ctx context.Context) (*trillian.SignedLogRoot, error) {
+	slr, err := t.ReadOnlyLogTreeTX.LatestSignedLogRoot(ctx)
+	if err != nil || t.s.root == nil {
+		return slr, err
+	}
+	var root types.LogRootV1
+	if err := root.UnmarshalBinary(slr.LogRoot); err != nil {
+		return nil, err
+	}
+	t.s.root(&root)
+	logRoot, err := root.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return &trillian.SignedLogRoot{LogRoot: logRoot}, nil
+}
+
+func TestVerifyTree(t *testing.T) {
+	ctx := context.Background()
+	opts := options{workers: 3, chunkSize: 8, batchSize: 3, memoryLimit: 1 << 20, maxMismatches: 1}
+	badHash := sha256.Sum256([]byte("bad"))
+
+	for _, tc := range []struct {
+		desc         string
+		size         int
+		opts         options
+		corrupt      *corruptStorage
+		wantOK       bool
+		wantErr      bool
+		wantKind     string
+		wantCount    int64
+		wantPruned   int64
+		wantCompared int64
+	}{
+		{desc: "empty", wantOK: true},
+		{desc: "ok", size: 37, wantOK: true, wantCompared: perfectNodes(37)},
+		{desc: "singleChunk", size: 37, opts: options{workers: 1, chunkSize: 64, batchSize: 100, memoryLimit: 1 << 20}, wantOK: true, wantCompared: perfectNodes(37)},
+		{desc: "tightMemory", size: 20, opts: options{workers: 2, chunkSize: 4, batchSize: 100, memoryLimit: 1}, wantOK: true, wantCompared: perfectNodes(20)},
+		{
+			desc: "pruned",
+			size: 37,
+			corrupt: &corruptStorage{leaf: func(leaf *trillian.LogLeaf) {
+				if leaf.LeafIndex%2 == 0 {
+					leaf.LeafValue, leaf.ExtraData = nil, nil
+				}
+			}},
+			wantOK:       true,
+			wantPruned:   19,
+			wantCompared: perfectNodes(37),
+		},
+		{
+			desc: "leafValue",
+			size: 37,
+			corrupt: &corruptStorage{leaf: func(leaf *trillian.LogLeaf) {
+				if leaf.LeafIndex == 20 {
+					leaf.LeafValue = []byte("changed")
+				}
+			}},
+			// The leaf hash, the 6 nodes from the leaf up, and the root hash.
+			wantKind:     "leaf_hash",
+			wantCount:    1 + 6 + 1,
+			wantCompared: perfectNodes(37),
+		},
+		{
+			desc: "node",
+			size: 37,
+			corrupt: &corruptStorage{node: func(n *tree.Node) {
+				if n.ID == compact.NewNodeID(3, 2) {
+					n.Hash = badHash[:]
+				}
+			}},
+			wantKind:     "node",
+			wantCount:    1,
+			wantCompared: perfectNodes(37),
+		},
+		{
+			desc: "rootHash",
+			size: 37,
+			corrupt: &corruptStorage{root: func(root *types.LogRootV1) {
+				root.RootHash = badHash[:]
+			}},
+			wantKind:     "root_hash",
+			wantCount:    1,
+			wantCompared: perfectNodes(37),
+		},
+		{
+			desc: "missingLeaves",
+			size: 7,
+			corrupt: &corruptStorage{root: func(root *types.LogRootV1) {
+				root.TreeSize = 9
+			}},
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			tree, ls := newLog(ctx, t, tc.size)
+			if tc.corrupt == nil {
+				tc.corrupt = &corruptStorage{}
+			}
+			tc.corrupt.LogStorage = ls
+			o := opts
+			if tc.opts.workers > 0 {
+				o = tc.opts
+			}
+			r, err := verifyTree(ctx, tc.corrupt, tree, o)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("verifyTree(): %v, want err: %v", err, tc.wantErr)
+			}
+			if gotErr := r.Error != ""; gotErr != tc.wantErr {
+				t.Errorf("report error %q, want error: %v", r.Error, tc.wantErr)
+			}
+			if r.OK != tc.wantOK {
+				t.Errorf("report OK=%v, want %v: %+v", r.OK, tc.wantOK, r)
+			}
+			if tc.wantErr {
+				return
+			}
+			if got, want := r.Leaves, int64(tc.size); got != want {
+				t.Errorf("report leaves=%d, want %d", got, want)
+			}
+			if r.PrunedLeaves != tc.wantPruned {
+				t.Errorf("report pruned_leaves=%d, want %d", r.PrunedLeaves, tc.wantPruned)
+			}
+			if r.NodesCompared != tc.wantCompared {
+				t.Errorf("report nodes_compared=%d, want %d", r.NodesCompared, tc.wantCompared)
+			}
+			if r.MismatchCount != tc.wantCount {
+				t.Errorf("report mismatch_count=%d, want %d: %+v", r.MismatchCount, tc.wantCount, r.Mismatches)
+			}
+			if tc.wantKind != "" {
+				if len(r.Mismatches) != 1 || r.Mismatches[0].Kind != tc.wantKind {
+					t.Errorf("report mismatches=%+v, want one of kind %q", r.Mismatches, tc.wantKind)
+				}
+			}
+			if tc.wantOK && r.ComputedRootHash != r.RootHash {
+				t.Errorf("report computed_root_hash=%s, want %s", r.ComputedRootHash, r.RootHash)
+			}
+		})
+	}
+}
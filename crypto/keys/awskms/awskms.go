@@ -0,0 +1,185 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package awskms provides access to private keys held by the AWS Key
+// Management Service, so that they don't have to be stored on disk.
+package awskms
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/google/trillian/crypto/keyspb"
+	"google.golang.org/protobuf/proto"
+)
+
+// Client is the part of the AWS KMS API used by Signer. It is implemented by
+// *kms.KMS.
+type Client interface {
+	GetPublicKeyWithContext(aws.Context, *kms.GetPublicKeyInput, ...request.Option) (*kms.GetPublicKeyOutput, error)
+	SignWithContext(aws.Context, *kms.SignInput, ...request.Option) (*kms.SignOutput, error)
+}
+
+// Signer is a crypto.Signer which signs with an asymmetric AWS KMS key. ECDSA
+// and RSA keys are supported. RSA keys sign with PKCS #1 v1.5, or with PSS if
+// the SignerOpts are *rsa.PSSOptions.
+type Signer struct {
+	client     Client
+	keyID      string
+	public     crypto.PublicKey
+	algorithms map[string]bool
+}
+
+// FromConfig returns a Signer for the key identified by config. The AWS
+// credentials are taken from the environment, shared configuration files or
+// the instance role, as usual for the AWS SDK.
+func FromConfig(ctx context.Context, config *keyspb.AWSKMSConfig) (*Signer, error) {
+	region, err := keyRegion(config)
+	if err != nil {
+		return nil, err
+	}
+	sess, err := session.NewSessionWithOptions(session.Options{SharedConfigState: session.SharedConfigEnable})
+	if err != nil {
+		return nil, fmt.Errorf("awskms: failed to create AWS session: %v", err)
+	}
+	cfg := aws.NewConfig()
+	if region != "" {
+		cfg = cfg.WithRegion(region)
+	}
+	return NewSigner(ctx, kms.New(sess, cfg), config.GetKeyId())
+}
+
+// FromProto builds a crypto.Signer from a proto.Message, which must be of type
+// AWSKMSConfig.
+func FromProto(ctx context.Context, pb proto.Message) (crypto.Signer, error) {
+	if pb, ok := pb.(*keyspb.AWSKMSConfig); ok {
+		return FromConfig(ctx, pb)
+	}
+	return nil, fmt.Errorf("awskms: got %T, want *keyspb.AWSKMSConfig", pb)
+}
+
+// keyRegion returns the region which holds the key of config: the region of
+// config, or of the key or alias ARN. It's empty if neither is set, so that
+// the region of the AWS SDK configuration is used.
+func keyRegion(config *keyspb.AWSKMSConfig) (string, error) {
+	keyID := config.GetKeyId()
+	if keyID == "" {
+		return "", errors.New("awskms: empty key ID")
+	}
+	region := config.GetRegion()
+	if !arn.IsARN(keyID) {
+		return region, nil
+	}
+	a, err := arn.Parse(keyID)
+	if err != nil {
+		return "", fmt.Errorf("awskms: invalid key ARN %q: %v", keyID, err)
+	}
+	if a.Service != "kms" {
+		return "", fmt.Errorf("awskms: key ARN %q is not a KMS ARN", keyID)
+	}
+	if region != "" && region != a.Region {
+		return "", fmt.Errorf("awskms: key ARN %q is in region %q, not %q", keyID, a.Region, region)
+	}
+	return a.Region, nil
+}
+
+// NewSigner returns a Signer for the key with the given key ID, key ARN, alias
+// name or alias ARN, which must be an asymmetric SIGN_VERIFY key.
+func NewSigner(ctx context.Context, client Client, keyID string) (*Signer, error) {
+	out, err := client.GetPublicKeyWithContext(ctx, &kms.GetPublicKeyInput{KeyId: aws.String(keyID)})
+	if err != nil {
+		return nil, fmt.Errorf("awskms: failed to get public key of %q: %v", keyID, err)
+	}
+	if usage := aws.StringValue(out.KeyUsage); usage != kms.KeyUsageTypeSignVerify {
+		return nil, fmt.Errorf("awskms: key %q has usage %s, want %s", keyID, usage, kms.KeyUsageTypeSignVerify)
+	}
+	pub, err := x509.ParsePKIXPublicKey(out.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("awskms: failed to parse public key of %q: %v", keyID, err)
+	}
+	switch pub.(type) {
+	case *ecdsa.PublicKey, *rsa.PublicKey:
+	default:
+		return nil, fmt.Errorf("awskms: key %q has unsupported public key type %T", keyID, pub)
+	}
+	algorithms := make(map[string]bool)
+	for _, a := range out.SigningAlgorithms {
+		algorithms[aws.StringValue(a)] = true
+	}
+	return &Signer{client: client, keyID: keyID, public: pub, algorithms: algorithms}, nil
+}
+
+// Public returns the public key of the signer.
+func (s *Signer) Public() crypto.PublicKey {
+	return s.public
+}
+
+// Sign signs digest, which must be the digest of opts.HashFunc(), with the
+// KMS key. The rand argument is ignored.
+func (s *Signer) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	alg, err := s.algorithm(opts)
+	if err != nil {
+		return nil, err
+	}
+	if got, want := len(digest), opts.HashFunc().Size(); got != want {
+		return nil, fmt.Errorf("awskms: digest has %d bytes, want %d", got, want)
+	}
+	out, err := s.client.SignWithContext(context.Background(), &kms.SignInput{
+		KeyId:            aws.String(s.keyID),
+		Message:          digest,
+		MessageType:      aws.String(kms.MessageTypeDigest),
+		SigningAlgorithm: aws.String(alg),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("awskms: failed to sign with %q: %v", s.keyID, err)
+	}
+	return out.Signature, nil
+}
+
+// algorithm returns the KMS signing algorithm matching the key and opts.
+func (s *Signer) algorithm(opts crypto.SignerOpts) (string, error) {
+	bits := map[crypto.Hash]string{crypto.SHA256: "256", crypto.SHA384: "384", crypto.SHA512: "512"}[opts.HashFunc()]
+	if bits == "" {
+		return "", fmt.Errorf("awskms: unsupported hash %v", opts.HashFunc())
+	}
+	var alg string
+	switch s.public.(type) {
+	case *ecdsa.PublicKey:
+		alg = "ECDSA_SHA_" + bits
+	case *rsa.PublicKey:
+		alg = "RSASSA_PKCS1_V1_5_SHA_" + bits
+		if pss, ok := opts.(*rsa.PSSOptions); ok {
+			// KMS always uses a salt as long as the hash.
+			if pss.SaltLength != rsa.PSSSaltLengthEqualsHash && pss.SaltLength != opts.HashFunc().Size() {
+				return "", fmt.Errorf("awskms: unsupported PSS salt length %d", pss.SaltLength)
+			}
+			alg = "RSASSA_PSS_SHA_" + bits
+		}
+	}
+	if !s.algorithms[alg] {
+		return "", fmt.Errorf("awskms: key %q doesn't support signing algorithm %s", s.keyID, alg)
+	}
+	return alg, nil
+}
@@ -0,0 +1,237 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awskms
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/kms"
+	ktestonly "github.com/google/trillian/crypto/keys/testonly"
+	"github.com/google/trillian/crypto/keyspb"
+)
+
+const testKeyID = "alias/trillian"
+
+// fakeClient is a Client which holds a single key.
+type fakeClient struct {
+	keyID      string
+	usage      string
+	signer     crypto.Signer
+	algorithms []string
+}
+
+func (c *fakeClient) GetPublicKeyWithContext(_ aws.Context, in *kms.GetPublicKeyInput, _ ...request.Option) (*kms.GetPublicKeyOutput, error) {
+	if got := aws.StringValue(in.KeyId); got != c.keyID {
+		return nil, fmt.Errorf("key %q not found", got)
+	}
+	der, err := x509.MarshalPKIXPublicKey(c.signer.Public())
+	if err != nil {
+		return nil, err
+	}
+	return &kms.GetPublicKeyOutput{
+		KeyId:             in.KeyId,
+		KeyUsage:          aws.String(c.usage),
+		PublicKey:         der,
+		SigningAlgorithms: aws.StringSlice(c.algorithms),
+	}, nil
+}
+
+func (c *fakeClient) SignWithContext(_ aws.Context, in *kms.SignInput, _ ...request.Option) (*kms.SignOutput, error) {
+	if got := aws.StringValue(in.KeyId); got != c.keyID {
+		return nil, fmt.Errorf("key %q not found", got)
+	}
+	if got := aws.StringValue(in.MessageType); got != kms.MessageTypeDigest {
+		return nil, fmt.Errorf("message type %q, want %q", got, kms.MessageTypeDigest)
+	}
+	var opts crypto.SignerOpts
+	switch alg := aws.StringValue(in.SigningAlgorithm); alg {
+	case kms.SigningAlgorithmSpecEcdsaSha256, kms.SigningAlgorithmSpecRsassaPkcs1V15Sha256:
+		opts = crypto.SHA256
+	case kms.SigningAlgorithmSpecRsassaPssSha256:
+		opts = &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash, Hash: crypto.SHA256}
+	default:
+		return nil, fmt.Errorf("unsupported signing algorithm %q", alg)
+	}
+	sig, err := c.signer.Sign(rand.Reader, in.Message, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &kms.SignOutput{KeyId: in.KeyId, Signature: sig, SigningAlgorithm: in.SigningAlgorithm}, nil
+}
+
+func newFakeClient(t *testing.T, rsaKey bool) *fakeClient {
+	t.Helper()
+	if rsaKey {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			t.Fatalf("GenerateKey(): %v", err)
+		}
+		return &fakeClient{
+			keyID:      testKeyID,
+			usage:      kms.KeyUsageTypeSignVerify,
+			signer:     key,
+			algorithms: []string{kms.SigningAlgorithmSpecRsassaPkcs1V15Sha256, kms.SigningAlgorithmSpecRsassaPssSha256},
+		}
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey(): %v", err)
+	}
+	return &fakeClient{
+		keyID:      testKeyID,
+		usage:      kms.KeyUsageTypeSignVerify,
+		signer:     key,
+		algorithms: []string{kms.SigningAlgorithmSpecEcdsaSha256},
+	}
+}
+
+func TestSignAndVerify(t *testing.T) {
+	ctx := context.Background()
+	for _, test := range []struct {
+		desc   string
+		rsaKey bool
+	}{
+		{desc: "ECDSA"},
+		{desc: "RSA", rsaKey: true},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			client := newFakeClient(t, test.rsaKey)
+			signer, err := NewSigner(ctx, client, testKeyID)
+			if err != nil {
+				t.Fatalf("NewSigner(): %v", err)
+			}
+			if err := ktestonly.SignAndVerify(signer, client.signer.Public()); err != nil {
+				t.Errorf("SignAndVerify(): %v", err)
+			}
+		})
+	}
+}
+
+func TestSignPSS(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeClient(t, true /* rsaKey */)
+	signer, err := NewSigner(ctx, client, testKeyID)
+	if err != nil {
+		t.Fatalf("NewSigner(): %v", err)
+	}
+	digest := sha256.Sum256([]byte("test"))
+	opts := &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash, Hash: crypto.SHA256}
+	sig, err := signer.Sign(rand.Reader, digest[:], opts)
+	if err != nil {
+		t.Fatalf("Sign(): %v", err)
+	}
+	if err := rsa.VerifyPSS(client.signer.Public().(*rsa.PublicKey), crypto.SHA256, digest[:], sig, opts); err != nil {
+		t.Errorf("VerifyPSS(): %v", err)
+	}
+
+	opts.SaltLength = rsa.PSSSaltLengthAuto
+	if _, err := signer.Sign(rand.Reader, digest[:], opts); err == nil {
+		t.Error("Sign() with automatic salt length succeeded, want error")
+	}
+}
+
+func TestSignErrors(t *testing.T) {
+	ctx := context.Background()
+	signer, err := NewSigner(ctx, newFakeClient(t, false /* rsaKey */), testKeyID)
+	if err != nil {
+		t.Fatalf("NewSigner(): %v", err)
+	}
+	digest := sha256.Sum256([]byte("test"))
+	for _, test := range []struct {
+		desc   string
+		digest []byte
+		opts   crypto.SignerOpts
+	}{
+		{desc: "unsupportedHash", digest: digest[:20], opts: crypto.SHA1},
+		{desc: "unsupportedAlgorithm", digest: make([]byte, 48), opts: crypto.SHA384},
+		{desc: "shortDigest", digest: digest[:16], opts: crypto.SHA256},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			if _, err := signer.Sign(rand.Reader, test.digest, test.opts); err == nil {
+				t.Error("Sign() succeeded, want error")
+			}
+		})
+	}
+}
+
+func TestNewSignerErrors(t *testing.T) {
+	ctx := context.Background()
+	for _, test := range []struct {
+		desc    string
+		keyID   string
+		usage   string
+		wantErr string
+	}{
+		{desc: "unknownKey", keyID: "alias/unknown", usage: kms.KeyUsageTypeSignVerify, wantErr: "not found"},
+		{desc: "encryptionKey", keyID: testKeyID, usage: kms.KeyUsageTypeEncryptDecrypt, wantErr: "usage"},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			client := newFakeClient(t, false /* rsaKey */)
+			client.usage = test.usage
+			_, err := NewSigner(ctx, client, test.keyID)
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("NewSigner() returned err %v, want error containing %q", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestKeyRegion(t *testing.T) {
+	const keyARN = "arn:aws:kms:eu-west-1:111122223333:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+	const aliasARN = "arn:aws:kms:us-east-2:111122223333:alias/trillian"
+	for _, test := range []struct {
+		desc    string
+		config  *keyspb.AWSKMSConfig
+		want    string
+		wantErr bool
+	}{
+		{desc: "alias", config: &keyspb.AWSKMSConfig{KeyId: "alias/trillian"}},
+		{desc: "aliasWithRegion", config: &keyspb.AWSKMSConfig{KeyId: "alias/trillian", Region: "us-west-2"}, want: "us-west-2"},
+		{desc: "keyARN", config: &keyspb.AWSKMSConfig{KeyId: keyARN}, want: "eu-west-1"},
+		{desc: "aliasARN", config: &keyspb.AWSKMSConfig{KeyId: aliasARN}, want: "us-east-2"},
+		{desc: "keyARNWithRegion", config: &keyspb.AWSKMSConfig{KeyId: keyARN, Region: "eu-west-1"}, want: "eu-west-1"},
+		{desc: "keyARNWithOtherRegion", config: &keyspb.AWSKMSConfig{KeyId: keyARN, Region: "us-west-2"}, wantErr: true},
+		{desc: "otherServiceARN", config: &keyspb.AWSKMSConfig{KeyId: "arn:aws:s3:::bucket"}, wantErr: true},
+		{desc: "emptyKeyID", config: &keyspb.AWSKMSConfig{}, wantErr: true},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			got, err := keyRegion(test.config)
+			if gotErr := err != nil; gotErr != test.wantErr {
+				t.Fatalf("keyRegion() returned err %v, want error: %v", err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("keyRegion() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestFromProtoWrongType(t *testing.T) {
+	if _, err := FromProto(context.Background(), &keyspb.PEMKeyFile{}); err == nil {
+		t.Error("FromProto(PEMKeyFile) succeeded, want error")
+	}
+}
@@ -0,0 +1,252 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package remote provides access to private keys held by external signing
+// services, which implement the RemoteSigner gRPC service, so that operators
+// can subject signing to their own policy, e.g. in air-gapped signing
+// ceremonies for high-value logs.
+package remote
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/trillian/crypto/keys/remote/remotepb"
+	"github.com/google/trillian/crypto/keyspb"
+	"golang.org/x/mod/sumdb/note"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultSignTimeout is the maximum time to wait for a signature, unless
+// configured otherwise.
+const DefaultSignTimeout = time.Minute
+
+// Signer is a crypto.Signer which signs with a key of a signing service.
+// ECDSA, RSA and Ed25519 keys are supported. RSA keys sign with PKCS #1 v1.5,
+// or with PSS if the SignerOpts are *rsa.PSSOptions. Signatures returned by
+// the service are verified with the public key before they are used.
+type Signer struct {
+	client  remotepb.RemoteSignerClient
+	keyID   string
+	public  crypto.PublicKey
+	timeout time.Duration
+	conn    *grpc.ClientConn
+}
+
+// FromConfig returns a Signer for the key identified by config, connected to
+// its signing service over TLS, unless config allows an insecure connection.
+// The Signer must be closed once no longer used.
+func FromConfig(ctx context.Context, config *keyspb.RemoteSignerConfig) (*Signer, error) {
+	if config.GetAddress() == "" {
+		return nil, errors.New("remote: empty signing service address")
+	}
+	var creds credentials.TransportCredentials
+	switch file := config.GetCaCertFile(); {
+	case config.GetInsecure() && file != "":
+		return nil, errors.New("remote: insecure connection with CA certificates")
+	case config.GetInsecure():
+		creds = insecure.NewCredentials()
+	case file != "":
+		var err error
+		if creds, err = credentials.NewClientTLSFromFile(file, ""); err != nil {
+			return nil, fmt.Errorf("remote: failed to load CA certificates: %v", err)
+		}
+	default:
+		creds = credentials.NewTLS(&tls.Config{})
+	}
+	timeout := config.GetSignTimeout().AsDuration()
+	if timeout < 0 {
+		return nil, fmt.Errorf("remote: negative sign timeout %v", timeout)
+	}
+	conn, err := grpc.DialContext(ctx, config.GetAddress(), grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("remote: failed to dial %s: %v", config.GetAddress(), err)
+	}
+	s, err := NewSigner(ctx, remotepb.NewRemoteSignerClient(conn), config.GetKeyId(), timeout)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	s.conn = conn
+	return s, nil
+}
+
+// FromProto builds a crypto.Signer from a proto.Message, which must be of type
+// RemoteSignerConfig.
+func FromProto(ctx context.Context, pb proto.Message) (crypto.Signer, error) {
+	if pb, ok := pb.(*keyspb.RemoteSignerConfig); ok {
+		return FromConfig(ctx, pb)
+	}
+	return nil, fmt.Errorf("remote: got %T, want *keyspb.RemoteSignerConfig", pb)
+}
+
+// NewSigner returns a Signer for the key with the given ID, which waits at
+// most timeout for each signature, or DefaultSignTimeout if timeout is zero.
+func NewSigner(ctx context.Context, client remotepb.RemoteSignerClient, keyID string, timeout time.Duration) (*Signer, error) {
+	if keyID == "" {
+		return nil, errors.New("remote: empty key ID")
+	}
+	rsp, err := client.GetPublicKey(ctx, &remotepb.GetPublicKeyRequest{KeyId: keyID})
+	if err != nil {
+		return nil, fmt.Errorf("remote: failed to get public key of %q: %v", keyID, err)
+	}
+	pub, err := x509.ParsePKIXPublicKey(rsp.PublicKeyDer)
+	if err != nil {
+		return nil, fmt.Errorf("remote: failed to parse public key of %q: %v", keyID, err)
+	}
+	switch pub.(type) {
+	case *ecdsa.PublicKey, *rsa.PublicKey, ed25519.PublicKey:
+	default:
+		return nil, fmt.Errorf("remote: key %q has unsupported public key type %T", keyID, pub)
+	}
+	if timeout == 0 {
+		timeout = DefaultSignTimeout
+	}
+	return &Signer{client: client, keyID: keyID, public: pub, timeout: timeout}, nil
+}
+
+// Public returns the public key of the signer.
+func (s *Signer) Public() crypto.PublicKey {
+	return s.public
+}
+
+// Sign signs digest, which must be the digest of opts.HashFunc(), or the
+// whole message for Ed25519 keys, with the key of the signing service. The
+// rand argument is ignored.
+func (s *Signer) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	req, err := s.signRequest(digest, opts)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	rsp, err := s.client.Sign(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("remote: failed to sign with %q: %v", s.keyID, err)
+	}
+	if err := verify(s.public, digest, rsp.Signature, opts); err != nil {
+		return nil, fmt.Errorf("remote: invalid signature from %q: %v", s.keyID, err)
+	}
+	return rsp.Signature, nil
+}
+
+// verify checks that sig is a signature of digest by pub, as made by Sign.
+func verify(pub crypto.PublicKey, digest, sig []byte, opts crypto.SignerOpts) error {
+	switch pub := pub.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(pub, digest, sig) {
+			return errors.New("ECDSA verification failed")
+		}
+		return nil
+	case *rsa.PublicKey:
+		if pss, ok := opts.(*rsa.PSSOptions); ok {
+			return rsa.VerifyPSS(pub, opts.HashFunc(), digest, sig, pss)
+		}
+		return rsa.VerifyPKCS1v15(pub, opts.HashFunc(), digest, sig)
+	case ed25519.PublicKey:
+		if !ed25519.Verify(pub, digest, sig) {
+			return errors.New("Ed25519 verification failed")
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported public key type %T", pub)
+}
+
+// signRequest returns the request signing digest with the key and opts.
+func (s *Signer) signRequest(digest []byte, opts crypto.SignerOpts) (*remotepb.SignRequest, error) {
+	req := &remotepb.SignRequest{KeyId: s.keyID, Digest: digest}
+	if _, ok := s.public.(ed25519.PublicKey); ok {
+		if opts.HashFunc() != crypto.Hash(0) {
+			return nil, fmt.Errorf("remote: Ed25519 keys sign whole messages, got hash %v", opts.HashFunc())
+		}
+		return req, nil
+	}
+
+	hash, ok := map[crypto.Hash]remotepb.Hash{
+		crypto.SHA256: remotepb.Hash_SHA256,
+		crypto.SHA384: remotepb.Hash_SHA384,
+		crypto.SHA512: remotepb.Hash_SHA512,
+	}[opts.HashFunc()]
+	if !ok {
+		return nil, fmt.Errorf("remote: unsupported hash %v", opts.HashFunc())
+	}
+	if got, want := len(digest), opts.HashFunc().Size(); got != want {
+		return nil, fmt.Errorf("remote: digest has %d bytes, want %d", got, want)
+	}
+	req.Hash = hash
+	if pss, ok := opts.(*rsa.PSSOptions); ok {
+		if _, ok := s.public.(*rsa.PublicKey); !ok {
+			return nil, fmt.Errorf("remote: PSS options for a %T key", s.public)
+		}
+		if pss.SaltLength != rsa.PSSSaltLengthEqualsHash && pss.SaltLength != opts.HashFunc().Size() {
+			return nil, fmt.Errorf("remote: unsupported PSS salt length %d", pss.SaltLength)
+		}
+		req.RsaPss = true
+	}
+	return req, nil
+}
+
+// Close closes the connection to the signing service, if the Signer was
+// created with FromConfig.
+func (s *Signer) Close() error {
+	if s.conn == nil {
+		return nil
+	}
+	return s.conn.Close()
+}
+
+// NewNoteSigner returns a note.Signer which signs notes, e.g. checkpoints,
+// with name and the Ed25519 key of signer, such as a Signer.
+func NewNoteSigner(name string, signer crypto.Signer) (note.Signer, error) {
+	pub, ok := signer.Public().(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("remote: notes are signed with Ed25519 keys, got %T", signer.Public())
+	}
+	vkey, err := note.NewEd25519VerifierKey(name, pub)
+	if err != nil {
+		return nil, fmt.Errorf("remote: %v", err)
+	}
+	// The verifier has the same name and key hash.
+	verifier, err := note.NewVerifier(vkey)
+	if err != nil {
+		return nil, fmt.Errorf("remote: %v", err)
+	}
+	return &noteSigner{name: name, hash: verifier.KeyHash(), signer: signer}, nil
+}
+
+// noteSigner is a note.Signer which signs with an Ed25519 crypto.Signer.
+type noteSigner struct {
+	name   string
+	hash   uint32
+	signer crypto.Signer
+}
+
+func (n *noteSigner) Name() string    { return n.name }
+func (n *noteSigner) KeyHash() uint32 { return n.hash }
+
+func (n *noteSigner) Sign(msg []byte) ([]byte, error) {
+	return n.signer.Sign(nil, msg, crypto.Hash(0))
+}
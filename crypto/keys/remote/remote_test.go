@@ -0,0 +1,296 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remote
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/trillian/crypto/keys/remote/remotepb"
+	ktestonly "github.com/google/trillian/crypto/keys/testonly"
+	"github.com/google/trillian/crypto/keyspb"
+	"golang.org/x/mod/sumdb/note"
+	"google.golang.org/grpc"
+)
+
+const testKeyID = "log-key"
+
+// fakeClient is a RemoteSignerClient which holds a single key, and waits for
+// delay before signing. If corrupt is set, it returns invalid signatures.
+type fakeClient struct {
+	keyID   string
+	signer  crypto.Signer
+	delay   time.Duration
+	corrupt bool
+}
+
+func (c *fakeClient) GetPublicKey(_ context.Context, in *remotepb.GetPublicKeyRequest, _ ...grpc.CallOption) (*remotepb.GetPublicKeyResponse, error) {
+	if in.KeyId != c.keyID {
+		return nil, fmt.Errorf("key %q not found", in.KeyId)
+	}
+	der, err := x509.MarshalPKIXPublicKey(c.signer.Public())
+	if err != nil {
+		return nil, err
+	}
+	return &remotepb.GetPublicKeyResponse{PublicKeyDer: der}, nil
+}
+
+func (c *fakeClient) Sign(ctx context.Context, in *remotepb.SignRequest, _ ...grpc.CallOption) (*remotepb.SignResponse, error) {
+	if in.KeyId != c.keyID {
+		return nil, fmt.Errorf("key %q not found", in.KeyId)
+	}
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(c.delay):
+	}
+	opts := map[remotepb.Hash]crypto.SignerOpts{
+		remotepb.Hash_NONE:   crypto.Hash(0),
+		remotepb.Hash_SHA256: crypto.SHA256,
+		remotepb.Hash_SHA384: crypto.SHA384,
+		remotepb.Hash_SHA512: crypto.SHA512,
+	}[in.Hash]
+	if in.RsaPss {
+		opts = &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash, Hash: opts.HashFunc()}
+	}
+	sig, err := c.signer.Sign(rand.Reader, in.Digest, opts)
+	if err != nil {
+		return nil, err
+	}
+	if c.corrupt {
+		sig[len(sig)-1] ^= 1
+	}
+	return &remotepb.SignResponse{Signature: sig}, nil
+}
+
+// fakeServer serves the key of a fakeClient.
+type fakeServer struct {
+	c *fakeClient
+}
+
+func (s fakeServer) GetPublicKey(ctx context.Context, in *remotepb.GetPublicKeyRequest) (*remotepb.GetPublicKeyResponse, error) {
+	return s.c.GetPublicKey(ctx, in)
+}
+
+func (s fakeServer) Sign(ctx context.Context, in *remotepb.SignRequest) (*remotepb.SignResponse, error) {
+	return s.c.Sign(ctx, in)
+}
+
+func newFakeClient(t *testing.T, keyType string) *fakeClient {
+	t.Helper()
+	var signer crypto.Signer
+	var err error
+	switch keyType {
+	case "ECDSA":
+		signer, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case "RSA":
+		signer, err = rsa.GenerateKey(rand.Reader, 2048)
+	case "Ed25519":
+		_, signer, err = ed25519.GenerateKey(rand.Reader)
+	}
+	if err != nil {
+		t.Fatalf("GenerateKey(): %v", err)
+	}
+	return &fakeClient{keyID: testKeyID, signer: signer}
+}
+
+func TestSignAndVerify(t *testing.T) {
+	ctx := context.Background()
+	for _, keyType := range []string{"ECDSA", "RSA", "Ed25519"} {
+		t.Run(keyType, func(t *testing.T) {
+			client := newFakeClient(t, keyType)
+			signer, err := NewSigner(ctx, client, testKeyID, 0)
+			if err != nil {
+				t.Fatalf("NewSigner(): %v", err)
+			}
+			if err := ktestonly.SignAndVerify(signer, client.signer.Public()); err != nil {
+				t.Errorf("SignAndVerify(): %v", err)
+			}
+		})
+	}
+}
+
+func TestSignPSS(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeClient(t, "RSA")
+	signer, err := NewSigner(ctx, client, testKeyID, 0)
+	if err != nil {
+		t.Fatalf("NewSigner(): %v", err)
+	}
+	digest := sha256.Sum256([]byte("test"))
+	opts := &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash, Hash: crypto.SHA256}
+	sig, err := signer.Sign(rand.Reader, digest[:], opts)
+	if err != nil {
+		t.Fatalf("Sign(): %v", err)
+	}
+	if err := rsa.VerifyPSS(client.signer.Public().(*rsa.PublicKey), crypto.SHA256, digest[:], sig, opts); err != nil {
+		t.Errorf("VerifyPSS(): %v", err)
+	}
+}
+
+func TestSignErrors(t *testing.T) {
+	ctx := context.Background()
+	digest := sha256.Sum256([]byte("test"))
+	for _, test := range []struct {
+		desc    string
+		keyType string
+		digest  []byte
+		opts    crypto.SignerOpts
+		delay   time.Duration
+		corrupt bool
+	}{
+		{desc: "unsupportedHash", keyType: "ECDSA", digest: digest[:20], opts: crypto.SHA1},
+		{desc: "shortDigest", keyType: "ECDSA", digest: digest[:16], opts: crypto.SHA256},
+		{desc: "pssForECDSA", keyType: "ECDSA", digest: digest[:], opts: &rsa.PSSOptions{Hash: crypto.SHA256}},
+		{desc: "pssSaltLength", keyType: "RSA", digest: digest[:], opts: &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthAuto, Hash: crypto.SHA256}},
+		{desc: "hashForEd25519", keyType: "Ed25519", digest: digest[:], opts: crypto.SHA256},
+		{desc: "timeout", keyType: "ECDSA", digest: digest[:], opts: crypto.SHA256, delay: time.Minute},
+		{desc: "invalidECDSASignature", keyType: "ECDSA", digest: digest[:], opts: crypto.SHA256, corrupt: true},
+		{desc: "invalidRSASignature", keyType: "RSA", digest: digest[:], opts: crypto.SHA256, corrupt: true},
+		{desc: "invalidPSSSignature", keyType: "RSA", digest: digest[:], opts: &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash, Hash: crypto.SHA256}, corrupt: true},
+		{desc: "invalidEd25519Signature", keyType: "Ed25519", digest: digest[:], opts: crypto.Hash(0), corrupt: true},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			client := newFakeClient(t, test.keyType)
+			client.delay = test.delay
+			client.corrupt = test.corrupt
+			signer, err := NewSigner(ctx, client, testKeyID, 10*time.Millisecond)
+			if err != nil {
+				t.Fatalf("NewSigner(): %v", err)
+			}
+			if _, err := signer.Sign(rand.Reader, test.digest, test.opts); err == nil {
+				t.Error("Sign() succeeded, want error")
+			}
+		})
+	}
+}
+
+func TestNewSignerErrors(t *testing.T) {
+	ctx := context.Background()
+	for _, test := range []struct {
+		desc    string
+		keyID   string
+		wantErr string
+	}{
+		{desc: "emptyKeyID", keyID: "", wantErr: "empty key ID"},
+		{desc: "unknownKey", keyID: "other-key", wantErr: "not found"},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			_, err := NewSigner(ctx, newFakeClient(t, "ECDSA"), test.keyID, 0)
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("NewSigner() returned err %v, want error containing %q", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewSignerDefaultTimeout(t *testing.T) {
+	signer, err := NewSigner(context.Background(), newFakeClient(t, "ECDSA"), testKeyID, 0)
+	if err != nil {
+		t.Fatalf("NewSigner(): %v", err)
+	}
+	if got, want := signer.timeout, DefaultSignTimeout; got != want {
+		t.Errorf("timeout = %v, want %v", got, want)
+	}
+}
+
+func TestFromConfig(t *testing.T) {
+	ctx := context.Background()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen(): %v", err)
+	}
+	s := grpc.NewServer()
+	remotepb.RegisterRemoteSignerServer(s, fakeServer{c: newFakeClient(t, "ECDSA")})
+	go s.Serve(lis)
+	defer s.Stop()
+
+	for _, test := range []struct {
+		desc    string
+		config  *keyspb.RemoteSignerConfig
+		wantErr bool
+	}{
+		{desc: "insecure", config: &keyspb.RemoteSignerConfig{Address: lis.Addr().String(), KeyId: testKeyID, Insecure: true}},
+		// TLS is required by default, which the server doesn't speak.
+		{desc: "tls", config: &keyspb.RemoteSignerConfig{Address: lis.Addr().String(), KeyId: testKeyID}, wantErr: true},
+		{desc: "insecureWithCACerts", config: &keyspb.RemoteSignerConfig{Address: lis.Addr().String(), KeyId: testKeyID, Insecure: true, CaCertFile: "ca.pem"}, wantErr: true},
+		{desc: "noAddress", config: &keyspb.RemoteSignerConfig{KeyId: testKeyID, Insecure: true}, wantErr: true},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+			defer cancel()
+			signer, err := FromConfig(ctx, test.config)
+			if gotErr := err != nil; gotErr != test.wantErr {
+				t.Fatalf("FromConfig(): %v, wantErr %v", err, test.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			defer signer.Close()
+			if err := ktestonly.SignAndVerify(signer, signer.Public()); err != nil {
+				t.Errorf("SignAndVerify(): %v", err)
+			}
+		})
+	}
+}
+
+func TestNoteSigner(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeClient(t, "Ed25519")
+	signer, err := NewSigner(ctx, client, testKeyID, 0)
+	if err != nil {
+		t.Fatalf("NewSigner(): %v", err)
+	}
+	ns, err := NewNoteSigner("example.com/log", signer)
+	if err != nil {
+		t.Fatalf("NewNoteSigner(): %v", err)
+	}
+	msg, err := note.Sign(&note.Note{Text: "checkpoint\n"}, ns)
+	if err != nil {
+		t.Fatalf("Sign(): %v", err)
+	}
+
+	vkey, err := note.NewEd25519VerifierKey("example.com/log", client.signer.Public().(ed25519.PublicKey))
+	if err != nil {
+		t.Fatalf("NewEd25519VerifierKey(): %v", err)
+	}
+	verifier, err := note.NewVerifier(vkey)
+	if err != nil {
+		t.Fatalf("NewVerifier(): %v", err)
+	}
+	if _, err := note.Open(msg, note.VerifierList(verifier)); err != nil {
+		t.Errorf("Open(): %v", err)
+	}
+
+	ecdsaSigner, err := NewSigner(ctx, newFakeClient(t, "ECDSA"), testKeyID, 0)
+	if err != nil {
+		t.Fatalf("NewSigner(): %v", err)
+	}
+	if _, err := NewNoteSigner("example.com/log", ecdsaSigner); err == nil {
+		t.Error("NewNoteSigner() with an ECDSA key succeeded, want error")
+	}
+}
@@ -23,6 +23,7 @@ package keyspb
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -412,7 +413,160 @@ func (x *PKCS11Config) GetPublicKey() string {
 	return ""
 }
 
-/// ECDSA defines parameters for an ECDSA key.
+// AWSKMSConfig identifies a private key held by the AWS Key Management
+// Service, which signs with it on behalf of Trillian.
+type AWSKMSConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The key ID, key ARN, alias name (e.g. "alias/trillian") or alias ARN of
+	// the key. The key must be an asymmetric SIGN_VERIFY key.
+	KeyId string `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	// The AWS region of the key. Optional. If not set, the region of the key or
+	// alias ARN is used, or else the region of the AWS SDK configuration.
+	Region string `protobuf:"bytes,2,opt,name=region,proto3" json:"region,omitempty"`
+}
+
+func (x *AWSKMSConfig) Reset() {
+	*x = AWSKMSConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crypto_keyspb_keyspb_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AWSKMSConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AWSKMSConfig) ProtoMessage() {}
+
+func (x *AWSKMSConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_keyspb_keyspb_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AWSKMSConfig.ProtoReflect.Descriptor instead.
+func (*AWSKMSConfig) Descriptor() ([]byte, []int) {
+	return file_crypto_keyspb_keyspb_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AWSKMSConfig) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *AWSKMSConfig) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+// RemoteSignerConfig identifies a private key held by an external signing
+// service, which implements the RemoteSigner gRPC service of
+// crypto/keys/remote/remotepb and signs with it on behalf of Trillian.
+type RemoteSignerConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The address of the signing service, e.g. "signer.example.com:443".
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// The ID of the key, as known to the signing service.
+	KeyId string `protobuf:"bytes,2,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	// File path of the PEM-encoded CA certificates which authenticate the
+	// signing service. Optional. If not set, the system's CA certificates
+	// authenticate it.
+	CaCertFile string `protobuf:"bytes,3,opt,name=ca_cert_file,json=caCertFile,proto3" json:"ca_cert_file,omitempty"`
+	// The maximum time to wait for a signature, e.g. while the signing service
+	// holds a signing ceremony. Optional. If not set, signing waits for one
+	// minute.
+	SignTimeout *durationpb.Duration `protobuf:"bytes,4,opt,name=sign_timeout,json=signTimeout,proto3" json:"sign_timeout,omitempty"`
+	// Connect to the signing service without TLS, e.g. in tests. The service is
+	// then neither authenticated nor encrypted. Can't be set with ca_cert_file.
+	Insecure bool `protobuf:"varint,5,opt,name=insecure,proto3" json:"insecure,omitempty"`
+}
+
+func (x *RemoteSignerConfig) Reset() {
+	*x = RemoteSignerConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crypto_keyspb_keyspb_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoteSignerConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoteSignerConfig) ProtoMessage() {}
+
+func (x *RemoteSignerConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_keyspb_keyspb_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoteSignerConfig.ProtoReflect.Descriptor instead.
+func (*RemoteSignerConfig) Descriptor() ([]byte, []int) {
+	return file_crypto_keyspb_keyspb_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RemoteSignerConfig) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *RemoteSignerConfig) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *RemoteSignerConfig) GetCaCertFile() string {
+	if x != nil {
+		return x.CaCertFile
+	}
+	return ""
+}
+
+func (x *RemoteSignerConfig) GetSignTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.SignTimeout
+	}
+	return nil
+}
+
+func (x *RemoteSignerConfig) GetInsecure() bool {
+	if x != nil {
+		return x.Insecure
+	}
+	return false
+}
+
+// / ECDSA defines parameters for an ECDSA key.
 type Specification_ECDSA struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -426,7 +580,7 @@ type Specification_ECDSA struct {
 func (x *Specification_ECDSA) Reset() {
 	*x = Specification_ECDSA{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_crypto_keyspb_keyspb_proto_msgTypes[5]
+		mi := &file_crypto_keyspb_keyspb_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -439,7 +593,7 @@ func (x *Specification_ECDSA) String() string {
 func (*Specification_ECDSA) ProtoMessage() {}
 
 func (x *Specification_ECDSA) ProtoReflect() protoreflect.Message {
-	mi := &file_crypto_keyspb_keyspb_proto_msgTypes[5]
+	mi := &file_crypto_keyspb_keyspb_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -477,7 +631,7 @@ type Specification_RSA struct {
 func (x *Specification_RSA) Reset() {
 	*x = Specification_RSA{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_crypto_keyspb_keyspb_proto_msgTypes[6]
+		mi := &file_crypto_keyspb_keyspb_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -490,7 +644,7 @@ func (x *Specification_RSA) String() string {
 func (*Specification_RSA) ProtoMessage() {}
 
 func (x *Specification_RSA) ProtoReflect() protoreflect.Message {
-	mi := &file_crypto_keyspb_keyspb_proto_msgTypes[6]
+	mi := &file_crypto_keyspb_keyspb_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -523,7 +677,7 @@ type Specification_Ed25519 struct {
 func (x *Specification_Ed25519) Reset() {
 	*x = Specification_Ed25519{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_crypto_keyspb_keyspb_proto_msgTypes[7]
+		mi := &file_crypto_keyspb_keyspb_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -536,7 +690,7 @@ func (x *Specification_Ed25519) String() string {
 func (*Specification_Ed25519) ProtoMessage() {}
 
 func (x *Specification_Ed25519) ProtoReflect() protoreflect.Message {
-	mi := &file_crypto_keyspb_keyspb_proto_msgTypes[7]
+	mi := &file_crypto_keyspb_keyspb_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -557,7 +711,9 @@ var File_crypto_keyspb_keyspb_proto protoreflect.FileDescriptor
 var file_crypto_keyspb_keyspb_proto_rawDesc = []byte{
 	0x0a, 0x1a, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2f, 0x6b, 0x65, 0x79, 0x73, 0x70, 0x62, 0x2f,
 	0x6b, 0x65, 0x79, 0x73, 0x70, 0x62, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x6b, 0x65,
-	0x79, 0x73, 0x70, 0x62, 0x22, 0x81, 0x03, 0x0a, 0x0d, 0x53, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69,
+	0x79, 0x73, 0x70, 0x62, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x81, 0x03, 0x0a, 0x0d, 0x53, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69,
 	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x40, 0x0a, 0x0c, 0x65, 0x63, 0x64, 0x73, 0x61, 0x5f,
 	0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6b,
 	0x65, 0x79, 0x73, 0x70, 0x62, 0x2e, 0x53, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74,
@@ -595,10 +751,26 @@ var file_crypto_keyspb_keyspb_proto_rawDesc = []byte{
 	0x6e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x69, 0x6e, 0x18, 0x02, 0x20,
 	0x01, 0x28, 0x09, 0x52, 0x03, 0x70, 0x69, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x75, 0x62, 0x6c,
 	0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x75,
-	0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x42, 0x2a, 0x5a, 0x28, 0x67, 0x69, 0x74, 0x68, 0x75,
-	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x74, 0x72, 0x69,
-	0x6c, 0x6c, 0x69, 0x61, 0x6e, 0x2f, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2f, 0x6b, 0x65, 0x79,
-	0x73, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x22, 0x3d, 0x0a, 0x0c, 0x41, 0x57, 0x53, 0x4b, 0x4d,
+	0x53, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x15, 0x0a, 0x06, 0x6b, 0x65, 0x79, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6b, 0x65, 0x79, 0x49, 0x64, 0x12, 0x16,
+	0x0a, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x22, 0xc1, 0x01, 0x0a, 0x12, 0x52, 0x65, 0x6d, 0x6f, 0x74,
+	0x65, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x18, 0x0a,
+	0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x15, 0x0a, 0x06, 0x6b, 0x65, 0x79, 0x5f, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6b, 0x65, 0x79, 0x49, 0x64, 0x12, 0x20,
+	0x0a, 0x0c, 0x63, 0x61, 0x5f, 0x63, 0x65, 0x72, 0x74, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x61, 0x43, 0x65, 0x72, 0x74, 0x46, 0x69, 0x6c, 0x65,
+	0x12, 0x3c, 0x0a, 0x0c, 0x73, 0x69, 0x67, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x0b, 0x73, 0x69, 0x67, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x1a,
+	0x0a, 0x08, 0x69, 0x6e, 0x73, 0x65, 0x63, 0x75, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x08, 0x69, 0x6e, 0x73, 0x65, 0x63, 0x75, 0x72, 0x65, 0x42, 0x2a, 0x5a, 0x28, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x74, 0x72, 0x69, 0x6c, 0x6c, 0x69, 0x61, 0x6e, 0x2f, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2f,
+	0x6b, 0x65, 0x79, 0x73, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -614,7 +786,7 @@ func file_crypto_keyspb_keyspb_proto_rawDescGZIP() []byte {
 }
 
 var file_crypto_keyspb_keyspb_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_crypto_keyspb_keyspb_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_crypto_keyspb_keyspb_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_crypto_keyspb_keyspb_proto_goTypes = []interface{}{
 	(Specification_ECDSA_Curve)(0), // 0: keyspb.Specification.ECDSA.Curve
 	(*Specification)(nil),          // 1: keyspb.Specification
@@ -622,20 +794,24 @@ var file_crypto_keyspb_keyspb_proto_goTypes = []interface{}{
 	(*PrivateKey)(nil),             // 3: keyspb.PrivateKey
 	(*PublicKey)(nil),              // 4: keyspb.PublicKey
 	(*PKCS11Config)(nil),           // 5: keyspb.PKCS11Config
-	(*Specification_ECDSA)(nil),    // 6: keyspb.Specification.ECDSA
-	(*Specification_RSA)(nil),      // 7: keyspb.Specification.RSA
-	(*Specification_Ed25519)(nil),  // 8: keyspb.Specification.Ed25519
+	(*AWSKMSConfig)(nil),           // 6: keyspb.AWSKMSConfig
+	(*RemoteSignerConfig)(nil),     // 7: keyspb.RemoteSignerConfig
+	(*Specification_ECDSA)(nil),    // 8: keyspb.Specification.ECDSA
+	(*Specification_RSA)(nil),      // 9: keyspb.Specification.RSA
+	(*Specification_Ed25519)(nil),  // 10: keyspb.Specification.Ed25519
+	(*durationpb.Duration)(nil),    // 11: google.protobuf.Duration
 }
 var file_crypto_keyspb_keyspb_proto_depIdxs = []int32{
-	6, // 0: keyspb.Specification.ecdsa_params:type_name -> keyspb.Specification.ECDSA
-	7, // 1: keyspb.Specification.rsa_params:type_name -> keyspb.Specification.RSA
-	8, // 2: keyspb.Specification.ed25519_params:type_name -> keyspb.Specification.Ed25519
-	0, // 3: keyspb.Specification.ECDSA.curve:type_name -> keyspb.Specification.ECDSA.Curve
-	4, // [4:4] is the sub-list for method output_type
-	4, // [4:4] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	8,  // 0: keyspb.Specification.ecdsa_params:type_name -> keyspb.Specification.ECDSA
+	9,  // 1: keyspb.Specification.rsa_params:type_name -> keyspb.Specification.RSA
+	10, // 2: keyspb.Specification.ed25519_params:type_name -> keyspb.Specification.Ed25519
+	11, // 3: keyspb.RemoteSignerConfig.sign_timeout:type_name -> google.protobuf.Duration
+	0,  // 4: keyspb.Specification.ECDSA.curve:type_name -> keyspb.Specification.ECDSA.Curve
+	5,  // [5:5] is the sub-list for method output_type
+	5,  // [5:5] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_crypto_keyspb_keyspb_proto_init() }
@@ -705,7 +881,7 @@ func file_crypto_keyspb_keyspb_proto_init() {
 			}
 		}
 		file_crypto_keyspb_keyspb_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Specification_ECDSA); i {
+			switch v := v.(*AWSKMSConfig); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -717,7 +893,7 @@ func file_crypto_keyspb_keyspb_proto_init() {
 			}
 		}
 		file_crypto_keyspb_keyspb_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Specification_RSA); i {
+			switch v := v.(*RemoteSignerConfig); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -729,6 +905,30 @@ func file_crypto_keyspb_keyspb_proto_init() {
 			}
 		}
 		file_crypto_keyspb_keyspb_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Specification_ECDSA); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crypto_keyspb_keyspb_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Specification_RSA); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crypto_keyspb_keyspb_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Specification_Ed25519); i {
 			case 0:
 				return &v.state
@@ -752,7 +952,7 @@ func file_crypto_keyspb_keyspb_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_crypto_keyspb_keyspb_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   8,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
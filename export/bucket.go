@@ -0,0 +1,115 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Bucket is an object store which static logs are written to.
+type Bucket interface {
+	// Read returns the contents of the object at the given path, or an error
+	// wrapping os.ErrNotExist if there's no such object.
+	Read(ctx context.Context, path string) ([]byte, error)
+	// Write creates or replaces the object at the given path. Immutable
+	// objects never change once written, so they can be cached indefinitely.
+	Write(ctx context.Context, path string, data []byte, immutable bool) error
+}
+
+const (
+	// immutableCacheControl is the Cache-Control header of immutable objects.
+	immutableCacheControl = "public, max-age=31536000, immutable"
+	// mutableCacheControl is the Cache-Control header of other objects, i.e.
+	// the checkpoint, which caches may only serve for a short while.
+	mutableCacheControl = "public, max-age=5"
+)
+
+// OpenBucket returns the Bucket with the given URI, which is either a GCS
+// bucket (gs://bucket/prefix), an S3 bucket (s3://bucket/prefix), or a local
+// directory, e.g. one which is served by a web server.
+func OpenBucket(ctx context.Context, uri string) (Bucket, error) {
+	switch {
+	case strings.HasPrefix(uri, "gs://"):
+		name, prefix := splitBucketURI(strings.TrimPrefix(uri, "gs://"))
+		return NewGCSBucket(ctx, name, prefix)
+	case strings.HasPrefix(uri, "s3://"):
+		name, prefix := splitBucketURI(strings.TrimPrefix(uri, "s3://"))
+		return NewS3Bucket(name, prefix)
+	case strings.Contains(uri, "://"):
+		return nil, fmt.Errorf("unsupported bucket URI %q", uri)
+	case uri == "":
+		return nil, errors.New("empty bucket URI")
+	}
+	return DirBucket(uri), nil
+}
+
+// splitBucketURI splits the rest of a bucket URI after its scheme into the
+// name of the bucket and the prefix of the object names.
+func splitBucketURI(rest string) (string, string) {
+	parts := strings.SplitN(rest, "/", 2)
+	if len(parts) == 1 {
+		return parts[0], ""
+	}
+	return parts[0], strings.Trim(parts[1], "/")
+}
+
+// DirBucket is a Bucket which keeps objects as files below a local directory.
+type DirBucket string
+
+// Read implements Bucket.
+func (d DirBucket) Read(ctx context.Context, p string) ([]byte, error) {
+	return os.ReadFile(filepath.Join(string(d), filepath.FromSlash(p)))
+}
+
+// Write implements Bucket. The file is written to a temporary file first, and
+// then renamed, so that readers never see partially written objects.
+func (d DirBucket) Write(ctx context.Context, p string, data []byte, immutable bool) error {
+	name := filepath.Join(string(d), filepath.FromSlash(p))
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(name), "."+path.Base(p)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Chmod(0o644); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), name)
+}
+
+// objectName returns the name of the object at the given path of a bucket
+// whose object names start with prefix.
+func objectName(prefix, p string) string {
+	if prefix == "" {
+		return p
+	}
+	return prefix + "/" + p
+}
@@ -0,0 +1,312 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package export materializes logs as static files in object storage, in the
+// layout of the C2SP tlog-tiles specification, so that they can be read from
+// a CDN, while Trillian remains the write path. The files of a log are:
+//
+//   - checkpoint: the latest checkpoint of the log, as a signed note.
+//   - tile/<L>/<N>[.p/<W>]: the Merkle tree tiles of height 8, as defined by
+//     golang.org/x/mod/sumdb/tlog, without the height in the path.
+//   - tile/entries/<N>[.p/<W>]: the entry bundles, which hold the values of
+//     the leaves covered by the corresponding tiles of level 0, each prefixed
+//     with its length as a big-endian uint16.
+//
+// All files except the checkpoint are immutable. The checkpoint is written
+// last, so readers always find the tiles and bundles of the tree it commits
+// to.
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian"
+	"github.com/google/trillian/types"
+	"golang.org/x/mod/sumdb/tlog"
+)
+
+const (
+	// TileHeight is the height of the exported tiles, and the log2 of the
+	// number of entries per bundle.
+	TileHeight = 8
+	// CheckpointPath is the path of the checkpoint.
+	CheckpointPath = "checkpoint"
+
+	bundleSize = 1 << TileHeight
+)
+
+// Exporter writes the tiles, entry bundles and checkpoints of a log to a
+// Bucket. The log server must return checkpoints with its log roots.
+type Exporter struct {
+	client    trillian.TrillianLogClient
+	logID     int64
+	bucket    Bucket
+	batchSize int64
+
+	// size is the tree size of the exported checkpoint, or -1 if there's
+	// none. It's only valid once loaded, i.e. read from the bucket.
+	size   int64
+	loaded bool
+}
+
+// New returns an Exporter which writes the given log to bucket, requesting at
+// most batchSize leaves with each GetLeavesByRange call. It resumes from the
+// checkpoint in the bucket, if any.
+func New(client trillian.TrillianLogClient, logID int64, bucket Bucket, batchSize int64) *Exporter {
+	return &Exporter{client: client, logID: logID, bucket: bucket, batchSize: batchSize}
+}
+
+// Run exports the log every interval, until ctx is done.
+func (e *Exporter) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if size, err := e.Export(ctx); err != nil {
+			glog.Warningf("%d: failed to export log: %v", e.logID, err)
+		} else {
+			glog.V(1).Infof("%d: exported tree size %d", e.logID, size)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Export writes the new tiles and entry bundles of the latest root of the log,
+// followed by its checkpoint, and returns the exported tree size.
+func (e *Exporter) Export(ctx context.Context) (int64, error) {
+	if !e.loaded {
+		size, err := e.readSize(ctx)
+		if err != nil {
+			return 0, err
+		}
+		e.size, e.loaded = size, true
+	}
+
+	rsp, err := e.client.GetLatestSignedLogRoot(ctx, &trillian.GetLatestSignedLogRootRequest{LogId: e.logID})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get latest root: %v", err)
+	}
+	if len(rsp.Checkpoint) == 0 {
+		return 0, errors.New("log server doesn't return checkpoints")
+	}
+	var root types.LogRootV1
+	if err := root.UnmarshalBinary(rsp.GetSignedLogRoot().GetLogRoot()); err != nil {
+		return 0, fmt.Errorf("failed to parse latest root: %v", err)
+	}
+	if root.TreeSize > math.MaxInt64 {
+		return 0, fmt.Errorf("tree size %d is too large", root.TreeSize)
+	}
+	size := int64(root.TreeSize)
+	switch {
+	case size < e.size:
+		return 0, fmt.Errorf("latest root has tree size %d, smaller than the exported size %d", size, e.size)
+	case size == e.size:
+		return size, nil
+	}
+
+	// The bundles are written along with the corresponding tiles of level 0,
+	// whose hashes they're checked against.
+	from := e.size
+	if from < 0 {
+		from = 0
+	}
+	for _, tile := range newTiles(from, size) {
+		if tile.L == 0 {
+			if err := e.exportBundle(ctx, tile); err != nil {
+				return 0, err
+			}
+			continue
+		}
+		data, err := e.getTile(ctx, tile)
+		if err != nil {
+			return 0, err
+		}
+		if err := e.bucket.Write(ctx, tilePath(tile), data, true); err != nil {
+			return 0, fmt.Errorf("failed to write tile %s: %v", tilePath(tile), err)
+		}
+	}
+
+	// Check that the written tiles hash to the root before publishing it.
+	hr := tlog.TileHashReader(tlog.Tree{N: size, Hash: toHash(root.RootHash)}, &tileReader{ctx: ctx, bucket: e.bucket})
+	if _, err := tlog.TreeHash(size, hr); err != nil {
+		return 0, fmt.Errorf("failed to verify tiles of tree size %d: %v", size, err)
+	}
+	if err := e.bucket.Write(ctx, CheckpointPath, rsp.Checkpoint, false); err != nil {
+		return 0, fmt.Errorf("failed to write checkpoint: %v", err)
+	}
+	e.size = size
+	return size, nil
+}
+
+// readSize returns the tree size of the checkpoint in the bucket, or -1 if
+// there's none yet.
+func (e *Exporter) readSize(ctx context.Context) (int64, error) {
+	data, err := e.bucket.Read(ctx, CheckpointPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return -1, nil
+	} else if err != nil {
+		return 0, fmt.Errorf("failed to read checkpoint: %v", err)
+	}
+	// The signatures follow the checkpoint, and are ignored.
+	var cp types.Checkpoint
+	if err := cp.UnmarshalText(data); err != nil {
+		return 0, fmt.Errorf("failed to parse checkpoint: %v", err)
+	}
+	if cp.Size > math.MaxInt64 {
+		return 0, fmt.Errorf("checkpoint size %d is too large", cp.Size)
+	}
+	return int64(cp.Size), nil
+}
+
+// exportBundle writes the given tile of level 0, and the corresponding entry
+// bundle.
+func (e *Exporter) exportBundle(ctx context.Context, tile tlog.Tile) error {
+	start := tile.N * bundleSize
+	end := start + int64(tile.W)
+	hashes, err := e.getTile(ctx, tile)
+	if err != nil {
+		return err
+	}
+
+	var bundle []byte
+	for next := start; next < end; {
+		count := end - next
+		if count > e.batchSize {
+			count = e.batchSize
+		}
+		rsp, err := e.client.GetLeavesByRange(ctx, &trillian.GetLeavesByRangeRequest{LogId: e.logID, StartIndex: next, Count: count})
+		if err != nil {
+			return fmt.Errorf("failed to get leaves from index %d: %v", next, err)
+		}
+		if len(rsp.Leaves) == 0 {
+			return fmt.Errorf("log returned no leaves from index %d", next)
+		}
+		for _, leaf := range rsp.Leaves {
+			if leaf.LeafIndex != next || next >= end {
+				return fmt.Errorf("log returned leaf %d, want %d", leaf.LeafIndex, next)
+			}
+			if len(leaf.LeafValue) > math.MaxUint16 {
+				return fmt.Errorf("leaf %d has %d bytes, more than entry bundles can hold", next, len(leaf.LeafValue))
+			}
+			hash := tlog.RecordHash(leaf.LeafValue)
+			if want := hashes[(next-start)*tlog.HashSize:][:tlog.HashSize]; string(hash[:]) != string(want) {
+				return fmt.Errorf("leaf %d has hash %x, but its tile has %x", next, hash, want)
+			}
+			l := len(leaf.LeafValue)
+			bundle = append(bundle, byte(l>>8), byte(l))
+			bundle = append(bundle, leaf.LeafValue...)
+			next++
+		}
+	}
+
+	if err := e.bucket.Write(ctx, bundlePath(tile), bundle, true); err != nil {
+		return fmt.Errorf("failed to write bundle %s: %v", bundlePath(tile), err)
+	}
+	if err := e.bucket.Write(ctx, tilePath(tile), hashes, true); err != nil {
+		return fmt.Errorf("failed to write tile %s: %v", tilePath(tile), err)
+	}
+	return nil
+}
+
+// getTile returns the data of the given tile from the log server.
+func (e *Exporter) getTile(ctx context.Context, tile tlog.Tile) ([]byte, error) {
+	rsp, err := e.client.GetTile(ctx, &trillian.GetTileRequest{
+		LogId:  e.logID,
+		Height: int32(tile.H),
+		Level:  int32(tile.L),
+		Index:  tile.N,
+		Width:  int32(tile.W),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tile %s: %v", tilePath(tile), err)
+	}
+	if got, want := len(rsp.Data), tile.W*tlog.HashSize; got != want {
+		return nil, fmt.Errorf("log returned %d bytes for tile %s, want %d", got, tilePath(tile), want)
+	}
+	return rsp.Data, nil
+}
+
+// newTiles returns the tiles of the tree of size newSize which aren't tiles of
+// the tree of size oldSize. Unlike tlog.NewTiles, it leaves out the partial
+// tiles of the tree sizes in between.
+func newTiles(oldSize, newSize int64) []tlog.Tile {
+	var tiles []tlog.Tile
+	for level := 0; newSize>>(TileHeight*level) > 0; level++ {
+		oldN := oldSize >> (TileHeight * level)
+		newN := newSize >> (TileHeight * level)
+		if oldN == newN {
+			continue
+		}
+		for n := oldN >> TileHeight; n < newN>>TileHeight; n++ {
+			tiles = append(tiles, tlog.Tile{H: TileHeight, L: level, N: n, W: bundleSize})
+		}
+		n := newN >> TileHeight
+		if w := int(newN - n<<TileHeight); w > 0 {
+			tiles = append(tiles, tlog.Tile{H: TileHeight, L: level, N: n, W: w})
+		}
+	}
+	return tiles
+}
+
+// tilePath returns the path of the given tile, which is its tlog path without
+// the height.
+func tilePath(tile tlog.Tile) string {
+	return "tile/" + strings.TrimPrefix(tile.Path(), fmt.Sprintf("tile/%d/", TileHeight))
+}
+
+// bundlePath returns the path of the entry bundle of the given tile of level
+// 0.
+func bundlePath(tile tlog.Tile) string {
+	return strings.Replace(tilePath(tile), "tile/0/", "tile/entries/", 1)
+}
+
+func toHash(b []byte) tlog.Hash {
+	var h tlog.Hash
+	copy(h[:], b)
+	return h
+}
+
+// tileReader is a tlog.TileReader which reads tiles from a bucket.
+type tileReader struct {
+	ctx    context.Context
+	bucket Bucket
+}
+
+func (r *tileReader) Height() int {
+	return TileHeight
+}
+
+func (r *tileReader) ReadTiles(tiles []tlog.Tile) ([][]byte, error) {
+	data := make([][]byte, 0, len(tiles))
+	for _, tile := range tiles {
+		d, err := r.bucket.Read(r.ctx, tilePath(tile))
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, d)
+	}
+	return data, nil
+}
+
+func (r *tileReader) SaveTiles(tiles []tlog.Tile, data [][]byte) {}
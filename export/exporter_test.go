@@ -0,0 +1,251 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/types"
+	"golang.org/x/mod/sumdb/note"
+	"golang.org/x/mod/sumdb/tlog"
+	"google.golang.org/grpc"
+)
+
+// fakeLog serves the leaves, tiles and checkpoints of a tree built with tlog,
+// returning at most maxLeaves leaves per request.
+type fakeLog struct {
+	trillian.TrillianLogClient
+	signer    note.Signer
+	data      [][]byte
+	hashes    []tlog.Hash
+	maxLeaves int64
+	corrupt   int64 // Index of a leaf to return a wrong value for, if positive.
+}
+
+func (f *fakeLog) ReadHashes(indexes []int64) ([]tlog.Hash, error) {
+	r := make([]tlog.Hash, 0, len(indexes))
+	for _, index := range indexes {
+		r = append(r, f.hashes[index])
+	}
+	return r, nil
+}
+
+func (f *fakeLog) append(t *testing.T, count int) {
+	t.Helper()
+	for i := 0; i < count; i++ {
+		d := []byte(fmt.Sprintf("leaf %d", len(f.data)))
+		stored, err := tlog.StoredHashes(int64(len(f.data)), d, f)
+		if err != nil {
+			t.Fatalf("StoredHashes(): %v", err)
+		}
+		f.data = append(f.data, d)
+		f.hashes = append(f.hashes, stored...)
+	}
+}
+
+func (f *fakeLog) GetLatestSignedLogRoot(ctx context.Context, in *trillian.GetLatestSignedLogRootRequest, opts ...grpc.CallOption) (*trillian.GetLatestSignedLogRootResponse, error) {
+	size := int64(len(f.data))
+	hash, err := tlog.TreeHash(size, f)
+	if err != nil {
+		return nil, err
+	}
+	root, err := (&types.LogRootV1{TreeSize: uint64(size), RootHash: hash[:]}).MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	text, err := types.Checkpoint{Origin: "test", Size: uint64(size), Hash: hash[:]}.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	cp, err := note.Sign(&note.Note{Text: string(text)}, f.signer)
+	if err != nil {
+		return nil, err
+	}
+	return &trillian.GetLatestSignedLogRootResponse{SignedLogRoot: &trillian.SignedLogRoot{LogRoot: root}, Checkpoint: cp}, nil
+}
+
+func (f *fakeLog) GetLeavesByRange(ctx context.Context, in *trillian.GetLeavesByRangeRequest, opts ...grpc.CallOption) (*trillian.GetLeavesByRangeResponse, error) {
+	rsp := &trillian.GetLeavesByRangeResponse{}
+	for i := in.StartIndex; i < in.StartIndex+in.Count && i < in.StartIndex+f.maxLeaves && i < int64(len(f.data)); i++ {
+		value := f.data[i]
+		if f.corrupt > 0 && i == f.corrupt {
+			value = []byte("corrupt")
+		}
+		rsp.Leaves = append(rsp.Leaves, &trillian.LogLeaf{LeafIndex: i, LeafValue: value})
+	}
+	return rsp, nil
+}
+
+func (f *fakeLog) GetTile(ctx context.Context, in *trillian.GetTileRequest, opts ...grpc.CallOption) (*trillian.GetTileResponse, error) {
+	data, err := tlog.ReadTileData(tlog.Tile{H: int(in.Height), L: int(in.Level), N: in.Index, W: int(in.Width)}, f)
+	if err != nil {
+		return nil, err
+	}
+	return &trillian.GetTileResponse{Data: data}, nil
+}
+
+// checkBucket checks that the bucket holds the checkpoint, tiles and entry
+// bundles of the given log.
+func checkBucket(ctx context.Context, t *testing.T, bucket Bucket, log *fakeLog) {
+	t.Helper()
+	size := int64(len(log.data))
+	cp, err := bucket.Read(ctx, CheckpointPath)
+	if err != nil {
+		t.Fatalf("Read(checkpoint): %v", err)
+	}
+	hash, err := tlog.TreeHash(size, log)
+	if err != nil {
+		t.Fatalf("TreeHash(): %v", err)
+	}
+	hr := tlog.TileHashReader(tlog.Tree{N: size, Hash: hash}, &tileReader{ctx: ctx, bucket: bucket})
+	if _, err := tlog.TreeHash(size, hr); err != nil {
+		t.Errorf("TreeHash() from bucket: %v", err)
+	}
+	if !bytes.Contains(cp, []byte(fmt.Sprintf("\n%d\n", size))) {
+		t.Errorf("checkpoint %q doesn't have size %d", cp, size)
+	}
+
+	var got [][]byte
+	for _, tile := range newTiles(0, size) {
+		if tile.L != 0 {
+			continue
+		}
+		bundle, err := bucket.Read(ctx, bundlePath(tile))
+		if err != nil {
+			t.Fatalf("Read(%s): %v", bundlePath(tile), err)
+		}
+		for len(bundle) > 0 {
+			l := int(bundle[0])<<8 | int(bundle[1])
+			got = append(got, bundle[2:2+l])
+			bundle = bundle[2+l:]
+		}
+	}
+	if len(got) != len(log.data) {
+		t.Fatalf("got %d entries in bundles, want %d", len(got), len(log.data))
+	}
+	for i := range got {
+		if !bytes.Equal(got[i], log.data[i]) {
+			t.Errorf("entry %d: got %q, want %q", i, got[i], log.data[i])
+		}
+	}
+}
+
+func TestExport(t *testing.T) {
+	ctx := context.Background()
+	skey, _, err := note.GenerateKey(rand.Reader, "test")
+	if err != nil {
+		t.Fatalf("GenerateKey(): %v", err)
+	}
+	signer, err := note.NewSigner(skey)
+	if err != nil {
+		t.Fatalf("NewSigner(): %v", err)
+	}
+	log := &fakeLog{signer: signer, maxLeaves: 100}
+	bucket := DirBucket(t.TempDir())
+
+	for _, test := range []struct {
+		desc  string
+		count int
+	}{
+		{desc: "empty", count: 0},
+		{desc: "partial", count: 10},
+		{desc: "bundles", count: 300},
+		{desc: "second-level", count: 256 * 256},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			log.append(t, test.count)
+			// Each pass starts with a new Exporter, which resumes from the
+			// checkpoint in the bucket.
+			e := New(log, 1, bucket, 50)
+			size, err := e.Export(ctx)
+			if err != nil {
+				t.Fatalf("Export(): %v", err)
+			}
+			if got, want := size, int64(len(log.data)); got != want {
+				t.Errorf("Export()=%d, want %d", got, want)
+			}
+			checkBucket(ctx, t, bucket, log)
+		})
+	}
+}
+
+func TestExportCorruptLeaf(t *testing.T) {
+	ctx := context.Background()
+	skey, _, err := note.GenerateKey(rand.Reader, "test")
+	if err != nil {
+		t.Fatalf("GenerateKey(): %v", err)
+	}
+	signer, err := note.NewSigner(skey)
+	if err != nil {
+		t.Fatalf("NewSigner(): %v", err)
+	}
+	log := &fakeLog{signer: signer, maxLeaves: 100, corrupt: 7}
+	log.append(t, 20)
+	bucket := DirBucket(t.TempDir())
+
+	if _, err := New(log, 1, bucket, 50).Export(ctx); err == nil {
+		t.Fatal("Export() with a corrupt leaf succeeded")
+	}
+	if _, err := bucket.Read(ctx, CheckpointPath); err == nil {
+		t.Error("Export() with a corrupt leaf wrote a checkpoint")
+	}
+}
+
+func TestExportWithoutCheckpoints(t *testing.T) {
+	e := New(noCheckpoints{}, 1, DirBucket(t.TempDir()), 50)
+	if _, err := e.Export(context.Background()); err == nil {
+		t.Error("Export() without checkpoints succeeded")
+	}
+}
+
+// noCheckpoints is a log client whose roots have no checkpoints.
+type noCheckpoints struct {
+	trillian.TrillianLogClient
+}
+
+func (n noCheckpoints) GetLatestSignedLogRoot(ctx context.Context, in *trillian.GetLatestSignedLogRootRequest, opts ...grpc.CallOption) (*trillian.GetLatestSignedLogRootResponse, error) {
+	root, err := (&types.LogRootV1{}).MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return &trillian.GetLatestSignedLogRootResponse{SignedLogRoot: &trillian.SignedLogRoot{LogRoot: root}}, nil
+}
+
+func TestTilePath(t *testing.T) {
+	for _, test := range []struct {
+		tile       tlog.Tile
+		want       string
+		wantBundle string
+	}{
+		{tile: tlog.Tile{H: 8, L: 0, N: 0, W: 256}, want: "tile/0/000", wantBundle: "tile/entries/000"},
+		{tile: tlog.Tile{H: 8, L: 0, N: 1234067, W: 5}, want: "tile/0/x001/x234/067.p/5", wantBundle: "tile/entries/x001/x234/067.p/5"},
+		{tile: tlog.Tile{H: 8, L: 2, N: 3, W: 256}, want: "tile/2/003"},
+	} {
+		if got := tilePath(test.tile); got != test.want {
+			t.Errorf("tilePath(%v)=%q, want %q", test.tile, got, test.want)
+		}
+		if test.wantBundle == "" {
+			continue
+		}
+		if got := bundlePath(test.tile); got != test.wantBundle {
+			t.Errorf("bundlePath(%v)=%q, want %q", test.tile, got, test.wantBundle)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"cloud.google.com/go/storage"
+)
+
+// gcsBucket is a Bucket which keeps objects in Google Cloud Storage.
+type gcsBucket struct {
+	bucket *storage.BucketHandle
+	prefix string
+}
+
+// NewGCSBucket returns a Bucket which keeps objects in the given GCS bucket,
+// with names starting with prefix. The credentials are taken from the
+// environment, as usual for Google Cloud client libraries.
+func NewGCSBucket(ctx context.Context, name, prefix string) (Bucket, error) {
+	client, err := storage.NewClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCS client: %v", err)
+	}
+	return &gcsBucket{bucket: client.Bucket(name), prefix: prefix}, nil
+}
+
+// Read implements Bucket.
+func (b *gcsBucket) Read(ctx context.Context, p string) ([]byte, error) {
+	r, err := b.bucket.Object(objectName(b.prefix, p)).NewReader(ctx)
+	if err == storage.ErrObjectNotExist {
+		return nil, fmt.Errorf("%s: %w", p, os.ErrNotExist)
+	} else if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+// Write implements Bucket.
+func (b *gcsBucket) Write(ctx context.Context, p string, data []byte, immutable bool) error {
+	w := b.bucket.Object(objectName(b.prefix, p)).NewWriter(ctx)
+	w.ContentType = "application/octet-stream"
+	w.CacheControl = mutableCacheControl
+	if immutable {
+		w.CacheControl = immutableCacheControl
+	}
+	if _, err := w.Write(data); err != nil {
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
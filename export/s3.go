@@ -0,0 +1,78 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// s3Bucket is a Bucket which keeps objects in Amazon S3.
+type s3Bucket struct {
+	client *s3.S3
+	name   string
+	prefix string
+}
+
+// NewS3Bucket returns a Bucket which keeps objects in the given S3 bucket,
+// with names starting with prefix. The credentials and region are taken from
+// the environment, shared configuration files or the instance role, as usual
+// for the AWS SDK.
+func NewS3Bucket(name, prefix string) (Bucket, error) {
+	sess, err := session.NewSessionWithOptions(session.Options{SharedConfigState: session.SharedConfigEnable})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AWS session: %v", err)
+	}
+	return &s3Bucket{client: s3.New(sess), name: name, prefix: prefix}, nil
+}
+
+// Read implements Bucket.
+func (b *s3Bucket) Read(ctx context.Context, p string) ([]byte, error) {
+	out, err := b.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(b.name),
+		Key:    aws.String(objectName(b.prefix, p)),
+	})
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == s3.ErrCodeNoSuchKey {
+		return nil, fmt.Errorf("%s: %w", p, os.ErrNotExist)
+	} else if err != nil {
+		return nil, err
+	}
+	defer out.Body.Close()
+	return io.ReadAll(out.Body)
+}
+
+// Write implements Bucket.
+func (b *s3Bucket) Write(ctx context.Context, p string, data []byte, immutable bool) error {
+	cacheControl := mutableCacheControl
+	if immutable {
+		cacheControl = immutableCacheControl
+	}
+	_, err := b.client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket:       aws.String(b.name),
+		Key:          aws.String(objectName(b.prefix, p)),
+		Body:         bytes.NewReader(data),
+		ContentType:  aws.String("application/octet-stream"),
+		CacheControl: aws.String(cacheControl),
+	})
+	return err
+}
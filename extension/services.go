@@ -0,0 +1,89 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package extension
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"google.golang.org/grpc"
+)
+
+var (
+	svcMu     sync.RWMutex
+	svcByName map[string]ServiceFunc
+)
+
+// ServiceFunc is the signature of a function which can be registered to add
+// a gRPC service to the server of a Trillian binary, e.g. the endpoints of a
+// personality which should share the port and process of the log server. It
+// is given the Registry of the binary, so the service can use its storage.
+type ServiceFunc func(s *grpc.Server, registry Registry) error
+
+// RegisterService registers a function that adds a gRPC service to a server.
+// It is usually called from an init function of a package which is linked
+// into the server binary, e.g. with a blank import in a file of the binary
+// guarded by a build tag. Registered services are only served if the binary
+// is asked to, see AddServices.
+func RegisterService(name string, fn ServiceFunc) error {
+	svcMu.Lock()
+	defer svcMu.Unlock()
+
+	if svcByName == nil {
+		svcByName = make(map[string]ServiceFunc)
+	}
+
+	if _, exists := svcByName[name]; exists {
+		return fmt.Errorf("service %v already registered", name)
+	}
+	svcByName[name] = fn
+	return nil
+}
+
+// Services returns the sorted names of the registered services.
+func Services() []string {
+	svcMu.RLock()
+	defer svcMu.RUnlock()
+
+	r := []string{}
+	for k := range svcByName {
+		r = append(r, k)
+	}
+	sort.Strings(r)
+	return r
+}
+
+// AddServices adds the registered services with the given names to s.
+func AddServices(s *grpc.Server, registry Registry, names []string) error {
+	fns := make([]ServiceFunc, 0, len(names))
+	svcMu.RLock()
+	for _, name := range names {
+		fn, exists := svcByName[name]
+		if !exists {
+			svcMu.RUnlock()
+			return fmt.Errorf("unknown service: %v, registered: %v", name, Services())
+		}
+		fns = append(fns, fn)
+	}
+	svcMu.RUnlock()
+
+	for i, fn := range fns {
+		if err := fn(s, registry); err != nil {
+			return fmt.Errorf("failed to add service %v: %v", names[i], err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package extension
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+)
+
+func TestAddServices(t *testing.T) {
+	var added []string
+	register := func(name string, err error) {
+		t.Helper()
+		if err := RegisterService(name, func(*grpc.Server, Registry) error {
+			added = append(added, name)
+			return err
+		}); err != nil {
+			t.Fatalf("RegisterService(%s)=%v", name, err)
+		}
+	}
+	register("a", nil)
+	register("b", nil)
+	register("broken", errors.New("broken"))
+
+	if err := RegisterService("a", nil); err == nil {
+		t.Error("RegisterService(a) twice: no error")
+	}
+
+	for _, test := range []struct {
+		desc      string
+		names     []string
+		wantAdded []string
+		wantErr   bool
+	}{
+		{desc: "none"},
+		{desc: "some", names: []string{"b", "a"}, wantAdded: []string{"b", "a"}},
+		{desc: "unknown", names: []string{"a", "c"}, wantErr: true},
+		{desc: "failed", names: []string{"broken"}, wantAdded: []string{"broken"}, wantErr: true},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			added = nil
+			err := AddServices(grpc.NewServer(), Registry{}, test.names)
+			if gotErr := err != nil; gotErr != test.wantErr {
+				t.Fatalf("AddServices()=%v, wantErr %v", err, test.wantErr)
+			}
+			if got, want := len(added), len(test.wantAdded); got != want {
+				t.Fatalf("AddServices() added %v, want %v", added, test.wantAdded)
+			}
+			for i := range added {
+				if added[i] != test.wantAdded[i] {
+					t.Errorf("AddServices() added %v, want %v", added, test.wantAdded)
+				}
+			}
+		})
+	}
+
+	if got, want := strings.Join(Services(), ","), "a,b,broken"; got != want {
+		t.Errorf("Services()=%v, want %v", got, want)
+	}
+}
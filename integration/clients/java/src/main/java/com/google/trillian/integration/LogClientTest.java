@@ -0,0 +1,246 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package com.google.trillian.integration;
+
+import com.google.protobuf.ByteString;
+import com.google.protobuf.Duration;
+import com.google.trillian.proto.CreateTreeRequest;
+import com.google.trillian.proto.DeleteTreeRequest;
+import com.google.trillian.proto.GetInclusionProofByHashRequest;
+import com.google.trillian.proto.GetInclusionProofByHashResponse;
+import com.google.trillian.proto.GetLatestSignedLogRootRequest;
+import com.google.trillian.proto.GetLeavesByRangeRequest;
+import com.google.trillian.proto.GetLeavesByRangeResponse;
+import com.google.trillian.proto.GetTreeRequest;
+import com.google.trillian.proto.InitLogRequest;
+import com.google.trillian.proto.LogLeaf;
+import com.google.trillian.proto.QueueLeafRequest;
+import com.google.trillian.proto.QueueLeafResponse;
+import com.google.trillian.proto.Tree;
+import com.google.trillian.proto.TreeState;
+import com.google.trillian.proto.TreeType;
+import com.google.trillian.proto.TrillianAdminGrpc;
+import com.google.trillian.proto.TrillianLogGrpc;
+import io.grpc.ManagedChannel;
+import io.grpc.ManagedChannelBuilder;
+import java.nio.ByteBuffer;
+import java.nio.charset.StandardCharsets;
+import java.security.MessageDigest;
+import java.security.NoSuchAlgorithmException;
+import java.util.concurrent.TimeUnit;
+
+/**
+ * Exercises the Trillian gRPC API through Java stubs generated from its protos.
+ *
+ * <p>The test creates a log, queues a leaf, waits for it to be integrated and reads it back, so
+ * that changes to the protos which break Java consumers show up before a release. It must be kept
+ * in step with the Python client in ../python.
+ *
+ * <p>Usage: {@code LogClientTest --server=host:port [--rpc_deadline=10] [--integration_timeout=60]},
+ * with the durations in seconds. Exits with a non-zero status if a check fails.
+ */
+public final class LogClientTest {
+  private final TrillianAdminGrpc.TrillianAdminBlockingStub admin;
+  private final TrillianLogGrpc.TrillianLogBlockingStub log;
+  private final long rpcDeadline;
+  private final long integrationTimeout;
+
+  private LogClientTest(ManagedChannel channel, long rpcDeadline, long integrationTimeout) {
+    this.admin = TrillianAdminGrpc.newBlockingStub(channel);
+    this.log = TrillianLogGrpc.newBlockingStub(channel);
+    this.rpcDeadline = rpcDeadline;
+    this.integrationTimeout = integrationTimeout;
+  }
+
+  public static void main(String[] args) throws Exception {
+    String server = null;
+    long rpcDeadline = 10;
+    long integrationTimeout = 60;
+    for (String arg : args) {
+      String[] kv = arg.split("=", 2);
+      if (kv.length != 2) {
+        usage("malformed argument " + arg);
+      }
+      switch (kv[0]) {
+        case "--server":
+          server = kv[1];
+          break;
+        case "--rpc_deadline":
+          rpcDeadline = Long.parseLong(kv[1]);
+          break;
+        case "--integration_timeout":
+          integrationTimeout = Long.parseLong(kv[1]);
+          break;
+        default:
+          usage("unknown flag " + kv[0]);
+      }
+    }
+    if (server == null) {
+      usage("--server must be set");
+    }
+
+    ManagedChannel channel = ManagedChannelBuilder.forTarget(server).usePlaintext().build();
+    try {
+      new LogClientTest(channel, rpcDeadline, integrationTimeout).run();
+    } finally {
+      channel.shutdownNow().awaitTermination(rpcDeadline, TimeUnit.SECONDS);
+    }
+    System.out.println("PASS");
+  }
+
+  private static void usage(String problem) {
+    System.err.println(problem);
+    System.err.println(
+        "Usage: LogClientTest --server=host:port [--rpc_deadline=secs] [--integration_timeout=secs]");
+    System.exit(2);
+  }
+
+  private TrillianAdminGrpc.TrillianAdminBlockingStub admin() {
+    return admin.withDeadlineAfter(rpcDeadline, TimeUnit.SECONDS);
+  }
+
+  private TrillianLogGrpc.TrillianLogBlockingStub log() {
+    return log.withDeadlineAfter(rpcDeadline, TimeUnit.SECONDS);
+  }
+
+  private void run() throws Exception {
+    Tree tree =
+        admin()
+            .createTree(
+                CreateTreeRequest.newBuilder()
+                    .setTree(
+                        Tree.newBuilder()
+                            .setTreeType(TreeType.LOG)
+                            .setTreeState(TreeState.ACTIVE)
+                            .setDisplayName("java client test")
+                            .setMaxRootDuration(Duration.newBuilder().setSeconds(3600)))
+                    .build());
+    long logId = tree.getTreeId();
+    try {
+      log().initLog(InitLogRequest.newBuilder().setLogId(logId).build());
+      checkTree(logId);
+      checkQueueAndReadLeaf(logId);
+    } finally {
+      admin().deleteTree(DeleteTreeRequest.newBuilder().setTreeId(logId).build());
+    }
+  }
+
+  private void checkTree(long logId) {
+    Tree tree = admin().getTree(GetTreeRequest.newBuilder().setTreeId(logId).build());
+    check("tree type", tree.getTreeType(), TreeType.LOG);
+    check("tree state", tree.getTreeState(), TreeState.ACTIVE);
+    check("display name", tree.getDisplayName(), "java client test");
+  }
+
+  private void checkQueueAndReadLeaf(long logId) throws Exception {
+    byte[] value = "leaf from the java client".getBytes(StandardCharsets.UTF_8);
+    ByteString merkleHash = ByteString.copyFrom(sha256(new byte[] {0}, value));
+    QueueLeafResponse queued =
+        log()
+            .queueLeaf(
+                QueueLeafRequest.newBuilder()
+                    .setLogId(logId)
+                    .setLeaf(
+                        LogLeaf.newBuilder()
+                            .setLeafValue(ByteString.copyFrom(value))
+                            .setLeafIdentityHash(ByteString.copyFrom(sha256(value))))
+                    .build());
+    check("queued leaf status", queued.getQueuedLeaf().getStatus().getCode(), 0);
+    long size = waitForSize(logId, 1);
+
+    GetLeavesByRangeResponse leaves =
+        log()
+            .getLeavesByRange(
+                GetLeavesByRangeRequest.newBuilder()
+                    .setLogId(logId)
+                    .setStartIndex(0)
+                    .setCount(1)
+                    .build());
+    check("number of leaves", leaves.getLeavesCount(), 1);
+    check("leaf value", leaves.getLeaves(0).getLeafValue(), ByteString.copyFrom(value));
+    check("merkle leaf hash", leaves.getLeaves(0).getMerkleLeafHash(), merkleHash);
+
+    GetLeavesByRangeResponse hashes =
+        log()
+            .getLeavesByRange(
+                GetLeavesByRangeRequest.newBuilder()
+                    .setLogId(logId)
+                    .setStartIndex(0)
+                    .setCount(1)
+                    .setOmitLeafData(true)
+                    .build());
+    check("omitted leaf value", hashes.getLeaves(0).getLeafValue(), ByteString.EMPTY);
+    check("omitted merkle leaf hash", hashes.getLeaves(0).getMerkleLeafHash(), merkleHash);
+
+    GetInclusionProofByHashResponse proof =
+        log()
+            .getInclusionProofByHash(
+                GetInclusionProofByHashRequest.newBuilder()
+                    .setLogId(logId)
+                    .setLeafHash(merkleHash)
+                    .setTreeSize(size)
+                    .build());
+    check("number of proofs", proof.getProofCount(), 1);
+    check("proof leaf index", proof.getProof(0).getLeafIndex(), 0L);
+  }
+
+  /** Polls the latest root of the log until it has at least size leaves, and returns its size. */
+  private long waitForSize(long logId, long size) throws InterruptedException {
+    long deadline = System.nanoTime() + TimeUnit.SECONDS.toNanos(integrationTimeout);
+    while (true) {
+      ByteString logRoot =
+          log()
+              .getLatestSignedLogRoot(
+                  GetLatestSignedLogRootRequest.newBuilder().setLogId(logId).build())
+              .getSignedLogRoot()
+              .getLogRoot();
+      long got = treeSize(logRoot);
+      if (got >= size) {
+        return got;
+      }
+      if (System.nanoTime() > deadline) {
+        throw new AssertionError(
+            String.format(
+                "log %d has size %d after %ds, want %d", logId, got, integrationTimeout, size));
+      }
+      Thread.sleep(1000);
+    }
+  }
+
+  /** Returns the tree size of a TLS-encoded LogRootV1. */
+  private static long treeSize(ByteString logRoot) {
+    ByteBuffer buf = logRoot.asReadOnlyByteBuffer();
+    int version = buf.getShort() & 0xffff;
+    if (version != 1) {
+      throw new AssertionError("unknown log root version " + version);
+    }
+    return buf.getLong();
+  }
+
+  private static byte[] sha256(byte[]... parts) throws NoSuchAlgorithmException {
+    MessageDigest md = MessageDigest.getInstance("SHA-256");
+    for (byte[] part : parts) {
+      md.update(part);
+    }
+    return md.digest();
+  }
+
+  private static void check(String what, Object got, Object want) {
+    if (!got.equals(want)) {
+      throw new AssertionError(String.format("%s: got %s, want %s", what, got, want));
+    }
+    System.out.printf("ok: %s%n", what);
+  }
+}
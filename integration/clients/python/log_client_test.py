@@ -0,0 +1,143 @@
+# Copyright 2022 Google LLC. All Rights Reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+"""Exercises the Trillian gRPC API through Python stubs generated from its protos.
+
+The test creates a log, queues a leaf, waits for it to be integrated and reads
+it back, so that changes to the protos which break Python consumers show up
+before a release. It must be kept in step with the Java client in
+../java.
+"""
+
+import argparse
+import hashlib
+import struct
+import sys
+import time
+import unittest
+
+import grpc
+from google.protobuf import duration_pb2
+
+import trillian_admin_api_pb2
+import trillian_admin_api_pb2_grpc
+import trillian_log_api_pb2
+import trillian_log_api_pb2_grpc
+import trillian_pb2
+
+FLAGS = None
+
+
+def parse_log_root(data):
+  """Returns the tree size and root hash of a TLS-encoded LogRootV1."""
+  version, size, hash_len = struct.unpack_from(">HQB", data)
+  if version != 1:
+    raise ValueError("unknown log root version %d" % version)
+  return size, data[11:11 + hash_len]
+
+
+class LogClientTest(unittest.TestCase):
+
+  def setUp(self):
+    self.channel = grpc.insecure_channel(FLAGS.server)
+    self.admin = trillian_admin_api_pb2_grpc.TrillianAdminStub(self.channel)
+    self.log = trillian_log_api_pb2_grpc.TrillianLogStub(self.channel)
+    self.tree = self.admin.CreateTree(
+        trillian_admin_api_pb2.CreateTreeRequest(tree=trillian_pb2.Tree(
+            tree_type=trillian_pb2.LOG,
+            tree_state=trillian_pb2.ACTIVE,
+            display_name="python client test",
+            max_root_duration=duration_pb2.Duration(seconds=3600))),
+        timeout=FLAGS.rpc_deadline)
+    self.log.InitLog(
+        trillian_log_api_pb2.InitLogRequest(log_id=self.tree.tree_id),
+        timeout=FLAGS.rpc_deadline)
+
+  def tearDown(self):
+    self.admin.DeleteTree(
+        trillian_admin_api_pb2.DeleteTreeRequest(tree_id=self.tree.tree_id),
+        timeout=FLAGS.rpc_deadline)
+    self.channel.close()
+
+  def wait_for_size(self, size):
+    """Polls the latest root of the log until it has at least size leaves."""
+    deadline = time.time() + FLAGS.integration_timeout
+    while True:
+      resp = self.log.GetLatestSignedLogRoot(
+          trillian_log_api_pb2.GetLatestSignedLogRootRequest(
+              log_id=self.tree.tree_id),
+          timeout=FLAGS.rpc_deadline)
+      got, _ = parse_log_root(resp.signed_log_root.log_root)
+      if got >= size:
+        return got
+      if time.time() > deadline:
+        self.fail("log %d has size %d after %ds, want %d" %
+                  (self.tree.tree_id, got, FLAGS.integration_timeout, size))
+      time.sleep(1)
+
+  def test_tree(self):
+    tree = self.admin.GetTree(
+        trillian_admin_api_pb2.GetTreeRequest(tree_id=self.tree.tree_id),
+        timeout=FLAGS.rpc_deadline)
+    self.assertEqual(tree.tree_type, trillian_pb2.LOG)
+    self.assertEqual(tree.tree_state, trillian_pb2.ACTIVE)
+    self.assertEqual(tree.display_name, "python client test")
+
+  def test_queue_and_read_leaf(self):
+    value = b"leaf from the python client"
+    merkle_hash = hashlib.sha256(b"\x00" + value).digest()
+    resp = self.log.QueueLeaf(
+        trillian_log_api_pb2.QueueLeafRequest(
+            log_id=self.tree.tree_id,
+            leaf=trillian_pb2.LogLeaf(
+                leaf_value=value,
+                leaf_identity_hash=hashlib.sha256(value).digest())),
+        timeout=FLAGS.rpc_deadline)
+    self.assertEqual(resp.queued_leaf.status.code, 0)  # OK
+    size = self.wait_for_size(1)
+
+    leaves = self.log.GetLeavesByRange(
+        trillian_log_api_pb2.GetLeavesByRangeRequest(
+            log_id=self.tree.tree_id, start_index=0, count=1),
+        timeout=FLAGS.rpc_deadline)
+    self.assertEqual(len(leaves.leaves), 1)
+    self.assertEqual(leaves.leaves[0].leaf_value, value)
+    self.assertEqual(leaves.leaves[0].merkle_leaf_hash, merkle_hash)
+
+    hashes = self.log.GetLeavesByRange(
+        trillian_log_api_pb2.GetLeavesByRangeRequest(
+            log_id=self.tree.tree_id, start_index=0, count=1,
+            omit_leaf_data=True),
+        timeout=FLAGS.rpc_deadline)
+    self.assertEqual(hashes.leaves[0].leaf_value, b"")
+    self.assertEqual(hashes.leaves[0].merkle_leaf_hash, merkle_hash)
+
+    proof = self.log.GetInclusionProofByHash(
+        trillian_log_api_pb2.GetInclusionProofByHashRequest(
+            log_id=self.tree.tree_id, leaf_hash=merkle_hash, tree_size=size),
+        timeout=FLAGS.rpc_deadline)
+    self.assertEqual(len(proof.proof), 1)
+    self.assertEqual(proof.proof[0].leaf_index, 0)
+
+
+if __name__ == "__main__":
+  parser = argparse.ArgumentParser()
+  parser.add_argument("--server", required=True,
+                      help="Address of the Trillian log and admin server")
+  parser.add_argument("--rpc_deadline", type=float, default=10,
+                      help="Deadline of each RPC, in seconds")
+  parser.add_argument("--integration_timeout", type=float, default=60,
+                      help="Time to wait for a leaf to be integrated, in seconds")
+  FLAGS, rest = parser.parse_known_args()
+  unittest.main(argv=sys.argv[:1] + rest)
@@ -0,0 +1,47 @@
+#!/bin/bash
+#
+# Runs the Java and Python clients in integration/clients, whose gRPC stubs are
+# generated from the protos in this repository, against the log server started
+# by docker-compose, to catch proto changes which break non-Go consumers.
+
+INTEGRATION_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" >/dev/null && pwd)"
+. "${INTEGRATION_DIR}"/functions.sh
+. "${INTEGRATION_DIR}"/docker_compose_integration_test.sh
+
+# CLIENT_LANGUAGES selects the clients to run.
+readonly CLIENT_LANGUAGES=${CLIENT_LANGUAGES:-"java python"}
+
+# run_client builds the client for the given language and runs it against the
+# given log server, on the network of the docker-compose deployment.
+run_client() {
+  local lang="$1"
+  local server="$2"
+  local image="trillian_${lang}_client"
+
+  docker build -f "integration/clients/${lang}/Dockerfile" -t "${image}" . && \
+    docker run --rm --network cloudbuild "${image}" --server="${server}"
+}
+
+# Change to the root Trillian directory.
+cd "${INTEGRATION_DIR}/.."
+
+if ! docker_compose_up "deployment_trillian-log-server_1:8091"; then
+  echo "Docker logs:"
+  docker-compose -f examples/deployment/docker-compose.yml logs
+  docker-compose -f examples/deployment/docker-compose.yml down
+  exit 1
+fi
+
+RESULT=0
+for lang in ${CLIENT_LANGUAGES}; do
+  if ! run_test "${lang} client" run_client "${lang}" "deployment_trillian-log-server_1:8090"; then
+    RESULT=1
+  fi
+done
+
+if [[ ${RESULT} != 0 ]]; then
+  echo "Docker logs:"
+  docker-compose -f examples/deployment/docker-compose.yml logs
+fi
+docker-compose -f examples/deployment/docker-compose.yml down
+exit ${RESULT}
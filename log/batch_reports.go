@@ -0,0 +1,160 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"context"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian/log/sequencerpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// BatchReports keeps the reports of the most recent sequencing passes over
+// each log, and serves them with the sequencerpb.Sequencer service. Passes
+// which neither integrated leaves, signed a root nor failed aren't kept, as
+// idle logs would otherwise push out all other reports.
+type BatchReports struct {
+	perLog int
+	mu     sync.Mutex
+	// reports holds the reports of each log, oldest first.
+	reports map[int64][]*sequencerpb.BatchReport
+	// w, if not nil, receives every report as a line of JSON.
+	w io.Writer
+}
+
+// NewBatchReports creates a BatchReports which keeps up to perLog reports of
+// each log. If w is not nil, every report is also written to it as a line of
+// JSON.
+func NewBatchReports(perLog int, w io.Writer) *BatchReports {
+	return &BatchReports{perLog: perLog, reports: make(map[int64][]*sequencerpb.BatchReport), w: w}
+}
+
+// ListBatchReports implements sequencerpb.SequencerServer.ListBatchReports.
+func (b *BatchReports) ListBatchReports(ctx context.Context, req *sequencerpb.ListBatchReportsRequest) (*sequencerpb.ListBatchReportsResponse, error) {
+	if req.GetMaxReports() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "max_reports %d is negative", req.GetMaxReports())
+	}
+	var reports []*sequencerpb.BatchReport
+	b.mu.Lock()
+	if id := req.GetTreeId(); id != 0 {
+		reports = append(reports, b.reports[id]...)
+	} else {
+		for _, r := range b.reports {
+			reports = append(reports, r...)
+		}
+	}
+	b.mu.Unlock()
+
+	sort.SliceStable(reports, func(i, j int) bool {
+		return reports[i].StartTime.AsTime().After(reports[j].StartTime.AsTime())
+	})
+	if max := int(req.GetMaxReports()); max > 0 && len(reports) > max {
+		reports = reports[:max]
+	}
+	return &sequencerpb.ListBatchReportsResponse{Reports: reports}, nil
+}
+
+// add keeps the report of a sequencing pass over treeID which started at
+// start, took duration and integrated batches of batchSize leaves. Either
+// res or err is set.
+func (b *BatchReports) add(treeID int64, start time.Time, duration time.Duration, batchSize int, res *BatchResult, err error) {
+	if b == nil {
+		return
+	}
+	if err == nil && len(res.Leaves) == 0 && res.NewRoot == nil {
+		return
+	}
+	report := &sequencerpb.BatchReport{
+		TreeId:    treeID,
+		StartTime: timestamppb.New(start),
+		Duration:  durationpb.New(duration),
+		BatchSize: int64(batchSize),
+	}
+	if err != nil {
+		report.Error = err.Error()
+	} else {
+		report.LeavesIntegrated = int64(len(res.Leaves))
+		report.MaxMergeDelay = durationpb.New(maxMergeDelay(res))
+		report.StorageLatencies = storageLatencies(res.Latencies)
+		if r := res.NewRoot; r != nil {
+			report.NewRoot = &sequencerpb.Root{
+				TreeSize:  r.TreeSize,
+				RootHash:  r.RootHash,
+				Timestamp: timestamppb.New(time.Unix(0, int64(r.TimestampNanos))),
+			}
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.perLog > 0 {
+		reports := append(b.reports[treeID], report)
+		if len(reports) > b.perLog {
+			reports = reports[len(reports)-b.perLog:]
+		}
+		b.reports[treeID] = reports
+	}
+	if b.w != nil {
+		line, err := protojson.Marshal(report)
+		if err == nil {
+			_, err = b.w.Write(append(line, '\n'))
+		}
+		if err != nil {
+			glog.Warningf("%v: failed to write batch report: %v", treeID, err)
+		}
+	}
+}
+
+// maxMergeDelay returns the longest time between the queueing and the
+// integration of the leaves of res.
+func maxMergeDelay(res *BatchResult) time.Duration {
+	var max time.Duration
+	for _, leaf := range res.Leaves {
+		if leaf.QueueTimestamp == nil || leaf.IntegrateTimestamp == nil {
+			continue
+		}
+		if d := leaf.IntegrateTimestamp.AsTime().Sub(leaf.QueueTimestamp.AsTime()); d > max {
+			max = d
+		}
+	}
+	return max
+}
+
+func storageLatencies(l StageLatencies) *sequencerpb.StorageLatencies {
+	d := func(d time.Duration) *durationpb.Duration {
+		if d == 0 {
+			return nil
+		}
+		return durationpb.New(d)
+	}
+	return &sequencerpb.StorageLatencies{
+		GetRoot:      d(l.GetRoot),
+		Dequeue:      d(l.Dequeue),
+		InitTree:     d(l.InitTree),
+		UpdateLeaves: d(l.UpdateLeaves),
+		SetNodes:     d(l.SetNodes),
+		StoreRoot:    d(l.StoreRoot),
+		Commit:       d(l.Commit),
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/log/sequencerpb"
+	"github.com/google/trillian/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var _ sequencerpb.SequencerServer = &BatchReports{}
+
+func TestBatchReports(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	b := NewBatchReports(2, &buf)
+
+	leaf := &trillian.LogLeaf{
+		QueueTimestamp:     timestamppb.New(fakeTime.Add(-3 * time.Second)),
+		IntegrateTimestamp: timestamppb.New(fakeTime),
+	}
+	root := &types.LogRootV1{TreeSize: 1, RootHash: []byte("root"), TimestampNanos: uint64(fakeTime.UnixNano())}
+	res := &BatchResult{NewRoot: root, Leaves: []*trillian.LogLeaf{leaf}, Latencies: StageLatencies{Dequeue: time.Millisecond}}
+	for i := 0; i < 3; i++ {
+		b.add(1, fakeTime.Add(time.Duration(i)*time.Second), time.Second, 50, res, nil)
+	}
+	b.add(2, fakeTime.Add(10*time.Second), time.Second, 50, nil, errors.New("boom"))
+	// Idle passes aren't reported.
+	b.add(2, fakeTime.Add(20*time.Second), time.Second, 50, &BatchResult{}, nil)
+
+	want := &sequencerpb.BatchReport{
+		TreeId:           1,
+		StartTime:        timestamppb.New(fakeTime.Add(2 * time.Second)),
+		Duration:         durationpb.New(time.Second),
+		BatchSize:        50,
+		LeavesIntegrated: 1,
+		MaxMergeDelay:    durationpb.New(3 * time.Second),
+		StorageLatencies: &sequencerpb.StorageLatencies{Dequeue: durationpb.New(time.Millisecond)},
+		NewRoot:          &sequencerpb.Root{TreeSize: 1, RootHash: []byte("root"), Timestamp: timestamppb.New(fakeTime)},
+	}
+
+	for _, test := range []struct {
+		desc      string
+		req       *sequencerpb.ListBatchReportsRequest
+		wantTrees []int64
+		wantFirst *sequencerpb.BatchReport
+	}{
+		{desc: "allLogs", req: &sequencerpb.ListBatchReportsRequest{}, wantTrees: []int64{2, 1, 1}},
+		{desc: "oneLog", req: &sequencerpb.ListBatchReportsRequest{TreeId: 1}, wantTrees: []int64{1, 1}, wantFirst: want},
+		{desc: "maxReports", req: &sequencerpb.ListBatchReportsRequest{MaxReports: 1}, wantTrees: []int64{2}},
+		{desc: "unknownLog", req: &sequencerpb.ListBatchReportsRequest{TreeId: 3}},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			resp, err := b.ListBatchReports(ctx, test.req)
+			if err != nil {
+				t.Fatalf("ListBatchReports(): %v", err)
+			}
+			var trees []int64
+			for _, r := range resp.Reports {
+				trees = append(trees, r.TreeId)
+			}
+			if got, want := len(trees), len(test.wantTrees); got != want {
+				t.Fatalf("ListBatchReports() returned reports of trees %v, want %v", trees, test.wantTrees)
+			}
+			for i := range trees {
+				if trees[i] != test.wantTrees[i] {
+					t.Fatalf("ListBatchReports() returned reports of trees %v, want %v", trees, test.wantTrees)
+				}
+			}
+			if test.wantFirst != nil && !proto.Equal(resp.Reports[0], test.wantFirst) {
+				t.Errorf("ListBatchReports() returned first report %v, want %v", resp.Reports[0], test.wantFirst)
+			}
+		})
+	}
+
+	if _, err := b.ListBatchReports(ctx, &sequencerpb.ListBatchReportsRequest{MaxReports: -1}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ListBatchReports() with negative max_reports returned %v, want code %v", err, codes.InvalidArgument)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if got, want := len(lines), 4; got != want {
+		t.Fatalf("Wrote %d report lines, want %d", got, want)
+	}
+	var failed sequencerpb.BatchReport
+	if err := protojson.Unmarshal([]byte(lines[3]), &failed); err != nil {
+		t.Fatalf("Unmarshal(%q): %v", lines[3], err)
+	}
+	if got, want := failed.Error, "boom"; got != want {
+		t.Errorf("Report line has error %q, want %q", got, want)
+	}
+}
+
+func TestBatchReportsNil(t *testing.T) {
+	var b *BatchReports
+	// Must not panic.
+	b.add(1, fakeTime, time.Second, 50, nil, errors.New("boom"))
+}
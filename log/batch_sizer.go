@@ -0,0 +1,114 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"math"
+	"runtime"
+	"sync"
+	"time"
+)
+
+const (
+	// minBatchScale is the smallest fraction of the configured batch size
+	// that the batch sizer backs off to.
+	minBatchScale = 1.0 / 1024
+	// batchScaleStep is the fraction of the configured batch size which the
+	// batch size grows by after every pass within the limits.
+	batchScaleStep = 0.1
+)
+
+// readMemStats is runtime.ReadMemStats, replaced in tests.
+var readMemStats = runtime.ReadMemStats
+
+// batchSizer adapts the batch size of sequencing passes to the GC pauses and
+// heap growth they cause. It halves the batch size whenever a pass exceeds
+// one of the limits, and grows it back linearly towards the configured size
+// while the passes stay within them.
+//
+// GC pauses and the heap are shared by the whole process, so a single sizer
+// covers all trees: a pass which sees a long pause may be a victim of the
+// passes running concurrently, and backing off all of them is what relieves
+// the pressure.
+type batchSizer struct {
+	mu    sync.Mutex
+	scale float64
+}
+
+func newBatchSizer() *batchSizer {
+	return &batchSizer{scale: 1}
+}
+
+// size returns the batch size to use for the next pass, given the configured
+// maximum.
+func (b *batchSizer) size(max int) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if n := int(math.Round(float64(max) * b.scale)); n > 0 {
+		return n
+	}
+	return 1
+}
+
+// measure runs f, and adapts the batch size to the longest GC pause and the
+// heap growth during the run. If both limits are zero, it only runs f.
+func (b *batchSizer) measure(maxPause time.Duration, maxHeapGrowth uint64, f func()) {
+	if maxPause <= 0 && maxHeapGrowth == 0 {
+		f()
+		return
+	}
+	var before, after runtime.MemStats
+	readMemStats(&before)
+	f()
+	readMemStats(&after)
+
+	pause, growth := gcPressure(&before, &after)
+	exceeded := (maxPause > 0 && pause > maxPause) || (maxHeapGrowth > 0 && growth > maxHeapGrowth)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if exceeded {
+		b.scale /= 2
+		if b.scale < minBatchScale {
+			b.scale = minBatchScale
+		}
+	} else if b.scale < 1 {
+		b.scale += batchScaleStep
+		if b.scale > 1 {
+			b.scale = 1
+		}
+	}
+}
+
+// gcPressure returns the longest GC pause which completed between the two
+// snapshots, and how much the heap grew in between.
+func gcPressure(before, after *runtime.MemStats) (time.Duration, uint64) {
+	var pause uint64
+	// The pause of GC number n (counting from 1) is kept in
+	// PauseNs[(n-1)%256], for the last 256 GCs.
+	first := before.NumGC
+	if after.NumGC-first > uint32(len(after.PauseNs)) {
+		first = after.NumGC - uint32(len(after.PauseNs))
+	}
+	for i := first; i < after.NumGC; i++ {
+		if p := after.PauseNs[i%uint32(len(after.PauseNs))]; p > pause {
+			pause = p
+		}
+	}
+	var growth uint64
+	if after.HeapAlloc > before.HeapAlloc {
+		growth = after.HeapAlloc - before.HeapAlloc
+	}
+	return time.Duration(pause), growth
+}
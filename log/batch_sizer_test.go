@@ -0,0 +1,113 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestGCPressure(t *testing.T) {
+	for _, tc := range []struct {
+		desc       string
+		before     runtime.MemStats
+		after      runtime.MemStats
+		wantPause  time.Duration
+		wantGrowth uint64
+	}{
+		{
+			desc:   "no-gc",
+			before: runtime.MemStats{NumGC: 3, HeapAlloc: 100},
+			after:  runtime.MemStats{NumGC: 3, HeapAlloc: 150, PauseNs: [256]uint64{2: 1e9}},
+			// The long pause happened before the run.
+			wantGrowth: 50,
+		},
+		{
+			desc:      "gcs",
+			before:    runtime.MemStats{NumGC: 3, HeapAlloc: 100},
+			after:     runtime.MemStats{NumGC: 5, HeapAlloc: 50, PauseNs: [256]uint64{2: 1e9, 3: 5, 4: 7}},
+			wantPause: 7,
+		},
+		{
+			desc:      "wrapped",
+			before:    runtime.MemStats{NumGC: 255},
+			after:     runtime.MemStats{NumGC: 257, PauseNs: [256]uint64{0: 9, 254: 1e9, 255: 8}},
+			wantPause: 9,
+		},
+		{
+			desc:      "more-than-kept",
+			before:    runtime.MemStats{NumGC: 1},
+			after:     runtime.MemStats{NumGC: 1000, PauseNs: [256]uint64{17: 11}},
+			wantPause: 11,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			pause, growth := gcPressure(&tc.before, &tc.after)
+			if pause != tc.wantPause || growth != tc.wantGrowth {
+				t.Errorf("gcPressure()=%v, %d, want %v, %d", pause, growth, tc.wantPause, tc.wantGrowth)
+			}
+		})
+	}
+}
+
+func TestBatchSizer(t *testing.T) {
+	defer func(f func(*runtime.MemStats)) { readMemStats = f }(readMemStats)
+	// Every run sees one GC with the given pause and heap growth.
+	var stats runtime.MemStats
+	readMemStats = func(m *runtime.MemStats) { *m = stats }
+	run := func(pause time.Duration, growth uint64) func() {
+		return func() {
+			stats.PauseNs[stats.NumGC%256] = uint64(pause)
+			stats.NumGC++
+			stats.HeapAlloc += growth
+		}
+	}
+
+	b := newBatchSizer()
+	if got, want := b.size(1000), 1000; got != want {
+		t.Fatalf("size()=%d, want %d", got, want)
+	}
+	for _, step := range []struct {
+		pause  time.Duration
+		growth uint64
+		want   int
+	}{
+		{pause: time.Second, want: 500},
+		{growth: 1 << 30, want: 250},
+		{want: 350},
+		{pause: 10 * time.Millisecond, growth: 1 << 20, want: 450},
+		{pause: time.Second, want: 225},
+	} {
+		b.measure(100*time.Millisecond, 100<<20, run(step.pause, step.growth))
+		if got := b.size(1000); got != step.want {
+			t.Errorf("after pause %v and growth %d: size()=%d, want %d", step.pause, step.growth, got, step.want)
+		}
+	}
+
+	// The batch size never drops to zero.
+	for i := 0; i < 20; i++ {
+		b.measure(time.Millisecond, 0, run(time.Second, 0))
+	}
+	if got, want := b.size(1000), 1; got != want {
+		t.Errorf("size()=%d, want %d", got, want)
+	}
+	// Without limits, nothing is measured.
+	numGC := stats.NumGC
+	b.measure(0, 0, func() {})
+	if stats.NumGC != numGC {
+		t.Error("measure() without limits ran a measurement")
+	}
+}
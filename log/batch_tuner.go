@@ -0,0 +1,197 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"strconv"
+	"sync"
+	"time"
+)
+
+// BatchTuning bounds the tuning of the batch size and run interval of each
+// log, see OperationInfo.BatchTuning.
+type BatchTuning struct {
+	// MinBatchSize and MaxBatchSize bound the batch size of a log.
+	MinBatchSize, MaxBatchSize int
+	// MaxInterval bounds the time between the passes over a log. The shortest
+	// interval is OperationInfo.RunInterval.
+	MaxInterval time.Duration
+	// TargetLatency is the pass duration above which the batch size shrinks.
+	// It only grows while passes take less than half of it.
+	TargetLatency time.Duration
+	// TargetCommitLatency, if positive, is the storage commit duration of a
+	// pass above which the batch size shrinks too, even if the pass as a
+	// whole was fast enough. The batch size then only grows while commits
+	// take less than half of it.
+	TargetCommitLatency time.Duration
+	// Hysteresis is the number of consecutive passes which must call for an
+	// adjustment before it's made. Values below 1 are treated as 1.
+	Hysteresis int
+}
+
+// batchTuner tunes the batch size and run interval of each log to the latency
+// of its passes and its backlog, so that trees with different loads don't
+// have to share a single static batch size:
+//   - passes slower than the target latency, or with commits slower than the
+//     target commit latency, halve the batch size;
+//   - full batches, which show a backlog, double the batch size if the passes
+//     are fast, and halve the interval. If the queue depth sampled before the
+//     pass shows a larger backlog, the batch size grows to cover it at once;
+//   - passes which integrate nothing double the interval.
+//
+// Adjustments are only made after Hysteresis consecutive passes call for
+// them, and latencies between half the target and the target neither grow
+// nor shrink the batch, so the tuning doesn't oscillate.
+type batchTuner struct {
+	mu    sync.Mutex
+	trees map[int64]*tunedLog
+}
+
+// tunedLog is the tuning state of a log.
+type tunedLog struct {
+	batchSize int
+	interval  time.Duration
+	lastPass  time.Time
+	// Numbers of consecutive slow, fast full and idle passes.
+	slow, full, idle int
+}
+
+func newBatchTuner() *batchTuner {
+	return &batchTuner{trees: make(map[int64]*tunedLog)}
+}
+
+// next returns the batch size for a pass over the log starting at now, and
+// whether the pass is due. The state of a log starts at the configured batch
+// size and run interval, within the bounds.
+func (b *batchTuner) next(logID int64, now time.Time, info *OperationInfo) (int, bool) {
+	cfg := info.BatchTuning
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	t, ok := b.trees[logID]
+	if !ok {
+		t = &tunedLog{
+			batchSize: clampInt(info.BatchSize, cfg.MinBatchSize, cfg.MaxBatchSize),
+			interval:  info.RunInterval,
+		}
+		b.trees[logID] = t
+	}
+	seqRunInterval.Set(t.interval.Seconds(), strconv.FormatInt(logID, 10))
+	if now.Sub(t.lastPass) < t.interval {
+		return 0, false
+	}
+	t.lastPass = now
+	return t.batchSize, true
+}
+
+// tunedPass describes a successful pass observed by batchTuner.
+type tunedPass struct {
+	duration time.Duration // Of the whole pass.
+	commit   time.Duration // Of the storage commit.
+	// leaves is the number of leaves integrated out of batchSize.
+	leaves, batchSize int
+	// queued is the queue depth sampled before the pass, or negative if it
+	// wasn't sampled.
+	queued int64
+}
+
+// observe adapts the tuning of the log to a pass. Failed passes aren't
+// observed.
+func (b *batchTuner) observe(logID int64, p tunedPass, info *OperationInfo) {
+	cfg := info.BatchTuning
+	hysteresis := cfg.Hysteresis
+	if hysteresis < 1 {
+		hysteresis = 1
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	t, ok := b.trees[logID]
+	if !ok {
+		return
+	}
+
+	slowCommit := cfg.TargetCommitLatency > 0 && p.commit > cfg.TargetCommitLatency
+	fastCommit := cfg.TargetCommitLatency <= 0 || p.commit < cfg.TargetCommitLatency/2
+	slow := p.duration > cfg.TargetLatency || slowCommit
+	full := p.leaves >= p.batchSize && p.duration < cfg.TargetLatency/2 && fastCommit
+	idle := p.leaves == 0
+	t.slow, t.full, t.idle = countIf(slow, t.slow), countIf(full, t.full), countIf(idle, t.idle)
+
+	label := strconv.FormatInt(logID, 10)
+	switch {
+	case t.slow >= hysteresis:
+		t.batchSize = clampInt(t.batchSize/2, cfg.MinBatchSize, cfg.MaxBatchSize)
+		t.slow = 0
+		reason := "slow_pass"
+		if slowCommit {
+			reason = "slow_commit"
+		}
+		seqBatchTunings.Inc(label, reason)
+	case t.full >= hysteresis:
+		size, reason := t.batchSize*2, "backlog"
+		// Only a bounded batch size jumps to the backlog, which may be huge.
+		if backlog := p.queued - int64(p.leaves); cfg.MaxBatchSize > 0 && backlog > int64(size) {
+			size, reason = cfg.MaxBatchSize, "queue_depth"
+			if backlog < int64(size) {
+				size = int(backlog)
+			}
+		}
+		t.batchSize = clampInt(size, cfg.MinBatchSize, cfg.MaxBatchSize)
+		t.interval = clampDuration(t.interval/2, info.RunInterval, cfg.MaxInterval)
+		t.full = 0
+		seqBatchTunings.Inc(label, reason)
+	case t.idle >= hysteresis:
+		t.interval = clampDuration(t.interval*2, info.RunInterval, cfg.MaxInterval)
+		t.idle = 0
+		seqBatchTunings.Inc(label, "idle")
+	}
+}
+
+// forget drops the tuning state of the log, e.g. when it's no longer
+// sequenced by this instance.
+func (b *batchTuner) forget(logID int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.trees, logID)
+}
+
+func countIf(cond bool, n int) int {
+	if cond {
+		return n + 1
+	}
+	return 0
+}
+
+func clampInt(v, min, max int) int {
+	if v > max && max > 0 {
+		v = max
+	}
+	if v < min {
+		v = min
+	}
+	if v < 1 {
+		v = 1
+	}
+	return v
+}
+
+func clampDuration(v, min, max time.Duration) time.Duration {
+	if v > max && max > 0 {
+		v = max
+	}
+	if v < min {
+		v = min
+	}
+	return v
+}
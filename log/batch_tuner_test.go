@@ -0,0 +1,162 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/trillian/monitoring"
+)
+
+func TestBatchTuner(t *testing.T) {
+	InitMetrics(monitoring.InertMetricFactory{})
+	info := &OperationInfo{
+		BatchSize:   100,
+		RunInterval: time.Second,
+		BatchTuning: &BatchTuning{
+			MinBatchSize:  10,
+			MaxBatchSize:  400,
+			MaxInterval:   4 * time.Second,
+			TargetLatency: time.Second,
+			Hysteresis:    2,
+		},
+	}
+	const logID = 1
+	b := newBatchTuner()
+	now := fakeTime
+
+	// pass runs a pass when it's due, which takes d and integrates leaves, or
+	// a full batch if leaves is negative. It returns the batch size, and the
+	// time until the pass was due.
+	pass := func(d time.Duration, leaves int) (int, time.Duration) {
+		t.Helper()
+		var waited time.Duration
+		for {
+			size, due := b.next(logID, now, info)
+			if due {
+				if leaves < 0 {
+					leaves = size
+				}
+				b.observe(logID, tunedPass{duration: d, leaves: leaves, batchSize: size, queued: -1}, info)
+				return size, waited
+			}
+			now = now.Add(100 * time.Millisecond)
+			waited += 100 * time.Millisecond
+		}
+	}
+
+	for i, step := range []struct {
+		d          time.Duration
+		leaves     int
+		wantSize   int
+		wantWaited time.Duration
+	}{
+		// Fast full batches double the batch size every second pass, up to
+		// the maximum.
+		{d: 100 * time.Millisecond, leaves: -1, wantSize: 100},
+		{d: 100 * time.Millisecond, leaves: -1, wantSize: 100, wantWaited: time.Second},
+		{d: 100 * time.Millisecond, leaves: -1, wantSize: 200, wantWaited: time.Second},
+		{d: 100 * time.Millisecond, leaves: -1, wantSize: 200, wantWaited: time.Second},
+		{d: 100 * time.Millisecond, leaves: -1, wantSize: 400, wantWaited: time.Second},
+		{d: 100 * time.Millisecond, leaves: -1, wantSize: 400, wantWaited: time.Second},
+		// Within the dead band, nothing changes.
+		{d: 700 * time.Millisecond, leaves: -1, wantSize: 400, wantWaited: time.Second},
+		{d: 700 * time.Millisecond, leaves: -1, wantSize: 400, wantWaited: time.Second},
+		// A single slow pass isn't enough to shrink the batch.
+		{d: 2 * time.Second, leaves: -1, wantSize: 400, wantWaited: time.Second},
+		{d: 700 * time.Millisecond, leaves: -1, wantSize: 400, wantWaited: time.Second},
+		{d: 2 * time.Second, leaves: -1, wantSize: 400, wantWaited: time.Second},
+		{d: 2 * time.Second, leaves: -1, wantSize: 400, wantWaited: time.Second},
+		{d: 2 * time.Second, leaves: -1, wantSize: 200, wantWaited: time.Second},
+		// Idle passes double the interval, up to the maximum.
+		{d: 100 * time.Millisecond, leaves: 0, wantSize: 200, wantWaited: time.Second},
+		{d: 100 * time.Millisecond, leaves: 0, wantSize: 200, wantWaited: time.Second},
+		{d: 100 * time.Millisecond, leaves: 0, wantSize: 200, wantWaited: 2 * time.Second},
+		{d: 100 * time.Millisecond, leaves: 0, wantSize: 200, wantWaited: 2 * time.Second},
+		{d: 100 * time.Millisecond, leaves: 0, wantSize: 200, wantWaited: 4 * time.Second},
+		{d: 100 * time.Millisecond, leaves: 0, wantSize: 200, wantWaited: 4 * time.Second},
+		{d: 100 * time.Millisecond, leaves: 0, wantSize: 200, wantWaited: 4 * time.Second},
+	} {
+		size, waited := pass(step.d, step.leaves)
+		if size != step.wantSize || waited != step.wantWaited {
+			t.Errorf("pass %d: got batch size %d after %v, want %d after %v", i, size, waited, step.wantSize, step.wantWaited)
+		}
+	}
+}
+
+func TestBatchTunerCommitAndQueueDepth(t *testing.T) {
+	InitMetrics(monitoring.InertMetricFactory{})
+	info := &OperationInfo{
+		BatchSize:   100,
+		RunInterval: time.Second,
+		BatchTuning: &BatchTuning{
+			MinBatchSize:        10,
+			MaxBatchSize:        1000,
+			TargetLatency:       time.Second,
+			TargetCommitLatency: 100 * time.Millisecond,
+		},
+	}
+	const logID = 1
+	b := newBatchTuner()
+	now := fakeTime
+
+	for i, step := range []struct {
+		commit   time.Duration
+		leaves   int
+		queued   int64
+		wantSize int
+	}{
+		// Slow commits shrink the batch, even though the passes are fast.
+		{commit: 200 * time.Millisecond, leaves: 100, queued: -1, wantSize: 50},
+		// Commits in the dead band don't grow a full batch.
+		{commit: 70 * time.Millisecond, leaves: 50, queued: -1, wantSize: 50},
+		// Fast commits of full batches double it.
+		{commit: 10 * time.Millisecond, leaves: 50, queued: -1, wantSize: 100},
+		// A backlog larger than that grows the batch to cover it.
+		{commit: 10 * time.Millisecond, leaves: 100, queued: 700, wantSize: 600},
+		// Up to the maximum.
+		{commit: 10 * time.Millisecond, leaves: 600, queued: 5000, wantSize: 1000},
+		// A small backlog doesn't shrink it.
+		{commit: 10 * time.Millisecond, leaves: 1000, queued: 1001, wantSize: 1000},
+	} {
+		now = now.Add(time.Hour)
+		size, due := b.next(logID, now, info)
+		if !due {
+			t.Fatalf("pass %d: not due", i)
+		}
+		b.observe(logID, tunedPass{duration: 200 * time.Millisecond, commit: step.commit, leaves: step.leaves, batchSize: size, queued: step.queued}, info)
+		if got, _ := b.next(logID, now.Add(time.Hour), info); got != step.wantSize {
+			t.Errorf("pass %d: got batch size %d, want %d", i, got, step.wantSize)
+		}
+		now = now.Add(time.Hour)
+	}
+}
+
+func TestClampInt(t *testing.T) {
+	for _, tc := range []struct {
+		v, min, max, want int
+	}{
+		{v: 5, min: 1, max: 10, want: 5},
+		{v: 0, min: 1, max: 10, want: 1},
+		{v: 20, min: 1, max: 10, want: 10},
+		{v: 20, min: 1, max: 0, want: 20},
+		{v: 0, min: 0, max: 0, want: 1},
+	} {
+		if got := clampInt(tc.v, tc.min, tc.max); got != tc.want {
+			t.Errorf("clampInt(%d, %d, %d)=%d, want %d", tc.v, tc.min, tc.max, got, tc.want)
+		}
+	}
+}
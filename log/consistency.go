@@ -0,0 +1,114 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/types"
+	"github.com/transparency-dev/merkle/compact"
+	"github.com/transparency-dev/merkle/rfc6962"
+)
+
+// ErrInconsistent is wrapped by the errors of CheckTreeConsistency which
+// report that the stored state of a log is inconsistent, rather than that it
+// couldn't be read.
+var ErrInconsistent = errors.New("tree is inconsistent")
+
+// CheckTreeConsistency verifies that the latest root of a log, its stored
+// Merkle tree nodes and its sequenced leaves agree with each other:
+//   - the root hash recomputed from the nodes covering the tree must match the
+//     root hash of the latest root;
+//   - the last leaf of the tree must be stored, and its Merkle leaf hash must
+//     match the corresponding node of the tree.
+//
+// Errors which report a failed check wrap ErrInconsistent. Other errors, e.g.
+// of storage, mean that the check couldn't complete.
+//
+// Logs which haven't been initialized yet are considered consistent.
+func CheckTreeConsistency(ctx context.Context, tree *trillian.Tree, ls storage.LogStorage) error {
+	tx, err := ls.SnapshotForTree(ctx, tree)
+	if err == storage.ErrTreeNeedsInit {
+		if tx != nil {
+			tx.Close()
+		}
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("%v: failed to open snapshot: %v", tree.TreeId, err)
+	}
+	defer tx.Close()
+
+	slr, err := tx.LatestSignedLogRoot(ctx)
+	if err == storage.ErrTreeNeedsInit {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("%v: failed to get latest root: %v", tree.TreeId, err)
+	}
+	var root types.LogRootV1
+	if err := root.UnmarshalBinary(slr.GetLogRoot()); err != nil {
+		return fmt.Errorf("%v: %w: failed to unmarshal latest root: %v", tree.TreeId, ErrInconsistent, err)
+	}
+	if root.TreeSize == 0 {
+		if want := rfc6962.DefaultHasher.EmptyRoot(); !bytes.Equal(root.RootHash, want) {
+			return fmt.Errorf("%v: %w: empty tree has root hash %x, want %x", tree.TreeId, ErrInconsistent, root.RootHash, want)
+		}
+		return tx.Commit(ctx)
+	}
+
+	ids := compact.RangeNodes(0, root.TreeSize, nil)
+	nodes, err := tx.GetMerkleNodes(ctx, ids)
+	if errors.Is(err, storage.ErrMissingNodes) {
+		return fmt.Errorf("%v: %w: tree of size %d is missing root nodes: %v", tree.TreeId, ErrInconsistent, root.TreeSize, err)
+	} else if err != nil {
+		return fmt.Errorf("%v: failed to read tree nodes: %v", tree.TreeId, err)
+	}
+	if got, want := len(nodes), len(ids); got != want {
+		return fmt.Errorf("%v: %w: tree of size %d has %d of its %d root nodes", tree.TreeId, ErrInconsistent, root.TreeSize, got, want)
+	}
+	hashes := make([][]byte, len(nodes))
+	for i, node := range nodes {
+		hashes[i] = node.Hash
+	}
+	if _, err := newCompactRange(&root, hashes); err != nil {
+		return fmt.Errorf("%v: %w: tree of size %d doesn't match its nodes: %v", tree.TreeId, ErrInconsistent, root.TreeSize, err)
+	}
+
+	last := int64(root.TreeSize - 1)
+	leaves, err := tx.GetLeavesByRange(ctx, last, 1)
+	if err != nil {
+		return fmt.Errorf("%v: failed to read leaf %d: %v", tree.TreeId, last, err)
+	}
+	if len(leaves) != 1 || leaves[0].LeafIndex != last {
+		return fmt.Errorf("%v: %w: tree of size %d is missing leaf %d", tree.TreeId, ErrInconsistent, root.TreeSize, last)
+	}
+	nodes, err = tx.GetMerkleNodes(ctx, []compact.NodeID{compact.NewNodeID(0, uint64(last))})
+	if errors.Is(err, storage.ErrMissingNodes) {
+		return fmt.Errorf("%v: %w: tree of size %d is missing the node for leaf %d: %v", tree.TreeId, ErrInconsistent, root.TreeSize, last, err)
+	} else if err != nil {
+		return fmt.Errorf("%v: failed to read node for leaf %d: %v", tree.TreeId, last, err)
+	}
+	if len(nodes) != 1 {
+		return fmt.Errorf("%v: %w: tree of size %d is missing the node for leaf %d", tree.TreeId, ErrInconsistent, root.TreeSize, last)
+	}
+	if got, want := leaves[0].MerkleLeafHash, nodes[0].Hash; !bytes.Equal(got, want) {
+		return fmt.Errorf("%v: %w: leaf %d has hash %x, but its tree node has %x", tree.TreeId, ErrInconsistent, last, got, want)
+	}
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,147 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/quota"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/types"
+	"github.com/google/trillian/util/clock"
+	"github.com/transparency-dev/merkle/rfc6962"
+)
+
+func TestCheckTreeConsistency(t *testing.T) {
+	InitMetrics(nil)
+	for _, test := range []struct {
+		desc    string
+		leaves  int
+		corrupt func(root *types.LogRootV1)
+		wantErr bool
+	}{
+		{desc: "empty"},
+		{desc: "ok", leaves: 7},
+		{
+			desc:   "bad-root-hash",
+			leaves: 7,
+			corrupt: func(root *types.LogRootV1) {
+				root.RootHash = sha256.New().Sum(nil)
+			},
+			wantErr: true,
+		},
+		{
+			desc:   "bad-empty-root-hash",
+			leaves: 0,
+			corrupt: func(root *types.LogRootV1) {
+				root.RootHash = []byte("not empty")
+			},
+			wantErr: true,
+		},
+		{
+			desc:   "size-beyond-nodes",
+			leaves: 7,
+			corrupt: func(root *types.LogRootV1) {
+				root.TreeSize = 9
+			},
+			wantErr: true,
+		},
+		{
+			desc:   "size-behind-nodes",
+			leaves: 7,
+			corrupt: func(root *types.LogRootV1) {
+				root.TreeSize = 5
+			},
+			wantErr: true,
+		},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			ctx := context.Background()
+			tree, ls := newMemoryLog(ctx, t)
+			ts := clock.NewFake(time.Unix(1500000000, 0))
+
+			if test.leaves > 0 {
+				leaves := make([]*trillian.LogLeaf, 0, test.leaves)
+				for i := 0; i < test.leaves; i++ {
+					data := []byte(fmt.Sprintf("leaf %d", i))
+					hash := sha256.Sum256(data)
+					leaves = append(leaves, &trillian.LogLeaf{
+						LeafValue:        data,
+						LeafIdentityHash: hash[:],
+						MerkleLeafHash:   rfc6962.DefaultHasher.HashLeaf(data),
+					})
+				}
+				if _, err := ls.QueueLeaves(ctx, tree, leaves, ts.Now()); err != nil {
+					t.Fatalf("QueueLeaves(): %v", err)
+				}
+				if _, err := IntegrateBatch(ctx, tree, test.leaves, 0, 0, ts, ls, quota.Noop()); err != nil {
+					t.Fatalf("IntegrateBatch(): %v", err)
+				}
+			}
+
+			if test.corrupt != nil {
+				ts.Set(ts.Now().Add(time.Minute))
+				if err := ls.ReadWriteTransaction(ctx, tree, func(ctx context.Context, tx storage.LogTreeTX) error {
+					slr, err := tx.LatestSignedLogRoot(ctx)
+					if err != nil {
+						return err
+					}
+					var root types.LogRootV1
+					if err := root.UnmarshalBinary(slr.LogRoot); err != nil {
+						return err
+					}
+					test.corrupt(&root)
+					root.TimestampNanos = uint64(ts.Now().UnixNano())
+					logRoot, err := root.MarshalBinary()
+					if err != nil {
+						return err
+					}
+					return tx.StoreSignedLogRoot(ctx, &trillian.SignedLogRoot{LogRoot: logRoot})
+				}); err != nil {
+					t.Fatalf("failed to store corrupt root: %v", err)
+				}
+			}
+
+			err := CheckTreeConsistency(ctx, tree, ls)
+			if gotErr := errors.Is(err, ErrInconsistent); gotErr != test.wantErr || (err != nil && !gotErr) {
+				t.Errorf("CheckTreeConsistency()=%v, want ErrInconsistent: %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+// failingSnapshotStorage is a LogStorage whose snapshots can't be opened.
+type failingSnapshotStorage struct {
+	storage.LogStorage
+}
+
+func (failingSnapshotStorage) SnapshotForTree(context.Context, *trillian.Tree) (storage.ReadOnlyLogTreeTX, error) {
+	return nil, errors.New("connection reset")
+}
+
+func TestCheckTreeConsistencyStorageError(t *testing.T) {
+	ctx := context.Background()
+	tree, ls := newMemoryLog(ctx, t)
+	err := CheckTreeConsistency(ctx, tree, failingSnapshotStorage{ls})
+	if err == nil || errors.Is(err, ErrInconsistent) {
+		t.Errorf("CheckTreeConsistency()=%v, want an error which isn't ErrInconsistent", err)
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/storage"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// addDailyStats adds the leaves integrated by a sequencing pass to the daily
+// statistics of the tree, by the UTC day of their integration timestamp. It
+// does nothing if the storage doesn't maintain daily statistics.
+func addDailyStats(ctx context.Context, tx storage.LogTreeTX, leaves []*trillian.LogLeaf) error {
+	stx, err := storage.AsLogStatsTX(tx)
+	if err != nil || len(leaves) == 0 {
+		return nil
+	}
+	byDay := make(map[time.Time]*trillian.DailyLogStats)
+	for _, leaf := range leaves {
+		day := leaf.GetIntegrateTimestamp().AsTime().UTC().Truncate(24 * time.Hour)
+		stats, ok := byDay[day]
+		if !ok {
+			stats = &trillian.DailyLogStats{Day: timestamppb.New(day)}
+			byDay[day] = stats
+		}
+		stats.LeavesAdded++
+		stats.LeafValueBytes += int64(len(leaf.LeafValue))
+	}
+	days := make([]*trillian.DailyLogStats, 0, len(byDay))
+	for _, stats := range byDay {
+		days = append(days, stats)
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Day.AsTime().Before(days[j].Day.AsTime()) })
+	return stx.AddDailyStats(ctx, days)
+}
@@ -0,0 +1,96 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/trillian"
+	"github.com/google/trillian/quota"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/util/clock"
+	"github.com/transparency-dev/merkle/rfc6962"
+)
+
+func TestIntegrateBatchDailyStats(t *testing.T) {
+	InitMetrics(nil)
+	ctx := context.Background()
+	tree, ls := newMemoryLog(ctx, t)
+	ts := clock.NewFake(time.Date(2022, 3, 1, 23, 0, 0, 0, time.UTC))
+
+	// queue adds leaves with values of the given sizes.
+	queue := func(sizes ...int) {
+		t.Helper()
+		var leaves []*trillian.LogLeaf
+		for _, size := range sizes {
+			data := []byte(fmt.Sprintf("%-*d", size, ts.Now().UnixNano()+int64(len(leaves))))
+			hash := sha256.Sum256(data)
+			leaves = append(leaves, &trillian.LogLeaf{
+				LeafValue:        data,
+				LeafIdentityHash: hash[:],
+				MerkleLeafHash:   rfc6962.DefaultHasher.HashLeaf(data),
+			})
+		}
+		if _, err := ls.QueueLeaves(ctx, tree, leaves, ts.Now()); err != nil {
+			t.Fatalf("QueueLeaves(): %v", err)
+		}
+	}
+	integrate := func() {
+		t.Helper()
+		if _, err := IntegrateBatch(ctx, tree, 10, 0, 0, ts, ls, quota.Noop()); err != nil {
+			t.Fatalf("IntegrateBatch(): %v", err)
+		}
+	}
+
+	queue(100, 200)
+	integrate()
+	ts.Set(ts.Now().Add(time.Minute))
+	queue(50)
+	integrate()
+	// The next pass is after midnight UTC.
+	ts.Set(ts.Now().Add(2 * time.Hour))
+	queue(30, 30, 30)
+	integrate()
+
+	tx, err := ls.SnapshotForTree(ctx, tree)
+	if err != nil {
+		t.Fatalf("SnapshotForTree(): %v", err)
+	}
+	defer tx.Close()
+	stx, err := storage.AsLogStatsTX(tx)
+	if err != nil {
+		t.Fatalf("AsLogStatsTX(): %v", err)
+	}
+	stats, err := stx.ListDailyStats(ctx)
+	if err != nil {
+		t.Fatalf("ListDailyStats(): %v", err)
+	}
+	var got []string
+	for _, s := range stats {
+		got = append(got, fmt.Sprintf("%s: %d leaves, %d bytes", s.Day.AsTime().Format(time.RFC3339), s.LeavesAdded, s.LeafValueBytes))
+	}
+	want := []string{
+		"2022-03-01T00:00:00Z: 3 leaves, 350 bytes",
+		"2022-03-02T00:00:00Z: 3 leaves, 90 bytes",
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("ListDailyStats(): diff (-got +want):\n%s", diff)
+	}
+}
@@ -0,0 +1,211 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/storage/dedup"
+)
+
+// DedupFilterConfig configures the dedup filters maintained by the
+// sequencer, see OperationInfo.DedupFilter.
+type DedupFilterConfig struct {
+	// Capacity and FalsePositiveRate size the new filters, see dedup.New.
+	// Filters which were persisted keep their size.
+	Capacity          uint64
+	FalsePositiveRate float64
+	// PersistInterval is the minimum time between stores of the filter of a
+	// log.
+	PersistInterval time.Duration
+	// BackfillBatchSize is the maximum number of leaves read per pass to add
+	// the leaves which were integrated while the filter wasn't maintained,
+	// e.g. before it was persisted the last time, to the filter. Zero means
+	// no limit.
+	BackfillBatchSize int
+}
+
+// dedupFilters maintains the dedup filters of LOG trees, see package
+// storage/dedup. The filter of a log is loaded from storage, or created, by
+// the first pass over it, and every pass adds the leaves it integrated. The
+// leaves which were integrated before, but after the filter was last
+// persisted, are read back from storage a batch per pass, so the filter
+// catches up with the log after a restart.
+type dedupFilters struct {
+	mu   sync.Mutex
+	logs map[int64]*dedupLog
+}
+
+// dedupLog is the dedup filter state of a log. Passes over a log don't run
+// concurrently, so it's only used by one at a time.
+type dedupLog struct {
+	// filter is nil if the storage doesn't persist dedup filters.
+	filter *dedup.Filter
+	// size is the number of leaves, from index 0, added to the filter.
+	size      uint64
+	persisted time.Time
+}
+
+func newDedupFilters() *dedupFilters {
+	return &dedupFilters{logs: make(map[int64]*dedupLog)}
+}
+
+// drop forgets the filter of the log, e.g. when it's no longer sequenced by
+// this instance. The filter is loaded from storage again if it comes back.
+func (d *dedupFilters) drop(logID int64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.logs, logID)
+}
+
+// update adds the leaves integrated by a pass over the tree, or the next
+// batch of leaves to backfill, to the filter of the tree, and persists it if
+// it's due. Trees whose hashes are encrypted aren't filtered, as the filter
+// would reveal which identity hashes they hold.
+func (d *dedupFilters) update(ctx context.Context, tree *trillian.Tree, res *BatchResult, info *OperationInfo, ls storage.LogStorage) error {
+	cfg := info.DedupFilter
+	if cfg == nil || tree.TreeType != trillian.TreeType_LOG || tree.EncryptHashes || res.OldRoot == nil {
+		return nil
+	}
+	d.mu.Lock()
+	l, ok := d.logs[tree.TreeId]
+	d.mu.Unlock()
+	if !ok {
+		var err error
+		if l, err = loadDedupFilter(ctx, tree, cfg, info.TimeSource.Now(), ls); err != nil {
+			return err
+		}
+		d.mu.Lock()
+		d.logs[tree.TreeId] = l
+		d.mu.Unlock()
+	}
+	if l.filter == nil {
+		return nil
+	}
+
+	switch oldSize := res.OldRoot.TreeSize; {
+	case l.size == oldSize:
+		for _, leaf := range res.Leaves {
+			l.filter.Add(leaf.LeafIdentityHash)
+		}
+		l.size += uint64(len(res.Leaves))
+	case l.size < oldSize:
+		count := oldSize - l.size
+		if max := uint64(cfg.BackfillBatchSize); cfg.BackfillBatchSize > 0 && count > max {
+			count = max
+		}
+		leaves, err := readLeafHashes(ctx, tree, int64(l.size), int64(count), ls)
+		if err != nil {
+			return fmt.Errorf("failed to read leaves to backfill: %v", err)
+		}
+		for _, leaf := range leaves {
+			l.filter.Add(leaf.LeafIdentityHash)
+		}
+		l.size += uint64(len(leaves))
+	default:
+		// The filter is ahead of the log, which shouldn't happen.
+		return fmt.Errorf("dedup filter holds %d leaves, but the tree size is %d", l.size, oldSize)
+	}
+	seqDedupFilterSize.Set(float64(l.size), strconv.FormatInt(tree.TreeId, 10))
+
+	now := info.TimeSource.Now()
+	if now.Sub(l.persisted) < cfg.PersistInterval {
+		return nil
+	}
+	data, err := l.filter.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	if err := ls.ReadWriteTransaction(ctx, tree, func(ctx context.Context, tx storage.LogTreeTX) error {
+		dtx, err := storage.AsDedupFilterTX(tx)
+		if err != nil {
+			return err
+		}
+		return dtx.StoreDedupFilter(ctx, &storage.DedupFilter{TreeSize: l.size, Filter: data})
+	}); err != nil {
+		return fmt.Errorf("failed to store dedup filter: %v", err)
+	}
+	l.persisted = now
+	return nil
+}
+
+// loadDedupFilter returns the stored dedup filter of the tree, or a new one
+// if there is none. The returned state has no filter if the storage doesn't
+// persist them.
+func loadDedupFilter(ctx context.Context, tree *trillian.Tree, cfg *DedupFilterConfig, now time.Time, ls storage.LogStorage) (*dedupLog, error) {
+	tx, err := ls.SnapshotForTree(ctx, tree)
+	if err != nil {
+		if tx != nil {
+			tx.Close()
+		}
+		return nil, err
+	}
+	defer tx.Close()
+	dtx, err := storage.AsDedupFilterTX(tx)
+	if err == storage.ErrDedupFilterUnsupported {
+		return &dedupLog{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	stored, err := dtx.DedupFilter(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dedup filter: %v", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	if stored != nil {
+		var f dedup.Filter
+		if err := f.UnmarshalBinary(stored.Filter); err != nil {
+			return nil, fmt.Errorf("failed to decode dedup filter: %v", err)
+		}
+		return &dedupLog{filter: &f, size: stored.TreeSize, persisted: now}, nil
+	}
+	f, err := dedup.New(cfg.Capacity, cfg.FalsePositiveRate)
+	if err != nil {
+		return nil, err
+	}
+	return &dedupLog{filter: f}, nil
+}
+
+// readLeafHashes reads count leaves of the tree from start, without their data
+// if the storage supports it.
+func readLeafHashes(ctx context.Context, tree *trillian.Tree, start, count int64, ls storage.LogStorage) ([]*trillian.LogLeaf, error) {
+	tx, err := ls.SnapshotForTree(ctx, tree)
+	if err != nil {
+		if tx != nil {
+			tx.Close()
+		}
+		return nil, err
+	}
+	defer tx.Close()
+	var leaves []*trillian.LogLeaf
+	if htx, err := storage.AsLeafHashesTX(tx); err == nil {
+		leaves, err = htx.GetLeafHashesByRange(ctx, start, count)
+		if err != nil {
+			return nil, err
+		}
+	} else if leaves, err = tx.GetLeavesByRange(ctx, start, count); err != nil {
+		return nil, err
+	}
+	return leaves, tx.Commit(ctx)
+}
@@ -0,0 +1,137 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/quota"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/util/clock"
+	"github.com/transparency-dev/merkle/rfc6962"
+)
+
+func TestDedupFilters(t *testing.T) {
+	InitMetrics(nil)
+	ctx := context.Background()
+	tree, ls := newMemoryLog(ctx, t)
+	ts := clock.NewFake(time.Unix(1000, 0))
+	info := &OperationInfo{
+		TimeSource:  ts,
+		DedupFilter: &DedupFilterConfig{Capacity: 1000, FalsePositiveRate: 0.01, PersistInterval: time.Minute, BackfillBatchSize: 3},
+	}
+
+	var hashes [][]byte
+	// pass queues count leaves and integrates them, updating d if not nil.
+	pass := func(d *dedupFilters, count int) {
+		t.Helper()
+		ts.Set(ts.Now().Add(time.Second))
+		var leaves []*trillian.LogLeaf
+		for i := 0; i < count; i++ {
+			data := []byte(fmt.Sprintf("leaf %d", len(hashes)))
+			hash := sha256.Sum256(data)
+			hashes = append(hashes, hash[:])
+			leaves = append(leaves, &trillian.LogLeaf{
+				LeafValue:        data,
+				LeafIdentityHash: hash[:],
+				MerkleLeafHash:   rfc6962.DefaultHasher.HashLeaf(data),
+			})
+		}
+		if count > 0 {
+			if _, err := ls.QueueLeaves(ctx, tree, leaves, ts.Now()); err != nil {
+				t.Fatalf("QueueLeaves(): %v", err)
+			}
+		}
+		res, err := IntegrateBatchWithResult(ctx, tree, 100, 0, 0, ts, ls, quota.Noop(), false)
+		if err != nil {
+			t.Fatalf("IntegrateBatchWithResult(): %v", err)
+		}
+		if d != nil {
+			if err := d.update(ctx, tree, res, info, ls); err != nil {
+				t.Fatalf("update(): %v", err)
+			}
+		}
+	}
+	stored := func() *storage.DedupFilter {
+		t.Helper()
+		tx, err := ls.SnapshotForTree(ctx, tree)
+		if err != nil {
+			t.Fatalf("SnapshotForTree(): %v", err)
+		}
+		defer tx.Close()
+		dtx, err := storage.AsDedupFilterTX(tx)
+		if err != nil {
+			t.Fatalf("AsDedupFilterTX(): %v", err)
+		}
+		f, err := dtx.DedupFilter(ctx)
+		if err != nil {
+			t.Fatalf("DedupFilter(): %v", err)
+		}
+		return f
+	}
+	checkSize := func(d *dedupFilters, want uint64) {
+		t.Helper()
+		l := d.logs[tree.TreeId]
+		if l.size != want {
+			t.Fatalf("filter size %d, want %d", l.size, want)
+		}
+		for i, hash := range hashes[:want] {
+			if !l.filter.MayContain(hash) {
+				t.Errorf("filter doesn't hold leaf %d", i)
+			}
+		}
+	}
+
+	d := newDedupFilters()
+	pass(d, 5)
+	checkSize(d, 5)
+	if f := stored(); f == nil || f.TreeSize != 5 {
+		t.Fatalf("stored filter %+v, want tree size 5", f)
+	}
+	// The filter isn't stored again within the persist interval.
+	pass(d, 2)
+	checkSize(d, 7)
+	if got := stored().TreeSize; got != 5 {
+		t.Errorf("stored filter of tree size %d, want 5", got)
+	}
+	// Leaves integrated while the filter isn't maintained are backfilled
+	// from the stored filter, a batch per pass.
+	pass(nil, 4)
+	d = newDedupFilters()
+	ts.Set(ts.Now().Add(time.Hour))
+	pass(d, 1)
+	checkSize(d, 8)
+	pass(d, 0)
+	checkSize(d, 11)
+	pass(d, 0)
+	checkSize(d, 12)
+	pass(d, 2)
+	checkSize(d, 14)
+
+	// A filter ahead of the log is an error, and must be dropped.
+	d.logs[tree.TreeId].size = 20
+	res, err := IntegrateBatchWithResult(ctx, tree, 100, 0, 0, ts, ls, quota.Noop(), false)
+	if err != nil {
+		t.Fatalf("IntegrateBatchWithResult(): %v", err)
+	}
+	if err := d.update(ctx, tree, res, info, ls); err == nil {
+		t.Error("update() of filter ahead of the log succeeded, want error")
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/google/trillian/types"
+	"github.com/google/trillian/util/shadow"
+)
+
+// frontierCache keeps the hashes of the compact range covering each tree,
+// i.e. the right edge of the tree, as of the last sequencing pass, so that
+// the next pass doesn't have to read them from storage.
+//
+// An entry is only used if it matches the size and root hash of the latest
+// root in storage, so passes made by another signer, e.g. after a mastership
+// change, make it miss rather than go wrong.
+type frontierCache struct {
+	mu     sync.Mutex
+	ranges map[int64]frontier
+
+	// shadow checks on a sample of cache hits that the cached range matches
+	// the one in storage.
+	shadow *shadow.Experiment
+}
+
+type frontier struct {
+	size     uint64
+	rootHash []byte
+	hashes   [][]byte
+}
+
+func newFrontierCache() *frontierCache {
+	return &frontierCache{
+		ranges: make(map[int64]frontier),
+		shadow: shadow.New("frontier_cache", 0),
+	}
+}
+
+// get returns the compact range hashes of the tree for the given root, or
+// nil if they aren't cached. It is safe to call on a nil cache.
+func (c *frontierCache) get(treeID int64, root *types.LogRootV1) [][]byte {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	f, ok := c.ranges[treeID]
+	if !ok || f.size != root.TreeSize || !bytes.Equal(f.rootHash, root.RootHash) {
+		return nil
+	}
+	return append([][]byte(nil), f.hashes...)
+}
+
+// put caches the compact range hashes of the tree for the given root. It is
+// safe to call on a nil cache.
+func (c *frontierCache) put(treeID int64, root *types.LogRootV1, hashes [][]byte) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ranges[treeID] = frontier{
+		size:     root.TreeSize,
+		rootHash: root.RootHash,
+		hashes:   append([][]byte(nil), hashes...),
+	}
+}
+
+// drop forgets the cached hashes of the tree. It is safe to call on a nil
+// cache.
+func (c *frontierCache) drop(treeID int64) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.ranges, treeID)
+}
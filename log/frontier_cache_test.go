@@ -0,0 +1,193 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/quota"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/storage/tree"
+	"github.com/google/trillian/util/clock"
+	"github.com/transparency-dev/merkle/compact"
+	"github.com/transparency-dev/merkle/rfc6962"
+)
+
+// nodeReadCountingStorage wraps a LogStorage, and counts the transactions
+// which read Merkle nodes.
+type nodeReadCountingStorage struct {
+	storage.LogStorage
+	reads int
+}
+
+func (n *nodeReadCountingStorage) ReadWriteTransaction(ctx context.Context, tree *trillian.Tree, f storage.LogTXFunc) error {
+	return n.LogStorage.ReadWriteTransaction(ctx, tree, func(ctx context.Context, tx storage.LogTreeTX) error {
+		return f(ctx, &nodeReadCountingTX{LogTreeTX: tx, reads: &n.reads})
+	})
+}
+
+type nodeReadCountingTX struct {
+	storage.LogTreeTX
+	reads *int
+}
+
+func (n *nodeReadCountingTX) GetMerkleNodes(ctx context.Context, ids []compact.NodeID) ([]tree.Node, error) {
+	*n.reads++
+	return n.LogTreeTX.GetMerkleNodes(ctx, ids)
+}
+
+func TestIntegrateBatchFrontierCache(t *testing.T) {
+	InitMetrics(nil)
+	ctx := context.Background()
+	tree, ms := newMemoryLog(ctx, t)
+	ls := &nodeReadCountingStorage{LogStorage: ms}
+	ts := clock.NewFake(time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC))
+	fc := newFrontierCache()
+
+	// integrate queues n leaves and integrates them with the given cache.
+	integrate := func(n int, fc *frontierCache) *BatchResult {
+		t.Helper()
+		var leaves []*trillian.LogLeaf
+		for i := 0; i < n; i++ {
+			data := []byte(fmt.Sprintf("leaf %d-%d", ts.Now().UnixNano(), i))
+			hash := sha256.Sum256(data)
+			leaves = append(leaves, &trillian.LogLeaf{
+				LeafValue:        data,
+				LeafIdentityHash: hash[:],
+				MerkleLeafHash:   rfc6962.DefaultHasher.HashLeaf(data),
+			})
+		}
+		if _, err := ls.QueueLeaves(ctx, tree, leaves, ts.Now()); err != nil {
+			t.Fatalf("QueueLeaves(): %v", err)
+		}
+		ts.Set(ts.Now().Add(time.Second))
+		res, err := integrateBatch(ctx, tree, 100, 0, 0, ts, ls, quota.Noop(), false /* dryRun */, fc)
+		if err != nil {
+			t.Fatalf("integrateBatch(): %v", err)
+		}
+		return res
+	}
+
+	// The tree is empty, so there is nothing to read.
+	integrate(3, fc)
+	if got, want := ls.reads, 0; got != want {
+		t.Errorf("node reads after first pass: %d, want %d", got, want)
+	}
+	// The following passes reuse the range of the previous one.
+	integrate(5, fc)
+	integrate(1, fc)
+	if got, want := ls.reads, 0; got != want {
+		t.Errorf("node reads after cached passes: %d, want %d", got, want)
+	}
+
+	// A pass which doesn't use the cache makes the cached range stale, which
+	// makes the next pass fall back to storage.
+	integrate(2, nil)
+	reads := ls.reads
+	res := integrate(4, fc)
+	if got, want := ls.reads, reads+1; got != want {
+		t.Errorf("node reads after stale cache: %d, want %d", got, want)
+	}
+
+	// The roots built from cached ranges match the tree in storage.
+	reads = ls.reads
+	check := integrate(1, nil)
+	if got, want := ls.reads, reads+1; got != want {
+		t.Errorf("node reads without cache: %d, want %d", got, want)
+	}
+	if got, want := check.OldRoot.RootHash, res.NewRoot.RootHash; string(got) != string(want) {
+		t.Errorf("root hash from storage %x, cached pass built %x", got, want)
+	}
+}
+
+func TestFrontierCacheDryRun(t *testing.T) {
+	InitMetrics(nil)
+	ctx := context.Background()
+	tree, ls := newMemoryLog(ctx, t)
+	ts := clock.NewFake(time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC))
+	fc := newFrontierCache()
+
+	data := []byte("leaf")
+	hash := sha256.Sum256(data)
+	leaf := &trillian.LogLeaf{LeafValue: data, LeafIdentityHash: hash[:], MerkleLeafHash: rfc6962.DefaultHasher.HashLeaf(data)}
+	if _, err := ls.QueueLeaves(ctx, tree, []*trillian.LogLeaf{leaf}, ts.Now()); err != nil {
+		t.Fatalf("QueueLeaves(): %v", err)
+	}
+	ts.Set(ts.Now().Add(time.Second))
+	res, err := integrateBatch(ctx, tree, 10, 0, 0, ts, ls, quota.Noop(), true /* dryRun */, fc)
+	if err != nil {
+		t.Fatalf("integrateBatch(): %v", err)
+	}
+	if hashes := fc.get(tree.TreeId, res.NewRoot); hashes != nil {
+		t.Errorf("dry run cached hashes %x for a root which wasn't committed", hashes)
+	}
+}
+
+func TestFrontierCacheShadow(t *testing.T) {
+	InitMetrics(nil)
+	ctx := context.Background()
+	tree, ms := newMemoryLog(ctx, t)
+	ls := &nodeReadCountingStorage{LogStorage: ms}
+	ts := clock.NewFake(time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC))
+	fc := newFrontierCache()
+	fc.shadow.SetSampleRate(1)
+
+	for i := 0; i < 3; i++ {
+		data := []byte(fmt.Sprintf("leaf %d", i))
+		hash := sha256.Sum256(data)
+		leaf := &trillian.LogLeaf{LeafValue: data, LeafIdentityHash: hash[:], MerkleLeafHash: rfc6962.DefaultHasher.HashLeaf(data)}
+		if _, err := ls.QueueLeaves(ctx, tree, []*trillian.LogLeaf{leaf}, ts.Now()); err != nil {
+			t.Fatalf("QueueLeaves(): %v", err)
+		}
+		ts.Set(ts.Now().Add(time.Second))
+		if _, err := integrateBatch(ctx, tree, 10, 0, 0, ts, ls, quota.Noop(), false /* dryRun */, fc); err != nil {
+			t.Fatalf("integrateBatch(): %v", err)
+		}
+	}
+	// Every cache hit, i.e. all but the first pass, is checked against storage.
+	if got, want := ls.reads, 2; got != want {
+		t.Errorf("node reads: %d, want %d", got, want)
+	}
+	if d := fc.shadow.Divergences(); len(d) != 0 {
+		t.Errorf("Divergences(): %v, want none", d)
+	}
+}
+
+func TestDiffCompactRanges(t *testing.T) {
+	fact := compact.RangeFactory{Hash: hashChildren}
+	newRange := func(leaves ...string) *compact.Range {
+		cr := fact.NewEmptyRange(0)
+		for _, l := range leaves {
+			if err := cr.Append(rfc6962.DefaultHasher.HashLeaf([]byte(l)), nil); err != nil {
+				t.Fatalf("Append(): %v", err)
+			}
+		}
+		return cr
+	}
+	if d := diffCompactRanges(newRange("a", "b", "c"), newRange("a", "b", "c")); d != "" {
+		t.Errorf("diffCompactRanges() of equal ranges: %q", d)
+	}
+	if d := diffCompactRanges(newRange("a", "b", "c"), newRange("a", "b", "x")); d == "" {
+		t.Error("diffCompactRanges() of different ranges: empty")
+	}
+	if d := diffCompactRanges(newRange("a", "b", "c"), newRange("a", "b")); d == "" {
+		t.Error("diffCompactRanges() of ranges of different sizes: empty")
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/google/trillian"
+	"github.com/transparency-dev/merkle/compact"
+	"github.com/transparency-dev/merkle/rfc6962"
+)
+
+func TestHashChildren(t *testing.T) {
+	h := rfc6962.DefaultHasher
+	for _, tc := range []struct {
+		desc string
+		l, r []byte
+	}{
+		{desc: "sha256", l: h.HashLeaf([]byte("l")), r: h.HashLeaf([]byte("r"))},
+		{desc: "empty", l: nil, r: nil},
+		{desc: "short", l: []byte("l"), r: h.HashLeaf([]byte("r"))},
+		{desc: "long", l: bytes.Repeat([]byte("l"), 64), r: bytes.Repeat([]byte("r"), 64)},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got, want := hashChildren(tc.l, tc.r), h.HashChildren(tc.l, tc.r); !bytes.Equal(got, want) {
+				t.Errorf("hashChildren()=%x, want %x", got, want)
+			}
+		})
+	}
+}
+
+// BenchmarkUpdateCompactRange measures the hashing part of integrating large
+// batches into a tree, with the generic and the specialised node hasher.
+func BenchmarkUpdateCompactRange(b *testing.B) {
+	for _, hasher := range []struct {
+		name string
+		hash compact.HashFn
+	}{
+		{name: "rfc6962", hash: rfc6962.DefaultHasher.HashChildren},
+		{name: "sha256", hash: hashChildren},
+	} {
+		for _, batch := range []int{1000, 10000, 100000} {
+			leaves := make([]*trillian.LogLeaf, batch)
+			for i := range leaves {
+				leaves[i] = &trillian.LogLeaf{
+					LeafIndex:      int64(12345 + i),
+					MerkleLeafHash: rfc6962.DefaultHasher.HashLeaf([]byte(fmt.Sprintf("leaf %d", i))),
+				}
+			}
+			fact := compact.RangeFactory{Hash: hasher.hash}
+			// Start from a non-empty tree, like most passes do.
+			base := fact.NewEmptyRange(0)
+			for i := 0; i < 12345; i++ {
+				if err := base.Append(rfc6962.DefaultHasher.HashLeaf([]byte(fmt.Sprintf("base %d", i))), nil); err != nil {
+					b.Fatalf("Append(): %v", err)
+				}
+			}
+			b.Run(fmt.Sprintf("%s/batch=%d", hasher.name, batch), func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					cr, err := fact.NewRange(0, base.End(), append([][]byte(nil), base.Hashes()...))
+					if err != nil {
+						b.Fatalf("NewRange(): %v", err)
+					}
+					if _, _, err := updateCompactRange(cr, leaves, "bench"); err != nil {
+						b.Fatalf("updateCompactRange(): %v", err)
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/types"
+	"github.com/google/trillian/util/clock"
+	"github.com/transparency-dev/merkle/rfc6962"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ImportLeaves appends a batch of leaves exported from another log to the
+// tree, in a single transaction, and stores a new root for it. The leaves must
+// continue the tree, i.e. have consecutive indices starting at its current
+// size, and keep the timestamps and hashes they were exported with. Leaves
+// whose data was pruned are imported with their stored hashes.
+//
+// A log which isn't initialised yet is initialised first. Returns the new root
+// of the tree.
+func ImportLeaves(ctx context.Context, tree *trillian.Tree, leaves []*trillian.LogLeaf, ts clock.TimeSource, ls storage.LogStorage) (*types.LogRootV1, error) {
+	for _, leaf := range leaves {
+		pruned := len(leaf.LeafValue) == 0 && leaf.ExtraData == nil
+		if !pruned && !bytes.Equal(rfc6962.DefaultHasher.HashLeaf(leaf.LeafValue), leaf.MerkleLeafHash) {
+			return nil, status.Errorf(codes.InvalidArgument, "leaf %d has a Merkle leaf hash which doesn't match its value", leaf.LeafIndex)
+		}
+	}
+	root, err := importLeaves(ctx, tree, leaves, ts, ls)
+	if err == storage.ErrTreeNeedsInit {
+		// Tree nodes are only stored from the revision after the first root.
+		if _, err := importLeaves(ctx, tree, nil, ts, ls); err != nil {
+			return nil, err
+		}
+		root, err = importLeaves(ctx, tree, leaves, ts, ls)
+	}
+	return root, err
+}
+
+// importLeaves is ImportLeaves, but returns storage.ErrTreeNeedsInit if there
+// are leaves and the log isn't initialised.
+func importLeaves(ctx context.Context, tree *trillian.Tree, leaves []*trillian.LogLeaf, ts clock.TimeSource, ls storage.LogStorage) (*types.LogRootV1, error) {
+	label := strconv.FormatInt(tree.TreeId, 10)
+	var newRoot *types.LogRootV1
+	err := ls.ReadWriteTransaction(ctx, tree, func(ctx context.Context, tx storage.LogTreeTX) error {
+		itx, err := storage.AsImportTX(tx)
+		if err != nil {
+			return err
+		}
+		var root types.LogRootV1
+		slr, err := tx.LatestSignedLogRoot(ctx)
+		if err != nil && err != storage.ErrTreeNeedsInit {
+			return err
+		}
+		if slr.GetLogRoot() == nil {
+			if len(leaves) > 0 {
+				return storage.ErrTreeNeedsInit
+			}
+		} else if err := root.UnmarshalBinary(slr.LogRoot); err != nil {
+			return fmt.Errorf("%v: failed to unmarshal latest root: %v", tree.TreeId, err)
+		}
+		if len(leaves) > 0 {
+			if got, want := leaves[0].LeafIndex, int64(root.TreeSize); got != want {
+				return status.Errorf(codes.FailedPrecondition, "leaves start at index %d, want tree size %d", got, want)
+			}
+		}
+
+		cr, err := initCompactRangeFromStorage(ctx, &root, tx)
+		if err != nil {
+			return fmt.Errorf("%v: compact range init failed: %v", tree.TreeId, err)
+		}
+		nodeMap, rootHash, err := updateCompactRange(cr, leaves, label)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		if err := itx.ImportLeaves(ctx, leaves); err != nil {
+			return err
+		}
+		if err := addDailyStats(ctx, tx, leaves); err != nil {
+			return fmt.Errorf("%v: failed to update daily stats: %v", tree.TreeId, err)
+		}
+		if err := tx.SetMerkleNodes(ctx, buildNodesFromNodeMap(nodeMap)); err != nil {
+			return fmt.Errorf("%v: failed to set Merkle nodes: %v", tree.TreeId, err)
+		}
+
+		if cr.End() == 0 {
+			rootHash = rfc6962.DefaultHasher.EmptyRoot()
+		}
+		// The root timestamps must increase, even if the clock doesn't.
+		timestamp := uint64(ts.Now().UnixNano())
+		if timestamp <= root.TimestampNanos {
+			timestamp = root.TimestampNanos + 1
+		}
+		newRoot = &types.LogRootV1{RootHash: rootHash, TimestampNanos: timestamp, TreeSize: cr.End()}
+		logRoot, err := newRoot.MarshalBinary()
+		if err != nil {
+			return fmt.Errorf("%v: failed to marshal root: %v", tree.TreeId, err)
+		}
+		return tx.StoreSignedLogRoot(ctx, &trillian.SignedLogRoot{LogRoot: logRoot})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newRoot, nil
+}
@@ -0,0 +1,120 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/util/clock"
+	"github.com/transparency-dev/merkle/compact"
+	"github.com/transparency-dev/merkle/rfc6962"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// exportedLeaves returns leaves with the given indices, as exported from a
+// log. Leaves with an index in pruned have no data.
+func exportedLeaves(begin, end int64, pruned ...int64) []*trillian.LogLeaf {
+	var leaves []*trillian.LogLeaf
+	for i := begin; i < end; i++ {
+		data := []byte(fmt.Sprintf("leaf %d", i))
+		hash := sha256.Sum256(data)
+		leaf := &trillian.LogLeaf{
+			LeafValue:          data,
+			LeafIdentityHash:   hash[:],
+			MerkleLeafHash:     rfc6962.DefaultHasher.HashLeaf(data),
+			LeafIndex:          i,
+			QueueTimestamp:     timestamppb.New(time.Unix(1000, 0)),
+			IntegrateTimestamp: timestamppb.New(time.Unix(2000, 0)),
+		}
+		for _, p := range pruned {
+			if p == i {
+				leaf.LeafValue = nil
+			}
+		}
+		leaves = append(leaves, leaf)
+	}
+	return leaves
+}
+
+func TestImportLeaves(t *testing.T) {
+	ctx := context.Background()
+	tree, ls := newMemoryLog(ctx, t)
+	// The clock doesn't move, but the root timestamps must.
+	fakeTime := clock.NewFake(time.Unix(5000, 0))
+
+	rf := &compact.RangeFactory{Hash: rfc6962.DefaultHasher.HashChildren}
+	want := rf.NewEmptyRange(0)
+	var lastTimestamp uint64
+	for _, batch := range [][]*trillian.LogLeaf{exportedLeaves(0, 5, 2), exportedLeaves(5, 11)} {
+		root, err := ImportLeaves(ctx, tree, batch, fakeTime, ls)
+		if err != nil {
+			t.Fatalf("ImportLeaves(): %v", err)
+		}
+		for _, leaf := range batch {
+			if err := want.Append(leaf.MerkleLeafHash, nil); err != nil {
+				t.Fatalf("Append(): %v", err)
+			}
+		}
+		wantHash, err := want.GetRootHash(nil)
+		if err != nil {
+			t.Fatalf("GetRootHash(): %v", err)
+		}
+		if root.TreeSize != want.End() || !bytes.Equal(root.RootHash, wantHash) {
+			t.Errorf("ImportLeaves(): root %+v, want size %d and hash %x", root, want.End(), wantHash)
+		}
+		if root.TimestampNanos <= lastTimestamp {
+			t.Errorf("ImportLeaves(): root timestamp %d, want > %d", root.TimestampNanos, lastTimestamp)
+		}
+		lastTimestamp = root.TimestampNanos
+	}
+	if got, want := prunedLeaves(ctx, t, tree, ls, 4), []bool{false, false, true, false}; fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("pruned leaves: %v, want %v", got, want)
+	}
+
+	badHash := exportedLeaves(11, 12)
+	badHash[0].LeafValue = []byte("changed")
+	for _, tc := range []struct {
+		desc     string
+		leaves   []*trillian.LogLeaf
+		wantCode codes.Code
+	}{
+		{desc: "gap", leaves: exportedLeaves(12, 13), wantCode: codes.FailedPrecondition},
+		{desc: "overlap", leaves: exportedLeaves(10, 12), wantCode: codes.FailedPrecondition},
+		{desc: "badHash", leaves: badHash, wantCode: codes.InvalidArgument},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			if _, err := ImportLeaves(ctx, tree, tc.leaves, fakeTime, ls); status.Code(err) != tc.wantCode {
+				t.Errorf("ImportLeaves(): %v, want code %v", err, tc.wantCode)
+			}
+		})
+	}
+}
+
+func TestImportLeavesUnsupported(t *testing.T) {
+	ctx := context.Background()
+	tree, ls := newMemoryLog(ctx, t)
+	_, err := ImportLeaves(ctx, tree, exportedLeaves(0, 1), clock.System, noRetentionStorage{ls})
+	if got, want := status.Code(err), codes.Unimplemented; got != want {
+		t.Errorf("ImportLeaves(): %v, want code %v", err, want)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/google/trillian/extension"
+	"github.com/google/trillian/util/election2"
+	"github.com/google/trillian/util/election2/electionpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Masters serves the current masters of logs with the electionpb.Masters
+// service, as recorded by the election factory of the registry.
+type Masters struct {
+	registry extension.Registry
+}
+
+// NewMasters creates a Masters which looks up masters with the election
+// factory, and the active logs with the log storage of the registry.
+func NewMasters(registry extension.Registry) *Masters {
+	return &Masters{registry: registry}
+}
+
+// ListMasters implements electionpb.MastersServer.ListMasters.
+func (m *Masters) ListMasters(ctx context.Context, req *electionpb.ListMastersRequest) (*electionpb.ListMastersResponse, error) {
+	mr, ok := m.registry.ElectionFactory.(election2.MasterReader)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "election factory can't look up masters")
+	}
+	ids := req.GetTreeIds()
+	if len(ids) == 0 {
+		var err error
+		if ids, err = m.registry.LogStorage.GetActiveLogIDs(ctx); err != nil {
+			return nil, err
+		}
+	}
+	masters := make([]*electionpb.Master, 0, len(ids))
+	for _, id := range ids {
+		// Elections are keyed by the decimal tree ID, see OperationManager.
+		instanceID, err := mr.Master(ctx, strconv.FormatInt(id, 10))
+		if err != nil {
+			return nil, status.Errorf(codes.Unavailable, "failed to look up master of tree %d: %v", id, err)
+		}
+		masters = append(masters, &electionpb.Master{TreeId: id, InstanceId: instanceID})
+	}
+	return &electionpb.ListMastersResponse{Masters: masters}, nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/trillian/extension"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/util/election2"
+	"github.com/google/trillian/util/election2/electionpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+var _ electionpb.MastersServer = &Masters{}
+
+// fakeMasterReader is an election factory whose masters are held in a map
+// from resource IDs to instance IDs.
+type fakeMasterReader struct {
+	election2.NoopFactory
+	masters map[string]string
+}
+
+func (f fakeMasterReader) Master(ctx context.Context, resourceID string) (string, error) {
+	if resourceID == "666" {
+		return "", errors.New("boom")
+	}
+	return f.masters[resourceID], nil
+}
+
+func TestListMasters(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ls := storage.NewMockLogStorage(ctrl)
+	ls.EXPECT().GetActiveLogIDs(gomock.Any()).Return([]int64{1, 2}, nil)
+	m := NewMasters(extension.Registry{
+		LogStorage:      ls,
+		ElectionFactory: fakeMasterReader{masters: map[string]string{"1": "signer-a", "3": "signer-b"}},
+	})
+
+	for _, test := range []struct {
+		desc     string
+		ids      []int64
+		want     []*electionpb.Master
+		wantCode codes.Code
+	}{
+		{
+			desc: "active-logs",
+			want: []*electionpb.Master{{TreeId: 1, InstanceId: "signer-a"}, {TreeId: 2}},
+		},
+		{
+			desc: "requested-trees",
+			ids:  []int64{3, 1},
+			want: []*electionpb.Master{{TreeId: 3, InstanceId: "signer-b"}, {TreeId: 1, InstanceId: "signer-a"}},
+		},
+		{
+			desc:     "lookup-error",
+			ids:      []int64{1, 666},
+			wantCode: codes.Unavailable,
+		},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			resp, err := m.ListMasters(ctx, &electionpb.ListMastersRequest{TreeIds: test.ids})
+			if got := status.Code(err); got != test.wantCode {
+				t.Fatalf("ListMasters()=_, %v; want code %v", err, test.wantCode)
+			}
+			if err != nil {
+				return
+			}
+			if got, want := resp, (&electionpb.ListMastersResponse{Masters: test.want}); !proto.Equal(got, want) {
+				t.Errorf("ListMasters()=%v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestListMastersUnimplemented(t *testing.T) {
+	m := NewMasters(extension.Registry{ElectionFactory: election2.NoopFactory{}})
+	_, err := m.ListMasters(context.Background(), &electionpb.ListMastersRequest{TreeIds: []int64{1}})
+	if got, want := status.Code(err), codes.Unimplemented; got != want {
+		t.Errorf("ListMasters()=_, %v; want code %v", err, want)
+	}
+}
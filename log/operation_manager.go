@@ -89,6 +89,47 @@ type OperationInfo struct {
 	BatchSize int
 	// TimeSource should be used by the Operation to allow mocking for tests.
 	TimeSource clock.TimeSource
+	// PoisonLeafThreshold is the number of consecutive failed sequencing
+	// passes of a LOG tree after which the leaf causing them is looked for
+	// and quarantined. Zero disables leaf quarantine.
+	PoisonLeafThreshold int
+	// QueueStatsInterval is the minimum time between exports of the queue age
+	// metrics of a LOG tree, which are sampled during sequencing passes. Zero
+	// disables them.
+	QueueStatsInterval time.Duration
+	// MaxGCPause is the longest GC pause which sequencing passes should
+	// cause. If a pass sees a longer pause, the batch size of the following
+	// passes is halved, and then grows back towards BatchSize while the passes
+	// stay within the limits. Zero disables the check.
+	MaxGCPause time.Duration
+	// MaxHeapGrowth is like MaxGCPause, for the growth of the heap in bytes
+	// during a sequencing pass.
+	MaxHeapGrowth uint64
+	// ShadowSampleRate is the fraction of sequencing passes which also run
+	// the replaced implementation of the parts being migrated, in shadow, and
+	// record where it diverges, see package shadow. Currently this compares
+	// the compact ranges kept between passes with the ones in storage.
+	ShadowSampleRate float64
+	// BatchTuning, if not nil, enables tuning the batch size and the run
+	// interval of each LOG or PREORDERED_LOG tree to the latency of its
+	// passes and its backlog, within the given bounds. BatchSize and
+	// RunInterval are then the initial batch size and the shortest interval.
+	BatchTuning *BatchTuning
+	// DedupFilter, if not nil, enables maintaining a bloom filter of the
+	// identity hashes of the leaves of each LOG tree, which is persisted for
+	// the storage to skip duplicate lookups when queueing leaves.
+	DedupFilter *DedupFilterConfig
+	// BatchReports, if not nil, keeps the reports of the sequencing passes.
+	BatchReports *BatchReports
+	// RetentionInterval is the minimum time between garbage collection passes
+	// over a LOG or PREORDERED_LOG tree with a retention policy, which prune
+	// the data of leaves older than the policy allows. A pass which prunes a
+	// full batch is followed by another one with the next sequencing pass.
+	// Zero disables garbage collection.
+	RetentionInterval time.Duration
+	// RetentionBatchSize is the maximum number of leaves pruned by a garbage
+	// collection pass.
+	RetentionBatchSize int
 
 	// The following parameters govern the overall scheduling of Operations
 	// by a OperationManager.
@@ -102,6 +143,16 @@ type OperationInfo struct {
 	RunInterval time.Duration
 	// NumWorkers is the number of worker goroutines to run in parallel.
 	NumWorkers int
+	// SequencerWorkers, if positive, replaces the rounds over all logs, which
+	// wait for the slowest pass before the next round starts, with this many
+	// workers which each take the next due log as soon as they are free, in
+	// weighted round robin order. RunInterval then is the shortest time
+	// between the starts of passes over a log, Timeout applies to each pass,
+	// and NumWorkers is unused.
+	SequencerWorkers int
+	// TreeWeights holds the number of passes a log gets in each round of the
+	// SequencerWorkers, for logs which don't get the default of 1.
+	TreeWeights map[int64]int
 	// Timeout sets an optional timeout on each operation run.
 	// If unset, default to the value of DefaultTimeout.
 	Timeout time.Duration
@@ -129,6 +180,10 @@ type OperationManager struct {
 	lastHeld []int64
 	// idsMutex guards logNames and lastHeld fields.
 	idsMutex sync.Mutex
+
+	// deferredResignations holds the resignations which wait for the passes
+	// over their logs to finish, with SequencerWorkers.
+	deferredResignations []election.Resignation
 }
 
 // NewOperationManager creates a new OperationManager instance.
@@ -295,21 +350,29 @@ func (o *OperationManager) getLogsAndExecutePass(ctx context.Context) error {
 	runCtx, cancel := context.WithTimeout(ctx, o.info.Timeout)
 	defer cancel()
 
+	logIDs, err := o.heldLogs(ctx)
+	if err != nil {
+		return err
+	}
+	executePassForAll(runCtx, &o.info, o.logOperation, logIDs)
+	return nil
+}
+
+// heldLogs returns the active logs that this instance is master for.
+func (o *OperationManager) heldLogs(ctx context.Context) ([]int64, error) {
 	activeIDs, err := o.info.Registry.LogStorage.GetActiveLogIDs(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to list active log IDs: %v", err)
+		return nil, fmt.Errorf("failed to list active log IDs: %v", err)
 	}
 	// Find the logs we are master for, skipping those logs that are not active,
 	// e.g. deleted or FROZEN ones.
 	// TODO(pavelkalinnikov): Resign mastership for the inactive logs.
 	logIDs, err := o.masterFor(ctx, activeIDs)
 	if err != nil {
-		return fmt.Errorf("failed to determine log IDs we're master for: %v", err)
+		return nil, fmt.Errorf("failed to determine log IDs we're master for: %v", err)
 	}
 	o.updateHeldIDs(ctx, logIDs, activeIDs)
-
-	executePassForAll(runCtx, &o.info, o.logOperation, logIDs)
-	return nil
+	return logIDs, nil
 }
 
 // OperationSingle performs a single pass of the manager.
@@ -327,11 +390,16 @@ func (o *OperationManager) OperationSingle(ctx context.Context) {
 func (o *OperationManager) OperationLoop(ctx context.Context) {
 	glog.Infof("Log operation manager starting")
 
-	// Outer loop, runs until terminated.
-	for {
-		if err := o.operateOnce(ctx); err != nil {
-			glog.Infof("Log operation manager shutting down")
-			break
+	if o.info.SequencerWorkers > 0 {
+		o.dispatchLoop(ctx)
+		glog.Infof("Log operation manager shutting down")
+	} else {
+		// Outer loop, runs until terminated.
+		for {
+			if err := o.operateOnce(ctx); err != nil {
+				glog.Infof("Log operation manager shutting down")
+				break
+			}
 		}
 	}
 
@@ -344,6 +412,10 @@ func (o *OperationManager) OperationLoop(ctx context.Context) {
 	}
 
 	// Drain any remaining resignations which might have triggered.
+	for _, r := range o.deferredResignations {
+		resignations.Inc(r.ID)
+		r.Execute(ctx)
+	}
 	close(o.pendingResignations)
 	for r := range o.pendingResignations {
 		resignations.Inc(r.ID)
@@ -402,6 +474,117 @@ func (o *OperationManager) operateOnce(ctx context.Context) error {
 	return nil
 }
 
+// dispatchLoop runs passes over the logs this instance is master for on
+// SequencerWorkers workers, scheduled by a treeScheduler, until ctx is done.
+func (o *OperationManager) dispatchLoop(ctx context.Context) {
+	glog.Infof("Running scheduler with %d worker(s)", o.info.SequencerWorkers)
+	sched := newTreeScheduler(o.info.RunInterval, o.info.TreeWeights)
+	sem := semaphore.NewWeighted(int64(o.info.SequencerWorkers))
+	var wg sync.WaitGroup
+	for {
+		if err := sem.Acquire(ctx, 1); err != nil {
+			break // Terminate because the context is canceled.
+		}
+		logID, err := o.nextLog(ctx, sched)
+		if err != nil {
+			sem.Release(1)
+			break
+		}
+		wg.Add(1)
+		go func(logID int64) {
+			defer wg.Done()
+			defer sem.Release(1)
+			defer sched.done(logID)
+			passCtx, cancel := context.WithTimeout(ctx, o.info.Timeout)
+			defer cancel()
+			if err := executePass(passCtx, &o.info, o.logOperation, logID); err != nil {
+				glog.Errorf("ExecutePass(%v) failed: %v", logID, err)
+			}
+		}(logID)
+	}
+	// Wait for the passes in flight to finish.
+	wg.Wait()
+}
+
+// nextLog waits until a log is due for a pass, and returns it. Each round of
+// the scheduler starts with the logs that this instance is master for, after
+// executing the pending resignations of the logs which aren't being passed
+// over. Returns an error only if ctx is done.
+func (o *OperationManager) nextLog(ctx context.Context, sched *treeScheduler) (int64, error) {
+	started := false
+	for {
+		wake := sched.changed()
+		logID, ok, wait := sched.take(o.info.TimeSource.Now())
+		if ok {
+			return logID, nil
+		}
+		// If a round which just started is over, all its logs are running.
+		if wait == 0 && !started {
+			o.executeResignations(ctx, sched)
+			logIDs, err := o.heldLogs(ctx)
+			if err != nil && ctx.Err() == nil {
+				glog.Errorf("failed to execute operation on logs: %v", err)
+			}
+			if len(logIDs) > 0 {
+				sched.startRound(logIDs)
+				started = true
+				continue
+			}
+			// Look for logs again later, even if they may run back to back.
+			if wait = o.info.RunInterval; wait <= 0 {
+				wait = time.Second
+			}
+		}
+		started = false
+
+		if err := o.waitForPass(ctx, wake, wait); err != nil {
+			return 0, err
+		}
+	}
+}
+
+// waitForPass waits until wake is closed, i.e. a pass finishes, or until wait
+// has passed if it's positive.
+// Returns an error only if ctx is done.
+func (o *OperationManager) waitForPass(ctx context.Context, wake <-chan struct{}, wait time.Duration) error {
+	var timeout <-chan time.Time
+	if wait > 0 {
+		timer := o.info.TimeSource.NewTimer(wait)
+		defer timer.Stop()
+		timeout = timer.Chan()
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-wake:
+	case <-timeout:
+	}
+	return nil
+}
+
+// executeResignations executes the pending resignations, except for those of
+// logs which are being passed over, which are deferred until after the pass.
+func (o *OperationManager) executeResignations(ctx context.Context, sched *treeScheduler) {
+	pending := o.deferredResignations
+	o.deferredResignations = nil
+	for done := false; !done; {
+		select {
+		case r := <-o.pendingResignations:
+			pending = append(pending, r)
+		default:
+			done = true
+		}
+	}
+	for _, r := range pending {
+		if logID, err := strconv.ParseInt(r.ID, 10, 64); err == nil && sched.isRunning(logID) {
+			o.deferredResignations = append(o.deferredResignations, r)
+			continue
+		}
+		resignations.Inc(r.ID)
+		r.Execute(ctx)
+	}
+}
+
 // executePassForAll runs ExecutePass of the given operation for each of the
 // passed-in logs, allowing up to a configurable number of parallel operations.
 func executePassForAll(ctx context.Context, info *OperationInfo, op Operation, logIDs []int64) {
@@ -447,3 +447,46 @@ type failureFactory struct{}
 func (ff failureFactory) NewElection(ctx context.Context, treeID string) (election2.Election, error) {
 	return nil, errors.New("injected failure")
 }
+
+func TestOperationManagerSequencerWorkers(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const slowLog, fastLog = int64(451), int64(145)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	fakeStorage, mockAdmin := setupLogIDs(ctrl, map[int64]string{slowLog: "SlowLog", fastLog: "FastLog"})
+	registry := extension.Registry{
+		LogStorage:   fakeStorage,
+		AdminStorage: mockAdmin,
+	}
+
+	// The pass over the slow log only finishes once the fast log has had
+	// three passes, which it couldn't if it waited for the slow one.
+	var fastPasses int64
+	released := make(chan struct{})
+	mockLogOp := NewMockOperation(ctrl)
+	mockLogOp.EXPECT().ExecutePass(gomock.Any(), slowLog, gomock.Any()).DoAndReturn(func(ctx context.Context, _ int64, _ *OperationInfo) (int, error) {
+		select {
+		case <-released:
+		case <-ctx.Done():
+			t.Error("slow pass timed out before the fast log had three passes")
+		}
+		cancel()
+		return 1, nil
+	})
+	mockLogOp.EXPECT().ExecutePass(gomock.Any(), fastLog, gomock.Any()).MinTimes(3).DoAndReturn(func(context.Context, int64, *OperationInfo) (int, error) {
+		if atomic.AddInt64(&fastPasses, 1) == 3 {
+			close(released)
+		}
+		return 1, nil
+	})
+
+	info := defaultOperationInfo(registry)
+	info.TimeSource = clock.System
+	info.RunInterval = time.Millisecond
+	info.Timeout = 10 * time.Second
+	info.SequencerWorkers = 2
+	lom := NewOperationManager(info, mockLogOp)
+	lom.OperationLoop(ctx)
+}
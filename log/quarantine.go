@@ -0,0 +1,166 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/quota"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/util/clock"
+)
+
+// IsolatePoisonLeaf looks for a queued leaf of a LOG tree which makes
+// sequencing passes of up to limit leaves fail, and quarantines it so that the
+// rest of the queue can be integrated.
+//
+// The leaf is found by bisecting the batch size with dry run passes: the
+// candidate is the last leaf of the smallest batch which fails. It is only
+// quarantined if the failure is confirmed to be caused by it, i.e. a pass
+// over the batch without the candidate succeeds, and a pass over the
+// candidate alone fails. Otherwise the error of the full batch is returned.
+// Returns nil if a full batch can be integrated, i.e. the failure didn't
+// reproduce.
+func IsolatePoisonLeaf(ctx context.Context, tree *trillian.Tree, limit int, guardWindow, maxRootDurationInterval time.Duration, ts clock.TimeSource, ls storage.LogStorage, qm quota.Manager) (*trillian.QuarantinedLeaf, error) {
+	if tree.TreeType != trillian.TreeType_LOG {
+		return nil, fmt.Errorf("%v: leaf quarantine not supported for TreeType %v", tree.TreeId, tree.TreeType)
+	}
+
+	// fails does a dry run pass of n leaves, and returns its error.
+	fails := func(ls storage.LogStorage, n int) error {
+		_, err := IntegrateBatchWithResult(ctx, tree, n, guardWindow, maxRootDurationInterval, ts, ls, qm, true /* dryRun */)
+		return err
+	}
+	failure := fails(ls, limit)
+	if failure == nil {
+		return nil, nil
+	}
+	if errors.Is(failure, storage.ErrTreeNeedsInit) {
+		return nil, failure
+	}
+	cause := failure
+	lo, hi := 1, limit
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		if err := fails(ls, mid); err != nil {
+			hi, cause = mid, err
+		} else {
+			lo = mid + 1
+		}
+	}
+
+	leaves, err := peekQueue(ctx, tree, hi, ts.Now().Add(-guardWindow), ls)
+	if err != nil {
+		return nil, err
+	}
+	if len(leaves) < hi {
+		return nil, fmt.Errorf("%v: queue has %d leaves, failing batch had %d", tree.TreeId, len(leaves), hi)
+	}
+	poison := leaves[hi-1]
+	isPoison := func(l *trillian.LogLeaf) bool { return bytes.Equal(l.LeafIdentityHash, poison.LeafIdentityHash) }
+
+	// The batch without the candidate must integrate. If the candidate is at
+	// the head of the queue, the batch is the leaf after it instead, so that
+	// a failure which doesn't depend on the leaves can't blame the first one.
+	without := hi
+	if without < 2 {
+		without = 2
+	}
+	if err := fails(&filteredStorage{LogStorage: ls, lookahead: without, keep: func(l *trillian.LogLeaf) bool { return !isPoison(l) }}, without); err != nil {
+		return nil, failure
+	}
+	// The candidate alone must fail.
+	if err := fails(&filteredStorage{LogStorage: ls, lookahead: hi, keep: isPoison}, 1); err == nil {
+		return nil, failure
+	}
+
+	var quarantined []*trillian.QuarantinedLeaf
+	err = ls.ReadWriteTransaction(ctx, tree, func(ctx context.Context, tx storage.LogTreeTX) error {
+		qtx, err := storage.AsQuarantineTX(tx)
+		if err != nil {
+			return err
+		}
+		reason := fmt.Sprintf("sequencer: %v", cause)
+		quarantined, err = qtx.QuarantineLeaves(ctx, [][]byte{poison.LeafIdentityHash}, reason, ts.Now())
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%v: failed to quarantine leaf %x: %v", tree.TreeId, poison.LeafIdentityHash, err)
+	}
+	if len(quarantined) == 0 {
+		// The leaf left the queue in the meantime.
+		return nil, nil
+	}
+	seqQuarantined.Inc(strconv.FormatInt(tree.TreeId, 10))
+	return quarantined[0], nil
+}
+
+// peekQueue returns up to limit leaves from the head of the queue, without
+// dequeueing them.
+func peekQueue(ctx context.Context, tree *trillian.Tree, limit int, cutoff time.Time, ls storage.LogStorage) ([]*trillian.LogLeaf, error) {
+	var leaves []*trillian.LogLeaf
+	err := ls.ReadWriteTransaction(ctx, tree, func(ctx context.Context, tx storage.LogTreeTX) error {
+		var err error
+		if leaves, err = tx.DequeueLeaves(ctx, limit, cutoff); err != nil {
+			return err
+		}
+		// Roll back, so that the leaves stay in the queue.
+		return errDryRun
+	})
+	if !errors.Is(err, errDryRun) {
+		return nil, fmt.Errorf("%v: failed to read queue: %v", tree.TreeId, err)
+	}
+	return leaves, nil
+}
+
+// filteredStorage wraps a LogStorage so that sequencing passes only dequeue
+// the leaves accepted by keep among the first lookahead queued leaves. It is
+// used for dry runs over a selection of the head of the queue.
+type filteredStorage struct {
+	storage.LogStorage
+	lookahead int
+	keep      func(*trillian.LogLeaf) bool
+}
+
+func (s *filteredStorage) ReadWriteTransaction(ctx context.Context, tree *trillian.Tree, f storage.LogTXFunc) error {
+	return s.LogStorage.ReadWriteTransaction(ctx, tree, func(ctx context.Context, tx storage.LogTreeTX) error {
+		return f(ctx, &filteredTX{LogTreeTX: tx, s: s})
+	})
+}
+
+type filteredTX struct {
+	storage.LogTreeTX
+	s *filteredStorage
+}
+
+func (t *filteredTX) DequeueLeaves(ctx context.Context, limit int, cutoff time.Time) ([]*trillian.LogLeaf, error) {
+	leaves, err := t.LogTreeTX.DequeueLeaves(ctx, t.s.lookahead, cutoff)
+	if err != nil {
+		return nil, err
+	}
+	kept := make([]*trillian.LogLeaf, 0, len(leaves))
+	for _, l := range leaves {
+		if len(kept) < limit && t.s.keep(l) {
+			kept = append(kept, l)
+		}
+	}
+	return kept, nil
+}
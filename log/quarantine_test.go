@@ -0,0 +1,192 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/quota"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/storage/memory"
+	stestonly "github.com/google/trillian/storage/testonly"
+	"github.com/google/trillian/types"
+	"github.com/google/trillian/util/clock"
+	"github.com/transparency-dev/merkle/rfc6962"
+	"google.golang.org/protobuf/proto"
+)
+
+// poisonStorage wraps a LogStorage, and fails every sequencing pass which
+// includes the leaf with the poison identity hash, or any leaf if failAll is
+// set. Passes without it succeed without updating the queue, which is enough
+// to drive the bisection.
+type poisonStorage struct {
+	storage.LogStorage
+	poison  []byte
+	failAll bool
+}
+
+func (p *poisonStorage) ReadWriteTransaction(ctx context.Context, tree *trillian.Tree, f storage.LogTXFunc) error {
+	return p.LogStorage.ReadWriteTransaction(ctx, tree, func(ctx context.Context, tx storage.LogTreeTX) error {
+		qtx, err := storage.AsQuarantineTX(tx)
+		if err != nil {
+			return err
+		}
+		return f(ctx, &poisonTX{LogTreeTX: tx, QuarantineTX: qtx, poison: p.poison, failAll: p.failAll})
+	})
+}
+
+type poisonTX struct {
+	storage.LogTreeTX
+	storage.QuarantineTX
+	poison  []byte
+	failAll bool
+}
+
+func (p *poisonTX) UpdateSequencedLeaves(ctx context.Context, leaves []*trillian.LogLeaf) error {
+	if p.failAll && len(leaves) > 0 {
+		return errors.New("storage failure")
+	}
+	for _, l := range leaves {
+		if bytes.Equal(l.LeafIdentityHash, p.poison) {
+			return errors.New("poisoned")
+		}
+	}
+	return nil
+}
+
+// newMemoryLog creates an initialized LOG tree in memory storage.
+func newMemoryLog(ctx context.Context, t *testing.T) (*trillian.Tree, storage.LogStorage) {
+	t.Helper()
+	ts := memory.NewTreeStorage()
+	tree, err := storage.CreateTree(ctx, memory.NewAdminStorage(ts), proto.Clone(stestonly.LogTree).(*trillian.Tree))
+	if err != nil {
+		t.Fatalf("CreateTree(): %v", err)
+	}
+	ls := memory.NewLogStorage(ts, nil)
+	root, err := (&types.LogRootV1{RootHash: rfc6962.DefaultHasher.EmptyRoot(), TimestampNanos: 1}).MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary(): %v", err)
+	}
+	if err := ls.ReadWriteTransaction(ctx, tree, func(ctx context.Context, tx storage.LogTreeTX) error {
+		return tx.StoreSignedLogRoot(ctx, &trillian.SignedLogRoot{LogRoot: root})
+	}); err != nil {
+		t.Fatalf("StoreSignedLogRoot(): %v", err)
+	}
+	return tree, ls
+}
+
+func setupPoisonTest(ctx context.Context, t *testing.T, numLeaves, poisonIdx int) (*trillian.Tree, *poisonStorage, []*trillian.LogLeaf) {
+	t.Helper()
+	tree, ls := newMemoryLog(ctx, t)
+	leaves := make([]*trillian.LogLeaf, 0, numLeaves)
+	for i := 0; i < numLeaves; i++ {
+		data := []byte(fmt.Sprintf("leaf %d", i))
+		hash := sha256.Sum256(data)
+		leaves = append(leaves, &trillian.LogLeaf{
+			LeafValue:        data,
+			LeafIdentityHash: hash[:],
+			MerkleLeafHash:   rfc6962.DefaultHasher.HashLeaf(data),
+		})
+	}
+	if _, err := ls.QueueLeaves(ctx, tree, leaves, time.Now()); err != nil {
+		t.Fatalf("QueueLeaves(): %v", err)
+	}
+	ps := &poisonStorage{LogStorage: ls}
+	if poisonIdx >= 0 {
+		ps.poison = leaves[poisonIdx].LeafIdentityHash
+	}
+	return tree, ps, leaves
+}
+
+func TestIsolatePoisonLeaf(t *testing.T) {
+	InitMetrics(nil)
+	for _, test := range []struct {
+		desc      string
+		numLeaves int
+		limit     int
+		poisonIdx int
+	}{
+		{desc: "first", numLeaves: 8, limit: 8, poisonIdx: 0},
+		{desc: "middle", numLeaves: 8, limit: 8, poisonIdx: 5},
+		{desc: "last", numLeaves: 8, limit: 8, poisonIdx: 7},
+		{desc: "limit-beyond-queue", numLeaves: 5, limit: 100, poisonIdx: 3},
+		{desc: "no-poison", numLeaves: 5, limit: 8, poisonIdx: -1},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			ctx := context.Background()
+			tree, ls, leaves := setupPoisonTest(ctx, t, test.numLeaves, test.poisonIdx)
+			ts := clock.NewFake(time.Unix(1500000000, 0))
+
+			got, err := IsolatePoisonLeaf(ctx, tree, test.limit, 0, 0, ts, ls, quota.Noop())
+			if err != nil {
+				t.Fatalf("IsolatePoisonLeaf(): %v", err)
+			}
+			if test.poisonIdx < 0 {
+				if got != nil {
+					t.Fatalf("IsolatePoisonLeaf() quarantined %x, want nothing", got.LeafIdentityHash)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatal("IsolatePoisonLeaf() quarantined nothing")
+			}
+			if want := leaves[test.poisonIdx].LeafIdentityHash; !bytes.Equal(got.LeafIdentityHash, want) {
+				t.Errorf("IsolatePoisonLeaf() quarantined %x, want %x", got.LeafIdentityHash, want)
+			}
+
+			// The rest of the queue can be integrated now.
+			if _, err := IntegrateBatchWithResult(ctx, tree, test.limit, 0, 0, ts, ls, quota.Noop(), true /* dryRun */); err != nil {
+				t.Errorf("IntegrateBatchWithResult() after quarantine: %v", err)
+			}
+		})
+	}
+}
+
+func TestIsolatePoisonLeaf_NotCausedByLeaf(t *testing.T) {
+	InitMetrics(nil)
+	ctx := context.Background()
+	tree, ls, _ := setupPoisonTest(ctx, t, 8, -1)
+	ls.failAll = true
+	ts := clock.NewFake(time.Unix(1500000000, 0))
+
+	got, err := IsolatePoisonLeaf(ctx, tree, 8, 0, 0, ts, ls, quota.Noop())
+	if err == nil {
+		t.Errorf("IsolatePoisonLeaf() returned nil error, want the sequencing failure")
+	}
+	if got != nil {
+		t.Errorf("IsolatePoisonLeaf() quarantined %x, want nothing", got.LeafIdentityHash)
+	}
+	queued, err := peekQueue(ctx, tree, 100, time.Now(), ls)
+	if err != nil {
+		t.Fatalf("peekQueue(): %v", err)
+	}
+	if got, want := len(queued), 8; got != want {
+		t.Errorf("%d leaves queued after IsolatePoisonLeaf(), want %d", got, want)
+	}
+}
+
+func TestIsolatePoisonLeaf_PreorderedLog(t *testing.T) {
+	tree := &trillian.Tree{TreeId: 1, TreeType: trillian.TreeType_PREORDERED_LOG}
+	if _, err := IsolatePoisonLeaf(context.Background(), tree, 10, 0, 0, clock.System, nil, quota.Noop()); err == nil {
+		t.Error("IsolatePoisonLeaf() for PREORDERED_LOG returned nil error")
+	}
+}
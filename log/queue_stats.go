@@ -0,0 +1,62 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/storage"
+)
+
+// RecordQueueStats reads the age distribution of the unsequenced leaves of
+// the tree, and exports it as the sequencer_queue_* metrics. The ages are
+// relative to now, and zero if the queue is empty.
+func RecordQueueStats(ctx context.Context, tree *trillian.Tree, now time.Time, ls storage.LogStorage) (*storage.QueueStats, error) {
+	tx, err := ls.SnapshotForTree(ctx, tree)
+	if err != nil {
+		return nil, fmt.Errorf("%v: failed to create snapshot: %v", tree.TreeId, err)
+	}
+	defer tx.Close()
+	qtx, err := storage.AsQueueStatsTX(tx)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := qtx.QueueStats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%v: failed to read queue stats: %v", tree.TreeId, err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	label := strconv.FormatInt(tree.TreeId, 10)
+	seqQueueSize.Set(float64(stats.Size), label)
+	seqQueueAgeMin.Set(queueAge(now, stats.Newest), label)
+	seqQueueAgeMedian.Set(queueAge(now, stats.Median), label)
+	seqQueueAgeMax.Set(queueAge(now, stats.Oldest), label)
+	return stats, nil
+}
+
+// queueAge returns the time in seconds between the queue timestamp ts and now.
+func queueAge(now, ts time.Time) float64 {
+	if ts.IsZero() || ts.After(now) {
+		return 0
+	}
+	return now.Sub(ts).Seconds()
+}
@@ -0,0 +1,136 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/extension"
+	"github.com/google/trillian/util/clock"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestRecordQueueStats(t *testing.T) {
+	InitMetrics(nil)
+	ctx := context.Background()
+	tree, ls := newMemoryLog(ctx, t)
+	label := strconv.FormatInt(tree.TreeId, 10)
+	now := time.Unix(1000, 0)
+
+	checkGauges := func(size, min, median, max float64) {
+		t.Helper()
+		for _, g := range []struct {
+			name string
+			got  float64
+			want float64
+		}{
+			{"size", seqQueueSize.Value(label), size},
+			{"min", seqQueueAgeMin.Value(label), min},
+			{"median", seqQueueAgeMedian.Value(label), median},
+			{"max", seqQueueAgeMax.Value(label), max},
+		} {
+			if g.got != g.want {
+				t.Errorf("%s: got %v, want %v", g.name, g.got, g.want)
+			}
+		}
+	}
+
+	if _, err := RecordQueueStats(ctx, tree, now, ls); err != nil {
+		t.Fatalf("RecordQueueStats(): %v", err)
+	}
+	checkGauges(0, 0, 0, 0)
+
+	// A few old leaves stuck behind many recent ones.
+	var leaves []*trillian.LogLeaf
+	for i, age := range []int64{600, 500, 30, 20, 10} {
+		data := []byte(fmt.Sprintf("leaf %d", i))
+		hash := sha256.Sum256(data)
+		leaves = append(leaves, &trillian.LogLeaf{
+			LeafValue:        data,
+			LeafIdentityHash: hash[:],
+			QueueTimestamp:   timestamppb.New(now.Add(-time.Duration(age) * time.Second)),
+		})
+	}
+	if _, err := ls.QueueLeaves(ctx, tree, leaves, now); err != nil {
+		t.Fatalf("QueueLeaves(): %v", err)
+	}
+	stats, err := RecordQueueStats(ctx, tree, now, ls)
+	if err != nil {
+		t.Fatalf("RecordQueueStats(): %v", err)
+	}
+	if got, want := stats.Size, int64(5); got != want {
+		t.Errorf("Size=%d, want %d", got, want)
+	}
+	checkGauges(5, 10, 30, 600)
+}
+
+func TestSequencerManagerQueueStatsInterval(t *testing.T) {
+	InitMetrics(nil)
+	ctx := context.Background()
+	tree, ls := newMemoryLog(ctx, t)
+	label := strconv.FormatInt(tree.TreeId, 10)
+	ts := clock.NewFake(time.Unix(1000, 0))
+
+	registry := extension.Registry{LogStorage: ls}
+	sm := &SequencerManager{registry: registry, failures: make(map[int64]int), queueStats: make(map[int64]time.Time)}
+	info := &OperationInfo{TimeSource: ts, QueueStatsInterval: time.Minute}
+
+	queue := func(age time.Duration) {
+		t.Helper()
+		data := []byte(fmt.Sprintf("leaf %v", age))
+		hash := sha256.Sum256(data)
+		leaf := &trillian.LogLeaf{LeafValue: data, LeafIdentityHash: hash[:], QueueTimestamp: timestamppb.New(ts.Now().Add(-age))}
+		if _, err := ls.QueueLeaves(ctx, tree, []*trillian.LogLeaf{leaf}, ts.Now()); err != nil {
+			t.Fatalf("QueueLeaves(): %v", err)
+		}
+	}
+
+	queue(time.Second)
+	sm.recordQueueStats(ctx, tree, info)
+	if got, want := seqQueueSize.Value(label), 1.0; got != want {
+		t.Errorf("sequencer_queue_size=%v, want %v", got, want)
+	}
+
+	// Within the interval, the metrics are not refreshed.
+	queue(time.Second)
+	ts.Set(ts.Now().Add(30 * time.Second))
+	sm.recordQueueStats(ctx, tree, info)
+	if got, want := seqQueueSize.Value(label), 1.0; got != want {
+		t.Errorf("sequencer_queue_size=%v, want %v", got, want)
+	}
+
+	ts.Set(ts.Now().Add(30 * time.Second))
+	sm.recordQueueStats(ctx, tree, info)
+	if got, want := seqQueueSize.Value(label), 2.0; got != want {
+		t.Errorf("sequencer_queue_size=%v, want %v", got, want)
+	}
+	if got, want := seqQueueAgeMax.Value(label), 61.0; got != want {
+		t.Errorf("sequencer_queue_age_max_seconds=%v, want %v", got, want)
+	}
+
+	// Zero disables the metrics.
+	queue(time.Second)
+	ts.Set(ts.Now().Add(time.Hour))
+	sm.recordQueueStats(ctx, tree, &OperationInfo{TimeSource: ts})
+	if got, want := seqQueueSize.Value(label), 2.0; got != want {
+		t.Errorf("sequencer_queue_size=%v, want %v", got, want)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrNoRetentionPolicy is returned by PruneLeaves for trees without a
+// retention policy.
+var ErrNoRetentionPolicy = status.Error(codes.FailedPrecondition, "tree has no retention policy")
+
+// PruneLeaves prunes the data of up to limit leaves of the tree which were
+// integrated longer ago, at now, than its retention policy allows. Returns
+// the cutoff time of the policy, and the number of leaves pruned. If dryRun,
+// nothing is pruned, and it returns the number of leaves which would have
+// been.
+func PruneLeaves(ctx context.Context, tree *trillian.Tree, now time.Time, limit int, ls storage.LogStorage, dryRun bool) (time.Time, int, error) {
+	maxAge := tree.GetRetentionPolicy().GetMaxLeafAge()
+	if maxAge == nil {
+		return time.Time{}, 0, ErrNoRetentionPolicy
+	}
+	cutoff := now.Add(-maxAge.AsDuration())
+
+	var pruned int
+	err := ls.ReadWriteTransaction(ctx, tree, func(ctx context.Context, tx storage.LogTreeTX) error {
+		rtx, err := storage.AsRetentionTX(tx)
+		if err != nil {
+			return err
+		}
+		if pruned, err = rtx.PruneLeafData(ctx, cutoff, limit); err != nil {
+			return err
+		}
+		if dryRun {
+			// Roll back, so that the leaves keep their data.
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !(dryRun && errors.Is(err, errDryRun)) {
+		return time.Time{}, 0, err
+	}
+	return cutoff, pruned, nil
+}
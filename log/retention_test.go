@@ -0,0 +1,171 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/extension"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/util/clock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// sequenceLeaves stores sequenced leaves in the tree, integrated at the given
+// ages before now.
+func sequenceLeaves(ctx context.Context, t *testing.T, tree *trillian.Tree, ls storage.LogStorage, now time.Time, ages ...time.Duration) {
+	t.Helper()
+	var leaves []*trillian.LogLeaf
+	for i, age := range ages {
+		data := []byte(fmt.Sprintf("leaf %d", i))
+		hash := sha256.Sum256(data)
+		leaves = append(leaves, &trillian.LogLeaf{
+			LeafValue:          data,
+			ExtraData:          []byte("extra"),
+			LeafIdentityHash:   hash[:],
+			MerkleLeafHash:     hash[:],
+			LeafIndex:          int64(i),
+			IntegrateTimestamp: timestamppb.New(now.Add(-age)),
+		})
+	}
+	if _, err := ls.QueueLeaves(ctx, tree, leaves, now); err != nil {
+		t.Fatalf("QueueLeaves(): %v", err)
+	}
+	if err := ls.ReadWriteTransaction(ctx, tree, func(ctx context.Context, tx storage.LogTreeTX) error {
+		return tx.UpdateSequencedLeaves(ctx, leaves)
+	}); err != nil {
+		t.Fatalf("UpdateSequencedLeaves(): %v", err)
+	}
+}
+
+// prunedLeaves returns which of the first count leaves of the tree have no
+// data.
+func prunedLeaves(ctx context.Context, t *testing.T, tree *trillian.Tree, ls storage.LogStorage, count int64) []bool {
+	t.Helper()
+	var pruned []bool
+	if err := ls.ReadWriteTransaction(ctx, tree, func(ctx context.Context, tx storage.LogTreeTX) error {
+		leaves, err := tx.GetLeavesByRange(ctx, 0, count)
+		for _, leaf := range leaves {
+			pruned = append(pruned, len(leaf.LeafValue) == 0 && leaf.ExtraData == nil)
+		}
+		return err
+	}); err != nil {
+		t.Fatalf("GetLeavesByRange(): %v", err)
+	}
+	return pruned
+}
+
+func TestPruneLeaves(t *testing.T) {
+	ctx := context.Background()
+	tree, ls := newMemoryLog(ctx, t)
+	now := time.Unix(100000, 0)
+	sequenceLeaves(ctx, t, tree, ls, now, 3*time.Hour, 2*time.Hour, 30*time.Minute, time.Minute)
+
+	if _, _, err := PruneLeaves(ctx, tree, now, 10, ls, false); err != ErrNoRetentionPolicy {
+		t.Errorf("PruneLeaves() without policy: %v, want %v", err, ErrNoRetentionPolicy)
+	}
+
+	tree.RetentionPolicy = &trillian.RetentionPolicy{MaxLeafAge: durationpb.New(time.Hour)}
+	for _, tc := range []struct {
+		desc       string
+		limit      int
+		dryRun     bool
+		wantPruned int
+		want       []bool
+	}{
+		{desc: "dryRun", limit: 10, dryRun: true, wantPruned: 2, want: []bool{false, false, false, false}},
+		{desc: "limited", limit: 1, wantPruned: 1, want: []bool{true, false, false, false}},
+		{desc: "rest", limit: 10, wantPruned: 1, want: []bool{true, true, false, false}},
+		{desc: "nothingLeft", limit: 10, wantPruned: 0, want: []bool{true, true, false, false}},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			cutoff, pruned, err := PruneLeaves(ctx, tree, now, tc.limit, ls, tc.dryRun)
+			if err != nil {
+				t.Fatalf("PruneLeaves(): %v", err)
+			}
+			if want := now.Add(-time.Hour); !cutoff.Equal(want) {
+				t.Errorf("PruneLeaves(): cutoff %v, want %v", cutoff, want)
+			}
+			if pruned != tc.wantPruned {
+				t.Errorf("PruneLeaves(): pruned %d, want %d", pruned, tc.wantPruned)
+			}
+			got := prunedLeaves(ctx, t, tree, ls, 4)
+			if fmt.Sprint(got) != fmt.Sprint(tc.want) {
+				t.Errorf("pruned leaves: %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+type noRetentionStorage struct {
+	storage.LogStorage
+}
+
+func (s noRetentionStorage) ReadWriteTransaction(ctx context.Context, tree *trillian.Tree, f storage.LogTXFunc) error {
+	return s.LogStorage.ReadWriteTransaction(ctx, tree, func(ctx context.Context, tx storage.LogTreeTX) error {
+		return f(ctx, struct{ storage.LogTreeTX }{tx})
+	})
+}
+
+func TestPruneLeavesUnsupported(t *testing.T) {
+	ctx := context.Background()
+	tree, ls := newMemoryLog(ctx, t)
+	tree.RetentionPolicy = &trillian.RetentionPolicy{MaxLeafAge: durationpb.New(time.Hour)}
+	_, _, err := PruneLeaves(ctx, tree, time.Now(), 10, noRetentionStorage{ls}, false)
+	if got, want := status.Code(err), codes.Unimplemented; got != want {
+		t.Errorf("PruneLeaves(): %v, want code %v", err, want)
+	}
+}
+
+func TestSequencerManagerPruneLeavesInterval(t *testing.T) {
+	InitMetrics(nil)
+	ctx := context.Background()
+	tree, ls := newMemoryLog(ctx, t)
+	tree.RetentionPolicy = &trillian.RetentionPolicy{MaxLeafAge: durationpb.New(time.Hour)}
+	label := strconv.FormatInt(tree.TreeId, 10)
+	ts := clock.NewFake(time.Unix(100000, 0))
+	sequenceLeaves(ctx, t, tree, ls, ts.Now(), 4*time.Hour, 3*time.Hour, 2*time.Hour, 30*time.Minute)
+
+	registry := extension.Registry{LogStorage: ls}
+	sm := &SequencerManager{registry: registry, pruned: make(map[int64]time.Time)}
+	info := &OperationInfo{TimeSource: ts, RetentionInterval: time.Hour, RetentionBatchSize: 2}
+
+	for _, step := range []struct {
+		advance time.Duration
+		want    float64
+	}{
+		{0, 2},
+		// A full batch is followed by another pass right away.
+		{0, 3},
+		// Within the interval of the last complete pass, nothing is pruned.
+		{45 * time.Minute, 3},
+		{15 * time.Minute, 4},
+	} {
+		ts.Set(ts.Now().Add(step.advance))
+		sm.pruneLeaves(ctx, tree, info)
+		if got := seqPrunedLeaves.Value(label); got != step.want {
+			t.Errorf("after %v: sequencer_pruned_leaves=%v, want %v", step.advance, got, step.want)
+		}
+	}
+}
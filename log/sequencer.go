@@ -17,6 +17,7 @@ package log
 import (
 	"bytes"
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"strconv"
@@ -32,12 +33,16 @@ import (
 	"github.com/google/trillian/storage/tree"
 	"github.com/google/trillian/types"
 	"github.com/google/trillian/util/clock"
+	"github.com/google/trillian/util/shadow"
 	"github.com/transparency-dev/merkle/compact"
 	"github.com/transparency-dev/merkle/rfc6962"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-const logIDLabel = "logid"
+const (
+	logIDLabel    = "logid"
+	traceSpanRoot = "/trillian/log"
+)
 
 var (
 	sequencerOnce          sync.Once
@@ -54,6 +59,18 @@ var (
 	seqCounter             monitoring.Counter
 	seqMergeDelay          monitoring.Histogram
 	seqTimestamp           monitoring.Gauge
+	seqQuarantined         monitoring.Counter
+	seqPrunedLeaves        monitoring.Counter
+	seqQueueSize           monitoring.Gauge
+	seqQueueAgeMin         monitoring.Gauge
+	seqQueueAgeMedian      monitoring.Gauge
+	seqQueueAgeMax         monitoring.Gauge
+	seqBatchSize           monitoring.Gauge
+	seqRunInterval         monitoring.Gauge
+	seqBatchTunings        monitoring.Counter
+	seqDedupFilterSize     monitoring.Gauge
+	seqFrontierCacheHits   monitoring.Counter
+	seqFrontierCacheMisses monitoring.Counter
 
 	// QuotaIncreaseFactor is the multiplier used for the number of tokens added back to
 	// sequencing-based quotas. The resulting PutTokens call is equivalent to
@@ -116,6 +133,7 @@ func InitMetrics(mf monitoring.MetricFactory) {
 			mf = monitoring.InertMetricFactory{}
 		}
 		quota.InitMetrics(mf)
+		shadow.InitMetrics(mf)
 		seqBatches = mf.NewCounter("sequencer_batches", "Number of sequencer batch operations", logIDLabel)
 		seqTreeSize = mf.NewGauge("sequencer_tree_size", "Tree size of last SLR signed", logIDLabel)
 		seqTimestamp = mf.NewGauge("sequencer_tree_timestamp", "Time of last SLR signed in ms since epoch", logIDLabel)
@@ -129,15 +147,52 @@ func InitMetrics(mf monitoring.MetricFactory) {
 		seqStoreRootLatency = mf.NewHistogram("sequencer_latency_store_root", "Latency of store-root part of sequencer batch operation in seconds", logIDLabel)
 		seqCounter = mf.NewCounter("sequencer_sequenced", "Number of leaves sequenced", logIDLabel)
 		seqMergeDelay = mf.NewHistogram("sequencer_merge_delay", "Delay between queuing and integration of leaves", logIDLabel)
+		seqQuarantined = mf.NewCounter("sequencer_quarantined", "Number of leaves quarantined because they made sequencing fail", logIDLabel)
+		seqPrunedLeaves = mf.NewCounter("sequencer_pruned_leaves", "Number of leaves whose data was pruned according to the retention policy of the tree", logIDLabel)
+		seqQueueSize = mf.NewGauge("sequencer_queue_size", "Number of unsequenced leaves in the queue", logIDLabel)
+		seqQueueAgeMin = mf.NewGauge("sequencer_queue_age_min_seconds", "Age in seconds of the most recently queued unsequenced leaf", logIDLabel)
+		seqQueueAgeMedian = mf.NewGauge("sequencer_queue_age_median_seconds", "Median age in seconds of the unsequenced leaves", logIDLabel)
+		seqQueueAgeMax = mf.NewGauge("sequencer_queue_age_max_seconds", "Age in seconds of the oldest unsequenced leaf", logIDLabel)
+		seqBatchSize = mf.NewGauge("sequencer_batch_size", "Batch size of the last sequencing pass, after adapting to GC pressure", logIDLabel)
+		seqRunInterval = mf.NewGauge("sequencer_run_interval_seconds", "Time between the sequencing passes over a log, as tuned to its load", logIDLabel)
+		seqBatchTunings = mf.NewCounter("sequencer_batch_tunings", "Number of changes of the batch size or run interval of a log by batch tuning, by reason", logIDLabel, "reason")
+		seqDedupFilterSize = mf.NewGauge("sequencer_dedup_filter_size", "Number of leaves of a log added to its dedup filter", logIDLabel)
+		seqFrontierCacheHits = mf.NewCounter("sequencer_frontier_cache_hits", "Number of sequencing passes which reused the compact range of the previous pass", logIDLabel)
+		seqFrontierCacheMisses = mf.NewCounter("sequencer_frontier_cache_misses", "Number of sequencing passes which read the compact range from storage", logIDLabel)
 	})
 }
 
+// initCompactRange builds a compact range that matches the passed in root,
+// from the hashes cached by the previous pass over the tree if fc has them,
+// or else from storage.
+func initCompactRange(ctx context.Context, treeID int64, root *types.LogRootV1, tx storage.ReadOnlyLogTreeTX, fc *frontierCache) (*compact.Range, error) {
+	label := strconv.FormatInt(treeID, 10)
+	if hashes := fc.get(treeID, root); hashes != nil {
+		// Building the range checks that it matches the root, which only
+		// takes O(log n) hashes, and guards against a cache entry which went
+		// out of sync with storage. The shadow run checks it node by node.
+		cr, err := fc.shadow.Run(label,
+			func() (interface{}, error) { return newCompactRange(root, hashes) },
+			func() (interface{}, error) { return initCompactRangeFromStorage(ctx, root, tx) },
+			diffCompactRanges)
+		if err == nil {
+			seqFrontierCacheHits.Inc(label)
+			return cr.(*compact.Range), nil
+		}
+		glog.Warningf("%v: discarding cached compact range: %v", treeID, err)
+		fc.drop(treeID)
+	}
+	if fc != nil {
+		seqFrontierCacheMisses.Inc(label)
+	}
+	return initCompactRangeFromStorage(ctx, root, tx)
+}
+
 // initCompactRangeFromStorage builds a compact range that matches the latest
 // data in the database. Ensures that the root hash matches the passed in root.
-func initCompactRangeFromStorage(ctx context.Context, root *types.LogRootV1, tx storage.LogTreeTX) (*compact.Range, error) {
-	fact := compact.RangeFactory{Hash: rfc6962.DefaultHasher.HashChildren}
+func initCompactRangeFromStorage(ctx context.Context, root *types.LogRootV1, tx storage.ReadOnlyLogTreeTX) (*compact.Range, error) {
 	if root.TreeSize == 0 {
-		return fact.NewEmptyRange(0), nil
+		return newCompactRange(root, nil)
 	}
 
 	ids := compact.RangeNodes(0, root.TreeSize, nil)
@@ -153,6 +208,16 @@ func initCompactRangeFromStorage(ctx context.Context, root *types.LogRootV1, tx
 	for i, node := range nodes {
 		hashes[i] = node.Hash
 	}
+	return newCompactRange(root, hashes)
+}
+
+// newCompactRange builds a compact range covering the tree from its hashes,
+// and ensures that the root hash matches the passed in root.
+func newCompactRange(root *types.LogRootV1, hashes [][]byte) (*compact.Range, error) {
+	fact := compact.RangeFactory{Hash: hashChildren}
+	if root.TreeSize == 0 {
+		return fact.NewEmptyRange(0), nil
+	}
 	cr, err := fact.NewRange(0, root.TreeSize, hashes)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create compact.Range: %v", err)
@@ -168,6 +233,22 @@ func initCompactRangeFromStorage(ctx context.Context, root *types.LogRootV1, tx
 	return cr, nil
 }
 
+// diffCompactRanges describes the first difference between two compact
+// ranges, or returns an empty string if they are equal.
+func diffCompactRanges(a, b interface{}) string {
+	ra, rb := a.(*compact.Range), b.(*compact.Range)
+	ha, hb := ra.Hashes(), rb.Hashes()
+	if ra.Begin() != rb.Begin() || ra.End() != rb.End() || len(ha) != len(hb) {
+		return fmt.Sprintf("range [%d, %d) with %d hashes vs [%d, %d) with %d hashes", ra.Begin(), ra.End(), len(ha), rb.Begin(), rb.End(), len(hb))
+	}
+	for i := range ha {
+		if !bytes.Equal(ha[i], hb[i]) {
+			return fmt.Sprintf("range [%d, %d) node %d: %x vs %x", ra.Begin(), ra.End(), i, ha[i], hb[i])
+		}
+	}
+	return ""
+}
+
 func buildNodesFromNodeMap(nodeMap map[compact.NodeID][]byte) []tree.Node {
 	nodes := make([]tree.Node, 0, len(nodeMap))
 	for id, hash := range nodeMap {
@@ -312,17 +393,71 @@ func (s *preorderedLogSequencingTask) update(ctx context.Context, leaves []*tril
 	return nil
 }
 
+// errDryRun is returned from within the sequencing transaction to make sure
+// that a dry run is rolled back rather than committed.
+var errDryRun = errors.New("dry run")
+
+// BatchResult describes the outcome of a single sequencing pass over a tree.
+type BatchResult struct {
+	// OldRoot is the latest root of the tree before the pass.
+	OldRoot *types.LogRootV1
+	// NewRoot is the root created by the pass, or nil if no root was signed.
+	NewRoot *types.LogRootV1
+	// Leaves are the leaves integrated by the pass, in sequence order.
+	Leaves []*trillian.LogLeaf
+	// Latencies breaks down the time the pass spent in storage.
+	Latencies StageLatencies
+}
+
+// StageLatencies breaks down the time a sequencing pass spent in storage.
+// Stages which the pass didn't reach are zero.
+type StageLatencies struct {
+	GetRoot      time.Duration // Reading the latest root.
+	Dequeue      time.Duration // Dequeueing the batch of leaves.
+	InitTree     time.Duration // Reading the compact range, unless cached.
+	UpdateLeaves time.Duration // Writing the sequenced leaves.
+	SetNodes     time.Duration // Writing the Merkle tree nodes.
+	StoreRoot    time.Duration // Writing the new root.
+	Commit       time.Duration // Committing the transaction.
+}
+
 // IntegrateBatch wraps up all the operations needed to take a batch of queued
 // or sequenced leaves and integrate them into the tree.
 func IntegrateBatch(ctx context.Context, tree *trillian.Tree, limit int, guardWindow, maxRootDurationInterval time.Duration, ts clock.TimeSource, ls storage.LogStorage, qm quota.Manager) (int, error) {
+	res, err := IntegrateBatchWithResult(ctx, tree, limit, guardWindow, maxRootDurationInterval, ts, ls, qm, false /* dryRun */)
+	if err != nil {
+		return 0, err
+	}
+	return len(res.Leaves), nil
+}
+
+// IntegrateBatchWithResult is like IntegrateBatch, but returns a description
+// of the sequencing pass. If dryRun is true, all the work of the pass is done
+// but the storage transaction is rolled back instead of committed, and quota
+// is not replenished, so the returned result only shows what would have been
+// integrated.
+func IntegrateBatchWithResult(ctx context.Context, tree *trillian.Tree, limit int, guardWindow, maxRootDurationInterval time.Duration, ts clock.TimeSource, ls storage.LogStorage, qm quota.Manager, dryRun bool) (*BatchResult, error) {
+	return integrateBatch(ctx, tree, limit, guardWindow, maxRootDurationInterval, ts, ls, qm, dryRun, nil)
+}
+
+// integrateBatch is IntegrateBatchWithResult which, if fc is not nil, reuses
+// the compact range of the tree kept in fc by the previous pass, and keeps the
+// compact range of this pass there for the next one.
+func integrateBatch(ctx context.Context, tree *trillian.Tree, limit int, guardWindow, maxRootDurationInterval time.Duration, ts clock.TimeSource, ls storage.LogStorage, qm quota.Manager, dryRun bool, fc *frontierCache) (*BatchResult, error) {
+	ctx, spanEnd := spanFor(ctx, "IntegrateBatch")
+	defer spanEnd()
 	start := ts.Now()
 	label := strconv.FormatInt(tree.TreeId, 10)
 
+	res := &BatchResult{}
 	numLeaves := 0
 	var newLogRoot *types.LogRootV1
 	var newSLR *trillian.SignedLogRoot
+	var newHashes [][]byte
+	var txDone time.Time
 	err := ls.ReadWriteTransaction(ctx, tree, func(ctx context.Context, tx storage.LogTreeTX) error {
 		stageStart := ts.Now()
+		defer func() { txDone = ts.Now() }()
 		defer seqBatches.Inc(label)
 		defer func() { seqLatency.Observe(clock.SecondsSince(ts, start), label) }()
 
@@ -339,7 +474,9 @@ func IntegrateBatch(ctx context.Context, tree *trillian.Tree, limit int, guardWi
 			return fmt.Errorf("%v: Sequencer failed to unmarshal latest root: %v", tree.TreeId, err)
 		}
 		seqGetRootLatency.Observe(clock.SecondsSince(ts, stageStart), label)
+		res.Latencies.GetRoot = ts.Now().Sub(stageStart)
 		seqTreeSize.Set(float64(currentRoot.TreeSize), label)
+		res.OldRoot = &currentRoot
 
 		if currentRoot.RootHash == nil {
 			glog.Warningf("%v: Fresh log - no previous TreeHeads exist.", tree.TreeId)
@@ -362,11 +499,14 @@ func IntegrateBatch(ctx context.Context, tree *trillian.Tree, limit int, guardWi
 			return fmt.Errorf("IntegrateBatch not supported for TreeType %v", tree.TreeType)
 		}
 
+		stageStart = ts.Now()
 		sequencedLeaves, err := st.fetch(ctx, limit, start.Add(-guardWindow))
 		if err != nil {
 			return fmt.Errorf("%v: Sequencer failed to load sequenced batch: %v", tree.TreeId, err)
 		}
+		res.Latencies.Dequeue = ts.Now().Sub(stageStart)
 		numLeaves = len(sequencedLeaves)
+		res.Leaves = sequencedLeaves
 
 		// We need to create a signed root if entries were added or the latest root
 		// is too old.
@@ -376,17 +516,21 @@ func IntegrateBatch(ctx context.Context, tree *trillian.Tree, limit int, guardWi
 			if maxRootDurationInterval == 0 || interval < maxRootDurationInterval {
 				// We have nothing to integrate into the tree.
 				glog.V(1).Infof("%v: No leaves sequenced in this signing operation", tree.TreeId)
+				if dryRun {
+					return errDryRun
+				}
 				return nil
 			}
 			glog.Infof("%v: Force new root generation as %v since last root", tree.TreeId, interval)
 		}
 
 		stageStart = ts.Now()
-		cr, err := initCompactRangeFromStorage(ctx, &currentRoot, tx)
+		cr, err := initCompactRange(ctx, tree.TreeId, &currentRoot, tx, fc)
 		if err != nil {
 			return fmt.Errorf("%v: compact range init failed: %v", tree.TreeId, err)
 		}
 		seqInitTreeLatency.Observe(clock.SecondsSince(ts, stageStart), label)
+		res.Latencies.InitTree = ts.Now().Sub(stageStart)
 		stageStart = ts.Now()
 
 		// We've done all the reads, can now do the updates in the same
@@ -399,11 +543,17 @@ func IntegrateBatch(ctx context.Context, tree *trillian.Tree, limit int, guardWi
 			return err
 		}
 		seqWriteTreeLatency.Observe(clock.SecondsSince(ts, stageStart), label)
+		newHashes = cr.Hashes()
 
 		// Store the sequenced batch.
+		stageStart = ts.Now()
 		if err := st.update(ctx, sequencedLeaves); err != nil {
 			return err
 		}
+		res.Latencies.UpdateLeaves = ts.Now().Sub(stageStart)
+		if err := addDailyStats(ctx, tx, sequencedLeaves); err != nil {
+			return fmt.Errorf("%v: failed to update daily stats: %v", tree.TreeId, err)
+		}
 		stageStart = ts.Now()
 
 		// Build objects for the nodes to be updated. Because we deduped via the map
@@ -417,6 +567,7 @@ func IntegrateBatch(ctx context.Context, tree *trillian.Tree, limit int, guardWi
 			return fmt.Errorf("%v: Sequencer failed to set Merkle nodes: %v", tree.TreeId, err)
 		}
 		seqSetNodesLatency.Observe(clock.SecondsSince(ts, stageStart), label)
+		res.Latencies.SetNodes = ts.Now().Sub(stageStart)
 		stageStart = ts.Now()
 
 		// Create the log root ready for signing.
@@ -442,15 +593,33 @@ func IntegrateBatch(ctx context.Context, tree *trillian.Tree, limit int, guardWi
 			return fmt.Errorf("%v: signer failed to marshal root: %v", tree.TreeId, err)
 		}
 		newSLR := &trillian.SignedLogRoot{LogRoot: logRoot}
+		res.NewRoot = newLogRoot
 
 		if err := tx.StoreSignedLogRoot(ctx, newSLR); err != nil {
 			return fmt.Errorf("%v: failed to write updated tree root: %v", tree.TreeId, err)
 		}
 		seqStoreRootLatency.Observe(clock.SecondsSince(ts, stageStart), label)
+		res.Latencies.StoreRoot = ts.Now().Sub(stageStart)
+		if dryRun {
+			return errDryRun
+		}
 		return nil
 	})
+	if !txDone.IsZero() {
+		res.Latencies.Commit = ts.Now().Sub(txDone)
+	}
+	if dryRun && errors.Is(err, errDryRun) {
+		glog.Infof("%v: dry run would have sequenced %v leaves", tree.TreeId, numLeaves)
+		return res, nil
+	}
 	if err != nil {
-		return 0, err
+		// The transaction may have failed after the root was read, so don't
+		// trust the cached range to match whatever storage holds now.
+		fc.drop(tree.TreeId)
+		return nil, err
+	}
+	if res.NewRoot != nil {
+		fc.put(tree.TreeId, res.NewRoot, newHashes)
 	}
 
 	// Let quota.Manager know about newly-sequenced entries.
@@ -460,7 +629,51 @@ func IntegrateBatch(ctx context.Context, tree *trillian.Tree, limit int, guardWi
 	if newSLR != nil {
 		glog.Infof("%v: sequenced %v leaves, size %v", tree.TreeId, numLeaves, newLogRoot.TreeSize)
 	}
-	return numLeaves, nil
+	return res, nil
+}
+
+// ResignRoot stores a new root for the tree which commits to the same tree
+// size and root hash as the latest root, but carries a fresh timestamp. This
+// is useful when the latest root has to be replaced without integrating any
+// leaves, e.g. because its timestamp has gone stale while the sequencer was
+// unable to make progress.
+func ResignRoot(ctx context.Context, tree *trillian.Tree, ts clock.TimeSource, ls storage.LogStorage) (*trillian.SignedLogRoot, error) {
+	var newSLR *trillian.SignedLogRoot
+	err := ls.ReadWriteTransaction(ctx, tree, func(ctx context.Context, tx storage.LogTreeTX) error {
+		sth, err := tx.LatestSignedLogRoot(ctx)
+		if err != nil || sth == nil {
+			return fmt.Errorf("%v: failed to get latest root: %v", tree.TreeId, err)
+		}
+		var currentRoot types.LogRootV1
+		if err := currentRoot.UnmarshalBinary(sth.LogRoot); err != nil {
+			return fmt.Errorf("%v: failed to unmarshal latest root: %v", tree.TreeId, err)
+		}
+		if currentRoot.RootHash == nil {
+			return storage.ErrTreeNeedsInit
+		}
+
+		newLogRoot := &types.LogRootV1{
+			RootHash:       currentRoot.RootHash,
+			TimestampNanos: uint64(ts.Now().UnixNano()),
+			TreeSize:       currentRoot.TreeSize,
+		}
+		if newLogRoot.TimestampNanos <= currentRoot.TimestampNanos {
+			return fmt.Errorf("%v: refusing to sign root with timestamp earlier than previous root (%d <= %d)", tree.TreeId, newLogRoot.TimestampNanos, currentRoot.TimestampNanos)
+		}
+		logRoot, err := newLogRoot.MarshalBinary()
+		if err != nil {
+			return fmt.Errorf("%v: failed to marshal root: %v", tree.TreeId, err)
+		}
+		newSLR = &trillian.SignedLogRoot{LogRoot: logRoot}
+		if err := tx.StoreSignedLogRoot(ctx, newSLR); err != nil {
+			return fmt.Errorf("%v: failed to write re-signed tree root: %v", tree.TreeId, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newSLR, nil
 }
 
 // replenishQuota replenishes all quotas, such as {Tree/Global, Read/Write},
@@ -488,3 +701,7 @@ func replenishQuota(ctx context.Context, numLeaves int, treeID int64, qm quota.M
 		quota.Metrics.IncReplenished(tokens, specs, err == nil)
 	}
 }
+
+func spanFor(ctx context.Context, name string) (context.Context, func()) {
+	return monitoring.StartSpan(ctx, fmt.Sprintf("%s.%s", traceSpanRoot, name))
+}
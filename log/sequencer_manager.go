@@ -17,6 +17,8 @@ package log
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/golang/glog"
@@ -29,6 +31,27 @@ import (
 type SequencerManager struct {
 	guardWindow time.Duration
 	registry    extension.Registry
+
+	mu sync.Mutex
+	// failures counts consecutive failed passes per tree.
+	failures map[int64]int
+	// queueStats holds the time of the last queue stats export per tree.
+	queueStats map[int64]time.Time
+	// pruned holds the time of the last complete garbage collection pass per
+	// tree.
+	pruned map[int64]time.Time
+
+	// batchSizer scales the batch size of every tree down under memory
+	// pressure, after tuning.
+	batchSizer *batchSizer
+	// frontiers keeps the compact range of every tree between passes.
+	frontiers *frontierCache
+	// tuner tunes the batch size and run interval of every tree, if
+	// OperationInfo.BatchTuning is set.
+	tuner *batchTuner
+	// dedup maintains the dedup filter of every tree, if
+	// OperationInfo.DedupFilter is set.
+	dedup *dedupFilters
 }
 
 var seqOpts = trees.NewGetOpts(trees.SequenceLog, trillian.TreeType_LOG, trillian.TreeType_PREORDERED_LOG)
@@ -40,17 +63,47 @@ func NewSequencerManager(registry extension.Registry, gw time.Duration) *Sequenc
 	return &SequencerManager{
 		guardWindow: gw,
 		registry:    registry,
+		failures:    make(map[int64]int),
+		queueStats:  make(map[int64]time.Time),
+		pruned:      make(map[int64]time.Time),
+		batchSizer:  newBatchSizer(),
+		frontiers:   newFrontierCache(),
+		tuner:       newBatchTuner(),
+		dedup:       newDedupFilters(),
 	}
 }
 
 // ExecutePass performs sequencing for the specified Log.
 func (s *SequencerManager) ExecutePass(ctx context.Context, logID int64, info *OperationInfo) (int, error) {
+	res, err := s.executePass(ctx, logID, info, false /* dryRun */)
+	if err != nil {
+		return 0, err
+	}
+	return len(res.Leaves), nil
+}
+
+// DryRunPass performs a sequencing pass for the specified Log without
+// committing it, and returns what would have been integrated.
+func (s *SequencerManager) DryRunPass(ctx context.Context, logID int64, info *OperationInfo) (*BatchResult, error) {
+	return s.executePass(ctx, logID, info, true /* dryRun */)
+}
+
+// SinglePass performs a sequencing pass for the specified Log, and returns
+// what was integrated.
+func (s *SequencerManager) SinglePass(ctx context.Context, logID int64, info *OperationInfo) (*BatchResult, error) {
+	return s.executePass(ctx, logID, info, false /* dryRun */)
+}
+
+func (s *SequencerManager) executePass(ctx context.Context, logID int64, info *OperationInfo, dryRun bool) (*BatchResult, error) {
 	// TODO(Martin2112): Honor the sequencing enabled in log parameters, needs an API change
 	// so deferring it
 
 	tree, err := trees.GetTree(ctx, s.registry.AdminStorage, logID, seqOpts)
 	if err != nil {
-		return 0, fmt.Errorf("error retrieving log %v: %v", logID, err)
+		s.frontiers.drop(logID)
+		s.tuner.forget(logID)
+		s.dedup.drop(logID)
+		return nil, fmt.Errorf("error retrieving log %v: %v", logID, err)
 	}
 	ctx = trees.NewContext(ctx, tree)
 
@@ -59,9 +112,158 @@ func (s *SequencerManager) ExecutePass(ctx context.Context, logID int64, info *O
 		glog.Warning("failed to parse tree.MaxRootDuration, using zero")
 		maxRootDuration = 0
 	}
-	leaves, err := IntegrateBatch(ctx, tree, info.BatchSize, s.guardWindow, maxRootDuration, info.TimeSource, s.registry.LogStorage, s.registry.QuotaManager)
+	queued := int64(-1)
+	if !dryRun {
+		queued = s.recordQueueStats(ctx, tree, info)
+		s.pruneLeaves(ctx, tree, info)
+		// Leaves are still queued while integration is paused.
+		if integrationPaused(tree, info.TimeSource.Now()) {
+			glog.V(1).Infof("%v: integration paused, skipping pass", logID)
+			return &BatchResult{}, nil
+		}
+	}
+	s.frontiers.shadow.SetSampleRate(info.ShadowSampleRate)
+	tuned := info.BatchTuning != nil && !dryRun
+	batchSize := info.BatchSize
+	if tuned {
+		var due bool
+		if batchSize, due = s.tuner.next(logID, info.TimeSource.Now(), info); !due {
+			return &BatchResult{}, nil
+		}
+	}
+	// The tuner, if any, picks the batch size of the log, and the batch sizer
+	// scales it down while the whole process is under memory pressure. The
+	// tuner judges passes against its own size, so that it doesn't grow the
+	// batch to make up for the sizer's backoff.
+	tunedSize := batchSize
+	batchSize = s.batchSizer.size(batchSize)
+	seqBatchSize.Set(float64(batchSize), strconv.FormatInt(logID, 10))
+	var res *BatchResult
+	start := info.TimeSource.Now()
+	s.batchSizer.measure(info.MaxGCPause, info.MaxHeapGrowth, func() {
+		res, err = integrateBatch(ctx, tree, batchSize, s.guardWindow, maxRootDuration, info.TimeSource, s.registry.LogStorage, s.registry.QuotaManager, dryRun, s.frontiers)
+	})
+	if dryRun {
+		return res, err
+	}
+	duration := info.TimeSource.Now().Sub(start)
+	info.BatchReports.add(logID, start, duration, batchSize, res, err)
 	if err != nil {
-		return 0, fmt.Errorf("failed to integrate batch for %v: %v", logID, err)
+		s.passFailed(ctx, tree, info, batchSize, maxRootDuration)
+		return nil, fmt.Errorf("failed to integrate batch for %v: %v", logID, err)
+	}
+	if tuned {
+		s.tuner.observe(logID, tunedPass{
+			duration:  duration,
+			commit:    res.Latencies.Commit,
+			leaves:    len(res.Leaves),
+			batchSize: tunedSize,
+			queued:    queued,
+		}, info)
+	}
+	if err := s.dedup.update(ctx, tree, res, info, s.registry.LogStorage); err != nil {
+		// The filter is rebuilt from the last persisted one.
+		s.dedup.drop(logID)
+		glog.Warningf("%v: failed to update dedup filter: %v", logID, err)
+	}
+	s.mu.Lock()
+	delete(s.failures, logID)
+	s.mu.Unlock()
+	return res, nil
+}
+
+// integrationPaused returns whether integration of the tree is paused at now.
+// A pause with a resume time ends automatically once that time has passed.
+func integrationPaused(tree *trillian.Tree, now time.Time) bool {
+	pause := tree.GetIntegrationPause()
+	if pause == nil {
+		return false
+	}
+	rt := pause.GetResumeTime()
+	return rt == nil || now.Before(rt.AsTime())
+}
+
+// passFailed records a failed sequencing pass for the tree, which integrated
+// batches of batchSize leaves. Once the number of consecutive failures reaches
+// info.PoisonLeafThreshold, it tries to find and quarantine the leaf which
+// makes the passes fail.
+func (s *SequencerManager) passFailed(ctx context.Context, tree *trillian.Tree, info *OperationInfo, batchSize int, maxRootDuration time.Duration) {
+	if info.PoisonLeafThreshold <= 0 || tree.TreeType != trillian.TreeType_LOG {
+		return
+	}
+	s.mu.Lock()
+	s.failures[tree.TreeId]++
+	failures := s.failures[tree.TreeId]
+	s.mu.Unlock()
+	if failures < info.PoisonLeafThreshold {
+		return
+	}
+
+	leaf, err := IsolatePoisonLeaf(ctx, tree, batchSize, s.guardWindow, maxRootDuration, info.TimeSource, s.registry.LogStorage, s.registry.QuotaManager)
+	if err != nil {
+		glog.Warningf("%v: failed to isolate poison leaf after %d failed passes: %v", tree.TreeId, failures, err)
+		return
+	}
+	if leaf != nil {
+		glog.Warningf("%v: quarantined leaf %x after %d failed passes: %s", tree.TreeId, leaf.LeafIdentityHash, failures, leaf.Reason)
+	}
+	s.mu.Lock()
+	delete(s.failures, tree.TreeId)
+	s.mu.Unlock()
+}
+
+// recordQueueStats exports the queue age metrics of the tree, if they haven't
+// been exported within the last info.QueueStatsInterval. The metrics are read
+// before the pass, so that leaves stuck behind failing passes show up too.
+// Returns the number of queued leaves, or -1 if they weren't read.
+func (s *SequencerManager) recordQueueStats(ctx context.Context, tree *trillian.Tree, info *OperationInfo) int64 {
+	if info.QueueStatsInterval <= 0 || tree.TreeType != trillian.TreeType_LOG {
+		return -1
+	}
+	now := info.TimeSource.Now()
+	s.mu.Lock()
+	if last, ok := s.queueStats[tree.TreeId]; ok && now.Sub(last) < info.QueueStatsInterval {
+		s.mu.Unlock()
+		return -1
+	}
+	s.queueStats[tree.TreeId] = now
+	s.mu.Unlock()
+
+	stats, err := RecordQueueStats(ctx, tree, now, s.registry.LogStorage)
+	if err != nil {
+		glog.Warningf("%v: failed to record queue stats: %v", tree.TreeId, err)
+		return -1
+	}
+	return stats.Size
+}
+
+// pruneLeaves runs a garbage collection pass over the tree, which prunes the
+// data of leaves older than its retention policy allows, if the last complete
+// pass was more than info.RetentionInterval ago. Leaves are pruned even while
+// integration is paused.
+func (s *SequencerManager) pruneLeaves(ctx context.Context, tree *trillian.Tree, info *OperationInfo) {
+	if info.RetentionInterval <= 0 || tree.GetRetentionPolicy() == nil {
+		return
+	}
+	now := info.TimeSource.Now()
+	s.mu.Lock()
+	last, ok := s.pruned[tree.TreeId]
+	s.mu.Unlock()
+	if ok && now.Sub(last) < info.RetentionInterval {
+		return
+	}
+
+	_, pruned, err := PruneLeaves(ctx, tree, now, info.RetentionBatchSize, s.registry.LogStorage, false /* dryRun */)
+	if err != nil {
+		glog.Warningf("%v: failed to prune leaves: %v", tree.TreeId, err)
+	} else if pruned > 0 {
+		seqPrunedLeaves.Add(float64(pruned), strconv.FormatInt(tree.TreeId, 10))
+		glog.V(1).Infof("%v: pruned the data of %d leaves", tree.TreeId, pruned)
+	}
+	// A full batch may leave more leaves to prune, which the next pass does.
+	if err != nil || pruned < info.RetentionBatchSize {
+		s.mu.Lock()
+		s.pruned[tree.TreeId] = now
+		s.mu.Unlock()
 	}
-	return leaves, nil
 }
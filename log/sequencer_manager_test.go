@@ -16,6 +16,7 @@ package log
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
 	"testing"
 	"time"
@@ -26,6 +27,7 @@ import (
 	"github.com/google/trillian/extension"
 	"github.com/google/trillian/quota"
 	"github.com/google/trillian/storage"
+	"github.com/google/trillian/storage/memory"
 	stestonly "github.com/google/trillian/storage/testonly"
 	"github.com/google/trillian/storage/tree"
 	"github.com/google/trillian/testonly"
@@ -34,6 +36,7 @@ import (
 	"github.com/transparency-dev/merkle/compact"
 	"github.com/transparency-dev/merkle/rfc6962"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // Arbitrary time for use in tests
@@ -236,3 +239,107 @@ func createTestInfo(registry extension.Registry) *OperationInfo {
 		TimeSource:  fakeTimeSource,
 	}
 }
+
+func TestSequencerManagerIntegrationPaused(t *testing.T) {
+	ctx := context.Background()
+	for _, test := range []struct {
+		desc       string
+		resumeTime time.Time
+		wantPass   bool
+	}{
+		{desc: "noResumeTime"},
+		{desc: "beforeResumeTime", resumeTime: fakeTime.Add(time.Minute)},
+		{desc: "afterResumeTime", resumeTime: fakeTime.Add(-time.Minute), wantPass: true},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			tree := proto.Clone(stestonly.LogTree).(*trillian.Tree)
+			tree.IntegrationPause = &trillian.IntegrationPause{PauseTime: timestamppb.New(fakeTime.Add(-time.Hour))}
+			if !test.resumeTime.IsZero() {
+				tree.IntegrationPause.ResumeTime = timestamppb.New(test.resumeTime)
+			}
+			logID := tree.GetTreeId()
+			mockAdminTx := storage.NewMockReadOnlyAdminTX(mockCtrl)
+			mockAdmin := &stestonly.FakeAdminStorage{ReadOnlyTX: []storage.ReadOnlyAdminTX{mockAdminTx}}
+			mockTx := storage.NewMockLogTreeTX(mockCtrl)
+			fakeStorage := &stestonly.FakeLogStorage{TX: mockTx}
+
+			mockAdminTx.EXPECT().GetTree(gomock.Any(), logID).Return(tree, nil)
+			mockAdminTx.EXPECT().Commit().Return(nil)
+			mockAdminTx.EXPECT().Close().Return(nil)
+			if test.wantPass {
+				mockTx.EXPECT().LatestSignedLogRoot(gomock.Any()).Return(testSignedRoot0, nil)
+				mockTx.EXPECT().DequeueLeaves(gomock.Any(), 50, fakeTime).Return([]*trillian.LogLeaf{}, nil)
+				mockTx.EXPECT().Commit(gomock.Any()).Return(nil)
+				mockTx.EXPECT().Close().Return(nil)
+			}
+
+			registry := extension.Registry{
+				AdminStorage: mockAdmin,
+				LogStorage:   fakeStorage,
+				QuotaManager: quota.Noop(),
+			}
+			sm := NewSequencerManager(registry, zeroDuration)
+			if _, err := sm.ExecutePass(ctx, logID, createTestInfo(registry)); err != nil {
+				t.Fatalf("ExecutePass(): %v", err)
+			}
+		})
+	}
+}
+
+func TestSequencerManagerBatchSizerAndTuner(t *testing.T) {
+	ctx := context.Background()
+	ts := memory.NewTreeStorage()
+	as := memory.NewAdminStorage(ts)
+	tree, err := storage.CreateTree(ctx, as, proto.Clone(stestonly.LogTree).(*trillian.Tree))
+	if err != nil {
+		t.Fatalf("CreateTree(): %v", err)
+	}
+	ls := memory.NewLogStorage(ts, nil)
+	root, err := (&types.LogRootV1{RootHash: rfc6962.DefaultHasher.EmptyRoot(), TimestampNanos: 1}).MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary(): %v", err)
+	}
+	if err := ls.ReadWriteTransaction(ctx, tree, func(ctx context.Context, tx storage.LogTreeTX) error {
+		return tx.StoreSignedLogRoot(ctx, &trillian.SignedLogRoot{LogRoot: root})
+	}); err != nil {
+		t.Fatalf("StoreSignedLogRoot(): %v", err)
+	}
+	leaves := make([]*trillian.LogLeaf, 0, 1000)
+	for i := 0; i < cap(leaves); i++ {
+		data := []byte(fmt.Sprintf("leaf %d", i))
+		hash := sha256.Sum256(data)
+		leaves = append(leaves, &trillian.LogLeaf{LeafValue: data, LeafIdentityHash: hash[:], MerkleLeafHash: rfc6962.DefaultHasher.HashLeaf(data)})
+	}
+	if _, err := ls.QueueLeaves(ctx, tree, leaves, fakeTime.Add(-time.Minute)); err != nil {
+		t.Fatalf("QueueLeaves(): %v", err)
+	}
+
+	registry := extension.Registry{AdminStorage: as, LogStorage: ls, QuotaManager: quota.Noop()}
+	timeSource := clock.NewFake(fakeTime)
+	info := createTestInfo(registry)
+	info.TimeSource = timeSource
+	info.BatchSize = 100
+	info.BatchTuning = &BatchTuning{MinBatchSize: 10, MaxBatchSize: 400, TargetLatency: time.Second, Hysteresis: 1}
+	sm := NewSequencerManager(registry, zeroDuration)
+	// The process is under memory pressure, which halves every batch.
+	sm.batchSizer.scale = 0.5
+
+	for i := 0; i < 3; i++ {
+		timeSource.Set(timeSource.Now().Add(time.Hour))
+		n, err := sm.ExecutePass(ctx, tree.TreeId, info)
+		if err != nil {
+			t.Fatalf("ExecutePass(): %v", err)
+		}
+		// The halved batches are full, but they don't make the tuner grow
+		// the batch size of the log to make up for the backoff.
+		if got, want := n, 50; got != want {
+			t.Errorf("pass %d: integrated %d leaves, want %d", i, got, want)
+		}
+	}
+	if got, _ := sm.tuner.next(tree.TreeId, timeSource.Now().Add(time.Hour), info); got != 100 {
+		t.Errorf("tuned batch size %d, want 100", got)
+	}
+}
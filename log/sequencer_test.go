@@ -24,6 +24,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/trillian"
+	"github.com/google/trillian/monitoring"
 	"github.com/google/trillian/quota"
 	"github.com/google/trillian/storage"
 	"github.com/google/trillian/testonly"
@@ -587,6 +588,45 @@ func TestIntegrateBatch(t *testing.T) {
 	}
 }
 
+func TestIntegrateBatchWithResult_DryRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	leaves16 := []*trillian.LogLeaf{testLeaf16}
+	params := testParameters{
+		logID:            154035,
+		dequeueLimit:     1,
+		shouldCommit:     false, // Dry runs must never commit.
+		dequeuedLeaves:   []*trillian.LogLeaf{getLeaf42()},
+		latestSignedRoot: testSignedRoot16,
+		merkleNodesGet:   &compactTree16,
+		updatedLeaves:    &leaves16,
+		merkleNodesSet:   &updatedNodes,
+		storeSignedRoot:  testSignedRoot,
+		// No PutTokens calls are expected on the mock.
+		qm: quota.NewMockManager(ctrl),
+	}
+	c, ctx := createTestContext(ctrl, params)
+	tree := &trillian.Tree{TreeId: params.logID, TreeType: trillian.TreeType_LOG}
+
+	res, err := IntegrateBatchWithResult(ctx, tree, 1, 0, 0, c.timeSource, c.fakeStorage, c.qm, true /* dryRun */)
+	if err != nil {
+		t.Fatalf("IntegrateBatchWithResult()=_,%v; want _,nil", err)
+	}
+	if got, want := len(res.Leaves), 1; got != want {
+		t.Errorf("IntegrateBatchWithResult() returned %d leaves, want %d", got, want)
+	}
+	if got, want := res.OldRoot.TreeSize, uint64(16); got != want {
+		t.Errorf("IntegrateBatchWithResult() OldRoot.TreeSize=%d, want %d", got, want)
+	}
+	if res.NewRoot == nil {
+		t.Fatal("IntegrateBatchWithResult() NewRoot=nil, want root")
+	}
+	if got, want := res.NewRoot.TreeSize, uint64(17); got != want {
+		t.Errorf("IntegrateBatchWithResult() NewRoot.TreeSize=%d, want %d", got, want)
+	}
+}
+
 func TestIntegrateBatch_PutTokens(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -696,3 +736,42 @@ func TestIntegrateBatch_PutTokens(t *testing.T) {
 		}()
 	}
 }
+
+func TestIntegrateBatchSpan(t *testing.T) {
+	ctx := context.Background()
+	InitMetrics(nil)
+	tree, ls := newMemoryLog(ctx, t)
+
+	type ctxKey struct{}
+	var spans []string
+	var inSpan bool
+	monitoring.SetStartSpan(func(ctx context.Context, name string) (context.Context, func()) {
+		spans = append(spans, name)
+		return context.WithValue(ctx, ctxKey{}, name), func() {}
+	})
+	defer monitoring.SetStartSpan(func(ctx context.Context, _ string) (context.Context, func()) { return ctx, func() {} })
+
+	spanStorage := &txFuncStorage{LogStorage: ls, f: func(ctx context.Context) {
+		inSpan = ctx.Value(ctxKey{}) == traceSpanRoot+".IntegrateBatch"
+	}}
+	if _, err := IntegrateBatch(ctx, tree, 1, 0, 0, clock.System, spanStorage, quota.Noop()); err != nil {
+		t.Fatalf("IntegrateBatch(): %v", err)
+	}
+	if got, want := fmt.Sprint(spans), "["+traceSpanRoot+".IntegrateBatch]"; got != want {
+		t.Errorf("IntegrateBatch() started spans %s, want %s", got, want)
+	}
+	if !inSpan {
+		t.Error("IntegrateBatch() ran its storage transaction outside of its span")
+	}
+}
+
+// txFuncStorage calls f with the context of each read-write transaction.
+type txFuncStorage struct {
+	storage.LogStorage
+	f func(context.Context)
+}
+
+func (s *txFuncStorage) ReadWriteTransaction(ctx context.Context, tree *trillian.Tree, f storage.LogTXFunc) error {
+	s.f(ctx)
+	return s.LogStorage.ReadWriteTransaction(ctx, tree, f)
+}
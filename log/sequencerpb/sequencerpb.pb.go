@@ -0,0 +1,663 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.0
+// 	protoc        v3.20.1
+// source: sequencerpb.proto
+
+package sequencerpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// BatchReport describes a sequencing pass over a log, which integrated a batch
+// of leaves, signed a new root, or failed.
+type BatchReport struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// ID of the log.
+	TreeId int64 `protobuf:"varint,1,opt,name=tree_id,json=treeId,proto3" json:"tree_id,omitempty"`
+	// Time at which the pass started.
+	StartTime *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// Total duration of the pass.
+	Duration *durationpb.Duration `protobuf:"bytes,3,opt,name=duration,proto3" json:"duration,omitempty"`
+	// Maximum number of leaves the pass could integrate.
+	BatchSize int64 `protobuf:"varint,4,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	// Number of leaves integrated by the pass.
+	LeavesIntegrated int64 `protobuf:"varint,5,opt,name=leaves_integrated,json=leavesIntegrated,proto3" json:"leaves_integrated,omitempty"`
+	// Longest time between the queueing and the integration of a leaf of the
+	// batch.
+	MaxMergeDelay *durationpb.Duration `protobuf:"bytes,6,opt,name=max_merge_delay,json=maxMergeDelay,proto3" json:"max_merge_delay,omitempty"`
+	// Time spent in each storage operation of the pass.
+	StorageLatencies *StorageLatencies `protobuf:"bytes,7,opt,name=storage_latencies,json=storageLatencies,proto3" json:"storage_latencies,omitempty"`
+	// The root signed by the pass. Unset if the pass failed.
+	NewRoot *Root `protobuf:"bytes,8,opt,name=new_root,json=newRoot,proto3" json:"new_root,omitempty"`
+	// The error which made the pass fail, if any.
+	Error string `protobuf:"bytes,9,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *BatchReport) Reset() {
+	*x = BatchReport{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sequencerpb_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchReport) ProtoMessage() {}
+
+func (x *BatchReport) ProtoReflect() protoreflect.Message {
+	mi := &file_sequencerpb_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchReport.ProtoReflect.Descriptor instead.
+func (*BatchReport) Descriptor() ([]byte, []int) {
+	return file_sequencerpb_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *BatchReport) GetTreeId() int64 {
+	if x != nil {
+		return x.TreeId
+	}
+	return 0
+}
+
+func (x *BatchReport) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *BatchReport) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *BatchReport) GetBatchSize() int64 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+func (x *BatchReport) GetLeavesIntegrated() int64 {
+	if x != nil {
+		return x.LeavesIntegrated
+	}
+	return 0
+}
+
+func (x *BatchReport) GetMaxMergeDelay() *durationpb.Duration {
+	if x != nil {
+		return x.MaxMergeDelay
+	}
+	return nil
+}
+
+func (x *BatchReport) GetStorageLatencies() *StorageLatencies {
+	if x != nil {
+		return x.StorageLatencies
+	}
+	return nil
+}
+
+func (x *BatchReport) GetNewRoot() *Root {
+	if x != nil {
+		return x.NewRoot
+	}
+	return nil
+}
+
+func (x *BatchReport) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// StorageLatencies breaks down the time a sequencing pass spent in storage.
+// Stages which the pass didn't reach are unset.
+type StorageLatencies struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Reading the latest root.
+	GetRoot *durationpb.Duration `protobuf:"bytes,1,opt,name=get_root,json=getRoot,proto3" json:"get_root,omitempty"`
+	// Dequeueing the batch of leaves.
+	Dequeue *durationpb.Duration `protobuf:"bytes,2,opt,name=dequeue,proto3" json:"dequeue,omitempty"`
+	// Reading the compact range of the tree, unless it was cached.
+	InitTree *durationpb.Duration `protobuf:"bytes,3,opt,name=init_tree,json=initTree,proto3" json:"init_tree,omitempty"`
+	// Writing the sequenced leaves.
+	UpdateLeaves *durationpb.Duration `protobuf:"bytes,4,opt,name=update_leaves,json=updateLeaves,proto3" json:"update_leaves,omitempty"`
+	// Writing the Merkle tree nodes.
+	SetNodes *durationpb.Duration `protobuf:"bytes,5,opt,name=set_nodes,json=setNodes,proto3" json:"set_nodes,omitempty"`
+	// Writing the new root.
+	StoreRoot *durationpb.Duration `protobuf:"bytes,6,opt,name=store_root,json=storeRoot,proto3" json:"store_root,omitempty"`
+	// Committing the storage transaction.
+	Commit *durationpb.Duration `protobuf:"bytes,7,opt,name=commit,proto3" json:"commit,omitempty"`
+}
+
+func (x *StorageLatencies) Reset() {
+	*x = StorageLatencies{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sequencerpb_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StorageLatencies) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorageLatencies) ProtoMessage() {}
+
+func (x *StorageLatencies) ProtoReflect() protoreflect.Message {
+	mi := &file_sequencerpb_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StorageLatencies.ProtoReflect.Descriptor instead.
+func (*StorageLatencies) Descriptor() ([]byte, []int) {
+	return file_sequencerpb_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *StorageLatencies) GetGetRoot() *durationpb.Duration {
+	if x != nil {
+		return x.GetRoot
+	}
+	return nil
+}
+
+func (x *StorageLatencies) GetDequeue() *durationpb.Duration {
+	if x != nil {
+		return x.Dequeue
+	}
+	return nil
+}
+
+func (x *StorageLatencies) GetInitTree() *durationpb.Duration {
+	if x != nil {
+		return x.InitTree
+	}
+	return nil
+}
+
+func (x *StorageLatencies) GetUpdateLeaves() *durationpb.Duration {
+	if x != nil {
+		return x.UpdateLeaves
+	}
+	return nil
+}
+
+func (x *StorageLatencies) GetSetNodes() *durationpb.Duration {
+	if x != nil {
+		return x.SetNodes
+	}
+	return nil
+}
+
+func (x *StorageLatencies) GetStoreRoot() *durationpb.Duration {
+	if x != nil {
+		return x.StoreRoot
+	}
+	return nil
+}
+
+func (x *StorageLatencies) GetCommit() *durationpb.Duration {
+	if x != nil {
+		return x.Commit
+	}
+	return nil
+}
+
+// Root summarizes a log root.
+type Root struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TreeSize  uint64                 `protobuf:"varint,1,opt,name=tree_size,json=treeSize,proto3" json:"tree_size,omitempty"`
+	RootHash  []byte                 `protobuf:"bytes,2,opt,name=root_hash,json=rootHash,proto3" json:"root_hash,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+}
+
+func (x *Root) Reset() {
+	*x = Root{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sequencerpb_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Root) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Root) ProtoMessage() {}
+
+func (x *Root) ProtoReflect() protoreflect.Message {
+	mi := &file_sequencerpb_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Root.ProtoReflect.Descriptor instead.
+func (*Root) Descriptor() ([]byte, []int) {
+	return file_sequencerpb_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Root) GetTreeSize() uint64 {
+	if x != nil {
+		return x.TreeSize
+	}
+	return 0
+}
+
+func (x *Root) GetRootHash() []byte {
+	if x != nil {
+		return x.RootHash
+	}
+	return nil
+}
+
+func (x *Root) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+// ListBatchReports request.
+type ListBatchReportsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// ID of the log to list the reports of. If zero, the reports of all logs
+	// are listed.
+	TreeId int64 `protobuf:"varint,1,opt,name=tree_id,json=treeId,proto3" json:"tree_id,omitempty"`
+	// Maximum number of reports to return. If zero, all reports kept by the
+	// signer are returned.
+	MaxReports int32 `protobuf:"varint,2,opt,name=max_reports,json=maxReports,proto3" json:"max_reports,omitempty"`
+}
+
+func (x *ListBatchReportsRequest) Reset() {
+	*x = ListBatchReportsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sequencerpb_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListBatchReportsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBatchReportsRequest) ProtoMessage() {}
+
+func (x *ListBatchReportsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sequencerpb_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBatchReportsRequest.ProtoReflect.Descriptor instead.
+func (*ListBatchReportsRequest) Descriptor() ([]byte, []int) {
+	return file_sequencerpb_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListBatchReportsRequest) GetTreeId() int64 {
+	if x != nil {
+		return x.TreeId
+	}
+	return 0
+}
+
+func (x *ListBatchReportsRequest) GetMaxReports() int32 {
+	if x != nil {
+		return x.MaxReports
+	}
+	return 0
+}
+
+// ListBatchReports response.
+type ListBatchReportsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The reports, most recent first.
+	Reports []*BatchReport `protobuf:"bytes,1,rep,name=reports,proto3" json:"reports,omitempty"`
+}
+
+func (x *ListBatchReportsResponse) Reset() {
+	*x = ListBatchReportsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sequencerpb_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListBatchReportsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBatchReportsResponse) ProtoMessage() {}
+
+func (x *ListBatchReportsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sequencerpb_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBatchReportsResponse.ProtoReflect.Descriptor instead.
+func (*ListBatchReportsResponse) Descriptor() ([]byte, []int) {
+	return file_sequencerpb_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListBatchReportsResponse) GetReports() []*BatchReport {
+	if x != nil {
+		return x.Reports
+	}
+	return nil
+}
+
+var File_sequencerpb_proto protoreflect.FileDescriptor
+
+var file_sequencerpb_proto_rawDesc = []byte{
+	0x0a, 0x11, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x0b, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x72, 0x70, 0x62,
+	0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x22, 0xb7, 0x03, 0x0a, 0x0b, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x72, 0x65, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x06, 0x74, 0x72, 0x65, 0x65, 0x49, 0x64, 0x12, 0x39, 0x0a, 0x0a, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a,
+	0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x09, 0x62, 0x61, 0x74, 0x63, 0x68, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x6c,
+	0x65, 0x61, 0x76, 0x65, 0x73, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x67, 0x72, 0x61, 0x74, 0x65, 0x64,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x6c, 0x65, 0x61, 0x76, 0x65, 0x73, 0x49, 0x6e,
+	0x74, 0x65, 0x67, 0x72, 0x61, 0x74, 0x65, 0x64, 0x12, 0x41, 0x0a, 0x0f, 0x6d, 0x61, 0x78, 0x5f,
+	0x6d, 0x65, 0x72, 0x67, 0x65, 0x5f, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x6d, 0x61,
+	0x78, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x12, 0x4a, 0x0a, 0x11, 0x73,
+	0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63,
+	0x65, 0x72, 0x70, 0x62, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x4c, 0x61, 0x74, 0x65,
+	0x6e, 0x63, 0x69, 0x65, 0x73, 0x52, 0x10, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x4c, 0x61,
+	0x74, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x12, 0x2c, 0x0a, 0x08, 0x6e, 0x65, 0x77, 0x5f, 0x72,
+	0x6f, 0x6f, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x73, 0x65, 0x71, 0x75,
+	0x65, 0x6e, 0x63, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x52, 0x6f, 0x6f, 0x74, 0x52, 0x07, 0x6e, 0x65,
+	0x77, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x9a, 0x03, 0x0a, 0x10,
+	0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73,
+	0x12, 0x34, 0x0a, 0x08, 0x67, 0x65, 0x74, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x67,
+	0x65, 0x74, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x33, 0x0a, 0x07, 0x64, 0x65, 0x71, 0x75, 0x65, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x07, 0x64, 0x65, 0x71, 0x75, 0x65, 0x75, 0x65, 0x12, 0x36, 0x0a, 0x09, 0x69,
+	0x6e, 0x69, 0x74, 0x5f, 0x74, 0x72, 0x65, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x69, 0x6e, 0x69, 0x74, 0x54,
+	0x72, 0x65, 0x65, 0x12, 0x3e, 0x0a, 0x0d, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x6c, 0x65,
+	0x61, 0x76, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4c, 0x65, 0x61,
+	0x76, 0x65, 0x73, 0x12, 0x36, 0x0a, 0x09, 0x73, 0x65, 0x74, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x73,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x08, 0x73, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x38, 0x0a, 0x0a, 0x73,
+	0x74, 0x6f, 0x72, 0x65, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x73, 0x74, 0x6f, 0x72,
+	0x65, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x31, 0x0a, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x22, 0x7a, 0x0a, 0x04, 0x52, 0x6f, 0x6f, 0x74,
+	0x12, 0x1b, 0x0a, 0x09, 0x74, 0x72, 0x65, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x08, 0x74, 0x72, 0x65, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1b, 0x0a,
+	0x09, 0x72, 0x6f, 0x6f, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x08, 0x72, 0x6f, 0x6f, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x22, 0x53, 0x0a, 0x17, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x17, 0x0a, 0x07, 0x74, 0x72, 0x65, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x06, 0x74, 0x72, 0x65, 0x65, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x5f,
+	0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x6d,
+	0x61, 0x78, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x22, 0x4e, 0x0a, 0x18, 0x4c, 0x69, 0x73,
+	0x74, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x07, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63,
+	0x65, 0x72, 0x70, 0x62, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x52, 0x07, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x32, 0x6e, 0x0a, 0x09, 0x53, 0x65, 0x71,
+	0x75, 0x65, 0x6e, 0x63, 0x65, 0x72, 0x12, 0x61, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x12, 0x24, 0x2e, 0x73, 0x65, 0x71,
+	0x75, 0x65, 0x6e, 0x63, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x25, 0x2e, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x72, 0x70, 0x62, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x2c, 0x5a, 0x2a, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x74,
+	0x72, 0x69, 0x6c, 0x6c, 0x69, 0x61, 0x6e, 0x2f, 0x6c, 0x6f, 0x67, 0x2f, 0x73, 0x65, 0x71, 0x75,
+	0x65, 0x6e, 0x63, 0x65, 0x72, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_sequencerpb_proto_rawDescOnce sync.Once
+	file_sequencerpb_proto_rawDescData = file_sequencerpb_proto_rawDesc
+)
+
+func file_sequencerpb_proto_rawDescGZIP() []byte {
+	file_sequencerpb_proto_rawDescOnce.Do(func() {
+		file_sequencerpb_proto_rawDescData = protoimpl.X.CompressGZIP(file_sequencerpb_proto_rawDescData)
+	})
+	return file_sequencerpb_proto_rawDescData
+}
+
+var file_sequencerpb_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_sequencerpb_proto_goTypes = []interface{}{
+	(*BatchReport)(nil),              // 0: sequencerpb.BatchReport
+	(*StorageLatencies)(nil),         // 1: sequencerpb.StorageLatencies
+	(*Root)(nil),                     // 2: sequencerpb.Root
+	(*ListBatchReportsRequest)(nil),  // 3: sequencerpb.ListBatchReportsRequest
+	(*ListBatchReportsResponse)(nil), // 4: sequencerpb.ListBatchReportsResponse
+	(*timestamppb.Timestamp)(nil),    // 5: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),      // 6: google.protobuf.Duration
+}
+var file_sequencerpb_proto_depIdxs = []int32{
+	5,  // 0: sequencerpb.BatchReport.start_time:type_name -> google.protobuf.Timestamp
+	6,  // 1: sequencerpb.BatchReport.duration:type_name -> google.protobuf.Duration
+	6,  // 2: sequencerpb.BatchReport.max_merge_delay:type_name -> google.protobuf.Duration
+	1,  // 3: sequencerpb.BatchReport.storage_latencies:type_name -> sequencerpb.StorageLatencies
+	2,  // 4: sequencerpb.BatchReport.new_root:type_name -> sequencerpb.Root
+	6,  // 5: sequencerpb.StorageLatencies.get_root:type_name -> google.protobuf.Duration
+	6,  // 6: sequencerpb.StorageLatencies.dequeue:type_name -> google.protobuf.Duration
+	6,  // 7: sequencerpb.StorageLatencies.init_tree:type_name -> google.protobuf.Duration
+	6,  // 8: sequencerpb.StorageLatencies.update_leaves:type_name -> google.protobuf.Duration
+	6,  // 9: sequencerpb.StorageLatencies.set_nodes:type_name -> google.protobuf.Duration
+	6,  // 10: sequencerpb.StorageLatencies.store_root:type_name -> google.protobuf.Duration
+	6,  // 11: sequencerpb.StorageLatencies.commit:type_name -> google.protobuf.Duration
+	5,  // 12: sequencerpb.Root.timestamp:type_name -> google.protobuf.Timestamp
+	0,  // 13: sequencerpb.ListBatchReportsResponse.reports:type_name -> sequencerpb.BatchReport
+	3,  // 14: sequencerpb.Sequencer.ListBatchReports:input_type -> sequencerpb.ListBatchReportsRequest
+	4,  // 15: sequencerpb.Sequencer.ListBatchReports:output_type -> sequencerpb.ListBatchReportsResponse
+	15, // [15:16] is the sub-list for method output_type
+	14, // [14:15] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
+}
+
+func init() { file_sequencerpb_proto_init() }
+func file_sequencerpb_proto_init() {
+	if File_sequencerpb_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_sequencerpb_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchReport); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sequencerpb_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StorageLatencies); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sequencerpb_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Root); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sequencerpb_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListBatchReportsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sequencerpb_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListBatchReportsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_sequencerpb_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_sequencerpb_proto_goTypes,
+		DependencyIndexes: file_sequencerpb_proto_depIdxs,
+		MessageInfos:      file_sequencerpb_proto_msgTypes,
+	}.Build()
+	File_sequencerpb_proto = out.File
+	file_sequencerpb_proto_rawDesc = nil
+	file_sequencerpb_proto_goTypes = nil
+	file_sequencerpb_proto_depIdxs = nil
+}
@@ -0,0 +1,109 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.20.1
+// source: sequencerpb.proto
+
+package sequencerpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// SequencerClient is the client API for Sequencer service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SequencerClient interface {
+	// Lists the reports of the most recent sequencing passes run by the signer.
+	// Passes which neither integrated leaves, signed a root nor failed aren't
+	// reported.
+	ListBatchReports(ctx context.Context, in *ListBatchReportsRequest, opts ...grpc.CallOption) (*ListBatchReportsResponse, error)
+}
+
+type sequencerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSequencerClient(cc grpc.ClientConnInterface) SequencerClient {
+	return &sequencerClient{cc}
+}
+
+func (c *sequencerClient) ListBatchReports(ctx context.Context, in *ListBatchReportsRequest, opts ...grpc.CallOption) (*ListBatchReportsResponse, error) {
+	out := new(ListBatchReportsResponse)
+	err := c.cc.Invoke(ctx, "/sequencerpb.Sequencer/ListBatchReports", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SequencerServer is the server API for Sequencer service.
+// All implementations should embed UnimplementedSequencerServer
+// for forward compatibility
+type SequencerServer interface {
+	// Lists the reports of the most recent sequencing passes run by the signer.
+	// Passes which neither integrated leaves, signed a root nor failed aren't
+	// reported.
+	ListBatchReports(context.Context, *ListBatchReportsRequest) (*ListBatchReportsResponse, error)
+}
+
+// UnimplementedSequencerServer should be embedded to have forward compatible implementations.
+type UnimplementedSequencerServer struct {
+}
+
+func (UnimplementedSequencerServer) ListBatchReports(context.Context, *ListBatchReportsRequest) (*ListBatchReportsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBatchReports not implemented")
+}
+
+// UnsafeSequencerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SequencerServer will
+// result in compilation errors.
+type UnsafeSequencerServer interface {
+	mustEmbedUnimplementedSequencerServer()
+}
+
+func RegisterSequencerServer(s grpc.ServiceRegistrar, srv SequencerServer) {
+	s.RegisterService(&Sequencer_ServiceDesc, srv)
+}
+
+func _Sequencer_ListBatchReports_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBatchReportsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SequencerServer).ListBatchReports(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sequencerpb.Sequencer/ListBatchReports",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SequencerServer).ListBatchReports(ctx, req.(*ListBatchReportsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Sequencer_ServiceDesc is the grpc.ServiceDesc for Sequencer service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Sequencer_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "sequencerpb.Sequencer",
+	HandlerType: (*SequencerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListBatchReports",
+			Handler:    _Sequencer_ListBatchReports_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "sequencerpb.proto",
+}
@@ -0,0 +1,136 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"sync"
+	"time"
+)
+
+// treeScheduler hands out the logs this instance is master for to the
+// workers of an OperationManager, in weighted round robin order: in each
+// round, a log gets as many passes as its weight, interleaved with the other
+// logs, and no log is passed over by two workers at once. Unlike the rounds
+// of executePassForAll, which wait for the slowest pass, a slow pass over a
+// large log only holds up one worker, while the others keep passing over the
+// small logs.
+type treeScheduler struct {
+	// interval is the shortest time between the starts of passes over a log.
+	interval time.Duration
+	// weights holds the weights of logs which don't have the default of 1.
+	weights map[int64]int
+
+	mu sync.Mutex
+	// round holds the logs of the current round, and the passes left to them.
+	round []*scheduledLog
+	// next is the position in round where the search for a log starts.
+	next      int
+	running   map[int64]bool
+	lastStart map[int64]time.Time
+	// wake is closed, and replaced, when a pass finishes.
+	wake chan struct{}
+}
+
+// scheduledLog is a log in the round of a treeScheduler.
+type scheduledLog struct {
+	id      int64
+	credits int
+}
+
+func newTreeScheduler(interval time.Duration, weights map[int64]int) *treeScheduler {
+	return &treeScheduler{
+		interval:  interval,
+		weights:   weights,
+		running:   make(map[int64]bool),
+		lastStart: make(map[int64]time.Time),
+		wake:      make(chan struct{}),
+	}
+}
+
+// startRound starts a round over the given logs. Logs which are being passed
+// over still finish their passes, and only get new ones once they have.
+func (s *treeScheduler) startRound(logIDs []int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.round = make([]*scheduledLog, 0, len(logIDs))
+	held := make(map[int64]bool, len(logIDs))
+	for _, id := range logIDs {
+		weight := 1
+		if w, ok := s.weights[id]; ok && w > 0 {
+			weight = w
+		}
+		s.round = append(s.round, &scheduledLog{id: id, credits: weight})
+		held[id] = true
+	}
+	s.next = 0
+	// Forget the logs which aren't held any more.
+	for id := range s.lastStart {
+		if !held[id] && !s.running[id] {
+			delete(s.lastStart, id)
+		}
+	}
+}
+
+// take returns the next log of the round to pass over at now, and marks it
+// as running until done is called. If no log is due, it returns false and the
+// time until one is, or zero if the round is over. Logs which are running
+// don't hold the round up, so that a slow pass doesn't hold up the others;
+// they may get their passes in the next round instead.
+func (s *treeScheduler) take(now time.Time) (int64, bool, time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var wait time.Duration
+	for i := range s.round {
+		l := s.round[(s.next+i)%len(s.round)]
+		if l.credits == 0 || s.running[l.id] {
+			continue
+		}
+		if due := s.lastStart[l.id].Add(s.interval).Sub(now); due > 0 {
+			if wait == 0 || due < wait {
+				wait = due
+			}
+			continue
+		}
+		l.credits--
+		s.next = (s.next + i + 1) % len(s.round)
+		s.running[l.id] = true
+		s.lastStart[l.id] = now
+		return l.id, true, 0
+	}
+	return 0, false, wait
+}
+
+// done marks the pass over the log as finished.
+func (s *treeScheduler) done(logID int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.running, logID)
+	close(s.wake)
+	s.wake = make(chan struct{})
+}
+
+// changed returns a channel which is closed when a pass finishes.
+func (s *treeScheduler) changed() <-chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.wake
+}
+
+// isRunning returns whether the log is being passed over.
+func (s *treeScheduler) isRunning(logID int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.running[logID]
+}
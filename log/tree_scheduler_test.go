@@ -0,0 +1,79 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTreeScheduler(t *testing.T) {
+	s := newTreeScheduler(time.Second, map[int64]int{1: 2, 3: 0})
+	now := time.Unix(1000, 0)
+
+	take := func() (int64, bool, time.Duration) {
+		t.Helper()
+		return s.take(now)
+	}
+	mustTake := func(want int64) {
+		t.Helper()
+		if got, ok, _ := take(); !ok || got != want {
+			t.Fatalf("take(): %d, %v, want %d", got, ok, want)
+		}
+		s.done(want)
+	}
+
+	// Log 1 has a weight of 2, and its passes are interleaved with the others.
+	s.startRound([]int64{1, 2, 3})
+	mustTake(1)
+	mustTake(2)
+	mustTake(3)
+	if _, ok, wait := take(); ok || wait != time.Second {
+		t.Fatalf("take() before log 1 is due: %v, %v, want false, %v", ok, wait, time.Second)
+	}
+	now = now.Add(time.Second)
+	mustTake(1)
+	if _, ok, wait := take(); ok || wait != 0 {
+		t.Fatalf("take() after the round: %v, %v, want false, 0", ok, wait)
+	}
+
+	// A running log isn't handed out again.
+	now = now.Add(time.Second)
+	s.startRound([]int64{1, 2})
+	if got, ok, _ := take(); !ok || got != 1 {
+		t.Fatalf("take(): %d, %v, want 1", got, ok)
+	}
+	wake := s.changed()
+	mustTake(2)
+	// The round is over, even though log 1 still has a pass left.
+	if _, ok, wait := take(); ok || wait != 0 {
+		t.Fatalf("take() while log 1 is running: %v, %v, want false, 0", ok, wait)
+	}
+	select {
+	case <-wake:
+	default:
+		t.Fatal("changed() not closed after a pass finished")
+	}
+	if !s.isRunning(1) {
+		t.Error("isRunning(1)=false, want true")
+	}
+	s.done(1)
+	if s.isRunning(1) {
+		t.Error("isRunning(1)=true after done, want false")
+	}
+	// It's due again after the interval, within the same round.
+	now = now.Add(time.Second)
+	mustTake(1)
+}
@@ -12,6 +12,11 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
+// Package opencensus provides tracing with OpenCensus, exported to
+// Stackdriver.
+//
+// Deprecated: The Trillian servers trace with OpenTelemetry instead, see the
+// opentelemetry package.
 package opencensus
 
 import (
@@ -0,0 +1,137 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package opentelemetry provides tracing with OpenTelemetry. Spans are
+// exported over OTLP/gRPC, e.g. to an OpenTelemetry collector, and the trace
+// context is propagated in and out of gRPC calls with the W3C Trace Context
+// headers.
+package opentelemetry
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpgrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/semconv"
+	"google.golang.org/grpc"
+)
+
+// tracerName is the name of the tracer which starts the spans of Trillian.
+const tracerName = "github.com/google/trillian"
+
+// defaultSampleRate is the fraction of traces sampled if no percentage is
+// set, which is that of the default OpenCensus sampler.
+const defaultSampleRate = 1e-4
+
+// Options configures the export of traces.
+type Options struct {
+	// ServiceName identifies the process in the exported traces.
+	ServiceName string
+	// Endpoint is the host:port of the OTLP/gRPC receiver to export to.
+	Endpoint string
+	// Insecure disables TLS for the connection to Endpoint.
+	Insecure bool
+	// Percent is the percentage of traces sampled, between 0 and 100. Zero
+	// samples the default fraction of traces. Traces continued from a caller
+	// are sampled if the caller sampled them.
+	Percent int
+}
+
+// EnableTracing sets up OpenTelemetry to export the spans started by
+// StartSpan and the interceptors of this package. The returned function
+// exports the remaining spans, and must be called before exiting.
+func EnableTracing(ctx context.Context, opts Options) (func(context.Context) error, error) {
+	var driverOpts []otlpgrpc.Option
+	if opts.Endpoint != "" {
+		driverOpts = append(driverOpts, otlpgrpc.WithEndpoint(opts.Endpoint))
+	}
+	if opts.Insecure {
+		driverOpts = append(driverOpts, otlpgrpc.WithInsecure())
+	}
+	// The exporter connects in the background, so that the server can start
+	// while the receiver is unavailable.
+	exp, err := otlp.NewExporter(ctx, otlpgrpc.NewDriver(driverOpts...))
+	if err != nil {
+		return nil, err
+	}
+	tp, err := newTracerProvider(opts, sdktrace.WithBatcher(exp))
+	if err != nil {
+		return nil, err
+	}
+	install(tp)
+	return tp.Shutdown, nil
+}
+
+// install makes tp the tracer provider of the process, and sets up the
+// propagation of the trace context.
+func install(tp *sdktrace.TracerProvider) {
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+}
+
+// newTracerProvider returns a tracer provider sampling the traces configured
+// by opts.
+func newTracerProvider(opts Options, extra ...sdktrace.TracerProviderOption) (*sdktrace.TracerProvider, error) {
+	sampler, err := newSampler(opts.Percent)
+	if err != nil {
+		return nil, err
+	}
+	tpOpts := []sdktrace.TracerProviderOption{sdktrace.WithSampler(sampler)}
+	if opts.ServiceName != "" {
+		tpOpts = append(tpOpts, sdktrace.WithResource(resource.Merge(resource.Default(),
+			resource.NewWithAttributes(semconv.ServiceNameKey.String(opts.ServiceName)))))
+	}
+	return sdktrace.NewTracerProvider(append(tpOpts, extra...)...), nil
+}
+
+func newSampler(percent int) (sdktrace.Sampler, error) {
+	switch {
+	case percent < 0:
+		return nil, errors.New("cannot trace a negative percentage of requests")
+	case percent > 100:
+		return nil, errors.New("cannot trace more than 100 percent of requests")
+	case percent == 0:
+		return sdktrace.ParentBased(sdktrace.TraceIDRatioBased(defaultSampleRate)), nil
+	case percent == 100:
+		return sdktrace.ParentBased(sdktrace.AlwaysSample()), nil
+	default:
+		return sdktrace.ParentBased(sdktrace.TraceIDRatioBased(float64(percent) / 100.0)), nil
+	}
+}
+
+// UnaryServerInterceptor returns a gRPC interceptor which starts a span for
+// every unary RPC, continuing the trace of the caller. It must be created
+// after EnableTracing.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return otelgrpc.UnaryServerInterceptor()
+}
+
+// StreamServerInterceptor is UnaryServerInterceptor for streaming RPCs.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return otelgrpc.StreamServerInterceptor()
+}
+
+// StartSpan starts a new tracing span.
+// The returned context should be used for all child calls within the span, and
+// the returned func should be called to close the span.
+func StartSpan(ctx context.Context, name string) (context.Context, func()) {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, name)
+	return ctx, func() { span.End() }
+}
@@ -0,0 +1,119 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentelemetry
+
+import (
+	"context"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// installTestProvider exports all spans to the returned exporter, until the
+// test ends.
+func installTestProvider(t *testing.T) *tracetest.InMemoryExporter {
+	t.Helper()
+	exp := tracetest.NewInMemoryExporter()
+	tp, err := newTracerProvider(Options{ServiceName: "test", Percent: 100}, sdktrace.WithSyncer(exp))
+	if err != nil {
+		t.Fatalf("newTracerProvider(): %v", err)
+	}
+	install(tp)
+	t.Cleanup(func() {
+		if err := tp.Shutdown(context.Background()); err != nil {
+			t.Errorf("Shutdown(): %v", err)
+		}
+	})
+	return exp
+}
+
+func TestNewSampler(t *testing.T) {
+	for _, tc := range []struct {
+		percent int
+		wantErr bool
+	}{
+		{percent: -1, wantErr: true},
+		{percent: 0},
+		{percent: 50},
+		{percent: 100},
+		{percent: 101, wantErr: true},
+	} {
+		if _, err := newSampler(tc.percent); (err != nil) != tc.wantErr {
+			t.Errorf("newSampler(%d): %v, want error %v", tc.percent, err, tc.wantErr)
+		}
+	}
+}
+
+func TestStartSpan(t *testing.T) {
+	exp := installTestProvider(t)
+
+	ctx, parentEnd := StartSpan(context.Background(), "parent")
+	_, childEnd := StartSpan(ctx, "child")
+	childEnd()
+	parentEnd()
+
+	spans := exp.GetSpans()
+	if got, want := len(spans), 2; got != want {
+		t.Fatalf("exported %d spans, want %d", got, want)
+	}
+	child, parent := spans[0], spans[1]
+	if child.Name != "child" || parent.Name != "parent" {
+		t.Fatalf("exported spans %q and %q, want child and parent", child.Name, parent.Name)
+	}
+	if got, want := child.Parent.SpanID(), parent.SpanContext.SpanID(); got != want {
+		t.Errorf("child span has parent %v, want %v", got, want)
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	exp := installTestProvider(t)
+
+	const (
+		traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+		spanID  = "00f067aa0ba902b7"
+	)
+	md := metadata.Pairs("traceparent", "00-"+traceID+"-"+spanID+"-01")
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+	info := &grpc.UnaryServerInfo{FullMethod: "/trillian.TrillianLog/GetLatestSignedLogRoot"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		_, spanEnd := StartSpan(ctx, "handler")
+		spanEnd()
+		return nil, nil
+	}
+	if _, err := UnaryServerInterceptor()(ctx, nil, info, handler); err != nil {
+		t.Fatalf("UnaryServerInterceptor(): %v", err)
+	}
+
+	spans := exp.GetSpans()
+	if got, want := len(spans), 2; got != want {
+		t.Fatalf("exported %d spans, want %d", got, want)
+	}
+	handlerSpan, rpcSpan := spans[0], spans[1]
+	if got, want := rpcSpan.Name, "trillian.TrillianLog/GetLatestSignedLogRoot"; got != want {
+		t.Errorf("RPC span named %q, want %q", got, want)
+	}
+	if got := rpcSpan.SpanContext.TraceID().String(); got != traceID {
+		t.Errorf("RPC span in trace %s, want the caller's trace %s", got, traceID)
+	}
+	if got := rpcSpan.Parent.SpanID().String(); got != spanID {
+		t.Errorf("RPC span has parent %s, want the caller's span %s", got, spanID)
+	}
+	if got, want := handlerSpan.Parent.SpanID(), rpcSpan.SpanContext.SpanID(); got != want {
+		t.Errorf("handler span has parent %v, want the RPC span %v", got, want)
+	}
+}
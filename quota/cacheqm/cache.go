@@ -175,6 +175,22 @@ func (m *manager) evict(ctx context.Context) {
 	wg.Wait()
 }
 
+// HasProfile implements quota.TreeConfigurer.HasProfile. Profiles are only
+// available if the wrapped Manager supports them.
+func (m *manager) HasProfile(name string) bool {
+	tc, ok := m.Manager.(quota.TreeConfigurer)
+	return ok && tc.HasProfile(name)
+}
+
+// ConfigureTree implements quota.TreeConfigurer.ConfigureTree.
+func (m *manager) ConfigureTree(ctx context.Context, treeID int64, profile string) error {
+	tc, ok := m.Manager.(quota.TreeConfigurer)
+	if !ok {
+		return fmt.Errorf("quota manager %T doesn't support profiles", m.Manager)
+	}
+	return tc.ConfigureTree(ctx, treeID, profile)
+}
+
 // wait waits for spawned goroutines to complete. Used by eviction tests.
 func (m *manager) wait() {
 	m.evictWg.Wait()
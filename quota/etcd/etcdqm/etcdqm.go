@@ -21,17 +21,19 @@ import (
 
 	"github.com/google/trillian/quota"
 	"github.com/google/trillian/quota/etcd/storage"
+	"github.com/google/trillian/quota/etcd/storagepb"
 	clientv3 "go.etcd.io/etcd/client/v3"
 )
 
 // Manager implements a quota manager based on etcd.
 type Manager struct {
-	qs *storage.QuotaStorage
+	qs       *storage.QuotaStorage
+	profiles map[string]*storagepb.Profile
 }
 
-// New returns a new etcd-based quota.Manager.
+// New returns a new etcd-based quota.Manager, with the DefaultProfiles.
 func New(client *clientv3.Client) *Manager {
-	return &Manager{qs: &storage.QuotaStorage{Client: client}}
+	return &Manager{qs: &storage.QuotaStorage{Client: client}, profiles: DefaultProfiles()}
 }
 
 // GetTokens implements the quota.Manager API.
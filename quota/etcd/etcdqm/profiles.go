@@ -0,0 +1,120 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdqm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/trillian/quota"
+	"github.com/google/trillian/quota/etcd/storage"
+	"github.com/google/trillian/quota/etcd/storagepb"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultProfiles returns the built-in quota profiles, "small", "medium" and
+// "large". Each has a time-based read quota replenished every second by a
+// tenth of its tokens, and a sequencing-based write quota.
+func DefaultProfiles() map[string]*storagepb.Profile {
+	profile := func(maxTokens int64) *storagepb.Profile {
+		return &storagepb.Profile{
+			Read: &storagepb.Config{
+				State:     storagepb.Config_ENABLED,
+				MaxTokens: maxTokens,
+				ReplenishmentStrategy: &storagepb.Config_TimeBased{
+					TimeBased: &storagepb.TimeBasedStrategy{
+						TokensToReplenish:        maxTokens / 10,
+						ReplenishIntervalSeconds: 1,
+					},
+				},
+			},
+			Write: &storagepb.Config{
+				State:     storagepb.Config_ENABLED,
+				MaxTokens: maxTokens,
+				ReplenishmentStrategy: &storagepb.Config_SequencingBased{
+					SequencingBased: &storagepb.SequencingBasedStrategy{},
+				},
+			},
+		}
+	}
+	return map[string]*storagepb.Profile{
+		"small":  profile(1000),
+		"medium": profile(10000),
+		"large":  profile(100000),
+	}
+}
+
+// SetProfiles adds custom quota profiles, which replace the built-in profiles
+// of the same name. It must be called before the Manager is used.
+func (m *Manager) SetProfiles(profiles map[string]*storagepb.Profile) error {
+	for name, p := range profiles {
+		if cfgs := profileConfigs(0, p); len(cfgs.Configs) == 0 {
+			return fmt.Errorf("quota profile %q has no quotas", name)
+		} else if err := storage.ValidateConfigs(cfgs); err != nil {
+			return fmt.Errorf("quota profile %q: %v", name, err)
+		}
+	}
+	for name, p := range profiles {
+		m.profiles[name] = p
+	}
+	return nil
+}
+
+// HasProfile implements quota.TreeConfigurer.HasProfile.
+func (m *Manager) HasProfile(name string) bool {
+	_, ok := m.profiles[name]
+	return ok
+}
+
+// ConfigureTree implements quota.TreeConfigurer.ConfigureTree. Existing
+// quotas of the tree are replaced.
+func (m *Manager) ConfigureTree(ctx context.Context, treeID int64, profile string) error {
+	p, ok := m.profiles[profile]
+	if !ok {
+		return fmt.Errorf("unknown quota profile %q", profile)
+	}
+	tree := profileConfigs(treeID, p)
+	_, err := m.qs.UpdateConfigs(ctx, false /* reset */, func(cfgs *storagepb.Configs) {
+		replaced := make(map[string]bool)
+		for _, cfg := range tree.Configs {
+			replaced[cfg.Name] = true
+		}
+		kept := cfgs.Configs[:0]
+		for _, cfg := range cfgs.Configs {
+			if !replaced[cfg.Name] {
+				kept = append(kept, cfg)
+			}
+		}
+		cfgs.Configs = append(kept, tree.Configs...)
+	})
+	return err
+}
+
+// profileConfigs returns the configs of the tree quotas given by profile.
+func profileConfigs(treeID int64, p *storagepb.Profile) *storagepb.Configs {
+	cfgs := &storagepb.Configs{}
+	for _, q := range []struct {
+		kind quota.Kind
+		cfg  *storagepb.Config
+	}{{quota.Read, p.Read}, {quota.Write, p.Write}} {
+		if q.cfg == nil {
+			continue
+		}
+		cfg := proto.Clone(q.cfg).(*storagepb.Config)
+		cfg.Name = configName(quota.Spec{Group: quota.Tree, Kind: q.kind, TreeID: treeID})
+		cfgs.Configs = append(cfgs.Configs, cfg)
+	}
+	return cfgs
+}
@@ -0,0 +1,136 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdqm
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/trillian/quota/etcd/storage"
+	"github.com/google/trillian/quota/etcd/storagepb"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestManager_SetProfiles(t *testing.T) {
+	qm := New(client)
+	for _, name := range []string{"small", "medium", "large"} {
+		if !qm.HasProfile(name) {
+			t.Errorf("HasProfile(%q) = false, want true", name)
+		}
+	}
+	if qm.HasProfile("custom") {
+		t.Errorf("HasProfile(%q) = true, want false", "custom")
+	}
+
+	for _, test := range []struct {
+		desc     string
+		profiles map[string]*storagepb.Profile
+		wantErr  bool
+	}{
+		{
+			desc:     "custom",
+			profiles: map[string]*storagepb.Profile{"custom": {Write: proto.Clone(treeWriteConfig).(*storagepb.Config)}},
+		},
+		{
+			desc:     "empty",
+			profiles: map[string]*storagepb.Profile{"custom": {}},
+			wantErr:  true,
+		},
+		{
+			desc: "sequencingBasedRead",
+			profiles: map[string]*storagepb.Profile{"custom": {Read: &storagepb.Config{
+				State:                 storagepb.Config_ENABLED,
+				MaxTokens:             10,
+				ReplenishmentStrategy: &storagepb.Config_SequencingBased{SequencingBased: &storagepb.SequencingBasedStrategy{}},
+			}}},
+			wantErr: true,
+		},
+	} {
+		qm := New(client)
+		err := qm.SetProfiles(test.profiles)
+		if gotErr := err != nil; gotErr != test.wantErr {
+			t.Errorf("%v: SetProfiles() returned err = %v, wantErr = %v", test.desc, err, test.wantErr)
+		}
+		if got, want := qm.HasProfile("custom"), !test.wantErr; got != want {
+			t.Errorf("%v: HasProfile(%q) = %v, want %v", test.desc, "custom", got, want)
+		}
+	}
+}
+
+func TestManager_ConfigureTree(t *testing.T) {
+	ctx := context.Background()
+	qs := &storage.QuotaStorage{Client: client}
+	if err := reset(ctx, qs, cfgs); err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+
+	qm := New(client)
+	custom := &storagepb.Profile{Write: &storagepb.Config{
+		State:                 storagepb.Config_ENABLED,
+		MaxTokens:             42,
+		ReplenishmentStrategy: &storagepb.Config_SequencingBased{SequencingBased: &storagepb.SequencingBasedStrategy{}},
+	}}
+	if err := qm.SetProfiles(map[string]*storagepb.Profile{"custom": custom}); err != nil {
+		t.Fatalf("SetProfiles() returned err = %v", err)
+	}
+	if err := qm.ConfigureTree(ctx, treeID, "unknown"); err == nil {
+		t.Error("ConfigureTree() with unknown profile returned err = nil")
+	}
+
+	// The profile's quotas replace the existing tree write quota, and others
+	// are kept.
+	if err := qm.ConfigureTree(ctx, treeID, "custom"); err != nil {
+		t.Fatalf("ConfigureTree() returned err = %v", err)
+	}
+	if err := qm.ConfigureTree(ctx, treeID+1, "small"); err != nil {
+		t.Fatalf("ConfigureTree() returned err = %v", err)
+	}
+	got, err := qs.Configs(ctx)
+	if err != nil {
+		t.Fatalf("Configs() returned err = %v", err)
+	}
+	small := DefaultProfiles()["small"]
+	want := map[string]*storagepb.Config{
+		globalWriteConfig.Name: globalWriteConfig,
+		userReadConfig.Name:    userReadConfig,
+		treeWriteConfig.Name:   custom.Write,
+		fmt.Sprintf("quotas/trees/%v/read/config", treeID+1):  small.Read,
+		fmt.Sprintf("quotas/trees/%v/write/config", treeID+1): small.Write,
+	}
+	if len(got.Configs) != len(want) {
+		t.Errorf("Configs() returned %v configs, want %v", len(got.Configs), len(want))
+	}
+	for _, cfg := range got.Configs {
+		w, ok := want[cfg.Name]
+		if !ok {
+			t.Errorf("Configs() returned unexpected config %q", cfg.Name)
+			continue
+		}
+		w = proto.Clone(w).(*storagepb.Config)
+		w.Name = cfg.Name
+		if !proto.Equal(cfg, w) {
+			t.Errorf("Configs() returned %v, want %v", cfg, w)
+		}
+	}
+
+	tokens, err := qs.Peek(ctx, []string{treeWriteConfig.Name})
+	if err != nil {
+		t.Fatalf("Peek() returned err = %v", err)
+	}
+	if got := tokens[treeWriteConfig.Name]; got != custom.Write.MaxTokens {
+		t.Errorf("Peek() returned %v tokens, want %v", got, custom.Write.MaxTokens)
+	}
+}
@@ -17,6 +17,7 @@ package etcd
 import (
 	"flag"
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
@@ -24,7 +25,9 @@ import (
 	"github.com/google/trillian/quota"
 	"github.com/google/trillian/quota/cacheqm"
 	"github.com/google/trillian/quota/etcd/etcdqm"
+	"github.com/google/trillian/quota/etcd/storagepb"
 	clientv3 "go.etcd.io/etcd/client/v3"
+	"google.golang.org/protobuf/encoding/prototext"
 )
 
 // QuotaManagerName identifies the etcd quota implementation.
@@ -38,6 +41,8 @@ var (
 		"Zero or lower means batching is disabled. Applicable for etcd quotas.")
 	quotaMaxCacheEntries = flag.Int("quota_max_cache_entries", cacheqm.DefaultMaxCacheEntries, "Max number of quota specs in the quota cache. "+
 		"Zero or lower means batching is disabled. Applicable for etcd quotas.")
+	quotaProfiles = flag.String("quota_profiles", "", "File with the storagepb.Profiles, in protobuf text format, of custom quota profiles for the quota_profile of new trees. "+
+		"They replace the built-in small, medium and large profiles of the same name. Applicable for etcd quotas.")
 )
 
 func init() {
@@ -58,7 +63,22 @@ func newEtcdQuotaManager() (quota.Manager, error) {
 		return nil, fmt.Errorf("failed to connect to etcd at %v: %v", *Servers, err)
 	}
 
-	var qm quota.Manager = etcdqm.New(client)
+	etcdQM := etcdqm.New(client)
+	if *quotaProfiles != "" {
+		b, err := os.ReadFile(*quotaProfiles)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read quota profiles: %v", err)
+		}
+		var profiles storagepb.Profiles
+		if err := prototext.Unmarshal(b, &profiles); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %v", *quotaProfiles, err)
+		}
+		if err := etcdQM.SetProfiles(profiles.Profiles); err != nil {
+			return nil, err
+		}
+	}
+
+	var qm quota.Manager = etcdQM
 	if *quotaMinBatchSize > 0 && *quotaMaxCacheEntries > 0 {
 		cachedQM, err := cacheqm.NewCachedManager(qm, *quotaMinBatchSize, *quotaMaxCacheEntries)
 		if err != nil {
@@ -169,6 +169,12 @@ func (qs *QuotaStorage) UpdateConfigs(ctx context.Context, reset bool, update fu
 	return updated, err
 }
 
+// ValidateConfigs returns an error if cfgs can't be stored, e.g. because a
+// config is malformed or a name is duplicated.
+func ValidateConfigs(cfgs *storagepb.Configs) error {
+	return validate(cfgs)
+}
+
 func validate(cfgs *storagepb.Configs) error {
 	names := make(map[string]bool)
 	for i, cfg := range cfgs.Configs {
@@ -114,3 +114,13 @@ type Manager interface {
 	// ResetQuota resets the quota for all specs.
 	ResetQuota(ctx context.Context, specs []Spec) error
 }
+
+// TreeConfigurer is implemented by Managers which create the quotas of new
+// trees from named profiles, see the quota_profile field of trillian.Tree.
+type TreeConfigurer interface {
+	// HasProfile returns whether a profile of the given name exists.
+	HasProfile(name string) bool
+
+	// ConfigureTree creates the quotas of the tree from the named profile.
+	ConfigureTree(ctx context.Context, treeID int64, profile string) error
+}
@@ -0,0 +1,74 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisqm
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"time"
+
+	"github.com/go-redis/redis"
+	"github.com/golang/glog"
+	"github.com/google/trillian/quota"
+)
+
+// QuotaManagerName identifies the Redis quota implementation.
+const QuotaManagerName = "redis"
+
+var (
+	redisAddr     = flag.String("redis_addr", "", "Address (host:port) of the Redis server used by --quota_system=redis")
+	redisPrefix   = flag.String("redis_prefix", "", "Prefix for the Redis keys of the quotas, for Redis servers shared with other users. Only effective for --quota_system=redis.")
+	quotaCapacity = flag.Int("redis_quota_capacity", 0, "Capacity of each write quota token bucket (global, per tree and per user); zero or lower means write quotas are unlimited. "+
+		"Only effective for --quota_system=redis.")
+	quotaRate = flag.Float64("redis_quota_rate", 0, "Rate, in tokens per second, at which the write quota token buckets are replenished. "+
+		"Only effective for --quota_system=redis.")
+)
+
+func init() {
+	if err := quota.RegisterProvider(QuotaManagerName, newRedisQuotaManager); err != nil {
+		glog.Fatalf("Failed to register quota manager %v: %v", QuotaManagerName, err)
+	}
+}
+
+func newRedisQuotaManager() (quota.Manager, error) {
+	if *redisAddr == "" {
+		return nil, errors.New("can't create redis quotamanager - redis_addr flag is unset")
+	}
+	client := redis.NewClient(&redis.Options{Addr: *redisAddr})
+	qm := New(client, ManagerOptions{
+		Parameters: writeParameters(*quotaCapacity, *quotaRate),
+		Prefix:     *redisPrefix,
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := qm.Load(ctx); err != nil {
+		// The scripts are sent along with the requests instead.
+		glog.Warningf("Failed to load Redis scripts: %v", err)
+	}
+	glog.Info("Using Redis QuotaManager")
+	return qm, nil
+}
+
+// writeParameters returns a ParameterFunc which limits write quotas to token
+// buckets of the given capacity and rate, and doesn't limit read quotas.
+func writeParameters(capacity int, rate float64) ParameterFunc {
+	return func(spec quota.Spec) (int, float64) {
+		if spec.Kind != quota.Write || capacity <= 0 {
+			return quota.MaxTokens, 0
+		}
+		return capacity, rate
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisqm
+
+import (
+	"testing"
+
+	"github.com/google/trillian/quota"
+)
+
+func TestWriteParameters(t *testing.T) {
+	for _, tc := range []struct {
+		desc         string
+		capacity     int
+		spec         quota.Spec
+		wantCapacity int
+		wantRate     float64
+	}{
+		{desc: "globalWrite", capacity: 100, spec: quota.Spec{Group: quota.Global, Kind: quota.Write}, wantCapacity: 100, wantRate: 2.5},
+		{desc: "treeWrite", capacity: 100, spec: quota.Spec{Group: quota.Tree, Kind: quota.Write, TreeID: 12}, wantCapacity: 100, wantRate: 2.5},
+		{desc: "userWrite", capacity: 100, spec: quota.Spec{Group: quota.User, Kind: quota.Write, User: "llama"}, wantCapacity: 100, wantRate: 2.5},
+		{desc: "read", capacity: 100, spec: quota.Spec{Group: quota.Tree, Kind: quota.Read, TreeID: 12}, wantCapacity: quota.MaxTokens},
+		{desc: "unlimited", spec: quota.Spec{Group: quota.Global, Kind: quota.Write}, wantCapacity: quota.MaxTokens},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			capacity, rate := writeParameters(tc.capacity, 2.5)(tc.spec)
+			if capacity != tc.wantCapacity || rate != tc.wantRate {
+				t.Errorf("Parameters(%v) = (%v, %v), want (%v, %v)", tc.spec, capacity, rate, tc.wantCapacity, tc.wantRate)
+			}
+		})
+	}
+}
+
+func TestNewRedisQuotaManagerNoAddr(t *testing.T) {
+	if _, err := quota.NewManager(QuotaManagerName); err == nil {
+		t.Error("NewManager() with --redis_addr unset returned nil error")
+	}
+}
@@ -17,11 +17,15 @@ package admin
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/golang/glog"
 	"github.com/google/trillian"
 	"github.com/google/trillian/extension"
+	"github.com/google/trillian/quota"
 	"github.com/google/trillian/storage"
+	"github.com/google/trillian/util/clock"
 	"google.golang.org/genproto/protobuf/field_mask"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -31,6 +35,14 @@ import (
 type Server struct {
 	registry         extension.Registry
 	allowedTreeTypes []trillian.TreeType
+	timeSource       clock.TimeSource
+	runbookRPCs      bool
+	watchInterval    time.Duration
+
+	// watchMu guards changed, which is closed when a tree is changed through
+	// this server, to wake up the WatchTrees calls.
+	watchMu sync.Mutex
+	changed chan struct{}
 }
 
 // New returns a trillian.TrillianAdminServer implementation.
@@ -41,6 +53,8 @@ func New(registry extension.Registry, allowedTreeTypes []trillian.TreeType) *Ser
 	return &Server{
 		registry:         registry,
 		allowedTreeTypes: allowedTreeTypes,
+		timeSource:       clock.System,
+		watchInterval:    DefaultWatchInterval,
 	}
 }
 
@@ -82,17 +96,34 @@ func (s *Server) CreateTree(ctx context.Context, req *trillian.CreateTreeRequest
 		return nil, status.Errorf(codes.InvalidArgument, "invalid tree type: %v", tree.TreeType)
 	}
 
-	// Clear generated fields, storage must set those
-	tree.TreeId = 0
+	// Clear generated fields, storage must set those. The tree ID is only
+	// generated if the request doesn't specify one.
+	tree.TreeId = req.GetTreeId()
 	tree.CreateTime = nil
 	tree.UpdateTime = nil
 	tree.Deleted = false
 	tree.DeleteTime = nil
+	tree.IntegrationPause = nil
+	tree.Quota = nil
+
+	var quotas quota.TreeConfigurer
+	if p := tree.QuotaProfile; p != "" {
+		var ok bool
+		if quotas, ok = s.registry.QuotaManager.(quota.TreeConfigurer); !ok || !quotas.HasProfile(p) {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown quota profile %q", p)
+		}
+	}
 
 	createdTree, err := storage.CreateTree(ctx, s.registry.AdminStorage, tree)
 	if err != nil {
 		return nil, err
 	}
+	if quotas != nil {
+		if err := quotas.ConfigureTree(ctx, createdTree.TreeId, createdTree.QuotaProfile); err != nil {
+			return nil, status.Errorf(codes.Internal, "tree %d created, but not its quotas from profile %q: %v", createdTree.TreeId, createdTree.QuotaProfile, err)
+		}
+	}
+	s.notifyWatches()
 	return createdTree, nil
 }
 
@@ -129,6 +160,7 @@ func (s *Server) UpdateTree(ctx context.Context, req *trillian.UpdateTreeRequest
 	if err != nil {
 		return nil, err
 	}
+	s.notifyWatches()
 	return updatedTree, nil
 }
 
@@ -150,6 +182,24 @@ func applyUpdateMask(from, to *trillian.Tree, mask *field_mask.FieldMask) error
 			to.StorageSettings = from.StorageSettings
 		case "max_root_duration":
 			to.MaxRootDuration = from.MaxRootDuration
+		case "sequenced_leaf_conflict_policy":
+			to.SequencedLeafConflictPolicy = from.SequencedLeafConflictPolicy
+		case "sequenced_leaf_duplicate_window":
+			to.SequencedLeafDuplicateWindow = from.SequencedLeafDuplicateWindow
+		case "read_consistency":
+			to.ReadConsistency = from.ReadConsistency
+		case "max_merge_delay":
+			to.MaxMergeDelay = from.MaxMergeDelay
+		case "leaf_schema":
+			to.LeafSchema = from.LeafSchema
+		case "retention_policy":
+			to.RetentionPolicy = from.RetentionPolicy
+		case "operator":
+			to.Operator = from.Operator
+		case "storage_caps":
+			to.StorageCaps = from.StorageCaps
+		case "sign_json_log_roots":
+			to.SignJsonLogRoots = from.SignJsonLogRoots
 		default:
 			return status.Errorf(codes.InvalidArgument, "invalid update_mask path: %q", path)
 		}
@@ -163,6 +213,7 @@ func (s *Server) DeleteTree(ctx context.Context, req *trillian.DeleteTreeRequest
 	if err != nil {
 		return nil, err
 	}
+	s.notifyWatches()
 	return tree, nil
 }
 
@@ -172,5 +223,6 @@ func (s *Server) UndeleteTree(ctx context.Context, req *trillian.UndeleteTreeReq
 	if err != nil {
 		return nil, err
 	}
+	s.notifyWatches()
 	return tree, nil
 }
@@ -25,7 +25,9 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/trillian"
 	"github.com/google/trillian/extension"
+	"github.com/google/trillian/quota"
 	"github.com/google/trillian/storage"
+	"github.com/google/trillian/storage/memory"
 	"github.com/google/trillian/storage/testonly"
 	"google.golang.org/genproto/protobuf/field_mask"
 	"google.golang.org/grpc/codes"
@@ -333,6 +335,98 @@ func TestServer_CreateTree(t *testing.T) {
 	}
 }
 
+func TestServer_CreateTree_TreeID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	for _, test := range []struct {
+		desc       string
+		reqTreeID  int64
+		treeTreeID int64
+		wantTreeID int64
+	}{
+		{desc: "generated", treeTreeID: 10},
+		{desc: "specified", reqTreeID: 12345, treeTreeID: 10, wantTreeID: 12345},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			setup := setupAdminServer(ctrl, false /* snapshot */, true /* shouldCommit */, false /* commitErr */)
+			tree := proto.Clone(testonly.LogTree).(*trillian.Tree)
+			tree.TreeId = test.treeTreeID
+
+			setup.tx.EXPECT().CreateTree(gomock.Any(), gomock.Any()).Do(func(_ context.Context, tree *trillian.Tree) {
+				if got, want := tree.TreeId, test.wantTreeID; got != want {
+					t.Errorf("storage CreateTree() called with TreeId %v, want %v", got, want)
+				}
+			}).Return(&trillian.Tree{}, nil)
+
+			if _, err := setup.server.CreateTree(ctx, &trillian.CreateTreeRequest{Tree: tree, TreeId: test.reqTreeID}); err != nil {
+				t.Fatalf("CreateTree() returned err = %v", err)
+			}
+		})
+	}
+}
+
+// fakeTreeConfigurer is a quota.Manager with the profiles in its keys, which
+// records the trees configured with them.
+type fakeTreeConfigurer struct {
+	quota.Manager
+	profiles map[string]bool
+	trees    map[int64]string
+}
+
+func (f *fakeTreeConfigurer) HasProfile(name string) bool {
+	return f.profiles[name]
+}
+
+func (f *fakeTreeConfigurer) ConfigureTree(ctx context.Context, treeID int64, profile string) error {
+	f.trees[treeID] = profile
+	return nil
+}
+
+func TestServer_CreateTree_QuotaProfile(t *testing.T) {
+	ctx := context.Background()
+	for _, test := range []struct {
+		desc     string
+		qm       quota.Manager
+		profile  string
+		wantCode codes.Code
+	}{
+		{desc: "noProfile", qm: quota.Noop()},
+		{desc: "profile", qm: &fakeTreeConfigurer{Manager: quota.Noop(), profiles: map[string]bool{"small": true}}, profile: "small"},
+		{desc: "unknownProfile", qm: &fakeTreeConfigurer{Manager: quota.Noop(), profiles: map[string]bool{"small": true}}, profile: "large", wantCode: codes.InvalidArgument},
+		{desc: "profilesUnsupported", qm: quota.Noop(), profile: "small", wantCode: codes.InvalidArgument},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			ts := memory.NewTreeStorage()
+			registry := extension.Registry{AdminStorage: memory.NewAdminStorage(ts), QuotaManager: test.qm}
+			if f, ok := test.qm.(*fakeTreeConfigurer); ok {
+				f.trees = make(map[int64]string)
+			}
+			s := New(registry, nil /* allowedTreeTypes */)
+
+			tree := proto.Clone(testonly.LogTree).(*trillian.Tree)
+			tree.QuotaProfile = test.profile
+			got, err := s.CreateTree(ctx, &trillian.CreateTreeRequest{Tree: tree})
+			if status.Code(err) != test.wantCode {
+				t.Fatalf("CreateTree() returned err = %v, want code %v", err, test.wantCode)
+			}
+			if err != nil {
+				if trees, err := storage.ListTrees(ctx, registry.AdminStorage, true); err != nil || len(trees) != 0 {
+					t.Errorf("ListTrees() = %v, %v, want no trees", trees, err)
+				}
+				return
+			}
+			if got.QuotaProfile != test.profile {
+				t.Errorf("CreateTree() returned quota_profile %q, want %q", got.QuotaProfile, test.profile)
+			}
+			if f, ok := test.qm.(*fakeTreeConfigurer); ok && f.trees[got.TreeId] != test.profile {
+				t.Errorf("quotas of tree %v configured with %q, want %q", got.TreeId, f.trees[got.TreeId], test.profile)
+			}
+		})
+	}
+}
+
 func TestServer_CreateTree_AllowedTreeTypes(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -412,9 +506,14 @@ func TestServer_UpdateTree(t *testing.T) {
 		Description:     "Brand New Tree Desc",
 		StorageSettings: settings,
 		MaxRootDuration: durationpb.New(2 * time.Nanosecond),
+
+		SequencedLeafConflictPolicy:  trillian.SequencedLeafConflictPolicy_CONFLICT_OVERWRITE_IF_IDENTICAL,
+		SequencedLeafDuplicateWindow: 100,
+		ReadConsistency:              trillian.ReadConsistency_EVENTUAL,
+		Operator:                     &trillian.TreeOperator{ContactEmail: "log-admin@example.com"},
 	}
 	successMask := &field_mask.FieldMask{
-		Paths: []string{"tree_state", "display_name", "description", "storage_settings", "max_root_duration"},
+		Paths: []string{"tree_state", "display_name", "description", "storage_settings", "max_root_duration", "sequenced_leaf_conflict_policy", "sequenced_leaf_duplicate_window", "read_consistency", "operator"},
 	}
 
 	successWant := proto.Clone(existingTree).(*trillian.Tree)
@@ -423,6 +522,10 @@ func TestServer_UpdateTree(t *testing.T) {
 	successWant.Description = successTree.Description
 	successWant.StorageSettings = successTree.StorageSettings
 	successWant.MaxRootDuration = successTree.MaxRootDuration
+	successWant.SequencedLeafConflictPolicy = successTree.SequencedLeafConflictPolicy
+	successWant.SequencedLeafDuplicateWindow = successTree.SequencedLeafDuplicateWindow
+	successWant.ReadConsistency = successTree.ReadConsistency
+	successWant.Operator = successTree.Operator
 
 	tests := []struct {
 		desc                           string
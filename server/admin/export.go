@@ -0,0 +1,213 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian"
+	"github.com/google/trillian/log"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/trees"
+	"github.com/google/trillian/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultExportChunkLeaves is the number of leaves per chunk streamed by an
+// ExportTree request which doesn't set max_leaves_per_chunk.
+const DefaultExportChunkLeaves = 1000
+
+// importCleanupTimeout bounds the deletion of a tree whose import failed.
+const importCleanupTimeout = time.Minute
+
+var optsExport = trees.NewGetOpts(trees.Admin, trillian.TreeType_LOG, trillian.TreeType_PREORDERED_LOG)
+
+// ExportTree implements trillian.TrillianAdminServer.ExportTree. The leaves
+// are read in a snapshot per chunk, so the log keeps being served meanwhile,
+// up to the size of the root read first.
+func (s *Server) ExportTree(req *trillian.ExportTreeRequest, stream trillian.TrillianAdmin_ExportTreeServer) error {
+	ctx := stream.Context()
+	limit := int64(req.GetMaxLeavesPerChunk())
+	switch {
+	case limit < 0:
+		return status.Errorf(codes.InvalidArgument, "max_leaves_per_chunk negative: %d", limit)
+	case limit == 0:
+		limit = DefaultExportChunkLeaves
+	}
+	tree, err := trees.GetTree(ctx, s.registry.AdminStorage, req.GetTreeId(), optsExport)
+	if err != nil {
+		return err
+	}
+
+	var slr *trillian.SignedLogRoot
+	if err := s.snapshot(ctx, tree, func(tx storage.ReadOnlyLogTreeTX) error {
+		slr, err = tx.LatestSignedLogRoot(ctx)
+		return err
+	}); err == storage.ErrTreeNeedsInit {
+		return status.Errorf(codes.FailedPrecondition, "log %d is not initialised", tree.TreeId)
+	} else if err != nil {
+		return err
+	}
+	var root types.LogRootV1
+	if err := root.UnmarshalBinary(slr.LogRoot); err != nil {
+		return status.Errorf(codes.Internal, "failed to unmarshal latest root: %v", err)
+	}
+	if err := stream.Send(&trillian.TreeSnapshotChunk{Tree: tree, SignedLogRoot: slr}); err != nil {
+		return err
+	}
+
+	size := int64(root.TreeSize)
+	for start := int64(0); start < size; start += limit {
+		count := limit
+		if start+count > size {
+			count = size - start
+		}
+		var leaves []*trillian.LogLeaf
+		if err := s.snapshot(ctx, tree, func(tx storage.ReadOnlyLogTreeTX) error {
+			leaves, err = tx.GetLeavesByRange(ctx, start, count)
+			return err
+		}); err != nil {
+			return err
+		}
+		if got := int64(len(leaves)); got != count {
+			return status.Errorf(codes.DataLoss, "read %d leaves at index %d, want %d", got, start, count)
+		}
+		if err := stream.Send(&trillian.TreeSnapshotChunk{Leaves: leaves}); err != nil {
+			return err
+		}
+	}
+	glog.Infof("%v: exported %d leaves", tree.TreeId, size)
+	return nil
+}
+
+// ImportTree implements trillian.TrillianAdminServer.ImportTree. If the import
+// fails once the tree is created, the tree is deleted, so that the snapshot
+// can be imported again.
+func (s *Server) ImportTree(stream trillian.TrillianAdmin_ImportTreeServer) error {
+	ctx := stream.Context()
+	chunk, err := stream.Recv()
+	if err == io.EOF {
+		return status.Error(codes.InvalidArgument, "empty snapshot")
+	} else if err != nil {
+		return err
+	}
+	if chunk.GetTree() == nil || chunk.GetSignedLogRoot() == nil {
+		return status.Error(codes.InvalidArgument, "the first chunk must hold the tree and its root")
+	}
+	var want types.LogRootV1
+	if err := want.UnmarshalBinary(chunk.GetSignedLogRoot().GetLogRoot()); err != nil {
+		return status.Errorf(codes.InvalidArgument, "malformed signed_log_root: %v", err)
+	}
+
+	// The tree is frozen while it's imported, so that it isn't sequenced.
+	tree := proto.Clone(chunk.GetTree()).(*trillian.Tree)
+	state := tree.TreeState
+	tree.TreeState = trillian.TreeState_ACTIVE
+	created, err := s.CreateTree(ctx, &trillian.CreateTreeRequest{Tree: tree, TreeId: tree.TreeId})
+	if err != nil {
+		return err
+	}
+	tree, err = storage.UpdateTree(ctx, s.registry.AdminStorage, created.TreeId, func(tree *trillian.Tree) {
+		tree.TreeState = trillian.TreeState_FROZEN
+	})
+	if err == nil {
+		tree, err = s.importTree(ctx, stream, chunk, tree, state, &want)
+	}
+	if err != nil {
+		s.deleteImportedTree(created.TreeId)
+		return err
+	}
+	s.notifyWatches()
+	return stream.SendAndClose(tree)
+}
+
+// importTree imports the leaves of the snapshot into the FROZEN tree, starting
+// with those of the first chunk, checks the resulting root against want, and
+// then sets the tree to the given state.
+func (s *Server) importTree(ctx context.Context, stream trillian.TrillianAdmin_ImportTreeServer, chunk *trillian.TreeSnapshotChunk, tree *trillian.Tree, state trillian.TreeState, want *types.LogRootV1) (*trillian.Tree, error) {
+	var root *types.LogRootV1
+	var err error
+	for {
+		if len(chunk.GetLeaves()) > 0 {
+			if root, err = log.ImportLeaves(ctx, tree, chunk.GetLeaves(), s.timeSource, s.registry.LogStorage); err != nil {
+				return nil, err
+			}
+		}
+		if chunk, err = stream.Recv(); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if chunk.GetTree() != nil || chunk.GetSignedLogRoot() != nil {
+			return nil, status.Error(codes.InvalidArgument, "only the first chunk may hold the tree and its root")
+		}
+	}
+	if root == nil {
+		// Initialise the log, as the snapshot has no leaves.
+		if root, err = log.ImportLeaves(ctx, tree, nil, s.timeSource, s.registry.LogStorage); err != nil {
+			return nil, err
+		}
+	}
+	if root.TreeSize != want.TreeSize || !bytes.Equal(root.RootHash, want.RootHash) {
+		return nil, status.Errorf(codes.DataLoss, "imported log %d has size %d and root hash %x, want size %d and root hash %x", tree.TreeId, root.TreeSize, root.RootHash, want.TreeSize, want.RootHash)
+	}
+
+	if state != trillian.TreeState_FROZEN {
+		if tree, err = storage.UpdateTree(ctx, s.registry.AdminStorage, tree.TreeId, func(tree *trillian.Tree) {
+			tree.TreeState = state
+		}); err != nil {
+			return nil, err
+		}
+	}
+	glog.Infof("%v: imported %d leaves", tree.TreeId, root.TreeSize)
+	return tree, nil
+}
+
+// deleteImportedTree deletes a tree whose import failed, along with the leaves
+// imported so far. It doesn't use the context of the import, which may be the
+// reason why it failed.
+func (s *Server) deleteImportedTree(treeID int64) {
+	ctx, cancel := context.WithTimeout(context.Background(), importCleanupTimeout)
+	defer cancel()
+	if _, err := storage.SoftDeleteTree(ctx, s.registry.AdminStorage, treeID); err != nil {
+		glog.Errorf("%v: failed to delete the tree after a failed import: %v", treeID, err)
+		return
+	}
+	if err := storage.HardDeleteTree(ctx, s.registry.AdminStorage, treeID); err != nil {
+		glog.Errorf("%v: failed to delete the tree after a failed import: %v", treeID, err)
+	}
+}
+
+// snapshot runs f in a read-only transaction of the tree.
+func (s *Server) snapshot(ctx context.Context, tree *trillian.Tree, f func(tx storage.ReadOnlyLogTreeTX) error) error {
+	tx, err := s.registry.LogStorage.SnapshotForTree(ctx, tree)
+	if err != nil {
+		if tx != nil {
+			tx.Close()
+		}
+		return err
+	}
+	defer tx.Close()
+	if err := f(tx); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,257 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/extension"
+	"github.com/google/trillian/log"
+	"github.com/google/trillian/quota"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/storage/memory"
+	"github.com/google/trillian/types"
+	"github.com/transparency-dev/merkle/rfc6962"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeExportStream is an ExportTree server stream which collects the chunks.
+type fakeExportStream struct {
+	grpc.ServerStream
+	chunks []*trillian.TreeSnapshotChunk
+}
+
+func (s *fakeExportStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *fakeExportStream) Send(chunk *trillian.TreeSnapshotChunk) error {
+	s.chunks = append(s.chunks, proto.Clone(chunk).(*trillian.TreeSnapshotChunk))
+	return nil
+}
+
+// fakeImportStream is an ImportTree server stream which receives the given
+// chunks.
+type fakeImportStream struct {
+	grpc.ServerStream
+	chunks []*trillian.TreeSnapshotChunk
+	tree   *trillian.Tree
+}
+
+func (s *fakeImportStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *fakeImportStream) Recv() (*trillian.TreeSnapshotChunk, error) {
+	if len(s.chunks) == 0 {
+		return nil, io.EOF
+	}
+	chunk := s.chunks[0]
+	s.chunks = s.chunks[1:]
+	return chunk, nil
+}
+
+func (s *fakeImportStream) SendAndClose(tree *trillian.Tree) error {
+	s.tree = tree
+	return nil
+}
+
+// newImportServer returns a server with empty storage.
+func newImportServer() *Server {
+	ts := memory.NewTreeStorage()
+	return New(extension.Registry{
+		AdminStorage: memory.NewAdminStorage(ts),
+		LogStorage:   memory.NewLogStorage(ts, nil),
+	}, nil /* allowedTreeTypes */)
+}
+
+// latestRoot returns the latest root and leaves of the tree.
+func latestRoot(ctx context.Context, t *testing.T, s *Server, tree *trillian.Tree) (*types.LogRootV1, []*trillian.LogLeaf) {
+	t.Helper()
+	var root types.LogRootV1
+	var leaves []*trillian.LogLeaf
+	if err := s.snapshot(ctx, tree, func(tx storage.ReadOnlyLogTreeTX) error {
+		slr, err := tx.LatestSignedLogRoot(ctx)
+		if err != nil {
+			return err
+		}
+		if err := root.UnmarshalBinary(slr.LogRoot); err != nil {
+			return err
+		}
+		if root.TreeSize > 0 {
+			leaves, err = tx.GetLeavesByRange(ctx, 0, int64(root.TreeSize))
+		}
+		return err
+	}); err != nil {
+		t.Fatalf("Failed to read log: %v", err)
+	}
+	return &root, leaves
+}
+
+func TestServer_ExportImportTree(t *testing.T) {
+	ctx := context.Background()
+	log.InitMetrics(nil)
+	s, tree, fakeTime := setupRunbookServer(ctx, t)
+
+	leaves := make([]*trillian.LogLeaf, 0, 25)
+	for i := 0; i < cap(leaves); i++ {
+		data := []byte(fmt.Sprintf("leaf %d", i))
+		hash := sha256.Sum256(data)
+		leaves = append(leaves, &trillian.LogLeaf{
+			LeafValue:        data,
+			LeafIdentityHash: hash[:],
+			MerkleLeafHash:   rfc6962.DefaultHasher.HashLeaf(data),
+		})
+	}
+	if _, err := s.registry.LogStorage.QueueLeaves(ctx, tree, leaves, fakeTime.Now()); err != nil {
+		t.Fatalf("QueueLeaves(): %v", err)
+	}
+	if _, err := log.IntegrateBatch(ctx, tree, len(leaves), 0, 0, fakeTime, s.registry.LogStorage, quota.Noop()); err != nil {
+		t.Fatalf("IntegrateBatch(): %v", err)
+	}
+	wantRoot, wantLeaves := latestRoot(ctx, t, s, tree)
+
+	if err := s.ExportTree(&trillian.ExportTreeRequest{TreeId: tree.TreeId, MaxLeavesPerChunk: -1}, &fakeExportStream{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ExportTree(max_leaves_per_chunk=-1): %v, want code %v", err, codes.InvalidArgument)
+	}
+	export := &fakeExportStream{}
+	if err := s.ExportTree(&trillian.ExportTreeRequest{TreeId: tree.TreeId, MaxLeavesPerChunk: 10}, export); err != nil {
+		t.Fatalf("ExportTree(): %v", err)
+	}
+	if got, want := len(export.chunks), 4; got != want {
+		t.Fatalf("ExportTree() streamed %d chunks, want %d", got, want)
+	}
+	chunks := func() []*trillian.TreeSnapshotChunk {
+		ret := make([]*trillian.TreeSnapshotChunk, 0, len(export.chunks))
+		for _, chunk := range export.chunks {
+			ret = append(ret, proto.Clone(chunk).(*trillian.TreeSnapshotChunk))
+		}
+		return ret
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		dst := newImportServer()
+		stream := &fakeImportStream{chunks: chunks()}
+		if err := dst.ImportTree(stream); err != nil {
+			t.Fatalf("ImportTree(): %v", err)
+		}
+		if got, want := stream.tree.TreeId, tree.TreeId; got != want {
+			t.Errorf("ImportTree() created tree %d, want %d", got, want)
+		}
+		if got, want := stream.tree.TreeState, trillian.TreeState_ACTIVE; got != want {
+			t.Errorf("ImportTree() created tree in state %v, want %v", got, want)
+		}
+		root, leaves := latestRoot(ctx, t, dst, stream.tree)
+		if root.TreeSize != wantRoot.TreeSize || string(root.RootHash) != string(wantRoot.RootHash) {
+			t.Errorf("imported root %+v, want size %d and hash %x", root, wantRoot.TreeSize, wantRoot.RootHash)
+		}
+		if got, want := len(leaves), len(wantLeaves); got != want {
+			t.Fatalf("imported %d leaves, want %d", got, want)
+		}
+		for i := range leaves {
+			if !proto.Equal(leaves[i], wantLeaves[i]) {
+				t.Errorf("imported leaf %d = %v, want %v", i, leaves[i], wantLeaves[i])
+			}
+		}
+
+		if err := dst.ImportTree(&fakeImportStream{chunks: chunks()}); status.Code(err) != codes.AlreadyExists {
+			t.Errorf("ImportTree() of existing tree: %v, want code %v", err, codes.AlreadyExists)
+		}
+	})
+
+	for _, tc := range []struct {
+		desc     string
+		modify   func([]*trillian.TreeSnapshotChunk) []*trillian.TreeSnapshotChunk
+		wantCode codes.Code
+	}{
+		{
+			desc:     "empty",
+			modify:   func([]*trillian.TreeSnapshotChunk) []*trillian.TreeSnapshotChunk { return nil },
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			desc: "noTree",
+			modify: func(chunks []*trillian.TreeSnapshotChunk) []*trillian.TreeSnapshotChunk {
+				chunks[0].Tree = nil
+				return chunks
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			desc: "secondTree",
+			modify: func(chunks []*trillian.TreeSnapshotChunk) []*trillian.TreeSnapshotChunk {
+				chunks[2].Tree = chunks[0].Tree
+				return chunks
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			desc: "leafValue",
+			modify: func(chunks []*trillian.TreeSnapshotChunk) []*trillian.TreeSnapshotChunk {
+				chunks[2].Leaves[3].LeafValue = []byte("changed")
+				return chunks
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			desc: "missingChunk",
+			modify: func(chunks []*trillian.TreeSnapshotChunk) []*trillian.TreeSnapshotChunk {
+				return append(chunks[:2], chunks[3])
+			},
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			desc: "truncated",
+			modify: func(chunks []*trillian.TreeSnapshotChunk) []*trillian.TreeSnapshotChunk {
+				return chunks[:3]
+			},
+			wantCode: codes.DataLoss,
+		},
+		{
+			desc: "rootHash",
+			modify: func(chunks []*trillian.TreeSnapshotChunk) []*trillian.TreeSnapshotChunk {
+				root := *wantRoot
+				root.RootHash = make([]byte, 32)
+				logRoot, err := root.MarshalBinary()
+				if err != nil {
+					t.Fatalf("MarshalBinary(): %v", err)
+				}
+				chunks[0].SignedLogRoot = &trillian.SignedLogRoot{LogRoot: logRoot}
+				return chunks
+			},
+			wantCode: codes.DataLoss,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			dst := newImportServer()
+			err := dst.ImportTree(&fakeImportStream{chunks: tc.modify(chunks())})
+			if status.Code(err) != tc.wantCode {
+				t.Errorf("ImportTree(): %v, want code %v", err, tc.wantCode)
+			}
+			// The failed import mustn't leave the tree behind.
+			if err := dst.ImportTree(&fakeImportStream{chunks: chunks()}); err != nil {
+				t.Errorf("ImportTree() after failed import: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian"
+	"github.com/google/trillian/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// PauseIntegration implements trillian.TrillianAdminServer.PauseIntegration.
+func (s *Server) PauseIntegration(ctx context.Context, req *trillian.PauseIntegrationRequest) (*trillian.Tree, error) {
+	now := s.timeSource.Now()
+	if rt := req.GetResumeTime(); rt != nil {
+		if err := rt.CheckValid(); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "resume_time malformed: %v", err)
+		}
+		if !rt.AsTime().After(now) {
+			return nil, status.Errorf(codes.InvalidArgument, "resume_time %v is not in the future", rt.AsTime())
+		}
+	}
+	pause := &trillian.IntegrationPause{
+		PauseTime:  timestamppb.New(now),
+		ResumeTime: req.GetResumeTime(),
+		Reason:     req.GetReason(),
+	}
+	tree, err := storage.UpdateTree(ctx, s.registry.AdminStorage, req.GetTreeId(), func(tree *trillian.Tree) {
+		tree.IntegrationPause = pause
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.notifyWatches()
+	glog.Infof("%v: integration paused until %v: %s", req.GetTreeId(), resumeTimeString(pause), pause.Reason)
+	return tree, nil
+}
+
+// ResumeIntegration implements trillian.TrillianAdminServer.ResumeIntegration.
+func (s *Server) ResumeIntegration(ctx context.Context, req *trillian.ResumeIntegrationRequest) (*trillian.Tree, error) {
+	tree, err := storage.UpdateTree(ctx, s.registry.AdminStorage, req.GetTreeId(), func(tree *trillian.Tree) {
+		tree.IntegrationPause = nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.notifyWatches()
+	glog.Infof("%v: integration resumed", req.GetTreeId())
+	return tree, nil
+}
+
+func resumeTimeString(pause *trillian.IntegrationPause) string {
+	if pause.ResumeTime == nil {
+		return "resumed manually"
+	}
+	return pause.ResumeTime.AsTime().String()
+}
@@ -0,0 +1,86 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/trillian"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestServer_PauseIntegration(t *testing.T) {
+	ctx := context.Background()
+	s, tree, fakeTime := setupRunbookServer(ctx, t)
+	now := fakeTime.Now()
+	resume := timestamppb.New(now.Add(time.Hour))
+
+	got, err := s.PauseIntegration(ctx, &trillian.PauseIntegrationRequest{TreeId: tree.TreeId, ResumeTime: resume, Reason: "maintenance"})
+	if err != nil {
+		t.Fatalf("PauseIntegration(): %v", err)
+	}
+	want := &trillian.IntegrationPause{PauseTime: timestamppb.New(now), ResumeTime: resume, Reason: "maintenance"}
+	if !proto.Equal(got.IntegrationPause, want) {
+		t.Errorf("PauseIntegration() returned pause %v, want %v", got.IntegrationPause, want)
+	}
+	stored, err := s.GetTree(ctx, &trillian.GetTreeRequest{TreeId: tree.TreeId})
+	if err != nil {
+		t.Fatalf("GetTree(): %v", err)
+	}
+	if !proto.Equal(stored.IntegrationPause, want) {
+		t.Errorf("GetTree() returned pause %v, want %v", stored.IntegrationPause, want)
+	}
+
+	got, err = s.ResumeIntegration(ctx, &trillian.ResumeIntegrationRequest{TreeId: tree.TreeId})
+	if err != nil {
+		t.Fatalf("ResumeIntegration(): %v", err)
+	}
+	if got.IntegrationPause != nil {
+		t.Errorf("ResumeIntegration() returned pause %v, want nil", got.IntegrationPause)
+	}
+}
+
+func TestServer_PauseIntegrationErrors(t *testing.T) {
+	ctx := context.Background()
+	s, tree, fakeTime := setupRunbookServer(ctx, t)
+	for _, test := range []struct {
+		desc string
+		req  *trillian.PauseIntegrationRequest
+		want codes.Code
+	}{
+		{
+			desc: "pastResumeTime",
+			req:  &trillian.PauseIntegrationRequest{TreeId: tree.TreeId, ResumeTime: timestamppb.New(fakeTime.Now().Add(-time.Minute))},
+			want: codes.InvalidArgument,
+		},
+		{
+			desc: "invalidResumeTime",
+			req:  &trillian.PauseIntegrationRequest{TreeId: tree.TreeId, ResumeTime: &timestamppb.Timestamp{Nanos: -1}},
+			want: codes.InvalidArgument,
+		},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			_, err := s.PauseIntegration(ctx, test.req)
+			if got := status.Code(err); got != test.want {
+				t.Errorf("PauseIntegration() returned code %v, want %v (err: %v)", got, test.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/trees"
+	"github.com/transparency-dev/merkle/rfc6962"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var optsQueueImport = trees.NewGetOpts(trees.QueueLog, trillian.TreeType_LOG)
+
+// ExportQueue implements trillian.TrillianAdminServer.ExportQueue. The queue
+// is read in a single snapshot, so the leaves are consistent with each other
+// even if the log keeps being sequenced meanwhile.
+func (s *Server) ExportQueue(req *trillian.ExportQueueRequest, stream trillian.TrillianAdmin_ExportQueueServer) error {
+	ctx := stream.Context()
+	limit := int(req.GetMaxLeavesPerChunk())
+	switch {
+	case limit < 0:
+		return status.Errorf(codes.InvalidArgument, "max_leaves_per_chunk negative: %d", limit)
+	case limit == 0:
+		limit = DefaultExportChunkLeaves
+	}
+	tree, err := trees.GetTree(ctx, s.registry.AdminStorage, req.GetTreeId(), optsQuarantine)
+	if err != nil {
+		return err
+	}
+
+	var leaves []*trillian.LogLeaf
+	if err := s.snapshot(ctx, tree, func(tx storage.ReadOnlyLogTreeTX) error {
+		qtx, err := storage.AsQueueExportTX(tx)
+		if err != nil {
+			return err
+		}
+		leaves, err = qtx.ListQueuedLeaves(ctx)
+		return err
+	}); err != nil {
+		return err
+	}
+	if err := stream.Send(&trillian.QueueSnapshotChunk{TreeId: tree.TreeId}); err != nil {
+		return err
+	}
+	for start := 0; start < len(leaves); start += limit {
+		end := start + limit
+		if end > len(leaves) {
+			end = len(leaves)
+		}
+		if err := stream.Send(&trillian.QueueSnapshotChunk{Leaves: leaves[start:end]}); err != nil {
+			return err
+		}
+	}
+	glog.Infof("%v: exported %d queued leaves", tree.TreeId, len(leaves))
+	return nil
+}
+
+// ImportQueue implements trillian.TrillianAdminServer.ImportQueue. Leaves are
+// queued with their exported queue timestamps, or the current time if they
+// have none, and each chunk is queued as it's received.
+func (s *Server) ImportQueue(stream trillian.TrillianAdmin_ImportQueueServer) error {
+	ctx := stream.Context()
+	chunk, err := stream.Recv()
+	if err == io.EOF {
+		return status.Error(codes.InvalidArgument, "empty snapshot")
+	} else if err != nil {
+		return err
+	}
+	if chunk.GetTreeId() == 0 {
+		return status.Error(codes.InvalidArgument, "the first chunk must hold the tree_id")
+	}
+	if err := s.checkRunbook(ctx, "ImportQueue", chunk.GetTreeId(), "importing queued leaves"); err != nil {
+		return err
+	}
+	tree, err := trees.GetTree(ctx, s.registry.AdminStorage, chunk.GetTreeId(), optsQueueImport)
+	if err != nil {
+		return err
+	}
+
+	resp := &trillian.ImportQueueResponse{}
+	for {
+		if err := s.importQueuedLeaves(ctx, tree, chunk.GetLeaves(), resp); err != nil {
+			return err
+		}
+		if chunk, err = stream.Recv(); err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		if chunk.GetTreeId() != 0 {
+			return status.Error(codes.InvalidArgument, "only the first chunk may hold the tree_id")
+		}
+	}
+	glog.Infof("%v: imported %d queued leaves, skipped %d duplicates", tree.TreeId, resp.QueuedLeaves, resp.DuplicateLeaves)
+	return stream.SendAndClose(resp)
+}
+
+// importQueuedLeaves queues leaves in the tree, in runs of leaves with the same
+// queue timestamp, and counts them in resp.
+func (s *Server) importQueuedLeaves(ctx context.Context, tree *trillian.Tree, leaves []*trillian.LogLeaf, resp *trillian.ImportQueueResponse) error {
+	for _, leaf := range leaves {
+		if len(leaf.GetLeafIdentityHash()) == 0 {
+			return status.Error(codes.InvalidArgument, "queued leaf has no leaf_identity_hash")
+		}
+		if !bytes.Equal(rfc6962.DefaultHasher.HashLeaf(leaf.GetLeafValue()), leaf.GetMerkleLeafHash()) {
+			return status.Errorf(codes.InvalidArgument, "queued leaf %x has a Merkle leaf hash which doesn't match its value", leaf.LeafIdentityHash)
+		}
+		if ts := leaf.GetQueueTimestamp(); ts != nil {
+			if err := ts.CheckValid(); err != nil {
+				return status.Errorf(codes.InvalidArgument, "queued leaf %x has an invalid queue_timestamp: %v", leaf.LeafIdentityHash, err)
+			}
+		}
+	}
+
+	ctx = trees.NewContext(ctx, tree)
+	for len(leaves) > 0 {
+		queueTimestamp := s.timeSource.Now()
+		if ts := leaves[0].GetQueueTimestamp(); ts != nil {
+			queueTimestamp = ts.AsTime()
+		}
+		n := 1
+		for n < len(leaves) && sameQueueTimestamp(leaves[0], leaves[n]) {
+			n++
+		}
+		queued, err := s.registry.LogStorage.QueueLeaves(ctx, tree, leaves[:n], queueTimestamp)
+		if err != nil {
+			return err
+		}
+		for _, q := range queued {
+			if status.FromProto(q.GetStatus()).Code() == codes.AlreadyExists {
+				resp.DuplicateLeaves++
+			} else {
+				resp.QueuedLeaves++
+			}
+		}
+		leaves = leaves[n:]
+	}
+	return nil
+}
+
+// sameQueueTimestamp returns whether the leaves were queued at the same time,
+// or both have no queue timestamp.
+func sameQueueTimestamp(a, b *trillian.LogLeaf) bool {
+	ta, tb := a.GetQueueTimestamp(), b.GetQueueTimestamp()
+	if ta == nil || tb == nil {
+		return ta == nil && tb == nil
+	}
+	return ta.AsTime().Equal(tb.AsTime())
+}
@@ -0,0 +1,223 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/transparency-dev/merkle/rfc6962"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeQueueExportStream is an ExportQueue server stream which collects the
+// chunks.
+type fakeQueueExportStream struct {
+	grpc.ServerStream
+	chunks []*trillian.QueueSnapshotChunk
+}
+
+func (s *fakeQueueExportStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *fakeQueueExportStream) Send(chunk *trillian.QueueSnapshotChunk) error {
+	s.chunks = append(s.chunks, proto.Clone(chunk).(*trillian.QueueSnapshotChunk))
+	return nil
+}
+
+// fakeQueueImportStream is an ImportQueue server stream which receives the
+// given chunks.
+type fakeQueueImportStream struct {
+	grpc.ServerStream
+	chunks []*trillian.QueueSnapshotChunk
+	resp   *trillian.ImportQueueResponse
+}
+
+func (s *fakeQueueImportStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *fakeQueueImportStream) Recv() (*trillian.QueueSnapshotChunk, error) {
+	if len(s.chunks) == 0 {
+		return nil, io.EOF
+	}
+	chunk := s.chunks[0]
+	s.chunks = s.chunks[1:]
+	return chunk, nil
+}
+
+func (s *fakeQueueImportStream) SendAndClose(resp *trillian.ImportQueueResponse) error {
+	s.resp = resp
+	return nil
+}
+
+// exportQueue returns the queued leaves of the tree, as exported by s.
+func exportQueue(t *testing.T, s *Server, tree *trillian.Tree) []*trillian.LogLeaf {
+	t.Helper()
+	stream := &fakeQueueExportStream{}
+	if err := s.ExportQueue(&trillian.ExportQueueRequest{TreeId: tree.TreeId}, stream); err != nil {
+		t.Fatalf("ExportQueue(): %v", err)
+	}
+	var leaves []*trillian.LogLeaf
+	for _, chunk := range stream.chunks {
+		leaves = append(leaves, chunk.Leaves...)
+	}
+	return leaves
+}
+
+func TestServer_ExportImportQueue(t *testing.T) {
+	ctx := context.Background()
+	s, tree, fakeTime := setupRunbookServer(ctx, t)
+
+	// Leaves are queued in two batches, the second one before the first.
+	var leaves []*trillian.LogLeaf
+	for i, queued := range []time.Time{fakeTime.Now(), fakeTime.Now().Add(-time.Minute)} {
+		batch := make([]*trillian.LogLeaf, 0, 5)
+		for j := 0; j < cap(batch); j++ {
+			data := []byte(fmt.Sprintf("leaf %d-%d", i, j))
+			hash := sha256.Sum256(data)
+			batch = append(batch, &trillian.LogLeaf{
+				LeafValue:        data,
+				LeafIdentityHash: hash[:],
+				MerkleLeafHash:   rfc6962.DefaultHasher.HashLeaf(data),
+				ExtraData:        []byte("extra"),
+				QueueTimestamp:   timestamppb.New(queued),
+			})
+		}
+		if _, err := s.registry.LogStorage.QueueLeaves(ctx, tree, batch, queued); err != nil {
+			t.Fatalf("QueueLeaves(): %v", err)
+		}
+		leaves = append(leaves, batch...)
+	}
+
+	if err := s.ExportQueue(&trillian.ExportQueueRequest{TreeId: tree.TreeId, MaxLeavesPerChunk: -1}, &fakeQueueExportStream{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ExportQueue(max_leaves_per_chunk=-1): %v, want code %v", err, codes.InvalidArgument)
+	}
+	export := &fakeQueueExportStream{}
+	if err := s.ExportQueue(&trillian.ExportQueueRequest{TreeId: tree.TreeId, MaxLeavesPerChunk: 4}, export); err != nil {
+		t.Fatalf("ExportQueue(): %v", err)
+	}
+	if got, want := len(export.chunks), 4; got != want {
+		t.Fatalf("ExportQueue() streamed %d chunks, want %d", got, want)
+	}
+	if got, want := export.chunks[0].TreeId, tree.TreeId; got != want {
+		t.Errorf("ExportQueue() streamed tree_id %d, want %d", got, want)
+	}
+	var exported []*trillian.LogLeaf
+	for _, chunk := range export.chunks {
+		exported = append(exported, chunk.Leaves...)
+	}
+	if got, want := len(exported), len(leaves); got != want {
+		t.Fatalf("ExportQueue() streamed %d leaves, want %d", got, want)
+	}
+	for i := 1; i < len(exported); i++ {
+		if exported[i].QueueTimestamp.AsTime().Before(exported[i-1].QueueTimestamp.AsTime()) {
+			t.Errorf("ExportQueue() leaf %d queued before leaf %d", i, i-1)
+		}
+	}
+	chunks := func(treeID int64) []*trillian.QueueSnapshotChunk {
+		ret := make([]*trillian.QueueSnapshotChunk, 0, len(export.chunks))
+		for _, chunk := range export.chunks {
+			ret = append(ret, proto.Clone(chunk).(*trillian.QueueSnapshotChunk))
+		}
+		ret[0].TreeId = treeID
+		return ret
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		dst, dstTree, _ := setupRunbookServer(ctx, t)
+		stream := &fakeQueueImportStream{chunks: chunks(dstTree.TreeId)}
+		if err := dst.ImportQueue(stream); err != nil {
+			t.Fatalf("ImportQueue(): %v", err)
+		}
+		if got, want := stream.resp.QueuedLeaves, int64(len(leaves)); got != want {
+			t.Errorf("ImportQueue() queued %d leaves, want %d", got, want)
+		}
+		imported := exportQueue(t, dst, dstTree)
+		if got, want := len(imported), len(exported); got != want {
+			t.Fatalf("imported %d leaves, want %d", got, want)
+		}
+		for i := range imported {
+			if !proto.Equal(imported[i], exported[i]) {
+				t.Errorf("imported leaf %d = %v, want %v", i, imported[i], exported[i])
+			}
+		}
+	})
+
+	t.Run("runbookDisabled", func(t *testing.T) {
+		dst, dstTree, _ := setupRunbookServer(ctx, t)
+		dst.runbookRPCs = false
+		err := dst.ImportQueue(&fakeQueueImportStream{chunks: chunks(dstTree.TreeId)})
+		if got, want := status.Code(err), codes.PermissionDenied; got != want {
+			t.Errorf("ImportQueue(): %v, want code %v", err, want)
+		}
+	})
+
+	for _, tc := range []struct {
+		desc   string
+		modify func([]*trillian.QueueSnapshotChunk) []*trillian.QueueSnapshotChunk
+	}{
+		{
+			desc:   "empty",
+			modify: func([]*trillian.QueueSnapshotChunk) []*trillian.QueueSnapshotChunk { return nil },
+		},
+		{
+			desc: "noTreeID",
+			modify: func(chunks []*trillian.QueueSnapshotChunk) []*trillian.QueueSnapshotChunk {
+				chunks[0].TreeId = 0
+				return chunks
+			},
+		},
+		{
+			desc: "secondTreeID",
+			modify: func(chunks []*trillian.QueueSnapshotChunk) []*trillian.QueueSnapshotChunk {
+				chunks[2].TreeId = chunks[0].TreeId
+				return chunks
+			},
+		},
+		{
+			desc: "noIdentityHash",
+			modify: func(chunks []*trillian.QueueSnapshotChunk) []*trillian.QueueSnapshotChunk {
+				chunks[1].Leaves[0].LeafIdentityHash = nil
+				return chunks
+			},
+		},
+		{
+			desc: "leafValue",
+			modify: func(chunks []*trillian.QueueSnapshotChunk) []*trillian.QueueSnapshotChunk {
+				chunks[1].Leaves[2].LeafValue = []byte("changed")
+				return chunks
+			},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			dst, dstTree, _ := setupRunbookServer(ctx, t)
+			err := dst.ImportQueue(&fakeQueueImportStream{chunks: tc.modify(chunks(dstTree.TreeId))})
+			if got, want := status.Code(err), codes.InvalidArgument; got != want {
+				t.Errorf("ImportQueue(): %v, want code %v", err, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian"
+	"github.com/google/trillian/storage"
+	"google.golang.org/protobuf/proto"
+)
+
+// SetTreeQuota implements trillian.TrillianAdminServer.SetTreeQuota.
+func (s *Server) SetTreeQuota(ctx context.Context, req *trillian.SetTreeQuotaRequest) (*trillian.TreeQuota, error) {
+	quota := req.GetQuota()
+	if proto.Equal(quota, &trillian.TreeQuota{}) {
+		// An empty quota doesn't limit anything, so it isn't stored.
+		quota = nil
+	}
+	tree, err := storage.UpdateTree(ctx, s.registry.AdminStorage, req.GetTreeId(), func(tree *trillian.Tree) {
+		tree.Quota = quota
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.notifyWatches()
+	glog.Infof("%v: quota set to %v", tree.TreeId, quota)
+	return treeQuota(tree), nil
+}
+
+// GetTreeQuota implements trillian.TrillianAdminServer.GetTreeQuota.
+func (s *Server) GetTreeQuota(ctx context.Context, req *trillian.GetTreeQuotaRequest) (*trillian.TreeQuota, error) {
+	tree, err := storage.GetTree(ctx, s.registry.AdminStorage, req.GetTreeId())
+	if err != nil {
+		return nil, err
+	}
+	return treeQuota(tree), nil
+}
+
+// treeQuota returns the quota of the tree, which is empty if it has none.
+func treeQuota(tree *trillian.Tree) *trillian.TreeQuota {
+	if tree.Quota == nil {
+		return &trillian.TreeQuota{}
+	}
+	return tree.Quota
+}
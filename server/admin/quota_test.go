@@ -0,0 +1,103 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/google/trillian"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestServer_SetTreeQuota(t *testing.T) {
+	ctx := context.Background()
+	s, tree, _ := setupRunbookServer(ctx, t)
+
+	got, err := s.GetTreeQuota(ctx, &trillian.GetTreeQuotaRequest{TreeId: tree.TreeId})
+	if err != nil {
+		t.Fatalf("GetTreeQuota(): %v", err)
+	}
+	if !proto.Equal(got, &trillian.TreeQuota{}) {
+		t.Errorf("GetTreeQuota() before SetTreeQuota: %v, want empty", got)
+	}
+
+	want := &trillian.TreeQuota{ReadTokensPerSecond: 100, ReadBurst: 200, WriteTokensPerSecond: 2.5}
+	if got, err = s.SetTreeQuota(ctx, &trillian.SetTreeQuotaRequest{TreeId: tree.TreeId, Quota: want}); err != nil {
+		t.Fatalf("SetTreeQuota(): %v", err)
+	}
+	if !proto.Equal(got, want) {
+		t.Errorf("SetTreeQuota(): %v, want %v", got, want)
+	}
+	if got, err = s.GetTreeQuota(ctx, &trillian.GetTreeQuotaRequest{TreeId: tree.TreeId}); err != nil {
+		t.Fatalf("GetTreeQuota(): %v", err)
+	}
+	if !proto.Equal(got, want) {
+		t.Errorf("GetTreeQuota(): %v, want %v", got, want)
+	}
+	stored, err := s.GetTree(ctx, &trillian.GetTreeRequest{TreeId: tree.TreeId})
+	if err != nil {
+		t.Fatalf("GetTree(): %v", err)
+	}
+	if !proto.Equal(stored.Quota, want) {
+		t.Errorf("GetTree() returned quota %v, want %v", stored.Quota, want)
+	}
+
+	if _, err = s.SetTreeQuota(ctx, &trillian.SetTreeQuotaRequest{TreeId: tree.TreeId}); err != nil {
+		t.Fatalf("SetTreeQuota(nil): %v", err)
+	}
+	if stored, err = s.GetTree(ctx, &trillian.GetTreeRequest{TreeId: tree.TreeId}); err != nil {
+		t.Fatalf("GetTree(): %v", err)
+	}
+	if stored.Quota != nil {
+		t.Errorf("GetTree() after removing the quota returned quota %v, want nil", stored.Quota)
+	}
+}
+
+func TestServer_SetTreeQuotaErrors(t *testing.T) {
+	ctx := context.Background()
+	s, tree, _ := setupRunbookServer(ctx, t)
+	for _, test := range []struct {
+		desc string
+		req  *trillian.SetTreeQuotaRequest
+		want codes.Code
+	}{
+		{
+			desc: "negativeRate",
+			req:  &trillian.SetTreeQuotaRequest{TreeId: tree.TreeId, Quota: &trillian.TreeQuota{ReadTokensPerSecond: -1}},
+			want: codes.InvalidArgument,
+		},
+		{
+			desc: "nanRate",
+			req:  &trillian.SetTreeQuotaRequest{TreeId: tree.TreeId, Quota: &trillian.TreeQuota{WriteTokensPerSecond: math.NaN()}},
+			want: codes.InvalidArgument,
+		},
+		{
+			desc: "negativeBurst",
+			req:  &trillian.SetTreeQuotaRequest{TreeId: tree.TreeId, Quota: &trillian.TreeQuota{WriteTokensPerSecond: 1, WriteBurst: -1}},
+			want: codes.InvalidArgument,
+		},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			_, err := s.SetTreeQuota(ctx, test.req)
+			if got := status.Code(err); got != test.want {
+				t.Errorf("SetTreeQuota() returned code %v, want %v (err: %v)", got, test.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian"
+	"github.com/google/trillian/log"
+	"github.com/google/trillian/trees"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// DefaultPruneLeaves is the number of leaves pruned by a PruneLeaves request
+// which doesn't set max_leaves.
+const DefaultPruneLeaves = 1000
+
+var optsPrune = trees.NewGetOpts(trees.Admin, trillian.TreeType_LOG, trillian.TreeType_PREORDERED_LOG)
+
+// PruneLeaves implements trillian.TrillianAdminServer.PruneLeaves.
+func (s *Server) PruneLeaves(ctx context.Context, req *trillian.PruneLeavesRequest) (*trillian.PruneLeavesResponse, error) {
+	limit := int(req.GetMaxLeaves())
+	switch {
+	case limit < 0:
+		return nil, status.Errorf(codes.InvalidArgument, "max_leaves negative: %d", limit)
+	case limit == 0:
+		limit = DefaultPruneLeaves
+	}
+	tree, err := trees.GetTree(ctx, s.registry.AdminStorage, req.GetTreeId(), optsPrune)
+	if err != nil {
+		return nil, err
+	}
+	cutoff, pruned, err := log.PruneLeaves(ctx, tree, s.timeSource.Now(), limit, s.registry.LogStorage, req.GetDryRun())
+	if err != nil {
+		return nil, err
+	}
+	if !req.GetDryRun() {
+		glog.Infof("%v: pruned data of %d leaves integrated before %v", tree.TreeId, pruned, cutoff)
+	}
+	return &trillian.PruneLeavesResponse{CutoffTime: timestamppb.New(cutoff), PrunedLeaves: int64(pruned)}, nil
+}
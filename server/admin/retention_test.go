@@ -0,0 +1,84 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestServer_PruneLeaves(t *testing.T) {
+	ctx := context.Background()
+	s, tree, fakeTime := setupRunbookServer(ctx, t)
+	now := fakeTime.Now()
+
+	leaf := &trillian.LogLeaf{
+		LeafValue:          []byte("old"),
+		LeafIdentityHash:   make([]byte, 32),
+		MerkleLeafHash:     make([]byte, 32),
+		IntegrateTimestamp: timestamppb.New(now.Add(-48 * time.Hour)),
+	}
+	ls := s.registry.LogStorage
+	if _, err := ls.QueueLeaves(ctx, tree, []*trillian.LogLeaf{leaf}, now); err != nil {
+		t.Fatalf("QueueLeaves(): %v", err)
+	}
+	if err := ls.ReadWriteTransaction(ctx, tree, func(ctx context.Context, tx storage.LogTreeTX) error {
+		return tx.UpdateSequencedLeaves(ctx, []*trillian.LogLeaf{leaf})
+	}); err != nil {
+		t.Fatalf("UpdateSequencedLeaves(): %v", err)
+	}
+
+	req := &trillian.PruneLeavesRequest{TreeId: tree.TreeId, DryRun: true}
+	if _, err := s.PruneLeaves(ctx, req); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("PruneLeaves() without policy: %v, want code %v", err, codes.FailedPrecondition)
+	}
+	if _, err := s.PruneLeaves(ctx, &trillian.PruneLeavesRequest{TreeId: tree.TreeId, MaxLeaves: -1}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("PruneLeaves(max_leaves=-1): %v, want code %v", err, codes.InvalidArgument)
+	}
+
+	update := &trillian.Tree{TreeId: tree.TreeId, RetentionPolicy: &trillian.RetentionPolicy{MaxLeafAge: durationpb.New(24 * time.Hour)}}
+	if _, err := s.UpdateTree(ctx, &trillian.UpdateTreeRequest{Tree: update, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"retention_policy"}}}); err != nil {
+		t.Fatalf("UpdateTree(): %v", err)
+	}
+	for _, dryRun := range []bool{true, false} {
+		req.DryRun = dryRun
+		resp, err := s.PruneLeaves(ctx, req)
+		if err != nil {
+			t.Fatalf("PruneLeaves(dry_run=%v): %v", dryRun, err)
+		}
+		if got, want := resp.PrunedLeaves, int64(1); got != want {
+			t.Errorf("PruneLeaves(dry_run=%v): pruned %d leaves, want %d", dryRun, got, want)
+		}
+		if got, want := resp.CutoffTime.AsTime(), now.Add(-24*time.Hour); !got.Equal(want) {
+			t.Errorf("PruneLeaves(dry_run=%v): cutoff %v, want %v", dryRun, got, want)
+		}
+	}
+	resp, err := s.PruneLeaves(ctx, req)
+	if err != nil {
+		t.Fatalf("PruneLeaves(): %v", err)
+	}
+	if resp.PrunedLeaves != 0 {
+		t.Errorf("PruneLeaves() after pruning: pruned %d leaves, want 0", resp.PrunedLeaves)
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian"
+	"github.com/google/trillian/log"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/trees"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	optsResign     = trees.NewGetOpts(trees.SequenceLog, trillian.TreeType_LOG, trillian.TreeType_PREORDERED_LOG)
+	optsQuarantine = trees.NewGetOpts(trees.Admin, trillian.TreeType_LOG)
+)
+
+// EnableRunbookRPCs allows the server to serve the operational RPCs which
+// modify the contents of a tree (ResignLogRoot, QuarantineLeaf and
+// RequeueQuarantinedLeaves). They are rejected unless this is called.
+func (s *Server) EnableRunbookRPCs() {
+	s.runbookRPCs = true
+}
+
+// checkRunbook returns an error if runbook RPCs aren't enabled, and otherwise
+// writes an audit log entry for the operation.
+func (s *Server) checkRunbook(ctx context.Context, method string, treeID int64, format string, args ...interface{}) error {
+	if !s.runbookRPCs {
+		return status.Errorf(codes.PermissionDenied, "%s is not enabled on this server", method)
+	}
+	caller := "unknown"
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		caller = p.Addr.String()
+	}
+	glog.Warningf("AUDIT: %s on tree %d from %s: "+format, append([]interface{}{method, treeID, caller}, args...)...)
+	return nil
+}
+
+// ResignLogRoot implements trillian.TrillianAdminServer.ResignLogRoot.
+//
+// The root is written while holding the mastership of the tree in the
+// election of the registry, so that it can't race with a signer integrating
+// leaves. The call blocks until mastership is acquired or ctx is done. It
+// fails with FailedPrecondition if the registry has no election, which the
+// log server only joins if --master_election names that of the signers.
+func (s *Server) ResignLogRoot(ctx context.Context, req *trillian.ResignLogRootRequest) (*trillian.ResignLogRootResponse, error) {
+	if err := s.checkRunbook(ctx, "ResignLogRoot", req.GetTreeId(), "re-signing latest root"); err != nil {
+		return nil, err
+	}
+	if s.registry.ElectionFactory == nil {
+		return nil, status.Error(codes.FailedPrecondition, "ResignLogRoot requires the master election of the signers, see --master_election")
+	}
+	tree, err := trees.GetTree(ctx, s.registry.AdminStorage, req.GetTreeId(), optsResign)
+	if err != nil {
+		return nil, err
+	}
+
+	// Elections are keyed by the decimal tree ID, see log.OperationManager.
+	e, err := s.registry.ElectionFactory.NewElection(ctx, strconv.FormatInt(tree.TreeId, 10))
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "failed to create election for tree %d: %v", tree.TreeId, err)
+	}
+	defer func() {
+		if err := e.Close(context.Background()); err != nil {
+			glog.Warningf("%d: failed to close election: %v", tree.TreeId, err)
+		}
+	}()
+	if err := e.Await(ctx); err != nil {
+		return nil, status.Errorf(codes.Unavailable, "failed to acquire mastership of tree %d: %v", tree.TreeId, err)
+	}
+	mctx, err := e.WithMastership(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "failed to get mastership context for tree %d: %v", tree.TreeId, err)
+	}
+	slr, err := log.ResignRoot(mctx, tree, s.timeSource, s.registry.LogStorage)
+	if err != nil {
+		return nil, err
+	}
+	return &trillian.ResignLogRootResponse{SignedLogRoot: slr}, nil
+}
+
+// QuarantineLeaf implements trillian.TrillianAdminServer.QuarantineLeaf.
+func (s *Server) QuarantineLeaf(ctx context.Context, req *trillian.QuarantineLeafRequest) (*trillian.QuarantineLeafResponse, error) {
+	if len(req.GetLeafIdentityHash()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "a leaf_identity_hash is required")
+	}
+	if req.GetReason() == "" {
+		return nil, status.Error(codes.InvalidArgument, "a reason is required")
+	}
+	if err := s.checkRunbook(ctx, "QuarantineLeaf", req.GetTreeId(), "leaf %x: %s", req.GetLeafIdentityHash(), req.GetReason()); err != nil {
+		return nil, err
+	}
+	tree, err := trees.GetTree(ctx, s.registry.AdminStorage, req.GetTreeId(), optsQuarantine)
+	if err != nil {
+		return nil, err
+	}
+
+	var leaves []*trillian.QuarantinedLeaf
+	err = s.registry.LogStorage.ReadWriteTransaction(ctx, tree, func(ctx context.Context, tx storage.LogTreeTX) error {
+		qtx, err := storage.AsQuarantineTX(tx)
+		if err != nil {
+			return err
+		}
+		leaves, err = qtx.QuarantineLeaves(ctx, [][]byte{req.GetLeafIdentityHash()}, req.GetReason(), s.timeSource.Now())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(leaves) == 0 {
+		return nil, status.Errorf(codes.NotFound, "no queued leaf with identity hash %x", req.GetLeafIdentityHash())
+	}
+	return &trillian.QuarantineLeafResponse{QuarantinedLeaf: leaves[0]}, nil
+}
+
+// RequeueQuarantinedLeaves implements trillian.TrillianAdminServer.RequeueQuarantinedLeaves.
+func (s *Server) RequeueQuarantinedLeaves(ctx context.Context, req *trillian.RequeueQuarantinedLeavesRequest) (*trillian.RequeueQuarantinedLeavesResponse, error) {
+	if err := s.checkRunbook(ctx, "RequeueQuarantinedLeaves", req.GetTreeId(), "%d leaves requested (0 means all)", len(req.GetLeafIdentityHashes())); err != nil {
+		return nil, err
+	}
+	tree, err := trees.GetTree(ctx, s.registry.AdminStorage, req.GetTreeId(), optsQuarantine)
+	if err != nil {
+		return nil, err
+	}
+
+	var leaves []*trillian.QuarantinedLeaf
+	err = s.registry.LogStorage.ReadWriteTransaction(ctx, tree, func(ctx context.Context, tx storage.LogTreeTX) error {
+		qtx, err := storage.AsQuarantineTX(tx)
+		if err != nil {
+			return err
+		}
+		leaves, err = qtx.RequeueQuarantinedLeaves(ctx, req.GetLeafIdentityHashes())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &trillian.RequeueQuarantinedLeavesResponse{RequeuedLeaves: leaves}, nil
+}
+
+// ListQuarantinedLeaves implements trillian.TrillianAdminServer.ListQuarantinedLeaves.
+func (s *Server) ListQuarantinedLeaves(ctx context.Context, req *trillian.ListQuarantinedLeavesRequest) (*trillian.ListQuarantinedLeavesResponse, error) {
+	tree, err := trees.GetTree(ctx, s.registry.AdminStorage, req.GetTreeId(), optsQuarantine)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := s.registry.LogStorage.SnapshotForTree(ctx, tree)
+	if err != nil {
+		if tx != nil {
+			tx.Close()
+		}
+		return nil, err
+	}
+	defer tx.Close()
+
+	qtx, err := storage.AsQuarantineTX(tx)
+	if err != nil {
+		return nil, err
+	}
+	leaves, err := qtx.ListQuarantinedLeaves(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return &trillian.ListQuarantinedLeavesResponse{QuarantinedLeaves: leaves}, nil
+}
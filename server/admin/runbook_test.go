@@ -0,0 +1,255 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/extension"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/storage/memory"
+	"github.com/google/trillian/storage/testonly"
+	"github.com/google/trillian/types"
+	"github.com/google/trillian/util/clock"
+	"github.com/google/trillian/util/election2"
+	eto "github.com/google/trillian/util/election2/testonly"
+	"github.com/transparency-dev/merkle/rfc6962"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func setupRunbookServer(ctx context.Context, t *testing.T) (*Server, *trillian.Tree, *clock.FakeTimeSource) {
+	t.Helper()
+	ts := memory.NewTreeStorage()
+	registry := extension.Registry{
+		AdminStorage:    memory.NewAdminStorage(ts),
+		LogStorage:      memory.NewLogStorage(ts, nil),
+		ElectionFactory: election2.NoopFactory{},
+	}
+	tree, err := storage.CreateTree(ctx, registry.AdminStorage, proto.Clone(testonly.LogTree).(*trillian.Tree))
+	if err != nil {
+		t.Fatalf("CreateTree(): %v", err)
+	}
+	root, err := (&types.LogRootV1{RootHash: rfc6962.DefaultHasher.EmptyRoot(), TimestampNanos: 1}).MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary(): %v", err)
+	}
+	if err := registry.LogStorage.ReadWriteTransaction(ctx, tree, func(ctx context.Context, tx storage.LogTreeTX) error {
+		return tx.StoreSignedLogRoot(ctx, &trillian.SignedLogRoot{LogRoot: root})
+	}); err != nil {
+		t.Fatalf("StoreSignedLogRoot(): %v", err)
+	}
+
+	fakeTime := clock.NewFake(time.Unix(1500000000, 0))
+	s := New(registry, nil /* allowedTreeTypes */)
+	s.timeSource = fakeTime
+	s.EnableRunbookRPCs()
+	return s, tree, fakeTime
+}
+
+func queuedIdentityHashes(ctx context.Context, t *testing.T, ls storage.LogStorage, tree *trillian.Tree) []string {
+	t.Helper()
+	var ret []string
+	if err := ls.ReadWriteTransaction(ctx, tree, func(ctx context.Context, tx storage.LogTreeTX) error {
+		leaves, err := tx.DequeueLeaves(ctx, 100, time.Now())
+		for _, l := range leaves {
+			ret = append(ret, string(l.LeafIdentityHash))
+		}
+		return err
+	}); err != nil {
+		t.Fatalf("DequeueLeaves(): %v", err)
+	}
+	return ret
+}
+
+func TestServer_RunbookRPCsDisabled(t *testing.T) {
+	ctx := context.Background()
+	s := New(extension.Registry{}, nil /* allowedTreeTypes */)
+	for _, test := range []struct {
+		desc string
+		fn   func() error
+	}{
+		{
+			desc: "ResignLogRoot",
+			fn: func() error {
+				_, err := s.ResignLogRoot(ctx, &trillian.ResignLogRootRequest{TreeId: 1})
+				return err
+			},
+		},
+		{
+			desc: "QuarantineLeaf",
+			fn: func() error {
+				_, err := s.QuarantineLeaf(ctx, &trillian.QuarantineLeafRequest{TreeId: 1, LeafIdentityHash: []byte("hash"), Reason: "bad"})
+				return err
+			},
+		},
+		{
+			desc: "RequeueQuarantinedLeaves",
+			fn: func() error {
+				_, err := s.RequeueQuarantinedLeaves(ctx, &trillian.RequeueQuarantinedLeavesRequest{TreeId: 1})
+				return err
+			},
+		},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			if got, want := status.Code(test.fn()), codes.PermissionDenied; got != want {
+				t.Errorf("%s() returned code %v, want %v", test.desc, got, want)
+			}
+		})
+	}
+}
+
+func TestServer_ResignLogRoot(t *testing.T) {
+	ctx := context.Background()
+	s, tree, fakeTime := setupRunbookServer(ctx, t)
+
+	fakeTime.Set(fakeTime.Now().Add(time.Minute))
+	resp, err := s.ResignLogRoot(ctx, &trillian.ResignLogRootRequest{TreeId: tree.TreeId})
+	if err != nil {
+		t.Fatalf("ResignLogRoot(): %v", err)
+	}
+	var root types.LogRootV1
+	if err := root.UnmarshalBinary(resp.SignedLogRoot.LogRoot); err != nil {
+		t.Fatalf("UnmarshalBinary(): %v", err)
+	}
+	if got, want := root.TimestampNanos, uint64(fakeTime.Now().UnixNano()); got != want {
+		t.Errorf("TimestampNanos=%d, want %d", got, want)
+	}
+	if got, want := root.TreeSize, uint64(0); got != want {
+		t.Errorf("TreeSize=%d, want %d", got, want)
+	}
+
+	// Re-signing again without time moving forward must fail.
+	if _, err := s.ResignLogRoot(ctx, &trillian.ResignLogRootRequest{TreeId: tree.TreeId}); err == nil {
+		t.Error("ResignLogRoot() with stale timestamp returned nil error")
+	}
+}
+
+func TestServer_ResignLogRootMastership(t *testing.T) {
+	ctx := context.Background()
+	s, tree, fakeTime := setupRunbookServer(ctx, t)
+	fakeTime.Set(fakeTime.Now().Add(time.Minute))
+
+	s.registry.ElectionFactory = nil
+	if _, err := s.ResignLogRoot(ctx, &trillian.ResignLogRootRequest{TreeId: tree.TreeId}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("ResignLogRoot() without election: %v, want code %v", err, codes.FailedPrecondition)
+	}
+
+	s.registry.ElectionFactory = failingAwaitFactory{}
+	if _, err := s.ResignLogRoot(ctx, &trillian.ResignLogRootRequest{TreeId: tree.TreeId}); status.Code(err) != codes.Unavailable {
+		t.Errorf("ResignLogRoot() without mastership: %v, want code %v", err, codes.Unavailable)
+	}
+
+	// Neither call may have written a root.
+	tx, err := s.registry.LogStorage.SnapshotForTree(ctx, tree)
+	if err != nil {
+		t.Fatalf("SnapshotForTree(): %v", err)
+	}
+	defer tx.Close()
+	slr, err := tx.LatestSignedLogRoot(ctx)
+	if err != nil {
+		t.Fatalf("LatestSignedLogRoot(): %v", err)
+	}
+	var root types.LogRootV1
+	if err := root.UnmarshalBinary(slr.LogRoot); err != nil {
+		t.Fatalf("UnmarshalBinary(): %v", err)
+	}
+	if got, want := root.TimestampNanos, uint64(1); got != want {
+		t.Errorf("TimestampNanos=%d, want %d", got, want)
+	}
+}
+
+// failingAwaitFactory creates elections which never capture mastership.
+type failingAwaitFactory struct{}
+
+func (failingAwaitFactory) NewElection(ctx context.Context, resourceID string) (election2.Election, error) {
+	d := eto.NewDecorator(election2.NoopElection(resourceID))
+	d.Update(eto.Errs{Await: errors.New("another instance is master")})
+	return d, nil
+}
+
+func TestServer_QuarantineAndRequeue(t *testing.T) {
+	ctx := context.Background()
+	s, tree, _ := setupRunbookServer(ctx, t)
+	ls := s.registry.LogStorage
+
+	var leaves []*trillian.LogLeaf
+	for _, data := range []string{"one", "two", "three"} {
+		hash := sha256.Sum256([]byte(data))
+		leaves = append(leaves, &trillian.LogLeaf{
+			LeafValue:        []byte(data),
+			LeafIdentityHash: hash[:],
+			MerkleLeafHash:   rfc6962.DefaultHasher.HashLeaf([]byte(data)),
+		})
+	}
+	if _, err := ls.QueueLeaves(ctx, tree, leaves, time.Now()); err != nil {
+		t.Fatalf("QueueLeaves(): %v", err)
+	}
+
+	bad := leaves[1].LeafIdentityHash
+	if _, err := s.QuarantineLeaf(ctx, &trillian.QuarantineLeafRequest{TreeId: tree.TreeId, LeafIdentityHash: bad}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("QuarantineLeaf() without reason returned %v, want code %v", err, codes.InvalidArgument)
+	}
+	resp, err := s.QuarantineLeaf(ctx, &trillian.QuarantineLeafRequest{TreeId: tree.TreeId, LeafIdentityHash: bad, Reason: "fails integration"})
+	if err != nil {
+		t.Fatalf("QuarantineLeaf(): %v", err)
+	}
+	if got, want := string(resp.QuarantinedLeaf.LeafIdentityHash), string(bad); got != want {
+		t.Errorf("QuarantineLeaf() quarantined %x, want %x", got, want)
+	}
+	if got, want := resp.QuarantinedLeaf.Reason, "fails integration"; got != want {
+		t.Errorf("QuarantineLeaf() reason=%q, want %q", got, want)
+	}
+	if got, want := len(queuedIdentityHashes(ctx, t, ls, tree)), 2; got != want {
+		t.Errorf("got %d queued leaves after quarantine, want %d", got, want)
+	}
+
+	lresp, err := s.ListQuarantinedLeaves(ctx, &trillian.ListQuarantinedLeavesRequest{TreeId: tree.TreeId})
+	if err != nil {
+		t.Fatalf("ListQuarantinedLeaves(): %v", err)
+	}
+	if got, want := len(lresp.QuarantinedLeaves), 1; got != want {
+		t.Fatalf("ListQuarantinedLeaves() returned %d leaves, want %d", got, want)
+	}
+	if got, want := string(lresp.QuarantinedLeaves[0].LeafIdentityHash), string(bad); got != want {
+		t.Errorf("ListQuarantinedLeaves() returned %x, want %x", got, want)
+	}
+
+	// Quarantining the same leaf again finds nothing in the queue.
+	if _, err := s.QuarantineLeaf(ctx, &trillian.QuarantineLeafRequest{TreeId: tree.TreeId, LeafIdentityHash: bad, Reason: "again"}); status.Code(err) != codes.NotFound {
+		t.Errorf("QuarantineLeaf() of quarantined leaf returned %v, want code %v", err, codes.NotFound)
+	}
+
+	rresp, err := s.RequeueQuarantinedLeaves(ctx, &trillian.RequeueQuarantinedLeavesRequest{TreeId: tree.TreeId})
+	if err != nil {
+		t.Fatalf("RequeueQuarantinedLeaves(): %v", err)
+	}
+	if got, want := len(rresp.RequeuedLeaves), 1; got != want {
+		t.Fatalf("RequeueQuarantinedLeaves() requeued %d leaves, want %d", got, want)
+	}
+	queued := queuedIdentityHashes(ctx, t, ls, tree)
+	if got, want := len(queued), 3; got != want {
+		t.Fatalf("got %d queued leaves after requeue, want %d", got, want)
+	}
+	if got, want := queued[2], string(bad); got != want {
+		t.Errorf("requeued leaf %x, want %x", got, want)
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/storage"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultWatchInterval is the default interval at which WatchTrees looks for
+// changes of trees made through other servers.
+const DefaultWatchInterval = 5 * time.Second
+
+// SetWatchInterval sets the interval at which WatchTrees looks for changes of
+// trees made through other servers.
+func (s *Server) SetWatchInterval(d time.Duration) {
+	s.watchInterval = d
+}
+
+// notifyWatches wakes up the WatchTrees calls, after a tree was changed
+// through this server.
+func (s *Server) notifyWatches() {
+	s.watchMu.Lock()
+	defer s.watchMu.Unlock()
+	if s.changed != nil {
+		close(s.changed)
+	}
+	s.changed = make(chan struct{})
+}
+
+// watchChanged returns a channel which is closed when a tree is next changed
+// through this server.
+func (s *Server) watchChanged() <-chan struct{} {
+	s.watchMu.Lock()
+	defer s.watchMu.Unlock()
+	if s.changed == nil {
+		s.changed = make(chan struct{})
+	}
+	return s.changed
+}
+
+// WatchTrees implements trillian.TrillianAdminServer.WatchTrees. Storage has
+// no change notifications, so the trees are listed again whenever a tree is
+// changed through this server, and otherwise at the watch interval.
+func (s *Server) WatchTrees(req *trillian.WatchTreesRequest, stream trillian.TrillianAdmin_WatchTreesServer) error {
+	ctx := stream.Context()
+	known := make(map[int64]*trillian.Tree)
+	for {
+		// Take the channel before listing, so that no change is missed.
+		changed := s.watchChanged()
+		trees, err := storage.ListTrees(ctx, s.registry.AdminStorage, req.GetShowDeleted())
+		if err != nil {
+			return err
+		}
+		for _, event := range treeEvents(known, trees) {
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		}
+
+		timer := s.timeSource.NewTimer(s.watchInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-changed:
+		case <-timer.Chan():
+		}
+		timer.Stop()
+	}
+}
+
+// treeEvents returns the events which turn the known trees into the listed
+// trees, ordered by tree ID, and updates known accordingly.
+func treeEvents(known map[int64]*trillian.Tree, trees []*trillian.Tree) []*trillian.TreeEvent {
+	var events []*trillian.TreeEvent
+	listed := make(map[int64]bool, len(trees))
+	for _, tree := range trees {
+		listed[tree.TreeId] = true
+		old, ok := known[tree.TreeId]
+		switch {
+		case !ok:
+			events = append(events, &trillian.TreeEvent{Type: trillian.TreeEvent_CREATED, Tree: tree})
+		case !proto.Equal(old, tree):
+			events = append(events, &trillian.TreeEvent{Type: trillian.TreeEvent_UPDATED, Tree: tree})
+		default:
+			continue
+		}
+		known[tree.TreeId] = proto.Clone(tree).(*trillian.Tree)
+	}
+	for id, tree := range known {
+		if !listed[id] {
+			events = append(events, &trillian.TreeEvent{Type: trillian.TreeEvent_DELETED, Tree: tree})
+			delete(known, id)
+		}
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Tree.TreeId < events[j].Tree.TreeId
+	})
+	return events
+}
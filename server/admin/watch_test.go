@@ -0,0 +1,147 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/extension"
+	"github.com/google/trillian/storage/memory"
+	"github.com/google/trillian/storage/testonly"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// fakeWatchStream is a WatchTrees server stream which passes the events on
+// to a channel.
+type fakeWatchStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	events chan *trillian.TreeEvent
+}
+
+func (s *fakeWatchStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeWatchStream) Send(event *trillian.TreeEvent) error {
+	s.events <- event
+	return nil
+}
+
+func TestServer_WatchTrees(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	registry := extension.Registry{AdminStorage: memory.NewAdminStorage(memory.NewTreeStorage())}
+	s := New(registry, nil)
+	// other changes the trees through another server, which s only notices
+	// at its watch interval.
+	other := New(registry, nil)
+	s.SetWatchInterval(10 * time.Millisecond)
+
+	existing, err := s.CreateTree(ctx, &trillian.CreateTreeRequest{Tree: proto.Clone(testonly.LogTree).(*trillian.Tree)})
+	if err != nil {
+		t.Fatalf("CreateTree(): %v", err)
+	}
+
+	stream := &fakeWatchStream{ctx: ctx, events: make(chan *trillian.TreeEvent)}
+	done := make(chan error)
+	go func() {
+		done <- s.WatchTrees(&trillian.WatchTreesRequest{}, stream)
+	}()
+	next := func(wantType trillian.TreeEvent_Type, wantID int64) {
+		t.Helper()
+		select {
+		case event := <-stream.events:
+			if event.Type != wantType || event.Tree.TreeId != wantID {
+				t.Errorf("got %v event of tree %d, want %v event of tree %d", event.Type, event.Tree.TreeId, wantType, wantID)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for %v event of tree %d", wantType, wantID)
+		}
+	}
+
+	next(trillian.TreeEvent_CREATED, existing.TreeId)
+
+	created, err := s.CreateTree(ctx, &trillian.CreateTreeRequest{Tree: proto.Clone(testonly.LogTree).(*trillian.Tree)})
+	if err != nil {
+		t.Fatalf("CreateTree(): %v", err)
+	}
+	next(trillian.TreeEvent_CREATED, created.TreeId)
+
+	update := &trillian.Tree{TreeId: created.TreeId, DisplayName: "renamed"}
+	if _, err := other.UpdateTree(ctx, &trillian.UpdateTreeRequest{Tree: update, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"display_name"}}}); err != nil {
+		t.Fatalf("UpdateTree(): %v", err)
+	}
+	next(trillian.TreeEvent_UPDATED, created.TreeId)
+
+	// The memory storage doesn't delete trees, which TestTreeEvents covers.
+	update = &trillian.Tree{TreeId: existing.TreeId, DisplayName: "renamed"}
+	if _, err := s.UpdateTree(ctx, &trillian.UpdateTreeRequest{Tree: update, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"display_name"}}}); err != nil {
+		t.Fatalf("UpdateTree(): %v", err)
+	}
+	next(trillian.TreeEvent_UPDATED, existing.TreeId)
+
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Errorf("WatchTrees() returned %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestTreeEvents(t *testing.T) {
+	tree1 := &trillian.Tree{TreeId: 1}
+	tree2 := &trillian.Tree{TreeId: 2}
+	tree2Frozen := &trillian.Tree{TreeId: 2, TreeState: trillian.TreeState_FROZEN}
+	tree3 := &trillian.Tree{TreeId: 3}
+
+	known := make(map[int64]*trillian.Tree)
+	for _, step := range []struct {
+		trees []*trillian.Tree
+		want  []*trillian.TreeEvent
+	}{
+		{
+			trees: []*trillian.Tree{tree2, tree1},
+			want: []*trillian.TreeEvent{
+				{Type: trillian.TreeEvent_CREATED, Tree: tree1},
+				{Type: trillian.TreeEvent_CREATED, Tree: tree2},
+			},
+		},
+		{
+			trees: []*trillian.Tree{tree1, tree2},
+		},
+		{
+			trees: []*trillian.Tree{tree3, tree2Frozen},
+			want: []*trillian.TreeEvent{
+				{Type: trillian.TreeEvent_DELETED, Tree: tree1},
+				{Type: trillian.TreeEvent_UPDATED, Tree: tree2Frozen},
+				{Type: trillian.TreeEvent_CREATED, Tree: tree3},
+			},
+		},
+	} {
+		got := treeEvents(known, step.trees)
+		if len(got) != len(step.want) {
+			t.Fatalf("treeEvents(%v) = %v, want %v", step.trees, got, step.want)
+		}
+		for i := range got {
+			if !proto.Equal(got[i], step.want[i]) {
+				t.Errorf("treeEvents(%v)[%d] = %v, want %v", step.trees, i, got[i], step.want[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/trees"
+	"github.com/google/trillian/types"
+	"github.com/transparency-dev/merkle"
+	"github.com/transparency-dev/merkle/compact"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// DefaultAuditSessionTTL is the default time after which unused audit
+	// sessions expire.
+	DefaultAuditSessionTTL = time.Hour
+	// DefaultMaxAuditSessions is the default maximum number of audit sessions
+	// held by the server.
+	DefaultMaxAuditSessions = 1000
+)
+
+// auditSession pins a tree size of a log for the reads which pass its ID.
+type auditSession struct {
+	logID    int64
+	treeSize int64
+	// slr is the root of the tree at treeSize.
+	slr     *trillian.SignedLogRoot
+	expires time.Time
+}
+
+// SetAuditSessionLimits sets the time after which unused audit sessions
+// expire, and the maximum number of sessions. BeginAuditSession fails with
+// ResourceExhausted while that many sessions are held.
+func (t *TrillianLogRPCServer) SetAuditSessionLimits(ttl time.Duration, maxSessions int) {
+	t.sessionsMu.Lock()
+	defer t.sessionsMu.Unlock()
+	t.sessionTTL = ttl
+	t.maxSessions = maxSessions
+}
+
+// BeginAuditSession pins a tree size of a log for the reads which pass the
+// returned session ID.
+func (t *TrillianLogRPCServer) BeginAuditSession(ctx context.Context, req *trillian.BeginAuditSessionRequest) (*trillian.BeginAuditSessionResponse, error) {
+	ctx, spanEnd := spanFor(ctx, "BeginAuditSession")
+	defer spanEnd()
+	if req.TreeSize < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "BeginAuditSessionRequest.TreeSize: %v, want >= 0", req.TreeSize)
+	}
+
+	tree, hasher, err := t.getTreeAndHasher(ctx, req.LogId, optsLogRead)
+	if err != nil {
+		return nil, err
+	}
+	ctx = trees.NewContext(ctx, tree)
+	tx, err := t.snapshotForTree(ctx, tree, "BeginAuditSession")
+	if err != nil {
+		return nil, err
+	}
+	defer t.closeAndLog(ctx, tree.TreeId, tx, "BeginAuditSession")
+
+	slr, err := tx.LatestSignedLogRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var root types.LogRootV1
+	if err := root.UnmarshalBinary(slr.LogRoot); err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not read current log root: %v", err)
+	}
+	size := req.TreeSize
+	if size == 0 {
+		size = int64(root.TreeSize)
+	} else if uint64(size) > root.TreeSize {
+		return nil, status.Errorf(codes.FailedPrecondition, "tree size %d is larger than the size %d of log %d", size, root.TreeSize, req.LogId)
+	}
+	if uint64(size) < root.TreeSize {
+		if slr, err = rootAtSize(ctx, tx, hasher, uint64(size)); err != nil {
+			return nil, err
+		}
+	}
+	if err := t.commitAndLog(ctx, req.LogId, tx, "BeginAuditSession"); err != nil {
+		return nil, err
+	}
+
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate audit session ID: %v", err)
+	}
+	id := hex.EncodeToString(b[:])
+	now := t.timeSource.Now()
+
+	t.sessionsMu.Lock()
+	defer t.sessionsMu.Unlock()
+	for k, s := range t.sessions {
+		if now.After(s.expires) {
+			delete(t.sessions, k)
+		}
+	}
+	if len(t.sessions) >= t.maxSessions {
+		return nil, status.Errorf(codes.ResourceExhausted, "too many audit sessions, at most %d are held", t.maxSessions)
+	}
+	s := &auditSession{logID: req.LogId, treeSize: size, slr: slr, expires: now.Add(t.sessionTTL)}
+	t.sessions[id] = s
+	return &trillian.BeginAuditSessionResponse{
+		AuditSessionId: id,
+		SignedLogRoot:  slr,
+		ExpireTime:     timestamppb.New(s.expires),
+	}, nil
+}
+
+// EndAuditSession releases an audit session.
+func (t *TrillianLogRPCServer) EndAuditSession(ctx context.Context, req *trillian.EndAuditSessionRequest) (*trillian.EndAuditSessionResponse, error) {
+	_, spanEnd := spanFor(ctx, "EndAuditSession")
+	defer spanEnd()
+	if _, err := t.auditSession(req.LogId, req.AuditSessionId); err != nil {
+		return nil, err
+	}
+	t.sessionsMu.Lock()
+	defer t.sessionsMu.Unlock()
+	delete(t.sessions, req.AuditSessionId)
+	return &trillian.EndAuditSessionResponse{}, nil
+}
+
+// auditSession returns the audit session with the given ID, and extends its
+// expiry. It returns nil if id is empty, i.e. the read isn't in a session.
+func (t *TrillianLogRPCServer) auditSession(logID int64, id string) (*auditSession, error) {
+	if id == "" {
+		return nil, nil
+	}
+	now := t.timeSource.Now()
+	t.sessionsMu.Lock()
+	defer t.sessionsMu.Unlock()
+	s, ok := t.sessions[id]
+	if !ok || s.logID != logID || now.After(s.expires) {
+		return nil, status.Errorf(codes.NotFound, "audit session %q of log %d not found or expired", id, logID)
+	}
+	s.expires = now.Add(t.sessionTTL)
+	return s, nil
+}
+
+// pinTreeSize sets a requested tree size to the size of the session if it is
+// zero, and fails if it is another size. It does nothing outside sessions.
+func (s *auditSession) pinTreeSize(size *int64, field string) error {
+	if s == nil {
+		return nil
+	}
+	if *size != 0 && *size != s.treeSize {
+		return status.Errorf(codes.InvalidArgument, "%s: %d, want 0 or %d of the audit session", field, *size, s.treeSize)
+	}
+	*size = s.treeSize
+	return nil
+}
+
+// limit returns the tree size which reads are limited to, i.e. the size of
+// the session, or treeSize outside sessions.
+func (s *auditSession) limit(treeSize int64) int64 {
+	if s == nil || s.treeSize > treeSize {
+		return treeSize
+	}
+	return s.treeSize
+}
+
+// root returns the root of the session, or slr outside sessions.
+func (s *auditSession) root(slr *trillian.SignedLogRoot) *trillian.SignedLogRoot {
+	if s == nil {
+		return slr
+	}
+	return s.slr
+}
+
+// rootAtSize returns the untimestamped root of the tree at an earlier size,
+// computed from the compact range of its leaves.
+func rootAtSize(ctx context.Context, tx storage.ReadOnlyLogTreeTX, hasher merkle.LogHasher, size uint64) (*trillian.SignedLogRoot, error) {
+	root := types.LogRootV1{TreeSize: size, RootHash: hasher.EmptyRoot()}
+	if size > 0 {
+		nodes, err := fetchNodes(ctx, tx, compact.RangeNodes(0, size, nil))
+		if err != nil {
+			return nil, err
+		}
+		hashes := make([][]byte, 0, len(nodes))
+		for _, node := range nodes {
+			hashes = append(hashes, node.Hash)
+		}
+		rf := compact.RangeFactory{Hash: hasher.HashChildren}
+		cr, err := rf.NewRange(0, size, hashes)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to build compact range: %v", err)
+		}
+		if root.RootHash, err = cr.GetRootHash(nil); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to compute root hash: %v", err)
+		}
+	}
+	logRoot, err := root.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return &trillian.SignedLogRoot{LogRoot: logRoot}, nil
+}
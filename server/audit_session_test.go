@@ -0,0 +1,205 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/extension"
+	"github.com/google/trillian/log"
+	"github.com/google/trillian/quota"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/storage/memory"
+	stestonly "github.com/google/trillian/storage/testonly"
+	"github.com/google/trillian/types"
+	"github.com/google/trillian/util/clock"
+	"github.com/transparency-dev/merkle/compact"
+	"github.com/transparency-dev/merkle/proof"
+	"github.com/transparency-dev/merkle/rfc6962"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuditSession(t *testing.T) {
+	log.InitMetrics(nil)
+	ctx := context.Background()
+	ts := memory.NewTreeStorage()
+	registry := extension.Registry{
+		AdminStorage: memory.NewAdminStorage(ts),
+		LogStorage:   memory.NewLogStorage(ts, nil),
+	}
+	tree, err := storage.CreateTree(ctx, registry.AdminStorage, stestonly.LogTree)
+	if err != nil {
+		t.Fatalf("CreateTree(): %v", err)
+	}
+	fakeClock := clock.NewFake(time.Unix(1500000000, 0))
+	server := NewTrillianLogRPCServer(registry, fakeClock)
+	if _, err := server.InitLog(ctx, &trillian.InitLogRequest{LogId: tree.TreeId}); err != nil {
+		t.Fatalf("InitLog(): %v", err)
+	}
+	hasher := rfc6962.DefaultHasher
+	rf := compact.RangeFactory{Hash: hasher.HashChildren}
+	cr := rf.NewEmptyRange(0)
+	// addLeaves queues and integrates n leaves, and returns the root hashes of
+	// the tree after each of them.
+	var roots [][]byte
+	addLeaves := func(n int) {
+		t.Helper()
+		for i := 0; i < n; i++ {
+			value := []byte(fmt.Sprintf("leaf%d", len(roots)))
+			req := &trillian.QueueLeafRequest{LogId: tree.TreeId, Leaf: &trillian.LogLeaf{LeafValue: value}}
+			if _, err := server.QueueLeaf(ctx, req); err != nil {
+				t.Fatalf("QueueLeaf(): %v", err)
+			}
+			if err := cr.Append(hasher.HashLeaf(value), nil); err != nil {
+				t.Fatalf("Append(): %v", err)
+			}
+			root, err := cr.GetRootHash(nil)
+			if err != nil {
+				t.Fatalf("GetRootHash(): %v", err)
+			}
+			roots = append(roots, root)
+		}
+		if _, err := log.IntegrateBatch(ctx, tree, n, 0, 0, clock.System, registry.LogStorage, quota.Noop()); err != nil {
+			t.Fatalf("IntegrateBatch(): %v", err)
+		}
+	}
+	rootOf := func(slr *trillian.SignedLogRoot) *types.LogRootV1 {
+		t.Helper()
+		var root types.LogRootV1
+		if err := root.UnmarshalBinary(slr.GetLogRoot()); err != nil {
+			t.Fatalf("UnmarshalBinary(): %v", err)
+		}
+		return &root
+	}
+	addLeaves(4)
+
+	// A session at an earlier size gets the root of that size.
+	begin, err := server.BeginAuditSession(ctx, &trillian.BeginAuditSessionRequest{LogId: tree.TreeId, TreeSize: 3})
+	if err != nil {
+		t.Fatalf("BeginAuditSession(3): %v", err)
+	}
+	if got := rootOf(begin.SignedLogRoot); got.TreeSize != 3 || !bytes.Equal(got.RootHash, roots[2]) {
+		t.Errorf("BeginAuditSession(3) root: size %d hash %x, want 3 %x", got.TreeSize, got.RootHash, roots[2])
+	}
+
+	// Reads in a session of the current size ignore later growth.
+	if begin, err = server.BeginAuditSession(ctx, &trillian.BeginAuditSessionRequest{LogId: tree.TreeId}); err != nil {
+		t.Fatalf("BeginAuditSession(): %v", err)
+	}
+	id := begin.AuditSessionId
+	if got := rootOf(begin.SignedLogRoot); got.TreeSize != 4 || !bytes.Equal(got.RootHash, roots[3]) {
+		t.Errorf("BeginAuditSession() root: size %d hash %x, want 4 %x", got.TreeSize, got.RootHash, roots[3])
+	}
+	addLeaves(2)
+
+	leaves, err := server.GetLeavesByRange(ctx, &trillian.GetLeavesByRangeRequest{LogId: tree.TreeId, StartIndex: 1, Count: 10, AuditSessionId: id})
+	if err != nil {
+		t.Fatalf("GetLeavesByRange(): %v", err)
+	}
+	if got, want := len(leaves.Leaves), 3; got != want {
+		t.Errorf("GetLeavesByRange() returned %d leaves, want %d", got, want)
+	}
+	if got := rootOf(leaves.SignedLogRoot).TreeSize; got != 4 {
+		t.Errorf("GetLeavesByRange() root size %d, want 4", got)
+	}
+	stream := &fakeLeavesStream{}
+	if err := server.StreamLeavesByRange(&trillian.GetLeavesByRangeRequest{LogId: tree.TreeId, Count: 10, AuditSessionId: id}, stream); err != nil {
+		t.Fatalf("StreamLeavesByRange(): %v", err)
+	}
+	if got, want := len(stream.resps[0].Leaves), 4; got != want {
+		t.Errorf("StreamLeavesByRange() returned %d leaves, want %d", got, want)
+	}
+
+	incl, err := server.GetInclusionProof(ctx, &trillian.GetInclusionProofRequest{LogId: tree.TreeId, LeafIndex: 1, AuditSessionId: id})
+	if err != nil {
+		t.Fatalf("GetInclusionProof(): %v", err)
+	}
+	if err := proof.VerifyInclusion(hasher, 1, 4, hasher.HashLeaf([]byte("leaf1")), incl.Proof.Hashes, roots[3]); err != nil {
+		t.Errorf("VerifyInclusion(): %v", err)
+	}
+	cons, err := server.GetConsistencyProof(ctx, &trillian.GetConsistencyProofRequest{LogId: tree.TreeId, FirstTreeSize: 2, AuditSessionId: id})
+	if err != nil {
+		t.Fatalf("GetConsistencyProof(): %v", err)
+	}
+	if err := proof.VerifyConsistency(hasher, 2, 4, cons.Proof.Hashes, roots[1], roots[3]); err != nil {
+		t.Errorf("VerifyConsistency(): %v", err)
+	}
+	entry, err := server.GetEntryAndProof(ctx, &trillian.GetEntryAndProofRequest{LogId: tree.TreeId, LeafIndex: 3, AuditSessionId: id})
+	if err != nil {
+		t.Fatalf("GetEntryAndProof(): %v", err)
+	}
+	if err := proof.VerifyInclusion(hasher, 3, 4, entry.Leaf.MerkleLeafHash, entry.Proof.Hashes, roots[3]); err != nil {
+		t.Errorf("VerifyInclusion() of entry: %v", err)
+	}
+	byHash, err := server.GetInclusionProofByHash(ctx, &trillian.GetInclusionProofByHashRequest{LogId: tree.TreeId, LeafHash: hasher.HashLeaf([]byte("leaf5")), AuditSessionId: id})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("GetInclusionProofByHash() of leaf after the session: %v, %v, want code %v", byHash, err, codes.NotFound)
+	}
+
+	for _, tc := range []struct {
+		desc string
+		req  *trillian.GetInclusionProofRequest
+		want codes.Code
+	}{
+		{desc: "otherSize", req: &trillian.GetInclusionProofRequest{LogId: tree.TreeId, LeafIndex: 1, TreeSize: 5, AuditSessionId: id}, want: codes.InvalidArgument},
+		{desc: "otherLog", req: &trillian.GetInclusionProofRequest{LogId: tree.TreeId + 1, LeafIndex: 1, AuditSessionId: id}, want: codes.NotFound},
+		{desc: "unknown", req: &trillian.GetInclusionProofRequest{LogId: tree.TreeId, LeafIndex: 1, AuditSessionId: "unknown"}, want: codes.NotFound},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			if _, err := server.GetInclusionProof(ctx, tc.req); status.Code(err) != tc.want {
+				t.Errorf("GetInclusionProof(): %v, want code %v", err, tc.want)
+			}
+		})
+	}
+
+	if _, err := server.BeginAuditSession(ctx, &trillian.BeginAuditSessionRequest{LogId: tree.TreeId, TreeSize: 7}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("BeginAuditSession() beyond the log: %v, want code %v", err, codes.FailedPrecondition)
+	}
+	if _, err := server.EndAuditSession(ctx, &trillian.EndAuditSessionRequest{LogId: tree.TreeId, AuditSessionId: id}); err != nil {
+		t.Fatalf("EndAuditSession(): %v", err)
+	}
+	req := &trillian.GetLeavesByRangeRequest{LogId: tree.TreeId, Count: 1, AuditSessionId: id}
+	if _, err := server.GetLeavesByRange(ctx, req); status.Code(err) != codes.NotFound {
+		t.Errorf("GetLeavesByRange() after EndAuditSession(): %v, want code %v", err, codes.NotFound)
+	}
+
+	// Sessions expire unless they are used.
+	server.SetAuditSessionLimits(time.Minute, 2)
+	if begin, err = server.BeginAuditSession(ctx, &trillian.BeginAuditSessionRequest{LogId: tree.TreeId}); err != nil {
+		t.Fatalf("BeginAuditSession(): %v", err)
+	}
+	if _, err := server.BeginAuditSession(ctx, &trillian.BeginAuditSessionRequest{LogId: tree.TreeId}); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("BeginAuditSession() over the limit: %v, want code %v", err, codes.ResourceExhausted)
+	}
+	req.AuditSessionId = begin.AuditSessionId
+	fakeClock.Set(fakeClock.Now().Add(50 * time.Second))
+	if _, err := server.GetLeavesByRange(ctx, req); err != nil {
+		t.Errorf("GetLeavesByRange() before expiry: %v", err)
+	}
+	fakeClock.Set(fakeClock.Now().Add(50 * time.Second))
+	if _, err := server.GetLeavesByRange(ctx, req); err != nil {
+		t.Errorf("GetLeavesByRange() within the extended expiry: %v", err)
+	}
+	fakeClock.Set(fakeClock.Now().Add(2 * time.Minute))
+	if _, err := server.GetLeavesByRange(ctx, req); status.Code(err) != codes.NotFound {
+		t.Errorf("GetLeavesByRange() after expiry: %v, want code %v", err, codes.NotFound)
+	}
+}
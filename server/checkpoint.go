@@ -0,0 +1,48 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"strconv"
+
+	"github.com/google/trillian/types"
+	"golang.org/x/mod/sumdb/note"
+)
+
+// EnableCheckpoints makes GetLatestSignedLogRoot also return the root as a
+// checkpoint signed by signer. The origin of the checkpoint of a tree is
+// originPrefix followed by the tree ID, e.g. "example.com/trillian/1234".
+func (t *TrillianLogRPCServer) EnableCheckpoints(signer note.Signer, originPrefix string) {
+	t.checkpointSigner = signer
+	t.checkpointOrigin = originPrefix
+}
+
+// checkpoint returns the signed checkpoint of the tree at root.
+func (t *TrillianLogRPCServer) checkpoint(treeID int64, root *types.LogRootV1) ([]byte, error) {
+	body, err := t.checkpointText(treeID, root.TreeSize, root.RootHash)
+	if err != nil {
+		return nil, err
+	}
+	return note.Sign(&note.Note{Text: string(body)}, t.checkpointSigner)
+}
+
+// checkpointText returns the unsigned checkpoint of the tree at a size.
+func (t *TrillianLogRPCServer) checkpointText(treeID int64, size uint64, hash []byte) ([]byte, error) {
+	return types.Checkpoint{
+		Origin: t.checkpointOrigin + strconv.FormatInt(treeID, 10),
+		Size:   size,
+		Hash:   hash,
+	}.MarshalText()
+}
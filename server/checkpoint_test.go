@@ -0,0 +1,88 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/client"
+	"github.com/google/trillian/extension"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/storage/memory"
+	stestonly "github.com/google/trillian/storage/testonly"
+	"github.com/google/trillian/types"
+	"github.com/google/trillian/util/clock"
+	"golang.org/x/mod/sumdb/note"
+)
+
+func TestGetLatestSignedLogRootCheckpoint(t *testing.T) {
+	ctx := context.Background()
+	ts := memory.NewTreeStorage()
+	registry := extension.Registry{
+		AdminStorage: memory.NewAdminStorage(ts),
+		LogStorage:   memory.NewLogStorage(ts, nil),
+	}
+	tree, err := storage.CreateTree(ctx, registry.AdminStorage, stestonly.LogTree)
+	if err != nil {
+		t.Fatalf("CreateTree(): %v", err)
+	}
+
+	server := NewTrillianLogRPCServer(registry, clock.NewFake(time.Unix(1500000000, 0)))
+	if _, err := server.InitLog(ctx, &trillian.InitLogRequest{LogId: tree.TreeId}); err != nil {
+		t.Fatalf("InitLog(): %v", err)
+	}
+	req := &trillian.GetLatestSignedLogRootRequest{LogId: tree.TreeId}
+	resp, err := server.GetLatestSignedLogRoot(ctx, req)
+	if err != nil {
+		t.Fatalf("GetLatestSignedLogRoot(): %v", err)
+	}
+	if resp.Checkpoint != nil {
+		t.Errorf("GetLatestSignedLogRoot() without signer returned checkpoint %q", resp.Checkpoint)
+	}
+
+	skey, vkey, err := note.GenerateKey(rand.Reader, "log")
+	if err != nil {
+		t.Fatalf("GenerateKey(): %v", err)
+	}
+	signer, err := note.NewSigner(skey)
+	if err != nil {
+		t.Fatalf("NewSigner(): %v", err)
+	}
+	verifier, err := note.NewVerifier(vkey)
+	if err != nil {
+		t.Fatalf("NewVerifier(): %v", err)
+	}
+	server.EnableCheckpoints(signer, "example.com/log/")
+	if resp, err = server.GetLatestSignedLogRoot(ctx, req); err != nil {
+		t.Fatalf("GetLatestSignedLogRoot(): %v", err)
+	}
+	var root types.LogRootV1
+	if err := root.UnmarshalBinary(resp.SignedLogRoot.LogRoot); err != nil {
+		t.Fatalf("UnmarshalBinary(): %v", err)
+	}
+	cp, err := client.VerifyCheckpoint(resp.Checkpoint, fmt.Sprintf("example.com/log/%d", tree.TreeId), verifier)
+	if err != nil {
+		t.Fatalf("VerifyCheckpoint(): %v", err)
+	}
+	if cp.Size != root.TreeSize || !bytes.Equal(cp.Hash, root.RootHash) {
+		t.Errorf("checkpoint size %d hash %x, want %d %x", cp.Size, cp.Hash, root.TreeSize, root.RootHash)
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian"
+	"github.com/google/trillian/log"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/util/clock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// consistencyRetryInterval is the longest time between the consistency checks
+// of RunConsistencyChecks while some logs couldn't be checked.
+const consistencyRetryInterval = time.Minute
+
+// CheckTreesConsistency verifies that the stored state of every log is
+// internally consistent, see log.CheckTreeConsistency. Logs which fail the
+// check are refused by the server until a later check passes, so that
+// clients can't get proofs from a broken tree, e.g. after a bad restore.
+// Logs whose check can't complete, e.g. because storage is unavailable, keep
+// the state of their previous check.
+//
+// Returns the number of logs which failed the check, and of those whose check
+// couldn't complete. An error is returned only if the logs can't be listed.
+func (t *TrillianLogRPCServer) CheckTreesConsistency(ctx context.Context) (int, int, error) {
+	trees, err := storage.ListTrees(ctx, t.registry.AdminStorage, false /* includeDeleted */)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	failed, unchecked := 0, 0
+	for _, tree := range trees {
+		if tree.TreeType != trillian.TreeType_LOG && tree.TreeType != trillian.TreeType_PREORDERED_LOG {
+			continue
+		}
+		label := strconv.FormatInt(tree.TreeId, 10)
+		err := log.CheckTreeConsistency(ctx, tree, t.registry.LogStorage)
+
+		t.mu.Lock()
+		switch {
+		case err == nil:
+			delete(t.inconsistent, tree.TreeId)
+			delete(t.unchecked, tree.TreeId)
+		case errors.Is(err, log.ErrInconsistent):
+			t.inconsistent[tree.TreeId] = err
+			delete(t.unchecked, tree.TreeId)
+		default:
+			// Logs which have never been checked stay refused.
+			if _, ok := t.unchecked[tree.TreeId]; ok || t.awaitingCheck {
+				t.unchecked[tree.TreeId] = err
+			}
+		}
+		t.mu.Unlock()
+
+		switch {
+		case err == nil:
+			t.consistencyFailed.Set(0, label)
+		case errors.Is(err, log.ErrInconsistent):
+			glog.Errorf("%v: refusing to serve log which failed the consistency check: %v", tree.TreeId, err)
+			t.consistencyFailed.Set(1, label)
+			failed++
+		default:
+			glog.Warningf("%v: failed to check consistency, will retry: %v", tree.TreeId, err)
+			unchecked++
+		}
+	}
+
+	t.mu.Lock()
+	t.awaitingCheck = false
+	t.mu.Unlock()
+	glog.Infof("Checked consistency of %d trees, %d failed, %d couldn't be checked", len(trees), failed, unchecked)
+	return failed, unchecked, nil
+}
+
+// StartConsistencyChecks runs RunConsistencyChecks in the background. No log
+// is served until all of them have been checked once, and logs whose check
+// couldn't complete aren't served until it does.
+func (t *TrillianLogRPCServer) StartConsistencyChecks(ctx context.Context, interval time.Duration) {
+	t.mu.Lock()
+	t.awaitingCheck = true
+	t.mu.Unlock()
+	go t.RunConsistencyChecks(ctx, interval)
+}
+
+// RunConsistencyChecks runs CheckTreesConsistency until ctx is done, waiting
+// interval between the checks. Repeating the check serves logs again once
+// they have been repaired, and checks the logs created since the previous
+// one. A zero interval runs a single check. Checks which couldn't complete
+// for some logs are retried sooner, until they do.
+func (t *TrillianLogRPCServer) RunConsistencyChecks(ctx context.Context, interval time.Duration) {
+	for {
+		wait := interval
+		if _, unchecked, err := t.CheckTreesConsistency(ctx); err != nil || unchecked > 0 {
+			if err != nil {
+				glog.Errorf("Failed to check consistency of trees: %v", err)
+			}
+			if wait <= 0 || wait > consistencyRetryInterval {
+				wait = consistencyRetryInterval
+			}
+		}
+		if wait <= 0 {
+			return
+		}
+		if err := clock.SleepSource(ctx, wait, t.timeSource); err != nil {
+			return
+		}
+	}
+}
+
+// checkServable returns an error if the tree failed the consistency check, or
+// hasn't been checked yet.
+func (t *TrillianLogRPCServer) checkServable(treeID int64) error {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if t.awaitingCheck {
+		return status.Errorf(codes.Unavailable, "log %d hasn't been checked for consistency yet", treeID)
+	}
+	if err, ok := t.unchecked[treeID]; ok {
+		return status.Errorf(codes.Unavailable, "log %d couldn't be checked for consistency yet: %v", treeID, err)
+	}
+	if err, ok := t.inconsistent[treeID]; ok {
+		return status.Errorf(codes.FailedPrecondition, "log %d failed the consistency check: %v", treeID, err)
+	}
+	return nil
+}
@@ -0,0 +1,271 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/extension"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/storage/memory"
+	stestonly "github.com/google/trillian/storage/testonly"
+	"github.com/google/trillian/types"
+	"github.com/google/trillian/util/clock"
+	"github.com/transparency-dev/merkle/rfc6962"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestCheckTreesConsistency(t *testing.T) {
+	ctx := context.Background()
+	ts := memory.NewTreeStorage()
+	registry := extension.Registry{
+		AdminStorage: memory.NewAdminStorage(ts),
+		LogStorage:   memory.NewLogStorage(ts, nil),
+	}
+
+	// The good log is empty, the bad one claims a size without any nodes.
+	var logs []*trillian.Tree
+	for _, root := range []*types.LogRootV1{
+		{RootHash: rfc6962.DefaultHasher.EmptyRoot(), TimestampNanos: 1},
+		{RootHash: rfc6962.DefaultHasher.EmptyRoot(), TimestampNanos: 1, TreeSize: 10},
+	} {
+		tree, err := storage.CreateTree(ctx, registry.AdminStorage, proto.Clone(stestonly.LogTree).(*trillian.Tree))
+		if err != nil {
+			t.Fatalf("CreateTree(): %v", err)
+		}
+		logRoot, err := root.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary(): %v", err)
+		}
+		if err := registry.LogStorage.ReadWriteTransaction(ctx, tree, func(ctx context.Context, tx storage.LogTreeTX) error {
+			return tx.StoreSignedLogRoot(ctx, &trillian.SignedLogRoot{LogRoot: logRoot})
+		}); err != nil {
+			t.Fatalf("StoreSignedLogRoot(): %v", err)
+		}
+		logs = append(logs, tree)
+	}
+	good, bad := logs[0], logs[1]
+
+	server := NewTrillianLogRPCServer(registry, clock.System)
+	failed, _, err := server.CheckTreesConsistency(ctx)
+	if err != nil {
+		t.Fatalf("CheckTreesConsistency(): %v", err)
+	}
+	if got, want := failed, 1; got != want {
+		t.Errorf("CheckTreesConsistency()=%d, want %d", got, want)
+	}
+
+	if _, err := server.GetLatestSignedLogRoot(ctx, &trillian.GetLatestSignedLogRootRequest{LogId: good.TreeId}); err != nil {
+		t.Errorf("GetLatestSignedLogRoot(good): %v", err)
+	}
+	_, err = server.GetLatestSignedLogRoot(ctx, &trillian.GetLatestSignedLogRootRequest{LogId: bad.TreeId})
+	if got, want := status.Code(err), codes.FailedPrecondition; got != want {
+		t.Errorf("GetLatestSignedLogRoot(bad) returned %v, want code %v", err, want)
+	}
+}
+
+// flakyLogStorage is a LogStorage whose snapshots can't be opened while fail
+// is set to 1.
+type flakyLogStorage struct {
+	storage.LogStorage
+	fail int32
+}
+
+func (s *flakyLogStorage) setFail(fail bool) {
+	var v int32
+	if fail {
+		v = 1
+	}
+	atomic.StoreInt32(&s.fail, v)
+}
+
+func (s *flakyLogStorage) SnapshotForTree(ctx context.Context, tree *trillian.Tree) (storage.ReadOnlyLogTreeTX, error) {
+	if atomic.LoadInt32(&s.fail) == 1 {
+		return nil, errors.New("connection reset")
+	}
+	return s.LogStorage.SnapshotForTree(ctx, tree)
+}
+
+func TestCheckTreesConsistencyStorageErrors(t *testing.T) {
+	ctx := context.Background()
+	ts := memory.NewTreeStorage()
+	ls := &flakyLogStorage{LogStorage: memory.NewLogStorage(ts, nil)}
+	registry := extension.Registry{
+		AdminStorage: memory.NewAdminStorage(ts),
+		LogStorage:   ls,
+	}
+	tree, err := storage.CreateTree(ctx, registry.AdminStorage, proto.Clone(stestonly.LogTree).(*trillian.Tree))
+	if err != nil {
+		t.Fatalf("CreateTree(): %v", err)
+	}
+	storeRoot := func(root *types.LogRootV1) {
+		t.Helper()
+		logRoot, err := root.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary(): %v", err)
+		}
+		if err := ls.ReadWriteTransaction(ctx, tree, func(ctx context.Context, tx storage.LogTreeTX) error {
+			return tx.StoreSignedLogRoot(ctx, &trillian.SignedLogRoot{LogRoot: logRoot})
+		}); err != nil {
+			t.Fatalf("StoreSignedLogRoot(): %v", err)
+		}
+	}
+	storeRoot(&types.LogRootV1{RootHash: rfc6962.DefaultHasher.EmptyRoot(), TimestampNanos: 1})
+
+	server := NewTrillianLogRPCServer(registry, clock.System)
+	// As set by StartConsistencyChecks.
+	server.awaitingCheck = true
+	for _, step := range []struct {
+		desc          string
+		fail          bool
+		corrupt       bool
+		wantUnchecked int
+		want          codes.Code
+	}{
+		{desc: "before-check", want: codes.Unavailable},
+		{desc: "first-check-fails", fail: true, wantUnchecked: 1, want: codes.Unavailable},
+		{desc: "first-check-passes", want: codes.OK},
+		{desc: "check-fails-after-pass", fail: true, wantUnchecked: 1, want: codes.OK},
+		{desc: "inconsistent", corrupt: true, want: codes.FailedPrecondition},
+		{desc: "check-fails-after-inconsistent", fail: true, wantUnchecked: 1, want: codes.FailedPrecondition},
+	} {
+		if step.desc != "before-check" {
+			if step.corrupt {
+				storeRoot(&types.LogRootV1{RootHash: rfc6962.DefaultHasher.EmptyRoot(), TimestampNanos: 2, TreeSize: 10})
+			}
+			ls.setFail(step.fail)
+			_, unchecked, err := server.CheckTreesConsistency(ctx)
+			if err != nil {
+				t.Fatalf("%s: CheckTreesConsistency(): %v", step.desc, err)
+			}
+			if unchecked != step.wantUnchecked {
+				t.Errorf("%s: CheckTreesConsistency() couldn't check %d logs, want %d", step.desc, unchecked, step.wantUnchecked)
+			}
+		}
+		if err := server.checkServable(tree.TreeId); status.Code(err) != step.want {
+			t.Errorf("%s: checkServable(): %v, want code %v", step.desc, err, step.want)
+		}
+	}
+}
+
+func TestRunConsistencyChecks(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts := memory.NewTreeStorage()
+	registry := extension.Registry{
+		AdminStorage: memory.NewAdminStorage(ts),
+		LogStorage:   memory.NewLogStorage(ts, nil),
+	}
+	tree, err := storage.CreateTree(ctx, registry.AdminStorage, proto.Clone(stestonly.LogTree).(*trillian.Tree))
+	if err != nil {
+		t.Fatalf("CreateTree(): %v", err)
+	}
+	storeRoot := func(root *types.LogRootV1) {
+		t.Helper()
+		logRoot, err := root.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary(): %v", err)
+		}
+		if err := registry.LogStorage.ReadWriteTransaction(ctx, tree, func(ctx context.Context, tx storage.LogTreeTX) error {
+			return tx.StoreSignedLogRoot(ctx, &trillian.SignedLogRoot{LogRoot: logRoot})
+		}); err != nil {
+			t.Fatalf("StoreSignedLogRoot(): %v", err)
+		}
+	}
+	// The root claims a size without any nodes.
+	storeRoot(&types.LogRootV1{RootHash: rfc6962.DefaultHasher.EmptyRoot(), TimestampNanos: 1, TreeSize: 10})
+
+	fakeTime := clock.NewFake(time.Unix(1500000000, 0))
+	server := NewTrillianLogRPCServer(registry, fakeTime)
+	go server.RunConsistencyChecks(ctx, time.Minute)
+
+	// waitCode waits for checkServable to return the given code, moving the
+	// clock forward to trigger the next check.
+	waitCode := func(want codes.Code) {
+		t.Helper()
+		for deadline := time.Now().Add(10 * time.Second); ; {
+			err := server.checkServable(tree.TreeId)
+			if got := status.Code(err); got == want {
+				return
+			} else if time.Now().After(deadline) {
+				t.Fatalf("checkServable() returned %v, want code %v", err, want)
+			}
+			fakeTime.Set(fakeTime.Now().Add(time.Minute))
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	waitCode(codes.FailedPrecondition)
+
+	// Once repaired, the log is served again after the next check.
+	storeRoot(&types.LogRootV1{RootHash: rfc6962.DefaultHasher.EmptyRoot(), TimestampNanos: 2})
+	waitCode(codes.OK)
+}
+
+func TestStartConsistencyChecksRetry(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ts := memory.NewTreeStorage()
+	ls := &flakyLogStorage{LogStorage: memory.NewLogStorage(ts, nil), fail: 1}
+	registry := extension.Registry{
+		AdminStorage: memory.NewAdminStorage(ts),
+		LogStorage:   ls,
+	}
+	tree, err := storage.CreateTree(ctx, registry.AdminStorage, proto.Clone(stestonly.LogTree).(*trillian.Tree))
+	if err != nil {
+		t.Fatalf("CreateTree(): %v", err)
+	}
+
+	fakeTime := clock.NewFake(time.Unix(1500000000, 0))
+	server := NewTrillianLogRPCServer(registry, fakeTime)
+	server.StartConsistencyChecks(ctx, time.Hour)
+	if err := server.checkServable(tree.TreeId); status.Code(err) != codes.Unavailable {
+		t.Errorf("checkServable() before the first check: %v, want code %v", err, codes.Unavailable)
+	}
+
+	// Wait for the first check, which fails.
+	for deadline := time.Now().Add(10 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+		server.mu.RLock()
+		_, unchecked := server.unchecked[tree.TreeId]
+		server.mu.RUnlock()
+		if unchecked {
+			break
+		} else if time.Now().After(deadline) {
+			t.Fatal("the first check didn't complete")
+		}
+	}
+	if err := server.checkServable(tree.TreeId); status.Code(err) != codes.Unavailable {
+		t.Errorf("checkServable() after a failed check: %v, want code %v", err, codes.Unavailable)
+	}
+
+	// The check is retried well before the interval.
+	ls.setFail(false)
+	start := fakeTime.Now()
+	for deadline := time.Now().Add(10 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+		err := server.checkServable(tree.TreeId)
+		if err == nil {
+			break
+		} else if time.Now().After(deadline) || fakeTime.Now().Sub(start) >= time.Hour {
+			t.Fatalf("checkServable() returned %v after %v, want OK after a retry", err, fakeTime.Now().Sub(start))
+		}
+		fakeTime.Set(fakeTime.Now().Add(consistencyRetryInterval))
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/trees"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// DefaultMaxPendingMemoryAcks is the default maximum number of leaves
+	// which were acknowledged with ACK_AFTER_MEMORY, but aren't written to
+	// storage yet.
+	DefaultMaxPendingMemoryAcks = 10000
+	// DefaultMemoryAckWriteTimeout is the default timeout of the storage
+	// writes of leaves acknowledged with ACK_AFTER_MEMORY.
+	DefaultMemoryAckWriteTimeout = time.Minute
+)
+
+// SetMaxPendingMemoryAcks sets the maximum number of leaves which were
+// acknowledged with ACK_AFTER_MEMORY, but aren't written to storage yet.
+// Further such requests fail with ResourceExhausted until the writes catch up.
+func (t *TrillianLogRPCServer) SetMaxPendingMemoryAcks(n int) {
+	t.maxPendingMemoryAcks = int64(n)
+}
+
+// SetMemoryAckWriteTimeout sets the timeout of the storage writes of leaves
+// acknowledged with ACK_AFTER_MEMORY, after which they fail and are lost.
+func (t *TrillianLogRPCServer) SetMemoryAckWriteTimeout(d time.Duration) {
+	t.memoryAckTimeout = d
+}
+
+// WaitForMemoryAcks waits until the leaves acknowledged with ACK_AFTER_MEMORY
+// are written to storage, or their writes time out. It's called on shutdown,
+// once the server no longer accepts requests, so that the leaves aren't lost
+// when the process exits.
+func (t *TrillianLogRPCServer) WaitForMemoryAcks() {
+	t.memoryAckWrites.Wait()
+}
+
+// checkDurability returns an error if leaves of tree can't be queued with
+// durability d.
+func (t *TrillianLogRPCServer) checkDurability(tree *trillian.Tree, d trillian.QueueDurability) error {
+	switch d {
+	case trillian.QueueDurability_ACK_AFTER_COMMIT:
+		return nil
+	case trillian.QueueDurability_ACK_AFTER_MEMORY:
+		if tree.MaxMergeDelay != nil {
+			return status.Errorf(codes.FailedPrecondition, "tree %d has a max merge delay, so its leaves can't be acknowledged before they are stored", tree.TreeId)
+		}
+		return nil
+	case trillian.QueueDurability_ACK_AFTER_REPLICATION:
+		_, err := storage.AsReplicatedQueuer(t.registry.LogStorage)
+		return err
+	}
+	return status.Errorf(codes.InvalidArgument, "unknown durability %v", d)
+}
+
+// queueLeaves queues leaves of tree, and returns once they are as durable as
+// d requires. The durability must have passed checkDurability. Fails if the
+// tree reached a hard storage cap.
+func (t *TrillianLogRPCServer) queueLeaves(ctx context.Context, tree *trillian.Tree, leaves []*trillian.LogLeaf, now time.Time, d trillian.QueueDurability) ([]*trillian.QueuedLogLeaf, error) {
+	if err := t.checkStorageCaps(ctx, tree, len(leaves)); err != nil {
+		return nil, err
+	}
+	switch d {
+	case trillian.QueueDurability_ACK_AFTER_MEMORY:
+		return t.queueLeavesAsync(tree, leaves, now)
+	case trillian.QueueDurability_ACK_AFTER_REPLICATION:
+		rq, err := storage.AsReplicatedQueuer(t.registry.LogStorage)
+		if err != nil {
+			return nil, err
+		}
+		return rq.QueueLeavesReplicated(trees.NewContext(ctx, tree), tree, leaves, now)
+	}
+	return t.registry.LogStorage.QueueLeaves(trees.NewContext(ctx, tree), tree, leaves, now)
+}
+
+// queueLeavesAsync writes leaves to storage in the background, and reports
+// them as queued right away. Failed writes are logged and counted, as there
+// is nobody to return them to.
+//
+// For the same reason, duplicates of leaves already in the log aren't
+// reported with an AlreadyExists status: the response is sent before the
+// storage tells them apart, so every leaf is reported as newly queued. The
+// storage still deduplicates them as it does for other requests.
+func (t *TrillianLogRPCServer) queueLeavesAsync(tree *trillian.Tree, leaves []*trillian.LogLeaf, now time.Time) ([]*trillian.QueuedLogLeaf, error) {
+	n := int64(len(leaves))
+	if atomic.AddInt64(&t.pendingMemoryAcks, n) > t.maxPendingMemoryAcks {
+		atomic.AddInt64(&t.pendingMemoryAcks, -n)
+		return nil, status.Errorf(codes.ResourceExhausted, "too many leaves acknowledged with ACK_AFTER_MEMORY are waiting to be stored")
+	}
+	ret := make([]*trillian.QueuedLogLeaf, len(leaves))
+	for i, leaf := range leaves {
+		// The storage may modify the leaves it writes.
+		ret[i] = &trillian.QueuedLogLeaf{Leaf: proto.Clone(leaf).(*trillian.LogLeaf)}
+	}
+
+	t.memoryAckWrites.Add(1)
+	go func() {
+		defer t.memoryAckWrites.Done()
+		defer atomic.AddInt64(&t.pendingMemoryAcks, -n)
+		// The write outlives the request, so it can't use its context.
+		ctx, cancel := context.WithTimeout(trees.NewContext(context.Background(), tree), t.memoryAckTimeout)
+		defer cancel()
+		if _, err := t.registry.LogStorage.QueueLeaves(ctx, tree, leaves, now); err != nil {
+			glog.Errorf("%d: failed to store %d leaves acknowledged with ACK_AFTER_MEMORY: %v", tree.TreeId, len(leaves), err)
+			t.memoryAckFailures.Add(float64(n), strconv.FormatInt(tree.TreeId, 10))
+		}
+	}()
+	return ret, nil
+}
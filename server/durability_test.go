@@ -0,0 +1,246 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/extension"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/storage/memory"
+	stestonly "github.com/google/trillian/storage/testonly"
+	"github.com/google/trillian/util/clock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// replicatedStorage counts the leaves queued with QueueLeavesReplicated.
+type replicatedStorage struct {
+	storage.LogStorage
+	replicated int
+}
+
+func (s *replicatedStorage) QueueLeavesReplicated(ctx context.Context, tree *trillian.Tree, leaves []*trillian.LogLeaf, queueTimestamp time.Time) ([]*trillian.QueuedLogLeaf, error) {
+	s.replicated += len(leaves)
+	return s.LogStorage.QueueLeaves(ctx, tree, leaves, queueTimestamp)
+}
+
+// dedupingStorage reports leaves with an identity hash it queued before as
+// duplicates, and counts them.
+type dedupingStorage struct {
+	storage.LogStorage
+	queued     map[string]bool
+	duplicates int
+}
+
+func (s *dedupingStorage) QueueLeaves(ctx context.Context, tree *trillian.Tree, leaves []*trillian.LogLeaf, queueTimestamp time.Time) ([]*trillian.QueuedLogLeaf, error) {
+	ret := make([]*trillian.QueuedLogLeaf, 0, len(leaves))
+	for _, leaf := range leaves {
+		if s.queued[string(leaf.LeafIdentityHash)] {
+			s.duplicates++
+			ret = append(ret, &trillian.QueuedLogLeaf{Leaf: leaf, Status: status.New(codes.AlreadyExists, "duplicate").Proto()})
+			continue
+		}
+		s.queued[string(leaf.LeafIdentityHash)] = true
+		ret = append(ret, &trillian.QueuedLogLeaf{Leaf: leaf})
+	}
+	return ret, nil
+}
+
+func TestQueueLeafMemoryAckDuplicate(t *testing.T) {
+	ctx := context.Background()
+	ts := memory.NewTreeStorage()
+	ls := &dedupingStorage{LogStorage: memory.NewLogStorage(ts, nil), queued: make(map[string]bool)}
+	registry := extension.Registry{
+		AdminStorage: memory.NewAdminStorage(ts),
+		LogStorage:   ls,
+	}
+	tree, err := storage.CreateTree(ctx, registry.AdminStorage, stestonly.LogTree)
+	if err != nil {
+		t.Fatalf("CreateTree(): %v", err)
+	}
+	server := NewTrillianLogRPCServer(registry, clock.NewFake(time.Unix(1500000000, 0)))
+	if _, err := server.InitLog(ctx, &trillian.InitLogRequest{LogId: tree.TreeId}); err != nil {
+		t.Fatalf("InitLog(): %v", err)
+	}
+
+	req := &trillian.QueueLeafRequest{
+		LogId:      tree.TreeId,
+		Leaf:       newTestLeaf([]byte("memory"), nil, 0),
+		Durability: trillian.QueueDurability_ACK_AFTER_MEMORY,
+	}
+	for i := 0; i < 2; i++ {
+		resp, err := server.QueueLeaf(ctx, req)
+		if err != nil {
+			t.Fatalf("QueueLeaf(ACK_AFTER_MEMORY) #%d: %v", i, err)
+		}
+		// The duplicate can't be reported, as it's acknowledged before the
+		// storage tells it apart.
+		if got := resp.QueuedLeaf.GetStatus().GetCode(); got != int32(codes.OK) {
+			t.Errorf("QueueLeaf(ACK_AFTER_MEMORY) #%d status: %v, want OK", i, codes.Code(got))
+		}
+		server.WaitForMemoryAcks()
+	}
+	if got, want := ls.duplicates, 1; got != want {
+		t.Errorf("storage saw %d duplicates, want %d", got, want)
+	}
+
+	req.Durability = trillian.QueueDurability_ACK_AFTER_COMMIT
+	resp, err := server.QueueLeaf(ctx, req)
+	if err != nil {
+		t.Fatalf("QueueLeaf(ACK_AFTER_COMMIT): %v", err)
+	}
+	if got := resp.QueuedLeaf.GetStatus().GetCode(); got != int32(codes.AlreadyExists) {
+		t.Errorf("QueueLeaf(ACK_AFTER_COMMIT) status: %v, want AlreadyExists", codes.Code(got))
+	}
+}
+
+// blockingStorage blocks QueueLeaves until its context is done, and records
+// the error.
+type blockingStorage struct {
+	storage.LogStorage
+	err error
+}
+
+func (s *blockingStorage) QueueLeaves(ctx context.Context, tree *trillian.Tree, leaves []*trillian.LogLeaf, queueTimestamp time.Time) ([]*trillian.QueuedLogLeaf, error) {
+	<-ctx.Done()
+	s.err = ctx.Err()
+	return nil, s.err
+}
+
+func TestQueueLeafMemoryAckTimeout(t *testing.T) {
+	ctx := context.Background()
+	ts := memory.NewTreeStorage()
+	ls := &blockingStorage{LogStorage: memory.NewLogStorage(ts, nil)}
+	registry := extension.Registry{
+		AdminStorage: memory.NewAdminStorage(ts),
+		LogStorage:   ls,
+	}
+	tree, err := storage.CreateTree(ctx, registry.AdminStorage, stestonly.LogTree)
+	if err != nil {
+		t.Fatalf("CreateTree(): %v", err)
+	}
+	server := NewTrillianLogRPCServer(registry, clock.NewFake(time.Unix(1500000000, 0)))
+	if _, err := server.InitLog(ctx, &trillian.InitLogRequest{LogId: tree.TreeId}); err != nil {
+		t.Fatalf("InitLog(): %v", err)
+	}
+	server.SetMemoryAckWriteTimeout(10 * time.Millisecond)
+
+	req := &trillian.QueueLeafRequest{
+		LogId:      tree.TreeId,
+		Leaf:       newTestLeaf([]byte("memory"), nil, 0),
+		Durability: trillian.QueueDurability_ACK_AFTER_MEMORY,
+	}
+	if _, err := server.QueueLeaf(ctx, req); err != nil {
+		t.Fatalf("QueueLeaf(ACK_AFTER_MEMORY): %v", err)
+	}
+	// The write doesn't hang on the storage forever.
+	server.WaitForMemoryAcks()
+	if got, want := ls.err, context.DeadlineExceeded; got != want {
+		t.Errorf("QueueLeaves() context error: %v, want %v", got, want)
+	}
+	if got := atomic.LoadInt64(&server.pendingMemoryAcks); got != 0 {
+		t.Errorf("pending memory acks: %d, want 0", got)
+	}
+}
+
+func TestQueueLeafDurability(t *testing.T) {
+	ctx := context.Background()
+	ts := memory.NewTreeStorage()
+	ls := &batchRecordingStorage{LogStorage: memory.NewLogStorage(ts, nil)}
+	registry := extension.Registry{
+		AdminStorage: memory.NewAdminStorage(ts),
+		LogStorage:   ls,
+	}
+	tree, err := storage.CreateTree(ctx, registry.AdminStorage, stestonly.LogTree)
+	if err != nil {
+		t.Fatalf("CreateTree(): %v", err)
+	}
+	promiseTree := proto.Clone(stestonly.LogTree).(*trillian.Tree)
+	promiseTree.MaxMergeDelay = durationpb.New(time.Hour)
+	if promiseTree, err = storage.CreateTree(ctx, registry.AdminStorage, promiseTree); err != nil {
+		t.Fatalf("CreateTree(): %v", err)
+	}
+
+	server := NewTrillianLogRPCServer(registry, clock.NewFake(time.Unix(1500000000, 0)))
+	for _, id := range []int64{tree.TreeId, promiseTree.TreeId} {
+		if _, err := server.InitLog(ctx, &trillian.InitLogRequest{LogId: id}); err != nil {
+			t.Fatalf("InitLog(): %v", err)
+		}
+	}
+
+	// Leaves acknowledged from memory are stored in the background.
+	req := &trillian.QueueLeafRequest{
+		LogId:      tree.TreeId,
+		Leaf:       newTestLeaf([]byte("memory"), nil, 0),
+		Durability: trillian.QueueDurability_ACK_AFTER_MEMORY,
+	}
+	resp, err := server.QueueLeaf(ctx, req)
+	if err != nil {
+		t.Fatalf("QueueLeaf(ACK_AFTER_MEMORY): %v", err)
+	}
+	if got := resp.QueuedLeaf.GetStatus().GetCode(); got != int32(codes.OK) {
+		t.Errorf("QueueLeaf(ACK_AFTER_MEMORY) status: %v, want OK", codes.Code(got))
+	}
+	server.WaitForMemoryAcks()
+	if got, want := ls.batches, []int{1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("QueueLeaves() batches after ACK_AFTER_MEMORY: %v, want %v", got, want)
+	}
+
+	for _, tc := range []struct {
+		desc       string
+		logID      int64
+		durability trillian.QueueDurability
+		maxPending int
+		want       codes.Code
+	}{
+		{desc: "memoryWithPromise", logID: promiseTree.TreeId, durability: trillian.QueueDurability_ACK_AFTER_MEMORY, want: codes.FailedPrecondition},
+		{desc: "tooManyPending", logID: tree.TreeId, durability: trillian.QueueDurability_ACK_AFTER_MEMORY, want: codes.ResourceExhausted},
+		{desc: "replicationUnsupported", logID: tree.TreeId, durability: trillian.QueueDurability_ACK_AFTER_REPLICATION, want: codes.Unimplemented},
+		{desc: "unknown", logID: tree.TreeId, durability: trillian.QueueDurability(100), want: codes.InvalidArgument},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			server.SetMaxPendingMemoryAcks(tc.maxPending)
+			defer server.SetMaxPendingMemoryAcks(DefaultMaxPendingMemoryAcks)
+			req := &trillian.QueueLeafRequest{LogId: tc.logID, Leaf: newTestLeaf([]byte(tc.desc), nil, 0), Durability: tc.durability}
+			if _, err := server.QueueLeaf(ctx, req); status.Code(err) != tc.want {
+				t.Errorf("QueueLeaf(): %v, want code %v", err, tc.want)
+			}
+		})
+	}
+
+	// Storage which supports replication is asked to wait for it.
+	rs := &replicatedStorage{LogStorage: ls}
+	registry.LogStorage = rs
+	server = NewTrillianLogRPCServer(registry, clock.NewFake(time.Unix(1500000000, 0)))
+	req = &trillian.QueueLeafRequest{
+		LogId:      tree.TreeId,
+		Leaf:       newTestLeaf([]byte("replicated"), nil, 0),
+		Durability: trillian.QueueDurability_ACK_AFTER_REPLICATION,
+	}
+	if _, err := server.QueueLeaf(ctx, req); err != nil {
+		t.Fatalf("QueueLeaf(ACK_AFTER_REPLICATION): %v", err)
+	}
+	if got, want := rs.replicated, 1; got != want {
+		t.Errorf("QueueLeavesReplicated() got %d leaves, want %d", got, want)
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/extension"
+	"github.com/google/trillian/log"
+	"github.com/google/trillian/quota"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/storage/memory"
+	stestonly "github.com/google/trillian/storage/testonly"
+	"github.com/google/trillian/util/clock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGetInclusionProofsAtSizes(t *testing.T) {
+	log.InitMetrics(nil)
+	ctx := context.Background()
+	ts := memory.NewTreeStorage()
+	registry := extension.Registry{
+		AdminStorage: memory.NewAdminStorage(ts),
+		LogStorage:   memory.NewLogStorage(ts, nil),
+	}
+	tree, err := storage.CreateTree(ctx, registry.AdminStorage, stestonly.LogTree)
+	if err != nil {
+		t.Fatalf("CreateTree(): %v", err)
+	}
+	server := NewTrillianLogRPCServer(registry, clock.System)
+	if _, err := server.InitLog(ctx, &trillian.InitLogRequest{LogId: tree.TreeId}); err != nil {
+		t.Fatalf("InitLog(): %v", err)
+	}
+	const size = 21
+	for i := 0; i < size; i++ {
+		req := &trillian.QueueLeafRequest{LogId: tree.TreeId, Leaf: &trillian.LogLeaf{LeafValue: []byte(fmt.Sprintf("leaf%d", i))}}
+		if _, err := server.QueueLeaf(ctx, req); err != nil {
+			t.Fatalf("QueueLeaf(): %v", err)
+		}
+	}
+	if _, err := log.IntegrateBatch(ctx, tree, size, 0, 0, clock.System, registry.LogStorage, quota.Noop()); err != nil {
+		t.Fatalf("IntegrateBatch(): %v", err)
+	}
+
+	sizes := []int64{21, 4, 8, 5, 13, 4}
+	req := &trillian.GetInclusionProofsAtSizesRequest{LogId: tree.TreeId, LeafIndex: 3, TreeSizes: sizes, IncludeNodeIds: true}
+	resp, err := server.GetInclusionProofsAtSizes(ctx, req)
+	if err != nil {
+		t.Fatalf("GetInclusionProofsAtSizes(): %v", err)
+	}
+	if got, want := len(resp.Proofs), len(sizes); got != want {
+		t.Fatalf("GetInclusionProofsAtSizes(): got %d proofs, want %d", got, want)
+	}
+	for i, size := range sizes {
+		want, err := server.GetInclusionProof(ctx, &trillian.GetInclusionProofRequest{LogId: tree.TreeId, LeafIndex: 3, TreeSize: size, IncludeNodeIds: true})
+		if err != nil {
+			t.Fatalf("GetInclusionProof(%d): %v", size, err)
+		}
+		if !proto.Equal(resp.Proofs[i], want.Proof) {
+			t.Errorf("GetInclusionProofsAtSizes(): proof at size %d = %v, want %v", size, resp.Proofs[i], want.Proof)
+		}
+	}
+
+	// The tree isn't as large as one of the sizes yet.
+	req.TreeSizes = []int64{8, size + 1}
+	resp, err = server.GetInclusionProofsAtSizes(ctx, req)
+	if err != nil {
+		t.Fatalf("GetInclusionProofsAtSizes(beyond tree): %v", err)
+	}
+	if len(resp.Proofs) != 0 || resp.SignedLogRoot == nil {
+		t.Errorf("GetInclusionProofsAtSizes(beyond tree): proofs %v, root %v, want no proofs and a root", resp.Proofs, resp.SignedLogRoot)
+	}
+}
+
+func TestGetInclusionProofsAtSizesErrors(t *testing.T) {
+	for _, test := range []struct {
+		desc string
+		req  *trillian.GetInclusionProofsAtSizesRequest
+	}{
+		{desc: "negativeIndex", req: &trillian.GetInclusionProofsAtSizesRequest{LogId: 1, LeafIndex: -1, TreeSizes: []int64{1}}},
+		{desc: "noSizes", req: &trillian.GetInclusionProofsAtSizesRequest{LogId: 1}},
+		{desc: "tooManySizes", req: &trillian.GetInclusionProofsAtSizesRequest{LogId: 1, TreeSizes: make([]int64, maxInclusionProofSizes+1)}},
+		{desc: "sizeAtIndex", req: &trillian.GetInclusionProofsAtSizesRequest{LogId: 1, LeafIndex: 4, TreeSizes: []int64{8, 4}}},
+		{desc: "zeroSize", req: &trillian.GetInclusionProofsAtSizesRequest{LogId: 1, TreeSizes: []int64{0}}},
+	} {
+		server := NewTrillianLogRPCServer(extension.Registry{}, fakeTimeSource)
+		_, err := server.GetInclusionProofsAtSizes(context.Background(), test.req)
+		if got, want := status.Code(err), codes.InvalidArgument; got != want {
+			t.Errorf("%v: GetInclusionProofsAtSizes() returned err = %v, wantCode = %s", test.desc, err, want)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interceptor
+
+import (
+	"math/rand"
+
+	"github.com/google/trillian"
+)
+
+// analyticsRecorder records the shape of a random sample of requests and
+// responses, to inform capacity planning: the widths of requested leaf
+// ranges, the sizes of leaves, and the number of hashes in proofs.
+//
+// Only sizes are recorded, aggregated into histograms labelled with the RPC
+// method. Leaf contents, tree IDs and callers are never retained, so the
+// metrics can't be used to reconstruct what was requested, or by whom.
+type analyticsRecorder struct {
+	rate float64
+	// sample returns a number in [0, 1), replaced in tests.
+	sample func() float64
+}
+
+func newAnalyticsRecorder(rate float64) *analyticsRecorder {
+	return &analyticsRecorder{rate: rate, sample: rand.Float64}
+}
+
+// sampled decides whether a request is part of the sample.
+func (a *analyticsRecorder) sampled() bool {
+	return a.sample() < a.rate
+}
+
+// request records the range widths and leaf sizes of a sampled request.
+func (a *analyticsRecorder) request(method string, req interface{}) {
+	analyticsSampleCounter.Inc(method)
+	switch req := req.(type) {
+	case *trillian.GetLeavesByRangeRequest:
+		analyticsRangeWidth.Observe(float64(req.Count), method)
+	case *trillian.GetConsistencyProofRequest:
+		analyticsRangeWidth.Observe(float64(req.SecondTreeSize-req.FirstTreeSize), method)
+	case *trillian.GetCompactRangeRequest:
+		analyticsRangeWidth.Observe(float64(req.End-req.Begin), method)
+	case *trillian.GetTileRequest:
+		analyticsRangeWidth.Observe(float64(req.Width), method)
+	case *trillian.QueueLeafRequest:
+		observeLeafSizes(method, req.Leaf)
+	case *trillian.AddSequencedLeavesRequest:
+		analyticsRangeWidth.Observe(float64(len(req.Leaves)), method)
+		observeLeafSizes(method, req.Leaves...)
+	}
+}
+
+// response records the proof and leaf sizes of the response to a sampled
+// request.
+func (a *analyticsRecorder) response(method string, resp interface{}) {
+	switch resp := resp.(type) {
+	case *trillian.GetInclusionProofResponse:
+		observeProofSizes(method, resp.Proof)
+	case *trillian.GetInclusionProofByHashResponse:
+		observeProofSizes(method, resp.Proof...)
+	case *trillian.GetConsistencyProofResponse:
+		observeProofSizes(method, resp.Proof)
+	case *trillian.GetEntryAndProofResponse:
+		observeProofSizes(method, resp.Proof)
+		observeLeafSizes(method, resp.Leaf)
+	case *trillian.GetCompactRangeResponse:
+		analyticsProofSize.Observe(float64(len(resp.Hashes)), method)
+	case *trillian.GetLeavesByRangeResponse:
+		observeLeafSizes(method, resp.Leaves...)
+	}
+}
+
+func observeProofSizes(method string, proofs ...*trillian.Proof) {
+	for _, p := range proofs {
+		if p != nil {
+			analyticsProofSize.Observe(float64(len(p.Hashes)), method)
+		}
+	}
+}
+
+func observeLeafSizes(method string, leaves ...*trillian.LogLeaf) {
+	for _, l := range leaves {
+		if l != nil {
+			analyticsLeafSize.Observe(float64(len(l.LeafValue)+len(l.ExtraData)), method)
+		}
+	}
+}
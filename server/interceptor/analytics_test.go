@@ -0,0 +1,154 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/monitoring"
+	"github.com/google/trillian/monitoring/testonly"
+	"github.com/google/trillian/quota"
+)
+
+// histogramDelta returns the number and sum of observations added to h for
+// the given labels since the returned function was created.
+func histogramDelta(h monitoring.Histogram, labels ...string) func() (uint64, float64) {
+	count, sum := h.Info(labels...)
+	return func() (uint64, float64) {
+		c, s := h.Info(labels...)
+		return c - count, s - sum
+	}
+}
+
+func TestAnalyticsRecorder(t *testing.T) {
+	New(nil /* admin */, quota.Noop(), false /* quotaDryRun */, nil /* mf */)
+	a := newAnalyticsRecorder(1)
+
+	for _, test := range []struct {
+		method    string
+		req       interface{}
+		resp      interface{}
+		wantWidth float64
+		wantLeaf  float64
+		wantProof float64
+	}{
+		{
+			method:    "GetLeavesByRange",
+			req:       &trillian.GetLeavesByRangeRequest{StartIndex: 10, Count: 5},
+			resp:      &trillian.GetLeavesByRangeResponse{Leaves: []*trillian.LogLeaf{{LeafValue: []byte("abc")}, {LeafValue: []byte("de"), ExtraData: []byte("f")}}},
+			wantWidth: 5,
+			wantLeaf:  6,
+		},
+		{
+			method:    "GetConsistencyProof",
+			req:       &trillian.GetConsistencyProofRequest{FirstTreeSize: 3, SecondTreeSize: 10},
+			resp:      &trillian.GetConsistencyProofResponse{Proof: &trillian.Proof{Hashes: make([][]byte, 4)}},
+			wantWidth: 7,
+			wantProof: 4,
+		},
+		{
+			method:    "GetInclusionProofByHash",
+			req:       &trillian.GetInclusionProofByHashRequest{LeafHash: []byte("secret")},
+			resp:      &trillian.GetInclusionProofByHashResponse{Proof: []*trillian.Proof{{Hashes: make([][]byte, 3)}, {Hashes: make([][]byte, 2)}}},
+			wantProof: 5,
+		},
+		{
+			method:   "QueueLeaf",
+			req:      &trillian.QueueLeafRequest{Leaf: &trillian.LogLeaf{LeafValue: []byte("value")}},
+			resp:     &trillian.QueueLeafResponse{},
+			wantLeaf: 5,
+		},
+		{
+			method:    "GetCompactRange",
+			req:       &trillian.GetCompactRangeRequest{Begin: 4, End: 12, TreeSize: 12},
+			resp:      &trillian.GetCompactRangeResponse{Hashes: make([][]byte, 1)},
+			wantWidth: 8,
+			wantProof: 1,
+		},
+	} {
+		t.Run(test.method, func(t *testing.T) {
+			samples := testonly.NewCounterSnapshot(analyticsSampleCounter, test.method)
+			width := histogramDelta(analyticsRangeWidth, test.method)
+			leaf := histogramDelta(analyticsLeafSize, test.method)
+			proof := histogramDelta(analyticsProofSize, test.method)
+
+			a.request(test.method, test.req)
+			a.response(test.method, test.resp)
+
+			if got, want := samples.Delta(), 1.0; got != want {
+				t.Errorf("sampled requests: %v, want %v", got, want)
+			}
+			if _, got := width(); got != test.wantWidth {
+				t.Errorf("range width: %v, want %v", got, test.wantWidth)
+			}
+			if _, got := leaf(); got != test.wantLeaf {
+				t.Errorf("leaf size: %v, want %v", got, test.wantLeaf)
+			}
+			if _, got := proof(); got != test.wantProof {
+				t.Errorf("proof size: %v, want %v", got, test.wantProof)
+			}
+		})
+	}
+}
+
+func TestTrillianInterceptor_Analytics(t *testing.T) {
+	intercept := New(nil /* admin */, quota.Noop(), false /* quotaDryRun */, nil /* mf */)
+	intercept.EnableAnalytics(0.25)
+	samples := []float64{0.1, 0.5, 0.2, 0.9}
+	intercept.analytics.sample = func() float64 {
+		s := samples[0]
+		samples = samples[1:]
+		return s
+	}
+
+	const method = "/trillian.TrillianAdmin/CreateTree"
+	sampled := testonly.NewCounterSnapshot(analyticsSampleCounter, "CreateTree")
+	req := &trillian.CreateTreeRequest{Tree: &trillian.Tree{DisplayName: "tree"}}
+	for i := 0; i < 4; i++ {
+		ctx := context.Background()
+		p := intercept.NewProcessor()
+		ctx, err := p.Before(ctx, req, method)
+		if err != nil {
+			t.Fatalf("Before() returned err = %v", err)
+		}
+		p.After(ctx, nil, method, errors.New("failed"))
+	}
+	if got, want := sampled.Delta(), 2.0; got != want {
+		t.Errorf("sampled requests: %v, want %v", got, want)
+	}
+
+	intercept.EnableAnalytics(0)
+	if intercept.analytics != nil {
+		t.Error("EnableAnalytics(0) didn't disable analytics")
+	}
+}
+
+func TestMethodName(t *testing.T) {
+	for _, tc := range []struct {
+		method string
+		want   string
+	}{
+		{method: "/trillian.TrillianLog/QueueLeaf", want: "QueueLeaf"},
+		{method: "/service.method", want: "method"},
+		{method: "/package.service.method"},
+	} {
+		if got := methodName(tc.method); got != tc.want {
+			t.Errorf("methodName(%v): %v, want %v", tc.method, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/trillian/util/clock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// circuitBreakers sheds the read requests for trees whose storage keeps
+// timing out, so that the requests for a single unhealthy tree don't tie up
+// the workers and database connections which all other trees need.
+//
+// The breaker of a tree opens after threshold consecutive read requests time
+// out, and then rejects its reads for openFor. After that a single probe
+// request is let through: if it doesn't time out the breaker closes again,
+// otherwise it stays open for another openFor.
+type circuitBreakers struct {
+	threshold int
+	openFor   time.Duration
+	ts        clock.TimeSource
+
+	mu    sync.Mutex
+	trees map[int64]*circuitState
+}
+
+// circuitState is the state of the breaker of a tree which has had timeouts.
+// Trees without an entry have a closed breaker.
+type circuitState struct {
+	// timeouts is the number of consecutive timed out requests.
+	timeouts int
+	// openUntil is the time until which requests are rejected, zero while
+	// the breaker is closed.
+	openUntil time.Time
+	// probing is set while the request let through after openUntil is in
+	// flight.
+	probing bool
+}
+
+func newCircuitBreakers(threshold int, openFor time.Duration, ts clock.TimeSource) *circuitBreakers {
+	return &circuitBreakers{
+		threshold: threshold,
+		openFor:   openFor,
+		ts:        ts,
+		trees:     make(map[int64]*circuitState),
+	}
+}
+
+// allow returns whether a read request for the tree may go ahead, and if not,
+// how long the caller should wait before retrying.
+func (b *circuitBreakers) allow(treeID int64) (bool, time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	st, ok := b.trees[treeID]
+	if !ok || st.openUntil.IsZero() {
+		return true, 0
+	}
+	now := b.ts.Now()
+	if now.Before(st.openUntil) {
+		return false, st.openUntil.Sub(now)
+	}
+	// Let a single probe through. Its outcome decides whether the breaker
+	// closes, and if it never reports back another probe is allowed after
+	// openFor.
+	st.openUntil = now.Add(b.openFor)
+	st.probing = true
+	return true, 0
+}
+
+// record updates the breaker of the tree with the outcome of an allowed read
+// request.
+func (b *circuitBreakers) record(treeID int64, timedOut bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	st, ok := b.trees[treeID]
+	if !timedOut {
+		// Requests which were let through before the breaker opened don't
+		// say anything about the storage now, only the probe does.
+		if ok && (st.openUntil.IsZero() || st.probing) {
+			delete(b.trees, treeID)
+		}
+		return
+	}
+	if !ok {
+		st = &circuitState{}
+		b.trees[treeID] = st
+	}
+	st.timeouts++
+	if st.probing || (st.openUntil.IsZero() && st.timeouts >= b.threshold) {
+		st.openUntil = b.ts.Now().Add(b.openFor)
+		st.probing = false
+		circuitOpenedCounter.Inc(fmt.Sprint(treeID))
+	}
+}
+
+// isTimeout returns whether err is the result of a deadline being exceeded.
+func isTimeout(err error) bool {
+	return errors.Is(err, context.DeadlineExceeded) || status.Code(err) == codes.DeadlineExceeded
+}
+
+// circuitOpenError returns the Unavailable error for a rejected request, with
+// a hint for when to retry.
+func circuitOpenError(treeID int64, retryAfter time.Duration) error {
+	st := status.Newf(codes.Unavailable, "reads of tree %d are shed after repeated storage timeouts, retry in %v", treeID, retryAfter)
+	if withInfo, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)}); err == nil {
+		st = withInfo
+	}
+	return st.Err()
+}
@@ -0,0 +1,199 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/trillian"
+	"github.com/google/trillian/quota"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/storage/testonly"
+	"github.com/google/trillian/util/clock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestCircuitBreakers(t *testing.T) {
+	const openFor = 10 * time.Second
+	ts := clock.NewFake(time.Unix(1000, 0))
+	b := newCircuitBreakers(3, openFor, ts)
+
+	mustAllow := func(treeID int64) {
+		t.Helper()
+		if ok, _ := b.allow(treeID); !ok {
+			t.Fatalf("allow(%d): rejected, want allowed", treeID)
+		}
+	}
+	mustReject := func(treeID int64, wantRetry time.Duration) {
+		t.Helper()
+		ok, retry := b.allow(treeID)
+		if ok {
+			t.Fatalf("allow(%d): allowed, want rejected", treeID)
+		}
+		if retry != wantRetry {
+			t.Errorf("allow(%d): retry in %v, want %v", treeID, retry, wantRetry)
+		}
+	}
+
+	// A success resets the count of consecutive timeouts.
+	b.record(1, true)
+	b.record(1, true)
+	b.record(1, false)
+	b.record(1, true)
+	b.record(1, true)
+	mustAllow(1)
+
+	b.record(1, true)
+	mustReject(1, openFor)
+	mustAllow(2)
+
+	// Requests which were in flight when the breaker opened don't close it.
+	b.record(1, false)
+	ts.Set(ts.Now().Add(4 * time.Second))
+	mustReject(1, 6*time.Second)
+
+	// A timed out probe opens the breaker again.
+	ts.Set(ts.Now().Add(6 * time.Second))
+	mustAllow(1)
+	mustReject(1, openFor)
+	b.record(1, true)
+	mustReject(1, openFor)
+
+	// A probe which never reports back is followed by another one.
+	ts.Set(ts.Now().Add(openFor))
+	mustAllow(1)
+	ts.Set(ts.Now().Add(openFor))
+	mustAllow(1)
+
+	// A successful probe closes the breaker.
+	b.record(1, false)
+	mustAllow(1)
+	mustAllow(1)
+}
+
+func TestIsTimeout(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		want bool
+	}{
+		{err: nil},
+		{err: errors.New("failed")},
+		{err: status.Error(codes.Unavailable, "unavailable")},
+		{err: context.DeadlineExceeded, want: true},
+		{err: fmt.Errorf("query: %w", context.DeadlineExceeded), want: true},
+		{err: status.Error(codes.DeadlineExceeded, "timeout"), want: true},
+	} {
+		if got := isTimeout(tc.err); got != tc.want {
+			t.Errorf("isTimeout(%v): %v, want %v", tc.err, got, tc.want)
+		}
+	}
+}
+
+// refundWaiter is a quota.Manager which lets tests wait for the tokens of
+// failed requests to be returned.
+type refundWaiter struct {
+	quota.Manager
+	wg sync.WaitGroup
+}
+
+func (r *refundWaiter) PutTokens(ctx context.Context, numTokens int, specs []quota.Spec) error {
+	defer r.wg.Done()
+	return r.Manager.PutTokens(ctx, numTokens, specs)
+}
+
+func TestTrillianInterceptor_ReadCircuitBreakers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logTree := proto.Clone(testonly.LogTree).(*trillian.Tree)
+	logTree.TreeId = 10
+	admin := storage.NewMockAdminStorage(ctrl)
+	adminTX := storage.NewMockReadOnlyAdminTX(ctrl)
+	admin.EXPECT().Snapshot(gomock.Any()).AnyTimes().Return(adminTX, nil)
+	adminTX.EXPECT().GetTree(gomock.Any(), logTree.TreeId).AnyTimes().Return(logTree, nil)
+	adminTX.EXPECT().Close().AnyTimes().Return(nil)
+	adminTX.EXPECT().Commit().AnyTimes().Return(nil)
+
+	// Three of the requests below fail, and have their tokens returned.
+	qm := &refundWaiter{Manager: quota.Noop()}
+	qm.wg.Add(3)
+	intercept := New(admin, qm, false /* quotaDryRun */, nil /* mf */)
+	intercept.EnableReadCircuitBreakers(2, time.Minute)
+	ts := clock.NewFake(time.Unix(1000, 0))
+	intercept.breakers.ts = ts
+
+	const readMethod = "/trillian.TrillianLog/GetInclusionProof"
+	const writeMethod = "/trillian.TrillianLog/QueueLeaf"
+	readReq := &trillian.GetInclusionProofRequest{LogId: logTree.TreeId}
+	writeReq := &trillian.QueueLeafRequest{LogId: logTree.TreeId}
+	call := func(method string, req interface{}, handlerErr error) error {
+		p := intercept.NewProcessor()
+		ctx, err := p.Before(context.Background(), req, method)
+		if err != nil {
+			return err
+		}
+		p.After(ctx, nil, method, handlerErr)
+		return nil
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := call(readMethod, readReq, context.DeadlineExceeded); err != nil {
+			t.Fatalf("Before() returned err = %v", err)
+		}
+	}
+
+	err := call(readMethod, readReq, nil)
+	st := status.Convert(err)
+	if st.Code() != codes.Unavailable {
+		t.Fatalf("Before() with open breaker returned err = %v, want code %v", err, codes.Unavailable)
+	}
+	var retry time.Duration
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.RetryInfo); ok {
+			retry = info.GetRetryDelay().AsDuration()
+		}
+	}
+	if retry != time.Minute {
+		t.Errorf("Before() with open breaker: retry delay %v, want %v", retry, time.Minute)
+	}
+
+	// Writes aren't shed.
+	if err := call(writeMethod, writeReq, context.DeadlineExceeded); err != nil {
+		t.Errorf("Before() of write returned err = %v", err)
+	}
+
+	ts.Set(ts.Now().Add(time.Minute))
+	if err := call(readMethod, readReq, nil); err != nil {
+		t.Fatalf("Before() of probe returned err = %v", err)
+	}
+	if err := call(readMethod, readReq, nil); err != nil {
+		t.Errorf("Before() after successful probe returned err = %v", err)
+	}
+
+	intercept.EnableReadCircuitBreakers(0, time.Minute)
+	if intercept.breakers != nil {
+		t.Error("EnableReadCircuitBreakers(0) didn't disable circuit breakers")
+	}
+	qm.wg.Wait()
+}
@@ -0,0 +1,123 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interceptor
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// UnauthenticatedCaller is the caller label used for requests that don't
+	// carry a verified client identity.
+	UnauthenticatedCaller = "unauthenticated"
+
+	// OtherCaller is the caller label used for requests from callers beyond
+	// the configured cardinality cap.
+	OtherCaller = "other"
+
+	requestDirection  = "request"
+	responseDirection = "response"
+)
+
+// callerTracker attributes requests, bytes and quota tokens to the caller
+// that issued them. The number of distinct caller labels is capped so that a
+// large (or hostile) population of clients can't explode metric cardinality.
+type callerTracker struct {
+	maxCallers int
+
+	mu      sync.Mutex
+	callers map[string]bool
+}
+
+func newCallerTracker(maxCallers int) *callerTracker {
+	return &callerTracker{
+		maxCallers: maxCallers,
+		callers:    make(map[string]bool),
+	}
+}
+
+// label returns the metric label to use for the given caller identity.
+// The first maxCallers identities seen are tracked individually, all others
+// are grouped under OtherCaller.
+func (c *callerTracker) label(id string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.callers[id] {
+		return id
+	}
+	if len(c.callers) >= c.maxCallers {
+		return OtherCaller
+	}
+	c.callers[id] = true
+	return id
+}
+
+// request records an incoming request for the caller identified by ctx and
+// returns the caller label used, so that later stages can attribute their
+// usage to the same caller.
+func (c *callerTracker) request(ctx context.Context, req interface{}) string {
+	caller := c.label(callerIdentity(ctx))
+	callerRequestCounter.Inc(caller)
+	callerBytesCounter.Add(float64(messageSize(req)), caller, requestDirection)
+	return caller
+}
+
+// response records the size of a response sent to caller.
+func (c *callerTracker) response(caller string, resp interface{}) {
+	callerBytesCounter.Add(float64(messageSize(resp)), caller, responseDirection)
+}
+
+// tokens records quota tokens acquired on behalf of caller.
+func (c *callerTracker) tokens(caller string, tokens int) {
+	callerTokensCounter.Add(float64(tokens), caller)
+}
+
+// callerIdentity returns the authenticated identity of the peer that issued
+// the RPC in ctx. Only identities backed by a verified TLS client certificate
+// are considered authenticated, in which case the certificate's subject common
+// name is returned. UnauthenticatedCaller is returned otherwise.
+func callerIdentity(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return UnauthenticatedCaller
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return UnauthenticatedCaller
+	}
+	chains := tlsInfo.State.VerifiedChains
+	if len(chains) == 0 || len(chains[0]) == 0 {
+		return UnauthenticatedCaller
+	}
+	if cn := chains[0][0].Subject.CommonName; cn != "" {
+		return cn
+	}
+	return UnauthenticatedCaller
+}
+
+// messageSize returns the serialized size of msg, or zero if msg isn't a
+// proto message.
+func messageSize(msg interface{}) int {
+	m, ok := msg.(proto.Message)
+	if !ok || m == nil {
+		return 0
+	}
+	return proto.Size(m)
+}
@@ -0,0 +1,113 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interceptor
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/monitoring/testonly"
+	"github.com/google/trillian/quota"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+func peerContext(cn string, verified bool) context.Context {
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: cn}}
+	state := tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+	if verified {
+		state.VerifiedChains = [][]*x509.Certificate{{cert}}
+	}
+	return peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{State: state}})
+}
+
+func TestCallerIdentity(t *testing.T) {
+	for _, test := range []struct {
+		desc string
+		ctx  context.Context
+		want string
+	}{
+		{desc: "noPeer", ctx: context.Background(), want: UnauthenticatedCaller},
+		{desc: "noAuthInfo", ctx: peer.NewContext(context.Background(), &peer.Peer{}), want: UnauthenticatedCaller},
+		{desc: "unverified", ctx: peerContext("monitor", false), want: UnauthenticatedCaller},
+		{desc: "emptyCN", ctx: peerContext("", true), want: UnauthenticatedCaller},
+		{desc: "verified", ctx: peerContext("monitor", true), want: "monitor"},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			if got := callerIdentity(test.ctx); got != test.want {
+				t.Errorf("callerIdentity()=%q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestCallerTracker_Label(t *testing.T) {
+	c := newCallerTracker(2)
+	for _, test := range []struct {
+		id, want string
+	}{
+		{id: "a", want: "a"},
+		{id: "b", want: "b"},
+		{id: "c", want: OtherCaller},
+		{id: "a", want: "a"},
+		{id: "d", want: OtherCaller},
+	} {
+		if got := c.label(test.id); got != test.want {
+			t.Errorf("label(%q)=%q, want %q", test.id, got, test.want)
+		}
+	}
+}
+
+func TestTrillianInterceptor_CallerMetrics(t *testing.T) {
+	intercept := New(nil /* admin */, quota.Noop(), false /* quotaDryRun */, nil /* mf */)
+	intercept.EnableCallerMetrics(1)
+
+	req := &trillian.CreateTreeRequest{Tree: &trillian.Tree{DisplayName: "tree"}}
+	resp := &trillian.Tree{TreeId: 12345, DisplayName: "tree"}
+	snapshots := map[string]testonly.CounterSnapshot{}
+	for _, caller := range []string{"personality", "monitor", OtherCaller} {
+		snapshots[caller] = testonly.NewCounterSnapshot(callerRequestCounter, caller)
+	}
+	respBytes := testonly.NewCounterSnapshot(callerBytesCounter, "personality", responseDirection)
+	for _, cn := range []string{"personality", "monitor"} {
+		ctx := peerContext(cn, true)
+		p := intercept.NewProcessor()
+		ctx, err := p.Before(ctx, req, "/trillian.TrillianAdmin/CreateTree")
+		if err != nil {
+			t.Fatalf("Before() returned err = %v", err)
+		}
+		p.After(ctx, resp, "/trillian.TrillianAdmin/CreateTree", nil /* handlerErr */)
+	}
+
+	for _, test := range []struct {
+		caller string
+		want   float64
+	}{
+		{caller: "personality", want: 1},
+		{caller: OtherCaller, want: 1},
+		{caller: "monitor", want: 0},
+	} {
+		if got := snapshots[test.caller].Delta(); got != test.want {
+			t.Errorf("callerRequestCounter[%q]=%v, want %v", test.caller, got, test.want)
+		}
+	}
+	if got, want := respBytes.Delta(), float64(messageSize(resp)); got != want {
+		t.Errorf("callerBytesCounter[personality, response]=%v, want %v", got, want)
+	}
+}
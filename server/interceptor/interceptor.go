@@ -24,12 +24,15 @@ import (
 
 	"github.com/golang/glog"
 	"github.com/google/trillian"
+	"github.com/google/trillian/log/sequencerpb"
 	"github.com/google/trillian/monitoring"
 	"github.com/google/trillian/quota"
 	"github.com/google/trillian/quota/etcd/quotapb"
 	"github.com/google/trillian/server/errors"
 	"github.com/google/trillian/storage"
 	"github.com/google/trillian/trees"
+	"github.com/google/trillian/util/clock"
+	"github.com/google/trillian/util/election2/electionpb"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -39,8 +42,13 @@ const (
 	badInfoReason            = "bad_info"
 	badTreeReason            = "bad_tree"
 	insufficientTokensReason = "insufficient_tokens"
+	treeQuotaReason          = "tree_quota"
+	circuitOpenReason        = "circuit_open"
+	readOnlyReason           = "read_only"
+	strictReason             = "strict_validation"
 	getTreeStage             = "get_tree"
 	getTokensStage           = "get_tokens"
+	concurrencyQueueStage    = "concurrency_queue"
 	traceSpanRoot            = "/trillian/server/int"
 )
 
@@ -50,11 +58,22 @@ var (
 	// its own timeout, separate from the RPC that causes the calls.
 	PutTokensTimeout = 5 * time.Second
 
-	requestCounter       monitoring.Counter
-	requestDeniedCounter monitoring.Counter
-	contextErrCounter    monitoring.Counter
-	metricsOnce          sync.Once
-	enabledServices      = map[string]bool{
+	requestCounter             monitoring.Counter
+	requestDeniedCounter       monitoring.Counter
+	contextErrCounter          monitoring.Counter
+	callerRequestCounter       monitoring.Counter
+	callerBytesCounter         monitoring.Counter
+	callerTokensCounter        monitoring.Counter
+	analyticsSampleCounter     monitoring.Counter
+	analyticsRangeWidth        monitoring.Histogram
+	analyticsLeafSize          monitoring.Histogram
+	analyticsProofSize         monitoring.Histogram
+	circuitOpenedCounter       monitoring.Counter
+	concurrencyInFlightGauge   monitoring.Gauge
+	concurrencyQueuedCounter   monitoring.Counter
+	concurrencyRejectedCounter monitoring.Counter
+	metricsOnce                sync.Once
+	enabledServices            = map[string]bool{
 		"trillian.TrillianLog":   true,
 		"trillian.TrillianAdmin": true,
 		"TrillianLog":            true,
@@ -88,6 +107,23 @@ type TrillianInterceptor struct {
 	// quotaDryRun controls whether lack of tokens actually blocks requests (if set to true, no
 	// requests are blocked by lack of tokens).
 	quotaDryRun bool
+
+	// callers tracks per-caller metrics, nil if disabled.
+	callers *callerTracker
+	// analytics records sampled request statistics, nil if disabled.
+	analytics *analyticsRecorder
+	// breakers sheds the reads of trees with timing out storage, nil if
+	// disabled.
+	breakers *circuitBreakers
+	// limiters bounds the concurrent requests of each RPC class, classes
+	// without an entry are unlimited.
+	limiters map[RPCClass]*concurrencyLimiter
+	// readOnly makes mutating requests fail.
+	readOnly bool
+	// strict makes requests with unknown fields or enum values fail.
+	strict bool
+	// treeQuotas enforces the quotas set on trees.
+	treeQuotas *treeQuotas
 }
 
 // New returns a new TrillianInterceptor instance.
@@ -97,6 +133,7 @@ func New(admin storage.AdminStorage, qm quota.Manager, quotaDryRun bool, mf moni
 		admin:       admin,
 		qm:          qm,
 		quotaDryRun: quotaDryRun,
+		treeQuotas:  newTreeQuotas(clock.System),
 	}
 }
 
@@ -117,6 +154,117 @@ func initMetrics(mf monitoring.MetricFactory) {
 		"interceptor_context_err_counter",
 		"Total number of times request context has been cancelled or deadline exceeded by stage",
 		"stage")
+	callerRequestCounter = mf.NewCounter(
+		"interceptor_caller_request_count",
+		"Number of intercepted requests by authenticated caller",
+		"caller")
+	callerBytesCounter = mf.NewCounter(
+		"interceptor_caller_bytes",
+		"Number of request and response bytes by authenticated caller",
+		"caller", "direction")
+	callerTokensCounter = mf.NewCounter(
+		"interceptor_caller_quota_tokens",
+		"Number of quota tokens acquired by authenticated caller",
+		"caller")
+	analyticsSampleCounter = mf.NewCounter(
+		"interceptor_analytics_sampled_requests",
+		"Number of requests sampled for request analytics",
+		"method")
+	analyticsRangeWidth = mf.NewHistogramWithBuckets(
+		"interceptor_analytics_range_width",
+		"Number of leaves in the ranges of sampled requests",
+		monitoring.ExpBuckets(1, 2, 25),
+		"method")
+	analyticsLeafSize = mf.NewHistogramWithBuckets(
+		"interceptor_analytics_leaf_size_bytes",
+		"Size of the leaf value and extra data of leaves in sampled requests and responses",
+		monitoring.ExpBuckets(1, 2, 25),
+		"method")
+	analyticsProofSize = mf.NewHistogramWithBuckets(
+		"interceptor_analytics_proof_size",
+		"Number of hashes in the proofs of responses to sampled requests",
+		monitoring.ExpBuckets(1, 2, 8),
+		"method")
+	circuitOpenedCounter = mf.NewCounter(
+		"interceptor_circuit_opened_count",
+		"Number of times reads of a tree were shed after repeated timeouts",
+		monitoring.TreeIDLabel)
+	concurrencyInFlightGauge = mf.NewGauge(
+		"interceptor_concurrency_in_flight",
+		"Number of requests being handled by RPC class, for classes with a concurrency limit",
+		"class")
+	concurrencyQueuedCounter = mf.NewCounter(
+		"interceptor_concurrency_queued_count",
+		"Number of requests which waited for the concurrency limit of their RPC class",
+		"class")
+	concurrencyRejectedCounter = mf.NewCounter(
+		"interceptor_concurrency_rejected_count",
+		"Number of requests rejected because the queue of their RPC class was full",
+		"class")
+}
+
+// EnableCallerMetrics turns on per-caller metrics (requests, bytes and quota
+// tokens), keyed by the caller's authenticated identity. At most maxCallers
+// distinct callers are labelled individually, any others are reported as
+// OtherCaller. A maxCallers value <= 0 disables per-caller metrics.
+func (i *TrillianInterceptor) EnableCallerMetrics(maxCallers int) {
+	if maxCallers <= 0 {
+		i.callers = nil
+		return
+	}
+	i.callers = newCallerTracker(maxCallers)
+}
+
+// EnableAnalytics turns on request analytics for the given fraction of
+// requests. Sampled requests and their responses are summarized as histograms
+// of range widths, leaf sizes and proof sizes per method; no leaf content,
+// tree ID or caller identity is recorded. A sampleRate <= 0 disables them.
+func (i *TrillianInterceptor) EnableAnalytics(sampleRate float64) {
+	if sampleRate <= 0 {
+		i.analytics = nil
+		return
+	}
+	i.analytics = newAnalyticsRecorder(sampleRate)
+}
+
+// EnableReadCircuitBreakers turns on per-tree circuit breakers for read
+// requests. Once threshold consecutive reads of a tree have timed out, its
+// reads fail fast with Unavailable, and a hint to retry, for openFor before a
+// single probe request is let through again. A threshold <= 0 disables them.
+func (i *TrillianInterceptor) EnableReadCircuitBreakers(threshold int, openFor time.Duration) {
+	if threshold <= 0 {
+		i.breakers = nil
+		return
+	}
+	i.breakers = newCircuitBreakers(threshold, openFor, clock.System)
+}
+
+// EnableConcurrencyLimits bounds the number of concurrent requests of each
+// RPC class to the given limits, replacing any previous ones. Requests beyond
+// a limit wait in a queue, and fail with ResourceExhausted if it's full.
+// Classes without a positive MaxInFlight are unlimited.
+func (i *TrillianInterceptor) EnableConcurrencyLimits(limits map[RPCClass]ConcurrencyLimit) {
+	i.limiters = make(map[RPCClass]*concurrencyLimiter)
+	for class, limit := range limits {
+		if limit.MaxInFlight > 0 {
+			i.limiters[class] = newConcurrencyLimiter(class, limit)
+		}
+	}
+}
+
+// SetReadOnly turns the read-only mode of the server on or off. In read-only
+// mode, all requests which modify trees, leaves or quota configs fail with
+// FailedPrecondition, while reads are served as usual.
+func (i *TrillianInterceptor) SetReadOnly(readOnly bool) {
+	i.readOnly = readOnly
+}
+
+// SetStrictValidation turns the strict validation of requests on or off. With
+// strict validation, requests of any service which have unknown fields or
+// enum values, in any nested message, fail with InvalidArgument instead of
+// being served as if those weren't set.
+func (i *TrillianInterceptor) SetStrictValidation(strict bool) {
+	i.strict = strict
 }
 
 func incRequestDeniedCounter(reason string, treeID int64, quotaUser string) {
@@ -140,17 +288,71 @@ func (i *TrillianInterceptor) UnaryInterceptor(ctx context.Context, req interfac
 	return resp, err
 }
 
+// StreamInterceptor executes the TrillianInterceptor logic for streaming RPCs.
+// Every message received from the client is processed like the request of a
+// unary RPC, e.g. the leaves of QueueLeavesStream are charged quota one by one,
+// and a denied message fails the call. Unlike for unary RPCs, tokens aren't
+// refunded for duplicate leaves, as the responses of streams don't correspond
+// to single messages.
+func (i *TrillianInterceptor) StreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &processedStream{ServerStream: ss, parent: i, method: info.FullMethod})
+}
+
+// processedStream is a grpc.ServerStream which runs a RequestProcessor for
+// every received message.
+type processedStream struct {
+	grpc.ServerStream
+	parent *TrillianInterceptor
+	method string
+}
+
+func (s *processedStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	ctx := s.Context()
+	rp := s.parent.NewProcessor()
+	if _, err := rp.Before(ctx, m, s.method); err != nil {
+		return err
+	}
+	rp.After(ctx, nil, s.method, nil)
+	return nil
+}
+
 // NewProcessor returns a RequestProcessor for the TrillianInterceptor logic.
 func (i *TrillianInterceptor) NewProcessor() RequestProcessor {
 	return &trillianProcessor{parent: i}
 }
 
 type trillianProcessor struct {
-	parent *TrillianInterceptor
-	info   *rpcInfo
+	parent  *TrillianInterceptor
+	info    *rpcInfo
+	caller  string
+	sampled bool
+	// guarded is set if the outcome of the request is reported to the
+	// circuit breaker of its tree.
+	guarded bool
+	// limiter is the limiter of the RPC class if the request holds one of
+	// its slots.
+	limiter *concurrencyLimiter
 }
 
 func (tp *trillianProcessor) Before(ctx context.Context, req interface{}, method string) (context.Context, error) {
+	// Check strict validation and the read-only mode first, as they apply to
+	// the quota service too.
+	if tp.parent.strict {
+		if err := checkStrict(req); err != nil {
+			incRequestDeniedCounter(strictReason, 0, "")
+			return ctx, err
+		}
+	}
+	if tp.parent.readOnly {
+		if info, err := newRPCInfo(req); err == nil && !info.readonly {
+			incRequestDeniedCounter(readOnlyReason, info.treeID, info.quotaUsers)
+			return ctx, status.Errorf(codes.FailedPrecondition, "server is in read-only mode, %s is not allowed", methodName(method))
+		}
+	}
+
 	// Skip if the interceptor is not enabled for this service.
 	if !enabledServices[serviceName(method)] {
 		return ctx, nil
@@ -167,6 +369,21 @@ func (tp *trillianProcessor) Before(ctx context.Context, req interface{}, method
 	}
 	tp.info = info
 	requestCounter.Inc(fmt.Sprint(info.treeID))
+	if callers := tp.parent.callers; callers != nil {
+		tp.caller = callers.request(ctx, req)
+	}
+	if analytics := tp.parent.analytics; analytics != nil && analytics.sampled() {
+		tp.sampled = true
+		analytics.request(methodName(method), req)
+	}
+
+	if breakers := tp.parent.breakers; breakers != nil && info.readonly && info.getTree {
+		if ok, retryAfter := breakers.allow(info.treeID); !ok {
+			incRequestDeniedCounter(circuitOpenReason, info.treeID, info.quotaUsers)
+			return ctx, circuitOpenError(info.treeID, retryAfter)
+		}
+		tp.guarded = true
+	}
 
 	// TODO(codingllama): Add auth interception
 
@@ -182,6 +399,14 @@ func (tp *trillianProcessor) Before(ctx context.Context, req interface{}, method
 			return ctx, err
 		}
 		ctx = trees.NewContext(ctx, tree)
+
+		if tree.Quota != nil && info.tokens > 0 && !tp.parent.treeQuotas.take(tree, info.kind(), info.tokens) {
+			if !tp.parent.quotaDryRun {
+				incRequestDeniedCounter(treeQuotaReason, info.treeID, info.quotaUsers)
+				return ctx, status.Errorf(codes.ResourceExhausted, "quota of tree %d exhausted", info.treeID)
+			}
+			glog.Warningf("(quotaDryRun) Request %+v not denied by the quota of tree %d due to dry run mode", req, info.treeID)
+		}
 	}
 
 	if info.tokens > 0 && len(info.specs) > 0 {
@@ -194,12 +419,24 @@ func (tp *trillianProcessor) Before(ctx context.Context, req interface{}, method
 			glog.Warningf("(quotaDryRun) Request %+v not denied due to dry run mode: %v", req, err)
 		}
 		quota.Metrics.IncAcquired(info.tokens, info.specs, err == nil)
+		if callers := tp.parent.callers; callers != nil && err == nil {
+			callers.tokens(tp.caller, info.tokens)
+		}
 		if err = innerCtx.Err(); err != nil {
 			contextErrCounter.Inc(getTokensStage)
 			return ctx, err
 		}
 	}
 
+	// Wait for a slot last, so that requests which fail the above checks
+	// don't hold one, and After is called for all requests which do.
+	if l := tp.parent.limiters[info.class]; l != nil {
+		if err := l.acquire(innerCtx); err != nil {
+			return ctx, err
+		}
+		tp.limiter = l
+	}
+
 	return ctx, nil
 }
 
@@ -209,6 +446,18 @@ func (tp *trillianProcessor) After(ctx context.Context, resp interface{}, method
 	}
 	_, spanEnd := spanFor(ctx, "After")
 	defer spanEnd()
+	if tp.limiter != nil {
+		tp.limiter.release()
+	}
+	if callers := tp.parent.callers; callers != nil && tp.info != nil && handlerErr == nil {
+		callers.response(tp.caller, resp)
+	}
+	if tp.sampled && handlerErr == nil {
+		tp.parent.analytics.response(methodName(method), resp)
+	}
+	if tp.guarded {
+		tp.parent.breakers.record(tp.info.treeID, isTimeout(handlerErr))
+	}
 	switch {
 	case tp.info == nil:
 		glog.Warningf("After called with nil rpcInfo, resp = [%+v], handlerErr = [%v]", resp, handlerErr)
@@ -298,6 +547,18 @@ func serviceName(fullMethod string) string {
 	return ""
 }
 
+// methodName returns the method name "method" for "/some.package.service/method"
+// and "/service.method".
+func methodName(fullMethod string) string {
+	if matches := fullyQualifiedRE.FindStringSubmatch(fullMethod); len(matches) == 3 {
+		return matches[2]
+	}
+	if matches := unqualifiedRE.FindStringSubmatch(fullMethod); len(matches) == 3 {
+		return matches[2]
+	}
+	return ""
+}
+
 type rpcInfo struct {
 	// getTree indicates whether the interceptor should populate treeID.
 	getTree bool
@@ -305,6 +566,8 @@ type rpcInfo struct {
 	readonly  bool
 	treeID    int64
 	treeTypes []trillian.TreeType
+	// class is the class of the RPC for concurrency limits.
+	class RPCClass
 
 	specs  []quota.Spec
 	tokens int
@@ -339,72 +602,125 @@ func newRPCInfoForRequest(req interface{}) (*rpcInfo, error) {
 		readonly:  true,
 		treeTypes: nil,
 		tokens:    0,
+		class:     AdminRPCs,
 	}
 
 	switch req := req.(type) {
 
-	// Not intercepted at all
+	// Not intercepted at all, apart from the read-only mode check
 	case
 		// Quota configuration requests
-		*quotapb.CreateConfigRequest,
-		*quotapb.DeleteConfigRequest,
 		*quotapb.GetConfigRequest,
 		*quotapb.ListConfigsRequest,
+		// Sequencer reports and masters, served by the log signer
+		*sequencerpb.ListBatchReportsRequest,
+		*electionpb.ListMastersRequest:
+		info.getTree = false
+	case
+		*quotapb.CreateConfigRequest,
+		*quotapb.DeleteConfigRequest,
 		*quotapb.UpdateConfigRequest:
 		info.getTree = false
-		info.readonly = false // Doesn't really matter as all interceptors are turned off
+		info.readonly = false
 
 	// Admin create
-	case *trillian.CreateTreeRequest:
+	case *trillian.CreateTreeRequest,
+		*trillian.TreeSnapshotChunk:
 		info.getTree = false // Tree doesn't exist
 		info.readonly = false
 
 	// Admin list
-	case *trillian.ListTreesRequest:
+	case *trillian.ListTreesRequest,
+		*trillian.WatchTreesRequest:
 		info.getTree = false // Zero to many trees
 
 	// Admin / readonly
-	case *trillian.GetTreeRequest:
+	case *trillian.GetTreeRequest,
+		*trillian.ListQuarantinedLeavesRequest,
+		*trillian.ExportTreeRequest,
+		*trillian.ExportQueueRequest,
+		*trillian.GetTreeQuotaRequest:
 		info.getTree = false // Read done within RPC handler
 
 	// Admin / readwrite
 	case *trillian.DeleteTreeRequest,
 		*trillian.UndeleteTreeRequest,
-		*trillian.UpdateTreeRequest:
+		*trillian.UpdateTreeRequest,
+		*trillian.ResignLogRootRequest,
+		*trillian.QuarantineLeafRequest,
+		*trillian.RequeueQuarantinedLeavesRequest,
+		*trillian.PauseIntegrationRequest,
+		*trillian.ResumeIntegrationRequest,
+		*trillian.PruneLeavesRequest,
+		*trillian.QueueSnapshotChunk,
+		*trillian.SetTreeQuotaRequest:
 		info.getTree = false // Read-modify-write done within RPC handler
 		info.readonly = false
 
 	// (Log + Pre-ordered Log) / readonly
-	case *trillian.GetConsistencyProofRequest,
+	case *trillian.BeginAuditSessionRequest,
+		*trillian.EndAuditSessionRequest,
+		*trillian.GetCompactRangeRequest,
+		*trillian.GetConsistencyProofRequest,
+		*trillian.GetCosignedCheckpointRequest,
 		*trillian.GetEntryAndProofRequest,
 		*trillian.GetInclusionProofByHashRequest,
 		*trillian.GetInclusionProofRequest,
-		*trillian.GetLatestSignedLogRootRequest:
+		*trillian.GetLatestSignedLogRootRequest,
+		*trillian.GetTileRequest:
 		info.treeTypes = []trillian.TreeType{trillian.TreeType_LOG, trillian.TreeType_PREORDERED_LOG}
 		info.tokens = 1
+		info.class = ProofRPCs
+	case *trillian.GetInclusionProofsAtSizesRequest:
+		info.treeTypes = []trillian.TreeType{trillian.TreeType_LOG, trillian.TreeType_PREORDERED_LOG}
+		info.tokens = 1
+		if n := len(req.GetTreeSizes()); n > 1 {
+			info.tokens = n
+		}
+		info.class = ProofRPCs
+	case *trillian.GetDailyLogStatsRequest,
+		*trillian.ListLeafExtraDataUpdatesRequest,
+		*trillian.ListVerificationFailureReportsRequest:
+		info.treeTypes = []trillian.TreeType{trillian.TreeType_LOG, trillian.TreeType_PREORDERED_LOG}
+		info.tokens = 1
+		info.class = RangeRPCs
 	case *trillian.GetLeavesByRangeRequest:
 		info.treeTypes = []trillian.TreeType{trillian.TreeType_LOG, trillian.TreeType_PREORDERED_LOG}
 		info.tokens = 1
 		if c := req.GetCount(); c > 1 {
 			info.tokens = int(c)
 		}
+		info.class = RangeRPCs
+
+	// Pre-ordered Log / readonly
+	case *trillian.GetMirrorProgressRequest:
+		info.treeTypes = []trillian.TreeType{trillian.TreeType_PREORDERED_LOG}
+		info.tokens = 1
+		info.class = RangeRPCs
+
 	// Log / readwrite
 	case *trillian.QueueLeafRequest:
 		info.readonly = false
 		info.treeTypes = []trillian.TreeType{trillian.TreeType_LOG}
 		info.tokens = 1
+		info.class = WriteRPCs
 
 	// Pre-ordered Log / readwrite
 	case *trillian.AddSequencedLeavesRequest:
 		info.readonly = false
 		info.treeTypes = []trillian.TreeType{trillian.TreeType_PREORDERED_LOG}
 		info.tokens = len(req.GetLeaves())
+		info.class = WriteRPCs
 
 	// (Log + Pre-ordered Log) / readwrite
-	case *trillian.InitLogRequest:
+	case *trillian.AddCosignatureRequest,
+		*trillian.InitLogRequest,
+		*trillian.UpdateLeafExtraDataRequest,
+		*trillian.ReportVerificationFailureRequest:
 		info.readonly = false
 		info.treeTypes = []trillian.TreeType{trillian.TreeType_LOG, trillian.TreeType_PREORDERED_LOG}
 		info.tokens = 1
+		info.class = WriteRPCs
 
 	default:
 		return nil, status.Errorf(codes.Internal, "newRPCInfo: unmapped request type: %T", req)
@@ -433,11 +749,7 @@ func newRPCInfo(req interface{}) (*rpcInfo, error) {
 	}
 
 	if info.tokens > 0 {
-		kind := quota.Write
-		if info.readonly {
-			kind = quota.Read
-		}
-
+		kind := info.kind()
 		for _, user := range chargedUsers(req) {
 			info.specs = append(info.specs, quota.Spec{Group: quota.User, Kind: kind, User: user})
 			if len(info.quotaUsers) > 0 {
@@ -454,6 +766,14 @@ func newRPCInfo(req interface{}) (*rpcInfo, error) {
 	return info, nil
 }
 
+// kind returns the kind of quota tokens the request consumes.
+func (info *rpcInfo) kind() quota.Kind {
+	if info.readonly {
+		return quota.Read
+	}
+	return quota.Write
+}
+
 type logIDRequest interface {
 	GetLogId() int64
 }
@@ -474,6 +794,11 @@ func ErrorWrapper(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo,
 	return rsp, errors.WrapError(err)
 }
 
+// StreamErrorWrapper is a grpc.StreamServerInterceptor that wraps the errors emitted by the underlying handler.
+func StreamErrorWrapper(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return errors.WrapError(handler(srv, ss))
+}
+
 func spanFor(ctx context.Context, name string) (context.Context, func()) {
 	return monitoring.StartSpan(ctx, fmt.Sprintf("%s.%s", traceSpanRoot, name))
 }
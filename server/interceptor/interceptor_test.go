@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"testing"
 	"time"
 
@@ -242,6 +243,16 @@ func TestTrillianInterceptor_QuotaInterception(t *testing.T) {
 			},
 			wantTokens: 1,
 		},
+		{
+			desc:   "logReadInclusionProofsAtSizes",
+			method: "/trillian.TrillianLog/GetInclusionProofsAtSizes",
+			req:    &trillian.GetInclusionProofsAtSizesRequest{LogId: logTree.TreeId, TreeSizes: []int64{10, 20, 30}},
+			specs: []quota.Spec{
+				{Group: quota.Tree, Kind: quota.Read, TreeID: logTree.TreeId},
+				{Group: quota.Global, Kind: quota.Read, Refundable: true},
+			},
+			wantTokens: 3,
+		},
 		{
 			desc:   "logRead with charges",
 			method: "/trillian.TrillianLog/GetLatestSignedLogRoot",
@@ -364,6 +375,73 @@ func TestTrillianInterceptor_QuotaInterception(t *testing.T) {
 	}
 }
 
+// fakeServerStream is a grpc.ServerStream which receives reqs.
+type fakeServerStream struct {
+	grpc.ServerStream
+	reqs []*trillian.QueueLeafRequest
+}
+
+func (s *fakeServerStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *fakeServerStream) RecvMsg(m interface{}) error {
+	if len(s.reqs) == 0 {
+		return io.EOF
+	}
+	proto.Merge(m.(proto.Message), s.reqs[0])
+	s.reqs = s.reqs[1:]
+	return nil
+}
+
+func TestTrillianInterceptor_StreamInterceptor(t *testing.T) {
+	logTree := proto.Clone(testonly.LogTree).(*trillian.Tree)
+	logTree.TreeId = 10
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	admin := storage.NewMockAdminStorage(ctrl)
+	adminTX := storage.NewMockReadOnlyAdminTX(ctrl)
+	admin.EXPECT().Snapshot(gomock.Any()).AnyTimes().Return(adminTX, nil)
+	adminTX.EXPECT().GetTree(gomock.Any(), logTree.TreeId).AnyTimes().Return(logTree, nil)
+	adminTX.EXPECT().Close().AnyTimes().Return(nil)
+	adminTX.EXPECT().Commit().AnyTimes().Return(nil)
+
+	// Every message is charged quota, and the first denied one fails the
+	// stream.
+	specs := []quota.Spec{
+		{Group: quota.Tree, Kind: quota.Write, TreeID: logTree.TreeId},
+		{Group: quota.Global, Kind: quota.Write, Refundable: true},
+	}
+	qm := quota.NewMockManager(ctrl)
+	first := qm.EXPECT().GetTokens(gomock.Any(), 1, specs).Return(nil)
+	qm.EXPECT().GetTokens(gomock.Any(), 1, specs).After(first).Return(errors.New("no tokens"))
+
+	stream := &fakeServerStream{reqs: []*trillian.QueueLeafRequest{
+		{LogId: logTree.TreeId, Leaf: &trillian.LogLeaf{LeafValue: []byte("a")}},
+		{LogId: logTree.TreeId, Leaf: &trillian.LogLeaf{LeafValue: []byte("b")}},
+		{LogId: logTree.TreeId, Leaf: &trillian.LogLeaf{LeafValue: []byte("c")}},
+	}}
+	var received int
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		for {
+			if err := ss.RecvMsg(&trillian.QueueLeafRequest{}); err != nil {
+				return err
+			}
+			received++
+		}
+	}
+
+	intercept := New(admin, qm, false /* quotaDryRun */, nil /* mf */)
+	err := intercept.StreamInterceptor(nil, stream, &grpc.StreamServerInfo{FullMethod: "/trillian.TrillianLog/QueueLeavesStream"}, handler)
+	if got, want := status.Code(err), codes.ResourceExhausted; got != want {
+		t.Errorf("StreamInterceptor() returned err = %v, wantCode = %v", err, want)
+	}
+	if got, want := received, 1; got != want {
+		t.Errorf("handler received %d messages, want %d", got, want)
+	}
+}
+
 func TestTrillianInterceptor_QuotaInterception_ReturnsTokens(t *testing.T) {
 	logTree := proto.Clone(testonly.LogTree).(*trillian.Tree)
 	logTree.TreeId = 10
@@ -513,6 +591,57 @@ func TestTrillianInterceptor_NotIntercepted(t *testing.T) {
 	}
 }
 
+func TestTrillianInterceptor_ReadOnly(t *testing.T) {
+	logTree := proto.Clone(testonly.LogTree).(*trillian.Tree)
+	logTree.TreeId = 10
+
+	tests := []struct {
+		method  string
+		req     interface{}
+		wantErr bool
+	}{
+		{method: "/trillian.TrillianLog/GetLatestSignedLogRoot", req: &trillian.GetLatestSignedLogRootRequest{LogId: logTree.TreeId}},
+		{method: "/trillian.TrillianLog/GetLeavesByRange", req: &trillian.GetLeavesByRangeRequest{LogId: logTree.TreeId, Count: 1}},
+		{method: "/trillian.TrillianLog/QueueLeaf", req: &trillian.QueueLeafRequest{LogId: logTree.TreeId}, wantErr: true},
+		{method: "/trillian.TrillianLog/InitLog", req: &trillian.InitLogRequest{LogId: logTree.TreeId}, wantErr: true},
+		{method: "/trillian.TrillianAdmin/GetTree", req: &trillian.GetTreeRequest{TreeId: logTree.TreeId}},
+		{method: "/trillian.TrillianAdmin/ListTrees", req: &trillian.ListTreesRequest{}},
+		{method: "/trillian.TrillianAdmin/CreateTree", req: &trillian.CreateTreeRequest{}, wantErr: true},
+		{method: "/trillian.TrillianAdmin/DeleteTree", req: &trillian.DeleteTreeRequest{TreeId: logTree.TreeId}, wantErr: true},
+		{method: "/quotapb.Quota/GetConfig", req: &quotapb.GetConfigRequest{}},
+		{method: "/quotapb.Quota/UpdateConfig", req: &quotapb.UpdateConfigRequest{}, wantErr: true},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	admin := storage.NewMockAdminStorage(ctrl)
+	adminTX := storage.NewMockReadOnlyAdminTX(ctrl)
+	admin.EXPECT().Snapshot(gomock.Any()).AnyTimes().Return(adminTX, nil)
+	adminTX.EXPECT().GetTree(gomock.Any(), logTree.TreeId).AnyTimes().Return(logTree, nil)
+	adminTX.EXPECT().Close().AnyTimes().Return(nil)
+	adminTX.EXPECT().Commit().AnyTimes().Return(nil)
+
+	ctx := context.Background()
+	intercept := New(admin, quota.Noop(), false /* quotaDryRun */, nil /* mf */)
+	intercept.SetReadOnly(true)
+	for _, test := range tests {
+		t.Run(test.method, func(t *testing.T) {
+			handler := &fakeHandler{}
+			_, err := intercept.UnaryInterceptor(ctx, test.req, &grpc.UnaryServerInfo{FullMethod: test.method}, handler.run)
+			if test.wantErr {
+				if got, want := status.Code(err), codes.FailedPrecondition; got != want {
+					t.Errorf("UnaryInterceptor() returned err = %v, want code %v", err, want)
+				}
+			} else if err != nil {
+				t.Errorf("UnaryInterceptor() returned err = %v", err)
+			}
+			if handler.called == test.wantErr {
+				t.Errorf("UnaryInterceptor(): handler called = %v, want %v", handler.called, !test.wantErr)
+			}
+		})
+	}
+}
+
 // TestTrillianInterceptor_BeforeAfter tests a few Before/After interactions that are
 // difficult/impossible to get unless the methods are called separately (i.e., not via
 // UnaryInterceptor()).
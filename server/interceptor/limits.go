@@ -0,0 +1,100 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interceptor
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RPCClass is a class of RPCs which share a concurrency limit.
+type RPCClass string
+
+const (
+	// WriteRPCs are the RPCs which add or modify leaves.
+	WriteRPCs RPCClass = "write"
+	// ProofRPCs are the RPCs which return roots and proofs.
+	ProofRPCs RPCClass = "proof"
+	// RangeRPCs are the RPCs which read ranges of leaves or other records.
+	RangeRPCs RPCClass = "range"
+	// AdminRPCs are the admin and quota RPCs.
+	AdminRPCs RPCClass = "admin"
+)
+
+// ConcurrencyLimit bounds the number of requests of an RPC class which are
+// handled at the same time.
+type ConcurrencyLimit struct {
+	// MaxInFlight is the maximum number of requests being handled, zero
+	// means unlimited.
+	MaxInFlight int
+	// MaxQueued is the maximum number of requests waiting for one of the
+	// in-flight requests to finish. Further requests are rejected.
+	MaxQueued int
+}
+
+// concurrencyLimiter implements a ConcurrencyLimit for a single RPC class.
+type concurrencyLimiter struct {
+	class RPCClass
+	// running and queued hold a token for every in-flight and waiting
+	// request respectively.
+	running chan struct{}
+	queued  chan struct{}
+}
+
+func newConcurrencyLimiter(class RPCClass, limit ConcurrencyLimit) *concurrencyLimiter {
+	return &concurrencyLimiter{
+		class:   class,
+		running: make(chan struct{}, limit.MaxInFlight),
+		queued:  make(chan struct{}, limit.MaxQueued),
+	}
+}
+
+// acquire waits until the request may be handled, and must be followed by a
+// call to release if it succeeds. It fails with ResourceExhausted if the
+// queue is full, or with the context's error if it's done while waiting.
+func (l *concurrencyLimiter) acquire(ctx context.Context) error {
+	select {
+	case l.running <- struct{}{}:
+		concurrencyInFlightGauge.Inc(string(l.class))
+		return nil
+	default:
+	}
+
+	select {
+	case l.queued <- struct{}{}:
+	default:
+		concurrencyRejectedCounter.Inc(string(l.class))
+		return status.Errorf(codes.ResourceExhausted, "too many concurrent %s requests", l.class)
+	}
+	defer func() { <-l.queued }()
+	concurrencyQueuedCounter.Inc(string(l.class))
+
+	select {
+	case l.running <- struct{}{}:
+		concurrencyInFlightGauge.Inc(string(l.class))
+		return nil
+	case <-ctx.Done():
+		contextErrCounter.Inc(concurrencyQueueStage)
+		return ctx.Err()
+	}
+}
+
+// release frees the slot taken by a successful acquire.
+func (l *concurrencyLimiter) release() {
+	<-l.running
+	concurrencyInFlightGauge.Dec(string(l.class))
+}
@@ -0,0 +1,103 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interceptor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/monitoring/testonly"
+	"github.com/google/trillian/quota"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestConcurrencyLimiter(t *testing.T) {
+	New(nil /* admin */, quota.Noop(), false /* quotaDryRun */, nil /* mf */)
+	l := newConcurrencyLimiter(ProofRPCs, ConcurrencyLimit{MaxInFlight: 1, MaxQueued: 1})
+	queued := testonly.NewCounterSnapshot(concurrencyQueuedCounter, string(ProofRPCs))
+	rejected := testonly.NewCounterSnapshot(concurrencyRejectedCounter, string(ProofRPCs))
+	ctx := context.Background()
+
+	if err := l.acquire(ctx); err != nil {
+		t.Fatalf("acquire(): %v", err)
+	}
+	if got, want := concurrencyInFlightGauge.Value(string(ProofRPCs)), 1.0; got != want {
+		t.Errorf("in-flight: %v, want %v", got, want)
+	}
+
+	acquired := make(chan error)
+	go func() { acquired <- l.acquire(ctx) }()
+	// Wait for the second request to be queued.
+	for len(l.queued) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	if err := l.acquire(ctx); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("acquire() with full queue: %v, want code %v", err, codes.ResourceExhausted)
+	}
+
+	l.release()
+	if err := <-acquired; err != nil {
+		t.Fatalf("acquire() of queued request: %v", err)
+	}
+	if got, want := queued.Delta(), 1.0; got != want {
+		t.Errorf("queued requests: %v, want %v", got, want)
+	}
+	if got, want := rejected.Delta(), 1.0; got != want {
+		t.Errorf("rejected requests: %v, want %v", got, want)
+	}
+
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := l.acquire(cctx); err != context.Canceled {
+		t.Errorf("acquire() with cancelled context: %v, want %v", err, context.Canceled)
+	}
+	l.release()
+	if got, want := concurrencyInFlightGauge.Value(string(ProofRPCs)), 0.0; got != want {
+		t.Errorf("in-flight after release: %v, want %v", got, want)
+	}
+}
+
+func TestTrillianInterceptor_ConcurrencyLimits(t *testing.T) {
+	intercept := New(nil /* admin */, quota.Noop(), false /* quotaDryRun */, nil /* mf */)
+	intercept.EnableConcurrencyLimits(map[RPCClass]ConcurrencyLimit{
+		AdminRPCs: {MaxInFlight: 1},
+		WriteRPCs: {},
+	})
+	if _, ok := intercept.limiters[WriteRPCs]; ok {
+		t.Error("EnableConcurrencyLimits() limited a class without MaxInFlight")
+	}
+
+	const method = "/trillian.TrillianAdmin/CreateTree"
+	req := &trillian.CreateTreeRequest{Tree: &trillian.Tree{DisplayName: "tree"}}
+	p := intercept.NewProcessor()
+	ctx, err := p.Before(context.Background(), req, method)
+	if err != nil {
+		t.Fatalf("Before() returned err = %v", err)
+	}
+	if _, err := intercept.NewProcessor().Before(context.Background(), req, method); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Before() beyond the limit returned err = %v, want code %v", err, codes.ResourceExhausted)
+	}
+	p.After(ctx, nil, method, nil)
+
+	p = intercept.NewProcessor()
+	ctx, err = p.Before(context.Background(), req, method)
+	if err != nil {
+		t.Fatalf("Before() after After() returned err = %v", err)
+	}
+	p.After(ctx, nil, method, nil)
+}
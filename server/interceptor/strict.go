@@ -0,0 +1,71 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interceptor
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// checkStrict returns an InvalidArgument error if the request, or any message
+// nested in it, has unknown fields or enum values, which clients built from
+// the published protos can't send. Requests which aren't proto messages are
+// accepted.
+func checkStrict(req interface{}) error {
+	m, ok := req.(proto.Message)
+	if !ok || m == nil {
+		return nil
+	}
+	return checkStrictMessage(m.ProtoReflect())
+}
+
+func checkStrictMessage(m protoreflect.Message) error {
+	if len(m.GetUnknown()) > 0 {
+		return status.Errorf(codes.InvalidArgument, "%s has unknown fields", m.Descriptor().FullName())
+	}
+	var err error
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsList():
+			l := v.List()
+			for i := 0; i < l.Len() && err == nil; i++ {
+				err = checkStrictValue(fd, l.Get(i))
+			}
+		case fd.IsMap():
+			v.Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+				err = checkStrictValue(fd.MapValue(), v)
+				return err == nil
+			})
+		default:
+			err = checkStrictValue(fd, v)
+		}
+		return err == nil
+	})
+	return err
+}
+
+func checkStrictValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) error {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return checkStrictMessage(v.Message())
+	case protoreflect.EnumKind:
+		if fd.Enum().Values().ByNumber(v.Enum()) == nil {
+			return status.Errorf(codes.InvalidArgument, "%s has unknown value %d", fd.FullName(), v.Enum())
+		}
+	}
+	return nil
+}
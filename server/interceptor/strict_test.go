@@ -0,0 +1,84 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interceptor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/quota"
+	"github.com/google/trillian/quota/etcd/quotapb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+// withUnknownField returns m with an unknown varint field added.
+func withUnknownField(m proto.Message) proto.Message {
+	r := m.ProtoReflect()
+	r.SetUnknown(protowire.AppendVarint(protowire.AppendTag(nil, 999, protowire.VarintType), 1))
+	return m
+}
+
+func TestCheckStrict(t *testing.T) {
+	for _, test := range []struct {
+		desc    string
+		req     interface{}
+		wantErr bool
+	}{
+		{desc: "valid", req: &trillian.QueueLeafRequest{LogId: 1, Leaf: &trillian.LogLeaf{LeafValue: []byte("leaf")}, Durability: trillian.QueueDurability_ACK_AFTER_MEMORY}},
+		{desc: "notProto", req: "request"},
+		{desc: "unknownField", req: withUnknownField(&trillian.QueueLeafRequest{LogId: 1}), wantErr: true},
+		{desc: "nestedUnknownField", req: &trillian.QueueLeafRequest{Leaf: withUnknownField(&trillian.LogLeaf{}).(*trillian.LogLeaf)}, wantErr: true},
+		{desc: "repeatedUnknownField", req: &trillian.AddSequencedLeavesRequest{Leaves: []*trillian.LogLeaf{{}, withUnknownField(&trillian.LogLeaf{}).(*trillian.LogLeaf)}}, wantErr: true},
+		{desc: "unknownEnum", req: &trillian.QueueLeafRequest{Durability: 7}, wantErr: true},
+		{desc: "nestedUnknownEnum", req: &trillian.CreateTreeRequest{Tree: &trillian.Tree{TreeType: 42}}, wantErr: true},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			err := checkStrict(test.req)
+			if test.wantErr {
+				if got, want := status.Code(err), codes.InvalidArgument; got != want {
+					t.Errorf("checkStrict() = %v, want code %v", err, want)
+				}
+			} else if err != nil {
+				t.Errorf("checkStrict() = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestTrillianInterceptor_StrictValidation(t *testing.T) {
+	ctx := context.Background()
+	info := &grpc.UnaryServerInfo{FullMethod: "/quotapb.Quota/GetConfig"}
+	for _, strict := range []bool{false, true} {
+		intercept := New(nil /* admin */, quota.Noop(), false /* quotaDryRun */, nil /* mf */)
+		intercept.SetStrictValidation(strict)
+		handler := &fakeHandler{}
+		_, err := intercept.UnaryInterceptor(ctx, withUnknownField(&quotapb.GetConfigRequest{}), info, handler.run)
+		if strict {
+			if got, want := status.Code(err), codes.InvalidArgument; got != want {
+				t.Errorf("UnaryInterceptor(strict) returned err = %v, want code %v", err, want)
+			}
+		} else if err != nil {
+			t.Errorf("UnaryInterceptor() returned err = %v", err)
+		}
+		if handler.called == strict {
+			t.Errorf("UnaryInterceptor(strict=%v): handler called = %v, want %v", strict, handler.called, !strict)
+		}
+	}
+}
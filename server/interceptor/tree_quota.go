@@ -0,0 +1,90 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interceptor
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/quota"
+	"github.com/google/trillian/util/clock"
+)
+
+// treeQuotas enforces the quotas set on trees with SetTreeQuota. The quota of
+// a tree is read along with the tree for every request, so that changes apply
+// to the following requests, but the token buckets are kept in memory, so
+// each server applies the rates on its own.
+type treeQuotas struct {
+	ts clock.TimeSource
+
+	mu      sync.Mutex
+	buckets map[treeQuotaKey]*tokenBucket
+}
+
+// treeQuotaKey identifies the token bucket of the reads or writes of a tree.
+type treeQuotaKey struct {
+	treeID int64
+	kind   quota.Kind
+}
+
+// tokenBucket holds the tokens left in a bucket at the time of its last
+// request.
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func newTreeQuotas(ts clock.TimeSource) *treeQuotas {
+	return &treeQuotas{ts: ts, buckets: make(map[treeQuotaKey]*tokenBucket)}
+}
+
+// take returns whether the given number of tokens of the kind could be taken
+// from the bucket of the tree, according to its current quota. Requests for
+// more tokens than the bucket holds when full are always denied.
+func (q *treeQuotas) take(tree *trillian.Tree, kind quota.Kind, tokens int) bool {
+	rate, burst := tree.GetQuota().GetWriteTokensPerSecond(), tree.GetQuota().GetWriteBurst()
+	if kind == quota.Read {
+		rate, burst = tree.GetQuota().GetReadTokensPerSecond(), tree.GetQuota().GetReadBurst()
+	}
+	if rate <= 0 {
+		return true
+	}
+	capacity := float64(burst)
+	if burst == 0 {
+		capacity = math.Max(1, math.Floor(rate))
+	}
+
+	now := q.ts.Now()
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	key := treeQuotaKey{treeID: tree.TreeId, kind: kind}
+	b, ok := q.buckets[key]
+	if !ok {
+		b = &tokenBucket{tokens: capacity, last: now}
+		q.buckets[key] = b
+	} else if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += elapsed.Seconds() * rate
+		b.last = now
+	}
+	// The capacity may have shrunk since the last request.
+	b.tokens = math.Min(b.tokens, capacity)
+	if b.tokens < float64(tokens) {
+		return false
+	}
+	b.tokens -= float64(tokens)
+	return true
+}
@@ -0,0 +1,160 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interceptor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/trillian"
+	"github.com/google/trillian/quota"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/storage/testonly"
+	"github.com/google/trillian/util/clock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestTreeQuotas(t *testing.T) {
+	ts := clock.NewFake(time.Unix(1000, 0))
+	q := newTreeQuotas(ts)
+	tree := &trillian.Tree{TreeId: 1, Quota: &trillian.TreeQuota{ReadTokensPerSecond: 2, ReadBurst: 4, WriteTokensPerSecond: 0.5}}
+
+	take := func(kind quota.Kind, tokens int, want bool) {
+		t.Helper()
+		if got := q.take(tree, kind, tokens); got != want {
+			t.Fatalf("take(%v, %d): %v, want %v", kind, tokens, got, want)
+		}
+	}
+
+	// Buckets start full.
+	take(quota.Read, 3, true)
+	take(quota.Read, 2, false)
+	take(quota.Read, 1, true)
+	take(quota.Read, 1, false)
+	// The write burst defaults to at least one token.
+	take(quota.Write, 1, true)
+	take(quota.Write, 1, false)
+	take(quota.Write, 2, false)
+
+	ts.Set(ts.Now().Add(time.Second))
+	take(quota.Read, 2, true)
+	take(quota.Read, 1, false)
+	take(quota.Write, 1, false)
+	ts.Set(ts.Now().Add(time.Second))
+	take(quota.Write, 1, true)
+
+	// Buckets don't fill beyond their burst.
+	ts.Set(ts.Now().Add(time.Hour))
+	take(quota.Read, 5, false)
+	take(quota.Read, 4, true)
+
+	// Other trees have their own buckets, and trees without a rate aren't
+	// limited.
+	other := &trillian.Tree{TreeId: 2, Quota: tree.Quota}
+	if !q.take(other, quota.Read, 4) {
+		t.Error("take() for another tree: false, want true")
+	}
+	unlimited := &trillian.Tree{TreeId: 3, Quota: &trillian.TreeQuota{ReadTokensPerSecond: 1}}
+	if !q.take(unlimited, quota.Write, 1000) {
+		t.Error("take() without a write rate: false, want true")
+	}
+
+	// A lower burst applies straight away.
+	ts.Set(ts.Now().Add(time.Hour))
+	tree.Quota = &trillian.TreeQuota{ReadTokensPerSecond: 2, ReadBurst: 1}
+	take(quota.Read, 2, false)
+	take(quota.Read, 1, true)
+}
+
+func TestTrillianInterceptor_TreeQuota(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logTree := proto.Clone(testonly.LogTree).(*trillian.Tree)
+	logTree.TreeId = 10
+	logTree.Quota = &trillian.TreeQuota{WriteTokensPerSecond: 1, WriteBurst: 2}
+	admin := storage.NewMockAdminStorage(ctrl)
+	adminTX := storage.NewMockReadOnlyAdminTX(ctrl)
+	admin.EXPECT().Snapshot(gomock.Any()).AnyTimes().Return(adminTX, nil)
+	adminTX.EXPECT().GetTree(gomock.Any(), logTree.TreeId).AnyTimes().DoAndReturn(func(context.Context, int64) (*trillian.Tree, error) {
+		return proto.Clone(logTree).(*trillian.Tree), nil
+	})
+	adminTX.EXPECT().Close().AnyTimes().Return(nil)
+	adminTX.EXPECT().Commit().AnyTimes().Return(nil)
+
+	for _, test := range []struct {
+		desc        string
+		quotaDryRun bool
+		wantCode    codes.Code
+	}{
+		{desc: "enforced", wantCode: codes.ResourceExhausted},
+		{desc: "dryRun", quotaDryRun: true, wantCode: codes.OK},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			intercept := New(admin, quota.Noop(), test.quotaDryRun, nil /* mf */)
+			ts := clock.NewFake(time.Unix(1000, 0))
+			intercept.treeQuotas = newTreeQuotas(ts)
+			call := func(req interface{}, method string) error {
+				t.Helper()
+				_, err := intercept.NewProcessor().Before(context.Background(), req, method)
+				return err
+			}
+			write := func() error {
+				return call(&trillian.QueueLeafRequest{LogId: logTree.TreeId}, "/trillian.TrillianLog/QueueLeaf")
+			}
+
+			for i := 0; i < 2; i++ {
+				if err := write(); err != nil {
+					t.Fatalf("QueueLeaf %d: %v", i, err)
+				}
+			}
+			if err := write(); status.Code(err) != test.wantCode {
+				t.Fatalf("QueueLeaf beyond the burst: %v, want code %v", err, test.wantCode)
+			}
+			// Reads aren't limited by the write rate.
+			if err := call(&trillian.GetInclusionProofRequest{LogId: logTree.TreeId}, "/trillian.TrillianLog/GetInclusionProof"); err != nil {
+				t.Errorf("GetInclusionProof(): %v", err)
+			}
+			ts.Set(ts.Now().Add(time.Second))
+			if err := write(); err != nil {
+				t.Errorf("QueueLeaf after a second: %v", err)
+			}
+		})
+	}
+
+	// Changes of the quota apply to the next request.
+	intercept := New(admin, quota.Noop(), false /* quotaDryRun */, nil /* mf */)
+	intercept.treeQuotas = newTreeQuotas(clock.NewFake(time.Unix(1000, 0)))
+	write := func() error {
+		_, err := intercept.NewProcessor().Before(context.Background(), &trillian.QueueLeafRequest{LogId: logTree.TreeId}, "/trillian.TrillianLog/QueueLeaf")
+		return err
+	}
+	for i := 0; i < 2; i++ {
+		if err := write(); err != nil {
+			t.Fatalf("QueueLeaf %d: %v", i, err)
+		}
+	}
+	if err := write(); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("QueueLeaf beyond the burst: %v, want code %v", err, codes.ResourceExhausted)
+	}
+	logTree.Quota = nil
+	if err := write(); err != nil {
+		t.Errorf("QueueLeaf after the quota was removed: %v", err)
+	}
+}
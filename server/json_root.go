@@ -0,0 +1,41 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"github.com/google/trillian"
+	"github.com/google/trillian/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// jsonLogRoot returns the canonical JSON encoding of root, and its signature
+// by the inclusion promise signing key. The signature covers the root prefixed
+// with types.JSONLogRootSignatureContext, so that it can't be taken for that
+// of an inclusion promise.
+func (t *TrillianLogRPCServer) jsonLogRoot(tree *trillian.Tree, root *types.LogRootV1) ([]byte, []byte, error) {
+	if t.promiseSigner == nil {
+		return nil, nil, status.Errorf(codes.FailedPrecondition, "tree %d has signed JSON log roots, but no inclusion promise signing key is configured", tree.TreeId)
+	}
+	b, err := root.MarshalCanonicalJSON()
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "Could not marshal JSON log root: %v", err)
+	}
+	sig, err := signMessage(t.promiseSigner, types.JSONLogRootSignedMessage(b))
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "Could not sign JSON log root: %v", err)
+	}
+	return b, sig, nil
+}
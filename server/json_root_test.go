@@ -0,0 +1,97 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"reflect"
+	"testing"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/client"
+	"github.com/google/trillian/extension"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/storage/memory"
+	stestonly "github.com/google/trillian/storage/testonly"
+	"github.com/google/trillian/types"
+	"github.com/google/trillian/util/clock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGetLatestSignedLogRootJSON(t *testing.T) {
+	ctx := context.Background()
+	ts := memory.NewTreeStorage()
+	registry := extension.Registry{
+		AdminStorage: memory.NewAdminStorage(ts),
+		LogStorage:   memory.NewLogStorage(ts, nil),
+	}
+	tree := proto.Clone(stestonly.LogTree).(*trillian.Tree)
+	tree.SignJsonLogRoots = true
+	tree, err := storage.CreateTree(ctx, registry.AdminStorage, tree)
+	if err != nil {
+		t.Fatalf("CreateTree(): %v", err)
+	}
+	plain, err := storage.CreateTree(ctx, registry.AdminStorage, stestonly.LogTree)
+	if err != nil {
+		t.Fatalf("CreateTree(): %v", err)
+	}
+	server := NewTrillianLogRPCServer(registry, clock.System)
+	for _, tree := range []*trillian.Tree{tree, plain} {
+		if _, err := server.InitLog(ctx, &trillian.InitLogRequest{LogId: tree.TreeId}); err != nil {
+			t.Fatalf("InitLog(): %v", err)
+		}
+	}
+
+	req := &trillian.GetLatestSignedLogRootRequest{LogId: tree.TreeId}
+	if _, err := server.GetLatestSignedLogRoot(ctx, req); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("GetLatestSignedLogRoot() without signer: %v, want code %v", err, codes.FailedPrecondition)
+	}
+
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey(): %v", err)
+	}
+	server.EnableInclusionPromises(key)
+	resp, err := server.GetLatestSignedLogRoot(ctx, req)
+	if err != nil {
+		t.Fatalf("GetLatestSignedLogRoot(): %v", err)
+	}
+	got, err := client.VerifyJSONLogRoot(pub, resp)
+	if err != nil {
+		t.Fatalf("VerifyJSONLogRoot(): %v", err)
+	}
+	var want types.LogRootV1
+	if err := want.UnmarshalBinary(resp.SignedLogRoot.LogRoot); err != nil {
+		t.Fatalf("UnmarshalBinary(): %v", err)
+	}
+	if want.Metadata == nil {
+		want.Metadata = []byte{}
+	}
+	if !reflect.DeepEqual(got, &want) {
+		t.Errorf("VerifyJSONLogRoot(): %+v, want %+v", got, &want)
+	}
+
+	resp, err = server.GetLatestSignedLogRoot(ctx, &trillian.GetLatestSignedLogRootRequest{LogId: plain.TreeId})
+	if err != nil {
+		t.Fatalf("GetLatestSignedLogRoot(): %v", err)
+	}
+	if resp.JsonLogRoot != nil || resp.JsonLogRootSignature != nil {
+		t.Errorf("GetLatestSignedLogRoot() of tree without sign_json_log_roots returned JSON root %s", resp.JsonLogRoot)
+	}
+}
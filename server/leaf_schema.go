@@ -0,0 +1,66 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"github.com/google/trillian"
+	"github.com/google/trillian/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// leafValidatorEntry caches the validator built from the leaf schema of a tree.
+type leafValidatorEntry struct {
+	schema    *trillian.LeafSchema
+	validator *types.LeafValidator
+}
+
+// leafValidator returns the validator for the leaf schema of tree, nil if it
+// has none. Validators are cached until the schema of the tree changes.
+func (t *TrillianLogRPCServer) leafValidator(tree *trillian.Tree) (*types.LeafValidator, error) {
+	if tree.LeafSchema == nil {
+		return nil, nil
+	}
+	t.mu.RLock()
+	e, ok := t.leafValidators[tree.TreeId]
+	t.mu.RUnlock()
+	if ok && proto.Equal(e.schema, tree.LeafSchema) {
+		return e.validator, nil
+	}
+
+	v, err := types.NewLeafValidator(tree.LeafSchema)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "tree %d has an invalid leaf schema: %v", tree.TreeId, err)
+	}
+	// Storage may hand out trees which it later modifies in place.
+	schema := proto.Clone(tree.LeafSchema).(*trillian.LeafSchema)
+	t.mu.Lock()
+	t.leafValidators[tree.TreeId] = leafValidatorEntry{schema: schema, validator: v}
+	t.mu.Unlock()
+	return v, nil
+}
+
+// validateLeafValue checks the value of leaf against the leaf schema which v
+// validates, if v isn't nil.
+func validateLeafValue(v *types.LeafValidator, leaf *trillian.LogLeaf, errPrefix string) error {
+	if v == nil {
+		return nil
+	}
+	if err := v.Validate(leaf.LeafValue); err != nil {
+		return status.Errorf(codes.InvalidArgument, "%v.LeafValue: doesn't match the leaf schema of the tree: %v", errPrefix, err)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/extension"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/storage/memory"
+	stestonly "github.com/google/trillian/storage/testonly"
+	"github.com/google/trillian/testonly"
+	"github.com/google/trillian/util/clock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestQueueLeafLeafSchema(t *testing.T) {
+	ctx := context.Background()
+	ts := memory.NewTreeStorage()
+	registry := extension.Registry{
+		AdminStorage: memory.NewAdminStorage(ts),
+		LogStorage:   memory.NewLogStorage(ts, nil),
+	}
+	tree := proto.Clone(stestonly.LogTree).(*trillian.Tree)
+	tree.LeafSchema = testonly.EntryLeafSchema(trillian.LeafSchema_PROTO_JSON)
+	tree, err := storage.CreateTree(ctx, registry.AdminStorage, tree)
+	if err != nil {
+		t.Fatalf("CreateTree(): %v", err)
+	}
+	server := NewTrillianLogRPCServer(registry, clock.System)
+	if _, err := server.InitLog(ctx, &trillian.InitLogRequest{LogId: tree.TreeId}); err != nil {
+		t.Fatalf("InitLog(): %v", err)
+	}
+
+	queue := func(value string) error {
+		_, err := server.QueueLeaf(ctx, &trillian.QueueLeafRequest{
+			LogId: tree.TreeId,
+			Leaf:  &trillian.LogLeaf{LeafValue: []byte(value)},
+		})
+		return err
+	}
+	if err := queue(`{"name": "a", "size": "1"}`); err != nil {
+		t.Errorf("QueueLeaf(valid): %v", err)
+	}
+	err = queue(`{"name": "b", "colour": "red"}`)
+	if got, want := status.Code(err), codes.InvalidArgument; got != want {
+		t.Fatalf("QueueLeaf(unknown field): %v, want code %v", err, want)
+	}
+	if !strings.Contains(err.Error(), "QueueLeafRequest.Leaf.LeafValue") || !strings.Contains(err.Error(), "colour") {
+		t.Errorf("QueueLeaf(unknown field): %v, want error naming the leaf value and field", err)
+	}
+
+	// A changed schema replaces the cached one.
+	if _, err := storage.UpdateTree(ctx, registry.AdminStorage, tree.TreeId, func(tree *trillian.Tree) {
+		tree.LeafSchema.Encoding = trillian.LeafSchema_PROTO_BINARY
+	}); err != nil {
+		t.Fatalf("UpdateTree(): %v", err)
+	}
+	if err := queue(`{"name": "c"}`); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("QueueLeaf(JSON) with binary schema: %v, want code %v", err, codes.InvalidArgument)
+	}
+	if err := queue("\x0a\x01c"); err != nil {
+		t.Errorf("QueueLeaf(binary): %v", err)
+	}
+}
+
+func TestAddSequencedLeavesLeafSchema(t *testing.T) {
+	ctx := context.Background()
+	ts := memory.NewTreeStorage()
+	registry := extension.Registry{
+		AdminStorage: memory.NewAdminStorage(ts),
+		LogStorage:   memory.NewLogStorage(ts, nil),
+	}
+	tree := proto.Clone(stestonly.PreorderedLogTree).(*trillian.Tree)
+	tree.LeafSchema = testonly.EntryLeafSchema(trillian.LeafSchema_PROTO_JSON)
+	tree, err := storage.CreateTree(ctx, registry.AdminStorage, tree)
+	if err != nil {
+		t.Fatalf("CreateTree(): %v", err)
+	}
+	server := NewTrillianLogRPCServer(registry, clock.System)
+	if _, err := server.InitLog(ctx, &trillian.InitLogRequest{LogId: tree.TreeId}); err != nil {
+		t.Fatalf("InitLog(): %v", err)
+	}
+
+	_, err = server.AddSequencedLeaves(ctx, &trillian.AddSequencedLeavesRequest{
+		LogId: tree.TreeId,
+		Leaves: []*trillian.LogLeaf{
+			{LeafValue: []byte(`{"name": "a"}`), LeafIndex: 0},
+			{LeafValue: []byte(`{"name": 1}`), LeafIndex: 1},
+		},
+	})
+	if got, want := status.Code(err), codes.InvalidArgument; got != want {
+		t.Fatalf("AddSequencedLeaves(): %v, want code %v", err, want)
+	}
+	if !strings.Contains(err.Error(), "AddSequencedLeavesRequest.Leaves[1].LeafValue") {
+		t.Errorf("AddSequencedLeaves(): %v, want error naming the second leaf", err)
+	}
+}
@@ -16,8 +16,11 @@ package server
 
 import (
 	"context"
+	"crypto"
 	"fmt"
 	"strconv"
+	"sync"
+	"time"
 
 	"github.com/golang/glog"
 	"github.com/google/trillian"
@@ -27,11 +30,15 @@ import (
 	"github.com/google/trillian/trees"
 	"github.com/google/trillian/types"
 	"github.com/google/trillian/util/clock"
+	"github.com/google/trillian/witness"
 	"github.com/transparency-dev/merkle"
+	"github.com/transparency-dev/merkle/compact"
 	"github.com/transparency-dev/merkle/proof"
 	"github.com/transparency-dev/merkle/rfc6962"
+	"golang.org/x/mod/sumdb/note"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // TODO: There is no access control in the server yet and clients could easily modify
@@ -43,7 +50,9 @@ var (
 	optsLogInit            = trees.NewGetOpts(trees.Admin, trillian.TreeType_LOG, trillian.TreeType_PREORDERED_LOG)
 	optsLogRead            = trees.NewGetOpts(trees.Query, trillian.TreeType_LOG, trillian.TreeType_PREORDERED_LOG)
 	optsLogWrite           = trees.NewGetOpts(trees.QueueLog, trillian.TreeType_LOG)
+	optsPreorderedLogRead  = trees.NewGetOpts(trees.Query, trillian.TreeType_PREORDERED_LOG)
 	optsPreorderedLogWrite = trees.NewGetOpts(trees.SequenceLog, trillian.TreeType_PREORDERED_LOG)
+	optsLeafAnnotate       = trees.NewGetOpts(trees.QueueLog, trillian.TreeType_LOG, trillian.TreeType_PREORDERED_LOG)
 )
 
 // TrillianLogRPCServer implements the RPC API defined in the proto
@@ -53,6 +62,63 @@ type TrillianLogRPCServer struct {
 	leafCounter           monitoring.Counter
 	proofIndexPercentiles monitoring.Histogram
 	fetchedLeaves         monitoring.Counter
+	consistencyFailed     monitoring.Gauge
+	verificationFailures  monitoring.Counter
+	// promiseSigner signs inclusion promises, nil if they are disabled.
+	promiseSigner crypto.Signer
+	// checkpointSigner signs the checkpoints returned with the latest roots,
+	// nil if they are disabled.
+	checkpointSigner note.Signer
+	// checkpointOrigin is the prefix of the checkpoint origins, followed by
+	// the tree ID.
+	checkpointOrigin string
+	// witnessPolicy holds the witnesses which cosign the checkpoints, nil if
+	// cosigning is disabled.
+	witnessPolicy *witness.Policy
+	// queueBatchSize is the maximum number of leaves of a QueueLeavesStream
+	// call which are queued together.
+	queueBatchSize int
+	// streamBatchSize is the maximum number of leaves of a StreamLeavesByRange
+	// call which are read from storage together.
+	streamBatchSize int64
+	// streamChunkBytes is the size of leaves above which StreamLeavesByRange
+	// starts a new response.
+	streamChunkBytes int
+	// maxPendingMemoryAcks is the maximum number of leaves acknowledged with
+	// ACK_AFTER_MEMORY which aren't written to storage yet, and
+	// pendingMemoryAcks is their current number.
+	maxPendingMemoryAcks int64
+	pendingMemoryAcks    int64
+	// memoryAckWrites tracks the storage writes of these leaves, which time
+	// out after memoryAckTimeout.
+	memoryAckWrites   sync.WaitGroup
+	memoryAckTimeout  time.Duration
+	memoryAckFailures monitoring.Counter
+	// storageUsageRefresh is the interval at which the storage usage of logs
+	// with storage caps is read again.
+	storageUsageRefresh time.Duration
+	storageCapReached   monitoring.Gauge
+	storageCapRejected  monitoring.Counter
+
+	sessionsMu sync.Mutex
+	// sessions holds the audit sessions by ID.
+	sessions    map[string]*auditSession
+	sessionTTL  time.Duration
+	maxSessions int
+
+	mu sync.RWMutex
+	// inconsistent holds the logs which failed the consistency check.
+	inconsistent map[int64]error
+	// unchecked holds the logs whose consistency check couldn't complete
+	// since StartConsistencyChecks, with the error of the last attempt.
+	unchecked map[int64]error
+	// awaitingCheck is set by StartConsistencyChecks until all logs have
+	// been checked once. No log is served meanwhile.
+	awaitingCheck bool
+	// leafValidators holds the validators of the trees with leaf schemas.
+	leafValidators map[int64]leafValidatorEntry
+	// storageUsages holds the storage usage of the logs with storage caps.
+	storageUsages map[int64]storageUsage
 }
 
 // NewTrillianLogRPCServer creates a new RPC server backed by a LogStorageProvider.
@@ -78,6 +144,44 @@ func NewTrillianLogRPCServer(registry extension.Registry, timeSource clock.TimeS
 			"fetched_leaves",
 			"Count of individual leaves fetched through GetLeaves* calls",
 		),
+		consistencyFailed: mf.NewGauge(
+			"tree_consistency_check_failed",
+			"Set to 1 for logs which failed the consistency check, and are not served",
+			"logid",
+		),
+		verificationFailures: mf.NewCounter(
+			"verification_failures_reported",
+			"Number of failures to verify data served by the log reported by clients",
+			"logid", "failure",
+		),
+		memoryAckFailures: mf.NewCounter(
+			"memory_ack_failed_leaves",
+			"Number of leaves acknowledged with ACK_AFTER_MEMORY which failed to be stored",
+			"logid",
+		),
+		storageCapReached: mf.NewGauge(
+			"tree_storage_cap_reached",
+			"Set to 1 for the storage caps which logs reached, as of the last read of their usage",
+			"logid", "cap",
+		),
+		storageCapRejected: mf.NewCounter(
+			"storage_cap_rejected_leaves",
+			"Number of leaves not queued as their log reached a hard storage cap",
+			"logid",
+		),
+		queueBatchSize:       DefaultQueueBatchSize,
+		streamBatchSize:      defaultStreamBatchSize,
+		streamChunkBytes:     defaultStreamChunkBytes,
+		maxPendingMemoryAcks: DefaultMaxPendingMemoryAcks,
+		memoryAckTimeout:     DefaultMemoryAckWriteTimeout,
+		sessions:             make(map[string]*auditSession),
+		sessionTTL:           DefaultAuditSessionTTL,
+		maxSessions:          DefaultMaxAuditSessions,
+		inconsistent:         make(map[int64]error),
+		unchecked:            make(map[int64]error),
+		leafValidators:       make(map[int64]leafValidatorEntry),
+		storageUsageRefresh:  DefaultStorageUsageRefresh,
+		storageUsages:        make(map[int64]storageUsage),
 	}
 }
 
@@ -100,13 +204,28 @@ func (t *TrillianLogRPCServer) QueueLeaf(ctx context.Context, req *trillian.Queu
 	if err != nil {
 		return nil, err
 	}
+	// Check before queueing that a promise can be made, if one is due.
+	if tree.MaxMergeDelay != nil && t.promiseSigner == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "tree %d has a max merge delay, but no inclusion promise signing key is configured", tree.TreeId)
+	}
+	if err := t.checkDurability(tree, req.Durability); err != nil {
+		return nil, err
+	}
+	validator, err := t.leafValidator(tree)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateLeafValue(validator, req.Leaf, "QueueLeafRequest.Leaf"); err != nil {
+		return nil, err
+	}
 
 	req.Leaf.MerkleLeafHash = hasher.HashLeaf(req.Leaf.LeafValue)
 	if len(req.Leaf.LeafIdentityHash) == 0 {
 		req.Leaf.LeafIdentityHash = req.Leaf.MerkleLeafHash
 	}
 
-	ret, err := t.registry.LogStorage.QueueLeaves(trees.NewContext(ctx, tree), tree, []*trillian.LogLeaf{req.Leaf}, t.timeSource.Now())
+	now := t.timeSource.Now()
+	ret, err := t.queueLeaves(ctx, tree, []*trillian.LogLeaf{req.Leaf}, now, req.Durability)
 	if err != nil {
 		return nil, err
 	}
@@ -117,7 +236,17 @@ func (t *TrillianLogRPCServer) QueueLeaf(ctx context.Context, req *trillian.Queu
 	if len(ret) != 1 {
 		return nil, status.Errorf(codes.Internal, "unexpected count of leaves %d", len(ret))
 	}
-	return &trillian.QueueLeafResponse{QueuedLeaf: ret[0]}, nil
+	resp := &trillian.QueueLeafResponse{QueuedLeaf: ret[0]}
+	if tree.MaxMergeDelay != nil {
+		leaf := ret[0].Leaf
+		if leaf == nil {
+			leaf = req.Leaf
+		}
+		if resp.InclusionPromise, err = t.inclusionPromise(tree, hasher, leaf, now); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
 }
 
 func hashLeaves(leaves []*trillian.LogLeaf, hasher merkle.LogHasher) {
@@ -142,28 +271,49 @@ func (t *TrillianLogRPCServer) AddSequencedLeaves(ctx context.Context, req *tril
 	if err != nil {
 		return nil, err
 	}
+	validator, err := t.leafValidator(tree)
+	if err != nil {
+		return nil, err
+	}
+	for i, leaf := range req.Leaves {
+		if err := validateLeafValue(validator, leaf, fmt.Sprintf("AddSequencedLeavesRequest.Leaves[%d]", i)); err != nil {
+			return nil, err
+		}
+	}
+
+	leaves, err := t.addSequencedLeaves(ctx, tree, hasher, req.Leaves)
+	if err != nil {
+		return nil, err
+	}
+	return &trillian.AddSequencedLeavesResponse{Results: leaves}, nil
+}
 
-	hashLeaves(req.Leaves, hasher)
+// addSequencedLeaves adds validated leaves to a pre-ordered log, and returns
+// their results.
+func (t *TrillianLogRPCServer) addSequencedLeaves(ctx context.Context, tree *trillian.Tree, hasher merkle.LogHasher, leaves []*trillian.LogLeaf) ([]*trillian.QueuedLogLeaf, error) {
+	hashLeaves(leaves, hasher)
 
 	ctx = trees.NewContext(ctx, tree)
-	leaves, err := t.registry.LogStorage.AddSequencedLeaves(ctx, tree, req.Leaves, t.timeSource.Now())
+	results, err := t.registry.LogStorage.AddSequencedLeaves(ctx, tree, leaves, t.timeSource.Now())
 	if err != nil {
 		return nil, err
 	}
-	if got, want := len(leaves), len(req.Leaves); got != want {
+	if got, want := len(results), len(leaves); got != want {
 		return nil, status.Errorf(codes.Internal, "AddSequencedLeaves returned %d leaves, want: %d", got, want)
 	}
+	if err := t.applyConflictPolicy(ctx, tree, leaves, results); err != nil {
+		return nil, err
+	}
 
-	label := strconv.FormatInt(req.LogId, 10)
-	for _, l := range leaves {
+	label := strconv.FormatInt(tree.TreeId, 10)
+	for _, l := range results {
 		if l.Status == nil || l.Status.Code == int32(codes.OK) {
 			t.leafCounter.Inc(label, "inserted")
 		} else {
 			t.leafCounter.Inc(label, "skipped")
 		}
 	}
-
-	return &trillian.AddSequencedLeavesResponse{Results: leaves}, nil
+	return results, nil
 }
 
 // GetInclusionProof obtains the proof of inclusion in the tree for a leaf that has been sequenced.
@@ -171,6 +321,13 @@ func (t *TrillianLogRPCServer) AddSequencedLeaves(ctx context.Context, req *tril
 func (t *TrillianLogRPCServer) GetInclusionProof(ctx context.Context, req *trillian.GetInclusionProofRequest) (*trillian.GetInclusionProofResponse, error) {
 	ctx, spanEnd := spanFor(ctx, "GetInclusionProof")
 	defer spanEnd()
+	session, err := t.auditSession(req.LogId, req.AuditSessionId)
+	if err != nil {
+		return nil, err
+	}
+	if err := session.pinTreeSize(&req.TreeSize, "GetInclusionProofRequest.TreeSize"); err != nil {
+		return nil, err
+	}
 	if err := validateGetInclusionProofRequest(req); err != nil {
 		return nil, err
 	}
@@ -199,13 +356,13 @@ func (t *TrillianLogRPCServer) GetInclusionProof(ctx context.Context, req *trill
 		return nil, status.Errorf(codes.Internal, "Could not read current log root: %v", err)
 	}
 
-	r := &trillian.GetInclusionProofResponse{SignedLogRoot: slr}
+	r := &trillian.GetInclusionProofResponse{SignedLogRoot: session.root(slr)}
 
 	if uint64(req.TreeSize) > root.TreeSize {
 		return r, nil
 	}
 
-	proof, err := getInclusionProofForLeafIndex(ctx, tx, hasher, uint64(req.TreeSize), uint64(req.LeafIndex))
+	proof, err := getInclusionProofForLeafIndex(ctx, tx, hasher, uint64(req.TreeSize), uint64(req.LeafIndex), req.IncludeNodeIds)
 	if err != nil {
 		return nil, err
 	}
@@ -225,6 +382,13 @@ func (t *TrillianLogRPCServer) GetInclusionProof(ctx context.Context, req *trill
 func (t *TrillianLogRPCServer) GetInclusionProofByHash(ctx context.Context, req *trillian.GetInclusionProofByHashRequest) (*trillian.GetInclusionProofByHashResponse, error) {
 	ctx, spanEnd := spanFor(ctx, "GetInclusionProofByHash")
 	defer spanEnd()
+	session, err := t.auditSession(req.LogId, req.AuditSessionId)
+	if err != nil {
+		return nil, err
+	}
+	if err := session.pinTreeSize(&req.TreeSize, "GetInclusionProofByHashRequest.TreeSize"); err != nil {
+		return nil, err
+	}
 
 	tree, hasher, err := t.getTreeAndHasher(ctx, req.LogId, optsLogRead)
 	if err != nil {
@@ -267,7 +431,7 @@ func (t *TrillianLogRPCServer) GetInclusionProofByHash(ctx context.Context, req
 		if leaf.LeafIndex >= req.TreeSize {
 			continue
 		}
-		proof, err := getInclusionProofForLeafIndex(ctx, tx, hasher, uint64(req.TreeSize), uint64(leaf.LeafIndex))
+		proof, err := getInclusionProofForLeafIndex(ctx, tx, hasher, uint64(req.TreeSize), uint64(leaf.LeafIndex), req.IncludeNodeIds)
 		if err != nil {
 			return nil, err
 		}
@@ -285,17 +449,84 @@ func (t *TrillianLogRPCServer) GetInclusionProofByHash(ctx context.Context, req
 
 	// TODO(gbelvin): Rename "Proof" -> "Proofs"
 	return &trillian.GetInclusionProofByHashResponse{
-		SignedLogRoot: slr,
+		SignedLogRoot: session.root(slr),
 		Proof:         proofs,
 	}, nil
 }
 
+// GetInclusionProofsAtSizes obtains proofs of inclusion of a leaf at several tree sizes, built
+// from one read of the nodes of all the proofs.
+func (t *TrillianLogRPCServer) GetInclusionProofsAtSizes(ctx context.Context, req *trillian.GetInclusionProofsAtSizesRequest) (*trillian.GetInclusionProofsAtSizesResponse, error) {
+	ctx, spanEnd := spanFor(ctx, "GetInclusionProofsAtSizes")
+	defer spanEnd()
+	if err := validateGetInclusionProofsAtSizesRequest(req); err != nil {
+		return nil, err
+	}
+
+	tree, hasher, err := t.getTreeAndHasher(ctx, req.LogId, optsLogRead)
+	if err != nil {
+		return nil, err
+	}
+	ctx = trees.NewContext(ctx, tree)
+
+	tx, err := t.snapshotForTree(ctx, tree, "GetInclusionProofsAtSizes")
+	if err != nil {
+		return nil, err
+	}
+	defer t.closeAndLog(ctx, tree.TreeId, tx, "GetInclusionProofsAtSizes")
+
+	slr, err := tx.LatestSignedLogRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var root types.LogRootV1
+	if err := root.UnmarshalBinary(slr.LogRoot); err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not read current log root: %v", err)
+	}
+
+	r := &trillian.GetInclusionProofsAtSizesResponse{SignedLogRoot: slr}
+	pns := make([]proof.Nodes, 0, len(req.TreeSizes))
+	for _, size := range req.TreeSizes {
+		if uint64(size) > root.TreeSize {
+			return r, nil
+		}
+		nodes, err := proof.Inclusion(uint64(req.LeafIndex), uint64(size))
+		if err != nil {
+			return nil, err
+		}
+		pns = append(pns, nodes)
+	}
+	proofs, err := fetchNodesAndBuildProofs(ctx, tx, hasher.HashChildren, uint64(req.LeafIndex), pns)
+	if err != nil {
+		return nil, err
+	}
+	if req.IncludeNodeIds {
+		for i, pn := range pns {
+			proofs[i].NodeIds = proofNodeIDs(pn)
+		}
+	}
+	t.recordIndexPercent(req.LeafIndex, root.TreeSize)
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	r.Proofs = proofs
+	return r, nil
+}
+
 // GetConsistencyProof obtains a proof that two versions of the tree are consistent with each
 // other and that the later tree includes all the entries of the prior one. For more details
 // see the example trees in RFC 6962.
 func (t *TrillianLogRPCServer) GetConsistencyProof(ctx context.Context, req *trillian.GetConsistencyProofRequest) (*trillian.GetConsistencyProofResponse, error) {
 	ctx, spanEnd := spanFor(ctx, "GetConsistencyProof")
 	defer spanEnd()
+	session, err := t.auditSession(req.LogId, req.AuditSessionId)
+	if err != nil {
+		return nil, err
+	}
+	if err := session.pinTreeSize(&req.SecondTreeSize, "GetConsistencyProofRequest.SecondTreeSize"); err != nil {
+		return nil, err
+	}
 	if err := validateGetConsistencyProofRequest(req); err != nil {
 		return nil, err
 	}
@@ -321,13 +552,13 @@ func (t *TrillianLogRPCServer) GetConsistencyProof(ctx context.Context, req *tri
 	if err := root.UnmarshalBinary(slr.LogRoot); err != nil {
 		return nil, status.Errorf(codes.Internal, "Could not read current log root: %v", err)
 	}
-	r := &trillian.GetConsistencyProofResponse{SignedLogRoot: slr}
+	r := &trillian.GetConsistencyProofResponse{SignedLogRoot: session.root(slr)}
 
 	if uint64(req.SecondTreeSize) > root.TreeSize {
 		return r, nil
 	}
 	// Try to get consistency proof
-	proof, err := tryGetConsistencyProof(ctx, uint64(req.FirstTreeSize), uint64(req.SecondTreeSize), tx, hasher)
+	proof, err := tryGetConsistencyProof(ctx, uint64(req.FirstTreeSize), uint64(req.SecondTreeSize), tx, hasher, req.IncludeNodeIds)
 	if err != nil {
 		return nil, err
 	}
@@ -368,6 +599,16 @@ func (t *TrillianLogRPCServer) GetLatestSignedLogRoot(ctx context.Context, req *
 	}
 
 	r := &trillian.GetLatestSignedLogRootResponse{SignedLogRoot: slr}
+	if t.checkpointSigner != nil {
+		if r.Checkpoint, err = t.checkpoint(tree.TreeId, &root); err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not sign checkpoint: %v", err)
+		}
+	}
+	if tree.SignJsonLogRoots {
+		if r.JsonLogRoot, r.JsonLogRootSignature, err = t.jsonLogRoot(tree, &root); err != nil {
+			return nil, err
+		}
+	}
 
 	if req.FirstTreeSize == 0 {
 		// no need to get consistency proof in this case
@@ -386,7 +627,7 @@ func (t *TrillianLogRPCServer) GetLatestSignedLogRoot(ctx context.Context, req *
 		return nil, err
 	}
 	// Try to get consistency proof
-	proof, err := tryGetConsistencyProof(ctx, uint64(reqProof.FirstTreeSize), uint64(reqProof.SecondTreeSize), tx, hasher)
+	proof, err := tryGetConsistencyProof(ctx, uint64(reqProof.FirstTreeSize), uint64(reqProof.SecondTreeSize), tx, hasher, false)
 	if err != nil {
 		return nil, err
 	}
@@ -398,7 +639,7 @@ func (t *TrillianLogRPCServer) GetLatestSignedLogRoot(ctx context.Context, req *
 	return r, nil
 }
 
-func tryGetConsistencyProof(ctx context.Context, firstTreeSize, secondTreeSize uint64, tx storage.ReadOnlyLogTreeTX, hasher merkle.LogHasher) (*trillian.Proof, error) {
+func tryGetConsistencyProof(ctx context.Context, firstTreeSize, secondTreeSize uint64, tx storage.ReadOnlyLogTreeTX, hasher merkle.LogHasher, withIDs bool) (*trillian.Proof, error) {
 	nodes, err := proof.Consistency(firstTreeSize, secondTreeSize)
 	if err != nil {
 		return nil, err
@@ -407,6 +648,9 @@ func tryGetConsistencyProof(ctx context.Context, firstTreeSize, secondTreeSize u
 	if err != nil {
 		return nil, err
 	}
+	if withIDs {
+		proof.NodeIds = proofNodeIDs(nodes)
+	}
 	return proof, nil
 }
 
@@ -419,6 +663,10 @@ func (t *TrillianLogRPCServer) GetLeavesByRange(ctx context.Context, req *trilli
 	if err := validateGetLeavesByRangeRequest(req); err != nil {
 		return nil, err
 	}
+	session, err := t.auditSession(req.LogId, req.AuditSessionId)
+	if err != nil {
+		return nil, err
+	}
 
 	tree, ctx, err := t.getTreeAndContext(ctx, req.LogId, optsLogRead)
 	if err != nil {
@@ -439,15 +687,26 @@ func (t *TrillianLogRPCServer) GetLeavesByRange(ctx context.Context, req *trilli
 		return nil, status.Errorf(codes.Internal, "Could not read current log root: %v", err)
 	}
 
-	r := &trillian.GetLeavesByRangeResponse{SignedLogRoot: slr}
+	r := &trillian.GetLeavesByRangeResponse{SignedLogRoot: session.root(slr)}
 
-	if req.StartIndex < int64(root.TreeSize) {
-		leaves, err := tx.GetLeavesByRange(ctx, req.StartIndex, req.Count)
+	start, size, pinned, err := pageRange(req, session.limit(int64(root.TreeSize)))
+	if err != nil {
+		return nil, err
+	}
+	if start < size {
+		count := req.Count
+		if (session != nil || pinned) && count > size-start {
+			count = size - start
+		}
+		leaves, err := getLeavesByRange(ctx, tx, start, count, req.OmitLeafData)
 		if err != nil {
 			return nil, err
 		}
 		t.fetchedLeaves.Add(float64(len(leaves)))
 		r.Leaves = leaves
+		if next := start + int64(len(leaves)); len(leaves) > 0 && next < size {
+			r.NextPageToken = pageToken{treeID: tree.TreeId, treeSize: size, next: next}.encode()
+		}
 	}
 
 	if err := t.commitAndLog(ctx, req.LogId, tx, "GetLeavesByRange"); err != nil {
@@ -457,11 +716,327 @@ func (t *TrillianLogRPCServer) GetLeavesByRange(ctx context.Context, req *trilli
 	return r, nil
 }
 
+// getLeavesByRange reads a range of leaves, without their LeafValue and
+// ExtraData if omitData is true. If the storage can't skip reading the data,
+// it's dropped from the leaves read.
+func getLeavesByRange(ctx context.Context, tx storage.ReadOnlyLogTreeTX, start, count int64, omitData bool) ([]*trillian.LogLeaf, error) {
+	if !omitData {
+		return tx.GetLeavesByRange(ctx, start, count)
+	}
+	if htx, err := storage.AsLeafHashesTX(tx); err == nil {
+		return htx.GetLeafHashesByRange(ctx, start, count)
+	}
+	leaves, err := tx.GetLeavesByRange(ctx, start, count)
+	if err != nil {
+		return nil, err
+	}
+	// The leaves may be shared with the storage, so they are copied rather
+	// than modified.
+	ret := make([]*trillian.LogLeaf, 0, len(leaves))
+	for _, leaf := range leaves {
+		ret = append(ret, &trillian.LogLeaf{
+			MerkleLeafHash:     leaf.MerkleLeafHash,
+			LeafIdentityHash:   leaf.LeafIdentityHash,
+			LeafIndex:          leaf.LeafIndex,
+			QueueTimestamp:     leaf.QueueTimestamp,
+			IntegrateTimestamp: leaf.IntegrateTimestamp,
+		})
+	}
+	return ret, nil
+}
+
+// GetCompactRange returns the hashes of the compact range covering the
+// requested range of leaves. The nodes of a compact range are perfect subtrees,
+// which don't change once all their leaves are integrated, so they are the
+// same under any tree size which is at least the end of the range.
+func (t *TrillianLogRPCServer) GetCompactRange(ctx context.Context, req *trillian.GetCompactRangeRequest) (*trillian.GetCompactRangeResponse, error) {
+	ctx, spanEnd := spanFor(ctx, "GetCompactRange")
+	defer spanEnd()
+	if err := validateGetCompactRangeRequest(req); err != nil {
+		return nil, err
+	}
+
+	tree, ctx, err := t.getTreeAndContext(ctx, req.LogId, optsLogRead)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := t.snapshotForTree(ctx, tree, "GetCompactRange")
+	if err != nil {
+		return nil, err
+	}
+	defer t.closeAndLog(ctx, tree.TreeId, tx, "GetCompactRange")
+
+	slr, err := tx.LatestSignedLogRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var root types.LogRootV1
+	if err := root.UnmarshalBinary(slr.LogRoot); err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not read current log root: %v", err)
+	}
+
+	r := &trillian.GetCompactRangeResponse{SignedLogRoot: slr}
+	if uint64(req.TreeSize) > root.TreeSize {
+		return r, nil
+	}
+	if ids := compact.RangeNodes(uint64(req.Begin), uint64(req.End), nil); len(ids) > 0 {
+		nodes, err := fetchNodes(ctx, tx, ids)
+		if err != nil {
+			return nil, err
+		}
+		r.Hashes = make([][]byte, 0, len(nodes))
+		for _, node := range nodes {
+			r.Hashes = append(r.Hashes, node.Hash)
+		}
+	}
+
+	if err := t.commitAndLog(ctx, req.LogId, tx, "GetCompactRange"); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// GetTile returns a tile of the Merkle tree in the tile layout of
+// golang.org/x/mod/sumdb/tlog, i.e. the hashes of the nodes at the bottom
+// level of the tile.
+func (t *TrillianLogRPCServer) GetTile(ctx context.Context, req *trillian.GetTileRequest) (*trillian.GetTileResponse, error) {
+	ctx, spanEnd := spanFor(ctx, "GetTile")
+	defer spanEnd()
+	if err := validateGetTileRequest(req); err != nil {
+		return nil, err
+	}
+
+	tree, ctx, err := t.getTreeAndContext(ctx, req.LogId, optsLogRead)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := t.snapshotForTree(ctx, tree, "GetTile")
+	if err != nil {
+		return nil, err
+	}
+	defer t.closeAndLog(ctx, tree.TreeId, tx, "GetTile")
+
+	slr, err := tx.LatestSignedLogRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var root types.LogRootV1
+	if err := root.UnmarshalBinary(slr.LogRoot); err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not read current log root: %v", err)
+	}
+
+	r := &trillian.GetTileResponse{SignedLogRoot: slr}
+	level := uint(req.Level) * uint(req.Height)
+	begin := uint64(req.Index) << uint(req.Height)
+	end := begin + uint64(req.Width)
+	if end<<level > root.TreeSize {
+		return r, nil
+	}
+	ids := make([]compact.NodeID, 0, req.Width)
+	for index := begin; index < end; index++ {
+		ids = append(ids, compact.NewNodeID(level, index))
+	}
+	nodes, err := fetchNodes(ctx, tx, ids)
+	if err != nil {
+		return nil, err
+	}
+	r.Data = make([]byte, 0, len(nodes)*rfc6962.DefaultHasher.Size())
+	for _, node := range nodes {
+		r.Data = append(r.Data, node.Hash...)
+	}
+
+	if err := t.commitAndLog(ctx, req.LogId, tx, "GetTile"); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// UpdateLeafExtraData replaces the extra data of a sequenced leaf, which is
+// not covered by the Merkle leaf hash, and records the update in the audit
+// trail of the leaf.
+func (t *TrillianLogRPCServer) UpdateLeafExtraData(ctx context.Context, req *trillian.UpdateLeafExtraDataRequest) (*trillian.UpdateLeafExtraDataResponse, error) {
+	ctx, spanEnd := spanFor(ctx, "UpdateLeafExtraData")
+	defer spanEnd()
+	if err := validateUpdateLeafExtraDataRequest(req); err != nil {
+		return nil, err
+	}
+
+	tree, ctx, err := t.getTreeAndContext(ctx, req.LogId, optsLeafAnnotate)
+	if err != nil {
+		return nil, err
+	}
+	var update *trillian.LeafExtraDataUpdate
+	err = t.registry.LogStorage.ReadWriteTransaction(ctx, tree, func(ctx context.Context, tx storage.LogTreeTX) error {
+		atx, err := storage.AsLeafAnnotationTX(tx)
+		if err != nil {
+			return err
+		}
+		update, err = atx.UpdateLeafExtraData(ctx, req.LeafIndex, req.ExtraData, req.Reason, t.timeSource.Now())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &trillian.UpdateLeafExtraDataResponse{Update: update}, nil
+}
+
+// ListLeafExtraDataUpdates returns the audit trail of the extra data of a
+// sequenced leaf.
+func (t *TrillianLogRPCServer) ListLeafExtraDataUpdates(ctx context.Context, req *trillian.ListLeafExtraDataUpdatesRequest) (*trillian.ListLeafExtraDataUpdatesResponse, error) {
+	ctx, spanEnd := spanFor(ctx, "ListLeafExtraDataUpdates")
+	defer spanEnd()
+	if err := validateListLeafExtraDataUpdatesRequest(req); err != nil {
+		return nil, err
+	}
+
+	tree, ctx, err := t.getTreeAndContext(ctx, req.LogId, optsLogRead)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := t.snapshotForTree(ctx, tree, "ListLeafExtraDataUpdates")
+	if err != nil {
+		return nil, err
+	}
+	defer t.closeAndLog(ctx, tree.TreeId, tx, "ListLeafExtraDataUpdates")
+
+	atx, err := storage.AsLeafAnnotationTX(tx)
+	if err != nil {
+		return nil, err
+	}
+	updates, err := atx.ListLeafExtraDataUpdates(ctx, req.LeafIndex)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.commitAndLog(ctx, req.LogId, tx, "ListLeafExtraDataUpdates"); err != nil {
+		return nil, err
+	}
+	return &trillian.ListLeafExtraDataUpdatesResponse{Updates: updates}, nil
+}
+
+// GetDailyLogStats returns the daily counters of the leaves integrated into a
+// log, as maintained by the sequencer.
+func (t *TrillianLogRPCServer) GetDailyLogStats(ctx context.Context, req *trillian.GetDailyLogStatsRequest) (*trillian.GetDailyLogStatsResponse, error) {
+	ctx, spanEnd := spanFor(ctx, "GetDailyLogStats")
+	defer spanEnd()
+	if err := validateGetDailyLogStatsRequest(req); err != nil {
+		return nil, err
+	}
+
+	tree, ctx, err := t.getTreeAndContext(ctx, req.LogId, optsLogRead)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := t.snapshotForTree(ctx, tree, "GetDailyLogStats")
+	if err != nil {
+		return nil, err
+	}
+	defer t.closeAndLog(ctx, tree.TreeId, tx, "GetDailyLogStats")
+
+	stx, err := storage.AsLogStatsTX(tx)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := stx.ListDailyStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.commitAndLog(ctx, req.LogId, tx, "GetDailyLogStats"); err != nil {
+		return nil, err
+	}
+
+	r := &trillian.GetDailyLogStatsResponse{}
+	for _, s := range stats {
+		day := s.GetDay().AsTime()
+		if req.Start != nil && day.Before(req.Start.AsTime()) {
+			continue
+		}
+		if req.End != nil && !day.Before(req.End.AsTime()) {
+			continue
+		}
+		r.Stats = append(r.Stats, s)
+	}
+	return r, nil
+}
+
+// ReportVerificationFailure stores a client's report of a failure to verify
+// data served by the log, and counts it in the metrics to alert operators.
+func (t *TrillianLogRPCServer) ReportVerificationFailure(ctx context.Context, req *trillian.ReportVerificationFailureRequest) (*trillian.ReportVerificationFailureResponse, error) {
+	ctx, spanEnd := spanFor(ctx, "ReportVerificationFailure")
+	defer spanEnd()
+	if err := validateReportVerificationFailureRequest(req); err != nil {
+		return nil, err
+	}
+
+	// Reports are accepted for logs which are only served for reading, as
+	// that's what clients verify.
+	tree, ctx, err := t.getTreeAndContext(ctx, req.LogId, optsLogRead)
+	if err != nil {
+		return nil, err
+	}
+	report := &trillian.VerificationFailureReport{
+		Failure:         req.Failure,
+		RequestId:       req.RequestId,
+		LogRoot:         req.LogRoot,
+		Details:         req.Details,
+		ReportTimestamp: timestamppb.New(t.timeSource.Now()),
+	}
+	err = t.registry.LogStorage.ReadWriteTransaction(ctx, tree, func(ctx context.Context, tx storage.LogTreeTX) error {
+		vtx, err := storage.AsVerificationFailureTX(tx)
+		if err != nil {
+			return err
+		}
+		return vtx.AddVerificationFailureReport(ctx, report)
+	})
+	if err != nil {
+		return nil, err
+	}
+	glog.Warningf("%d: client reported %v for request %q: %s", req.LogId, req.Failure, req.RequestId, req.Details)
+	t.verificationFailures.Inc(strconv.FormatInt(req.LogId, 10), req.Failure.String())
+	return &trillian.ReportVerificationFailureResponse{}, nil
+}
+
+// ListVerificationFailureReports returns the stored verification failure
+// reports of a log.
+func (t *TrillianLogRPCServer) ListVerificationFailureReports(ctx context.Context, req *trillian.ListVerificationFailureReportsRequest) (*trillian.ListVerificationFailureReportsResponse, error) {
+	ctx, spanEnd := spanFor(ctx, "ListVerificationFailureReports")
+	defer spanEnd()
+
+	tree, ctx, err := t.getTreeAndContext(ctx, req.LogId, optsLogRead)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := t.snapshotForTree(ctx, tree, "ListVerificationFailureReports")
+	if err != nil {
+		return nil, err
+	}
+	defer t.closeAndLog(ctx, tree.TreeId, tx, "ListVerificationFailureReports")
+
+	vtx, err := storage.AsVerificationFailureTX(tx)
+	if err != nil {
+		return nil, err
+	}
+	reports, err := vtx.ListVerificationFailureReports(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.commitAndLog(ctx, req.LogId, tx, "ListVerificationFailureReports"); err != nil {
+		return nil, err
+	}
+	return &trillian.ListVerificationFailureReportsResponse{Reports: reports}, nil
+}
+
 // GetEntryAndProof returns both a Merkle Leaf entry and an inclusion proof for a given index
 // and tree size.
 func (t *TrillianLogRPCServer) GetEntryAndProof(ctx context.Context, req *trillian.GetEntryAndProofRequest) (*trillian.GetEntryAndProofResponse, error) {
 	ctx, spanEnd := spanFor(ctx, "GetEntryAndProof")
 	defer spanEnd()
+	session, err := t.auditSession(req.LogId, req.AuditSessionId)
+	if err != nil {
+		return nil, err
+	}
+	if err := session.pinTreeSize(&req.TreeSize, "GetEntryAndProofRequest.TreeSize"); err != nil {
+		return nil, err
+	}
 	if err := validateGetEntryAndProofRequest(req); err != nil {
 		return nil, err
 	}
@@ -490,7 +1065,7 @@ func (t *TrillianLogRPCServer) GetEntryAndProof(ctx context.Context, req *trilli
 		return nil, status.Errorf(codes.Internal, "Could not read current log root: %v", err)
 	}
 
-	r := &trillian.GetEntryAndProofResponse{SignedLogRoot: slr}
+	r := &trillian.GetEntryAndProofResponse{SignedLogRoot: session.root(slr)}
 
 	if req.TreeSize > int64(root.TreeSize) && req.LeafIndex < int64(root.TreeSize) {
 		// return latest proof we can manage
@@ -498,13 +1073,13 @@ func (t *TrillianLogRPCServer) GetEntryAndProof(ctx context.Context, req *trilli
 	}
 
 	if req.TreeSize <= int64(root.TreeSize) {
-		proof, err := getInclusionProofForLeafIndex(ctx, tx, hasher, uint64(req.TreeSize), uint64(req.LeafIndex))
+		proof, err := getInclusionProofForLeafIndex(ctx, tx, hasher, uint64(req.TreeSize), uint64(req.LeafIndex), req.IncludeNodeIds)
 		if err != nil {
 			return nil, err
 		}
 
 		// We also need the leaf entry
-		leaves, err := tx.GetLeavesByRange(ctx, req.LeafIndex, 1)
+		leaves, err := getLeavesByRange(ctx, tx, req.LeafIndex, 1, req.OmitLeafData)
 		if err != nil {
 			return nil, err
 		}
@@ -544,13 +1119,20 @@ func (t *TrillianLogRPCServer) closeAndLog(ctx context.Context, logID int64, tx
 
 // getInclusionProofForLeafIndex is used by multiple handlers. It does the storage fetching
 // and makes additional checks on the returned proof. Returns a Proof suitable for inclusion in
-// an RPC response
-func getInclusionProofForLeafIndex(ctx context.Context, tx storage.ReadOnlyLogTreeTX, hasher merkle.LogHasher, size, leafIndex uint64) (*trillian.Proof, error) {
+// an RPC response, with the positions of its nodes if withIDs is set.
+func getInclusionProofForLeafIndex(ctx context.Context, tx storage.ReadOnlyLogTreeTX, hasher merkle.LogHasher, size, leafIndex uint64, withIDs bool) (*trillian.Proof, error) {
 	nodes, err := proof.Inclusion(leafIndex, size)
 	if err != nil {
 		return nil, err
 	}
-	return fetchNodesAndBuildProof(ctx, tx, hasher.HashChildren, leafIndex, nodes)
+	proof, err := fetchNodesAndBuildProof(ctx, tx, hasher.HashChildren, leafIndex, nodes)
+	if err != nil {
+		return nil, err
+	}
+	if withIDs {
+		proof.NodeIds = proofNodeIDs(nodes)
+	}
+	return proof, nil
 }
 
 func (t *TrillianLogRPCServer) getTreeAndHasher(ctx context.Context, treeID int64, opts trees.GetOpts) (*trillian.Tree, merkle.LogHasher, error) {
@@ -558,6 +1140,9 @@ func (t *TrillianLogRPCServer) getTreeAndHasher(ctx context.Context, treeID int6
 	if err != nil {
 		return nil, nil, err
 	}
+	if err := t.checkServable(treeID); err != nil {
+		return nil, nil, err
+	}
 	return tree, rfc6962.DefaultHasher, nil
 }
 
@@ -566,6 +1151,9 @@ func (t *TrillianLogRPCServer) getTreeAndContext(ctx context.Context, treeID int
 	if err != nil {
 		return nil, nil, err
 	}
+	if err := t.checkServable(treeID); err != nil {
+		return nil, nil, err
+	}
 	return tree, trees.NewContext(ctx, tree), nil
 }
 
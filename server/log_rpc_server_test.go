@@ -26,7 +26,9 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/trillian"
 	"github.com/google/trillian/extension"
+	mtestonly "github.com/google/trillian/monitoring/testonly"
 	"github.com/google/trillian/storage"
+	"github.com/google/trillian/storage/memory"
 	stestonly "github.com/google/trillian/storage/testonly"
 	"github.com/google/trillian/storage/tree"
 	"github.com/google/trillian/types"
@@ -38,6 +40,7 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/prototext"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // cmpMatcher is a custom gomock.Matcher that uses cmp.Equal combined with a
@@ -914,6 +917,36 @@ func TestGetProofByIndex(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "ok with node IDs",
+			setupStorage: func(c *gomock.Controller, s *storage.MockLogStorage) {
+				tx := storage.NewMockLogTreeTX(c)
+				s.EXPECT().SnapshotForTree(gomock.Any(), cmpMatcher{tree1}).Return(tx, nil)
+				tx.EXPECT().LatestSignedLogRoot(gomock.Any()).Return(signedRoot1, nil)
+				tx.EXPECT().GetMerkleNodes(gomock.Any(), nodeIdsInclusionSize7Index2).Return([]tree.Node{
+					{ID: nodeIdsInclusionSize7Index2[0], Hash: []byte("nodehash0")},
+					{ID: nodeIdsInclusionSize7Index2[1], Hash: []byte("nodehash1")},
+					{ID: nodeIdsInclusionSize7Index2[2], Hash: []byte("nodehash2")},
+					{ID: nodeIdsInclusionSize7Index2[3], Hash: []byte("nodehash3")},
+				}, nil)
+				tx.EXPECT().Commit(gomock.Any()).Return(nil)
+				tx.EXPECT().Close().Return(nil)
+			},
+			req: &trillian.GetInclusionProofRequest{LogId: logID1, TreeSize: 7, LeafIndex: 2, IncludeNodeIds: true},
+			wantResp: &trillian.GetInclusionProofResponse{
+				SignedLogRoot: signedRoot1,
+				Proof: &trillian.Proof{
+					LeafIndex: 2,
+					Hashes: [][]byte{
+						[]byte("nodehash0"),
+						[]byte("nodehash1"),
+						th.HashChildren([]byte("nodehash3"), []byte("nodehash2")),
+					},
+					// The last hash is of the ephemeral node covering leaves 4-6.
+					NodeIds: []*trillian.NodeID{{Level: 0, Index: 3}, {Level: 1, Index: 0}, {Level: 2, Index: 1}},
+				},
+			},
+		},
 		{
 			name: "skew beyond sth",
 			setupStorage: func(c *gomock.Controller, s *storage.MockLogStorage) {
@@ -1390,6 +1423,120 @@ func TestTrillianLogRPCServer_GetConsistencyProofErrors(t *testing.T) {
 	}
 }
 
+func TestGetCompactRange(t *testing.T) {
+	nodes := []tree.Node{
+		{ID: compact.NewNodeID(1, 1), Hash: []byte("nodehash1")},
+		{ID: compact.NewNodeID(1, 2), Hash: []byte("nodehash2")},
+		{ID: compact.NewNodeID(0, 6), Hash: []byte("nodehash3")},
+	}
+	ids := []compact.NodeID{nodes[0].ID, nodes[1].ID, nodes[2].ID}
+
+	for _, test := range []struct {
+		desc        string
+		begin, end  int64
+		treeSize    int64
+		nodeIDs     []compact.NodeID
+		nodes       []tree.Node
+		getNodesErr error
+		noCommit    bool
+		wantHashes  [][]byte
+		errStr      string
+	}{
+		{
+			desc:  "ok",
+			begin: 2, end: 7, treeSize: 7,
+			nodeIDs:    ids,
+			nodes:      nodes,
+			wantHashes: [][]byte{[]byte("nodehash1"), []byte("nodehash2"), []byte("nodehash3")},
+		},
+		{
+			desc:  "empty-range",
+			begin: 3, end: 3, treeSize: 7,
+		},
+		{
+			desc:  "tree-size-beyond-root",
+			begin: 2, end: 7, treeSize: 8,
+			noCommit: true,
+		},
+		{
+			desc:  "get-nodes-error",
+			begin: 2, end: 7, treeSize: 7,
+			nodeIDs:     ids,
+			getNodesErr: errors.New("getMerkleNodes() failed"),
+			noCommit:    true,
+			errStr:      "getMerkleNodes",
+		},
+		{
+			desc:  "wrong-node",
+			begin: 2, end: 7, treeSize: 7,
+			nodeIDs:  ids,
+			nodes:    []tree.Node{nodes[0], nodes[2], nodes[1]},
+			noCommit: true,
+			errStr:   "expected node {1 2} at",
+		},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fakeStorage := storage.NewMockLogStorage(ctrl)
+			mockTX := storage.NewMockLogTreeTX(ctrl)
+			fakeStorage.EXPECT().SnapshotForTree(gomock.Any(), cmpMatcher{tree1}).Return(mockTX, nil)
+			mockTX.EXPECT().LatestSignedLogRoot(gomock.Any()).Return(signedRoot1, nil)
+			if test.nodeIDs != nil {
+				mockTX.EXPECT().GetMerkleNodes(gomock.Any(), test.nodeIDs).Return(test.nodes, test.getNodesErr)
+			}
+			if !test.noCommit {
+				mockTX.EXPECT().Commit(gomock.Any()).Return(nil)
+			}
+			mockTX.EXPECT().Close().Return(nil)
+
+			registry := extension.Registry{
+				AdminStorage: fakeAdminStorage(ctrl, storageParams{treeID: logID1, numSnapshots: 1}),
+				LogStorage:   fakeStorage,
+			}
+			server := NewTrillianLogRPCServer(registry, fakeTimeSource)
+			req := &trillian.GetCompactRangeRequest{LogId: logID1, Begin: test.begin, End: test.end, TreeSize: test.treeSize}
+			resp, err := server.GetCompactRange(context.Background(), req)
+			if test.errStr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.errStr) {
+					t.Errorf("GetCompactRange(%+v)=_, %v; want _, err containing %q", req, err, test.errStr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetCompactRange(%+v)=_, %v; want _, nil", req, err)
+			}
+			if got, want := resp.Hashes, test.wantHashes; !cmp.Equal(got, want) {
+				t.Errorf("GetCompactRange(%+v) hashes=%q, want %q", req, got, want)
+			}
+			if !proto.Equal(resp.SignedLogRoot, signedRoot1) {
+				t.Errorf("GetCompactRange(%+v) root=%v, want %v", req, resp.SignedLogRoot, signedRoot1)
+			}
+		})
+	}
+}
+
+func TestTrillianLogRPCServer_GetCompactRangeErrors(t *testing.T) {
+	tests := []struct {
+		desc string
+		req  *trillian.GetCompactRangeRequest
+	}{
+		{desc: "badBegin", req: &trillian.GetCompactRangeRequest{LogId: 1, Begin: -1, End: 2, TreeSize: 2}},
+		{desc: "endBeforeBegin", req: &trillian.GetCompactRangeRequest{LogId: 1, Begin: 5, End: 4, TreeSize: 10}},
+		{desc: "treeSizeBeforeEnd", req: &trillian.GetCompactRangeRequest{LogId: 1, Begin: 1, End: 4, TreeSize: 3}},
+	}
+
+	logServer := NewTrillianLogRPCServer(extension.Registry{}, fakeTimeSource)
+	ctx := context.Background()
+	for _, test := range tests {
+		_, err := logServer.GetCompactRange(ctx, test.req)
+		if s, ok := status.FromError(err); !ok || s.Code() != codes.InvalidArgument {
+			t.Errorf("%v: GetCompactRange() returned err = %v, wantCode = %s", test.desc, err, codes.InvalidArgument)
+		}
+	}
+}
+
 func TestTrillianLogRPCServer_GetEntryAndProofErrors(t *testing.T) {
 	tests := []struct {
 		desc string
@@ -1751,3 +1898,246 @@ func addTreeID(tree *trillian.Tree, treeID int64) *trillian.Tree {
 	newTree.TreeId = treeID
 	return newTree
 }
+
+func TestUpdateLeafExtraData(t *testing.T) {
+	ctx := context.Background()
+	ts := memory.NewTreeStorage()
+	registry := extension.Registry{
+		AdminStorage: memory.NewAdminStorage(ts),
+		LogStorage:   memory.NewLogStorage(ts, nil),
+	}
+	tree, err := storage.CreateTree(ctx, registry.AdminStorage, proto.Clone(stestonly.LogTree).(*trillian.Tree))
+	if err != nil {
+		t.Fatalf("CreateTree(): %v", err)
+	}
+	logRoot, err := (&types.LogRootV1{RootHash: rfc6962.DefaultHasher.EmptyRoot(), TimestampNanos: 1}).MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary(): %v", err)
+	}
+	leaf := newTestLeaf([]byte("cert"), []byte("issued"), 0)
+	leaf.LeafIdentityHash = leaf.MerkleLeafHash
+	if err := registry.LogStorage.ReadWriteTransaction(ctx, tree, func(ctx context.Context, tx storage.LogTreeTX) error {
+		return tx.StoreSignedLogRoot(ctx, &trillian.SignedLogRoot{LogRoot: logRoot})
+	}); err != nil {
+		t.Fatalf("StoreSignedLogRoot(): %v", err)
+	}
+	if _, err := registry.LogStorage.QueueLeaves(ctx, tree, []*trillian.LogLeaf{leaf}, time.Unix(1, 0)); err != nil {
+		t.Fatalf("QueueLeaves(): %v", err)
+	}
+	logRoot, err = (&types.LogRootV1{TreeSize: 1, RootHash: leaf.MerkleLeafHash, TimestampNanos: 2}).MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary(): %v", err)
+	}
+	if err := registry.LogStorage.ReadWriteTransaction(ctx, tree, func(ctx context.Context, tx storage.LogTreeTX) error {
+		if err := tx.UpdateSequencedLeaves(ctx, []*trillian.LogLeaf{leaf}); err != nil {
+			return err
+		}
+		return tx.StoreSignedLogRoot(ctx, &trillian.SignedLogRoot{LogRoot: logRoot})
+	}); err != nil {
+		t.Fatalf("UpdateSequencedLeaves(): %v", err)
+	}
+
+	now := time.Unix(1500000000, 0)
+	server := NewTrillianLogRPCServer(registry, clock.NewFake(now))
+	for i, extra := range []string{"revoked", "reinstated"} {
+		resp, err := server.UpdateLeafExtraData(ctx, &trillian.UpdateLeafExtraDataRequest{
+			LogId:     tree.TreeId,
+			LeafIndex: 0,
+			ExtraData: []byte(extra),
+			Reason:    fmt.Sprintf("update %d", i),
+		})
+		if err != nil {
+			t.Fatalf("UpdateLeafExtraData(%q): %v", extra, err)
+		}
+		if got, want := string(resp.Update.ExtraData), extra; got != want {
+			t.Errorf("UpdateLeafExtraData(%q): ExtraData=%q, want %q", extra, got, want)
+		}
+	}
+
+	leaves, err := server.GetLeavesByRange(ctx, &trillian.GetLeavesByRangeRequest{LogId: tree.TreeId, StartIndex: 0, Count: 1})
+	if err != nil {
+		t.Fatalf("GetLeavesByRange(): %v", err)
+	}
+	if got, want := string(leaves.Leaves[0].ExtraData), "reinstated"; got != want {
+		t.Errorf("GetLeavesByRange(): ExtraData=%q, want %q", got, want)
+	}
+
+	list, err := server.ListLeafExtraDataUpdates(ctx, &trillian.ListLeafExtraDataUpdatesRequest{LogId: tree.TreeId, LeafIndex: 0})
+	if err != nil {
+		t.Fatalf("ListLeafExtraDataUpdates(): %v", err)
+	}
+	var got []string
+	for _, u := range list.Updates {
+		got = append(got, fmt.Sprintf("%s->%s: %s", u.PreviousExtraData, u.ExtraData, u.Reason))
+	}
+	want := []string{"issued->revoked: update 0", "revoked->reinstated: update 1"}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("ListLeafExtraDataUpdates(): diff (-got +want):\n%s", diff)
+	}
+
+	for _, test := range []struct {
+		desc string
+		req  *trillian.UpdateLeafExtraDataRequest
+		want codes.Code
+	}{
+		{desc: "badIndex", req: &trillian.UpdateLeafExtraDataRequest{LogId: tree.TreeId, LeafIndex: -1}, want: codes.InvalidArgument},
+		{desc: "notSequenced", req: &trillian.UpdateLeafExtraDataRequest{LogId: tree.TreeId, LeafIndex: 1}, want: codes.NotFound},
+	} {
+		if _, err := server.UpdateLeafExtraData(ctx, test.req); status.Code(err) != test.want {
+			t.Errorf("%s: UpdateLeafExtraData()=_, %v, want code %v", test.desc, err, test.want)
+		}
+	}
+}
+
+func TestGetDailyLogStats(t *testing.T) {
+	ctx := context.Background()
+	ts := memory.NewTreeStorage()
+	registry := extension.Registry{
+		AdminStorage: memory.NewAdminStorage(ts),
+		LogStorage:   memory.NewLogStorage(ts, nil),
+	}
+	tree, err := storage.CreateTree(ctx, registry.AdminStorage, proto.Clone(stestonly.LogTree).(*trillian.Tree))
+	if err != nil {
+		t.Fatalf("CreateTree(): %v", err)
+	}
+	logRoot, err := (&types.LogRootV1{RootHash: rfc6962.DefaultHasher.EmptyRoot(), TimestampNanos: 1}).MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary(): %v", err)
+	}
+	day := func(d int) *timestamppb.Timestamp {
+		return timestamppb.New(time.Date(2022, 3, d, 0, 0, 0, 0, time.UTC))
+	}
+	if err := registry.LogStorage.ReadWriteTransaction(ctx, tree, func(ctx context.Context, tx storage.LogTreeTX) error {
+		if err := tx.StoreSignedLogRoot(ctx, &trillian.SignedLogRoot{LogRoot: logRoot}); err != nil {
+			return err
+		}
+		stx, err := storage.AsLogStatsTX(tx)
+		if err != nil {
+			return err
+		}
+		return stx.AddDailyStats(ctx, []*trillian.DailyLogStats{
+			{Day: day(1), LeavesAdded: 1, LeafValueBytes: 10},
+			{Day: day(2), LeavesAdded: 2, LeafValueBytes: 20},
+			{Day: day(4), LeavesAdded: 4, LeafValueBytes: 40},
+		})
+	}); err != nil {
+		t.Fatalf("AddDailyStats(): %v", err)
+	}
+
+	server := NewTrillianLogRPCServer(registry, clock.System)
+	for _, test := range []struct {
+		desc       string
+		start, end *timestamppb.Timestamp
+		want       []int64
+		wantCode   codes.Code
+	}{
+		{desc: "all", want: []int64{1, 2, 4}},
+		{desc: "from", start: day(2), want: []int64{2, 4}},
+		{desc: "until", end: day(4), want: []int64{1, 2}},
+		{desc: "range", start: day(2), end: day(3), want: []int64{2}},
+		{desc: "endBeforeStart", start: day(3), end: day(2), wantCode: codes.InvalidArgument},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			resp, err := server.GetDailyLogStats(ctx, &trillian.GetDailyLogStatsRequest{LogId: tree.TreeId, Start: test.start, End: test.end})
+			if got := status.Code(err); got != test.wantCode {
+				t.Fatalf("GetDailyLogStats()=_, %v, want code %v", err, test.wantCode)
+			}
+			if err != nil {
+				return
+			}
+			var got []int64
+			for _, s := range resp.Stats {
+				got = append(got, s.LeavesAdded)
+			}
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf("GetDailyLogStats(): diff (-got +want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReportVerificationFailure(t *testing.T) {
+	ctx := context.Background()
+	ts := memory.NewTreeStorage()
+	registry := extension.Registry{
+		AdminStorage: memory.NewAdminStorage(ts),
+		LogStorage:   memory.NewLogStorage(ts, nil),
+	}
+	tree, err := storage.CreateTree(ctx, registry.AdminStorage, proto.Clone(stestonly.LogTree).(*trillian.Tree))
+	if err != nil {
+		t.Fatalf("CreateTree(): %v", err)
+	}
+	logRoot, err := (&types.LogRootV1{RootHash: rfc6962.DefaultHasher.EmptyRoot(), TimestampNanos: 1}).MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary(): %v", err)
+	}
+	if err := registry.LogStorage.ReadWriteTransaction(ctx, tree, func(ctx context.Context, tx storage.LogTreeTX) error {
+		return tx.StoreSignedLogRoot(ctx, &trillian.SignedLogRoot{LogRoot: logRoot})
+	}); err != nil {
+		t.Fatalf("StoreSignedLogRoot(): %v", err)
+	}
+
+	now := time.Unix(1500000000, 0)
+	server := NewTrillianLogRPCServer(registry, clock.NewFake(now))
+	logID := fmt.Sprint(tree.TreeId)
+	reported := mtestonly.NewCounterSnapshot(server.verificationFailures, logID, "CONSISTENCY_PROOF_MISMATCH")
+
+	for _, test := range []struct {
+		desc string
+		req  *trillian.ReportVerificationFailureRequest
+		want codes.Code
+	}{
+		{
+			desc: "ok",
+			req: &trillian.ReportVerificationFailureRequest{
+				LogId:     tree.TreeId,
+				Failure:   trillian.VerificationFailure_CONSISTENCY_PROOF_MISMATCH,
+				RequestId: "req-1",
+				LogRoot:   logRoot,
+				Details:   "root mismatch",
+			},
+		},
+		{
+			desc: "noFailure",
+			req:  &trillian.ReportVerificationFailureRequest{LogId: tree.TreeId, RequestId: "req-2"},
+			want: codes.InvalidArgument,
+		},
+		{
+			desc: "noRequestID",
+			req:  &trillian.ReportVerificationFailureRequest{LogId: tree.TreeId, Failure: trillian.VerificationFailure_LOG_ROOT_MISMATCH},
+			want: codes.InvalidArgument,
+		},
+		{
+			desc: "longDetails",
+			req: &trillian.ReportVerificationFailureRequest{
+				LogId:     tree.TreeId,
+				Failure:   trillian.VerificationFailure_LOG_ROOT_MISMATCH,
+				RequestId: "req-3",
+				Details:   strings.Repeat("x", maxFailureDetailsLength+1),
+			},
+			want: codes.InvalidArgument,
+		},
+	} {
+		if _, err := server.ReportVerificationFailure(ctx, test.req); status.Code(err) != test.want {
+			t.Errorf("%s: ReportVerificationFailure()=_, %v, want code %v", test.desc, err, test.want)
+		}
+	}
+	if got, want := reported.Delta(), 1.0; got != want {
+		t.Errorf("verification_failures_reported delta: %v, want %v", got, want)
+	}
+
+	resp, err := server.ListVerificationFailureReports(ctx, &trillian.ListVerificationFailureReportsRequest{LogId: tree.TreeId})
+	if err != nil {
+		t.Fatalf("ListVerificationFailureReports(): %v", err)
+	}
+	want := []*trillian.VerificationFailureReport{{
+		Failure:         trillian.VerificationFailure_CONSISTENCY_PROOF_MISMATCH,
+		RequestId:       "req-1",
+		LogRoot:         logRoot,
+		Details:         "root mismatch",
+		ReportTimestamp: timestamppb.New(now),
+	}}
+	if diff := cmp.Diff(resp.Reports, want, cmp.Comparer(proto.Equal)); diff != "" {
+		t.Errorf("ListVerificationFailureReports(): diff (-got +want):\n%s", diff)
+	}
+}
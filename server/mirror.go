@@ -0,0 +1,148 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/types"
+	"github.com/transparency-dev/merkle"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// mirrorProgressBatch is the number of leaves beyond the tree size which
+// GetMirrorProgress reads at a time, while looking for the first missing leaf.
+const mirrorProgressBatch = 1000
+
+// AddSequencedLeavesStream adds the leaves of a stream of requests for the
+// same pre-ordered log, which must continue from the next index expected by
+// the log without gaps, and responds to each request once its leaves are
+// added.
+func (t *TrillianLogRPCServer) AddSequencedLeavesStream(stream trillian.TrillianLog_AddSequencedLeavesStreamServer) error {
+	ctx, spanEnd := spanFor(stream.Context(), "AddSequencedLeavesStream")
+	defer spanEnd()
+
+	var tree *trillian.Tree
+	var hasher merkle.LogHasher
+	var validator *types.LeafValidator
+	var next int64
+	for i := 0; ; i++ {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if err := validateAddSequencedLeavesRequest(req); err != nil {
+			return err
+		}
+
+		if tree == nil {
+			if tree, hasher, err = t.getTreeAndHasher(ctx, req.LogId, optsPreorderedLogWrite); err != nil {
+				return err
+			}
+			if validator, err = t.leafValidator(tree); err != nil {
+				return err
+			}
+			if _, next, err = t.mirrorProgress(ctx, tree); err != nil {
+				return err
+			}
+		} else if req.LogId != tree.TreeId {
+			return status.Errorf(codes.InvalidArgument, "AddSequencedLeavesRequest[%d].LogId: %d, want %d of the first request", i, req.LogId, tree.TreeId)
+		}
+		if first := req.Leaves[0].LeafIndex; first != next {
+			return status.Errorf(codes.FailedPrecondition, "AddSequencedLeavesRequest[%d].Leaves[0].LeafIndex=%d, want next index %d", i, first, next)
+		}
+		for j, leaf := range req.Leaves {
+			if err := validateLeafValue(validator, leaf, fmt.Sprintf("AddSequencedLeavesRequest[%d].Leaves[%d]", i, j)); err != nil {
+				return err
+			}
+		}
+
+		results, err := t.addSequencedLeaves(ctx, tree, hasher, req.Leaves)
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(&trillian.AddSequencedLeavesResponse{Results: results}); err != nil {
+			return err
+		}
+		next += int64(len(req.Leaves))
+	}
+}
+
+// GetMirrorProgress returns the index of the next leaf expected by a
+// pre-ordered log, along with its latest root.
+func (t *TrillianLogRPCServer) GetMirrorProgress(ctx context.Context, req *trillian.GetMirrorProgressRequest) (*trillian.GetMirrorProgressResponse, error) {
+	ctx, spanEnd := spanFor(ctx, "GetMirrorProgress")
+	defer spanEnd()
+
+	tree, ctx, err := t.getTreeAndContext(ctx, req.LogId, optsPreorderedLogRead)
+	if err != nil {
+		return nil, err
+	}
+	slr, next, err := t.mirrorProgress(ctx, tree)
+	if err != nil {
+		return nil, err
+	}
+	return &trillian.GetMirrorProgressResponse{NextIndex: next, SignedLogRoot: slr}, nil
+}
+
+// mirrorProgress returns the latest root of a pre-ordered log, and the index
+// of the first leaf missing from the log. The leaves below the tree size are
+// integrated, so it only looks for gaps beyond.
+func (t *TrillianLogRPCServer) mirrorProgress(ctx context.Context, tree *trillian.Tree) (*trillian.SignedLogRoot, int64, error) {
+	tx, err := t.snapshotForTree(ctx, tree, "GetMirrorProgress")
+	if err != nil {
+		return nil, 0, err
+	}
+	defer t.closeAndLog(ctx, tree.TreeId, tx, "GetMirrorProgress")
+
+	slr, err := tx.LatestSignedLogRoot(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	var root types.LogRootV1
+	if err := root.UnmarshalBinary(slr.LogRoot); err != nil {
+		return nil, 0, status.Errorf(codes.Internal, "Could not read current log root: %v", err)
+	}
+
+	next := int64(root.TreeSize)
+	for {
+		// Storage returns the leaves of pre-ordered logs up to the first gap.
+		leaves, err := tx.GetLeavesByRange(ctx, next, mirrorProgressBatch)
+		if err != nil {
+			return nil, 0, err
+		}
+		start := next
+		for _, leaf := range leaves {
+			if leaf.LeafIndex != next {
+				break
+			}
+			next++
+		}
+		if next-start < mirrorProgressBatch {
+			break
+		}
+	}
+
+	if err := t.commitAndLog(ctx, tree.TreeId, tx, "GetMirrorProgress"); err != nil {
+		return nil, 0, err
+	}
+	return slr, next, nil
+}
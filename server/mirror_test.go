@@ -0,0 +1,269 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/extension"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/storage/memory"
+	stestonly "github.com/google/trillian/storage/testonly"
+	"github.com/google/trillian/util/clock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeSequencedStream is an AddSequencedLeavesStream server stream which
+// receives reqs.
+type fakeSequencedStream struct {
+	grpc.ServerStream
+	reqs  []*trillian.AddSequencedLeavesRequest
+	resps []*trillian.AddSequencedLeavesResponse
+}
+
+func (s *fakeSequencedStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *fakeSequencedStream) Recv() (*trillian.AddSequencedLeavesRequest, error) {
+	if len(s.reqs) == 0 {
+		return nil, io.EOF
+	}
+	req := s.reqs[0]
+	s.reqs = s.reqs[1:]
+	return req, nil
+}
+
+func (s *fakeSequencedStream) Send(resp *trillian.AddSequencedLeavesResponse) error {
+	s.resps = append(s.resps, resp)
+	return nil
+}
+
+// sequencedStorage keeps the sequenced leaves of a pre-ordered log in a map,
+// which the memory storage doesn't support, on top of a memory storage which
+// keeps its roots.
+type sequencedStorage struct {
+	storage.LogStorage
+	leaves map[int64]*trillian.LogLeaf
+}
+
+func (s *sequencedStorage) AddSequencedLeaves(ctx context.Context, tree *trillian.Tree, leaves []*trillian.LogLeaf, timestamp time.Time) ([]*trillian.QueuedLogLeaf, error) {
+	results := make([]*trillian.QueuedLogLeaf, 0, len(leaves))
+	for _, leaf := range leaves {
+		if _, ok := s.leaves[leaf.LeafIndex]; ok {
+			results = append(results, &trillian.QueuedLogLeaf{Status: status.New(codes.FailedPrecondition, "conflict").Proto()})
+			continue
+		}
+		s.leaves[leaf.LeafIndex] = leaf
+		results = append(results, &trillian.QueuedLogLeaf{})
+	}
+	return results, nil
+}
+
+func (s *sequencedStorage) SnapshotForTree(ctx context.Context, tree *trillian.Tree) (storage.ReadOnlyLogTreeTX, error) {
+	tx, err := s.LogStorage.SnapshotForTree(ctx, tree)
+	if err != nil {
+		return tx, err
+	}
+	return &sequencedTX{ReadOnlyLogTreeTX: tx, leaves: s.leaves}, nil
+}
+
+type sequencedTX struct {
+	storage.ReadOnlyLogTreeTX
+	leaves map[int64]*trillian.LogLeaf
+}
+
+// GetLeavesByRange returns the leaves of the range up to the first gap, like
+// the storage implementations which support pre-ordered logs.
+func (t *sequencedTX) GetLeavesByRange(ctx context.Context, start, count int64) ([]*trillian.LogLeaf, error) {
+	var leaves []*trillian.LogLeaf
+	for i := start; i < start+count; i++ {
+		leaf, ok := t.leaves[i]
+		if !ok {
+			break
+		}
+		leaves = append(leaves, leaf)
+	}
+	return leaves, nil
+}
+
+func newMirrorTestServer(ctx context.Context, t *testing.T) (*TrillianLogRPCServer, *sequencedStorage, int64) {
+	t.Helper()
+	ts := memory.NewTreeStorage()
+	ls := &sequencedStorage{LogStorage: memory.NewLogStorage(ts, nil), leaves: make(map[int64]*trillian.LogLeaf)}
+	registry := extension.Registry{
+		AdminStorage: memory.NewAdminStorage(ts),
+		LogStorage:   ls,
+	}
+	tree, err := storage.CreateTree(ctx, registry.AdminStorage, stestonly.PreorderedLogTree)
+	if err != nil {
+		t.Fatalf("CreateTree(): %v", err)
+	}
+	server := NewTrillianLogRPCServer(registry, clock.System)
+	if _, err := server.InitLog(ctx, &trillian.InitLogRequest{LogId: tree.TreeId}); err != nil {
+		t.Fatalf("InitLog(): %v", err)
+	}
+	return server, ls, tree.TreeId
+}
+
+// newSequencedRequest returns a request adding count leaves from index start.
+func newSequencedRequest(logID, start int64, count int) *trillian.AddSequencedLeavesRequest {
+	req := &trillian.AddSequencedLeavesRequest{LogId: logID}
+	for i := start; i < start+int64(count); i++ {
+		req.Leaves = append(req.Leaves, &trillian.LogLeaf{LeafIndex: i, LeafValue: []byte(fmt.Sprintf("leaf%d", i))})
+	}
+	return req
+}
+
+func TestAddSequencedLeavesStream(t *testing.T) {
+	ctx := context.Background()
+	server, ls, logID := newMirrorTestServer(ctx, t)
+
+	progress := func() int64 {
+		t.Helper()
+		resp, err := server.GetMirrorProgress(ctx, &trillian.GetMirrorProgressRequest{LogId: logID})
+		if err != nil {
+			t.Fatalf("GetMirrorProgress(): %v", err)
+		}
+		if resp.SignedLogRoot == nil {
+			t.Error("GetMirrorProgress() returned no root")
+		}
+		return resp.NextIndex
+	}
+	if got := progress(); got != 0 {
+		t.Errorf("GetMirrorProgress() of empty log: next index %d, want 0", got)
+	}
+
+	stream := &fakeSequencedStream{reqs: []*trillian.AddSequencedLeavesRequest{
+		newSequencedRequest(logID, 0, 3),
+		newSequencedRequest(logID, 3, 2),
+	}}
+	if err := server.AddSequencedLeavesStream(stream); err != nil {
+		t.Fatalf("AddSequencedLeavesStream(): %v", err)
+	}
+	if got, want := len(stream.resps), 2; got != want {
+		t.Fatalf("AddSequencedLeavesStream() sent %d responses, want %d", got, want)
+	}
+	for i, want := range []int{3, 2} {
+		if got := len(stream.resps[i].Results); got != want {
+			t.Errorf("response %d has %d results, want %d", i, got, want)
+		}
+	}
+	if got, want := progress(), int64(5); got != want {
+		t.Errorf("GetMirrorProgress(): next index %d, want %d", got, want)
+	}
+
+	// A leaf after a gap doesn't count.
+	ls.leaves[7] = &trillian.LogLeaf{LeafIndex: 7, LeafValue: []byte("leaf7")}
+	if got, want := progress(), int64(5); got != want {
+		t.Errorf("GetMirrorProgress() with gap: next index %d, want %d", got, want)
+	}
+
+	// A mirror resumes from the next index, in a new stream.
+	stream = &fakeSequencedStream{reqs: []*trillian.AddSequencedLeavesRequest{newSequencedRequest(logID, 5, 2)}}
+	if err := server.AddSequencedLeavesStream(stream); err != nil {
+		t.Fatalf("AddSequencedLeavesStream(resume): %v", err)
+	}
+	if got, want := progress(), int64(8); got != want {
+		t.Errorf("GetMirrorProgress() after resume: next index %d, want %d", got, want)
+	}
+}
+
+func TestAddSequencedLeavesStreamErrors(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		desc     string
+		reqs     func(logID int64) []*trillian.AddSequencedLeavesRequest
+		wantCode codes.Code
+		wantSent int
+	}{
+		{
+			desc: "gapAtStart",
+			reqs: func(logID int64) []*trillian.AddSequencedLeavesRequest {
+				return []*trillian.AddSequencedLeavesRequest{newSequencedRequest(logID, 1, 2)}
+			},
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			desc: "gapBetweenRequests",
+			reqs: func(logID int64) []*trillian.AddSequencedLeavesRequest {
+				return []*trillian.AddSequencedLeavesRequest{newSequencedRequest(logID, 0, 2), newSequencedRequest(logID, 3, 2)}
+			},
+			wantCode: codes.FailedPrecondition,
+			wantSent: 1,
+		},
+		{
+			desc: "overlap",
+			reqs: func(logID int64) []*trillian.AddSequencedLeavesRequest {
+				return []*trillian.AddSequencedLeavesRequest{newSequencedRequest(logID, 0, 2), newSequencedRequest(logID, 1, 2)}
+			},
+			wantCode: codes.FailedPrecondition,
+			wantSent: 1,
+		},
+		{
+			desc: "differentLogs",
+			reqs: func(logID int64) []*trillian.AddSequencedLeavesRequest {
+				return []*trillian.AddSequencedLeavesRequest{newSequencedRequest(logID, 0, 2), newSequencedRequest(logID+1, 2, 2)}
+			},
+			wantCode: codes.InvalidArgument,
+			wantSent: 1,
+		},
+		{
+			desc: "noLeaves",
+			reqs: func(logID int64) []*trillian.AddSequencedLeavesRequest {
+				return []*trillian.AddSequencedLeavesRequest{{LogId: logID}}
+			},
+			wantCode: codes.InvalidArgument,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			server, _, logID := newMirrorTestServer(ctx, t)
+			stream := &fakeSequencedStream{reqs: tc.reqs(logID)}
+			err := server.AddSequencedLeavesStream(stream)
+			if got := status.Code(err); got != tc.wantCode {
+				t.Errorf("AddSequencedLeavesStream(): %v, want code %v", err, tc.wantCode)
+			}
+			if got := len(stream.resps); got != tc.wantSent {
+				t.Errorf("AddSequencedLeavesStream() sent %d responses, want %d", got, tc.wantSent)
+			}
+		})
+	}
+}
+
+func TestGetMirrorProgressLogTree(t *testing.T) {
+	ctx := context.Background()
+	ts := memory.NewTreeStorage()
+	registry := extension.Registry{
+		AdminStorage: memory.NewAdminStorage(ts),
+		LogStorage:   memory.NewLogStorage(ts, nil),
+	}
+	tree, err := storage.CreateTree(ctx, registry.AdminStorage, stestonly.LogTree)
+	if err != nil {
+		t.Fatalf("CreateTree(): %v", err)
+	}
+	server := NewTrillianLogRPCServer(registry, clock.System)
+	_, err = server.GetMirrorProgress(ctx, &trillian.GetMirrorProgressRequest{LogId: tree.TreeId})
+	if got, want := status.Code(err), codes.InvalidArgument; got != want {
+		t.Errorf("GetMirrorProgress() of a LOG tree: %v, want code %v", err, want)
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/extension"
+	"github.com/google/trillian/log"
+	"github.com/google/trillian/quota"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/storage/memory"
+	stestonly "github.com/google/trillian/storage/testonly"
+	"github.com/google/trillian/util/clock"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestOmitLeafData(t *testing.T) {
+	log.InitMetrics(nil)
+	ctx := context.Background()
+	ts := memory.NewTreeStorage()
+	registry := extension.Registry{
+		AdminStorage: memory.NewAdminStorage(ts),
+		LogStorage:   memory.NewLogStorage(ts, nil),
+	}
+	tree, err := storage.CreateTree(ctx, registry.AdminStorage, stestonly.LogTree)
+	if err != nil {
+		t.Fatalf("CreateTree(): %v", err)
+	}
+	server := NewTrillianLogRPCServer(registry, clock.System)
+	if _, err := server.InitLog(ctx, &trillian.InitLogRequest{LogId: tree.TreeId}); err != nil {
+		t.Fatalf("InitLog(): %v", err)
+	}
+	const size = 5
+	for i := 0; i < size; i++ {
+		leaf := &trillian.LogLeaf{LeafValue: []byte(fmt.Sprintf("leaf%d", i)), ExtraData: []byte("extra")}
+		if _, err := server.QueueLeaf(ctx, &trillian.QueueLeafRequest{LogId: tree.TreeId, Leaf: leaf}); err != nil {
+			t.Fatalf("QueueLeaf(): %v", err)
+		}
+	}
+	if _, err := log.IntegrateBatch(ctx, tree, size, 0, 0, clock.System, registry.LogStorage, quota.Noop()); err != nil {
+		t.Fatalf("IntegrateBatch(): %v", err)
+	}
+
+	// checkOmitted checks that got is want without its data.
+	checkOmitted := func(t *testing.T, got, want *trillian.LogLeaf) {
+		t.Helper()
+		if len(got.LeafValue) != 0 || len(got.ExtraData) != 0 {
+			t.Errorf("leaf %d has data %q and extra data %q, want none", got.LeafIndex, got.LeafValue, got.ExtraData)
+		}
+		want = proto.Clone(want).(*trillian.LogLeaf)
+		want.LeafValue, want.ExtraData = nil, nil
+		if !proto.Equal(got, want) {
+			t.Errorf("leaf without data = %v, want %v", got, want)
+		}
+	}
+
+	req := &trillian.GetLeavesByRangeRequest{LogId: tree.TreeId, StartIndex: 1, Count: 3}
+	full, err := server.GetLeavesByRange(ctx, req)
+	if err != nil {
+		t.Fatalf("GetLeavesByRange(): %v", err)
+	}
+	req.OmitLeafData = true
+	hashes, err := server.GetLeavesByRange(ctx, req)
+	if err != nil {
+		t.Fatalf("GetLeavesByRange(omit_leaf_data): %v", err)
+	}
+	if got, want := len(hashes.Leaves), len(full.Leaves); got != want {
+		t.Fatalf("GetLeavesByRange(omit_leaf_data) returned %d leaves, want %d", got, want)
+	}
+	for i := range hashes.Leaves {
+		checkOmitted(t, hashes.Leaves[i], full.Leaves[i])
+	}
+
+	stream := &fakeLeavesStream{}
+	if err := server.StreamLeavesByRange(req, stream); err != nil {
+		t.Fatalf("StreamLeavesByRange(omit_leaf_data): %v", err)
+	}
+	if got, want := len(stream.resps[0].Leaves), len(full.Leaves); got != want {
+		t.Fatalf("StreamLeavesByRange(omit_leaf_data) sent %d leaves, want %d", got, want)
+	}
+	for i, leaf := range stream.resps[0].Leaves {
+		checkOmitted(t, leaf, full.Leaves[i])
+	}
+
+	entry, err := server.GetEntryAndProof(ctx, &trillian.GetEntryAndProofRequest{LogId: tree.TreeId, LeafIndex: 2, TreeSize: size, OmitLeafData: true})
+	if err != nil {
+		t.Fatalf("GetEntryAndProof(omit_leaf_data): %v", err)
+	}
+	checkOmitted(t, entry.Leaf, full.Leaves[1])
+	if entry.Proof == nil {
+		t.Error("GetEntryAndProof(omit_leaf_data) returned no proof")
+	}
+
+	// The stored leaves keep their data.
+	req.OmitLeafData = false
+	again, err := server.GetLeavesByRange(ctx, req)
+	if err != nil {
+		t.Fatalf("GetLeavesByRange(): %v", err)
+	}
+	for i := range again.Leaves {
+		if len(again.Leaves[i].LeafValue) == 0 || len(again.Leaves[i].ExtraData) == 0 {
+			t.Errorf("GetLeavesByRange() returned leaf %d without data after omit_leaf_data reads", again.Leaves[i].LeafIndex)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+
+	"github.com/google/trillian"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// pageTokenVersion is the first byte of encoded page tokens, so that
+	// their format can change.
+	pageTokenVersion = 1
+	// pageTokenLen is the length of encoded page tokens: the version, and
+	// the three fields of pageToken.
+	pageTokenLen = 1 + 3*8
+)
+
+// pageToken is the position of the next page of a range read of a log, which
+// is pinned to the tree size of the first page. It's opaque to clients, which
+// can't forge more than a read they could request anyway, as the server
+// checks it against the log.
+type pageToken struct {
+	treeID   int64
+	treeSize int64
+	next     int64
+}
+
+// encode returns the token as a URL-safe string.
+func (p pageToken) encode() string {
+	buf := make([]byte, pageTokenLen)
+	buf[0] = pageTokenVersion
+	binary.BigEndian.PutUint64(buf[1:], uint64(p.treeID))
+	binary.BigEndian.PutUint64(buf[9:], uint64(p.treeSize))
+	binary.BigEndian.PutUint64(buf[17:], uint64(p.next))
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
+
+// decodePageToken parses a token returned by encode for the given log.
+func decodePageToken(s string, logID int64) (*pageToken, error) {
+	buf, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(buf) != pageTokenLen || buf[0] != pageTokenVersion {
+		return nil, status.Error(codes.InvalidArgument, "malformed page_token")
+	}
+	p := &pageToken{
+		treeID:   int64(binary.BigEndian.Uint64(buf[1:])),
+		treeSize: int64(binary.BigEndian.Uint64(buf[9:])),
+		next:     int64(binary.BigEndian.Uint64(buf[17:])),
+	}
+	if p.treeID != logID {
+		return nil, status.Errorf(codes.InvalidArgument, "page_token of log %d, want %d", p.treeID, logID)
+	}
+	if p.next < 0 || p.next >= p.treeSize {
+		return nil, status.Error(codes.InvalidArgument, "malformed page_token")
+	}
+	return p, nil
+}
+
+// pageRange returns the start index of a range read, and the tree size which
+// it is limited to. Reads with a page token continue from its position, and
+// are limited to its tree size, which the tree can't have grown past, so
+// pinned is true. Otherwise the read starts at the start index of the request,
+// and is limited to size.
+func pageRange(req *trillian.GetLeavesByRangeRequest, size int64) (start, limit int64, pinned bool, err error) {
+	if req.PageToken == "" {
+		return req.StartIndex, size, false, nil
+	}
+	p, err := decodePageToken(req.PageToken, req.LogId)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	if req.StartIndex != 0 && req.StartIndex != p.next {
+		return 0, 0, false, status.Errorf(codes.InvalidArgument, "GetLeavesByRangeRequest.StartIndex: %d, want 0 or %d of the page_token", req.StartIndex, p.next)
+	}
+	if p.treeSize > size {
+		return 0, 0, false, status.Errorf(codes.InvalidArgument, "page_token of tree size %d, which is beyond %d", p.treeSize, size)
+	}
+	return p.next, p.treeSize, true, nil
+}
@@ -0,0 +1,159 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/extension"
+	"github.com/google/trillian/log"
+	"github.com/google/trillian/quota"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/storage/memory"
+	stestonly "github.com/google/trillian/storage/testonly"
+	"github.com/google/trillian/util/clock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPageToken(t *testing.T) {
+	want := pageToken{treeID: 12345, treeSize: 100, next: 42}
+	got, err := decodePageToken(want.encode(), 12345)
+	if err != nil {
+		t.Fatalf("decodePageToken(): %v", err)
+	}
+	if *got != want {
+		t.Errorf("decodePageToken()=%+v, want %+v", *got, want)
+	}
+
+	for _, tc := range []struct {
+		desc  string
+		token string
+	}{
+		{desc: "notBase64", token: "!!!"},
+		{desc: "short", token: want.encode()[:10]},
+		{desc: "otherLog", token: pageToken{treeID: 1, treeSize: 100, next: 42}.encode()},
+		{desc: "atSize", token: pageToken{treeID: 12345, treeSize: 100, next: 100}.encode()},
+		{desc: "negative", token: pageToken{treeID: 12345, treeSize: 100, next: -1}.encode()},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			if _, err := decodePageToken(tc.token, 12345); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("decodePageToken(): %v, want code %v", err, codes.InvalidArgument)
+			}
+		})
+	}
+}
+
+func TestGetLeavesByRangePaging(t *testing.T) {
+	log.InitMetrics(nil)
+	ctx := context.Background()
+	ts := memory.NewTreeStorage()
+	registry := extension.Registry{
+		AdminStorage: memory.NewAdminStorage(ts),
+		LogStorage:   memory.NewLogStorage(ts, nil),
+	}
+	tree, err := storage.CreateTree(ctx, registry.AdminStorage, stestonly.LogTree)
+	if err != nil {
+		t.Fatalf("CreateTree(): %v", err)
+	}
+	server := NewTrillianLogRPCServer(registry, clock.System)
+	if _, err := server.InitLog(ctx, &trillian.InitLogRequest{LogId: tree.TreeId}); err != nil {
+		t.Fatalf("InitLog(): %v", err)
+	}
+	next := 0
+	grow := func(n int) {
+		t.Helper()
+		for i := 0; i < n; i++ {
+			req := &trillian.QueueLeafRequest{LogId: tree.TreeId, Leaf: &trillian.LogLeaf{LeafValue: []byte(fmt.Sprintf("leaf%d", next))}}
+			if _, err := server.QueueLeaf(ctx, req); err != nil {
+				t.Fatalf("QueueLeaf(): %v", err)
+			}
+			next++
+		}
+		if _, err := log.IntegrateBatch(ctx, tree, n, 0, 0, clock.System, registry.LogStorage, quota.Noop()); err != nil {
+			t.Fatalf("IntegrateBatch(): %v", err)
+		}
+	}
+	grow(5)
+
+	// The log grows while it's paged, but the pages stop at the size of the
+	// first one.
+	req := &trillian.GetLeavesByRangeRequest{LogId: tree.TreeId, Count: 2}
+	var indices []int64
+	for pages := 0; ; pages++ {
+		resp, err := server.GetLeavesByRange(ctx, req)
+		if err != nil {
+			t.Fatalf("GetLeavesByRange(page %d): %v", pages, err)
+		}
+		for _, leaf := range resp.Leaves {
+			indices = append(indices, leaf.LeafIndex)
+		}
+		if resp.NextPageToken == "" {
+			break
+		}
+		if pages > 5 {
+			t.Fatal("GetLeavesByRange() returned too many pages")
+		}
+		req.PageToken = resp.NextPageToken
+		grow(3)
+	}
+	if got, want := fmt.Sprint(indices), "[0 1 2 3 4]"; got != want {
+		t.Errorf("paged leaves %s, want %s", got, want)
+	}
+
+	// A new read is pinned to the grown tree.
+	req = &trillian.GetLeavesByRangeRequest{LogId: tree.TreeId, StartIndex: 5, Count: 100}
+	resp, err := server.GetLeavesByRange(ctx, req)
+	if err != nil {
+		t.Fatalf("GetLeavesByRange(): %v", err)
+	}
+	if got, want := len(resp.Leaves), next-5; got != want || resp.NextPageToken != "" {
+		t.Errorf("GetLeavesByRange() returned %d leaves and token %q, want %d leaves and no token", got, resp.NextPageToken, want)
+	}
+
+	// StreamLeavesByRange continues from a page token.
+	token := pageToken{treeID: tree.TreeId, treeSize: 6, next: 3}.encode()
+	stream := &fakeLeavesStream{}
+	if err := server.StreamLeavesByRange(&trillian.GetLeavesByRangeRequest{LogId: tree.TreeId, Count: 100, PageToken: token}, stream); err != nil {
+		t.Fatalf("StreamLeavesByRange(): %v", err)
+	}
+	var streamed []int64
+	for _, resp := range stream.resps {
+		for _, leaf := range resp.Leaves {
+			streamed = append(streamed, leaf.LeafIndex)
+		}
+	}
+	if got, want := fmt.Sprint(streamed), "[3 4 5]"; got != want {
+		t.Errorf("StreamLeavesByRange() streamed %s, want %s", got, want)
+	}
+
+	for _, tc := range []struct {
+		desc string
+		req  *trillian.GetLeavesByRangeRequest
+	}{
+		{desc: "otherStart", req: &trillian.GetLeavesByRangeRequest{LogId: tree.TreeId, StartIndex: 2, Count: 1, PageToken: token}},
+		{desc: "beyondTree", req: &trillian.GetLeavesByRangeRequest{LogId: tree.TreeId, Count: 1, PageToken: pageToken{treeID: tree.TreeId, treeSize: int64(next) + 1, next: 3}.encode()}},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			if _, err := server.GetLeavesByRange(ctx, tc.req); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("GetLeavesByRange(): %v, want code %v", err, codes.InvalidArgument)
+
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/types"
+	"github.com/transparency-dev/merkle"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// EnableInclusionPromises makes QueueLeaf return inclusion promises signed
+// with signer for trees with a max merge delay. Without a signer, QueueLeaf
+// fails for such trees.
+func (t *TrillianLogRPCServer) EnableInclusionPromises(signer crypto.Signer) {
+	t.promiseSigner = signer
+}
+
+// inclusionPromise returns the signed promise of the tree to integrate leaf
+// within its max merge delay of queueTime.
+func (t *TrillianLogRPCServer) inclusionPromise(tree *trillian.Tree, hasher merkle.LogHasher, leaf *trillian.LogLeaf, queueTime time.Time) (*trillian.InclusionPromise, error) {
+	// A leaf which was queued earlier is promised from its original queue
+	// time, as its deadline is the earlier one.
+	if ts := leaf.GetQueueTimestamp(); ts.IsValid() && ts.AsTime().Before(queueTime) {
+		queueTime = ts.AsTime()
+	}
+	promise, err := (&types.InclusionPromiseV1{
+		LogID: uint64(tree.TreeId),
+		// Storage may not return the Merkle hash of an existing leaf, which
+		// can also differ from the request's if its identity hash is custom.
+		MerkleLeafHash:      hasher.HashLeaf(leaf.LeafValue),
+		QueueTimestampNanos: uint64(queueTime.UnixNano()),
+		MaxMergeDelayNanos:  uint64(tree.MaxMergeDelay.AsDuration()),
+	}).MarshalBinary()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to marshal inclusion promise: %v", err)
+	}
+	sig, err := signMessage(t.promiseSigner, promise)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to sign inclusion promise: %v", err)
+	}
+	return &trillian.InclusionPromise{Promise: promise, Signature: sig}, nil
+}
+
+// signMessage signs the SHA-256 digest of msg, or msg itself with an Ed25519
+// key, which does its own hashing.
+func signMessage(signer crypto.Signer, msg []byte) ([]byte, error) {
+	if _, ok := signer.Public().(ed25519.PublicKey); ok {
+		return signer.Sign(rand.Reader, msg, crypto.Hash(0))
+	}
+	digest := sha256.Sum256(msg)
+	return signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+}
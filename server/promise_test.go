@@ -0,0 +1,103 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/client"
+	"github.com/google/trillian/extension"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/storage/memory"
+	stestonly "github.com/google/trillian/storage/testonly"
+	"github.com/google/trillian/util/clock"
+	"github.com/transparency-dev/merkle/rfc6962"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestQueueLeafInclusionPromise(t *testing.T) {
+	ctx := context.Background()
+	ts := memory.NewTreeStorage()
+	registry := extension.Registry{
+		AdminStorage: memory.NewAdminStorage(ts),
+		LogStorage:   memory.NewLogStorage(ts, nil),
+	}
+	tree := proto.Clone(stestonly.LogTree).(*trillian.Tree)
+	tree.MaxMergeDelay = durationpb.New(time.Hour)
+	tree, err := storage.CreateTree(ctx, registry.AdminStorage, tree)
+	if err != nil {
+		t.Fatalf("CreateTree(): %v", err)
+	}
+
+	now := time.Unix(1500000000, 0)
+	fakeClock := clock.NewFake(now)
+	server := NewTrillianLogRPCServer(registry, fakeClock)
+	if _, err := server.InitLog(ctx, &trillian.InitLogRequest{LogId: tree.TreeId}); err != nil {
+		t.Fatalf("InitLog(): %v", err)
+	}
+	leaf := newTestLeaf([]byte("value"), nil, 0)
+	req := &trillian.QueueLeafRequest{LogId: tree.TreeId, Leaf: leaf}
+	if _, err := server.QueueLeaf(ctx, req); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("QueueLeaf() without signer: %v, want code %v", err, codes.FailedPrecondition)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey(): %v", err)
+	}
+	server.EnableInclusionPromises(key)
+	resp, err := server.QueueLeaf(ctx, req)
+	if err != nil {
+		t.Fatalf("QueueLeaf(): %v", err)
+	}
+	promise, err := client.VerifyInclusionPromise(key.Public(), resp.InclusionPromise)
+	if err != nil {
+		t.Fatalf("VerifyInclusionPromise(): %v", err)
+	}
+	if got, want := promise.LogID, uint64(tree.TreeId); got != want {
+		t.Errorf("promise LogID: %d, want %d", got, want)
+	}
+	if !bytes.Equal(promise.MerkleLeafHash, leaf.MerkleLeafHash) {
+		t.Errorf("promise MerkleLeafHash: %x, want %x", promise.MerkleLeafHash, leaf.MerkleLeafHash)
+	}
+	if got, want := promise.Deadline(), now.Add(time.Hour); !got.Equal(want) {
+		t.Errorf("promise Deadline(): %v, want %v", got, want)
+	}
+
+	// An existing leaf is promised from the time it was first queued.
+	existing := proto.Clone(leaf).(*trillian.LogLeaf)
+	existing.QueueTimestamp = timestamppb.New(now.Add(-time.Minute))
+	resp.InclusionPromise, err = server.inclusionPromise(tree, rfc6962.DefaultHasher, existing, now)
+	if err != nil {
+		t.Fatalf("inclusionPromise(): %v", err)
+	}
+	if promise, err = client.VerifyInclusionPromise(key.Public(), resp.InclusionPromise); err != nil {
+		t.Fatalf("VerifyInclusionPromise(): %v", err)
+	}
+	if got, want := promise.Deadline(), now.Add(59*time.Minute); !got.Equal(want) {
+		t.Errorf("promise Deadline() of existing leaf: %v, want %v", got, want)
+	}
+}
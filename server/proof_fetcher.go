@@ -58,6 +58,49 @@ func fetchNodesAndBuildProof(ctx context.Context, nr nodeReader, hasher compact.
 	}, nil
 }
 
+// fetchNodesAndBuildProofs is like fetchNodesAndBuildProof, but builds proofs
+// for the same leaf from each of pns, e.g. at different tree sizes. The nodes
+// which the proofs share are only fetched once.
+func fetchNodesAndBuildProofs(ctx context.Context, nr nodeReader, hasher compact.HashFn, leafIndex uint64, pns []proof.Nodes) ([]*trillian.Proof, error) {
+	ctx, spanEnd := spanFor(ctx, "fetchNodesAndBuildProofs")
+	defer spanEnd()
+	var ids []compact.NodeID
+	seen := make(map[compact.NodeID]bool)
+	for _, pn := range pns {
+		for _, id := range pn.IDs {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	nodes, err := fetchNodes(ctx, nr, ids)
+	if err != nil {
+		return nil, err
+	}
+	hashes := make(map[compact.NodeID][]byte, len(nodes))
+	for _, node := range nodes {
+		hashes[node.ID] = node.Hash
+	}
+
+	proofs := make([]*trillian.Proof, 0, len(pns))
+	for _, pn := range pns {
+		h := make([][]byte, len(pn.IDs))
+		for i, id := range pn.IDs {
+			h[i] = hashes[id]
+		}
+		proof, err := pn.Rehash(h, hasher)
+		if err != nil {
+			return nil, err
+		}
+		proofs = append(proofs, &trillian.Proof{
+			LeafIndex: int64(leafIndex),
+			Hashes:    proof,
+		})
+	}
+	return proofs, nil
+}
+
 // fetchNodes obtains the nodes denoted by the given NodeFetch structs, and
 // returns them after some validation checks.
 func fetchNodes(ctx context.Context, nr nodeReader, ids []compact.NodeID) ([]tree.Node, error) {
@@ -80,3 +123,19 @@ func fetchNodes(ctx context.Context, nr nodeReader, ids []compact.NodeID) ([]tre
 
 	return nodes, nil
 }
+
+// proofNodeIDs returns the positions of the nodes of the proof which
+// fetchNodesAndBuildProof builds from pn, i.e. the IDs of the fetched nodes,
+// with the ones rehashed into an ephemeral node replaced by the ID of that.
+func proofNodeIDs(pn proof.Nodes) []*trillian.NodeID {
+	ephem, begin, end := pn.Ephem()
+	ids := make([]*trillian.NodeID, 0, len(pn.IDs))
+	for i := 0; i < len(pn.IDs); i++ {
+		id := pn.IDs[i]
+		if i == begin && begin < end {
+			id, i = ephem, end-1
+		}
+		ids = append(ids, &trillian.NodeID{Level: uint64(id.Level), Index: id.Index})
+	}
+	return ids
+}
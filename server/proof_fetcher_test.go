@@ -15,12 +15,15 @@
 package server
 
 import (
+	"bytes"
 	"context"
 	"encoding/hex"
 	"fmt"
 	"testing"
 
 	"github.com/google/trillian/storage/testonly"
+	"github.com/google/trillian/storage/tree"
+	"github.com/transparency-dev/merkle/compact"
 	"github.com/transparency-dev/merkle/proof"
 	"github.com/transparency-dev/merkle/rfc6962"
 	inmemory "github.com/transparency-dev/merkle/testonly"
@@ -116,6 +119,66 @@ func TestTree32InclusionProofFetchAll(t *testing.T) {
 	}
 }
 
+// countingNodeReader is a nodeReader which counts the nodes read.
+type countingNodeReader struct {
+	nodeReader
+	nodes int
+}
+
+func (r *countingNodeReader) GetMerkleNodes(ctx context.Context, ids []compact.NodeID) ([]tree.Node, error) {
+	r.nodes += len(ids)
+	return r.nodeReader.GetMerkleNodes(ctx, ids)
+}
+
+func TestTree32InclusionProofsAtSizesFetchAll(t *testing.T) {
+	ctx := context.Background()
+	hasher := rfc6962.DefaultHasher.HashChildren
+	const ts uint64 = 32
+	mt := treeAtSize(ts)
+
+	for l := uint64(0); l < ts; l++ {
+		r := &countingNodeReader{nodeReader: testonly.NewMultiFakeNodeReaderFromLeaves([]testonly.LeafBatch{
+			{TreeRevision: testTreeRevision, Leaves: expandLeaves(0, ts-1), ExpectedRoot: mt.Hash()},
+		})}
+		var pns []proof.Nodes
+		total := 0
+		for s := l + 1; s <= ts; s++ {
+			nodes, err := proof.Inclusion(l, s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pns = append(pns, nodes)
+			total += len(nodes.IDs)
+		}
+
+		proofs, err := fetchNodesAndBuildProofs(ctx, r, hasher, l, pns)
+		if err != nil {
+			t.Fatalf("fetchNodesAndBuildProofs(%d): %v", l, err)
+		}
+		if got, want := len(proofs), len(pns); got != want {
+			t.Fatalf("fetchNodesAndBuildProofs(%d): got %d proofs, want %d", l, got, want)
+		}
+		for i, p := range proofs {
+			s := l + 1 + uint64(i)
+			if got, want := p.LeafIndex, int64(l); got != want {
+				t.Errorf("leaf index mismatch: got %d, want %d", got, want)
+			}
+			refProof, err := mt.InclusionProof(l, s)
+			if err != nil {
+				t.Fatalf("InclusionProof: %v", err)
+			}
+			if got, want := fmt.Sprintf("%x", p.Hashes), fmt.Sprintf("%x", refProof); got != want {
+				t.Errorf("(%d, %d): got proof %s, want %s", s, l, got, want)
+			}
+		}
+		// The nodes shared by the proofs are read once, so unless there is a
+		// single proof, fewer nodes are read than the proofs hold.
+		if l+1 < ts && r.nodes >= total {
+			t.Errorf("(%d): read %d nodes, want fewer than the %d nodes of the proofs", l, r.nodes, total)
+		}
+	}
+}
+
 func TestTree32InclusionProofFetchMultiBatch(t *testing.T) {
 	ctx := context.Background()
 	hasher := rfc6962.DefaultHasher.HashChildren
@@ -200,6 +263,59 @@ func TestTree32ConsistencyProofFetchAll(t *testing.T) {
 	}
 }
 
+func TestProofNodeIDs(t *testing.T) {
+	ctx := context.Background()
+	hasher := rfc6962.DefaultHasher.HashChildren
+	const ts uint64 = 37
+	r := testonly.NewMultiFakeNodeReaderFromLeaves([]testonly.LeafBatch{
+		{TreeRevision: testTreeRevision, Leaves: expandLeaves(0, ts-1), ExpectedRoot: treeAtSize(ts).Hash()},
+	})
+
+	// subtreeHash returns the hash of the node with the given ID in the tree of
+	// the given size, which is ephemeral if the node's subtree is incomplete.
+	subtreeHash := func(level, index, size uint64) []byte {
+		begin, end := index<<level, (index+1)<<level
+		if end > size {
+			end = size
+		}
+		mt := inmemory.New(rfc6962.DefaultHasher)
+		for _, leaf := range expandLeaves(begin, end-1) {
+			mt.AppendData([]byte(leaf))
+		}
+		return mt.Hash()
+	}
+
+	for size := uint64(1); size <= ts; size++ {
+		for i := uint64(0); i < size*2; i++ {
+			var nodes proof.Nodes
+			var err error
+			desc := fmt.Sprintf("inclusion(%d, %d)", i, size)
+			if i < size {
+				nodes, err = proof.Inclusion(i, size)
+			} else {
+				desc = fmt.Sprintf("consistency(%d, %d)", i-size, size)
+				nodes, err = proof.Consistency(i-size, size)
+			}
+			if err != nil {
+				t.Fatalf("%s: %v", desc, err)
+			}
+			p, err := fetchNodesAndBuildProof(ctx, r, hasher, 0, nodes)
+			if err != nil {
+				t.Fatalf("%s: fetchNodesAndBuildProof(): %v", desc, err)
+			}
+			ids := proofNodeIDs(nodes)
+			if got, want := len(ids), len(p.Hashes); got != want {
+				t.Fatalf("%s: got %d node IDs for %d hashes", desc, got, want)
+			}
+			for j, id := range ids {
+				if got, want := p.Hashes[j], subtreeHash(id.Level, id.Index, size); !bytes.Equal(got, want) {
+					t.Errorf("%s: hash %d is %x, but node %v has hash %x", desc, j, got, id, want)
+				}
+			}
+		}
+	}
+}
+
 func expandLeaves(n, m uint64) []string {
 	leaves := make([]string, 0, m-n+1)
 	for l := n; l <= m; l++ {
@@ -0,0 +1,141 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/types"
+	"github.com/transparency-dev/merkle"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultQueueBatchSize is the default maximum number of leaves of a
+// QueueLeavesStream call which are queued together.
+const DefaultQueueBatchSize = 1000
+
+// SetQueueBatchSize sets the maximum number of leaves of a QueueLeavesStream
+// call which are queued together, in a single storage transaction.
+func (t *TrillianLogRPCServer) SetQueueBatchSize(size int) {
+	t.queueBatchSize = size
+}
+
+// queueStreamBatch holds the leaves of a QueueLeavesStream call which aren't
+// queued yet.
+type queueStreamBatch struct {
+	tree       *trillian.Tree
+	hasher     merkle.LogHasher
+	durability trillian.QueueDurability
+	leaves     []*trillian.LogLeaf
+}
+
+// QueueLeavesStream queues the leaves of a stream of requests for the same
+// log, in batches of up to the queue batch size.
+func (t *TrillianLogRPCServer) QueueLeavesStream(stream trillian.TrillianLog_QueueLeavesStreamServer) error {
+	ctx, spanEnd := spanFor(stream.Context(), "QueueLeavesStream")
+	defer spanEnd()
+
+	var batch *queueStreamBatch
+	var validator *types.LeafValidator
+	var logID int64
+	resp := &trillian.QueueLeavesStreamResponse{}
+	for i := 0; ; i++ {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		prefix := fmt.Sprintf("QueueLeafRequest[%d].Leaf", i)
+		if err := validateLogLeaf(req.Leaf, prefix); err != nil {
+			return err
+		}
+
+		if batch == nil {
+			tree, hasher, err := t.getTreeAndHasher(ctx, req.LogId, optsLogWrite)
+			if err != nil {
+				return err
+			}
+			// Check before queueing that promises can be made, if they are due.
+			if tree.MaxMergeDelay != nil && t.promiseSigner == nil {
+				return status.Errorf(codes.FailedPrecondition, "tree %d has a max merge delay, but no inclusion promise signing key is configured", tree.TreeId)
+			}
+			if err := t.checkDurability(tree, req.Durability); err != nil {
+				return err
+			}
+			if validator, err = t.leafValidator(tree); err != nil {
+				return err
+			}
+			batch = &queueStreamBatch{tree: tree, hasher: hasher, durability: req.Durability}
+			logID = req.LogId
+		} else if req.LogId != logID {
+			return status.Errorf(codes.InvalidArgument, "QueueLeafRequest[%d].LogId: %d, want %d of the first request", i, req.LogId, logID)
+		} else if req.Durability != batch.durability {
+			return status.Errorf(codes.InvalidArgument, "QueueLeafRequest[%d].Durability: %v, want %v of the first request", i, req.Durability, batch.durability)
+		}
+		if err := validateLeafValue(validator, req.Leaf, prefix); err != nil {
+			return err
+		}
+
+		batch.leaves = append(batch.leaves, req.Leaf)
+		if len(batch.leaves) >= t.queueBatchSize {
+			if err := t.queueBatch(ctx, batch, resp); err != nil {
+				return err
+			}
+		}
+	}
+	if batch != nil && len(batch.leaves) > 0 {
+		if err := t.queueBatch(ctx, batch, resp); err != nil {
+			return err
+		}
+	}
+	return stream.SendAndClose(resp)
+}
+
+// queueBatch queues the leaves of batch, and appends their statuses and
+// promises to resp.
+func (t *TrillianLogRPCServer) queueBatch(ctx context.Context, batch *queueStreamBatch, resp *trillian.QueueLeavesStreamResponse) error {
+	tree := batch.tree
+	hashLeaves(batch.leaves, batch.hasher)
+
+	now := t.timeSource.Now()
+	ret, err := t.queueLeaves(ctx, tree, batch.leaves, now, batch.durability)
+	if err != nil {
+		return err
+	}
+	if got, want := len(ret), len(batch.leaves); got != want {
+		return status.Errorf(codes.Internal, "QueueLeaves returned %d leaves, want: %d", got, want)
+	}
+	resp.QueuedLeaves = append(resp.QueuedLeaves, ret...)
+	if tree.MaxMergeDelay != nil {
+		for i, queued := range ret {
+			leaf := queued.GetLeaf()
+			if leaf == nil {
+				leaf = batch.leaves[i]
+			}
+			promise, err := t.inclusionPromise(tree, batch.hasher, leaf, now)
+			if err != nil {
+				return err
+			}
+			resp.InclusionPromises = append(resp.InclusionPromises, promise)
+		}
+	}
+	batch.leaves = nil
+	return nil
+}
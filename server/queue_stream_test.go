@@ -0,0 +1,189 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/trillian"
+	"github.com/google/trillian/extension"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/storage/memory"
+	stestonly "github.com/google/trillian/storage/testonly"
+	"github.com/google/trillian/util/clock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeQueueStream is a QueueLeavesStream server stream which receives reqs.
+type fakeQueueStream struct {
+	grpc.ServerStream
+	reqs []*trillian.QueueLeafRequest
+	resp *trillian.QueueLeavesStreamResponse
+}
+
+func (s *fakeQueueStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *fakeQueueStream) Recv() (*trillian.QueueLeafRequest, error) {
+	if len(s.reqs) == 0 {
+		return nil, io.EOF
+	}
+	req := s.reqs[0]
+	s.reqs = s.reqs[1:]
+	return req, nil
+}
+
+func (s *fakeQueueStream) SendAndClose(resp *trillian.QueueLeavesStreamResponse) error {
+	s.resp = resp
+	return nil
+}
+
+// batchRecordingStorage records the sizes of the batches of queued leaves.
+type batchRecordingStorage struct {
+	storage.LogStorage
+	batches []int
+}
+
+func (s *batchRecordingStorage) QueueLeaves(ctx context.Context, tree *trillian.Tree, leaves []*trillian.LogLeaf, queueTimestamp time.Time) ([]*trillian.QueuedLogLeaf, error) {
+	s.batches = append(s.batches, len(leaves))
+	return s.LogStorage.QueueLeaves(ctx, tree, leaves, queueTimestamp)
+}
+
+func TestQueueLeavesStream(t *testing.T) {
+	ctx := context.Background()
+	ts := memory.NewTreeStorage()
+	ls := &batchRecordingStorage{LogStorage: memory.NewLogStorage(ts, nil)}
+	registry := extension.Registry{
+		AdminStorage: memory.NewAdminStorage(ts),
+		LogStorage:   ls,
+	}
+	tree, err := storage.CreateTree(ctx, registry.AdminStorage, stestonly.LogTree)
+	if err != nil {
+		t.Fatalf("CreateTree(): %v", err)
+	}
+	server := NewTrillianLogRPCServer(registry, clock.System)
+	server.SetQueueBatchSize(2)
+	if _, err := server.InitLog(ctx, &trillian.InitLogRequest{LogId: tree.TreeId}); err != nil {
+		t.Fatalf("InitLog(): %v", err)
+	}
+
+	newRequests := func(values ...string) []*trillian.QueueLeafRequest {
+		var reqs []*trillian.QueueLeafRequest
+		for _, v := range values {
+			reqs = append(reqs, &trillian.QueueLeafRequest{LogId: tree.TreeId, Leaf: &trillian.LogLeaf{LeafValue: []byte(v)}})
+		}
+		return reqs
+	}
+
+	stream := &fakeQueueStream{reqs: newRequests("a", "b", "c", "d", "e")}
+	if err := server.QueueLeavesStream(stream); err != nil {
+		t.Fatalf("QueueLeavesStream(): %v", err)
+	}
+	if diff := cmp.Diff([]int{2, 2, 1}, ls.batches); diff != "" {
+		t.Errorf("QueueLeaves() batch sizes diff (-want +got):\n%s", diff)
+	}
+	var got []string
+	for _, queued := range stream.resp.GetQueuedLeaves() {
+		got = append(got, fmt.Sprintf("%s:%v", queued.GetLeaf().GetLeafValue(), status.FromProto(queued.GetStatus()).Code()))
+	}
+	want := []string{"a:OK", "b:OK", "c:OK", "d:OK", "e:OK"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("QueueLeavesStream() statuses diff (-want +got):\n%s", diff)
+	}
+	if n := len(stream.resp.GetInclusionPromises()); n != 0 {
+		t.Errorf("QueueLeavesStream() returned %d inclusion promises, want none", n)
+	}
+
+	// An empty stream queues nothing.
+	ls.batches = nil
+	stream = &fakeQueueStream{}
+	if err := server.QueueLeavesStream(stream); err != nil {
+		t.Fatalf("QueueLeavesStream(empty): %v", err)
+	}
+	if len(ls.batches) != 0 || len(stream.resp.GetQueuedLeaves()) != 0 {
+		t.Errorf("QueueLeavesStream(empty) queued batches %v, returned %v", ls.batches, stream.resp)
+	}
+}
+
+func TestQueueLeavesStreamErrors(t *testing.T) {
+	ctx := context.Background()
+	ts := memory.NewTreeStorage()
+	registry := extension.Registry{
+		AdminStorage: memory.NewAdminStorage(ts),
+		LogStorage:   memory.NewLogStorage(ts, nil),
+	}
+	tree, err := storage.CreateTree(ctx, registry.AdminStorage, stestonly.LogTree)
+	if err != nil {
+		t.Fatalf("CreateTree(): %v", err)
+	}
+	server := NewTrillianLogRPCServer(registry, clock.System)
+	if _, err := server.InitLog(ctx, &trillian.InitLogRequest{LogId: tree.TreeId}); err != nil {
+		t.Fatalf("InitLog(): %v", err)
+	}
+
+	leaf := &trillian.LogLeaf{LeafValue: []byte("a")}
+	for _, tc := range []struct {
+		desc     string
+		reqs     []*trillian.QueueLeafRequest
+		wantCode codes.Code
+	}{
+		{
+			desc: "differentLogs",
+			reqs: []*trillian.QueueLeafRequest{
+				{LogId: tree.TreeId, Leaf: leaf},
+				{LogId: tree.TreeId + 1, Leaf: leaf},
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			desc: "differentDurability",
+			reqs: []*trillian.QueueLeafRequest{
+				{LogId: tree.TreeId, Leaf: leaf},
+				{LogId: tree.TreeId, Leaf: leaf, Durability: trillian.QueueDurability_ACK_AFTER_MEMORY},
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			desc: "replicationUnsupported",
+			reqs: []*trillian.QueueLeafRequest{
+				{LogId: tree.TreeId, Leaf: leaf, Durability: trillian.QueueDurability_ACK_AFTER_REPLICATION},
+			},
+			wantCode: codes.Unimplemented,
+		},
+		{
+			desc: "missingLeaf",
+			reqs: []*trillian.QueueLeafRequest{
+				{LogId: tree.TreeId, Leaf: leaf},
+				{LogId: tree.TreeId},
+			},
+			wantCode: codes.InvalidArgument,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := server.QueueLeavesStream(&fakeQueueStream{reqs: tc.reqs})
+			if got := status.Code(err); got != tc.wantCode {
+				t.Errorf("QueueLeavesStream(): %v, want code %v", err, tc.wantCode)
+			}
+		})
+	}
+}
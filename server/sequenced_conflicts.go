@@ -0,0 +1,115 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// applyConflictPolicy rewrites the AddSequencedLeaves results of leaves which
+// conflict with stored leaves, according to the conflict policy and duplicate
+// window of the tree. Storage reports all conflicts as FailedPrecondition,
+// which is what the default CONFLICT_FAIL policy returns.
+func (t *TrillianLogRPCServer) applyConflictPolicy(ctx context.Context, tree *trillian.Tree, leaves []*trillian.LogLeaf, results []*trillian.QueuedLogLeaf) error {
+	policy := tree.SequencedLeafConflictPolicy
+	if policy == trillian.SequencedLeafConflictPolicy_CONFLICT_FAIL {
+		return nil
+	}
+	var conflicts []int
+	for i, r := range results {
+		if r.GetStatus().GetCode() == int32(codes.FailedPrecondition) {
+			conflicts = append(conflicts, i)
+		}
+	}
+	if len(conflicts) == 0 {
+		return nil
+	}
+
+	tx, err := t.snapshotForTree(ctx, tree, "AddSequencedLeaves")
+	if err != nil {
+		return err
+	}
+	defer t.closeAndLog(ctx, tree.TreeId, tx, "AddSequencedLeaves")
+
+	slr, err := tx.LatestSignedLogRoot(ctx)
+	if err != nil {
+		return err
+	}
+	var root types.LogRootV1
+	if err := root.UnmarshalBinary(slr.LogRoot); err != nil {
+		return status.Errorf(codes.Internal, "Could not read current log root: %v", err)
+	}
+
+	for _, i := range conflicts {
+		leaf := leaves[i]
+		if window := tree.SequencedLeafDuplicateWindow; window > 0 && leaf.LeafIndex < int64(root.TreeSize)-window {
+			// Too far behind the tree size, the conflict stands.
+			continue
+		}
+		stored, err := storedLeafAt(ctx, tx, leaf.LeafIndex)
+		if err != nil {
+			return err
+		}
+		if stored == nil {
+			// The leaf conflicts with a leaf at another index, e.g. one with
+			// the same identity hash, which no policy resolves.
+			continue
+		}
+		switch policy {
+		case trillian.SequencedLeafConflictPolicy_CONFLICT_SKIP:
+			results[i] = &trillian.QueuedLogLeaf{
+				Leaf:   stored,
+				Status: status.New(codes.AlreadyExists, "conflicting leaf skipped").Proto(),
+			}
+		case trillian.SequencedLeafConflictPolicy_CONFLICT_OVERWRITE_IF_IDENTICAL:
+			if identicalLeaves(leaf, stored) {
+				results[i] = &trillian.QueuedLogLeaf{
+					Leaf:   stored,
+					Status: status.New(codes.OK, "OK").Proto(),
+				}
+			} else {
+				results[i].Leaf = stored
+			}
+		}
+	}
+	return t.commitAndLog(ctx, tree.TreeId, tx, "AddSequencedLeaves")
+}
+
+// storedLeafAt returns the leaf stored at the given index, or nil if there is
+// none.
+func storedLeafAt(ctx context.Context, tx storage.ReadOnlyLogTreeTX, index int64) (*trillian.LogLeaf, error) {
+	leaves, err := tx.GetLeavesByRange(ctx, index, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(leaves) != 1 || leaves[0].LeafIndex != index {
+		return nil, nil
+	}
+	return leaves[0], nil
+}
+
+func identicalLeaves(a, b *trillian.LogLeaf) bool {
+	return bytes.Equal(a.LeafIdentityHash, b.LeafIdentityHash) &&
+		bytes.Equal(a.MerkleLeafHash, b.MerkleLeafHash) &&
+		bytes.Equal(a.LeafValue, b.LeafValue) &&
+		bytes.Equal(a.ExtraData, b.ExtraData)
+}
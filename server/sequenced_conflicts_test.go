@@ -0,0 +1,157 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/trillian"
+	"github.com/google/trillian/extension"
+	"github.com/google/trillian/storage"
+	stestonly "github.com/google/trillian/storage/testonly"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestAddSequencedLeaves_ConflictPolicy(t *testing.T) {
+	// The tree of signedRoot1 has size 7.
+	newLeaves := func() []*trillian.LogLeaf {
+		return []*trillian.LogLeaf{
+			newTestLeaf([]byte("value5"), nil, 5),
+			newTestLeaf([]byte("value6"), nil, 6),
+			newTestLeaf([]byte("value7"), nil, 7),
+		}
+	}
+	stored := newLeaves()[:2]
+	stored[1] = newTestLeaf([]byte("other6"), nil, 6)
+	for _, l := range stored {
+		l.LeafIdentityHash = l.MerkleLeafHash
+	}
+	// If conflictElsewhere is set, the leaf at index 7 conflicts with a leaf
+	// stored at another index, as there is none at its own.
+	storageResults := func(conflictElsewhere bool) []*trillian.QueuedLogLeaf {
+		conflict := status.New(codes.FailedPrecondition, "conflicting LeafIndex").Proto()
+		last := status.New(codes.OK, "OK").Proto()
+		if conflictElsewhere {
+			last = status.New(codes.FailedPrecondition, "conflicting LeafIdentityHash").Proto()
+		}
+		return []*trillian.QueuedLogLeaf{
+			{Status: conflict},
+			{Status: conflict},
+			{Status: last},
+		}
+	}
+
+	for _, test := range []struct {
+		desc              string
+		policy            trillian.SequencedLeafConflictPolicy
+		window            int64
+		conflictElsewhere bool
+		wantCodes         []codes.Code
+		wantLeaves        []*trillian.LogLeaf
+	}{
+		{
+			desc:       "fail",
+			policy:     trillian.SequencedLeafConflictPolicy_CONFLICT_FAIL,
+			wantCodes:  []codes.Code{codes.FailedPrecondition, codes.FailedPrecondition, codes.OK},
+			wantLeaves: []*trillian.LogLeaf{nil, nil, nil},
+		},
+		{
+			desc:       "skip",
+			policy:     trillian.SequencedLeafConflictPolicy_CONFLICT_SKIP,
+			wantCodes:  []codes.Code{codes.AlreadyExists, codes.AlreadyExists, codes.OK},
+			wantLeaves: []*trillian.LogLeaf{stored[0], stored[1], nil},
+		},
+		{
+			desc:       "skip-within-window",
+			policy:     trillian.SequencedLeafConflictPolicy_CONFLICT_SKIP,
+			window:     1,
+			wantCodes:  []codes.Code{codes.FailedPrecondition, codes.AlreadyExists, codes.OK},
+			wantLeaves: []*trillian.LogLeaf{nil, stored[1], nil},
+		},
+		{
+			desc:              "skip-conflict-elsewhere",
+			policy:            trillian.SequencedLeafConflictPolicy_CONFLICT_SKIP,
+			conflictElsewhere: true,
+			wantCodes:         []codes.Code{codes.AlreadyExists, codes.AlreadyExists, codes.FailedPrecondition},
+			wantLeaves:        []*trillian.LogLeaf{stored[0], stored[1], nil},
+		},
+		{
+			desc:       "overwrite-if-identical",
+			policy:     trillian.SequencedLeafConflictPolicy_CONFLICT_OVERWRITE_IF_IDENTICAL,
+			wantCodes:  []codes.Code{codes.OK, codes.FailedPrecondition, codes.OK},
+			wantLeaves: []*trillian.LogLeaf{stored[0], stored[1], nil},
+		},
+		{
+			desc:              "overwrite-if-identical-conflict-elsewhere",
+			policy:            trillian.SequencedLeafConflictPolicy_CONFLICT_OVERWRITE_IF_IDENTICAL,
+			conflictElsewhere: true,
+			wantCodes:         []codes.Code{codes.OK, codes.FailedPrecondition, codes.FailedPrecondition},
+			wantLeaves:        []*trillian.LogLeaf{stored[0], stored[1], nil},
+		},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tree := addTreeID(stestonly.PreorderedLogTree, logID3)
+			tree.SequencedLeafConflictPolicy = test.policy
+			tree.SequencedLeafDuplicateWindow = test.window
+
+			adminStorage := storage.NewMockAdminStorage(ctrl)
+			adminTX := storage.NewMockReadOnlyAdminTX(ctrl)
+			adminStorage.EXPECT().Snapshot(gomock.Any()).Return(adminTX, nil)
+			adminTX.EXPECT().GetTree(gomock.Any(), logID3).Return(tree, nil)
+			adminTX.EXPECT().Commit().Return(nil)
+			adminTX.EXPECT().Close().Return(nil)
+
+			logStorage := storage.NewMockLogStorage(ctrl)
+			logStorage.EXPECT().AddSequencedLeaves(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(storageResults(test.conflictElsewhere), nil)
+			if test.policy != trillian.SequencedLeafConflictPolicy_CONFLICT_FAIL {
+				tx := storage.NewMockLogTreeTX(ctrl)
+				logStorage.EXPECT().SnapshotForTree(gomock.Any(), gomock.Any()).Return(tx, nil)
+				tx.EXPECT().LatestSignedLogRoot(gomock.Any()).Return(signedRoot1, nil)
+				tx.EXPECT().GetLeavesByRange(gomock.Any(), gomock.Any(), int64(1)).AnyTimes().DoAndReturn(
+					func(_ context.Context, start, _ int64) ([]*trillian.LogLeaf, error) {
+						if i := int(start - 5); i < len(stored) {
+							return []*trillian.LogLeaf{stored[i]}, nil
+						}
+						return nil, nil
+					})
+				tx.EXPECT().Commit(gomock.Any()).Return(nil)
+				tx.EXPECT().Close().Return(nil)
+			}
+
+			registry := extension.Registry{AdminStorage: adminStorage, LogStorage: logStorage}
+			server := NewTrillianLogRPCServer(registry, fakeTimeSource)
+			rsp, err := server.AddSequencedLeaves(ctx, &trillian.AddSequencedLeavesRequest{LogId: logID3, Leaves: newLeaves()})
+			if err != nil {
+				t.Fatalf("AddSequencedLeaves(): %v", err)
+			}
+			for i, r := range rsp.Results {
+				if got, want := codes.Code(r.Status.Code), test.wantCodes[i]; got != want {
+					t.Errorf("Results[%d].Status.Code=%v, want %v", i, got, want)
+				}
+				if got, want := r.Leaf, test.wantLeaves[i]; !proto.Equal(got, want) {
+					t.Errorf("Results[%d].Leaf=%v, want %v", i, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/trees"
+	"github.com/google/trillian/types"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultStorageUsageRefresh is the default interval at which the storage
+// usage of logs with storage caps is read again.
+const DefaultStorageUsageRefresh = time.Minute
+
+// storageUsage is the storage used by a log, as read at a time.
+type storageUsage struct {
+	read   time.Time
+	leaves int64
+	// bytes is the total size of the leaf values, or -1 if the storage
+	// doesn't keep daily log statistics.
+	bytes int64
+}
+
+// SetStorageUsageRefresh sets the interval at which the storage usage of logs
+// with storage caps is read again, which bounds how long a log can exceed a
+// cap before it's noticed.
+func (t *TrillianLogRPCServer) SetStorageUsageRefresh(d time.Duration) {
+	t.storageUsageRefresh = d
+}
+
+// checkStorageCaps returns a ResourceExhausted error, with a QuotaFailure
+// detail naming the cap, if tree reached one of its hard storage caps, so that
+// its leaves are not queued. Reached soft caps are logged when the usage of
+// the tree is read, and all of them are exported as the
+// tree_storage_cap_reached metric.
+func (t *TrillianLogRPCServer) checkStorageCaps(ctx context.Context, tree *trillian.Tree, leaves int) error {
+	caps := tree.StorageCaps
+	if caps == nil {
+		return nil
+	}
+	usage, fresh, err := t.storageUsage(ctx, tree)
+	if err != nil {
+		return err
+	}
+	if fresh && usage.bytes < 0 && (caps.SoftMaxBytes > 0 || caps.HardMaxBytes > 0) {
+		glog.Warningf("%d: storage caps on bytes not checked, as the storage doesn't keep daily log statistics", tree.TreeId)
+	}
+
+	label := strconv.FormatInt(tree.TreeId, 10)
+	var violation *errdetails.QuotaFailure_Violation
+	for _, c := range []struct {
+		name      string
+		max, used int64
+		hard      bool
+	}{
+		{name: "soft_max_leaves", max: caps.SoftMaxLeaves, used: usage.leaves},
+		{name: "hard_max_leaves", max: caps.HardMaxLeaves, used: usage.leaves, hard: true},
+		{name: "soft_max_bytes", max: caps.SoftMaxBytes, used: usage.bytes},
+		{name: "hard_max_bytes", max: caps.HardMaxBytes, used: usage.bytes, hard: true},
+	} {
+		reached := c.max > 0 && c.used >= c.max
+		if fresh {
+			value := 0.0
+			if reached {
+				value = 1
+			}
+			t.storageCapReached.Set(value, label, c.name)
+			if reached && !c.hard {
+				glog.Warningf("%d: storage cap %s reached: %d of %d", tree.TreeId, c.name, c.used, c.max)
+			}
+		}
+		if reached && c.hard && violation == nil {
+			violation = &errdetails.QuotaFailure_Violation{
+				Subject:     "storage_caps." + c.name,
+				Description: fmt.Sprintf("log %d uses %d of %d", tree.TreeId, c.used, c.max),
+			}
+		}
+	}
+	if violation == nil {
+		return nil
+	}
+
+	t.storageCapRejected.Add(float64(leaves), label)
+	st := status.Newf(codes.ResourceExhausted, "log %d reached its storage cap %s", tree.TreeId, violation.Subject)
+	if withInfo, err := st.WithDetails(&errdetails.QuotaFailure{Violations: []*errdetails.QuotaFailure_Violation{violation}}); err == nil {
+		st = withInfo
+	}
+	return st.Err()
+}
+
+// storageUsage returns the storage usage of tree, and whether it was read
+// from storage rather than cached.
+func (t *TrillianLogRPCServer) storageUsage(ctx context.Context, tree *trillian.Tree) (storageUsage, bool, error) {
+	now := t.timeSource.Now()
+	t.mu.RLock()
+	u, ok := t.storageUsages[tree.TreeId]
+	t.mu.RUnlock()
+	if ok && now.Sub(u.read) < t.storageUsageRefresh {
+		return u, false, nil
+	}
+
+	u, err := t.readStorageUsage(ctx, tree)
+	if err != nil {
+		return storageUsage{}, false, err
+	}
+	u.read = now
+	t.mu.Lock()
+	t.storageUsages[tree.TreeId] = u
+	t.mu.Unlock()
+	return u, true, nil
+}
+
+// readStorageUsage reads the storage usage of tree: its size, and the total
+// size of its leaf values from its daily log statistics.
+func (t *TrillianLogRPCServer) readStorageUsage(ctx context.Context, tree *trillian.Tree) (storageUsage, error) {
+	const op = "readStorageUsage"
+	ctx = trees.NewContext(ctx, tree)
+	tx, err := t.snapshotForTree(ctx, tree, op)
+	if err == storage.ErrTreeNeedsInit {
+		return storageUsage{}, nil
+	} else if err != nil {
+		return storageUsage{}, err
+	}
+	defer t.closeAndLog(ctx, tree.TreeId, tx, op)
+
+	slr, err := tx.LatestSignedLogRoot(ctx)
+	if err != nil {
+		return storageUsage{}, err
+	}
+	var root types.LogRootV1
+	if err := root.UnmarshalBinary(slr.LogRoot); err != nil {
+		return storageUsage{}, status.Errorf(codes.Internal, "failed to unmarshal latest root: %v", err)
+	}
+	u := storageUsage{leaves: int64(root.TreeSize), bytes: -1}
+	if stx, err := storage.AsLogStatsTX(tx); err == nil {
+		stats, err := stx.ListDailyStats(ctx)
+		if err != nil {
+			return storageUsage{}, err
+		}
+		u.bytes = 0
+		for _, s := range stats {
+			u.bytes += s.LeafValueBytes
+		}
+	}
+	if err := t.commitAndLog(ctx, tree.TreeId, tx, op); err != nil {
+		return storageUsage{}, err
+	}
+	return u, nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/extension"
+	"github.com/google/trillian/log"
+	"github.com/google/trillian/quota"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/storage/memory"
+	stestonly "github.com/google/trillian/storage/testonly"
+	"github.com/google/trillian/util/clock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestStorageCaps(t *testing.T) {
+	log.InitMetrics(nil)
+	ctx := context.Background()
+	for _, tc := range []struct {
+		desc        string
+		caps        *trillian.StorageCaps
+		wantSubject string
+	}{
+		{desc: "leaves", caps: &trillian.StorageCaps{SoftMaxLeaves: 2, HardMaxLeaves: 4}, wantSubject: "storage_caps.hard_max_leaves"},
+		// The leaf values are 5 bytes long.
+		{desc: "bytes", caps: &trillian.StorageCaps{HardMaxLeaves: 100, HardMaxBytes: 20}, wantSubject: "storage_caps.hard_max_bytes"},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			ts := memory.NewTreeStorage()
+			registry := extension.Registry{
+				AdminStorage: memory.NewAdminStorage(ts),
+				LogStorage:   memory.NewLogStorage(ts, nil),
+			}
+			tree := proto.Clone(stestonly.LogTree).(*trillian.Tree)
+			tree.StorageCaps = tc.caps
+			tree, err := storage.CreateTree(ctx, registry.AdminStorage, tree)
+			if err != nil {
+				t.Fatalf("CreateTree(): %v", err)
+			}
+			fakeTime := clock.NewFake(time.Unix(1000, 0))
+			server := NewTrillianLogRPCServer(registry, fakeTime)
+			if _, err := server.InitLog(ctx, &trillian.InitLogRequest{LogId: tree.TreeId}); err != nil {
+				t.Fatalf("InitLog(): %v", err)
+			}
+			queued := 0
+			queueLeaf := func() error {
+				req := &trillian.QueueLeafRequest{LogId: tree.TreeId, Leaf: &trillian.LogLeaf{LeafValue: []byte(fmt.Sprintf("leaf%d", queued))}}
+				queued++
+				_, err := server.QueueLeaf(ctx, req)
+				return err
+			}
+
+			for i := 0; i < 4; i++ {
+				if err := queueLeaf(); err != nil {
+					t.Fatalf("QueueLeaf(%d): %v", i, err)
+				}
+			}
+			if _, err := log.IntegrateBatch(ctx, tree, 4, 0, 0, clock.System, registry.LogStorage, quota.Noop()); err != nil {
+				t.Fatalf("IntegrateBatch(): %v", err)
+			}
+			// The usage is only read again after the refresh interval.
+			if err := queueLeaf(); err != nil {
+				t.Fatalf("QueueLeaf() before refresh: %v", err)
+			}
+
+			fakeTime.Set(fakeTime.Now().Add(DefaultStorageUsageRefresh))
+			err = queueLeaf()
+			if got, want := status.Code(err), codes.ResourceExhausted; got != want {
+				t.Fatalf("QueueLeaf() at cap: %v, want code %v", err, want)
+			}
+			var subject string
+			for _, d := range status.Convert(err).Details() {
+				if qf, ok := d.(*errdetails.QuotaFailure); ok && len(qf.Violations) == 1 {
+					subject = qf.Violations[0].Subject
+				}
+			}
+			if subject != tc.wantSubject {
+				t.Errorf("QueueLeaf() at cap: violation subject %q, want %q", subject, tc.wantSubject)
+			}
+
+			// Lifting the caps takes effect right away.
+			if _, err := storage.UpdateTree(ctx, registry.AdminStorage, tree.TreeId, func(tree *trillian.Tree) {
+				tree.StorageCaps = nil
+			}); err != nil {
+				t.Fatalf("UpdateTree(): %v", err)
+			}
+			if err := queueLeaf(); err != nil {
+				t.Errorf("QueueLeaf() without caps: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// defaultStreamBatchSize is the default maximum number of leaves which
+	// StreamLeavesByRange reads from storage in a single transaction.
+	defaultStreamBatchSize = 1000
+	// defaultStreamChunkBytes is the default size of leaves above which
+	// StreamLeavesByRange starts a new response. It keeps responses well below
+	// the default gRPC message size limit of 4MiB.
+	defaultStreamChunkBytes = 1 << 20
+)
+
+// StreamLeavesByRange streams the leaves of a sequential range, up to the tree
+// size at the start of the call. Leaves are read from storage in batches, each
+// in its own snapshot, and sent in chunks of limited size. Sending blocks while
+// the client doesn't keep up, so the server buffers at most a batch of leaves.
+func (t *TrillianLogRPCServer) StreamLeavesByRange(req *trillian.GetLeavesByRangeRequest, stream trillian.TrillianLog_StreamLeavesByRangeServer) error {
+	ctx, spanEnd := spanFor(stream.Context(), "StreamLeavesByRange")
+	defer spanEnd()
+	if err := validateGetLeavesByRangeRequest(req); err != nil {
+		return err
+	}
+	session, err := t.auditSession(req.LogId, req.AuditSessionId)
+	if err != nil {
+		return err
+	}
+
+	tree, ctx, err := t.getTreeAndContext(ctx, req.LogId, optsLogRead)
+	if err != nil {
+		return err
+	}
+	slr, root, err := t.latestRoot(ctx, tree, "StreamLeavesByRange")
+	if err != nil {
+		return err
+	}
+
+	resp := &trillian.GetLeavesByRangeResponse{SignedLogRoot: session.root(slr)}
+	start, treeSize, _, err := pageRange(req, session.limit(int64(root.TreeSize)))
+	if err != nil {
+		return err
+	}
+	end := start + req.Count
+	if end > treeSize || end < start {
+		end = treeSize
+	}
+	size := 0 // Size of the leaves in resp.
+	for start < end {
+		count := end - start
+		if count > t.streamBatchSize {
+			count = t.streamBatchSize
+		}
+		leaves, err := t.readLeaves(ctx, tree, start, count, req.OmitLeafData)
+		if err != nil {
+			return err
+		}
+		if len(leaves) == 0 {
+			return status.Errorf(codes.Internal, "no leaves at index %d below tree size %d", start, root.TreeSize)
+		}
+		t.fetchedLeaves.Add(float64(len(leaves)))
+		start += int64(len(leaves))
+
+		for _, leaf := range leaves {
+			leafSize := proto.Size(leaf)
+			if len(resp.Leaves) > 0 && size+leafSize > t.streamChunkBytes {
+				if err := stream.Send(resp); err != nil {
+					return err
+				}
+				resp, size = &trillian.GetLeavesByRangeResponse{}, 0
+			}
+			resp.Leaves = append(resp.Leaves, leaf)
+			size += leafSize
+		}
+		if err := ctx.Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+	}
+	// Send the last chunk, or the root alone if there are no leaves to stream.
+	return stream.Send(resp)
+}
+
+// latestRoot returns the latest signed root of the tree, and its parsed form.
+func (t *TrillianLogRPCServer) latestRoot(ctx context.Context, tree *trillian.Tree, method string) (*trillian.SignedLogRoot, *types.LogRootV1, error) {
+	tx, err := t.snapshotForTree(ctx, tree, method)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer t.closeAndLog(ctx, tree.TreeId, tx, method)
+
+	slr, err := tx.LatestSignedLogRoot(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	var root types.LogRootV1
+	if err := root.UnmarshalBinary(slr.LogRoot); err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "Could not read current log root: %v", err)
+	}
+	if err := t.commitAndLog(ctx, tree.TreeId, tx, method); err != nil {
+		return nil, nil, err
+	}
+	return slr, &root, nil
+}
+
+// readLeaves reads a range of leaves in its own snapshot of the tree, without
+// their data if omitData is true.
+func (t *TrillianLogRPCServer) readLeaves(ctx context.Context, tree *trillian.Tree, start, count int64, omitData bool) ([]*trillian.LogLeaf, error) {
+	tx, err := t.snapshotForTree(ctx, tree, "StreamLeavesByRange")
+	if err != nil {
+		return nil, err
+	}
+	defer t.closeAndLog(ctx, tree.TreeId, tx, "StreamLeavesByRange")
+
+	leaves, err := getLeavesByRange(ctx, tx, start, count, omitData)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.commitAndLog(ctx, tree.TreeId, tx, "StreamLeavesByRange"); err != nil {
+		return nil, err
+	}
+	return leaves, nil
+}
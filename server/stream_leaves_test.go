@@ -0,0 +1,138 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/extension"
+	"github.com/google/trillian/log"
+	"github.com/google/trillian/quota"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/storage/memory"
+	stestonly "github.com/google/trillian/storage/testonly"
+	"github.com/google/trillian/util/clock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeLeavesStream is a StreamLeavesByRange server stream which records the
+// sent responses.
+type fakeLeavesStream struct {
+	grpc.ServerStream
+	resps []*trillian.GetLeavesByRangeResponse
+}
+
+func (s *fakeLeavesStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *fakeLeavesStream) Send(resp *trillian.GetLeavesByRangeResponse) error {
+	s.resps = append(s.resps, resp)
+	return nil
+}
+
+func TestStreamLeavesByRange(t *testing.T) {
+	log.InitMetrics(nil)
+	ctx := context.Background()
+	ts := memory.NewTreeStorage()
+	registry := extension.Registry{
+		AdminStorage: memory.NewAdminStorage(ts),
+		LogStorage:   memory.NewLogStorage(ts, nil),
+	}
+	tree, err := storage.CreateTree(ctx, registry.AdminStorage, stestonly.LogTree)
+	if err != nil {
+		t.Fatalf("CreateTree(): %v", err)
+	}
+	server := NewTrillianLogRPCServer(registry, clock.System)
+	server.streamBatchSize = 3
+	if _, err := server.InitLog(ctx, &trillian.InitLogRequest{LogId: tree.TreeId}); err != nil {
+		t.Fatalf("InitLog(): %v", err)
+	}
+	const numLeaves = 7
+	for i := 0; i < numLeaves; i++ {
+		req := &trillian.QueueLeafRequest{LogId: tree.TreeId, Leaf: &trillian.LogLeaf{LeafValue: []byte(fmt.Sprintf("leaf%d", i))}}
+		if _, err := server.QueueLeaf(ctx, req); err != nil {
+			t.Fatalf("QueueLeaf(): %v", err)
+		}
+	}
+	if _, err := log.IntegrateBatch(ctx, tree, numLeaves, 0, 0, clock.System, registry.LogStorage, quota.Noop()); err != nil {
+		t.Fatalf("IntegrateBatch(): %v", err)
+	}
+
+	for _, tc := range []struct {
+		desc       string
+		start      int64
+		count      int64
+		chunkBytes int
+		wantResps  int
+		wantLeaves int
+	}{
+		{desc: "all", start: 0, count: numLeaves, chunkBytes: defaultStreamChunkBytes, wantResps: 1, wantLeaves: numLeaves},
+		{desc: "leafPerChunk", start: 1, count: 100, chunkBytes: 1, wantResps: 6, wantLeaves: 6},
+		{desc: "partial", start: 2, count: 4, chunkBytes: defaultStreamChunkBytes, wantResps: 1, wantLeaves: 4},
+		{desc: "beyondTreeSize", start: numLeaves, count: 10, chunkBytes: defaultStreamChunkBytes, wantResps: 1},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			server.streamChunkBytes = tc.chunkBytes
+			stream := &fakeLeavesStream{}
+			req := &trillian.GetLeavesByRangeRequest{LogId: tree.TreeId, StartIndex: tc.start, Count: tc.count}
+			if err := server.StreamLeavesByRange(req, stream); err != nil {
+				t.Fatalf("StreamLeavesByRange(): %v", err)
+			}
+			if got := len(stream.resps); got != tc.wantResps {
+				t.Fatalf("StreamLeavesByRange() sent %d responses, want %d", got, tc.wantResps)
+			}
+			if stream.resps[0].SignedLogRoot == nil {
+				t.Error("StreamLeavesByRange() sent no log root in the first response")
+			}
+			next := tc.start
+			for i, resp := range stream.resps {
+				if i > 0 && resp.SignedLogRoot != nil {
+					t.Errorf("StreamLeavesByRange() sent a log root in response %d", i)
+				}
+				for _, leaf := range resp.Leaves {
+					if leaf.LeafIndex != next {
+						t.Errorf("StreamLeavesByRange() sent leaf %d, want %d", leaf.LeafIndex, next)
+					}
+					next++
+				}
+			}
+			if got := int(next - tc.start); got != tc.wantLeaves {
+				t.Errorf("StreamLeavesByRange() sent %d leaves, want %d", got, tc.wantLeaves)
+			}
+		})
+	}
+
+	for _, tc := range []struct {
+		desc     string
+		req      *trillian.GetLeavesByRangeRequest
+		wantCode codes.Code
+	}{
+		{desc: "negativeStart", req: &trillian.GetLeavesByRangeRequest{LogId: tree.TreeId, StartIndex: -1, Count: 1}, wantCode: codes.InvalidArgument},
+		{desc: "zeroCount", req: &trillian.GetLeavesByRangeRequest{LogId: tree.TreeId}, wantCode: codes.InvalidArgument},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := server.StreamLeavesByRange(tc.req, &fakeLeavesStream{})
+			if got := status.Code(err); got != tc.wantCode {
+				t.Errorf("StreamLeavesByRange(): %v, want code %v", err, tc.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/extension"
+	"github.com/google/trillian/log"
+	"github.com/google/trillian/quota"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/storage/memory"
+	stestonly "github.com/google/trillian/storage/testonly"
+	"github.com/google/trillian/util/clock"
+	"golang.org/x/mod/sumdb/tlog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetTile(t *testing.T) {
+	log.InitMetrics(nil)
+	ctx := context.Background()
+	ts := memory.NewTreeStorage()
+	registry := extension.Registry{
+		AdminStorage: memory.NewAdminStorage(ts),
+		LogStorage:   memory.NewLogStorage(ts, nil),
+	}
+	tree, err := storage.CreateTree(ctx, registry.AdminStorage, stestonly.LogTree)
+	if err != nil {
+		t.Fatalf("CreateTree(): %v", err)
+	}
+	server := NewTrillianLogRPCServer(registry, clock.System)
+	if _, err := server.InitLog(ctx, &trillian.InitLogRequest{LogId: tree.TreeId}); err != nil {
+		t.Fatalf("InitLog(): %v", err)
+	}
+
+	// Build the same tree with tlog, which hashes like RFC 6962.
+	const size = 21
+	var hashes []tlog.Hash
+	hr := tlog.HashReaderFunc(func(indexes []int64) ([]tlog.Hash, error) {
+		var r []tlog.Hash
+		for _, index := range indexes {
+			r = append(r, hashes[index])
+		}
+		return r, nil
+	})
+	for i := int64(0); i < size; i++ {
+		value := []byte(fmt.Sprintf("leaf%d", i))
+		req := &trillian.QueueLeafRequest{LogId: tree.TreeId, Leaf: &trillian.LogLeaf{LeafValue: value}}
+		if _, err := server.QueueLeaf(ctx, req); err != nil {
+			t.Fatalf("QueueLeaf(): %v", err)
+		}
+		stored, err := tlog.StoredHashes(i, value, hr)
+		if err != nil {
+			t.Fatalf("StoredHashes(): %v", err)
+		}
+		hashes = append(hashes, stored...)
+	}
+	if _, err := log.IntegrateBatch(ctx, tree, size, 0, 0, clock.System, registry.LogStorage, quota.Noop()); err != nil {
+		t.Fatalf("IntegrateBatch(): %v", err)
+	}
+
+	for _, height := range []int{1, 2, 3, 8} {
+		for _, tile := range tlog.NewTiles(height, 0, size) {
+			req := &trillian.GetTileRequest{LogId: tree.TreeId, Height: int32(tile.H), Level: int32(tile.L), Index: tile.N, Width: int32(tile.W)}
+			resp, err := server.GetTile(ctx, req)
+			if err != nil {
+				t.Fatalf("GetTile(%s): %v", tile.Path(), err)
+			}
+			want, err := tlog.ReadTileData(tile, hr)
+			if err != nil {
+				t.Fatalf("ReadTileData(%s): %v", tile.Path(), err)
+			}
+			if !bytes.Equal(resp.Data, want) {
+				t.Errorf("GetTile(%s): data %x, want %x", tile.Path(), resp.Data, want)
+			}
+		}
+	}
+
+	// The tree doesn't cover the full tile at the right edge yet.
+	resp, err := server.GetTile(ctx, &trillian.GetTileRequest{LogId: tree.TreeId, Height: 2, Level: 0, Index: 5, Width: 4})
+	if err != nil {
+		t.Fatalf("GetTile(beyond tree): %v", err)
+	}
+	if len(resp.Data) != 0 || resp.SignedLogRoot == nil {
+		t.Errorf("GetTile(beyond tree): data %x, root %v, want no data and a root", resp.Data, resp.SignedLogRoot)
+	}
+}
+
+func TestGetTileErrors(t *testing.T) {
+	for _, test := range []struct {
+		desc string
+		req  *trillian.GetTileRequest
+	}{
+		{desc: "zeroHeight", req: &trillian.GetTileRequest{LogId: 1, Height: 0, Width: 1}},
+		{desc: "highHeight", req: &trillian.GetTileRequest{LogId: 1, Height: 31, Width: 1}},
+		{desc: "badLevel", req: &trillian.GetTileRequest{LogId: 1, Height: 8, Level: -1, Width: 1}},
+		{desc: "badIndex", req: &trillian.GetTileRequest{LogId: 1, Height: 8, Index: -1, Width: 1}},
+		{desc: "zeroWidth", req: &trillian.GetTileRequest{LogId: 1, Height: 8}},
+		{desc: "wideWidth", req: &trillian.GetTileRequest{LogId: 1, Height: 8, Width: 257}},
+		{desc: "highLevel", req: &trillian.GetTileRequest{LogId: 1, Height: 8, Level: math.MaxInt32, Width: 1}},
+		{desc: "highIndex", req: &trillian.GetTileRequest{LogId: 1, Height: 8, Level: 1, Index: 1 << 47, Width: 1}},
+	} {
+		server := NewTrillianLogRPCServer(extension.Registry{}, fakeTimeSource)
+		_, err := server.GetTile(context.Background(), test.req)
+		if got, want := status.Code(err), codes.InvalidArgument; got != want {
+			t.Errorf("%v: GetTile() returned err = %v, wantCode = %s", test.desc, err, want)
+		}
+	}
+}
@@ -16,6 +16,7 @@ package server
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/google/trillian"
 	"github.com/transparency-dev/merkle"
@@ -23,6 +24,14 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+// Limits of the fields of verification failure reports, which match the
+// columns they are stored in.
+const (
+	maxRequestIDLength       = 255
+	maxReportedLogRootLength = 65535
+	maxFailureDetailsLength  = 1024
+)
+
 func validateGetInclusionProofRequest(req *trillian.GetInclusionProofRequest) error {
 	if req.TreeSize <= 0 {
 		return status.Errorf(codes.InvalidArgument, "GetInclusionProofRequest.TreeSize: %v, want > 0", req.TreeSize)
@@ -46,6 +55,25 @@ func validateGetInclusionProofByHashRequest(req *trillian.GetInclusionProofByHas
 	return nil
 }
 
+// maxInclusionProofSizes is the maximum number of tree sizes of a
+// GetInclusionProofsAtSizesRequest.
+const maxInclusionProofSizes = 256
+
+func validateGetInclusionProofsAtSizesRequest(req *trillian.GetInclusionProofsAtSizesRequest) error {
+	if req.LeafIndex < 0 {
+		return status.Errorf(codes.InvalidArgument, "GetInclusionProofsAtSizesRequest.LeafIndex: %v, want >= 0", req.LeafIndex)
+	}
+	if n := len(req.TreeSizes); n == 0 || n > maxInclusionProofSizes {
+		return status.Errorf(codes.InvalidArgument, "GetInclusionProofsAtSizesRequest.TreeSizes: %d sizes, want 1 to %d", n, maxInclusionProofSizes)
+	}
+	for i, size := range req.TreeSizes {
+		if req.LeafIndex >= size {
+			return status.Errorf(codes.InvalidArgument, "GetInclusionProofsAtSizesRequest.TreeSizes[%d]: %v <= LeafIndex: %v, want > ", i, size, req.LeafIndex)
+		}
+	}
+	return nil
+}
+
 func validateGetLeavesByRangeRequest(req *trillian.GetLeavesByRangeRequest) error {
 	if req.StartIndex < 0 {
 		return status.Errorf(codes.InvalidArgument, "GetLeavesByRangeRequest.StartIndex: %v, want >= 0", req.StartIndex)
@@ -56,6 +84,83 @@ func validateGetLeavesByRangeRequest(req *trillian.GetLeavesByRangeRequest) erro
 	return nil
 }
 
+func validateGetCompactRangeRequest(req *trillian.GetCompactRangeRequest) error {
+	if req.Begin < 0 {
+		return status.Errorf(codes.InvalidArgument, "GetCompactRangeRequest.Begin: %v, want >= 0", req.Begin)
+	}
+	if req.End < req.Begin {
+		return status.Errorf(codes.InvalidArgument, "GetCompactRangeRequest.End: %v < GetCompactRangeRequest.Begin: %v, want >= ", req.End, req.Begin)
+	}
+	if req.TreeSize < req.End {
+		return status.Errorf(codes.InvalidArgument, "GetCompactRangeRequest.TreeSize: %v < GetCompactRangeRequest.End: %v, want >= ", req.TreeSize, req.End)
+	}
+	return nil
+}
+
+// maxTileHeight is the largest tile height supported by the tile layout of
+// golang.org/x/mod/sumdb/tlog.
+const maxTileHeight = 30
+
+func validateGetTileRequest(req *trillian.GetTileRequest) error {
+	if req.Height < 1 || req.Height > maxTileHeight {
+		return status.Errorf(codes.InvalidArgument, "GetTileRequest.Height: %v, want in [1, %d]", req.Height, maxTileHeight)
+	}
+	if req.Level < 0 {
+		return status.Errorf(codes.InvalidArgument, "GetTileRequest.Level: %v, want >= 0", req.Level)
+	}
+	if req.Index < 0 {
+		return status.Errorf(codes.InvalidArgument, "GetTileRequest.Index: %v, want >= 0", req.Index)
+	}
+	if req.Width < 1 || req.Width > 1<<req.Height {
+		return status.Errorf(codes.InvalidArgument, "GetTileRequest.Width: %v, want in [1, %d]", req.Width, 1<<req.Height)
+	}
+	// The tree size covering the tile must fit in an int64.
+	if shift := (int64(req.Level) + 1) * int64(req.Height); shift >= 63 || req.Index >= math.MaxInt64>>shift {
+		return status.Errorf(codes.InvalidArgument, "GetTileRequest: tile %d at level %d is beyond the maximum tree size", req.Index, req.Level)
+	}
+	return nil
+}
+
+func validateUpdateLeafExtraDataRequest(req *trillian.UpdateLeafExtraDataRequest) error {
+	if req.LeafIndex < 0 {
+		return status.Errorf(codes.InvalidArgument, "UpdateLeafExtraDataRequest.LeafIndex: %v, want >= 0", req.LeafIndex)
+	}
+	return nil
+}
+
+func validateListLeafExtraDataUpdatesRequest(req *trillian.ListLeafExtraDataUpdatesRequest) error {
+	if req.LeafIndex < 0 {
+		return status.Errorf(codes.InvalidArgument, "ListLeafExtraDataUpdatesRequest.LeafIndex: %v, want >= 0", req.LeafIndex)
+	}
+	return nil
+}
+
+func validateGetDailyLogStatsRequest(req *trillian.GetDailyLogStatsRequest) error {
+	if req.Start != nil && req.End != nil && req.End.AsTime().Before(req.Start.AsTime()) {
+		return status.Errorf(codes.InvalidArgument, "GetDailyLogStatsRequest.End: %v < GetDailyLogStatsRequest.Start: %v, want >= ", req.End.AsTime(), req.Start.AsTime())
+	}
+	return nil
+}
+
+func validateReportVerificationFailureRequest(req *trillian.ReportVerificationFailureRequest) error {
+	if req.Failure == trillian.VerificationFailure_UNKNOWN_VERIFICATION_FAILURE {
+		return status.Error(codes.InvalidArgument, "ReportVerificationFailureRequest.Failure: unset")
+	}
+	if req.RequestId == "" {
+		return status.Error(codes.InvalidArgument, "ReportVerificationFailureRequest.RequestId: empty")
+	}
+	if got, max := len(req.RequestId), maxRequestIDLength; got > max {
+		return status.Errorf(codes.InvalidArgument, "ReportVerificationFailureRequest.RequestId: %d bytes, want <= %d", got, max)
+	}
+	if got, max := len(req.LogRoot), maxReportedLogRootLength; got > max {
+		return status.Errorf(codes.InvalidArgument, "ReportVerificationFailureRequest.LogRoot: %d bytes, want <= %d", got, max)
+	}
+	if got, max := len(req.Details), maxFailureDetailsLength; got > max {
+		return status.Errorf(codes.InvalidArgument, "ReportVerificationFailureRequest.Details: %d bytes, want <= %d", got, max)
+	}
+	return nil
+}
+
 func validateGetConsistencyProofRequest(req *trillian.GetConsistencyProofRequest) error {
 	if req.FirstTreeSize <= 0 {
 		return status.Errorf(codes.InvalidArgument, "GetConsistencyProofRequest.FirstTreeSize: %v, want > 0", req.FirstTreeSize)
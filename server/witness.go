@@ -0,0 +1,198 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/trees"
+	"github.com/google/trillian/types"
+	"github.com/google/trillian/witness"
+	"golang.org/x/mod/sumdb/note"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// EnableWitnessing makes the server accept the cosignatures of the witnesses
+// of policy over its checkpoints, and serve the checkpoints cosigned by the
+// quorum of each log. Checkpoints must be enabled with EnableCheckpoints.
+func (t *TrillianLogRPCServer) EnableWitnessing(policy *witness.Policy) {
+	t.witnessPolicy = policy
+}
+
+// witnessQuorum returns the number of witnesses which must cosign the
+// checkpoints of tree, or an error if they aren't cosigned.
+func (t *TrillianLogRPCServer) witnessQuorum(tree *trillian.Tree) (int, error) {
+	if t.witnessPolicy == nil || t.checkpointSigner == nil {
+		return 0, status.Error(codes.FailedPrecondition, "checkpoint cosigning is not enabled")
+	}
+	q := t.witnessPolicy.Quorum(tree.TreeId)
+	if q == 0 {
+		return 0, status.Errorf(codes.FailedPrecondition, "checkpoints of tree %d are not cosigned", tree.TreeId)
+	}
+	return q, nil
+}
+
+// AddCosignature stores the witness signatures of a checkpoint.
+func (t *TrillianLogRPCServer) AddCosignature(ctx context.Context, req *trillian.AddCosignatureRequest) (*trillian.AddCosignatureResponse, error) {
+	ctx, spanEnd := spanFor(ctx, "AddCosignature")
+	defer spanEnd()
+
+	// Checkpoints of logs which are only served for reading are cosigned too.
+	tree, hasher, err := t.getTreeAndHasher(ctx, req.LogId, optsLogRead)
+	if err != nil {
+		return nil, err
+	}
+	quorum, err := t.witnessQuorum(tree)
+	if err != nil {
+		return nil, err
+	}
+	n, err := t.witnessPolicy.Cosignatures(req.CosignedCheckpoint)
+	if err != nil {
+		var unverified *note.UnverifiedNoteError
+		if errors.As(err, &unverified) {
+			return nil, status.Error(codes.PermissionDenied, "checkpoint has no signature of a configured witness")
+		}
+		return nil, status.Errorf(codes.InvalidArgument, "AddCosignatureRequest.CosignedCheckpoint: %v", err)
+	}
+	var cp types.Checkpoint
+	if err := cp.UnmarshalText([]byte(n.Text)); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "AddCosignatureRequest.CosignedCheckpoint: %v", err)
+	}
+	// The witnesses must have signed exactly the text the log serves, so
+	// that their signatures can be served along with the log's.
+	if text, err := t.checkpointText(tree.TreeId, cp.Size, cp.Hash); err != nil {
+		return nil, err
+	} else if !bytes.Equal(text, []byte(n.Text)) {
+		return nil, status.Errorf(codes.InvalidArgument, "AddCosignatureRequest.CosignedCheckpoint: not a checkpoint of log %d", tree.TreeId)
+	}
+
+	ctx = trees.NewContext(ctx, tree)
+	resp := &trillian.AddCosignatureResponse{}
+	err = t.registry.LogStorage.ReadWriteTransaction(ctx, tree, func(ctx context.Context, tx storage.LogTreeTX) error {
+		cotx, err := storage.AsCosignatureTX(tx)
+		if err != nil {
+			return err
+		}
+		slr, err := tx.LatestSignedLogRoot(ctx)
+		if err != nil {
+			return err
+		}
+		var root types.LogRootV1
+		if err := root.UnmarshalBinary(slr.LogRoot); err != nil {
+			return status.Errorf(codes.Internal, "Could not read current log root: %v", err)
+		}
+		if cp.Size > root.TreeSize {
+			return status.Errorf(codes.FailedPrecondition, "checkpoint size %d is larger than the size %d of log %d", cp.Size, root.TreeSize, tree.TreeId)
+		}
+		if cp.Size < root.TreeSize {
+			if slr, err = rootAtSize(ctx, tx, hasher, cp.Size); err != nil {
+				return err
+			}
+			if err := root.UnmarshalBinary(slr.LogRoot); err != nil {
+				return status.Errorf(codes.Internal, "Could not read log root: %v", err)
+			}
+		}
+		if !bytes.Equal(cp.Hash, root.RootHash) {
+			return status.Errorf(codes.FailedPrecondition, "checkpoint hash %x doesn't match the root hash %x of log %d at size %d", cp.Hash, root.RootHash, tree.TreeId, cp.Size)
+		}
+
+		for _, sig := range n.Sigs {
+			c := &storage.Cosignature{TreeSize: cp.Size, RootHash: cp.Hash, Witness: sig.Name, Signature: sig.Base64}
+			if err := cotx.AddCosignature(ctx, c); err != nil {
+				return err
+			}
+		}
+		latest, err := cotx.LatestCosignatures(ctx, quorum)
+		if err != nil {
+			return err
+		}
+		resp.Cosigned = len(latest) > 0 && latest[0].TreeSize >= cp.Size
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, sig := range n.Sigs {
+		glog.V(1).Infof("%d: witness %s cosigned tree size %d", tree.TreeId, sig.Name, cp.Size)
+	}
+	return resp, nil
+}
+
+// GetCosignedCheckpoint returns the latest checkpoint of a log cosigned by its
+// quorum of witnesses.
+func (t *TrillianLogRPCServer) GetCosignedCheckpoint(ctx context.Context, req *trillian.GetCosignedCheckpointRequest) (*trillian.GetCosignedCheckpointResponse, error) {
+	ctx, spanEnd := spanFor(ctx, "GetCosignedCheckpoint")
+	defer spanEnd()
+
+	tree, ctx, err := t.getTreeAndContext(ctx, req.LogId, optsLogRead)
+	if err != nil {
+		return nil, err
+	}
+	quorum, err := t.witnessQuorum(tree)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := t.snapshotForTree(ctx, tree, "GetCosignedCheckpoint")
+	if err != nil {
+		return nil, err
+	}
+	defer t.closeAndLog(ctx, tree.TreeId, tx, "GetCosignedCheckpoint")
+
+	cotx, err := storage.AsCosignatureTX(tx)
+	if err != nil {
+		return nil, err
+	}
+	cosigs, err := cotx.LatestCosignatures(ctx, quorum)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.commitAndLog(ctx, req.LogId, tx, "GetCosignedCheckpoint"); err != nil {
+		return nil, err
+	}
+
+	// Witnesses may have been removed from the configuration since they
+	// cosigned, so only the current ones count.
+	var sigs []note.Signature
+	for _, c := range cosigs {
+		if !t.witnessPolicy.IsWitness(c.Witness) {
+			continue
+		}
+		raw, err := base64.StdEncoding.DecodeString(c.Signature)
+		if err != nil || len(raw) < 4 {
+			return nil, status.Errorf(codes.Internal, "malformed cosignature of witness %s", c.Witness)
+		}
+		sigs = append(sigs, note.Signature{Name: c.Witness, Hash: binary.BigEndian.Uint32(raw), Base64: c.Signature})
+	}
+	if len(sigs) < quorum {
+		return nil, status.Errorf(codes.NotFound, "log %d has no checkpoint cosigned by %d witnesses", req.LogId, quorum)
+	}
+	text, err := t.checkpointText(tree.TreeId, cosigs[0].TreeSize, cosigs[0].RootHash)
+	if err != nil {
+		return nil, err
+	}
+	cp, err := note.Sign(&note.Note{Text: string(text), Sigs: sigs}, t.checkpointSigner)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not sign checkpoint: %v", err)
+	}
+	return &trillian.GetCosignedCheckpointResponse{CosignedCheckpoint: cp}, nil
+}
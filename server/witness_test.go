@@ -0,0 +1,203 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/extension"
+	"github.com/google/trillian/log"
+	"github.com/google/trillian/quota"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/storage/memory"
+	stestonly "github.com/google/trillian/storage/testonly"
+	"github.com/google/trillian/types"
+	"github.com/google/trillian/util/clock"
+	"github.com/google/trillian/witness"
+	"github.com/google/trillian/witness/witnesspb"
+	"github.com/transparency-dev/merkle/compact"
+	"github.com/transparency-dev/merkle/rfc6962"
+	"golang.org/x/mod/sumdb/note"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newNoteKey(t *testing.T, name string) (note.Signer, note.Verifier, string) {
+	t.Helper()
+	skey, vkey, err := note.GenerateKey(rand.Reader, name)
+	if err != nil {
+		t.Fatalf("GenerateKey(): %v", err)
+	}
+	s, err := note.NewSigner(skey)
+	if err != nil {
+		t.Fatalf("NewSigner(): %v", err)
+	}
+	v, err := note.NewVerifier(vkey)
+	if err != nil {
+		t.Fatalf("NewVerifier(): %v", err)
+	}
+	return s, v, vkey
+}
+
+func TestCosignatures(t *testing.T) {
+	log.InitMetrics(nil)
+	ctx := context.Background()
+	ts := memory.NewTreeStorage()
+	registry := extension.Registry{
+		AdminStorage: memory.NewAdminStorage(ts),
+		LogStorage:   memory.NewLogStorage(ts, nil),
+	}
+	tree, err := storage.CreateTree(ctx, registry.AdminStorage, stestonly.LogTree)
+	if err != nil {
+		t.Fatalf("CreateTree(): %v", err)
+	}
+	server := NewTrillianLogRPCServer(registry, clock.NewFake(time.Unix(1500000000, 0)))
+	if _, err := server.InitLog(ctx, &trillian.InitLogRequest{LogId: tree.TreeId}); err != nil {
+		t.Fatalf("InitLog(): %v", err)
+	}
+	hasher := rfc6962.DefaultHasher
+	rf := compact.RangeFactory{Hash: hasher.HashChildren}
+	cr := rf.NewEmptyRange(0)
+	var roots [][]byte
+	for i := 0; i < 4; i++ {
+		value := []byte(fmt.Sprintf("leaf%d", i))
+		req := &trillian.QueueLeafRequest{LogId: tree.TreeId, Leaf: &trillian.LogLeaf{LeafValue: value}}
+		if _, err := server.QueueLeaf(ctx, req); err != nil {
+			t.Fatalf("QueueLeaf(): %v", err)
+		}
+		if err := cr.Append(hasher.HashLeaf(value), nil); err != nil {
+			t.Fatalf("Append(): %v", err)
+		}
+		root, err := cr.GetRootHash(nil)
+		if err != nil {
+			t.Fatalf("GetRootHash(): %v", err)
+		}
+		roots = append(roots, root)
+	}
+	if _, err := log.IntegrateBatch(ctx, tree, 4, 0, 0, clock.System, registry.LogStorage, quota.Noop()); err != nil {
+		t.Fatalf("IntegrateBatch(): %v", err)
+	}
+
+	logS, logV, _ := newNoteKey(t, "log")
+	s1, v1, k1 := newNoteKey(t, "w1")
+	s2, v2, k2 := newNoteKey(t, "w2")
+	other, _, _ := newNoteKey(t, "other")
+	policy, err := witness.NewPolicy(&witnesspb.WitnessConfig{WitnessKeys: []string{k1, k2}, DefaultQuorum: 2})
+	if err != nil {
+		t.Fatalf("NewPolicy(): %v", err)
+	}
+	origin := fmt.Sprintf("example.com/log/%d", tree.TreeId)
+	// cosign returns the checkpoint of the log at size signed by signers.
+	cosign := func(origin string, size uint64, hash []byte, signers ...note.Signer) []byte {
+		t.Helper()
+		text, err := types.Checkpoint{Origin: origin, Size: size, Hash: hash}.MarshalText()
+		if err != nil {
+			t.Fatalf("MarshalText(): %v", err)
+		}
+		msg, err := note.Sign(&note.Note{Text: string(text)}, signers...)
+		if err != nil {
+			t.Fatalf("Sign(): %v", err)
+		}
+		return msg
+	}
+	add := func(msg []byte) (*trillian.AddCosignatureResponse, error) {
+		return server.AddCosignature(ctx, &trillian.AddCosignatureRequest{LogId: tree.TreeId, CosignedCheckpoint: msg})
+	}
+	getReq := &trillian.GetCosignedCheckpointRequest{LogId: tree.TreeId}
+
+	if _, err := add(cosign(origin, 4, roots[3], s1)); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("AddCosignature() without witnessing: %v, want FailedPrecondition", err)
+	}
+	server.EnableCheckpoints(logS, "example.com/log/")
+	server.EnableWitnessing(policy)
+
+	for _, tc := range []struct {
+		desc     string
+		msg      []byte
+		wantCode codes.Code
+	}{
+		{desc: "not-a-witness", msg: cosign(origin, 4, roots[3], other), wantCode: codes.PermissionDenied},
+		{desc: "not-a-note", msg: []byte("garbage"), wantCode: codes.InvalidArgument},
+		{desc: "wrong-origin", msg: cosign("example.com/log/1", 4, roots[3], s1), wantCode: codes.InvalidArgument},
+		{desc: "size-too-large", msg: cosign(origin, 5, roots[3], s1), wantCode: codes.FailedPrecondition},
+		{desc: "wrong-hash", msg: cosign(origin, 4, roots[2], s1), wantCode: codes.FailedPrecondition},
+		{desc: "wrong-earlier-hash", msg: cosign(origin, 2, roots[2], s1), wantCode: codes.FailedPrecondition},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			if _, err := add(tc.msg); status.Code(err) != tc.wantCode {
+				t.Errorf("AddCosignature(): %v, want %v", err, tc.wantCode)
+			}
+		})
+	}
+
+	// An earlier checkpoint cosigned by both witnesses is served until a later
+	// one reaches the quorum.
+	resp, err := add(cosign(origin, 2, roots[1], s1, s2))
+	if err != nil {
+		t.Fatalf("AddCosignature(2): %v", err)
+	}
+	if !resp.Cosigned {
+		t.Errorf("AddCosignature(2) by both witnesses: not cosigned")
+	}
+	if resp, err = add(cosign(origin, 4, roots[3], s1, other)); err != nil {
+		t.Fatalf("AddCosignature(4): %v", err)
+	}
+	if resp.Cosigned {
+		t.Errorf("AddCosignature(4) by one witness: cosigned")
+	}
+	checkCosigned := func(wantSize uint64, wantHash []byte) {
+		t.Helper()
+		got, err := server.GetCosignedCheckpoint(ctx, getReq)
+		if err != nil {
+			t.Fatalf("GetCosignedCheckpoint(): %v", err)
+		}
+		n, err := witness.VerifyCosigned(got.CosignedCheckpoint, logV, []note.Verifier{v1, v2}, 2)
+		if err != nil {
+			t.Fatalf("VerifyCosigned(): %v", err)
+		}
+		var cp types.Checkpoint
+		if err := cp.UnmarshalText([]byte(n.Text)); err != nil {
+			t.Fatalf("UnmarshalText(): %v", err)
+		}
+		if cp.Origin != origin || cp.Size != wantSize || !bytes.Equal(cp.Hash, wantHash) {
+			t.Errorf("GetCosignedCheckpoint() = %s %d %x, want %s %d %x", cp.Origin, cp.Size, cp.Hash, origin, wantSize, wantHash)
+		}
+	}
+	checkCosigned(2, roots[1])
+
+	if resp, err = add(cosign(origin, 4, roots[3], s2)); err != nil {
+		t.Fatalf("AddCosignature(4): %v", err)
+	}
+	if !resp.Cosigned {
+		t.Errorf("AddCosignature(4) by the second witness: not cosigned")
+	}
+	checkCosigned(4, roots[3])
+
+	// Without the quorum of current witnesses there is nothing to serve.
+	_, _, k3 := newNoteKey(t, "w3")
+	if policy, err = witness.NewPolicy(&witnesspb.WitnessConfig{WitnessKeys: []string{k1, k3}, DefaultQuorum: 2}); err != nil {
+		t.Fatalf("NewPolicy(): %v", err)
+	}
+	server.EnableWitnessing(policy)
+	if _, err := server.GetCosignedCheckpoint(ctx, getReq); status.Code(err) != codes.NotFound {
+		t.Errorf("GetCosignedCheckpoint() after witness removal: %v, want NotFound", err)
+	}
+}
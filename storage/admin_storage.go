@@ -72,8 +72,10 @@ type AdminStorage interface {
 type AdminWriter interface {
 	// CreateTree inserts the specified tree in storage, returning a tree
 	// with all storage-generated fields set.
-	// Note that treeID and timestamps will be automatically generated by
-	// the storage layer, thus may be ignored by the implementation.
+	// Note that timestamps will be automatically generated by the storage
+	// layer, thus may be ignored by the implementation. The treeID is
+	// generated too, unless it's set, see TreeIDForCreation. A set treeID
+	// which is taken results in an AlreadyExists error.
 	// Remaining fields must be set to valid values.
 	// Returns an error if the tree is invalid or creation fails.
 	CreateTree(ctx context.Context, tree *trillian.Tree) (*trillian.Tree, error)
@@ -0,0 +1,50 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/golang/glog"
+	bundle "github.com/google/trillian/archive"
+	"github.com/google/trillian/monitoring"
+	"github.com/google/trillian/storage"
+)
+
+var bundleDirs = flag.String("archive_bundle_dirs", "", "Comma-separated list of archive bundle directories to serve with the archive storage system. Bundles on object storage can be served from a file system mount")
+
+func init() {
+	if err := storage.RegisterProvider("archive", newArchiveStorageProvider); err != nil {
+		glog.Fatalf("Failed to register storage provider archive: %v", err)
+	}
+}
+
+func newArchiveStorageProvider(monitoring.MetricFactory) (storage.Provider, error) {
+	if *bundleDirs == "" {
+		return nil, errors.New("--archive_bundle_dirs must be set")
+	}
+	var readers []*bundle.Reader
+	for _, dir := range strings.Split(*bundleDirs, ",") {
+		r, err := bundle.NewReader(dir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read bundle %s: %v", dir, err)
+		}
+		readers = append(readers, r)
+	}
+	return New(readers...)
+}
@@ -0,0 +1,426 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package archive provides read-only storage which serves frozen logs
+// directly from archive bundles, without a database.
+//
+// Every bundle is served as a FROZEN LOG tree with the ID of the archived
+// log. Leaves are served by range, and tree nodes are computed from the
+// subtree hashes and leaves of the bundle's chunks, so inclusion and
+// consistency proofs can be served. The nodes covering whole chunks are
+// computed once when the bundle is loaded. Leaves can't be looked up by hash, and
+// all writes fail with ErrReadOnly.
+package archive
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/trillian"
+	bundle "github.com/google/trillian/archive"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/storage/tree"
+	"github.com/google/trillian/types"
+	"github.com/transparency-dev/merkle/compact"
+	"github.com/transparency-dev/merkle/rfc6962"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ErrReadOnly is returned by all write operations of archive storage.
+var ErrReadOnly = status.Error(codes.FailedPrecondition, "archive storage is read-only")
+
+// Storage serves a set of bundles. It implements storage.Provider.
+type Storage struct {
+	logs map[int64]*archivedLog
+}
+
+// New returns a Storage serving the bundles read by the given readers. The
+// subtree chunks are read upfront, while leaf chunks are only read, and
+// checked against the manifest, when they're needed to serve a request; use
+// bundle.VerifyReader to check a bundle upfront.
+func New(readers ...*bundle.Reader) (*Storage, error) {
+	s := &Storage{logs: make(map[int64]*archivedLog)}
+	for _, r := range readers {
+		l, err := newArchivedLog(r)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := s.logs[l.tree.TreeId]; ok {
+			return nil, fmt.Errorf("more than one bundle of log %d", l.tree.TreeId)
+		}
+		s.logs[l.tree.TreeId] = l
+	}
+	return s, nil
+}
+
+// LogStorage implements storage.Provider.
+func (s *Storage) LogStorage() storage.LogStorage {
+	return &logStorage{s: s}
+}
+
+// AdminStorage implements storage.Provider.
+func (s *Storage) AdminStorage() storage.AdminStorage {
+	return &adminStorage{s: s}
+}
+
+// Close implements storage.Provider.
+func (s *Storage) Close() error {
+	return nil
+}
+
+func (s *Storage) getLog(treeID int64) (*archivedLog, error) {
+	l, ok := s.logs[treeID]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no archived log with ID %d", treeID)
+	}
+	return l, nil
+}
+
+// archivedLog is a log served from a bundle.
+type archivedLog struct {
+	r       *bundle.Reader
+	tree    *trillian.Tree
+	root    *trillian.SignedLogRoot
+	size    uint64
+	factory *compact.RangeFactory
+	// leafChunks are the leaf chunks of the bundle, ordered by First.
+	leafChunks []bundle.Chunk
+	// subtrees maps the First index of leaf chunks to the compact range
+	// hashes of their subtree chunk.
+	subtrees map[uint64][][]byte
+	// nodes holds the nodes covering one or more whole chunks, from the
+	// start of the log up to the first chunk without subtree hashes.
+	nodes map[compact.NodeID][]byte
+}
+
+func newArchivedLog(r *bundle.Reader) (*archivedLog, error) {
+	m := r.Manifest()
+	rootHash, err := hex.DecodeString(m.RootHash)
+	if err != nil {
+		return nil, fmt.Errorf("log %d: bad root hash in manifest: %v", m.LogID, err)
+	}
+
+	// Serve the archived root matching the manifest, so that clients get the
+	// root the log signed; fall back to a root made from the manifest.
+	logRoot := &types.LogRootV1{TreeSize: m.TreeSize, RootHash: rootHash}
+	for _, c := range m.ChunksByKind(bundle.RootChunk) {
+		roots, err := r.Roots(c)
+		if err != nil {
+			return nil, fmt.Errorf("log %d: %v", m.LogID, err)
+		}
+		for _, root := range roots {
+			if root.TreeSize == m.TreeSize && bytes.Equal(root.RootHash, rootHash) && root.TimestampNanos >= logRoot.TimestampNanos {
+				logRoot = root
+			}
+		}
+	}
+	root, err := logRoot.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	frozen := timestamppb.New(time.Unix(0, int64(logRoot.TimestampNanos)))
+	l := &archivedLog{
+		r: r,
+		tree: &trillian.Tree{
+			TreeId:          m.LogID,
+			TreeState:       trillian.TreeState_FROZEN,
+			TreeType:        trillian.TreeType_LOG,
+			DisplayName:     fmt.Sprintf("Archived log %d", m.LogID),
+			MaxRootDuration: durationpb.New(0),
+			CreateTime:      frozen,
+			UpdateTime:      frozen,
+		},
+		root:       &trillian.SignedLogRoot{LogRoot: root},
+		size:       m.TreeSize,
+		factory:    &compact.RangeFactory{Hash: rfc6962.DefaultHasher.HashChildren},
+		leafChunks: m.ChunksByKind(bundle.LeafChunk),
+		subtrees:   make(map[uint64][][]byte),
+		nodes:      make(map[compact.NodeID][]byte),
+	}
+	sort.Slice(l.leafChunks, func(i, j int) bool { return l.leafChunks[i].First < l.leafChunks[j].First })
+	counts := make(map[uint64]uint64)
+	for _, c := range l.leafChunks {
+		counts[c.First] = c.Count
+	}
+	for _, c := range m.ChunksByKind(bundle.SubtreeChunk) {
+		if count, ok := counts[c.First]; !ok || count != c.Count {
+			continue
+		}
+		hashes, err := r.Subtrees(c)
+		if err != nil {
+			return nil, fmt.Errorf("log %d: %v", m.LogID, err)
+		}
+		l.subtrees[c.First] = hashes
+	}
+	if err := l.buildNodes(); err != nil {
+		return nil, fmt.Errorf("log %d: %v", m.LogID, err)
+	}
+	return l, nil
+}
+
+// buildNodes fills in the nodes covering whole chunks, by merging the compact
+// ranges of the chunks from the start of the log.
+func (l *archivedLog) buildNodes() error {
+	visit := func(id compact.NodeID, hash []byte) { l.nodes[id] = hash }
+	rng := l.factory.NewEmptyRange(0)
+	for _, c := range l.leafChunks {
+		hashes, ok := l.subtrees[c.First]
+		if !ok || c.First != rng.End() {
+			break
+		}
+		cr, err := l.factory.NewRange(c.First, c.First+c.Count, hashes)
+		if err != nil {
+			return fmt.Errorf("subtrees of chunk %s: %v", c.Name, err)
+		}
+		for i, id := range compact.RangeNodes(c.First, c.First+c.Count, nil) {
+			visit(id, hashes[i])
+		}
+		if err := rng.AppendRange(cr, visit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// chunkFor returns the leaf chunk containing the leaf at index, if any.
+func (l *archivedLog) chunkFor(index uint64) (bundle.Chunk, bool) {
+	i := sort.Search(len(l.leafChunks), func(i int) bool {
+		c := l.leafChunks[i]
+		return c.First+c.Count > index
+	})
+	if i == len(l.leafChunks) || l.leafChunks[i].First > index {
+		return bundle.Chunk{}, false
+	}
+	return l.leafChunks[i], true
+}
+
+type logStorage struct {
+	s *Storage
+}
+
+func (ls *logStorage) CheckDatabaseAccessible(context.Context) error {
+	return nil
+}
+
+// GetActiveLogIDs returns no IDs, as archived logs are frozen.
+func (ls *logStorage) GetActiveLogIDs(context.Context) ([]int64, error) {
+	return nil, nil
+}
+
+func (ls *logStorage) SnapshotForTree(ctx context.Context, tree *trillian.Tree) (storage.ReadOnlyLogTreeTX, error) {
+	l, err := ls.s.getLog(tree.TreeId)
+	if err != nil {
+		return nil, err
+	}
+	return &snapshot{
+		l:      l,
+		leaves: make(map[string][]*trillian.LogLeaf),
+	}, nil
+}
+
+func (ls *logStorage) ReadWriteTransaction(context.Context, *trillian.Tree, storage.LogTXFunc) error {
+	return ErrReadOnly
+}
+
+func (ls *logStorage) QueueLeaves(context.Context, *trillian.Tree, []*trillian.LogLeaf, time.Time) ([]*trillian.QueuedLogLeaf, error) {
+	return nil, ErrReadOnly
+}
+
+func (ls *logStorage) AddSequencedLeaves(context.Context, *trillian.Tree, []*trillian.LogLeaf, time.Time) ([]*trillian.QueuedLogLeaf, error) {
+	return nil, ErrReadOnly
+}
+
+// snapshot is a read-only transaction on an archived log. It caches the leaf
+// chunks it reads, so that the nodes of a proof share chunk reads.
+type snapshot struct {
+	l *archivedLog
+
+	mu     sync.Mutex
+	leaves map[string][]*trillian.LogLeaf
+}
+
+func (t *snapshot) Commit(context.Context) error {
+	return nil
+}
+
+func (t *snapshot) Close() error {
+	return nil
+}
+
+func (t *snapshot) LatestSignedLogRoot(context.Context) (*trillian.SignedLogRoot, error) {
+	return proto.Clone(t.l.root).(*trillian.SignedLogRoot), nil
+}
+
+// GetLeavesByRange returns the leaves in [start, start+count) which are in
+// the same chunk as the leaf at start.
+func (t *snapshot) GetLeavesByRange(ctx context.Context, start, count int64) ([]*trillian.LogLeaf, error) {
+	if start < 0 || count <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid range start=%d, count=%d", start, count)
+	}
+	c, ok := t.l.chunkFor(uint64(start))
+	if !ok {
+		return nil, nil
+	}
+	leaves, err := t.chunkLeaves(c)
+	if err != nil {
+		return nil, err
+	}
+	begin := uint64(start) - c.First
+	end := begin + uint64(count)
+	if end > uint64(len(leaves)) {
+		end = uint64(len(leaves))
+	}
+	ret := make([]*trillian.LogLeaf, 0, end-begin)
+	for _, l := range leaves[begin:end] {
+		ret = append(ret, proto.Clone(l).(*trillian.LogLeaf))
+	}
+	return ret, nil
+}
+
+func (t *snapshot) GetLeavesByHash(context.Context, [][]byte, bool) ([]*trillian.LogLeaf, error) {
+	return nil, status.Error(codes.Unimplemented, "archive storage can't look up leaves by hash")
+}
+
+// GetMerkleNodes serves the requested nodes which cover whole chunks from the
+// nodes built when loading the bundle, and computes the others from the
+// subtree hashes of the chunks they cover, and the leaves of the chunks they
+// partially cover.
+func (t *snapshot) GetMerkleNodes(ctx context.Context, ids []compact.NodeID) ([]tree.Node, error) {
+	nodes := make([]tree.Node, 0, len(ids))
+	for _, id := range ids {
+		begin, end := id.Coverage()
+		if end > t.l.size {
+			return nil, status.Errorf(codes.OutOfRange, "node %+v is beyond tree size %d", id, t.l.size)
+		}
+		if hash, ok := t.l.nodes[id]; ok {
+			nodes = append(nodes, tree.Node{ID: id, Hash: hash})
+			continue
+		}
+		rng := t.l.factory.NewEmptyRange(begin)
+		first := sort.Search(len(t.l.leafChunks), func(i int) bool {
+			c := t.l.leafChunks[i]
+			return c.First+c.Count > begin
+		})
+		for _, c := range t.l.leafChunks[first:] {
+			if c.First >= end {
+				break
+			}
+			if err := t.appendChunk(rng, c, begin, end); err != nil {
+				return nil, err
+			}
+		}
+		hashes := rng.Hashes()
+		if rng.End() != end || len(hashes) != 1 {
+			return nil, status.Errorf(codes.Internal, "failed to compute node %+v from the bundle", id)
+		}
+		nodes = append(nodes, tree.Node{ID: id, Hash: hashes[0]})
+	}
+	return nodes, nil
+}
+
+// appendChunk appends the part of chunk c within [begin, end) to rng.
+func (t *snapshot) appendChunk(rng *compact.Range, c bundle.Chunk, begin, end uint64) error {
+	if c.First >= begin && c.First+c.Count <= end {
+		hashes, ok := t.l.subtrees[c.First]
+		if !ok {
+			return status.Errorf(codes.DataLoss, "log %d: no subtree chunk for chunk %s", t.l.tree.TreeId, c.Name)
+		}
+		cr, err := t.l.factory.NewRange(c.First, c.First+c.Count, hashes)
+		if err != nil {
+			return fmt.Errorf("subtrees of chunk %s: %v", c.Name, err)
+		}
+		return rng.AppendRange(cr, nil)
+	}
+	leaves, err := t.chunkLeaves(c)
+	if err != nil {
+		return err
+	}
+	for _, l := range leaves {
+		if i := uint64(l.LeafIndex); i >= begin && i < end {
+			if err := rng.Append(l.MerkleLeafHash, nil); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (t *snapshot) chunkLeaves(c bundle.Chunk) ([]*trillian.LogLeaf, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if leaves, ok := t.leaves[c.Name]; ok {
+		return leaves, nil
+	}
+	leaves, err := t.l.r.Leaves(c)
+	if err != nil {
+		return nil, status.Errorf(codes.DataLoss, "log %d: %v", t.l.tree.TreeId, err)
+	}
+	t.leaves[c.Name] = leaves
+	return leaves, nil
+}
+
+type adminStorage struct {
+	s *Storage
+}
+
+func (as *adminStorage) Snapshot(context.Context) (storage.ReadOnlyAdminTX, error) {
+	return &adminTX{s: as.s}, nil
+}
+
+func (as *adminStorage) ReadWriteTransaction(context.Context, storage.AdminTXFunc) error {
+	return ErrReadOnly
+}
+
+func (as *adminStorage) CheckDatabaseAccessible(context.Context) error {
+	return nil
+}
+
+type adminTX struct {
+	s *Storage
+}
+
+func (t *adminTX) GetTree(ctx context.Context, treeID int64) (*trillian.Tree, error) {
+	l, err := t.s.getLog(treeID)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Clone(l.tree).(*trillian.Tree), nil
+}
+
+func (t *adminTX) ListTrees(ctx context.Context, includeDeleted bool) ([]*trillian.Tree, error) {
+	ret := make([]*trillian.Tree, 0, len(t.s.logs))
+	for _, l := range t.s.logs {
+		ret = append(ret, proto.Clone(l.tree).(*trillian.Tree))
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].TreeId < ret[j].TreeId })
+	return ret, nil
+}
+
+func (t *adminTX) Commit() error {
+	return nil
+}
+
+func (t *adminTX) Close() error {
+	return nil
+}
@@ -0,0 +1,215 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/trillian"
+	bundle "github.com/google/trillian/archive"
+	"github.com/google/trillian/extension"
+	"github.com/google/trillian/server"
+	"github.com/google/trillian/types"
+	"github.com/google/trillian/util/clock"
+	"github.com/transparency-dev/merkle/compact"
+	"github.com/transparency-dev/merkle/proof"
+	"github.com/transparency-dev/merkle/rfc6962"
+	inmemory "github.com/transparency-dev/merkle/testonly"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const testLogID = 1234
+
+// newTestStorage writes a bundle of numLeaves leaves in chunks of chunkSize,
+// and returns archive storage serving it, and the equivalent in-memory tree.
+func newTestStorage(t *testing.T, numLeaves int, chunkSize uint64) (*Storage, *inmemory.Tree) {
+	t.Helper()
+	s, tree, _ := newTestStorageInDir(t, numLeaves, chunkSize)
+	return s, tree
+}
+
+// newTestStorageInDir is newTestStorage, which also returns the directory of
+// the bundle.
+func newTestStorageInDir(t *testing.T, numLeaves int, chunkSize uint64) (*Storage, *inmemory.Tree, string) {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "bundle")
+	w, err := bundle.NewWriter(dir, testLogID, chunkSize)
+	if err != nil {
+		t.Fatalf("NewWriter(): %v", err)
+	}
+	tree := inmemory.New(rfc6962.DefaultHasher)
+	for i := 0; i < numLeaves; i++ {
+		value := []byte(fmt.Sprintf("leaf %d", i))
+		tree.AppendData(value)
+		if err := w.AppendLeaves(&trillian.LogLeaf{LeafIndex: int64(i), LeafValue: value}); err != nil {
+			t.Fatalf("AppendLeaves(): %v", err)
+		}
+	}
+	root, err := (&types.LogRootV1{TreeSize: tree.Size(), RootHash: tree.Hash(), TimestampNanos: 12345}).MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary(): %v", err)
+	}
+	if err := w.AddRoot(&trillian.SignedLogRoot{LogRoot: root}); err != nil {
+		t.Fatalf("AddRoot(): %v", err)
+	}
+	if _, err := w.Close(); err != nil {
+		t.Fatalf("Close(): %v", err)
+	}
+	r, err := bundle.NewReader(dir)
+	if err != nil {
+		t.Fatalf("NewReader(): %v", err)
+	}
+	s, err := New(r)
+	if err != nil {
+		t.Fatalf("New(): %v", err)
+	}
+	return s, tree, dir
+}
+
+func TestServeFromBundle(t *testing.T) {
+	ctx := context.Background()
+	const size = 21
+	s, tree := newTestStorage(t, size, 5)
+	logServer := server.NewTrillianLogRPCServer(extension.Registry{AdminStorage: s.AdminStorage(), LogStorage: s.LogStorage()}, clock.System)
+
+	if failed, unchecked, err := logServer.CheckTreesConsistency(ctx); err != nil || failed != 0 || unchecked != 0 {
+		t.Fatalf("CheckTreesConsistency(): %d, %d, %v, want 0, 0, nil", failed, unchecked, err)
+	}
+
+	rsp, err := logServer.GetLatestSignedLogRoot(ctx, &trillian.GetLatestSignedLogRootRequest{LogId: testLogID})
+	if err != nil {
+		t.Fatalf("GetLatestSignedLogRoot(): %v", err)
+	}
+	var root types.LogRootV1
+	if err := root.UnmarshalBinary(rsp.SignedLogRoot.LogRoot); err != nil {
+		t.Fatalf("UnmarshalBinary(): %v", err)
+	}
+	if root.TreeSize != size || !bytes.Equal(root.RootHash, tree.Hash()) || root.TimestampNanos != 12345 {
+		t.Fatalf("latest root %+v, want the archived root of size %d", root, size)
+	}
+
+	var leaves []*trillian.LogLeaf
+	for len(leaves) < size {
+		rsp, err := logServer.GetLeavesByRange(ctx, &trillian.GetLeavesByRangeRequest{LogId: testLogID, StartIndex: int64(len(leaves)), Count: 100})
+		if err != nil {
+			t.Fatalf("GetLeavesByRange(%d): %v", len(leaves), err)
+		}
+		if len(rsp.Leaves) == 0 {
+			t.Fatalf("GetLeavesByRange(%d) returned no leaves", len(leaves))
+		}
+		leaves = append(leaves, rsp.Leaves...)
+	}
+	for i, l := range leaves {
+		if got, want := string(l.LeafValue), fmt.Sprintf("leaf %d", i); l.LeafIndex != int64(i) || got != want {
+			t.Errorf("leaf %d: index %d, value %q, want %q", i, l.LeafIndex, got, want)
+		}
+	}
+
+	for treeSize := uint64(1); treeSize <= size; treeSize++ {
+		for index := uint64(0); index < treeSize; index++ {
+			rsp, err := logServer.GetInclusionProof(ctx, &trillian.GetInclusionProofRequest{LogId: testLogID, LeafIndex: int64(index), TreeSize: int64(treeSize)})
+			if err != nil {
+				t.Fatalf("GetInclusionProof(%d, %d): %v", index, treeSize, err)
+			}
+			if err := proof.VerifyInclusion(rfc6962.DefaultHasher, index, treeSize, tree.LeafHash(index), rsp.Proof.Hashes, tree.HashAt(treeSize)); err != nil {
+				t.Errorf("VerifyInclusion(%d, %d): %v", index, treeSize, err)
+			}
+		}
+		rsp, err := logServer.GetConsistencyProof(ctx, &trillian.GetConsistencyProofRequest{LogId: testLogID, FirstTreeSize: int64(treeSize), SecondTreeSize: size})
+		if err != nil {
+			t.Fatalf("GetConsistencyProof(%d, %d): %v", treeSize, size, err)
+		}
+		if err := proof.VerifyConsistency(rfc6962.DefaultHasher, treeSize, size, rsp.Proof.Hashes, tree.HashAt(treeSize), tree.Hash()); err != nil {
+			t.Errorf("VerifyConsistency(%d, %d): %v", treeSize, size, err)
+		}
+	}
+}
+
+func TestNodesOfWholeChunks(t *testing.T) {
+	ctx := context.Background()
+	s, tree, dir := newTestStorageInDir(t, 21, 4)
+
+	// Nodes covering whole chunks are served without reading any chunk.
+	r, err := bundle.NewReader(dir)
+	if err != nil {
+		t.Fatalf("NewReader(): %v", err)
+	}
+	for _, c := range r.Manifest().Chunks {
+		if c.Kind != bundle.RootChunk {
+			if err := os.Remove(filepath.Join(dir, c.Name)); err != nil {
+				t.Fatalf("Remove(): %v", err)
+			}
+		}
+	}
+	tx, err := s.LogStorage().SnapshotForTree(ctx, &trillian.Tree{TreeId: testLogID})
+	if err != nil {
+		t.Fatalf("SnapshotForTree(): %v", err)
+	}
+	defer tx.Close()
+	ids := []compact.NodeID{compact.NewNodeID(2, 0), compact.NewNodeID(2, 4), compact.NewNodeID(3, 1), compact.NewNodeID(4, 0)}
+	nodes, err := tx.GetMerkleNodes(ctx, ids)
+	if err != nil {
+		t.Fatalf("GetMerkleNodes(): %v", err)
+	}
+	for i, n := range nodes {
+		begin, end := ids[i].Coverage()
+		if want := tree.HashAt(end); begin == 0 && !bytes.Equal(n.Hash, want) {
+			t.Errorf("node %+v: hash %x, want %x", ids[i], n.Hash, want)
+		}
+	}
+
+	// Nodes within a chunk need its leaves.
+	if _, err := tx.GetMerkleNodes(ctx, []compact.NodeID{compact.NewNodeID(0, 1)}); status.Code(err) != codes.DataLoss {
+		t.Errorf("GetMerkleNodes() within a removed chunk: %v, want DataLoss", err)
+	}
+}
+
+func TestReadOnly(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestStorage(t, 3, 2)
+	tx, err := s.AdminStorage().Snapshot(ctx)
+	if err != nil {
+		t.Fatalf("Snapshot(): %v", err)
+	}
+	tree, err := tx.GetTree(ctx, testLogID)
+	if err != nil {
+		t.Fatalf("GetTree(): %v", err)
+	}
+	if tree.TreeState != trillian.TreeState_FROZEN {
+		t.Errorf("TreeState=%v, want FROZEN", tree.TreeState)
+	}
+	if _, err := tx.GetTree(ctx, testLogID+1); status.Code(err) != codes.NotFound {
+		t.Errorf("GetTree() of unknown tree: %v, want NotFound", err)
+	}
+
+	ls := s.LogStorage()
+	if _, err := ls.QueueLeaves(ctx, tree, nil, time.Now()); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("QueueLeaves(): %v, want %v", err, ErrReadOnly)
+	}
+	if err := s.AdminStorage().ReadWriteTransaction(ctx, nil); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("ReadWriteTransaction(): %v, want %v", err, ErrReadOnly)
+	}
+	if ids, err := ls.GetActiveLogIDs(ctx); err != nil || len(ids) != 0 {
+		t.Errorf("GetActiveLogIDs(): %v, %v, want none", ids, err)
+	}
+}
@@ -0,0 +1,264 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bbolt
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/storage"
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// NewAdminStorage returns a bbolt storage.AdminStorage implementation backed
+// by db.
+func NewAdminStorage(db *bolt.DB) storage.AdminStorage {
+	return &boltAdminStorage{db}
+}
+
+// boltAdminStorage implements storage.AdminStorage
+type boltAdminStorage struct {
+	db *bolt.DB
+}
+
+func (s *boltAdminStorage) Snapshot(ctx context.Context) (storage.ReadOnlyAdminTX, error) {
+	return s.beginInternal(false /* writable */)
+}
+
+func (s *boltAdminStorage) beginInternal(writable bool) (*adminTX, error) {
+	tx, err := s.db.Begin(writable)
+	if err != nil {
+		return nil, err
+	}
+	return &adminTX{tx: tx}, nil
+}
+
+func (s *boltAdminStorage) ReadWriteTransaction(ctx context.Context, f storage.AdminTXFunc) error {
+	tx, err := s.beginInternal(true /* writable */)
+	if err != nil {
+		return err
+	}
+	defer tx.Close()
+	if err := f(ctx, tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *boltAdminStorage) CheckDatabaseAccessible(ctx context.Context) error {
+	return s.db.View(func(*bolt.Tx) error { return nil })
+}
+
+type adminTX struct {
+	tx *bolt.Tx
+
+	// mu guards reads/writes on closed, which happen on Commit/Close methods.
+	//
+	// We don't check closed on methods apart from the ones above, as we trust tx
+	// to keep tabs on its state, and hence fail to do queries after closed.
+	mu     sync.Mutex
+	closed bool
+}
+
+func (t *adminTX) Commit() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.closed = true
+	if !t.tx.Writable() {
+		// Read-only bbolt transactions can only be rolled back.
+		return t.tx.Rollback()
+	}
+	return t.tx.Commit()
+}
+
+func (t *adminTX) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return nil
+	}
+	t.closed = true
+	return t.tx.Rollback()
+}
+
+func (t *adminTX) GetTree(ctx context.Context, treeID int64) (*trillian.Tree, error) {
+	return readTree(t.tx, treeID)
+}
+
+// readTree returns the tree with the given ID, or a NotFound error.
+func readTree(tx *bolt.Tx, treeID int64) (*trillian.Tree, error) {
+	v := tx.Bucket(treesBucket).Get(int64Key(treeID))
+	if v == nil {
+		return nil, status.Errorf(codes.NotFound, "tree %v not found", treeID)
+	}
+	tree := &trillian.Tree{}
+	if err := proto.Unmarshal(v, tree); err != nil {
+		return nil, fmt.Errorf("error reading tree %v: %v", treeID, err)
+	}
+	return tree, nil
+}
+
+// forEachTree calls f with each of the stored trees, in order of their IDs.
+func forEachTree(tx *bolt.Tx, f func(*trillian.Tree) error) error {
+	return tx.Bucket(treesBucket).ForEach(func(k, v []byte) error {
+		tree := &trillian.Tree{}
+		if err := proto.Unmarshal(v, tree); err != nil {
+			return fmt.Errorf("error reading tree %v: %v", keyInt64(k), err)
+		}
+		return f(tree)
+	})
+}
+
+func (t *adminTX) ListTrees(ctx context.Context, includeDeleted bool) ([]*trillian.Tree, error) {
+	trees := []*trillian.Tree{}
+	err := forEachTree(t.tx, func(tree *trillian.Tree) error {
+		if includeDeleted || !tree.Deleted {
+			trees = append(trees, tree)
+		}
+		return nil
+	})
+	return trees, err
+}
+
+func (t *adminTX) CreateTree(ctx context.Context, tree *trillian.Tree) (*trillian.Tree, error) {
+	if err := storage.ValidateTreeForCreation(ctx, tree); err != nil {
+		return nil, err
+	}
+	if err := checkUnsupportedFields(tree); err != nil {
+		return nil, err
+	}
+
+	id, err := storage.TreeIDForCreation(tree)
+	if err != nil {
+		return nil, err
+	}
+	if t.tx.Bucket(treesBucket).Get(int64Key(id)) != nil {
+		return nil, status.Errorf(codes.AlreadyExists, "tree %v already exists", id)
+	}
+
+	now := timestamppb.New(time.Now())
+	newTree := proto.Clone(tree).(*trillian.Tree)
+	newTree.TreeId = id
+	newTree.CreateTime = now
+	newTree.UpdateTime = now
+	if err := t.putTree(newTree); err != nil {
+		return nil, err
+	}
+	if _, err := createLogBucket(t.tx, id); err != nil {
+		return nil, fmt.Errorf("failed to create buckets of tree %v: %v", id, err)
+	}
+	return newTree, nil
+}
+
+func (t *adminTX) putTree(tree *trillian.Tree) error {
+	v, err := proto.Marshal(tree)
+	if err != nil {
+		return fmt.Errorf("failed to marshal tree %v: %v", tree.TreeId, err)
+	}
+	return t.tx.Bucket(treesBucket).Put(int64Key(tree.TreeId), v)
+}
+
+func (t *adminTX) UpdateTree(ctx context.Context, treeID int64, updateFunc func(*trillian.Tree)) (*trillian.Tree, error) {
+	tree, err := t.GetTree(ctx, treeID)
+	if err != nil {
+		return nil, err
+	}
+
+	beforeUpdate := proto.Clone(tree).(*trillian.Tree)
+	updateFunc(tree)
+	if err := storage.ValidateTreeForUpdate(ctx, beforeUpdate, tree); err != nil {
+		return nil, err
+	}
+	if err := checkUnsupportedFields(tree); err != nil {
+		return nil, err
+	}
+
+	tree.UpdateTime = timestamppb.New(time.Now())
+	if err := t.putTree(tree); err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
+
+func (t *adminTX) SoftDeleteTree(ctx context.Context, treeID int64) (*trillian.Tree, error) {
+	return t.updateDeleted(ctx, treeID, true /* deleted */, timestamppb.New(time.Now()))
+}
+
+func (t *adminTX) UndeleteTree(ctx context.Context, treeID int64) (*trillian.Tree, error) {
+	return t.updateDeleted(ctx, treeID, false /* deleted */, nil)
+}
+
+// updateDeleted updates the Deleted and DeleteTime fields of the specified tree.
+func (t *adminTX) updateDeleted(ctx context.Context, treeID int64, deleted bool, deleteTime *timestamppb.Timestamp) (*trillian.Tree, error) {
+	tree, err := t.validateDeleted(treeID, !deleted)
+	if err != nil {
+		return nil, err
+	}
+	tree.Deleted = deleted
+	tree.DeleteTime = deleteTime
+	if err := t.putTree(tree); err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
+
+// HardDeleteTree deletes the tree and the bucket holding all of its data.
+func (t *adminTX) HardDeleteTree(ctx context.Context, treeID int64) error {
+	if _, err := t.validateDeleted(treeID, true /* wantDeleted */); err != nil {
+		return err
+	}
+	if err := t.tx.Bucket(treesBucket).Delete(int64Key(treeID)); err != nil {
+		return err
+	}
+	if err := t.tx.Bucket(logsBucket).DeleteBucket(int64Key(treeID)); err != nil && err != bolt.ErrBucketNotFound {
+		return err
+	}
+	return nil
+}
+
+// validateDeleted returns the tree if its soft deletion state is the wanted
+// one, or an error otherwise.
+func (t *adminTX) validateDeleted(treeID int64, wantDeleted bool) (*trillian.Tree, error) {
+	tree, err := readTree(t.tx, treeID)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case wantDeleted && !tree.Deleted:
+		return nil, status.Errorf(codes.FailedPrecondition, "tree %v is not soft deleted", treeID)
+	case !wantDeleted && tree.Deleted:
+		return nil, status.Errorf(codes.FailedPrecondition, "tree %v already soft deleted", treeID)
+	}
+	return tree, nil
+}
+
+// checkUnsupportedFields returns an error if the tree uses features which
+// this storage doesn't support.
+func checkUnsupportedFields(tree *trillian.Tree) error {
+	if tree.StorageSettings != nil {
+		return status.Errorf(codes.InvalidArgument, "storage_settings not supported, but got %v", tree.StorageSettings)
+	}
+	if tree.EncryptHashes {
+		return status.Error(codes.InvalidArgument, "encrypt_hashes not supported")
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bbolt
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/storage/testonly"
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// openTestDB opens a new database in a temporary directory, which is closed
+// when the test finishes.
+func openTestDB(t *testing.T) *bolt.DB {
+	t.Helper()
+	return openDBAt(t, filepath.Join(t.TempDir(), "trillian.db"))
+}
+
+// openDBAt opens the database at path, which is closed when the test
+// finishes.
+func openDBAt(t *testing.T, path string) *bolt.DB {
+	t.Helper()
+	db, err := OpenDB(path)
+	if err != nil {
+		t.Fatalf("OpenDB() failed: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := db.Close(); err != nil {
+			t.Errorf("Close() failed: %v", err)
+		}
+	})
+	return db
+}
+
+func TestBoltAdminStorage(t *testing.T) {
+	tester := &testonly.AdminStorageTester{NewAdminStorage: func() storage.AdminStorage {
+		return NewAdminStorage(openTestDB(t))
+	}}
+	tester.RunAllTests(t)
+}
+
+func TestAdminTX_CreateTree_UnsupportedFields(t *testing.T) {
+	s := NewAdminStorage(openTestDB(t))
+	ctx := context.Background()
+
+	tree := proto.Clone(testonly.LogTree).(*trillian.Tree)
+	tree.EncryptHashes = true
+	_, err := storage.CreateTree(ctx, s, tree)
+	if got, want := status.Code(err), codes.InvalidArgument; got != want {
+		t.Errorf("CreateTree() returned %v, want code %v", err, want)
+	}
+}
+
+func TestAdminTX_HardDeleteTree_DeletesData(t *testing.T) {
+	db := openTestDB(t)
+	s := NewAdminStorage(db)
+	ctx := context.Background()
+
+	tree, err := storage.CreateTree(ctx, s, testonly.LogTree)
+	if err != nil {
+		t.Fatalf("CreateTree() failed: %v", err)
+	}
+	if _, err := storage.SoftDeleteTree(ctx, s, tree.TreeId); err != nil {
+		t.Fatalf("SoftDeleteTree() failed: %v", err)
+	}
+	if err := storage.HardDeleteTree(ctx, s, tree.TreeId); err != nil {
+		t.Fatalf("HardDeleteTree() failed: %v", err)
+	}
+	if err := db.View(func(tx *bolt.Tx) error {
+		if b := tx.Bucket(logsBucket).Bucket(int64Key(tree.TreeId)); b != nil {
+			t.Errorf("Bucket of tree %d still exists after HardDeleteTree()", tree.TreeId)
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("View() failed: %v", err)
+	}
+}
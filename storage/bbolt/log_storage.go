@@ -0,0 +1,585 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bbolt
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian"
+	"github.com/google/trillian/monitoring"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/storage/cache"
+	"github.com/google/trillian/storage/tree"
+	"github.com/google/trillian/types"
+	"github.com/transparency-dev/merkle/compact"
+	"github.com/transparency-dev/merkle/rfc6962"
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const logIDLabel = "logid"
+
+var (
+	once            sync.Once
+	queuedCounter   monitoring.Counter
+	dequeuedCounter monitoring.Counter
+)
+
+func createMetrics(mf monitoring.MetricFactory) {
+	queuedCounter = mf.NewCounter("bbolt_queued_leaves", "Number of leaves queued", logIDLabel)
+	dequeuedCounter = mf.NewCounter("bbolt_dequeued_leaves", "Number of leaves dequeued", logIDLabel)
+}
+
+func labelForTX(t *logTreeTX) string {
+	return strconv.FormatInt(t.treeID, 10)
+}
+
+type boltLogStorage struct {
+	db            *bolt.DB
+	metricFactory monitoring.MetricFactory
+}
+
+// NewLogStorage creates a storage.LogStorage instance for the specified bbolt
+// database.
+func NewLogStorage(db *bolt.DB, mf monitoring.MetricFactory) storage.LogStorage {
+	if mf == nil {
+		mf = monitoring.InertMetricFactory{}
+	}
+	return &boltLogStorage{
+		db:            db,
+		metricFactory: mf,
+	}
+}
+
+func (m *boltLogStorage) CheckDatabaseAccessible(ctx context.Context) error {
+	return m.db.View(func(*bolt.Tx) error { return nil })
+}
+
+func (m *boltLogStorage) GetActiveLogIDs(ctx context.Context) ([]int64, error) {
+	ids := []int64{}
+	err := m.db.View(func(tx *bolt.Tx) error {
+		return forEachTree(tx, func(tree *trillian.Tree) error {
+			if tree.Deleted {
+				return nil
+			}
+			// Include logs that are DRAINING in the active list as we're still
+			// integrating leaves into them.
+			switch tree.TreeType {
+			case trillian.TreeType_LOG, trillian.TreeType_PREORDERED_LOG:
+				switch tree.TreeState {
+				case trillian.TreeState_ACTIVE, trillian.TreeState_DRAINING:
+					ids = append(ids, tree.TreeId)
+				}
+			}
+			return nil
+		})
+	})
+	return ids, err
+}
+
+func (m *boltLogStorage) beginInternal(ctx context.Context, tree *trillian.Tree, writable bool) (*logTreeTX, error) {
+	once.Do(func() {
+		createMetrics(m.metricFactory)
+	})
+	if err := checkUnsupportedFields(tree); err != nil {
+		return nil, err
+	}
+
+	stCache := cache.NewLogSubtreeCache(rfc6962.DefaultHasher)
+	ttx, err := beginTreeTX(m.db, tree, writable, rfc6962.DefaultHasher.Size(), stCache)
+	if err != nil {
+		return nil, err
+	}
+
+	ltx := &logTreeTX{
+		treeTX:   ttx,
+		dequeued: make(map[string][]byte),
+	}
+	ltx.slr, ltx.readRev, err = ltx.fetchLatestRoot()
+	if err == storage.ErrTreeNeedsInit {
+		ltx.treeTX.writeRevision = 0
+		return ltx, err
+	} else if err != nil {
+		ttx.Close()
+		return nil, err
+	}
+
+	if err := ltx.root.UnmarshalBinary(ltx.slr.LogRoot); err != nil {
+		ttx.Close()
+		return nil, err
+	}
+
+	ltx.treeTX.writeRevision = ltx.readRev + 1
+	return ltx, nil
+}
+
+func (m *boltLogStorage) ReadWriteTransaction(ctx context.Context, tree *trillian.Tree, f storage.LogTXFunc) error {
+	tx, err := m.beginInternal(ctx, tree, true /* writable */)
+	if err != nil && err != storage.ErrTreeNeedsInit {
+		return err
+	}
+	defer tx.Close()
+	if err := f(ctx, tx); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (m *boltLogStorage) AddSequencedLeaves(ctx context.Context, tree *trillian.Tree, leaves []*trillian.LogLeaf, timestamp time.Time) ([]*trillian.QueuedLogLeaf, error) {
+	tx, err := m.beginInternal(ctx, tree, true /* writable */)
+	if tx != nil {
+		// Ensure we don't leak the transaction. For example if we get an
+		// ErrTreeNeedsInit from beginInternal() or if AddSequencedLeaves fails
+		// below.
+		defer tx.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	res, err := tx.AddSequencedLeaves(ctx, leaves, timestamp)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (m *boltLogStorage) SnapshotForTree(ctx context.Context, tree *trillian.Tree) (storage.ReadOnlyLogTreeTX, error) {
+	tx, err := m.beginInternal(ctx, tree, false /* writable */)
+	if err != nil && err != storage.ErrTreeNeedsInit {
+		return nil, err
+	}
+	return tx, err
+}
+
+func (m *boltLogStorage) QueueLeaves(ctx context.Context, tree *trillian.Tree, leaves []*trillian.LogLeaf, queueTimestamp time.Time) ([]*trillian.QueuedLogLeaf, error) {
+	tx, err := m.beginInternal(ctx, tree, true /* writable */)
+	if tx != nil {
+		// Ensure we don't leak the transaction. For example if we get an
+		// ErrTreeNeedsInit from beginInternal() or if QueueLeaves fails
+		// below.
+		defer tx.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	existing, err := tx.QueueLeaves(ctx, leaves, queueTimestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	ret := make([]*trillian.QueuedLogLeaf, len(leaves))
+	for i, e := range existing {
+		if e != nil {
+			ret[i] = &trillian.QueuedLogLeaf{
+				Leaf:   e,
+				Status: status.Newf(codes.AlreadyExists, "leaf already exists: %v", e.LeafIdentityHash).Proto(),
+			}
+			continue
+		}
+		ret[i] = &trillian.QueuedLogLeaf{Leaf: leaves[i]}
+	}
+	return ret, nil
+}
+
+type logTreeTX struct {
+	treeTX
+	root    types.LogRootV1
+	readRev int64
+	slr     *trillian.SignedLogRoot
+	// dequeued maps the identity hashes of the dequeued leaves to their
+	// Unsequenced keys.
+	dequeued map[string][]byte
+}
+
+// GetMerkleNodes returns the requested nodes at the read revision.
+func (t *logTreeTX) GetMerkleNodes(ctx context.Context, ids []compact.NodeID) ([]tree.Node, error) {
+	t.treeTX.mu.Lock()
+	defer t.treeTX.mu.Unlock()
+	return t.subtreeCache.GetNodes(ids, t.getSubtreesAtRev(ctx, t.readRev))
+}
+
+// unsequencedKey returns the key of the Unsequenced entry of a leaf, which
+// orders the queue by queue timestamp.
+func unsequencedKey(queueTimestampNanos int64, leafIdentityHash []byte) []byte {
+	return concat(int64Key(queueTimestampNanos), leafIdentityHash)
+}
+
+// putLeafData stores the data of the leaf under its identity hash, unless
+// there already is a leaf with that hash. It returns whether it stored it.
+func (t *logTreeTX) putLeafData(leaf *trillian.LogLeaf, queueTimestamp *timestamppb.Timestamp) (bool, error) {
+	b := t.bucket.Bucket(leafDataBucket)
+	if b.Get(leaf.LeafIdentityHash) != nil {
+		return false, nil
+	}
+	v, err := proto.Marshal(&trillian.LogLeaf{
+		MerkleLeafHash:   leaf.MerkleLeafHash,
+		LeafValue:        leaf.LeafValue,
+		ExtraData:        leaf.ExtraData,
+		LeafIdentityHash: leaf.LeafIdentityHash,
+		QueueTimestamp:   queueTimestamp,
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, b.Put(leaf.LeafIdentityHash, v)
+}
+
+// getLeafData returns the leaf with the given identity hash, without its index
+// and integrate timestamp, or nil if there is none.
+func (t *logTreeTX) getLeafData(leafIdentityHash []byte) (*trillian.LogLeaf, error) {
+	v := t.bucket.Bucket(leafDataBucket).Get(leafIdentityHash)
+	if v == nil {
+		return nil, nil
+	}
+	leaf := &trillian.LogLeaf{}
+	if err := proto.Unmarshal(v, leaf); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal leaf data: %v", err)
+	}
+	return leaf, nil
+}
+
+// putSequencedLeaf stores the leaf at its index, unless the index is taken. It
+// returns whether it stored it.
+func (t *logTreeTX) putSequencedLeaf(leaf *trillian.LogLeaf, integrateTimestamp *timestamppb.Timestamp) (bool, error) {
+	b := t.bucket.Bucket(sequencedLeafDataBucket)
+	k := int64Key(leaf.LeafIndex)
+	if b.Get(k) != nil {
+		return false, nil
+	}
+	v, err := proto.Marshal(&trillian.LogLeaf{
+		MerkleLeafHash:     leaf.MerkleLeafHash,
+		LeafIdentityHash:   leaf.LeafIdentityHash,
+		LeafIndex:          leaf.LeafIndex,
+		IntegrateTimestamp: integrateTimestamp,
+	})
+	if err != nil {
+		return false, err
+	}
+	if err := b.Put(k, v); err != nil {
+		return false, err
+	}
+	return true, t.bucket.Bucket(merkleHashIndexBucket).Put(concat(leaf.MerkleLeafHash, k), []byte{})
+}
+
+// getSequencedLeaf returns the leaf at index, or nil if there is none.
+func (t *logTreeTX) getSequencedLeaf(index int64) (*trillian.LogLeaf, error) {
+	v := t.bucket.Bucket(sequencedLeafDataBucket).Get(int64Key(index))
+	if v == nil {
+		return nil, nil
+	}
+	leaf := &trillian.LogLeaf{}
+	if err := proto.Unmarshal(v, leaf); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal sequenced leaf: %v", err)
+	}
+	data, err := t.getLeafData(leaf.LeafIdentityHash)
+	if err != nil {
+		return nil, err
+	} else if data == nil {
+		return nil, fmt.Errorf("no leaf data for leaf %d with identity hash %x", index, leaf.LeafIdentityHash)
+	}
+	leaf.LeafValue = data.LeafValue
+	leaf.ExtraData = data.ExtraData
+	leaf.QueueTimestamp = data.QueueTimestamp
+	return leaf, nil
+}
+
+func (t *logTreeTX) QueueLeaves(ctx context.Context, leaves []*trillian.LogLeaf, queueTimestamp time.Time) ([]*trillian.LogLeaf, error) {
+	t.treeTX.mu.Lock()
+	defer t.treeTX.mu.Unlock()
+
+	// Don't accept batches if any of the leaves are invalid.
+	for _, leaf := range leaves {
+		if len(leaf.LeafIdentityHash) != t.hashSizeBytes {
+			return nil, fmt.Errorf("queued leaf must have a leaf ID hash of length %d", t.hashSizeBytes)
+		}
+		leaf.QueueTimestamp = timestamppb.New(queueTimestamp)
+		if err := leaf.QueueTimestamp.CheckValid(); err != nil {
+			return nil, fmt.Errorf("got invalid queue timestamp: %w", err)
+		}
+	}
+
+	queue := t.bucket.Bucket(unsequencedBucket)
+	existingLeaves := make([]*trillian.LogLeaf, len(leaves))
+	for i, leaf := range leaves {
+		inserted, err := t.putLeafData(leaf, leaf.QueueTimestamp)
+		if err != nil {
+			glog.Warningf("Error inserting %d into LeafData: %s", i, err)
+			return nil, err
+		}
+		if !inserted {
+			if existingLeaves[i], err = t.getLeafData(leaf.LeafIdentityHash); err != nil {
+				return nil, fmt.Errorf("failed to retrieve existing leaf: %v", err)
+			}
+			continue
+		}
+		// Create the work queue entry
+		if err := queue.Put(unsequencedKey(queueTimestamp.UnixNano(), leaf.LeafIdentityHash), leaf.MerkleLeafHash); err != nil {
+			glog.Warningf("Error inserting into Unsequenced: %s", err)
+			return nil, err
+		}
+	}
+	queuedCounter.Add(float64(len(leaves)), labelForTX(t))
+	return existingLeaves, nil
+}
+
+func (t *logTreeTX) DequeueLeaves(ctx context.Context, limit int, cutoffTime time.Time) ([]*trillian.LogLeaf, error) {
+	t.treeTX.mu.Lock()
+	defer t.treeTX.mu.Unlock()
+
+	if t.treeType == trillian.TreeType_PREORDERED_LOG {
+		return t.getLeavesByRangeInternal(int64(t.root.TreeSize), int64(limit))
+	}
+
+	leaves := make([]*trillian.LogLeaf, 0, limit)
+	cutoff := cutoffTime.UnixNano()
+	c := t.bucket.Bucket(unsequencedBucket).Cursor()
+	for k, v := c.First(); k != nil && len(leaves) < limit; k, v = c.Next() {
+		queueTimestamp := keyInt64(k)
+		if queueTimestamp > cutoff {
+			break
+		}
+		leafIDHash := k[8:]
+		if len(leafIDHash) != t.hashSizeBytes {
+			return nil, fmt.Errorf("dequeued a leaf with incorrect hash size")
+		}
+		if _, ok := t.dequeued[string(leafIDHash)]; ok {
+			// dupe, user probably called DequeueLeaves more than once.
+			continue
+		}
+		// Keys and values are only valid during the transaction, and the
+		// leaves can outlive it.
+		t.dequeued[string(leafIDHash)] = append([]byte(nil), k...)
+		leaves = append(leaves, &trillian.LogLeaf{
+			LeafIdentityHash: append([]byte(nil), leafIDHash...),
+			MerkleLeafHash:   append([]byte(nil), v...),
+			QueueTimestamp:   timestamppb.New(time.Unix(0, queueTimestamp)),
+		})
+	}
+
+	dequeuedCounter.Add(float64(len(leaves)), labelForTX(t))
+	return leaves, nil
+}
+
+func (t *logTreeTX) UpdateSequencedLeaves(ctx context.Context, leaves []*trillian.LogLeaf) error {
+	t.treeTX.mu.Lock()
+	defer t.treeTX.mu.Unlock()
+
+	queue := t.bucket.Bucket(unsequencedBucket)
+	for _, leaf := range leaves {
+		// This should fail on insert but catch it early
+		if len(leaf.LeafIdentityHash) != t.hashSizeBytes {
+			return fmt.Errorf("sequenced leaf has incorrect hash size")
+		}
+		if err := leaf.IntegrateTimestamp.CheckValid(); err != nil {
+			return fmt.Errorf("got invalid integrate timestamp: %w", err)
+		}
+		k, ok := t.dequeued[string(leaf.LeafIdentityHash)]
+		if !ok {
+			return fmt.Errorf("attempting to update leaf that wasn't dequeued. IdentityHash: %x", leaf.LeafIdentityHash)
+		}
+		if inserted, err := t.putSequencedLeaf(leaf, leaf.IntegrateTimestamp); err != nil {
+			glog.Warningf("Failed to update sequenced leaves: %s", err)
+			return err
+		} else if !inserted {
+			return status.Errorf(codes.FailedPrecondition, "leaf index %d is taken", leaf.LeafIndex)
+		}
+		if err := queue.Delete(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *logTreeTX) AddSequencedLeaves(ctx context.Context, leaves []*trillian.LogLeaf, timestamp time.Time) ([]*trillian.QueuedLogLeaf, error) {
+	t.treeTX.mu.Lock()
+	defer t.treeTX.mu.Unlock()
+
+	res := make([]*trillian.QueuedLogLeaf, len(leaves))
+	ok := status.New(codes.OK, "OK").Proto()
+	queueTimestamp := timestamppb.New(timestamp)
+	// Like in the SQL storages, the leaves are integrated at time zero.
+	integrateTimestamp := timestamppb.New(time.Unix(0, 0))
+
+	for i, leaf := range leaves {
+		// This should fail on insert, but catch it early.
+		if got, want := len(leaf.LeafIdentityHash), t.hashSizeBytes; got != want {
+			return nil, status.Errorf(codes.FailedPrecondition, "leaves[%d] has incorrect hash size %d, want %d", i, got, want)
+		}
+
+		res[i] = &trillian.QueuedLogLeaf{Status: ok}
+
+		// Check the index first, so that a conflicting leaf leaves no data.
+		if existing, err := t.getSequencedLeaf(leaf.LeafIndex); err != nil {
+			return nil, err
+		} else if existing != nil {
+			res[i].Status = status.New(codes.FailedPrecondition, "conflicting LeafIndex").Proto()
+			continue
+		}
+		inserted, err := t.putLeafData(leaf, queueTimestamp)
+		if err != nil {
+			glog.Errorf("Error inserting leaves[%d] into LeafData: %s", i, err)
+			return nil, err
+		}
+		if !inserted {
+			res[i].Status = status.New(codes.FailedPrecondition, "conflicting LeafIdentityHash").Proto()
+			continue
+		}
+		if _, err := t.putSequencedLeaf(leaf, integrateTimestamp); err != nil {
+			glog.Errorf("Error inserting leaves[%d] into SequencedLeafData: %s", i, err)
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+func (t *logTreeTX) GetLeavesByRange(ctx context.Context, start, count int64) ([]*trillian.LogLeaf, error) {
+	t.treeTX.mu.Lock()
+	defer t.treeTX.mu.Unlock()
+	return t.getLeavesByRangeInternal(start, count)
+}
+
+func (t *logTreeTX) getLeavesByRangeInternal(start, count int64) ([]*trillian.LogLeaf, error) {
+	if count <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid count %d, want > 0", count)
+	}
+	if start < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid start %d, want >= 0", start)
+	}
+
+	if t.treeType == trillian.TreeType_LOG {
+		treeSize := int64(t.root.TreeSize)
+		if treeSize <= 0 {
+			return nil, status.Errorf(codes.OutOfRange, "empty tree")
+		} else if start >= treeSize {
+			return nil, status.Errorf(codes.OutOfRange, "invalid start %d, want < TreeSize(%d)", start, treeSize)
+		}
+		// Ensure no entries queried/returned beyond the tree.
+		if maxCount := treeSize - start; count > maxCount {
+			count = maxCount
+		}
+	}
+
+	ret := make([]*trillian.LogLeaf, 0, count)
+	for index := start; index < start+count; index++ {
+		leaf, err := t.getSequencedLeaf(index)
+		if err != nil {
+			return nil, err
+		}
+		if leaf == nil {
+			if index < int64(t.root.TreeSize) {
+				return nil, fmt.Errorf("missing leaf %d of tree of size %d", index, t.root.TreeSize)
+			}
+			break
+		}
+		ret = append(ret, leaf)
+	}
+	return ret, nil
+}
+
+func (t *logTreeTX) GetLeavesByHash(ctx context.Context, leafHashes [][]byte, orderBySequence bool) ([]*trillian.LogLeaf, error) {
+	t.treeTX.mu.Lock()
+	defer t.treeTX.mu.Unlock()
+
+	var ret []*trillian.LogLeaf
+	c := t.bucket.Bucket(merkleHashIndexBucket).Cursor()
+	for _, hash := range leafHashes {
+		// The tree could include duplicates, stored under the same prefix.
+		for k, _ := c.Seek(hash); k != nil && bytes.HasPrefix(k, hash); k, _ = c.Next() {
+			if len(k) != len(hash)+8 {
+				continue
+			}
+			index := keyInt64(k[len(hash):])
+			leaf, err := t.getSequencedLeaf(index)
+			if err != nil {
+				return nil, err
+			} else if leaf == nil {
+				return nil, fmt.Errorf("no leaf %d for Merkle leaf hash %x", index, hash)
+			}
+			ret = append(ret, leaf)
+		}
+	}
+	if orderBySequence {
+		sort.Slice(ret, func(i, j int) bool { return ret[i].LeafIndex < ret[j].LeafIndex })
+	}
+	return ret, nil
+}
+
+func (t *logTreeTX) LatestSignedLogRoot(ctx context.Context) (*trillian.SignedLogRoot, error) {
+	t.treeTX.mu.Lock()
+	defer t.treeTX.mu.Unlock()
+
+	if t.slr == nil {
+		return nil, storage.ErrTreeNeedsInit
+	}
+
+	return t.slr, nil
+}
+
+// fetchLatestRoot reads the latest root and its revision.
+func (t *logTreeTX) fetchLatestRoot() (*trillian.SignedLogRoot, int64, error) {
+	k, v := t.bucket.Bucket(treeHeadBucket).Cursor().Last()
+	if k == nil {
+		// It's possible there are no roots for this tree yet
+		return nil, 0, storage.ErrTreeNeedsInit
+	}
+	slr := &trillian.SignedLogRoot{}
+	if err := proto.Unmarshal(v, slr); err != nil {
+		return nil, 0, fmt.Errorf("failed to unmarshal signed log root: %v", err)
+	}
+	return slr, keyInt64(k), nil
+}
+
+func (t *logTreeTX) StoreSignedLogRoot(ctx context.Context, root *trillian.SignedLogRoot) error {
+	t.treeTX.mu.Lock()
+	defer t.treeTX.mu.Unlock()
+
+	var logRoot types.LogRootV1
+	if err := logRoot.UnmarshalBinary(root.LogRoot); err != nil {
+		glog.Warningf("Failed to parse log root: %x %v", root.LogRoot, err)
+		return err
+	}
+	b := t.bucket.Bucket(treeHeadBucket)
+	k := int64Key(t.treeTX.writeRevision)
+	if b.Get(k) != nil {
+		return status.Errorf(codes.FailedPrecondition, "tree %d already has a root at revision %d", t.treeID, t.treeTX.writeRevision)
+	}
+	v, err := proto.Marshal(root)
+	if err != nil {
+		return err
+	}
+	if err := b.Put(k, v); err != nil {
+		glog.Warningf("Failed to store signed root: %s", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,220 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bbolt
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/trillian"
+	"github.com/google/trillian/integration/storagetest"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/storage/testonly"
+	"github.com/google/trillian/storage/tree"
+	"github.com/google/trillian/types"
+	"github.com/transparency-dev/merkle/compact"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestLogSuite(t *testing.T) {
+	storageFactory := func(_ context.Context, t *testing.T) (storage.LogStorage, storage.AdminStorage) {
+		db := openTestDB(t)
+		return NewLogStorage(db, nil), NewAdminStorage(db)
+	}
+
+	storagetest.RunLogStorageTests(t, storageFactory)
+}
+
+func mustCreateTree(ctx context.Context, t *testing.T, s storage.AdminStorage, tree *trillian.Tree) *trillian.Tree {
+	t.Helper()
+	tree, err := storage.CreateTree(ctx, s, tree)
+	if err != nil {
+		t.Fatalf("storage.CreateTree(): %v", err)
+	}
+	return tree
+}
+
+func storeLogRoot(ctx context.Context, tx storage.LogTreeTX, size uint64, hash []byte) error {
+	logRoot, err := (&types.LogRootV1{TreeSize: size, RootHash: hash}).MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("error marshaling new LogRoot: %v", err)
+	}
+	return tx.StoreSignedLogRoot(ctx, &trillian.SignedLogRoot{LogRoot: logRoot})
+}
+
+func runLogTX(s storage.LogStorage, tree *trillian.Tree, t *testing.T, f storage.LogTXFunc) {
+	t.Helper()
+	if err := s.ReadWriteTransaction(context.Background(), tree, f); err != nil {
+		t.Fatalf("Failed to run log tx: %v", err)
+	}
+}
+
+func createTestLeaves(n, startSeq int64) []*trillian.LogLeaf {
+	var leaves []*trillian.LogLeaf
+	for l := int64(0); l < n; l++ {
+		lv := fmt.Sprintf("Leaf %d", l+startSeq)
+		leafHash := sha256.Sum256([]byte(lv))
+		leaves = append(leaves, &trillian.LogLeaf{
+			LeafIdentityHash: leafHash[:],
+			MerkleLeafHash:   leafHash[:],
+			LeafValue:        []byte(lv),
+			ExtraData:        []byte(fmt.Sprintf("Extra %d", l)),
+			LeafIndex:        startSeq + l,
+		})
+	}
+	return leaves
+}
+
+// leafNodes returns nodes for the leaves [0, size), with hashes depending on
+// the revision.
+func leafNodes(size int, rev int64) []tree.Node {
+	nodes := make([]tree.Node, 0, size)
+	for i := 0; i < size; i++ {
+		hash := sha256.Sum256([]byte(fmt.Sprintf("%d-%d", rev, i)))
+		nodes = append(nodes, tree.Node{ID: compact.NewNodeID(0, uint64(i)), Hash: hash[:]})
+	}
+	return nodes
+}
+
+func TestSequenceAndReadLeaves(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	tree := mustCreateTree(ctx, t, NewAdminStorage(db), testonly.LogTree)
+	s := NewLogStorage(db, nil)
+	runLogTX(s, tree, t, func(ctx context.Context, tx storage.LogTreeTX) error {
+		return storeLogRoot(ctx, tx, 0, []byte{0})
+	})
+
+	leaves := createTestLeaves(5, 0)
+	queueTime := time.Unix(1000, 0)
+	if _, err := s.QueueLeaves(ctx, tree, leaves, queueTime); err != nil {
+		t.Fatalf("QueueLeaves(): %v", err)
+	}
+	// Queueing the same leaves again returns them as existing leaves.
+	queued, err := s.QueueLeaves(ctx, tree, leaves[:2], queueTime)
+	if err != nil {
+		t.Fatalf("QueueLeaves(): %v", err)
+	}
+	for i, q := range queued {
+		if q.Status == nil {
+			t.Errorf("QueueLeaves()[%d].Status = nil, want AlreadyExists", i)
+		}
+	}
+
+	integrateTime := timestamppb.New(time.Unix(2000, 0))
+	runLogTX(s, tree, t, func(ctx context.Context, tx storage.LogTreeTX) error {
+		dequeued, err := tx.DequeueLeaves(ctx, 10, queueTime)
+		if err != nil {
+			t.Fatalf("DequeueLeaves(): %v", err)
+		}
+		if got, want := len(dequeued), len(leaves); got != want {
+			t.Fatalf("DequeueLeaves() returned %d leaves, want %d", got, want)
+		}
+		for i, leaf := range dequeued {
+			leaf.LeafIndex = int64(i)
+			leaf.IntegrateTimestamp = integrateTime
+		}
+		if err := tx.UpdateSequencedLeaves(ctx, dequeued); err != nil {
+			t.Fatalf("UpdateSequencedLeaves(): %v", err)
+		}
+		return storeLogRoot(ctx, tx, uint64(len(dequeued)), []byte{1})
+	})
+
+	tx, err := s.SnapshotForTree(ctx, tree)
+	if err != nil {
+		t.Fatalf("SnapshotForTree(): %v", err)
+	}
+	defer tx.Close()
+	got, err := tx.GetLeavesByRange(ctx, 0, 10)
+	if err != nil {
+		t.Fatalf("GetLeavesByRange(): %v", err)
+	}
+	if len(got) != len(leaves) {
+		t.Fatalf("GetLeavesByRange() returned %d leaves, want %d", len(got), len(leaves))
+	}
+	for _, leaf := range got {
+		if leaf.IntegrateTimestamp.AsTime() != integrateTime.AsTime() {
+			t.Errorf("leaf %d has IntegrateTimestamp %v, want %v", leaf.LeafIndex, leaf.IntegrateTimestamp.AsTime(), integrateTime.AsTime())
+		}
+		if leaf.QueueTimestamp.AsTime() != queueTime.UTC() {
+			t.Errorf("leaf %d has QueueTimestamp %v, want %v", leaf.LeafIndex, leaf.QueueTimestamp.AsTime(), queueTime.UTC())
+		}
+	}
+
+	byHash, err := tx.GetLeavesByHash(ctx, [][]byte{got[3].MerkleLeafHash, got[1].MerkleLeafHash}, true)
+	if err != nil {
+		t.Fatalf("GetLeavesByHash(): %v", err)
+	}
+	if diff := cmp.Diff([]*trillian.LogLeaf{got[1], got[3]}, byHash, protocmp.Transform()); diff != "" {
+		t.Errorf("GetLeavesByHash() diff (-want +got):\n%s", diff)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		t.Fatalf("Commit(): %v", err)
+	}
+}
+
+func TestMerkleNodesRevisions(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "trillian.db")
+	db, err := OpenDB(path)
+	if err != nil {
+		t.Fatalf("OpenDB(): %v", err)
+	}
+	tree := mustCreateTree(ctx, t, NewAdminStorage(db), testonly.LogTree)
+	s := NewLogStorage(db, nil)
+
+	const size = 300 // Crosses a tile boundary.
+	for rev := int64(0); rev < 2; rev++ {
+		rev := rev
+		runLogTX(s, tree, t, func(ctx context.Context, tx storage.LogTreeTX) error {
+			if err := tx.SetMerkleNodes(ctx, leafNodes(size, rev)); err != nil {
+				t.Fatalf("SetMerkleNodes(): %v", err)
+			}
+			return storeLogRoot(ctx, tx, size, []byte{byte(rev)})
+		})
+	}
+
+	// The nodes are read at the latest revision, also after reopening the
+	// database.
+	if err := db.Close(); err != nil {
+		t.Fatalf("Close(): %v", err)
+	}
+	db = openDBAt(t, path)
+	s = NewLogStorage(db, nil)
+
+	want := leafNodes(size, 1)
+	ids := make([]compact.NodeID, 0, len(want))
+	for _, n := range want {
+		ids = append(ids, n.ID)
+	}
+	tx, err := s.SnapshotForTree(ctx, tree)
+	if err != nil {
+		t.Fatalf("SnapshotForTree(): %v", err)
+	}
+	defer tx.Close()
+	got, err := tx.GetMerkleNodes(ctx, ids)
+	if err != nil {
+		t.Fatalf("GetMerkleNodes(): %v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GetMerkleNodes() diff (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bbolt
+
+import (
+	"errors"
+	"flag"
+	"sync"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian/monitoring"
+	"github.com/google/trillian/storage"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	boltPath = flag.String("bbolt_path", "", "Path of the file holding the bbolt database, which is created if it doesn't exist")
+
+	boltMu              sync.Mutex
+	boltErr             error
+	boltDB              *bolt.DB
+	boltStorageInstance *boltProvider
+)
+
+func init() {
+	if err := storage.RegisterProvider("bbolt", newBoltStorageProvider); err != nil {
+		glog.Fatalf("Failed to register storage provider bbolt: %v", err)
+	}
+}
+
+type boltProvider struct {
+	db *bolt.DB
+	mf monitoring.MetricFactory
+}
+
+func newBoltStorageProvider(mf monitoring.MetricFactory) (storage.Provider, error) {
+	boltMu.Lock()
+	defer boltMu.Unlock()
+	if boltStorageInstance == nil {
+		db, err := getBoltDatabaseLocked()
+		if err != nil {
+			return nil, err
+		}
+		boltStorageInstance = &boltProvider{
+			db: db,
+			mf: mf,
+		}
+	}
+	return boltStorageInstance, nil
+}
+
+// getBoltDatabaseLocked returns the bbolt database, opening it if needed.
+// Requires boltMu to be locked.
+func getBoltDatabaseLocked() (*bolt.DB, error) {
+	if boltDB != nil || boltErr != nil {
+		return boltDB, boltErr
+	}
+	if *boltPath == "" {
+		boltErr = errors.New("--bbolt_path must be set")
+		return nil, boltErr
+	}
+	db, err := OpenDB(*boltPath)
+	if err != nil {
+		boltErr = err
+		return nil, err
+	}
+	boltDB, boltErr = db, nil
+	return db, nil
+}
+
+func (s *boltProvider) LogStorage() storage.LogStorage {
+	return NewLogStorage(s.db, s.mf)
+}
+
+func (s *boltProvider) AdminStorage() storage.AdminStorage {
+	return NewAdminStorage(s.db)
+}
+
+func (s *boltProvider) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,126 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bbolt provides a storage layer implementation which keeps all data
+// in a single local file, using the embedded bbolt key/value store.
+//
+// It is meant for single-node deployments without a database server, e.g. on
+// the edge or in air-gapped environments. The file, see --bbolt_path, can only
+// be opened by one process at a time, so it can't be shared by separate log
+// server and signer processes. bbolt serializes all writes, which limits the
+// throughput of the storage.
+//
+// The data of each tree is stored in a bucket of the Logs bucket, keyed by the
+// tree ID, with the following nested buckets:
+//   - LeafData: leaves by their identity hash.
+//   - Unsequenced: the queue of leaves waiting for integration, keyed by their
+//     queue timestamp and identity hash.
+//   - SequencedLeafData: integrated leaves by their index.
+//   - MerkleHashIndex: the indices of the leaves by their Merkle leaf hash.
+//   - Subtree: the tiles of the Merkle tree by their ID and revision.
+//   - TreeHead: the signed log roots by their revision.
+package bbolt
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/golang/glog"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	treesBucket = []byte("Trees")
+	logsBucket  = []byte("Logs")
+
+	leafDataBucket          = []byte("LeafData")
+	unsequencedBucket       = []byte("Unsequenced")
+	sequencedLeafDataBucket = []byte("SequencedLeafData")
+	merkleHashIndexBucket   = []byte("MerkleHashIndex")
+	subtreeBucket           = []byte("Subtree")
+	treeHeadBucket          = []byte("TreeHead")
+
+	logBuckets = [][]byte{
+		leafDataBucket,
+		unsequencedBucket,
+		sequencedLeafDataBucket,
+		merkleHashIndexBucket,
+		subtreeBucket,
+		treeHeadBucket,
+	}
+)
+
+// OpenDB opens the bbolt database in the file at path, creating it if it
+// doesn't exist. It fails if another process has the file open.
+func OpenDB(path string) (*bolt.DB, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		glog.Warningf("Could not open bbolt database %q: %s", path, err)
+		return nil, err
+	}
+	if err := db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{treesBucket, logsBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create buckets: %v", err)
+	}
+	return db, nil
+}
+
+// createLogBucket creates the bucket holding the data of the tree, if it
+// doesn't exist yet, and returns it.
+func createLogBucket(tx *bolt.Tx, treeID int64) (*bolt.Bucket, error) {
+	b, err := tx.Bucket(logsBucket).CreateBucketIfNotExists(int64Key(treeID))
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range logBuckets {
+		if _, err := b.CreateBucketIfNotExists(name); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// int64Key returns the big-endian encoding of i, which orders keys like the
+// non-negative numbers they encode.
+func int64Key(i int64) []byte {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], uint64(i))
+	return b[:]
+}
+
+// keyInt64 decodes a key encoded by int64Key.
+func keyInt64(k []byte) int64 {
+	return int64(binary.BigEndian.Uint64(k))
+}
+
+// concat returns a new slice holding the concatenation of parts.
+func concat(parts ...[]byte) []byte {
+	n := 0
+	for _, p := range parts {
+		n += len(p)
+	}
+	ret := make([]byte, 0, n)
+	for _, p := range parts {
+		ret = append(ret, p...)
+	}
+	return ret
+}
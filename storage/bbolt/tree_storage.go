@@ -0,0 +1,197 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bbolt
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian"
+	"github.com/google/trillian/storage/cache"
+	"github.com/google/trillian/storage/storagepb"
+	"github.com/google/trillian/storage/tree"
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// beginTreeTX starts a transaction on the data of the tree. Read-only
+// transactions fail if the tree has no bucket, writable ones create it.
+func beginTreeTX(db *bolt.DB, tree *trillian.Tree, writable bool, hashSizeBytes int, subtreeCache *cache.SubtreeCache) (treeTX, error) {
+	tx, err := db.Begin(writable)
+	if err != nil {
+		glog.Warningf("Could not start tree TX: %s", err)
+		return treeTX{}, err
+	}
+	var b *bolt.Bucket
+	if writable {
+		b, err = createLogBucket(tx, tree.TreeId)
+	} else if b = tx.Bucket(logsBucket).Bucket(int64Key(tree.TreeId)); b == nil {
+		err = status.Errorf(codes.NotFound, "tree %v not found", tree.TreeId)
+	}
+	if err != nil {
+		tx.Rollback()
+		return treeTX{}, err
+	}
+	return treeTX{
+		tx:            tx,
+		bucket:        b,
+		mu:            &sync.Mutex{},
+		treeID:        tree.TreeId,
+		treeType:      tree.TreeType,
+		hashSizeBytes: hashSizeBytes,
+		subtreeCache:  subtreeCache,
+		writeRevision: -1,
+	}, nil
+}
+
+type treeTX struct {
+	// mu ensures that tx can only be used for one query/exec at a time.
+	mu     *sync.Mutex
+	closed bool
+	tx     *bolt.Tx
+	// bucket holds the data of the tree.
+	bucket        *bolt.Bucket
+	treeID        int64
+	treeType      trillian.TreeType
+	hashSizeBytes int
+	subtreeCache  *cache.SubtreeCache
+	writeRevision int64
+}
+
+// subtreePrefix returns the prefix of the Subtree keys of the subtree with the
+// given ID. The length of the ID comes first, so that the keys of an ID don't
+// share a prefix with the keys of a longer one.
+func subtreePrefix(id []byte) []byte {
+	return concat([]byte{byte(len(id))}, id)
+}
+
+// getSubtrees returns the latest revisions of the subtrees which are not newer
+// than treeRevision.
+func (t *treeTX) getSubtrees(ctx context.Context, treeRevision int64, ids [][]byte) ([]*storagepb.SubtreeProto, error) {
+	glog.V(2).Infof("getSubtrees(len(ids)=%d)", len(ids))
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	c := t.bucket.Bucket(subtreeBucket).Cursor()
+	ret := make([]*storagepb.SubtreeProto, 0, len(ids))
+	for _, id := range ids {
+		prefix := subtreePrefix(id)
+		seek := concat(prefix, int64Key(treeRevision))
+		// Find the last key which is not greater than seek.
+		k, v := c.Seek(seek)
+		if k == nil {
+			k, v = c.Last()
+		} else if !bytes.Equal(k, seek) {
+			k, v = c.Prev()
+		}
+		if len(k) != len(seek) || !bytes.HasPrefix(k, prefix) {
+			continue
+		}
+		var subtree storagepb.SubtreeProto
+		if err := proto.Unmarshal(v, &subtree); err != nil {
+			glog.Warningf("Failed to unmarshal SubtreeProto: %s", err)
+			return nil, err
+		}
+		if subtree.Prefix == nil {
+			subtree.Prefix = []byte{}
+		}
+		ret = append(ret, &subtree)
+	}
+
+	// The InternalNodes cache is possibly nil here, but the SubtreeCache (which called
+	// this method) will re-populate it.
+	return ret, nil
+}
+
+func (t *treeTX) storeSubtrees(ctx context.Context, subtrees []*storagepb.SubtreeProto) error {
+	glog.V(2).Infof("storeSubtrees(len(subtrees)=%d)", len(subtrees))
+	b := t.bucket.Bucket(subtreeBucket)
+	for _, s := range subtrees {
+		if s.Prefix == nil {
+			panic(fmt.Errorf("nil prefix on %v", s))
+		}
+		subtreeBytes, err := proto.Marshal(s)
+		if err != nil {
+			return err
+		}
+		if err := b.Put(concat(subtreePrefix(s.Prefix), int64Key(t.writeRevision)), subtreeBytes); err != nil {
+			glog.Warningf("Failed to set merkle subtrees: %s", err)
+			return err
+		}
+	}
+	return nil
+}
+
+// getSubtreesAtRev returns a GetSubtreesFunc which reads at the passed in rev.
+func (t *treeTX) getSubtreesAtRev(ctx context.Context, rev int64) cache.GetSubtreesFunc {
+	return func(ids [][]byte) ([]*storagepb.SubtreeProto, error) {
+		return t.getSubtrees(ctx, rev, ids)
+	}
+}
+
+func (t *treeTX) SetMerkleNodes(ctx context.Context, nodes []tree.Node) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	rev := t.writeRevision - 1
+	return t.subtreeCache.SetNodes(nodes, t.getSubtreesAtRev(ctx, rev))
+}
+
+func (t *treeTX) Commit(ctx context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.tx.Writable() {
+		// Read-only bbolt transactions can only be rolled back.
+		t.closed = true
+		return t.tx.Rollback()
+	}
+	if t.writeRevision > -1 {
+		tiles, err := t.subtreeCache.UpdatedTiles()
+		if err != nil {
+			glog.Warningf("SubtreeCache updated tiles error: %v", err)
+			return err
+		}
+		if err := t.storeSubtrees(ctx, tiles); err != nil {
+			glog.Warningf("TX commit flush error: %v", err)
+			return err
+		}
+	}
+	t.closed = true
+	if err := t.tx.Commit(); err != nil {
+		glog.Warningf("TX commit error: %s", err)
+		return err
+	}
+	return nil
+}
+
+func (t *treeTX) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return nil
+	}
+	t.closed = true
+	if err := t.tx.Rollback(); err != nil {
+		glog.Warningf("Rollback error on Close(): %v", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,203 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"container/list"
+	"strconv"
+	"sync"
+
+	"github.com/google/trillian/monitoring"
+	"github.com/google/trillian/storage/storagepb"
+	"google.golang.org/protobuf/proto"
+)
+
+const logIDLabel = "logid"
+
+var (
+	sharedMetricsOnce      sync.Once
+	sharedTileHits         monitoring.Counter
+	sharedTileMisses       monitoring.Counter
+	sharedTileEvictions    monitoring.Counter
+	sharedTileCachedBytes  monitoring.Gauge
+	sharedTileInvalidation monitoring.Counter
+)
+
+func initSharedMetrics(mf monitoring.MetricFactory) {
+	if mf == nil {
+		mf = monitoring.InertMetricFactory{}
+	}
+	sharedTileHits = mf.NewCounter("shared_subtree_cache_hits", "Number of subtrees read from the shared subtree cache", logIDLabel)
+	sharedTileMisses = mf.NewCounter("shared_subtree_cache_misses", "Number of subtrees read from storage as they weren't in the shared subtree cache", logIDLabel)
+	sharedTileEvictions = mf.NewCounter("shared_subtree_cache_evictions", "Number of subtrees evicted from the shared subtree cache to stay within its size limit")
+	sharedTileCachedBytes = mf.NewGauge("shared_subtree_cache_bytes", "Total size of the subtrees in the shared subtree cache")
+	sharedTileInvalidation = mf.NewCounter("shared_subtree_cache_invalidations", "Number of times the subtrees of a tree were dropped from the shared subtree cache as a newer revision was read", logIDLabel)
+}
+
+// SharedTileCache is an LRU cache of the subtrees read from storage, shared by
+// the transactions of all trees, so that e.g. the top subtrees which most
+// proofs need aren't read again for every request. Subtrees are keyed by tree,
+// revision and subtree ID, and only the latest revision read of each tree is
+// kept: reading a newer one, i.e. after a new root was stored, drops those of
+// the older ones.
+//
+// Unlike SubtreeCache, SharedTileCache is thread-safe.
+type SharedTileCache struct {
+	maxBytes int64
+
+	mu    sync.Mutex
+	bytes int64
+	// lru holds the *sharedTile entries, the most recently used first.
+	lru *list.List
+	// trees holds the latest revision read of each tree, and the entries of
+	// its cached subtrees at that revision.
+	trees map[int64]*sharedTree
+}
+
+type sharedTree struct {
+	rev   int64
+	tiles map[string]*list.Element
+}
+
+type sharedTile struct {
+	treeID int64
+	id     string
+	tile   *storagepb.SubtreeProto
+	size   int64
+}
+
+// NewSharedTileCache returns a cache of subtrees whose serialized sizes add up
+// to at most maxBytes, or nil if maxBytes is not positive. A nil cache reads
+// all subtrees from storage.
+func NewSharedTileCache(maxBytes int64, mf monitoring.MetricFactory) *SharedTileCache {
+	sharedMetricsOnce.Do(func() { initSharedMetrics(mf) })
+	if maxBytes <= 0 {
+		return nil
+	}
+	return &SharedTileCache{
+		maxBytes: maxBytes,
+		lru:      list.New(),
+		trees:    make(map[int64]*sharedTree),
+	}
+}
+
+// Wrap returns a GetSubtreesFunc which returns the subtrees of the tree at
+// the revision from the cache, and reads the others with getSubtrees and adds
+// them to it. The subtrees must not depend on the transaction reading them,
+// e.g. be read at exactly the revision, rather than at the latest one which
+// the transaction sees. The returned subtrees are copies, so callers may
+// modify them.
+func (c *SharedTileCache) Wrap(treeID, rev int64, getSubtrees GetSubtreesFunc) GetSubtreesFunc {
+	if c == nil {
+		return getSubtrees
+	}
+	return func(ids [][]byte) ([]*storagepb.SubtreeProto, error) {
+		label := strconv.FormatInt(treeID, 10)
+		ret := make([]*storagepb.SubtreeProto, 0, len(ids))
+		var missing [][]byte
+		for _, id := range ids {
+			if tile := c.get(treeID, rev, string(id)); tile != nil {
+				ret = append(ret, tile)
+			} else {
+				missing = append(missing, id)
+			}
+		}
+		sharedTileHits.Add(float64(len(ret)), label)
+		if len(missing) == 0 {
+			return ret, nil
+		}
+		sharedTileMisses.Add(float64(len(missing)), label)
+		tiles, err := getSubtrees(missing)
+		if err != nil {
+			return nil, err
+		}
+		for _, tile := range tiles {
+			c.put(treeID, rev, tile)
+		}
+		return append(ret, tiles...), nil
+	}
+}
+
+// get returns a copy of the cached subtree, or nil if it isn't cached.
+func (c *SharedTileCache) get(treeID, rev int64, id string) *storagepb.SubtreeProto {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := c.treeLocked(treeID, rev)
+	if t == nil {
+		return nil
+	}
+	e, ok := t.tiles[id]
+	if !ok {
+		return nil
+	}
+	c.lru.MoveToFront(e)
+	return proto.Clone(e.Value.(*sharedTile).tile).(*storagepb.SubtreeProto)
+}
+
+// put adds a copy of the subtree to the cache, unless a newer revision of the
+// tree was read, and evicts the least recently used subtrees beyond the size
+// limit.
+func (c *SharedTileCache) put(treeID, rev int64, tile *storagepb.SubtreeProto) {
+	size := int64(proto.Size(tile))
+	if size > c.maxBytes {
+		return
+	}
+	tile = proto.Clone(tile).(*storagepb.SubtreeProto)
+	id := string(tile.Prefix)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := c.treeLocked(treeID, rev)
+	if t == nil {
+		return
+	}
+	if _, ok := t.tiles[id]; ok {
+		return
+	}
+	t.tiles[id] = c.lru.PushFront(&sharedTile{treeID: treeID, id: id, tile: tile, size: size})
+	c.bytes += size
+	for c.bytes > c.maxBytes {
+		c.removeLocked(c.lru.Back())
+		sharedTileEvictions.Inc()
+	}
+	sharedTileCachedBytes.Set(float64(c.bytes))
+}
+
+// treeLocked returns the cached subtrees of the tree at the revision, or nil
+// if a newer revision was read before. Reading a newer revision than before
+// drops the subtrees of the older one.
+func (c *SharedTileCache) treeLocked(treeID, rev int64) *sharedTree {
+	t, ok := c.trees[treeID]
+	switch {
+	case ok && rev < t.rev:
+		return nil
+	case ok && rev > t.rev:
+		for _, e := range t.tiles {
+			c.removeLocked(e)
+		}
+		sharedTileInvalidation.Inc(strconv.FormatInt(treeID, 10))
+		sharedTileCachedBytes.Set(float64(c.bytes))
+		fallthrough
+	case !ok:
+		t = &sharedTree{rev: rev, tiles: make(map[string]*list.Element)}
+		c.trees[treeID] = t
+	}
+	return t
+}
+
+func (c *SharedTileCache) removeLocked(e *list.Element) {
+	st := c.lru.Remove(e).(*sharedTile)
+	delete(c.trees[st.treeID].tiles, st.id)
+	c.bytes -= st.size
+}
@@ -0,0 +1,118 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/trillian/storage/storagepb"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeTileStorage returns a tile for every requested ID whose leaves record
+// the revision they were read at, and counts the tiles read.
+type fakeTileStorage struct {
+	reads int
+}
+
+func (s *fakeTileStorage) get(rev int64) GetSubtreesFunc {
+	return func(ids [][]byte) ([]*storagepb.SubtreeProto, error) {
+		s.reads += len(ids)
+		ret := make([]*storagepb.SubtreeProto, 0, len(ids))
+		for _, id := range ids {
+			ret = append(ret, &storagepb.SubtreeProto{
+				Prefix: id,
+				Depth:  8,
+				Leaves: map[string][]byte{"rev": []byte(fmt.Sprint(rev))},
+			})
+		}
+		return ret, nil
+	}
+}
+
+func TestSharedTileCache(t *testing.T) {
+	ids := [][]byte{{}, {1}, {1, 2}}
+	// read reads the tiles of tree 1 at rev through the cache, and checks how
+	// many were read from storage.
+	read := func(c *SharedTileCache, s *fakeTileStorage, rev int64, wantReads int) []*storagepb.SubtreeProto {
+		t.Helper()
+		before := s.reads
+		tiles, err := c.Wrap(1, rev, s.get(rev))(ids)
+		if err != nil {
+			t.Fatalf("GetSubtreesFunc(): %v", err)
+		}
+		if len(tiles) != len(ids) {
+			t.Fatalf("GetSubtreesFunc() returned %d tiles, want %d", len(tiles), len(ids))
+		}
+		for _, tile := range tiles {
+			if got, want := string(tile.Leaves["rev"]), fmt.Sprint(rev); got != want {
+				t.Errorf("tile %x of revision %s, want %s", tile.Prefix, got, want)
+			}
+		}
+		if got := s.reads - before; got != wantReads {
+			t.Errorf("read %d tiles from storage, want %d", got, wantReads)
+		}
+		return tiles
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		c, s := NewSharedTileCache(0, nil), &fakeTileStorage{}
+		if c != nil {
+			t.Fatal("NewSharedTileCache(0) returned a cache, want nil")
+		}
+		read(c, s, 1, 3)
+		read(c, s, 1, 3)
+	})
+
+	t.Run("revisions", func(t *testing.T) {
+		c, s := NewSharedTileCache(1<<20, nil), &fakeTileStorage{}
+		tiles := read(c, s, 1, 3)
+		// The returned tiles are copies.
+		tiles[0].Leaves["rev"] = []byte("changed")
+		read(c, s, 1, 0)
+		// Another tree doesn't share the tiles.
+		if _, err := c.Wrap(2, 1, s.get(1))(ids); err != nil {
+			t.Fatalf("GetSubtreesFunc(): %v", err)
+		}
+		read(c, s, 1, 0)
+		// A new revision drops the tiles of the old one, which are then read
+		// from storage without being cached.
+		read(c, s, 2, 3)
+		read(c, s, 2, 0)
+		read(c, s, 1, 3)
+		read(c, s, 1, 3)
+		read(c, s, 2, 0)
+	})
+
+	t.Run("sizeLimit", func(t *testing.T) {
+		s := &fakeTileStorage{}
+		tiles, err := s.get(1)(ids)
+		if err != nil {
+			t.Fatalf("GetSubtreesFunc(): %v", err)
+		}
+		// The cache holds two of the tiles.
+		size := proto.Size(tiles[1]) + proto.Size(tiles[2])
+		c := NewSharedTileCache(int64(size), nil)
+		read(c, s, 1, 3)
+		// The first tile was evicted, and reading it again evicts the least
+		// recently used one of the others.
+		read(c, s, 1, 1)
+		read(c, s, 1, 1)
+		if got, want := c.bytes, int64(size); got > want {
+			t.Errorf("cache holds %d bytes, want at most %d", got, want)
+		}
+	})
+}
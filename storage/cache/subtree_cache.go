@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/google/trillian/storage"
 	"github.com/google/trillian/storage/storagepb"
 	"github.com/google/trillian/storage/tree"
 	"github.com/transparency-dev/merkle"
@@ -157,13 +158,13 @@ func (s *SubtreeCache) GetNodes(ids []compact.NodeID, getSubtrees GetSubtreesFun
 	if notFound, err := s.preload(ids, getSubtrees); err != nil {
 		return nil, err
 	} else if r := len(notFound); r != 0 {
-		return nil, fmt.Errorf("preload did not get all tiles: %d not found", r)
+		return nil, fmt.Errorf("%w: preload did not get all tiles: %d not found", storage.ErrMissingNodes, r)
 	}
 
 	ret := make([]tree.Node, 0, len(ids))
 	for _, id := range ids {
 		if h, err := s.getNodeHash(id); err != nil {
-			return nil, fmt.Errorf("getNodeHash(%+v): %v", id, err)
+			return nil, fmt.Errorf("getNodeHash(%+v): %w", id, err)
 		} else if h != nil {
 			ret = append(ret, tree.Node{ID: id, Hash: h})
 		}
@@ -176,7 +177,7 @@ func (s *SubtreeCache) getNodeHash(id compact.NodeID) ([]byte, error) {
 	subID, sx := splitID(id)
 	c := s.subtrees[string(subID)]
 	if c == nil {
-		return nil, fmt.Errorf("tile %x not found", subID)
+		return nil, fmt.Errorf("%w: tile %x not found", storage.ErrMissingNodes, subID)
 	}
 
 	// Look up the hash in the appropriate map.
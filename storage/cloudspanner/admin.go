@@ -267,8 +267,11 @@ func (t *adminTX) CreateTree(ctx context.Context, tree *trillian.Tree) (*trillia
 	if err := storage.ValidateTreeForCreation(ctx, tree); err != nil {
 		return nil, err
 	}
+	if err := checkUnsupportedFields(tree); err != nil {
+		return nil, err
+	}
 
-	id, err := storage.NewTreeID()
+	id, err := storage.TreeIDForCreation(tree)
 	if err != nil {
 		return nil, err
 	}
@@ -336,6 +339,7 @@ func newTreeInfo(tree *trillian.Tree, treeID int64, now time.Time) (*spannerpb.T
 		CreateTimeNanos:       now.UnixNano(),
 		UpdateTimeNanos:       now.UnixNano(),
 		MaxRootDurationMillis: int64(maxRootDuration / time.Millisecond),
+		EventualReads:         tree.ReadConsistency == trillian.ReadConsistency_EVENTUAL,
 	}
 
 	switch tt := tree.TreeType; tt {
@@ -394,6 +398,9 @@ func (t *adminTX) UpdateTree(ctx context.Context, treeID int64, updateFunc func(
 	if !proto.Equal(beforeTree.StorageSettings, tree.StorageSettings) {
 		return nil, status.New(codes.InvalidArgument, "readonly field changed: storage_settings").Err()
 	}
+	if err := checkUnsupportedFields(tree); err != nil {
+		return nil, err
+	}
 
 	ts, ok := treeStateMap[tree.TreeState]
 	if !ok {
@@ -412,6 +419,7 @@ func (t *adminTX) UpdateTree(ctx context.Context, treeID int64, updateFunc func(
 	info.Description = tree.Description
 	info.UpdateTimeNanos = now.UnixNano()
 	info.MaxRootDurationMillis = int64(maxRootDuration / time.Millisecond)
+	info.EventualReads = tree.ReadConsistency == trillian.ReadConsistency_EVENTUAL
 
 	if err := t.updateTreeInfo(ctx, info); err != nil {
 		return nil, err
@@ -563,6 +571,9 @@ func toTrillianTree(info *spannerpb.TreeInfo) (*trillian.Tree, error) {
 	}
 	tree.StorageSettings = settings
 
+	if info.EventualReads {
+		tree.ReadConsistency = trillian.ReadConsistency_EVENTUAL
+	}
 	if info.Deleted {
 		tree.Deleted = info.Deleted
 	}
@@ -576,6 +587,46 @@ func toTrillianTree(info *spannerpb.TreeInfo) (*trillian.Tree, error) {
 	return tree, nil
 }
 
+// checkUnsupportedFields returns an error if the tree asks for a non-default
+// handling of conflicting sequenced leaves, or for encrypted hashes, neither
+// of which is supported by this storage implementation.
+func checkUnsupportedFields(tree *trillian.Tree) error {
+	if tree.SequencedLeafConflictPolicy != trillian.SequencedLeafConflictPolicy_CONFLICT_FAIL || tree.SequencedLeafDuplicateWindow != 0 {
+		return status.Error(codes.InvalidArgument, "sequenced_leaf_conflict_policy and sequenced_leaf_duplicate_window not supported")
+	}
+	if tree.EncryptHashes {
+		return status.Error(codes.InvalidArgument, "encrypt_hashes not supported")
+	}
+	if tree.MaxMergeDelay != nil {
+		return status.Error(codes.InvalidArgument, "max_merge_delay not supported")
+	}
+	if tree.LeafSchema != nil {
+		return status.Error(codes.InvalidArgument, "leaf_schema not supported")
+	}
+	if tree.IntegrationPause != nil {
+		return status.Error(codes.InvalidArgument, "integration_pause not supported")
+	}
+	if tree.QuotaProfile != "" {
+		return status.Error(codes.InvalidArgument, "quota_profile not supported")
+	}
+	if tree.RetentionPolicy != nil {
+		return status.Error(codes.InvalidArgument, "retention_policy not supported")
+	}
+	if tree.Operator != nil {
+		return status.Error(codes.InvalidArgument, "operator not supported")
+	}
+	if tree.Quota != nil {
+		return status.Error(codes.InvalidArgument, "quota not supported")
+	}
+	if tree.StorageCaps != nil {
+		return status.Error(codes.InvalidArgument, "storage_caps not supported")
+	}
+	if tree.SignJsonLogRoots {
+		return status.Error(codes.InvalidArgument, "sign_json_log_roots not supported")
+	}
+	return nil
+}
+
 // unmarshalSettings returns the message obtained from tree.StorageSettings.
 // If tree.StorageSettings is nil no unmarshaling will be attempted; instead the method will return
 // (nil, nil).
@@ -28,12 +28,12 @@ import (
 	"cloud.google.com/go/spanner"
 	"github.com/golang/glog"
 	"github.com/google/trillian"
+	"github.com/google/trillian/monitoring"
 	"github.com/google/trillian/storage"
 	"github.com/google/trillian/storage/cache"
 	"github.com/google/trillian/storage/cloudspanner/spannerpb"
 	"github.com/google/trillian/types"
 	"github.com/transparency-dev/merkle/rfc6962"
-	"go.opencensus.io/trace"
 	"golang.org/x/sync/semaphore"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -41,6 +41,8 @@ import (
 )
 
 const (
+	traceSpanRoot = "/trillian/storage/cloudspanner"
+
 	leafDataTbl            = "LeafData"
 	seqDataByMerkleHashIdx = "SequenceByMerkleHash"
 	seqDataTbl             = "SequencedLeafData"
@@ -201,6 +203,8 @@ func (ls *logStorage) BeginForTree(ctx context.Context, treeID int64) (storage.L
 }
 
 func (ls *logStorage) ReadWriteTransaction(ctx context.Context, tree *trillian.Tree, f storage.LogTXFunc) error {
+	ctx, spanEnd := spanFor(ctx, "ReadWriteTransaction")
+	defer spanEnd()
 	_, err := ls.ts.client.ReadWriteTransaction(ctx, func(ctx context.Context, stx *spanner.ReadWriteTransaction) error {
 		tx, err := ls.begin(ctx, tree, false /* readonly */, stx)
 		if err != nil && err != storage.ErrTreeNeedsInit {
@@ -214,11 +218,25 @@ func (ls *logStorage) ReadWriteTransaction(ctx context.Context, tree *trillian.T
 	return err
 }
 
+// SnapshotForTree returns a strong snapshot of the tree, unless the tree allows
+// EVENTUAL reads, in which case the snapshot lags ReadOnlyStaleness behind.
 func (ls *logStorage) SnapshotForTree(ctx context.Context, tree *trillian.Tree) (storage.ReadOnlyLogTreeTX, error) {
-	return ls.begin(ctx, tree, true /* readonly */, ls.ts.client.ReadOnlyTransaction())
+	stx := ls.ts.client.ReadOnlyTransaction()
+	if tree.ReadConsistency == trillian.ReadConsistency_EVENTUAL {
+		stx = ls.readOnlyTX()
+	}
+	return ls.begin(ctx, tree, true /* readonly */, stx)
+}
+
+// QueueLeavesReplicated implements storage.ReplicatedQueuer. Spanner only
+// commits a transaction once a quorum of replicas has written it.
+func (ls *logStorage) QueueLeavesReplicated(ctx context.Context, tree *trillian.Tree, leaves []*trillian.LogLeaf, qTimestamp time.Time) ([]*trillian.QueuedLogLeaf, error) {
+	return ls.QueueLeaves(ctx, tree, leaves, qTimestamp)
 }
 
 func (ls *logStorage) QueueLeaves(ctx context.Context, tree *trillian.Tree, leaves []*trillian.LogLeaf, qTimestamp time.Time) ([]*trillian.QueuedLogLeaf, error) {
+	ctx, spanEnd := spanFor(ctx, "QueueLeaves")
+	defer spanEnd()
 	_, treeConfig, err := ls.ts.getTreeAndConfig(ctx, tree)
 	if err != nil {
 		return nil, err
@@ -294,13 +312,13 @@ func (ls *logStorage) QueueLeaves(ctx context.Context, tree *trillian.Tree, leav
 }
 
 func (ls *logStorage) AddSequencedLeaves(ctx context.Context, tree *trillian.Tree, leaves []*trillian.LogLeaf, ts time.Time) ([]*trillian.QueuedLogLeaf, error) {
-	ctx, span := trace.StartSpan(ctx, "AddSequencedLeaves")
-	defer span.End()
+	ctx, spanEnd := spanFor(ctx, "AddSequencedLeaves")
+	defer spanEnd()
 
 	okProto := status.New(codes.OK, "OK").Proto()
 
-	_, span = trace.StartSpan(ctx, "insert")
-	defer span.End()
+	_, insertEnd := spanFor(ctx, "AddSequencedLeaves.insert")
+	defer insertEnd()
 	res := make([]*trillian.QueuedLogLeaf, len(leaves))
 	errs := make(chan error, 1)
 	var wg sync.WaitGroup
@@ -366,12 +384,12 @@ func (ls *logStorage) AddSequencedLeaves(ctx context.Context, tree *trillian.Tre
 			}()
 		}
 	}
-	span.End()
+	insertEnd()
 
 	// Wait for all of our mutations to apply (or fail).
-	_, span = trace.StartSpan(ctx, "wait")
+	_, waitEnd := spanFor(ctx, "AddSequencedLeaves.wait")
 	wg.Wait()
-	span.End()
+	waitEnd()
 
 	// Check if any failed, and return the first error if so.
 	select {
@@ -992,3 +1010,7 @@ func (b byIndex) Len() int { return len(b) }
 func (b byIndex) Swap(i, j int) { b[i], b[j] = b[j], b[i] }
 
 func (b byIndex) Less(i, j int) bool { return b[i].LeafIndex < b[j].LeafIndex }
+
+func spanFor(ctx context.Context, name string) (context.Context, func()) {
+	return monitoring.StartSpan(ctx, fmt.Sprintf("%s.%s", traceSpanRoot, name))
+}
@@ -0,0 +1,61 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrCosignaturesUnsupported is returned by AsCosignatureTX for storage
+// implementations which don't store witness cosignatures.
+var ErrCosignaturesUnsupported = status.Error(codes.Unimplemented, "storage does not support witness cosignatures")
+
+// Cosignature is the signature of a witness over the checkpoint of a tree at
+// a tree size.
+type Cosignature struct {
+	TreeSize uint64
+	RootHash []byte
+	// Witness is the name of the witness's key, and Signature the base64
+	// encoded key hash and signature, as in the signature lines of signed
+	// notes.
+	Witness   string
+	Signature string
+}
+
+// CosignatureTX is implemented by LogTreeTX implementations which store the
+// cosignatures of witnesses over the checkpoints of the tree.
+type CosignatureTX interface {
+	// AddCosignature stores a cosignature, replacing an earlier cosignature
+	// of the same witness at the same tree size.
+	AddCosignature(ctx context.Context, c *Cosignature) error
+
+	// LatestCosignatures returns the cosignatures at the largest tree size
+	// which has been cosigned by at least quorum witnesses, or nil if there is
+	// no such tree size.
+	LatestCosignatures(ctx context.Context, quorum int) ([]*Cosignature, error)
+}
+
+// AsCosignatureTX returns tx as a CosignatureTX, or ErrCosignaturesUnsupported
+// if the storage implementation doesn't store cosignatures.
+func AsCosignatureTX(tx ReadOnlyLogTreeTX) (CosignatureTX, error) {
+	cotx, ok := tx.(CosignatureTX)
+	if !ok {
+		return nil, ErrCosignaturesUnsupported
+	}
+	return cotx, nil
+}
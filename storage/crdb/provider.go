@@ -0,0 +1,99 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crdb
+
+import (
+	"database/sql"
+	"flag"
+	"sync"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian/monitoring"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/storage/postgres"
+)
+
+var (
+	crdbURI    = flag.String("crdb_uri", "postgresql://root@127.0.0.1:26257/defaultdb?sslmode=disable", "Connection URI for CockroachDB database")
+	crdbDriver = flag.String("crdb_driver", "postgres", "Name of the database/sql driver used to connect to CockroachDB, which must be linked into the binary")
+	maxConns   = flag.Int("crdb_max_conns", 0, "Maximum connections to the database")
+	maxIdle    = flag.Int("crdb_max_idle_conns", -1, "Maximum idle database connections in the connection pool")
+	maxRetries = flag.Int("crdb_max_tx_retries", 10, "Maximum number of times a read-write transaction is retried after CockroachDB asked the client to retry it")
+
+	crdbMu              sync.Mutex
+	crdbErr             error
+	crdbDB              *sql.DB
+	crdbStorageInstance *crdbProvider
+)
+
+func init() {
+	if err := storage.RegisterProvider("crdb", newCRDBStorageProvider); err != nil {
+		glog.Fatalf("Failed to register storage provider crdb: %v", err)
+	}
+}
+
+type crdbProvider struct {
+	db *sql.DB
+	mf monitoring.MetricFactory
+}
+
+func newCRDBStorageProvider(mf monitoring.MetricFactory) (storage.Provider, error) {
+	crdbMu.Lock()
+	defer crdbMu.Unlock()
+	if crdbStorageInstance == nil {
+		db, err := getCRDBDatabaseLocked()
+		if err != nil {
+			return nil, err
+		}
+		crdbStorageInstance = &crdbProvider{
+			db: db,
+			mf: mf,
+		}
+	}
+	return crdbStorageInstance, nil
+}
+
+// getCRDBDatabaseLocked returns an instance of CockroachDB database, or
+// creates one. Requires crdbMu to be locked.
+func getCRDBDatabaseLocked() (*sql.DB, error) {
+	if crdbDB != nil || crdbErr != nil {
+		return crdbDB, crdbErr
+	}
+	db, err := postgres.OpenDB(*crdbDriver, *crdbURI)
+	if err != nil {
+		crdbErr = err
+		return nil, err
+	}
+	if *maxConns > 0 {
+		db.SetMaxOpenConns(*maxConns)
+	}
+	if *maxIdle >= 0 {
+		db.SetMaxIdleConns(*maxIdle)
+	}
+	crdbDB, crdbErr = db, nil
+	return db, nil
+}
+
+func (s *crdbProvider) LogStorage() storage.LogStorage {
+	return NewLogStorage(s.db, s.mf, *maxRetries)
+}
+
+func (s *crdbProvider) AdminStorage() storage.AdminStorage {
+	return NewAdminStorage(s.db, *maxRetries)
+}
+
+func (s *crdbProvider) Close() error {
+	return s.db.Close()
+}
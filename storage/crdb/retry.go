@@ -0,0 +1,91 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crdb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian/client/backoff"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errCodeRetrySerializable is the SQLSTATE of the errors with which
+// CockroachDB asks the client to retry a transaction.
+const errCodeRetrySerializable = "40001"
+
+// sqlStateError is implemented by the errors of the common PostgreSQL drivers,
+// e.g. *pgconn.PgError and *pq.Error.
+type sqlStateError interface {
+	error
+	SQLState() string
+}
+
+// isRetryable returns whether the transaction which failed with err should be
+// retried. The postgres storage converts the retry errors it recognizes on
+// commit to Aborted, others are passed up as they are.
+func isRetryable(err error) bool {
+	var pgErr sqlStateError
+	if errors.As(err, &pgErr) {
+		return pgErr.SQLState() == errCodeRetrySerializable
+	}
+	return status.Code(err) == codes.Aborted
+}
+
+// retrier runs transactions until they don't fail with retryable errors.
+type retrier struct {
+	maxRetries int
+	// sleep waits before a retry, unless ctx is done first. Tests replace it.
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+func newRetrier(maxRetries int) *retrier {
+	return &retrier{maxRetries: maxRetries, sleep: sleep}
+}
+
+// do runs f, and runs it again after a backoff while it fails with a
+// retryable error, at most maxRetries times. It returns the last error.
+func (r *retrier) do(ctx context.Context, f func() error) error {
+	b := backoff.Backoff{
+		Min:    10 * time.Millisecond,
+		Max:    time.Second,
+		Factor: 2,
+		Jitter: true,
+	}
+	for retries := 0; ; retries++ {
+		err := f()
+		if err == nil || !isRetryable(err) || retries >= r.maxRetries {
+			return err
+		}
+		glog.V(1).Infof("Retrying transaction after error: %v", err)
+		if err := r.sleep(ctx, b.Duration()); err != nil {
+			return err
+		}
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
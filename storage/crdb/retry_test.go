@@ -0,0 +1,149 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/storage/memory"
+	"github.com/google/trillian/storage/testonly"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeCRDBError struct {
+	code string
+}
+
+func (e *fakeCRDBError) Error() string    { return "ERROR: restart transaction (SQLSTATE " + e.code + ")" }
+func (e *fakeCRDBError) SQLState() string { return e.code }
+
+func noSleep(context.Context, time.Duration) error { return nil }
+
+func TestIsRetryable(t *testing.T) {
+	for _, tc := range []struct {
+		desc string
+		err  error
+		want bool
+	}{
+		{desc: "retry-serializable", err: &fakeCRDBError{code: "40001"}, want: true},
+		{desc: "wrapped", err: fmt.Errorf("commit: %w", &fakeCRDBError{code: "40001"}), want: true},
+		{desc: "aborted", err: status.Error(codes.Aborted, "PostgreSQL: restart transaction"), want: true},
+		{desc: "unique-violation", err: &fakeCRDBError{code: "23505"}},
+		{desc: "not-found", err: status.Error(codes.NotFound, "no such tree")},
+		{desc: "plain", err: errors.New("bang")},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := isRetryable(tc.err); got != tc.want {
+				t.Errorf("isRetryable(%v)=%v, want %v", tc.err, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRetrierDo(t *testing.T) {
+	retryErr := &fakeCRDBError{code: "40001"}
+	otherErr := errors.New("bang")
+	for _, tc := range []struct {
+		desc      string
+		errs      []error
+		wantCalls int
+		wantErr   error
+	}{
+		{desc: "ok", errs: []error{nil}, wantCalls: 1},
+		{desc: "retried", errs: []error{retryErr, retryErr, nil}, wantCalls: 3},
+		{desc: "not-retryable", errs: []error{otherErr, nil}, wantCalls: 1, wantErr: otherErr},
+		{desc: "retryable-then-other", errs: []error{retryErr, otherErr, nil}, wantCalls: 2, wantErr: otherErr},
+		{desc: "too-many-retries", errs: []error{retryErr, retryErr, retryErr, retryErr, nil}, wantCalls: 4, wantErr: retryErr},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			r := &retrier{maxRetries: 3, sleep: noSleep}
+			calls := 0
+			err := r.do(context.Background(), func() error {
+				err := tc.errs[calls]
+				calls++
+				return err
+			})
+			if err != tc.wantErr {
+				t.Errorf("do()=%v, want %v", err, tc.wantErr)
+			}
+			if calls != tc.wantCalls {
+				t.Errorf("do() called f %d times, want %d", calls, tc.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRetrierDoContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	r := newRetrier(3)
+	calls := 0
+	err := r.do(ctx, func() error {
+		calls++
+		return &fakeCRDBError{code: "40001"}
+	})
+	if err != context.Canceled {
+		t.Errorf("do()=%v, want %v", err, context.Canceled)
+	}
+	if calls != 1 {
+		t.Errorf("do() called f %d times, want 1", calls)
+	}
+}
+
+func TestReadWriteTransactionRetries(t *testing.T) {
+	ctx := context.Background()
+	ts := memory.NewTreeStorage()
+	as := &adminStorage{AdminStorage: memory.NewAdminStorage(ts), retry: &retrier{maxRetries: 3, sleep: noSleep}}
+	ls := &logStorage{LogStorage: memory.NewLogStorage(ts, nil), retry: &retrier{maxRetries: 3, sleep: noSleep}}
+
+	var tree *trillian.Tree
+	calls := 0
+	if err := as.ReadWriteTransaction(ctx, func(ctx context.Context, tx storage.AdminTX) error {
+		calls++
+		var err error
+		tree, err = tx.CreateTree(ctx, testonly.LogTree)
+		if err != nil {
+			return err
+		}
+		if calls == 1 {
+			return &fakeCRDBError{code: "40001"}
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("admin ReadWriteTransaction()=%v", err)
+	}
+	if calls != 2 {
+		t.Errorf("admin ReadWriteTransaction() called f %d times, want 2", calls)
+	}
+
+	calls = 0
+	err := ls.ReadWriteTransaction(ctx, tree, func(ctx context.Context, tx storage.LogTreeTX) error {
+		calls++
+		return status.Error(codes.Aborted, "PostgreSQL: restart transaction")
+	})
+	if got, want := status.Code(err), codes.Aborted; got != want {
+		t.Errorf("log ReadWriteTransaction()=%v, want code %v", err, want)
+	}
+	if calls != 4 {
+		t.Errorf("log ReadWriteTransaction() called f %d times, want 4", calls)
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package crdb provides a CockroachDB-based storage layer implementation.
+//
+// CockroachDB speaks the PostgreSQL wire protocol and dialect, so the storage
+// reuses the postgres package and its schema, and adds the transaction retry
+// loops that CockroachDB requires: under its serializable isolation, a
+// transaction which conflicts with a concurrent one fails with a
+// RETRY_SERIALIZABLE (SQLSTATE 40001) error and is to be retried by the client.
+//
+// As with the postgres package, binaries must link a database/sql driver,
+// e.g. github.com/lib/pq as the Trillian binaries do, and name it in the
+// --crdb_driver flag.
+package crdb
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/monitoring"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/storage/postgres"
+)
+
+// NewLogStorage creates a storage.LogStorage instance for the specified
+// CockroachDB database, which retries read-write transactions at most
+// maxRetries times.
+//
+// Retrying re-runs the whole transaction, including the function passed to
+// ReadWriteTransaction, so that function must not have side effects outside
+// of the transaction which can't be repeated.
+func NewLogStorage(db *sql.DB, mf monitoring.MetricFactory, maxRetries int) storage.LogStorage {
+	return &logStorage{
+		LogStorage: postgres.NewLogStorage(db, mf),
+		retry:      newRetrier(maxRetries),
+	}
+}
+
+// NewAdminStorage returns a CockroachDB storage.AdminStorage implementation
+// backed by DB, which retries read-write transactions at most maxRetries
+// times.
+func NewAdminStorage(db *sql.DB, maxRetries int) storage.AdminStorage {
+	return &adminStorage{
+		AdminStorage: postgres.NewAdminStorage(db),
+		retry:        newRetrier(maxRetries),
+	}
+}
+
+// logStorage retries the read-write transactions of a storage.LogStorage.
+type logStorage struct {
+	storage.LogStorage
+	retry *retrier
+}
+
+func (s *logStorage) ReadWriteTransaction(ctx context.Context, tree *trillian.Tree, f storage.LogTXFunc) error {
+	return s.retry.do(ctx, func() error {
+		return s.LogStorage.ReadWriteTransaction(ctx, tree, f)
+	})
+}
+
+func (s *logStorage) QueueLeaves(ctx context.Context, tree *trillian.Tree, leaves []*trillian.LogLeaf, queueTimestamp time.Time) ([]*trillian.QueuedLogLeaf, error) {
+	var ret []*trillian.QueuedLogLeaf
+	err := s.retry.do(ctx, func() error {
+		var err error
+		ret, err = s.LogStorage.QueueLeaves(ctx, tree, leaves, queueTimestamp)
+		return err
+	})
+	return ret, err
+}
+
+// QueueLeavesReplicated implements storage.ReplicatedQueuer. CockroachDB only
+// commits a transaction once a quorum of the Raft replicas of its ranges has
+// written it.
+func (s *logStorage) QueueLeavesReplicated(ctx context.Context, tree *trillian.Tree, leaves []*trillian.LogLeaf, queueTimestamp time.Time) ([]*trillian.QueuedLogLeaf, error) {
+	return s.QueueLeaves(ctx, tree, leaves, queueTimestamp)
+}
+
+func (s *logStorage) AddSequencedLeaves(ctx context.Context, tree *trillian.Tree, leaves []*trillian.LogLeaf, timestamp time.Time) ([]*trillian.QueuedLogLeaf, error) {
+	var ret []*trillian.QueuedLogLeaf
+	err := s.retry.do(ctx, func() error {
+		var err error
+		ret, err = s.LogStorage.AddSequencedLeaves(ctx, tree, leaves, timestamp)
+		return err
+	})
+	return ret, err
+}
+
+// adminStorage retries the read-write transactions of a storage.AdminStorage.
+type adminStorage struct {
+	storage.AdminStorage
+	retry *retrier
+}
+
+func (s *adminStorage) ReadWriteTransaction(ctx context.Context, f storage.AdminTXFunc) error {
+	return s.retry.do(ctx, func() error {
+		return s.AdminStorage.ReadWriteTransaction(ctx, f)
+	})
+}
@@ -0,0 +1,127 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dedup implements bloom filters over the identity hashes of the
+// leaves of a log, which let storage implementations skip the lookup in their
+// leaf identity index when queueing a leaf which certainly isn't a duplicate.
+//
+// A filter has no false negatives for the hashes added to it, and false
+// positives at a rate which depends on its size and the number of hashes.
+// Filters are maintained by the log signer, for the leaves it integrates, and
+// persisted periodically, see storage.DedupFilterTX. Leaves which were queued
+// but not integrated yet aren't in the filter, so storage must still handle
+// duplicates the filter misses.
+package dedup
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// version is the first byte of encoded filters.
+const version = 1
+
+// headerSize is the size of the version byte, the number of bits and the
+// number of hash functions of an encoded filter.
+const headerSize = 1 + 8 + 4
+
+// Filter is a bloom filter of leaf identity hashes. It's not safe for
+// concurrent use.
+type Filter struct {
+	bits   []byte
+	m      uint64 // The number of bits.
+	hashes uint32 // The number of hash functions.
+}
+
+// New returns an empty filter which holds capacity hashes with about the
+// given false positive rate, which must be between 0 and 1.
+func New(capacity uint64, falsePositiveRate float64) (*Filter, error) {
+	if capacity == 0 {
+		return nil, errors.New("capacity must be positive")
+	}
+	if falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+		return nil, fmt.Errorf("false positive rate %v not between 0 and 1", falsePositiveRate)
+	}
+	// The optimal number of bits and hash functions, see
+	// https://en.wikipedia.org/wiki/Bloom_filter#Optimal_number_of_hash_functions.
+	m := uint64(math.Ceil(-float64(capacity) * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2)))
+	m = (m + 7) / 8 * 8
+	k := uint32(math.Max(1, math.Round(float64(m)/float64(capacity)*math.Ln2)))
+	return &Filter{bits: make([]byte, m/8), m: m, hashes: k}, nil
+}
+
+// Add adds an identity hash to the filter.
+func (f *Filter) Add(identityHash []byte) {
+	h1, h2 := split(identityHash)
+	for i := uint32(0); i < f.hashes; i++ {
+		bit := (h1 + uint64(i)*h2) % f.m
+		f.bits[bit/8] |= 1 << (bit % 8)
+	}
+}
+
+// MayContain returns whether the identity hash may have been added to the
+// filter. If it returns false, the hash certainly wasn't.
+func (f *Filter) MayContain(identityHash []byte) bool {
+	h1, h2 := split(identityHash)
+	for i := uint32(0); i < f.hashes; i++ {
+		bit := (h1 + uint64(i)*h2) % f.m
+		if f.bits[bit/8]&(1<<(bit%8)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// split derives the two hashes which the hash functions of the filter are
+// combined from. Identity hashes are rehashed, as they may be chosen by
+// personalities rather than be uniformly distributed.
+func split(identityHash []byte) (uint64, uint64) {
+	sum := sha256.Sum256(identityHash)
+	// An odd h2 visits different bits, whatever the number of bits.
+	return binary.BigEndian.Uint64(sum[:8]), binary.BigEndian.Uint64(sum[8:16]) | 1
+}
+
+// MarshalBinary encodes the filter.
+func (f *Filter) MarshalBinary() ([]byte, error) {
+	data := make([]byte, headerSize+len(f.bits))
+	data[0] = version
+	binary.BigEndian.PutUint64(data[1:9], f.m)
+	binary.BigEndian.PutUint32(data[9:13], f.hashes)
+	copy(data[headerSize:], f.bits)
+	return data, nil
+}
+
+// UnmarshalBinary decodes a filter encoded by MarshalBinary.
+func (f *Filter) UnmarshalBinary(data []byte) error {
+	if len(data) < headerSize {
+		return fmt.Errorf("filter of %d bytes too short", len(data))
+	}
+	if data[0] != version {
+		return fmt.Errorf("unknown filter version %d", data[0])
+	}
+	m := binary.BigEndian.Uint64(data[1:9])
+	hashes := binary.BigEndian.Uint32(data[9:13])
+	if m == 0 || m%8 != 0 || uint64(len(data)-headerSize) != m/8 {
+		return fmt.Errorf("filter of %d bytes doesn't hold %d bits", len(data), m)
+	}
+	if hashes == 0 {
+		return errors.New("filter has no hash functions")
+	}
+	f.bits = append([]byte(nil), data[headerSize:]...)
+	f.m, f.hashes = m, hashes
+	return nil
+}
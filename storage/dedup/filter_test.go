@@ -0,0 +1,111 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dedup
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"testing"
+)
+
+func identityHash(i uint64) []byte {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], i)
+	h := sha256.Sum256(b[:])
+	return h[:]
+}
+
+func TestFilter(t *testing.T) {
+	const capacity, rate = 10000, 0.01
+	f, err := New(capacity, rate)
+	if err != nil {
+		t.Fatalf("New(): %v", err)
+	}
+	for i := uint64(0); i < capacity; i++ {
+		f.Add(identityHash(i))
+	}
+	for i := uint64(0); i < capacity; i++ {
+		if !f.MayContain(identityHash(i)) {
+			t.Fatalf("MayContain(%d)=false for an added hash", i)
+		}
+	}
+	var fp int
+	for i := uint64(capacity); i < 2*capacity; i++ {
+		if f.MayContain(identityHash(i)) {
+			fp++
+		}
+	}
+	if got, max := float64(fp)/capacity, 2*rate; got > max {
+		t.Errorf("false positive rate %v, want at most %v", got, max)
+	}
+
+	data, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary(): %v", err)
+	}
+	var g Filter
+	if err := g.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary(): %v", err)
+	}
+	for i := uint64(0); i < 2*capacity; i++ {
+		if got, want := g.MayContain(identityHash(i)), f.MayContain(identityHash(i)); got != want {
+			t.Fatalf("MayContain(%d) after decoding = %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	for _, tc := range []struct {
+		capacity uint64
+		rate     float64
+	}{
+		{capacity: 0, rate: 0.01},
+		{capacity: 10, rate: 0},
+		{capacity: 10, rate: 1},
+	} {
+		if _, err := New(tc.capacity, tc.rate); err == nil {
+			t.Errorf("New(%d, %v): no error", tc.capacity, tc.rate)
+		}
+	}
+}
+
+func TestUnmarshalBinaryErrors(t *testing.T) {
+	f, err := New(100, 0.01)
+	if err != nil {
+		t.Fatalf("New(): %v", err)
+	}
+	data, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary(): %v", err)
+	}
+	badVersion := append([]byte(nil), data...)
+	badVersion[0] = 2
+	noHashes := append([]byte(nil), data...)
+	binary.BigEndian.PutUint32(noHashes[9:13], 0)
+	for _, tc := range []struct {
+		desc string
+		data []byte
+	}{
+		{desc: "empty"},
+		{desc: "badVersion", data: badVersion},
+		{desc: "truncated", data: data[:len(data)-1]},
+		{desc: "noHashes", data: noHashes},
+	} {
+		var g Filter
+		if err := g.UnmarshalBinary(tc.data); err == nil {
+			t.Errorf("%s: UnmarshalBinary(): no error", tc.desc)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrDedupFilterUnsupported is returned by AsDedupFilterTX for storage
+// implementations which don't persist dedup filters.
+var ErrDedupFilterUnsupported = status.Error(codes.Unimplemented, "storage does not support dedup filters")
+
+// DedupFilter is the persisted dedup filter of a log, a bloom filter of the
+// identity hashes of its leaves, see package storage/dedup.
+type DedupFilter struct {
+	// TreeSize is the number of leaves, from index 0, which were added to the
+	// filter.
+	TreeSize uint64
+	// Filter is the filter, as encoded by dedup.Filter.MarshalBinary.
+	Filter []byte
+}
+
+// DedupFilterTX is implemented by LogTreeTX implementations which persist the
+// dedup filter of the tree, and may consult it when queueing leaves.
+type DedupFilterTX interface {
+	// DedupFilter returns the stored dedup filter, or nil if there is none.
+	DedupFilter(ctx context.Context) (*DedupFilter, error)
+
+	// StoreDedupFilter stores the dedup filter, replacing the previous one.
+	StoreDedupFilter(ctx context.Context, f *DedupFilter) error
+}
+
+// AsDedupFilterTX returns tx as a DedupFilterTX, or ErrDedupFilterUnsupported
+// if the storage implementation doesn't persist dedup filters.
+func AsDedupFilterTX(tx ReadOnlyLogTreeTX) (DedupFilterTX, error) {
+	dtx, ok := tx.(DedupFilterTX)
+	if !ok {
+		return nil, ErrDedupFilterUnsupported
+	}
+	return dtx, nil
+}
@@ -0,0 +1,111 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package hashenc encrypts the hashes stored for trees with encrypt_hashes
+// set, so that a copy of the database doesn't reveal which entries they hold.
+//
+// The encryption is a deterministic AEAD: the same hash and associated data
+// always encrypt to the same ciphertext, which lets storage look up encrypted
+// hashes by equality, e.g. to find a leaf by its Merkle leaf hash. It is the
+// SIV construction of Rogaway and Shrimpton, with HMAC-SHA256 as the PRF that
+// derives the synthetic IV, and AES-256-CTR as the cipher. The keys are
+// derived per tree from a master key with HKDF-SHA256.
+package hashenc
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	// Overhead is the number of bytes a ciphertext is longer than the hash
+	// it encrypts.
+	Overhead = aes.BlockSize
+	// MinKeySize is the minimum size of a master key in bytes.
+	MinKeySize = 32
+
+	hkdfInfo = "trillian hash encryption v1"
+)
+
+// ErrOpen is returned if a ciphertext fails authentication.
+var ErrOpen = errors.New("hashenc: message authentication failed")
+
+// Cipher encrypts and decrypts the hashes of a single tree. It is safe for
+// concurrent use.
+type Cipher struct {
+	macKey []byte
+	block  cipher.Block
+}
+
+// NewCipher returns the Cipher of the given tree, with keys derived from the
+// master key.
+func NewCipher(masterKey []byte, treeID int64) (*Cipher, error) {
+	if len(masterKey) < MinKeySize {
+		return nil, fmt.Errorf("hashenc: master key of %d bytes, want at least %d", len(masterKey), MinKeySize)
+	}
+	info := make([]byte, len(hkdfInfo)+8)
+	copy(info, hkdfInfo)
+	binary.BigEndian.PutUint64(info[len(hkdfInfo):], uint64(treeID))
+	keys := make([]byte, 64)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, masterKey, nil, info), keys); err != nil {
+		return nil, fmt.Errorf("hashenc: failed to derive keys: %v", err)
+	}
+	block, err := aes.NewCipher(keys[32:])
+	if err != nil {
+		return nil, err
+	}
+	return &Cipher{macKey: keys[:32], block: block}, nil
+}
+
+// Seal encrypts hash, binding it to the associated data ad, which isn't
+// stored. The result is Overhead bytes longer than hash.
+func (c *Cipher) Seal(hash, ad []byte) []byte {
+	out := make([]byte, Overhead+len(hash))
+	copy(out, c.siv(hash, ad))
+	cipher.NewCTR(c.block, out[:Overhead]).XORKeyStream(out[Overhead:], hash)
+	return out
+}
+
+// Open decrypts a ciphertext returned by Seal with the same associated data.
+func (c *Cipher) Open(sealed, ad []byte) ([]byte, error) {
+	if len(sealed) < Overhead {
+		return nil, ErrOpen
+	}
+	hash := make([]byte, len(sealed)-Overhead)
+	cipher.NewCTR(c.block, sealed[:Overhead]).XORKeyStream(hash, sealed[Overhead:])
+	if !hmac.Equal(c.siv(hash, ad), sealed[:Overhead]) {
+		return nil, ErrOpen
+	}
+	return hash, nil
+}
+
+// siv returns the synthetic IV of hash and ad. The length of ad is included,
+// so that the boundary between the two is unambiguous.
+func (c *Cipher) siv(hash, ad []byte) []byte {
+	mac := hmac.New(sha256.New, c.macKey)
+	var l [8]byte
+	binary.BigEndian.PutUint64(l[:], uint64(len(ad)))
+	mac.Write(l[:])
+	mac.Write(ad)
+	mac.Write(hash)
+	return mac.Sum(nil)[:Overhead]
+}
@@ -0,0 +1,86 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hashenc
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"testing"
+)
+
+func TestCipher(t *testing.T) {
+	key := bytes.Repeat([]byte{0x42}, MinKeySize)
+	c, err := NewCipher(key, 1)
+	if err != nil {
+		t.Fatalf("NewCipher(): %v", err)
+	}
+	other, err := NewCipher(key, 2)
+	if err != nil {
+		t.Fatalf("NewCipher(): %v", err)
+	}
+	hash := sha256.Sum256([]byte("leaf"))
+	ad := []byte("ad")
+
+	sealed := c.Seal(hash[:], ad)
+	if got, want := len(sealed), len(hash)+Overhead; got != want {
+		t.Errorf("Seal(): %d bytes, want %d", got, want)
+	}
+	if bytes.Contains(sealed, hash[:]) {
+		t.Error("Seal(): contains the plaintext")
+	}
+	if again := c.Seal(hash[:], ad); !bytes.Equal(again, sealed) {
+		t.Errorf("Seal() not deterministic: %x, then %x", sealed, again)
+	}
+	if got := other.Seal(hash[:], ad); bytes.Equal(got, sealed) {
+		t.Error("Seal() of different trees: same ciphertext")
+	}
+	if got := c.Seal(hash[:], []byte("other")); bytes.Equal(got, sealed) {
+		t.Error("Seal() with different associated data: same ciphertext")
+	}
+
+	got, err := c.Open(sealed, ad)
+	if err != nil {
+		t.Fatalf("Open(): %v", err)
+	}
+	if !bytes.Equal(got, hash[:]) {
+		t.Errorf("Open(): %x, want %x", got, hash)
+	}
+
+	tampered := append([]byte(nil), sealed...)
+	tampered[len(tampered)-1] ^= 1
+	for _, tc := range []struct {
+		desc   string
+		c      *Cipher
+		sealed []byte
+		ad     []byte
+	}{
+		{desc: "tampered", c: c, sealed: tampered, ad: ad},
+		{desc: "wrong-ad", c: c, sealed: sealed, ad: []byte("other")},
+		{desc: "wrong-tree", c: other, sealed: sealed, ad: ad},
+		{desc: "short", c: c, sealed: sealed[:Overhead-1], ad: ad},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			if _, err := tc.c.Open(tc.sealed, tc.ad); err != ErrOpen {
+				t.Errorf("Open(): %v, want %v", err, ErrOpen)
+			}
+		})
+	}
+}
+
+func TestNewCipherShortKey(t *testing.T) {
+	if _, err := NewCipher(make([]byte, MinKeySize-1), 1); err == nil {
+		t.Error("NewCipher() with short key: no error")
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+
+	"github.com/google/trillian"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrImportUnsupported is returned by AsImportTX for storage implementations
+// which can't import leaves.
+var ErrImportUnsupported = status.Error(codes.Unimplemented, "storage does not support importing leaves")
+
+// ImportTX is implemented by LogTreeTX implementations which are able to
+// store leaves exported from another log, so that a tree can be restored from
+// a snapshot.
+type ImportTX interface {
+	// ImportLeaves stores the given leaves as sequenced, at their LeafIndex,
+	// keeping their queue and integrate timestamps. It doesn't update the
+	// Merkle tree nodes, which the caller must store with SetMerkleNodes.
+	ImportLeaves(ctx context.Context, leaves []*trillian.LogLeaf) error
+}
+
+// AsImportTX returns tx as an ImportTX, or ErrImportUnsupported if the
+// storage implementation can't import leaves.
+func AsImportTX(tx ReadOnlyLogTreeTX) (ImportTX, error) {
+	itx, ok := tx.(ImportTX)
+	if !ok {
+		return nil, ErrImportUnsupported
+	}
+	return itx, nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/trillian"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrLeafAnnotationUnsupported is returned by AsLeafAnnotationTX for storage
+// implementations which can't update the extra data of sequenced leaves.
+var ErrLeafAnnotationUnsupported = status.Error(codes.Unimplemented, "storage does not support leaf extra data updates")
+
+// LeafAnnotationTX is implemented by LogTreeTX implementations which are able
+// to update the ExtraData of sequenced leaves, which is not covered by the
+// Merkle leaf hash, and keep an audit trail of the updates.
+type LeafAnnotationTX interface {
+	// UpdateLeafExtraData replaces the ExtraData of the sequenced leaf at
+	// leafIndex, and records the update with the previous ExtraData, the
+	// reason and time in the audit trail of the leaf. Returns a NotFound error
+	// if there is no leaf at leafIndex.
+	//
+	// Storage implementations which keep the ExtraData per leaf identity
+	// hash update the ExtraData of all the leaves with the same identity hash,
+	// and record the update in the audit trail of each of them.
+	UpdateLeafExtraData(ctx context.Context, leafIndex int64, extraData []byte, reason string, now time.Time) (*trillian.LeafExtraDataUpdate, error)
+
+	// ListLeafExtraDataUpdates returns the audit trail of the leaf at
+	// leafIndex, oldest update first.
+	ListLeafExtraDataUpdates(ctx context.Context, leafIndex int64) ([]*trillian.LeafExtraDataUpdate, error)
+}
+
+// AsLeafAnnotationTX returns tx as a LeafAnnotationTX, or
+// ErrLeafAnnotationUnsupported if the storage implementation doesn't support
+// leaf extra data updates.
+func AsLeafAnnotationTX(tx ReadOnlyLogTreeTX) (LeafAnnotationTX, error) {
+	atx, ok := tx.(LeafAnnotationTX)
+	if !ok {
+		return nil, ErrLeafAnnotationUnsupported
+	}
+	return atx, nil
+}
@@ -0,0 +1,47 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+
+	"github.com/google/trillian"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrLeafHashesUnsupported is returned by AsLeafHashesTX for storage
+// implementations which can't read leaves without their data.
+var ErrLeafHashesUnsupported = status.Error(codes.Unimplemented, "storage does not support reading leaves without their data")
+
+// LeafHashesTX is implemented by ReadOnlyLogTreeTX implementations which are
+// able to read sequenced leaves without reading their data, for clients which
+// only verify the structure of a log.
+type LeafHashesTX interface {
+	// GetLeafHashesByRange is like GetLeavesByRange, but the returned leaves
+	// have no LeafValue and ExtraData. Their hashes, index and timestamps are
+	// set.
+	GetLeafHashesByRange(ctx context.Context, start, count int64) ([]*trillian.LogLeaf, error)
+}
+
+// AsLeafHashesTX returns tx as a LeafHashesTX, or ErrLeafHashesUnsupported if
+// the storage implementation can't read leaves without their data.
+func AsLeafHashesTX(tx ReadOnlyLogTreeTX) (LeafHashesTX, error) {
+	htx, ok := tx.(LeafHashesTX)
+	if !ok {
+		return nil, ErrLeafHashesUnsupported
+	}
+	return htx, nil
+}
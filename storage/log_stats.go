@@ -0,0 +1,50 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+
+	"github.com/google/trillian"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrLogStatsUnsupported is returned by AsLogStatsTX for storage
+// implementations which don't maintain daily statistics.
+var ErrLogStatsUnsupported = status.Error(codes.Unimplemented, "storage does not support daily log statistics")
+
+// LogStatsTX is implemented by LogTreeTX implementations which maintain
+// per-day counters of the leaves integrated into a tree, so that reporting
+// doesn't require scanning the leaves.
+type LogStatsTX interface {
+	// AddDailyStats adds the counters in stats to the stored counters of the
+	// tree for the same days. The Day of every entry must be midnight UTC.
+	AddDailyStats(ctx context.Context, stats []*trillian.DailyLogStats) error
+
+	// ListDailyStats returns the stored counters of the tree, oldest day
+	// first.
+	ListDailyStats(ctx context.Context) ([]*trillian.DailyLogStats, error)
+}
+
+// AsLogStatsTX returns tx as a LogStatsTX, or ErrLogStatsUnsupported if the
+// storage implementation doesn't maintain daily statistics.
+func AsLogStatsTX(tx ReadOnlyLogTreeTX) (LogStatsTX, error) {
+	stx, ok := tx.(LogStatsTX)
+	if !ok {
+		return nil, ErrLogStatsUnsupported
+	}
+	return stx, nil
+}
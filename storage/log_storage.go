@@ -16,6 +16,7 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/google/trillian"
@@ -28,6 +29,10 @@ import (
 // ErrTreeNeedsInit is returned when calling methods on an uninitialised tree.
 var ErrTreeNeedsInit = status.Error(codes.FailedPrecondition, "tree needs initialising")
 
+// ErrMissingNodes is wrapped by the errors of GetMerkleNodes for nodes which
+// aren't stored, as opposed to nodes which couldn't be read.
+var ErrMissingNodes = errors.New("tree nodes not found")
+
 // ReadOnlyLogTreeTX provides a read-only view into the Log data.
 // A ReadOnlyLogTreeTX can only read from the tree specified in its creation.
 type ReadOnlyLogTreeTX interface {
@@ -23,6 +23,8 @@ import (
 	"github.com/golang/glog"
 	"github.com/google/trillian"
 	"github.com/google/trillian/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -87,7 +89,7 @@ func (t *adminTX) GetTree(ctx context.Context, treeID int64) (*trillian.Tree, er
 	tree.RLock()
 	defer tree.RUnlock()
 
-	return tree.meta, nil
+	return proto.Clone(tree.meta).(*trillian.Tree), nil
 }
 
 func (t *adminTX) ListTrees(ctx context.Context, includeDeleted bool) ([]*trillian.Tree, error) {
@@ -96,7 +98,12 @@ func (t *adminTX) ListTrees(ctx context.Context, includeDeleted bool) ([]*trilli
 
 	var ret []*trillian.Tree
 	for _, v := range t.ms.trees {
-		ret = append(ret, v.meta)
+		// UpdateTree modifies the tree in place, so return a copy.
+		v.RLock()
+		if includeDeleted || !v.meta.Deleted {
+			ret = append(ret, proto.Clone(v.meta).(*trillian.Tree))
+		}
+		v.RUnlock()
 	}
 	return ret, nil
 }
@@ -109,7 +116,7 @@ func (t *adminTX) CreateTree(ctx context.Context, tr *trillian.Tree) (*trillian.
 		return nil, err
 	}
 
-	id, err := storage.NewTreeID()
+	id, err := storage.TreeIDForCreation(tr)
 	if err != nil {
 		return nil, err
 	}
@@ -129,6 +136,9 @@ func (t *adminTX) CreateTree(ctx context.Context, tr *trillian.Tree) (*trillian.
 
 	t.ms.mu.Lock()
 	defer t.ms.mu.Unlock()
+	if _, ok := t.ms.trees[id]; ok {
+		return nil, status.Errorf(codes.AlreadyExists, "tree %v already exists", id)
+	}
 	t.ms.trees[id] = newTree(meta)
 
 	glog.V(1).Infof("trees: %v", t.ms.trees)
@@ -159,11 +169,37 @@ func (t *adminTX) UpdateTree(ctx context.Context, treeID int64, updateFunc func(
 }
 
 func (t *adminTX) SoftDeleteTree(ctx context.Context, treeID int64) (*trillian.Tree, error) {
-	return nil, fmt.Errorf("method not supported: SoftDeleteTree")
+	mTree := t.ms.getTree(treeID)
+	if mTree == nil {
+		return nil, status.Errorf(codes.NotFound, "tree %v not found", treeID)
+	}
+	mTree.mu.Lock()
+	defer mTree.mu.Unlock()
+	if mTree.meta.Deleted {
+		return nil, status.Errorf(codes.FailedPrecondition, "tree %v already soft deleted", treeID)
+	}
+	mTree.meta.Deleted = true
+	mTree.meta.DeleteTime = timestamppb.New(time.Now())
+	return proto.Clone(mTree.meta).(*trillian.Tree), nil
 }
 
+// HardDeleteTree removes the tree along with its data, which is held by the
+// tree itself.
 func (t *adminTX) HardDeleteTree(ctx context.Context, treeID int64) error {
-	return fmt.Errorf("method not supported: HardDeleteTree")
+	t.ms.mu.Lock()
+	defer t.ms.mu.Unlock()
+	mTree, ok := t.ms.trees[treeID]
+	if !ok {
+		return status.Errorf(codes.NotFound, "tree %v not found", treeID)
+	}
+	mTree.RLock()
+	deleted := mTree.meta.Deleted
+	mTree.RUnlock()
+	if !deleted {
+		return status.Errorf(codes.FailedPrecondition, "tree %v is not soft deleted", treeID)
+	}
+	delete(t.ms.trees, treeID)
+	return nil
 }
 
 func (t *adminTX) UndeleteTree(ctx context.Context, treeID int64) (*trillian.Tree, error) {
@@ -0,0 +1,71 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/btree"
+	"github.com/google/trillian/storage"
+)
+
+// cosignaturesKey formats a key for use in a tree's BTree store. The
+// associated Item value will be the cosignatures of the tree, a slice of
+// *storage.Cosignature.
+func cosignaturesKey(treeID int64) btree.Item {
+	return &kv{k: fmt.Sprintf("/%d/cosignatures", treeID)}
+}
+
+func (t *logTreeTX) cosignatures() []*storage.Cosignature {
+	if item := t.tx.Get(cosignaturesKey(t.treeID)); item != nil {
+		return item.(*kv).v.([]*storage.Cosignature)
+	}
+	return nil
+}
+
+// AddCosignature implements storage.CosignatureTX.
+func (t *logTreeTX) AddCosignature(ctx context.Context, c *storage.Cosignature) error {
+	cosigs := make([]*storage.Cosignature, 0, len(t.cosignatures())+1)
+	for _, e := range t.cosignatures() {
+		if e.TreeSize != c.TreeSize || e.Witness != c.Witness {
+			cosigs = append(cosigs, e)
+		}
+	}
+	cc := *c
+	k := cosignaturesKey(t.treeID)
+	k.(*kv).v = append(cosigs, &cc)
+	t.tx.ReplaceOrInsert(k)
+	return nil
+}
+
+// LatestCosignatures implements storage.CosignatureTX.
+func (t *logTreeTX) LatestCosignatures(ctx context.Context, quorum int) ([]*storage.Cosignature, error) {
+	bySize := make(map[uint64][]*storage.Cosignature)
+	for _, c := range t.cosignatures() {
+		bySize[c.TreeSize] = append(bySize[c.TreeSize], c)
+	}
+	var ret []*storage.Cosignature
+	for size, cosigs := range bySize {
+		if len(cosigs) >= quorum && (ret == nil || size > ret[0].TreeSize) {
+			ret = cosigs
+		}
+	}
+	for i, c := range ret {
+		cc := *c
+		ret[i] = &cc
+	}
+	return ret, nil
+}
@@ -0,0 +1,48 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/btree"
+	"github.com/google/trillian/storage"
+)
+
+// dedupFilterKey formats a key for use in a tree's BTree store. The associated
+// Item value will be the dedup filter of the tree, a *storage.DedupFilter.
+func dedupFilterKey(treeID int64) btree.Item {
+	return &kv{k: fmt.Sprintf("/%d/dedupFilter", treeID)}
+}
+
+// DedupFilter implements storage.DedupFilterTX.
+func (t *logTreeTX) DedupFilter(ctx context.Context) (*storage.DedupFilter, error) {
+	item := t.tx.Get(dedupFilterKey(t.treeID))
+	if item == nil {
+		return nil, nil
+	}
+	f := *item.(*kv).v.(*storage.DedupFilter)
+	f.Filter = append([]byte(nil), f.Filter...)
+	return &f, nil
+}
+
+// StoreDedupFilter implements storage.DedupFilterTX.
+func (t *logTreeTX) StoreDedupFilter(ctx context.Context, f *storage.DedupFilter) error {
+	k := dedupFilterKey(t.treeID)
+	k.(*kv).v = &storage.DedupFilter{TreeSize: f.TreeSize, Filter: append([]byte(nil), f.Filter...)}
+	t.tx.ReplaceOrInsert(k)
+	return nil
+}
@@ -0,0 +1,42 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"context"
+
+	"github.com/google/trillian"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// ImportLeaves implements storage.ImportTX.
+func (t *logTreeTX) ImportLeaves(ctx context.Context, leaves []*trillian.LogLeaf) error {
+	m := t.tx.Get(hashToSeqKey(t.treeID)).(*kv).v.(map[string][]int64)
+	for _, leaf := range leaves {
+		if got, want := len(leaf.LeafIdentityHash), t.hashSizeBytes; got != want {
+			return status.Errorf(codes.InvalidArgument, "leaf %d has incorrect hash size %d, want %d", leaf.LeafIndex, got, want)
+		}
+		k := seqLeafKey(t.treeID, leaf.LeafIndex)
+		if t.tx.Get(k) != nil {
+			return status.Errorf(codes.AlreadyExists, "leaf %d already exists", leaf.LeafIndex)
+		}
+		k.(*kv).v = proto.Clone(leaf).(*trillian.LogLeaf)
+		t.tx.ReplaceOrInsert(k)
+		m[string(leaf.MerkleLeafHash)] = append(m[string(leaf.MerkleLeafHash)], leaf.LeafIndex)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/btree"
+	"github.com/google/trillian"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// extraDataUpdatesKey formats a key for use in a tree's BTree store.
+// The associated Item value will be the audit trail of the leaf, a slice of
+// *trillian.LeafExtraDataUpdate.
+func extraDataUpdatesKey(treeID, seq int64) btree.Item {
+	return &kv{k: fmt.Sprintf("/%d/extra/%020d", treeID, seq)}
+}
+
+// UpdateLeafExtraData implements storage.LeafAnnotationTX.
+func (t *logTreeTX) UpdateLeafExtraData(ctx context.Context, leafIndex int64, extraData []byte, reason string, now time.Time) (*trillian.LeafExtraDataUpdate, error) {
+	item := t.tx.Get(seqLeafKey(t.treeID, leafIndex))
+	if item == nil {
+		return nil, status.Errorf(codes.NotFound, "no sequenced leaf at index %d", leafIndex)
+	}
+	// Leaves returned by earlier reads must not change, so store a copy.
+	leaf := proto.Clone(item.(*kv).v.(*trillian.LogLeaf)).(*trillian.LogLeaf)
+	update := &trillian.LeafExtraDataUpdate{
+		LeafIndex:         leafIndex,
+		LeafIdentityHash:  leaf.LeafIdentityHash,
+		PreviousExtraData: leaf.ExtraData,
+		ExtraData:         extraData,
+		UpdateTimestamp:   timestamppb.New(now),
+		Reason:            reason,
+	}
+	leaf.ExtraData = extraData
+	k := seqLeafKey(t.treeID, leafIndex)
+	k.(*kv).v = leaf
+	t.tx.ReplaceOrInsert(k)
+
+	var updates []*trillian.LeafExtraDataUpdate
+	if item := t.tx.Get(extraDataUpdatesKey(t.treeID, leafIndex)); item != nil {
+		updates = item.(*kv).v.([]*trillian.LeafExtraDataUpdate)
+	}
+	k = extraDataUpdatesKey(t.treeID, leafIndex)
+	k.(*kv).v = append(updates[:len(updates):len(updates)], update)
+	t.tx.ReplaceOrInsert(k)
+	return proto.Clone(update).(*trillian.LeafExtraDataUpdate), nil
+}
+
+// ListLeafExtraDataUpdates implements storage.LeafAnnotationTX.
+func (t *logTreeTX) ListLeafExtraDataUpdates(ctx context.Context, leafIndex int64) ([]*trillian.LeafExtraDataUpdate, error) {
+	item := t.tx.Get(extraDataUpdatesKey(t.treeID, leafIndex))
+	if item == nil {
+		return nil, nil
+	}
+	updates := item.(*kv).v.([]*trillian.LeafExtraDataUpdate)
+	ret := make([]*trillian.LeafExtraDataUpdate, 0, len(updates))
+	for _, u := range updates {
+		ret = append(ret, proto.Clone(u).(*trillian.LeafExtraDataUpdate))
+	}
+	return ret, nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/btree"
+	"github.com/google/trillian"
+	"google.golang.org/protobuf/proto"
+)
+
+// dailyStatsKey formats a key for use in a tree's BTree store.
+// The associated Item value will be the *trillian.DailyLogStats of the day.
+func dailyStatsKey(treeID, unixDay int64) btree.Item {
+	return &kv{k: fmt.Sprintf("/%d/stats/%020d", treeID, unixDay)}
+}
+
+// AddDailyStats implements storage.LogStatsTX.
+func (t *logTreeTX) AddDailyStats(ctx context.Context, stats []*trillian.DailyLogStats) error {
+	for _, s := range stats {
+		day := s.GetDay().AsTime().Unix() / (24 * 60 * 60)
+		sum := proto.Clone(s).(*trillian.DailyLogStats)
+		if item := t.tx.Get(dailyStatsKey(t.treeID, day)); item != nil {
+			old := item.(*kv).v.(*trillian.DailyLogStats)
+			sum.LeavesAdded += old.LeavesAdded
+			sum.LeafValueBytes += old.LeafValueBytes
+		}
+		k := dailyStatsKey(t.treeID, day)
+		k.(*kv).v = sum
+		t.tx.ReplaceOrInsert(k)
+	}
+	return nil
+}
+
+// ListDailyStats implements storage.LogStatsTX.
+func (t *logTreeTX) ListDailyStats(ctx context.Context) ([]*trillian.DailyLogStats, error) {
+	var ret []*trillian.DailyLogStats
+	prefix := fmt.Sprintf("/%d/stats/", t.treeID)
+	t.tx.AscendGreaterOrEqual(&kv{k: prefix}, func(item btree.Item) bool {
+		e := item.(*kv)
+		if !strings.HasPrefix(e.k, prefix) {
+			return false
+		}
+		ret = append(ret, proto.Clone(e.v.(*trillian.DailyLogStats)).(*trillian.DailyLogStats))
+		return true
+	})
+	return ret, nil
+}
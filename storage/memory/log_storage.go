@@ -334,9 +334,9 @@ func (t *logTreeTX) StoreSignedLogRoot(ctx context.Context, slr *trillian.Signed
 	k.(*kv).v = t.treeTX.writeRevision
 	t.tx.ReplaceOrInsert(k)
 
-	// TODO(alcutter): this breaks the transactional model
-	if root.TimestampNanos > t.tree.currentSTH {
-		t.tree.currentSTH = root.TimestampNanos
+	// The root becomes the current one when the TX commits.
+	if root.TimestampNanos > t.treeTX.latestSTH {
+		t.treeTX.latestSTH = root.TimestampNanos
 	}
 	return nil
 }
@@ -0,0 +1,104 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/btree"
+	"github.com/google/trillian"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// quarantineKey formats a key for use in a tree's BTree store.
+// The associated Item value will be a list of quarantined entries.
+func quarantineKey(treeID int64) btree.Item {
+	return &kv{k: fmt.Sprintf("/%d/quarantine", treeID)}
+}
+
+// quarantined is an entry in the quarantine list, holding the original leaf
+// so that it can be put back into the queue unchanged.
+type quarantined struct {
+	leaf *trillian.LogLeaf
+	info *trillian.QuarantinedLeaf
+}
+
+// QuarantineLeaves implements storage.QuarantineTX.
+func (t *logTreeTX) QuarantineLeaves(ctx context.Context, leafIdentityHashes [][]byte, reason string, now time.Time) ([]*trillian.QuarantinedLeaf, error) {
+	q := t.tx.Get(unseqKey(t.treeID)).(*kv).v.(*list.List)
+	ql := t.tx.Get(quarantineKey(t.treeID)).(*kv).v.(*list.List)
+
+	ret := make([]*trillian.QuarantinedLeaf, 0, len(leafIdentityHashes))
+	for _, hash := range leafIdentityHashes {
+		for e := q.Front(); e != nil; e = e.Next() {
+			leaf := e.Value.(*trillian.LogLeaf)
+			if !bytes.Equal(leaf.LeafIdentityHash, hash) {
+				continue
+			}
+			q.Remove(e)
+			info := &trillian.QuarantinedLeaf{
+				LeafIdentityHash:    leaf.LeafIdentityHash,
+				MerkleLeafHash:      leaf.MerkleLeafHash,
+				QueueTimestamp:      leaf.QueueTimestamp,
+				QuarantineTimestamp: timestamppb.New(now),
+				Reason:              reason,
+			}
+			ql.PushBack(&quarantined{leaf: leaf, info: info})
+			ret = append(ret, proto.Clone(info).(*trillian.QuarantinedLeaf))
+			break
+		}
+	}
+	return ret, nil
+}
+
+// ListQuarantinedLeaves implements storage.QuarantineTX.
+func (t *logTreeTX) ListQuarantinedLeaves(ctx context.Context) ([]*trillian.QuarantinedLeaf, error) {
+	ql := t.tx.Get(quarantineKey(t.treeID)).(*kv).v.(*list.List)
+	ret := make([]*trillian.QuarantinedLeaf, 0, ql.Len())
+	for e := ql.Front(); e != nil; e = e.Next() {
+		ret = append(ret, proto.Clone(e.Value.(*quarantined).info).(*trillian.QuarantinedLeaf))
+	}
+	return ret, nil
+}
+
+// RequeueQuarantinedLeaves implements storage.QuarantineTX.
+func (t *logTreeTX) RequeueQuarantinedLeaves(ctx context.Context, leafIdentityHashes [][]byte) ([]*trillian.QuarantinedLeaf, error) {
+	q := t.tx.Get(unseqKey(t.treeID)).(*kv).v.(*list.List)
+	ql := t.tx.Get(quarantineKey(t.treeID)).(*kv).v.(*list.List)
+
+	wanted := make(map[string]bool)
+	for _, hash := range leafIdentityHashes {
+		wanted[string(hash)] = true
+	}
+	ret := make([]*trillian.QuarantinedLeaf, 0, len(leafIdentityHashes))
+	for e := ql.Front(); e != nil; {
+		next := e.Next()
+		entry := e.Value.(*quarantined)
+		if len(wanted) == 0 || wanted[string(entry.leaf.LeafIdentityHash)] {
+			ql.Remove(e)
+			// No ordering by queue timestamp in this storage either, requeued
+			// leaves simply go to the back of the queue.
+			q.PushBack(entry.leaf)
+			ret = append(ret, entry.info)
+		}
+		e = next
+	}
+	return ret, nil
+}
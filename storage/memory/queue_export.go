@@ -0,0 +1,42 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"sort"
+
+	"github.com/google/trillian"
+	"google.golang.org/protobuf/proto"
+)
+
+// ListQueuedLeaves implements storage.QueueExportTX.
+func (t *logTreeTX) ListQueuedLeaves(ctx context.Context) ([]*trillian.LogLeaf, error) {
+	q := t.tx.Get(unseqKey(t.treeID)).(*kv).v.(*list.List)
+	ret := make([]*trillian.LogLeaf, 0, q.Len())
+	for e := q.Front(); e != nil; e = e.Next() {
+		ret = append(ret, proto.Clone(e.Value.(*trillian.LogLeaf)).(*trillian.LogLeaf))
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		ti, tj := ret[i].QueueTimestamp.AsTime(), ret[j].QueueTimestamp.AsTime()
+		if !ti.Equal(tj) {
+			return ti.Before(tj)
+		}
+		return bytes.Compare(ret[i].LeafIdentityHash, ret[j].LeafIdentityHash) < 0
+	})
+	return ret, nil
+}
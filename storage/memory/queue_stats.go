@@ -0,0 +1,44 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"container/list"
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/storage"
+)
+
+// QueueStats implements storage.QueueStatsTX. Leaves queued without a
+// QueueTimestamp are counted, but don't contribute to the timestamps.
+func (t *logTreeTX) QueueStats(ctx context.Context) (*storage.QueueStats, error) {
+	q := t.tx.Get(unseqKey(t.treeID)).(*kv).v.(*list.List)
+	stats := &storage.QueueStats{Size: int64(q.Len())}
+	var times []time.Time
+	for e := q.Front(); e != nil; e = e.Next() {
+		if ts := e.Value.(*trillian.LogLeaf).QueueTimestamp; ts != nil {
+			times = append(times, ts.AsTime())
+		}
+	}
+	if len(times) == 0 {
+		return stats, nil
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+	stats.Oldest, stats.Median, stats.Newest = times[0], times[len(times)/2], times[len(times)-1]
+	return stats, nil
+}
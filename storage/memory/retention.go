@@ -0,0 +1,52 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/btree"
+	"github.com/google/trillian"
+	"google.golang.org/protobuf/proto"
+)
+
+// PruneLeafData implements storage.RetentionTX.
+func (t *logTreeTX) PruneLeafData(ctx context.Context, cutoff time.Time, limit int) (int, error) {
+	var pruned []*kv
+	prefix := fmt.Sprintf("/%d/seq/", t.treeID)
+	t.tx.AscendGreaterOrEqual(&kv{k: prefix}, func(item btree.Item) bool {
+		e := item.(*kv)
+		if !strings.HasPrefix(e.k, prefix) || len(pruned) >= limit {
+			return false
+		}
+		leaf := e.v.(*trillian.LogLeaf)
+		if !leaf.GetIntegrateTimestamp().AsTime().Before(cutoff) || (len(leaf.LeafValue) == 0 && leaf.ExtraData == nil) {
+			return true
+		}
+		// The stored leaf is shared with other transactions, so it's replaced
+		// rather than modified.
+		leaf = proto.Clone(leaf).(*trillian.LogLeaf)
+		leaf.LeafValue, leaf.ExtraData = nil, nil
+		pruned = append(pruned, &kv{k: e.k, v: leaf})
+		return true
+	})
+	for _, e := range pruned {
+		t.tx.ReplaceOrInsert(e)
+	}
+	return len(pruned), nil
+}
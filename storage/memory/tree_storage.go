@@ -123,6 +123,10 @@ func newTree(t *trillian.Tree) *tree {
 	k.(*kv).v = make(map[string][]int64)
 	ret.store.ReplaceOrInsert(k)
 
+	k = quarantineKey(t.TreeId)
+	k.(*kv).v = list.New()
+	ret.store.ReplaceOrInsert(k)
+
 	return ret
 }
 
@@ -160,6 +164,8 @@ type treeTX struct {
 	subtreeCache  *cache.SubtreeCache
 	writeRevision int64
 	unlock        func()
+	// latestSTH is the timestamp of the latest root stored by this TX.
+	latestSTH uint64
 }
 
 func (t *treeTX) getSubtrees(ctx context.Context, treeRevision int64, ids [][]byte) ([]*storagepb.SubtreeProto, error) {
@@ -236,6 +242,9 @@ func (t *treeTX) Commit(ctx context.Context) error {
 	t.closed = true
 	// update the shared view of the tree post TX:
 	t.tree.store = t.tx
+	if t.latestSTH > t.tree.currentSTH {
+		t.tree.currentSTH = t.latestSTH
+	}
 	return nil
 }
 
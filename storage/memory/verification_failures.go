@@ -0,0 +1,57 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/btree"
+	"github.com/google/trillian"
+	"google.golang.org/protobuf/proto"
+)
+
+// verificationFailuresKey formats a key for use in a tree's BTree store.
+// The associated Item value will be the reports of the tree, a slice of
+// *trillian.VerificationFailureReport.
+func verificationFailuresKey(treeID int64) btree.Item {
+	return &kv{k: fmt.Sprintf("/%d/vfailures", treeID)}
+}
+
+// AddVerificationFailureReport implements storage.VerificationFailureTX.
+func (t *logTreeTX) AddVerificationFailureReport(ctx context.Context, report *trillian.VerificationFailureReport) error {
+	var reports []*trillian.VerificationFailureReport
+	if item := t.tx.Get(verificationFailuresKey(t.treeID)); item != nil {
+		reports = item.(*kv).v.([]*trillian.VerificationFailureReport)
+	}
+	k := verificationFailuresKey(t.treeID)
+	k.(*kv).v = append(reports[:len(reports):len(reports)], proto.Clone(report).(*trillian.VerificationFailureReport))
+	t.tx.ReplaceOrInsert(k)
+	return nil
+}
+
+// ListVerificationFailureReports implements storage.VerificationFailureTX.
+func (t *logTreeTX) ListVerificationFailureReports(ctx context.Context) ([]*trillian.VerificationFailureReport, error) {
+	item := t.tx.Get(verificationFailuresKey(t.treeID))
+	if item == nil {
+		return nil, nil
+	}
+	reports := item.(*kv).v.([]*trillian.VerificationFailureReport)
+	ret := make([]*trillian.VerificationFailureReport, 0, len(reports))
+	for _, r := range reports {
+		ret = append(ret, proto.Clone(r).(*trillian.VerificationFailureReport))
+	}
+	return ret, nil
+}
@@ -50,13 +50,29 @@ const (
 			PublicKey,
 			MaxRootDurationMillis,
 			Deleted,
-			DeleteTimeMillis
+			DeleteTimeMillis,
+			SequencedLeafConflictPolicy,
+			SequencedLeafDuplicateWindow,
+			ReadConsistency,
+			EncryptHashes,
+			MaxMergeDelayMillis,
+			LeafSchema,
+			IntegrationPause,
+			QuotaProfile,
+			RetentionPolicy,
+			Operator,
+			TreeQuota,
+			StorageCaps,
+			SignJSONLogRoots
 		FROM Trees`
 	selectNonDeletedTrees = selectTrees + nonDeletedWhere
 	selectTreeByID        = selectTrees + " WHERE TreeId = ?"
 
 	updateTreeSQL = `UPDATE Trees
-		SET TreeState = ?, TreeType = ?, DisplayName = ?, Description = ?, UpdateTimeMillis = ?, MaxRootDurationMillis = ?, PrivateKey = ?
+		SET TreeState = ?, TreeType = ?, DisplayName = ?, Description = ?, UpdateTimeMillis = ?, MaxRootDurationMillis = ?, PrivateKey = ?,
+			SequencedLeafConflictPolicy = ?, SequencedLeafDuplicateWindow = ?, ReadConsistency = ?, MaxMergeDelayMillis = ?,
+			LeafSchema = ?, IntegrationPause = ?, RetentionPolicy = ?, Operator = ?, TreeQuota = ?, StorageCaps = ?,
+			SignJSONLogRoots = ?
 		WHERE TreeId = ?`
 )
 
@@ -182,10 +198,13 @@ func (t *adminTX) CreateTree(ctx context.Context, tree *trillian.Tree) (*trillia
 		return nil, err
 	}
 
-	id, err := storage.NewTreeID()
+	id, err := storage.TreeIDForCreation(tree)
 	if err != nil {
 		return nil, err
 	}
+	if err := checkTreeIDFree(ctx, t.tx, id); err != nil {
+		return nil, err
+	}
 
 	// Use the time truncated-to-millis throughout, as that's what's stored.
 	nowMillis := storage.ToMillisSinceEpoch(time.Now())
@@ -205,6 +224,30 @@ func (t *adminTX) CreateTree(ctx context.Context, tree *trillian.Tree) (*trillia
 		return nil, fmt.Errorf("could not parse MaxRootDuration: %w", err)
 	}
 	rootDuration := newTree.MaxRootDuration.AsDuration()
+	leafSchema, err := marshalLeafSchema(newTree)
+	if err != nil {
+		return nil, err
+	}
+	integrationPause, err := marshalIntegrationPause(newTree)
+	if err != nil {
+		return nil, err
+	}
+	retentionPolicy, err := marshalRetentionPolicy(newTree)
+	if err != nil {
+		return nil, err
+	}
+	operator, err := marshalOperator(newTree)
+	if err != nil {
+		return nil, err
+	}
+	treeQuota, err := marshalTreeQuota(newTree)
+	if err != nil {
+		return nil, err
+	}
+	storageCaps, err := marshalStorageCaps(newTree)
+	if err != nil {
+		return nil, err
+	}
 
 	insertTreeStmt, err := t.tx.PrepareContext(
 		ctx,
@@ -221,8 +264,21 @@ func (t *adminTX) CreateTree(ctx context.Context, tree *trillian.Tree) (*trillia
 			UpdateTimeMillis,
 			PrivateKey,
 			PublicKey,
-			MaxRootDurationMillis)
-		VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+			MaxRootDurationMillis,
+			SequencedLeafConflictPolicy,
+			SequencedLeafDuplicateWindow,
+			ReadConsistency,
+			EncryptHashes,
+			MaxMergeDelayMillis,
+			LeafSchema,
+			IntegrationPause,
+			QuotaProfile,
+			RetentionPolicy,
+			Operator,
+			TreeQuota,
+			StorageCaps,
+			SignJSONLogRoots)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
 	if err != nil {
 		return nil, err
 	}
@@ -243,6 +299,19 @@ func (t *adminTX) CreateTree(ctx context.Context, tree *trillian.Tree) (*trillia
 		[]byte{}, // Unused, filling in for backward compatibility.
 		[]byte{}, // Unused, filling in for backward compatibility.
 		rootDuration/time.Millisecond,
+		newTree.SequencedLeafConflictPolicy.String(),
+		newTree.SequencedLeafDuplicateWindow,
+		newTree.ReadConsistency.String(),
+		newTree.EncryptHashes,
+		maxMergeDelayMillis(newTree),
+		leafSchema,
+		integrationPause,
+		newTree.QuotaProfile,
+		retentionPolicy,
+		operator,
+		treeQuota,
+		storageCaps,
+		newTree.SignJsonLogRoots,
 	)
 	if err != nil {
 		return nil, err
@@ -312,6 +381,30 @@ func (t *adminTX) UpdateTree(ctx context.Context, treeID int64, updateFunc func(
 		return nil, fmt.Errorf("could not parse MaxRootDuration: %w", err)
 	}
 	rootDuration := tree.MaxRootDuration.AsDuration()
+	leafSchema, err := marshalLeafSchema(tree)
+	if err != nil {
+		return nil, err
+	}
+	integrationPause, err := marshalIntegrationPause(tree)
+	if err != nil {
+		return nil, err
+	}
+	retentionPolicy, err := marshalRetentionPolicy(tree)
+	if err != nil {
+		return nil, err
+	}
+	operator, err := marshalOperator(tree)
+	if err != nil {
+		return nil, err
+	}
+	treeQuota, err := marshalTreeQuota(tree)
+	if err != nil {
+		return nil, err
+	}
+	storageCaps, err := marshalStorageCaps(tree)
+	if err != nil {
+		return nil, err
+	}
 
 	stmt, err := t.tx.PrepareContext(ctx, updateTreeSQL)
 	if err != nil {
@@ -328,6 +421,17 @@ func (t *adminTX) UpdateTree(ctx context.Context, treeID int64, updateFunc func(
 		nowMillis,
 		rootDuration/time.Millisecond,
 		[]byte{}, // Unused, filling in for backward compatibility.
+		tree.SequencedLeafConflictPolicy.String(),
+		tree.SequencedLeafDuplicateWindow,
+		tree.ReadConsistency.String(),
+		maxMergeDelayMillis(tree),
+		leafSchema,
+		integrationPause,
+		retentionPolicy,
+		operator,
+		treeQuota,
+		storageCaps,
+		tree.SignJsonLogRoots,
 		tree.TreeId); err != nil {
 		return nil, err
 	}
@@ -371,6 +475,19 @@ func (t *adminTX) HardDeleteTree(ctx context.Context, treeID int64) error {
 	return err
 }
 
+// checkTreeIDFree returns an AlreadyExists error if there is a tree with the
+// given ID, including a soft-deleted one.
+func checkTreeIDFree(ctx context.Context, tx *sql.Tx, treeID int64) error {
+	var id int64
+	switch err := tx.QueryRowContext(ctx, "SELECT TreeId FROM Trees WHERE TreeId = ?", treeID).Scan(&id); {
+	case err == sql.ErrNoRows:
+		return nil
+	case err != nil:
+		return err
+	}
+	return status.Errorf(codes.AlreadyExists, "tree %v already exists", treeID)
+}
+
 func validateDeleted(ctx context.Context, tx *sql.Tx, treeID int64, wantDeleted bool) error {
 	var nullDeleted sql.NullBool
 	switch err := tx.QueryRowContext(ctx, "SELECT Deleted FROM Trees WHERE TreeId = ?", treeID).Scan(&nullDeleted); {
@@ -395,3 +512,90 @@ func validateStorageSettings(tree *trillian.Tree) error {
 	}
 	return nil
 }
+
+// maxMergeDelayMillis returns the stored form of the max merge delay of the
+// tree, zero if it has none.
+func maxMergeDelayMillis(tree *trillian.Tree) int64 {
+	if tree.MaxMergeDelay == nil {
+		return 0
+	}
+	return int64(tree.MaxMergeDelay.AsDuration() / time.Millisecond)
+}
+
+// marshalLeafSchema returns the stored form of the leaf schema of the tree,
+// nil if it has none.
+func marshalLeafSchema(tree *trillian.Tree) ([]byte, error) {
+	if tree.LeafSchema == nil {
+		return nil, nil
+	}
+	b, err := proto.Marshal(tree.LeafSchema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal leaf schema: %v", err)
+	}
+	return b, nil
+}
+
+// marshalIntegrationPause returns the stored form of the integration pause of
+// the tree, nil if it has none.
+func marshalIntegrationPause(tree *trillian.Tree) ([]byte, error) {
+	if tree.IntegrationPause == nil {
+		return nil, nil
+	}
+	b, err := proto.Marshal(tree.IntegrationPause)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal integration pause: %v", err)
+	}
+	return b, nil
+}
+
+// marshalRetentionPolicy returns the stored form of the retention policy of
+// the tree, nil if it has none.
+func marshalRetentionPolicy(tree *trillian.Tree) ([]byte, error) {
+	if tree.RetentionPolicy == nil {
+		return nil, nil
+	}
+	b, err := proto.Marshal(tree.RetentionPolicy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal retention policy: %v", err)
+	}
+	return b, nil
+}
+
+// marshalOperator returns the stored form of the operator of the tree, nil if
+// it has none.
+func marshalOperator(tree *trillian.Tree) ([]byte, error) {
+	if tree.Operator == nil {
+		return nil, nil
+	}
+	b, err := proto.Marshal(tree.Operator)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal operator: %v", err)
+	}
+	return b, nil
+}
+
+// marshalTreeQuota returns the stored form of the quota of the tree, nil if it
+// has none.
+func marshalTreeQuota(tree *trillian.Tree) ([]byte, error) {
+	if tree.Quota == nil {
+		return nil, nil
+	}
+	b, err := proto.Marshal(tree.Quota)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal quota: %v", err)
+	}
+	return b, nil
+}
+
+// marshalStorageCaps returns the stored form of the storage caps of the tree,
+// nil if it has none.
+func marshalStorageCaps(tree *trillian.Tree) ([]byte, error) {
+	if tree.StorageCaps == nil {
+		return nil, nil
+	}
+	b, err := proto.Marshal(tree.StorageCaps)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal storage caps: %v", err)
+	}
+	return b, nil
+}
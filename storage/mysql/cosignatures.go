@@ -0,0 +1,72 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/trillian/storage"
+)
+
+const (
+	replaceCosignatureSQL = `REPLACE INTO Cosignatures(TreeId,TreeSize,RootHash,Witness,Signature)
+			VALUES(?,?,?,?,?)`
+	selectLatestCosignedSizeSQL = `SELECT TreeSize FROM Cosignatures
+			WHERE TreeId=?
+			GROUP BY TreeSize
+			HAVING COUNT(*)>=?
+			ORDER BY TreeSize DESC LIMIT 1`
+	selectCosignaturesSQL = `SELECT RootHash,Witness,Signature FROM Cosignatures
+			WHERE TreeId=? AND TreeSize=?
+			ORDER BY Witness`
+)
+
+// AddCosignature implements storage.CosignatureTX.
+func (t *logTreeTX) AddCosignature(ctx context.Context, c *storage.Cosignature) error {
+	t.treeTX.mu.Lock()
+	defer t.treeTX.mu.Unlock()
+
+	_, err := t.tx.ExecContext(ctx, replaceCosignatureSQL, t.treeID, c.TreeSize, c.RootHash, c.Witness, c.Signature)
+	return mysqlToGRPC(err)
+}
+
+// LatestCosignatures implements storage.CosignatureTX.
+func (t *logTreeTX) LatestCosignatures(ctx context.Context, quorum int) ([]*storage.Cosignature, error) {
+	t.treeTX.mu.Lock()
+	defer t.treeTX.mu.Unlock()
+
+	var size uint64
+	if err := t.tx.QueryRowContext(ctx, selectLatestCosignedSizeSQL, t.treeID, quorum).Scan(&size); err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, mysqlToGRPC(err)
+	}
+	rows, err := t.tx.QueryContext(ctx, selectCosignaturesSQL, t.treeID, size)
+	if err != nil {
+		return nil, mysqlToGRPC(err)
+	}
+	defer rows.Close()
+	var ret []*storage.Cosignature
+	for rows.Next() {
+		c := &storage.Cosignature{TreeSize: size}
+		if err := rows.Scan(&c.RootHash, &c.Witness, &c.Signature); err != nil {
+			return nil, fmt.Errorf("failed to scan cosignature: %v", err)
+		}
+		ret = append(ret, c)
+	}
+	return ret, rows.Err()
+}
@@ -0,0 +1,192 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/storage/dedup"
+)
+
+// dedupFilterChunkSize is the maximum size of the DedupFilters rows a filter
+// is split into, to keep them below max_allowed_packet.
+const dedupFilterChunkSize = 1 << 20
+
+const (
+	selectDedupFilterSQL = `SELECT ChunkIndex,TreeSize,Data FROM DedupFilters
+			WHERE TreeId=?
+			ORDER BY ChunkIndex`
+	deleteDedupFilterSQL  = "DELETE FROM DedupFilters WHERE TreeId=? AND ChunkIndex>=?"
+	replaceDedupFilterSQL = "REPLACE INTO DedupFilters(TreeId,ChunkIndex,TreeSize,Data) VALUES(?,?,?,?)"
+)
+
+// queryer is implemented by both sql.DB and sql.Tx.
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// DedupFilter implements storage.DedupFilterTX.
+func (t *logTreeTX) DedupFilter(ctx context.Context) (*storage.DedupFilter, error) {
+	t.treeTX.mu.Lock()
+	defer t.treeTX.mu.Unlock()
+	return readDedupFilter(ctx, t.tx, t.treeID)
+}
+
+// StoreDedupFilter implements storage.DedupFilterTX.
+func (t *logTreeTX) StoreDedupFilter(ctx context.Context, f *storage.DedupFilter) error {
+	t.treeTX.mu.Lock()
+	defer t.treeTX.mu.Unlock()
+
+	chunks := (len(f.Filter) + dedupFilterChunkSize - 1) / dedupFilterChunkSize
+	if _, err := t.tx.ExecContext(ctx, deleteDedupFilterSQL, t.treeID, chunks); err != nil {
+		return mysqlToGRPC(err)
+	}
+	for i := 0; i < chunks; i++ {
+		end := (i + 1) * dedupFilterChunkSize
+		if end > len(f.Filter) {
+			end = len(f.Filter)
+		}
+		if _, err := t.tx.ExecContext(ctx, replaceDedupFilterSQL, t.treeID, i, f.TreeSize, f.Filter[i*dedupFilterChunkSize:end]); err != nil {
+			return mysqlToGRPC(err)
+		}
+	}
+	return nil
+}
+
+// readDedupFilter reads the stored dedup filter of the tree, or returns nil if
+// there is none.
+func readDedupFilter(ctx context.Context, q queryer, treeID int64) (*storage.DedupFilter, error) {
+	rows, err := q.QueryContext(ctx, selectDedupFilterSQL, treeID)
+	if err != nil {
+		return nil, mysqlToGRPC(err)
+	}
+	defer rows.Close()
+	var ret *storage.DedupFilter
+	for i := 0; rows.Next(); i++ {
+		var index int
+		var size uint64
+		var data []byte
+		if err := rows.Scan(&index, &size, &data); err != nil {
+			return nil, fmt.Errorf("failed to scan dedup filter: %v", err)
+		}
+		if ret == nil {
+			ret = &storage.DedupFilter{TreeSize: size}
+		}
+		if index != i || size != ret.TreeSize {
+			return nil, fmt.Errorf("dedup filter chunk %d of tree size %d, want chunk %d of tree size %d", index, size, i, ret.TreeSize)
+		}
+		ret.Filter = append(ret.Filter, data...)
+	}
+	return ret, rows.Err()
+}
+
+// dedupFilterCache caches the stored dedup filters of trees for QueueLeaves.
+// The filters are read outside of the queueing transactions, and again once
+// they are older than the refresh interval.
+type dedupFilterCache struct {
+	db      *sql.DB
+	refresh time.Duration
+
+	mu      sync.Mutex
+	filters map[int64]*cachedDedupFilter
+}
+
+// cachedDedupFilter is the cached dedup filter of a tree. Its mu is held while
+// the filter is read, so that concurrent QueueLeaves calls don't read it more
+// than once.
+type cachedDedupFilter struct {
+	mu      sync.Mutex
+	filter  *dedup.Filter
+	fetched time.Time
+}
+
+// newDedupFilterCache returns a cache of the filters stored in db, or nil if
+// refresh is not positive, which disables the use of the filters.
+func newDedupFilterCache(db *sql.DB, refresh time.Duration) *dedupFilterCache {
+	if refresh <= 0 {
+		return nil
+	}
+	return &dedupFilterCache{db: db, refresh: refresh, filters: make(map[int64]*cachedDedupFilter)}
+}
+
+// get returns the dedup filter of the tree, or nil if it has none or it can't
+// be read. The filter may not hold the most recently integrated leaves, nor
+// any queued ones.
+func (c *dedupFilterCache) get(ctx context.Context, treeID int64) *dedup.Filter {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	cf, ok := c.filters[treeID]
+	if !ok {
+		cf = &cachedDedupFilter{}
+		c.filters[treeID] = cf
+	}
+	c.mu.Unlock()
+
+	cf.mu.Lock()
+	defer cf.mu.Unlock()
+	if now := time.Now(); now.Sub(cf.fetched) >= c.refresh {
+		// Failures aren't retried before the next refresh either, so that
+		// queueing doesn't keep waiting for them.
+		cf.fetched = now
+		f, err := c.fetch(ctx, treeID)
+		if err != nil {
+			glog.Warningf("%v: failed to read dedup filter: %v", treeID, err)
+		}
+		cf.filter = f
+	}
+	return cf.filter
+}
+
+func (c *dedupFilterCache) fetch(ctx context.Context, treeID int64) (*dedup.Filter, error) {
+	stored, err := readDedupFilter(ctx, c.db, treeID)
+	if err != nil || stored == nil {
+		return nil, err
+	}
+	var f dedup.Filter
+	if err := f.UnmarshalBinary(stored.Filter); err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// insertNewLeafData inserts the LeafData rows of the leaves with a single
+// statement. It returns false, with nothing inserted, if any of the leaves
+// is a duplicate.
+func (t *logTreeTX) insertNewLeafData(ctx context.Context, leaves []leafAndPosition) (bool, error) {
+	placeholders := make([]string, 0, len(leaves))
+	args := make([]interface{}, 0, 5*len(leaves))
+	for _, ol := range leaves {
+		leaf := ol.leaf
+		placeholders = append(placeholders, valuesPlaceholder5)
+		args = append(args, t.treeID, t.sealHash(leaf.LeafIdentityHash, identityHashAD), leaf.LeafValue, leaf.ExtraData, leaf.QueueTimestamp.AsTime().UnixNano())
+	}
+	query := "INSERT INTO LeafData(TreeId,LeafIdentityHash,LeafValue,ExtraData,QueueTimestampNanos) VALUES" + strings.Join(placeholders, ",")
+	_, err := t.tx.ExecContext(ctx, query, args...)
+	if isDuplicateErr(err) {
+		return false, nil
+	} else if err != nil {
+		return false, mysqlToGRPC(err)
+	}
+	return true, nil
+}
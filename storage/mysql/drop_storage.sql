@@ -1,9 +1,16 @@
 -- Caution - this removes all tables in our schema
 
+DROP TABLE IF EXISTS DedupFilters;
+DROP TABLE IF EXISTS Cosignatures;
+DROP TABLE IF EXISTS VerificationFailureReports;
+DROP TABLE IF EXISTS DailyLogStats;
+DROP TABLE IF EXISTS LeafExtraDataUpdates;
+DROP TABLE IF EXISTS QuarantinedLeaves;
 DROP TABLE IF EXISTS Unsequenced;
 DROP TABLE IF EXISTS Subtree;
 DROP TABLE IF EXISTS SequencedLeafData;
 DROP TABLE IF EXISTS TreeHead;
 DROP TABLE IF EXISTS LeafData;
+DROP TABLE IF EXISTS TreeShards;
 DROP TABLE IF EXISTS TreeControl;
 DROP TABLE IF EXISTS Trees;
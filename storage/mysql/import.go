@@ -0,0 +1,48 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+
+	"github.com/google/trillian"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ImportLeaves implements storage.ImportTX.
+func (t *logTreeTX) ImportLeaves(ctx context.Context, leaves []*trillian.LogLeaf) error {
+	t.treeTX.mu.Lock()
+	defer t.treeTX.mu.Unlock()
+
+	for _, leaf := range leaves {
+		if got, want := len(leaf.LeafIdentityHash), t.hashSizeBytes; got != want {
+			return status.Errorf(codes.InvalidArgument, "leaf %d has incorrect hash size %d, want %d", leaf.LeafIndex, got, want)
+		}
+		identityHash := t.sealHash(leaf.LeafIdentityHash, identityHashAD)
+		_, err := t.tx.ExecContext(ctx, insertLeafDataSQL,
+			t.treeID, identityHash, leaf.LeafValue, leaf.ExtraData, leaf.GetQueueTimestamp().AsTime().UnixNano())
+		// A leaf sequenced more than once has a single LeafData row.
+		if err != nil && !isDuplicateErr(err) {
+			return mysqlToGRPC(err)
+		}
+		if _, err := t.tx.ExecContext(ctx, insertSequencedLeafSQL+valuesPlaceholder5,
+			t.treeID, identityHash, t.sealHash(leaf.MerkleLeafHash, merkleHashAD), leaf.LeafIndex,
+			leaf.GetIntegrateTimestamp().AsTime().UnixNano()); err != nil {
+			return mysqlToGRPC(err)
+		}
+	}
+	return nil
+}
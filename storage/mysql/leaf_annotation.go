@@ -0,0 +1,149 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/trillian"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	selectSequencedLeafExtraDataSQL = `SELECT l.LeafIdentityHash,l.ExtraData
+			FROM LeafData l,SequencedLeafData s
+			WHERE l.LeafIdentityHash = s.LeafIdentityHash
+			AND s.SequenceNumber = ? AND l.TreeId = ? AND s.TreeId = l.TreeId`
+	selectSequenceNumbersByIdentityHashSQL = `SELECT SequenceNumber
+			FROM SequencedLeafData
+			WHERE TreeId=? AND LeafIdentityHash=?
+			ORDER BY SequenceNumber`
+	updateLeafExtraDataSQL = "UPDATE LeafData SET ExtraData=? WHERE TreeId=? AND LeafIdentityHash=?"
+	// The locking read makes concurrent updates of a leaf wait for each other,
+	// so that they don't pick the same UpdateSequence.
+	selectNextLeafExtraDataUpdateSQL = `SELECT COALESCE(MAX(UpdateSequence)+1,0)
+			FROM LeafExtraDataUpdates
+			WHERE TreeId=? AND SequenceNumber=?
+			FOR UPDATE`
+	insertLeafExtraDataUpdateSQL = `INSERT INTO LeafExtraDataUpdates(TreeId,SequenceNumber,UpdateSequence,UpdateTimestampNanos,LeafIdentityHash,PreviousExtraData,ExtraData,Reason)
+			VALUES(?,?,?,?,?,?,?,?)`
+	selectLeafExtraDataUpdatesSQL = `SELECT LeafIdentityHash,PreviousExtraData,ExtraData,UpdateTimestampNanos,Reason
+			FROM LeafExtraDataUpdates
+			WHERE TreeId=? AND SequenceNumber=?
+			ORDER BY UpdateSequence`
+)
+
+// UpdateLeafExtraData implements storage.LeafAnnotationTX. The ExtraData is
+// stored in LeafData, so it is shared by all the leaves with the same identity
+// hash, and the update is recorded in the audit trail of each of them.
+func (t *logTreeTX) UpdateLeafExtraData(ctx context.Context, leafIndex int64, extraData []byte, reason string, now time.Time) (*trillian.LeafExtraDataUpdate, error) {
+	t.treeTX.mu.Lock()
+	defer t.treeTX.mu.Unlock()
+
+	var storedHash, previous []byte
+	err := t.tx.QueryRowContext(ctx, selectSequencedLeafExtraDataSQL, leafIndex, t.treeID).Scan(&storedHash, &previous)
+	if err == sql.ErrNoRows {
+		return nil, status.Errorf(codes.NotFound, "no sequenced leaf at index %d", leafIndex)
+	} else if err != nil {
+		return nil, mysqlToGRPC(err)
+	}
+	identityHash, err := t.openHash(storedHash, identityHashAD)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := t.tx.ExecContext(ctx, updateLeafExtraDataSQL, extraData, t.treeID, storedHash); err != nil {
+		return nil, mysqlToGRPC(err)
+	}
+	indices, err := t.sequenceNumbersByIdentityHash(ctx, storedHash)
+	if err != nil {
+		return nil, err
+	}
+	reason = truncateReason(reason)
+	for _, index := range indices {
+		var seq int64
+		if err := t.tx.QueryRowContext(ctx, selectNextLeafExtraDataUpdateSQL, t.treeID, index).Scan(&seq); err != nil {
+			return nil, mysqlToGRPC(err)
+		}
+		if _, err := t.tx.ExecContext(ctx, insertLeafExtraDataUpdateSQL, t.treeID, index, seq, now.UnixNano(), storedHash, previous, extraData, reason); err != nil {
+			return nil, mysqlToGRPC(err)
+		}
+	}
+	return &trillian.LeafExtraDataUpdate{
+		LeafIndex:         leafIndex,
+		LeafIdentityHash:  identityHash,
+		PreviousExtraData: previous,
+		ExtraData:         extraData,
+		UpdateTimestamp:   timestamppb.New(now),
+		Reason:            reason,
+	}, nil
+}
+
+// sequenceNumbersByIdentityHash returns the indices of the sequenced leaves
+// with the given stored identity hash.
+func (t *logTreeTX) sequenceNumbersByIdentityHash(ctx context.Context, storedHash []byte) ([]int64, error) {
+	rows, err := t.tx.QueryContext(ctx, selectSequenceNumbersByIdentityHashSQL, t.treeID, storedHash)
+	if err != nil {
+		return nil, mysqlToGRPC(err)
+	}
+	defer rows.Close()
+	var indices []int64
+	for rows.Next() {
+		var index int64
+		if err := rows.Scan(&index); err != nil {
+			return nil, fmt.Errorf("failed to scan sequence number: %v", err)
+		}
+		indices = append(indices, index)
+	}
+	return indices, rows.Err()
+}
+
+// ListLeafExtraDataUpdates implements storage.LeafAnnotationTX.
+func (t *logTreeTX) ListLeafExtraDataUpdates(ctx context.Context, leafIndex int64) ([]*trillian.LeafExtraDataUpdate, error) {
+	t.treeTX.mu.Lock()
+	defer t.treeTX.mu.Unlock()
+
+	rows, err := t.tx.QueryContext(ctx, selectLeafExtraDataUpdatesSQL, t.treeID, leafIndex)
+	if err != nil {
+		return nil, mysqlToGRPC(err)
+	}
+	defer rows.Close()
+	var ret []*trillian.LeafExtraDataUpdate
+	for rows.Next() {
+		var identityHash, previous, extraData []byte
+		var timestamp int64
+		var reason string
+		if err := rows.Scan(&identityHash, &previous, &extraData, &timestamp, &reason); err != nil {
+			return nil, fmt.Errorf("failed to scan leaf extra data update: %v", err)
+		}
+		identityHash, err := t.openHash(identityHash, identityHashAD)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, &trillian.LeafExtraDataUpdate{
+			LeafIndex:         leafIndex,
+			LeafIdentityHash:  identityHash,
+			PreviousExtraData: previous,
+			ExtraData:         extraData,
+			UpdateTimestamp:   timestamppb.New(time.Unix(0, timestamp)),
+			Reason:            reason,
+		})
+	}
+	return ret, rows.Err()
+}
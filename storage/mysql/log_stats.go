@@ -0,0 +1,75 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/trillian"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	addDailyLogStatsSQL = `INSERT INTO DailyLogStats(TreeId,Day,LeavesAdded,LeafValueBytes)
+			VALUES(?,?,?,?)
+			ON DUPLICATE KEY UPDATE LeavesAdded=LeavesAdded+VALUES(LeavesAdded),LeafValueBytes=LeafValueBytes+VALUES(LeafValueBytes)`
+	selectDailyLogStatsSQL = `SELECT Day,LeavesAdded,LeafValueBytes
+			FROM DailyLogStats
+			WHERE TreeId=?
+			ORDER BY Day`
+
+	secondsPerDay = 24 * 60 * 60
+)
+
+// AddDailyStats implements storage.LogStatsTX.
+func (t *logTreeTX) AddDailyStats(ctx context.Context, stats []*trillian.DailyLogStats) error {
+	t.treeTX.mu.Lock()
+	defer t.treeTX.mu.Unlock()
+
+	for _, s := range stats {
+		day := s.GetDay().AsTime().Unix() / secondsPerDay
+		if _, err := t.tx.ExecContext(ctx, addDailyLogStatsSQL, t.treeID, day, s.LeavesAdded, s.LeafValueBytes); err != nil {
+			return mysqlToGRPC(err)
+		}
+	}
+	return nil
+}
+
+// ListDailyStats implements storage.LogStatsTX.
+func (t *logTreeTX) ListDailyStats(ctx context.Context) ([]*trillian.DailyLogStats, error) {
+	t.treeTX.mu.Lock()
+	defer t.treeTX.mu.Unlock()
+
+	rows, err := t.tx.QueryContext(ctx, selectDailyLogStatsSQL, t.treeID)
+	if err != nil {
+		return nil, mysqlToGRPC(err)
+	}
+	defer rows.Close()
+	var ret []*trillian.DailyLogStats
+	for rows.Next() {
+		var day, leaves, bytes int64
+		if err := rows.Scan(&day, &leaves, &bytes); err != nil {
+			return nil, fmt.Errorf("failed to scan daily log stats: %v", err)
+		}
+		ret = append(ret, &trillian.DailyLogStats{
+			Day:            timestamppb.New(time.Unix(day*secondsPerDay, 0)),
+			LeavesAdded:    leaves,
+			LeafValueBytes: bytes,
+		})
+	}
+	return ret, rows.Err()
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/google/trillian/monitoring"
 	"github.com/google/trillian/storage"
 	"github.com/google/trillian/storage/cache"
+	"github.com/google/trillian/storage/hashenc"
 	"github.com/google/trillian/storage/tree"
 	"github.com/google/trillian/types"
 	"github.com/transparency-dev/merkle/compact"
@@ -40,6 +41,8 @@ import (
 )
 
 const (
+	traceSpanRoot = "/trillian/storage/mysql"
+
 	valuesPlaceholder5 = "(?,?,?,?,?)"
 
 	insertLeafDataSQL      = "INSERT INTO LeafData(TreeId,LeafIdentityHash,LeafValue,ExtraData,QueueTimestampNanos) VALUES" + valuesPlaceholder5
@@ -59,6 +62,12 @@ const (
 			FROM LeafData l,SequencedLeafData s
 			WHERE l.LeafIdentityHash = s.LeafIdentityHash
 			AND s.SequenceNumber >= ? AND s.SequenceNumber < ? AND l.TreeId = ? AND s.TreeId = l.TreeId` + orderBySequenceNumberSQL
+	// selectLeafHashesByRangeSQL is selectLeavesByRangeSQL without the leaf
+	// data, which is usually most of the bytes read.
+	selectLeafHashesByRangeSQL = `SELECT s.MerkleLeafHash,l.LeafIdentityHash,NULL,s.SequenceNumber,NULL,l.QueueTimestampNanos,s.IntegrateTimestampNanos
+			FROM LeafData l,SequencedLeafData s
+			WHERE l.LeafIdentityHash = s.LeafIdentityHash
+			AND s.SequenceNumber >= ? AND s.SequenceNumber < ? AND l.TreeId = ? AND s.TreeId = l.TreeId` + orderBySequenceNumberSQL
 
 	// These statements need to be expanded to provide the correct number of parameter placeholders.
 	selectLeavesByMerkleHashSQL = `SELECT s.MerkleLeafHash,l.LeafIdentityHash,l.LeafValue,s.SequenceNumber,l.ExtraData,l.QueueTimestampNanos,s.IntegrateTimestampNanos
@@ -79,13 +88,16 @@ const (
 	selectLeavesByMerkleHashOrderedBySequenceSQL = selectLeavesByMerkleHashSQL + orderBySequenceNumberSQL
 
 	logIDLabel = "logid"
+	queryLabel = "query"
 )
 
 var (
-	once             sync.Once
-	queuedCounter    monitoring.Counter
-	queuedDupCounter monitoring.Counter
-	dequeuedCounter  monitoring.Counter
+	once                  sync.Once
+	queuedCounter         monitoring.Counter
+	queuedDupCounter      monitoring.Counter
+	queuedFilteredCounter monitoring.Counter
+	snapshotCounter       monitoring.Counter
+	dequeuedCounter       monitoring.Counter
 
 	queueLatency            monitoring.Histogram
 	queueInsertLatency      monitoring.Histogram
@@ -95,11 +107,15 @@ var (
 	dequeueLatency          monitoring.Histogram
 	dequeueSelectLatency    monitoring.Histogram
 	dequeueRemoveLatency    monitoring.Histogram
+
+	queryLatency monitoring.Histogram
 )
 
 func createMetrics(mf monitoring.MetricFactory) {
 	queuedCounter = mf.NewCounter("mysql_queued_leaves", "Number of leaves queued", logIDLabel)
 	queuedDupCounter = mf.NewCounter("mysql_queued_dup_leaves", "Number of duplicate leaves queued", logIDLabel)
+	queuedFilteredCounter = mf.NewCounter("mysql_queued_dedup_filtered_leaves", "Number of leaves inserted at once, as the dedup filter of the log doesn't hold them", logIDLabel)
+	snapshotCounter = mf.NewCounter("mysql_replica_snapshots", "Number of snapshots of storage with a read replica, by the database they read from and why", logIDLabel, "source")
 	dequeuedCounter = mf.NewCounter("mysql_dequeued_leaves", "Number of leaves dequeued", logIDLabel)
 
 	queueLatency = mf.NewHistogram("mysql_queue_leaves_latency", "Latency of queue leaves operation in seconds", logIDLabel)
@@ -111,8 +127,24 @@ func createMetrics(mf monitoring.MetricFactory) {
 	dequeueLatency = mf.NewHistogram("mysql_dequeue_leaves_latency", "Latency of dequeue leaves operation in seconds", logIDLabel)
 	dequeueSelectLatency = mf.NewHistogram("mysql_dequeue_leaves_latency_select", "Latency of selection part of dequeue leaves operation in seconds", logIDLabel)
 	dequeueRemoveLatency = mf.NewHistogram("mysql_dequeue_leaves_latency_remove", "Latency of removal part of dequeue leaves operation in seconds", logIDLabel)
+
+	queryLatency = mf.NewHistogram("mysql_query_latency", "Latency of storage queries in seconds, by logical query", queryLabel)
 }
 
+// Values of the query label of the queryLatency metric.
+const (
+	queryGetSubtrees           = "get-subtrees"
+	querySetSubtrees           = "set-subtrees"
+	queryGetLeavesByRange      = "get-leaves-by-range"
+	queryGetLeavesByHash       = "get-leaves-by-hash"
+	queryQueue                 = "queue"
+	queryDequeue               = "dequeue"
+	queryUpdateSequencedLeaves = "update-sequenced-leaves"
+	queryLatestRoot            = "latest-root"
+	queryLatestRevision        = "latest-revision"
+	queryUpdateRoot            = "update-root"
+)
+
 func labelForTX(t *logTreeTX) string {
 	return strconv.FormatInt(t.treeID, 10)
 }
@@ -121,15 +153,40 @@ func observe(hist monitoring.Histogram, duration time.Duration, label string) {
 	hist.Observe(duration.Seconds(), label)
 }
 
+// observeQuery records the latency of the logical query which started at
+// start, so that a query which regressed, e.g. after a schema change, stands
+// out from the others.
+func observeQuery(query string, start time.Time) {
+	queryLatency.Observe(time.Since(start).Seconds(), query)
+}
+
 type mySQLLogStorage struct {
 	*mySQLTreeStorage
 	admin         storage.AdminStorage
 	metricFactory monitoring.MetricFactory
+	hashKey       []byte
+	// dedup caches the dedup filters of trees, or is nil if they aren't used
+	// when queueing leaves.
+	dedup *dedupFilterCache
+	// tiles caches the subtrees read by all transactions, or is nil if they
+	// are only cached per transaction.
+	tiles *cache.SharedTileCache
+	// replica is the read replica of the snapshots, or nil if they read from
+	// the primary database.
+	replica *readReplica
 }
 
 // NewLogStorage creates a storage.LogStorage instance for the specified MySQL URL.
 // It assumes storage.AdminStorage is backed by the same MySQL database as well.
+// Trees with encrypt_hashes set can't be used, see NewLogStorageWithHashKey.
 func NewLogStorage(db *sql.DB, mf monitoring.MetricFactory) storage.LogStorage {
+	return NewLogStorageWithHashKey(db, mf, nil)
+}
+
+// NewLogStorageWithHashKey is like NewLogStorage, but encrypts the stored
+// hashes of trees with encrypt_hashes set using keys derived from hashKey.
+// See the hashenc package for details.
+func NewLogStorageWithHashKey(db *sql.DB, mf monitoring.MetricFactory, hashKey []byte) storage.LogStorage {
 	if mf == nil {
 		mf = monitoring.InertMetricFactory{}
 	}
@@ -137,6 +194,9 @@ func NewLogStorage(db *sql.DB, mf monitoring.MetricFactory) storage.LogStorage {
 		admin:            NewAdminStorage(db),
 		mySQLTreeStorage: newTreeStorage(db),
 		metricFactory:    mf,
+		hashKey:          hashKey,
+		dedup:            newDedupFilterCache(db, *dedupFilterRefresh),
+		tiles:            cache.NewSharedTileCache(*subtreeCacheSize, mf),
 	}
 }
 
@@ -183,11 +243,23 @@ func (m *mySQLLogStorage) beginInternal(ctx context.Context, tree *trillian.Tree
 		createMetrics(m.metricFactory)
 	})
 
+	var hashes *hashenc.Cipher
+	if tree.EncryptHashes {
+		if m.hashKey == nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "tree %d has encrypted hashes, but no hash encryption key is configured", tree.TreeId)
+		}
+		var err error
+		if hashes, err = hashenc.NewCipher(m.hashKey, tree.TreeId); err != nil {
+			return nil, err
+		}
+	}
+
 	stCache := cache.NewLogSubtreeCache(rfc6962.DefaultHasher)
 	ttx, err := m.beginTreeTx(ctx, tree, rfc6962.DefaultHasher.Size(), stCache)
 	if err != nil && err != storage.ErrTreeNeedsInit {
 		return nil, err
 	}
+	ttx.hashes = hashes
 
 	ltx := &logTreeTX{
 		treeTX:   ttx,
@@ -217,6 +289,8 @@ func (m *mySQLLogStorage) beginInternal(ctx context.Context, tree *trillian.Tree
 // if the transaction is rolled back as a result of a canceled context. It must
 // return "generic" errors, and only log the specific ones for debugging.
 func (m *mySQLLogStorage) ReadWriteTransaction(ctx context.Context, tree *trillian.Tree, f storage.LogTXFunc) error {
+	ctx, spanEnd := spanFor(ctx, "ReadWriteTransaction")
+	defer spanEnd()
 	tx, err := m.beginInternal(ctx, tree)
 	if err != nil && err != storage.ErrTreeNeedsInit {
 		return err
@@ -229,6 +303,8 @@ func (m *mySQLLogStorage) ReadWriteTransaction(ctx context.Context, tree *trilli
 }
 
 func (m *mySQLLogStorage) AddSequencedLeaves(ctx context.Context, tree *trillian.Tree, leaves []*trillian.LogLeaf, timestamp time.Time) ([]*trillian.QueuedLogLeaf, error) {
+	ctx, spanEnd := spanFor(ctx, "AddSequencedLeaves")
+	defer spanEnd()
 	tx, err := m.beginInternal(ctx, tree)
 	if tx != nil {
 		// Ensure we don't leak the transaction. For example if we get an
@@ -250,14 +326,29 @@ func (m *mySQLLogStorage) AddSequencedLeaves(ctx context.Context, tree *trillian
 }
 
 func (m *mySQLLogStorage) SnapshotForTree(ctx context.Context, tree *trillian.Tree) (storage.ReadOnlyLogTreeTX, error) {
+	// The span lasts until the snapshot is committed or closed.
+	ctx, spanEnd := spanFor(ctx, "SnapshotForTree")
+	if m.replica != nil {
+		if tx := m.replicaSnapshot(ctx, tree); tx != nil {
+			tx.treeTX.spanEnd = spanEnd
+			return tx, nil
+		}
+	}
 	tx, err := m.beginInternal(ctx, tree)
 	if err != nil && err != storage.ErrTreeNeedsInit {
+		spanEnd()
 		return nil, err
 	}
+	if m.replica != nil && err == nil {
+		m.replica.see(tree.TreeId, tx.readRev)
+	}
+	tx.treeTX.spanEnd = spanEnd
 	return tx, err
 }
 
 func (m *mySQLLogStorage) QueueLeaves(ctx context.Context, tree *trillian.Tree, leaves []*trillian.LogLeaf, queueTimestamp time.Time) ([]*trillian.QueuedLogLeaf, error) {
+	ctx, spanEnd := spanFor(ctx, "QueueLeaves")
+	defer spanEnd()
 	tx, err := m.beginInternal(ctx, tree)
 	if tx != nil {
 		// Ensure we don't leak the transaction. For example if we get an
@@ -304,17 +395,18 @@ type logTreeTX struct {
 func (t *logTreeTX) GetMerkleNodes(ctx context.Context, ids []compact.NodeID) ([]tree.Node, error) {
 	t.treeTX.mu.Lock()
 	defer t.treeTX.mu.Unlock()
-	return t.subtreeCache.GetNodes(ids, t.getSubtreesAtRev(ctx, t.readRev))
+	return t.subtreeCache.GetNodes(ids, t.ls.tiles.Wrap(t.treeID, t.readRev, t.getSubtreesAtRev(ctx, t.readRev)))
 }
 
 func (t *logTreeTX) DequeueLeaves(ctx context.Context, limit int, cutoffTime time.Time) ([]*trillian.LogLeaf, error) {
+	defer observeQuery(queryDequeue, time.Now())
 	t.treeTX.mu.Lock()
 	defer t.treeTX.mu.Unlock()
 
 	if t.treeType == trillian.TreeType_PREORDERED_LOG {
 		// TODO(pavelkalinnikov): Optimize this by fetching only the required
 		// fields of LogLeaf. We can avoid joining with LeafData table here.
-		return t.getLeavesByRangeInternal(ctx, int64(t.root.TreeSize), int64(limit))
+		return t.getLeavesByRangeInternal(ctx, int64(t.root.TreeSize), int64(limit), selectLeavesByRangeSQL)
 	}
 
 	start := time.Now()
@@ -378,6 +470,7 @@ func sortLeavesForInsert(leaves []*trillian.LogLeaf) []leafAndPosition {
 }
 
 func (t *logTreeTX) QueueLeaves(ctx context.Context, leaves []*trillian.LogLeaf, queueTimestamp time.Time) ([]*trillian.LogLeaf, error) {
+	defer observeQuery(queryQueue, time.Now())
 	t.treeTX.mu.Lock()
 	defer t.treeTX.mu.Unlock()
 
@@ -398,6 +491,32 @@ func (t *logTreeTX) QueueLeaves(ctx context.Context, leaves []*trillian.LogLeaf,
 	existingCount := 0
 	existingLeaves := make([]*trillian.LogLeaf, len(leaves))
 
+	// The leaves which the dedup filter of the tree doesn't hold are almost
+	// always new, so their LeafData rows are inserted at once, unless one of
+	// them was queued since the filter was stored.
+	inserted := make(map[int]bool)
+	if f := t.ls.dedup.get(ctx, t.treeID); f != nil && t.hashes == nil {
+		var fresh []leafAndPosition
+		for _, ol := range ordLeaves {
+			if !f.MayContain(ol.leaf.LeafIdentityHash) {
+				fresh = append(fresh, ol)
+			}
+		}
+		if len(fresh) > 1 {
+			ok, err := t.insertNewLeafData(ctx, fresh)
+			if err != nil {
+				glog.Warningf("Error inserting into LeafData: %s", err)
+				return nil, err
+			}
+			if ok {
+				for _, ol := range fresh {
+					inserted[ol.idx] = true
+				}
+				queuedFilteredCounter.Add(float64(len(fresh)), label)
+			}
+		}
+	}
+
 	for _, ol := range ordLeaves {
 		i, leaf := ol.idx, ol.leaf
 
@@ -406,7 +525,11 @@ func (t *logTreeTX) QueueLeaves(ctx context.Context, leaves []*trillian.LogLeaf,
 			return nil, fmt.Errorf("got invalid queue timestamp: %w", err)
 		}
 		qTimestamp := leaf.QueueTimestamp.AsTime()
-		_, err := t.tx.ExecContext(ctx, insertLeafDataSQL, t.treeID, leaf.LeafIdentityHash, leaf.LeafValue, leaf.ExtraData, qTimestamp.UnixNano())
+		identityHash := t.sealHash(leaf.LeafIdentityHash, identityHashAD)
+		var err error
+		if !inserted[i] {
+			_, err = t.tx.ExecContext(ctx, insertLeafDataSQL, t.treeID, identityHash, leaf.LeafValue, leaf.ExtraData, qTimestamp.UnixNano())
+		}
 		insertDuration := time.Since(leafStart)
 		observe(queueInsertLeafLatency, insertDuration, label)
 		if isDuplicateErr(err) {
@@ -424,10 +547,10 @@ func (t *logTreeTX) QueueLeaves(ctx context.Context, leaves []*trillian.LogLeaf,
 		// Create the work queue entry
 		args := []interface{}{
 			t.treeID,
-			leaf.LeafIdentityHash,
-			leaf.MerkleLeafHash,
+			identityHash,
+			t.sealHash(leaf.MerkleLeafHash, merkleHashAD),
 		}
-		args = append(args, queueArgs(t.treeID, leaf.LeafIdentityHash, qTimestamp)...)
+		args = append(args, queueArgs(t.treeID, identityHash, qTimestamp)...)
 		_, err = t.tx.ExecContext(
 			ctx,
 			insertUnsequencedEntrySQL,
@@ -527,8 +650,9 @@ func (t *logTreeTX) AddSequencedLeaves(ctx context.Context, leaves []*trillian.L
 		res[i] = &trillian.QueuedLogLeaf{Status: ok}
 
 		// TODO(pavelkalinnikov): Measure latencies.
+		identityHash := t.sealHash(leaf.LeafIdentityHash, identityHashAD)
 		_, err := t.tx.ExecContext(ctx, insertLeafDataSQL,
-			t.treeID, leaf.LeafIdentityHash, leaf.LeafValue, leaf.ExtraData, timestamp.UnixNano())
+			t.treeID, identityHash, leaf.LeafValue, leaf.ExtraData, timestamp.UnixNano())
 		// TODO(pavelkalinnikov): Detach PREORDERED_LOG integration latency metric.
 
 		// TODO(pavelkalinnikov): Support opting out from duplicates detection.
@@ -542,7 +666,7 @@ func (t *logTreeTX) AddSequencedLeaves(ctx context.Context, leaves []*trillian.L
 		}
 
 		_, err = t.tx.ExecContext(ctx, insertSequencedLeafSQL+valuesPlaceholder5,
-			t.treeID, leaf.LeafIdentityHash, leaf.MerkleLeafHash, leaf.LeafIndex, 0)
+			t.treeID, identityHash, t.sealHash(leaf.MerkleLeafHash, merkleHashAD), leaf.LeafIndex, 0)
 		// TODO(pavelkalinnikov): Update IntegrateTimestamp on integrating the leaf.
 
 		if isDuplicateErr(err) {
@@ -570,10 +694,20 @@ func (t *logTreeTX) AddSequencedLeaves(ctx context.Context, leaves []*trillian.L
 func (t *logTreeTX) GetLeavesByRange(ctx context.Context, start, count int64) ([]*trillian.LogLeaf, error) {
 	t.treeTX.mu.Lock()
 	defer t.treeTX.mu.Unlock()
-	return t.getLeavesByRangeInternal(ctx, start, count)
+	return t.getLeavesByRangeInternal(ctx, start, count, selectLeavesByRangeSQL)
+}
+
+// GetLeafHashesByRange implements storage.LeafHashesTX.
+func (t *logTreeTX) GetLeafHashesByRange(ctx context.Context, start, count int64) ([]*trillian.LogLeaf, error) {
+	t.treeTX.mu.Lock()
+	defer t.treeTX.mu.Unlock()
+	return t.getLeavesByRangeInternal(ctx, start, count, selectLeafHashesByRangeSQL)
 }
 
-func (t *logTreeTX) getLeavesByRangeInternal(ctx context.Context, start, count int64) ([]*trillian.LogLeaf, error) {
+// getLeavesByRangeInternal reads the leaves with the given query, which is
+// selectLeavesByRangeSQL or selectLeafHashesByRangeSQL.
+func (t *logTreeTX) getLeavesByRangeInternal(ctx context.Context, start, count int64, query string) ([]*trillian.LogLeaf, error) {
+	defer observeQuery(queryGetLeavesByRange, time.Now())
 	if count <= 0 {
 		return nil, status.Errorf(codes.InvalidArgument, "invalid count %d, want > 0", count)
 	}
@@ -596,7 +730,7 @@ func (t *logTreeTX) getLeavesByRangeInternal(ctx context.Context, start, count i
 	// TODO(pavelkalinnikov): Further clip `count` to a safe upper bound like 64k.
 
 	args := []interface{}{start, start + count, t.treeID}
-	rows, err := t.tx.QueryContext(ctx, selectLeavesByRangeSQL, args...)
+	rows, err := t.tx.QueryContext(ctx, query, args...)
 	if err != nil {
 		glog.Warningf("Failed to get leaves by range: %s", err)
 		return nil, err
@@ -618,6 +752,9 @@ func (t *logTreeTX) getLeavesByRangeInternal(ctx context.Context, start, count i
 			glog.Warningf("Failed to scan merkle leaves: %s", err)
 			return nil, err
 		}
+		if err := t.openLeafHashes(leaf); err != nil {
+			return nil, err
+		}
 		if leaf.LeafIndex != wantIndex {
 			if wantIndex < int64(t.root.TreeSize) {
 				return nil, fmt.Errorf("got unexpected index %d, want %d", leaf.LeafIndex, wantIndex)
@@ -651,7 +788,7 @@ func (t *logTreeTX) GetLeavesByHash(ctx context.Context, leafHashes [][]byte, or
 		return nil, err
 	}
 
-	return t.getLeavesByHashInternal(ctx, leafHashes, tmpl, "merkle")
+	return t.getLeavesByHashInternal(ctx, leafHashes, merkleHashAD, tmpl, "merkle")
 }
 
 // getLeafDataByIdentityHash retrieves leaf data by LeafIdentityHash, returned
@@ -662,7 +799,7 @@ func (t *logTreeTX) getLeafDataByIdentityHash(ctx context.Context, leafHashes []
 	if err != nil {
 		return nil, err
 	}
-	return t.getLeavesByHashInternal(ctx, leafHashes, tmpl, "leaf-identity")
+	return t.getLeavesByHashInternal(ctx, leafHashes, identityHashAD, tmpl, "leaf-identity")
 }
 
 func (t *logTreeTX) LatestSignedLogRoot(ctx context.Context) (*trillian.SignedLogRoot, error) {
@@ -678,6 +815,7 @@ func (t *logTreeTX) LatestSignedLogRoot(ctx context.Context) (*trillian.SignedLo
 
 // fetchLatestRoot reads the latest root and the revision from the DB.
 func (t *logTreeTX) fetchLatestRoot(ctx context.Context) (*trillian.SignedLogRoot, int64, error) {
+	defer observeQuery(queryLatestRoot, time.Now())
 	var timestamp, treeSize, treeRevision int64
 	var rootHash, rootSignatureBytes []byte
 	if err := t.tx.QueryRowContext(
@@ -702,6 +840,7 @@ func (t *logTreeTX) fetchLatestRoot(ctx context.Context) (*trillian.SignedLogRoo
 }
 
 func (t *logTreeTX) StoreSignedLogRoot(ctx context.Context, root *trillian.SignedLogRoot) error {
+	defer observeQuery(queryUpdateRoot, time.Now())
 	t.treeTX.mu.Lock()
 	defer t.treeTX.mu.Unlock()
 
@@ -730,13 +869,16 @@ func (t *logTreeTX) StoreSignedLogRoot(ctx context.Context, root *trillian.Signe
 	return checkResultOkAndRowCountIs(res, err, 1)
 }
 
-func (t *logTreeTX) getLeavesByHashInternal(ctx context.Context, leafHashes [][]byte, tmpl *sql.Stmt, desc string) ([]*trillian.LogLeaf, error) {
+// getLeavesByHashInternal looks up leaves by the hashes in the column which
+// the statement queries, and ad is the associated data of that column.
+func (t *logTreeTX) getLeavesByHashInternal(ctx context.Context, leafHashes [][]byte, ad []byte, tmpl *sql.Stmt, desc string) ([]*trillian.LogLeaf, error) {
+	defer observeQuery(queryGetLeavesByHash, time.Now())
 	stx := t.tx.StmtContext(ctx, tmpl)
 	defer stx.Close()
 
 	var args []interface{}
 	for _, hash := range leafHashes {
-		args = append(args, []byte(hash))
+		args = append(args, t.sealHash(hash, ad))
 	}
 	args = append(args, t.treeID)
 	rows, err := stx.QueryContext(ctx, args...)
@@ -762,6 +904,9 @@ func (t *logTreeTX) getLeavesByHashInternal(ctx context.Context, leafHashes [][]
 			glog.Warningf("LogID: %d Scan() %s = %s", t.treeID, desc, err)
 			return nil, err
 		}
+		if err := t.openLeafHashes(leaf); err != nil {
+			return nil, err
+		}
 		leaf.QueueTimestamp = timestamppb.New(time.Unix(0, queueTS))
 		if err := leaf.QueueTimestamp.CheckValid(); err != nil {
 			return nil, fmt.Errorf("got invalid queue timestamp: %w", err)
@@ -787,6 +932,21 @@ func (t *logTreeTX) getLeavesByHashInternal(ctx context.Context, leafHashes [][]
 	return ret, nil
 }
 
+// openLeafHashes decrypts the hashes of a leaf read from the database, see
+// treeTX.openHash. The dummy MerkleLeafHash of a lookup by identity hash isn't
+// read from the database, so it's left as it is.
+func (t *logTreeTX) openLeafHashes(leaf *trillian.LogLeaf) error {
+	var err error
+	if leaf.LeafIdentityHash, err = t.openHash(leaf.LeafIdentityHash, identityHashAD); err != nil {
+		return err
+	}
+	if string(leaf.MerkleLeafHash) == dummyMerkleLeafHash {
+		return nil
+	}
+	leaf.MerkleLeafHash, err = t.openHash(leaf.MerkleLeafHash, merkleHashAD)
+	return err
+}
+
 // leafAndPosition records original position before sort.
 type leafAndPosition struct {
 	leaf *trillian.LogLeaf
@@ -808,3 +968,7 @@ func (l byLeafIdentityHashWithPosition) Swap(i, j int) {
 func (l byLeafIdentityHashWithPosition) Less(i, j int) bool {
 	return bytes.Compare(l[i].leaf.LeafIdentityHash, l[j].leaf.LeafIdentityHash) == -1
 }
+
+func spanFor(ctx context.Context, name string) (context.Context, func()) {
+	return monitoring.StartSpan(ctx, fmt.Sprintf("%s.%s", traceSpanRoot, name))
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/google/trillian"
 	"github.com/google/trillian/integration/storagetest"
 	"github.com/google/trillian/storage"
+	"github.com/google/trillian/storage/dedup"
 	"github.com/google/trillian/storage/testonly"
 	"github.com/google/trillian/types"
 	"google.golang.org/protobuf/proto"
@@ -254,6 +255,79 @@ func TestQueueLeavesDuplicateBigBatch(t *testing.T) {
 	}
 }
 
+func TestDedupFilter(t *testing.T) {
+	ctx := context.Background()
+
+	cleanTestDB(DB)
+	as := NewAdminStorage(DB)
+	tree := mustCreateTree(ctx, t, as, testonly.LogTree)
+	s := NewLogStorage(DB, nil)
+	mustSignAndStoreLogRoot(ctx, t, s, tree, 0)
+
+	// The filter is stored in more than one chunk.
+	f, err := dedup.New(1000000, 0.01)
+	if err != nil {
+		t.Fatalf("dedup.New(): %v", err)
+	}
+	stored := createTestLeaves(10, 0)
+	for _, leaf := range stored {
+		f.Add(leaf.LeafIdentityHash)
+	}
+	data, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary(): %v", err)
+	}
+	if len(data) <= dedupFilterChunkSize {
+		t.Fatalf("filter of %d bytes, want more than %d", len(data), dedupFilterChunkSize)
+	}
+	for _, want := range []*storage.DedupFilter{{TreeSize: 1, Filter: []byte("short")}, {TreeSize: 10, Filter: data}} {
+		runLogTX(s, tree, t, func(ctx context.Context, tx storage.LogTreeTX) error {
+			dtx, err := storage.AsDedupFilterTX(tx)
+			if err != nil {
+				return err
+			}
+			if err := dtx.StoreDedupFilter(ctx, want); err != nil {
+				return err
+			}
+			got, err := dtx.DedupFilter(ctx)
+			if err != nil {
+				return err
+			}
+			if !cmp.Equal(got, want) {
+				t.Errorf("DedupFilter(): got tree size %d and %d bytes, want %d and %d bytes", got.TreeSize, len(got.Filter), want.TreeSize, len(want.Filter))
+			}
+			return nil
+		})
+	}
+
+	// Leaves not held by the filter are inserted at once, unless one of them
+	// is a duplicate.
+	s.(*mySQLLogStorage).dedup = newDedupFilterCache(DB, time.Hour)
+	fresh := createTestLeaves(10, 10)
+	if _, err := s.QueueLeaves(ctx, tree, fresh[:3], fakeQueueTime); err != nil {
+		t.Fatalf("Failed to queue leaves: %v", err)
+	}
+	queued, err := s.QueueLeaves(ctx, tree, fresh, fakeQueueTime)
+	if err != nil {
+		t.Fatalf("Failed to queue leaves: %v", err)
+	}
+	for i, leaf := range queued {
+		if got, want := leaf.Status != nil, i < 3; got != want {
+			t.Errorf("QueueLeaves()[%d] duplicate = %v, want %v", i, got, want)
+		}
+	}
+	if _, err := s.QueueLeaves(ctx, tree, createTestLeaves(10, 20), fakeQueueTime); err != nil {
+		t.Fatalf("Failed to queue leaves: %v", err)
+	}
+	var count int
+	if err := DB.QueryRowContext(ctx, "SELECT COUNT(*) FROM Unsequenced WHERE TreeID=?", tree.TreeId).Scan(&count); err != nil {
+		t.Fatalf("Could not query row count: %v", err)
+	}
+	if got, want := count, 20; got != want {
+		t.Errorf("Got %d unsequenced rows, want %d", got, want)
+	}
+}
+
 // -----------------------------------------------------------------------------
 
 func TestDequeueLeavesHaveQueueTimestamp(t *testing.T) {
@@ -444,6 +518,78 @@ func TestGetLeavesByHash(t *testing.T) {
 	})
 }
 
+func TestGetLeafHashesByRange(t *testing.T) {
+	ctx := context.Background()
+
+	// Create fake leaf as if it had been sequenced
+	cleanTestDB(DB)
+	as := NewAdminStorage(DB)
+	tree := mustCreateTree(ctx, t, as, testonly.PreorderedLogTree)
+	s := NewLogStorage(DB, nil)
+
+	data := []byte("some data")
+	createFakeLeaf(ctx, DB, tree.TreeId, dummyRawHash, dummyHash, data, someExtraData, sequenceNumber, t)
+
+	runLogTX(s, tree, t, func(ctx context.Context, tx storage.LogTreeTX) error {
+		htx, err := storage.AsLeafHashesTX(tx)
+		if err != nil {
+			t.Fatalf("AsLeafHashesTX(): %v", err)
+		}
+		leaves, err := htx.GetLeafHashesByRange(ctx, sequenceNumber, 1)
+		if err != nil {
+			t.Fatalf("Unexpected error getting leaf hashes by range: %v", err)
+		}
+		if len(leaves) != 1 {
+			t.Fatalf("Got %d leaves but expected one", len(leaves))
+		}
+		checkLeafContents(leaves[0], sequenceNumber, dummyRawHash, dummyHash, nil, nil, t)
+		return nil
+	})
+}
+
+func TestUpdateLeafExtraDataSharedIdentityHash(t *testing.T) {
+	ctx := context.Background()
+
+	// Two sequenced leaves share the LeafData of one identity hash.
+	cleanTestDB(DB)
+	as := NewAdminStorage(DB)
+	tree := mustCreateTree(ctx, t, as, testonly.PreorderedLogTree)
+	s := NewLogStorage(DB, nil)
+
+	data := []byte("some data")
+	createFakeLeaf(ctx, DB, tree.TreeId, dummyRawHash, dummyHash, data, someExtraData, sequenceNumber, t)
+	if _, err := DB.ExecContext(ctx, "INSERT INTO SequencedLeafData(TreeId, SequenceNumber, LeafIdentityHash, MerkleLeafHash, IntegrateTimestampNanos) VALUES(?,?,?,?,?)",
+		tree.TreeId, sequenceNumber+1, dummyRawHash, dummyHash, fakeIntegrateTime.UnixNano()); err != nil {
+		t.Fatalf("Failed to create test leaf: %v", err)
+	}
+
+	// Updates within the same nanosecond are all recorded, for both leaves.
+	runLogTX(s, tree, t, func(ctx context.Context, tx storage.LogTreeTX) error {
+		atx, err := storage.AsLeafAnnotationTX(tx)
+		if err != nil {
+			t.Fatalf("AsLeafAnnotationTX(): %v", err)
+		}
+		for _, extra := range []string{"first", "second"} {
+			if _, err := atx.UpdateLeafExtraData(ctx, sequenceNumber, []byte(extra), "test", fakeIntegrateTime); err != nil {
+				t.Fatalf("UpdateLeafExtraData(%q): %v", extra, err)
+			}
+		}
+		for _, index := range []int64{sequenceNumber, sequenceNumber + 1} {
+			updates, err := atx.ListLeafExtraDataUpdates(ctx, index)
+			if err != nil {
+				t.Fatalf("ListLeafExtraDataUpdates(%d): %v", index, err)
+			}
+			if got, want := len(updates), 2; got != want {
+				t.Fatalf("ListLeafExtraDataUpdates(%d) returned %d updates, want %d", index, got, want)
+			}
+			if got, want := string(updates[1].ExtraData), "second"; got != want {
+				t.Errorf("ListLeafExtraDataUpdates(%d): last ExtraData %q, want %q", index, got, want)
+			}
+		}
+		return nil
+	})
+}
+
 func TestGetLeavesByHashBigBatch(t *testing.T) {
 	t.Skip("Known Issue: https://github.com/google/trillian/issues/1845")
 	ctx := context.Background()
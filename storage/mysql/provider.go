@@ -16,21 +16,32 @@ package mysql
 
 import (
 	"database/sql"
+	"encoding/hex"
+	"errors"
 	"flag"
+	"fmt"
+	"os"
+	"strings"
 	"sync"
 
 	"github.com/golang/glog"
 	"github.com/google/trillian/monitoring"
 	"github.com/google/trillian/storage"
+	"github.com/google/trillian/storage/hashenc"
 
 	// Load MySQL driver
 	_ "github.com/go-sql-driver/mysql"
 )
 
 var (
-	mySQLURI = flag.String("mysql_uri", "test:zaphod@tcp(127.0.0.1:3306)/test", "Connection URI for MySQL database")
-	maxConns = flag.Int("mysql_max_conns", 0, "Maximum connections to the database")
-	maxIdle  = flag.Int("mysql_max_idle_conns", -1, "Maximum idle database connections in the connection pool")
+	mySQLURI           = flag.String("mysql_uri", "test:zaphod@tcp(127.0.0.1:3306)/test", "Connection URI for MySQL database")
+	readURI            = flag.String("mysql_read_uri", "", "If set, connection URI of a read replica of the --mysql_uri database, which the snapshot reads of logs, e.g. of proofs, leaves and roots, are served from unless it lags behind. For logs with READ_OWN_WRITES consistency, the replica must have the latest root of the primary database, otherwise the latest root already read. Can't be used with --mysql_shard_uris")
+	maxConns           = flag.Int("mysql_max_conns", 0, "Maximum connections to the database")
+	maxIdle            = flag.Int("mysql_max_idle_conns", -1, "Maximum idle database connections in the connection pool")
+	shardURIs          = flag.String("mysql_shard_uris", "", "Comma-separated list of name=URI pairs of MySQL databases holding the leaf and node data of trees. If set, the database at --mysql_uri holds the tree metadata and the routing table of trees to these shards")
+	hashKey            = flag.String("mysql_hash_encryption_key_file", "", "File containing the hex-encoded master key used to encrypt the stored hashes of trees with encrypt_hashes set")
+	subtreeCacheSize   = flag.Int64("mysql_subtree_cache_size_bytes", 0, "If set, the total size of the subtrees read from the database which are cached across requests, so that e.g. the top subtrees needed by most proofs aren't read again for every one. The subtrees of a log are dropped once a newer root is read. Zero disables the cache")
+	dedupFilterRefresh = flag.Duration("mysql_dedup_filter_refresh_interval", 0, "Interval between reads of the dedup filters of logs, which the log signer maintains with --dedup_filter_capacity, to insert the queued leaves they don't hold with a single statement instead of one per leaf. Zero disables the use of the filters")
 
 	mysqlMu              sync.Mutex
 	mysqlErr             error
@@ -55,8 +66,11 @@ func init() {
 }
 
 type mysqlProvider struct {
-	db *sql.DB
-	mf monitoring.MetricFactory
+	db      *sql.DB
+	readDB  *sql.DB
+	shards  map[string]*sql.DB
+	mf      monitoring.MetricFactory
+	hashKey []byte
 }
 
 func newMySQLStorageProvider(mf monitoring.MetricFactory) (storage.Provider, error) {
@@ -67,9 +81,29 @@ func newMySQLStorageProvider(mf monitoring.MetricFactory) (storage.Provider, err
 		if err != nil {
 			return nil, err
 		}
+		key, err := readHashKey(*hashKey)
+		if err != nil {
+			return nil, err
+		}
+		shards, err := openShards(*shardURIs)
+		if err != nil {
+			return nil, err
+		}
+		var readDB *sql.DB
+		if *readURI != "" {
+			if shards != nil {
+				return nil, errors.New("--mysql_read_uri can't be used with --mysql_shard_uris")
+			}
+			if readDB, err = openDBWithLimits(*readURI); err != nil {
+				return nil, fmt.Errorf("failed to open read replica: %v", err)
+			}
+		}
 		mysqlStorageInstance = &mysqlProvider{
-			db: db,
-			mf: mf,
+			db:      db,
+			readDB:  readDB,
+			shards:  shards,
+			mf:      mf,
+			hashKey: key,
 		}
 	}
 	return mysqlStorageInstance, nil
@@ -81,29 +115,118 @@ func getMySQLDatabaseLocked() (*sql.DB, error) {
 	if mysqlDB != nil || mysqlErr != nil {
 		return mysqlDB, mysqlErr
 	}
-	db, err := OpenDB(*mySQLURI)
+	db, err := openDBWithLimits(*mySQLURI)
 	if err != nil {
 		mysqlErr = err
 		return nil, err
 	}
+	mysqlDB, mysqlErr = db, nil
+	return db, nil
+}
+
+// openDBWithLimits opens the database at the given URI, applying the
+// connection limits set by flags.
+func openDBWithLimits(uri string) (*sql.DB, error) {
+	db, err := OpenDB(uri)
+	if err != nil {
+		return nil, err
+	}
 	if *maxConns > 0 {
 		db.SetMaxOpenConns(*maxConns)
 	}
 	if *maxIdle >= 0 {
 		db.SetMaxIdleConns(*maxIdle)
 	}
-	mysqlDB, mysqlErr = db, nil
 	return db, nil
 }
 
+// openShards opens the shard databases in the given comma-separated list of
+// name=URI pairs, or returns nil if the list is empty.
+func openShards(list string) (map[string]*sql.DB, error) {
+	uris, err := parseShardURIs(list)
+	if err != nil || len(uris) == 0 {
+		return nil, err
+	}
+	shards := make(map[string]*sql.DB)
+	for name, uri := range uris {
+		db, err := openDBWithLimits(uri)
+		if err != nil {
+			for _, db := range shards {
+				db.Close()
+			}
+			return nil, fmt.Errorf("failed to open shard %q: %v", name, err)
+		}
+		shards[name] = db
+	}
+	return shards, nil
+}
+
+// parseShardURIs parses a comma-separated list of name=URI pairs.
+func parseShardURIs(list string) (map[string]string, error) {
+	uris := make(map[string]string)
+	if list == "" {
+		return uris, nil
+	}
+	for _, pair := range strings.Split(list, ",") {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid shard %q, want name=URI", pair)
+		}
+		name, uri := parts[0], parts[1]
+		if len(name) > maxShardNameLen {
+			return nil, fmt.Errorf("shard name %q longer than %d characters", name, maxShardNameLen)
+		}
+		if _, ok := uris[name]; ok {
+			return nil, fmt.Errorf("duplicate shard %q", name)
+		}
+		uris[name] = uri
+	}
+	return uris, nil
+}
+
+// readHashKey returns the hash encryption key in the given file, or nil if no
+// file is given.
+func readHashKey(path string) ([]byte, error) {
+	if path == "" {
+		return nil, nil
+	}
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read hash encryption key: %v", err)
+	}
+	key, err := hex.DecodeString(strings.TrimSpace(string(contents)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode hash encryption key: %v", err)
+	}
+	if len(key) < hashenc.MinKeySize {
+		return nil, fmt.Errorf("hash encryption key of %d bytes, want at least %d", len(key), hashenc.MinKeySize)
+	}
+	return key, nil
+}
+
 func (s *mysqlProvider) LogStorage() storage.LogStorage {
-	return NewLogStorage(s.db, s.mf)
+	if s.shards != nil {
+		return NewShardedLogStorage(s.db, s.shards, s.mf, s.hashKey)
+	}
+	if s.readDB != nil {
+		return NewLogStorageWithReadReplica(s.db, s.readDB, s.mf, s.hashKey)
+	}
+	return NewLogStorageWithHashKey(s.db, s.mf, s.hashKey)
 }
 
 func (s *mysqlProvider) AdminStorage() storage.AdminStorage {
+	if s.shards != nil {
+		return NewShardedAdminStorage(s.db, s.shards, s.mf)
+	}
 	return NewAdminStorage(s.db)
 }
 
 func (s *mysqlProvider) Close() error {
+	for _, db := range s.shards {
+		db.Close()
+	}
+	if s.readDB != nil {
+		s.readDB.Close()
+	}
 	return s.db.Close()
 }
@@ -0,0 +1,181 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/trillian"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	selectQueuedLeafSQL = `SELECT MerkleLeafHash,QueueTimestampNanos
+			FROM Unsequenced
+			WHERE TreeId=? AND Bucket=0 AND LeafIdentityHash=?`
+	deleteQueuedLeafSQL      = "DELETE FROM Unsequenced WHERE TreeId=? AND Bucket=0 AND QueueTimestampNanos=? AND LeafIdentityHash=?"
+	insertQuarantinedLeafSQL = `INSERT INTO QuarantinedLeaves(TreeId,LeafIdentityHash,MerkleLeafHash,QueueTimestampNanos,QuarantineTimestampNanos,Reason)
+			VALUES(?,?,?,?,?,?)`
+	selectQuarantinedLeavesSQL = `SELECT LeafIdentityHash,MerkleLeafHash,QueueTimestampNanos,QuarantineTimestampNanos,Reason
+			FROM QuarantinedLeaves
+			WHERE TreeId=?`
+	selectQuarantinedLeafSQL  = selectQuarantinedLeavesSQL + " AND LeafIdentityHash=?"
+	orderQuarantinedLeavesSQL = " ORDER BY QueueTimestampNanos,LeafIdentityHash"
+	deleteQuarantinedLeafSQL  = "DELETE FROM QuarantinedLeaves WHERE TreeId=? AND LeafIdentityHash=?"
+	maxReasonLength           = 1024
+	reasonTruncatedMark       = "..."
+)
+
+// QuarantineLeaves implements storage.QuarantineTX.
+func (t *logTreeTX) QuarantineLeaves(ctx context.Context, leafIdentityHashes [][]byte, reason string, now time.Time) ([]*trillian.QuarantinedLeaf, error) {
+	t.treeTX.mu.Lock()
+	defer t.treeTX.mu.Unlock()
+
+	reason = truncateReason(reason)
+	ret := make([]*trillian.QuarantinedLeaf, 0, len(leafIdentityHashes))
+	for _, hash := range leafIdentityHashes {
+		storedHash := t.sealHash(hash, identityHashAD)
+		var storedMerkleHash []byte
+		var queueTimestamp int64
+		err := t.tx.QueryRowContext(ctx, selectQueuedLeafSQL, t.treeID, storedHash).Scan(&storedMerkleHash, &queueTimestamp)
+		if err == sql.ErrNoRows {
+			continue
+		} else if err != nil {
+			return nil, mysqlToGRPC(err)
+		}
+		merkleHash, err := t.openHash(storedMerkleHash, merkleHashAD)
+		if err != nil {
+			return nil, err
+		}
+		res, err := t.tx.ExecContext(ctx, deleteQueuedLeafSQL, t.treeID, queueTimestamp, storedHash)
+		if err := checkResultOkAndRowCountIs(res, err, 1); err != nil {
+			return nil, err
+		}
+		if _, err := t.tx.ExecContext(ctx, insertQuarantinedLeafSQL, t.treeID, storedHash, storedMerkleHash, queueTimestamp, now.UnixNano(), reason); err != nil {
+			return nil, mysqlToGRPC(err)
+		}
+		ret = append(ret, &trillian.QuarantinedLeaf{
+			LeafIdentityHash:    hash,
+			MerkleLeafHash:      merkleHash,
+			QueueTimestamp:      timestamppb.New(time.Unix(0, queueTimestamp)),
+			QuarantineTimestamp: timestamppb.New(now),
+			Reason:              reason,
+		})
+	}
+	return ret, nil
+}
+
+// ListQuarantinedLeaves implements storage.QuarantineTX.
+func (t *logTreeTX) ListQuarantinedLeaves(ctx context.Context) ([]*trillian.QuarantinedLeaf, error) {
+	t.treeTX.mu.Lock()
+	defer t.treeTX.mu.Unlock()
+
+	rows, err := t.tx.QueryContext(ctx, selectQuarantinedLeavesSQL+orderQuarantinedLeavesSQL, t.treeID)
+	if err != nil {
+		return nil, mysqlToGRPC(err)
+	}
+	defer rows.Close()
+	var ret []*trillian.QuarantinedLeaf
+	for rows.Next() {
+		leaf, err := t.scanQuarantinedLeaf(rows)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, leaf)
+	}
+	return ret, rows.Err()
+}
+
+// RequeueQuarantinedLeaves implements storage.QuarantineTX.
+func (t *logTreeTX) RequeueQuarantinedLeaves(ctx context.Context, leafIdentityHashes [][]byte) ([]*trillian.QuarantinedLeaf, error) {
+	var leaves []*trillian.QuarantinedLeaf
+	if len(leafIdentityHashes) == 0 {
+		all, err := t.ListQuarantinedLeaves(ctx)
+		if err != nil {
+			return nil, err
+		}
+		leaves = all
+	}
+
+	t.treeTX.mu.Lock()
+	defer t.treeTX.mu.Unlock()
+
+	for _, hash := range leafIdentityHashes {
+		leaf, err := t.scanQuarantinedLeaf(t.tx.QueryRowContext(ctx, selectQuarantinedLeafSQL, t.treeID, t.sealHash(hash, identityHashAD)))
+		if err == sql.ErrNoRows {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		leaves = append(leaves, leaf)
+	}
+
+	for _, leaf := range leaves {
+		storedHash := t.sealHash(leaf.LeafIdentityHash, identityHashAD)
+		res, err := t.tx.ExecContext(ctx, deleteQuarantinedLeafSQL, t.treeID, storedHash)
+		if err := checkResultOkAndRowCountIs(res, err, 1); err != nil {
+			return nil, err
+		}
+		args := []interface{}{t.treeID, storedHash, t.sealHash(leaf.MerkleLeafHash, merkleHashAD)}
+		args = append(args, queueArgs(t.treeID, storedHash, leaf.QueueTimestamp.AsTime())...)
+		if _, err := t.tx.ExecContext(ctx, insertUnsequencedEntrySQL, args...); err != nil {
+			return nil, mysqlToGRPC(err)
+		}
+	}
+	return leaves, nil
+}
+
+// truncateReason shortens reason to fit into the Reason columns.
+func truncateReason(reason string) string {
+	if len(reason) > maxReasonLength {
+		return reason[:maxReasonLength-len(reasonTruncatedMark)] + reasonTruncatedMark
+	}
+	return reason
+}
+
+// scanner is satisfied by both *sql.Row and *sql.Rows.
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func (t *logTreeTX) scanQuarantinedLeaf(row scanner) (*trillian.QuarantinedLeaf, error) {
+	var identityHash, merkleHash []byte
+	var queueTimestamp, quarantineTimestamp int64
+	var reason string
+	if err := row.Scan(&identityHash, &merkleHash, &queueTimestamp, &quarantineTimestamp, &reason); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to scan quarantined leaf: %v", err)
+	}
+	identityHash, err := t.openHash(identityHash, identityHashAD)
+	if err != nil {
+		return nil, err
+	}
+	merkleHash, err = t.openHash(merkleHash, merkleHashAD)
+	if err != nil {
+		return nil, err
+	}
+	return &trillian.QuarantinedLeaf{
+		LeafIdentityHash:    identityHash,
+		MerkleLeafHash:      merkleHash,
+		QueueTimestamp:      timestamppb.New(time.Unix(0, queueTimestamp)),
+		QuarantineTimestamp: timestamppb.New(time.Unix(0, quarantineTimestamp)),
+		Reason:              reason,
+	}, nil
+}
@@ -69,11 +69,21 @@ func (t *logTreeTX) dequeueLeaf(rows *sql.Rows) (*trillian.LogLeaf, dequeuedLeaf
 	if err := queueTimestampProto.CheckValid(); err != nil {
 		return nil, dequeuedLeaf{}, fmt.Errorf("got invalid queue timestamp: %w", err)
 	}
+	identityHash, err := t.openHash(leafIDHash, identityHashAD)
+	if err != nil {
+		return nil, dequeuedLeaf{}, err
+	}
+	merkleHash, err = t.openHash(merkleHash, merkleHashAD)
+	if err != nil {
+		return nil, dequeuedLeaf{}, err
+	}
 	leaf := &trillian.LogLeaf{
-		LeafIdentityHash: leafIDHash,
+		LeafIdentityHash: identityHash,
 		MerkleLeafHash:   merkleHash,
 		QueueTimestamp:   queueTimestampProto,
 	}
+	// The stored identity hash is remembered, because that is what
+	// removeSequencedLeaves needs to delete the entry.
 	return leaf, dequeueInfo(leafIDHash, queueTimestamp), nil
 }
 
@@ -82,6 +92,7 @@ func queueArgs(_ int64, _ []byte, queueTimestamp time.Time) []interface{} {
 }
 
 func (t *logTreeTX) UpdateSequencedLeaves(ctx context.Context, leaves []*trillian.LogLeaf) error {
+	defer observeQuery(queryUpdateSequencedLeaves, time.Now())
 	dequeuedLeaves := make([]dequeuedLeaf, 0, len(leaves))
 	for _, leaf := range leaves {
 		// This should fail on insert but catch it early
@@ -97,8 +108,8 @@ func (t *logTreeTX) UpdateSequencedLeaves(ctx context.Context, leaves []*trillia
 			ctx,
 			insertSequencedLeafSQL+valuesPlaceholder5,
 			t.treeID,
-			leaf.LeafIdentityHash,
-			leaf.MerkleLeafHash,
+			t.sealHash(leaf.LeafIdentityHash, identityHashAD),
+			t.sealHash(leaf.MerkleLeafHash, merkleHashAD),
 			leaf.LeafIndex,
 			iTimestamp.UnixNano())
 		if err != nil {
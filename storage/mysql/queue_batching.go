@@ -65,6 +65,14 @@ func (t *logTreeTX) dequeueLeaf(rows *sql.Rows) (*trillian.LogLeaf, dequeuedLeaf
 	if err := queueTimestampProto.CheckValid(); err != nil {
 		return nil, dequeuedLeaf{}, fmt.Errorf("got invalid queue timestamp: %w", err)
 	}
+	leafIDHash, err = t.openHash(leafIDHash, identityHashAD)
+	if err != nil {
+		return nil, nil, err
+	}
+	merkleHash, err = t.openHash(merkleHash, merkleHashAD)
+	if err != nil {
+		return nil, nil, err
+	}
 	// Note: the LeafData and ExtraData being nil here is OK as this is only used by the
 	// sequencer. The sequencer only writes to the SequencedLeafData table and the client
 	// supplied data was already written to LeafData as part of queueing the leaf.
@@ -94,6 +102,7 @@ func queueArgs(treeID int64, identityHash []byte, queueTimestamp time.Time) []in
 }
 
 func (t *logTreeTX) UpdateSequencedLeaves(ctx context.Context, leaves []*trillian.LogLeaf) error {
+	defer observeQuery(queryUpdateSequencedLeaves, time.Now())
 	querySuffix := []string{}
 	args := []interface{}{}
 	dequeuedLeaves := make([]dequeuedLeaf, 0, len(leaves))
@@ -103,7 +112,7 @@ func (t *logTreeTX) UpdateSequencedLeaves(ctx context.Context, leaves []*trillia
 		}
 		iTimestamp := leaf.IntegrateTimestamp.AsTime()
 		querySuffix = append(querySuffix, valuesPlaceholder5)
-		args = append(args, t.treeID, leaf.LeafIdentityHash, leaf.MerkleLeafHash, leaf.LeafIndex, iTimestamp.UnixNano())
+		args = append(args, t.treeID, t.sealHash(leaf.LeafIdentityHash, identityHashAD), t.sealHash(leaf.MerkleLeafHash, merkleHashAD), leaf.LeafIndex, iTimestamp.UnixNano())
 		qe, ok := t.dequeued[string(leaf.LeafIdentityHash)]
 		if !ok {
 			return fmt.Errorf("attempting to update leaf that wasn't dequeued. IdentityHash: %x", leaf.LeafIdentityHash)
@@ -0,0 +1,55 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/trillian"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const selectQueuedLeavesForExportSQL = `SELECT u.LeafIdentityHash,u.MerkleLeafHash,u.QueueTimestampNanos,l.LeafValue,l.ExtraData
+			FROM Unsequenced u JOIN LeafData l ON u.TreeId=l.TreeId AND u.LeafIdentityHash=l.LeafIdentityHash
+			WHERE u.TreeId=? AND u.Bucket=0
+			ORDER BY u.QueueTimestampNanos,u.LeafIdentityHash`
+
+// ListQueuedLeaves implements storage.QueueExportTX.
+func (t *logTreeTX) ListQueuedLeaves(ctx context.Context) ([]*trillian.LogLeaf, error) {
+	t.treeTX.mu.Lock()
+	defer t.treeTX.mu.Unlock()
+
+	rows, err := t.tx.QueryContext(ctx, selectQueuedLeavesForExportSQL, t.treeID)
+	if err != nil {
+		return nil, mysqlToGRPC(err)
+	}
+	defer rows.Close()
+	var ret []*trillian.LogLeaf
+	for rows.Next() {
+		leaf := &trillian.LogLeaf{}
+		var queueTimestamp int64
+		if err := rows.Scan(&leaf.LeafIdentityHash, &leaf.MerkleLeafHash, &queueTimestamp, &leaf.LeafValue, &leaf.ExtraData); err != nil {
+			return nil, fmt.Errorf("failed to scan queued leaf: %v", err)
+		}
+		if err := t.openLeafHashes(leaf); err != nil {
+			return nil, err
+		}
+		leaf.QueueTimestamp = timestamppb.New(time.Unix(0, queueTimestamp))
+		ret = append(ret, leaf)
+	}
+	return ret, rows.Err()
+}
@@ -0,0 +1,68 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/trillian/storage"
+)
+
+const (
+	selectQueueStatsSQL = `SELECT COUNT(*),COALESCE(MIN(QueueTimestampNanos),0),COALESCE(MAX(QueueTimestampNanos),0)
+			FROM Unsequenced
+			WHERE TreeId=? AND Bucket=0`
+	selectQueueMedianSQL = `SELECT QueueTimestampNanos
+			FROM Unsequenced
+			WHERE TreeId=? AND Bucket=0
+			ORDER BY QueueTimestampNanos LIMIT 1 OFFSET ?`
+
+	// queueMedianSample is the maximum number of leaves at the head of the
+	// queue from which the median is taken, as the index is walked up to it.
+	queueMedianSample = 10000
+)
+
+// QueueStats implements storage.QueueStatsTX.
+//
+// The median is that of the queueMedianSample oldest leaves, which is exact
+// for smaller queues. For larger ones it is older than the median of the
+// whole queue, i.e. the reported median age is an upper bound.
+func (t *logTreeTX) QueueStats(ctx context.Context) (*storage.QueueStats, error) {
+	t.treeTX.mu.Lock()
+	defer t.treeTX.mu.Unlock()
+
+	var size, oldest, newest int64
+	if err := t.tx.QueryRowContext(ctx, selectQueueStatsSQL, t.treeID).Scan(&size, &oldest, &newest); err != nil {
+		return nil, mysqlToGRPC(err)
+	}
+	if size == 0 {
+		return &storage.QueueStats{}, nil
+	}
+	sample := size
+	if sample > queueMedianSample {
+		sample = queueMedianSample
+	}
+	var median int64
+	if err := t.tx.QueryRowContext(ctx, selectQueueMedianSQL, t.treeID, sample/2).Scan(&median); err != nil {
+		return nil, mysqlToGRPC(err)
+	}
+	return &storage.QueueStats{
+		Size:   size,
+		Oldest: time.Unix(0, oldest),
+		Median: time.Unix(0, median),
+		Newest: time.Unix(0, newest),
+	}, nil
+}
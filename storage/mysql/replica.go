@@ -0,0 +1,125 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian"
+	"github.com/google/trillian/monitoring"
+	"github.com/google/trillian/storage"
+)
+
+const selectLatestRevisionSQL = `SELECT TreeRevision FROM TreeHead WHERE TreeId=?
+			ORDER BY TreeHeadTimestamp DESC LIMIT 1`
+
+// Values of the source label of the snapshotCounter metric.
+const (
+	replicaSource      = "replica"
+	staleReplicaSource = "primary_stale_replica"
+	replicaErrSource   = "primary_replica_error"
+)
+
+// NewLogStorageWithReadReplica is like NewLogStorageWithHashKey, but the
+// snapshots of trees read from readDB, a replica of db, unless it lags behind
+// the latest root of the tree:
+//   - For trees with READ_OWN_WRITES consistency, the revision of the latest
+//     root is read from db for every snapshot.
+//   - For trees with EVENTUAL consistency, it's the latest revision read by a
+//     snapshot of this storage, so that the roots read don't go back in time.
+//
+// Snapshots of a lagging replica, or which fail to start, read from db.
+// Read-write transactions always use db.
+func NewLogStorageWithReadReplica(db, readDB *sql.DB, mf monitoring.MetricFactory, hashKey []byte) storage.LogStorage {
+	m := NewLogStorageWithHashKey(db, mf, hashKey).(*mySQLLogStorage)
+	replica := NewLogStorageWithHashKey(readDB, mf, hashKey).(*mySQLLogStorage)
+	// The subtrees at a revision are the same in both databases.
+	replica.tiles = m.tiles
+	m.replica = &readReplica{ls: replica, seen: make(map[int64]int64)}
+	return m
+}
+
+// readReplica is the read replica of a mySQLLogStorage.
+type readReplica struct {
+	ls *mySQLLogStorage
+
+	// seen is the latest revision read by a snapshot of each tree.
+	mu   sync.Mutex
+	seen map[int64]int64
+}
+
+// see records that a snapshot of the tree read the revision.
+func (r *readReplica) see(treeID, rev int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if rev > r.seen[treeID] {
+		r.seen[treeID] = rev
+	}
+}
+
+// minRevision returns the oldest revision of the tree which snapshots may
+// read.
+func (m *mySQLLogStorage) minRevision(ctx context.Context, tree *trillian.Tree) (int64, error) {
+	if tree.ReadConsistency == trillian.ReadConsistency_EVENTUAL {
+		m.replica.mu.Lock()
+		defer m.replica.mu.Unlock()
+		return m.replica.seen[tree.TreeId], nil
+	}
+	defer observeQuery(queryLatestRevision, time.Now())
+	var rev int64
+	if err := m.db.QueryRowContext(ctx, selectLatestRevisionSQL, tree.TreeId).Scan(&rev); err != nil && err != sql.ErrNoRows {
+		return 0, err
+	}
+	return rev, nil
+}
+
+// replicaSnapshot returns a snapshot of the tree read from the replica, or
+// nil if it must be read from the primary database.
+func (m *mySQLLogStorage) replicaSnapshot(ctx context.Context, tree *trillian.Tree) *logTreeTX {
+	once.Do(func() {
+		createMetrics(m.metricFactory)
+	})
+	label := strconv.FormatInt(tree.TreeId, 10)
+	minRev, err := m.minRevision(ctx, tree)
+	if err != nil {
+		glog.Warningf("%v: failed to read latest revision: %v", tree.TreeId, err)
+		snapshotCounter.Inc(label, replicaErrSource)
+		return nil
+	}
+	tx, err := m.replica.ls.beginInternal(ctx, tree)
+	switch {
+	case err != nil:
+		if tx != nil {
+			tx.Close()
+		}
+		if err != storage.ErrTreeNeedsInit {
+			glog.Warningf("%v: failed to begin replica snapshot: %v", tree.TreeId, err)
+		}
+		snapshotCounter.Inc(label, replicaErrSource)
+		return nil
+	case tx.readRev < minRev:
+		tx.Close()
+		snapshotCounter.Inc(label, staleReplicaSource)
+		return nil
+	}
+	m.replica.see(tree.TreeId, tx.readRev)
+	snapshotCounter.Inc(label, replicaSource)
+	return tx
+}
@@ -0,0 +1,89 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/storage/testonly"
+	"github.com/google/trillian/types"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestReadReplica(t *testing.T) {
+	ctx := context.Background()
+	cleanTestDB(DB)
+	replicaDB, done := openTestDBOrDie()
+	defer done(ctx)
+
+	tree := mustCreateTree(ctx, t, NewAdminStorage(DB), testonly.LogTree)
+	mustCreateTree(ctx, t, NewAdminStorage(replicaDB), tree)
+	eventual := proto.Clone(tree).(*trillian.Tree)
+	eventual.ReadConsistency = trillian.ReadConsistency_EVENTUAL
+	primary, replica := NewLogStorage(DB, nil), NewLogStorage(replicaDB, nil)
+
+	// storeRoot stores a root of the given size in s, at the next revision.
+	// The hash tells which database a root was read from.
+	storeRoot := func(s storage.LogStorage, size uint64, hash string) {
+		t.Helper()
+		root, err := SignLogRoot(&types.LogRootV1{TimestampNanos: size, TreeSize: size, RootHash: []byte(hash)})
+		if err != nil {
+			t.Fatalf("SignLogRoot(): %v", err)
+		}
+		runLogTX(s, tree, t, func(ctx context.Context, tx storage.LogTreeTX) error {
+			return tx.StoreSignedLogRoot(ctx, root)
+		})
+	}
+	// checkRoot checks the latest root read by a snapshot of s.
+	checkRoot := func(desc string, s storage.LogStorage, tree *trillian.Tree, wantSize uint64, wantHash string) {
+		t.Helper()
+		tx, err := s.SnapshotForTree(ctx, tree)
+		if err != nil {
+			t.Fatalf("%s: SnapshotForTree(): %v", desc, err)
+		}
+		defer tx.Close()
+		slr, err := tx.LatestSignedLogRoot(ctx)
+		if err != nil {
+			t.Fatalf("%s: LatestSignedLogRoot(): %v", desc, err)
+		}
+		var root types.LogRootV1
+		if err := root.UnmarshalBinary(slr.LogRoot); err != nil {
+			t.Fatalf("%s: UnmarshalBinary(): %v", desc, err)
+		}
+		if root.TreeSize != wantSize || string(root.RootHash) != wantHash {
+			t.Errorf("%s: got root of size %d and hash %q, want size %d and hash %q", desc, root.TreeSize, root.RootHash, wantSize, wantHash)
+		}
+		commit(ctx, tx, t)
+	}
+
+	s := NewLogStorageWithReadReplica(DB, replicaDB, nil, nil)
+	storeRoot(primary, 1, "primary")
+	checkRoot("uninitialised replica", s, tree, 1, "primary")
+
+	storeRoot(replica, 1, "replica")
+	checkRoot("up-to-date replica", s, tree, 1, "replica")
+
+	storeRoot(primary, 2, "primary")
+	checkRoot("lagging replica", s, tree, 2, "primary")
+	// The latest root read is from the primary database now.
+	checkRoot("lagging replica, eventual", s, eventual, 2, "primary")
+	checkRoot("lagging replica, eventual, new storage", NewLogStorageWithReadReplica(DB, replicaDB, nil, nil), eventual, 1, "replica")
+
+	storeRoot(replica, 2, "replica")
+	checkRoot("caught up replica", s, tree, 2, "replica")
+}
@@ -0,0 +1,76 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// Pruned leaves keep their LeafData row, which the SequencedLeafData row
+	// references, with an empty LeafValue and no ExtraData.
+	selectPrunableLeavesSQL = `SELECT s.LeafIdentityHash
+			FROM SequencedLeafData s JOIN LeafData l ON s.TreeId=l.TreeId AND s.LeafIdentityHash=l.LeafIdentityHash
+			WHERE s.TreeId=? AND s.IntegrateTimestampNanos<? AND (LENGTH(l.LeafValue)>0 OR l.ExtraData IS NOT NULL)
+			ORDER BY s.SequenceNumber
+			LIMIT ?`
+	pruneLeafDataSQL = "UPDATE LeafData SET LeafValue='',ExtraData=NULL WHERE TreeId=? AND LeafIdentityHash=?"
+)
+
+// PruneLeafData implements storage.RetentionTX.
+func (t *logTreeTX) PruneLeafData(ctx context.Context, cutoff time.Time, limit int) (int, error) {
+	t.treeTX.mu.Lock()
+	defer t.treeTX.mu.Unlock()
+
+	hashes, err := t.prunableLeaves(ctx, cutoff, limit)
+	if err != nil {
+		return 0, err
+	}
+	pruned := 0
+	for _, hash := range hashes {
+		res, err := t.tx.ExecContext(ctx, pruneLeafDataSQL, t.treeID, hash)
+		if err != nil {
+			return 0, mysqlToGRPC(err)
+		}
+		// A leaf sequenced more than once is only pruned the first time.
+		n, err := res.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		pruned += int(n)
+	}
+	return pruned, nil
+}
+
+// prunableLeaves returns the stored identity hashes of up to limit sequenced
+// leaves integrated before cutoff, whose data hasn't been pruned yet.
+func (t *logTreeTX) prunableLeaves(ctx context.Context, cutoff time.Time, limit int) ([][]byte, error) {
+	rows, err := t.tx.QueryContext(ctx, selectPrunableLeavesSQL, t.treeID, cutoff.UnixNano(), limit)
+	if err != nil {
+		return nil, mysqlToGRPC(err)
+	}
+	defer rows.Close()
+	var hashes [][]byte
+	for rows.Next() {
+		// The stored identity hash is used as is, sealed or not.
+		var hash []byte
+		if err := rows.Scan(&hash); err != nil {
+			return nil, err
+		}
+		hashes = append(hashes, hash)
+	}
+	return hashes, rows.Err()
+}
@@ -22,6 +22,19 @@ CREATE TABLE IF NOT EXISTS Trees(
   PublicKey             MEDIUMBLOB NOT NULL,
   Deleted               BOOLEAN,
   DeleteTimeMillis      BIGINT,
+  SequencedLeafConflictPolicy  ENUM('CONFLICT_FAIL', 'CONFLICT_SKIP', 'CONFLICT_OVERWRITE_IF_IDENTICAL') NOT NULL DEFAULT 'CONFLICT_FAIL',
+  SequencedLeafDuplicateWindow BIGINT NOT NULL DEFAULT 0,
+  ReadConsistency       ENUM('READ_OWN_WRITES', 'EVENTUAL') NOT NULL DEFAULT 'READ_OWN_WRITES',
+  EncryptHashes         BOOLEAN NOT NULL DEFAULT FALSE,
+  MaxMergeDelayMillis   BIGINT NOT NULL DEFAULT 0,
+  LeafSchema            MEDIUMBLOB,
+  IntegrationPause      MEDIUMBLOB,
+  QuotaProfile          VARCHAR(64),
+  RetentionPolicy       MEDIUMBLOB,
+  Operator              MEDIUMBLOB,
+  TreeQuota             MEDIUMBLOB,
+  StorageCaps           MEDIUMBLOB,
+  SignJSONLogRoots      BOOLEAN NOT NULL DEFAULT FALSE,
   PRIMARY KEY(TreeId)
 );
 
@@ -36,6 +49,17 @@ CREATE TABLE IF NOT EXISTS TreeControl(
   FOREIGN KEY(TreeId) REFERENCES Trees(TreeId) ON DELETE CASCADE
 );
 
+-- Routing table of trees to the shard databases holding their leaf and node
+-- data. It's only used in the metadata database of sharded deployments, see
+-- --mysql_shard_uris. Trees without a row keep their data in the metadata
+-- database.
+CREATE TABLE IF NOT EXISTS TreeShards(
+  TreeId               BIGINT NOT NULL,
+  Shard                VARCHAR(64) NOT NULL,
+  PRIMARY KEY(TreeId),
+  FOREIGN KEY(TreeId) REFERENCES Trees(TreeId) ON DELETE CASCADE
+);
+
 CREATE TABLE IF NOT EXISTS Subtree(
   TreeId               BIGINT NOT NULL,
   SubtreeId            VARBINARY(255) NOT NULL,
@@ -135,3 +159,83 @@ CREATE TABLE IF NOT EXISTS Unsequenced(
   QueueID VARBINARY(32) DEFAULT NULL UNIQUE,
   PRIMARY KEY (TreeId, Bucket, QueueTimestampNanos, LeafIdentityHash)
 );
+
+-- Leaves which have been taken out of the Unsequenced queue by an operator (or
+-- the sequencer) because they couldn't be integrated. They can be put back into
+-- the queue with their original QueueTimestampNanos.
+CREATE TABLE IF NOT EXISTS QuarantinedLeaves(
+  TreeId                   BIGINT NOT NULL,
+  LeafIdentityHash         VARBINARY(255) NOT NULL,
+  MerkleLeafHash           VARBINARY(255) NOT NULL,
+  QueueTimestampNanos      BIGINT NOT NULL,
+  QuarantineTimestampNanos BIGINT NOT NULL,
+  Reason                   VARCHAR(1024) NOT NULL,
+  PRIMARY KEY (TreeId, LeafIdentityHash),
+  FOREIGN KEY(TreeId) REFERENCES Trees(TreeId) ON DELETE CASCADE
+);
+
+-- Audit trail of the updates of the ExtraData of sequenced leaves. ExtraData
+-- isn't covered by the Merkle leaf hash, so personalities may update it after
+-- the leaf has been integrated.
+CREATE TABLE IF NOT EXISTS LeafExtraDataUpdates(
+  TreeId               BIGINT NOT NULL,
+  SequenceNumber       BIGINT UNSIGNED NOT NULL,
+  -- Orders the updates of the leaf, as their timestamps may be equal.
+  UpdateSequence       BIGINT NOT NULL,
+  UpdateTimestampNanos BIGINT NOT NULL,
+  LeafIdentityHash     VARBINARY(255) NOT NULL,
+  PreviousExtraData    LONGBLOB,
+  ExtraData            LONGBLOB,
+  Reason               VARCHAR(1024) NOT NULL,
+  PRIMARY KEY (TreeId, SequenceNumber, UpdateSequence),
+  FOREIGN KEY(TreeId) REFERENCES Trees(TreeId) ON DELETE CASCADE
+);
+
+-- Per-day counters of the leaves integrated into a log, maintained by the
+-- sequencer so that reporting doesn't require scanning the leaf tables.
+CREATE TABLE IF NOT EXISTS DailyLogStats(
+  TreeId               BIGINT NOT NULL,
+  -- The day (UTC), as the number of days since the Unix epoch.
+  Day                  BIGINT NOT NULL,
+  LeavesAdded          BIGINT NOT NULL,
+  LeafValueBytes       BIGINT NOT NULL,
+  PRIMARY KEY (TreeId, Day),
+  FOREIGN KEY(TreeId) REFERENCES Trees(TreeId) ON DELETE CASCADE
+);
+
+-- Reports of clients which failed to verify data served by a log, e.g. a proof
+-- which doesn't match their trusted root.
+CREATE TABLE IF NOT EXISTS VerificationFailureReports(
+  TreeId               BIGINT NOT NULL,
+  ReportTimestampNanos BIGINT NOT NULL,
+  RequestId            VARCHAR(255) NOT NULL,
+  Failure              ENUM('UNKNOWN_VERIFICATION_FAILURE', 'INCLUSION_PROOF_MISMATCH', 'CONSISTENCY_PROOF_MISMATCH', 'LOG_ROOT_MISMATCH', 'LEAF_HASH_MISMATCH') NOT NULL,
+  LogRoot              BLOB,
+  Details              VARCHAR(1024) NOT NULL,
+  PRIMARY KEY (TreeId, ReportTimestampNanos, RequestId),
+  FOREIGN KEY(TreeId) REFERENCES Trees(TreeId) ON DELETE CASCADE
+);
+
+-- Cosignatures of witnesses over the checkpoints of a log. The signatures are
+-- base64 encoded, as in the signature lines of signed notes.
+CREATE TABLE IF NOT EXISTS Cosignatures(
+  TreeId               BIGINT NOT NULL,
+  TreeSize             BIGINT NOT NULL,
+  RootHash             VARBINARY(255) NOT NULL,
+  Witness              VARCHAR(255) NOT NULL,
+  Signature            VARCHAR(1024) NOT NULL,
+  PRIMARY KEY (TreeId, TreeSize, Witness),
+  FOREIGN KEY(TreeId) REFERENCES Trees(TreeId) ON DELETE CASCADE
+);
+
+-- Bloom filters of the identity hashes of the leaves of logs, maintained by
+-- the sequencer, see package storage/dedup. A filter is split into chunks,
+-- which all record the number of leaves added to it.
+CREATE TABLE IF NOT EXISTS DedupFilters(
+  TreeId               BIGINT NOT NULL,
+  ChunkIndex           INTEGER NOT NULL,
+  TreeSize             BIGINT NOT NULL,
+  Data                 MEDIUMBLOB NOT NULL,
+  PRIMARY KEY (TreeId, ChunkIndex),
+  FOREIGN KEY(TreeId) REFERENCES Trees(TreeId) ON DELETE CASCADE
+);
@@ -0,0 +1,369 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian"
+	"github.com/google/trillian/monitoring"
+	"github.com/google/trillian/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxShardNameLen is the size of the Shard column of TreeShards.
+	maxShardNameLen = 64
+
+	selectTreeShardSQL   = "SELECT Shard FROM TreeShards WHERE TreeId = ?"
+	insertTreeShardSQL   = "INSERT INTO TreeShards(TreeId, Shard) VALUES(?, ?)"
+	countTreesByShardSQL = "SELECT Shard, COUNT(*) FROM TreeShards GROUP BY Shard"
+)
+
+var (
+	shardMetricsOnce sync.Once
+	// shardDeleteFailures counts the hard deleted trees whose data couldn't
+	// be deleted from their shard, by shard.
+	shardDeleteFailures monitoring.Counter
+)
+
+// NewShardedAdminStorage returns a MySQL storage.AdminStorage which keeps the
+// metadata of trees in the meta database, and the leaf and node data of each
+// new tree in one of the given shard databases, keyed by name. Trees are
+// assigned to the shard with the fewest trees, and the assignment is recorded
+// in the TreeShards routing table of the meta database.
+//
+// All databases use the same schema. The data tables of a shard reference its
+// Trees table, so each shard keeps a copy of the trees assigned to it, which
+// is only used for referential integrity. The meta database is authoritative.
+func NewShardedAdminStorage(meta *sql.DB, shards map[string]*sql.DB, mf monitoring.MetricFactory) storage.AdminStorage {
+	if mf == nil {
+		mf = monitoring.InertMetricFactory{}
+	}
+	shardMetricsOnce.Do(func() {
+		shardDeleteFailures = mf.NewCounter("mysql_shard_delete_failures", "Number of hard deleted trees whose data was left behind in their shard", "shard")
+	})
+	return &shardedAdminStorage{
+		mysqlAdminStorage: &mysqlAdminStorage{db: meta},
+		r:                 newShardRouter(meta, shards),
+	}
+}
+
+// NewShardedLogStorage returns a MySQL storage.LogStorage which routes the
+// transactions of each tree to the database holding its data, according to
+// the routing table maintained by NewShardedAdminStorage. Trees without a
+// routing entry, e.g. those created before sharding was enabled, keep their
+// data in the meta database.
+func NewShardedLogStorage(meta *sql.DB, shards map[string]*sql.DB, mf monitoring.MetricFactory, hashKey []byte) storage.LogStorage {
+	s := &shardedLogStorage{
+		r:      newShardRouter(meta, shards),
+		meta:   NewLogStorageWithHashKey(meta, mf, hashKey),
+		shards: make(map[string]storage.LogStorage),
+	}
+	for name, db := range shards {
+		s.shards[name] = NewLogStorageWithHashKey(db, mf, hashKey)
+	}
+	return s
+}
+
+// shardRouter looks up the shards of trees in the TreeShards routing table.
+type shardRouter struct {
+	meta   *sql.DB
+	shards map[string]*sql.DB
+	names  []string // Sorted names of the shards.
+
+	// mu guards routes, which caches the shards of trees. The shard of a tree
+	// doesn't change during its lifetime, so entries are never stale, except
+	// for trees hard deleted by another process.
+	mu     sync.RWMutex
+	routes map[int64]string
+}
+
+func newShardRouter(meta *sql.DB, shards map[string]*sql.DB) *shardRouter {
+	names := make([]string, 0, len(shards))
+	for name := range shards {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return &shardRouter{
+		meta:   meta,
+		shards: shards,
+		names:  names,
+		routes: make(map[int64]string),
+	}
+}
+
+// shardOf returns the name of the shard holding the data of the given tree,
+// or an empty string if the data is in the meta database.
+func (r *shardRouter) shardOf(ctx context.Context, treeID int64) (string, error) {
+	r.mu.RLock()
+	name, ok := r.routes[treeID]
+	r.mu.RUnlock()
+	if ok {
+		return name, nil
+	}
+
+	// Trees without a routing entry aren't cached, as the entry of a tree
+	// which is being created may not be committed yet.
+	switch err := r.meta.QueryRowContext(ctx, selectTreeShardSQL, treeID).Scan(&name); {
+	case err == sql.ErrNoRows:
+		return "", nil
+	case err != nil:
+		return "", fmt.Errorf("failed to look up shard of tree %d: %v", treeID, err)
+	}
+	if _, ok := r.shards[name]; !ok {
+		return "", status.Errorf(codes.FailedPrecondition, "tree %d is assigned to unknown shard %q", treeID, name)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.routes[treeID] = name
+	return name, nil
+}
+
+// forget removes the cached shard of the given tree.
+func (r *shardRouter) forget(treeID int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.routes, treeID)
+}
+
+// pickShard returns the name of the shard with the fewest trees, breaking ties
+// by name.
+func (r *shardRouter) pickShard(ctx context.Context, tx *sql.Tx) (string, error) {
+	rows, err := tx.QueryContext(ctx, countTreesByShardSQL)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+	counts := make(map[string]int64)
+	for rows.Next() {
+		var name string
+		var count int64
+		if err := rows.Scan(&name, &count); err != nil {
+			return "", err
+		}
+		counts[name] = count
+	}
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+
+	best := ""
+	for _, name := range r.names {
+		if best == "" || counts[name] < counts[best] {
+			best = name
+		}
+	}
+	if best == "" {
+		return "", status.Error(codes.FailedPrecondition, "no shards configured")
+	}
+	return best, nil
+}
+
+// checkAccessible returns nil if the meta database and all the shards are
+// accessible, or an error otherwise.
+func (r *shardRouter) checkAccessible(ctx context.Context) error {
+	if err := r.meta.PingContext(ctx); err != nil {
+		return err
+	}
+	for _, name := range r.names {
+		if err := r.shards[name].PingContext(ctx); err != nil {
+			return fmt.Errorf("shard %q: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// shardedAdminStorage implements storage.AdminStorage. Reads only involve the
+// meta database, so only read-write transactions differ from the unsharded
+// admin storage.
+type shardedAdminStorage struct {
+	*mysqlAdminStorage
+	r *shardRouter
+}
+
+func (s *shardedAdminStorage) ReadWriteTransaction(ctx context.Context, f storage.AdminTXFunc) error {
+	tx, err := s.db.BeginTx(ctx, nil /* opts */)
+	if err != nil {
+		return err
+	}
+	stx := &shardedAdminTX{adminTX: &adminTX{tx: tx}, r: s.r}
+	defer stx.Close()
+	if err := f(ctx, stx); err != nil {
+		return err
+	}
+	if err := stx.Commit(); err != nil {
+		return err
+	}
+	// The shards are only changed once the meta database is, so that a
+	// rolled back transaction doesn't leave them behind. The transaction is
+	// committed by then, so failures don't fail it.
+	for _, hook := range stx.afterCommit {
+		hook(ctx)
+	}
+	return nil
+}
+
+func (s *shardedAdminStorage) CheckDatabaseAccessible(ctx context.Context) error {
+	return s.r.checkAccessible(ctx)
+}
+
+type shardedAdminTX struct {
+	*adminTX
+	r *shardRouter
+	// afterCommit holds the changes to the shards which are made once the
+	// transaction is committed.
+	afterCommit []func(context.Context)
+}
+
+func (t *shardedAdminTX) CreateTree(ctx context.Context, tree *trillian.Tree) (*trillian.Tree, error) {
+	newTree, err := t.adminTX.CreateTree(ctx, tree)
+	if err != nil {
+		return nil, err
+	}
+	name, err := t.r.pickShard(ctx, t.tx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := t.tx.ExecContext(ctx, insertTreeShardSQL, newTree.TreeId, name); err != nil {
+		return nil, err
+	}
+
+	// The copy is committed before the routing entry. If the latter fails,
+	// the copy is left behind, and reused if the tree ID is ever picked again.
+	if err := NewAdminStorage(t.r.shards[name]).ReadWriteTransaction(ctx, func(ctx context.Context, tx storage.AdminTX) error {
+		_, err := tx.CreateTree(ctx, newTree)
+		if status.Code(err) == codes.AlreadyExists {
+			return nil
+		}
+		return err
+	}); err != nil {
+		return nil, fmt.Errorf("failed to create tree %d in shard %q: %v", newTree.TreeId, name, err)
+	}
+	return newTree, nil
+}
+
+func (t *shardedAdminTX) HardDeleteTree(ctx context.Context, treeID int64) error {
+	name, err := t.r.shardOf(ctx, treeID)
+	if err != nil {
+		return err
+	}
+	// This also deletes the routing entry of the tree.
+	if err := t.adminTX.HardDeleteTree(ctx, treeID); err != nil {
+		return err
+	}
+	if name == "" {
+		return nil
+	}
+	// The tree is gone once the meta database commits, and with it the
+	// routing entry, so failing to delete the data only leaves it behind in
+	// the shard, where it is no longer reachable. It's logged and counted for
+	// operators to delete it, see deleteShardCopy.
+	t.afterCommit = append(t.afterCommit, func(ctx context.Context) {
+		t.r.forget(treeID)
+		if err := deleteShardCopy(ctx, t.r.shards[name], treeID); err != nil {
+			glog.Errorf("%v: tree deleted, but its data was left behind in shard %q, delete tree %v from the Trees table of the shard to drop it: %v", treeID, name, treeID, err)
+			shardDeleteFailures.Inc(name)
+		}
+	})
+	return nil
+}
+
+// deleteShardCopy deletes the copy of the given tree in a shard, and the data
+// of the tree with it. The copy is never soft deleted, so it's deleted
+// directly. Deleting a copy which is already gone succeeds, so that the
+// deletion can be repeated if it failed.
+func deleteShardCopy(ctx context.Context, shard *sql.DB, treeID int64) error {
+	tx, err := shard.BeginTx(ctx, nil /* opts */)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.ExecContext(ctx, "DELETE FROM TreeControl WHERE TreeId = ?", treeID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM Trees WHERE TreeId = ?", treeID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// shardedLogStorage implements storage.LogStorage.
+type shardedLogStorage struct {
+	r      *shardRouter
+	meta   storage.LogStorage
+	shards map[string]storage.LogStorage
+}
+
+// storageFor returns the log storage of the database holding the data of the
+// given tree.
+func (s *shardedLogStorage) storageFor(ctx context.Context, tree *trillian.Tree) (storage.LogStorage, error) {
+	name, err := s.r.shardOf(ctx, tree.TreeId)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		return s.meta, nil
+	}
+	return s.shards[name], nil
+}
+
+func (s *shardedLogStorage) CheckDatabaseAccessible(ctx context.Context) error {
+	return s.r.checkAccessible(ctx)
+}
+
+func (s *shardedLogStorage) GetActiveLogIDs(ctx context.Context) ([]int64, error) {
+	return s.meta.GetActiveLogIDs(ctx)
+}
+
+func (s *shardedLogStorage) SnapshotForTree(ctx context.Context, tree *trillian.Tree) (storage.ReadOnlyLogTreeTX, error) {
+	ls, err := s.storageFor(ctx, tree)
+	if err != nil {
+		return nil, err
+	}
+	return ls.SnapshotForTree(ctx, tree)
+}
+
+func (s *shardedLogStorage) ReadWriteTransaction(ctx context.Context, tree *trillian.Tree, f storage.LogTXFunc) error {
+	ls, err := s.storageFor(ctx, tree)
+	if err != nil {
+		return err
+	}
+	return ls.ReadWriteTransaction(ctx, tree, f)
+}
+
+func (s *shardedLogStorage) QueueLeaves(ctx context.Context, tree *trillian.Tree, leaves []*trillian.LogLeaf, queueTimestamp time.Time) ([]*trillian.QueuedLogLeaf, error) {
+	ls, err := s.storageFor(ctx, tree)
+	if err != nil {
+		return nil, err
+	}
+	return ls.QueueLeaves(ctx, tree, leaves, queueTimestamp)
+}
+
+func (s *shardedLogStorage) AddSequencedLeaves(ctx context.Context, tree *trillian.Tree, leaves []*trillian.LogLeaf, timestamp time.Time) ([]*trillian.QueuedLogLeaf, error) {
+	ls, err := s.storageFor(ctx, tree)
+	if err != nil {
+		return nil, err
+	}
+	return ls.AddSequencedLeaves(ctx, tree, leaves, timestamp)
+}
@@ -0,0 +1,194 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/monitoring/testonly"
+	"github.com/google/trillian/storage"
+	stestonly "github.com/google/trillian/storage/testonly"
+)
+
+func TestShardedStorage(t *testing.T) {
+	ctx := context.Background()
+	cleanTestDB(DB)
+	shard0, done0 := openTestDBOrDie()
+	defer done0(ctx)
+	shard1, done1 := openTestDBOrDie()
+	defer done1(ctx)
+	shards := map[string]*sql.DB{"shard0": shard0, "shard1": shard1}
+
+	as := NewShardedAdminStorage(DB, shards, nil)
+	ls := NewShardedLogStorage(DB, shards, nil, nil)
+
+	// A tree created before sharding was enabled keeps its data in DB.
+	legacy := mustCreateTree(ctx, t, NewAdminStorage(DB), stestonly.LogTree)
+	tree0 := mustCreateTree(ctx, t, as, stestonly.LogTree)
+	tree1 := mustCreateTree(ctx, t, as, stestonly.LogTree)
+
+	for _, test := range []struct {
+		tree *trillian.Tree
+		db   *sql.DB
+	}{
+		{tree: legacy, db: DB},
+		{tree: tree0, db: shard0},
+		{tree: tree1, db: shard1},
+	} {
+		mustSignAndStoreLogRoot(ctx, t, ls, test.tree, 0)
+		if _, err := ls.QueueLeaves(ctx, test.tree, []*trillian.LogLeaf{createTestLeaves(1, 0)[0]}, time.Now()); err != nil {
+			t.Fatalf("QueueLeaves(%d): %v", test.tree.TreeId, err)
+		}
+		if got, want := countLeafData(ctx, t, test.db, test.tree.TreeId), 1; got != want {
+			t.Errorf("tree %d: got %d leaves in its database, want %d", test.tree.TreeId, got, want)
+		}
+	}
+
+	ids, err := ls.GetActiveLogIDs(ctx)
+	if err != nil {
+		t.Fatalf("GetActiveLogIDs: %v", err)
+	}
+	if got, want := len(ids), 3; got != want {
+		t.Errorf("GetActiveLogIDs: got %d IDs, want %d", got, want)
+	}
+
+	if err := as.ReadWriteTransaction(ctx, func(ctx context.Context, tx storage.AdminTX) error {
+		_, err := tx.SoftDeleteTree(ctx, tree0.TreeId)
+		return err
+	}); err != nil {
+		t.Fatalf("SoftDeleteTree: %v", err)
+	}
+	// The data in the shard is only deleted once the meta database commits.
+	errRollback := errors.New("rollback")
+	if err := as.ReadWriteTransaction(ctx, func(ctx context.Context, tx storage.AdminTX) error {
+		if err := tx.HardDeleteTree(ctx, tree0.TreeId); err != nil {
+			return err
+		}
+		return errRollback
+	}); err != errRollback {
+		t.Fatalf("rolled back HardDeleteTree: %v, want %v", err, errRollback)
+	}
+	if got, want := countLeafData(ctx, t, shard0, tree0.TreeId), 1; got != want {
+		t.Errorf("got %d leaves in shard after rolled back HardDeleteTree, want %d", got, want)
+	}
+	if err := as.ReadWriteTransaction(ctx, func(ctx context.Context, tx storage.AdminTX) error {
+		return tx.HardDeleteTree(ctx, tree0.TreeId)
+	}); err != nil {
+		t.Fatalf("HardDeleteTree: %v", err)
+	}
+	// Deleting the data again succeeds.
+	if err := deleteShardCopy(ctx, shard0, tree0.TreeId); err != nil {
+		t.Errorf("deleteShardCopy() of deleted tree: %v", err)
+	}
+	var name string
+	if err := shard0.QueryRowContext(ctx, "SELECT DisplayName FROM Trees WHERE TreeId = ?", tree0.TreeId).Scan(&name); err != sql.ErrNoRows {
+		t.Errorf("tree %d in shard after HardDeleteTree: err = %v, want %v", tree0.TreeId, err, sql.ErrNoRows)
+	}
+	if got := countLeafData(ctx, t, shard0, tree0.TreeId); got != 0 {
+		t.Errorf("got %d leaves in shard after HardDeleteTree, want 0", got)
+	}
+}
+
+func TestShardedStorageDeleteFailure(t *testing.T) {
+	ctx := context.Background()
+	cleanTestDB(DB)
+	shard, done := openTestDBOrDie()
+	defer done(ctx)
+
+	as := NewShardedAdminStorage(DB, map[string]*sql.DB{"shard0": shard}, nil)
+	ls := NewShardedLogStorage(DB, map[string]*sql.DB{"shard0": shard}, nil, nil)
+	tree := mustCreateTree(ctx, t, as, stestonly.LogTree)
+	mustSignAndStoreLogRoot(ctx, t, ls, tree, 0)
+	if _, err := ls.QueueLeaves(ctx, tree, []*trillian.LogLeaf{createTestLeaves(1, 0)[0]}, time.Now()); err != nil {
+		t.Fatalf("QueueLeaves: %v", err)
+	}
+	if err := as.ReadWriteTransaction(ctx, func(ctx context.Context, tx storage.AdminTX) error {
+		_, err := tx.SoftDeleteTree(ctx, tree.TreeId)
+		return err
+	}); err != nil {
+		t.Fatalf("SoftDeleteTree: %v", err)
+	}
+
+	// The shard can't be reached when the data is deleted.
+	unreachable, err := sql.Open("mysql", "root@tcp(127.0.0.1:1)/trillian?timeout=1s")
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	defer unreachable.Close()
+	failures := testonly.NewCounterSnapshot(shardDeleteFailures, "shard0")
+	broken := NewShardedAdminStorage(DB, map[string]*sql.DB{"shard0": unreachable}, nil)
+	if err := broken.ReadWriteTransaction(ctx, func(ctx context.Context, tx storage.AdminTX) error {
+		return tx.HardDeleteTree(ctx, tree.TreeId)
+	}); err != nil {
+		t.Fatalf("HardDeleteTree with unreachable shard: %v, want nil as the tree is deleted", err)
+	}
+	if _, err := storage.GetTree(ctx, as, tree.TreeId); err == nil {
+		t.Error("GetTree() after HardDeleteTree returned nil error")
+	}
+	if got, want := failures.Delta(), 1.0; got != want {
+		t.Errorf("shard delete failures: got %v, want %v", got, want)
+	}
+
+	// The data is left behind, until it's deleted from the shard.
+	if got, want := countLeafData(ctx, t, shard, tree.TreeId), 1; got != want {
+		t.Errorf("got %d leaves in shard after failed delete, want %d", got, want)
+	}
+	if err := deleteShardCopy(ctx, shard, tree.TreeId); err != nil {
+		t.Fatalf("deleteShardCopy(): %v", err)
+	}
+	if got := countLeafData(ctx, t, shard, tree.TreeId); got != 0 {
+		t.Errorf("got %d leaves in shard after deleteShardCopy, want 0", got)
+	}
+}
+
+func TestParseShardURIs(t *testing.T) {
+	for _, test := range []struct {
+		list    string
+		want    int
+		wantErr bool
+	}{
+		{list: "", want: 0},
+		{list: "a=user@tcp(host:3306)/db", want: 1},
+		{list: "a=user@tcp(host0:3306)/db,b=user@tcp(host1:3306)/db?parseTime=true", want: 2},
+		{list: "user@tcp(host:3306)/db", wantErr: true},
+		{list: "=user@tcp(host:3306)/db", wantErr: true},
+		{list: "a=", wantErr: true},
+		{list: "a=uri0,a=uri1", wantErr: true},
+	} {
+		uris, err := parseShardURIs(test.list)
+		if gotErr := err != nil; gotErr != test.wantErr {
+			t.Errorf("parseShardURIs(%q): %v, wantErr %v", test.list, err, test.wantErr)
+			continue
+		}
+		if got := len(uris); err == nil && got != test.want {
+			t.Errorf("parseShardURIs(%q): got %d shards, want %d", test.list, got, test.want)
+		}
+	}
+}
+
+// countLeafData returns the number of leaves of the given tree stored in db.
+func countLeafData(ctx context.Context, t *testing.T, db *sql.DB, treeID int64) int {
+	t.Helper()
+	var count int
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM LeafData WHERE TreeId = ?", treeID).Scan(&count); err != nil {
+		t.Fatalf("Failed to count leaves: %v", err)
+	}
+	return count
+}
@@ -29,12 +29,17 @@ import (
 	"github.com/golang/glog"
 	"github.com/google/trillian"
 	"github.com/google/trillian/storage"
+	"github.com/google/trillian/storage/hashenc"
 	"github.com/google/trillian/storage/testdb"
 	storageto "github.com/google/trillian/storage/testonly"
 	stree "github.com/google/trillian/storage/tree"
 	"github.com/google/trillian/types"
 	"github.com/transparency-dev/merkle/compact"
 	"github.com/transparency-dev/merkle/rfc6962"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func TestNodeRoundTrip(t *testing.T) {
@@ -141,6 +146,108 @@ func TestLogNodeRoundTripMultiSubtree(t *testing.T) {
 	}
 }
 
+func TestEncryptedHashes(t *testing.T) {
+	ctx := context.Background()
+	cleanTestDB(DB)
+	as := NewAdminStorage(DB)
+	tree := proto.Clone(storageto.LogTree).(*trillian.Tree)
+	tree.EncryptHashes = true
+	tree = mustCreateTree(ctx, t, as, tree)
+
+	if _, err := NewLogStorage(DB, nil).SnapshotForTree(ctx, tree); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("SnapshotForTree() without key: %v, want %v", err, codes.FailedPrecondition)
+	}
+
+	s := NewLogStorageWithHashKey(DB, nil, bytes.Repeat([]byte{1}, hashenc.MinKeySize))
+	mustSignAndStoreLogRoot(ctx, t, s, tree, 0)
+
+	const writeRev = int64(100)
+	nodes := createSomeNodes(16)
+	nodeIDs := make([]compact.NodeID, len(nodes))
+	for i := range nodes {
+		nodeIDs[i] = nodes[i].ID
+	}
+	leaves := createTestLeaves(4, 0)
+	for _, leaf := range leaves {
+		leaf.IntegrateTimestamp = timestamppb.New(fakeIntegrateTime)
+	}
+	if _, err := s.QueueLeaves(ctx, tree, leaves, fakeQueueTime); err != nil {
+		t.Fatalf("QueueLeaves(): %v", err)
+	}
+	runLogTX(s, tree, t, func(ctx context.Context, tx storage.LogTreeTX) error {
+		dequeued, err := tx.DequeueLeaves(ctx, len(leaves), fakeDequeueCutoffTime)
+		if err != nil {
+			t.Fatalf("DequeueLeaves(): %v", err)
+		}
+		if got, want := len(dequeued), len(leaves); got != want {
+			t.Fatalf("DequeueLeaves(): %d leaves, want %d", got, want)
+		}
+		for _, leaf := range dequeued {
+			if !leafInBatch(leaf, leaves) {
+				t.Errorf("DequeueLeaves(): unexpected leaf %x", leaf.LeafIdentityHash)
+			}
+		}
+		if err := tx.UpdateSequencedLeaves(ctx, leaves); err != nil {
+			t.Fatalf("UpdateSequencedLeaves(): %v", err)
+		}
+		forceWriteRevision(writeRev, tx)
+		if err := tx.SetMerkleNodes(ctx, nodes); err != nil {
+			t.Fatalf("SetMerkleNodes(): %v", err)
+		}
+		return storeLogRoot(ctx, tx, uint64(len(leaves)), uint64(writeRev), []byte{1, 2, 3})
+	})
+
+	// Nothing stored in the database should reveal the hashes.
+	for _, query := range []string{
+		"SELECT LeafIdentityHash FROM LeafData WHERE TreeId=?",
+		"SELECT MerkleLeafHash FROM SequencedLeafData WHERE TreeId=?",
+		"SELECT Nodes FROM Subtree WHERE TreeId=?",
+	} {
+		rows, err := DB.QueryContext(ctx, query, tree.TreeId)
+		if err != nil {
+			t.Fatalf("%s: %v", query, err)
+		}
+		for rows.Next() {
+			var stored []byte
+			if err := rows.Scan(&stored); err != nil {
+				t.Fatalf("%s: %v", query, err)
+			}
+			for _, leaf := range leaves {
+				if bytes.Contains(stored, leaf.LeafIdentityHash) {
+					t.Errorf("%s: found leaf hash %x", query, leaf.LeafIdentityHash)
+				}
+			}
+			for _, n := range nodes {
+				if bytes.Contains(stored, n.Hash) {
+					t.Errorf("%s: found node hash %x", query, n.Hash)
+				}
+			}
+		}
+		if err := rows.Err(); err != nil {
+			t.Fatalf("%s: %v", query, err)
+		}
+		rows.Close()
+	}
+
+	runLogTX(s, tree, t, func(ctx context.Context, tx storage.LogTreeTX) error {
+		got, err := tx.GetLeavesByHash(ctx, [][]byte{leaves[1].MerkleLeafHash}, false)
+		if err != nil {
+			t.Fatalf("GetLeavesByHash(): %v", err)
+		}
+		if len(got) != 1 || !bytes.Equal(got[0].LeafIdentityHash, leaves[1].LeafIdentityHash) || got[0].LeafIndex != leaves[1].LeafIndex {
+			t.Errorf("GetLeavesByHash(): %v, want %v", got, leaves[1])
+		}
+		readNodes, err := tx.GetMerkleNodes(ctx, nodeIDs)
+		if err != nil {
+			t.Fatalf("GetMerkleNodes(): %v", err)
+		}
+		if err := nodesAreEqual(readNodes, nodes); err != nil {
+			t.Errorf("GetMerkleNodes(): %v", err)
+		}
+		return nil
+	})
+}
+
 func forceWriteRevision(rev int64, tx storage.LogTreeTX) {
 	mtx, ok := tx.(*logTreeTX)
 	if !ok {
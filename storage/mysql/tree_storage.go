@@ -23,10 +23,12 @@ import (
 	"runtime/debug"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/golang/glog"
 	"github.com/google/trillian"
 	"github.com/google/trillian/storage/cache"
+	"github.com/google/trillian/storage/hashenc"
 	"github.com/google/trillian/storage/storagepb"
 	"github.com/google/trillian/storage/tree"
 	"google.golang.org/protobuf/proto"
@@ -55,6 +57,13 @@ const (
 	placeholderSQL = "<placeholder>"
 )
 
+// The associated data of encrypted leaf hashes, which stops the ciphertext of
+// a hash in one column from being passed off as that of another.
+var (
+	identityHashAD = []byte("LeafIdentityHash")
+	merkleHashAD   = []byte("MerkleLeafHash")
+)
+
 // mySQLTreeStorage is shared between the mySQLLog- and (forthcoming) mySQLMap-
 // Storage implementations, and contains functionality which is common to both,
 type mySQLTreeStorage struct {
@@ -170,9 +179,92 @@ type treeTX struct {
 	hashSizeBytes int
 	subtreeCache  *cache.SubtreeCache
 	writeRevision int64
+	// hashes encrypts the stored hashes of the tree, or is nil if the tree
+	// doesn't have encrypt_hashes set.
+	hashes *hashenc.Cipher
+	// spanEnd, if set, ends the tracing span of the transaction when it's
+	// committed or rolled back.
+	spanEnd func()
+}
+
+// sealHash returns hash as it should be stored in the database, i.e. encrypted
+// if the tree has encrypt_hashes set.
+func (t *treeTX) sealHash(hash, ad []byte) []byte {
+	if t.hashes == nil {
+		return hash
+	}
+	return t.hashes.Seal(hash, ad)
+}
+
+// openHash reverses sealHash for a hash read from the database.
+func (t *treeTX) openHash(stored, ad []byte) ([]byte, error) {
+	if t.hashes == nil {
+		return stored, nil
+	}
+	hash, err := t.hashes.Open(stored, ad)
+	if err != nil {
+		return nil, fmt.Errorf("TreeID: %d: failed to decrypt stored hash %x: %v", t.treeID, stored, err)
+	}
+	return hash, nil
+}
+
+// sealTile returns a copy of the tile with encrypted node hashes, if the tree
+// has encrypt_hashes set. Each hash is bound to its position in the tree.
+func (t *treeTX) sealTile(st *storagepb.SubtreeProto) *storagepb.SubtreeProto {
+	if t.hashes == nil {
+		return st
+	}
+	sealed := &storagepb.SubtreeProto{
+		Prefix:            st.Prefix,
+		Depth:             st.Depth,
+		InternalNodeCount: st.InternalNodeCount,
+	}
+	sealed.Leaves = t.sealTileNodes(st.Prefix, 'L', st.Leaves)
+	sealed.InternalNodes = t.sealTileNodes(st.Prefix, 'I', st.InternalNodes)
+	return sealed
+}
+
+func (t *treeTX) sealTileNodes(prefix []byte, kind byte, nodes map[string][]byte) map[string][]byte {
+	if nodes == nil {
+		return nil
+	}
+	ret := make(map[string][]byte, len(nodes))
+	for k, v := range nodes {
+		ret[k] = t.hashes.Seal(v, tileNodeAD(prefix, kind, k))
+	}
+	return ret
+}
+
+// openTile decrypts the node hashes of a tile read from the database in place.
+func (t *treeTX) openTile(st *storagepb.SubtreeProto) error {
+	if t.hashes == nil {
+		return nil
+	}
+	for _, n := range []struct {
+		kind  byte
+		nodes map[string][]byte
+	}{{'L', st.Leaves}, {'I', st.InternalNodes}} {
+		for k, v := range n.nodes {
+			hash, err := t.hashes.Open(v, tileNodeAD(st.Prefix, n.kind, k))
+			if err != nil {
+				return fmt.Errorf("TreeID: %d: failed to decrypt node %x/%s: %v", t.treeID, st.Prefix, k, err)
+			}
+			n.nodes[k] = hash
+		}
+	}
+	return nil
+}
+
+func tileNodeAD(prefix []byte, kind byte, suffix string) []byte {
+	ad := make([]byte, 0, len(prefix)+len(suffix)+2)
+	ad = append(ad, byte(len(prefix)))
+	ad = append(ad, prefix...)
+	ad = append(ad, kind)
+	return append(ad, suffix...)
 }
 
 func (t *treeTX) getSubtrees(ctx context.Context, treeRevision int64, ids [][]byte) ([]*storagepb.SubtreeProto, error) {
+	defer observeQuery(queryGetSubtrees, time.Now())
 	glog.V(2).Infof("getSubtrees(len(ids)=%d)", len(ids))
 	glog.V(4).Infof("getSubtrees(")
 	if len(ids) == 0 {
@@ -226,6 +318,9 @@ func (t *treeTX) getSubtrees(ctx context.Context, treeRevision int64, ids [][]by
 			glog.Warningf("Failed to unmarshal SubtreeProto: %s", err)
 			return nil, err
 		}
+		if err := t.openTile(&subtree); err != nil {
+			return nil, err
+		}
 		if subtree.Prefix == nil {
 			subtree.Prefix = []byte{}
 		}
@@ -250,6 +345,7 @@ func (t *treeTX) getSubtrees(ctx context.Context, treeRevision int64, ids [][]by
 }
 
 func (t *treeTX) storeSubtrees(ctx context.Context, subtrees []*storagepb.SubtreeProto) error {
+	defer observeQuery(querySetSubtrees, time.Now())
 	glog.V(2).Infof("storeSubtrees(len(subtrees)=%d)", len(subtrees))
 	if glog.V(4) {
 		glog.Infof("storeSubtrees(")
@@ -277,7 +373,7 @@ func (t *treeTX) storeSubtrees(ctx context.Context, subtrees []*storagepb.Subtre
 		if s.Prefix == nil {
 			panic(fmt.Errorf("nil prefix on %v", s))
 		}
-		subtreeBytes, err := proto.Marshal(s)
+		subtreeBytes, err := proto.Marshal(t.sealTile(s))
 		if err != nil {
 			return err
 		}
@@ -341,6 +437,7 @@ func (t *treeTX) SetMerkleNodes(ctx context.Context, nodes []tree.Node) error {
 func (t *treeTX) Commit(ctx context.Context) error {
 	t.mu.Lock()
 	defer t.mu.Unlock()
+	defer t.endSpan()
 
 	if t.writeRevision > -1 {
 		tiles, err := t.subtreeCache.UpdatedTiles()
@@ -362,6 +459,7 @@ func (t *treeTX) Commit(ctx context.Context) error {
 }
 
 func (t *treeTX) rollbackInternal() error {
+	defer t.endSpan()
 	t.closed = true
 	if err := t.tx.Rollback(); err != nil {
 		glog.Warningf("TX rollback error: %s, stack:\n%s", err, string(debug.Stack()))
@@ -370,6 +468,13 @@ func (t *treeTX) rollbackInternal() error {
 	return nil
 }
 
+func (t *treeTX) endSpan() {
+	if t.spanEnd != nil {
+		t.spanEnd()
+		t.spanEnd = nil
+	}
+}
+
 func (t *treeTX) Close() error {
 	t.mu.Lock()
 	defer t.mu.Unlock()
@@ -0,0 +1,68 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/trillian"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	insertVerificationFailureSQL = `INSERT INTO VerificationFailureReports(TreeId,ReportTimestampNanos,RequestId,Failure,LogRoot,Details)
+			VALUES(?,?,?,?,?,?)`
+	selectVerificationFailuresSQL = `SELECT ReportTimestampNanos,RequestId,Failure,LogRoot,Details
+			FROM VerificationFailureReports
+			WHERE TreeId=?
+			ORDER BY ReportTimestampNanos,RequestId`
+)
+
+// AddVerificationFailureReport implements storage.VerificationFailureTX.
+func (t *logTreeTX) AddVerificationFailureReport(ctx context.Context, report *trillian.VerificationFailureReport) error {
+	t.treeTX.mu.Lock()
+	defer t.treeTX.mu.Unlock()
+
+	_, err := t.tx.ExecContext(ctx, insertVerificationFailureSQL,
+		t.treeID, report.GetReportTimestamp().AsTime().UnixNano(), report.RequestId, report.Failure.String(), report.LogRoot, report.Details)
+	return mysqlToGRPC(err)
+}
+
+// ListVerificationFailureReports implements storage.VerificationFailureTX.
+func (t *logTreeTX) ListVerificationFailureReports(ctx context.Context) ([]*trillian.VerificationFailureReport, error) {
+	t.treeTX.mu.Lock()
+	defer t.treeTX.mu.Unlock()
+
+	rows, err := t.tx.QueryContext(ctx, selectVerificationFailuresSQL, t.treeID)
+	if err != nil {
+		return nil, mysqlToGRPC(err)
+	}
+	defer rows.Close()
+	var ret []*trillian.VerificationFailureReport
+	for rows.Next() {
+		r := &trillian.VerificationFailureReport{}
+		var ts int64
+		var failure string
+		if err := rows.Scan(&ts, &r.RequestId, &failure, &r.LogRoot, &r.Details); err != nil {
+			return nil, fmt.Errorf("failed to scan verification failure report: %v", err)
+		}
+		r.Failure = trillian.VerificationFailure(trillian.VerificationFailure_value[failure])
+		r.ReportTimestamp = timestamppb.New(time.Unix(0, ts))
+		ret = append(ret, r)
+	}
+	return ret, rows.Err()
+}
@@ -0,0 +1,558 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultSequenceIntervalSeconds = 60
+
+	nonDeletedWhere = " WHERE (Deleted IS NULL OR Deleted = FALSE)"
+
+	// The columns are in the order storage.ReadTree scans them.
+	selectTrees = `
+		SELECT
+			TreeId,
+			TreeState,
+			TreeType,
+			HashStrategy,
+			HashAlgorithm,
+			SignatureAlgorithm,
+			DisplayName,
+			Description,
+			CreateTimeMillis,
+			UpdateTimeMillis,
+			PrivateKey,
+			PublicKey,
+			MaxRootDurationMillis,
+			Deleted,
+			DeleteTimeMillis,
+			SequencedLeafConflictPolicy,
+			SequencedLeafDuplicateWindow,
+			ReadConsistency,
+			EncryptHashes,
+			MaxMergeDelayMillis,
+			LeafSchema,
+			IntegrationPause,
+			QuotaProfile,
+			RetentionPolicy,
+			Operator,
+			TreeQuota,
+			StorageCaps,
+			SignJSONLogRoots
+		FROM Trees`
+	selectNonDeletedTrees = selectTrees + nonDeletedWhere
+	selectTreeByID        = selectTrees + " WHERE TreeId = $1"
+
+	insertTreeSQL = `INSERT INTO Trees(
+			TreeId,
+			TreeState,
+			TreeType,
+			HashStrategy,
+			HashAlgorithm,
+			SignatureAlgorithm,
+			DisplayName,
+			Description,
+			CreateTimeMillis,
+			UpdateTimeMillis,
+			PrivateKey,
+			PublicKey,
+			MaxRootDurationMillis,
+			SequencedLeafConflictPolicy,
+			SequencedLeafDuplicateWindow,
+			ReadConsistency,
+			EncryptHashes,
+			MaxMergeDelayMillis,
+			LeafSchema,
+			IntegrationPause,
+			QuotaProfile,
+			RetentionPolicy,
+			Operator,
+			TreeQuota,
+			StorageCaps,
+			SignJSONLogRoots)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26)`
+	insertTreeControlSQL = `INSERT INTO TreeControl(
+			TreeId,
+			SigningEnabled,
+			SequencingEnabled,
+			SequenceIntervalSeconds)
+		VALUES($1, $2, $3, $4)`
+
+	updateTreeSQL = `UPDATE Trees
+		SET TreeState = $1, TreeType = $2, DisplayName = $3, Description = $4, UpdateTimeMillis = $5, MaxRootDurationMillis = $6, PrivateKey = $7,
+			SequencedLeafConflictPolicy = $8, SequencedLeafDuplicateWindow = $9, ReadConsistency = $10, MaxMergeDelayMillis = $11,
+			LeafSchema = $12, IntegrationPause = $13, RetentionPolicy = $14, Operator = $15, TreeQuota = $16, StorageCaps = $17,
+			SignJSONLogRoots = $18
+		WHERE TreeId = $19`
+)
+
+// NewAdminStorage returns a PostgreSQL storage.AdminStorage implementation backed by DB.
+func NewAdminStorage(db *sql.DB) storage.AdminStorage {
+	return &postgresAdminStorage{db}
+}
+
+// postgresAdminStorage implements storage.AdminStorage
+type postgresAdminStorage struct {
+	db *sql.DB
+}
+
+func (s *postgresAdminStorage) Snapshot(ctx context.Context) (storage.ReadOnlyAdminTX, error) {
+	return s.beginInternal(ctx, readOnlyTxOptions)
+}
+
+func (s *postgresAdminStorage) beginInternal(ctx context.Context, opts *sql.TxOptions) (storage.AdminTX, error) {
+	tx, err := s.db.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &adminTX{tx: tx}, nil
+}
+
+func (s *postgresAdminStorage) ReadWriteTransaction(ctx context.Context, f storage.AdminTXFunc) error {
+	tx, err := s.beginInternal(ctx, nil /* opts */)
+	if err != nil {
+		return err
+	}
+	defer tx.Close()
+	if err := f(ctx, tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *postgresAdminStorage) CheckDatabaseAccessible(ctx context.Context) error {
+	return s.db.PingContext(ctx)
+}
+
+type adminTX struct {
+	tx *sql.Tx
+
+	// mu guards reads/writes on closed, which happen on Commit/Close methods.
+	//
+	// We don't check closed on methods apart from the ones above, as we trust tx
+	// to keep tabs on its state, and hence fail to do queries after closed.
+	mu     sync.RWMutex
+	closed bool
+}
+
+func (t *adminTX) Commit() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.closed = true
+	return postgresToGRPC(t.tx.Commit())
+}
+
+func (t *adminTX) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return nil
+	}
+	t.closed = true
+	return t.tx.Rollback()
+}
+
+func (t *adminTX) GetTree(ctx context.Context, treeID int64) (*trillian.Tree, error) {
+	// GetTree is an entry point for most RPCs, let's provide somewhat nicer error messages.
+	tree, err := storage.ReadTree(t.tx.QueryRowContext(ctx, selectTreeByID, treeID))
+	switch {
+	case err == sql.ErrNoRows:
+		// ErrNoRows doesn't provide useful information, so we don't forward it.
+		return nil, status.Errorf(codes.NotFound, "tree %v not found", treeID)
+	case err != nil:
+		return nil, fmt.Errorf("error reading tree %v: %v", treeID, err)
+	}
+	return tree, nil
+}
+
+func (t *adminTX) ListTrees(ctx context.Context, includeDeleted bool) ([]*trillian.Tree, error) {
+	query := selectNonDeletedTrees
+	if includeDeleted {
+		query = selectTrees
+	}
+
+	rows, err := t.tx.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	trees := []*trillian.Tree{}
+	for rows.Next() {
+		tree, err := storage.ReadTree(rows)
+		if err != nil {
+			return nil, err
+		}
+		trees = append(trees, tree)
+	}
+	return trees, rows.Err()
+}
+
+func (t *adminTX) CreateTree(ctx context.Context, tree *trillian.Tree) (*trillian.Tree, error) {
+	if err := storage.ValidateTreeForCreation(ctx, tree); err != nil {
+		return nil, err
+	}
+	if err := checkUnsupportedFields(tree); err != nil {
+		return nil, err
+	}
+
+	id, err := storage.TreeIDForCreation(tree)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkTreeIDFree(ctx, t.tx, id); err != nil {
+		return nil, err
+	}
+
+	// Use the time truncated-to-millis throughout, as that's what's stored.
+	nowMillis := storage.ToMillisSinceEpoch(time.Now())
+	now := storage.FromMillisSinceEpoch(nowMillis)
+
+	newTree := proto.Clone(tree).(*trillian.Tree)
+	newTree.TreeId = id
+	newTree.CreateTime = timestamppb.New(now)
+	if err := newTree.CreateTime.CheckValid(); err != nil {
+		return nil, fmt.Errorf("failed to build create time: %w", err)
+	}
+	newTree.UpdateTime = timestamppb.New(now)
+	if err := newTree.UpdateTime.CheckValid(); err != nil {
+		return nil, fmt.Errorf("failed to build update time: %w", err)
+	}
+	if err := newTree.MaxRootDuration.CheckValid(); err != nil {
+		return nil, fmt.Errorf("could not parse MaxRootDuration: %w", err)
+	}
+	rootDuration := newTree.MaxRootDuration.AsDuration()
+	leafSchema, err := marshalLeafSchema(newTree)
+	if err != nil {
+		return nil, err
+	}
+	integrationPause, err := marshalIntegrationPause(newTree)
+	if err != nil {
+		return nil, err
+	}
+	retentionPolicy, err := marshalRetentionPolicy(newTree)
+	if err != nil {
+		return nil, err
+	}
+	operator, err := marshalOperator(newTree)
+	if err != nil {
+		return nil, err
+	}
+	treeQuota, err := marshalTreeQuota(newTree)
+	if err != nil {
+		return nil, err
+	}
+	storageCaps, err := marshalStorageCaps(newTree)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := t.tx.ExecContext(
+		ctx,
+		insertTreeSQL,
+		newTree.TreeId,
+		newTree.TreeState.String(),
+		newTree.TreeType.String(),
+		"RFC6962_SHA256", // Unused, filling in for compatibility with storage.ReadTree.
+		"SHA256",         // Unused, filling in for compatibility with storage.ReadTree.
+		"ECDSA",          // Unused, filling in for compatibility with storage.ReadTree.
+		newTree.DisplayName,
+		newTree.Description,
+		nowMillis,
+		nowMillis,
+		[]byte{}, // Unused, filling in for compatibility with storage.ReadTree.
+		[]byte{}, // Unused, filling in for compatibility with storage.ReadTree.
+		int64(rootDuration/time.Millisecond),
+		newTree.SequencedLeafConflictPolicy.String(),
+		newTree.SequencedLeafDuplicateWindow,
+		newTree.ReadConsistency.String(),
+		newTree.EncryptHashes,
+		maxMergeDelayMillis(newTree),
+		leafSchema,
+		integrationPause,
+		newTree.QuotaProfile,
+		retentionPolicy,
+		operator,
+		treeQuota,
+		storageCaps,
+		newTree.SignJsonLogRoots,
+	); err != nil {
+		return nil, postgresToGRPC(err)
+	}
+
+	if _, err := t.tx.ExecContext(
+		ctx,
+		insertTreeControlSQL,
+		newTree.TreeId,
+		true, /* SigningEnabled */
+		true, /* SequencingEnabled */
+		defaultSequenceIntervalSeconds,
+	); err != nil {
+		return nil, postgresToGRPC(err)
+	}
+
+	return newTree, nil
+}
+
+func (t *adminTX) UpdateTree(ctx context.Context, treeID int64, updateFunc func(*trillian.Tree)) (*trillian.Tree, error) {
+	tree, err := t.GetTree(ctx, treeID)
+	if err != nil {
+		return nil, err
+	}
+
+	beforeUpdate := proto.Clone(tree).(*trillian.Tree)
+	updateFunc(tree)
+	if err := storage.ValidateTreeForUpdate(ctx, beforeUpdate, tree); err != nil {
+		return nil, err
+	}
+	if err := checkUnsupportedFields(tree); err != nil {
+		return nil, err
+	}
+
+	// Use the time truncated-to-millis throughout, as that's what's stored.
+	nowMillis := storage.ToMillisSinceEpoch(time.Now())
+	now := storage.FromMillisSinceEpoch(nowMillis)
+	tree.UpdateTime = timestamppb.New(now)
+	if err := tree.MaxRootDuration.CheckValid(); err != nil {
+		return nil, fmt.Errorf("could not parse MaxRootDuration: %w", err)
+	}
+	rootDuration := tree.MaxRootDuration.AsDuration()
+	leafSchema, err := marshalLeafSchema(tree)
+	if err != nil {
+		return nil, err
+	}
+	integrationPause, err := marshalIntegrationPause(tree)
+	if err != nil {
+		return nil, err
+	}
+	retentionPolicy, err := marshalRetentionPolicy(tree)
+	if err != nil {
+		return nil, err
+	}
+	operator, err := marshalOperator(tree)
+	if err != nil {
+		return nil, err
+	}
+	treeQuota, err := marshalTreeQuota(tree)
+	if err != nil {
+		return nil, err
+	}
+	storageCaps, err := marshalStorageCaps(tree)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = t.tx.ExecContext(
+		ctx,
+		updateTreeSQL,
+		tree.TreeState.String(),
+		tree.TreeType.String(),
+		tree.DisplayName,
+		tree.Description,
+		nowMillis,
+		int64(rootDuration/time.Millisecond),
+		[]byte{}, // Unused, filling in for compatibility with storage.ReadTree.
+		tree.SequencedLeafConflictPolicy.String(),
+		tree.SequencedLeafDuplicateWindow,
+		tree.ReadConsistency.String(),
+		maxMergeDelayMillis(tree),
+		leafSchema,
+		integrationPause,
+		retentionPolicy,
+		operator,
+		treeQuota,
+		storageCaps,
+		tree.SignJsonLogRoots,
+		tree.TreeId); err != nil {
+		return nil, postgresToGRPC(err)
+	}
+
+	return tree, nil
+}
+
+func (t *adminTX) SoftDeleteTree(ctx context.Context, treeID int64) (*trillian.Tree, error) {
+	return t.updateDeleted(ctx, treeID, true /* deleted */, sql.NullInt64{Int64: storage.ToMillisSinceEpoch(time.Now()), Valid: true})
+}
+
+func (t *adminTX) UndeleteTree(ctx context.Context, treeID int64) (*trillian.Tree, error) {
+	return t.updateDeleted(ctx, treeID, false /* deleted */, sql.NullInt64{})
+}
+
+// updateDeleted updates the Deleted and DeleteTimeMillis fields of the specified tree.
+func (t *adminTX) updateDeleted(ctx context.Context, treeID int64, deleted bool, deleteTimeMillis sql.NullInt64) (*trillian.Tree, error) {
+	if err := validateDeleted(ctx, t.tx, treeID, !deleted); err != nil {
+		return nil, err
+	}
+	if _, err := t.tx.ExecContext(
+		ctx,
+		"UPDATE Trees SET Deleted = $1, DeleteTimeMillis = $2 WHERE TreeId = $3",
+		deleted, deleteTimeMillis, treeID); err != nil {
+		return nil, postgresToGRPC(err)
+	}
+	return t.GetTree(ctx, treeID)
+}
+
+// HardDeleteTree deletes the tree and, through the cascading foreign keys of
+// the schema, all of its data.
+func (t *adminTX) HardDeleteTree(ctx context.Context, treeID int64) error {
+	if err := validateDeleted(ctx, t.tx, treeID, true /* wantDeleted */); err != nil {
+		return err
+	}
+	_, err := t.tx.ExecContext(ctx, "DELETE FROM Trees WHERE TreeId = $1", treeID)
+	return postgresToGRPC(err)
+}
+
+// checkTreeIDFree returns an AlreadyExists error if there is a tree with the
+// given ID, including a soft-deleted one.
+func checkTreeIDFree(ctx context.Context, tx *sql.Tx, treeID int64) error {
+	var id int64
+	switch err := tx.QueryRowContext(ctx, "SELECT TreeId FROM Trees WHERE TreeId = $1", treeID).Scan(&id); {
+	case err == sql.ErrNoRows:
+		return nil
+	case err != nil:
+		return err
+	}
+	return status.Errorf(codes.AlreadyExists, "tree %v already exists", treeID)
+}
+
+func validateDeleted(ctx context.Context, tx *sql.Tx, treeID int64, wantDeleted bool) error {
+	var nullDeleted sql.NullBool
+	switch err := tx.QueryRowContext(ctx, "SELECT Deleted FROM Trees WHERE TreeId = $1", treeID).Scan(&nullDeleted); {
+	case err == sql.ErrNoRows:
+		return status.Errorf(codes.NotFound, "tree %v not found", treeID)
+	case err != nil:
+		return err
+	}
+
+	switch deleted := nullDeleted.Valid && nullDeleted.Bool; {
+	case wantDeleted && !deleted:
+		return status.Errorf(codes.FailedPrecondition, "tree %v is not soft deleted", treeID)
+	case !wantDeleted && deleted:
+		return status.Errorf(codes.FailedPrecondition, "tree %v already soft deleted", treeID)
+	}
+	return nil
+}
+
+// checkUnsupportedFields returns an error if the tree asks for a feature which
+// this storage implementation doesn't provide.
+func checkUnsupportedFields(tree *trillian.Tree) error {
+	if tree.StorageSettings != nil {
+		return status.Errorf(codes.InvalidArgument, "storage_settings not supported, but got %v", tree.StorageSettings)
+	}
+	if tree.EncryptHashes {
+		return status.Error(codes.InvalidArgument, "encrypt_hashes not supported by PostgreSQL storage")
+	}
+	return nil
+}
+
+// maxMergeDelayMillis returns the stored form of the max merge delay of the
+// tree, zero if it has none.
+func maxMergeDelayMillis(tree *trillian.Tree) int64 {
+	if tree.MaxMergeDelay == nil {
+		return 0
+	}
+	return int64(tree.MaxMergeDelay.AsDuration() / time.Millisecond)
+}
+
+// marshalLeafSchema returns the stored form of the leaf schema of the tree,
+// nil if it has none.
+func marshalLeafSchema(tree *trillian.Tree) ([]byte, error) {
+	if tree.LeafSchema == nil {
+		return nil, nil
+	}
+	b, err := proto.Marshal(tree.LeafSchema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal leaf schema: %v", err)
+	}
+	return b, nil
+}
+
+// marshalIntegrationPause returns the stored form of the integration pause of
+// the tree, nil if it has none.
+func marshalIntegrationPause(tree *trillian.Tree) ([]byte, error) {
+	if tree.IntegrationPause == nil {
+		return nil, nil
+	}
+	b, err := proto.Marshal(tree.IntegrationPause)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal integration pause: %v", err)
+	}
+	return b, nil
+}
+
+// marshalRetentionPolicy returns the stored form of the retention policy of
+// the tree, nil if it has none.
+func marshalRetentionPolicy(tree *trillian.Tree) ([]byte, error) {
+	if tree.RetentionPolicy == nil {
+		return nil, nil
+	}
+	b, err := proto.Marshal(tree.RetentionPolicy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal retention policy: %v", err)
+	}
+	return b, nil
+}
+
+// marshalOperator returns the stored form of the operator of the tree, nil if
+// it has none.
+func marshalOperator(tree *trillian.Tree) ([]byte, error) {
+	if tree.Operator == nil {
+		return nil, nil
+	}
+	b, err := proto.Marshal(tree.Operator)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal operator: %v", err)
+	}
+	return b, nil
+}
+
+// marshalTreeQuota returns the stored form of the quota of the tree, nil if it
+// has none.
+func marshalTreeQuota(tree *trillian.Tree) ([]byte, error) {
+	if tree.Quota == nil {
+		return nil, nil
+	}
+	b, err := proto.Marshal(tree.Quota)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal quota: %v", err)
+	}
+	return b, nil
+}
+
+// marshalStorageCaps returns the stored form of the storage caps of the tree,
+// nil if it has none.
+func marshalStorageCaps(tree *trillian.Tree) ([]byte, error) {
+	if tree.StorageCaps == nil {
+		return nil, nil
+	}
+	b, err := proto.Marshal(tree.StorageCaps)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal storage caps: %v", err)
+	}
+	return b, nil
+}
@@ -0,0 +1,51 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// serialization_failure: The transaction conflicted with a concurrent one.
+	errCodeSerializationFailure = "40001"
+	// deadlock_detected: The transaction was chosen as a deadlock victim.
+	errCodeDeadlock = "40P01"
+)
+
+// sqlStateError is implemented by the errors of the common PostgreSQL drivers,
+// e.g. *pgconn.PgError and *pq.Error, which lets this package avoid depending
+// on a particular one.
+type sqlStateError interface {
+	error
+	SQLState() string
+}
+
+// postgresToGRPC converts some types of PostgreSQL errors to GRPC errors. This
+// gives clients more signal when the operation can be retried.
+func postgresToGRPC(err error) error {
+	var pgErr sqlStateError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+	switch pgErr.SQLState() {
+	case errCodeDeadlock, errCodeSerializationFailure:
+		return status.Errorf(codes.Aborted, "PostgreSQL: %v", pgErr)
+	}
+	return err
+}
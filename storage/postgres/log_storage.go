@@ -0,0 +1,761 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian"
+	"github.com/google/trillian/monitoring"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/storage/cache"
+	"github.com/google/trillian/storage/tree"
+	"github.com/google/trillian/types"
+	"github.com/transparency-dev/merkle/compact"
+	"github.com/transparency-dev/merkle/rfc6962"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// Duplicates are detected by the number of inserted rows rather than by
+	// errors, as any error aborts a PostgreSQL transaction.
+	insertLeafDataSQL = `INSERT INTO LeafData(TreeId,LeafIdentityHash,LeafValue,ExtraData,QueueTimestampNanos)
+			VALUES($1,$2,$3,$4,$5) ON CONFLICT DO NOTHING`
+	insertSequencedLeafSQL = `INSERT INTO SequencedLeafData(TreeId,LeafIdentityHash,MerkleLeafHash,SequenceNumber,IntegrateTimestampNanos)
+			VALUES($1,$2,$3,$4,$5)`
+	insertSequencedLeafIfAbsentSQL = insertSequencedLeafSQL + " ON CONFLICT DO NOTHING"
+	deleteLeafDataSQL              = "DELETE FROM LeafData WHERE TreeId=$1 AND LeafIdentityHash=$2"
+
+	selectNonDeletedTreeIDByTypeAndStateSQL = `
+		SELECT TreeId FROM Trees
+		  WHERE TreeType IN($1,$2)
+		  AND TreeState IN($3,$4)
+		  AND (Deleted IS NULL OR Deleted = FALSE)`
+
+	selectLatestSignedLogRootSQL = `SELECT TreeHeadTimestamp,TreeSize,RootHash,TreeRevision,RootSignature
+			FROM TreeHead WHERE TreeId=$1
+			ORDER BY TreeHeadTimestamp DESC LIMIT 1`
+
+	selectLeavesByRangeSQL = `SELECT s.MerkleLeafHash,l.LeafIdentityHash,l.LeafValue,s.SequenceNumber,l.ExtraData,l.QueueTimestampNanos,s.IntegrateTimestampNanos
+			FROM LeafData l,SequencedLeafData s
+			WHERE l.LeafIdentityHash = s.LeafIdentityHash
+			AND s.SequenceNumber >= $1 AND s.SequenceNumber < $2 AND l.TreeId = $3 AND s.TreeId = l.TreeId` + orderBySequenceNumberSQL
+	// selectLeafHashesByRangeSQL is selectLeavesByRangeSQL without the leaf
+	// data, which is usually most of the bytes read.
+	selectLeafHashesByRangeSQL = `SELECT s.MerkleLeafHash,l.LeafIdentityHash,NULL,s.SequenceNumber,NULL,l.QueueTimestampNanos,s.IntegrateTimestampNanos
+			FROM LeafData l,SequencedLeafData s
+			WHERE l.LeafIdentityHash = s.LeafIdentityHash
+			AND s.SequenceNumber >= $1 AND s.SequenceNumber < $2 AND l.TreeId = $3 AND s.TreeId = l.TreeId` + orderBySequenceNumberSQL
+
+	// These statements need to be expanded to provide the correct number of
+	// parameter placeholders, which follow the TreeId parameter.
+	selectLeavesByMerkleHashSQL = `SELECT s.MerkleLeafHash,l.LeafIdentityHash,l.LeafValue,s.SequenceNumber,l.ExtraData,l.QueueTimestampNanos,s.IntegrateTimestampNanos
+			FROM LeafData l,SequencedLeafData s
+			WHERE l.LeafIdentityHash = s.LeafIdentityHash
+			AND l.TreeId = $1 AND s.TreeId = l.TreeId AND s.MerkleLeafHash IN (` + placeholderSQL + `)`
+	// dummyMerkleLeafHash is returned as the Merkle leaf hash of leaves
+	// looked up by identity hash, so that the rows of all leaf-selection
+	// statements have the same shape. It assumes a hash size of 32.
+	dummyMerkleLeafHash               = "00000000000000000000000000000000"
+	selectLeavesByLeafIdentityHashSQL = `SELECT CAST('` + dummyMerkleLeafHash + `' AS BYTEA),l.LeafIdentityHash,l.LeafValue,-1,l.ExtraData,l.QueueTimestampNanos,s.IntegrateTimestampNanos
+			FROM LeafData l LEFT JOIN SequencedLeafData s ON (l.LeafIdentityHash = s.LeafIdentityHash AND l.TreeId = s.TreeId)
+			WHERE l.TreeId = $1 AND l.LeafIdentityHash IN (` + placeholderSQL + `)`
+
+	// Same as above except with leaves ordered by sequence so we only incur this cost when necessary
+	orderBySequenceNumberSQL                     = " ORDER BY s.SequenceNumber"
+	selectLeavesByMerkleHashOrderedBySequenceSQL = selectLeavesByMerkleHashSQL + orderBySequenceNumberSQL
+
+	logIDLabel = "logid"
+	queryLabel = "query"
+)
+
+var (
+	once             sync.Once
+	queuedCounter    monitoring.Counter
+	queuedDupCounter monitoring.Counter
+	dequeuedCounter  monitoring.Counter
+
+	queueLatency         monitoring.Histogram
+	dequeueLatency       monitoring.Histogram
+	dequeueRemoveLatency monitoring.Histogram
+
+	queryLatency monitoring.Histogram
+)
+
+func createMetrics(mf monitoring.MetricFactory) {
+	queuedCounter = mf.NewCounter("postgres_queued_leaves", "Number of leaves queued", logIDLabel)
+	queuedDupCounter = mf.NewCounter("postgres_queued_dup_leaves", "Number of duplicate leaves queued", logIDLabel)
+	dequeuedCounter = mf.NewCounter("postgres_dequeued_leaves", "Number of leaves dequeued", logIDLabel)
+
+	queueLatency = mf.NewHistogram("postgres_queue_leaves_latency", "Latency of queue leaves operation in seconds", logIDLabel)
+	dequeueLatency = mf.NewHistogram("postgres_dequeue_leaves_latency", "Latency of dequeue leaves operation in seconds", logIDLabel)
+	dequeueRemoveLatency = mf.NewHistogram("postgres_dequeue_leaves_latency_remove", "Latency of removal part of dequeue leaves operation in seconds", logIDLabel)
+
+	queryLatency = mf.NewHistogram("postgres_query_latency", "Latency of storage queries in seconds, by logical query", queryLabel)
+}
+
+// Values of the query label of the queryLatency metric.
+const (
+	queryGetSubtrees           = "get-subtrees"
+	querySetSubtrees           = "set-subtrees"
+	queryGetLeavesByRange      = "get-leaves-by-range"
+	queryGetLeavesByHash       = "get-leaves-by-hash"
+	queryQueue                 = "queue"
+	queryDequeue               = "dequeue"
+	queryUpdateSequencedLeaves = "update-sequenced-leaves"
+	queryLatestRoot            = "latest-root"
+	queryUpdateRoot            = "update-root"
+)
+
+func labelForTX(t *logTreeTX) string {
+	return strconv.FormatInt(t.treeID, 10)
+}
+
+func observe(hist monitoring.Histogram, duration time.Duration, label string) {
+	hist.Observe(duration.Seconds(), label)
+}
+
+// observeQuery records the latency of the logical query which started at
+// start, so that a query which regressed, e.g. after a schema change, stands
+// out from the others.
+func observeQuery(query string, start time.Time) {
+	queryLatency.Observe(time.Since(start).Seconds(), query)
+}
+
+type postgresLogStorage struct {
+	*postgresTreeStorage
+	admin         storage.AdminStorage
+	metricFactory monitoring.MetricFactory
+}
+
+// NewLogStorage creates a storage.LogStorage instance for the specified
+// PostgreSQL database. It assumes storage.AdminStorage is backed by the same
+// database as well.
+func NewLogStorage(db *sql.DB, mf monitoring.MetricFactory) storage.LogStorage {
+	if mf == nil {
+		mf = monitoring.InertMetricFactory{}
+	}
+	return &postgresLogStorage{
+		admin:               NewAdminStorage(db),
+		postgresTreeStorage: newTreeStorage(db),
+		metricFactory:       mf,
+	}
+}
+
+func (m *postgresLogStorage) CheckDatabaseAccessible(ctx context.Context) error {
+	return m.db.PingContext(ctx)
+}
+
+func (m *postgresLogStorage) getLeavesByMerkleHashStmt(ctx context.Context, num int, orderBySequence bool) (*sql.Stmt, error) {
+	if orderBySequence {
+		return m.getStmt(ctx, selectLeavesByMerkleHashOrderedBySequenceSQL, num, 1, 1)
+	}
+
+	return m.getStmt(ctx, selectLeavesByMerkleHashSQL, num, 1, 1)
+}
+
+func (m *postgresLogStorage) getLeavesByLeafIdentityHashStmt(ctx context.Context, num int) (*sql.Stmt, error) {
+	return m.getStmt(ctx, selectLeavesByLeafIdentityHashSQL, num, 1, 1)
+}
+
+func (m *postgresLogStorage) GetActiveLogIDs(ctx context.Context) ([]int64, error) {
+	// Include logs that are DRAINING in the active list as we're still
+	// integrating leaves into them.
+	rows, err := m.db.QueryContext(
+		ctx, selectNonDeletedTreeIDByTypeAndStateSQL,
+		trillian.TreeType_LOG.String(), trillian.TreeType_PREORDERED_LOG.String(),
+		trillian.TreeState_ACTIVE.String(), trillian.TreeState_DRAINING.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	ids := []int64{}
+	for rows.Next() {
+		var treeID int64
+		if err := rows.Scan(&treeID); err != nil {
+			return nil, err
+		}
+		ids = append(ids, treeID)
+	}
+	return ids, rows.Err()
+}
+
+func (m *postgresLogStorage) beginInternal(ctx context.Context, tree *trillian.Tree, opts *sql.TxOptions) (*logTreeTX, error) {
+	once.Do(func() {
+		createMetrics(m.metricFactory)
+	})
+	if err := checkUnsupportedFields(tree); err != nil {
+		return nil, err
+	}
+
+	stCache := cache.NewLogSubtreeCache(rfc6962.DefaultHasher)
+	ttx, err := m.beginTreeTx(ctx, tree, rfc6962.DefaultHasher.Size(), stCache, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	ltx := &logTreeTX{
+		treeTX:   ttx,
+		ls:       m,
+		dequeued: make(map[string]dequeuedLeaf),
+	}
+	ltx.slr, ltx.readRev, err = ltx.fetchLatestRoot(ctx)
+	if err == storage.ErrTreeNeedsInit {
+		ltx.treeTX.writeRevision = 0
+		return ltx, err
+	} else if err != nil {
+		ttx.Close()
+		return nil, err
+	}
+
+	if err := ltx.root.UnmarshalBinary(ltx.slr.LogRoot); err != nil {
+		ttx.Close()
+		return nil, err
+	}
+
+	ltx.treeTX.writeRevision = ltx.readRev + 1
+	return ltx, nil
+}
+
+func (m *postgresLogStorage) ReadWriteTransaction(ctx context.Context, tree *trillian.Tree, f storage.LogTXFunc) error {
+	tx, err := m.beginInternal(ctx, tree, nil /* opts */)
+	if err != nil && err != storage.ErrTreeNeedsInit {
+		return err
+	}
+	defer tx.Close()
+	if err := f(ctx, tx); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (m *postgresLogStorage) AddSequencedLeaves(ctx context.Context, tree *trillian.Tree, leaves []*trillian.LogLeaf, timestamp time.Time) ([]*trillian.QueuedLogLeaf, error) {
+	tx, err := m.beginInternal(ctx, tree, nil /* opts */)
+	if tx != nil {
+		// Ensure we don't leak the transaction. For example if we get an
+		// ErrTreeNeedsInit from beginInternal() or if AddSequencedLeaves fails
+		// below.
+		defer tx.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	res, err := tx.AddSequencedLeaves(ctx, leaves, timestamp)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (m *postgresLogStorage) SnapshotForTree(ctx context.Context, tree *trillian.Tree) (storage.ReadOnlyLogTreeTX, error) {
+	tx, err := m.beginInternal(ctx, tree, readOnlyTxOptions)
+	if err != nil && err != storage.ErrTreeNeedsInit {
+		return nil, err
+	}
+	return tx, err
+}
+
+func (m *postgresLogStorage) QueueLeaves(ctx context.Context, tree *trillian.Tree, leaves []*trillian.LogLeaf, queueTimestamp time.Time) ([]*trillian.QueuedLogLeaf, error) {
+	tx, err := m.beginInternal(ctx, tree, nil /* opts */)
+	if tx != nil {
+		// Ensure we don't leak the transaction. For example if we get an
+		// ErrTreeNeedsInit from beginInternal() or if QueueLeaves fails
+		// below.
+		defer tx.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	existing, err := tx.QueueLeaves(ctx, leaves, queueTimestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	ret := make([]*trillian.QueuedLogLeaf, len(leaves))
+	for i, e := range existing {
+		if e != nil {
+			ret[i] = &trillian.QueuedLogLeaf{
+				Leaf:   e,
+				Status: status.Newf(codes.AlreadyExists, "leaf already exists: %v", e.LeafIdentityHash).Proto(),
+			}
+			continue
+		}
+		ret[i] = &trillian.QueuedLogLeaf{Leaf: leaves[i]}
+	}
+	return ret, nil
+}
+
+type logTreeTX struct {
+	treeTX
+	ls       *postgresLogStorage
+	root     types.LogRootV1
+	readRev  int64
+	slr      *trillian.SignedLogRoot
+	dequeued map[string]dequeuedLeaf
+}
+
+// GetMerkleNodes returns the requested nodes at the read revision.
+func (t *logTreeTX) GetMerkleNodes(ctx context.Context, ids []compact.NodeID) ([]tree.Node, error) {
+	t.treeTX.mu.Lock()
+	defer t.treeTX.mu.Unlock()
+	return t.subtreeCache.GetNodes(ids, t.getSubtreesAtRev(ctx, t.readRev))
+}
+
+// sortLeavesForInsert returns a slice containing the passed in leaves sorted
+// by LeafIdentityHash, and paired with their original positions.
+// QueueLeaves and AddSequencedLeaves use this to make the order that LeafData
+// row locks are acquired deterministic and reduce the chance of deadlocks.
+func sortLeavesForInsert(leaves []*trillian.LogLeaf) []leafAndPosition {
+	ordLeaves := make([]leafAndPosition, len(leaves))
+	for i, leaf := range leaves {
+		ordLeaves[i] = leafAndPosition{leaf: leaf, idx: i}
+	}
+	sort.Sort(byLeafIdentityHashWithPosition(ordLeaves))
+	return ordLeaves
+}
+
+func (t *logTreeTX) QueueLeaves(ctx context.Context, leaves []*trillian.LogLeaf, queueTimestamp time.Time) ([]*trillian.LogLeaf, error) {
+	defer observeQuery(queryQueue, time.Now())
+	t.treeTX.mu.Lock()
+	defer t.treeTX.mu.Unlock()
+
+	// Don't accept batches if any of the leaves are invalid.
+	for _, leaf := range leaves {
+		if len(leaf.LeafIdentityHash) != t.hashSizeBytes {
+			return nil, fmt.Errorf("queued leaf must have a leaf ID hash of length %d", t.hashSizeBytes)
+		}
+		leaf.QueueTimestamp = timestamppb.New(queueTimestamp)
+		if err := leaf.QueueTimestamp.CheckValid(); err != nil {
+			return nil, fmt.Errorf("got invalid queue timestamp: %w", err)
+		}
+	}
+	start := time.Now()
+	label := labelForTX(t)
+
+	ordLeaves := sortLeavesForInsert(leaves)
+	existingCount := 0
+	existingLeaves := make([]*trillian.LogLeaf, len(leaves))
+
+	for _, ol := range ordLeaves {
+		i, leaf := ol.idx, ol.leaf
+
+		qTimestamp := leaf.QueueTimestamp.AsTime()
+		res, err := t.tx.ExecContext(ctx, insertLeafDataSQL, t.treeID, leaf.LeafIdentityHash, leaf.LeafValue, leaf.ExtraData, qTimestamp.UnixNano())
+		if err != nil {
+			glog.Warningf("Error inserting %d into LeafData: %s", i, err)
+			return nil, postgresToGRPC(err)
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return nil, postgresToGRPC(err)
+		} else if n == 0 {
+			// Remember the duplicate leaf, using the requested leaf for now.
+			existingLeaves[i] = leaf
+			existingCount++
+			queuedDupCounter.Inc(label)
+			continue
+		}
+
+		// Create the work queue entry
+		if _, err := t.tx.ExecContext(ctx, insertUnsequencedEntrySQL,
+			t.treeID, leaf.LeafIdentityHash, leaf.MerkleLeafHash, qTimestamp.UnixNano()); err != nil {
+			glog.Warningf("Error inserting into Unsequenced: %s", err)
+			return nil, postgresToGRPC(err)
+		}
+	}
+	queuedCounter.Add(float64(len(leaves)), label)
+
+	if existingCount == 0 {
+		observe(queueLatency, time.Since(start), label)
+		return existingLeaves, nil
+	}
+
+	// For existing leaves, we need to retrieve the contents.  First collate the desired LeafIdentityHash values.
+	var toRetrieve [][]byte
+	for _, existing := range existingLeaves {
+		if existing != nil {
+			toRetrieve = append(toRetrieve, existing.LeafIdentityHash)
+		}
+	}
+	results, err := t.getLeafDataByIdentityHash(ctx, toRetrieve)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve existing leaves: %v", err)
+	}
+	if len(results) != len(toRetrieve) {
+		return nil, fmt.Errorf("failed to retrieve all existing leaves: got %d, want %d", len(results), len(toRetrieve))
+	}
+	// Replace the requested leaves with the actual leaves.
+	for i, requested := range existingLeaves {
+		if requested == nil {
+			continue
+		}
+		found := false
+		for _, result := range results {
+			if bytes.Equal(result.LeafIdentityHash, requested.LeafIdentityHash) {
+				existingLeaves[i] = result
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("failed to find existing leaf for hash %x", requested.LeafIdentityHash)
+		}
+	}
+	observe(queueLatency, time.Since(start), label)
+
+	return existingLeaves, nil
+}
+
+func (t *logTreeTX) AddSequencedLeaves(ctx context.Context, leaves []*trillian.LogLeaf, timestamp time.Time) ([]*trillian.QueuedLogLeaf, error) {
+	t.treeTX.mu.Lock()
+	defer t.treeTX.mu.Unlock()
+
+	res := make([]*trillian.QueuedLogLeaf, len(leaves))
+	ok := status.New(codes.OK, "OK").Proto()
+
+	// Note: LeafData inserts are presumably protected from deadlocks due to
+	// sorting, but the order of the corresponding SequencedLeafData inserts
+	// becomes indeterministic. However, in a typical case when leaves are
+	// supplied in contiguous non-intersecting batches, the chance of having
+	// circular dependencies between transactions is significantly lower.
+	ordLeaves := sortLeavesForInsert(leaves)
+	for _, ol := range ordLeaves {
+		i, leaf := ol.idx, ol.leaf
+
+		// This should fail on insert, but catch it early.
+		if got, want := len(leaf.LeafIdentityHash), t.hashSizeBytes; got != want {
+			return nil, status.Errorf(codes.FailedPrecondition, "leaves[%d] has incorrect hash size %d, want %d", i, got, want)
+		}
+
+		res[i] = &trillian.QueuedLogLeaf{Status: ok}
+
+		inserted, err := t.execIfAbsent(ctx, insertLeafDataSQL,
+			t.treeID, leaf.LeafIdentityHash, leaf.LeafValue, leaf.ExtraData, timestamp.UnixNano())
+		if err != nil {
+			glog.Errorf("Error inserting leaves[%d] into LeafData: %s", i, err)
+			return nil, err
+		}
+		if !inserted {
+			res[i].Status = status.New(codes.FailedPrecondition, "conflicting LeafIdentityHash").Proto()
+			continue
+		}
+
+		inserted, err = t.execIfAbsent(ctx, insertSequencedLeafIfAbsentSQL,
+			t.treeID, leaf.LeafIdentityHash, leaf.MerkleLeafHash, leaf.LeafIndex, 0)
+		if err != nil {
+			glog.Errorf("Error inserting leaves[%d] into SequencedLeafData: %s", i, err)
+			return nil, err
+		}
+		if !inserted {
+			res[i].Status = status.New(codes.FailedPrecondition, "conflicting LeafIndex").Proto()
+			// Remove the side effect of the LeafData insert above.
+			if _, err := t.tx.ExecContext(ctx, deleteLeafDataSQL, t.treeID, leaf.LeafIdentityHash); err != nil {
+				glog.Errorf("Error removing leaves[%d] from LeafData: %s", i, err)
+				return nil, postgresToGRPC(err)
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// execIfAbsent executes an insert statement with an ON CONFLICT DO NOTHING
+// clause, and returns whether a row was inserted.
+func (t *logTreeTX) execIfAbsent(ctx context.Context, query string, args ...interface{}) (bool, error) {
+	res, err := t.tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, postgresToGRPC(err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, postgresToGRPC(err)
+	}
+	return n > 0, nil
+}
+
+func (t *logTreeTX) GetLeavesByRange(ctx context.Context, start, count int64) ([]*trillian.LogLeaf, error) {
+	t.treeTX.mu.Lock()
+	defer t.treeTX.mu.Unlock()
+	return t.getLeavesByRangeInternal(ctx, start, count, selectLeavesByRangeSQL)
+}
+
+// GetLeafHashesByRange implements storage.LeafHashesTX.
+func (t *logTreeTX) GetLeafHashesByRange(ctx context.Context, start, count int64) ([]*trillian.LogLeaf, error) {
+	t.treeTX.mu.Lock()
+	defer t.treeTX.mu.Unlock()
+	return t.getLeavesByRangeInternal(ctx, start, count, selectLeafHashesByRangeSQL)
+}
+
+// getLeavesByRangeInternal reads the leaves with the given query, which is
+// selectLeavesByRangeSQL or selectLeafHashesByRangeSQL.
+func (t *logTreeTX) getLeavesByRangeInternal(ctx context.Context, start, count int64, query string) ([]*trillian.LogLeaf, error) {
+	defer observeQuery(queryGetLeavesByRange, time.Now())
+	if count <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid count %d, want > 0", count)
+	}
+	if start < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid start %d, want >= 0", start)
+	}
+
+	if t.treeType == trillian.TreeType_LOG {
+		treeSize := int64(t.root.TreeSize)
+		if treeSize <= 0 {
+			return nil, status.Errorf(codes.OutOfRange, "empty tree")
+		} else if start >= treeSize {
+			return nil, status.Errorf(codes.OutOfRange, "invalid start %d, want < TreeSize(%d)", start, treeSize)
+		}
+		// Ensure no entries queried/returned beyond the tree.
+		if maxCount := treeSize - start; count > maxCount {
+			count = maxCount
+		}
+	}
+
+	rows, err := t.tx.QueryContext(ctx, query, start, start+count, t.treeID)
+	if err != nil {
+		glog.Warningf("Failed to get leaves by range: %s", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	ret := make([]*trillian.LogLeaf, 0, count)
+	for wantIndex := start; rows.Next(); wantIndex++ {
+		leaf := &trillian.LogLeaf{}
+		var qTimestamp, iTimestamp int64
+		if err := rows.Scan(
+			&leaf.MerkleLeafHash,
+			&leaf.LeafIdentityHash,
+			&leaf.LeafValue,
+			&leaf.LeafIndex,
+			&leaf.ExtraData,
+			&qTimestamp,
+			&iTimestamp); err != nil {
+			glog.Warningf("Failed to scan merkle leaves: %s", err)
+			return nil, err
+		}
+		if leaf.LeafIndex != wantIndex {
+			if wantIndex < int64(t.root.TreeSize) {
+				return nil, fmt.Errorf("got unexpected index %d, want %d", leaf.LeafIndex, wantIndex)
+			}
+			break
+		}
+		leaf.QueueTimestamp = timestamppb.New(time.Unix(0, qTimestamp))
+		if err := leaf.QueueTimestamp.CheckValid(); err != nil {
+			return nil, fmt.Errorf("got invalid queue timestamp: %w", err)
+		}
+		leaf.IntegrateTimestamp = timestamppb.New(time.Unix(0, iTimestamp))
+		if err := leaf.IntegrateTimestamp.CheckValid(); err != nil {
+			return nil, fmt.Errorf("got invalid integrate timestamp: %w", err)
+		}
+		ret = append(ret, leaf)
+	}
+	if err := rows.Err(); err != nil {
+		glog.Warningf("Failed to read returned leaves: %s", err)
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (t *logTreeTX) GetLeavesByHash(ctx context.Context, leafHashes [][]byte, orderBySequence bool) ([]*trillian.LogLeaf, error) {
+	t.treeTX.mu.Lock()
+	defer t.treeTX.mu.Unlock()
+	if len(leafHashes) == 0 {
+		return nil, nil
+	}
+
+	tmpl, err := t.ls.getLeavesByMerkleHashStmt(ctx, len(leafHashes), orderBySequence)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.getLeavesByHashInternal(ctx, leafHashes, tmpl, "merkle")
+}
+
+// getLeafDataByIdentityHash retrieves leaf data by LeafIdentityHash, returned
+// as a slice of LogLeaf objects for convenience.  However, note that the
+// returned LogLeaf objects will not have a valid MerkleLeafHash, LeafIndex, or IntegrateTimestamp.
+func (t *logTreeTX) getLeafDataByIdentityHash(ctx context.Context, leafHashes [][]byte) ([]*trillian.LogLeaf, error) {
+	tmpl, err := t.ls.getLeavesByLeafIdentityHashStmt(ctx, len(leafHashes))
+	if err != nil {
+		return nil, err
+	}
+	return t.getLeavesByHashInternal(ctx, leafHashes, tmpl, "leaf-identity")
+}
+
+func (t *logTreeTX) LatestSignedLogRoot(ctx context.Context) (*trillian.SignedLogRoot, error) {
+	t.treeTX.mu.Lock()
+	defer t.treeTX.mu.Unlock()
+
+	if t.slr == nil {
+		return nil, storage.ErrTreeNeedsInit
+	}
+
+	return t.slr, nil
+}
+
+// fetchLatestRoot reads the latest root and the revision from the DB.
+func (t *logTreeTX) fetchLatestRoot(ctx context.Context) (*trillian.SignedLogRoot, int64, error) {
+	defer observeQuery(queryLatestRoot, time.Now())
+	var timestamp, treeSize, treeRevision int64
+	var rootHash, rootSignatureBytes []byte
+	switch err := t.tx.QueryRowContext(
+		ctx, selectLatestSignedLogRootSQL, t.treeID).Scan(
+		&timestamp, &treeSize, &rootHash, &treeRevision, &rootSignatureBytes,
+	); {
+	case err == sql.ErrNoRows:
+		// It's possible there are no roots for this tree yet
+		return nil, 0, storage.ErrTreeNeedsInit
+	case err != nil:
+		return nil, 0, postgresToGRPC(err)
+	}
+
+	// Put logRoot back together. Fortunately LogRoot has a deterministic serialization.
+	logRoot, err := (&types.LogRootV1{
+		RootHash:       rootHash,
+		TimestampNanos: uint64(timestamp),
+		TreeSize:       uint64(treeSize),
+	}).MarshalBinary()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return &trillian.SignedLogRoot{LogRoot: logRoot}, treeRevision, nil
+}
+
+func (t *logTreeTX) StoreSignedLogRoot(ctx context.Context, root *trillian.SignedLogRoot) error {
+	defer observeQuery(queryUpdateRoot, time.Now())
+	t.treeTX.mu.Lock()
+	defer t.treeTX.mu.Unlock()
+
+	var logRoot types.LogRootV1
+	if err := logRoot.UnmarshalBinary(root.LogRoot); err != nil {
+		glog.Warningf("Failed to parse log root: %x %v", root.LogRoot, err)
+		return err
+	}
+	if len(logRoot.Metadata) != 0 {
+		return fmt.Errorf("unimplemented: postgres storage does not support log root metadata")
+	}
+
+	res, err := t.tx.ExecContext(
+		ctx,
+		insertTreeHeadSQL,
+		t.treeID,
+		int64(logRoot.TimestampNanos),
+		int64(logRoot.TreeSize),
+		logRoot.RootHash,
+		t.treeTX.writeRevision,
+		[]byte{})
+	if err != nil {
+		glog.Warningf("Failed to store signed root: %s", err)
+	}
+
+	return checkResultOkAndRowCountIs(res, err, 1)
+}
+
+// getLeavesByHashInternal looks up leaves by the hashes in the column which
+// the statement queries.
+func (t *logTreeTX) getLeavesByHashInternal(ctx context.Context, leafHashes [][]byte, tmpl *sql.Stmt, desc string) ([]*trillian.LogLeaf, error) {
+	defer observeQuery(queryGetLeavesByHash, time.Now())
+	stx := t.tx.StmtContext(ctx, tmpl)
+	defer stx.Close()
+
+	args := make([]interface{}, 0, len(leafHashes)+1)
+	args = append(args, t.treeID)
+	for _, hash := range leafHashes {
+		args = append(args, hash)
+	}
+	rows, err := stx.QueryContext(ctx, args...)
+	if err != nil {
+		glog.Warningf("Query() %s hash = %v", desc, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	// The tree could include duplicates so we don't know how many results will be returned
+	var ret []*trillian.LogLeaf
+	for rows.Next() {
+		leaf := &trillian.LogLeaf{}
+		// Leaves looked up by identity hash which are queued but not yet
+		// integrated have no SequencedLeafData row to join with, and hence a
+		// NULL IntegrateTimestamp.
+		var integrateTS sql.NullInt64
+		var queueTS int64
+
+		if err := rows.Scan(&leaf.MerkleLeafHash, &leaf.LeafIdentityHash, &leaf.LeafValue, &leaf.LeafIndex, &leaf.ExtraData, &queueTS, &integrateTS); err != nil {
+			glog.Warningf("LogID: %d Scan() %s = %s", t.treeID, desc, err)
+			return nil, err
+		}
+		leaf.QueueTimestamp = timestamppb.New(time.Unix(0, queueTS))
+		if err := leaf.QueueTimestamp.CheckValid(); err != nil {
+			return nil, fmt.Errorf("got invalid queue timestamp: %w", err)
+		}
+		if integrateTS.Valid {
+			leaf.IntegrateTimestamp = timestamppb.New(time.Unix(0, integrateTS.Int64))
+			if err := leaf.IntegrateTimestamp.CheckValid(); err != nil {
+				return nil, fmt.Errorf("got invalid integrate timestamp: %w", err)
+			}
+		}
+
+		if got, want := len(leaf.MerkleLeafHash), t.hashSizeBytes; got != want {
+			return nil, fmt.Errorf("LogID: %d Scanned leaf %s does not have hash length %d, got %d", t.treeID, desc, want, got)
+		}
+
+		ret = append(ret, leaf)
+	}
+	if err := rows.Err(); err != nil {
+		glog.Warningf("Failed to read returned leaves: %s", err)
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// leafAndPosition records original position before sort.
+type leafAndPosition struct {
+	leaf *trillian.LogLeaf
+	idx  int
+}
+
+// byLeafIdentityHashWithPosition allows sorting (as above), but where we need
+// to remember the original position
+type byLeafIdentityHashWithPosition []leafAndPosition
+
+func (l byLeafIdentityHashWithPosition) Len() int {
+	return len(l)
+}
+
+func (l byLeafIdentityHashWithPosition) Swap(i, j int) {
+	l[i], l[j] = l[j], l[i]
+}
+
+func (l byLeafIdentityHashWithPosition) Less(i, j int) bool {
+	return bytes.Compare(l[i].leaf.LeafIdentityHash, l[j].leaf.LeafIdentityHash) == -1
+}
@@ -0,0 +1,158 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	selectQueuedLeavesSQL = `SELECT LeafIdentityHash,MerkleLeafHash,QueueTimestampNanos
+			FROM Unsequenced
+			WHERE TreeId=$1
+			AND Bucket=0
+			AND QueueTimestampNanos<=$2
+			ORDER BY QueueTimestampNanos,LeafIdentityHash ASC LIMIT $3`
+	insertUnsequencedEntrySQL = `INSERT INTO Unsequenced(TreeId,Bucket,LeafIdentityHash,MerkleLeafHash,QueueTimestampNanos)
+			VALUES($1,0,$2,$3,$4)`
+	deleteUnsequencedSQL = "DELETE FROM Unsequenced WHERE TreeId=$1 AND Bucket=0 AND QueueTimestampNanos=$2 AND LeafIdentityHash=$3"
+)
+
+type dequeuedLeaf struct {
+	queueTimestampNanos int64
+	leafIdentityHash    []byte
+}
+
+func (t *logTreeTX) DequeueLeaves(ctx context.Context, limit int, cutoffTime time.Time) ([]*trillian.LogLeaf, error) {
+	defer observeQuery(queryDequeue, time.Now())
+	t.treeTX.mu.Lock()
+	defer t.treeTX.mu.Unlock()
+
+	if t.treeType == trillian.TreeType_PREORDERED_LOG {
+		return t.getLeavesByRangeInternal(ctx, int64(t.root.TreeSize), int64(limit), selectLeavesByRangeSQL)
+	}
+
+	start := time.Now()
+	rows, err := t.tx.QueryContext(ctx, selectQueuedLeavesSQL, t.treeID, cutoffTime.UnixNano(), limit)
+	if err != nil {
+		glog.Warningf("Failed to select rows for work: %s", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	leaves := make([]*trillian.LogLeaf, 0, limit)
+	for rows.Next() {
+		var leafIDHash, merkleHash []byte
+		var queueTimestamp int64
+		if err := rows.Scan(&leafIDHash, &merkleHash, &queueTimestamp); err != nil {
+			glog.Warningf("Error scanning work rows: %s", err)
+			return nil, err
+		}
+		if len(leafIDHash) != t.hashSizeBytes {
+			return nil, errors.New("dequeued a leaf with incorrect hash size")
+		}
+
+		k := string(leafIDHash)
+		if _, ok := t.dequeued[k]; ok {
+			// dupe, user probably called DequeueLeaves more than once.
+			continue
+		}
+
+		// Note: the LeafData and ExtraData being nil here is OK as this is only used by the
+		// sequencer. The sequencer only writes to the SequencedLeafData table and the client
+		// supplied data was already written to LeafData as part of queueing the leaf.
+		queueTimestampProto := timestamppb.New(time.Unix(0, queueTimestamp))
+		if err := queueTimestampProto.CheckValid(); err != nil {
+			return nil, fmt.Errorf("got invalid queue timestamp: %w", err)
+		}
+		t.dequeued[k] = dequeuedLeaf{queueTimestampNanos: queueTimestamp, leafIdentityHash: leafIDHash}
+		leaves = append(leaves, &trillian.LogLeaf{
+			LeafIdentityHash: leafIDHash,
+			MerkleLeafHash:   merkleHash,
+			QueueTimestamp:   queueTimestampProto,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	label := labelForTX(t)
+	observe(dequeueLatency, time.Since(start), label)
+	dequeuedCounter.Add(float64(len(leaves)), label)
+
+	return leaves, nil
+}
+
+func (t *logTreeTX) UpdateSequencedLeaves(ctx context.Context, leaves []*trillian.LogLeaf) error {
+	defer observeQuery(queryUpdateSequencedLeaves, time.Now())
+	dequeuedLeaves := make([]dequeuedLeaf, 0, len(leaves))
+	for _, leaf := range leaves {
+		// This should fail on insert but catch it early
+		if len(leaf.LeafIdentityHash) != t.hashSizeBytes {
+			return errors.New("sequenced leaf has incorrect hash size")
+		}
+
+		if err := leaf.IntegrateTimestamp.CheckValid(); err != nil {
+			return fmt.Errorf("got invalid integrate timestamp: %w", err)
+		}
+		iTimestamp := leaf.IntegrateTimestamp.AsTime()
+		if _, err := t.tx.ExecContext(
+			ctx,
+			insertSequencedLeafSQL,
+			t.treeID,
+			leaf.LeafIdentityHash,
+			leaf.MerkleLeafHash,
+			leaf.LeafIndex,
+			iTimestamp.UnixNano()); err != nil {
+			glog.Warningf("Failed to update sequenced leaves: %s", err)
+			return postgresToGRPC(err)
+		}
+
+		qe, ok := t.dequeued[string(leaf.LeafIdentityHash)]
+		if !ok {
+			return fmt.Errorf("attempting to update leaf that wasn't dequeued. IdentityHash: %x", leaf.LeafIdentityHash)
+		}
+		dequeuedLeaves = append(dequeuedLeaves, qe)
+	}
+
+	return t.removeSequencedLeaves(ctx, dequeuedLeaves)
+}
+
+// removeSequencedLeaves removes the passed in leaves from the queue.
+func (t *logTreeTX) removeSequencedLeaves(ctx context.Context, leaves []dequeuedLeaf) error {
+	start := time.Now()
+	stx, err := t.tx.PrepareContext(ctx, deleteUnsequencedSQL)
+	if err != nil {
+		glog.Warningf("Failed to prep delete statement for sequenced work: %v", err)
+		return err
+	}
+	defer stx.Close()
+	for _, dql := range leaves {
+		result, err := stx.ExecContext(ctx, t.treeID, dql.queueTimestampNanos, dql.leafIdentityHash)
+		if err := checkResultOkAndRowCountIs(result, err, int64(1)); err != nil {
+			return err
+		}
+	}
+
+	observe(dequeueRemoveLatency, time.Since(start), labelForTX(t))
+	return nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/google/trillian/integration/storagetest"
+	"github.com/google/trillian/storage"
+	"github.com/google/trillian/storage/testdb"
+	"github.com/google/trillian/storage/testonly"
+)
+
+// newTestDB returns a new database with the storage schema, which is dropped
+// at the end of the test. It skips the test if PostgreSQL isn't available.
+func newTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	testdb.SkipIfNoPostgreSQL(t)
+	db, done, err := testdb.NewTrillianPostgreSQLDB(context.Background())
+	if err != nil {
+		t.Fatalf("NewTrillianPostgreSQLDB(): %v", err)
+	}
+	t.Cleanup(func() { done(context.Background()) })
+	return db
+}
+
+// cleanTestDB deletes all trees, and the rows of every other table with them.
+func cleanTestDB(db *sql.DB) {
+	if _, err := db.ExecContext(context.TODO(), "DELETE FROM Trees"); err != nil {
+		panic(fmt.Sprintf("Failed to delete rows in Trees: %v", err))
+	}
+}
+
+func TestPostgresAdminStorage(t *testing.T) {
+	db := newTestDB(t)
+	tester := &testonly.AdminStorageTester{NewAdminStorage: func() storage.AdminStorage {
+		cleanTestDB(db)
+		return NewAdminStorage(db)
+	}}
+	tester.RunAllTests(t)
+}
+
+func TestLogSuite(t *testing.T) {
+	db := newTestDB(t)
+	storageFactory := func(context.Context, *testing.T) (storage.LogStorage, storage.AdminStorage) {
+		t.Cleanup(func() { cleanTestDB(db) })
+		return NewLogStorage(db, nil), NewAdminStorage(db)
+	}
+
+	storagetest.RunLogStorageTests(t, storageFactory)
+}
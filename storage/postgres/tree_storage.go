@@ -0,0 +1,361 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package postgres provides a PostgreSQL-based storage layer implementation.
+//
+// The package doesn't link a database/sql driver itself. Binaries using it
+// must import one, e.g. github.com/lib/pq as the Trillian binaries do, and pass
+// its name in the --postgres_driver flag.
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian"
+	"github.com/google/trillian/storage/cache"
+	"github.com/google/trillian/storage/storagepb"
+	"github.com/google/trillian/storage/tree"
+	"google.golang.org/protobuf/proto"
+)
+
+// These statements are fixed
+const (
+	insertSubtreeMultiSQL = `INSERT INTO Subtree(TreeId, SubtreeId, Nodes, SubtreeRevision) VALUES ` + placeholderSQL
+	insertTreeHeadSQL     = `INSERT INTO TreeHead(TreeId,TreeHeadTimestamp,TreeSize,RootHash,TreeRevision,RootSignature)
+		 VALUES($1,$2,$3,$4,$5,$6)`
+
+	// selectSubtreeSQL returns the latest revision of each of the requested
+	// subtrees which is not newer than the read revision.
+	selectSubtreeSQL = `
+ SELECT DISTINCT ON (SubtreeId) SubtreeId, SubtreeRevision, Nodes
+ FROM Subtree
+ WHERE TreeId = $1 AND SubtreeRevision <= $2
+ AND SubtreeId IN (` + placeholderSQL + `)
+ ORDER BY SubtreeId, SubtreeRevision DESC`
+	placeholderSQL = "<placeholder>"
+)
+
+// readOnlyTxOptions are the options of snapshot transactions, which see a
+// consistent view of the database across all of their queries.
+var readOnlyTxOptions = &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}
+
+// postgresTreeStorage contains the functionality shared by the log and admin
+// storage implementations.
+type postgresTreeStorage struct {
+	db *sql.DB
+
+	// Must hold the mutex before manipulating the statement map. Sharing a lock because
+	// it only needs to be held while the statements are built, not while they execute and
+	// this will be a short time. These maps are from the number of expanded placeholders
+	// in the query to the statement that should be used.
+	statementMutex sync.Mutex
+	statements     map[string]map[int]*sql.Stmt
+}
+
+// OpenDB opens a database connection for all PostgreSQL-based storage
+// implementations, using the named database/sql driver.
+func OpenDB(driver, dbURL string) (*sql.DB, error) {
+	db, err := sql.Open(driver, dbURL)
+	if err != nil {
+		// Don't log uri as it could contain credentials
+		glog.Warningf("Could not open PostgreSQL database, check config: %s", err)
+		return nil, err
+	}
+	return db, nil
+}
+
+func newTreeStorage(db *sql.DB) *postgresTreeStorage {
+	return &postgresTreeStorage{
+		db:         db,
+		statements: make(map[string]map[int]*sql.Stmt),
+	}
+}
+
+// expandPlaceholderSQL expands an sql statement by replacing its placeholder
+// with num groups of width numbered parameters, starting after the offset
+// parameters which precede it. Groups of width 1 are not parenthesized, e.g.
+// "$3,$4" for num=2, offset=2, width=1, and "($1,$2),($3,$4)" for num=2,
+// offset=0, width=2.
+func expandPlaceholderSQL(sql string, num, offset, width int) string {
+	if num <= 0 {
+		panic(fmt.Errorf("trying to expand SQL placeholder with <= 0 parameters: %s", sql))
+	}
+
+	var b strings.Builder
+	next := offset + 1
+	for i := 0; i < num; i++ {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		if width > 1 {
+			b.WriteByte('(')
+		}
+		for j := 0; j < width; j++ {
+			if j > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteByte('$')
+			b.WriteString(strconv.Itoa(next))
+			next++
+		}
+		if width > 1 {
+			b.WriteByte(')')
+		}
+	}
+
+	return strings.Replace(sql, placeholderSQL, b.String(), 1)
+}
+
+// getStmt creates and caches sql.Stmt structs based on the passed in statement
+// and number of expanded placeholders.
+func (m *postgresTreeStorage) getStmt(ctx context.Context, statement string, num, offset, width int) (*sql.Stmt, error) {
+	m.statementMutex.Lock()
+	defer m.statementMutex.Unlock()
+
+	if m.statements[statement] != nil {
+		if m.statements[statement][num] != nil {
+			return m.statements[statement][num], nil
+		}
+	} else {
+		m.statements[statement] = make(map[int]*sql.Stmt)
+	}
+
+	s, err := m.db.PrepareContext(ctx, expandPlaceholderSQL(statement, num, offset, width))
+	if err != nil {
+		glog.Warningf("Failed to prepare statement %d: %s", num, err)
+		return nil, err
+	}
+
+	m.statements[statement][num] = s
+
+	return s, nil
+}
+
+func (m *postgresTreeStorage) getSubtreeStmt(ctx context.Context, num int) (*sql.Stmt, error) {
+	return m.getStmt(ctx, selectSubtreeSQL, num, 2, 1)
+}
+
+func (m *postgresTreeStorage) setSubtreeStmt(ctx context.Context, num int) (*sql.Stmt, error) {
+	return m.getStmt(ctx, insertSubtreeMultiSQL, num, 0, 4)
+}
+
+// beginTreeTx starts a transaction over the tree with the given options, or
+// the default ones if opts is nil.
+func (m *postgresTreeStorage) beginTreeTx(ctx context.Context, tree *trillian.Tree, hashSizeBytes int, subtreeCache *cache.SubtreeCache, opts *sql.TxOptions) (treeTX, error) {
+	t, err := m.db.BeginTx(ctx, opts)
+	if err != nil {
+		glog.Warningf("Could not start tree TX: %s", err)
+		return treeTX{}, err
+	}
+	return treeTX{
+		tx:            t,
+		mu:            &sync.Mutex{},
+		ts:            m,
+		treeID:        tree.TreeId,
+		treeType:      tree.TreeType,
+		hashSizeBytes: hashSizeBytes,
+		subtreeCache:  subtreeCache,
+		writeRevision: -1,
+	}, nil
+}
+
+type treeTX struct {
+	// mu ensures that tx can only be used for one query/exec at a time.
+	mu            *sync.Mutex
+	closed        bool
+	tx            *sql.Tx
+	ts            *postgresTreeStorage
+	treeID        int64
+	treeType      trillian.TreeType
+	hashSizeBytes int
+	subtreeCache  *cache.SubtreeCache
+	writeRevision int64
+}
+
+func (t *treeTX) getSubtrees(ctx context.Context, treeRevision int64, ids [][]byte) ([]*storagepb.SubtreeProto, error) {
+	defer observeQuery(queryGetSubtrees, time.Now())
+	glog.V(2).Infof("getSubtrees(len(ids)=%d)", len(ids))
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	tmpl, err := t.ts.getSubtreeStmt(ctx, len(ids))
+	if err != nil {
+		return nil, err
+	}
+	stx := t.tx.StmtContext(ctx, tmpl)
+	defer stx.Close()
+
+	args := make([]interface{}, 0, len(ids)+2)
+	args = append(args, t.treeID, treeRevision)
+	for _, id := range ids {
+		args = append(args, id)
+	}
+
+	rows, err := stx.QueryContext(ctx, args...)
+	if err != nil {
+		glog.Warningf("Failed to get merkle subtrees: %s", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	ret := make([]*storagepb.SubtreeProto, 0, len(ids))
+	for rows.Next() {
+		var subtreeIDBytes []byte
+		var subtreeRev int64
+		var nodesRaw []byte
+		if err := rows.Scan(&subtreeIDBytes, &subtreeRev, &nodesRaw); err != nil {
+			glog.Warningf("Failed to scan merkle subtree: %s", err)
+			return nil, err
+		}
+		var subtree storagepb.SubtreeProto
+		if err := proto.Unmarshal(nodesRaw, &subtree); err != nil {
+			glog.Warningf("Failed to unmarshal SubtreeProto: %s", err)
+			return nil, err
+		}
+		if subtree.Prefix == nil {
+			subtree.Prefix = []byte{}
+		}
+		ret = append(ret, &subtree)
+	}
+	if err := rows.Err(); err != nil {
+		glog.Warningf("Failed to read merkle subtrees: %s", err)
+		return nil, err
+	}
+
+	// The InternalNodes cache is possibly nil here, but the SubtreeCache (which called
+	// this method) will re-populate it.
+	return ret, nil
+}
+
+func (t *treeTX) storeSubtrees(ctx context.Context, subtrees []*storagepb.SubtreeProto) error {
+	defer observeQuery(querySetSubtrees, time.Now())
+	glog.V(2).Infof("storeSubtrees(len(subtrees)=%d)", len(subtrees))
+	if len(subtrees) == 0 {
+		return nil
+	}
+
+	args := make([]interface{}, 0, 4*len(subtrees))
+	for _, s := range subtrees {
+		if s.Prefix == nil {
+			panic(fmt.Errorf("nil prefix on %v", s))
+		}
+		subtreeBytes, err := proto.Marshal(s)
+		if err != nil {
+			return err
+		}
+		args = append(args, t.treeID, s.Prefix, subtreeBytes, t.writeRevision)
+	}
+
+	tmpl, err := t.ts.setSubtreeStmt(ctx, len(subtrees))
+	if err != nil {
+		return err
+	}
+	stx := t.tx.StmtContext(ctx, tmpl)
+	defer stx.Close()
+
+	if _, err := stx.ExecContext(ctx, args...); err != nil {
+		glog.Warningf("Failed to set merkle subtrees: %s", err)
+		return postgresToGRPC(err)
+	}
+	return nil
+}
+
+func checkResultOkAndRowCountIs(res sql.Result, err error, count int64) error {
+	// The Exec() might have just failed
+	if err != nil {
+		return postgresToGRPC(err)
+	}
+
+	// Otherwise we have to look at the result of the operation
+	rowsAffected, rowsError := res.RowsAffected()
+
+	if rowsError != nil {
+		return postgresToGRPC(rowsError)
+	}
+
+	if rowsAffected != count {
+		return fmt.Errorf("expected %d row(s) to be affected but saw: %d", count,
+			rowsAffected)
+	}
+
+	return nil
+}
+
+// getSubtreesAtRev returns a GetSubtreesFunc which reads at the passed in rev.
+func (t *treeTX) getSubtreesAtRev(ctx context.Context, rev int64) cache.GetSubtreesFunc {
+	return func(ids [][]byte) ([]*storagepb.SubtreeProto, error) {
+		return t.getSubtrees(ctx, rev, ids)
+	}
+}
+
+func (t *treeTX) SetMerkleNodes(ctx context.Context, nodes []tree.Node) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	rev := t.writeRevision - 1
+	return t.subtreeCache.SetNodes(nodes, t.getSubtreesAtRev(ctx, rev))
+}
+
+func (t *treeTX) Commit(ctx context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.writeRevision > -1 {
+		tiles, err := t.subtreeCache.UpdatedTiles()
+		if err != nil {
+			glog.Warningf("SubtreeCache updated tiles error: %v", err)
+			return err
+		}
+		if err := t.storeSubtrees(ctx, tiles); err != nil {
+			glog.Warningf("TX commit flush error: %v", err)
+			return err
+		}
+	}
+	t.closed = true
+	if err := t.tx.Commit(); err != nil {
+		glog.Warningf("TX commit error: %s, stack:\n%s", err, string(debug.Stack()))
+		return postgresToGRPC(err)
+	}
+	return nil
+}
+
+func (t *treeTX) rollbackInternal() error {
+	t.closed = true
+	if err := t.tx.Rollback(); err != nil {
+		glog.Warningf("TX rollback error: %s, stack:\n%s", err, string(debug.Stack()))
+		return err
+	}
+	return nil
+}
+
+func (t *treeTX) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return nil
+	}
+	err := t.rollbackInternal()
+	if err != nil {
+		glog.Warningf("Rollback error on Close(): %v", err)
+	}
+	return err
+}
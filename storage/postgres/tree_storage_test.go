@@ -0,0 +1,61 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestExpandPlaceholderSQL(t *testing.T) {
+	for _, tc := range []struct {
+		num, offset, width int
+		want               string
+	}{
+		{num: 1, offset: 0, width: 1, want: "IN ($1) LIMIT 1"},
+		{num: 3, offset: 2, width: 1, want: "IN ($3,$4,$5) LIMIT 1"},
+		{num: 1, offset: 0, width: 2, want: "IN (($1,$2)) LIMIT 1"},
+		{num: 2, offset: 1, width: 3, want: "IN (($2,$3,$4),($5,$6,$7)) LIMIT 1"},
+	} {
+		if got := expandPlaceholderSQL("IN ("+placeholderSQL+") LIMIT 1", tc.num, tc.offset, tc.width); got != tc.want {
+			t.Errorf("expandPlaceholderSQL(%d, %d, %d): %q, want %q", tc.num, tc.offset, tc.width, got, tc.want)
+		}
+	}
+}
+
+type fakePgError string
+
+func (e fakePgError) Error() string    { return "pg error " + string(e) }
+func (e fakePgError) SQLState() string { return string(e) }
+
+func TestPostgresToGRPC(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		want codes.Code
+	}{
+		{err: errors.New("failed"), want: codes.Unknown},
+		{err: fakePgError("23505"), want: codes.Unknown},
+		{err: fakePgError(errCodeDeadlock), want: codes.Aborted},
+		{err: fmt.Errorf("commit: %w", fakePgError(errCodeSerializationFailure)), want: codes.Aborted},
+	} {
+		if got := status.Code(postgresToGRPC(tc.err)); got != tc.want {
+			t.Errorf("postgresToGRPC(%v): code %v, want %v", tc.err, got, tc.want)
+		}
+	}
+}
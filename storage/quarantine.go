@@ -0,0 +1,63 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/trillian"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrQuarantineUnsupported is returned by AsQuarantineTX for storage
+// implementations which can't quarantine leaves.
+var ErrQuarantineUnsupported = status.Error(codes.Unimplemented, "storage does not support leaf quarantine")
+
+// QuarantineTX is implemented by LogTreeTX implementations which are able to
+// move unsequenced leaves out of the queue of a LOG tree, so that leaves which
+// can't be integrated don't block the integration of other leaves.
+//
+// Quarantined leaves keep their LeafData, so they still count as duplicates
+// when the same leaf is queued again, and can be put back into the queue
+// without involving the personality.
+type QuarantineTX interface {
+	// QuarantineLeaves moves the queued leaves with the given identity hashes
+	// out of the queue, recording the reason and time of the quarantine. Hashes
+	// which don't match a queued leaf are ignored. Returns the leaves that were
+	// quarantined.
+	QuarantineLeaves(ctx context.Context, leafIdentityHashes [][]byte, reason string, now time.Time) ([]*trillian.QuarantinedLeaf, error)
+
+	// ListQuarantinedLeaves returns all the quarantined leaves of the tree,
+	// ordered by their original queue timestamp.
+	ListQuarantinedLeaves(ctx context.Context) ([]*trillian.QuarantinedLeaf, error)
+
+	// RequeueQuarantinedLeaves puts the quarantined leaves with the given
+	// identity hashes back into the queue, with their original queue
+	// timestamp. If leafIdentityHashes is empty, all quarantined leaves are
+	// requeued. Returns the leaves that were requeued.
+	RequeueQuarantinedLeaves(ctx context.Context, leafIdentityHashes [][]byte) ([]*trillian.QuarantinedLeaf, error)
+}
+
+// AsQuarantineTX returns tx as a QuarantineTX, or ErrQuarantineUnsupported if
+// the storage implementation doesn't support leaf quarantine.
+func AsQuarantineTX(tx ReadOnlyLogTreeTX) (QuarantineTX, error) {
+	qtx, ok := tx.(QuarantineTX)
+	if !ok {
+		return nil, ErrQuarantineUnsupported
+	}
+	return qtx, nil
+}
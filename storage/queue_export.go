@@ -0,0 +1,47 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+
+	"github.com/google/trillian"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrQueueExportUnsupported is returned by AsQueueExportTX for storage
+// implementations which can't list the leaves of their queue.
+var ErrQueueExportUnsupported = status.Error(codes.Unimplemented, "storage does not support exporting the queue")
+
+// QueueExportTX is implemented by ReadOnlyLogTreeTX implementations which are
+// able to list the unsequenced leaves of a LOG tree, so that its queue can be
+// inspected or moved to another tree.
+type QueueExportTX interface {
+	// ListQueuedLeaves returns the leaves which are queued and not sequenced
+	// yet, with their data, hashes and queue timestamps, ordered by queue
+	// timestamp and then by leaf identity hash.
+	ListQueuedLeaves(ctx context.Context) ([]*trillian.LogLeaf, error)
+}
+
+// AsQueueExportTX returns tx as a QueueExportTX, or ErrQueueExportUnsupported
+// if the storage implementation can't list its queue.
+func AsQueueExportTX(tx ReadOnlyLogTreeTX) (QueueExportTX, error) {
+	qtx, ok := tx.(QueueExportTX)
+	if !ok {
+		return nil, ErrQueueExportUnsupported
+	}
+	return qtx, nil
+}
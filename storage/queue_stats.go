@@ -0,0 +1,56 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrQueueStatsUnsupported is returned by AsQueueStatsTX for storage
+// implementations which can't report statistics about their queue.
+var ErrQueueStatsUnsupported = status.Error(codes.Unimplemented, "storage does not support queue statistics")
+
+// QueueStats describes the distribution of the queue timestamps of the
+// unsequenced leaves of a LOG tree. The timestamps are zero if the queue is
+// empty.
+type QueueStats struct {
+	// Size is the number of leaves in the queue.
+	Size int64
+	// Oldest, Median and Newest are the queue timestamps of the oldest, the
+	// median and the most recently queued leaf. Implementations may estimate
+	// the median from a sample of the queue, see their documentation.
+	Oldest, Median, Newest time.Time
+}
+
+// QueueStatsTX is implemented by ReadOnlyLogTreeTX implementations which are
+// able to report the age distribution of the unsequenced leaves of a LOG tree.
+type QueueStatsTX interface {
+	// QueueStats returns the statistics of the queue of the tree.
+	QueueStats(ctx context.Context) (*QueueStats, error)
+}
+
+// AsQueueStatsTX returns tx as a QueueStatsTX, or ErrQueueStatsUnsupported if
+// the storage implementation doesn't support queue statistics.
+func AsQueueStatsTX(tx ReadOnlyLogTreeTX) (QueueStatsTX, error) {
+	qtx, ok := tx.(QueueStatsTX)
+	if !ok {
+		return nil, ErrQueueStatsUnsupported
+	}
+	return qtx, nil
+}
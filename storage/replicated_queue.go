@@ -0,0 +1,48 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/trillian"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrReplicationUnsupported is returned by AsReplicatedQueuer for storage
+// implementations which can't acknowledge queued leaves after replication.
+var ErrReplicationUnsupported = status.Error(codes.Unimplemented, "storage does not support acknowledging queued leaves after replication")
+
+// ReplicatedQueuer is implemented by LogStorage implementations which are
+// able to wait until queued leaves are replicated.
+type ReplicatedQueuer interface {
+	// QueueLeavesReplicated is like LogStorage.QueueLeaves, but only returns
+	// once the leaves are durably stored by a quorum of replicas, so that
+	// they survive the loss of the primary.
+	QueueLeavesReplicated(ctx context.Context, tree *trillian.Tree, leaves []*trillian.LogLeaf, queueTimestamp time.Time) ([]*trillian.QueuedLogLeaf, error)
+}
+
+// AsReplicatedQueuer returns s as a ReplicatedQueuer, or
+// ErrReplicationUnsupported if the storage implementation doesn't support
+// replicated queueing.
+func AsReplicatedQueuer(s LogStorage) (ReplicatedQueuer, error) {
+	rq, ok := s.(ReplicatedQueuer)
+	if !ok {
+		return nil, ErrReplicationUnsupported
+	}
+	return rq, nil
+}
@@ -0,0 +1,51 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrRetentionUnsupported is returned by AsRetentionTX for storage
+// implementations which can't prune leaf data.
+var ErrRetentionUnsupported = status.Error(codes.Unimplemented, "storage does not support leaf data retention")
+
+// RetentionTX is implemented by LogTreeTX implementations which are able to
+// prune the data of old sequenced leaves, so that the storage of logs with a
+// retention policy stays bounded.
+//
+// Pruning removes the LeafValue and ExtraData of a leaf, but keeps its hashes
+// and timestamps, so proofs can still be served and the leaf is still found
+// by hash. Pruned leaves are returned with an empty LeafValue.
+type RetentionTX interface {
+	// PruneLeafData prunes the data of up to limit sequenced leaves which
+	// were integrated before cutoff and haven't been pruned yet, lowest
+	// leaf index first. Returns the number of leaves pruned.
+	PruneLeafData(ctx context.Context, cutoff time.Time, limit int) (int, error)
+}
+
+// AsRetentionTX returns tx as a RetentionTX, or ErrRetentionUnsupported if
+// the storage implementation can't prune leaf data.
+func AsRetentionTX(tx ReadOnlyLogTreeTX) (RetentionTX, error) {
+	rtx, ok := tx.(RetentionTX)
+	if !ok {
+		return nil, ErrRetentionUnsupported
+	}
+	return rtx, nil
+}
@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/google/trillian"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -51,10 +52,10 @@ func ReadTree(row Row) (*trillian.Tree, error) {
 	tree := &trillian.Tree{}
 
 	// Enums and Datetimes need an extra conversion step
-	var treeState, treeType, hashStrategy, hashAlgorithm, signatureAlgorithm string
-	var createMillis, updateMillis, maxRootDurationMillis int64
-	var displayName, description sql.NullString
-	var privateKey, publicKey []byte
+	var treeState, treeType, hashStrategy, hashAlgorithm, signatureAlgorithm, conflictPolicy, readConsistency string
+	var createMillis, updateMillis, maxRootDurationMillis, maxMergeDelayMillis int64
+	var displayName, description, quotaProfile sql.NullString
+	var privateKey, publicKey, leafSchema, integrationPause, retentionPolicy, operator, treeQuota, storageCaps []byte
 	var deleted sql.NullBool
 	var deleteMillis sql.NullInt64
 	err := row.Scan(
@@ -73,6 +74,19 @@ func ReadTree(row Row) (*trillian.Tree, error) {
 		&maxRootDurationMillis,
 		&deleted,
 		&deleteMillis,
+		&conflictPolicy,
+		&tree.SequencedLeafDuplicateWindow,
+		&readConsistency,
+		&tree.EncryptHashes,
+		&maxMergeDelayMillis,
+		&leafSchema,
+		&integrationPause,
+		&quotaProfile,
+		&retentionPolicy,
+		&operator,
+		&treeQuota,
+		&storageCaps,
+		&tree.SignJsonLogRoots,
 	)
 	if err != nil {
 		return nil, err
@@ -80,6 +94,7 @@ func ReadTree(row Row) (*trillian.Tree, error) {
 
 	SetNullStringIfValid(displayName, &tree.DisplayName)
 	SetNullStringIfValid(description, &tree.Description)
+	SetNullStringIfValid(quotaProfile, &tree.QuotaProfile)
 
 	// Convert all things!
 	if ts, ok := trillian.TreeState_value[treeState]; ok {
@@ -92,6 +107,16 @@ func ReadTree(row Row) (*trillian.Tree, error) {
 	} else {
 		return nil, fmt.Errorf("unknown TreeType: %v", treeType)
 	}
+	if cp, ok := trillian.SequencedLeafConflictPolicy_value[conflictPolicy]; ok {
+		tree.SequencedLeafConflictPolicy = trillian.SequencedLeafConflictPolicy(cp)
+	} else {
+		return nil, fmt.Errorf("unknown SequencedLeafConflictPolicy: %v", conflictPolicy)
+	}
+	if rc, ok := trillian.ReadConsistency_value[readConsistency]; ok {
+		tree.ReadConsistency = trillian.ReadConsistency(rc)
+	} else {
+		return nil, fmt.Errorf("unknown ReadConsistency: %v", readConsistency)
+	}
 	if hashStrategy != "RFC6962_SHA256" {
 		return nil, fmt.Errorf("unknown HashStrategy: %v", hashStrategy)
 	}
@@ -115,6 +140,45 @@ func ReadTree(row Row) (*trillian.Tree, error) {
 		return nil, fmt.Errorf("failed to parse update time: %w", err)
 	}
 	tree.MaxRootDuration = durationpb.New(time.Duration(maxRootDurationMillis * int64(time.Millisecond)))
+	if maxMergeDelayMillis > 0 {
+		tree.MaxMergeDelay = durationpb.New(time.Duration(maxMergeDelayMillis * int64(time.Millisecond)))
+	}
+	if len(leafSchema) > 0 {
+		tree.LeafSchema = &trillian.LeafSchema{}
+		if err := proto.Unmarshal(leafSchema, tree.LeafSchema); err != nil {
+			return nil, fmt.Errorf("failed to parse leaf schema: %w", err)
+		}
+	}
+	if len(integrationPause) > 0 {
+		tree.IntegrationPause = &trillian.IntegrationPause{}
+		if err := proto.Unmarshal(integrationPause, tree.IntegrationPause); err != nil {
+			return nil, fmt.Errorf("failed to parse integration pause: %w", err)
+		}
+	}
+	if len(retentionPolicy) > 0 {
+		tree.RetentionPolicy = &trillian.RetentionPolicy{}
+		if err := proto.Unmarshal(retentionPolicy, tree.RetentionPolicy); err != nil {
+			return nil, fmt.Errorf("failed to parse retention policy: %w", err)
+		}
+	}
+	if len(operator) > 0 {
+		tree.Operator = &trillian.TreeOperator{}
+		if err := proto.Unmarshal(operator, tree.Operator); err != nil {
+			return nil, fmt.Errorf("failed to parse operator: %w", err)
+		}
+	}
+	if len(treeQuota) > 0 {
+		tree.Quota = &trillian.TreeQuota{}
+		if err := proto.Unmarshal(treeQuota, tree.Quota); err != nil {
+			return nil, fmt.Errorf("failed to parse quota: %w", err)
+		}
+	}
+	if len(storageCaps) > 0 {
+		tree.StorageCaps = &trillian.StorageCaps{}
+		if err := proto.Unmarshal(storageCaps, tree.StorageCaps); err != nil {
+			return nil, fmt.Errorf("failed to parse storage caps: %w", err)
+		}
+	}
 
 	tree.Deleted = deleted.Valid && deleted.Bool
 	if tree.Deleted && deleteMillis.Valid {
@@ -0,0 +1,179 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian/testonly"
+
+	_ "github.com/lib/pq" // postgres driver
+)
+
+const (
+	// PostgreSQLURIEnv is the name of the ENV variable checked for the test
+	// PostgreSQL instance URI to use. The database in the URI is only used to
+	// create and drop the test databases.
+	PostgreSQLURIEnv = "TEST_POSTGRESQL_URI"
+	// CockroachDBURIEnv is the name of the ENV variable checked for the test
+	// CockroachDB instance URI to use, as for PostgreSQLURIEnv.
+	CockroachDBURIEnv = "TEST_CRDB_URI"
+
+	defaultTestPostgreSQLURI  = "postgresql://postgres@127.0.0.1:5432/postgres?sslmode=disable"
+	defaultTestCockroachDBURI = "postgresql://root@127.0.0.1:26257/defaultdb?sslmode=disable"
+)
+
+var postgresSQL = testonly.RelativeToPackage("../postgres/schema/storage.sql")
+
+// pgServer is a database server which speaks the PostgreSQL protocol, and
+// runs the schema of the postgres storage.
+type pgServer struct {
+	name, uriEnv, defaultURI string
+}
+
+var (
+	postgreSQL  = pgServer{name: "PostgreSQL", uriEnv: PostgreSQLURIEnv, defaultURI: defaultTestPostgreSQLURI}
+	cockroachDB = pgServer{name: "CockroachDB", uriEnv: CockroachDBURIEnv, defaultURI: defaultTestCockroachDBURI}
+)
+
+// uri returns the connection URI of the server, see mysqlURI.
+func (s pgServer) uri() string {
+	if e := os.Getenv(s.uriEnv); len(e) > 0 {
+		return e
+	}
+	return s.defaultURI
+}
+
+func (s pgServer) available() bool {
+	db, err := sql.Open("postgres", s.uri())
+	if err != nil {
+		log.Printf("sql.Open(): %v", err)
+		return false
+	}
+	defer db.Close()
+	if err := db.Ping(); err != nil {
+		log.Printf("db.Ping(): %v", err)
+		return false
+	}
+	return true
+}
+
+// newTrillianDB creates a randomly-named database with the postgres storage
+// schema. It returns the database handle and a clean-up function, as
+// NewTrillianDB does.
+func (s pgServer) newTrillianDB(ctx context.Context) (*sql.DB, func(context.Context), error) {
+	u, err := url.Parse(s.uri())
+	if err != nil {
+		return nil, nil, err
+	}
+	admin, err := sql.Open("postgres", u.String())
+	if err != nil {
+		return nil, nil, err
+	}
+	name := fmt.Sprintf("trl_%v", time.Now().UnixNano())
+	stmt := fmt.Sprintf("CREATE DATABASE %v", name)
+	if _, err := admin.ExecContext(ctx, stmt); err != nil {
+		admin.Close()
+		return nil, nil, fmt.Errorf("error running statement %q: %v", stmt, err)
+	}
+
+	u.Path = "/" + name
+	db, err := sql.Open("postgres", u.String())
+	if err != nil {
+		admin.Close()
+		return nil, nil, err
+	}
+	done := func(ctx context.Context) {
+		defer admin.Close()
+		// The database can't be dropped while connections to it are open.
+		db.Close()
+		if _, err := admin.ExecContext(ctx, fmt.Sprintf("DROP DATABASE %v", name)); err != nil {
+			glog.Warningf("Failed to drop test database %q: %v", name, err)
+		}
+	}
+
+	sqlBytes, err := ioutil.ReadFile(postgresSQL)
+	if err != nil {
+		done(ctx)
+		return nil, nil, err
+	}
+	for _, stmt := range strings.Split(sanitize(string(sqlBytes)), ";") {
+		stmt = strings.TrimSpace(stmt)
+		if stmt == "" {
+			continue
+		}
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			done(ctx)
+			return nil, nil, fmt.Errorf("error running statement %q: %v", stmt, err)
+		}
+	}
+	return db, done, nil
+}
+
+func (s pgServer) skipIfUnavailable(t *testing.T) {
+	t.Helper()
+	if !s.available() {
+		t.Skipf("Skipping test as %s not available", s.name)
+	}
+	t.Logf("Test %s available at %q", s.name, s.uri())
+}
+
+// PostgreSQLAvailable indicates whether the configured PostgreSQL database is
+// available.
+func PostgreSQLAvailable() bool {
+	return postgreSQL.available()
+}
+
+// NewTrillianPostgreSQLDB creates an empty PostgreSQL database with the
+// schema of the postgres storage. The database name is randomly generated.
+func NewTrillianPostgreSQLDB(ctx context.Context) (*sql.DB, func(context.Context), error) {
+	return postgreSQL.newTrillianDB(ctx)
+}
+
+// SkipIfNoPostgreSQL is a test helper that skips tests that require a local
+// PostgreSQL.
+func SkipIfNoPostgreSQL(t *testing.T) {
+	t.Helper()
+	postgreSQL.skipIfUnavailable(t)
+}
+
+// CockroachDBAvailable indicates whether the configured CockroachDB database
+// is available.
+func CockroachDBAvailable() bool {
+	return cockroachDB.available()
+}
+
+// NewTrillianCockroachDB creates an empty CockroachDB database with the
+// schema of the postgres storage. The database name is randomly generated.
+func NewTrillianCockroachDB(ctx context.Context) (*sql.DB, func(context.Context), error) {
+	return cockroachDB.newTrillianDB(ctx)
+}
+
+// SkipIfNoCockroachDB is a test helper that skips tests that require a local
+// CockroachDB.
+func SkipIfNoCockroachDB(t *testing.T) {
+	t.Helper()
+	cockroachDB.skipIfUnavailable(t)
+}
@@ -61,6 +61,7 @@ type AdminStorageTester struct {
 // RunAllTests runs all AdminStorage tests.
 func (tester *AdminStorageTester) RunAllTests(t *testing.T) {
 	t.Run("TestCreateTree", tester.TestCreateTree)
+	t.Run("TestCreateTreeWithID", tester.TestCreateTreeWithID)
 	t.Run("TestUpdateTree", tester.TestUpdateTree)
 	t.Run("TestListTrees", tester.TestListTrees)
 	t.Run("TestSoftDeleteTree", tester.TestSoftDeleteTree)
@@ -159,6 +160,38 @@ func (tester *AdminStorageTester) TestCreateTree(t *testing.T) {
 	}
 }
 
+// TestCreateTreeWithID tests AdminStorage Tree creation with caller-specified
+// tree IDs.
+func (tester *AdminStorageTester) TestCreateTreeWithID(t *testing.T) {
+	ctx := context.Background()
+	s := tester.NewAdminStorage()
+
+	tree := proto.Clone(LogTree).(*trillian.Tree)
+	tree.TreeId = 12345
+	newTree, err := storage.CreateTree(ctx, s, tree)
+	if err != nil {
+		t.Fatalf("CreateTree() = (_, %v), want (_, nil)", err)
+	}
+	if got, want := newTree.TreeId, tree.TreeId; got != want {
+		t.Errorf("CreateTree() returned TreeId %v, want %v", got, want)
+	}
+	if err := assertStoredTree(ctx, s, newTree); err != nil {
+		t.Error(err)
+	}
+
+	if _, err := storage.CreateTree(ctx, s, tree); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("CreateTree() with a taken ID = (_, %v), want code %v", err, codes.AlreadyExists)
+	}
+
+	// Soft-deleted trees keep their IDs.
+	if _, err := storage.SoftDeleteTree(ctx, s, tree.TreeId); err != nil {
+		t.Fatalf("SoftDeleteTree() = (_, %v), want (_, nil)", err)
+	}
+	if _, err := storage.CreateTree(ctx, s, tree); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("CreateTree() with the ID of a soft-deleted tree = (_, %v), want code %v", err, codes.AlreadyExists)
+	}
+}
+
 // TestUpdateTree tests AdminStorage Tree updates.
 func (tester *AdminStorageTester) TestUpdateTree(t *testing.T) {
 	ctx := context.Background()
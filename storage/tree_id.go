@@ -18,6 +18,8 @@ import (
 	"crypto/rand"
 	"math"
 	"math/big"
+
+	"github.com/google/trillian"
 )
 
 // NewTreeID generates a random, positive, non-zero tree ID.
@@ -28,3 +30,12 @@ func NewTreeID() (int64, error) {
 	}
 	return id.Int64() + 1, nil
 }
+
+// TreeIDForCreation returns the ID of a tree being created: the tree ID set by
+// the caller of CreateTree, or a new random one if it's zero.
+func TreeIDForCreation(tree *trillian.Tree) (int64, error) {
+	if tree.TreeId != 0 {
+		return tree.TreeId, nil
+	}
+	return NewTreeID()
+}
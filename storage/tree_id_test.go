@@ -14,7 +14,11 @@
 
 package storage
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/google/trillian"
+)
 
 func TestNewTreeID(t *testing.T) {
 	// Grab a few IDs, check that they're not zero and not repeating.
@@ -33,3 +37,12 @@ func TestNewTreeID(t *testing.T) {
 		}
 	}
 }
+
+func TestTreeIDForCreation(t *testing.T) {
+	if id, err := TreeIDForCreation(&trillian.Tree{TreeId: 12345}); err != nil || id != 12345 {
+		t.Errorf("TreeIDForCreation(12345) = (%v, %v), want (12345, nil)", id, err)
+	}
+	if id, err := TreeIDForCreation(&trillian.Tree{}); err != nil || id <= 0 {
+		t.Errorf("TreeIDForCreation(0) = (%v, %v), want (> 0, nil)", id, err)
+	}
+}
@@ -16,8 +16,14 @@ package storage
 
 import (
 	"context"
+	"math"
+	"net/mail"
+	"net/url"
+	"strconv"
+	"strings"
 
 	"github.com/google/trillian"
+	"github.com/google/trillian/types"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
@@ -31,6 +37,8 @@ func ValidateTreeForCreation(ctx context.Context, tree *trillian.Tree) error {
 	switch {
 	case tree == nil:
 		return status.Error(codes.InvalidArgument, "a tree is required")
+	case tree.TreeId < 0:
+		return status.Errorf(codes.InvalidArgument, "invalid tree_id: %v", tree.TreeId)
 	case tree.TreeState != trillian.TreeState_ACTIVE:
 		return status.Errorf(codes.InvalidArgument, "invalid tree_state: %s", tree.TreeState)
 	case tree.TreeType == trillian.TreeType_UNKNOWN_TREE_TYPE:
@@ -88,6 +96,10 @@ func ValidateTreeForUpdate(ctx context.Context, storedTree, newTree *trillian.Tr
 		return status.Error(codes.InvalidArgument, "readonly field changed: deleted")
 	case !proto.Equal(storedTree.DeleteTime, newTree.DeleteTime):
 		return status.Error(codes.InvalidArgument, "readonly field changed: delete_time")
+	case storedTree.EncryptHashes != newTree.EncryptHashes:
+		return status.Error(codes.InvalidArgument, "readonly field changed: encrypt_hashes")
+	case storedTree.QuotaProfile != newTree.QuotaProfile:
+		return status.Error(codes.InvalidArgument, "readonly field changed: quota_profile")
 	}
 	return validateMutableTreeFields(ctx, newTree)
 }
@@ -102,6 +114,89 @@ func validateMutableTreeFields(ctx context.Context, tree *trillian.Tree) error {
 		return status.Errorf(codes.InvalidArgument, "max_root_duration negative: %v", tree.MaxRootDuration)
 	}
 
+	if _, ok := trillian.SequencedLeafConflictPolicy_name[int32(tree.SequencedLeafConflictPolicy)]; !ok {
+		return status.Errorf(codes.InvalidArgument, "invalid sequenced_leaf_conflict_policy: %v", tree.SequencedLeafConflictPolicy)
+	}
+	if tree.SequencedLeafDuplicateWindow < 0 {
+		return status.Errorf(codes.InvalidArgument, "sequenced_leaf_duplicate_window negative: %v", tree.SequencedLeafDuplicateWindow)
+	}
+	if _, ok := trillian.ReadConsistency_name[int32(tree.ReadConsistency)]; !ok {
+		return status.Errorf(codes.InvalidArgument, "invalid read_consistency: %v", tree.ReadConsistency)
+	}
+	if tree.MaxMergeDelay != nil {
+		if err := tree.MaxMergeDelay.CheckValid(); err != nil {
+			return status.Errorf(codes.InvalidArgument, "max_merge_delay malformed: %v", err)
+		} else if duration := tree.MaxMergeDelay.AsDuration(); duration <= 0 {
+			return status.Errorf(codes.InvalidArgument, "max_merge_delay not positive: %v", tree.MaxMergeDelay)
+		}
+	}
+
+	if tree.LeafSchema != nil {
+		if _, err := types.NewLeafValidator(tree.LeafSchema); err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid leaf_schema: %v", err)
+		}
+	}
+
+	if p := tree.IntegrationPause; p != nil {
+		if err := p.PauseTime.CheckValid(); err != nil {
+			return status.Errorf(codes.InvalidArgument, "integration_pause.pause_time malformed: %v", err)
+		}
+		if p.ResumeTime != nil {
+			if err := p.ResumeTime.CheckValid(); err != nil {
+				return status.Errorf(codes.InvalidArgument, "integration_pause.resume_time malformed: %v", err)
+			}
+		}
+	}
+
+	if p := tree.RetentionPolicy; p != nil {
+		if err := p.MaxLeafAge.CheckValid(); err != nil {
+			return status.Errorf(codes.InvalidArgument, "retention_policy.max_leaf_age malformed: %v", err)
+		} else if age := p.MaxLeafAge.AsDuration(); age <= 0 {
+			return status.Errorf(codes.InvalidArgument, "retention_policy.max_leaf_age not positive: %v", p.MaxLeafAge)
+		}
+	}
+
+	if tree.Operator != nil {
+		if err := validateTreeOperator(tree.Operator); err != nil {
+			return err
+		}
+	}
+
+	if q := tree.Quota; q != nil {
+		for _, rate := range []struct {
+			name  string
+			rate  float64
+			burst int64
+		}{
+			{name: "read", rate: q.ReadTokensPerSecond, burst: q.ReadBurst},
+			{name: "write", rate: q.WriteTokensPerSecond, burst: q.WriteBurst},
+		} {
+			if !(rate.rate >= 0) || math.IsInf(rate.rate, 1) {
+				return status.Errorf(codes.InvalidArgument, "invalid quota.%s_tokens_per_second: %v", rate.name, rate.rate)
+			}
+			if rate.burst < 0 {
+				return status.Errorf(codes.InvalidArgument, "quota.%s_burst negative: %v", rate.name, rate.burst)
+			}
+		}
+	}
+
+	if c := tree.StorageCaps; c != nil {
+		for _, limit := range []struct {
+			name       string
+			soft, hard int64
+		}{
+			{name: "leaves", soft: c.SoftMaxLeaves, hard: c.HardMaxLeaves},
+			{name: "bytes", soft: c.SoftMaxBytes, hard: c.HardMaxBytes},
+		} {
+			if limit.soft < 0 || limit.hard < 0 {
+				return status.Errorf(codes.InvalidArgument, "storage_caps max_%s negative: soft %d, hard %d", limit.name, limit.soft, limit.hard)
+			}
+			if limit.hard > 0 && limit.soft > limit.hard {
+				return status.Errorf(codes.InvalidArgument, "storage_caps.soft_max_%s %d above hard_max_%s %d", limit.name, limit.soft, limit.name, limit.hard)
+			}
+		}
+	}
+
 	// Implementations may vary, so let's assume storage_settings is mutable.
 	// Other than checking that it's a valid Any there isn't much to do at this layer, though.
 	if tree.StorageSettings != nil {
@@ -113,3 +208,51 @@ func validateMutableTreeFields(ctx context.Context, tree *trillian.Tree) error {
 
 	return nil
 }
+
+func validateTreeOperator(op *trillian.TreeOperator) error {
+	if op.ContactEmail != "" {
+		if addr, err := mail.ParseAddress(op.ContactEmail); err != nil || addr.Name != "" || addr.Address != op.ContactEmail {
+			return status.Errorf(codes.InvalidArgument, "invalid operator.contact_email: %q", op.ContactEmail)
+		}
+	}
+	if op.Url != "" {
+		if u, err := url.Parse(op.Url); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return status.Errorf(codes.InvalidArgument, "invalid operator.url: %q, want an absolute http or https URL", op.Url)
+		}
+	}
+	seen := make(map[string]bool)
+	for _, oid := range op.PolicyOids {
+		if !validOID(oid) {
+			return status.Errorf(codes.InvalidArgument, "invalid operator.policy_oids: %q", oid)
+		}
+		if seen[oid] {
+			return status.Errorf(codes.InvalidArgument, "duplicate operator.policy_oids: %q", oid)
+		}
+		seen[oid] = true
+	}
+	return nil
+}
+
+// validOID returns whether oid is an object identifier in dotted-decimal
+// notation, such as "1.3.6.1.4.1.11129".
+func validOID(oid string) bool {
+	arcs := strings.Split(oid, ".")
+	if len(arcs) < 2 {
+		return false
+	}
+	for i, arc := range arcs {
+		if arc == "" || (len(arc) > 1 && arc[0] == '0') {
+			return false
+		}
+		n, err := strconv.ParseUint(arc, 10, 64)
+		switch {
+		case err != nil:
+			return false
+		case i == 0 && n > 2:
+			return false
+		case i == 1 && arcs[0] != "2" && n > 39:
+			return false
+		}
+	}
+	return true
+}
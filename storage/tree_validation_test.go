@@ -16,10 +16,12 @@ package storage
 
 import (
 	"context"
+	"math"
 	"testing"
 	"time"
 
 	"github.com/google/trillian"
+	"github.com/google/trillian/testonly"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
@@ -67,6 +69,12 @@ func TestValidateTreeForCreation(t *testing.T) {
 	deleteTimeTree := newTree()
 	deleteTimeTree.DeleteTime = timestamppb.Now()
 
+	withTreeID := newTree()
+	withTreeID.TreeId = 12345
+
+	negativeTreeID := newTree()
+	negativeTreeID.TreeId = -1
+
 	tests := []struct {
 		desc    string
 		tree    *trillian.Tree
@@ -129,6 +137,15 @@ func TestValidateTreeForCreation(t *testing.T) {
 			tree:    deleteTimeTree,
 			wantErr: true,
 		},
+		{
+			desc: "withTreeID",
+			tree: withTreeID,
+		},
+		{
+			desc:    "negativeTreeID",
+			tree:    negativeTreeID,
+			wantErr: true,
+		},
 	}
 	for _, test := range tests {
 		err := ValidateTreeForCreation(ctx, test.tree)
@@ -194,6 +211,223 @@ func TestValidateTreeForUpdate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			desc: "validConflictPolicy",
+			updatefn: func(tree *trillian.Tree) {
+				tree.SequencedLeafConflictPolicy = trillian.SequencedLeafConflictPolicy_CONFLICT_SKIP
+				tree.SequencedLeafDuplicateWindow = 1000
+			},
+		},
+		{
+			desc: "invalidConflictPolicy",
+			updatefn: func(tree *trillian.Tree) {
+				tree.SequencedLeafConflictPolicy = 42
+			},
+			wantErr: true,
+		},
+		{
+			desc: "negativeDuplicateWindow",
+			updatefn: func(tree *trillian.Tree) {
+				tree.SequencedLeafDuplicateWindow = -1
+			},
+			wantErr: true,
+		},
+		{
+			desc: "validReadConsistency",
+			updatefn: func(tree *trillian.Tree) {
+				tree.ReadConsistency = trillian.ReadConsistency_EVENTUAL
+			},
+		},
+		{
+			desc: "invalidReadConsistency",
+			updatefn: func(tree *trillian.Tree) {
+				tree.ReadConsistency = 42
+			},
+			wantErr: true,
+		},
+		{
+			desc: "validMergeDelay",
+			updatefn: func(tree *trillian.Tree) {
+				tree.MaxMergeDelay = durationpb.New(time.Hour)
+			},
+		},
+		{
+			desc: "zeroMergeDelay",
+			updatefn: func(tree *trillian.Tree) {
+				tree.MaxMergeDelay = durationpb.New(0)
+			},
+			wantErr: true,
+		},
+		{
+			desc: "validLeafSchema",
+			updatefn: func(tree *trillian.Tree) {
+				tree.LeafSchema = testonly.EntryLeafSchema(trillian.LeafSchema_PROTO_JSON)
+			},
+		},
+		{
+			desc: "invalidLeafSchema",
+			updatefn: func(tree *trillian.Tree) {
+				tree.LeafSchema = testonly.EntryLeafSchema(trillian.LeafSchema_PROTO_JSON)
+				tree.LeafSchema.MessageType = "example.Missing"
+			},
+			wantErr: true,
+		},
+		{
+			desc: "validIntegrationPause",
+			updatefn: func(tree *trillian.Tree) {
+				tree.IntegrationPause = &trillian.IntegrationPause{
+					PauseTime:  timestamppb.New(time.Unix(1000, 0)),
+					ResumeTime: timestamppb.New(time.Unix(2000, 0)),
+				}
+			},
+		},
+		{
+			desc: "integrationPauseWithoutTime",
+			updatefn: func(tree *trillian.Tree) {
+				tree.IntegrationPause = &trillian.IntegrationPause{Reason: "maintenance"}
+			},
+			wantErr: true,
+		},
+		{
+			desc: "validRetentionPolicy",
+			updatefn: func(tree *trillian.Tree) {
+				tree.RetentionPolicy = &trillian.RetentionPolicy{MaxLeafAge: durationpb.New(24 * time.Hour)}
+			},
+		},
+		{
+			desc: "retentionPolicyWithoutAge",
+			updatefn: func(tree *trillian.Tree) {
+				tree.RetentionPolicy = &trillian.RetentionPolicy{}
+			},
+			wantErr: true,
+		},
+		{
+			desc: "negativeRetentionAge",
+			updatefn: func(tree *trillian.Tree) {
+				tree.RetentionPolicy = &trillian.RetentionPolicy{MaxLeafAge: durationpb.New(-time.Hour)}
+			},
+			wantErr: true,
+		},
+		{
+			desc: "validOperator",
+			updatefn: func(tree *trillian.Tree) {
+				tree.Operator = &trillian.TreeOperator{
+					ContactEmail: "log-admin@example.com",
+					Url:          "https://example.com/logs",
+					PolicyOids:   []string{"1.3.6.1.4.1.11129", "2.999.1"},
+				}
+			},
+		},
+		{
+			desc: "operatorEmailWithName",
+			updatefn: func(tree *trillian.Tree) {
+				tree.Operator = &trillian.TreeOperator{ContactEmail: "Log Admin <log-admin@example.com>"}
+			},
+			wantErr: true,
+		},
+		{
+			desc: "operatorBadEmail",
+			updatefn: func(tree *trillian.Tree) {
+				tree.Operator = &trillian.TreeOperator{ContactEmail: "log-admin"}
+			},
+			wantErr: true,
+		},
+		{
+			desc: "operatorRelativeURL",
+			updatefn: func(tree *trillian.Tree) {
+				tree.Operator = &trillian.TreeOperator{Url: "/logs"}
+			},
+			wantErr: true,
+		},
+		{
+			desc: "operatorFTPURL",
+			updatefn: func(tree *trillian.Tree) {
+				tree.Operator = &trillian.TreeOperator{Url: "ftp://example.com/logs"}
+			},
+			wantErr: true,
+		},
+		{
+			desc: "operatorSingleArcOID",
+			updatefn: func(tree *trillian.Tree) {
+				tree.Operator = &trillian.TreeOperator{PolicyOids: []string{"1"}}
+			},
+			wantErr: true,
+		},
+		{
+			desc: "operatorBadFirstArcOID",
+			updatefn: func(tree *trillian.Tree) {
+				tree.Operator = &trillian.TreeOperator{PolicyOids: []string{"3.1"}}
+			},
+			wantErr: true,
+		},
+		{
+			desc: "operatorLeadingZeroOID",
+			updatefn: func(tree *trillian.Tree) {
+				tree.Operator = &trillian.TreeOperator{PolicyOids: []string{"1.03"}}
+			},
+			wantErr: true,
+		},
+		{
+			desc: "operatorEmptyArcOID",
+			updatefn: func(tree *trillian.Tree) {
+				tree.Operator = &trillian.TreeOperator{PolicyOids: []string{"1..3"}}
+			},
+			wantErr: true,
+		},
+		{
+			desc: "operatorDuplicateOID",
+			updatefn: func(tree *trillian.Tree) {
+				tree.Operator = &trillian.TreeOperator{PolicyOids: []string{"1.2.3", "1.2.3"}}
+			},
+			wantErr: true,
+		},
+		{
+			desc: "validQuota",
+			updatefn: func(tree *trillian.Tree) {
+				tree.Quota = &trillian.TreeQuota{ReadTokensPerSecond: 100, ReadBurst: 200, WriteTokensPerSecond: 0.5}
+			},
+		},
+		{
+			desc: "negativeQuotaRate",
+			updatefn: func(tree *trillian.Tree) {
+				tree.Quota = &trillian.TreeQuota{WriteTokensPerSecond: -1}
+			},
+			wantErr: true,
+		},
+		{
+			desc: "infiniteQuotaRate",
+			updatefn: func(tree *trillian.Tree) {
+				tree.Quota = &trillian.TreeQuota{ReadTokensPerSecond: math.Inf(1)}
+			},
+			wantErr: true,
+		},
+		{
+			desc: "negativeQuotaBurst",
+			updatefn: func(tree *trillian.Tree) {
+				tree.Quota = &trillian.TreeQuota{ReadTokensPerSecond: 1, ReadBurst: -1}
+			},
+			wantErr: true,
+		},
+		{
+			desc: "validStorageCaps",
+			updatefn: func(tree *trillian.Tree) {
+				tree.StorageCaps = &trillian.StorageCaps{SoftMaxLeaves: 100, HardMaxLeaves: 200, SoftMaxBytes: 1 << 20}
+			},
+		},
+		{
+			desc: "negativeStorageCap",
+			updatefn: func(tree *trillian.Tree) {
+				tree.StorageCaps = &trillian.StorageCaps{HardMaxBytes: -1}
+			},
+			wantErr: true,
+		},
+		{
+			desc: "softStorageCapAboveHard",
+			updatefn: func(tree *trillian.Tree) {
+				tree.StorageCaps = &trillian.StorageCaps{SoftMaxLeaves: 300, HardMaxLeaves: 200}
+			},
+			wantErr: true,
+		},
 		// Changes on readonly fields
 		{
 			desc: "TreeId",
@@ -259,6 +493,11 @@ func TestValidateTreeForUpdate(t *testing.T) {
 			updatefn: func(tree *trillian.Tree) { tree.DeleteTime = timestamppb.Now() },
 			wantErr:  true,
 		},
+		{
+			desc:     "EncryptHashes",
+			updatefn: func(tree *trillian.Tree) { tree.EncryptHashes = !tree.EncryptHashes },
+			wantErr:  true,
+		},
 	}
 	for _, test := range tests {
 		tree := newTree()
@@ -0,0 +1,49 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+
+	"github.com/google/trillian"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrVerificationFailuresUnsupported is returned by AsVerificationFailureTX
+// for storage implementations which don't store verification failure reports.
+var ErrVerificationFailuresUnsupported = status.Error(codes.Unimplemented, "storage does not support verification failure reports")
+
+// VerificationFailureTX is implemented by LogTreeTX implementations which
+// store the reports of clients which failed to verify data served by the log.
+type VerificationFailureTX interface {
+	// AddVerificationFailureReport stores a report for the tree.
+	AddVerificationFailureReport(ctx context.Context, report *trillian.VerificationFailureReport) error
+
+	// ListVerificationFailureReports returns the stored reports of the tree,
+	// oldest first.
+	ListVerificationFailureReports(ctx context.Context) ([]*trillian.VerificationFailureReport, error)
+}
+
+// AsVerificationFailureTX returns tx as a VerificationFailureTX, or
+// ErrVerificationFailuresUnsupported if the storage implementation doesn't
+// store verification failure reports.
+func AsVerificationFailureTX(tx ReadOnlyLogTreeTX) (VerificationFailureTX, error) {
+	vtx, ok := tx.(VerificationFailureTX)
+	if !ok {
+		return nil, ErrVerificationFailuresUnsupported
+	}
+	return vtx, nil
+}
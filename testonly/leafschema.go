@@ -0,0 +1,53 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testonly
+
+import (
+	"github.com/google/trillian"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// EntryLeafSchema returns a LeafSchema for leaf values which encode the
+// following message type with the given encoding:
+//
+//	package example;
+//	message Entry {
+//	  string name = 1;
+//	  int64 size = 2;
+//	  Entry child = 3;
+//	}
+func EntryLeafSchema(encoding trillian.LeafSchema_Encoding) *trillian.LeafSchema {
+	optional := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()
+	return &trillian.LeafSchema{
+		FileDescriptors: &descriptorpb.FileDescriptorSet{
+			File: []*descriptorpb.FileDescriptorProto{{
+				Name:    proto.String("example/entry.proto"),
+				Package: proto.String("example"),
+				Syntax:  proto.String("proto3"),
+				MessageType: []*descriptorpb.DescriptorProto{{
+					Name: proto.String("Entry"),
+					Field: []*descriptorpb.FieldDescriptorProto{
+						{Name: proto.String("name"), JsonName: proto.String("name"), Number: proto.Int32(1), Label: optional, Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum()},
+						{Name: proto.String("size"), JsonName: proto.String("size"), Number: proto.Int32(2), Label: optional, Type: descriptorpb.FieldDescriptorProto_TYPE_INT64.Enum()},
+						{Name: proto.String("child"), JsonName: proto.String("child"), Number: proto.Int32(3), Label: optional, Type: descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(), TypeName: proto.String(".example.Entry")},
+					},
+				}},
+			}},
+		},
+		MessageType: "example.Entry",
+		Encoding:    encoding,
+	}
+}
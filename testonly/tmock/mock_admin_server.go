@@ -65,6 +65,34 @@ func (mr *MockTrillianAdminServerMockRecorder) DeleteTree(arg0, arg1 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTree", reflect.TypeOf((*MockTrillianAdminServer)(nil).DeleteTree), arg0, arg1)
 }
 
+// ExportQueue mocks base method.
+func (m *MockTrillianAdminServer) ExportQueue(arg0 *trillian.ExportQueueRequest, arg1 trillian.TrillianAdmin_ExportQueueServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportQueue", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportQueue indicates an expected call of ExportQueue.
+func (mr *MockTrillianAdminServerMockRecorder) ExportQueue(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportQueue", reflect.TypeOf((*MockTrillianAdminServer)(nil).ExportQueue), arg0, arg1)
+}
+
+// ExportTree mocks base method.
+func (m *MockTrillianAdminServer) ExportTree(arg0 *trillian.ExportTreeRequest, arg1 trillian.TrillianAdmin_ExportTreeServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportTree", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportTree indicates an expected call of ExportTree.
+func (mr *MockTrillianAdminServerMockRecorder) ExportTree(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportTree", reflect.TypeOf((*MockTrillianAdminServer)(nil).ExportTree), arg0, arg1)
+}
+
 // GetTree mocks base method.
 func (m *MockTrillianAdminServer) GetTree(arg0 context.Context, arg1 *trillian.GetTreeRequest) (*trillian.Tree, error) {
 	m.ctrl.T.Helper()
@@ -80,6 +108,64 @@ func (mr *MockTrillianAdminServerMockRecorder) GetTree(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTree", reflect.TypeOf((*MockTrillianAdminServer)(nil).GetTree), arg0, arg1)
 }
 
+// GetTreeQuota mocks base method.
+func (m *MockTrillianAdminServer) GetTreeQuota(arg0 context.Context, arg1 *trillian.GetTreeQuotaRequest) (*trillian.TreeQuota, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTreeQuota", arg0, arg1)
+	ret0, _ := ret[0].(*trillian.TreeQuota)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTreeQuota indicates an expected call of GetTreeQuota.
+func (mr *MockTrillianAdminServerMockRecorder) GetTreeQuota(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTreeQuota", reflect.TypeOf((*MockTrillianAdminServer)(nil).GetTreeQuota), arg0, arg1)
+}
+
+// ImportQueue mocks base method.
+func (m *MockTrillianAdminServer) ImportQueue(arg0 trillian.TrillianAdmin_ImportQueueServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportQueue", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportQueue indicates an expected call of ImportQueue.
+func (mr *MockTrillianAdminServerMockRecorder) ImportQueue(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportQueue", reflect.TypeOf((*MockTrillianAdminServer)(nil).ImportQueue), arg0)
+}
+
+// ImportTree mocks base method.
+func (m *MockTrillianAdminServer) ImportTree(arg0 trillian.TrillianAdmin_ImportTreeServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportTree", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportTree indicates an expected call of ImportTree.
+func (mr *MockTrillianAdminServerMockRecorder) ImportTree(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportTree", reflect.TypeOf((*MockTrillianAdminServer)(nil).ImportTree), arg0)
+}
+
+// ListQuarantinedLeaves mocks base method.
+func (m *MockTrillianAdminServer) ListQuarantinedLeaves(arg0 context.Context, arg1 *trillian.ListQuarantinedLeavesRequest) (*trillian.ListQuarantinedLeavesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListQuarantinedLeaves", arg0, arg1)
+	ret0, _ := ret[0].(*trillian.ListQuarantinedLeavesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListQuarantinedLeaves indicates an expected call of ListQuarantinedLeaves.
+func (mr *MockTrillianAdminServerMockRecorder) ListQuarantinedLeaves(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListQuarantinedLeaves", reflect.TypeOf((*MockTrillianAdminServer)(nil).ListQuarantinedLeaves), arg0, arg1)
+}
+
 // ListTrees mocks base method.
 func (m *MockTrillianAdminServer) ListTrees(arg0 context.Context, arg1 *trillian.ListTreesRequest) (*trillian.ListTreesResponse, error) {
 	m.ctrl.T.Helper()
@@ -95,6 +181,111 @@ func (mr *MockTrillianAdminServerMockRecorder) ListTrees(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrees", reflect.TypeOf((*MockTrillianAdminServer)(nil).ListTrees), arg0, arg1)
 }
 
+// PauseIntegration mocks base method.
+func (m *MockTrillianAdminServer) PauseIntegration(arg0 context.Context, arg1 *trillian.PauseIntegrationRequest) (*trillian.Tree, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PauseIntegration", arg0, arg1)
+	ret0, _ := ret[0].(*trillian.Tree)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PauseIntegration indicates an expected call of PauseIntegration.
+func (mr *MockTrillianAdminServerMockRecorder) PauseIntegration(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseIntegration", reflect.TypeOf((*MockTrillianAdminServer)(nil).PauseIntegration), arg0, arg1)
+}
+
+// PruneLeaves mocks base method.
+func (m *MockTrillianAdminServer) PruneLeaves(arg0 context.Context, arg1 *trillian.PruneLeavesRequest) (*trillian.PruneLeavesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PruneLeaves", arg0, arg1)
+	ret0, _ := ret[0].(*trillian.PruneLeavesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneLeaves indicates an expected call of PruneLeaves.
+func (mr *MockTrillianAdminServerMockRecorder) PruneLeaves(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneLeaves", reflect.TypeOf((*MockTrillianAdminServer)(nil).PruneLeaves), arg0, arg1)
+}
+
+// QuarantineLeaf mocks base method.
+func (m *MockTrillianAdminServer) QuarantineLeaf(arg0 context.Context, arg1 *trillian.QuarantineLeafRequest) (*trillian.QuarantineLeafResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QuarantineLeaf", arg0, arg1)
+	ret0, _ := ret[0].(*trillian.QuarantineLeafResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QuarantineLeaf indicates an expected call of QuarantineLeaf.
+func (mr *MockTrillianAdminServerMockRecorder) QuarantineLeaf(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QuarantineLeaf", reflect.TypeOf((*MockTrillianAdminServer)(nil).QuarantineLeaf), arg0, arg1)
+}
+
+// RequeueQuarantinedLeaves mocks base method.
+func (m *MockTrillianAdminServer) RequeueQuarantinedLeaves(arg0 context.Context, arg1 *trillian.RequeueQuarantinedLeavesRequest) (*trillian.RequeueQuarantinedLeavesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequeueQuarantinedLeaves", arg0, arg1)
+	ret0, _ := ret[0].(*trillian.RequeueQuarantinedLeavesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequeueQuarantinedLeaves indicates an expected call of RequeueQuarantinedLeaves.
+func (mr *MockTrillianAdminServerMockRecorder) RequeueQuarantinedLeaves(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequeueQuarantinedLeaves", reflect.TypeOf((*MockTrillianAdminServer)(nil).RequeueQuarantinedLeaves), arg0, arg1)
+}
+
+// ResignLogRoot mocks base method.
+func (m *MockTrillianAdminServer) ResignLogRoot(arg0 context.Context, arg1 *trillian.ResignLogRootRequest) (*trillian.ResignLogRootResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResignLogRoot", arg0, arg1)
+	ret0, _ := ret[0].(*trillian.ResignLogRootResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResignLogRoot indicates an expected call of ResignLogRoot.
+func (mr *MockTrillianAdminServerMockRecorder) ResignLogRoot(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResignLogRoot", reflect.TypeOf((*MockTrillianAdminServer)(nil).ResignLogRoot), arg0, arg1)
+}
+
+// ResumeIntegration mocks base method.
+func (m *MockTrillianAdminServer) ResumeIntegration(arg0 context.Context, arg1 *trillian.ResumeIntegrationRequest) (*trillian.Tree, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResumeIntegration", arg0, arg1)
+	ret0, _ := ret[0].(*trillian.Tree)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResumeIntegration indicates an expected call of ResumeIntegration.
+func (mr *MockTrillianAdminServerMockRecorder) ResumeIntegration(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeIntegration", reflect.TypeOf((*MockTrillianAdminServer)(nil).ResumeIntegration), arg0, arg1)
+}
+
+// SetTreeQuota mocks base method.
+func (m *MockTrillianAdminServer) SetTreeQuota(arg0 context.Context, arg1 *trillian.SetTreeQuotaRequest) (*trillian.TreeQuota, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTreeQuota", arg0, arg1)
+	ret0, _ := ret[0].(*trillian.TreeQuota)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetTreeQuota indicates an expected call of SetTreeQuota.
+func (mr *MockTrillianAdminServerMockRecorder) SetTreeQuota(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTreeQuota", reflect.TypeOf((*MockTrillianAdminServer)(nil).SetTreeQuota), arg0, arg1)
+}
+
 // UndeleteTree mocks base method.
 func (m *MockTrillianAdminServer) UndeleteTree(arg0 context.Context, arg1 *trillian.UndeleteTreeRequest) (*trillian.Tree, error) {
 	m.ctrl.T.Helper()
@@ -124,3 +315,17 @@ func (mr *MockTrillianAdminServerMockRecorder) UpdateTree(arg0, arg1 interface{}
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTree", reflect.TypeOf((*MockTrillianAdminServer)(nil).UpdateTree), arg0, arg1)
 }
+
+// WatchTrees mocks base method.
+func (m *MockTrillianAdminServer) WatchTrees(arg0 *trillian.WatchTreesRequest, arg1 trillian.TrillianAdmin_WatchTreesServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchTrees", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WatchTrees indicates an expected call of WatchTrees.
+func (mr *MockTrillianAdminServerMockRecorder) WatchTrees(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchTrees", reflect.TypeOf((*MockTrillianAdminServer)(nil).WatchTrees), arg0, arg1)
+}
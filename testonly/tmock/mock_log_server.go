@@ -35,6 +35,21 @@ func (m *MockTrillianLogServer) EXPECT() *MockTrillianLogServerMockRecorder {
 	return m.recorder
 }
 
+// AddCosignature mocks base method.
+func (m *MockTrillianLogServer) AddCosignature(arg0 context.Context, arg1 *trillian.AddCosignatureRequest) (*trillian.AddCosignatureResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCosignature", arg0, arg1)
+	ret0, _ := ret[0].(*trillian.AddCosignatureResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddCosignature indicates an expected call of AddCosignature.
+func (mr *MockTrillianLogServerMockRecorder) AddCosignature(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCosignature", reflect.TypeOf((*MockTrillianLogServer)(nil).AddCosignature), arg0, arg1)
+}
+
 // AddSequencedLeaves mocks base method.
 func (m *MockTrillianLogServer) AddSequencedLeaves(arg0 context.Context, arg1 *trillian.AddSequencedLeavesRequest) (*trillian.AddSequencedLeavesResponse, error) {
 	m.ctrl.T.Helper()
@@ -50,6 +65,65 @@ func (mr *MockTrillianLogServerMockRecorder) AddSequencedLeaves(arg0, arg1 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSequencedLeaves", reflect.TypeOf((*MockTrillianLogServer)(nil).AddSequencedLeaves), arg0, arg1)
 }
 
+// AddSequencedLeavesStream mocks base method.
+func (m *MockTrillianLogServer) AddSequencedLeavesStream(arg0 trillian.TrillianLog_AddSequencedLeavesStreamServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSequencedLeavesStream", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddSequencedLeavesStream indicates an expected call of AddSequencedLeavesStream.
+func (mr *MockTrillianLogServerMockRecorder) AddSequencedLeavesStream(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSequencedLeavesStream", reflect.TypeOf((*MockTrillianLogServer)(nil).AddSequencedLeavesStream), arg0)
+}
+
+// BeginAuditSession mocks base method.
+func (m *MockTrillianLogServer) BeginAuditSession(arg0 context.Context, arg1 *trillian.BeginAuditSessionRequest) (*trillian.BeginAuditSessionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginAuditSession", arg0, arg1)
+	ret0, _ := ret[0].(*trillian.BeginAuditSessionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginAuditSession indicates an expected call of BeginAuditSession.
+func (mr *MockTrillianLogServerMockRecorder) BeginAuditSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginAuditSession", reflect.TypeOf((*MockTrillianLogServer)(nil).BeginAuditSession), arg0, arg1)
+}
+
+// EndAuditSession mocks base method.
+func (m *MockTrillianLogServer) EndAuditSession(arg0 context.Context, arg1 *trillian.EndAuditSessionRequest) (*trillian.EndAuditSessionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EndAuditSession", arg0, arg1)
+	ret0, _ := ret[0].(*trillian.EndAuditSessionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EndAuditSession indicates an expected call of EndAuditSession.
+func (mr *MockTrillianLogServerMockRecorder) EndAuditSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EndAuditSession", reflect.TypeOf((*MockTrillianLogServer)(nil).EndAuditSession), arg0, arg1)
+}
+
+// GetCompactRange mocks base method.
+func (m *MockTrillianLogServer) GetCompactRange(arg0 context.Context, arg1 *trillian.GetCompactRangeRequest) (*trillian.GetCompactRangeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompactRange", arg0, arg1)
+	ret0, _ := ret[0].(*trillian.GetCompactRangeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompactRange indicates an expected call of GetCompactRange.
+func (mr *MockTrillianLogServerMockRecorder) GetCompactRange(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompactRange", reflect.TypeOf((*MockTrillianLogServer)(nil).GetCompactRange), arg0, arg1)
+}
+
 // GetConsistencyProof mocks base method.
 func (m *MockTrillianLogServer) GetConsistencyProof(arg0 context.Context, arg1 *trillian.GetConsistencyProofRequest) (*trillian.GetConsistencyProofResponse, error) {
 	m.ctrl.T.Helper()
@@ -65,6 +139,36 @@ func (mr *MockTrillianLogServerMockRecorder) GetConsistencyProof(arg0, arg1 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConsistencyProof", reflect.TypeOf((*MockTrillianLogServer)(nil).GetConsistencyProof), arg0, arg1)
 }
 
+// GetCosignedCheckpoint mocks base method.
+func (m *MockTrillianLogServer) GetCosignedCheckpoint(arg0 context.Context, arg1 *trillian.GetCosignedCheckpointRequest) (*trillian.GetCosignedCheckpointResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCosignedCheckpoint", arg0, arg1)
+	ret0, _ := ret[0].(*trillian.GetCosignedCheckpointResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCosignedCheckpoint indicates an expected call of GetCosignedCheckpoint.
+func (mr *MockTrillianLogServerMockRecorder) GetCosignedCheckpoint(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCosignedCheckpoint", reflect.TypeOf((*MockTrillianLogServer)(nil).GetCosignedCheckpoint), arg0, arg1)
+}
+
+// GetDailyLogStats mocks base method.
+func (m *MockTrillianLogServer) GetDailyLogStats(arg0 context.Context, arg1 *trillian.GetDailyLogStatsRequest) (*trillian.GetDailyLogStatsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDailyLogStats", arg0, arg1)
+	ret0, _ := ret[0].(*trillian.GetDailyLogStatsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDailyLogStats indicates an expected call of GetDailyLogStats.
+func (mr *MockTrillianLogServerMockRecorder) GetDailyLogStats(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDailyLogStats", reflect.TypeOf((*MockTrillianLogServer)(nil).GetDailyLogStats), arg0, arg1)
+}
+
 // GetEntryAndProof mocks base method.
 func (m *MockTrillianLogServer) GetEntryAndProof(arg0 context.Context, arg1 *trillian.GetEntryAndProofRequest) (*trillian.GetEntryAndProofResponse, error) {
 	m.ctrl.T.Helper()
@@ -110,6 +214,21 @@ func (mr *MockTrillianLogServerMockRecorder) GetInclusionProofByHash(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInclusionProofByHash", reflect.TypeOf((*MockTrillianLogServer)(nil).GetInclusionProofByHash), arg0, arg1)
 }
 
+// GetInclusionProofsAtSizes mocks base method.
+func (m *MockTrillianLogServer) GetInclusionProofsAtSizes(arg0 context.Context, arg1 *trillian.GetInclusionProofsAtSizesRequest) (*trillian.GetInclusionProofsAtSizesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInclusionProofsAtSizes", arg0, arg1)
+	ret0, _ := ret[0].(*trillian.GetInclusionProofsAtSizesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInclusionProofsAtSizes indicates an expected call of GetInclusionProofsAtSizes.
+func (mr *MockTrillianLogServerMockRecorder) GetInclusionProofsAtSizes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInclusionProofsAtSizes", reflect.TypeOf((*MockTrillianLogServer)(nil).GetInclusionProofsAtSizes), arg0, arg1)
+}
+
 // GetLatestSignedLogRoot mocks base method.
 func (m *MockTrillianLogServer) GetLatestSignedLogRoot(arg0 context.Context, arg1 *trillian.GetLatestSignedLogRootRequest) (*trillian.GetLatestSignedLogRootResponse, error) {
 	m.ctrl.T.Helper()
@@ -140,6 +259,36 @@ func (mr *MockTrillianLogServerMockRecorder) GetLeavesByRange(arg0, arg1 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLeavesByRange", reflect.TypeOf((*MockTrillianLogServer)(nil).GetLeavesByRange), arg0, arg1)
 }
 
+// GetMirrorProgress mocks base method.
+func (m *MockTrillianLogServer) GetMirrorProgress(arg0 context.Context, arg1 *trillian.GetMirrorProgressRequest) (*trillian.GetMirrorProgressResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMirrorProgress", arg0, arg1)
+	ret0, _ := ret[0].(*trillian.GetMirrorProgressResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMirrorProgress indicates an expected call of GetMirrorProgress.
+func (mr *MockTrillianLogServerMockRecorder) GetMirrorProgress(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMirrorProgress", reflect.TypeOf((*MockTrillianLogServer)(nil).GetMirrorProgress), arg0, arg1)
+}
+
+// GetTile mocks base method.
+func (m *MockTrillianLogServer) GetTile(arg0 context.Context, arg1 *trillian.GetTileRequest) (*trillian.GetTileResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTile", arg0, arg1)
+	ret0, _ := ret[0].(*trillian.GetTileResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTile indicates an expected call of GetTile.
+func (mr *MockTrillianLogServerMockRecorder) GetTile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTile", reflect.TypeOf((*MockTrillianLogServer)(nil).GetTile), arg0, arg1)
+}
+
 // InitLog mocks base method.
 func (m *MockTrillianLogServer) InitLog(arg0 context.Context, arg1 *trillian.InitLogRequest) (*trillian.InitLogResponse, error) {
 	m.ctrl.T.Helper()
@@ -155,6 +304,36 @@ func (mr *MockTrillianLogServerMockRecorder) InitLog(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InitLog", reflect.TypeOf((*MockTrillianLogServer)(nil).InitLog), arg0, arg1)
 }
 
+// ListLeafExtraDataUpdates mocks base method.
+func (m *MockTrillianLogServer) ListLeafExtraDataUpdates(arg0 context.Context, arg1 *trillian.ListLeafExtraDataUpdatesRequest) (*trillian.ListLeafExtraDataUpdatesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLeafExtraDataUpdates", arg0, arg1)
+	ret0, _ := ret[0].(*trillian.ListLeafExtraDataUpdatesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLeafExtraDataUpdates indicates an expected call of ListLeafExtraDataUpdates.
+func (mr *MockTrillianLogServerMockRecorder) ListLeafExtraDataUpdates(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLeafExtraDataUpdates", reflect.TypeOf((*MockTrillianLogServer)(nil).ListLeafExtraDataUpdates), arg0, arg1)
+}
+
+// ListVerificationFailureReports mocks base method.
+func (m *MockTrillianLogServer) ListVerificationFailureReports(arg0 context.Context, arg1 *trillian.ListVerificationFailureReportsRequest) (*trillian.ListVerificationFailureReportsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVerificationFailureReports", arg0, arg1)
+	ret0, _ := ret[0].(*trillian.ListVerificationFailureReportsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVerificationFailureReports indicates an expected call of ListVerificationFailureReports.
+func (mr *MockTrillianLogServerMockRecorder) ListVerificationFailureReports(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVerificationFailureReports", reflect.TypeOf((*MockTrillianLogServer)(nil).ListVerificationFailureReports), arg0, arg1)
+}
+
 // QueueLeaf mocks base method.
 func (m *MockTrillianLogServer) QueueLeaf(arg0 context.Context, arg1 *trillian.QueueLeafRequest) (*trillian.QueueLeafResponse, error) {
 	m.ctrl.T.Helper()
@@ -169,3 +348,61 @@ func (mr *MockTrillianLogServerMockRecorder) QueueLeaf(arg0, arg1 interface{}) *
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueLeaf", reflect.TypeOf((*MockTrillianLogServer)(nil).QueueLeaf), arg0, arg1)
 }
+
+// QueueLeavesStream mocks base method.
+func (m *MockTrillianLogServer) QueueLeavesStream(arg0 trillian.TrillianLog_QueueLeavesStreamServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueLeavesStream", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// QueueLeavesStream indicates an expected call of QueueLeavesStream.
+func (mr *MockTrillianLogServerMockRecorder) QueueLeavesStream(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueLeavesStream", reflect.TypeOf((*MockTrillianLogServer)(nil).QueueLeavesStream), arg0)
+}
+
+// ReportVerificationFailure mocks base method.
+func (m *MockTrillianLogServer) ReportVerificationFailure(arg0 context.Context, arg1 *trillian.ReportVerificationFailureRequest) (*trillian.ReportVerificationFailureResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReportVerificationFailure", arg0, arg1)
+	ret0, _ := ret[0].(*trillian.ReportVerificationFailureResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReportVerificationFailure indicates an expected call of ReportVerificationFailure.
+func (mr *MockTrillianLogServerMockRecorder) ReportVerificationFailure(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportVerificationFailure", reflect.TypeOf((*MockTrillianLogServer)(nil).ReportVerificationFailure), arg0, arg1)
+}
+
+// StreamLeavesByRange mocks base method.
+func (m *MockTrillianLogServer) StreamLeavesByRange(arg0 *trillian.GetLeavesByRangeRequest, arg1 trillian.TrillianLog_StreamLeavesByRangeServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamLeavesByRange", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamLeavesByRange indicates an expected call of StreamLeavesByRange.
+func (mr *MockTrillianLogServerMockRecorder) StreamLeavesByRange(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamLeavesByRange", reflect.TypeOf((*MockTrillianLogServer)(nil).StreamLeavesByRange), arg0, arg1)
+}
+
+// UpdateLeafExtraData mocks base method.
+func (m *MockTrillianLogServer) UpdateLeafExtraData(arg0 context.Context, arg1 *trillian.UpdateLeafExtraDataRequest) (*trillian.UpdateLeafExtraDataResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLeafExtraData", arg0, arg1)
+	ret0, _ := ret[0].(*trillian.UpdateLeafExtraDataResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateLeafExtraData indicates an expected call of UpdateLeafExtraData.
+func (mr *MockTrillianLogServerMockRecorder) UpdateLeafExtraData(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLeafExtraData", reflect.TypeOf((*MockTrillianLogServer)(nil).UpdateLeafExtraData), arg0, arg1)
+}
@@ -23,6 +23,7 @@ package trillian
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
 	anypb "google.golang.org/protobuf/types/known/anypb"
 	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
@@ -285,6 +286,598 @@ func (TreeType) EnumDescriptor() ([]byte, []int) {
 	return file_trillian_proto_rawDescGZIP(), []int{3}
 }
 
+// Defines how AddSequencedLeaves handles leaves which conflict with leaves
+// already stored in a PREORDERED_LOG tree, i.e. which have the same leaf index
+// or leaf identity hash.
+type SequencedLeafConflictPolicy int32
+
+const (
+	// Conflicting leaves are rejected with FAILED_PRECONDITION.
+	SequencedLeafConflictPolicy_CONFLICT_FAIL SequencedLeafConflictPolicy = 0
+	// Leaves conflicting with the leaf stored at their index are not stored, and
+	// reported as ALREADY_EXISTS along with that leaf. Leaves which only
+	// conflict with a leaf at another index are rejected with
+	// FAILED_PRECONDITION.
+	SequencedLeafConflictPolicy_CONFLICT_SKIP SequencedLeafConflictPolicy = 1
+	// Leaves which are identical to the leaf stored at their index are accepted
+	// as if they had been stored again, and reported as OK along with that
+	// leaf. Other conflicting leaves are rejected with FAILED_PRECONDITION.
+	SequencedLeafConflictPolicy_CONFLICT_OVERWRITE_IF_IDENTICAL SequencedLeafConflictPolicy = 2
+)
+
+// Enum value maps for SequencedLeafConflictPolicy.
+var (
+	SequencedLeafConflictPolicy_name = map[int32]string{
+		0: "CONFLICT_FAIL",
+		1: "CONFLICT_SKIP",
+		2: "CONFLICT_OVERWRITE_IF_IDENTICAL",
+	}
+	SequencedLeafConflictPolicy_value = map[string]int32{
+		"CONFLICT_FAIL":                   0,
+		"CONFLICT_SKIP":                   1,
+		"CONFLICT_OVERWRITE_IF_IDENTICAL": 2,
+	}
+)
+
+func (x SequencedLeafConflictPolicy) Enum() *SequencedLeafConflictPolicy {
+	p := new(SequencedLeafConflictPolicy)
+	*p = x
+	return p
+}
+
+func (x SequencedLeafConflictPolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SequencedLeafConflictPolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_trillian_proto_enumTypes[4].Descriptor()
+}
+
+func (SequencedLeafConflictPolicy) Type() protoreflect.EnumType {
+	return &file_trillian_proto_enumTypes[4]
+}
+
+func (x SequencedLeafConflictPolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SequencedLeafConflictPolicy.Descriptor instead.
+func (SequencedLeafConflictPolicy) EnumDescriptor() ([]byte, []int) {
+	return file_trillian_proto_rawDescGZIP(), []int{4}
+}
+
+// Defines which writes the reads of a tree are guaranteed to reflect.
+type ReadConsistency int32
+
+const (
+	// Reads reflect all writes committed before they started, so leaves are
+	// readable as soon as the signer has committed the root integrating them.
+	ReadConsistency_READ_OWN_WRITES ReadConsistency = 0
+	// Reads may be served from a snapshot which lags behind the latest writes,
+	// by up to a storage-specific bound. This is cheaper for storage which
+	// serves such reads from replicas. Storage which can't serve stale reads
+	// serves them as READ_OWN_WRITES.
+	ReadConsistency_EVENTUAL ReadConsistency = 1
+)
+
+// Enum value maps for ReadConsistency.
+var (
+	ReadConsistency_name = map[int32]string{
+		0: "READ_OWN_WRITES",
+		1: "EVENTUAL",
+	}
+	ReadConsistency_value = map[string]int32{
+		"READ_OWN_WRITES": 0,
+		"EVENTUAL":        1,
+	}
+)
+
+func (x ReadConsistency) Enum() *ReadConsistency {
+	p := new(ReadConsistency)
+	*p = x
+	return p
+}
+
+func (x ReadConsistency) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReadConsistency) Descriptor() protoreflect.EnumDescriptor {
+	return file_trillian_proto_enumTypes[5].Descriptor()
+}
+
+func (ReadConsistency) Type() protoreflect.EnumType {
+	return &file_trillian_proto_enumTypes[5]
+}
+
+func (x ReadConsistency) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReadConsistency.Descriptor instead.
+func (ReadConsistency) EnumDescriptor() ([]byte, []int) {
+	return file_trillian_proto_rawDescGZIP(), []int{5}
+}
+
+// Encoding is the way a leaf value encodes a message.
+type LeafSchema_Encoding int32
+
+const (
+	// The protobuf binary wire format.
+	LeafSchema_PROTO_BINARY LeafSchema_Encoding = 0
+	// The protobuf JSON mapping, e.g. as produced by protojson.
+	LeafSchema_PROTO_JSON LeafSchema_Encoding = 1
+)
+
+// Enum value maps for LeafSchema_Encoding.
+var (
+	LeafSchema_Encoding_name = map[int32]string{
+		0: "PROTO_BINARY",
+		1: "PROTO_JSON",
+	}
+	LeafSchema_Encoding_value = map[string]int32{
+		"PROTO_BINARY": 0,
+		"PROTO_JSON":   1,
+	}
+)
+
+func (x LeafSchema_Encoding) Enum() *LeafSchema_Encoding {
+	p := new(LeafSchema_Encoding)
+	*p = x
+	return p
+}
+
+func (x LeafSchema_Encoding) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LeafSchema_Encoding) Descriptor() protoreflect.EnumDescriptor {
+	return file_trillian_proto_enumTypes[6].Descriptor()
+}
+
+func (LeafSchema_Encoding) Type() protoreflect.EnumType {
+	return &file_trillian_proto_enumTypes[6]
+}
+
+func (x LeafSchema_Encoding) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LeafSchema_Encoding.Descriptor instead.
+func (LeafSchema_Encoding) EnumDescriptor() ([]byte, []int) {
+	return file_trillian_proto_rawDescGZIP(), []int{0, 0}
+}
+
+// LeafSchema describes the structure which the leaf values of a tree must
+// have, as a protobuf message type.
+type LeafSchema struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The files defining the message type and its dependencies, as produced by
+	// protoc --include_imports --descriptor_set_out.
+	FileDescriptors *descriptorpb.FileDescriptorSet `protobuf:"bytes,1,opt,name=file_descriptors,json=fileDescriptors,proto3" json:"file_descriptors,omitempty"`
+	// The full name of the message type of the leaf values, e.g.
+	// "example.v1.Entry".
+	MessageType string `protobuf:"bytes,2,opt,name=message_type,json=messageType,proto3" json:"message_type,omitempty"`
+	// The encoding of the leaf values.
+	Encoding LeafSchema_Encoding `protobuf:"varint,3,opt,name=encoding,proto3,enum=trillian.LeafSchema_Encoding" json:"encoding,omitempty"`
+}
+
+func (x *LeafSchema) Reset() {
+	*x = LeafSchema{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_trillian_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LeafSchema) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeafSchema) ProtoMessage() {}
+
+func (x *LeafSchema) ProtoReflect() protoreflect.Message {
+	mi := &file_trillian_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeafSchema.ProtoReflect.Descriptor instead.
+func (*LeafSchema) Descriptor() ([]byte, []int) {
+	return file_trillian_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *LeafSchema) GetFileDescriptors() *descriptorpb.FileDescriptorSet {
+	if x != nil {
+		return x.FileDescriptors
+	}
+	return nil
+}
+
+func (x *LeafSchema) GetMessageType() string {
+	if x != nil {
+		return x.MessageType
+	}
+	return ""
+}
+
+func (x *LeafSchema) GetEncoding() LeafSchema_Encoding {
+	if x != nil {
+		return x.Encoding
+	}
+	return LeafSchema_PROTO_BINARY
+}
+
+// IntegrationPause describes a pause of the integration of a tree's queued
+// leaves.
+type IntegrationPause struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The time at which integration was paused.
+	PauseTime *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=pause_time,json=pauseTime,proto3" json:"pause_time,omitempty"`
+	// The time at which integration resumes automatically. If unset,
+	// integration is paused until ResumeIntegration is called.
+	ResumeTime *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=resume_time,json=resumeTime,proto3" json:"resume_time,omitempty"`
+	// The reason for the pause, e.g. a maintenance ticket.
+	Reason string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *IntegrationPause) Reset() {
+	*x = IntegrationPause{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_trillian_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IntegrationPause) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IntegrationPause) ProtoMessage() {}
+
+func (x *IntegrationPause) ProtoReflect() protoreflect.Message {
+	mi := &file_trillian_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IntegrationPause.ProtoReflect.Descriptor instead.
+func (*IntegrationPause) Descriptor() ([]byte, []int) {
+	return file_trillian_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *IntegrationPause) GetPauseTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PauseTime
+	}
+	return nil
+}
+
+func (x *IntegrationPause) GetResumeTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ResumeTime
+	}
+	return nil
+}
+
+func (x *IntegrationPause) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// RetentionPolicy bounds how long the data of a log's leaves is kept.
+type RetentionPolicy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The age, since their integration, after which the values and extra data
+	// of leaves are pruned. Their hashes are kept, so proofs can still be
+	// served, and pruned leaves are returned with empty values.
+	MaxLeafAge *durationpb.Duration `protobuf:"bytes,1,opt,name=max_leaf_age,json=maxLeafAge,proto3" json:"max_leaf_age,omitempty"`
+}
+
+func (x *RetentionPolicy) Reset() {
+	*x = RetentionPolicy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_trillian_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RetentionPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetentionPolicy) ProtoMessage() {}
+
+func (x *RetentionPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_trillian_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetentionPolicy.ProtoReflect.Descriptor instead.
+func (*RetentionPolicy) Descriptor() ([]byte, []int) {
+	return file_trillian_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RetentionPolicy) GetMaxLeafAge() *durationpb.Duration {
+	if x != nil {
+		return x.MaxLeafAge
+	}
+	return nil
+}
+
+// TreeOperator describes who operates a tree, and under which policies, for
+// ecosystems which publish lists of logs.
+type TreeOperator struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Email address at which the operator can be contacted, e.g.
+	// "log-admin@example.com", without a display name.
+	ContactEmail string `protobuf:"bytes,1,opt,name=contact_email,json=contactEmail,proto3" json:"contact_email,omitempty"`
+	// URL of the operator's page about the tree, e.g. its submission or
+	// inclusion policy. Must be an absolute http or https URL.
+	Url string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	// Object identifiers, in dotted-decimal notation, e.g. "1.3.6.1.4.1.11129",
+	// of the policies the tree is operated under. No OID may be repeated.
+	PolicyOids []string `protobuf:"bytes,3,rep,name=policy_oids,json=policyOids,proto3" json:"policy_oids,omitempty"`
+}
+
+func (x *TreeOperator) Reset() {
+	*x = TreeOperator{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_trillian_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TreeOperator) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TreeOperator) ProtoMessage() {}
+
+func (x *TreeOperator) ProtoReflect() protoreflect.Message {
+	mi := &file_trillian_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TreeOperator.ProtoReflect.Descriptor instead.
+func (*TreeOperator) Descriptor() ([]byte, []int) {
+	return file_trillian_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TreeOperator) GetContactEmail() string {
+	if x != nil {
+		return x.ContactEmail
+	}
+	return ""
+}
+
+func (x *TreeOperator) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *TreeOperator) GetPolicyOids() []string {
+	if x != nil {
+		return x.PolicyOids
+	}
+	return nil
+}
+
+// TreeQuota limits the rates at which the requests for a tree consume quota
+// tokens. Each server applies it to the requests it handles, with token
+// buckets of its own.
+type TreeQuota struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Rate at which read tokens are replenished, in tokens per second. If zero,
+	// reads aren't limited.
+	ReadTokensPerSecond float64 `protobuf:"fixed64,1,opt,name=read_tokens_per_second,json=readTokensPerSecond,proto3" json:"read_tokens_per_second,omitempty"`
+	// Maximum number of read tokens which can be consumed at once. If zero, it's
+	// the number replenished in a second, and at least one.
+	ReadBurst int64 `protobuf:"varint,2,opt,name=read_burst,json=readBurst,proto3" json:"read_burst,omitempty"`
+	// Rate at which write tokens are replenished, in tokens per second. If zero,
+	// writes aren't limited.
+	WriteTokensPerSecond float64 `protobuf:"fixed64,3,opt,name=write_tokens_per_second,json=writeTokensPerSecond,proto3" json:"write_tokens_per_second,omitempty"`
+	// Maximum number of write tokens which can be consumed at once. If zero,
+	// it's the number replenished in a second, and at least one.
+	WriteBurst int64 `protobuf:"varint,4,opt,name=write_burst,json=writeBurst,proto3" json:"write_burst,omitempty"`
+}
+
+func (x *TreeQuota) Reset() {
+	*x = TreeQuota{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_trillian_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TreeQuota) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TreeQuota) ProtoMessage() {}
+
+func (x *TreeQuota) ProtoReflect() protoreflect.Message {
+	mi := &file_trillian_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TreeQuota.ProtoReflect.Descriptor instead.
+func (*TreeQuota) Descriptor() ([]byte, []int) {
+	return file_trillian_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TreeQuota) GetReadTokensPerSecond() float64 {
+	if x != nil {
+		return x.ReadTokensPerSecond
+	}
+	return 0
+}
+
+func (x *TreeQuota) GetReadBurst() int64 {
+	if x != nil {
+		return x.ReadBurst
+	}
+	return 0
+}
+
+func (x *TreeQuota) GetWriteTokensPerSecond() float64 {
+	if x != nil {
+		return x.WriteTokensPerSecond
+	}
+	return 0
+}
+
+func (x *TreeQuota) GetWriteBurst() int64 {
+	if x != nil {
+		return x.WriteBurst
+	}
+	return 0
+}
+
+// StorageCaps limits the storage used by a log, so that a single tree can't
+// grow without bounds in a database shared by many. The usage is that of the
+// integrated leaves of the log, read again periodically by each server, so a
+// log may exceed its caps by the leaves queued in the meantime.
+type StorageCaps struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Number of leaves from which on warnings are logged for the log. If zero,
+	// there is no soft cap on the number of leaves.
+	SoftMaxLeaves int64 `protobuf:"varint,1,opt,name=soft_max_leaves,json=softMaxLeaves,proto3" json:"soft_max_leaves,omitempty"`
+	// Number of leaves from which on QueueLeaf requests, and the batches of
+	// QueueLeavesStream requests, for the log fail with RESOURCE_EXHAUSTED, and
+	// a QuotaFailure detail naming the cap. If zero, there is no hard cap on the
+	// number of leaves.
+	HardMaxLeaves int64 `protobuf:"varint,2,opt,name=hard_max_leaves,json=hardMaxLeaves,proto3" json:"hard_max_leaves,omitempty"`
+	// Total size of the leaf values from which on warnings are logged for the
+	// log. If zero, there is no soft cap on their size. The size is counted by
+	// the daily log statistics, so it includes leaves whose data was pruned, and
+	// isn't checked for storage which doesn't keep them.
+	SoftMaxBytes int64 `protobuf:"varint,3,opt,name=soft_max_bytes,json=softMaxBytes,proto3" json:"soft_max_bytes,omitempty"`
+	// Total size of the leaf values from which on the log's leaves are rejected
+	// like for hard_max_leaves. If zero, there is no hard cap on their size.
+	HardMaxBytes int64 `protobuf:"varint,4,opt,name=hard_max_bytes,json=hardMaxBytes,proto3" json:"hard_max_bytes,omitempty"`
+}
+
+func (x *StorageCaps) Reset() {
+	*x = StorageCaps{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_trillian_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StorageCaps) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorageCaps) ProtoMessage() {}
+
+func (x *StorageCaps) ProtoReflect() protoreflect.Message {
+	mi := &file_trillian_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StorageCaps.ProtoReflect.Descriptor instead.
+func (*StorageCaps) Descriptor() ([]byte, []int) {
+	return file_trillian_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *StorageCaps) GetSoftMaxLeaves() int64 {
+	if x != nil {
+		return x.SoftMaxLeaves
+	}
+	return 0
+}
+
+func (x *StorageCaps) GetHardMaxLeaves() int64 {
+	if x != nil {
+		return x.HardMaxLeaves
+	}
+	return 0
+}
+
+func (x *StorageCaps) GetSoftMaxBytes() int64 {
+	if x != nil {
+		return x.SoftMaxBytes
+	}
+	return 0
+}
+
+func (x *StorageCaps) GetHardMaxBytes() int64 {
+	if x != nil {
+		return x.HardMaxBytes
+	}
+	return 0
+}
+
 // Represents a tree.
 // Readonly attributes are assigned at tree creation, after which they may not
 // be modified.
@@ -334,12 +927,71 @@ type Tree struct {
 	// Time of tree deletion, if any.
 	// Readonly.
 	DeleteTime *timestamppb.Timestamp `protobuf:"bytes,20,opt,name=delete_time,json=deleteTime,proto3" json:"delete_time,omitempty"`
+	// How AddSequencedLeaves handles leaves which conflict with stored leaves.
+	// Only used by PREORDERED_LOG trees.
+	SequencedLeafConflictPolicy SequencedLeafConflictPolicy `protobuf:"varint,21,opt,name=sequenced_leaf_conflict_policy,json=sequencedLeafConflictPolicy,proto3,enum=trillian.SequencedLeafConflictPolicy" json:"sequenced_leaf_conflict_policy,omitempty"`
+	// Number of leaf indices below the current tree size within which
+	// conflicting leaves are handled according to the conflict policy. Leaves
+	// which conflict further behind the tree size are always rejected. If zero,
+	// the policy applies to all leaves.
+	// Only used by PREORDERED_LOG trees.
+	SequencedLeafDuplicateWindow int64 `protobuf:"varint,22,opt,name=sequenced_leaf_duplicate_window,json=sequencedLeafDuplicateWindow,proto3" json:"sequenced_leaf_duplicate_window,omitempty"`
+	// Which writes reads of the tree, e.g. GetLeavesByRange, are guaranteed to
+	// reflect.
+	ReadConsistency ReadConsistency `protobuf:"varint,23,opt,name=read_consistency,json=readConsistency,proto3,enum=trillian.ReadConsistency" json:"read_consistency,omitempty"`
+	// If true, the leaf hashes and Merkle tree node hashes of the tree are
+	// stored encrypted, with a deterministic AEAD keyed per tree, so that a
+	// copy of the database doesn't reveal which entries a private log contains.
+	// Hashes are decrypted when they are served. Requires storage support and
+	// a configured key.
+	// Readonly.
+	EncryptHashes bool `protobuf:"varint,24,opt,name=encrypt_hashes,json=encryptHashes,proto3" json:"encrypt_hashes,omitempty"`
+	// If set, QueueLeaf responses for the LOG tree include an inclusion promise
+	// signed by the server: a commitment to integrate the leaf within this delay
+	// of the time it was queued. Requires the server to be configured with a
+	// promise signing key.
+	MaxMergeDelay *durationpb.Duration `protobuf:"bytes,25,opt,name=max_merge_delay,json=maxMergeDelay,proto3" json:"max_merge_delay,omitempty"`
+	// If set, the server rejects leaves whose values don't conform to the
+	// schema, with an InvalidArgument error describing the mismatch.
+	LeafSchema *LeafSchema `protobuf:"bytes,26,opt,name=leaf_schema,json=leafSchema,proto3" json:"leaf_schema,omitempty"`
+	// If set, the integration of queued leaves into the tree is paused, e.g.
+	// during storage maintenance. Leaves can still be queued, and are integrated
+	// once integration resumes. Set with PauseIntegration, and cleared with
+	// ResumeIntegration, it can't be changed with CreateTree or UpdateTree.
+	IntegrationPause *IntegrationPause `protobuf:"bytes,27,opt,name=integration_pause,json=integrationPause,proto3" json:"integration_pause,omitempty"`
+	// Name of the quota profile from which the tree quotas were created when
+	// the tree was, e.g. "small", "medium" or "large", or a custom profile
+	// configured on the server. If empty, no tree quotas are created. Requires
+	// a quota system which supports profiles.
+	// Readonly.
+	QuotaProfile string `protobuf:"bytes,28,opt,name=quota_profile,json=quotaProfile,proto3" json:"quota_profile,omitempty"`
+	// If set, the log signer prunes the data of leaves older than the policy
+	// allows, in the background, at its --retention_interval. Requires storage
+	// which supports pruning. Only for LOG and PREORDERED_LOG trees.
+	RetentionPolicy *RetentionPolicy `protobuf:"bytes,29,opt,name=retention_policy,json=retentionPolicy,proto3" json:"retention_policy,omitempty"`
+	// Who operates the tree, and under which policies. Validated when the tree
+	// is created or updated.
+	// Optional.
+	Operator *TreeOperator `protobuf:"bytes,30,opt,name=operator,proto3" json:"operator,omitempty"`
+	// If set, the rates at which the requests for the tree may consume quota
+	// tokens, on top of the limits of the server's quota system. Set with
+	// SetTreeQuota, it can't be changed with CreateTree or UpdateTree, and
+	// changes apply to the following requests without restarting the servers.
+	Quota *TreeQuota `protobuf:"bytes,31,opt,name=quota,proto3" json:"quota,omitempty"`
+	// If set, the caps on the storage used by the log. They only apply to LOG
+	// trees, whose leaves are queued.
+	StorageCaps *StorageCaps `protobuf:"bytes,32,opt,name=storage_caps,json=storageCaps,proto3" json:"storage_caps,omitempty"`
+	// If true, GetLatestSignedLogRoot responses for the tree also hold the root
+	// in canonical JSON (RFC 8785), signed with the server's inclusion promise
+	// signing key, so that it can be verified without TLS or protobuf
+	// libraries. Requires the server to be configured with that key.
+	SignJsonLogRoots bool `protobuf:"varint,33,opt,name=sign_json_log_roots,json=signJsonLogRoots,proto3" json:"sign_json_log_roots,omitempty"`
 }
 
 func (x *Tree) Reset() {
 	*x = Tree{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_trillian_proto_msgTypes[0]
+		mi := &file_trillian_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -352,7 +1004,7 @@ func (x *Tree) String() string {
 func (*Tree) ProtoMessage() {}
 
 func (x *Tree) ProtoReflect() protoreflect.Message {
-	mi := &file_trillian_proto_msgTypes[0]
+	mi := &file_trillian_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -365,7 +1017,7 @@ func (x *Tree) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Tree.ProtoReflect.Descriptor instead.
 func (*Tree) Descriptor() ([]byte, []int) {
-	return file_trillian_proto_rawDescGZIP(), []int{0}
+	return file_trillian_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *Tree) GetTreeId() int64 {
@@ -445,6 +1097,176 @@ func (x *Tree) GetDeleteTime() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Tree) GetSequencedLeafConflictPolicy() SequencedLeafConflictPolicy {
+	if x != nil {
+		return x.SequencedLeafConflictPolicy
+	}
+	return SequencedLeafConflictPolicy_CONFLICT_FAIL
+}
+
+func (x *Tree) GetSequencedLeafDuplicateWindow() int64 {
+	if x != nil {
+		return x.SequencedLeafDuplicateWindow
+	}
+	return 0
+}
+
+func (x *Tree) GetReadConsistency() ReadConsistency {
+	if x != nil {
+		return x.ReadConsistency
+	}
+	return ReadConsistency_READ_OWN_WRITES
+}
+
+func (x *Tree) GetEncryptHashes() bool {
+	if x != nil {
+		return x.EncryptHashes
+	}
+	return false
+}
+
+func (x *Tree) GetMaxMergeDelay() *durationpb.Duration {
+	if x != nil {
+		return x.MaxMergeDelay
+	}
+	return nil
+}
+
+func (x *Tree) GetLeafSchema() *LeafSchema {
+	if x != nil {
+		return x.LeafSchema
+	}
+	return nil
+}
+
+func (x *Tree) GetIntegrationPause() *IntegrationPause {
+	if x != nil {
+		return x.IntegrationPause
+	}
+	return nil
+}
+
+func (x *Tree) GetQuotaProfile() string {
+	if x != nil {
+		return x.QuotaProfile
+	}
+	return ""
+}
+
+func (x *Tree) GetRetentionPolicy() *RetentionPolicy {
+	if x != nil {
+		return x.RetentionPolicy
+	}
+	return nil
+}
+
+func (x *Tree) GetOperator() *TreeOperator {
+	if x != nil {
+		return x.Operator
+	}
+	return nil
+}
+
+func (x *Tree) GetQuota() *TreeQuota {
+	if x != nil {
+		return x.Quota
+	}
+	return nil
+}
+
+func (x *Tree) GetStorageCaps() *StorageCaps {
+	if x != nil {
+		return x.StorageCaps
+	}
+	return nil
+}
+
+func (x *Tree) GetSignJsonLogRoots() bool {
+	if x != nil {
+		return x.SignJsonLogRoots
+	}
+	return false
+}
+
+// InclusionPromise is a log's signed commitment, issued when a leaf is queued,
+// to integrate the leaf within the maximum merge delay of the tree.
+type InclusionPromise struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// promise holds the TLS-serialization of the following structure (described
+	// in RFC5246 notation):
+	//
+	// enum { v1(1), (65535)} Version;
+	// struct {
+	//   uint64 log_id;
+	//   opaque merkle_leaf_hash<0..128>;
+	//   uint64 queue_timestamp_nanos;
+	//   uint64 max_merge_delay_nanos;
+	// } InclusionPromiseV1;
+	// struct {
+	//   Version version;
+	//   select(version) {
+	//     case v1: InclusionPromiseV1;
+	//   }
+	// } InclusionPromise;
+	//
+	// The leaf is to be integrated no later than queue_timestamp_nanos +
+	// max_merge_delay_nanos.
+	Promise []byte `protobuf:"bytes,1,opt,name=promise,proto3" json:"promise,omitempty"`
+	// signature is the signature of promise by the server's promise signing
+	// key. ECDSA and RSA (PKCS #1 v1.5) keys sign the SHA-256 digest of
+	// promise, Ed25519 keys sign promise itself.
+	Signature []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (x *InclusionPromise) Reset() {
+	*x = InclusionPromise{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_trillian_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InclusionPromise) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InclusionPromise) ProtoMessage() {}
+
+func (x *InclusionPromise) ProtoReflect() protoreflect.Message {
+	mi := &file_trillian_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InclusionPromise.ProtoReflect.Descriptor instead.
+func (*InclusionPromise) Descriptor() ([]byte, []int) {
+	return file_trillian_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *InclusionPromise) GetPromise() []byte {
+	if x != nil {
+		return x.Promise
+	}
+	return nil
+}
+
+func (x *InclusionPromise) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
 // SignedLogRoot represents a commitment by a Log to a particular tree.
 type SignedLogRoot struct {
 	state         protoimpl.MessageState
@@ -490,7 +1312,7 @@ type SignedLogRoot struct {
 func (x *SignedLogRoot) Reset() {
 	*x = SignedLogRoot{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_trillian_proto_msgTypes[1]
+		mi := &file_trillian_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -503,7 +1325,7 @@ func (x *SignedLogRoot) String() string {
 func (*SignedLogRoot) ProtoMessage() {}
 
 func (x *SignedLogRoot) ProtoReflect() protoreflect.Message {
-	mi := &file_trillian_proto_msgTypes[1]
+	mi := &file_trillian_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -516,7 +1338,7 @@ func (x *SignedLogRoot) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SignedLogRoot.ProtoReflect.Descriptor instead.
 func (*SignedLogRoot) Descriptor() ([]byte, []int) {
-	return file_trillian_proto_rawDescGZIP(), []int{1}
+	return file_trillian_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *SignedLogRoot) GetLogRoot() []byte {
@@ -538,12 +1360,18 @@ type Proof struct {
 	// used for a consistency proof.
 	LeafIndex int64    `protobuf:"varint,1,opt,name=leaf_index,json=leafIndex,proto3" json:"leaf_index,omitempty"`
 	Hashes    [][]byte `protobuf:"bytes,3,rep,name=hashes,proto3" json:"hashes,omitempty"`
+	// node_ids holds the position in the tree of the node of each of the
+	// hashes, in the same order, if the request asked for them with
+	// include_node_ids. A hash which the server computed from several stored
+	// nodes, because it covers a subtree which is incomplete at the tree size of
+	// the proof, has the position of the root of that (ephemeral) subtree.
+	NodeIds []*NodeID `protobuf:"bytes,4,rep,name=node_ids,json=nodeIds,proto3" json:"node_ids,omitempty"`
 }
 
 func (x *Proof) Reset() {
 	*x = Proof{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_trillian_proto_msgTypes[2]
+		mi := &file_trillian_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -556,7 +1384,7 @@ func (x *Proof) String() string {
 func (*Proof) ProtoMessage() {}
 
 func (x *Proof) ProtoReflect() protoreflect.Message {
-	mi := &file_trillian_proto_msgTypes[2]
+	mi := &file_trillian_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -569,7 +1397,7 @@ func (x *Proof) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Proof.ProtoReflect.Descriptor instead.
 func (*Proof) Descriptor() ([]byte, []int) {
-	return file_trillian_proto_rawDescGZIP(), []int{2}
+	return file_trillian_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *Proof) GetLeafIndex() int64 {
@@ -586,112 +1414,307 @@ func (x *Proof) GetHashes() [][]byte {
 	return nil
 }
 
+func (x *Proof) GetNodeIds() []*NodeID {
+	if x != nil {
+		return x.NodeIds
+	}
+	return nil
+}
+
+// NodeID is the position of a node in a Merkle tree: its level, with leaves at
+// level 0, and its index from the left among the nodes of that level.
+type NodeID struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Level uint64 `protobuf:"varint,1,opt,name=level,proto3" json:"level,omitempty"`
+	Index uint64 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+}
+
+func (x *NodeID) Reset() {
+	*x = NodeID{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_trillian_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NodeID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeID) ProtoMessage() {}
+
+func (x *NodeID) ProtoReflect() protoreflect.Message {
+	mi := &file_trillian_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeID.ProtoReflect.Descriptor instead.
+func (*NodeID) Descriptor() ([]byte, []int) {
+	return file_trillian_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *NodeID) GetLevel() uint64 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *NodeID) GetIndex() uint64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
 var File_trillian_proto protoreflect.FileDescriptor
 
 var file_trillian_proto_rawDesc = []byte{
 	0x0a, 0x0e, 0x74, 0x72, 0x69, 0x6c, 0x6c, 0x69, 0x61, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
 	0x12, 0x08, 0x74, 0x72, 0x69, 0x6c, 0x6c, 0x69, 0x61, 0x6e, 0x1a, 0x19, 0x67, 0x6f, 0x6f, 0x67,
 	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x61, 0x6e, 0x79, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xf1, 0x05, 0x0a, 0x04, 0x54, 0x72, 0x65, 0x65, 0x12,
-	0x17, 0x0a, 0x07, 0x74, 0x72, 0x65, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x06, 0x74, 0x72, 0x65, 0x65, 0x49, 0x64, 0x12, 0x32, 0x0a, 0x0a, 0x74, 0x72, 0x65, 0x65,
-	0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x74,
-	0x72, 0x69, 0x6c, 0x6c, 0x69, 0x61, 0x6e, 0x2e, 0x54, 0x72, 0x65, 0x65, 0x53, 0x74, 0x61, 0x74,
-	0x65, 0x52, 0x09, 0x74, 0x72, 0x65, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x2f, 0x0a, 0x09,
-	0x74, 0x72, 0x65, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32,
-	0x12, 0x2e, 0x74, 0x72, 0x69, 0x6c, 0x6c, 0x69, 0x61, 0x6e, 0x2e, 0x54, 0x72, 0x65, 0x65, 0x54,
-	0x79, 0x70, 0x65, 0x52, 0x08, 0x74, 0x72, 0x65, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x21, 0x0a,
-	0x0c, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x08, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65,
-	0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18,
-	0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
-	0x6f, 0x6e, 0x12, 0x3f, 0x0a, 0x10, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x65,
-	0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41,
-	0x6e, 0x79, 0x52, 0x0f, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x53, 0x65, 0x74, 0x74, 0x69,
-	0x6e, 0x67, 0x73, 0x12, 0x45, 0x0a, 0x11, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x5f,
-	0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x20, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f,
+	0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xe7, 0x01, 0x0a, 0x0a, 0x4c, 0x65, 0x61,
+	0x66, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x12, 0x4d, 0x0a, 0x10, 0x66, 0x69, 0x6c, 0x65, 0x5f,
+	0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x22, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x6f, 0x72, 0x53, 0x65, 0x74, 0x52, 0x0f, 0x66, 0x69, 0x6c, 0x65, 0x44, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x39, 0x0a, 0x08, 0x65, 0x6e, 0x63,
+	0x6f, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x74, 0x72,
+	0x69, 0x6c, 0x6c, 0x69, 0x61, 0x6e, 0x2e, 0x4c, 0x65, 0x61, 0x66, 0x53, 0x63, 0x68, 0x65, 0x6d,
+	0x61, 0x2e, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x08, 0x65, 0x6e, 0x63, 0x6f,
+	0x64, 0x69, 0x6e, 0x67, 0x22, 0x2c, 0x0a, 0x08, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67,
+	0x12, 0x10, 0x0a, 0x0c, 0x50, 0x52, 0x4f, 0x54, 0x4f, 0x5f, 0x42, 0x49, 0x4e, 0x41, 0x52, 0x59,
+	0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x50, 0x52, 0x4f, 0x54, 0x4f, 0x5f, 0x4a, 0x53, 0x4f, 0x4e,
+	0x10, 0x01, 0x22, 0xa2, 0x01, 0x0a, 0x10, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x50, 0x61, 0x75, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x0a, 0x70, 0x61, 0x75, 0x73, 0x65,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x70, 0x61, 0x75, 0x73, 0x65, 0x54, 0x69,
+	0x6d, 0x65, 0x12, 0x3b, 0x0a, 0x0b, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f, 0x74, 0x69, 0x6d,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x0a, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x4e, 0x0a, 0x0f, 0x52, 0x65, 0x74, 0x65, 0x6e,
+	0x74, 0x69, 0x6f, 0x6e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x3b, 0x0a, 0x0c, 0x6d, 0x61,
+	0x78, 0x5f, 0x6c, 0x65, 0x61, 0x66, 0x5f, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x6d, 0x61, 0x78,
+	0x4c, 0x65, 0x61, 0x66, 0x41, 0x67, 0x65, 0x22, 0x66, 0x0a, 0x0c, 0x54, 0x72, 0x65, 0x65, 0x4f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x61,
+	0x63, 0x74, 0x5f, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x63, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x10, 0x0a, 0x03,
+	0x75, 0x72, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x1f,
+	0x0a, 0x0b, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x5f, 0x6f, 0x69, 0x64, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x4f, 0x69, 0x64, 0x73, 0x22,
+	0xb7, 0x01, 0x0a, 0x09, 0x54, 0x72, 0x65, 0x65, 0x51, 0x75, 0x6f, 0x74, 0x61, 0x12, 0x33, 0x0a,
+	0x16, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x5f, 0x70, 0x65, 0x72,
+	0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x13, 0x72,
+	0x65, 0x61, 0x64, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x50, 0x65, 0x72, 0x53, 0x65, 0x63, 0x6f,
+	0x6e, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x62, 0x75, 0x72, 0x73, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x72, 0x65, 0x61, 0x64, 0x42, 0x75, 0x72, 0x73,
+	0x74, 0x12, 0x35, 0x0a, 0x17, 0x77, 0x72, 0x69, 0x74, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x73, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x14, 0x77, 0x72, 0x69, 0x74, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x50,
+	0x65, 0x72, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x77, 0x72, 0x69, 0x74,
+	0x65, 0x5f, 0x62, 0x75, 0x72, 0x73, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x77,
+	0x72, 0x69, 0x74, 0x65, 0x42, 0x75, 0x72, 0x73, 0x74, 0x22, 0xa9, 0x01, 0x0a, 0x0b, 0x53, 0x74,
+	0x6f, 0x72, 0x61, 0x67, 0x65, 0x43, 0x61, 0x70, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x73, 0x6f, 0x66,
+	0x74, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x6c, 0x65, 0x61, 0x76, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0d, 0x73, 0x6f, 0x66, 0x74, 0x4d, 0x61, 0x78, 0x4c, 0x65, 0x61, 0x76, 0x65,
+	0x73, 0x12, 0x26, 0x0a, 0x0f, 0x68, 0x61, 0x72, 0x64, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x6c, 0x65,
+	0x61, 0x76, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x68, 0x61, 0x72, 0x64,
+	0x4d, 0x61, 0x78, 0x4c, 0x65, 0x61, 0x76, 0x65, 0x73, 0x12, 0x24, 0x0a, 0x0e, 0x73, 0x6f, 0x66,
+	0x74, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0c, 0x73, 0x6f, 0x66, 0x74, 0x4d, 0x61, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12,
+	0x24, 0x0a, 0x0e, 0x68, 0x61, 0x72, 0x64, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x62, 0x79, 0x74, 0x65,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x68, 0x61, 0x72, 0x64, 0x4d, 0x61, 0x78,
+	0x42, 0x79, 0x74, 0x65, 0x73, 0x22, 0x87, 0x0c, 0x0a, 0x04, 0x54, 0x72, 0x65, 0x65, 0x12, 0x17,
+	0x0a, 0x07, 0x74, 0x72, 0x65, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x06, 0x74, 0x72, 0x65, 0x65, 0x49, 0x64, 0x12, 0x32, 0x0a, 0x0a, 0x74, 0x72, 0x65, 0x65, 0x5f,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x74, 0x72,
+	0x69, 0x6c, 0x6c, 0x69, 0x61, 0x6e, 0x2e, 0x54, 0x72, 0x65, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x52, 0x09, 0x74, 0x72, 0x65, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x2f, 0x0a, 0x09, 0x74,
+	0x72, 0x65, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12,
+	0x2e, 0x74, 0x72, 0x69, 0x6c, 0x6c, 0x69, 0x61, 0x6e, 0x2e, 0x54, 0x72, 0x65, 0x65, 0x54, 0x79,
+	0x70, 0x65, 0x52, 0x08, 0x74, 0x72, 0x65, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x21, 0x0a, 0x0c,
+	0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12,
+	0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x3f, 0x0a, 0x10, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x65, 0x74,
+	0x74, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e,
+	0x79, 0x52, 0x0f, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e,
+	0x67, 0x73, 0x12, 0x45, 0x0a, 0x11, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x5f, 0x64,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0f, 0x6d, 0x61, 0x78, 0x52, 0x6f, 0x6f,
+	0x74, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3b, 0x0a, 0x0b, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
 	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
-	0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0f, 0x6d, 0x61, 0x78, 0x52, 0x6f,
-	0x6f, 0x74, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3b, 0x0a, 0x0b, 0x63, 0x72,
-	0x65, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x63, 0x72, 0x65,
-	0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x3b, 0x0a, 0x0b, 0x75, 0x70, 0x64, 0x61, 0x74,
-	0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
-	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65,
-	0x54, 0x69, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x18,
-	0x13, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x12, 0x3b,
-	0x0a, 0x0b, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x14, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
-	0x0a, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x4a, 0x04, 0x08, 0x04, 0x10,
-	0x08, 0x4a, 0x04, 0x08, 0x0a, 0x10, 0x0d, 0x4a, 0x04, 0x08, 0x0e, 0x10, 0x0f, 0x4a, 0x04, 0x08,
-	0x12, 0x10, 0x13, 0x52, 0x1e, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65,
-	0x5f, 0x6d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x5f, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x5f, 0x65, 0x70,
-	0x6f, 0x63, 0x68, 0x52, 0x10, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x5f, 0x70,
-	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x0e, 0x68, 0x61, 0x73, 0x68, 0x5f, 0x61, 0x6c, 0x67, 0x6f,
-	0x72, 0x69, 0x74, 0x68, 0x6d, 0x52, 0x0d, 0x68, 0x61, 0x73, 0x68, 0x5f, 0x73, 0x74, 0x72, 0x61,
-	0x74, 0x65, 0x67, 0x79, 0x52, 0x0b, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x5f, 0x6b, 0x65,
-	0x79, 0x52, 0x0a, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x52, 0x13, 0x73,
-	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74,
-	0x68, 0x6d, 0x52, 0x16, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x63, 0x69,
-	0x70, 0x68, 0x65, 0x72, 0x5f, 0x73, 0x75, 0x69, 0x74, 0x65, 0x52, 0x1e, 0x75, 0x70, 0x64, 0x61,
-	0x74, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x5f, 0x73,
-	0x69, 0x6e, 0x63, 0x65, 0x5f, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x22, 0x9d, 0x01, 0x0a, 0x0d, 0x53,
-	0x69, 0x67, 0x6e, 0x65, 0x64, 0x4c, 0x6f, 0x67, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x19, 0x0a, 0x08,
-	0x6c, 0x6f, 0x67, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07,
-	0x6c, 0x6f, 0x67, 0x52, 0x6f, 0x6f, 0x74, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x08, 0x4a, 0x04, 0x08,
-	0x09, 0x10, 0x0a, 0x52, 0x08, 0x6b, 0x65, 0x79, 0x5f, 0x68, 0x69, 0x6e, 0x74, 0x52, 0x06, 0x6c,
-	0x6f, 0x67, 0x5f, 0x69, 0x64, 0x52, 0x12, 0x6c, 0x6f, 0x67, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x5f,
-	0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x09, 0x72, 0x6f, 0x6f, 0x74, 0x5f,
-	0x68, 0x61, 0x73, 0x68, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52,
-	0x0f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x5f, 0x6e, 0x61, 0x6e, 0x6f, 0x73,
-	0x52, 0x0d, 0x74, 0x72, 0x65, 0x65, 0x5f, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52,
-	0x09, 0x74, 0x72, 0x65, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x22, 0x50, 0x0a, 0x05, 0x50, 0x72,
-	0x6f, 0x6f, 0x66, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x65, 0x61, 0x66, 0x5f, 0x69, 0x6e, 0x64, 0x65,
-	0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6c, 0x65, 0x61, 0x66, 0x49, 0x6e, 0x64,
-	0x65, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03,
-	0x28, 0x0c, 0x52, 0x06, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x4a, 0x04, 0x08, 0x02, 0x10, 0x03,
-	0x52, 0x0a, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x2a, 0x44, 0x0a, 0x0d,
-	0x4c, 0x6f, 0x67, 0x52, 0x6f, 0x6f, 0x74, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x1b, 0x0a,
-	0x17, 0x4c, 0x4f, 0x47, 0x5f, 0x52, 0x4f, 0x4f, 0x54, 0x5f, 0x46, 0x4f, 0x52, 0x4d, 0x41, 0x54,
-	0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x16, 0x0a, 0x12, 0x4c, 0x4f,
-	0x47, 0x5f, 0x52, 0x4f, 0x4f, 0x54, 0x5f, 0x46, 0x4f, 0x52, 0x4d, 0x41, 0x54, 0x5f, 0x56, 0x31,
-	0x10, 0x01, 0x2a, 0x97, 0x01, 0x0a, 0x0c, 0x48, 0x61, 0x73, 0x68, 0x53, 0x74, 0x72, 0x61, 0x74,
-	0x65, 0x67, 0x79, 0x12, 0x19, 0x0a, 0x15, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x48,
-	0x41, 0x53, 0x48, 0x5f, 0x53, 0x54, 0x52, 0x41, 0x54, 0x45, 0x47, 0x59, 0x10, 0x00, 0x12, 0x12,
-	0x0a, 0x0e, 0x52, 0x46, 0x43, 0x36, 0x39, 0x36, 0x32, 0x5f, 0x53, 0x48, 0x41, 0x32, 0x35, 0x36,
-	0x10, 0x01, 0x12, 0x13, 0x0a, 0x0f, 0x54, 0x45, 0x53, 0x54, 0x5f, 0x4d, 0x41, 0x50, 0x5f, 0x48,
-	0x41, 0x53, 0x48, 0x45, 0x52, 0x10, 0x02, 0x12, 0x19, 0x0a, 0x15, 0x4f, 0x42, 0x4a, 0x45, 0x43,
-	0x54, 0x5f, 0x52, 0x46, 0x43, 0x36, 0x39, 0x36, 0x32, 0x5f, 0x53, 0x48, 0x41, 0x32, 0x35, 0x36,
-	0x10, 0x03, 0x12, 0x15, 0x0a, 0x11, 0x43, 0x4f, 0x4e, 0x49, 0x4b, 0x53, 0x5f, 0x53, 0x48, 0x41,
-	0x35, 0x31, 0x32, 0x5f, 0x32, 0x35, 0x36, 0x10, 0x04, 0x12, 0x11, 0x0a, 0x0d, 0x43, 0x4f, 0x4e,
-	0x49, 0x4b, 0x53, 0x5f, 0x53, 0x48, 0x41, 0x32, 0x35, 0x36, 0x10, 0x05, 0x2a, 0x8b, 0x01, 0x0a,
-	0x09, 0x54, 0x72, 0x65, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x12, 0x55, 0x4e,
-	0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x54, 0x52, 0x45, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45,
-	0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x41, 0x43, 0x54, 0x49, 0x56, 0x45, 0x10, 0x01, 0x12, 0x0a,
-	0x0a, 0x06, 0x46, 0x52, 0x4f, 0x5a, 0x45, 0x4e, 0x10, 0x02, 0x12, 0x1f, 0x0a, 0x17, 0x44, 0x45,
-	0x50, 0x52, 0x45, 0x43, 0x41, 0x54, 0x45, 0x44, 0x5f, 0x53, 0x4f, 0x46, 0x54, 0x5f, 0x44, 0x45,
-	0x4c, 0x45, 0x54, 0x45, 0x44, 0x10, 0x03, 0x1a, 0x02, 0x08, 0x01, 0x12, 0x1f, 0x0a, 0x17, 0x44,
-	0x45, 0x50, 0x52, 0x45, 0x43, 0x41, 0x54, 0x45, 0x44, 0x5f, 0x48, 0x41, 0x52, 0x44, 0x5f, 0x44,
-	0x45, 0x4c, 0x45, 0x54, 0x45, 0x44, 0x10, 0x04, 0x1a, 0x02, 0x08, 0x01, 0x12, 0x0c, 0x0a, 0x08,
-	0x44, 0x52, 0x41, 0x49, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x05, 0x2a, 0x49, 0x0a, 0x08, 0x54, 0x72,
-	0x65, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x15, 0x0a, 0x11, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57,
-	0x4e, 0x5f, 0x54, 0x52, 0x45, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x10, 0x00, 0x12, 0x07, 0x0a,
-	0x03, 0x4c, 0x4f, 0x47, 0x10, 0x01, 0x12, 0x12, 0x0a, 0x0e, 0x50, 0x52, 0x45, 0x4f, 0x52, 0x44,
-	0x45, 0x52, 0x45, 0x44, 0x5f, 0x4c, 0x4f, 0x47, 0x10, 0x03, 0x22, 0x04, 0x08, 0x02, 0x10, 0x02,
-	0x2a, 0x03, 0x4d, 0x41, 0x50, 0x42, 0x48, 0x0a, 0x19, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2e, 0x74, 0x72, 0x69, 0x6c, 0x6c, 0x69, 0x61, 0x6e, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x42, 0x0d, 0x54, 0x72, 0x69, 0x6c, 0x6c, 0x69, 0x61, 0x6e, 0x50, 0x72, 0x6f, 0x74,
-	0x6f, 0x50, 0x01, 0x5a, 0x1a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x74, 0x72, 0x69, 0x6c, 0x6c, 0x69, 0x61, 0x6e, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x3b, 0x0a, 0x0b, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54,
+	0x69, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x13,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x12, 0x3b, 0x0a,
+	0x0b, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x14, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a,
+	0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x6a, 0x0a, 0x1e, 0x73, 0x65,
+	0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x64, 0x5f, 0x6c, 0x65, 0x61, 0x66, 0x5f, 0x63, 0x6f, 0x6e,
+	0x66, 0x6c, 0x69, 0x63, 0x74, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x15, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x25, 0x2e, 0x74, 0x72, 0x69, 0x6c, 0x6c, 0x69, 0x61, 0x6e, 0x2e, 0x53, 0x65,
+	0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x64, 0x4c, 0x65, 0x61, 0x66, 0x43, 0x6f, 0x6e, 0x66, 0x6c,
+	0x69, 0x63, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x1b, 0x73, 0x65, 0x71, 0x75, 0x65,
+	0x6e, 0x63, 0x65, 0x64, 0x4c, 0x65, 0x61, 0x66, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74,
+	0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x45, 0x0a, 0x1f, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e,
+	0x63, 0x65, 0x64, 0x5f, 0x6c, 0x65, 0x61, 0x66, 0x5f, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61,
+	0x74, 0x65, 0x5f, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x18, 0x16, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x1c, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x64, 0x4c, 0x65, 0x61, 0x66, 0x44, 0x75,
+	0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x12, 0x44, 0x0a,
+	0x10, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x63, 0x6f, 0x6e, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x63,
+	0x79, 0x18, 0x17, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x74, 0x72, 0x69, 0x6c, 0x6c, 0x69,
+	0x61, 0x6e, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e,
+	0x63, 0x79, 0x52, 0x0f, 0x72, 0x65, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x73, 0x69, 0x73, 0x74, 0x65,
+	0x6e, 0x63, 0x79, 0x12, 0x25, 0x0a, 0x0e, 0x65, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x5f, 0x68,
+	0x61, 0x73, 0x68, 0x65, 0x73, 0x18, 0x18, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x65, 0x6e, 0x63,
+	0x72, 0x79, 0x70, 0x74, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x12, 0x41, 0x0a, 0x0f, 0x6d, 0x61,
+	0x78, 0x5f, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x5f, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x18, 0x19, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d,
+	0x6d, 0x61, 0x78, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x12, 0x35, 0x0a,
+	0x0b, 0x6c, 0x65, 0x61, 0x66, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x18, 0x1a, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x74, 0x72, 0x69, 0x6c, 0x6c, 0x69, 0x61, 0x6e, 0x2e, 0x4c, 0x65,
+	0x61, 0x66, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x52, 0x0a, 0x6c, 0x65, 0x61, 0x66, 0x53, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x12, 0x47, 0x0a, 0x11, 0x69, 0x6e, 0x74, 0x65, 0x67, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x61, 0x75, 0x73, 0x65, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x74, 0x72, 0x69, 0x6c, 0x6c, 0x69, 0x61, 0x6e, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x67,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x75, 0x73, 0x65, 0x52, 0x10, 0x69, 0x6e, 0x74,
+	0x65, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x75, 0x73, 0x65, 0x12, 0x23, 0x0a,
+	0x0d, 0x71, 0x75, 0x6f, 0x74, 0x61, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x1c,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x71, 0x75, 0x6f, 0x74, 0x61, 0x50, 0x72, 0x6f, 0x66, 0x69,
+	0x6c, 0x65, 0x12, 0x44, 0x0a, 0x10, 0x72, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x1d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x74,
+	0x72, 0x69, 0x6c, 0x6c, 0x69, 0x61, 0x6e, 0x2e, 0x52, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x6f,
+	0x6e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x0f, 0x72, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69,
+	0x6f, 0x6e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x32, 0x0a, 0x08, 0x6f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x6f, 0x72, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x74, 0x72, 0x69,
+	0x6c, 0x6c, 0x69, 0x61, 0x6e, 0x2e, 0x54, 0x72, 0x65, 0x65, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x6f, 0x72, 0x52, 0x08, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x29, 0x0a, 0x05,
+	0x71, 0x75, 0x6f, 0x74, 0x61, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x74, 0x72,
+	0x69, 0x6c, 0x6c, 0x69, 0x61, 0x6e, 0x2e, 0x54, 0x72, 0x65, 0x65, 0x51, 0x75, 0x6f, 0x74, 0x61,
+	0x52, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x61, 0x12, 0x38, 0x0a, 0x0c, 0x73, 0x74, 0x6f, 0x72, 0x61,
+	0x67, 0x65, 0x5f, 0x63, 0x61, 0x70, 0x73, 0x18, 0x20, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e,
+	0x74, 0x72, 0x69, 0x6c, 0x6c, 0x69, 0x61, 0x6e, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x43, 0x61, 0x70, 0x73, 0x52, 0x0b, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x43, 0x61, 0x70,
+	0x73, 0x12, 0x2d, 0x0a, 0x13, 0x73, 0x69, 0x67, 0x6e, 0x5f, 0x6a, 0x73, 0x6f, 0x6e, 0x5f, 0x6c,
+	0x6f, 0x67, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x73, 0x18, 0x21, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10,
+	0x73, 0x69, 0x67, 0x6e, 0x4a, 0x73, 0x6f, 0x6e, 0x4c, 0x6f, 0x67, 0x52, 0x6f, 0x6f, 0x74, 0x73,
+	0x4a, 0x04, 0x08, 0x04, 0x10, 0x08, 0x4a, 0x04, 0x08, 0x0a, 0x10, 0x0d, 0x4a, 0x04, 0x08, 0x0e,
+	0x10, 0x0f, 0x4a, 0x04, 0x08, 0x12, 0x10, 0x13, 0x52, 0x1e, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x5f, 0x73, 0x69, 0x6e,
+	0x63, 0x65, 0x5f, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x52, 0x10, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63,
+	0x61, 0x74, 0x65, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x0e, 0x68, 0x61, 0x73, 0x68,
+	0x5f, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x52, 0x0d, 0x68, 0x61, 0x73, 0x68,
+	0x5f, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x52, 0x0b, 0x70, 0x72, 0x69, 0x76, 0x61,
+	0x74, 0x65, 0x5f, 0x6b, 0x65, 0x79, 0x52, 0x0a, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b,
+	0x65, 0x79, 0x52, 0x13, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x61, 0x6c,
+	0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x52, 0x16, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x5f, 0x63, 0x69, 0x70, 0x68, 0x65, 0x72, 0x5f, 0x73, 0x75, 0x69, 0x74, 0x65, 0x52,
+	0x1e, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6d, 0x69, 0x6c,
+	0x6c, 0x69, 0x73, 0x5f, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x5f, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x22,
+	0x4a, 0x0a, 0x10, 0x49, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x6d,
+	0x69, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x6d, 0x69, 0x73, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x6d, 0x69, 0x73, 0x65, 0x12, 0x1c, 0x0a,
+	0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x9d, 0x01, 0x0a, 0x0d,
+	0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x4c, 0x6f, 0x67, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x19, 0x0a,
+	0x08, 0x6c, 0x6f, 0x67, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x07, 0x6c, 0x6f, 0x67, 0x52, 0x6f, 0x6f, 0x74, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x08, 0x4a, 0x04,
+	0x08, 0x09, 0x10, 0x0a, 0x52, 0x08, 0x6b, 0x65, 0x79, 0x5f, 0x68, 0x69, 0x6e, 0x74, 0x52, 0x06,
+	0x6c, 0x6f, 0x67, 0x5f, 0x69, 0x64, 0x52, 0x12, 0x6c, 0x6f, 0x67, 0x5f, 0x72, 0x6f, 0x6f, 0x74,
+	0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x09, 0x72, 0x6f, 0x6f, 0x74,
+	0x5f, 0x68, 0x61, 0x73, 0x68, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x52, 0x0f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x5f, 0x6e, 0x61, 0x6e, 0x6f,
+	0x73, 0x52, 0x0d, 0x74, 0x72, 0x65, 0x65, 0x5f, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x52, 0x09, 0x74, 0x72, 0x65, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x22, 0x7d, 0x0a, 0x05, 0x50,
+	0x72, 0x6f, 0x6f, 0x66, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x65, 0x61, 0x66, 0x5f, 0x69, 0x6e, 0x64,
+	0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6c, 0x65, 0x61, 0x66, 0x49, 0x6e,
+	0x64, 0x65, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x0c, 0x52, 0x06, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x12, 0x2b, 0x0a, 0x08, 0x6e,
+	0x6f, 0x64, 0x65, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e,
+	0x74, 0x72, 0x69, 0x6c, 0x6c, 0x69, 0x61, 0x6e, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x44, 0x52,
+	0x07, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x73, 0x4a, 0x04, 0x08, 0x02, 0x10, 0x03, 0x52, 0x0a,
+	0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x22, 0x34, 0x0a, 0x06, 0x4e, 0x6f,
+	0x64, 0x65, 0x49, 0x44, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e,
+	0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x2a, 0x44, 0x0a, 0x0d, 0x4c, 0x6f, 0x67, 0x52, 0x6f, 0x6f, 0x74, 0x46, 0x6f, 0x72, 0x6d, 0x61,
+	0x74, 0x12, 0x1b, 0x0a, 0x17, 0x4c, 0x4f, 0x47, 0x5f, 0x52, 0x4f, 0x4f, 0x54, 0x5f, 0x46, 0x4f,
+	0x52, 0x4d, 0x41, 0x54, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x16,
+	0x0a, 0x12, 0x4c, 0x4f, 0x47, 0x5f, 0x52, 0x4f, 0x4f, 0x54, 0x5f, 0x46, 0x4f, 0x52, 0x4d, 0x41,
+	0x54, 0x5f, 0x56, 0x31, 0x10, 0x01, 0x2a, 0x97, 0x01, 0x0a, 0x0c, 0x48, 0x61, 0x73, 0x68, 0x53,
+	0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x12, 0x19, 0x0a, 0x15, 0x55, 0x4e, 0x4b, 0x4e, 0x4f,
+	0x57, 0x4e, 0x5f, 0x48, 0x41, 0x53, 0x48, 0x5f, 0x53, 0x54, 0x52, 0x41, 0x54, 0x45, 0x47, 0x59,
+	0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x52, 0x46, 0x43, 0x36, 0x39, 0x36, 0x32, 0x5f, 0x53, 0x48,
+	0x41, 0x32, 0x35, 0x36, 0x10, 0x01, 0x12, 0x13, 0x0a, 0x0f, 0x54, 0x45, 0x53, 0x54, 0x5f, 0x4d,
+	0x41, 0x50, 0x5f, 0x48, 0x41, 0x53, 0x48, 0x45, 0x52, 0x10, 0x02, 0x12, 0x19, 0x0a, 0x15, 0x4f,
+	0x42, 0x4a, 0x45, 0x43, 0x54, 0x5f, 0x52, 0x46, 0x43, 0x36, 0x39, 0x36, 0x32, 0x5f, 0x53, 0x48,
+	0x41, 0x32, 0x35, 0x36, 0x10, 0x03, 0x12, 0x15, 0x0a, 0x11, 0x43, 0x4f, 0x4e, 0x49, 0x4b, 0x53,
+	0x5f, 0x53, 0x48, 0x41, 0x35, 0x31, 0x32, 0x5f, 0x32, 0x35, 0x36, 0x10, 0x04, 0x12, 0x11, 0x0a,
+	0x0d, 0x43, 0x4f, 0x4e, 0x49, 0x4b, 0x53, 0x5f, 0x53, 0x48, 0x41, 0x32, 0x35, 0x36, 0x10, 0x05,
+	0x2a, 0x8b, 0x01, 0x0a, 0x09, 0x54, 0x72, 0x65, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x16,
+	0x0a, 0x12, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x54, 0x52, 0x45, 0x45, 0x5f, 0x53,
+	0x54, 0x41, 0x54, 0x45, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x41, 0x43, 0x54, 0x49, 0x56, 0x45,
+	0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x46, 0x52, 0x4f, 0x5a, 0x45, 0x4e, 0x10, 0x02, 0x12, 0x1f,
+	0x0a, 0x17, 0x44, 0x45, 0x50, 0x52, 0x45, 0x43, 0x41, 0x54, 0x45, 0x44, 0x5f, 0x53, 0x4f, 0x46,
+	0x54, 0x5f, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x44, 0x10, 0x03, 0x1a, 0x02, 0x08, 0x01, 0x12,
+	0x1f, 0x0a, 0x17, 0x44, 0x45, 0x50, 0x52, 0x45, 0x43, 0x41, 0x54, 0x45, 0x44, 0x5f, 0x48, 0x41,
+	0x52, 0x44, 0x5f, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x44, 0x10, 0x04, 0x1a, 0x02, 0x08, 0x01,
+	0x12, 0x0c, 0x0a, 0x08, 0x44, 0x52, 0x41, 0x49, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x05, 0x2a, 0x49,
+	0x0a, 0x08, 0x54, 0x72, 0x65, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x15, 0x0a, 0x11, 0x55, 0x4e,
+	0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x54, 0x52, 0x45, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x10,
+	0x00, 0x12, 0x07, 0x0a, 0x03, 0x4c, 0x4f, 0x47, 0x10, 0x01, 0x12, 0x12, 0x0a, 0x0e, 0x50, 0x52,
+	0x45, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x45, 0x44, 0x5f, 0x4c, 0x4f, 0x47, 0x10, 0x03, 0x22, 0x04,
+	0x08, 0x02, 0x10, 0x02, 0x2a, 0x03, 0x4d, 0x41, 0x50, 0x2a, 0x68, 0x0a, 0x1b, 0x53, 0x65, 0x71,
+	0x75, 0x65, 0x6e, 0x63, 0x65, 0x64, 0x4c, 0x65, 0x61, 0x66, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69,
+	0x63, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x11, 0x0a, 0x0d, 0x43, 0x4f, 0x4e, 0x46,
+	0x4c, 0x49, 0x43, 0x54, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x10, 0x00, 0x12, 0x11, 0x0a, 0x0d, 0x43,
+	0x4f, 0x4e, 0x46, 0x4c, 0x49, 0x43, 0x54, 0x5f, 0x53, 0x4b, 0x49, 0x50, 0x10, 0x01, 0x12, 0x23,
+	0x0a, 0x1f, 0x43, 0x4f, 0x4e, 0x46, 0x4c, 0x49, 0x43, 0x54, 0x5f, 0x4f, 0x56, 0x45, 0x52, 0x57,
+	0x52, 0x49, 0x54, 0x45, 0x5f, 0x49, 0x46, 0x5f, 0x49, 0x44, 0x45, 0x4e, 0x54, 0x49, 0x43, 0x41,
+	0x4c, 0x10, 0x02, 0x2a, 0x34, 0x0a, 0x0f, 0x52, 0x65, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x73, 0x69,
+	0x73, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x13, 0x0a, 0x0f, 0x52, 0x45, 0x41, 0x44, 0x5f, 0x4f,
+	0x57, 0x4e, 0x5f, 0x57, 0x52, 0x49, 0x54, 0x45, 0x53, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x45,
+	0x56, 0x45, 0x4e, 0x54, 0x55, 0x41, 0x4c, 0x10, 0x01, 0x42, 0x48, 0x0a, 0x19, 0x63, 0x6f, 0x6d,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x74, 0x72, 0x69, 0x6c, 0x6c, 0x69, 0x61, 0x6e,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x42, 0x0d, 0x54, 0x72, 0x69, 0x6c, 0x6c, 0x69, 0x61, 0x6e,
+	0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x1a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x74, 0x72, 0x69, 0x6c, 0x6c,
+	0x69, 0x61, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -706,33 +1729,60 @@ func file_trillian_proto_rawDescGZIP() []byte {
 	return file_trillian_proto_rawDescData
 }
 
-var file_trillian_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
-var file_trillian_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_trillian_proto_enumTypes = make([]protoimpl.EnumInfo, 7)
+var file_trillian_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_trillian_proto_goTypes = []interface{}{
-	(LogRootFormat)(0),            // 0: trillian.LogRootFormat
-	(HashStrategy)(0),             // 1: trillian.HashStrategy
-	(TreeState)(0),                // 2: trillian.TreeState
-	(TreeType)(0),                 // 3: trillian.TreeType
-	(*Tree)(nil),                  // 4: trillian.Tree
-	(*SignedLogRoot)(nil),         // 5: trillian.SignedLogRoot
-	(*Proof)(nil),                 // 6: trillian.Proof
-	(*anypb.Any)(nil),             // 7: google.protobuf.Any
-	(*durationpb.Duration)(nil),   // 8: google.protobuf.Duration
-	(*timestamppb.Timestamp)(nil), // 9: google.protobuf.Timestamp
+	(LogRootFormat)(0),                     // 0: trillian.LogRootFormat
+	(HashStrategy)(0),                      // 1: trillian.HashStrategy
+	(TreeState)(0),                         // 2: trillian.TreeState
+	(TreeType)(0),                          // 3: trillian.TreeType
+	(SequencedLeafConflictPolicy)(0),       // 4: trillian.SequencedLeafConflictPolicy
+	(ReadConsistency)(0),                   // 5: trillian.ReadConsistency
+	(LeafSchema_Encoding)(0),               // 6: trillian.LeafSchema.Encoding
+	(*LeafSchema)(nil),                     // 7: trillian.LeafSchema
+	(*IntegrationPause)(nil),               // 8: trillian.IntegrationPause
+	(*RetentionPolicy)(nil),                // 9: trillian.RetentionPolicy
+	(*TreeOperator)(nil),                   // 10: trillian.TreeOperator
+	(*TreeQuota)(nil),                      // 11: trillian.TreeQuota
+	(*StorageCaps)(nil),                    // 12: trillian.StorageCaps
+	(*Tree)(nil),                           // 13: trillian.Tree
+	(*InclusionPromise)(nil),               // 14: trillian.InclusionPromise
+	(*SignedLogRoot)(nil),                  // 15: trillian.SignedLogRoot
+	(*Proof)(nil),                          // 16: trillian.Proof
+	(*NodeID)(nil),                         // 17: trillian.NodeID
+	(*descriptorpb.FileDescriptorSet)(nil), // 18: google.protobuf.FileDescriptorSet
+	(*timestamppb.Timestamp)(nil),          // 19: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),            // 20: google.protobuf.Duration
+	(*anypb.Any)(nil),                      // 21: google.protobuf.Any
 }
 var file_trillian_proto_depIdxs = []int32{
-	2, // 0: trillian.Tree.tree_state:type_name -> trillian.TreeState
-	3, // 1: trillian.Tree.tree_type:type_name -> trillian.TreeType
-	7, // 2: trillian.Tree.storage_settings:type_name -> google.protobuf.Any
-	8, // 3: trillian.Tree.max_root_duration:type_name -> google.protobuf.Duration
-	9, // 4: trillian.Tree.create_time:type_name -> google.protobuf.Timestamp
-	9, // 5: trillian.Tree.update_time:type_name -> google.protobuf.Timestamp
-	9, // 6: trillian.Tree.delete_time:type_name -> google.protobuf.Timestamp
-	7, // [7:7] is the sub-list for method output_type
-	7, // [7:7] is the sub-list for method input_type
-	7, // [7:7] is the sub-list for extension type_name
-	7, // [7:7] is the sub-list for extension extendee
-	0, // [0:7] is the sub-list for field type_name
+	18, // 0: trillian.LeafSchema.file_descriptors:type_name -> google.protobuf.FileDescriptorSet
+	6,  // 1: trillian.LeafSchema.encoding:type_name -> trillian.LeafSchema.Encoding
+	19, // 2: trillian.IntegrationPause.pause_time:type_name -> google.protobuf.Timestamp
+	19, // 3: trillian.IntegrationPause.resume_time:type_name -> google.protobuf.Timestamp
+	20, // 4: trillian.RetentionPolicy.max_leaf_age:type_name -> google.protobuf.Duration
+	2,  // 5: trillian.Tree.tree_state:type_name -> trillian.TreeState
+	3,  // 6: trillian.Tree.tree_type:type_name -> trillian.TreeType
+	21, // 7: trillian.Tree.storage_settings:type_name -> google.protobuf.Any
+	20, // 8: trillian.Tree.max_root_duration:type_name -> google.protobuf.Duration
+	19, // 9: trillian.Tree.create_time:type_name -> google.protobuf.Timestamp
+	19, // 10: trillian.Tree.update_time:type_name -> google.protobuf.Timestamp
+	19, // 11: trillian.Tree.delete_time:type_name -> google.protobuf.Timestamp
+	4,  // 12: trillian.Tree.sequenced_leaf_conflict_policy:type_name -> trillian.SequencedLeafConflictPolicy
+	5,  // 13: trillian.Tree.read_consistency:type_name -> trillian.ReadConsistency
+	20, // 14: trillian.Tree.max_merge_delay:type_name -> google.protobuf.Duration
+	7,  // 15: trillian.Tree.leaf_schema:type_name -> trillian.LeafSchema
+	8,  // 16: trillian.Tree.integration_pause:type_name -> trillian.IntegrationPause
+	9,  // 17: trillian.Tree.retention_policy:type_name -> trillian.RetentionPolicy
+	10, // 18: trillian.Tree.operator:type_name -> trillian.TreeOperator
+	11, // 19: trillian.Tree.quota:type_name -> trillian.TreeQuota
+	12, // 20: trillian.Tree.storage_caps:type_name -> trillian.StorageCaps
+	17, // 21: trillian.Proof.node_ids:type_name -> trillian.NodeID
+	22, // [22:22] is the sub-list for method output_type
+	22, // [22:22] is the sub-list for method input_type
+	22, // [22:22] is the sub-list for extension type_name
+	22, // [22:22] is the sub-list for extension extendee
+	0,  // [0:22] is the sub-list for field type_name
 }
 
 func init() { file_trillian_proto_init() }
@@ -742,7 +1792,7 @@ func file_trillian_proto_init() {
 	}
 	if !protoimpl.UnsafeEnabled {
 		file_trillian_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Tree); i {
+			switch v := v.(*LeafSchema); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -754,7 +1804,7 @@ func file_trillian_proto_init() {
 			}
 		}
 		file_trillian_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SignedLogRoot); i {
+			switch v := v.(*IntegrationPause); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -766,6 +1816,90 @@ func file_trillian_proto_init() {
 			}
 		}
 		file_trillian_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RetentionPolicy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_trillian_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TreeOperator); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_trillian_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TreeQuota); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_trillian_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StorageCaps); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_trillian_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Tree); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_trillian_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InclusionPromise); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_trillian_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignedLogRoot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_trillian_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Proof); i {
 			case 0:
 				return &v.state
@@ -777,14 +1911,26 @@ func file_trillian_proto_init() {
 				return nil
 			}
 		}
+		file_trillian_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NodeID); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_trillian_proto_rawDesc,
-			NumEnums:      4,
-			NumMessages:   3,
+			NumEnums:      7,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -28,7 +28,8 @@ type TrillianAdminClient interface {
 	GetTree(ctx context.Context, in *GetTreeRequest, opts ...grpc.CallOption) (*Tree, error)
 	// Creates a new tree.
 	// System-generated fields are not required and will be ignored if present,
-	// e.g.: tree_id, create_time and update_time.
+	// e.g.: tree_id, create_time and update_time. Set the tree_id of the request
+	// to choose the ID of the new tree.
 	// Returns the created tree, with all system-generated fields assigned.
 	CreateTree(ctx context.Context, in *CreateTreeRequest, opts ...grpc.CallOption) (*Tree, error)
 	// Updates a tree.
@@ -42,6 +43,62 @@ type TrillianAdminClient interface {
 	// A soft-deleted tree may be undeleted for a certain period, after which
 	// it'll be permanently deleted.
 	UndeleteTree(ctx context.Context, in *UndeleteTreeRequest, opts ...grpc.CallOption) (*Tree, error)
+	// Stores a new root for a log which commits to the same tree as its latest
+	// root, but with a fresh timestamp.
+	// This is an operational RPC which is only served if explicitly enabled.
+	ResignLogRoot(ctx context.Context, in *ResignLogRootRequest, opts ...grpc.CallOption) (*ResignLogRootResponse, error)
+	// Moves a leaf out of a log's queue of unsequenced leaves, so that it no
+	// longer blocks the integration of other leaves.
+	// This is an operational RPC which is only served if explicitly enabled.
+	QuarantineLeaf(ctx context.Context, in *QuarantineLeafRequest, opts ...grpc.CallOption) (*QuarantineLeafResponse, error)
+	// Puts quarantined leaves back into the log's queue of unsequenced leaves.
+	// This is an operational RPC which is only served if explicitly enabled.
+	RequeueQuarantinedLeaves(ctx context.Context, in *RequeueQuarantinedLeavesRequest, opts ...grpc.CallOption) (*RequeueQuarantinedLeavesResponse, error)
+	// Lists the leaves of a log which have been quarantined, either by an
+	// operator or automatically by the sequencer.
+	ListQuarantinedLeaves(ctx context.Context, in *ListQuarantinedLeavesRequest, opts ...grpc.CallOption) (*ListQuarantinedLeavesResponse, error)
+	// Pauses the integration of a log's queued leaves, e.g. during storage
+	// maintenance, until the given time or until ResumeIntegration is called.
+	// Leaves can still be queued while integration is paused. Returns the
+	// updated tree.
+	PauseIntegration(ctx context.Context, in *PauseIntegrationRequest, opts ...grpc.CallOption) (*Tree, error)
+	// Resumes the integration of a log's queued leaves. Returns the updated
+	// tree.
+	ResumeIntegration(ctx context.Context, in *ResumeIntegrationRequest, opts ...grpc.CallOption) (*Tree, error)
+	// Streams the changes of the trees the requester has access to, so that
+	// controllers can reconcile without polling ListTrees. The stream starts
+	// with a CREATED event for each existing tree. Changes made through any
+	// server are noticed within the server's watch interval, and those made
+	// through the same server immediately.
+	WatchTrees(ctx context.Context, in *WatchTreesRequest, opts ...grpc.CallOption) (TrillianAdmin_WatchTreesClient, error)
+	// Prunes the data of a log's leaves which are older than its retention
+	// policy allows, like the log signer does in the background, so that
+	// operators can run or inspect the garbage collection on demand.
+	PruneLeaves(ctx context.Context, in *PruneLeavesRequest, opts ...grpc.CallOption) (*PruneLeavesResponse, error)
+	// Streams a self-contained snapshot of a log, i.e. its metadata, latest
+	// root and leaves, so that it can be moved to another storage backend, or
+	// backed up and restored with ImportTree.
+	ExportTree(ctx context.Context, in *ExportTreeRequest, opts ...grpc.CallOption) (TrillianAdmin_ExportTreeClient, error)
+	// Creates a log from a snapshot streamed by ExportTree, keeping its tree ID
+	// unless zero. The Merkle tree is rebuilt from the leaves, and the import
+	// fails unless it matches the exported root. The log is FROZEN while it's
+	// imported, and gets the exported tree_state once complete. If the import
+	// fails, the log is deleted, so that the snapshot can be imported again.
+	ImportTree(ctx context.Context, opts ...grpc.CallOption) (TrillianAdmin_ImportTreeClient, error)
+	// Streams the leaves which are queued in a log and not sequenced yet, all
+	// read from one snapshot, so that a stuck integration can be inspected, or
+	// the queue be moved to another log with ImportQueue.
+	ExportQueue(ctx context.Context, in *ExportQueueRequest, opts ...grpc.CallOption) (TrillianAdmin_ExportQueueClient, error)
+	// Queues the leaves of a snapshot streamed by ExportQueue in a log, keeping
+	// their identity hashes and queue timestamps. Leaves which are already in
+	// the log are skipped.
+	ImportQueue(ctx context.Context, opts ...grpc.CallOption) (TrillianAdmin_ImportQueueClient, error)
+	// Sets the rates at which the requests for a tree may consume quota tokens.
+	// Every server applies the new quota to the following requests for the tree.
+	// Returns the new quota.
+	SetTreeQuota(ctx context.Context, in *SetTreeQuotaRequest, opts ...grpc.CallOption) (*TreeQuota, error)
+	// Returns the quota of a tree, which is empty if it has none.
+	GetTreeQuota(ctx context.Context, in *GetTreeQuotaRequest, opts ...grpc.CallOption) (*TreeQuota, error)
 }
 
 type trillianAdminClient struct {
@@ -106,6 +163,251 @@ func (c *trillianAdminClient) UndeleteTree(ctx context.Context, in *UndeleteTree
 	return out, nil
 }
 
+func (c *trillianAdminClient) ResignLogRoot(ctx context.Context, in *ResignLogRootRequest, opts ...grpc.CallOption) (*ResignLogRootResponse, error) {
+	out := new(ResignLogRootResponse)
+	err := c.cc.Invoke(ctx, "/trillian.TrillianAdmin/ResignLogRoot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trillianAdminClient) QuarantineLeaf(ctx context.Context, in *QuarantineLeafRequest, opts ...grpc.CallOption) (*QuarantineLeafResponse, error) {
+	out := new(QuarantineLeafResponse)
+	err := c.cc.Invoke(ctx, "/trillian.TrillianAdmin/QuarantineLeaf", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trillianAdminClient) RequeueQuarantinedLeaves(ctx context.Context, in *RequeueQuarantinedLeavesRequest, opts ...grpc.CallOption) (*RequeueQuarantinedLeavesResponse, error) {
+	out := new(RequeueQuarantinedLeavesResponse)
+	err := c.cc.Invoke(ctx, "/trillian.TrillianAdmin/RequeueQuarantinedLeaves", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trillianAdminClient) ListQuarantinedLeaves(ctx context.Context, in *ListQuarantinedLeavesRequest, opts ...grpc.CallOption) (*ListQuarantinedLeavesResponse, error) {
+	out := new(ListQuarantinedLeavesResponse)
+	err := c.cc.Invoke(ctx, "/trillian.TrillianAdmin/ListQuarantinedLeaves", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trillianAdminClient) PauseIntegration(ctx context.Context, in *PauseIntegrationRequest, opts ...grpc.CallOption) (*Tree, error) {
+	out := new(Tree)
+	err := c.cc.Invoke(ctx, "/trillian.TrillianAdmin/PauseIntegration", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trillianAdminClient) ResumeIntegration(ctx context.Context, in *ResumeIntegrationRequest, opts ...grpc.CallOption) (*Tree, error) {
+	out := new(Tree)
+	err := c.cc.Invoke(ctx, "/trillian.TrillianAdmin/ResumeIntegration", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trillianAdminClient) WatchTrees(ctx context.Context, in *WatchTreesRequest, opts ...grpc.CallOption) (TrillianAdmin_WatchTreesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TrillianAdmin_ServiceDesc.Streams[0], "/trillian.TrillianAdmin/WatchTrees", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &trillianAdminWatchTreesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TrillianAdmin_WatchTreesClient interface {
+	Recv() (*TreeEvent, error)
+	grpc.ClientStream
+}
+
+type trillianAdminWatchTreesClient struct {
+	grpc.ClientStream
+}
+
+func (x *trillianAdminWatchTreesClient) Recv() (*TreeEvent, error) {
+	m := new(TreeEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *trillianAdminClient) PruneLeaves(ctx context.Context, in *PruneLeavesRequest, opts ...grpc.CallOption) (*PruneLeavesResponse, error) {
+	out := new(PruneLeavesResponse)
+	err := c.cc.Invoke(ctx, "/trillian.TrillianAdmin/PruneLeaves", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trillianAdminClient) ExportTree(ctx context.Context, in *ExportTreeRequest, opts ...grpc.CallOption) (TrillianAdmin_ExportTreeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TrillianAdmin_ServiceDesc.Streams[1], "/trillian.TrillianAdmin/ExportTree", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &trillianAdminExportTreeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TrillianAdmin_ExportTreeClient interface {
+	Recv() (*TreeSnapshotChunk, error)
+	grpc.ClientStream
+}
+
+type trillianAdminExportTreeClient struct {
+	grpc.ClientStream
+}
+
+func (x *trillianAdminExportTreeClient) Recv() (*TreeSnapshotChunk, error) {
+	m := new(TreeSnapshotChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *trillianAdminClient) ImportTree(ctx context.Context, opts ...grpc.CallOption) (TrillianAdmin_ImportTreeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TrillianAdmin_ServiceDesc.Streams[2], "/trillian.TrillianAdmin/ImportTree", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &trillianAdminImportTreeClient{stream}
+	return x, nil
+}
+
+type TrillianAdmin_ImportTreeClient interface {
+	Send(*TreeSnapshotChunk) error
+	CloseAndRecv() (*Tree, error)
+	grpc.ClientStream
+}
+
+type trillianAdminImportTreeClient struct {
+	grpc.ClientStream
+}
+
+func (x *trillianAdminImportTreeClient) Send(m *TreeSnapshotChunk) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *trillianAdminImportTreeClient) CloseAndRecv() (*Tree, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(Tree)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *trillianAdminClient) ExportQueue(ctx context.Context, in *ExportQueueRequest, opts ...grpc.CallOption) (TrillianAdmin_ExportQueueClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TrillianAdmin_ServiceDesc.Streams[3], "/trillian.TrillianAdmin/ExportQueue", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &trillianAdminExportQueueClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TrillianAdmin_ExportQueueClient interface {
+	Recv() (*QueueSnapshotChunk, error)
+	grpc.ClientStream
+}
+
+type trillianAdminExportQueueClient struct {
+	grpc.ClientStream
+}
+
+func (x *trillianAdminExportQueueClient) Recv() (*QueueSnapshotChunk, error) {
+	m := new(QueueSnapshotChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *trillianAdminClient) ImportQueue(ctx context.Context, opts ...grpc.CallOption) (TrillianAdmin_ImportQueueClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TrillianAdmin_ServiceDesc.Streams[4], "/trillian.TrillianAdmin/ImportQueue", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &trillianAdminImportQueueClient{stream}
+	return x, nil
+}
+
+type TrillianAdmin_ImportQueueClient interface {
+	Send(*QueueSnapshotChunk) error
+	CloseAndRecv() (*ImportQueueResponse, error)
+	grpc.ClientStream
+}
+
+type trillianAdminImportQueueClient struct {
+	grpc.ClientStream
+}
+
+func (x *trillianAdminImportQueueClient) Send(m *QueueSnapshotChunk) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *trillianAdminImportQueueClient) CloseAndRecv() (*ImportQueueResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ImportQueueResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *trillianAdminClient) SetTreeQuota(ctx context.Context, in *SetTreeQuotaRequest, opts ...grpc.CallOption) (*TreeQuota, error) {
+	out := new(TreeQuota)
+	err := c.cc.Invoke(ctx, "/trillian.TrillianAdmin/SetTreeQuota", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trillianAdminClient) GetTreeQuota(ctx context.Context, in *GetTreeQuotaRequest, opts ...grpc.CallOption) (*TreeQuota, error) {
+	out := new(TreeQuota)
+	err := c.cc.Invoke(ctx, "/trillian.TrillianAdmin/GetTreeQuota", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TrillianAdminServer is the server API for TrillianAdmin service.
 // All implementations should embed UnimplementedTrillianAdminServer
 // for forward compatibility
@@ -116,7 +418,8 @@ type TrillianAdminServer interface {
 	GetTree(context.Context, *GetTreeRequest) (*Tree, error)
 	// Creates a new tree.
 	// System-generated fields are not required and will be ignored if present,
-	// e.g.: tree_id, create_time and update_time.
+	// e.g.: tree_id, create_time and update_time. Set the tree_id of the request
+	// to choose the ID of the new tree.
 	// Returns the created tree, with all system-generated fields assigned.
 	CreateTree(context.Context, *CreateTreeRequest) (*Tree, error)
 	// Updates a tree.
@@ -130,6 +433,62 @@ type TrillianAdminServer interface {
 	// A soft-deleted tree may be undeleted for a certain period, after which
 	// it'll be permanently deleted.
 	UndeleteTree(context.Context, *UndeleteTreeRequest) (*Tree, error)
+	// Stores a new root for a log which commits to the same tree as its latest
+	// root, but with a fresh timestamp.
+	// This is an operational RPC which is only served if explicitly enabled.
+	ResignLogRoot(context.Context, *ResignLogRootRequest) (*ResignLogRootResponse, error)
+	// Moves a leaf out of a log's queue of unsequenced leaves, so that it no
+	// longer blocks the integration of other leaves.
+	// This is an operational RPC which is only served if explicitly enabled.
+	QuarantineLeaf(context.Context, *QuarantineLeafRequest) (*QuarantineLeafResponse, error)
+	// Puts quarantined leaves back into the log's queue of unsequenced leaves.
+	// This is an operational RPC which is only served if explicitly enabled.
+	RequeueQuarantinedLeaves(context.Context, *RequeueQuarantinedLeavesRequest) (*RequeueQuarantinedLeavesResponse, error)
+	// Lists the leaves of a log which have been quarantined, either by an
+	// operator or automatically by the sequencer.
+	ListQuarantinedLeaves(context.Context, *ListQuarantinedLeavesRequest) (*ListQuarantinedLeavesResponse, error)
+	// Pauses the integration of a log's queued leaves, e.g. during storage
+	// maintenance, until the given time or until ResumeIntegration is called.
+	// Leaves can still be queued while integration is paused. Returns the
+	// updated tree.
+	PauseIntegration(context.Context, *PauseIntegrationRequest) (*Tree, error)
+	// Resumes the integration of a log's queued leaves. Returns the updated
+	// tree.
+	ResumeIntegration(context.Context, *ResumeIntegrationRequest) (*Tree, error)
+	// Streams the changes of the trees the requester has access to, so that
+	// controllers can reconcile without polling ListTrees. The stream starts
+	// with a CREATED event for each existing tree. Changes made through any
+	// server are noticed within the server's watch interval, and those made
+	// through the same server immediately.
+	WatchTrees(*WatchTreesRequest, TrillianAdmin_WatchTreesServer) error
+	// Prunes the data of a log's leaves which are older than its retention
+	// policy allows, like the log signer does in the background, so that
+	// operators can run or inspect the garbage collection on demand.
+	PruneLeaves(context.Context, *PruneLeavesRequest) (*PruneLeavesResponse, error)
+	// Streams a self-contained snapshot of a log, i.e. its metadata, latest
+	// root and leaves, so that it can be moved to another storage backend, or
+	// backed up and restored with ImportTree.
+	ExportTree(*ExportTreeRequest, TrillianAdmin_ExportTreeServer) error
+	// Creates a log from a snapshot streamed by ExportTree, keeping its tree ID
+	// unless zero. The Merkle tree is rebuilt from the leaves, and the import
+	// fails unless it matches the exported root. The log is FROZEN while it's
+	// imported, and gets the exported tree_state once complete. If the import
+	// fails, the log is deleted, so that the snapshot can be imported again.
+	ImportTree(TrillianAdmin_ImportTreeServer) error
+	// Streams the leaves which are queued in a log and not sequenced yet, all
+	// read from one snapshot, so that a stuck integration can be inspected, or
+	// the queue be moved to another log with ImportQueue.
+	ExportQueue(*ExportQueueRequest, TrillianAdmin_ExportQueueServer) error
+	// Queues the leaves of a snapshot streamed by ExportQueue in a log, keeping
+	// their identity hashes and queue timestamps. Leaves which are already in
+	// the log are skipped.
+	ImportQueue(TrillianAdmin_ImportQueueServer) error
+	// Sets the rates at which the requests for a tree may consume quota tokens.
+	// Every server applies the new quota to the following requests for the tree.
+	// Returns the new quota.
+	SetTreeQuota(context.Context, *SetTreeQuotaRequest) (*TreeQuota, error)
+	// Returns the quota of a tree, which is empty if it has none.
+	GetTreeQuota(context.Context, *GetTreeQuotaRequest) (*TreeQuota, error)
 }
 
 // UnimplementedTrillianAdminServer should be embedded to have forward compatible implementations.
@@ -154,6 +513,48 @@ func (UnimplementedTrillianAdminServer) DeleteTree(context.Context, *DeleteTreeR
 func (UnimplementedTrillianAdminServer) UndeleteTree(context.Context, *UndeleteTreeRequest) (*Tree, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UndeleteTree not implemented")
 }
+func (UnimplementedTrillianAdminServer) ResignLogRoot(context.Context, *ResignLogRootRequest) (*ResignLogRootResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResignLogRoot not implemented")
+}
+func (UnimplementedTrillianAdminServer) QuarantineLeaf(context.Context, *QuarantineLeafRequest) (*QuarantineLeafResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QuarantineLeaf not implemented")
+}
+func (UnimplementedTrillianAdminServer) RequeueQuarantinedLeaves(context.Context, *RequeueQuarantinedLeavesRequest) (*RequeueQuarantinedLeavesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequeueQuarantinedLeaves not implemented")
+}
+func (UnimplementedTrillianAdminServer) ListQuarantinedLeaves(context.Context, *ListQuarantinedLeavesRequest) (*ListQuarantinedLeavesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListQuarantinedLeaves not implemented")
+}
+func (UnimplementedTrillianAdminServer) PauseIntegration(context.Context, *PauseIntegrationRequest) (*Tree, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseIntegration not implemented")
+}
+func (UnimplementedTrillianAdminServer) ResumeIntegration(context.Context, *ResumeIntegrationRequest) (*Tree, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeIntegration not implemented")
+}
+func (UnimplementedTrillianAdminServer) WatchTrees(*WatchTreesRequest, TrillianAdmin_WatchTreesServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchTrees not implemented")
+}
+func (UnimplementedTrillianAdminServer) PruneLeaves(context.Context, *PruneLeavesRequest) (*PruneLeavesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PruneLeaves not implemented")
+}
+func (UnimplementedTrillianAdminServer) ExportTree(*ExportTreeRequest, TrillianAdmin_ExportTreeServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportTree not implemented")
+}
+func (UnimplementedTrillianAdminServer) ImportTree(TrillianAdmin_ImportTreeServer) error {
+	return status.Errorf(codes.Unimplemented, "method ImportTree not implemented")
+}
+func (UnimplementedTrillianAdminServer) ExportQueue(*ExportQueueRequest, TrillianAdmin_ExportQueueServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportQueue not implemented")
+}
+func (UnimplementedTrillianAdminServer) ImportQueue(TrillianAdmin_ImportQueueServer) error {
+	return status.Errorf(codes.Unimplemented, "method ImportQueue not implemented")
+}
+func (UnimplementedTrillianAdminServer) SetTreeQuota(context.Context, *SetTreeQuotaRequest) (*TreeQuota, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetTreeQuota not implemented")
+}
+func (UnimplementedTrillianAdminServer) GetTreeQuota(context.Context, *GetTreeQuotaRequest) (*TreeQuota, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTreeQuota not implemented")
+}
 
 // UnsafeTrillianAdminServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to TrillianAdminServer will
@@ -274,6 +675,283 @@ func _TrillianAdmin_UndeleteTree_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TrillianAdmin_ResignLogRoot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResignLogRootRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrillianAdminServer).ResignLogRoot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trillian.TrillianAdmin/ResignLogRoot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrillianAdminServer).ResignLogRoot(ctx, req.(*ResignLogRootRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrillianAdmin_QuarantineLeaf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuarantineLeafRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrillianAdminServer).QuarantineLeaf(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trillian.TrillianAdmin/QuarantineLeaf",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrillianAdminServer).QuarantineLeaf(ctx, req.(*QuarantineLeafRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrillianAdmin_RequeueQuarantinedLeaves_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequeueQuarantinedLeavesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrillianAdminServer).RequeueQuarantinedLeaves(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trillian.TrillianAdmin/RequeueQuarantinedLeaves",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrillianAdminServer).RequeueQuarantinedLeaves(ctx, req.(*RequeueQuarantinedLeavesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrillianAdmin_ListQuarantinedLeaves_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListQuarantinedLeavesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrillianAdminServer).ListQuarantinedLeaves(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trillian.TrillianAdmin/ListQuarantinedLeaves",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrillianAdminServer).ListQuarantinedLeaves(ctx, req.(*ListQuarantinedLeavesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrillianAdmin_PauseIntegration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseIntegrationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrillianAdminServer).PauseIntegration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trillian.TrillianAdmin/PauseIntegration",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrillianAdminServer).PauseIntegration(ctx, req.(*PauseIntegrationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrillianAdmin_ResumeIntegration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeIntegrationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrillianAdminServer).ResumeIntegration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trillian.TrillianAdmin/ResumeIntegration",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrillianAdminServer).ResumeIntegration(ctx, req.(*ResumeIntegrationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrillianAdmin_WatchTrees_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchTreesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TrillianAdminServer).WatchTrees(m, &trillianAdminWatchTreesServer{stream})
+}
+
+type TrillianAdmin_WatchTreesServer interface {
+	Send(*TreeEvent) error
+	grpc.ServerStream
+}
+
+type trillianAdminWatchTreesServer struct {
+	grpc.ServerStream
+}
+
+func (x *trillianAdminWatchTreesServer) Send(m *TreeEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _TrillianAdmin_PruneLeaves_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PruneLeavesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrillianAdminServer).PruneLeaves(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trillian.TrillianAdmin/PruneLeaves",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrillianAdminServer).PruneLeaves(ctx, req.(*PruneLeavesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrillianAdmin_ExportTree_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportTreeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TrillianAdminServer).ExportTree(m, &trillianAdminExportTreeServer{stream})
+}
+
+type TrillianAdmin_ExportTreeServer interface {
+	Send(*TreeSnapshotChunk) error
+	grpc.ServerStream
+}
+
+type trillianAdminExportTreeServer struct {
+	grpc.ServerStream
+}
+
+func (x *trillianAdminExportTreeServer) Send(m *TreeSnapshotChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _TrillianAdmin_ImportTree_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TrillianAdminServer).ImportTree(&trillianAdminImportTreeServer{stream})
+}
+
+type TrillianAdmin_ImportTreeServer interface {
+	SendAndClose(*Tree) error
+	Recv() (*TreeSnapshotChunk, error)
+	grpc.ServerStream
+}
+
+type trillianAdminImportTreeServer struct {
+	grpc.ServerStream
+}
+
+func (x *trillianAdminImportTreeServer) SendAndClose(m *Tree) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *trillianAdminImportTreeServer) Recv() (*TreeSnapshotChunk, error) {
+	m := new(TreeSnapshotChunk)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _TrillianAdmin_ExportQueue_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportQueueRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TrillianAdminServer).ExportQueue(m, &trillianAdminExportQueueServer{stream})
+}
+
+type TrillianAdmin_ExportQueueServer interface {
+	Send(*QueueSnapshotChunk) error
+	grpc.ServerStream
+}
+
+type trillianAdminExportQueueServer struct {
+	grpc.ServerStream
+}
+
+func (x *trillianAdminExportQueueServer) Send(m *QueueSnapshotChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _TrillianAdmin_ImportQueue_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TrillianAdminServer).ImportQueue(&trillianAdminImportQueueServer{stream})
+}
+
+type TrillianAdmin_ImportQueueServer interface {
+	SendAndClose(*ImportQueueResponse) error
+	Recv() (*QueueSnapshotChunk, error)
+	grpc.ServerStream
+}
+
+type trillianAdminImportQueueServer struct {
+	grpc.ServerStream
+}
+
+func (x *trillianAdminImportQueueServer) SendAndClose(m *ImportQueueResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *trillianAdminImportQueueServer) Recv() (*QueueSnapshotChunk, error) {
+	m := new(QueueSnapshotChunk)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _TrillianAdmin_SetTreeQuota_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTreeQuotaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrillianAdminServer).SetTreeQuota(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trillian.TrillianAdmin/SetTreeQuota",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrillianAdminServer).SetTreeQuota(ctx, req.(*SetTreeQuotaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrillianAdmin_GetTreeQuota_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTreeQuotaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrillianAdminServer).GetTreeQuota(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trillian.TrillianAdmin/GetTreeQuota",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrillianAdminServer).GetTreeQuota(ctx, req.(*GetTreeQuotaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TrillianAdmin_ServiceDesc is the grpc.ServiceDesc for TrillianAdmin service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -305,7 +983,69 @@ var TrillianAdmin_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UndeleteTree",
 			Handler:    _TrillianAdmin_UndeleteTree_Handler,
 		},
+		{
+			MethodName: "ResignLogRoot",
+			Handler:    _TrillianAdmin_ResignLogRoot_Handler,
+		},
+		{
+			MethodName: "QuarantineLeaf",
+			Handler:    _TrillianAdmin_QuarantineLeaf_Handler,
+		},
+		{
+			MethodName: "RequeueQuarantinedLeaves",
+			Handler:    _TrillianAdmin_RequeueQuarantinedLeaves_Handler,
+		},
+		{
+			MethodName: "ListQuarantinedLeaves",
+			Handler:    _TrillianAdmin_ListQuarantinedLeaves_Handler,
+		},
+		{
+			MethodName: "PauseIntegration",
+			Handler:    _TrillianAdmin_PauseIntegration_Handler,
+		},
+		{
+			MethodName: "ResumeIntegration",
+			Handler:    _TrillianAdmin_ResumeIntegration_Handler,
+		},
+		{
+			MethodName: "PruneLeaves",
+			Handler:    _TrillianAdmin_PruneLeaves_Handler,
+		},
+		{
+			MethodName: "SetTreeQuota",
+			Handler:    _TrillianAdmin_SetTreeQuota_Handler,
+		},
+		{
+			MethodName: "GetTreeQuota",
+			Handler:    _TrillianAdmin_GetTreeQuota_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchTrees",
+			Handler:       _TrillianAdmin_WatchTrees_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportTree",
+			Handler:       _TrillianAdmin_ExportTree_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportTree",
+			Handler:       _TrillianAdmin_ImportTree_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportQueue",
+			Handler:       _TrillianAdmin_ExportQueue_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportQueue",
+			Handler:       _TrillianAdmin_ImportQueue_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "trillian_admin_api.proto",
 }
@@ -25,6 +25,13 @@ type TrillianLogClient interface {
 	// QueueLeaf adds a single leaf to the queue of pending leaves for a normal
 	// log.
 	QueueLeaf(ctx context.Context, in *QueueLeafRequest, opts ...grpc.CallOption) (*QueueLeafResponse, error)
+	// QueueLeavesStream adds the leaves of a stream of requests to the queue of
+	// pending leaves for a normal log, so that clients don't need to batch them.
+	// All requests of the stream must be for the same log. The server queues the
+	// leaves in batches as they arrive, and returns their statuses once the
+	// client closes the stream. If the call fails, the leaves of earlier batches
+	// may already be queued, and can be submitted again.
+	QueueLeavesStream(ctx context.Context, opts ...grpc.CallOption) (TrillianLog_QueueLeavesStreamClient, error)
 	// GetInclusionProof returns an inclusion proof for a leaf with a given index
 	// in a particular tree.
 	//
@@ -37,6 +44,14 @@ type TrillianLogClient interface {
 	// If any of the leaves that match the given Merkle has have a leaf index that
 	// is beyond the requested tree size, the corresponding proof entry will be empty.
 	GetInclusionProofByHash(ctx context.Context, in *GetInclusionProofByHashRequest, opts ...grpc.CallOption) (*GetInclusionProofByHashResponse, error)
+	// GetInclusionProofsAtSizes returns inclusion proofs for a leaf with a given
+	// index at several sizes of a particular tree, e.g. to demonstrate that the
+	// leaf stayed in the log over time. The nodes shared by the proofs are only
+	// read once.
+	//
+	// If any of the requested tree sizes is larger than the server is aware of,
+	// the response will include the latest known log root and no proofs.
+	GetInclusionProofsAtSizes(ctx context.Context, in *GetInclusionProofsAtSizesRequest, opts ...grpc.CallOption) (*GetInclusionProofsAtSizesResponse, error)
 	// GetConsistencyProof returns a consistency proof between different sizes of
 	// a particular tree.
 	//
@@ -63,9 +78,83 @@ type TrillianLogClient interface {
 	// AddSequencedLeaves adds a batch of leaves with assigned sequence numbers
 	// to a pre-ordered log.  The indices of the provided leaves must be contiguous.
 	AddSequencedLeaves(ctx context.Context, in *AddSequencedLeavesRequest, opts ...grpc.CallOption) (*AddSequencedLeavesResponse, error)
+	// AddSequencedLeavesStream adds the leaves of a stream of requests to a
+	// pre-ordered log, like AddSequencedLeaves, so that mirrors can replicate
+	// another log without a call per batch. All requests of the stream must be
+	// for the same log, and must continue from the next index expected by the
+	// log, as returned by GetMirrorProgress, without gaps. The server responds
+	// to each request once its leaves are added, so an interrupted mirror can
+	// resume after the last acknowledged leaf.
+	AddSequencedLeavesStream(ctx context.Context, opts ...grpc.CallOption) (TrillianLog_AddSequencedLeavesStreamClient, error)
+	// GetMirrorProgress returns the index of the next leaf expected by a
+	// pre-ordered log, i.e. the number of leaves from index zero which it holds
+	// without gaps, whether they're integrated or not.
+	GetMirrorProgress(ctx context.Context, in *GetMirrorProgressRequest, opts ...grpc.CallOption) (*GetMirrorProgressResponse, error)
 	// GetLeavesByRange returns a batch of leaves whose leaf indices are in a
 	// sequential range.
 	GetLeavesByRange(ctx context.Context, in *GetLeavesByRangeRequest, opts ...grpc.CallOption) (*GetLeavesByRangeResponse, error)
+	// StreamLeavesByRange returns the leaves of a sequential range, like
+	// GetLeavesByRange, but in a stream of responses, so that large ranges can
+	// be exported without buffering them in memory. The range is capped at the
+	// tree size when the call starts, and the first response holds the signed
+	// log root of that tree size. The leaves of each response immediately follow
+	// those of the previous one.
+	StreamLeavesByRange(ctx context.Context, in *GetLeavesByRangeRequest, opts ...grpc.CallOption) (TrillianLog_StreamLeavesByRangeClient, error)
+	// GetCompactRange returns the hashes of the minimal set of tree nodes which
+	// cover a range of leaves, i.e. the compact range of the leaves, under a
+	// particular tree size. It allows external systems to replicate parts of
+	// the tree or build tiles without fetching all the leaves.
+	//
+	// If the requested tree size is larger than the server is aware of, the
+	// response will include the latest known log root and no hashes.
+	GetCompactRange(ctx context.Context, in *GetCompactRangeRequest, opts ...grpc.CallOption) (*GetCompactRangeResponse, error)
+	// GetTile returns a tile of the Merkle tree, in the tile layout of
+	// golang.org/x/mod/sumdb/tlog, so that clients with tile caches can verify
+	// proofs against the log without fetching each proof from the server.
+	// Tiles are immutable once the tree covers all their hashes.
+	//
+	// If the tree known to the server doesn't cover the tile yet, the response
+	// will include the latest known log root and no data.
+	GetTile(ctx context.Context, in *GetTileRequest, opts ...grpc.CallOption) (*GetTileResponse, error)
+	// UpdateLeafExtraData replaces the extra_data of a sequenced leaf. The
+	// extra data is not covered by the Merkle leaf hash, so this doesn't change
+	// the tree; it allows personalities to attach metadata which arrives after
+	// the leaf was logged, e.g. revocation status. Every update is recorded in
+	// an audit trail together with the previous extra data.
+	UpdateLeafExtraData(ctx context.Context, in *UpdateLeafExtraDataRequest, opts ...grpc.CallOption) (*UpdateLeafExtraDataResponse, error)
+	// ListLeafExtraDataUpdates returns the audit trail of the updates of the
+	// extra_data of a sequenced leaf.
+	ListLeafExtraDataUpdates(ctx context.Context, in *ListLeafExtraDataUpdatesRequest, opts ...grpc.CallOption) (*ListLeafExtraDataUpdatesResponse, error)
+	// GetDailyLogStats returns per-day counters of the leaves integrated into a
+	// log, which the sequencer maintains as it integrates them, so reporting
+	// doesn't require scanning the leaves.
+	GetDailyLogStats(ctx context.Context, in *GetDailyLogStatsRequest, opts ...grpc.CallOption) (*GetDailyLogStatsResponse, error)
+	// ReportVerificationFailure records that a client failed to verify data
+	// served by the log, e.g. a proof which doesn't match the client's trusted
+	// root. Reports are stored and counted in the server's metrics, so that
+	// operators are alerted to corruption of the serving path or interference
+	// between the log and its clients.
+	ReportVerificationFailure(ctx context.Context, in *ReportVerificationFailureRequest, opts ...grpc.CallOption) (*ReportVerificationFailureResponse, error)
+	// ListVerificationFailureReports returns the stored verification failure
+	// reports of a log.
+	ListVerificationFailureReports(ctx context.Context, in *ListVerificationFailureReportsRequest, opts ...grpc.CallOption) (*ListVerificationFailureReportsResponse, error)
+	// BeginAuditSession pins a tree size of a log, so that the reads which pass
+	// the returned audit_session_id are served against exactly the tree of that
+	// size, however much the log grows meanwhile: proofs are computed for the
+	// pinned size, leaf ranges end at it, and responses carry its root.
+	// Sessions are held in the memory of the server instance which began them,
+	// and expire if they aren't used for a while.
+	BeginAuditSession(ctx context.Context, in *BeginAuditSessionRequest, opts ...grpc.CallOption) (*BeginAuditSessionResponse, error)
+	// EndAuditSession releases an audit session before it expires.
+	EndAuditSession(ctx context.Context, in *EndAuditSessionRequest, opts ...grpc.CallOption) (*EndAuditSessionResponse, error)
+	// AddCosignature stores the signatures which witnesses added to a
+	// checkpoint of a log, as returned by GetLatestSignedLogRoot. Only
+	// signatures of the witnesses configured on the log server are accepted,
+	// and the checkpoint must match the log's root at its tree size.
+	AddCosignature(ctx context.Context, in *AddCosignatureRequest, opts ...grpc.CallOption) (*AddCosignatureResponse, error)
+	// GetCosignedCheckpoint returns the latest checkpoint of a log which has
+	// been cosigned by the quorum of witnesses configured for the log.
+	GetCosignedCheckpoint(ctx context.Context, in *GetCosignedCheckpointRequest, opts ...grpc.CallOption) (*GetCosignedCheckpointResponse, error)
 }
 
 type trillianLogClient struct {
@@ -85,6 +174,40 @@ func (c *trillianLogClient) QueueLeaf(ctx context.Context, in *QueueLeafRequest,
 	return out, nil
 }
 
+func (c *trillianLogClient) QueueLeavesStream(ctx context.Context, opts ...grpc.CallOption) (TrillianLog_QueueLeavesStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TrillianLog_ServiceDesc.Streams[0], "/trillian.TrillianLog/QueueLeavesStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &trillianLogQueueLeavesStreamClient{stream}
+	return x, nil
+}
+
+type TrillianLog_QueueLeavesStreamClient interface {
+	Send(*QueueLeafRequest) error
+	CloseAndRecv() (*QueueLeavesStreamResponse, error)
+	grpc.ClientStream
+}
+
+type trillianLogQueueLeavesStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *trillianLogQueueLeavesStreamClient) Send(m *QueueLeafRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *trillianLogQueueLeavesStreamClient) CloseAndRecv() (*QueueLeavesStreamResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(QueueLeavesStreamResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *trillianLogClient) GetInclusionProof(ctx context.Context, in *GetInclusionProofRequest, opts ...grpc.CallOption) (*GetInclusionProofResponse, error) {
 	out := new(GetInclusionProofResponse)
 	err := c.cc.Invoke(ctx, "/trillian.TrillianLog/GetInclusionProof", in, out, opts...)
@@ -103,6 +226,15 @@ func (c *trillianLogClient) GetInclusionProofByHash(ctx context.Context, in *Get
 	return out, nil
 }
 
+func (c *trillianLogClient) GetInclusionProofsAtSizes(ctx context.Context, in *GetInclusionProofsAtSizesRequest, opts ...grpc.CallOption) (*GetInclusionProofsAtSizesResponse, error) {
+	out := new(GetInclusionProofsAtSizesResponse)
+	err := c.cc.Invoke(ctx, "/trillian.TrillianLog/GetInclusionProofsAtSizes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *trillianLogClient) GetConsistencyProof(ctx context.Context, in *GetConsistencyProofRequest, opts ...grpc.CallOption) (*GetConsistencyProofResponse, error) {
 	out := new(GetConsistencyProofResponse)
 	err := c.cc.Invoke(ctx, "/trillian.TrillianLog/GetConsistencyProof", in, out, opts...)
@@ -148,6 +280,46 @@ func (c *trillianLogClient) AddSequencedLeaves(ctx context.Context, in *AddSeque
 	return out, nil
 }
 
+func (c *trillianLogClient) AddSequencedLeavesStream(ctx context.Context, opts ...grpc.CallOption) (TrillianLog_AddSequencedLeavesStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TrillianLog_ServiceDesc.Streams[1], "/trillian.TrillianLog/AddSequencedLeavesStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &trillianLogAddSequencedLeavesStreamClient{stream}
+	return x, nil
+}
+
+type TrillianLog_AddSequencedLeavesStreamClient interface {
+	Send(*AddSequencedLeavesRequest) error
+	Recv() (*AddSequencedLeavesResponse, error)
+	grpc.ClientStream
+}
+
+type trillianLogAddSequencedLeavesStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *trillianLogAddSequencedLeavesStreamClient) Send(m *AddSequencedLeavesRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *trillianLogAddSequencedLeavesStreamClient) Recv() (*AddSequencedLeavesResponse, error) {
+	m := new(AddSequencedLeavesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *trillianLogClient) GetMirrorProgress(ctx context.Context, in *GetMirrorProgressRequest, opts ...grpc.CallOption) (*GetMirrorProgressResponse, error) {
+	out := new(GetMirrorProgressResponse)
+	err := c.cc.Invoke(ctx, "/trillian.TrillianLog/GetMirrorProgress", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *trillianLogClient) GetLeavesByRange(ctx context.Context, in *GetLeavesByRangeRequest, opts ...grpc.CallOption) (*GetLeavesByRangeResponse, error) {
 	out := new(GetLeavesByRangeResponse)
 	err := c.cc.Invoke(ctx, "/trillian.TrillianLog/GetLeavesByRange", in, out, opts...)
@@ -157,6 +329,137 @@ func (c *trillianLogClient) GetLeavesByRange(ctx context.Context, in *GetLeavesB
 	return out, nil
 }
 
+func (c *trillianLogClient) StreamLeavesByRange(ctx context.Context, in *GetLeavesByRangeRequest, opts ...grpc.CallOption) (TrillianLog_StreamLeavesByRangeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TrillianLog_ServiceDesc.Streams[2], "/trillian.TrillianLog/StreamLeavesByRange", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &trillianLogStreamLeavesByRangeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TrillianLog_StreamLeavesByRangeClient interface {
+	Recv() (*GetLeavesByRangeResponse, error)
+	grpc.ClientStream
+}
+
+type trillianLogStreamLeavesByRangeClient struct {
+	grpc.ClientStream
+}
+
+func (x *trillianLogStreamLeavesByRangeClient) Recv() (*GetLeavesByRangeResponse, error) {
+	m := new(GetLeavesByRangeResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *trillianLogClient) GetCompactRange(ctx context.Context, in *GetCompactRangeRequest, opts ...grpc.CallOption) (*GetCompactRangeResponse, error) {
+	out := new(GetCompactRangeResponse)
+	err := c.cc.Invoke(ctx, "/trillian.TrillianLog/GetCompactRange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trillianLogClient) GetTile(ctx context.Context, in *GetTileRequest, opts ...grpc.CallOption) (*GetTileResponse, error) {
+	out := new(GetTileResponse)
+	err := c.cc.Invoke(ctx, "/trillian.TrillianLog/GetTile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trillianLogClient) UpdateLeafExtraData(ctx context.Context, in *UpdateLeafExtraDataRequest, opts ...grpc.CallOption) (*UpdateLeafExtraDataResponse, error) {
+	out := new(UpdateLeafExtraDataResponse)
+	err := c.cc.Invoke(ctx, "/trillian.TrillianLog/UpdateLeafExtraData", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trillianLogClient) ListLeafExtraDataUpdates(ctx context.Context, in *ListLeafExtraDataUpdatesRequest, opts ...grpc.CallOption) (*ListLeafExtraDataUpdatesResponse, error) {
+	out := new(ListLeafExtraDataUpdatesResponse)
+	err := c.cc.Invoke(ctx, "/trillian.TrillianLog/ListLeafExtraDataUpdates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trillianLogClient) GetDailyLogStats(ctx context.Context, in *GetDailyLogStatsRequest, opts ...grpc.CallOption) (*GetDailyLogStatsResponse, error) {
+	out := new(GetDailyLogStatsResponse)
+	err := c.cc.Invoke(ctx, "/trillian.TrillianLog/GetDailyLogStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trillianLogClient) ReportVerificationFailure(ctx context.Context, in *ReportVerificationFailureRequest, opts ...grpc.CallOption) (*ReportVerificationFailureResponse, error) {
+	out := new(ReportVerificationFailureResponse)
+	err := c.cc.Invoke(ctx, "/trillian.TrillianLog/ReportVerificationFailure", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trillianLogClient) ListVerificationFailureReports(ctx context.Context, in *ListVerificationFailureReportsRequest, opts ...grpc.CallOption) (*ListVerificationFailureReportsResponse, error) {
+	out := new(ListVerificationFailureReportsResponse)
+	err := c.cc.Invoke(ctx, "/trillian.TrillianLog/ListVerificationFailureReports", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trillianLogClient) BeginAuditSession(ctx context.Context, in *BeginAuditSessionRequest, opts ...grpc.CallOption) (*BeginAuditSessionResponse, error) {
+	out := new(BeginAuditSessionResponse)
+	err := c.cc.Invoke(ctx, "/trillian.TrillianLog/BeginAuditSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trillianLogClient) EndAuditSession(ctx context.Context, in *EndAuditSessionRequest, opts ...grpc.CallOption) (*EndAuditSessionResponse, error) {
+	out := new(EndAuditSessionResponse)
+	err := c.cc.Invoke(ctx, "/trillian.TrillianLog/EndAuditSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trillianLogClient) AddCosignature(ctx context.Context, in *AddCosignatureRequest, opts ...grpc.CallOption) (*AddCosignatureResponse, error) {
+	out := new(AddCosignatureResponse)
+	err := c.cc.Invoke(ctx, "/trillian.TrillianLog/AddCosignature", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trillianLogClient) GetCosignedCheckpoint(ctx context.Context, in *GetCosignedCheckpointRequest, opts ...grpc.CallOption) (*GetCosignedCheckpointResponse, error) {
+	out := new(GetCosignedCheckpointResponse)
+	err := c.cc.Invoke(ctx, "/trillian.TrillianLog/GetCosignedCheckpoint", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TrillianLogServer is the server API for TrillianLog service.
 // All implementations should embed UnimplementedTrillianLogServer
 // for forward compatibility
@@ -164,6 +467,13 @@ type TrillianLogServer interface {
 	// QueueLeaf adds a single leaf to the queue of pending leaves for a normal
 	// log.
 	QueueLeaf(context.Context, *QueueLeafRequest) (*QueueLeafResponse, error)
+	// QueueLeavesStream adds the leaves of a stream of requests to the queue of
+	// pending leaves for a normal log, so that clients don't need to batch them.
+	// All requests of the stream must be for the same log. The server queues the
+	// leaves in batches as they arrive, and returns their statuses once the
+	// client closes the stream. If the call fails, the leaves of earlier batches
+	// may already be queued, and can be submitted again.
+	QueueLeavesStream(TrillianLog_QueueLeavesStreamServer) error
 	// GetInclusionProof returns an inclusion proof for a leaf with a given index
 	// in a particular tree.
 	//
@@ -176,6 +486,14 @@ type TrillianLogServer interface {
 	// If any of the leaves that match the given Merkle has have a leaf index that
 	// is beyond the requested tree size, the corresponding proof entry will be empty.
 	GetInclusionProofByHash(context.Context, *GetInclusionProofByHashRequest) (*GetInclusionProofByHashResponse, error)
+	// GetInclusionProofsAtSizes returns inclusion proofs for a leaf with a given
+	// index at several sizes of a particular tree, e.g. to demonstrate that the
+	// leaf stayed in the log over time. The nodes shared by the proofs are only
+	// read once.
+	//
+	// If any of the requested tree sizes is larger than the server is aware of,
+	// the response will include the latest known log root and no proofs.
+	GetInclusionProofsAtSizes(context.Context, *GetInclusionProofsAtSizesRequest) (*GetInclusionProofsAtSizesResponse, error)
 	// GetConsistencyProof returns a consistency proof between different sizes of
 	// a particular tree.
 	//
@@ -202,9 +520,83 @@ type TrillianLogServer interface {
 	// AddSequencedLeaves adds a batch of leaves with assigned sequence numbers
 	// to a pre-ordered log.  The indices of the provided leaves must be contiguous.
 	AddSequencedLeaves(context.Context, *AddSequencedLeavesRequest) (*AddSequencedLeavesResponse, error)
+	// AddSequencedLeavesStream adds the leaves of a stream of requests to a
+	// pre-ordered log, like AddSequencedLeaves, so that mirrors can replicate
+	// another log without a call per batch. All requests of the stream must be
+	// for the same log, and must continue from the next index expected by the
+	// log, as returned by GetMirrorProgress, without gaps. The server responds
+	// to each request once its leaves are added, so an interrupted mirror can
+	// resume after the last acknowledged leaf.
+	AddSequencedLeavesStream(TrillianLog_AddSequencedLeavesStreamServer) error
+	// GetMirrorProgress returns the index of the next leaf expected by a
+	// pre-ordered log, i.e. the number of leaves from index zero which it holds
+	// without gaps, whether they're integrated or not.
+	GetMirrorProgress(context.Context, *GetMirrorProgressRequest) (*GetMirrorProgressResponse, error)
 	// GetLeavesByRange returns a batch of leaves whose leaf indices are in a
 	// sequential range.
 	GetLeavesByRange(context.Context, *GetLeavesByRangeRequest) (*GetLeavesByRangeResponse, error)
+	// StreamLeavesByRange returns the leaves of a sequential range, like
+	// GetLeavesByRange, but in a stream of responses, so that large ranges can
+	// be exported without buffering them in memory. The range is capped at the
+	// tree size when the call starts, and the first response holds the signed
+	// log root of that tree size. The leaves of each response immediately follow
+	// those of the previous one.
+	StreamLeavesByRange(*GetLeavesByRangeRequest, TrillianLog_StreamLeavesByRangeServer) error
+	// GetCompactRange returns the hashes of the minimal set of tree nodes which
+	// cover a range of leaves, i.e. the compact range of the leaves, under a
+	// particular tree size. It allows external systems to replicate parts of
+	// the tree or build tiles without fetching all the leaves.
+	//
+	// If the requested tree size is larger than the server is aware of, the
+	// response will include the latest known log root and no hashes.
+	GetCompactRange(context.Context, *GetCompactRangeRequest) (*GetCompactRangeResponse, error)
+	// GetTile returns a tile of the Merkle tree, in the tile layout of
+	// golang.org/x/mod/sumdb/tlog, so that clients with tile caches can verify
+	// proofs against the log without fetching each proof from the server.
+	// Tiles are immutable once the tree covers all their hashes.
+	//
+	// If the tree known to the server doesn't cover the tile yet, the response
+	// will include the latest known log root and no data.
+	GetTile(context.Context, *GetTileRequest) (*GetTileResponse, error)
+	// UpdateLeafExtraData replaces the extra_data of a sequenced leaf. The
+	// extra data is not covered by the Merkle leaf hash, so this doesn't change
+	// the tree; it allows personalities to attach metadata which arrives after
+	// the leaf was logged, e.g. revocation status. Every update is recorded in
+	// an audit trail together with the previous extra data.
+	UpdateLeafExtraData(context.Context, *UpdateLeafExtraDataRequest) (*UpdateLeafExtraDataResponse, error)
+	// ListLeafExtraDataUpdates returns the audit trail of the updates of the
+	// extra_data of a sequenced leaf.
+	ListLeafExtraDataUpdates(context.Context, *ListLeafExtraDataUpdatesRequest) (*ListLeafExtraDataUpdatesResponse, error)
+	// GetDailyLogStats returns per-day counters of the leaves integrated into a
+	// log, which the sequencer maintains as it integrates them, so reporting
+	// doesn't require scanning the leaves.
+	GetDailyLogStats(context.Context, *GetDailyLogStatsRequest) (*GetDailyLogStatsResponse, error)
+	// ReportVerificationFailure records that a client failed to verify data
+	// served by the log, e.g. a proof which doesn't match the client's trusted
+	// root. Reports are stored and counted in the server's metrics, so that
+	// operators are alerted to corruption of the serving path or interference
+	// between the log and its clients.
+	ReportVerificationFailure(context.Context, *ReportVerificationFailureRequest) (*ReportVerificationFailureResponse, error)
+	// ListVerificationFailureReports returns the stored verification failure
+	// reports of a log.
+	ListVerificationFailureReports(context.Context, *ListVerificationFailureReportsRequest) (*ListVerificationFailureReportsResponse, error)
+	// BeginAuditSession pins a tree size of a log, so that the reads which pass
+	// the returned audit_session_id are served against exactly the tree of that
+	// size, however much the log grows meanwhile: proofs are computed for the
+	// pinned size, leaf ranges end at it, and responses carry its root.
+	// Sessions are held in the memory of the server instance which began them,
+	// and expire if they aren't used for a while.
+	BeginAuditSession(context.Context, *BeginAuditSessionRequest) (*BeginAuditSessionResponse, error)
+	// EndAuditSession releases an audit session before it expires.
+	EndAuditSession(context.Context, *EndAuditSessionRequest) (*EndAuditSessionResponse, error)
+	// AddCosignature stores the signatures which witnesses added to a
+	// checkpoint of a log, as returned by GetLatestSignedLogRoot. Only
+	// signatures of the witnesses configured on the log server are accepted,
+	// and the checkpoint must match the log's root at its tree size.
+	AddCosignature(context.Context, *AddCosignatureRequest) (*AddCosignatureResponse, error)
+	// GetCosignedCheckpoint returns the latest checkpoint of a log which has
+	// been cosigned by the quorum of witnesses configured for the log.
+	GetCosignedCheckpoint(context.Context, *GetCosignedCheckpointRequest) (*GetCosignedCheckpointResponse, error)
 }
 
 // UnimplementedTrillianLogServer should be embedded to have forward compatible implementations.
@@ -214,12 +606,18 @@ type UnimplementedTrillianLogServer struct {
 func (UnimplementedTrillianLogServer) QueueLeaf(context.Context, *QueueLeafRequest) (*QueueLeafResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method QueueLeaf not implemented")
 }
+func (UnimplementedTrillianLogServer) QueueLeavesStream(TrillianLog_QueueLeavesStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method QueueLeavesStream not implemented")
+}
 func (UnimplementedTrillianLogServer) GetInclusionProof(context.Context, *GetInclusionProofRequest) (*GetInclusionProofResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetInclusionProof not implemented")
 }
 func (UnimplementedTrillianLogServer) GetInclusionProofByHash(context.Context, *GetInclusionProofByHashRequest) (*GetInclusionProofByHashResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetInclusionProofByHash not implemented")
 }
+func (UnimplementedTrillianLogServer) GetInclusionProofsAtSizes(context.Context, *GetInclusionProofsAtSizesRequest) (*GetInclusionProofsAtSizesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInclusionProofsAtSizes not implemented")
+}
 func (UnimplementedTrillianLogServer) GetConsistencyProof(context.Context, *GetConsistencyProofRequest) (*GetConsistencyProofResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetConsistencyProof not implemented")
 }
@@ -235,9 +633,51 @@ func (UnimplementedTrillianLogServer) InitLog(context.Context, *InitLogRequest)
 func (UnimplementedTrillianLogServer) AddSequencedLeaves(context.Context, *AddSequencedLeavesRequest) (*AddSequencedLeavesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddSequencedLeaves not implemented")
 }
+func (UnimplementedTrillianLogServer) AddSequencedLeavesStream(TrillianLog_AddSequencedLeavesStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method AddSequencedLeavesStream not implemented")
+}
+func (UnimplementedTrillianLogServer) GetMirrorProgress(context.Context, *GetMirrorProgressRequest) (*GetMirrorProgressResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMirrorProgress not implemented")
+}
 func (UnimplementedTrillianLogServer) GetLeavesByRange(context.Context, *GetLeavesByRangeRequest) (*GetLeavesByRangeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetLeavesByRange not implemented")
 }
+func (UnimplementedTrillianLogServer) StreamLeavesByRange(*GetLeavesByRangeRequest, TrillianLog_StreamLeavesByRangeServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamLeavesByRange not implemented")
+}
+func (UnimplementedTrillianLogServer) GetCompactRange(context.Context, *GetCompactRangeRequest) (*GetCompactRangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCompactRange not implemented")
+}
+func (UnimplementedTrillianLogServer) GetTile(context.Context, *GetTileRequest) (*GetTileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTile not implemented")
+}
+func (UnimplementedTrillianLogServer) UpdateLeafExtraData(context.Context, *UpdateLeafExtraDataRequest) (*UpdateLeafExtraDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateLeafExtraData not implemented")
+}
+func (UnimplementedTrillianLogServer) ListLeafExtraDataUpdates(context.Context, *ListLeafExtraDataUpdatesRequest) (*ListLeafExtraDataUpdatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLeafExtraDataUpdates not implemented")
+}
+func (UnimplementedTrillianLogServer) GetDailyLogStats(context.Context, *GetDailyLogStatsRequest) (*GetDailyLogStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDailyLogStats not implemented")
+}
+func (UnimplementedTrillianLogServer) ReportVerificationFailure(context.Context, *ReportVerificationFailureRequest) (*ReportVerificationFailureResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportVerificationFailure not implemented")
+}
+func (UnimplementedTrillianLogServer) ListVerificationFailureReports(context.Context, *ListVerificationFailureReportsRequest) (*ListVerificationFailureReportsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListVerificationFailureReports not implemented")
+}
+func (UnimplementedTrillianLogServer) BeginAuditSession(context.Context, *BeginAuditSessionRequest) (*BeginAuditSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BeginAuditSession not implemented")
+}
+func (UnimplementedTrillianLogServer) EndAuditSession(context.Context, *EndAuditSessionRequest) (*EndAuditSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EndAuditSession not implemented")
+}
+func (UnimplementedTrillianLogServer) AddCosignature(context.Context, *AddCosignatureRequest) (*AddCosignatureResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddCosignature not implemented")
+}
+func (UnimplementedTrillianLogServer) GetCosignedCheckpoint(context.Context, *GetCosignedCheckpointRequest) (*GetCosignedCheckpointResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCosignedCheckpoint not implemented")
+}
 
 // UnsafeTrillianLogServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to TrillianLogServer will
@@ -268,6 +708,32 @@ func _TrillianLog_QueueLeaf_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TrillianLog_QueueLeavesStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TrillianLogServer).QueueLeavesStream(&trillianLogQueueLeavesStreamServer{stream})
+}
+
+type TrillianLog_QueueLeavesStreamServer interface {
+	SendAndClose(*QueueLeavesStreamResponse) error
+	Recv() (*QueueLeafRequest, error)
+	grpc.ServerStream
+}
+
+type trillianLogQueueLeavesStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *trillianLogQueueLeavesStreamServer) SendAndClose(m *QueueLeavesStreamResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *trillianLogQueueLeavesStreamServer) Recv() (*QueueLeafRequest, error) {
+	m := new(QueueLeafRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _TrillianLog_GetInclusionProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetInclusionProofRequest)
 	if err := dec(in); err != nil {
@@ -304,6 +770,24 @@ func _TrillianLog_GetInclusionProofByHash_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TrillianLog_GetInclusionProofsAtSizes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInclusionProofsAtSizesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrillianLogServer).GetInclusionProofsAtSizes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trillian.TrillianLog/GetInclusionProofsAtSizes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrillianLogServer).GetInclusionProofsAtSizes(ctx, req.(*GetInclusionProofsAtSizesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TrillianLog_GetConsistencyProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetConsistencyProofRequest)
 	if err := dec(in); err != nil {
@@ -394,6 +878,50 @@ func _TrillianLog_AddSequencedLeaves_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TrillianLog_AddSequencedLeavesStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TrillianLogServer).AddSequencedLeavesStream(&trillianLogAddSequencedLeavesStreamServer{stream})
+}
+
+type TrillianLog_AddSequencedLeavesStreamServer interface {
+	Send(*AddSequencedLeavesResponse) error
+	Recv() (*AddSequencedLeavesRequest, error)
+	grpc.ServerStream
+}
+
+type trillianLogAddSequencedLeavesStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *trillianLogAddSequencedLeavesStreamServer) Send(m *AddSequencedLeavesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *trillianLogAddSequencedLeavesStreamServer) Recv() (*AddSequencedLeavesRequest, error) {
+	m := new(AddSequencedLeavesRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _TrillianLog_GetMirrorProgress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMirrorProgressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrillianLogServer).GetMirrorProgress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trillian.TrillianLog/GetMirrorProgress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrillianLogServer).GetMirrorProgress(ctx, req.(*GetMirrorProgressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TrillianLog_GetLeavesByRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetLeavesByRangeRequest)
 	if err := dec(in); err != nil {
@@ -412,6 +940,225 @@ func _TrillianLog_GetLeavesByRange_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TrillianLog_StreamLeavesByRange_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetLeavesByRangeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TrillianLogServer).StreamLeavesByRange(m, &trillianLogStreamLeavesByRangeServer{stream})
+}
+
+type TrillianLog_StreamLeavesByRangeServer interface {
+	Send(*GetLeavesByRangeResponse) error
+	grpc.ServerStream
+}
+
+type trillianLogStreamLeavesByRangeServer struct {
+	grpc.ServerStream
+}
+
+func (x *trillianLogStreamLeavesByRangeServer) Send(m *GetLeavesByRangeResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _TrillianLog_GetCompactRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCompactRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrillianLogServer).GetCompactRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trillian.TrillianLog/GetCompactRange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrillianLogServer).GetCompactRange(ctx, req.(*GetCompactRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrillianLog_GetTile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrillianLogServer).GetTile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trillian.TrillianLog/GetTile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrillianLogServer).GetTile(ctx, req.(*GetTileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrillianLog_UpdateLeafExtraData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateLeafExtraDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrillianLogServer).UpdateLeafExtraData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trillian.TrillianLog/UpdateLeafExtraData",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrillianLogServer).UpdateLeafExtraData(ctx, req.(*UpdateLeafExtraDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrillianLog_ListLeafExtraDataUpdates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLeafExtraDataUpdatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrillianLogServer).ListLeafExtraDataUpdates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trillian.TrillianLog/ListLeafExtraDataUpdates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrillianLogServer).ListLeafExtraDataUpdates(ctx, req.(*ListLeafExtraDataUpdatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrillianLog_GetDailyLogStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDailyLogStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrillianLogServer).GetDailyLogStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trillian.TrillianLog/GetDailyLogStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrillianLogServer).GetDailyLogStats(ctx, req.(*GetDailyLogStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrillianLog_ReportVerificationFailure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportVerificationFailureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrillianLogServer).ReportVerificationFailure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trillian.TrillianLog/ReportVerificationFailure",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrillianLogServer).ReportVerificationFailure(ctx, req.(*ReportVerificationFailureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrillianLog_ListVerificationFailureReports_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListVerificationFailureReportsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrillianLogServer).ListVerificationFailureReports(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trillian.TrillianLog/ListVerificationFailureReports",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrillianLogServer).ListVerificationFailureReports(ctx, req.(*ListVerificationFailureReportsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrillianLog_BeginAuditSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BeginAuditSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrillianLogServer).BeginAuditSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trillian.TrillianLog/BeginAuditSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrillianLogServer).BeginAuditSession(ctx, req.(*BeginAuditSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrillianLog_EndAuditSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EndAuditSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrillianLogServer).EndAuditSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trillian.TrillianLog/EndAuditSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrillianLogServer).EndAuditSession(ctx, req.(*EndAuditSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrillianLog_AddCosignature_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddCosignatureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrillianLogServer).AddCosignature(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trillian.TrillianLog/AddCosignature",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrillianLogServer).AddCosignature(ctx, req.(*AddCosignatureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrillianLog_GetCosignedCheckpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCosignedCheckpointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrillianLogServer).GetCosignedCheckpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trillian.TrillianLog/GetCosignedCheckpoint",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrillianLogServer).GetCosignedCheckpoint(ctx, req.(*GetCosignedCheckpointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TrillianLog_ServiceDesc is the grpc.ServiceDesc for TrillianLog service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -431,6 +1178,10 @@ var TrillianLog_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetInclusionProofByHash",
 			Handler:    _TrillianLog_GetInclusionProofByHash_Handler,
 		},
+		{
+			MethodName: "GetInclusionProofsAtSizes",
+			Handler:    _TrillianLog_GetInclusionProofsAtSizes_Handler,
+		},
 		{
 			MethodName: "GetConsistencyProof",
 			Handler:    _TrillianLog_GetConsistencyProof_Handler,
@@ -451,11 +1202,76 @@ var TrillianLog_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AddSequencedLeaves",
 			Handler:    _TrillianLog_AddSequencedLeaves_Handler,
 		},
+		{
+			MethodName: "GetMirrorProgress",
+			Handler:    _TrillianLog_GetMirrorProgress_Handler,
+		},
 		{
 			MethodName: "GetLeavesByRange",
 			Handler:    _TrillianLog_GetLeavesByRange_Handler,
 		},
+		{
+			MethodName: "GetCompactRange",
+			Handler:    _TrillianLog_GetCompactRange_Handler,
+		},
+		{
+			MethodName: "GetTile",
+			Handler:    _TrillianLog_GetTile_Handler,
+		},
+		{
+			MethodName: "UpdateLeafExtraData",
+			Handler:    _TrillianLog_UpdateLeafExtraData_Handler,
+		},
+		{
+			MethodName: "ListLeafExtraDataUpdates",
+			Handler:    _TrillianLog_ListLeafExtraDataUpdates_Handler,
+		},
+		{
+			MethodName: "GetDailyLogStats",
+			Handler:    _TrillianLog_GetDailyLogStats_Handler,
+		},
+		{
+			MethodName: "ReportVerificationFailure",
+			Handler:    _TrillianLog_ReportVerificationFailure_Handler,
+		},
+		{
+			MethodName: "ListVerificationFailureReports",
+			Handler:    _TrillianLog_ListVerificationFailureReports_Handler,
+		},
+		{
+			MethodName: "BeginAuditSession",
+			Handler:    _TrillianLog_BeginAuditSession_Handler,
+		},
+		{
+			MethodName: "EndAuditSession",
+			Handler:    _TrillianLog_EndAuditSession_Handler,
+		},
+		{
+			MethodName: "AddCosignature",
+			Handler:    _TrillianLog_AddCosignature_Handler,
+		},
+		{
+			MethodName: "GetCosignedCheckpoint",
+			Handler:    _TrillianLog_GetCosignedCheckpoint_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "QueueLeavesStream",
+			Handler:       _TrillianLog_QueueLeavesStream_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "AddSequencedLeavesStream",
+			Handler:       _TrillianLog_AddSequencedLeavesStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "StreamLeavesByRange",
+			Handler:       _TrillianLog_StreamLeavesByRange_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "trillian_log_api.proto",
 }
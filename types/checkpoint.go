@@ -0,0 +1,71 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// Checkpoint is the body of a log checkpoint in the transparency-dev format,
+// which is signed as a note (see golang.org/x/mod/sumdb/note). It consists of
+// the following lines, each ending with a newline:
+//
+//	<origin>
+//	<tree size, in decimal>
+//	<root hash, in base64>
+//
+// Any further lines are extension lines, which are ignored.
+type Checkpoint struct {
+	// Origin is the unique identity of the log.
+	Origin string
+	// Size is the number of leaves in the log.
+	Size uint64
+	// Hash is the root hash of the log at Size.
+	Hash []byte
+}
+
+// MarshalText returns the checkpoint body.
+func (c Checkpoint) MarshalText() ([]byte, error) {
+	if c.Origin == "" {
+		return nil, errors.New("empty checkpoint origin")
+	}
+	return []byte(fmt.Sprintf("%s\n%d\n%s\n", c.Origin, c.Size, base64.StdEncoding.EncodeToString(c.Hash))), nil
+}
+
+// UnmarshalText parses a checkpoint body, ignoring extension lines.
+func (c *Checkpoint) UnmarshalText(text []byte) error {
+	lines := bytes.SplitN(text, []byte("\n"), 4)
+	if len(lines) < 4 {
+		return errors.New("checkpoint has fewer than 3 lines")
+	}
+	origin, size, hash := string(lines[0]), string(lines[1]), string(lines[2])
+	if origin == "" {
+		return errors.New("empty checkpoint origin")
+	}
+	n, err := strconv.ParseUint(size, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid checkpoint size %q: %v", size, err)
+	}
+	h, err := base64.StdEncoding.DecodeString(hash)
+	if err != nil {
+		return fmt.Errorf("invalid checkpoint hash %q: %v", hash, err)
+	}
+	*c = Checkpoint{Origin: origin, Size: n, Hash: h}
+	return nil
+}
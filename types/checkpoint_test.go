@@ -0,0 +1,58 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCheckpointRoundTrip(t *testing.T) {
+	c := Checkpoint{Origin: "example.com/log", Size: 123, Hash: []byte("0123456789abcdef0123456789abcdef")}
+	text, err := c.MarshalText()
+	if err != nil {
+		t.Fatalf("MarshalText(): %v", err)
+	}
+	want := "example.com/log\n123\nMDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY=\n"
+	if got := string(text); got != want {
+		t.Errorf("MarshalText()=%q, want %q", got, want)
+	}
+	var got Checkpoint
+	if err := got.UnmarshalText(append(text, "extension\n"...)); err != nil {
+		t.Fatalf("UnmarshalText(): %v", err)
+	}
+	if diff := cmp.Diff(got, c); diff != "" {
+		t.Errorf("UnmarshalText() diff (-got +want):\n%s", diff)
+	}
+}
+
+func TestCheckpointErrors(t *testing.T) {
+	if _, err := (Checkpoint{}).MarshalText(); err == nil {
+		t.Error("MarshalText() without origin succeeded, want error")
+	}
+	for _, text := range []string{
+		"",
+		"origin\n1\n",
+		"\n1\nAAAA\n",
+		"origin\n-1\nAAAA\n",
+		"origin\n1\nnot base64\n",
+	} {
+		var c Checkpoint
+		if err := c.UnmarshalText([]byte(text)); err == nil {
+			t.Errorf("UnmarshalText(%q) succeeded, want error", text)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/trillian"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protopath"
+	"google.golang.org/protobuf/reflect/protorange"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// LeafValidator checks that leaf values conform to the LeafSchema of a tree.
+type LeafValidator struct {
+	msg      protoreflect.MessageDescriptor
+	encoding trillian.LeafSchema_Encoding
+}
+
+// NewLeafValidator builds a LeafValidator for schema, or returns an error if
+// the schema is invalid.
+func NewLeafValidator(schema *trillian.LeafSchema) (*LeafValidator, error) {
+	if _, ok := trillian.LeafSchema_Encoding_name[int32(schema.GetEncoding())]; !ok {
+		return nil, fmt.Errorf("unknown encoding: %v", schema.GetEncoding())
+	}
+	if schema.GetFileDescriptors() == nil {
+		return nil, errors.New("no file descriptors")
+	}
+	files, err := protodesc.NewFiles(schema.FileDescriptors)
+	if err != nil {
+		return nil, fmt.Errorf("invalid file descriptors: %v", err)
+	}
+	d, err := files.FindDescriptorByName(protoreflect.FullName(schema.MessageType))
+	if err != nil {
+		return nil, fmt.Errorf("message type %q: %v", schema.MessageType, err)
+	}
+	msg, ok := d.(protoreflect.MessageDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%q is not a message type", schema.MessageType)
+	}
+	return &LeafValidator{msg: msg, encoding: schema.Encoding}, nil
+}
+
+// Validate returns an error describing why value doesn't conform to the
+// schema, or nil if it does. Values must not contain fields unknown to the
+// schema, and must set all of its required fields.
+func (v *LeafValidator) Validate(value []byte) error {
+	m := dynamicpb.NewMessage(v.msg)
+	switch v.encoding {
+	case trillian.LeafSchema_PROTO_JSON:
+		// Unknown JSON fields are rejected unless DiscardUnknown is set.
+		if err := protojson.Unmarshal(value, m); err != nil {
+			return err
+		}
+	default:
+		if err := proto.Unmarshal(value, m); err != nil {
+			return err
+		}
+		if err := checkNoUnknownFields(m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkNoUnknownFields returns an error naming the first message within m
+// which holds fields that its descriptor doesn't define.
+func checkNoUnknownFields(m proto.Message) error {
+	return protorange.Range(m.ProtoReflect(), func(p protopath.Values) error {
+		last := p.Index(-1)
+		if last.Value.IsValid() {
+			if msg, ok := last.Value.Interface().(protoreflect.Message); ok && len(msg.GetUnknown()) > 0 {
+				return fmt.Errorf("%v: unknown fields", p.Path)
+			}
+		}
+		return nil
+	})
+}
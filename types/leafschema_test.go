@@ -0,0 +1,105 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/testonly"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestNewLeafValidator(t *testing.T) {
+	for _, tc := range []struct {
+		desc    string
+		modify  func(*trillian.LeafSchema)
+		wantErr string
+	}{
+		{desc: "ok", modify: func(*trillian.LeafSchema) {}},
+		{desc: "no-descriptors", modify: func(s *trillian.LeafSchema) { s.FileDescriptors = nil }, wantErr: "no file descriptors"},
+		{desc: "unknown-type", modify: func(s *trillian.LeafSchema) { s.MessageType = "example.Other" }, wantErr: "example.Other"},
+		{desc: "field-type", modify: func(s *trillian.LeafSchema) { s.MessageType = "example.Entry.name" }, wantErr: "not a message type"},
+		{desc: "bad-encoding", modify: func(s *trillian.LeafSchema) { s.Encoding = 99 }, wantErr: "unknown encoding"},
+		{
+			desc: "missing-dependency",
+			modify: func(s *trillian.LeafSchema) {
+				s.FileDescriptors.File[0].Dependency = []string{"example/other.proto"}
+			},
+			wantErr: "invalid file descriptors",
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			schema := testonly.EntryLeafSchema(trillian.LeafSchema_PROTO_BINARY)
+			tc.modify(schema)
+			_, err := NewLeafValidator(schema)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Errorf("NewLeafValidator()=%v, want nil", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("NewLeafValidator()=%v, want error containing %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestLeafValidatorValidate(t *testing.T) {
+	// entry returns the binary encoding of an Entry with the given name, and
+	// child if it isn't nil.
+	entry := func(name string, child []byte) []byte {
+		b := protowire.AppendTag(nil, 1, protowire.BytesType)
+		b = protowire.AppendString(b, name)
+		if child != nil {
+			b = protowire.AppendTag(b, 3, protowire.BytesType)
+			b = protowire.AppendBytes(b, child)
+		}
+		return b
+	}
+	unknown := protowire.AppendVarint(protowire.AppendTag(nil, 9, protowire.VarintType), 1)
+
+	for _, tc := range []struct {
+		desc     string
+		encoding trillian.LeafSchema_Encoding
+		value    []byte
+		wantErr  string
+	}{
+		{desc: "binary", value: entry("a", entry("b", nil))},
+		{desc: "binary-unknown-field", value: append(entry("a", nil), unknown...), wantErr: "unknown fields"},
+		{desc: "binary-nested-unknown-field", value: entry("a", append(entry("b", nil), unknown...)), wantErr: "child: unknown fields"},
+		{desc: "binary-malformed", value: []byte{0x0a, 0x05, 'a'}, wantErr: "cannot parse"},
+		{desc: "binary-wrong-wire-type", value: protowire.AppendVarint(protowire.AppendTag(nil, 1, protowire.VarintType), 1), wantErr: "unknown fields"},
+		{desc: "json", encoding: trillian.LeafSchema_PROTO_JSON, value: []byte(`{"name": "a", "size": "12", "child": {"name": "b"}}`)},
+		{desc: "json-unknown-field", encoding: trillian.LeafSchema_PROTO_JSON, value: []byte(`{"name": "a", "colour": "red"}`), wantErr: "colour"},
+		{desc: "json-wrong-type", encoding: trillian.LeafSchema_PROTO_JSON, value: []byte(`{"size": "twelve"}`), wantErr: "int64"},
+		{desc: "json-malformed", encoding: trillian.LeafSchema_PROTO_JSON, value: []byte(`{"name": `), wantErr: "unexpected"},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			v, err := NewLeafValidator(testonly.EntryLeafSchema(tc.encoding))
+			if err != nil {
+				t.Fatalf("NewLeafValidator(): %v", err)
+			}
+			err = v.Validate(tc.value)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate()=%v, want nil", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("Validate()=%v, want error containing %q", err, tc.wantErr)
+			}
+		})
+	}
+}
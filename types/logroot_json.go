@@ -0,0 +1,122 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/google/trillian"
+)
+
+// JSONLogRootSignatureContext is the context string which the signature of a
+// canonical JSON log root covers before the root itself. It keeps the
+// signature from being taken for that of another message signed with the same
+// key, e.g. an inclusion promise, whose TLS encoding starts with its version.
+const JSONLogRootSignatureContext = "trillian JSON log root signature v1\x00"
+
+// jsonLogRoot is the JSON form of a LogRoot. The integers are encoded as
+// decimal strings, as JSON numbers can't hold all uint64 values exactly, and
+// the byte strings in standard base64 with padding.
+type jsonLogRoot struct {
+	Metadata       string `json:"metadata"`
+	Revision       string `json:"revision"`
+	RootHash       string `json:"root_hash"`
+	TimestampNanos string `json:"timestamp_nanos"`
+	TreeSize       string `json:"tree_size"`
+	Version        int    `json:"version"`
+}
+
+// MarshalCanonicalJSON returns the canonical JSON encoding (RFC 8785) of the
+// log root, for clients which can't parse the TLS encoding of MarshalBinary,
+// e.g. {"metadata":"","revision":"0","root_hash":"3q2+7w==",
+// "timestamp_nanos":"1500000000000000000","tree_size":"5","version":1}.
+func (l *LogRootV1) MarshalCanonicalJSON() ([]byte, error) {
+	if len(l.RootHash) > 128 {
+		return nil, fmt.Errorf("root hash too long: %d bytes", len(l.RootHash))
+	}
+	if len(l.Metadata) > 65535 {
+		return nil, fmt.Errorf("metadata too long: %d bytes", len(l.Metadata))
+	}
+	// The members are written in the order of their names, and the values
+	// hold no characters which need escaping, so this is the canonical form.
+	var b bytes.Buffer
+	fmt.Fprintf(&b, `{"metadata":%q,"revision":%q,"root_hash":%q,"timestamp_nanos":%q,"tree_size":%q,"version":%d}`,
+		base64.StdEncoding.EncodeToString(l.Metadata),
+		strconv.FormatUint(l.Revision, 10),
+		base64.StdEncoding.EncodeToString(l.RootHash),
+		strconv.FormatUint(l.TimestampNanos, 10),
+		strconv.FormatUint(l.TreeSize, 10),
+		trillian.LogRootFormat_LOG_ROOT_FORMAT_V1)
+	return b.Bytes(), nil
+}
+
+// JSONLogRootSignedMessage returns the message which the signature of the
+// canonical JSON log root covers, i.e. JSONLogRootSignatureContext followed by
+// the root.
+func JSONLogRootSignedMessage(root []byte) []byte {
+	msg := make([]byte, 0, len(JSONLogRootSignatureContext)+len(root))
+	msg = append(msg, JSONLogRootSignatureContext...)
+	return append(msg, root...)
+}
+
+// UnmarshalCanonicalJSON parses the canonical JSON encoding of a log root, as
+// returned by MarshalCanonicalJSON. Any other encoding of the same root is
+// rejected, so that a signature over data is a signature over the root.
+func (l *LogRootV1) UnmarshalCanonicalJSON(data []byte) error {
+	if l == nil {
+		return fmt.Errorf("nil log root")
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var j jsonLogRoot
+	if err := dec.Decode(&j); err != nil {
+		return fmt.Errorf("failed to parse JSON log root: %v", err)
+	}
+	if j.Version != int(trillian.LogRootFormat_LOG_ROOT_FORMAT_V1) {
+		return fmt.Errorf("invalid LogRoot version: %v, want %v", j.Version, trillian.LogRootFormat_LOG_ROOT_FORMAT_V1)
+	}
+
+	var root LogRootV1
+	var err error
+	if root.TreeSize, err = strconv.ParseUint(j.TreeSize, 10, 64); err != nil {
+		return fmt.Errorf("invalid tree_size: %v", err)
+	}
+	if root.RootHash, err = base64.StdEncoding.DecodeString(j.RootHash); err != nil {
+		return fmt.Errorf("invalid root_hash: %v", err)
+	}
+	if root.TimestampNanos, err = strconv.ParseUint(j.TimestampNanos, 10, 64); err != nil {
+		return fmt.Errorf("invalid timestamp_nanos: %v", err)
+	}
+	if root.Revision, err = strconv.ParseUint(j.Revision, 10, 64); err != nil {
+		return fmt.Errorf("invalid revision: %v", err)
+	}
+	if root.Metadata, err = base64.StdEncoding.DecodeString(j.Metadata); err != nil {
+		return fmt.Errorf("invalid metadata: %v", err)
+	}
+
+	canonical, err := root.MarshalCanonicalJSON()
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(canonical, data) {
+		return fmt.Errorf("JSON log root not in canonical form")
+	}
+	*l = root
+	return nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestLogRootCanonicalJSON(t *testing.T) {
+	root := &LogRootV1{
+		TreeSize:       5,
+		RootHash:       []byte{0xde, 0xad, 0xbe, 0xef},
+		TimestampNanos: math.MaxUint64,
+		Revision:       2,
+		Metadata:       []byte("meta"),
+	}
+	b, err := root.MarshalCanonicalJSON()
+	if err != nil {
+		t.Fatalf("MarshalCanonicalJSON(): %v", err)
+	}
+	want := `{"metadata":"bWV0YQ==","revision":"2","root_hash":"3q2+7w==","timestamp_nanos":"18446744073709551615","tree_size":"5","version":1}`
+	if got := string(b); got != want {
+		t.Errorf("MarshalCanonicalJSON(): %s, want %s", got, want)
+	}
+	var got LogRootV1
+	if err := got.UnmarshalCanonicalJSON(b); err != nil {
+		t.Fatalf("UnmarshalCanonicalJSON(): %v", err)
+	}
+	if !reflect.DeepEqual(&got, root) {
+		t.Errorf("UnmarshalCanonicalJSON(): %#v, want %#v", got, root)
+	}
+
+	if _, err := (&LogRootV1{RootHash: make([]byte, 129)}).MarshalCanonicalJSON(); err == nil {
+		t.Error("MarshalCanonicalJSON() of long root hash: no error")
+	}
+}
+
+func TestJSONLogRootSignedMessage(t *testing.T) {
+	want := "trillian JSON log root signature v1\x00{}"
+	if got := string(JSONLogRootSignedMessage([]byte("{}"))); got != want {
+		t.Errorf("JSONLogRootSignedMessage(): %q, want %q", got, want)
+	}
+}
+
+func TestUnmarshalLogRootCanonicalJSON(t *testing.T) {
+	for _, tc := range []struct {
+		desc string
+		data string
+	}{
+		{desc: "whitespace", data: `{"metadata":"", "revision":"0","root_hash":"","timestamp_nanos":"0","tree_size":"0","version":1}`},
+		{desc: "order", data: `{"revision":"0","metadata":"","root_hash":"","timestamp_nanos":"0","tree_size":"0","version":1}`},
+		{desc: "leadingZero", data: `{"metadata":"","revision":"0","root_hash":"","timestamp_nanos":"0","tree_size":"05","version":1}`},
+		{desc: "number", data: `{"metadata":"","revision":"0","root_hash":"","timestamp_nanos":"0","tree_size":5,"version":1}`},
+		{desc: "unpadded", data: `{"metadata":"","revision":"0","root_hash":"3q2+7w","timestamp_nanos":"0","tree_size":"0","version":1}`},
+		{desc: "version", data: `{"metadata":"","revision":"0","root_hash":"","timestamp_nanos":"0","tree_size":"0","version":2}`},
+		{desc: "unknownField", data: `{"extra":"","metadata":"","revision":"0","root_hash":"","timestamp_nanos":"0","tree_size":"0","version":1}`},
+		{desc: "trailingData", data: `{"metadata":"","revision":"0","root_hash":"","timestamp_nanos":"0","tree_size":"0","version":1}{}`},
+		{desc: "empty", data: ``},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			var got LogRootV1
+			if err := got.UnmarshalCanonicalJSON([]byte(tc.data)); err == nil {
+				t.Errorf("UnmarshalCanonicalJSON(%s): no error", tc.data)
+			}
+		})
+	}
+
+	var nilPtr *LogRootV1
+	if err := nilPtr.UnmarshalCanonicalJSON([]byte(`{}`)); err == nil {
+		t.Error("nil.UnmarshalCanonicalJSON(): no error")
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/google/trillian/types/internal/tls"
+)
+
+// inclusionPromiseFormatV1 is the version of InclusionPromiseV1.
+const inclusionPromiseFormatV1 = 1
+
+// InclusionPromiseV1 holds the TLS-deserialization of the following structure
+// (described in RFC5246 section 4 notation):
+// struct {
+//   uint64 log_id;
+//   opaque merkle_leaf_hash<0..128>;
+//   uint64 queue_timestamp_nanos;
+//   uint64 max_merge_delay_nanos;
+// } InclusionPromiseV1;
+type InclusionPromiseV1 struct {
+	// LogID is the ID of the log which made the promise.
+	LogID uint64
+	// MerkleLeafHash is the hash of the promised leaf.
+	MerkleLeafHash []byte `tls:"minlen:0,maxlen:128"`
+	// QueueTimestampNanos is the time in nanoseconds when the leaf was
+	// queued, counting from the UNIX epoch.
+	QueueTimestampNanos uint64
+	// MaxMergeDelayNanos is the maximum time in nanoseconds after
+	// QueueTimestampNanos by which the leaf is to be integrated.
+	MaxMergeDelayNanos uint64
+}
+
+// InclusionPromise holds the TLS-deserialization of the following structure
+// (described in RFC5246 section 4 notation):
+// enum { v1(1), (65535)} Version;
+// struct {
+//   Version version;
+//   select(version) {
+//     case v1: InclusionPromiseV1;
+//   }
+// } InclusionPromise;
+type InclusionPromise struct {
+	Version tls.Enum            `tls:"size:2"`
+	V1      *InclusionPromiseV1 `tls:"selector:Version,val:1"`
+}
+
+// Deadline returns the time by which the leaf is to be integrated.
+func (p *InclusionPromiseV1) Deadline() time.Time {
+	return time.Unix(0, int64(p.QueueTimestampNanos+p.MaxMergeDelayNanos))
+}
+
+// UnmarshalBinary verifies that promiseBytes is a TLS serialized
+// InclusionPromise with version 1, and populates the caller with the
+// deserialized *InclusionPromiseV1.
+func (p *InclusionPromiseV1) UnmarshalBinary(promiseBytes []byte) error {
+	if len(promiseBytes) < 3 {
+		return fmt.Errorf("promiseBytes too short")
+	}
+	if p == nil {
+		return fmt.Errorf("nil inclusion promise")
+	}
+	if version := binary.BigEndian.Uint16(promiseBytes); version != inclusionPromiseFormatV1 {
+		return fmt.Errorf("invalid InclusionPromise.Version: %v, want %v", version, inclusionPromiseFormatV1)
+	}
+
+	var promise InclusionPromise
+	rest, err := tls.Unmarshal(promiseBytes, &promise)
+	if err != nil {
+		return err
+	}
+	if len(rest) > 0 {
+		return fmt.Errorf("trailing data after InclusionPromise: %d bytes", len(rest))
+	}
+
+	*p = *promise.V1
+	return nil
+}
+
+// MarshalBinary returns a canonical TLS serialization of InclusionPromise.
+func (p *InclusionPromiseV1) MarshalBinary() ([]byte, error) {
+	return tls.Marshal(InclusionPromise{
+		Version: tls.Enum(inclusionPromiseFormatV1),
+		V1:      p,
+	})
+}
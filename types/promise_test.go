@@ -0,0 +1,61 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestInclusionPromise(t *testing.T) {
+	promise := &InclusionPromiseV1{
+		LogID:               42,
+		MerkleLeafHash:      []byte("leafhash"),
+		QueueTimestampNanos: uint64(time.Second),
+		MaxMergeDelayNanos:  uint64(time.Minute),
+	}
+	b, err := promise.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary(): %v", err)
+	}
+	var got InclusionPromiseV1
+	if err := got.UnmarshalBinary(b); err != nil {
+		t.Fatalf("UnmarshalBinary(): %v", err)
+	}
+	if !reflect.DeepEqual(&got, promise) {
+		t.Errorf("serialize/parse round trip failed. got %#v, want %#v", got, promise)
+	}
+	if got, want := got.Deadline(), time.Unix(61, 0); !got.Equal(want) {
+		t.Errorf("Deadline(): %v, want %v", got, want)
+	}
+
+	for _, tc := range []struct {
+		desc    string
+		promise []byte
+	}{
+		{desc: "version", promise: append([]byte{0, 2}, b[2:]...)},
+		{desc: "junk", promise: append(append([]byte(nil), b...), 5)},
+		{desc: "short", promise: b[:2]},
+		{desc: "nil"},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			var got InclusionPromiseV1
+			if err := got.UnmarshalBinary(tc.promise); err == nil {
+				t.Error("UnmarshalBinary(): no error")
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consul
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// errNotFound is returned for keys which don't exist, and sessions which
+// Consul invalidated, e.g. because they weren't renewed within their TTL.
+var errNotFound = errors.New("not found")
+
+// Client talks to the HTTP API of a Consul agent. It only speaks the few
+// requests which master election needs, so that the log signer doesn't depend
+// on the Consul client libraries.
+type Client struct {
+	addr   string
+	token  string
+	client *http.Client
+}
+
+// NewClient returns a Client of the Consul agent at the given address, e.g.
+// http://127.0.0.1:8500. If token isn't empty, it's sent as the ACL token of
+// the requests, which need write access to the election keys and sessions.
+func NewClient(addr string, client *http.Client, token string) *Client {
+	return &Client{addr: strings.TrimRight(addr, "/"), token: token, client: client}
+}
+
+// kvPair is a key as returned by the KV API.
+type kvPair struct {
+	Key         string
+	Value       []byte
+	Session     string
+	ModifyIndex uint64
+}
+
+// createSession creates a session which is invalidated if it isn't renewed
+// within ttl, which releases the locks it holds.
+func (c *Client) createSession(ctx context.Context, name string, ttl time.Duration) (string, error) {
+	req := struct {
+		Name     string
+		TTL      string
+		Behavior string
+	}{Name: name, TTL: ttl.String(), Behavior: "release"}
+	var resp struct {
+		ID string
+	}
+	if _, err := c.do(ctx, http.MethodPut, "/v1/session/create", nil, &req, &resp); err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+// renewSession resets the TTL of the session. Returns errNotFound if it was
+// invalidated.
+func (c *Client) renewSession(ctx context.Context, id string) error {
+	_, err := c.do(ctx, http.MethodPut, "/v1/session/renew/"+url.PathEscape(id), nil, nil, nil)
+	return err
+}
+
+// destroySession invalidates the session, which releases the locks it holds.
+func (c *Client) destroySession(ctx context.Context, id string) error {
+	_, err := c.do(ctx, http.MethodPut, "/v1/session/destroy/"+url.PathEscape(id), nil, nil, nil)
+	return err
+}
+
+// acquire locks the key with the session, and sets it to value. Returns false
+// if another session holds the lock.
+func (c *Client) acquire(ctx context.Context, key, session string, value []byte) (bool, error) {
+	var ok bool
+	_, err := c.do(ctx, http.MethodPut, kvPath(key), url.Values{"acquire": {session}}, value, &ok)
+	return ok, err
+}
+
+// release unlocks the key if the session holds it.
+func (c *Client) release(ctx context.Context, key, session string) (bool, error) {
+	var ok bool
+	_, err := c.do(ctx, http.MethodPut, kvPath(key), url.Values{"release": {session}}, nil, &ok)
+	return ok, err
+}
+
+// get reads the key, or returns nil if it doesn't exist. If index isn't zero,
+// this is a blocking query, which returns once the key changed since index, or
+// after about wait. Returns the index to block on next.
+func (c *Client) get(ctx context.Context, key string, index uint64, wait time.Duration) (*kvPair, uint64, error) {
+	var params url.Values
+	if index != 0 {
+		params = url.Values{"index": {strconv.FormatUint(index, 10)}, "wait": {wait.String()}}
+	}
+	var pairs []*kvPair
+	next, err := c.do(ctx, http.MethodGet, kvPath(key), params, nil, &pairs)
+	if err == errNotFound {
+		return nil, next, nil
+	} else if err != nil {
+		return nil, 0, err
+	}
+	if len(pairs) == 0 {
+		return nil, next, nil
+	}
+	return pairs[0], next, nil
+}
+
+// kvPath returns the API path of the key, with its segments escaped.
+func kvPath(key string) string {
+	segments := strings.Split(strings.TrimLeft(key, "/"), "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return "/v1/kv/" + strings.Join(segments, "/")
+}
+
+// do sends a request, and decodes its response into out unless it's nil.
+// Values of type []byte are sent as raw bodies, others as JSON. Returns the
+// X-Consul-Index of the response, and errNotFound if it was 404.
+func (c *Client) do(ctx context.Context, method, path string, params url.Values, in, out interface{}) (uint64, error) {
+	var body io.Reader
+	switch in := in.(type) {
+	case nil:
+	case []byte:
+		body = bytes.NewReader(in)
+	default:
+		data, err := json.Marshal(in)
+		if err != nil {
+			return 0, err
+		}
+		body = bytes.NewReader(data)
+	}
+	u := c.addr + path
+	if len(params) > 0 {
+		u += "?" + params.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return 0, err
+	}
+	if c.token != "" {
+		req.Header.Set("X-Consul-Token", c.token)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+	index, _ := strconv.ParseUint(resp.Header.Get("X-Consul-Index"), 10, 64)
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return index, errNotFound
+	case resp.StatusCode != http.StatusOK:
+		return 0, fmt.Errorf("%s %s: %d: %s", method, path, resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	if out != nil {
+		if err := json.Unmarshal(data, out); err != nil {
+			return 0, fmt.Errorf("%s %s: malformed response: %v", method, path, err)
+		}
+	}
+	return index, nil
+}
@@ -0,0 +1,314 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package consul provides an implementation of master election based on
+// Consul sessions and locks.
+package consul
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian/monitoring"
+	"github.com/google/trillian/util/clock"
+	"github.com/google/trillian/util/election2"
+)
+
+const resourceLabel = "resource"
+
+var (
+	once             sync.Once
+	acquisitions     monitoring.Counter
+	resignations     monitoring.Counter
+	mastershipLosses monitoring.Counter
+)
+
+// InitMetrics creates the metrics of the elections. It should be called before
+// NewFactory for the metrics to be exported by mf.
+func InitMetrics(mf monitoring.MetricFactory) {
+	once.Do(func() {
+		if mf == nil {
+			mf = monitoring.InertMetricFactory{}
+		}
+		acquisitions = mf.NewCounter("consul_election_acquisitions", "Number of times this instance captured mastership", resourceLabel)
+		resignations = mf.NewCounter("consul_election_resignations", "Number of times this instance resigned mastership", resourceLabel)
+		mastershipLosses = mf.NewCounter("consul_election_mastership_losses", "Number of times this instance lost mastership because its Consul session was invalidated, or its lock released", resourceLabel)
+	})
+}
+
+// term is a period during which an instance holds mastership.
+type term struct {
+	lost   chan struct{} // Closed when the term ends.
+	cancel func()        // Stops monitoring the lock.
+	done   chan struct{} // Closed when the lock isn't monitored anymore.
+	end    sync.Once
+
+	// lockLost is set before done is closed if the instance lost the lock,
+	// rather than stopped monitoring it.
+	lockLost bool
+}
+
+func (t *term) close() {
+	t.end.Do(func() { close(t.lost) })
+}
+
+func (t *term) ended() bool {
+	select {
+	case <-t.lost:
+		return true
+	default:
+		return false
+	}
+}
+
+// Election is an implementation of election2.Election based on a Consul lock.
+// The instance whose session holds the lock of the resource's key is the
+// master, and the key holds its ID. The session is renewed in the background,
+// and mastership is lost if Consul invalidates it, or the lock is released by
+// anything else than Resign.
+type Election struct {
+	resourceID string
+	instanceID string
+	key        string
+	ttl        time.Duration
+	client     *Client
+
+	session string // The ID of the session, once created.
+	term    *term  // Set while the instance holds mastership.
+}
+
+// retryPeriod returns the time to wait after a failed request before retrying.
+func (e *Election) retryPeriod() time.Duration {
+	return e.ttl / 10
+}
+
+// Await blocks until the instance captures mastership.
+func (e *Election) Await(ctx context.Context) error {
+	if t := e.term; t != nil {
+		if !t.ended() {
+			return nil
+		}
+		<-t.done
+		e.term = nil
+	}
+	var index uint64
+	for {
+		// The session is renewed while waiting for the lock too, so that it
+		// outlives long waits.
+		if e.session != "" {
+			if err := e.client.renewSession(ctx, e.session); err == errNotFound {
+				e.session = ""
+			} else if err != nil {
+				return err
+			}
+		}
+		if e.session == "" {
+			id, err := e.client.createSession(ctx, fmt.Sprintf("%s/%s", e.instanceID, e.resourceID), e.ttl)
+			if err != nil {
+				return fmt.Errorf("failed to create Consul session: %v", err)
+			}
+			e.session = id
+		}
+
+		ok, err := e.client.acquire(ctx, e.key, e.session, []byte(e.instanceID))
+		if err != nil {
+			return err
+		}
+		if ok {
+			mctx, cancel := context.WithCancel(context.Background())
+			e.term = &term{lost: make(chan struct{}), cancel: cancel, done: make(chan struct{})}
+			go e.monitor(mctx, e.term)
+			acquisitions.Inc(e.resourceID)
+			glog.Infof("%s: captured mastership of Consul key %s", e.resourceID, e.key)
+			return nil
+		}
+
+		// Wait for the lock to change hands, or to retry anyway before the
+		// session expires.
+		_, next, err := e.client.get(ctx, e.key, index, e.ttl/2)
+		if err != nil {
+			return err
+		}
+		if next < index {
+			// The index went backwards, e.g. after a Consul restore.
+			next = 0
+		}
+		index = next
+	}
+}
+
+// monitor renews the session of the term, and watches its lock, until it's
+// canceled or mastership is lost.
+func (e *Election) monitor(ctx context.Context, t *term) {
+	defer close(t.done)
+	renewed := time.Now()
+	var index uint64
+	for {
+		kv, next, err := e.client.get(ctx, e.key, index, e.ttl/2)
+		if ctx.Err() != nil {
+			return
+		}
+		switch {
+		case err != nil:
+			glog.Warningf("%s: failed to read Consul key %s: %v", e.resourceID, e.key, err)
+		case kv == nil || kv.Session != e.session:
+			glog.Errorf("%s: Consul lock of %s released", e.resourceID, e.key)
+			e.lose(t)
+			return
+		default:
+			index = next
+		}
+
+		if rerr := e.client.renewSession(ctx, e.session); ctx.Err() != nil {
+			return
+		} else if rerr == errNotFound {
+			glog.Errorf("%s: Consul session invalidated", e.resourceID)
+			e.lose(t)
+			return
+		} else if rerr != nil {
+			if time.Since(renewed) > e.ttl {
+				glog.Errorf("%s: failed to renew Consul session for %v: %v", e.resourceID, time.Since(renewed), rerr)
+				e.lose(t)
+				return
+			}
+			glog.Warningf("%s: failed to renew Consul session: %v", e.resourceID, rerr)
+			err = rerr
+		} else {
+			renewed = time.Now()
+		}
+
+		if err != nil {
+			if clock.SleepContext(ctx, e.retryPeriod()) != nil {
+				return
+			}
+		}
+	}
+}
+
+// lose ends the term, as the instance lost mastership.
+func (e *Election) lose(t *term) {
+	mastershipLosses.Inc(e.resourceID)
+	t.lockLost = true
+	t.close()
+}
+
+// WithMastership returns a "mastership context" which remains active until the
+// instance stops being the master, or the passed in context is canceled.
+func (e *Election) WithMastership(ctx context.Context) (context.Context, error) {
+	cctx, cancel := context.WithCancel(ctx)
+	t := e.term
+	if t == nil || t.ended() {
+		cancel()
+		return cctx, nil
+	}
+	go func() {
+		select {
+		case <-t.lost:
+			glog.Infof("%s: canceled mastership context", e.resourceID)
+		case <-cctx.Done():
+		}
+		cancel()
+	}()
+	return cctx, nil
+}
+
+// Resign releases mastership for this instance. The instance can be elected
+// again using Await. Idempotent, might be useful to retry if fails.
+func (e *Election) Resign(ctx context.Context) error {
+	t := e.term
+	if t == nil {
+		return nil // Resigning if not master is a no-op.
+	}
+	t.cancel()
+	<-t.done
+	t.close()
+	if !t.lockLost {
+		if _, err := e.client.release(ctx, e.key, e.session); err != nil {
+			return err
+		}
+		resignations.Inc(e.resourceID)
+	}
+	e.term = nil
+	return nil
+}
+
+// Close resigns and permanently stops participating in election. No other
+// method should be called after Close.
+func (e *Election) Close(ctx context.Context) error {
+	if err := e.Resign(ctx); err != nil {
+		glog.Errorf("%s: Resign(): %v", e.resourceID, err)
+	}
+	if e.session == "" {
+		return nil
+	}
+	// Destroying the session releases the lock even if the above Resign call
+	// failed.
+	if err := e.client.destroySession(ctx, e.session); err != nil {
+		return err
+	}
+	e.session = ""
+	return nil
+}
+
+var _ election2.MasterReader = (*Factory)(nil)
+
+// Factory creates Election instances.
+type Factory struct {
+	client     *Client
+	instanceID string
+	prefix     string
+	ttl        time.Duration
+}
+
+// NewFactory builds an election factory whose elections lock the Consul key
+// named prefix followed by the resource ID, with sessions of the given TTL.
+// The passed in client should remain valid for the lifetime of the object.
+func NewFactory(instanceID string, client *Client, prefix string, ttl time.Duration) *Factory {
+	InitMetrics(nil)
+	return &Factory{
+		client:     client,
+		instanceID: instanceID,
+		prefix:     prefix,
+		ttl:        ttl,
+	}
+}
+
+// NewElection creates a specific Election instance.
+func (f *Factory) NewElection(ctx context.Context, resourceID string) (election2.Election, error) {
+	el := &Election{
+		resourceID: resourceID,
+		instanceID: f.instanceID,
+		key:        f.prefix + resourceID,
+		ttl:        f.ttl,
+		client:     f.client,
+	}
+	glog.Infof("Election created: %+v", el)
+	return el, nil
+}
+
+// Master returns the ID of the instance which currently holds the lock of the
+// resource, or an empty string if none does.
+func (f *Factory) Master(ctx context.Context, resourceID string) (string, error) {
+	kv, _, err := f.client.get(ctx, f.prefix+resourceID, 0, 0)
+	if err != nil {
+		return "", err
+	}
+	if kv == nil || kv.Session == "" {
+		return "", nil
+	}
+	return string(kv.Value), nil
+}
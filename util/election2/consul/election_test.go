@@ -0,0 +1,310 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consul
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/trillian/monitoring/testonly"
+	"github.com/google/trillian/util/election2"
+	eltestonly "github.com/google/trillian/util/election2/testonly"
+)
+
+const testTTL = time.Second
+
+// fakeAgent serves the requests of Client from memory, like a Consul agent.
+// Sessions only expire when invalidated by the test.
+type fakeAgent struct {
+	mu       sync.Mutex
+	index    uint64
+	sessions map[string]bool
+	kvs      map[string]*kvPair
+	renewals int
+}
+
+// newFakeClient starts a fake Consul agent, and returns a Client of it.
+func newFakeClient(t *testing.T) (*Client, *fakeAgent) {
+	t.Helper()
+	fake := &fakeAgent{sessions: make(map[string]bool), kvs: make(map[string]*kvPair)}
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+	return NewClient(srv.URL, srv.Client(), ""), fake
+}
+
+func (f *fakeAgent) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	q := r.URL.Query()
+	var resp interface{}
+	switch p := r.URL.Path; {
+	case p == "/v1/session/create":
+		f.index++
+		id := fmt.Sprintf("session-%d", f.index)
+		f.sessions[id] = true
+		resp = map[string]string{"ID": id}
+	case strings.HasPrefix(p, "/v1/session/renew/"):
+		if !f.sessions[strings.TrimPrefix(p, "/v1/session/renew/")] {
+			http.Error(w, "session not found", http.StatusNotFound)
+			return
+		}
+		f.renewals++
+		resp = []struct{}{}
+	case strings.HasPrefix(p, "/v1/session/destroy/"):
+		f.invalidate(strings.TrimPrefix(p, "/v1/session/destroy/"))
+		resp = true
+	case strings.HasPrefix(p, "/v1/kv/") && r.Method == http.MethodGet:
+		key := strings.TrimPrefix(p, "/v1/kv/")
+		if index, _ := strconv.ParseUint(q.Get("index"), 10, 64); index != 0 {
+			wait, _ := time.ParseDuration(q.Get("wait"))
+			for deadline := time.Now().Add(wait); f.keyIndex(key) <= index && time.Now().Before(deadline); {
+				f.mu.Unlock()
+				time.Sleep(10 * time.Millisecond)
+				f.mu.Lock()
+			}
+		}
+		w.Header().Set("X-Consul-Index", strconv.FormatUint(f.keyIndex(key), 10))
+		kv, ok := f.kvs[key]
+		if !ok {
+			http.Error(w, "", http.StatusNotFound)
+			return
+		}
+		resp = []*kvPair{kv}
+	case strings.HasPrefix(p, "/v1/kv/") && r.Method == http.MethodPut:
+		key := strings.TrimPrefix(p, "/v1/kv/")
+		kv, ok := f.kvs[key]
+		if !ok {
+			kv = &kvPair{Key: key}
+		}
+		switch {
+		case q.Get("acquire") != "":
+			session := q.Get("acquire")
+			if !f.sessions[session] {
+				http.Error(w, "invalid session", http.StatusInternalServerError)
+				return
+			}
+			if kv.Session != "" && kv.Session != session {
+				resp = false
+				break
+			}
+			value, _ := io.ReadAll(r.Body)
+			kv.Session, kv.Value = session, value
+			f.store(kv)
+			resp = true
+		case q.Get("release") != "":
+			if kv.Session != q.Get("release") {
+				resp = false
+				break
+			}
+			kv.Session = ""
+			f.store(kv)
+			resp = true
+		}
+	default:
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// keyIndex returns the index of the key, or of the agent if it doesn't exist.
+// Must be called with mu held.
+func (f *fakeAgent) keyIndex(key string) uint64 {
+	if kv, ok := f.kvs[key]; ok {
+		return kv.ModifyIndex
+	}
+	return f.index
+}
+
+// store stores kv at a new index. Must be called with mu held.
+func (f *fakeAgent) store(kv *kvPair) {
+	f.index++
+	kv.ModifyIndex = f.index
+	f.kvs[kv.Key] = kv
+}
+
+// invalidate invalidates the session, and releases its locks. Must be called
+// with mu held.
+func (f *fakeAgent) invalidate(session string) {
+	delete(f.sessions, session)
+	for _, kv := range f.kvs {
+		if kv.Session == session {
+			kv.Session = ""
+			f.store(kv)
+		}
+	}
+}
+
+func TestElection(t *testing.T) {
+	client, _ := newFakeClient(t)
+	for _, nt := range eltestonly.Tests {
+		// Create a new Factory for each test for better isolation.
+		fact := NewFactory("testID", client, nt.Name+"/resources/", testTTL)
+		t.Run(nt.Name, func(t *testing.T) {
+			nt.Run(t, fact)
+		})
+	}
+}
+
+func TestMaster(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newFakeClient(t)
+	factA := NewFactory("a", client, "res/", testTTL)
+	factB := NewFactory("b", client, "res/", testTTL)
+	elA, err := factA.NewElection(ctx, "10")
+	if err != nil {
+		t.Fatalf("NewElection(a): %v", err)
+	}
+	elB, err := factB.NewElection(ctx, "10")
+	if err != nil {
+		t.Fatalf("NewElection(b): %v", err)
+	}
+	wantMaster := func(want string) {
+		t.Helper()
+		for _, fact := range []*Factory{factA, factB} {
+			if got, err := fact.Master(ctx, "10"); err != nil || got != want {
+				t.Errorf("Master(10)=%q, %v; want %q, nil", got, err, want)
+			}
+		}
+	}
+
+	wantMaster("")
+	acquired := testonly.NewCounterSnapshot(acquisitions, "10")
+	if err := elA.Await(ctx); err != nil {
+		t.Fatalf("Await(a): %v", err)
+	}
+	wantMaster("a")
+	if got := acquired.Delta(); got != 1 {
+		t.Errorf("acquisitions delta=%v, want 1", got)
+	}
+
+	// b blocks while a holds the lock, and captures it once a resigns.
+	awaited := make(chan error)
+	go func() { awaited <- elB.Await(ctx) }()
+	select {
+	case err := <-awaited:
+		t.Fatalf("Await(b) returned %v while a is the master", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	resigned := testonly.NewCounterSnapshot(resignations, "10")
+	if err := elA.Resign(ctx); err != nil {
+		t.Fatalf("Resign(a): %v", err)
+	}
+	if got := resigned.Delta(); got != 1 {
+		t.Errorf("resignations delta=%v, want 1", got)
+	}
+	if err := <-awaited; err != nil {
+		t.Fatalf("Await(b): %v", err)
+	}
+	wantMaster("b")
+	for _, el := range []election2.Election{elA, elB} {
+		if err := el.Close(ctx); err != nil {
+			t.Errorf("Close(): %v", err)
+		}
+	}
+	wantMaster("")
+}
+
+func TestSessionInvalidated(t *testing.T) {
+	ctx := context.Background()
+	client, fake := newFakeClient(t)
+	fact := NewFactory("a", client, "res/", testTTL)
+	el, err := fact.NewElection(ctx, "10")
+	if err != nil {
+		t.Fatalf("NewElection(): %v", err)
+	}
+	if err := el.Await(ctx); err != nil {
+		t.Fatalf("Await(): %v", err)
+	}
+	mctx, err := el.WithMastership(ctx)
+	if err != nil {
+		t.Fatalf("WithMastership(): %v", err)
+	}
+
+	lost := testonly.NewCounterSnapshot(mastershipLosses, "10")
+	fake.mu.Lock()
+	fake.invalidate(el.(*Election).session)
+	fake.mu.Unlock()
+	select {
+	case <-mctx.Done():
+	case <-time.After(testTTL):
+		t.Fatal("mastership context not canceled after session invalidation")
+	}
+	if got := lost.Delta(); got != 1 {
+		t.Errorf("mastership losses delta=%v, want 1", got)
+	}
+
+	// A new session is created to capture mastership again.
+	if err := el.Await(ctx); err != nil {
+		t.Fatalf("Await() after invalidation: %v", err)
+	}
+	if got, err := fact.Master(ctx, "10"); err != nil || got != "a" {
+		t.Errorf("Master(10)=%q, %v; want a, nil", got, err)
+	}
+	if err := el.Close(ctx); err != nil {
+		t.Errorf("Close(): %v", err)
+	}
+}
+
+func TestSessionRenewed(t *testing.T) {
+	ctx := context.Background()
+	client, fake := newFakeClient(t)
+	fact := NewFactory("a", client, "res/", 200*time.Millisecond)
+	el, err := fact.NewElection(ctx, "10")
+	if err != nil {
+		t.Fatalf("NewElection(): %v", err)
+	}
+	if err := el.Await(ctx); err != nil {
+		t.Fatalf("Await(): %v", err)
+	}
+	time.Sleep(time.Second)
+	fake.mu.Lock()
+	renewals := fake.renewals
+	fake.mu.Unlock()
+	// The lock is watched for half of the TTL between renewals.
+	if renewals < 4 {
+		t.Errorf("session renewed %d times in 1s, want at least 4", renewals)
+	}
+	if err := el.Close(ctx); err != nil {
+		t.Errorf("Close(): %v", err)
+	}
+}
+
+func TestKVPath(t *testing.T) {
+	for _, tc := range []struct {
+		key  string
+		want string
+	}{
+		{key: "trillian/10", want: "/v1/kv/trillian/10"},
+		{key: "/trillian/10", want: "/v1/kv/trillian/10"},
+		{key: "a b/c?d#e", want: "/v1/kv/a%20b/c%3Fd%23e"},
+	} {
+		if got := kvPath(tc.key); got != tc.want {
+			t.Errorf("kvPath(%q)=%q, want %q", tc.key, got, tc.want)
+		}
+	}
+}
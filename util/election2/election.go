@@ -85,3 +85,12 @@ type Election interface {
 type Factory interface {
 	NewElection(ctx context.Context, resourceID string) (Election, error)
 }
+
+// MasterReader is implemented by Factory implementations which can look up the
+// current master of a resource, e.g. for diagnostics.
+type MasterReader interface {
+	// Master returns the ID of the instance which currently holds mastership
+	// of the resource with the specified ID, or an empty string if no instance
+	// holds it.
+	Master(ctx context.Context, resourceID string) (string, error)
+}
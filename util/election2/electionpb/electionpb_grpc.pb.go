@@ -0,0 +1,105 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.20.1
+// source: electionpb.proto
+
+package electionpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// MastersClient is the client API for Masters service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type MastersClient interface {
+	// Lists the current masters of trees.
+	ListMasters(ctx context.Context, in *ListMastersRequest, opts ...grpc.CallOption) (*ListMastersResponse, error)
+}
+
+type mastersClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMastersClient(cc grpc.ClientConnInterface) MastersClient {
+	return &mastersClient{cc}
+}
+
+func (c *mastersClient) ListMasters(ctx context.Context, in *ListMastersRequest, opts ...grpc.CallOption) (*ListMastersResponse, error) {
+	out := new(ListMastersResponse)
+	err := c.cc.Invoke(ctx, "/electionpb.Masters/ListMasters", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MastersServer is the server API for Masters service.
+// All implementations should embed UnimplementedMastersServer
+// for forward compatibility
+type MastersServer interface {
+	// Lists the current masters of trees.
+	ListMasters(context.Context, *ListMastersRequest) (*ListMastersResponse, error)
+}
+
+// UnimplementedMastersServer should be embedded to have forward compatible implementations.
+type UnimplementedMastersServer struct {
+}
+
+func (UnimplementedMastersServer) ListMasters(context.Context, *ListMastersRequest) (*ListMastersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMasters not implemented")
+}
+
+// UnsafeMastersServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MastersServer will
+// result in compilation errors.
+type UnsafeMastersServer interface {
+	mustEmbedUnimplementedMastersServer()
+}
+
+func RegisterMastersServer(s grpc.ServiceRegistrar, srv MastersServer) {
+	s.RegisterService(&Masters_ServiceDesc, srv)
+}
+
+func _Masters_ListMasters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMastersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MastersServer).ListMasters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/electionpb.Masters/ListMasters",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MastersServer).ListMasters(ctx, req.(*ListMastersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Masters_ServiceDesc is the grpc.ServiceDesc for Masters service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Masters_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "electionpb.Masters",
+	HandlerType: (*MastersServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListMasters",
+			Handler:    _Masters_ListMasters_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "electionpb.proto",
+}
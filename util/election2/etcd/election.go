@@ -19,14 +19,46 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
+	"sync/atomic"
 
 	"github.com/golang/glog"
+	"github.com/google/trillian/monitoring"
 	"github.com/google/trillian/util/election2"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.etcd.io/etcd/client/v3/concurrency"
 )
 
-const resignID = "<resign>"
+const (
+	resignID = "<resign>"
+
+	resourceLabel = "resource"
+)
+
+var (
+	once          sync.Once
+	acquisitions  monitoring.Counter
+	resignations  monitoring.Counter
+	leaseExpiries monitoring.Counter
+	splitBrains   monitoring.Counter
+)
+
+// InitMetrics creates the metrics of the elections. It should be called before
+// NewFactory for the metrics to be exported by mf.
+func InitMetrics(mf monitoring.MetricFactory) {
+	once.Do(func() {
+		if mf == nil {
+			mf = monitoring.InertMetricFactory{}
+		}
+		acquisitions = mf.NewCounter("election_acquisitions", "Number of times this instance captured mastership", resourceLabel)
+		resignations = mf.NewCounter("election_resignations", "Number of times this instance resigned mastership", resourceLabel)
+		leaseExpiries = mf.NewCounter("election_lease_expiries", "Number of times the etcd lease of an election expired before it was closed", resourceLabel)
+		// splitBrains counts the times this instance observed another instance
+		// capture mastership while it still held it, so that both acted as
+		// the master until this instance noticed.
+		splitBrains = mf.NewCounter("election_split_brains", "Number of times another master was observed while this instance held mastership", resourceLabel)
+	})
+}
 
 // Election is an implementation of election2.Election based on etcd.
 type Election struct {
@@ -37,11 +69,28 @@ type Election struct {
 	client   *clientv3.Client
 	session  *concurrency.Session
 	election *concurrency.Election
+
+	closed int32 // Set to 1 by Close, accessed atomically.
 }
 
 // Await blocks until the instance captures mastership.
 func (e *Election) Await(ctx context.Context) error {
-	return e.election.Campaign(ctx, e.instanceID)
+	if err := e.election.Campaign(ctx, e.instanceID); err != nil {
+		return err
+	}
+	acquisitions.Inc(e.resourceID)
+	return nil
+}
+
+// watchLease records the expiry of the session's lease, which makes the
+// instance lose mastership, and fail to capture it until a new Election is
+// created.
+func (e *Election) watchLease() {
+	<-e.session.Done()
+	if atomic.LoadInt32(&e.closed) == 0 {
+		leaseExpiries.Inc(e.resourceID)
+		glog.Warningf("%s: etcd session lease expired", e.resourceID)
+	}
 }
 
 // WithMastership returns a "mastership context" which remains active until the
@@ -88,7 +137,8 @@ func (e *Election) WithMastership(ctx context.Context) (context.Context, error)
 				conquerorID := string(kv.Value)
 				// TODO(pavelkalinnikov): conquerorID can be resignID too. Serialize a
 				// protobuf with all mastership details instead of ID string.
-				glog.Warningf("%s: mastership overtaken by %s", e.resourceID, conquerorID)
+				splitBrains.Inc(e.resourceID)
+				glog.Errorf("%s: mastership overtaken by %s while held, two masters observed", e.resourceID, conquerorID)
 				break
 			} else if string(kv.Value) == resignID {
 				glog.Infof("%s: canceling context due to resignation", e.resourceID)
@@ -110,7 +160,11 @@ func (e *Election) Resign(ctx context.Context) error {
 	} else if err != nil {
 		return err
 	}
-	return e.election.Resign(ctx)
+	if err := e.election.Resign(ctx); err != nil {
+		return err
+	}
+	resignations.Inc(e.resourceID)
+	return nil
 }
 
 // Close resigns and permanently stops participating in election. No other
@@ -122,9 +176,12 @@ func (e *Election) Close(ctx context.Context) error {
 	// Session's Close revokes the underlying lease, which results in removing
 	// the election-related keys. This achieves the effect of resignation even if
 	// the above Resign call failed (e.g. due to ctx cancelation).
+	atomic.StoreInt32(&e.closed, 1)
 	return e.session.Close()
 }
 
+var _ election2.MasterReader = (*Factory)(nil)
+
 // Factory creates Election instances.
 type Factory struct {
 	client     *clientv3.Client
@@ -135,6 +192,7 @@ type Factory struct {
 // NewFactory builds an election factory that uses the given parameters. The
 // passed in etcd client should remain valid for the lifetime of the object.
 func NewFactory(instanceID string, client *clientv3.Client, lockDir string) *Factory {
+	InitMetrics(nil)
 	return &Factory{
 		client:     client,
 		instanceID: instanceID,
@@ -150,10 +208,10 @@ func (f *Factory) NewElection(ctx context.Context, resourceID string) (election2
 	if err != nil {
 		return nil, fmt.Errorf("failed to create etcd session: %v", err)
 	}
-	lockFile := fmt.Sprintf("%s/%s", strings.TrimRight(f.lockDir, "/"), resourceID)
+	lockFile := f.lockFile(resourceID)
 	election := concurrency.NewElection(session, lockFile)
 
-	el := Election{
+	el := &Election{
 		resourceID: resourceID,
 		instanceID: f.instanceID,
 		lockFile:   lockFile,
@@ -162,6 +220,30 @@ func (f *Factory) NewElection(ctx context.Context, resourceID string) (election2
 		election:   election,
 	}
 	glog.Infof("Election created: %+v", el)
+	go el.watchLease()
+
+	return el, nil
+}
+
+// Master returns the ID of the instance which currently holds mastership of
+// the resource, as recorded in etcd, or an empty string if none does.
+func (f *Factory) Master(ctx context.Context, resourceID string) (string, error) {
+	// Candidates are keyed under the lock file, and the oldest one is the
+	// master. See concurrency.Election.Leader.
+	resp, err := f.client.Get(ctx, f.lockFile(resourceID)+"/", clientv3.WithFirstCreate()...)
+	if err != nil {
+		return "", err
+	}
+	if len(resp.Kvs) == 0 {
+		return "", nil
+	}
+	if id := string(resp.Kvs[0].Value); id != resignID {
+		return id, nil
+	}
+	return "", nil
+}
 
-	return &el, nil
+// lockFile returns the etcd key prefix of the election of the given resource.
+func (f *Factory) lockFile(resourceID string) string {
+	return fmt.Sprintf("%s/%s", strings.TrimRight(f.lockDir, "/"), resourceID)
 }
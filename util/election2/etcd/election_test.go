@@ -19,8 +19,10 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/google/trillian/monitoring/testonly"
 	"github.com/google/trillian/testonly/integration/etcd"
-	"github.com/google/trillian/util/election2/testonly"
+	"github.com/google/trillian/util/election2"
+	eltestonly "github.com/google/trillian/util/election2/testonly"
 )
 
 func TestElectionThroughCommonClient(t *testing.T) {
@@ -64,7 +66,7 @@ func TestElection(t *testing.T) {
 	}
 	defer cleanup()
 
-	for _, nt := range testonly.Tests {
+	for _, nt := range eltestonly.Tests {
 		// Create a new Factory for each test for better isolation.
 		fact := NewFactory("testID", client, fmt.Sprintf("%s/resources/", nt.Name))
 		t.Run(nt.Name, func(t *testing.T) {
@@ -72,3 +74,102 @@ func TestElection(t *testing.T) {
 		})
 	}
 }
+
+func TestMaster(t *testing.T) {
+	_, client, cleanup, err := etcd.StartEtcd()
+	if err != nil {
+		t.Fatalf("StartEtcd(): %v", err)
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	factA := NewFactory("a", client, "res/")
+	factB := NewFactory("b", client, "res/")
+	elA, err := factA.NewElection(ctx, "10")
+	if err != nil {
+		t.Fatalf("NewElection(a): %v", err)
+	}
+	elB, err := factB.NewElection(ctx, "10")
+	if err != nil {
+		t.Fatalf("NewElection(b): %v", err)
+	}
+	wantMaster := func(want string) {
+		t.Helper()
+		for _, fact := range []*Factory{factA, factB} {
+			if got, err := fact.Master(ctx, "10"); err != nil || got != want {
+				t.Errorf("Master(10)=%q, %v; want %q, nil", got, err, want)
+			}
+		}
+	}
+
+	wantMaster("")
+	acquired := testonly.NewCounterSnapshot(acquisitions, "10")
+	if err := elA.Await(ctx); err != nil {
+		t.Fatalf("Await(a): %v", err)
+	}
+	wantMaster("a")
+	if got := acquired.Delta(); got != 1 {
+		t.Errorf("acquisitions delta=%v, want 1", got)
+	}
+
+	resigned := testonly.NewCounterSnapshot(resignations, "10")
+	if err := elA.Resign(ctx); err != nil {
+		t.Fatalf("Resign(a): %v", err)
+	}
+	wantMaster("")
+	if got := resigned.Delta(); got != 1 {
+		t.Errorf("resignations delta=%v, want 1", got)
+	}
+
+	if err := elB.Await(ctx); err != nil {
+		t.Fatalf("Await(b): %v", err)
+	}
+	wantMaster("b")
+	for _, el := range []election2.Election{elA, elB} {
+		if err := el.Close(ctx); err != nil {
+			t.Errorf("Close(): %v", err)
+		}
+	}
+}
+
+func TestSplitBrain(t *testing.T) {
+	_, client, cleanup, err := etcd.StartEtcd()
+	if err != nil {
+		t.Fatalf("StartEtcd(): %v", err)
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	elA, err := NewFactory("a", client, "res/").NewElection(ctx, "10")
+	if err != nil {
+		t.Fatalf("NewElection(a): %v", err)
+	}
+	elB, err := NewFactory("b", client, "res/").NewElection(ctx, "10")
+	if err != nil {
+		t.Fatalf("NewElection(b): %v", err)
+	}
+	if err := elA.Await(ctx); err != nil {
+		t.Fatalf("Await(a): %v", err)
+	}
+	mctx, err := elA.WithMastership(ctx)
+	if err != nil {
+		t.Fatalf("WithMastership(a): %v", err)
+	}
+
+	// Revoke the lease of a behind its back, so that b captures mastership
+	// while a still holds its mastership context.
+	splits := testonly.NewCounterSnapshot(splitBrains, "10")
+	if _, err := client.Revoke(ctx, elA.(*Election).session.Lease()); err != nil {
+		t.Fatalf("Revoke(): %v", err)
+	}
+	if err := elB.Await(ctx); err != nil {
+		t.Fatalf("Await(b): %v", err)
+	}
+	<-mctx.Done()
+	if got := splits.Delta(); got != 1 {
+		t.Errorf("split brains delta=%v, want 1", got)
+	}
+	if err := elB.Close(ctx); err != nil {
+		t.Errorf("Close(b): %v", err)
+	}
+}
@@ -0,0 +1,234 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+// The files which Kubernetes mounts into every pod for its service account.
+const (
+	serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+	tokenFile         = serviceAccountDir + "/token"
+	caFile            = serviceAccountDir + "/ca.crt"
+	namespaceFile     = serviceAccountDir + "/namespace"
+)
+
+// rfc3339Micro is the format of the MicroTime fields of Lease objects.
+const rfc3339Micro = "2006-01-02T15:04:05.000000Z07:00"
+
+var (
+	errNotFound = errors.New("lease not found")
+	errConflict = errors.New("lease modified concurrently")
+)
+
+// Client reads and writes coordination.k8s.io/v1 Lease objects through the
+// Kubernetes API server. It only speaks the few requests which master election
+// needs, so that the log signer doesn't depend on the Kubernetes client
+// libraries.
+type Client struct {
+	server    string
+	tokenFile string
+	client    *http.Client
+}
+
+// NewClient returns a Client of the API server at the given URL. If tokenFile
+// isn't empty, requests carry the bearer token read from it, which is re-read
+// for every request as Kubernetes rotates service account tokens.
+func NewClient(server string, client *http.Client, tokenFile string) *Client {
+	return &Client{server: strings.TrimRight(server, "/"), tokenFile: tokenFile, client: client}
+}
+
+// NewInClusterClient returns a Client of the API server of the cluster which
+// the process runs in, authenticated as the service account of its pod. The
+// service account must be allowed to get, create and update Leases.
+func NewInClusterClient() (*Client, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return nil, errors.New("not running in a Kubernetes cluster: KUBERNETES_SERVICE_HOST and KUBERNETES_SERVICE_PORT must be set")
+	}
+	ca, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cluster CA: %v", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("no certificates in %s", caFile)
+	}
+	client := &http.Client{
+		Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}},
+		Timeout:   30 * time.Second,
+	}
+	return NewClient("https://"+net.JoinHostPort(host, port), client, tokenFile), nil
+}
+
+// InClusterNamespace returns the namespace of the pod which the process runs
+// in.
+func InClusterNamespace() (string, error) {
+	ns, err := os.ReadFile(namespaceFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read pod namespace: %v", err)
+	}
+	return strings.TrimSpace(string(ns)), nil
+}
+
+// lease is the subset of a coordination.k8s.io/v1 Lease used for election.
+type lease struct {
+	APIVersion string     `json:"apiVersion"`
+	Kind       string     `json:"kind"`
+	Metadata   objectMeta `json:"metadata"`
+	Spec       leaseSpec  `json:"spec"`
+}
+
+type objectMeta struct {
+	Name            string `json:"name"`
+	Namespace       string `json:"namespace,omitempty"`
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+}
+
+type leaseSpec struct {
+	HolderIdentity       string     `json:"holderIdentity,omitempty"`
+	LeaseDurationSeconds int32      `json:"leaseDurationSeconds,omitempty"`
+	AcquireTime          *microTime `json:"acquireTime,omitempty"`
+	RenewTime            *microTime `json:"renewTime,omitempty"`
+	LeaseTransitions     int32      `json:"leaseTransitions,omitempty"`
+}
+
+// expired returns whether the lease was last renewed longer than its duration
+// before now, according to the clock of its holder.
+func (l *lease) expired(now time.Time) bool {
+	if l.Spec.RenewTime == nil {
+		return true
+	}
+	d := time.Duration(l.Spec.LeaseDurationSeconds) * time.Second
+	return now.After(l.Spec.RenewTime.Time.Add(d))
+}
+
+// microTime is a time serialized like a Kubernetes MicroTime.
+type microTime struct {
+	time.Time
+}
+
+func newMicroTime(t time.Time) *microTime {
+	return &microTime{t}
+}
+
+func (t microTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.UTC().Format(rfc3339Micro))
+}
+
+func (t *microTime) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	parsed, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return err
+	}
+	t.Time = parsed
+	return nil
+}
+
+// leasePath returns the API path of the Leases in the namespace, or of the
+// named one.
+func leasePath(namespace string, name ...string) string {
+	return path.Join(append([]string{"/apis/coordination.k8s.io/v1/namespaces", namespace, "leases"}, name...)...)
+}
+
+func (c *Client) getLease(ctx context.Context, namespace, name string) (*lease, error) {
+	return c.do(ctx, http.MethodGet, leasePath(namespace, name), nil)
+}
+
+// createLease creates the lease, and returns errConflict if it exists already.
+func (c *Client) createLease(ctx context.Context, l *lease) (*lease, error) {
+	return c.do(ctx, http.MethodPost, leasePath(l.Metadata.Namespace), l)
+}
+
+// updateLease replaces the lease, and returns errConflict if it was modified
+// since the resource version it was read at.
+func (c *Client) updateLease(ctx context.Context, l *lease) (*lease, error) {
+	return c.do(ctx, http.MethodPut, leasePath(l.Metadata.Namespace, l.Metadata.Name), l)
+}
+
+func (c *Client) do(ctx context.Context, method, path string, l *lease) (*lease, error) {
+	var body io.Reader
+	if l != nil {
+		l.APIVersion, l.Kind = "coordination.k8s.io/v1", "Lease"
+		data, err := json.Marshal(l)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.server+path, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.tokenFile != "" {
+		token, err := os.ReadFile(c.tokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read service account token: %v", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated:
+	case http.StatusNotFound:
+		return nil, errNotFound
+	case http.StatusConflict:
+		return nil, errConflict
+	default:
+		// Errors are returned as a Status object with a message.
+		var status struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(data, &status) != nil || status.Message == "" {
+			status.Message = http.StatusText(resp.StatusCode)
+		}
+		return nil, fmt.Errorf("%s %s: %d: %s", method, path, resp.StatusCode, status.Message)
+	}
+	var ret lease
+	if err := json.Unmarshal(data, &ret); err != nil {
+		return nil, fmt.Errorf("%s %s: malformed Lease: %v", method, path, err)
+	}
+	return &ret, nil
+}
@@ -0,0 +1,387 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package k8s provides an implementation of master election based on
+// Kubernetes Leases, for instances which run in a Kubernetes cluster without
+// an etcd cluster of their own.
+package k8s
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian/monitoring"
+	"github.com/google/trillian/util/clock"
+	"github.com/google/trillian/util/election2"
+)
+
+const resourceLabel = "resource"
+
+var (
+	once         sync.Once
+	acquisitions monitoring.Counter
+	resignations monitoring.Counter
+	leaseLosses  monitoring.Counter
+
+	// validName matches the names of Kubernetes objects, i.e. DNS subdomains.
+	validName = regexp.MustCompile(`^[a-z0-9]([-a-z0-9.]{0,251}[a-z0-9])?$`)
+)
+
+// InitMetrics creates the metrics of the elections. It should be called before
+// NewFactory for the metrics to be exported by mf.
+func InitMetrics(mf monitoring.MetricFactory) {
+	once.Do(func() {
+		if mf == nil {
+			mf = monitoring.InertMetricFactory{}
+		}
+		acquisitions = mf.NewCounter("k8s_election_acquisitions", "Number of times this instance captured mastership", resourceLabel)
+		resignations = mf.NewCounter("k8s_election_resignations", "Number of times this instance resigned mastership", resourceLabel)
+		leaseLosses = mf.NewCounter("k8s_election_lease_losses", "Number of times this instance lost mastership because it failed to renew its Lease, or another instance took it over", resourceLabel)
+	})
+}
+
+// term is a period during which an instance holds mastership.
+type term struct {
+	lost chan struct{} // Closed when the term ends.
+	stop chan struct{} // Closed to stop renewing the lease.
+	done chan struct{} // Closed when the lease isn't renewed anymore.
+	end  sync.Once
+
+	// lease is the last version of the lease written by the instance, and
+	// leaseLost whether the instance lost it. Both are owned by the renewing
+	// goroutine until done is closed.
+	lease     *lease
+	leaseLost bool
+}
+
+func (t *term) close() {
+	t.end.Do(func() { close(t.lost) })
+}
+
+func (t *term) ended() bool {
+	select {
+	case <-t.lost:
+		return true
+	default:
+		return false
+	}
+}
+
+// Election is an implementation of election2.Election based on a Kubernetes
+// Lease. The instance which holds the Lease is the master. It renews the Lease
+// in the background, and loses mastership if it fails to do so for longer than
+// two thirds of the Lease duration, before other instances may take it over.
+//
+// Like the Kubernetes client libraries, instances consider a Lease held by
+// another instance expired only once they have observed it unchanged for its
+// duration, so that the election doesn't depend on synchronized clocks.
+type Election struct {
+	resourceID string
+	instanceID string
+	namespace  string
+	name       string
+	duration   time.Duration
+	client     *Client
+
+	// The version of the lease last observed while campaigning, and when it
+	// was first observed.
+	observedVersion string
+	observedAt      time.Time
+
+	term *term // Set while the instance holds mastership.
+}
+
+// retryPeriod returns the time between attempts to capture or renew the lease.
+func (e *Election) retryPeriod() time.Duration {
+	return e.duration / 5
+}
+
+// renewDeadline returns the time after the last renewal of the lease at which
+// the instance gives up mastership.
+func (e *Election) renewDeadline() time.Duration {
+	return e.duration * 2 / 3
+}
+
+// Await blocks until the instance captures mastership.
+func (e *Election) Await(ctx context.Context) error {
+	if t := e.term; t != nil {
+		if !t.ended() {
+			return nil
+		}
+		<-t.done
+		e.term = nil
+	}
+	for {
+		l, err := e.tryAcquire(ctx)
+		if err != nil {
+			return err
+		}
+		if l != nil {
+			e.term = &term{lost: make(chan struct{}), stop: make(chan struct{}), done: make(chan struct{}), lease: l}
+			go e.renew(e.term)
+			acquisitions.Inc(e.resourceID)
+			glog.Infof("%s: captured mastership of Lease %s/%s", e.resourceID, e.namespace, e.name)
+			return nil
+		}
+		if err := clock.SleepContext(ctx, e.retryPeriod()); err != nil {
+			return err
+		}
+	}
+}
+
+// tryAcquire takes the lease if it's free, and returns it. Returns nil if
+// another instance holds it.
+func (e *Election) tryAcquire(ctx context.Context) (*lease, error) {
+	now := time.Now()
+	l, err := e.client.getLease(ctx, e.namespace, e.name)
+	if err == errNotFound {
+		l = &lease{Metadata: objectMeta{Name: e.name, Namespace: e.namespace}}
+		e.hold(l, now)
+		if l, err = e.client.createLease(ctx, l); err == errConflict {
+			return nil, nil
+		}
+		return l, err
+	} else if err != nil {
+		return nil, err
+	}
+
+	if l.Metadata.ResourceVersion != e.observedVersion {
+		e.observedVersion, e.observedAt = l.Metadata.ResourceVersion, now
+	}
+	holder := l.Spec.HolderIdentity
+	held := time.Duration(l.Spec.LeaseDurationSeconds) * time.Second
+	if holder != "" && holder != e.instanceID && now.Sub(e.observedAt) <= held {
+		return nil, nil
+	}
+	e.hold(l, now)
+	if l, err = e.client.updateLease(ctx, l); err == errConflict {
+		return nil, nil
+	}
+	return l, err
+}
+
+// hold updates l to be held by the instance since now.
+func (e *Election) hold(l *lease, now time.Time) {
+	if l.Spec.HolderIdentity != e.instanceID {
+		if l.Spec.AcquireTime != nil {
+			l.Spec.LeaseTransitions++
+		}
+		l.Spec.HolderIdentity = e.instanceID
+		l.Spec.AcquireTime = newMicroTime(now)
+	}
+	l.Spec.LeaseDurationSeconds = int32((e.duration + time.Second - 1) / time.Second)
+	l.Spec.RenewTime = newMicroTime(now)
+}
+
+// renew renews the lease of the term until it's stopped, or mastership is
+// lost.
+func (e *Election) renew(t *term) {
+	defer close(t.done)
+	renewed := time.Now()
+	ticker := time.NewTicker(e.retryPeriod())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-t.stop:
+			return
+		case <-ticker.C:
+		}
+		now := time.Now()
+		l, err := e.renewOnce(t.lease, now)
+		switch {
+		case err == nil:
+			t.lease, renewed = l, now
+			continue
+		case l != nil:
+			glog.Errorf("%s: mastership taken over by %s", e.resourceID, l.Spec.HolderIdentity)
+		case now.Sub(renewed) > e.renewDeadline():
+			glog.Errorf("%s: failed to renew Lease for %v: %v", e.resourceID, now.Sub(renewed), err)
+		default:
+			glog.Warningf("%s: failed to renew Lease: %v", e.resourceID, err)
+			continue
+		}
+		leaseLosses.Inc(e.resourceID)
+		t.leaseLost = true
+		t.close()
+		return
+	}
+}
+
+// renewOnce writes the lease with a new renewal time. If another instance took
+// the lease over, returns it along with the error.
+func (e *Election) renewOnce(l *lease, now time.Time) (*lease, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), e.retryPeriod())
+	defer cancel()
+	renewed := *l
+	e.hold(&renewed, now)
+	ret, err := e.client.updateLease(ctx, &renewed)
+	if err != errConflict {
+		return ret, err
+	}
+	// The lease changed since it was last written, re-read it to find out
+	// whether it's still held.
+	if ret, err = e.client.getLease(ctx, e.namespace, e.name); err != nil {
+		return nil, err
+	}
+	if ret.Spec.HolderIdentity != e.instanceID {
+		return ret, fmt.Errorf("lease held by %q", ret.Spec.HolderIdentity)
+	}
+	e.hold(ret, now)
+	return e.client.updateLease(ctx, ret)
+}
+
+// WithMastership returns a "mastership context" which remains active until the
+// instance stops being the master, or the passed in context is canceled.
+func (e *Election) WithMastership(ctx context.Context) (context.Context, error) {
+	cctx, cancel := context.WithCancel(ctx)
+	t := e.term
+	if t == nil || t.ended() {
+		cancel()
+		return cctx, nil
+	}
+	go func() {
+		select {
+		case <-t.lost:
+			glog.Infof("%s: canceled mastership context", e.resourceID)
+		case <-cctx.Done():
+		}
+		cancel()
+	}()
+	return cctx, nil
+}
+
+// Resign releases mastership for this instance. The instance can be elected
+// again using Await. Idempotent, might be useful to retry if fails.
+func (e *Election) Resign(ctx context.Context) error {
+	t := e.term
+	if t == nil {
+		return nil // Resigning if not master is a no-op.
+	}
+	select {
+	case <-t.stop:
+	default:
+		close(t.stop)
+	}
+	<-t.done
+	t.close()
+
+	if !t.leaseLost {
+		// Free the lease, so that other instances needn't wait for it to
+		// expire. Another instance may have taken it over meanwhile, in which
+		// case the update conflicts.
+		l := *t.lease
+		l.Spec.HolderIdentity, l.Spec.LeaseDurationSeconds = "", 1
+		if _, err := e.client.updateLease(ctx, &l); err != nil && err != errConflict {
+			return err
+		}
+		resignations.Inc(e.resourceID)
+	}
+	e.term = nil
+	return nil
+}
+
+// Close resigns and permanently stops participating in election. No other
+// method should be called after Close.
+func (e *Election) Close(ctx context.Context) error {
+	if err := e.Resign(ctx); err != nil {
+		// The lease expires without renewals anyway.
+		glog.Errorf("%s: Resign(): %v", e.resourceID, err)
+	}
+	return nil
+}
+
+var _ election2.MasterReader = (*Factory)(nil)
+
+// Factory creates Election instances.
+type Factory struct {
+	client     *Client
+	instanceID string
+	namespace  string
+	prefix     string
+	duration   time.Duration
+}
+
+// NewFactory builds an election factory whose elections hold the Lease named
+// prefix followed by the resource ID, in the given namespace, for the given
+// duration. The passed in client should remain valid for the lifetime of the
+// object.
+func NewFactory(instanceID string, client *Client, namespace, prefix string, duration time.Duration) *Factory {
+	InitMetrics(nil)
+	return &Factory{
+		client:     client,
+		instanceID: instanceID,
+		namespace:  namespace,
+		prefix:     prefix,
+		duration:   duration,
+	}
+}
+
+// leaseName returns the name of the Lease of the given resource. Resource IDs
+// which can't be part of a name, e.g. as they have upper case letters, are
+// replaced by their SHA-256 hash.
+func (f *Factory) leaseName(resourceID string) (string, error) {
+	name := f.prefix + resourceID
+	if !validName.MatchString(name) {
+		hash := sha256.Sum256([]byte(resourceID))
+		name = f.prefix + hex.EncodeToString(hash[:])
+	}
+	if !validName.MatchString(name) {
+		return "", fmt.Errorf("invalid Lease name prefix %q", f.prefix)
+	}
+	return name, nil
+}
+
+// NewElection creates a specific Election instance.
+func (f *Factory) NewElection(ctx context.Context, resourceID string) (election2.Election, error) {
+	name, err := f.leaseName(resourceID)
+	if err != nil {
+		return nil, err
+	}
+	el := &Election{
+		resourceID: resourceID,
+		instanceID: f.instanceID,
+		namespace:  f.namespace,
+		name:       name,
+		duration:   f.duration,
+		client:     f.client,
+	}
+	glog.Infof("Election created: %+v", el)
+	return el, nil
+}
+
+// Master returns the ID of the instance which currently holds the Lease of the
+// resource, or an empty string if none does. A Lease whose holder didn't renew
+// it in time by its own clock is considered free.
+func (f *Factory) Master(ctx context.Context, resourceID string) (string, error) {
+	name, err := f.leaseName(resourceID)
+	if err != nil {
+		return "", err
+	}
+	l, err := f.client.getLease(ctx, f.namespace, name)
+	if err == errNotFound {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	if l.expired(time.Now()) {
+		return "", nil
+	}
+	return l.Spec.HolderIdentity, nil
+}
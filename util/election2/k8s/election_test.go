@@ -0,0 +1,327 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/trillian/monitoring/testonly"
+	"github.com/google/trillian/util/election2"
+	eltestonly "github.com/google/trillian/util/election2/testonly"
+)
+
+const testDuration = time.Second
+
+// fakeAPIServer serves the Lease requests of Client from memory, with the
+// optimistic concurrency of the Kubernetes API server.
+type fakeAPIServer struct {
+	mu      sync.Mutex
+	version int
+	leases  map[string]*lease // By namespace/name.
+}
+
+// newFakeClient starts a fake API server, and returns a Client of it.
+func newFakeClient(t *testing.T) (*Client, *fakeAPIServer) {
+	t.Helper()
+	fake := &fakeAPIServer{leases: make(map[string]*lease)}
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+	return NewClient(srv.URL, srv.Client(), ""), fake
+}
+
+func (f *fakeAPIServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	// The path is leasePath(ns) or leasePath(ns, name).
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/apis/coordination.k8s.io/v1/namespaces/"), "/")
+	if len(parts) < 2 || parts[1] != "leases" {
+		http.Error(w, "bad path", http.StatusBadRequest)
+		return
+	}
+	ns := parts[0]
+
+	var l lease
+	if r.Method != http.MethodGet {
+		if err := json.NewDecoder(r.Body).Decode(&l); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	switch r.Method {
+	case http.MethodGet:
+		name := parts[len(parts)-1]
+		stored, ok := f.leases[ns+"/"+name]
+		if !ok {
+			http.Error(w, "{}", http.StatusNotFound)
+			return
+		}
+		l = *stored
+	case http.MethodPost:
+		if _, ok := f.leases[ns+"/"+l.Metadata.Name]; ok {
+			http.Error(w, "{}", http.StatusConflict)
+			return
+		}
+		f.store(ns, &l)
+	case http.MethodPut:
+		stored, ok := f.leases[ns+"/"+l.Metadata.Name]
+		if !ok {
+			http.Error(w, "{}", http.StatusNotFound)
+			return
+		}
+		if stored.Metadata.ResourceVersion != l.Metadata.ResourceVersion {
+			http.Error(w, "{}", http.StatusConflict)
+			return
+		}
+		f.store(ns, &l)
+	}
+	if err := json.NewEncoder(w).Encode(&l); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// store stores l at a new resource version. Must be called with mu held.
+func (f *fakeAPIServer) store(ns string, l *lease) {
+	f.version++
+	l.Metadata.Namespace, l.Metadata.ResourceVersion = ns, strconv.Itoa(f.version)
+	stored := *l
+	f.leases[ns+"/"+l.Metadata.Name] = &stored
+}
+
+// setHolder makes the given instance hold the lease, as if it took it over.
+func (f *fakeAPIServer) setHolder(ns, name, holder string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	l := *f.leases[ns+"/"+name]
+	l.Spec.HolderIdentity = holder
+	l.Spec.RenewTime = newMicroTime(time.Now())
+	f.store(ns, &l)
+}
+
+func TestElection(t *testing.T) {
+	client, _ := newFakeClient(t)
+	for _, nt := range eltestonly.Tests {
+		// Create a new Factory for each test for better isolation.
+		fact := NewFactory("testID", client, "default", strings.ToLower(nt.Name)+"-", testDuration)
+		t.Run(nt.Name, func(t *testing.T) {
+			nt.Run(t, fact)
+		})
+	}
+}
+
+func TestLeaseName(t *testing.T) {
+	for _, tc := range []struct {
+		prefix  string
+		id      string
+		want    string
+		wantErr bool
+	}{
+		{prefix: "trillian-", id: "1234", want: "trillian-1234"},
+		{prefix: "trillian.", id: "a-b.c", want: "trillian.a-b.c"},
+		{prefix: "trillian-", id: "testID", want: "trillian-8728176916a5fa3861e92749a9927cd5ae267ae57c81b253828d1a298e3a4ebe"},
+		{prefix: "trillian-", id: "a/b", want: "trillian-c14cddc033f64b9dea80ea675cf280a015e672516090a5626781153dc68fea11"},
+		{prefix: "Trillian-", id: "1234", wantErr: true},
+		{prefix: strings.Repeat("a", 250), id: "1234", wantErr: true},
+	} {
+		f := NewFactory("a", nil, "default", tc.prefix, testDuration)
+		got, err := f.leaseName(tc.id)
+		if gotErr := err != nil; gotErr != tc.wantErr {
+			t.Errorf("leaseName(%q, %q): %v, wantErr %v", tc.prefix, tc.id, err, tc.wantErr)
+		} else if got != tc.want {
+			t.Errorf("leaseName(%q, %q)=%q, want %q", tc.prefix, tc.id, got, tc.want)
+		}
+	}
+	f := NewFactory("a", nil, "default", "Trillian-", testDuration)
+	if _, err := f.NewElection(context.Background(), "1234"); err == nil {
+		t.Error("NewElection() with invalid prefix: no error")
+	}
+}
+
+func TestMaster(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newFakeClient(t)
+	factA := NewFactory("a", client, "default", "res-", testDuration)
+	factB := NewFactory("b", client, "default", "res-", testDuration)
+	elA, err := factA.NewElection(ctx, "10")
+	if err != nil {
+		t.Fatalf("NewElection(a): %v", err)
+	}
+	elB, err := factB.NewElection(ctx, "10")
+	if err != nil {
+		t.Fatalf("NewElection(b): %v", err)
+	}
+	wantMaster := func(want string) {
+		t.Helper()
+		for _, fact := range []*Factory{factA, factB} {
+			if got, err := fact.Master(ctx, "10"); err != nil || got != want {
+				t.Errorf("Master(10)=%q, %v; want %q, nil", got, err, want)
+			}
+		}
+	}
+
+	wantMaster("")
+	acquired := testonly.NewCounterSnapshot(acquisitions, "10")
+	if err := elA.Await(ctx); err != nil {
+		t.Fatalf("Await(a): %v", err)
+	}
+	wantMaster("a")
+	if got := acquired.Delta(); got != 1 {
+		t.Errorf("acquisitions delta=%v, want 1", got)
+	}
+
+	// b can't capture mastership while a renews its lease.
+	cctx, cancel := context.WithTimeout(ctx, 2*testDuration)
+	defer cancel()
+	if err := elB.Await(cctx); err != context.DeadlineExceeded {
+		t.Fatalf("Await(b)=%v, want %v", err, context.DeadlineExceeded)
+	}
+	wantMaster("a")
+
+	resigned := testonly.NewCounterSnapshot(resignations, "10")
+	if err := elA.Resign(ctx); err != nil {
+		t.Fatalf("Resign(a): %v", err)
+	}
+	wantMaster("")
+	if got := resigned.Delta(); got != 1 {
+		t.Errorf("resignations delta=%v, want 1", got)
+	}
+
+	if err := elB.Await(ctx); err != nil {
+		t.Fatalf("Await(b): %v", err)
+	}
+	wantMaster("b")
+	for _, el := range []election2.Election{elA, elB} {
+		if err := el.Close(ctx); err != nil {
+			t.Errorf("Close(): %v", err)
+		}
+	}
+}
+
+func TestExpiry(t *testing.T) {
+	ctx := context.Background()
+	client, fake := newFakeClient(t)
+	fact := NewFactory("b", client, "default", "res-", testDuration)
+	el, err := fact.NewElection(ctx, "10")
+	if err != nil {
+		t.Fatalf("NewElection(): %v", err)
+	}
+
+	// Another instance holds a lease which it doesn't renew.
+	l := &lease{Metadata: objectMeta{Name: "res-10"}}
+	l.Spec.HolderIdentity, l.Spec.LeaseDurationSeconds = "a", 1
+	l.Spec.AcquireTime, l.Spec.RenewTime = newMicroTime(time.Now()), newMicroTime(time.Now())
+	fake.store("default", l)
+
+	start := time.Now()
+	if err := el.Await(ctx); err != nil {
+		t.Fatalf("Await(): %v", err)
+	}
+	if got := time.Since(start); got < testDuration {
+		t.Errorf("Await() captured an unexpired lease after %v", got)
+	}
+	got, err := client.getLease(ctx, "default", "res-10")
+	if err != nil {
+		t.Fatalf("getLease(): %v", err)
+	}
+	if got.Spec.HolderIdentity != "b" || got.Spec.LeaseTransitions != 1 {
+		t.Errorf("lease held by %q after %d transitions, want b after 1", got.Spec.HolderIdentity, got.Spec.LeaseTransitions)
+	}
+	if err := el.Close(ctx); err != nil {
+		t.Errorf("Close(): %v", err)
+	}
+}
+
+func TestTakeover(t *testing.T) {
+	ctx := context.Background()
+	client, fake := newFakeClient(t)
+	fact := NewFactory("a", client, "default", "res-", testDuration)
+	el, err := fact.NewElection(ctx, "10")
+	if err != nil {
+		t.Fatalf("NewElection(): %v", err)
+	}
+	if err := el.Await(ctx); err != nil {
+		t.Fatalf("Await(): %v", err)
+	}
+	mctx, err := el.WithMastership(ctx)
+	if err != nil {
+		t.Fatalf("WithMastership(): %v", err)
+	}
+
+	lost := testonly.NewCounterSnapshot(leaseLosses, "10")
+	fake.setHolder("default", "res-10", "b")
+	select {
+	case <-mctx.Done():
+	case <-time.After(testDuration):
+		t.Fatal("mastership context not canceled after takeover")
+	}
+	if got := lost.Delta(); got != 1 {
+		t.Errorf("lease losses delta=%v, want 1", got)
+	}
+	if got, err := fact.Master(ctx, "10"); err != nil || got != "b" {
+		t.Errorf("Master(10)=%q, %v; want b, nil", got, err)
+	}
+	// Resigning must not free the lease of the new master.
+	if err := el.Close(ctx); err != nil {
+		t.Errorf("Close(): %v", err)
+	}
+	if got, err := fact.Master(ctx, "10"); err != nil || got != "b" {
+		t.Errorf("Master(10) after Close=%q, %v; want b, nil", got, err)
+	}
+}
+
+func TestMicroTime(t *testing.T) {
+	want := time.Date(2022, 6, 1, 12, 30, 15, 123456000, time.UTC)
+	data, err := json.Marshal(newMicroTime(want.In(time.FixedZone("X", 3600))))
+	if err != nil {
+		t.Fatalf("Marshal(): %v", err)
+	}
+	if got, want := string(data), `"2022-06-01T12:30:15.123456Z"`; got != want {
+		t.Errorf("Marshal()=%s, want %s", got, want)
+	}
+	var got microTime
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal(): %v", err)
+	}
+	if !got.Equal(want) {
+		t.Errorf("Unmarshal()=%v, want %v", got, want)
+	}
+	if err := json.Unmarshal([]byte(`"yesterday"`), &got); err == nil {
+		t.Error("Unmarshal(yesterday): no error")
+	}
+}
+
+func TestClientError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.Header.Get("Authorization"), ""; got != want {
+			t.Errorf("Authorization=%q, want %q", got, want)
+		}
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"kind":"Status","message":"leases is forbidden"}`)
+	}))
+	defer srv.Close()
+	client := NewClient(srv.URL, srv.Client(), "")
+	_, err := client.getLease(context.Background(), "default", "res-10")
+	if err == nil || !strings.Contains(err.Error(), "leases is forbidden") {
+		t.Errorf("getLease()=%v, want forbidden error", err)
+	}
+}
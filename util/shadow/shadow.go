@@ -0,0 +1,157 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package shadow runs a candidate implementation of an operation in shadow of
+// the implementation in use, and records where their outputs diverge. This
+// de-risks changes of internal algorithms and formats: the candidate sees
+// production inputs without affecting what is served, and can be switched to
+// once it has stopped diverging.
+package shadow
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/google/trillian/monitoring"
+)
+
+const (
+	experimentLabel = "experiment"
+	// maxRecent is the number of divergences kept by an Experiment.
+	maxRecent = 100
+)
+
+var (
+	once        sync.Once
+	runs        monitoring.Counter
+	divergences monitoring.Counter
+)
+
+// InitMetrics sets up the metrics of this package. It is called by New with
+// the inert metric factory if it hasn't been called before.
+func InitMetrics(mf monitoring.MetricFactory) {
+	once.Do(func() {
+		if mf == nil {
+			mf = monitoring.InertMetricFactory{}
+		}
+		runs = mf.NewCounter("shadow_runs", "Number of calls on which the candidate implementation was run in shadow", experimentLabel)
+		divergences = mf.NewCounter("shadow_divergences", "Number of shadow runs on which the candidate implementation diverged", experimentLabel)
+	})
+}
+
+// Divergence describes a call on which the candidate implementation of an
+// experiment disagreed with the control implementation.
+type Divergence struct {
+	// Label identifies the input of the call, e.g. a tree ID.
+	Label string
+	// Time is when the divergence was found.
+	Time time.Time
+	// Diff describes the difference between the outputs.
+	Diff string
+}
+
+// Experiment compares a candidate implementation of an operation against the
+// control implementation, whose output is the one used. The candidate is
+// usually the new implementation while a change is rolled out, but can be the
+// old one after switching over, to confirm that nothing changed.
+type Experiment struct {
+	name string
+	// sample returns a number in [0, 1), replaced in tests.
+	sample func() float64
+
+	mu     sync.Mutex
+	rate   float64
+	recent []Divergence
+}
+
+// New returns an experiment which runs the candidate on the given fraction of
+// calls.
+func New(name string, rate float64) *Experiment {
+	InitMetrics(nil)
+	return &Experiment{name: name, rate: rate, sample: rand.Float64}
+}
+
+// SetSampleRate sets the fraction of calls on which the candidate runs. A rate
+// of zero disables the experiment.
+func (e *Experiment) SetSampleRate(rate float64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.rate = rate
+}
+
+// Run returns the output of control. On a sample of calls it also runs
+// candidate, and records a divergence if diff reports a difference between
+// their outputs, or if only one of them fails. The candidate runs after the
+// control, on the same goroutine, so it may use the same transaction. Its
+// failures, including panics, never affect the returned output.
+//
+// diff is only called if both implementations succeed, and returns an empty
+// string if their outputs are equivalent.
+func (e *Experiment) Run(label string, control, candidate func() (interface{}, error), diff func(control, candidate interface{}) string) (interface{}, error) {
+	out, err := control()
+	if e == nil || !e.sampled() {
+		return out, err
+	}
+	runs.Inc(e.name)
+	if d := e.compare(out, err, candidate, diff); d != "" {
+		e.record(label, d)
+	}
+	return out, err
+}
+
+func (e *Experiment) sampled() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.rate > 0 && e.sample() < e.rate
+}
+
+// compare runs candidate, and describes how it diverged from the control
+// output, if at all.
+func (e *Experiment) compare(out interface{}, err error, candidate func() (interface{}, error), diff func(control, candidate interface{}) string) (d string) {
+	defer func() {
+		if r := recover(); r != nil {
+			d = fmt.Sprintf("candidate panicked: %v", r)
+		}
+	}()
+	candOut, candErr := candidate()
+	switch {
+	case err != nil && candErr != nil:
+		return ""
+	case err != nil || candErr != nil:
+		return fmt.Sprintf("control error: %v, candidate error: %v", err, candErr)
+	}
+	return diff(out, candOut)
+}
+
+func (e *Experiment) record(label, diff string) {
+	divergences.Inc(e.name)
+	glog.Warningf("%s: shadow experiment %s diverged: %s", label, e.name, diff)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if len(e.recent) == maxRecent {
+		e.recent = e.recent[1:]
+	}
+	e.recent = append(e.recent, Divergence{Label: label, Time: time.Now(), Diff: diff})
+}
+
+// Divergences returns the most recent divergences found by the experiment,
+// oldest first.
+func (e *Experiment) Divergences() []Divergence {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]Divergence(nil), e.recent...)
+}
@@ -0,0 +1,155 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shadow
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	errFailed := errors.New("failed")
+	value := func(v int) func() (interface{}, error) {
+		return func() (interface{}, error) { return v, nil }
+	}
+	failure := func() (interface{}, error) { return nil, errFailed }
+	diff := func(a, b interface{}) string {
+		if a != b {
+			return fmt.Sprintf("%v != %v", a, b)
+		}
+		return ""
+	}
+
+	for _, tc := range []struct {
+		desc      string
+		sample    float64
+		control   func() (interface{}, error)
+		candidate func() (interface{}, error)
+		wantOut   interface{}
+		wantErr   error
+		wantRan   bool
+		wantDiff  string
+	}{
+		{
+			desc:      "agree",
+			control:   value(1),
+			candidate: value(1),
+			wantOut:   1,
+			wantRan:   true,
+		},
+		{
+			desc:      "diverge",
+			control:   value(1),
+			candidate: value(2),
+			wantOut:   1,
+			wantRan:   true,
+			wantDiff:  "1 != 2",
+		},
+		{
+			desc:      "not-sampled",
+			sample:    0.5,
+			control:   value(1),
+			candidate: value(2),
+			wantOut:   1,
+		},
+		{
+			desc:      "both-fail",
+			control:   failure,
+			candidate: failure,
+			wantErr:   errFailed,
+			wantRan:   true,
+		},
+		{
+			desc:      "control-fails",
+			control:   failure,
+			candidate: value(2),
+			wantErr:   errFailed,
+			wantRan:   true,
+			wantDiff:  "control error: failed, candidate error: <nil>",
+		},
+		{
+			desc:      "candidate-fails",
+			control:   value(1),
+			candidate: failure,
+			wantOut:   1,
+			wantRan:   true,
+			wantDiff:  "control error: <nil>, candidate error: failed",
+		},
+		{
+			desc:      "candidate-panics",
+			control:   value(1),
+			candidate: func() (interface{}, error) { panic("oops") },
+			wantOut:   1,
+			wantRan:   true,
+			wantDiff:  "candidate panicked: oops",
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			e := New("test", 0.5)
+			e.sample = func() float64 { return tc.sample }
+			ran := false
+			candidate := func() (interface{}, error) {
+				ran = true
+				return tc.candidate()
+			}
+			out, err := e.Run("label", tc.control, candidate, diff)
+			if out != tc.wantOut || err != tc.wantErr {
+				t.Errorf("Run(): %v, %v, want %v, %v", out, err, tc.wantOut, tc.wantErr)
+			}
+			if ran != tc.wantRan {
+				t.Errorf("Run() ran candidate: %v, want %v", ran, tc.wantRan)
+			}
+			var diffs []string
+			for _, d := range e.Divergences() {
+				if d.Label != "label" {
+					t.Errorf("Divergence label: %q, want %q", d.Label, "label")
+				}
+				diffs = append(diffs, d.Diff)
+			}
+			if got := strings.Join(diffs, "; "); got != tc.wantDiff {
+				t.Errorf("Divergences(): %q, want %q", got, tc.wantDiff)
+			}
+		})
+	}
+}
+
+func TestRecentDivergences(t *testing.T) {
+	e := New("test", 1)
+	for i := 0; i < maxRecent+10; i++ {
+		e.Run(fmt.Sprint(i),
+			func() (interface{}, error) { return nil, nil },
+			func() (interface{}, error) { return nil, nil },
+			func(a, b interface{}) string { return "differs" })
+	}
+	d := e.Divergences()
+	if got, want := len(d), maxRecent; got != want {
+		t.Fatalf("Divergences(): %d entries, want %d", got, want)
+	}
+	if got, want := d[0].Label, "10"; got != want {
+		t.Errorf("Divergences()[0].Label: %q, want %q", got, want)
+	}
+
+	e.SetSampleRate(0)
+	ran := false
+	e.Run("label",
+		func() (interface{}, error) { return nil, nil },
+		func() (interface{}, error) { ran = true; return nil, nil },
+		func(a, b interface{}) string { return "" })
+	if ran {
+		t.Error("Run() ran candidate with zero sample rate")
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// logRootV1 is the version tag of LogRoot serializations, i.e. the
+// LOG_ROOT_FORMAT_V1 value of the trillian.LogRootFormat enum.
+const logRootV1 = 1
+
+// Size limits of the variable-length fields of LogRoot.
+const (
+	maxRootHashLen = 128
+	maxMetadataLen = 65535
+)
+
+// LogRoot holds the fields of a v1 log root, as serialized in the log_root
+// field of trillian.SignedLogRoot. It is the equivalent of types.LogRootV1.
+type LogRoot struct {
+	// TreeSize is the number of leaves in the log Merkle tree.
+	TreeSize uint64
+	// RootHash is the hash of the root node of the tree.
+	RootHash []byte
+	// TimestampNanos is the time in nanoseconds for when this root was created,
+	// counting from the UNIX epoch.
+	TimestampNanos uint64
+	// Revision is the Merkle tree revision associated with this root.
+	Revision uint64
+	// Metadata holds additional data associated with this root.
+	Metadata []byte
+}
+
+// ParseLogRoot parses the TLS serialization of a v1 log root. Like
+// types.LogRootV1.UnmarshalBinary, it ignores any data following the root.
+func ParseLogRoot(b []byte) (*LogRoot, error) {
+	if len(b) < 3 {
+		return nil, errors.New("logRootBytes too short")
+	}
+	if v := binary.BigEndian.Uint16(b); v != logRootV1 {
+		return nil, fmt.Errorf("invalid LogRoot.Version: %v, want %v", v, logRootV1)
+	}
+	r := &reader{b: b[2:]}
+	var root LogRoot
+	root.TreeSize = r.uint64()
+	root.RootHash = r.opaque(1, maxRootHashLen)
+	root.TimestampNanos = r.uint64()
+	root.Revision = r.uint64()
+	root.Metadata = r.opaque(2, maxMetadataLen)
+	if r.err != nil {
+		return nil, r.err
+	}
+	return &root, nil
+}
+
+// reader reads TLS-encoded fields from a buffer, and records the first error.
+type reader struct {
+	b   []byte
+	err error
+}
+
+// next returns the following n bytes of the buffer.
+func (r *reader) next(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if len(r.b) < n {
+		r.err = fmt.Errorf("truncated log root: %d bytes left, want %d", len(r.b), n)
+		return nil
+	}
+	b := r.b[:n]
+	r.b = r.b[n:]
+	return b
+}
+
+func (r *reader) uint64() uint64 {
+	b := r.next(8)
+	if b == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(b)
+}
+
+// opaque reads a byte string preceded by a lenBytes-long length of at most
+// maxLen. Empty strings are returned as non-nil, like the TLS decoder does.
+func (r *reader) opaque(lenBytes, maxLen int) []byte {
+	lb := r.next(lenBytes)
+	if lb == nil {
+		return nil
+	}
+	n := 0
+	for _, c := range lb {
+		n = n<<8 | int(c)
+	}
+	if n > maxLen {
+		r.err = fmt.Errorf("field length %d exceeds maximum %d", n, maxLen)
+		return nil
+	}
+	b := r.next(n)
+	if b == nil {
+		return nil
+	}
+	return append([]byte{}, b...)
+}
@@ -0,0 +1,88 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package verify verifies the log roots and proofs served by Trillian logs.
+//
+// It provides the verification logic of the client package without its
+// dependencies: it only imports the standard library and the
+// github.com/transparency-dev/merkle module, and takes the serialized roots
+// and the proof hashes instead of gRPC and protobuf messages. This makes it
+// suitable for embedding in constrained environments, such as firmware
+// tooling and WebAssembly. Tests keep it in sync with the client and types
+// packages.
+package verify
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/transparency-dev/merkle"
+	"github.com/transparency-dev/merkle/proof"
+	"github.com/transparency-dev/merkle/rfc6962"
+)
+
+// LogVerifier verifies the roots and proofs of Trillian logs, both regular
+// and pre-ordered. It is the equivalent of client.LogVerifier, and is safe for
+// concurrent use.
+type LogVerifier struct {
+	// hasher is the hash strategy used to compute nodes in the Merkle tree.
+	hasher merkle.LogHasher
+}
+
+// NewLogVerifier returns a LogVerifier which uses the given hash strategy.
+func NewLogVerifier(hasher merkle.LogHasher) *LogVerifier {
+	return &LogVerifier{hasher: hasher}
+}
+
+// Default returns a LogVerifier for the RFC 6962 hash strategy of Trillian
+// logs.
+func Default() *LogVerifier {
+	return NewLogVerifier(rfc6962.DefaultHasher)
+}
+
+// VerifyRoot parses newRoot, the serialized log root of a SignedLogRoot, and
+// verifies that it is a valid append-only operation from trusted. If
+// trusted.TreeSize is zero, a consistency proof is not needed.
+func (v *LogVerifier) VerifyRoot(trusted *LogRoot, newRoot []byte, consistency [][]byte) (*LogRoot, error) {
+	if trusted == nil {
+		return nil, errors.New("VerifyRoot() error: trusted == nil")
+	}
+	r, err := ParseLogRoot(newRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	// Implicitly trust the first root we get.
+	if trusted.TreeSize != 0 {
+		if err := proof.VerifyConsistency(v.hasher, trusted.TreeSize, r.TreeSize, consistency, trusted.RootHash, r.RootHash); err != nil {
+			return nil, fmt.Errorf("failed to verify consistency proof from %d->%d %x->%x: %v", trusted.TreeSize, r.TreeSize, trusted.RootHash, r.RootHash, err)
+		}
+	}
+	return r, nil
+}
+
+// VerifyInclusionByHash verifies that the inclusion proof for the leaf with
+// the given Merkle leafHash at leafIndex matches the given trusted root.
+func (v *LogVerifier) VerifyInclusionByHash(trusted *LogRoot, leafIndex uint64, leafHash []byte, hashes [][]byte) error {
+	if trusted == nil {
+		return errors.New("VerifyInclusionByHash() error: trusted == nil")
+	}
+	return proof.VerifyInclusion(v.hasher, leafIndex, trusted.TreeSize, leafHash, hashes, trusted.RootHash)
+}
+
+// VerifyInclusion verifies that the inclusion proof for the leaf with the
+// given value at leafIndex matches the given trusted root.
+func (v *LogVerifier) VerifyInclusion(trusted *LogRoot, leafIndex uint64, leafValue []byte, hashes [][]byte) error {
+	return v.VerifyInclusionByHash(trusted, leafIndex, v.hasher.HashLeaf(leafValue), hashes)
+}
@@ -0,0 +1,216 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify_test
+
+import (
+	"bytes"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/trillian"
+	"github.com/google/trillian/client"
+	"github.com/google/trillian/types"
+	"github.com/google/trillian/verify"
+	"github.com/transparency-dev/merkle/rfc6962"
+	"github.com/transparency-dev/merkle/testonly"
+)
+
+// TestDependencies checks that the package only imports the standard library
+// and the Merkle tree module.
+func TestDependencies(t *testing.T) {
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatalf("Glob(): %v", err)
+	}
+	for _, name := range files {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(token.NewFileSet(), name, nil, parser.ImportsOnly)
+		if err != nil {
+			t.Fatalf("ParseFile(%s): %v", name, err)
+		}
+		for _, imp := range f.Imports {
+			path, err := strconv.Unquote(imp.Path.Value)
+			if err != nil {
+				t.Fatalf("Unquote(%s): %v", imp.Path.Value, err)
+			}
+			std := !strings.Contains(strings.Split(path, "/")[0], ".")
+			if !std && !strings.HasPrefix(path, "github.com/transparency-dev/merkle") {
+				t.Errorf("%s imports %s", name, path)
+			}
+		}
+	}
+}
+
+func TestParseLogRoot(t *testing.T) {
+	valid, err := (&types.LogRootV1{
+		TreeSize:       12,
+		RootHash:       bytes.Repeat([]byte{0xab}, 32),
+		TimestampNanos: 1234,
+		Revision:       5,
+		Metadata:       []byte("metadata"),
+	}).MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary(): %v", err)
+	}
+	empty, err := (&types.LogRootV1{}).MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary(): %v", err)
+	}
+	longHash := append([]byte{0, 1, 0, 0, 0, 0, 0, 0, 0, 1, 129}, make([]byte, 129+26)...)
+
+	for _, tc := range []struct {
+		desc string
+		b    []byte
+	}{
+		{desc: "valid", b: valid},
+		{desc: "empty", b: empty},
+		{desc: "trailingData", b: append(append([]byte{}, valid...), 1, 2, 3)},
+		{desc: "wrongVersion", b: append([]byte{0, 2}, valid[2:]...)},
+		{desc: "truncated", b: valid[:len(valid)-1]},
+		{desc: "truncatedHash", b: valid[:20]},
+		{desc: "tooShort", b: valid[:2]},
+		{desc: "nil"},
+		{desc: "hashTooLong", b: longHash},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			var want types.LogRootV1
+			wantErr := want.UnmarshalBinary(tc.b)
+			got, err := verify.ParseLogRoot(tc.b)
+			if (err != nil) != (wantErr != nil) {
+				t.Fatalf("ParseLogRoot(): %v, want error %v", err, wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(fromTypes(&want), got); diff != "" {
+				t.Errorf("ParseLogRoot() diff (-types +verify):\n%s", diff)
+			}
+		})
+	}
+}
+
+// TestLogVerifier checks that LogVerifier accepts and rejects the same roots
+// and proofs as client.LogVerifier.
+func TestLogVerifier(t *testing.T) {
+	const numLeaves = 13
+	tree := testonly.New(rfc6962.DefaultHasher)
+	for i := 0; i < numLeaves; i++ {
+		tree.AppendData([]byte(fmt.Sprintf("leaf%d", i)))
+	}
+	roots := make([]*types.LogRootV1, numLeaves+1)
+	serialized := make([][]byte, numLeaves+1)
+	for size := range roots {
+		roots[size] = &types.LogRootV1{TreeSize: uint64(size), RootHash: tree.HashAt(uint64(size))}
+		b, err := roots[size].MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary(): %v", err)
+		}
+		serialized[size] = b
+	}
+	cv := client.NewLogVerifier(rfc6962.DefaultHasher)
+	v := verify.Default()
+
+	// corrupt returns a copy of hashes with the i-th hash altered, or hashes
+	// with an extra hash if i is out of range.
+	corrupt := func(hashes [][]byte, i int) [][]byte {
+		if i >= len(hashes) {
+			return append(append([][]byte{}, hashes...), make([]byte, 32))
+		}
+		c := append([][]byte{}, hashes...)
+		c[i] = append([]byte{^hashes[i][0]}, hashes[i][1:]...)
+		return c
+	}
+
+	for size1 := uint64(1); size1 <= numLeaves; size1++ {
+		for size2 := uint64(1); size2 <= numLeaves; size2++ {
+			hashes, err := tree.ConsistencyProof(size1, size2)
+			if err != nil {
+				hashes = nil
+			}
+			for i := 0; i <= len(hashes); i++ {
+				pf := hashes
+				if i > 0 {
+					pf = corrupt(hashes, i-1)
+				}
+				_, wantErr := cv.VerifyRoot(roots[size1], &trillian.SignedLogRoot{LogRoot: serialized[size2]}, pf)
+				_, err := v.VerifyRoot(fromTypes(roots[size1]), serialized[size2], pf)
+				if (err != nil) != (wantErr != nil) {
+					t.Errorf("VerifyRoot(%d->%d, proof %d): %v, want error %v", size1, size2, i, err, wantErr)
+				}
+			}
+		}
+	}
+
+	for size := uint64(1); size <= numLeaves; size++ {
+		for index := uint64(0); index < size; index++ {
+			hashes, err := tree.InclusionProof(index, size)
+			if err != nil {
+				t.Fatalf("InclusionProof(%d, %d): %v", index, size, err)
+			}
+			leafValue := []byte(fmt.Sprintf("leaf%d", index))
+			for _, idx := range []uint64{index, (index + 1) % size} {
+				for i := 0; i <= len(hashes); i++ {
+					pf := hashes
+					if i > 0 {
+						pf = corrupt(hashes, i-1)
+					}
+					leafHash := rfc6962.DefaultHasher.HashLeaf(leafValue)
+					wantErr := cv.VerifyInclusionByHash(roots[size], leafHash, &trillian.Proof{LeafIndex: int64(idx), Hashes: pf})
+					if err := v.VerifyInclusionByHash(fromTypes(roots[size]), idx, leafHash, pf); (err != nil) != (wantErr != nil) {
+						t.Errorf("VerifyInclusionByHash(%d, %d, proof %d): %v, want error %v", idx, size, i, err, wantErr)
+					}
+					if err := v.VerifyInclusion(fromTypes(roots[size]), idx, leafValue, pf); (err != nil) != (wantErr != nil) {
+						t.Errorf("VerifyInclusion(%d, %d, proof %d): %v, want error %v", idx, size, i, err, wantErr)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestLogVerifierErrors(t *testing.T) {
+	v := verify.Default()
+	b, err := (&types.LogRootV1{}).MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary(): %v", err)
+	}
+	if _, err := v.VerifyRoot(nil, b, nil); err == nil {
+		t.Error("VerifyRoot(nil trusted) succeeded, want error")
+	}
+	if _, err := v.VerifyRoot(&verify.LogRoot{}, nil, nil); err == nil {
+		t.Error("VerifyRoot(nil root) succeeded, want error")
+	}
+	if err := v.VerifyInclusionByHash(nil, 0, nil, nil); err == nil {
+		t.Error("VerifyInclusionByHash(nil trusted) succeeded, want error")
+	}
+}
+
+func fromTypes(r *types.LogRootV1) *verify.LogRoot {
+	return &verify.LogRoot{
+		TreeSize:       r.TreeSize,
+		RootHash:       r.RootHash,
+		TimestampNanos: r.TimestampNanos,
+		Revision:       r.Revision,
+		Metadata:       r.Metadata,
+	}
+}
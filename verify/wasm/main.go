@@ -0,0 +1,193 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build js && wasm
+
+// The wasm binary exposes the verify package to JavaScript, so that browser
+// applications can verify the roots and proofs of Trillian logs themselves.
+// It is built with:
+//
+//	GOOS=js GOARCH=wasm go build -o trillian_verify.wasm ./verify/wasm
+//
+// and loaded with trillian_verify.js, see the README.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"syscall/js"
+
+	"github.com/google/trillian/verify"
+)
+
+// globalName is the name of the global object holding the exported functions.
+const globalName = "trillianVerify"
+
+func main() {
+	register()
+	// Keep the functions callable.
+	select {}
+}
+
+// register sets the global object holding the exported functions. Functions
+// return an object with an error field if they fail.
+func register() {
+	v := verify.Default()
+	js.Global().Set(globalName, js.ValueOf(map[string]interface{}{
+		"parseLogRoot": export(func(args []js.Value) (interface{}, error) {
+			if len(args) != 1 {
+				return nil, errors.New("want 1 argument: logRoot")
+			}
+			root, err := verify.ParseLogRoot(toBytes(args[0]))
+			if err != nil {
+				return nil, err
+			}
+			return fromLogRoot(root), nil
+		}),
+		"verifyRoot": export(func(args []js.Value) (interface{}, error) {
+			if len(args) != 3 {
+				return nil, errors.New("want 3 arguments: trusted, newRoot, consistency")
+			}
+			trusted, err := toLogRoot(args[0])
+			if err != nil {
+				return nil, err
+			}
+			root, err := v.VerifyRoot(trusted, toBytes(args[1]), toHashes(args[2]))
+			if err != nil {
+				return nil, err
+			}
+			return fromLogRoot(root), nil
+		}),
+		"verifyInclusion": export(func(args []js.Value) (interface{}, error) {
+			if len(args) != 4 {
+				return nil, errors.New("want 4 arguments: trusted, leafIndex, leafValue, proof")
+			}
+			trusted, err := toLogRoot(args[0])
+			if err != nil {
+				return nil, err
+			}
+			index, err := toUint64(args[1])
+			if err != nil {
+				return nil, fmt.Errorf("leafIndex: %v", err)
+			}
+			return nil, v.VerifyInclusion(trusted, index, toBytes(args[2]), toHashes(args[3]))
+		}),
+		"verifyInclusionByHash": export(func(args []js.Value) (interface{}, error) {
+			if len(args) != 4 {
+				return nil, errors.New("want 4 arguments: trusted, leafIndex, leafHash, proof")
+			}
+			trusted, err := toLogRoot(args[0])
+			if err != nil {
+				return nil, err
+			}
+			index, err := toUint64(args[1])
+			if err != nil {
+				return nil, fmt.Errorf("leafIndex: %v", err)
+			}
+			return nil, v.VerifyInclusionByHash(trusted, index, toBytes(args[2]), toHashes(args[3]))
+		}),
+	}))
+}
+
+// export wraps f as a JavaScript function, which returns an object with an
+// error field if f fails.
+func export(f func(args []js.Value) (interface{}, error)) js.Func {
+	return js.FuncOf(func(_ js.Value, args []js.Value) interface{} {
+		res, err := f(args)
+		if err != nil {
+			return map[string]interface{}{"error": err.Error()}
+		}
+		return res
+	})
+}
+
+// toBytes copies a Uint8Array. Undefined and null values are returned as nil.
+func toBytes(v js.Value) []byte {
+	if v.IsUndefined() || v.IsNull() {
+		return nil
+	}
+	b := make([]byte, v.Get("length").Int())
+	js.CopyBytesToGo(b, v)
+	return b
+}
+
+func fromBytes(b []byte) js.Value {
+	v := js.Global().Get("Uint8Array").New(len(b))
+	js.CopyBytesToJS(v, b)
+	return v
+}
+
+// toHashes copies an array of Uint8Arrays.
+func toHashes(v js.Value) [][]byte {
+	if v.IsUndefined() || v.IsNull() {
+		return nil
+	}
+	hashes := make([][]byte, v.Length())
+	for i := range hashes {
+		hashes[i] = toBytes(v.Index(i))
+	}
+	return hashes
+}
+
+// toUint64 converts a number or a BigInt. It uses the decimal representation
+// of the value, as Value.Type panics for BigInts.
+func toUint64(v js.Value) (uint64, error) {
+	if v.IsUndefined() || v.IsNull() {
+		return 0, errors.New("missing value, want number or bigint")
+	}
+	return strconv.ParseUint(js.Global().Call("String", v).String(), 10, 64)
+}
+
+// fromUint64 converts n to a BigInt, as numbers don't hold all uint64 values.
+func fromUint64(n uint64) js.Value {
+	return js.Global().Call("BigInt", strconv.FormatUint(n, 10))
+}
+
+// toLogRoot converts an object with the fields of a LogRoot, as returned by
+// fromLogRoot.
+func toLogRoot(v js.Value) (*verify.LogRoot, error) {
+	if v.IsUndefined() || v.IsNull() {
+		return nil, errors.New("missing trusted root")
+	}
+	var root verify.LogRoot
+	var err error
+	if root.TreeSize, err = toUint64(v.Get("treeSize")); err != nil {
+		return nil, fmt.Errorf("treeSize: %v", err)
+	}
+	root.RootHash = toBytes(v.Get("rootHash"))
+	// The remaining fields aren't needed for verification.
+	if t := v.Get("timestampNanos"); !t.IsUndefined() {
+		if root.TimestampNanos, err = toUint64(t); err != nil {
+			return nil, fmt.Errorf("timestampNanos: %v", err)
+		}
+	}
+	if r := v.Get("revision"); !r.IsUndefined() {
+		if root.Revision, err = toUint64(r); err != nil {
+			return nil, fmt.Errorf("revision: %v", err)
+		}
+	}
+	root.Metadata = toBytes(v.Get("metadata"))
+	return &root, nil
+}
+
+func fromLogRoot(root *verify.LogRoot) map[string]interface{} {
+	return map[string]interface{}{
+		"treeSize":       fromUint64(root.TreeSize),
+		"rootHash":       fromBytes(root.RootHash),
+		"timestampNanos": fromUint64(root.TimestampNanos),
+		"revision":       fromUint64(root.Revision),
+		"metadata":       fromBytes(root.Metadata),
+	}
+}
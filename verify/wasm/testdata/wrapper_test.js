@@ -0,0 +1,78 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Runs the test cases generated by wrapper_test.go against the verifier.
+//
+// Usage: node wrapper_test.js <wasm_exec.js> <verifier.wasm> <cases.json>
+'use strict';
+
+const fs = require('fs');
+const path = require('path');
+
+const [wasmExec, wasmFile, casesFile] = process.argv.slice(2);
+require(path.resolve(wasmExec));
+const {load} = require(path.join(__dirname, '..', 'trillian_verify.js'));
+
+const bytes = (hex) => Uint8Array.from(Buffer.from(hex, 'hex'));
+const hashes = (hexes) => (hexes || []).map(bytes);
+
+async function main() {
+  const v = await load(fs.readFileSync(wasmFile));
+  const cases = JSON.parse(fs.readFileSync(casesFile, 'utf8'));
+  let failures = 0;
+  for (const tc of cases) {
+    let err = null;
+    try {
+      const trusted = v.parseLogRoot(bytes(tc.trusted));
+      switch (tc.op) {
+        case 'parseLogRoot':
+          if (trusted.treeSize !== BigInt(tc.treeSize)) {
+            throw new Error(`treeSize ${trusted.treeSize}, want ${tc.treeSize}`);
+          }
+          break;
+        case 'verifyRoot': {
+          const root = v.verifyRoot(trusted, bytes(tc.newRoot), hashes(tc.proof));
+          if (root.treeSize !== BigInt(tc.treeSize)) {
+            throw new Error(`treeSize ${root.treeSize}, want ${tc.treeSize}`);
+          }
+          break;
+        }
+        case 'verifyInclusion':
+          v.verifyInclusion(trusted, tc.leafIndex, bytes(tc.leaf), hashes(tc.proof));
+          break;
+        case 'verifyInclusionByHash':
+          v.verifyInclusionByHash(trusted, BigInt(tc.leafIndex), bytes(tc.leaf), hashes(tc.proof));
+          break;
+        default:
+          throw new Error(`unknown op ${tc.op}`);
+      }
+    } catch (e) {
+      err = e;
+    }
+    if ((err !== null) !== tc.wantErr) {
+      failures++;
+      console.log(`${tc.desc}: got error ${err}, want error: ${tc.wantErr}`);
+    }
+  }
+  if (failures > 0) {
+    process.exit(1);
+  }
+  // The Go program blocks forever, so exit explicitly.
+  process.exit(0);
+}
+
+main().catch((e) => {
+  console.log(e);
+  process.exit(1);
+});
@@ -0,0 +1,92 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// JavaScript bindings for the Trillian verifier compiled to WebAssembly. The
+// wasm_exec.js support file of the Go release which built the verifier must
+// be loaded first, as it defines the Go class.
+//
+// Log roots are objects with the treeSize, rootHash, timestampNanos, revision
+// and metadata fields of the Go verify.LogRoot. Integers are returned as
+// BigInts, and can be given as BigInts or numbers. Byte strings, including
+// the serialized log_root field of a SignedLogRoot and the proof hashes, are
+// Uint8Arrays. Failed verifications throw an Error.
+(function(root) {
+  'use strict';
+
+  // load instantiates the verifier from source, which is the WebAssembly
+  // binary as a BufferSource, or a Response or Promise of a Response for
+  // streaming compilation, e.g. fetch('trillian_verify.wasm'). It returns a
+  // Promise of the verifier.
+  async function load(source) {
+    const go = new root.Go();
+    let result;
+    if (source instanceof ArrayBuffer || ArrayBuffer.isView(source)) {
+      result = await WebAssembly.instantiate(source, go.importObject);
+    } else {
+      result = await WebAssembly.instantiateStreaming(source, go.importObject);
+    }
+    // The Go program registers its functions, then blocks forever.
+    go.run(result.instance);
+    const api = root.trillianVerify;
+    if (!api) {
+      throw new Error('trillianVerify functions not registered');
+    }
+    return new Verifier(api);
+  }
+
+  // Verifier wraps the functions of the Go program, and throws their errors.
+  class Verifier {
+    constructor(api) {
+      this.api = api;
+    }
+
+    // parseLogRoot parses the serialized log_root of a SignedLogRoot.
+    parseLogRoot(logRoot) {
+      return check(this.api.parseLogRoot(logRoot));
+    }
+
+    // verifyRoot parses newRoot, the serialized log_root of a SignedLogRoot,
+    // checks that it is consistent with the trusted root, and returns it. A
+    // consistency proof isn't needed if the trusted root is empty.
+    verifyRoot(trusted, newRoot, consistency) {
+      return check(this.api.verifyRoot(trusted, newRoot, consistency || []));
+    }
+
+    // verifyInclusion checks that the leaf with the given value is included
+    // in the trusted root at leafIndex.
+    verifyInclusion(trusted, leafIndex, leafValue, proof) {
+      check(this.api.verifyInclusion(trusted, leafIndex, leafValue, proof));
+    }
+
+    // verifyInclusionByHash checks that the leaf with the given Merkle leaf
+    // hash is included in the trusted root at leafIndex.
+    verifyInclusionByHash(trusted, leafIndex, leafHash, proof) {
+      check(this.api.verifyInclusionByHash(trusted, leafIndex, leafHash, proof));
+    }
+  }
+
+  function check(result) {
+    if (result && result.error !== undefined) {
+      throw new Error(result.error);
+    }
+    return result;
+  }
+
+  const exported = {load: load, Verifier: Verifier};
+  if (typeof module !== 'undefined' && module.exports) {
+    module.exports = exported;
+  } else {
+    root.TrillianVerify = exported;
+  }
+})(globalThis);
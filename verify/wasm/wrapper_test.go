@@ -0,0 +1,136 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !js
+
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/trillian/types"
+	"github.com/transparency-dev/merkle/rfc6962"
+	"github.com/transparency-dev/merkle/testonly"
+)
+
+// testCase is a test case of testdata/wrapper_test.js. Byte strings are hex
+// encoded.
+type testCase struct {
+	Desc      string   `json:"desc"`
+	Op        string   `json:"op"`
+	Trusted   string   `json:"trusted"`
+	NewRoot   string   `json:"newRoot,omitempty"`
+	TreeSize  uint64   `json:"treeSize,omitempty"`
+	LeafIndex uint64   `json:"leafIndex,omitempty"`
+	Leaf      string   `json:"leaf,omitempty"`
+	Proof     []string `json:"proof,omitempty"`
+	WantErr   bool     `json:"wantErr"`
+}
+
+// TestWrapper builds the verifier for WebAssembly, and runs it through the
+// JavaScript bindings with Node.js.
+func TestWrapper(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping WebAssembly build in short mode")
+	}
+	node, err := exec.LookPath("node")
+	if err != nil {
+		t.Skip("Skipping test as Node.js is not installed")
+	}
+	out, err := exec.Command("go", "env", "GOROOT").Output()
+	if err != nil {
+		t.Fatalf("go env GOROOT: %v", err)
+	}
+	goroot := strings.TrimSpace(string(out))
+	// The support file moved from misc/wasm to lib/wasm in Go 1.24.
+	wasmExec := filepath.Join(goroot, "lib", "wasm", "wasm_exec.js")
+	if _, err := os.Stat(wasmExec); err != nil {
+		wasmExec = filepath.Join(goroot, "misc", "wasm", "wasm_exec.js")
+	}
+
+	dir := t.TempDir()
+	wasm := filepath.Join(dir, "trillian_verify.wasm")
+	build := exec.Command("go", "build", "-o", wasm, ".")
+	build.Env = append(os.Environ(), "GOOS=js", "GOARCH=wasm")
+	if out, err := build.CombinedOutput(); err != nil {
+		t.Fatalf("go build: %v\n%s", err, out)
+	}
+
+	casesFile := filepath.Join(dir, "cases.json")
+	b, err := json.Marshal(testCases(t))
+	if err != nil {
+		t.Fatalf("Marshal(): %v", err)
+	}
+	if err := os.WriteFile(casesFile, b, 0o644); err != nil {
+		t.Fatalf("WriteFile(): %v", err)
+	}
+	if out, err := exec.Command(node, "testdata/wrapper_test.js", wasmExec, wasm, casesFile).CombinedOutput(); err != nil {
+		t.Errorf("wrapper_test.js: %v\n%s", err, out)
+	}
+}
+
+func testCases(t *testing.T) []testCase {
+	t.Helper()
+	const size1, size2 = 5, 11
+	tree := testonly.New(rfc6962.DefaultHasher)
+	for i := 0; i < size2; i++ {
+		tree.AppendData(leaf(i))
+	}
+	root := func(size uint64) string {
+		b, err := (&types.LogRootV1{TreeSize: size, RootHash: tree.HashAt(size), Metadata: []byte("md")}).MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary(): %v", err)
+		}
+		return hex.EncodeToString(b)
+	}
+	consistency, err := tree.ConsistencyProof(size1, size2)
+	if err != nil {
+		t.Fatalf("ConsistencyProof(): %v", err)
+	}
+	inclusion, err := tree.InclusionProof(3, size2)
+	if err != nil {
+		t.Fatalf("InclusionProof(): %v", err)
+	}
+
+	return []testCase{
+		{Desc: "parseLogRoot", Op: "parseLogRoot", Trusted: root(size2), TreeSize: size2},
+		{Desc: "parseLogRootInvalid", Op: "parseLogRoot", Trusted: "0002", WantErr: true},
+		{Desc: "verifyRoot", Op: "verifyRoot", Trusted: root(size1), NewRoot: root(size2), TreeSize: size2, Proof: hexes(consistency)},
+		{Desc: "verifyRootFirst", Op: "verifyRoot", Trusted: root(0), NewRoot: root(size2), TreeSize: size2},
+		{Desc: "verifyRootBadProof", Op: "verifyRoot", Trusted: root(size1), NewRoot: root(size2), TreeSize: size2, Proof: hexes(consistency[1:]), WantErr: true},
+		{Desc: "verifyInclusion", Op: "verifyInclusion", Trusted: root(size2), LeafIndex: 3, Leaf: hex.EncodeToString(leaf(3)), Proof: hexes(inclusion)},
+		{Desc: "verifyInclusionWrongLeaf", Op: "verifyInclusion", Trusted: root(size2), LeafIndex: 3, Leaf: hex.EncodeToString(leaf(4)), Proof: hexes(inclusion), WantErr: true},
+		{Desc: "verifyInclusionByHash", Op: "verifyInclusionByHash", Trusted: root(size2), LeafIndex: 3, Leaf: hex.EncodeToString(tree.LeafHash(3)), Proof: hexes(inclusion)},
+		{Desc: "verifyInclusionByHashWrongIndex", Op: "verifyInclusionByHash", Trusted: root(size2), LeafIndex: 2, Leaf: hex.EncodeToString(tree.LeafHash(3)), Proof: hexes(inclusion), WantErr: true},
+	}
+}
+
+func leaf(i int) []byte {
+	return []byte(fmt.Sprintf("leaf%d", i))
+}
+
+func hexes(hashes [][]byte) []string {
+	s := make([]string, len(hashes))
+	for i, h := range hashes {
+		s[i] = hex.EncodeToString(h)
+	}
+	return s
+}
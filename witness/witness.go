@@ -0,0 +1,115 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package witness verifies the cosignatures which witnesses add to the
+// checkpoints of Trillian logs, and decides when a checkpoint is cosigned by a
+// quorum of them.
+//
+// Checkpoints and cosignatures are signed notes, see
+// golang.org/x/mod/sumdb/note: a witness which has checked that a checkpoint
+// is consistent with the ones it saw before adds its own signature to it.
+package witness
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/trillian/witness/witnesspb"
+	"golang.org/x/mod/sumdb/note"
+)
+
+// Policy holds the witnesses whose cosignatures a log server accepts, and the
+// number of them which make a checkpoint of each log cosigned.
+type Policy struct {
+	verifiers     []note.Verifier
+	names         map[string]bool
+	defaultQuorum int
+	treeQuorums   map[int64]int
+}
+
+// NewPolicy returns the policy given by cfg.
+func NewPolicy(cfg *witnesspb.WitnessConfig) (*Policy, error) {
+	p := &Policy{
+		names:         make(map[string]bool),
+		defaultQuorum: int(cfg.DefaultQuorum),
+		treeQuorums:   make(map[int64]int),
+	}
+	for _, key := range cfg.WitnessKeys {
+		v, err := note.NewVerifier(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid witness key %q: %v", key, err)
+		}
+		if p.names[v.Name()] {
+			return nil, fmt.Errorf("duplicate witness name %q", v.Name())
+		}
+		p.names[v.Name()] = true
+		p.verifiers = append(p.verifiers, v)
+	}
+	if p.defaultQuorum < 0 || p.defaultQuorum > len(p.verifiers) {
+		return nil, fmt.Errorf("default quorum %d, want between 0 and the %d witnesses", p.defaultQuorum, len(p.verifiers))
+	}
+	for id, q := range cfg.TreeQuorums {
+		if q < 0 || int(q) > len(p.verifiers) {
+			return nil, fmt.Errorf("quorum %d of tree %d, want between 0 and the %d witnesses", q, id, len(p.verifiers))
+		}
+		p.treeQuorums[id] = int(q)
+	}
+	return p, nil
+}
+
+// Quorum returns the number of witnesses which must cosign a checkpoint of
+// the tree, or zero if the tree's checkpoints aren't cosigned.
+func (p *Policy) Quorum(treeID int64) int {
+	if q, ok := p.treeQuorums[treeID]; ok {
+		return q
+	}
+	return p.defaultQuorum
+}
+
+// IsWitness returns whether name is the name of one of the witnesses.
+func (p *Policy) IsWitness(name string) bool {
+	return p.names[name]
+}
+
+// Cosignatures opens a signed note, and returns it with the valid signatures
+// of the witnesses in its Sigs. Signatures by other keys, e.g. the log's own,
+// are ignored. It fails if the note has no valid witness signature.
+func (p *Policy) Cosignatures(msg []byte) (*note.Note, error) {
+	return note.Open(msg, note.VerifierList(p.verifiers...))
+}
+
+// VerifyCosigned checks that a cosigned checkpoint is signed by the log, and
+// by at least quorum of the witnesses. It returns the opened note.
+func VerifyCosigned(msg []byte, log note.Verifier, witnesses []note.Verifier, quorum int) (*note.Note, error) {
+	n, err := note.Open(msg, note.VerifierList(append([]note.Verifier{log}, witnesses...)...))
+	if err != nil {
+		return nil, err
+	}
+	logSigned := false
+	cosigners := make(map[string]bool)
+	for _, sig := range n.Sigs {
+		if sig.Name == log.Name() && sig.Hash == log.KeyHash() {
+			logSigned = true
+			continue
+		}
+		cosigners[sig.Name] = true
+	}
+	if !logSigned {
+		return nil, errors.New("checkpoint isn't signed by the log")
+	}
+	if len(cosigners) < quorum {
+		return nil, fmt.Errorf("checkpoint is cosigned by %d witnesses, want at least %d", len(cosigners), quorum)
+	}
+	return n, nil
+}
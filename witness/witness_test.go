@@ -0,0 +1,134 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package witness
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/google/trillian/witness/witnesspb"
+	"golang.org/x/mod/sumdb/note"
+)
+
+func newKey(t *testing.T, name string) (note.Signer, note.Verifier, string) {
+	t.Helper()
+	skey, vkey, err := note.GenerateKey(rand.Reader, name)
+	if err != nil {
+		t.Fatalf("GenerateKey(): %v", err)
+	}
+	s, err := note.NewSigner(skey)
+	if err != nil {
+		t.Fatalf("NewSigner(): %v", err)
+	}
+	v, err := note.NewVerifier(vkey)
+	if err != nil {
+		t.Fatalf("NewVerifier(): %v", err)
+	}
+	return s, v, vkey
+}
+
+func TestNewPolicy(t *testing.T) {
+	_, _, k1 := newKey(t, "w1")
+	_, _, k2 := newKey(t, "w2")
+	_, _, dup := newKey(t, "w1")
+
+	for _, tc := range []struct {
+		desc    string
+		cfg     *witnesspb.WitnessConfig
+		wantErr bool
+	}{
+		{desc: "empty", cfg: &witnesspb.WitnessConfig{}},
+		{desc: "ok", cfg: &witnesspb.WitnessConfig{WitnessKeys: []string{k1, k2}, DefaultQuorum: 2, TreeQuorums: map[int64]int32{1: 1}}},
+		{desc: "invalid-key", cfg: &witnesspb.WitnessConfig{WitnessKeys: []string{"w1+bad"}}, wantErr: true},
+		{desc: "duplicate-name", cfg: &witnesspb.WitnessConfig{WitnessKeys: []string{k1, dup}}, wantErr: true},
+		{desc: "default-quorum-too-large", cfg: &witnesspb.WitnessConfig{WitnessKeys: []string{k1}, DefaultQuorum: 2}, wantErr: true},
+		{desc: "negative-default-quorum", cfg: &witnesspb.WitnessConfig{WitnessKeys: []string{k1}, DefaultQuorum: -1}, wantErr: true},
+		{desc: "tree-quorum-too-large", cfg: &witnesspb.WitnessConfig{WitnessKeys: []string{k1}, TreeQuorums: map[int64]int32{1: 2}}, wantErr: true},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := NewPolicy(tc.cfg)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("NewPolicy(): %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestPolicy(t *testing.T) {
+	s1, _, k1 := newKey(t, "w1")
+	_, _, k2 := newKey(t, "w2")
+	other, _, _ := newKey(t, "other")
+	p, err := NewPolicy(&witnesspb.WitnessConfig{WitnessKeys: []string{k1, k2}, DefaultQuorum: 2, TreeQuorums: map[int64]int32{7: 0}})
+	if err != nil {
+		t.Fatalf("NewPolicy(): %v", err)
+	}
+	if got, want := p.Quorum(1), 2; got != want {
+		t.Errorf("Quorum(1) = %d, want %d", got, want)
+	}
+	if got, want := p.Quorum(7), 0; got != want {
+		t.Errorf("Quorum(7) = %d, want %d", got, want)
+	}
+	if !p.IsWitness("w2") || p.IsWitness("other") {
+		t.Errorf("IsWitness() doesn't match the configured witnesses")
+	}
+
+	msg, err := note.Sign(&note.Note{Text: "checkpoint\n"}, s1, other)
+	if err != nil {
+		t.Fatalf("Sign(): %v", err)
+	}
+	n, err := p.Cosignatures(msg)
+	if err != nil {
+		t.Fatalf("Cosignatures(): %v", err)
+	}
+	if len(n.Sigs) != 1 || n.Sigs[0].Name != "w1" {
+		t.Errorf("Cosignatures() returned signatures %v, want only w1", n.Sigs)
+	}
+	if msg, err = note.Sign(&note.Note{Text: "checkpoint\n"}, other); err != nil {
+		t.Fatalf("Sign(): %v", err)
+	}
+	if _, err := p.Cosignatures(msg); err == nil {
+		t.Errorf("Cosignatures() without witness signatures succeeded")
+	}
+}
+
+func TestVerifyCosigned(t *testing.T) {
+	logS, logV, _ := newKey(t, "log")
+	s1, v1, _ := newKey(t, "w1")
+	s2, v2, _ := newKey(t, "w2")
+	witnesses := []note.Verifier{v1, v2}
+
+	for _, tc := range []struct {
+		desc    string
+		signers []note.Signer
+		quorum  int
+		wantErr bool
+	}{
+		{desc: "quorum", signers: []note.Signer{logS, s1, s2}, quorum: 2},
+		{desc: "more-than-quorum", signers: []note.Signer{logS, s1, s2}, quorum: 1},
+		{desc: "no-quorum", signers: []note.Signer{logS, s1}, quorum: 2, wantErr: true},
+		{desc: "no-log", signers: []note.Signer{s1, s2}, quorum: 2, wantErr: true},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			msg, err := note.Sign(&note.Note{Text: "checkpoint\n"}, tc.signers...)
+			if err != nil {
+				t.Fatalf("Sign(): %v", err)
+			}
+			_, err = VerifyCosigned(msg, logV, witnesses, tc.quorum)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("VerifyCosigned(): %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
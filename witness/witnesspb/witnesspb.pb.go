@@ -0,0 +1,197 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.0
+// 	protoc        v3.20.1
+// source: witnesspb.proto
+
+package witnesspb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// WitnessConfig configures the witnesses whose cosignatures over the
+// checkpoints of the logs the log server accepts.
+type WitnessConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The signed note verifier keys of the witnesses, as generated by
+	// golang.org/x/mod/sumdb/note.GenerateKey. The key names identify the
+	// witnesses, and must be unique.
+	WitnessKeys []string `protobuf:"bytes,1,rep,name=witness_keys,json=witnessKeys,proto3" json:"witness_keys,omitempty"`
+	// The number of witnesses which must cosign a checkpoint of a log before
+	// it is served as cosigned, for logs without an entry in tree_quorums.
+	// Zero disables cosigning of these logs.
+	DefaultQuorum int32 `protobuf:"varint,2,opt,name=default_quorum,json=defaultQuorum,proto3" json:"default_quorum,omitempty"`
+	// The quorums of individual logs, by tree ID, overriding default_quorum.
+	TreeQuorums map[int64]int32 `protobuf:"bytes,3,rep,name=tree_quorums,json=treeQuorums,proto3" json:"tree_quorums,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (x *WitnessConfig) Reset() {
+	*x = WitnessConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_witnesspb_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WitnessConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WitnessConfig) ProtoMessage() {}
+
+func (x *WitnessConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_witnesspb_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WitnessConfig.ProtoReflect.Descriptor instead.
+func (*WitnessConfig) Descriptor() ([]byte, []int) {
+	return file_witnesspb_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *WitnessConfig) GetWitnessKeys() []string {
+	if x != nil {
+		return x.WitnessKeys
+	}
+	return nil
+}
+
+func (x *WitnessConfig) GetDefaultQuorum() int32 {
+	if x != nil {
+		return x.DefaultQuorum
+	}
+	return 0
+}
+
+func (x *WitnessConfig) GetTreeQuorums() map[int64]int32 {
+	if x != nil {
+		return x.TreeQuorums
+	}
+	return nil
+}
+
+var File_witnesspb_proto protoreflect.FileDescriptor
+
+var file_witnesspb_proto_rawDesc = []byte{
+	0x0a, 0x0f, 0x77, 0x69, 0x74, 0x6e, 0x65, 0x73, 0x73, 0x70, 0x62, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x09, 0x77, 0x69, 0x74, 0x6e, 0x65, 0x73, 0x73, 0x70, 0x62, 0x22, 0xe7, 0x01, 0x0a,
+	0x0d, 0x57, 0x69, 0x74, 0x6e, 0x65, 0x73, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x21,
+	0x0a, 0x0c, 0x77, 0x69, 0x74, 0x6e, 0x65, 0x73, 0x73, 0x5f, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x77, 0x69, 0x74, 0x6e, 0x65, 0x73, 0x73, 0x4b, 0x65, 0x79,
+	0x73, 0x12, 0x25, 0x0a, 0x0e, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x71, 0x75, 0x6f,
+	0x72, 0x75, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x64, 0x65, 0x66, 0x61, 0x75,
+	0x6c, 0x74, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x12, 0x4c, 0x0a, 0x0c, 0x74, 0x72, 0x65, 0x65,
+	0x5f, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29,
+	0x2e, 0x77, 0x69, 0x74, 0x6e, 0x65, 0x73, 0x73, 0x70, 0x62, 0x2e, 0x57, 0x69, 0x74, 0x6e, 0x65,
+	0x73, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x54, 0x72, 0x65, 0x65, 0x51, 0x75, 0x6f,
+	0x72, 0x75, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x74, 0x72, 0x65, 0x65, 0x51,
+	0x75, 0x6f, 0x72, 0x75, 0x6d, 0x73, 0x1a, 0x3e, 0x0a, 0x10, 0x54, 0x72, 0x65, 0x65, 0x51, 0x75,
+	0x6f, 0x72, 0x75, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x74, 0x72, 0x69, 0x6c,
+	0x6c, 0x69, 0x61, 0x6e, 0x2f, 0x77, 0x69, 0x74, 0x6e, 0x65, 0x73, 0x73, 0x2f, 0x77, 0x69, 0x74,
+	0x6e, 0x65, 0x73, 0x73, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_witnesspb_proto_rawDescOnce sync.Once
+	file_witnesspb_proto_rawDescData = file_witnesspb_proto_rawDesc
+)
+
+func file_witnesspb_proto_rawDescGZIP() []byte {
+	file_witnesspb_proto_rawDescOnce.Do(func() {
+		file_witnesspb_proto_rawDescData = protoimpl.X.CompressGZIP(file_witnesspb_proto_rawDescData)
+	})
+	return file_witnesspb_proto_rawDescData
+}
+
+var file_witnesspb_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_witnesspb_proto_goTypes = []interface{}{
+	(*WitnessConfig)(nil), // 0: witnesspb.WitnessConfig
+	nil,                   // 1: witnesspb.WitnessConfig.TreeQuorumsEntry
+}
+var file_witnesspb_proto_depIdxs = []int32{
+	1, // 0: witnesspb.WitnessConfig.tree_quorums:type_name -> witnesspb.WitnessConfig.TreeQuorumsEntry
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_witnesspb_proto_init() }
+func file_witnesspb_proto_init() {
+	if File_witnesspb_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_witnesspb_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WitnessConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_witnesspb_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_witnesspb_proto_goTypes,
+		DependencyIndexes: file_witnesspb_proto_depIdxs,
+		MessageInfos:      file_witnesspb_proto_msgTypes,
+	}.Build()
+	File_witnesspb_proto = out.File
+	file_witnesspb_proto_rawDesc = nil
+	file_witnesspb_proto_goTypes = nil
+	file_witnesspb_proto_depIdxs = nil
+}